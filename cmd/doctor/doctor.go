@@ -0,0 +1,106 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package doctor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/doctor"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/spf13/cobra"
+)
+
+const commandName = "doctor"
+
+const (
+	formatText = "text"
+	formatJson = "json"
+)
+
+// NewCommand creates the doctor command, which diagnoses the configuration in the environment variables
+// the run command reads, and resolves the region endpoints with the resolver.
+func NewCommand(tokenStoreFactory tokenstore.TokenStoreFactory, authClientFactory client.AuthClientFactory, apiProbe doctor.APIProbe, resolver doctor.Resolver) *cobra.Command {
+	var grantTypeFlag string
+	var storeTypeFlag string
+	var format string
+	var noResolve bool
+
+	cmd := &cobra.Command{
+		Use:   commandName,
+		Short: "Check the server configuration, authentication and PingOne access",
+		Long: `Check the configuration of the PingOne MCP server, resolve the endpoints of its PingOne region, authenticate,
+and verify that the worker application or user can call the PingOne management API. Each failed check is reported
+with the action that fixes it. Pass the same --grant-type and --store-type flags as to the run command.
+
+The client_credentials grant type obtains a new access token. The authorization_code and device_code grant types
+check the stored login session instead, so that no browser is opened.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.InitCommandLogger(cmd, commandName)
+			logger.FromContext(cmd.Context()).Debug("Command invoked")
+
+			if tokenStoreFactory == nil || authClientFactory == nil || apiProbe == nil {
+				return errs.NewCommandError(commandName, errors.New("provided dependencies are nil in doctor command"))
+			}
+
+			if format != formatText && format != formatJson {
+				return errs.NewCommandError(commandName, fmt.Errorf("unsupported format %q, must be %s or %s", format, formatText, formatJson))
+			}
+
+			grantType, err := auth.ParseGrantType(grantTypeFlag)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+
+			var tokenStore tokenstore.TokenStore
+			if grantType.IsInteractive() {
+				storeType, err := tokenstore.ParseStoreType(storeTypeFlag)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				tokenStore, err = tokenStoreFactory.NewTokenStore(storeType)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+			}
+
+			if noResolve {
+				resolver = nil
+			}
+			report := doctor.NewDoctor(resolver, authClientFactory, tokenStore, apiProbe).Run(cmd.Context(), doctor.Config{
+				RootDomain:    os.Getenv(profile.RootDomainEnvVar),
+				EnvironmentId: os.Getenv(doctor.EnvironmentIdEnvVar),
+				GrantType:     grantType,
+			})
+
+			if format == formatJson {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				err = encoder.Encode(report)
+			} else {
+				err = report.WriteText(cmd.OutOrStdout())
+			}
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if report.Failed() {
+				return errs.NewCommandError(commandName, errors.New("one or more checks failed"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&grantTypeFlag, "grant-type", auth.GrantTypeAuthorizationCode.String(), "OAuth grant type used for authentication (authorization_code, device_code or client_credentials)")
+	cmd.Flags().StringVar(&storeTypeFlag, "store-type", tokenstore.StoreTypeKeychain.String(), "Token store type of the login session to check (keychain, file or encrypted_file)")
+	cmd.Flags().StringVar(&format, "format", formatText, "The report format (text or json)")
+	cmd.Flags().BoolVar(&noResolve, "no-resolve", false, "Do not resolve the PingOne region endpoints")
+
+	return cmd
+}
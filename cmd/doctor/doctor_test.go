@@ -0,0 +1,105 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package doctor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/cmd/doctor"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	diagnostics "github.com/pingidentity/pingone-mcp-server/internal/doctor"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mockauth "github.com/pingidentity/pingone-mcp-server/internal/testutils/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubResolver struct{}
+
+func (stubResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.ParseIP("192.0.2.1")}}, nil
+}
+
+type stubAPIProbe struct{}
+
+func (stubAPIProbe) CountEnvironments(ctx context.Context, accessToken string) (int, *http.Response, error) {
+	return 2, &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func executeDoctorCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := doctor.NewCommand(testutils.NewMockTokenStoreFactoryWithStore(testutils.NewInMemoryTokenStoreWithDefaultSession()),
+		mockauth.NewMockAuthClientFactory(testutils.NewDefaultStaticTokenSource()), stubAPIProbe{}, stubResolver{})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func setValidEnv(t *testing.T) {
+	t.Setenv(profile.RootDomainEnvVar, "pingone.com")
+	t.Setenv(diagnostics.EnvironmentIdEnvVar, "2f8b6a1e-3c4d-4e5f-8a9b-0c1d2e3f4a5b")
+	t.Setenv(auth.ClientCredentialsClientIdEnvVar, "worker-client-id")
+	t.Setenv(auth.ClientCredentialsClientSecretEnvVar, "worker-secret")
+}
+
+func TestDoctorCommand_ClientCredentials(t *testing.T) {
+	setValidEnv(t)
+
+	out, err := executeDoctorCommand(t, "--grant-type", "client_credentials")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Resolved auth.pingone.com and api.pingone.com")
+	assert.Contains(t, out, "can read 2 environment(s)")
+	assert.NotContains(t, out, "FAIL")
+}
+
+func TestDoctorCommand_Json(t *testing.T) {
+	setValidEnv(t)
+
+	out, err := executeDoctorCommand(t, "--grant-type", "client_credentials", "--no-resolve", "--format", "json")
+	require.NoError(t, err)
+
+	var report diagnostics.Report
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Checks, 7)
+	assert.Equal(t, diagnostics.CheckRegion, report.Checks[3].Name)
+	assert.Equal(t, diagnostics.StatusSkipped, report.Checks[3].Status)
+}
+
+func TestDoctorCommand_StoredSession(t *testing.T) {
+	setValidEnv(t)
+	t.Setenv(profile.DeviceCodeClientIdEnvVar, "admin-client-id")
+
+	out, err := executeDoctorCommand(t, "--grant-type", "device_code", "--store-type", "file")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "Stored login session is active")
+}
+
+func TestDoctorCommand_FailedChecks(t *testing.T) {
+	setValidEnv(t)
+	t.Setenv(profile.RootDomainEnvVar, "")
+
+	out, err := executeDoctorCommand(t, "--grant-type", "client_credentials")
+
+	assert.ErrorContains(t, err, "one or more checks failed")
+	assert.Contains(t, out, "To fix:")
+	assert.Contains(t, out, profile.RootDomainEnvVar)
+}
+
+func TestDoctorCommand_InvalidFlags(t *testing.T) {
+	_, err := executeDoctorCommand(t, "--format", "yaml")
+	assert.ErrorContains(t, err, "unsupported format")
+
+	_, err = executeDoctorCommand(t, "--grant-type", "password")
+	assert.Error(t, err)
+}
@@ -51,7 +51,7 @@ func NewCommand(tokenStoreFactory tokenstore.TokenStoreFactory) *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringVar(&storeTypeFlag, "store-type", tokenstore.StoreTypeKeychain.String(), "Token store type to use (keychain or file)")
+	cmd.Flags().StringVar(&storeTypeFlag, "store-type", tokenstore.StoreTypeKeychain.String(), "Token store type to use (keychain, file or encrypted_file). encrypted_file encrypts the session with a passphrase from the "+tokenstore.PassphraseEnvVar+" environment variable, for hosts without an OS keychain")
 
 	return cmd
 }
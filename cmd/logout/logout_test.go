@@ -138,6 +138,12 @@ func TestLogoutCommand_Direct_StoreTypeSelection(t *testing.T) {
 			expectedStoreType: tokenstore.StoreTypeFile,
 			description:       "Logout should use file store type when specified",
 		},
+		{
+			name:              "encrypted file store type",
+			args:              []string{"--store-type", "encrypted_file"},
+			expectedStoreType: tokenstore.StoreTypeEncryptedFile,
+			description:       "Logout should use encrypted file store type when specified",
+		},
 	}
 
 	for _, tt := range tests {
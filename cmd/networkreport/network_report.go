@@ -0,0 +1,181 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package networkreport
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/networkreport"
+	"github.com/pingidentity/pingone-mcp-server/internal/persona"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/server"
+	"github.com/pingidentity/pingone-mcp-server/internal/server/httptransport"
+	"github.com/pingidentity/pingone-mcp-server/internal/telemetry"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolsets"
+	"github.com/spf13/cobra"
+)
+
+const commandName = "network-report"
+
+const (
+	formatText = "text"
+	formatJson = "json"
+)
+
+// NewCommand creates the network-report command, which resolves host addresses with the resolver.
+func NewCommand(resolver networkreport.Resolver) *cobra.Command {
+	var rootDomain string
+	var profilesFile string
+	var grantTypeFlag string
+	var includedTools []string
+	var excludedTools []string
+	var includedToolCollections []string
+	var excludedToolCollections []string
+	var disableReadOnly bool
+	var readOnly bool
+	var enabledToolsets []string
+	var personaName string
+	var openTelemetry bool
+	var transportTypeFlag string
+	var httpAddress string
+	var httpOAuthIssuer string
+	var format string
+	var noResolve bool
+
+	cmd := &cobra.Command{
+		Use:   commandName,
+		Short: "List the network connections the server makes, for firewall change requests",
+		Long: `List the hostnames, resolved IP addresses, ports and protocols that the PingOne MCP server connects to,
+and the ports it listens on, for the configured region, tools and features. Pass the same flags as to the
+run command to report on the same configuration.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.InitCommandLogger(cmd, commandName)
+			logger.FromContext(cmd.Context()).Debug("Command invoked")
+
+			if format != formatText && format != formatJson {
+				return errs.NewCommandError(commandName, fmt.Errorf("unsupported format %q, must be %s or %s", format, formatText, formatJson))
+			}
+
+			grantType, err := auth.ParseGrantType(grantTypeFlag)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+
+			transportType, err := server.ParseTransportType(transportTypeFlag)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+
+			var rootDomains []string
+			if rootDomain == "" {
+				rootDomain = os.Getenv(profile.RootDomainEnvVar)
+			}
+			if strings.TrimSpace(rootDomain) != "" {
+				rootDomains = append(rootDomains, rootDomain)
+			}
+			profiles, err := profile.LoadProfiles(profilesFile)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			for _, name := range profiles.Names() {
+				rootDomains = append(rootDomains, profiles[name].RootDomain)
+			}
+			if len(rootDomains) == 0 {
+				return errs.NewCommandError(commandName, fmt.Errorf("a PingOne root domain is required, set --root-domain, the %s environment variable or --profiles-file", profile.RootDomainEnvVar))
+			}
+
+			var serverPersona *persona.Persona
+			if personaName != "" {
+				serverPersona, err = persona.Get(personaName)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				if len(includedTools) > 0 || len(includedToolCollections) > 0 || len(enabledToolsets) > 0 {
+					return errs.NewCommandError(commandName, errors.New("--persona cannot be used with --include-tools, --include-tool-collections or --enable-toolsets, as the persona selects the tools to enable"))
+				}
+				includedTools = serverPersona.ToolNames()
+			}
+			if len(enabledToolsets) > 0 {
+				toolsetCollections, err := toolsets.CollectionNames(enabledToolsets)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				includedToolCollections = append(includedToolCollections, toolsetCollections...)
+			}
+			if cmd.Flags().Changed("read-only") {
+				if cmd.Flags().Changed("disable-read-only") {
+					return errs.NewCommandError(commandName, errors.New("--read-only cannot be used with --disable-read-only"))
+				}
+				disableReadOnly = !readOnly
+			}
+			if serverPersona != nil && serverPersona.ReadOnly {
+				disableReadOnly = false
+			}
+			toolFilter := filter.NewFilter(!disableReadOnly, includedTools, excludedTools, includedToolCollections, excludedToolCollections)
+
+			config := networkreport.Config{
+				RootDomains: rootDomains,
+				GrantType:   grantType,
+				Tools:       tools.ListEnabledTools(toolFilter),
+			}
+			if openTelemetry {
+				destinations := telemetry.DestinationsFromEnv()
+				config.OtlpEndpoints = destinations.OtlpEndpoints
+				config.PrometheusAddress = destinations.PrometheusAddress
+			}
+			if transportType == server.TransportTypeHttp {
+				config.HttpAddress = httpAddress
+				config.OAuthIssuer = httpOAuthIssuer
+			}
+
+			report, err := networkreport.Build(config)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if !noResolve {
+				report.Resolve(cmd.Context(), resolver)
+			}
+
+			if format == formatJson {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				err = encoder.Encode(report)
+			} else {
+				err = report.WriteText(cmd.OutOrStdout())
+			}
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rootDomain, "root-domain", "", "The PingOne root domain of the region, such as pingone.com or pingone.eu. Defaults to the "+profile.RootDomainEnvVar+" environment variable")
+	cmd.Flags().StringVar(&profilesFile, "profiles-file", "", "Path to a JSON file of named PingOne profiles, whose regions are included in the report")
+	cmd.Flags().StringVar(&grantTypeFlag, "grant-type", auth.GrantTypeAuthorizationCode.String(), "OAuth grant type used for authentication (authorization_code, device_code or client_credentials)")
+	cmd.Flags().StringSliceVar(&includedTools, "include-tools", []string{}, "A list of tools to enable")
+	cmd.Flags().StringSliceVar(&excludedTools, "exclude-tools", []string{}, "A list of tools to disable")
+	cmd.Flags().StringSliceVar(&includedToolCollections, "include-tool-collections", []string{}, "A list of tool collections to enable")
+	cmd.Flags().StringSliceVar(&excludedToolCollections, "exclude-tool-collections", []string{}, "A list of tool collections to disable")
+	cmd.Flags().StringSliceVar(&enabledToolsets, "enable-toolsets", []string{}, "A list of toolsets to enable ("+strings.Join(toolsets.Names(), ", ")+")")
+	cmd.Flags().BoolVar(&disableReadOnly, "disable-read-only", false, "Disable read-only mode to include write tools")
+	cmd.Flags().BoolVar(&readOnly, "read-only", true, "Only include read-only tools. Set to false to include write tools, like --disable-read-only")
+	cmd.Flags().StringVar(&personaName, "persona", "", "Enable a predefined bundle of tools for a role ("+strings.Join(persona.Names(), ", ")+")")
+	cmd.Flags().BoolVar(&openTelemetry, "opentelemetry", false, "Include the OpenTelemetry exporters configured with the standard OTEL_* environment variables")
+	cmd.Flags().StringVar(&transportTypeFlag, "transport", server.TransportTypeStdio.String(), "MCP transport to serve clients on (stdio or http)")
+	cmd.Flags().StringVar(&httpAddress, "http-address", httptransport.DefaultAddress, "The host and port to listen on with the http transport")
+	cmd.Flags().StringVar(&httpOAuthIssuer, "http-oauth-issuer", "", "The issuer of the OAuth access tokens that authenticate HTTP requests")
+	cmd.Flags().StringVar(&format, "format", formatText, "The report format (text or json)")
+	cmd.Flags().BoolVar(&noResolve, "no-resolve", false, "Do not resolve the IP addresses of hosts, such as when the report is generated outside the deployment network")
+
+	return cmd
+}
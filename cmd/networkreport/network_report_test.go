@@ -0,0 +1,136 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package networkreport_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/cmd/networkreport"
+	report "github.com/pingidentity/pingone-mcp-server/internal/networkreport"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubResolver struct{}
+
+func (stubResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	return []net.IPAddr{{IP: net.ParseIP("192.0.2.1")}}, nil
+}
+
+func executeNetworkReportCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := networkreport.NewCommand(stubResolver{})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func executeJsonReport(t *testing.T, args ...string) report.Report {
+	t.Helper()
+	out, err := executeNetworkReportCommand(t, append(args, "--format", "json")...)
+	require.NoError(t, err)
+	var result report.Report
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	return result
+}
+
+func TestNetworkReportCommand_Text(t *testing.T) {
+	t.Setenv(profile.RootDomainEnvVar, "pingone.eu")
+
+	out, err := executeNetworkReportCommand(t)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "auth.pingone.eu")
+	assert.Contains(t, out, "api.pingone.eu")
+	assert.Contains(t, out, "192.0.2.1")
+}
+
+func TestNetworkReportCommand_EnabledTools(t *testing.T) {
+	t.Setenv(profile.RootDomainEnvVar, "")
+
+	result := executeJsonReport(t, "--root-domain", "pingone.com", "--include-tools", environments.ListEnvironmentsDef.McpTool.Name+","+users.BulkCreateUsersDef.McpTool.Name, "--no-resolve")
+
+	require.Len(t, result.Connections, 3)
+	assert.Equal(t, "api.pingone.com", result.Connections[1].Host)
+	assert.Equal(t, []string{environments.ListEnvironmentsDef.McpTool.Name}, result.Connections[1].Tools, "write tools should only be included with --disable-read-only")
+	assert.Empty(t, result.Connections[1].Addresses)
+
+	result = executeJsonReport(t, "--root-domain", "pingone.com", "--persona", "helpdesk", "--disable-read-only", "--grant-type", "client_credentials", "--no-resolve")
+	require.Len(t, result.Connections, 2)
+	assert.Contains(t, result.Connections[1].Tools, users.BulkCreateUsersDef.McpTool.Name)
+
+	result = executeJsonReport(t, "--root-domain", "pingone.com", "--enable-toolsets", "users", "--read-only=false", "--no-resolve")
+	require.Len(t, result.Connections, 3)
+	assert.Contains(t, result.Connections[1].Tools, users.BulkCreateUsersDef.McpTool.Name)
+	assert.NotContains(t, result.Connections[1].Tools, environments.ListEnvironmentsDef.McpTool.Name)
+}
+
+func TestNetworkReportCommand_ProfilesAndFeatures(t *testing.T) {
+	t.Setenv(profile.RootDomainEnvVar, "")
+	t.Setenv("OTEL_SDK_DISABLED", "")
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	t.Setenv("OTEL_METRICS_EXPORTER", "none")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://otel.example.com")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	profilesFile := filepath.Join(t.TempDir(), "profiles.json")
+	require.NoError(t, os.WriteFile(profilesFile, []byte(`{"eu": {"environmentId": "0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f", "rootDomain": "pingone.eu", "deviceCodeClientId": "client"}}`), 0600))
+
+	result := executeJsonReport(t, "--root-domain", "pingone.com", "--profiles-file", profilesFile, "--grant-type", "device_code",
+		"--opentelemetry", "--transport", "http", "--http-address", "0.0.0.0:8080", "--no-resolve")
+
+	var hosts []string
+	for _, connection := range result.Connections {
+		hosts = append(hosts, connection.Host)
+	}
+	assert.Equal(t, []string{"auth.pingone.com", "api.pingone.com", "auth.pingone.eu", "api.pingone.eu", "otel.example.com", "0.0.0.0"}, hosts)
+}
+
+func TestNetworkReportCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{
+			name:          "no root domain",
+			errorContains: "a PingOne root domain is required",
+		},
+		{
+			name:          "unsupported format",
+			args:          []string{"--root-domain", "pingone.com", "--format", "xml"},
+			errorContains: `unsupported format "xml"`,
+		},
+		{
+			name:          "invalid grant type",
+			args:          []string{"--root-domain", "pingone.com", "--grant-type", "implicit"},
+			errorContains: "unable to parse grant type",
+		},
+		{
+			name:          "persona with included tools",
+			args:          []string{"--root-domain", "pingone.com", "--persona", "developer", "--include-tools", environments.ListEnvironmentsDef.McpTool.Name},
+			errorContains: "--persona cannot be used with --include-tools",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(profile.RootDomainEnvVar, "")
+
+			_, err := executeNetworkReportCommand(t, tt.args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
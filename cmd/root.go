@@ -3,13 +3,19 @@
 package cmd
 
 import (
+	"net"
 	"os"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/cmd/doctor"
 	"github.com/pingidentity/pingone-mcp-server/cmd/logout"
+	"github.com/pingidentity/pingone-mcp-server/cmd/networkreport"
+	"github.com/pingidentity/pingone-mcp-server/cmd/rotatebearertoken"
 	"github.com/pingidentity/pingone-mcp-server/cmd/run"
 	"github.com/pingidentity/pingone-mcp-server/cmd/session"
+	"github.com/pingidentity/pingone-mcp-server/cmd/tools"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	diagnostics "github.com/pingidentity/pingone-mcp-server/internal/doctor"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
@@ -40,11 +46,19 @@ func NewRootCommand(serverVersion string) *cobra.Command {
 	mcpEnvironmentId := os.Getenv(mcpEnvironmentIdEnvVar)
 
 	authClientFactory := client.NewPingOneClientAuthWrapperFactory(serverVersion, mcpEnvironmentId)
-	// Always run on stdio transport
+	// The stdio transport is used unless the run command is configured to serve HTTP
 	result.AddCommand(run.NewCommand(tokenStoreFactory, clientFactory, legacyClientFactory, authClientFactory, &mcp.StdioTransport{}, serverVersion))
 
 	result.AddCommand(logout.NewCommand(tokenStoreFactory))
 
 	result.AddCommand(session.NewCommand(tokenStoreFactory))
+
+	result.AddCommand(networkreport.NewCommand(net.DefaultResolver))
+
+	result.AddCommand(rotatebearertoken.NewCommand(tokenStoreFactory))
+
+	result.AddCommand(tools.NewCommand())
+
+	result.AddCommand(doctor.NewCommand(tokenStoreFactory, authClientFactory, diagnostics.NewLegacyAPIProbe(legacyClientFactory), net.DefaultResolver))
 	return result
 }
@@ -0,0 +1,74 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package rotatebearertoken
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/server/httptransport"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/spf13/cobra"
+)
+
+const commandName = "rotate-bearer-token"
+
+// NewCommand creates the rotate-bearer-token command, which saves a new HTTP bearer token in the token store.
+func NewCommand(tokenStoreFactory tokenstore.TokenStoreFactory) *cobra.Command {
+	var storeTypeFlag string
+	var overlap time.Duration
+
+	cmd := &cobra.Command{
+		Use:   commandName,
+		Short: "Generate a new bearer token for the HTTP transport",
+		Long: `Generate a new bearer token for the HTTP transport and save it in the token store, printing it to stdout.
+The token it replaces is still accepted for the overlap, so that clients can be given the new token without
+restarting the server. A running server picks up the new token from the token store when a client first
+sends it. The ` + httptransport.BearerTokenEnvVar + ` environment variable takes precedence over the stored token.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.InitCommandLogger(cmd, commandName)
+			logger.FromContext(cmd.Context()).Debug("Command invoked")
+
+			if tokenStoreFactory == nil {
+				return errs.NewCommandError(commandName, errors.New("provided tokenStoreFactory is nil in rotate-bearer-token command"))
+			}
+
+			storeType, err := tokenstore.ParseStoreType(storeTypeFlag)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+
+			tokenStore, err := tokenStoreFactory.NewTokenStore(storeType)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			secretStore, ok := tokenStore.(tokenstore.SecretStore)
+			if !ok {
+				return errs.NewCommandError(commandName, fmt.Errorf("the %s token store cannot hold bearer tokens", storeType))
+			}
+
+			tokens, err := httptransport.RotateBearerToken(secretStore, overlap, time.Now())
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if tokens.PreviousExpiresAt != nil {
+				logger.FromContext(cmd.Context()).Info("HTTP bearer token rotated, the previous token is accepted until it expires",
+					slog.Time("previousTokenExpiresAt", *tokens.PreviousExpiresAt))
+			} else {
+				logger.FromContext(cmd.Context()).Info("HTTP bearer token rotated")
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), tokens.Current)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&storeTypeFlag, "store-type", tokenstore.StoreTypeKeychain.String(), "Token store type to use (keychain, file or encrypted_file). Use the same store type as the run command")
+	cmd.Flags().DurationVar(&overlap, "overlap", httptransport.DefaultBearerTokenOverlap, "How long the replaced bearer token is still accepted. 0 stops accepting it immediately")
+
+	return cmd
+}
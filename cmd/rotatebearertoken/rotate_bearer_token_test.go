@@ -0,0 +1,95 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package rotatebearertoken_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/cmd/rotatebearertoken"
+	"github.com/pingidentity/pingone-mcp-server/internal/server/httptransport"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeRotateBearerTokenCommand(t *testing.T, tokenStoreFactory tokenstore.TokenStoreFactory, args ...string) (string, error) {
+	t.Helper()
+	cmd := rotatebearertoken.NewCommand(tokenStoreFactory)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestRotateBearerTokenCommand(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	tokenStoreFactory := testutils.NewMockTokenStoreFactoryWithStore(tokenStore)
+
+	out, err := executeRotateBearerTokenCommand(t, tokenStoreFactory, "--store-type", "file")
+	require.NoError(t, err)
+	first := strings.TrimSpace(out)
+	stored, err := httptransport.LoadBearerTokens(tokenStore)
+	require.NoError(t, err)
+	assert.Equal(t, stored.Current, first, "the printed token should be the stored token")
+
+	out, err = executeRotateBearerTokenCommand(t, tokenStoreFactory, "--store-type", "file", "--overlap", "1h")
+	require.NoError(t, err)
+	stored, err = httptransport.LoadBearerTokens(tokenStore)
+	require.NoError(t, err)
+	assert.Equal(t, stored.Current, strings.TrimSpace(out))
+	assert.Equal(t, first, stored.Previous, "the replaced token should be accepted for the overlap")
+	require.NotNil(t, stored.PreviousExpiresAt)
+
+	tokenStoreFactory.AssertCalled(t, "NewTokenStore", tokenstore.StoreTypeFile)
+}
+
+func TestRotateBearerTokenCommand_Errors(t *testing.T) {
+	tests := []struct {
+		name              string
+		tokenStoreFactory tokenstore.TokenStoreFactory
+		args              []string
+		errorContains     string
+	}{
+		{
+			name:              "nil token store factory",
+			tokenStoreFactory: nil,
+			errorContains:     "provided tokenStoreFactory is nil",
+		},
+		{
+			name:              "unknown store type",
+			tokenStoreFactory: testutils.NewMockTokenStoreFactory(),
+			args:              []string{"--store-type", "unknown"},
+			errorContains:     "unknown",
+		},
+		{
+			name:              "negative overlap",
+			tokenStoreFactory: testutils.NewMockTokenStoreFactoryWithStore(testutils.NewInMemoryTokenStore()),
+			args:              []string{"--overlap", "-1h"},
+			errorContains:     "must not be negative",
+		},
+		{
+			name:              "token store without secrets",
+			tokenStoreFactory: testutils.NewMockTokenStoreFactoryWithStore(sessionOnlyTokenStore{}),
+			errorContains:     "cannot hold bearer tokens",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := executeRotateBearerTokenCommand(t, tt.tokenStoreFactory, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+// sessionOnlyTokenStore is a token store that does not implement tokenstore.SecretStore
+type sessionOnlyTokenStore struct {
+	tokenstore.TokenStore
+}
@@ -5,34 +5,121 @@ package run
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/scopes"
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/mockbackend"
+	"github.com/pingidentity/pingone-mcp-server/internal/persona"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/safemode"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/recording"
 	"github.com/pingidentity/pingone-mcp-server/internal/server"
+	"github.com/pingidentity/pingone-mcp-server/internal/server/httptransport"
+	"github.com/pingidentity/pingone-mcp-server/internal/telemetry"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/capabilities"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultbookmarks"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/descriptionpack"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputlimit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputtransform"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/texttemplates"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolpolicy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolsets"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltimeout"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/usagereport"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
 	"github.com/spf13/cobra"
 )
 
 const commandName = "run"
 
+const (
+	allowProductionReadEnvVar             = "PINGONE_MCP_ALLOW_PRODUCTION_READ"
+	allowProductionWriteEnvVar            = "PINGONE_MCP_ALLOW_PRODUCTION_WRITE"
+	allowedProductionEnvironmentIdsEnvVar = "PINGONE_MCP_ALLOWED_PRODUCTION_ENVIRONMENT_IDS"
+	allowedEnvironmentIdsEnvVar           = "PINGONE_MCP_ALLOWED_ENVIRONMENT_IDS"
+	deniedEnvironmentIdsEnvVar            = "PINGONE_MCP_DENIED_ENVIRONMENT_IDS"
+)
+
 func NewCommand(tokenStoreFactory tokenstore.TokenStoreFactory, clientFactory sdk.ClientFactory, legacyClientFactory legacy.ClientFactory, authClientFactory client.AuthClientFactory, transport mcp.Transport, version string) *cobra.Command {
 	var includedTools []string
 	var excludedTools []string
 	var includedToolCollections []string
 	var excludedToolCollections []string
 	var disableReadOnly bool
+	var readOnly bool
+	var enabledToolsets []string
+	var dynamicToolsets bool
 	var grantTypeFlag string
+	var loginScopes []string
+	var toolsetScopeFlags []string
 	var storeTypeFlag string
+	var productionGuardrailFlag string
+	var allowProductionRead bool
+	var allowProductionWrite bool
+	var allowedProductionEnvironmentIds []string
+	var allowedEnvironmentIds []string
+	var deniedEnvironmentIds []string
+	var defaultFilterFlags []string
+	var defaultBookmarksFile string
+	var listResultPageSize int
+	var responseCacheTTL time.Duration
+	var toolTimeout time.Duration
+	var toolTimeoutFlags []string
+	var redactToolResults bool
+	var multiRegion bool
+	var maxOutputBytes int
+	var maxOutputTokens int
+	var outputTransformersFile string
+	var textTemplatesDir string
+	var toolDescriptionsFile string
+	var toolPolicyFile string
+	var toolCapabilitiesFlag string
+	var profilesFile string
+	var toolUsageReportFile string
+	var traceTools bool
+	var openTelemetry bool
+	var safeModeThreshold int
+	var confirmDestructiveTools bool
+	var auditLogFile string
+	var personaName string
+	var environmentCacheTTL time.Duration
+	var environmentCacheMaxEntries int
+	var mockBackend bool
+	var transportTypeFlag string
+	var httpAddress string
+	var httpSessionTimeout time.Duration
+	var httpShutdownTimeout time.Duration
+	var httpOAuthIssuer string
+	var httpOAuthResource string
+	var httpOAuthAudience string
+	var httpOAuthReadScope string
+	var httpOAuthWriteScope string
+	var mockBackendFixturesFile string
+	var apiMaxRetries int
+	var apiMaxBackoff time.Duration
+	var recordApiFile string
+	var replayApiFile string
 
 	cmd := &cobra.Command{
 		Use:   commandName,
@@ -52,16 +139,304 @@ The server will communicate over stdin/stdout.`,
 			}
 			logger.FromContext(cmd.Context()).Debug("Using grant type", slog.String("grantType", grantType.String()))
 
+			// Offline runs serve PingOne API requests in the process and authenticate without credentials
+			offline := mockBackend || replayApiFile != ""
+
+			transportType, err := server.ParseTransportType(transportTypeFlag)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			httpOptions := httptransport.Options{
+				Address:         httpAddress,
+				BearerToken:     strings.TrimSpace(os.Getenv(httptransport.BearerTokenEnvVar)),
+				SessionTimeout:  httpSessionTimeout,
+				ShutdownTimeout: httpShutdownTimeout,
+			}
+			if httpOAuthIssuer != "" {
+				if transportType != server.TransportTypeHttp {
+					return errs.NewCommandError(commandName, errors.New("--http-oauth-issuer requires --transport http"))
+				}
+				httpOptions.OAuth = &httptransport.OAuthOptions{
+					Issuer:     httpOAuthIssuer,
+					Resource:   httpOAuthResource,
+					Audience:   httpOAuthAudience,
+					ReadScope:  httpOAuthReadScope,
+					WriteScope: httpOAuthWriteScope,
+					// Only PingOne tools can be called with the read scope, tools managing the server's own session need the write scope
+					Tools: validation.NewToolRegistry(tools.ListTools()),
+				}
+			} else if httpOAuthResource != "" || httpOAuthAudience != "" {
+				return errs.NewCommandError(commandName, errors.New("--http-oauth-resource and --http-oauth-audience require --http-oauth-issuer"))
+			}
+			if transportType == server.TransportTypeHttp {
+				// A bearer token from the environment takes precedence over a token generated by rotate-bearer-token
+				if httpOptions.BearerToken == "" && httpOptions.OAuth == nil && !offline {
+					keyring, err := storedBearerTokenKeyring(tokenStoreFactory, storeTypeFlag)
+					if err != nil {
+						return errs.NewCommandError(commandName, err)
+					}
+					httpOptions.BearerTokenKeyring = keyring
+				}
+				if err := httpOptions.Validate(); err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				if httpOptions.BearerToken == "" && httpOptions.OAuth == nil && httpOptions.BearerTokenKeyring == nil {
+					logger.FromContext(cmd.Context()).Warn("Serving HTTP without a bearer token, any local process can use the server's PingOne session", slog.String("httpAddress", httpAddress))
+				}
+			}
+
+			if mockBackendFixturesFile != "" && !mockBackend {
+				return errs.NewCommandError(commandName, errors.New("--mock-backend-fixtures requires --mock-backend"))
+			}
+			var mockTokenStore tokenstore.TokenStore
+			if mockBackend {
+				backend, err := mockbackend.NewBackend(mockBackendFixturesFile)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				clientFactory = mockbackend.NewClientFactory(backend)
+				legacyClientFactory = mockbackend.NewLegacyClientFactory(backend)
+				authClientFactory = mockbackend.NewAuthClientFactory(backend)
+				mockTokenStore = mockbackend.NewTokenStore()
+				logger.FromContext(cmd.Context()).Warn("Using the mock PingOne backend, tools act on in-memory fixture data and no requests are sent to PingOne",
+					slog.String("mockBackendFixturesFile", mockBackendFixturesFile))
+			}
+
+			if recordApiFile != "" && replayApiFile != "" {
+				return errs.NewCommandError(commandName, errors.New("--record-api-file cannot be used with --replay-api-file"))
+			}
+			if mockBackend && (recordApiFile != "" || replayApiFile != "") {
+				return errs.NewCommandError(commandName, errors.New("--record-api-file and --replay-api-file cannot be used with --mock-backend"))
+			}
+			if replayApiFile != "" {
+				replayer, err := recording.NewReplayer(replayApiFile)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				clientFactory = mockbackend.NewClientFactory(replayer)
+				legacyClientFactory = mockbackend.NewLegacyClientFactory(replayer)
+				authClientFactory = mockbackend.NewStaticAuthClientFactory(replayer.OrganizationId(), replayer.EnvironmentId())
+				mockTokenStore = mockbackend.NewTokenStore()
+				logger.FromContext(cmd.Context()).Warn("Replaying recorded PingOne API interactions, no requests are sent to PingOne",
+					slog.String("replayApiFile", replayApiFile))
+			}
+			if recordApiFile != "" {
+				recorder, err := recording.NewRecorder(recordApiFile)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				if configurable, ok := clientFactory.(sdk.TransportWrapperConfigurable); ok {
+					configurable.SetTransportWrapper(recorder.Transport)
+				}
+				if configurable, ok := legacyClientFactory.(sdk.TransportWrapperConfigurable); ok {
+					configurable.SetTransportWrapper(recorder.Transport)
+				}
+				logger.FromContext(cmd.Context()).Warn("Recording PingOne API interactions, with secrets redacted. Recorded responses may contain personal data",
+					slog.String("recordApiFile", recordApiFile))
+			}
+
+			retryOptions := sdk.DefaultRetryOptions()
+			retryOptions.MaxRetries = apiMaxRetries
+			retryOptions.MaxBackoff = apiMaxBackoff
+			if err := retryOptions.Validate(); err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if retryConfigurable, ok := clientFactory.(sdk.RetryConfigurable); ok {
+				retryConfigurable.SetRetryOptions(retryOptions)
+			}
+			if retryConfigurable, ok := legacyClientFactory.(sdk.RetryConfigurable); ok {
+				retryConfigurable.SetRetryOptions(retryOptions)
+			}
+
+			if grantType == auth.GrantTypeClientCredentials && !offline {
+				if err := validateClientCredentialsEnv(); err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				logger.FromContext(cmd.Context()).Info("Authenticating as a worker application, tools will act with the application's roles rather than an administrator's")
+			}
+
 			storeType, err := tokenstore.ParseStoreType(storeTypeFlag)
 			if err != nil {
 				return errs.NewCommandError(commandName, err)
 			}
 
-			tokenStore, err := tokenStoreFactory.NewTokenStore(storeType)
+			productionGuardrail, err := validation.ParseProductionGuardrail(productionGuardrailFlag)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			logger.FromContext(cmd.Context()).Debug("Using production guardrail", slog.String("productionGuardrail", productionGuardrail.String()))
+
+			var serverPersona *persona.Persona
+			if personaName != "" {
+				serverPersona, err = persona.Get(personaName)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				if len(includedTools) > 0 || len(includedToolCollections) > 0 || len(enabledToolsets) > 0 {
+					return errs.NewCommandError(commandName, errors.New("--persona cannot be used with --include-tools, --include-tool-collections or --enable-toolsets, as the persona selects the tools to enable"))
+				}
+				if dynamicToolsets {
+					return errs.NewCommandError(commandName, errors.New("--persona cannot be used with --dynamic-toolsets, as the persona selects the tools to enable"))
+				}
+			}
+
+			if len(enabledToolsets) > 0 {
+				toolsetCollections, err := toolsets.CollectionNames(enabledToolsets)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				// Dynamic toolsets register the collections of the enabled toolsets themselves, so that other
+				// toolsets can be enabled later
+				if !dynamicToolsets {
+					includedToolCollections = append(includedToolCollections, toolsetCollections...)
+				}
+			}
+
+			toolsetScopes, err := scopes.ParseToolsetScopes(toolsetScopeFlags)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			// Offline runs authenticate without PingOne, so there are no scopes to request
+			requestedScopes := scopes.Requested(loginScopes, toolsetScopes, enabledToolsets, dynamicToolsets)
+			if len(requestedScopes) > 0 && !offline {
+				scopesConfigurable, ok := authClientFactory.(client.ScopesConfigurable)
+				if !ok {
+					return errs.NewCommandError(commandName, errors.New("--login-scopes and --toolset-scopes are not supported by the configured auth client"))
+				}
+				scopesConfigurable.SetScopes(requestedScopes)
+				logger.FromContext(cmd.Context()).Info("Requesting pinned OAuth scopes when logging in to PingOne", slog.Any("scopes", requestedScopes))
+			}
+
+			if cmd.Flags().Changed("read-only") {
+				if cmd.Flags().Changed("disable-read-only") {
+					return errs.NewCommandError(commandName, errors.New("--read-only cannot be used with --disable-read-only"))
+				}
+				disableReadOnly = !readOnly
+			}
+
+			productionAccessPolicy, err := productionAccessPolicyFromFlags(cmd, allowProductionRead, allowProductionWrite, allowedProductionEnvironmentIds)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if productionGuardrail == validation.ProductionGuardrailReadOnly && productionAccessPolicy.AllowsWrite() {
+				return errs.NewCommandError(commandName, errors.New("production access overrides that allow writes cannot be used with the read-only production guardrail"))
+			}
+			if serverPersona != nil && !serverPersona.AllowProductionWrite && productionAccessPolicy.AllowsWrite() {
+				return errs.NewCommandError(commandName, fmt.Errorf("production access overrides that allow writes cannot be used with the %s persona", serverPersona.Name))
+			}
+			if !productionAccessPolicy.IsEmpty() {
+				logger.FromContext(cmd.Context()).Warn("PRODUCTION environment access overrides enabled",
+					slog.Bool("allowProductionRead", productionAccessPolicy.AllowRead),
+					slog.Bool("allowProductionWrite", productionAccessPolicy.AllowWrite),
+					slog.Any("allowedProductionEnvironmentIds", productionAccessPolicy.AllowedEnvironmentIds))
+			}
+
+			environmentScope, err := environmentScopeFromFlags(cmd, allowedEnvironmentIds, deniedEnvironmentIds)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if !environmentScope.IsEmpty() {
+				logger.FromContext(cmd.Context()).Info("Tools are restricted to an environment scope",
+					slog.Any("allowedEnvironmentIds", environmentScope.AllowedEnvironmentIds),
+					slog.Any("deniedEnvironmentIds", environmentScope.DeniedEnvironmentIds))
+			}
+
+			environmentCacheOptions := validation.EnvironmentCacheOptions{
+				TTL:        environmentCacheTTL,
+				MaxEntries: environmentCacheMaxEntries,
+			}
+			if err := environmentCacheOptions.Validate(); err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			logger.FromContext(cmd.Context()).Debug("Using environment cache options",
+				slog.Duration("ttl", environmentCacheOptions.TTL),
+				slog.Int("maxEntries", environmentCacheOptions.MaxEntries))
+
+			defaultFilters, err := defaultfilter.ParseDefaultFilters(defaultFilterFlags, tools.ListTools())
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			logger.FromContext(cmd.Context()).Debug("Using default filters", slog.Any("defaultFilters", defaultFilters))
+
+			defaultBookmarks, err := defaultbookmarks.LoadDefaultBookmarks(defaultBookmarksFile)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			logger.FromContext(cmd.Context()).Debug("Using default bookmarks", slog.Int("productCount", len(defaultBookmarks)))
+
+			if listResultPageSize < 0 {
+				return errs.NewCommandError(commandName, errors.New("list result page size must not be negative"))
+			}
+
+			if responseCacheTTL < 0 {
+				return errs.NewCommandError(commandName, errors.New("response cache TTL must not be negative"))
+			}
+
+			if toolTimeout < 0 {
+				return errs.NewCommandError(commandName, errors.New("tool timeout must not be negative"))
+			}
+			toolTimeoutOverrides, err := tooltimeout.ParseToolTimeouts(toolTimeoutFlags, tools.ListTools())
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			toolTimeouts := tooltimeout.ToolTimeouts{
+				Default: toolTimeout,
+				PerTool: toolTimeoutOverrides,
+			}
+			logger.FromContext(cmd.Context()).Debug("Using tool timeouts",
+				slog.Duration("default", toolTimeouts.Default),
+				slog.Any("perTool", toolTimeouts.PerTool))
+
+			if maxOutputBytes < 0 {
+				return errs.NewCommandError(commandName, errors.New("max output bytes must not be negative"))
+			}
+			if maxOutputTokens < 0 {
+				return errs.NewCommandError(commandName, errors.New("max output tokens must not be negative"))
+			}
+
+			if safeModeThreshold < 0 {
+				return errs.NewCommandError(commandName, errors.New("safe mode threshold must not be negative"))
+			}
+
+			outputTransformers, err := outputtransform.LoadOutputTransformers(outputTransformersFile, tools.ListTools())
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if len(outputTransformers) > 0 {
+				logger.FromContext(cmd.Context()).Info("Output transformers enabled", slog.Int("toolCount", len(outputTransformers)))
+			}
+
+			textTemplates, err := texttemplates.LoadTextTemplates(textTemplatesDir, tools.ListTools())
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if len(textTemplates) > 0 {
+				logger.FromContext(cmd.Context()).Info("Text templates enabled", slog.Int("toolCount", len(textTemplates)))
+			}
+
+			descriptionPack, err := descriptionpack.LoadPack(toolDescriptionsFile, tools.ListTools())
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+
+			toolPolicy, err := toolpolicy.LoadPolicy(toolPolicyFile, append(tools.ListTools(), server.ListServerTools()...))
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+
+			toolCapabilities, err := capabilities.ParseMode(toolCapabilitiesFlag)
 			if err != nil {
 				return errs.NewCommandError(commandName, err)
 			}
 
+			tokenStore := mockTokenStore
+			if tokenStore == nil {
+				tokenStore, err = tokenStoreFactory.NewTokenStore(storeType)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+			}
+
 			hasSession, err := tokenStore.HasSession()
 			if err != nil {
 				return errs.NewCommandError(commandName, err)
@@ -80,10 +455,53 @@ The server will communicate over stdin/stdout.`,
 				} else {
 					logger.FromContext(cmd.Context()).Debug("Active session found", slog.String("sessionId", session.SessionId))
 				}
+				if !offline {
+					scopes.WarnExcess(cmd.Context(), session.AccessToken, requestedScopes)
+				}
 			} else {
 				logger.FromContext(cmd.Context()).Debug("No active session found, authentication will be refreshed when a tool is invoked")
 			}
 
+			profileSwitcher, tokenStore, err := profileSwitcherFromFile(profilesFile, tokenStore, authClientFactory, clientFactory, legacyClientFactory)
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+
+			var safeMode *safemode.SafeMode
+			if transportType == server.TransportTypeStdio && safeModeThreshold > 0 {
+				crashMarker, detection, err := startCrashMarker(version)
+				if err != nil {
+					// Crash detection must not stop the server from starting
+					logger.FromContext(cmd.Context()).Warn("Abnormal exit detection unavailable", slog.String("error", err.Error()))
+				} else {
+					defer func() {
+						// Keep the marker when panicking, so that the next run detects the abnormal exit
+						if r := recover(); r != nil {
+							panic(r)
+						}
+						if clearErr := crashMarker.Clear(); clearErr != nil {
+							logger.FromContext(cmd.Context()).Warn("Failed to clear crash marker", slog.String("error", clearErr.Error()))
+						}
+					}()
+					if detection.AbnormalExits > 0 {
+						logger.FromContext(cmd.Context()).Warn("Previous runs of the server exited abnormally", slog.Int("abnormalExits", detection.AbnormalExits), slog.Int("safeModeThreshold", safeModeThreshold))
+					}
+					safeMode = safemode.New(detection, safeModeThreshold, crashMarker.FilePath())
+				}
+			}
+			if safeMode != nil {
+				disableReadOnly = false
+				responseCacheTTL = 0
+				environmentCacheOptions = validation.EnvironmentCacheOptions{}
+			}
+
+			if serverPersona != nil {
+				includedTools = serverPersona.ToolNames()
+				if serverPersona.ReadOnly {
+					disableReadOnly = false
+				}
+			}
+
 			toolFilter := filter.NewFilter(!disableReadOnly, includedTools, excludedTools, includedToolCollections, excludedToolCollections)
 
 			logger.FromContext(cmd.Context()).Debug("Run command tool filter built",
@@ -94,11 +512,112 @@ The server will communicate over stdin/stdout.`,
 				slog.Any("excludedToolCollections", excludedToolCollections))
 
 			// Warn if user may have specified write tools but forgot --disable-read-only
-			if !disableReadOnly && len(includedTools) > 0 {
+			if !disableReadOnly && len(includedTools) > 0 && serverPersona == nil {
 				warnAboutPotentialWriteToolsFiltered(cmd.Context(), includedTools)
 			}
 
-			err = server.Start(cmd.Context(), version, transport, clientFactory, legacyClientFactory, authClientFactory, tokenStore, toolFilter, grantType)
+			var usageRecorder *usagereport.Recorder
+			if toolUsageReportFile != "" {
+				usageRecorder = usagereport.NewRecorder(version, tools.ListTools(), usagereport.DefaultRetryWindow)
+				logger.FromContext(cmd.Context()).Info("Tool usage reporting enabled", slog.String("toolUsageReportFile", toolUsageReportFile))
+			}
+
+			var auditLog *auditlog.FileLog
+			if auditLogFile != "" {
+				auditLog, err = auditlog.NewFileLog(auditLogFile)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+			}
+
+			if openTelemetry {
+				shutdownTelemetry, err := telemetry.Setup(cmd.Context(), version)
+				if err != nil {
+					return errs.NewCommandError(commandName, err)
+				}
+				defer func() {
+					// Export the last spans and metrics even when the command context is already cancelled
+					shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(cmd.Context()), telemetry.ShutdownTimeout)
+					defer cancel()
+					if shutdownErr := shutdownTelemetry(shutdownCtx); shutdownErr != nil {
+						logger.FromContext(cmd.Context()).Error("Failed to shut down OpenTelemetry", slog.String("error", shutdownErr.Error()))
+					}
+				}()
+			}
+
+			mcpServer, err := server.NewServer(cmd.Context(), server.Options{
+				Version:                 version,
+				ClientFactory:           clientFactory,
+				LegacySdkClientFactory:  legacyClientFactory,
+				AuthClientFactory:       authClientFactory,
+				TokenStore:              tokenStore,
+				ToolFilter:              toolFilter,
+				GrantType:               grantType,
+				ProductionGuardrail:     productionGuardrail,
+				ProductionAccessPolicy:  productionAccessPolicy,
+				EnvironmentScope:        environmentScope,
+				EnvironmentCacheOptions: environmentCacheOptions,
+				DefaultFilters:          defaultFilters,
+				DefaultBookmarks:        defaultBookmarks,
+				ListResultPageSize:      listResultPageSize,
+				ResponseCacheTTL:        responseCacheTTL,
+				OutputTransformers:      outputTransformers,
+				TextTemplates:           textTemplates,
+				ProfileSwitcher:         profileSwitcher,
+				UsageRecorder:           usageRecorder,
+				TraceTools:              traceTools,
+				SafeMode:                safeMode,
+				ConfirmDestructiveTools: confirmDestructiveTools,
+				AuditLog:                auditLog,
+				Persona:                 serverPersona,
+				DescriptionPack:         descriptionPack,
+				ToolPolicy:              toolPolicy,
+				ToolCapabilities:        toolCapabilities,
+				DynamicToolsets:         dynamicToolsets,
+				EnabledToolsets:         enabledToolsets,
+				ToolTimeouts:            toolTimeouts,
+				RedactToolResults:       redactToolResults,
+				MaxOutputBytes:          outputlimit.MaxBytes(maxOutputBytes, maxOutputTokens),
+				MultiRegion:             multiRegion,
+			})
+			if err != nil {
+				return errs.NewCommandError(commandName, err)
+			}
+			if transportType == server.TransportTypeHttp {
+				httpOptions.Status = httptransport.NewStatus(statusGuardrails(toolFilter.ReadOnly, productionGuardrail, productionAccessPolicy, environmentScope, confirmDestructiveTools), tokenStore, httptransport.DefaultMaxStatusToolCalls)
+				// Added after all other middleware, so that calls rejected by the guardrails are shown too
+				mcpServer.AddReceivingMiddleware(httpOptions.Status.Handler)
+			}
+			logger.FromContext(cmd.Context()).Info("Starting PingOne MCP server...", slog.String("transport", transportType.String()))
+			if transportType == server.TransportTypeHttp {
+				// Stop serving on an interrupt or termination signal, so that in-flight requests can complete
+				serveCtx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				err = httptransport.Serve(serveCtx, mcpServer, httpOptions)
+				stop()
+				if errors.Is(err, context.Canceled) {
+					logger.FromContext(cmd.Context()).Info("PingOne MCP server stopped")
+					err = nil
+				}
+			} else {
+				// Stop on an interrupt or termination signal as on a client disconnect, so that MCP clients
+				// stopping the server are not detected as abnormal exits
+				runCtx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				err = mcpServer.Run(runCtx, transport)
+				stop()
+				if errors.Is(err, context.Canceled) && cmd.Context().Err() == nil {
+					logger.FromContext(cmd.Context()).Info("PingOne MCP server stopped")
+					err = nil
+				}
+			}
+
+			// Write the usage report even if the server stopped with an error, as the usage up to that point is still useful
+			if usageRecorder != nil {
+				if reportErr := usageRecorder.WriteReportFile(toolUsageReportFile); reportErr != nil {
+					logger.FromContext(cmd.Context()).Error("Failed to write tool usage report", slog.String("error", reportErr.Error()))
+				} else {
+					logger.FromContext(cmd.Context()).Info("Tool usage report written", slog.String("toolUsageReportFile", toolUsageReportFile))
+				}
+			}
 			if err != nil {
 				return errs.NewCommandError(commandName, err)
 			}
@@ -110,13 +629,227 @@ The server will communicate over stdin/stdout.`,
 	cmd.Flags().StringSliceVar(&excludedTools, "exclude-tools", []string{}, "A list of tools to disable")
 	cmd.Flags().StringSliceVar(&includedToolCollections, "include-tool-collections", []string{}, "A list of tool collections to enable")
 	cmd.Flags().StringSliceVar(&excludedToolCollections, "exclude-tool-collections", []string{}, "A list of tool collections to disable")
+	cmd.Flags().StringSliceVar(&enabledToolsets, "enable-toolsets", []string{}, "A list of toolsets to enable ("+strings.Join(toolsets.Names(), ", ")+"), each a group of tool collections for an area of PingOne administration. Combined with --include-tool-collections")
+	cmd.Flags().BoolVar(&dynamicToolsets, "dynamic-toolsets", false, "Only register the tools of the toolsets enabled with --enable-toolsets at startup, and add the enable_toolset and disable_toolset tools to change the enabled toolsets while the server runs. Write tools are still only included when read-only mode is disabled")
 	cmd.Flags().BoolVar(&disableReadOnly, "disable-read-only", false, "Disable read-only mode to include write tools")
-	cmd.Flags().StringVar(&grantTypeFlag, "grant-type", auth.GrantTypeAuthorizationCode.String(), "OAuth grant type to use for authentication (authorization_code or device_code). device_code is recommended in headless or CI/CD environments")
-	cmd.Flags().StringVar(&storeTypeFlag, "store-type", tokenstore.StoreTypeKeychain.String(), "Token store type to use (keychain or file)")
+	cmd.Flags().BoolVar(&readOnly, "read-only", true, "Only include read-only tools. Set to false to include write tools, like --disable-read-only. Cannot be combined with --disable-read-only")
+	cmd.Flags().BoolVar(&confirmDestructiveTools, "confirm-destructive-tools", true, "Ask the user to approve each call of a destructive tool, such as schedule_environment_deletion, before it runs. Requires an MCP client that supports elicitation; calls from other clients are rejected. Set to false to run destructive tools without confirmation")
+	cmd.Flags().BoolVar(&multiRegion, "multi-region", false, "Send the PingOne API requests of tool calls to the region of the environment they act on, looked up in the configured region, so that environments of the organization in other geographies can be managed. Also enables the select_region tool to select the region of calls that do not act on an environment")
+	cmd.Flags().BoolVar(&redactToolResults, "redact-tool-results", false, "Mask the values of sensitive fields, such as passwords, secrets, tokens and MFA seeds, in the tool results returned to the MCP client. Sensitive fields are always masked in logs and the audit log")
+	cmd.Flags().StringVar(&auditLogFile, "audit-log-file", "", "Path to an append-only JSON Lines file recording every write tool call made through the server, with its arguments (sensitive values redacted), the PingOne principal, the result status and the affected resource IDs. When set, the query_mutation_audit_log tool is enabled to query the log")
+	cmd.Flags().StringVar(&personaName, "persona", "", "Enable a predefined bundle of tools for a role ("+strings.Join(persona.Names(), ", ")+"), with tool descriptions and guardrails tuned to it. Cannot be combined with --include-tools, --include-tool-collections or --enable-toolsets. The persona's write tools still require --disable-read-only")
+	cmd.Flags().StringVar(&grantTypeFlag, "grant-type", auth.GrantTypeAuthorizationCode.String(), "OAuth grant type to use for authentication (authorization_code, device_code or client_credentials). device_code is recommended in headless environments, and client_credentials authenticates as a worker application without user interaction, for CI/CD and server deployments")
+	cmd.Flags().StringSliceVar(&loginScopes, "login-scopes", []string{}, "A list of OAuth scopes to request when logging in to PingOne, instead of the scopes in the PINGONE_AUTHORIZATION_CODE_SCOPES, PINGONE_DEVICE_CODE_SCOPES or PINGONE_CLIENT_CREDENTIALS_SCOPES environment variables. Combined with --toolset-scopes. A warning is logged when the access token is granted scopes that were not requested")
+	cmd.Flags().StringArrayVar(&toolsetScopeFlags, "toolset-scopes", []string{}, "The OAuth scopes needed by a toolset, in the form <toolset>=<scope> <scope>, requested when logging in along with --login-scopes if the toolset is enabled. The scopes of every toolset are requested when tools are not enabled by toolset or with --dynamic-toolsets. Can be specified multiple times")
+	cmd.Flags().StringVar(&storeTypeFlag, "store-type", tokenstore.StoreTypeKeychain.String(), "Token store type to use (keychain, file or encrypted_file). encrypted_file encrypts the session with a passphrase from the "+tokenstore.PassphraseEnvVar+" environment variable, for hosts without an OS keychain")
+	cmd.Flags().StringVar(&productionGuardrailFlag, "production-guardrail", validation.ProductionGuardrailStrict.String(), "Restrictions applied to PRODUCTION environments (strict or read-only). read-only allows all read-only tools against PRODUCTION environments while continuing to block write tools")
+	cmd.Flags().BoolVar(&allowProductionRead, "allow-production-read", false, "Allow read operations against all PRODUCTION environments, for deployments approved for PRODUCTION access. Can also be set with the "+allowProductionReadEnvVar+" environment variable")
+	cmd.Flags().BoolVar(&allowProductionWrite, "allow-production-write", false, "Allow write operations against all PRODUCTION environments, for deployments approved for PRODUCTION changes. Can also be set with the "+allowProductionWriteEnvVar+" environment variable")
+	cmd.Flags().StringSliceVar(&allowedProductionEnvironmentIds, "allowed-production-environment-ids", []string{}, "A list of PRODUCTION environment IDs that allow read and write operations. Can also be set as a comma-separated list with the "+allowedProductionEnvironmentIdsEnvVar+" environment variable")
+	cmd.Flags().StringSliceVar(&allowedEnvironmentIds, "allowed-environment-ids", []string{}, "A list of the only environment IDs that tools can act on. Can also be set as a comma-separated list with the "+allowedEnvironmentIdsEnvVar+" environment variable")
+	cmd.Flags().StringSliceVar(&deniedEnvironmentIds, "denied-environment-ids", []string{}, "A list of environment IDs that tools cannot act on, taking priority over allowed environment IDs. Can also be set as a comma-separated list with the "+deniedEnvironmentIdsEnvVar+" environment variable")
+	cmd.Flags().DurationVar(&environmentCacheTTL, "environment-cache-ttl", validation.DefaultEnvironmentCacheTTL, "How long a validated PRODUCTION environment is cached before it is looked up again. 0 disables caching")
+	cmd.Flags().IntVar(&environmentCacheMaxEntries, "environment-cache-max-entries", validation.DefaultEnvironmentCacheMaxEntries, "The maximum number of validated PRODUCTION environments to cache. 0 disables caching")
+	cmd.Flags().StringArrayVar(&defaultFilterFlags, "default-filter", []string{}, "A default SCIM filter applied to a list tool, in the form <tool name>=<SCIM filter>. Combined with any filter provided by the client using 'and'. Can be specified multiple times")
+	cmd.Flags().StringVar(&defaultBookmarksFile, "default-bookmarks-file", "", "Path to a JSON file of default bookmarks per product type, added to the Bill of Materials products of environments created or updated by tools")
+	cmd.Flags().IntVar(&apiMaxRetries, "api-max-retries", sdk.DefaultMaxRetries, "How many times a PingOne API request rejected with 429 Too Many Requests or 503 Service Unavailable is retried before the tool call fails. 0 disables retries")
+	cmd.Flags().DurationVar(&apiMaxBackoff, "api-max-backoff", sdk.DefaultMaxBackoff, "The longest wait before retrying a rejected PingOne API request. Requests that PingOne asks to retry later than this fail without retrying")
+	cmd.Flags().DurationVar(&responseCacheTTL, "response-cache-ttl", 0, "When greater than zero, results of read-only tools are cached for this long, so that repeated calls with the same arguments do not call the PingOne API again. Write tools invalidate cached results for the environment they change. 0 disables the cache")
+	cmd.Flags().DurationVar(&toolTimeout, "tool-timeout", tooltimeout.DefaultToolTimeout, "How long a tool call may run before it is cancelled, along with its PingOne API requests. Background jobs are not cancelled. 0 lets tool calls run until they complete")
+	cmd.Flags().StringArrayVar(&toolTimeoutFlags, "tool-timeout-override", []string{}, "The timeout of a tool, in the form <tool name>=<duration>, used instead of --tool-timeout. 0 lets calls of the tool run until they complete. Can be specified multiple times")
+	cmd.Flags().IntVar(&listResultPageSize, "list-result-page-size", 0, "When greater than zero, list tool results with more items than this are returned as a paged MCP resource, with only the first page inline. 0 returns all items inline")
+	cmd.Flags().IntVar(&maxOutputBytes, "max-output-bytes", outputlimit.DefaultMaxOutputBytes, "Tool results larger than this many bytes are truncated to the limit, and include a summary and the URI of a resource holding the rest of the result. 0 disables the byte limit")
+	cmd.Flags().IntVar(&maxOutputTokens, "max-output-tokens", 0, "Tool results with output larger than about this many tokens, counted as "+strconv.Itoa(outputlimit.BytesPerToken)+" bytes each, are truncated like results over --max-output-bytes. The smaller limit applies. 0 disables the token limit")
+	cmd.Flags().StringVar(&outputTransformersFile, "output-transformers-file", "", "Path to a JSON file of commands per tool name that transform the tool's JSON output. Each command receives the output on stdin and writes the transformed output to stdout")
+	cmd.Flags().StringVar(&textTemplatesDir, "text-templates-dir", "", "Path to a directory of Go templates, named <tool name>"+texttemplates.FileExtension+", that render the text content of the tool's results instead of JSON. The structured content of results is unchanged")
+	cmd.Flags().StringVar(&toolDescriptionsFile, "tool-descriptions-file", "", "Path to a JSON or YAML file of replacement titles, descriptions and parameter descriptions per tool name, such as translated descriptions or an enterprise's own terminology, listed to MCP clients instead of the server's own")
+	cmd.Flags().StringVar(&toolPolicyFile, "tool-policy-file", "", "Path to a JSON or YAML file that sets tools to allow, deny or confirm, by tool name or by category (read, write or destructive). Denied tools are hidden and their calls rejected and recorded in the audit log; calls of confirmed tools must be approved by the user")
+	cmd.Flags().StringVar(&toolCapabilitiesFlag, "tool-capabilities", string(capabilities.ModeMark), "What to do with tools that the authenticated principal's PingOne roles do not allow, such as create_environment without the Organization Admin role, once the roles are looked up after login: mark (note it in their descriptions), hide (do not list them) or off (do not look up the roles)")
+	cmd.Flags().StringVar(&profilesFile, "profiles-file", "", "Path to a JSON file of named PingOne profiles (organization and region). When set, the switch_profile tool is enabled to switch between profiles mid-session")
+	cmd.Flags().StringVar(&transportTypeFlag, "transport", server.TransportTypeStdio.String(), "MCP transport to serve clients on (stdio or http). http serves remote clients over streamable HTTP at "+httptransport.StreamableHttpPath+", with an SSE fallback at "+httptransport.SsePath+". Requests must send the bearer token from the "+httptransport.BearerTokenEnvVar+" environment variable, which is required unless listening on a loopback address, or an OAuth access token when --http-oauth-issuer is set")
+	cmd.Flags().StringVar(&httpAddress, "http-address", httptransport.DefaultAddress, "The host and port to listen on with the http transport")
+	cmd.Flags().DurationVar(&httpSessionTimeout, "http-session-timeout", httptransport.DefaultSessionTimeout, "How long an idle streamable HTTP session is kept before it is closed. 0 keeps idle sessions open")
+	cmd.Flags().DurationVar(&httpShutdownTimeout, "http-shutdown-timeout", httptransport.DefaultShutdownTimeout, "How long in-flight HTTP requests are given to complete when the server stops")
+	cmd.Flags().StringVar(&httpOAuthIssuer, "http-oauth-issuer", "", "Authenticate HTTP requests with OAuth access tokens from this issuer, such as https://auth.pingone.com/<environment ID>/as, instead of the "+httptransport.BearerTokenEnvVar+" bearer token. Requires --http-oauth-resource")
+	cmd.Flags().StringVar(&httpOAuthResource, "http-oauth-resource", "", "The URL that clients use to reach the MCP server, such as https://mcp.example.com"+httptransport.StreamableHttpPath+", advertised in the OAuth protected resource metadata")
+	cmd.Flags().StringVar(&httpOAuthAudience, "http-oauth-audience", "", "The audience that OAuth access tokens must be issued for. Defaults to --http-oauth-resource")
+	cmd.Flags().StringVar(&httpOAuthReadScope, "http-oauth-read-scope", httptransport.DefaultReadScope, "The OAuth scope that allows calls to read-only tools")
+	cmd.Flags().StringVar(&httpOAuthWriteScope, "http-oauth-write-scope", httptransport.DefaultWriteScope, "The OAuth scope that allows calls to all tools, including write tools and the tools that manage the server's PingOne session")
+	cmd.Flags().BoolVar(&mockBackend, "mock-backend", false, "Run against a built-in, in-memory mock of PingOne seeded with demo data instead of a real PingOne organization. No credentials or network access are needed and changes are discarded when the server stops")
+	cmd.Flags().StringVar(&mockBackendFixturesFile, "mock-backend-fixtures", "", "Path to a JSON fixtures file, or an environment exported with the export_environment tool, to seed the mock backend with instead of the built-in demo data. Requires --mock-backend")
+	cmd.Flags().StringVar(&recordApiFile, "record-api-file", "", "Path to a file to record the PingOne API requests and responses of tool calls to, with secrets such as tokens, passwords and client secrets redacted, for reproducing bugs with --replay-api-file. The file is replaced if it exists")
+	cmd.Flags().StringVar(&replayApiFile, "replay-api-file", "", "Path to a file of PingOne API interactions recorded with --record-api-file to serve instead of calling PingOne. No credentials or network access are needed, and requests that were not recorded fail")
+	cmd.Flags().StringVar(&toolUsageReportFile, "tool-usage-report-file", "", "Path to write an anonymized JSON report of tool calls, schema validation failures, tool errors and immediate retries per tool when the server stops. The report contains no tool arguments or results")
+	cmd.Flags().BoolVar(&traceTools, "trace-tools", false, "Developer mode: attach an execution trace to the _meta of every tool result, listing each PingOne API request made by the call with its duration, status code and retry count. Traces are also logged")
+	cmd.Flags().BoolVar(&openTelemetry, "opentelemetry", false, "Export OpenTelemetry traces and metrics of tool calls and PingOne API requests, configured with the standard OTEL_* environment variables such as OTEL_EXPORTER_OTLP_ENDPOINT. Set OTEL_METRICS_EXPORTER=prometheus to serve metrics for Prometheus to scrape instead")
+	cmd.Flags().IntVar(&safeModeThreshold, "safe-mode-threshold", safemode.DefaultThreshold, "With the stdio transport, start in safe mode after this many consecutive runs of the server exited abnormally, such as by crashing. Safe mode only enables read-only tools, disables caching and adds the diagnose_safe_mode tool. 0 disables abnormal exit detection")
 
 	return cmd
 }
 
+// productionAccessPolicyFromFlags builds the production access policy from the command flags.
+// Environment variables are used for flags that are not set on the command line.
+func productionAccessPolicyFromFlags(cmd *cobra.Command, allowRead bool, allowWrite bool, allowedEnvironmentIds []string) (validation.ProductionAccessPolicy, error) {
+	var err error
+	if !cmd.Flags().Changed("allow-production-read") {
+		if allowRead, err = boolFromEnv(allowProductionReadEnvVar); err != nil {
+			return validation.ProductionAccessPolicy{}, err
+		}
+	}
+	if !cmd.Flags().Changed("allow-production-write") {
+		if allowWrite, err = boolFromEnv(allowProductionWriteEnvVar); err != nil {
+			return validation.ProductionAccessPolicy{}, err
+		}
+	}
+	if !cmd.Flags().Changed("allowed-production-environment-ids") {
+		allowedEnvironmentIds = stringSliceFromEnv(allowedProductionEnvironmentIdsEnvVar, allowedEnvironmentIds)
+	}
+
+	environmentIds, err := validation.ParseEnvironmentIds(allowedEnvironmentIds)
+	if err != nil {
+		return validation.ProductionAccessPolicy{}, err
+	}
+
+	return validation.ProductionAccessPolicy{
+		AllowRead:             allowRead,
+		AllowWrite:            allowWrite,
+		AllowedEnvironmentIds: environmentIds,
+	}, nil
+}
+
+// environmentScopeFromFlags builds the environment scope from the command flags.
+// Environment variables are used for flags that are not set on the command line.
+func environmentScopeFromFlags(cmd *cobra.Command, allowedEnvironmentIds []string, deniedEnvironmentIds []string) (validation.EnvironmentScope, error) {
+	if !cmd.Flags().Changed("allowed-environment-ids") {
+		allowedEnvironmentIds = stringSliceFromEnv(allowedEnvironmentIdsEnvVar, allowedEnvironmentIds)
+	}
+	if !cmd.Flags().Changed("denied-environment-ids") {
+		deniedEnvironmentIds = stringSliceFromEnv(deniedEnvironmentIdsEnvVar, deniedEnvironmentIds)
+	}
+
+	allowed, err := validation.ParseEnvironmentIds(allowedEnvironmentIds)
+	if err != nil {
+		return validation.EnvironmentScope{}, err
+	}
+	denied, err := validation.ParseEnvironmentIds(deniedEnvironmentIds)
+	if err != nil {
+		return validation.EnvironmentScope{}, err
+	}
+
+	return validation.EnvironmentScope{
+		AllowedEnvironmentIds: allowed,
+		DeniedEnvironmentIds:  denied,
+	}, nil
+}
+
+// storedBearerTokenKeyring returns the keyring of bearer tokens generated by rotate-bearer-token in the token
+// store, or nil if the token store does not hold secrets or no bearer token has been generated
+func storedBearerTokenKeyring(tokenStoreFactory tokenstore.TokenStoreFactory, storeTypeFlag string) (*httptransport.BearerTokenKeyring, error) {
+	storeType, err := tokenstore.ParseStoreType(storeTypeFlag)
+	if err != nil {
+		return nil, err
+	}
+	tokenStore, err := tokenStoreFactory.NewTokenStore(storeType)
+	if err != nil {
+		return nil, err
+	}
+	secretStore, ok := tokenStore.(tokenstore.SecretStore)
+	if !ok {
+		return nil, nil
+	}
+	keyring, err := httptransport.NewBearerTokenKeyring(secretStore)
+	if errors.Is(err, tokenstore.ErrSecretNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return keyring, nil
+}
+
+// validateClientCredentialsEnv checks that the worker application credentials are configured, so that a
+// missing credential is reported when the server starts rather than on the first tool call.
+func validateClientCredentialsEnv() error {
+	for _, envVar := range []string{auth.ClientCredentialsClientIdEnvVar, auth.ClientCredentialsClientSecretEnvVar} {
+		if strings.TrimSpace(os.Getenv(envVar)) == "" {
+			return fmt.Errorf("the %s grant type requires the %s environment variable", auth.GrantTypeClientCredentials.String(), envVar)
+		}
+	}
+	return nil
+}
+
+// statusGuardrails describes the guardrail configuration for the status page of the HTTP transport
+func statusGuardrails(readOnly bool, productionGuardrail validation.ProductionGuardrail, productionAccessPolicy validation.ProductionAccessPolicy, environmentScope validation.EnvironmentScope, confirmDestructiveTools bool) []httptransport.StatusSetting {
+	environmentIds := func(ids []uuid.UUID, none string) string {
+		if len(ids) == 0 {
+			return none
+		}
+		values := make([]string, len(ids))
+		for i, id := range ids {
+			values[i] = id.String()
+		}
+		return strings.Join(values, ", ")
+	}
+	return []httptransport.StatusSetting{
+		{Name: "Read-only mode", Value: strconv.FormatBool(readOnly)},
+		{Name: "Production guardrail", Value: productionGuardrail.String()},
+		{Name: "Read all PRODUCTION environments", Value: strconv.FormatBool(productionAccessPolicy.AllowRead)},
+		{Name: "Write all PRODUCTION environments", Value: strconv.FormatBool(productionAccessPolicy.AllowWrite)},
+		{Name: "Allowed PRODUCTION environments", Value: environmentIds(productionAccessPolicy.AllowedEnvironmentIds, "none")},
+		{Name: "Environment scope allowed environments", Value: environmentIds(environmentScope.AllowedEnvironmentIds, "all")},
+		{Name: "Environment scope denied environments", Value: environmentIds(environmentScope.DeniedEnvironmentIds, "none")},
+		{Name: "Confirm destructive tools", Value: strconv.FormatBool(confirmDestructiveTools)},
+	}
+}
+
+// profileSwitcherFromFile loads the profiles file and creates a switcher between the profiles, along with
+// the token store holding a session per profile that the server must use.
+// The clients of the given factories connect with the settings of the active profile.
+// Returns a nil switcher and the given token store when no profiles are configured, which leaves profile
+// switching disabled.
+func profileSwitcherFromFile(profilesFile string, tokenStore tokenstore.TokenStore, authClientFactory client.AuthClientFactory, clientFactory sdk.ClientFactory, legacyClientFactory legacy.ClientFactory) (*profile.Switcher, tokenstore.TokenStore, error) {
+	profiles, err := profile.LoadProfiles(profilesFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, tokenStore, nil
+	}
+
+	authEnvironment, ok := authClientFactory.(profile.AuthEnvironmentSetter)
+	if !ok {
+		return nil, nil, errors.New("profiles are not supported by the configured auth client")
+	}
+	sessions := tokenstore.NewNamedSessionStore(tokenStore)
+	switcher := profile.NewSwitcher(profiles, sessions, authEnvironment)
+	for _, factory := range []any{authClientFactory, clientFactory, legacyClientFactory} {
+		if configurable, ok := factory.(auth.ClientSettingsConfigurable); ok {
+			configurable.SetClientSettingsProvider(switcher)
+		}
+	}
+	return switcher, sessions, nil
+}
+
+// stringSliceFromEnv returns the comma-separated values of the environment variable, or the default if it is not set
+func stringSliceFromEnv(envVar string, defaultValue []string) []string {
+	if value := strings.TrimSpace(os.Getenv(envVar)); value != "" {
+		return strings.Split(value, ",")
+	}
+	return defaultValue
+}
+
+func boolFromEnv(envVar string) (bool, error) {
+	value := strings.TrimSpace(os.Getenv(envVar))
+	if value == "" {
+		return false, nil
+	}
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value for %s environment variable: %s", envVar, value)
+	}
+	return result, nil
+}
+
 // warnAboutPotentialWriteToolsFiltered checks if any of the included tools are write tools
 // and warns the user that they will be filtered out due to read-only mode being enabled
 func warnAboutPotentialWriteToolsFiltered(ctx context.Context, includedTools []string) {
@@ -143,3 +876,16 @@ func warnAboutPotentialWriteToolsFiltered(ctx context.Context, includedTools []s
 			slog.String("suggestion", "Add --disable-read-only flag to enable write tools"))
 	}
 }
+
+// startCrashMarker records the start of this run in the crash marker file, returning the abnormal exits of previous runs
+func startCrashMarker(version string) (*safemode.CrashMarker, safemode.Detection, error) {
+	crashMarker, err := safemode.NewCrashMarker()
+	if err != nil {
+		return nil, safemode.Detection{}, err
+	}
+	detection, err := crashMarker.Start(version)
+	if err != nil {
+		return nil, safemode.Detection{}, err
+	}
+	return crashMarker, detection, nil
+}
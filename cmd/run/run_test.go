@@ -5,21 +5,30 @@ package run_test
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/safemode"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
 	authtestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/auth"
 	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/applications"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/scimfilters"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolsets"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,6 +60,90 @@ func TestRunCommand_FromRoot_NoServerRun(t *testing.T) {
 			errorContains: "unable to parse grant type",
 			description:   "Run command should return error for invalid flag",
 		},
+		{
+			name:          "run invalid production-guardrail value",
+			args:          []string{"run", "--production-guardrail", "invalid"},
+			expectError:   true,
+			errorContains: "unable to parse production guardrail",
+			description:   "Run command should return error for invalid production guardrail",
+		},
+		{
+			name:          "run default-filter for unknown tool",
+			args:          []string{"run", "--default-filter", "unknown_tool=name eq \"x\""},
+			expectError:   true,
+			errorContains: "invalid default filter for unknown tool",
+			description:   "Run command should return error for a default filter on an unknown tool",
+		},
+		{
+			name:          "run default-filter invalid format",
+			args:          []string{"run", "--default-filter", "list_populations"},
+			expectError:   true,
+			errorContains: "expected format <tool name>=<SCIM filter>",
+			description:   "Run command should return error for an invalid default filter",
+		},
+		{
+			name:          "run default-bookmarks-file does not exist",
+			args:          []string{"run", "--default-bookmarks-file", "does-not-exist.json"},
+			expectError:   true,
+			errorContains: "failed to read default bookmarks file",
+			description:   "Run command should return error for a missing default bookmarks file",
+		},
+		{
+			name:          "run negative list-result-page-size",
+			args:          []string{"run", "--list-result-page-size", "-1"},
+			expectError:   true,
+			errorContains: "list result page size must not be negative",
+			description:   "Run command should return error for a negative list result page size",
+		},
+		{
+			name:          "run negative safe-mode-threshold",
+			args:          []string{"run", "--safe-mode-threshold", "-1"},
+			expectError:   true,
+			errorContains: "safe mode threshold must not be negative",
+			description:   "Run command should return error for a negative safe mode threshold",
+		},
+		{
+			name:          "run negative response-cache-ttl",
+			args:          []string{"run", "--response-cache-ttl", "-1s"},
+			expectError:   true,
+			errorContains: "response cache TTL must not be negative",
+			description:   "Run command should return error for a negative response cache TTL",
+		},
+		{
+			name:          "run negative tool-timeout",
+			args:          []string{"run", "--tool-timeout", "-1s"},
+			expectError:   true,
+			errorContains: "tool timeout must not be negative",
+			description:   "Run command should return error for a negative tool timeout",
+		},
+		{
+			name:          "run negative max-output-bytes",
+			args:          []string{"run", "--max-output-bytes", "-1"},
+			expectError:   true,
+			errorContains: "max output bytes must not be negative",
+			description:   "Run command should return error for a negative max output bytes",
+		},
+		{
+			name:          "run negative max-output-tokens",
+			args:          []string{"run", "--max-output-tokens", "-1"},
+			expectError:   true,
+			errorContains: "max output tokens must not be negative",
+			description:   "Run command should return error for a negative max output tokens",
+		},
+		{
+			name:          "run tool-timeout-override for unknown tool",
+			args:          []string{"run", "--tool-timeout-override", "unknown_tool=10m"},
+			expectError:   true,
+			errorContains: "invalid tool timeout for unknown tool",
+			description:   "Run command should return error for a tool timeout of an unknown tool",
+		},
+		{
+			name:          "run tool-timeout-override invalid format",
+			args:          []string{"run", "--tool-timeout-override", "list_populations"},
+			expectError:   true,
+			errorContains: "expected format <tool name>=<duration>",
+			description:   "Run command should return error for an invalid tool timeout",
+		},
 	}
 
 	for _, tt := range tests {
@@ -74,6 +167,16 @@ func TestRunCommand_FromRoot_NoServerRun(t *testing.T) {
 	}
 }
 
+func TestRunCommand_ClientCredentialsRequiresCredentials(t *testing.T) {
+	t.Setenv(auth.ClientCredentialsClientIdEnvVar, "worker-client-id")
+	t.Setenv(auth.ClientCredentialsClientSecretEnvVar, "")
+
+	err := testutils.ExecuteCliRootCommand(t, context.Background(), "run", "--grant-type", "client_credentials")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires the "+auth.ClientCredentialsClientSecretEnvVar+" environment variable")
+}
+
 func TestRunCommand_FromSubcommand_RunServer(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -211,6 +314,266 @@ func TestRunCommand_FromSubcommand_InvalidStoreType(t *testing.T) {
 	assert.Contains(t, err.Error(), "unable to parse store type from string: invalid", "Error should indicate invalid store type")
 }
 
+func TestRunCommand_FromSubcommand_RetryErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{
+			name:          "negative max retries",
+			args:          []string{"--api-max-retries", "-1"},
+			errorContains: "max retries must not be negative",
+		},
+		{
+			name:          "zero max backoff",
+			args:          []string{"--api-max-backoff", "0s"},
+			errorContains: "retry backoff must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testutils.ExecuteCliRunCommand(t, context.Background(), testutils.NewMockTokenStoreFactory(), sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), &mcp.StdioTransport{}, tt.args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestRunCommand_FromSubcommand_MockBackend(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The token store factory is not used, as the mock backend keeps its session in memory
+	tokenStoreFactory := testutils.NewMockTokenStoreFactory()
+
+	r, w, _ := os.Pipe()
+	os.Stdin = r
+	os.Stdout = w
+
+	// Run the server in a goroutine so the test doesn't block.
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		err := testutils.ExecuteCliRunCommand(t, ctx, tokenStoreFactory, sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), &mcp.StdioTransport{}, "--mock-backend", "--grant-type", "client_credentials")
+		assert.ErrorIs(t, err, context.Canceled, "server should stop due to context cancellation")
+	})
+
+	// Give the server a moment to start up.
+	time.Sleep(100 * time.Millisecond)
+
+	// Cancel the context to signal the server to shut down.
+	cancel()
+	wg.Wait()
+	tokenStoreFactory.AssertNotCalled(t, "NewTokenStore", mock.Anything)
+}
+
+func TestRunCommand_FromSubcommand_OfflineBackendErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{
+			name:          "fixtures without mock backend",
+			args:          []string{"--mock-backend-fixtures", "fixtures.json"},
+			errorContains: "--mock-backend-fixtures requires --mock-backend",
+		},
+		{
+			name:          "missing fixtures file",
+			args:          []string{"--mock-backend", "--mock-backend-fixtures", filepath.Join(t.TempDir(), "missing.json")},
+			errorContains: "failed to read mock backend fixtures",
+		},
+		{
+			name:          "record and replay",
+			args:          []string{"--record-api-file", "recorded.json", "--replay-api-file", "recorded.json"},
+			errorContains: "--record-api-file cannot be used with --replay-api-file",
+		},
+		{
+			name:          "replay with mock backend",
+			args:          []string{"--mock-backend", "--replay-api-file", "recorded.json"},
+			errorContains: "cannot be used with --mock-backend",
+		},
+		{
+			name:          "missing replay file",
+			args:          []string{"--replay-api-file", filepath.Join(t.TempDir(), "missing.json")},
+			errorContains: "failed to read recorded API interactions file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testutils.ExecuteCliRunCommand(t, context.Background(), testutils.NewMockTokenStoreFactory(), sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), &mcp.StdioTransport{}, tt.args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestRunCommand_FromSubcommand_TransportErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{
+			name:          "unknown transport",
+			args:          []string{"--transport", "websocket"},
+			errorContains: "unable to parse transport type from string: websocket",
+		},
+		{
+			name:          "non-loopback address without bearer token",
+			args:          []string{"--transport", "http", "--http-address", "0.0.0.0:8080"},
+			errorContains: "requires a bearer token",
+		},
+		{
+			name:          "invalid HTTP address",
+			args:          []string{"--transport", "http", "--http-address", "localhost"},
+			errorContains: "invalid HTTP address",
+		},
+		{
+			name:          "negative session timeout",
+			args:          []string{"--transport", "http", "--http-session-timeout", "-1m"},
+			errorContains: "session timeout must not be negative",
+		},
+		{
+			name:          "OAuth issuer without http transport",
+			args:          []string{"--http-oauth-issuer", "https://auth.pingone.com/0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f/as"},
+			errorContains: "--http-oauth-issuer requires --transport http",
+		},
+		{
+			name:          "OAuth resource without issuer",
+			args:          []string{"--transport", "http", "--http-oauth-resource", "https://mcp.example.com/mcp"},
+			errorContains: "require --http-oauth-issuer",
+		},
+		{
+			name:          "OAuth issuer without resource",
+			args:          []string{"--transport", "http", "--http-oauth-issuer", "https://auth.pingone.com/0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f/as"},
+			errorContains: "invalid OAuth resource",
+		},
+		{
+			name:          "OAuth issuer without https",
+			args:          []string{"--transport", "http", "--http-oauth-issuer", "http://auth.example.com/as", "--http-oauth-resource", "https://mcp.example.com/mcp"},
+			errorContains: "it must use https",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PINGONE_MCP_HTTP_BEARER_TOKEN", "")
+			err := testutils.ExecuteCliRunCommand(t, context.Background(), testutils.NewMockTokenStoreFactoryWithStore(testutils.NewInMemoryTokenStore()), sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), &mcp.StdioTransport{}, tt.args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestRunCommand_FromSubcommand_PersonaErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{
+			name:          "unknown persona",
+			args:          []string{"--persona", "unknown"},
+			errorContains: `unknown persona "unknown"`,
+		},
+		{
+			name:          "persona with included tools",
+			args:          []string{"--persona", "helpdesk", "--include-tools", environments.ListEnvironmentsDef.McpTool.Name},
+			errorContains: "--persona cannot be used with --include-tools, --include-tool-collections or --enable-toolsets",
+		},
+		{
+			name:          "persona with included tool collections",
+			args:          []string{"--persona", "helpdesk", "--include-tool-collections", environments.CollectionName},
+			errorContains: "--persona cannot be used with --include-tools, --include-tool-collections or --enable-toolsets",
+		},
+		{
+			name:          "persona with enabled toolsets",
+			args:          []string{"--persona", "helpdesk", "--enable-toolsets", "users"},
+			errorContains: "--persona cannot be used with --include-tools, --include-tool-collections or --enable-toolsets",
+		},
+		{
+			name:          "persona with dynamic toolsets",
+			args:          []string{"--persona", "helpdesk", "--dynamic-toolsets"},
+			errorContains: "--persona cannot be used with --dynamic-toolsets",
+		},
+		{
+			name:          "persona without production writes with allow-production-write",
+			args:          []string{"--persona", "developer", "--allow-production-write"},
+			errorContains: "cannot be used with the developer persona",
+		},
+		{
+			name:          "persona without production writes with allowed production environments",
+			args:          []string{"--persona", "security-auditor", "--allowed-production-environment-ids", "0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f"},
+			errorContains: "cannot be used with the security-auditor persona",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PINGONE_MCP_ALLOW_PRODUCTION_WRITE", "")
+			t.Setenv("PINGONE_MCP_ALLOWED_PRODUCTION_ENVIRONMENT_IDS", "")
+			err := testutils.ExecuteCliRunCommand(t, context.Background(), testutils.NewMockTokenStoreFactory(), sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), &mcp.StdioTransport{}, tt.args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestRunCommand_FromSubcommand_ToolsetErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{
+			name:          "unknown toolset",
+			args:          []string{"--enable-toolsets", "users,groups"},
+			errorContains: `unknown toolset "groups"`,
+		},
+		{
+			name:          "unknown toolset with dynamic toolsets",
+			args:          []string{"--dynamic-toolsets", "--enable-toolsets", "groups"},
+			errorContains: `unknown toolset "groups"`,
+		},
+		{
+			name:          "read-only with disable-read-only",
+			args:          []string{"--read-only=false", "--disable-read-only"},
+			errorContains: "--read-only cannot be used with --disable-read-only",
+		},
+		{
+			name:          "scopes of unknown toolset",
+			args:          []string{"--toolset-scopes", "groups=p1:read:group"},
+			errorContains: `invalid toolset scopes: unknown toolset "groups"`,
+		},
+		{
+			name:          "toolset without scopes",
+			args:          []string{"--toolset-scopes", "users="},
+			errorContains: `invalid toolset scopes "users=", expected format <toolset>=<scope> <scope>`,
+		},
+		{
+			name:          "login scopes with auth client that cannot pin scopes",
+			args:          []string{"--login-scopes", "openid"},
+			errorContains: "--login-scopes and --toolset-scopes are not supported by the configured auth client",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testutils.ExecuteCliRunCommand(t, context.Background(), testutils.NewMockTokenStoreFactory(), sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), &mcp.StdioTransport{}, tt.args...)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
 func TestRunCommand_FromSubcommand_ToolFiltering(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -324,6 +687,71 @@ func TestRunCommand_FromSubcommand_ToolFiltering(t *testing.T) {
 			args:          []string{"run", "--disable-read-only", "--include-tools", environments.CreateEnvironmentDef.McpTool.Name},
 			expectedTools: []string{environments.CreateEnvironmentDef.McpTool.Name},
 		},
+		{
+			name:            "toolset enables its collections and the common collections",
+			args:            []string{"run", "--enable-toolsets", "users"},
+			expectedTools:   []string{users.FindUserDef.McpTool.Name, populations.ListPopulationsDef.McpTool.Name, scimfilters.BuildScimFilterDef.McpTool.Name},
+			unexpectedTools: []string{environments.ListEnvironmentsDef.McpTool.Name, users.BulkCreateUsersDef.McpTool.Name},
+		},
+		{
+			name:            "toolsets combined with included collections",
+			args:            []string{"run", "--enable-toolsets", "roles", "--include-tool-collections", environments.CollectionName},
+			expectedTools:   []string{roles.ListRolesDef.McpTool.Name, environments.ListEnvironmentsDef.McpTool.Name},
+			unexpectedTools: []string{populations.ListPopulationsDef.McpTool.Name},
+		},
+		{
+			name:            "collection exclusion narrows toolsets",
+			args:            []string{"run", "--enable-toolsets", "users", "--exclude-tool-collections", populations.CollectionName},
+			expectedTools:   []string{users.FindUserDef.McpTool.Name},
+			unexpectedTools: []string{populations.ListPopulationsDef.McpTool.Name},
+		},
+		{
+			name:            "dynamic toolsets register the enabled toolsets and the toolset tools",
+			args:            []string{"run", "--dynamic-toolsets", "--enable-toolsets", "roles"},
+			expectedTools:   []string{roles.ListRolesDef.McpTool.Name, scimfilters.BuildScimFilterDef.McpTool.Name, toolsets.EnableToolsetDef.McpTool.Name, toolsets.DisableToolsetDef.McpTool.Name},
+			unexpectedTools: []string{users.FindUserDef.McpTool.Name, roles.AssignRoleToUserDef.McpTool.Name},
+		},
+		{
+			name:            "toolset tools only registered with dynamic toolsets",
+			args:            []string{"run", "--enable-toolsets", "roles"},
+			expectedTools:   []string{roles.ListRolesDef.McpTool.Name},
+			unexpectedTools: []string{toolsets.EnableToolsetDef.McpTool.Name, toolsets.DisableToolsetDef.McpTool.Name},
+		},
+		{
+			name:          "read-only false includes write tools",
+			args:          []string{"run", "--read-only=false"},
+			expectedTools: []string{environments.CreateEnvironmentDef.McpTool.Name},
+		},
+		{
+			name:            "read-only true excludes write tools",
+			args:            []string{"run", "--read-only"},
+			expectedTools:   []string{environments.ListEnvironmentsDef.McpTool.Name},
+			unexpectedTools: []string{environments.CreateEnvironmentDef.McpTool.Name},
+		},
+		{
+			name:            "persona selects its read-only tools in read-only mode",
+			args:            []string{"run", "--persona", "helpdesk"},
+			expectedTools:   []string{environments.ListEnvironmentsDef.McpTool.Name, populations.ListPopulationsDef.McpTool.Name},
+			unexpectedTools: []string{users.BulkCreateUsersDef.McpTool.Name, applications.ListApplicationsDef.McpTool.Name},
+		},
+		{
+			name:            "persona write tools included when disable-read-only flag is set",
+			args:            []string{"run", "--persona", "helpdesk", "--disable-read-only"},
+			expectedTools:   []string{environments.ListEnvironmentsDef.McpTool.Name, users.BulkCreateUsersDef.McpTool.Name},
+			unexpectedTools: []string{environments.CreateEnvironmentDef.McpTool.Name, applications.ListApplicationsDef.McpTool.Name},
+		},
+		{
+			name:            "exclusion narrows persona tools",
+			args:            []string{"run", "--persona", "helpdesk", "--exclude-tools", environments.ListEnvironmentsDef.McpTool.Name},
+			expectedTools:   []string{populations.ListPopulationsDef.McpTool.Name},
+			unexpectedTools: []string{environments.ListEnvironmentsDef.McpTool.Name},
+		},
+		{
+			name:            "read-only persona ignores disable-read-only flag",
+			args:            []string{"run", "--persona", "security-auditor", "--disable-read-only"},
+			expectedTools:   []string{applications.ListApplicationsDef.McpTool.Name},
+			unexpectedTools: testutils.WriteToolNames(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -373,3 +801,94 @@ func TestRunCommand_FromSubcommand_ToolFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestRunCommand_FromSubcommand_SafeMode(t *testing.T) {
+	markerFile := filepath.Join(t.TempDir(), "crash_marker.json")
+	t.Setenv(safemode.MarkerFileEnvVar, markerFile)
+	// The previous run is the third in a row that did not exit normally
+	require.NoError(t, os.WriteFile(markerFile, []byte(`{"pid":1234,"version":"1.0.0","startedAt":"2025-01-01T00:00:00Z","abnormalExits":2}`), 0600))
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tokenStore := testutils.NewInMemoryTokenStoreWithDefaultSession()
+	tokenStoreFactory := testutils.NewMockTokenStoreFactoryWithStore(tokenStore)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		err := testutils.ExecuteCliRunCommand(t, ctx, tokenStoreFactory, sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), serverTransport, "--disable-read-only")
+		assert.ErrorIs(t, err, context.Canceled, "server should stop due to context cancellation")
+	})
+
+	// Give the server a moment to start up.
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	assert.Contains(t, session.InitializeResult().Instructions, "safe mode")
+
+	toolsResult, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	toolNames := make([]string, len(toolsResult.Tools))
+	for i, tool := range toolsResult.Tools {
+		toolNames[i] = tool.Name
+	}
+	assert.Contains(t, toolNames, safemode.DiagnoseSafeModeDef.McpTool.Name)
+	assert.Contains(t, toolNames, environments.ListEnvironmentsDef.McpTool.Name)
+	assert.NotContains(t, toolNames, environments.CreateEnvironmentDef.McpTool.Name, "write tools should not be enabled in safe mode")
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: safemode.DiagnoseSafeModeDef.McpTool.Name})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	output := result.StructuredContent.(map[string]any)
+	assert.Equal(t, float64(3), output["abnormalExits"])
+
+	// Cancel the context to signal the server to shut down.
+	cancel()
+	wg.Wait()
+	assert.NoFileExists(t, markerFile, "a normal exit should end safe mode")
+}
+
+func TestRunCommand_FromSubcommand_SafeModeDisabled(t *testing.T) {
+	markerFile := filepath.Join(t.TempDir(), "crash_marker.json")
+	t.Setenv(safemode.MarkerFileEnvVar, markerFile)
+	require.NoError(t, os.WriteFile(markerFile, []byte(`{"abnormalExits":10}`), 0600))
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tokenStoreFactory := testutils.NewMockTokenStoreFactoryWithStore(testutils.NewInMemoryTokenStoreWithDefaultSession())
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		err := testutils.ExecuteCliRunCommand(t, ctx, tokenStoreFactory, sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), serverTransport, "--disable-read-only", "--safe-mode-threshold", "0")
+		assert.ErrorIs(t, err, context.Canceled, "server should stop due to context cancellation")
+	})
+
+	// Give the server a moment to start up.
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	toolsResult, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	toolNames := make([]string, len(toolsResult.Tools))
+	for i, tool := range toolsResult.Tools {
+		toolNames[i] = tool.Name
+	}
+	assert.NotContains(t, toolNames, safemode.DiagnoseSafeModeDef.McpTool.Name)
+	assert.Contains(t, toolNames, environments.CreateEnvironmentDef.McpTool.Name)
+
+	// Cancel the context to signal the server to shut down.
+	cancel()
+	wg.Wait()
+	assert.FileExists(t, markerFile, "the marker should not be touched when detection is disabled")
+}
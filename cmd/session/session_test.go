@@ -179,6 +179,12 @@ func TestSessionCommand_Direct_StoreTypeSelection(t *testing.T) {
 			expectedStoreType: tokenstore.StoreTypeFile,
 			description:       "Session should use file store type when specified",
 		},
+		{
+			name:              "encrypted file store type",
+			args:              []string{"--store-type", "encrypted_file"},
+			expectedStoreType: tokenstore.StoreTypeEncryptedFile,
+			description:       "Session should use encrypted file store type when specified",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,108 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/toolcatalog"
+	"github.com/spf13/cobra"
+)
+
+const commandName = "tools"
+
+const (
+	formatText = "text"
+	formatJson = "json"
+)
+
+// NewCommand creates the tools command, whose subcommands describe the tools the server can expose without
+// starting it.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   commandName,
+		Short: "Describe the tools the server can expose",
+		Long: `Describe the tools the PingOne MCP server can expose, with their annotations, validation policies and
+JSON schemas, for security reviews and generated documentation. No transport is started and PingOne is not called.`,
+	}
+	cmd.AddCommand(newListCommand(), newSchemaCommand())
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	const subcommandName = commandName + " list"
+	var collections []string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the tools, with their annotations and validation policies",
+		Long: `List every tool the server can expose, with its collection, whether it is read-only, the behavior its
+annotations declare, whether calls must be confirmed, and how calls on production environments are validated.
+Tools of the server collection are only registered when their feature is enabled. Use --format json for the
+descriptions, annotations, validation policies and schemas of the tools.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.InitCommandLogger(cmd, subcommandName)
+			logger.FromContext(cmd.Context()).Debug("Command invoked")
+
+			if format != formatText && format != formatJson {
+				return errs.NewCommandError(subcommandName, fmt.Errorf("unsupported format %q, must be %s or %s", format, formatText, formatJson))
+			}
+			catalog, err := toolcatalog.Filter(toolcatalog.List(), collections)
+			if err != nil {
+				return errs.NewCommandError(subcommandName, err)
+			}
+
+			if format == formatJson {
+				err = writeJson(cmd.OutOrStdout(), catalog)
+			} else {
+				err = toolcatalog.WriteText(cmd.OutOrStdout(), catalog)
+			}
+			if err != nil {
+				return errs.NewCommandError(subcommandName, err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&collections, "collections", []string{}, "A list of tool collections to list the tools of, including server for the tools of server features")
+	cmd.Flags().StringVar(&format, "format", formatText, "The output format (text or json)")
+
+	return cmd
+}
+
+func newSchemaCommand() *cobra.Command {
+	const subcommandName = commandName + " schema"
+
+	cmd := &cobra.Command{
+		Use:   "schema [tool...]",
+		Short: "Print the JSON input and output schemas of tools",
+		Long:  "Print the JSON schemas of the input and output of the named tools, or of every tool if none are named.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			logger.InitCommandLogger(cmd, subcommandName)
+			logger.FromContext(cmd.Context()).Debug("Command invoked")
+
+			schemas, err := toolcatalog.Schemas(toolcatalog.List(), args)
+			if err != nil {
+				return errs.NewCommandError(subcommandName, err)
+			}
+			if err := writeJson(cmd.OutOrStdout(), schemas); err != nil {
+				return errs.NewCommandError(subcommandName, err)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func writeJson(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
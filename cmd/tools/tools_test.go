@@ -0,0 +1,80 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tools_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/cmd/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/toolcatalog"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func executeToolsCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := tools.NewCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(context.Background())
+	return out.String(), err
+}
+
+func TestToolsListCommand_Text(t *testing.T) {
+	out, err := executeToolsCommand(t, "list")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "PRODUCTION ENVIRONMENTS")
+	assert.Contains(t, out, environments.ListEnvironmentsDef.McpTool.Name)
+	assert.Contains(t, out, populations.ListPopulationsDef.McpTool.Name)
+	assert.Contains(t, out, "Tools of the server collection are only registered when their feature is enabled")
+}
+
+func TestToolsListCommand_Json(t *testing.T) {
+	out, err := executeToolsCommand(t, "list", "--collections", environments.CollectionName, "--format", "json")
+	require.NoError(t, err)
+
+	var catalog []toolcatalog.Tool
+	require.NoError(t, json.Unmarshal([]byte(out), &catalog))
+	require.NotEmpty(t, catalog)
+	for _, tool := range catalog {
+		assert.Equal(t, environments.CollectionName, tool.Collection)
+		assert.NotEmpty(t, tool.Description)
+		assert.NotNil(t, tool.InputSchema)
+	}
+}
+
+func TestToolsListCommand_Errors(t *testing.T) {
+	_, err := executeToolsCommand(t, "list", "--format", "yaml")
+	assert.ErrorContains(t, err, "unsupported format")
+
+	_, err = executeToolsCommand(t, "list", "--collections", "unknown")
+	assert.ErrorContains(t, err, "unknown tool collection")
+}
+
+func TestToolsSchemaCommand(t *testing.T) {
+	out, err := executeToolsCommand(t, "schema", environments.ListEnvironmentsDef.McpTool.Name, populations.ListPopulationsDef.McpTool.Name)
+	require.NoError(t, err)
+
+	var schemas []struct {
+		Name         string         `json:"name"`
+		InputSchema  map[string]any `json:"inputSchema"`
+		OutputSchema map[string]any `json:"outputSchema"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &schemas))
+	require.Len(t, schemas, 2)
+	assert.Equal(t, environments.ListEnvironmentsDef.McpTool.Name, schemas[0].Name)
+	assert.Equal(t, "object", schemas[0].InputSchema["type"])
+	assert.Equal(t, "object", schemas[0].OutputSchema["type"])
+	assert.Equal(t, populations.ListPopulationsDef.McpTool.Name, schemas[1].Name)
+
+	_, err = executeToolsCommand(t, "schema", "unknown_tool")
+	assert.ErrorContains(t, err, `unknown tool "unknown_tool"`)
+}
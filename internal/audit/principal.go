@@ -0,0 +1,27 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"context"
+)
+
+const principalKey contextKey = "Principal"
+
+// ContextWithPrincipal returns a context recording who the PingOne session of a tool call acts as.
+func ContextWithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// PrincipalFromContext returns the principal of the context, or "" if it is not known.
+func PrincipalFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if v := ctx.Value(principalKey); v != nil {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
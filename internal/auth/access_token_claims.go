@@ -0,0 +1,46 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AccessTokenClaims are the PingOne access token claims describing the session
+type AccessTokenClaims struct {
+	Subject        string `json:"sub"`
+	ClientId       string `json:"client_id"`
+	EnvironmentId  string `json:"env"`
+	OrganizationId string `json:"org"`
+	Scope          string `json:"scope"`
+}
+
+// Principal returns who the session acts as: the authenticated user, or the client for client credentials.
+func (c *AccessTokenClaims) Principal() string {
+	if c.Subject != "" {
+		return c.Subject
+	}
+	return c.ClientId
+}
+
+// ParseAccessTokenClaims decodes the claims of a PingOne access token. The signature is not verified,
+// as the token was issued to this server and is only read to describe the session.
+func ParseAccessTokenClaims(accessToken string) (*AccessTokenClaims, error) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("session access token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode session access token claims: %w", err)
+	}
+	claims := &AccessTokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("failed to decode session access token claims: %w", err)
+	}
+	return claims, nil
+}
@@ -5,3 +5,8 @@ package client
 type AuthClientFactory interface {
 	NewAuthClient() (AuthClient, error)
 }
+
+// ScopesConfigurable is implemented by auth client factories whose clients can request pinned OAuth scopes
+type ScopesConfigurable interface {
+	SetScopes(scopes []string)
+}
@@ -11,5 +11,6 @@ import (
 
 type AuthClient interface {
 	TokenSource(ctx context.Context, grantType auth.GrantType) (oauth2.TokenSource, error)
+	RefreshTokenSource(ctx context.Context, grantType auth.GrantType, token *oauth2.Token) (oauth2.TokenSource, error)
 	BrowserLoginAvailable(grantType auth.GrantType) bool
 }
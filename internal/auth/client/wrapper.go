@@ -5,6 +5,8 @@ package client
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync"
 
 	"github.com/pingidentity/pingone-go-client/config"
 	pingoneOauth2 "github.com/pingidentity/pingone-go-client/oauth2"
@@ -12,16 +14,24 @@ import (
 	"github.com/pingidentity/pingone-go-client/utils/browser"
 	"github.com/pingidentity/pingone-mcp-server/internal/audit"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/scopes"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
 	"golang.org/x/oauth2"
 )
 
 var _ AuthClient = &PingOneClientAuthWrapper{}
 var _ AuthClientFactory = &PingOneClientAuthWrapperFactory{}
+var _ ScopesConfigurable = &PingOneClientAuthWrapperFactory{}
+var _ auth.ClientSettingsConfigurable = &PingOneClientAuthWrapperFactory{}
 
 type PingOneClientAuthWrapper struct {
 	serverVersion string
 	environmentId string
+	// scopes are requested instead of the scopes configured by environment variable, unless empty
+	scopes []string
+	// clientSettings are used instead of the root domain and client credentials configured by environment
+	// variable, unless nil
+	clientSettings *auth.ClientSettings
 }
 
 func NewPingOneClientAuthWrapper(serverVersion, environmentId string) *PingOneClientAuthWrapper {
@@ -34,12 +44,99 @@ func NewPingOneClientAuthWrapper(serverVersion, environmentId string) *PingOneCl
 func (p *PingOneClientAuthWrapper) TokenSource(ctx context.Context, grantType auth.GrantType) (oauth2.TokenSource, error) {
 	logger.FromContext(ctx).Debug("Creating token source from PingOne go client")
 
+	pingoneConfig, err := p.configuration(ctx, grantType)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenSource, err := pingoneConfig.Service.TokenSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.checkScopes(ctx, tokenSource), nil
+}
+
+// RefreshTokenSource returns a token source that refreshes the given token with its refresh token, using the
+// client configured in the environment for the grant type.
+func (p *PingOneClientAuthWrapper) RefreshTokenSource(ctx context.Context, grantType auth.GrantType, token *oauth2.Token) (oauth2.TokenSource, error) {
+	logger.FromContext(ctx).Debug("Creating refresh token source from PingOne go client")
+
+	pingoneConfig, err := p.configuration(ctx, grantType)
+	if err != nil {
+		return nil, err
+	}
+	serviceConfig := pingoneConfig.Service
+
+	var clientId *string
+	var scopes *[]string
+	switch grantType {
+	case auth.GrantTypeAuthorizationCode:
+		if serviceConfig.Auth.AuthorizationCode != nil {
+			clientId, scopes = serviceConfig.Auth.AuthorizationCode.AuthorizationCodeClientID, serviceConfig.Auth.AuthorizationCode.AuthorizationCodeScopes
+		}
+	case auth.GrantTypeDeviceCode:
+		if serviceConfig.Auth.DeviceCode != nil {
+			clientId, scopes = serviceConfig.Auth.DeviceCode.DeviceCodeClientID, serviceConfig.Auth.DeviceCode.DeviceCodeScopes
+		}
+	default:
+		return nil, fmt.Errorf("grant type %s does not use refresh tokens", grantType.String())
+	}
+	if clientId == nil || *clientId == "" {
+		return nil, fmt.Errorf("no client ID is configured to refresh tokens of grant type %s", grantType.String())
+	}
+
+	endpoints, err := serviceConfig.AuthEndpoints()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth endpoints: %w", err)
+	}
+	oauthConfig := &oauth2.Config{
+		ClientID: *clientId,
+		Endpoint: endpoints.Endpoint,
+	}
+	if scopes != nil {
+		oauthConfig.Scopes = *scopes
+	}
+
+	return p.checkScopes(ctx, oauthConfig.TokenSource(ctx, token)), nil
+}
+
+// checkScopes wraps the token source to warn when its tokens are granted more scopes than the pinned scopes
+func (p *PingOneClientAuthWrapper) checkScopes(ctx context.Context, tokenSource oauth2.TokenSource) oauth2.TokenSource {
+	if len(p.scopes) == 0 {
+		return tokenSource
+	}
+	return &scopeCheckingTokenSource{
+		ctx:       ctx,
+		base:      tokenSource,
+		requested: p.scopes,
+	}
+}
+
+// scopeCheckingTokenSource warns when the tokens of the base token source are granted scopes that were not requested
+type scopeCheckingTokenSource struct {
+	ctx       context.Context
+	base      oauth2.TokenSource
+	requested []string
+}
+
+func (s *scopeCheckingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.base.Token()
+	if err == nil && token != nil {
+		scopes.WarnExcess(s.ctx, token.AccessToken, s.requested)
+	}
+	return token, err
+}
+
+// configuration returns the PingOne go client configuration for the grant type, completed from environment variables
+func (p *PingOneClientAuthWrapper) configuration(ctx context.Context, grantType auth.GrantType) (*pingone.Configuration, error) {
 	var clientGrantType pingoneOauth2.GrantType
 	switch grantType {
 	case auth.GrantTypeAuthorizationCode:
 		clientGrantType = pingoneOauth2.GrantTypeAuthorizationCode
 	case auth.GrantTypeDeviceCode:
 		clientGrantType = pingoneOauth2.GrantTypeDeviceCode
+	case auth.GrantTypeClientCredentials:
+		clientGrantType = pingoneOauth2.GrantTypeClientCredentials
 	default:
 		return nil, fmt.Errorf("unsupported grant type for PingOne client auth wrapper: %s", grantType.String())
 	}
@@ -56,7 +153,43 @@ func (p *PingOneClientAuthWrapper) TokenSource(ctx context.Context, grantType au
 	pingoneConfig := pingone.NewConfiguration(clientConfig)
 	pingoneConfig.AppendUserAgent(audit.PingOneAPIUserAgent(p.serverVersion))
 
-	return pingoneConfig.Service.TokenSource(ctx)
+	// Client settings are set once the environment variables are loaded, so that they take precedence
+	if settings := p.clientSettings; settings != nil {
+		clientConfig.WithRootDomain(settings.RootDomain)
+		switch grantType {
+		case auth.GrantTypeAuthorizationCode:
+			clientConfig.WithAuthorizationCodeClientID(settings.AuthorizationCodeClientId)
+		case auth.GrantTypeDeviceCode:
+			clientConfig.WithDeviceCodeClientID(settings.DeviceCodeClientId)
+		case auth.GrantTypeClientCredentials:
+			clientConfig.WithClientCredentialsClientID(settings.ClientCredentialsClientId)
+			clientConfig.WithClientCredentialsClientSecret(settings.ClientCredentialsClientSecret)
+		}
+	}
+
+	// Pinned scopes are set once the environment variables are loaded, so that they take precedence
+	if len(p.scopes) > 0 {
+		pinnedScopes := slices.Clone(p.scopes)
+		switch grantType {
+		case auth.GrantTypeAuthorizationCode:
+			if clientConfig.Auth.AuthorizationCode == nil {
+				clientConfig.Auth.AuthorizationCode = &config.AuthorizationCode{}
+			}
+			clientConfig.Auth.AuthorizationCode.AuthorizationCodeScopes = &pinnedScopes
+		case auth.GrantTypeDeviceCode:
+			if clientConfig.Auth.DeviceCode == nil {
+				clientConfig.Auth.DeviceCode = &config.DeviceCode{}
+			}
+			clientConfig.Auth.DeviceCode.DeviceCodeScopes = &pinnedScopes
+		case auth.GrantTypeClientCredentials:
+			if clientConfig.Auth.ClientCredentials == nil {
+				clientConfig.Auth.ClientCredentials = &config.ClientCredentials{}
+			}
+			clientConfig.Auth.ClientCredentials.ClientCredentialsScopes = &pinnedScopes
+		}
+	}
+
+	return pingoneConfig, nil
 }
 
 func (p *PingOneClientAuthWrapper) BrowserLoginAvailable(grantType auth.GrantType) bool {
@@ -122,6 +255,14 @@ func (p *PingOneClientAuthWrapper) configureHeadlessHandlers(ctx context.Context
 				log.Info("Enter this code when prompted", "code", userCode)
 			}
 
+			// Also display the prompt to the user over the MCP connection where possible,
+			// as server logs are not visible to the user in most MCP clients
+			if prompt := auth.DeviceCodePromptFromContext(ctx); prompt != nil {
+				if err := prompt(ctx, verificationURI, fullURL, userCode); err != nil {
+					return err
+				}
+			}
+
 			log.Info("Waiting for authorization...")
 			return nil
 		}
@@ -173,8 +314,11 @@ func (p *PingOneClientAuthWrapper) configureHeadlessHandlers(ctx context.Context
 }
 
 type PingOneClientAuthWrapperFactory struct {
-	serverVersion string
-	environmentId string
+	mu                     sync.RWMutex
+	serverVersion          string
+	environmentId          string
+	scopes                 []string
+	clientSettingsProvider auth.ClientSettingsProvider
 }
 
 func NewPingOneClientAuthWrapperFactory(serverVersion, environmentId string) *PingOneClientAuthWrapperFactory {
@@ -185,5 +329,39 @@ func NewPingOneClientAuthWrapperFactory(serverVersion, environmentId string) *Pi
 }
 
 func (f *PingOneClientAuthWrapperFactory) NewAuthClient() (AuthClient, error) {
-	return NewPingOneClientAuthWrapper(f.serverVersion, f.environmentId), nil
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	authClient := NewPingOneClientAuthWrapper(f.serverVersion, f.environmentId)
+	authClient.scopes = f.scopes
+	if f.clientSettingsProvider != nil {
+		authClient.clientSettings = f.clientSettingsProvider.ClientSettings()
+	}
+	return authClient, nil
+}
+
+// SetEnvironmentId changes the environment that auth clients created by the factory log in to.
+func (f *PingOneClientAuthWrapperFactory) SetEnvironmentId(environmentId string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.environmentId = environmentId
+}
+
+// SetScopes pins the OAuth scopes that auth clients created by the factory request when logging in, instead of
+// the scopes configured by environment variable. Tokens granted more scopes than pinned are warned about.
+func (f *PingOneClientAuthWrapperFactory) SetScopes(scopes []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.scopes = slices.Clone(scopes)
+}
+
+// SetClientSettingsProvider makes auth clients created by the factory use the client settings of the provider
+// instead of the root domain and client credentials configured by environment variable.
+func (f *PingOneClientAuthWrapperFactory) SetClientSettingsProvider(provider auth.ClientSettingsProvider) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.clientSettingsProvider = provider
 }
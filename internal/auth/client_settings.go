@@ -0,0 +1,26 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auth
+
+// ClientSettings are the PingOne root domain and client credentials that PingOne clients use in place of the
+// environment variables the server was started with, such as those of the active profile. Settings that are empty
+// are not set, rather than left to the environment variables.
+type ClientSettings struct {
+	RootDomain                    string
+	AuthorizationCodeClientId     string
+	DeviceCodeClientId            string
+	ClientCredentialsClientId     string
+	ClientCredentialsClientSecret string
+}
+
+// ClientSettingsProvider provides the client settings that PingOne clients use when they are created.
+type ClientSettingsProvider interface {
+	// ClientSettings returns the client settings, or nil if clients use the environment variables.
+	ClientSettings() *ClientSettings
+}
+
+// ClientSettingsConfigurable is implemented by client factories whose clients can use the client settings of a
+// provider in place of environment variables.
+type ClientSettingsConfigurable interface {
+	SetClientSettingsProvider(provider ClientSettingsProvider)
+}
@@ -0,0 +1,23 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auth
+
+import "context"
+
+// DeviceCodePrompt displays the device authorization verification URL and user code to the user,
+// for example over the MCP connection when the server cannot open a browser.
+// Returning an error cancels the device authorization flow.
+type DeviceCodePrompt func(ctx context.Context, verificationURI, verificationURIComplete, userCode string) error
+
+type deviceCodePromptContextKey struct{}
+
+// ContextWithDeviceCodePrompt returns a context that displays device authorization prompts with the given prompt.
+func ContextWithDeviceCodePrompt(ctx context.Context, prompt DeviceCodePrompt) context.Context {
+	return context.WithValue(ctx, deviceCodePromptContextKey{}, prompt)
+}
+
+// DeviceCodePromptFromContext returns the device authorization prompt of the context, or nil if there is none.
+func DeviceCodePromptFromContext(ctx context.Context) DeviceCodePrompt {
+	prompt, _ := ctx.Value(deviceCodePromptContextKey{}).(DeviceCodePrompt)
+	return prompt
+}
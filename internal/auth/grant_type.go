@@ -10,6 +10,13 @@ const (
 	_ GrantType = iota
 	GrantTypeAuthorizationCode
 	GrantTypeDeviceCode
+	GrantTypeClientCredentials
+)
+
+// Environment variables read by the PingOne client SDK for the client credentials grant type.
+const (
+	ClientCredentialsClientIdEnvVar     = "PINGONE_CLIENT_CREDENTIALS_CLIENT_ID"
+	ClientCredentialsClientSecretEnvVar = "PINGONE_CLIENT_CREDENTIALS_CLIENT_SECRET"
 )
 
 func (g GrantType) String() string {
@@ -18,6 +25,8 @@ func (g GrantType) String() string {
 		return "authorization_code"
 	case GrantTypeDeviceCode:
 		return "device_code"
+	case GrantTypeClientCredentials:
+		return "client_credentials"
 	default:
 		return "unknown"
 	}
@@ -29,7 +38,16 @@ func ParseGrantType(s string) (GrantType, error) {
 		return GrantTypeAuthorizationCode, nil
 	case "device_code":
 		return GrantTypeDeviceCode, nil
+	case "client_credentials":
+		return GrantTypeClientCredentials, nil
 	default:
 		return 0, fmt.Errorf("unable to parse grant type from string: %s", s)
 	}
 }
+
+// IsInteractive returns true if the grant type requires a user to authorize the server.
+// Non-interactive grant types, such as client credentials for a worker application, can log in
+// without a browser or user.
+func (g GrantType) IsInteractive() bool {
+	return g != GrantTypeClientCredentials
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/logout"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"golang.org/x/oauth2"
 )
 
 const authTimeout = 5 * time.Minute
@@ -47,11 +48,22 @@ func login(ctx context.Context, authClient client.AuthClient, tokenStore tokenst
 			// Shouldn't happen
 			return nil, errors.New("token store indicated session exists but returned nil session")
 		}
-		if !forceReAuth && activeSession.Expiry.After(time.Now()) { //TODO handle token refresh
+		if !forceReAuth && activeSession.Expiry.After(time.Now()) {
 			// Session is still valid
 			logger.FromContext(ctx).Debug("An existing local auth session was found and is still valid", slog.String("sessionId", activeSession.SessionId), slog.String("expiry", activeSession.Expiry.Format(time.RFC3339)))
 			return activeSession, nil
 		}
+		if !forceReAuth && activeSession.RefreshToken != "" {
+			refreshedSession, err := RefreshSession(ctx, authClient, tokenStore, grantType, *activeSession)
+			if err == nil {
+				return refreshedSession, nil
+			}
+			if auth.IsInvalidGrant(err) {
+				logger.FromContext(ctx).Warn("The refresh token of the existing local auth session has expired or been revoked", slog.String("sessionId", activeSession.SessionId))
+			} else {
+				logger.FromContext(ctx).Warn("Failed to refresh the existing local auth session", slog.String("sessionId", activeSession.SessionId), slog.String("error", err.Error()))
+			}
+		}
 		logger.FromContext(ctx).Info("An existing local auth session was found. Logging out before re-authenticating", slog.String("sessionId", activeSession.SessionId))
 		err = logout.Logout(ctx, tokenStore)
 		if err != nil {
@@ -94,3 +106,75 @@ func login(ctx context.Context, authClient client.AuthClient, tokenStore tokenst
 
 	return &authSession, nil
 }
+
+// RefreshSession refreshes an expired auth session with its refresh token, storing the refreshed session in the
+// provided tokenStore under the same session ID. Errors for refresh tokens that have expired or been revoked
+// satisfy auth.IsInvalidGrant, and the session is left in the tokenStore for the caller to remove.
+func RefreshSession(ctx context.Context, authClient client.AuthClient, tokenStore tokenstore.TokenStore, grantType auth.GrantType, session auth.AuthSession) (*auth.AuthSession, error) {
+	logger.FromContext(ctx).Debug("Refreshing the local auth session", slog.String("sessionId", session.SessionId))
+	tokenSource, err := authClient.RefreshTokenSource(ctx, grantType, &oauth2.Token{
+		AccessToken:  session.AccessToken,
+		RefreshToken: session.RefreshToken,
+		Expiry:       session.Expiry,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tokenSource == nil {
+		return nil, errors.New("authClient returned nil refresh TokenSource")
+	}
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		// Should not happen
+		return nil, errors.New("refresh token source returned nil token")
+	}
+
+	refreshedSession := auth.NewAuthSession(*token, session.SessionId)
+	if err := tokenStore.PutSession(refreshedSession); err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Debug("Auth session refreshed", slog.String("sessionId", refreshedSession.SessionId), slog.String("expiry", refreshedSession.Expiry.Format(time.RFC3339)))
+
+	return &refreshedSession, nil
+}
+
+// RefreshSessionIfExpired refreshes the auth session in the provided tokenStore if it has expired, without user
+// interaction: with the session's refresh token, or with the client credentials grant type by logging in again.
+// It is used by work that runs outside tool calls, such as scheduled environment deletions, where the user cannot
+// be asked to log in. Sessions that cannot be refreshed this way return an error.
+func RefreshSessionIfExpired(ctx context.Context, authClient client.AuthClient, tokenStore tokenstore.TokenStore, grantType auth.GrantType) error {
+	hasSession, err := tokenStore.HasSession()
+	if err != nil {
+		return err
+	}
+	if !hasSession {
+		return errors.New("no active auth session found, log in again to run work outside tool calls")
+	}
+	session, err := tokenStore.GetSession()
+	if err != nil {
+		return err
+	}
+	if session == nil {
+		// Shouldn't happen
+		return errors.New("token store indicated session exists but returned nil session")
+	}
+	if session.Expiry.After(time.Now()) {
+		return nil
+	}
+
+	if grantType == auth.GrantTypeClientCredentials {
+		_, err := LoginIfNecessary(ctx, authClient, tokenStore, grantType)
+		return err
+	}
+	if session.RefreshToken == "" {
+		return fmt.Errorf("the auth session expired at %s and has no refresh token, log in again to run work outside tool calls", session.Expiry.Format(time.RFC3339))
+	}
+	if _, err := RefreshSession(ctx, authClient, tokenStore, grantType, *session); err != nil {
+		return fmt.Errorf("failed to refresh the auth session that expired at %s: %w", session.Expiry.Format(time.RFC3339), err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+)
+
+const deviceCodePromptLogger = "pingone-mcp-server"
+
+// NewMcpDeviceCodePrompt returns a device authorization prompt that displays the verification URL and
+// user code to the user of the MCP session. Clients that support URL elicitation are asked to open the
+// verification URL, otherwise the prompt is sent as a log message notification.
+func NewMcpDeviceCodePrompt(session *mcp.ServerSession) auth.DeviceCodePrompt {
+	return func(ctx context.Context, verificationURI, verificationURIComplete, userCode string) error {
+		if session == nil {
+			return nil
+		}
+
+		message := fmt.Sprintf("To authorize the PingOne MCP Server, open %s and enter the code %s", verificationURI, userCode)
+
+		if supportsURLElicitation(session) {
+			result, err := session.Elicit(ctx, &mcp.ElicitParams{
+				Mode:          "url",
+				Message:       message,
+				URL:           verificationURIComplete,
+				ElicitationID: uuid.NewString(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to display device authorization prompt: %w", err)
+			}
+			if result.Action != "accept" {
+				return errors.New("device authorization was declined by the user")
+			}
+			return nil
+		}
+
+		// Logging notifications are best effort, the prompt is also written to the server log
+		_ = session.Log(ctx, &mcp.LoggingMessageParams{
+			Level:  "notice",
+			Logger: deviceCodePromptLogger,
+			Data:   message,
+		})
+		return nil
+	}
+}
+
+func supportsURLElicitation(session *mcp.ServerSession) bool {
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil && params.Capabilities.Elicitation.URL != nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package middleware_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/middleware"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	authtestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/auth"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// TestAuthMiddleware_DeviceCodePromptOverMcp verifies that the device authorization prompt is
+// displayed to the user through URL elicitation, and that declining it fails the tool call
+func TestAuthMiddleware_DeviceCodePromptOverMcp(t *testing.T) {
+	testCases := []struct {
+		name          string
+		action        string
+		expectSuccess bool
+	}{
+		{name: "Prompt accepted", action: "accept", expectSuccess: true},
+		{name: "Prompt declined", action: "decline"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenSource := testutils.NewStaticTokenSource(&oauth2.Token{
+				AccessToken: "device-code-access-token",
+				Expiry:      time.Now().Add(time.Hour),
+			})
+
+			// The mock auth client displays the prompt, as the PingOne client does before polling for the token
+			var promptErr error
+			mockAuthClient := &authtestutils.MockAuthClient{}
+			mockAuthClient.On("TokenSource", mock.Anything, auth.GrantTypeDeviceCode).
+				Run(func(args mock.Arguments) {
+					ctx := args.Get(0).(context.Context)
+					prompt := auth.DeviceCodePromptFromContext(ctx)
+					require.NotNil(t, prompt)
+					promptErr = prompt(ctx, "https://auth.pingone.com/device", "https://auth.pingone.com/device?user_code=ABCD-EFGH", "ABCD-EFGH")
+				}).
+				Return(tokenSource, nil)
+			mockClientFactory := &authtestutils.MockAuthClientFactory{}
+			mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
+
+			server := mcptestutils.TestMcpServer(t)
+			server.AddReceivingMiddleware(middleware.NewAuthMiddleware(mockClientFactory, testutils.NewInMemoryTokenStore(), auth.GrantTypeDeviceCode).Handler)
+			mcp.AddTool(server, &mcp.Tool{Name: "list_populations"}, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+				if promptErr != nil {
+					return nil, nil, promptErr
+				}
+				return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil, nil
+			})
+
+			var elicitParams *mcp.ElicitParams
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-mcp-client", Version: "v0.0.1-test"}, &mcp.ClientOptions{
+				Capabilities: &mcp.ClientCapabilities{
+					Elicitation: &mcp.ElicitationCapabilities{URL: &mcp.URLElicitationCapabilities{}},
+				},
+				ElicitationHandler: func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+					elicitParams = req.Params
+					return &mcp.ElicitResult{Action: tc.action}, nil
+				},
+			})
+
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+			serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+			require.NoError(t, err)
+			defer serverSession.Close()
+			clientSession, err := client.Connect(t.Context(), clientTransport, nil)
+			require.NoError(t, err)
+			defer clientSession.Close()
+
+			result, err := clientSession.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_populations", Arguments: map[string]any{}})
+			require.NoError(t, err)
+
+			require.NotNil(t, elicitParams, "Expected the device authorization prompt to be elicited")
+			assert.Equal(t, "url", elicitParams.Mode)
+			assert.Equal(t, "https://auth.pingone.com/device?user_code=ABCD-EFGH", elicitParams.URL)
+			assert.Contains(t, elicitParams.Message, "ABCD-EFGH")
+
+			if tc.expectSuccess {
+				assert.False(t, result.IsError)
+			} else {
+				assert.True(t, result.IsError)
+				assert.ErrorContains(t, promptErr, "device authorization was declined")
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/login"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/initialize"
@@ -21,12 +22,16 @@ import (
 // This middleware should be added to the MCP server via AddReceivingMiddleware.
 // It runs the initializeAuthContext function to establish authentication, which may:
 // 1. Check for an existing session
-// 2. Trigger browser-based login if necessary
+// 2. Trigger browser-based or device authorization login if necessary
 // 3. Add session information to the context
+//
+// For the device authorization grant, the verification URL and user code are displayed to the user
+// over the MCP session, as the server may not be able to open a browser.
 type AuthMiddleware struct {
 	authClientFactory client.AuthClientFactory
 	tokenStore        tokenstore.TokenStore
 	grantType         auth.GrantType
+	skippedTools      map[string]bool
 }
 
 // NewAuthMiddleware creates middleware with auth dependencies.
@@ -42,9 +47,19 @@ func NewAuthMiddleware(
 		authClientFactory: authClientFactory,
 		tokenStore:        tokenStore,
 		grantType:         grantType,
+		skippedTools:      make(map[string]bool),
 	}
 }
 
+// SkipTools excludes tools from authentication, for tools that do not call PingOne APIs and must be
+// usable without a session, such as switching to another profile before logging in.
+func (m *AuthMiddleware) SkipTools(toolNames ...string) *AuthMiddleware {
+	for _, toolName := range toolNames {
+		m.skippedTools[toolName] = true
+	}
+	return m
+}
+
 // Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
 // This handler intercepts all MCP method calls and ensures tool calls have proper authentication context.
 func (m *AuthMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
@@ -63,6 +78,12 @@ func (m *AuthMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
 
 		toolName := callToolReq.Params.Name
 
+		if m.skippedTools[toolName] {
+			logger.FromContext(ctx).Debug("Skipping authentication for tool",
+				slog.String("tool", toolName))
+			return next(ctx, method, req)
+		}
+
 		logger.FromContext(ctx).Debug("Initializing authentication for tool",
 			slog.String("tool", toolName))
 
@@ -75,6 +96,11 @@ func (m *AuthMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
 			return nil, fmt.Errorf("authentication failed: failed to create auth client: %w", err)
 		}
 
+		// Display any device authorization prompt to the user of the MCP session
+		if m.grantType == auth.GrantTypeDeviceCode {
+			ctx = auth.ContextWithDeviceCodePrompt(ctx, NewMcpDeviceCodePrompt(callToolReq.Session))
+		}
+
 		// Initialize auth context
 		authenticatedCtx, err := initialize.InitializeAuthContext(ctx, authClient, m.tokenStore, m.grantType)
 		if err != nil {
@@ -87,6 +113,11 @@ func (m *AuthMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
 		logger.FromContext(ctx).Debug("Authentication initialized successfully",
 			slog.String("tool", toolName))
 
+		// Let work the tool defers beyond the call refresh the session before it runs
+		authenticatedCtx = auth.ContextWithSessionRefresher(authenticatedCtx, func(ctx context.Context) error {
+			return login.RefreshSessionIfExpired(ctx, authClient, m.tokenStore, m.grantType)
+		})
+
 		// Authentication successful, continue to next handler with authenticated context
 		return next(authenticatedCtx, method, req)
 	}
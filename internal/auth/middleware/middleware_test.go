@@ -80,6 +80,29 @@ func TestAuthMiddleware_NonToolCallPassThrough(t *testing.T) {
 	}
 }
 
+// TestAuthMiddleware_SkippedToolPassThrough verifies that skipped tools bypass authentication
+func TestAuthMiddleware_SkippedToolPassThrough(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	authClientFactory := authtestutils.NewEmptyMockAuthClientFactory()
+	middleware := middleware.NewAuthMiddleware(authClientFactory, tokenStore, auth.GrantTypeAuthorizationCode).SkipTools("switch_profile")
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name: "switch_profile",
+		},
+	}
+	nextHandler := &mockNextHandler{}
+	expectedResult := &mcp.CallToolResult{}
+	nextHandler.On("Handle", mock.Anything, "tools/call", req).Return(expectedResult, nil)
+
+	result, err := middleware.Handler(nextHandler.Handle)(context.Background(), "tools/call", req)
+
+	require.NoError(t, err)
+	assert.Equal(t, expectedResult, result)
+	nextHandler.AssertExpectations(t)
+	authClientFactory.AssertNotCalled(t, "NewAuthClient")
+}
+
 // TestAuthMiddleware_InvalidRequestType verifies error handling for invalid request types
 func TestAuthMiddleware_InvalidRequestType(t *testing.T) {
 	// Set up middleware
@@ -196,14 +219,34 @@ func TestAuthMiddleware_InitializeAuthContext(t *testing.T) {
 				return testutils.NewInMemoryTokenStoreWithDefaultSession()
 			},
 			setupAuthClient: func(grantType auth.GrantType) (*authtestutils.MockAuthClient, *authtestutils.MockAuthClientFactory) {
+				// Device authorization does not require a browser, so browser availability is not checked
 				mockAuthClient := &authtestutils.MockAuthClient{}
-				mockAuthClient.On("BrowserLoginAvailable", grantType).Return(false)
 				mockClientFactory := &authtestutils.MockAuthClientFactory{}
 				mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
 				return mockAuthClient, mockClientFactory
 			},
 			expectTokenSourceRetrieval: false,
 		},
+		{
+			name:      "Auto auth - client credentials without browser",
+			grantType: auth.GrantTypeClientCredentials,
+			setupTokenStore: func() *testutils.InMemoryTokenStore {
+				return testutils.NewInMemoryTokenStore()
+			},
+			setupAuthClient: func(grantType auth.GrantType) (*authtestutils.MockAuthClient, *authtestutils.MockAuthClientFactory) {
+				// Client credentials does not involve a user, so browser availability is not checked
+				clientCredentialsTokenSource := testutils.NewStaticTokenSource(&oauth2.Token{
+					AccessToken: "client-credentials-access-token",
+					Expiry:      time.Now().Add(time.Hour),
+				})
+				mockAuthClient := &authtestutils.MockAuthClient{}
+				mockAuthClient.On("TokenSource", mock.Anything, grantType).Return(clientCredentialsTokenSource, nil)
+				mockClientFactory := &authtestutils.MockAuthClientFactory{}
+				mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
+				return mockAuthClient, mockClientFactory
+			},
+			expectTokenSourceRetrieval: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -335,3 +378,199 @@ func TestAuthMiddleware_MultipleToolCalls(t *testing.T) {
 	mockAuthClient.AssertExpectations(t)
 	assert.Equal(t, len(tools), len(nextHandler.Calls))
 }
+
+// failingTokenSource fails to get a token, like a refresh rejected by PingOne
+type failingTokenSource struct {
+	err error
+}
+
+func (s *failingTokenSource) Token() (*oauth2.Token, error) {
+	return nil, s.err
+}
+
+var invalidGrantErr = &oauth2.RetrieveError{ErrorCode: "invalid_grant", ErrorDescription: "The refresh token is invalid"}
+
+// expiredSessionTokenStore returns a token store with an expired session that has a refresh token
+func expiredSessionTokenStore(t *testing.T) *testutils.InMemoryTokenStore {
+	t.Helper()
+	tokenStore := testutils.NewInMemoryTokenStore()
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		SessionId:    "expired-session-id",
+		AccessToken:  "expired-access-token",
+		RefreshToken: "stale-refresh-token",
+		Expiry:       time.Now().Add(-time.Minute),
+	}))
+	return tokenStore
+}
+
+func callListPopulations(t *testing.T, authMiddleware *middleware.AuthMiddleware) (mcp.Result, error) {
+	t.Helper()
+	nextHandler := &mockNextHandler{}
+	nextHandler.On("Handle", mock.Anything, "tools/call", mock.Anything).Return(&mcp.CallToolResult{}, nil)
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name: "list_populations",
+		},
+	}
+	return authMiddleware.Handler(nextHandler.Handle)(context.Background(), "tools/call", req)
+}
+
+// TestAuthMiddleware_RefreshesExpiredSession verifies that expired sessions are refreshed rather than logging in again
+func TestAuthMiddleware_RefreshesExpiredSession(t *testing.T) {
+	for _, browserLoginAvailable := range []bool{true, false} {
+		tokenStore := expiredSessionTokenStore(t)
+		refreshedTokenSource := testutils.NewStaticTokenSource(&oauth2.Token{
+			AccessToken:  "refreshed-access-token",
+			RefreshToken: "rotated-refresh-token",
+			Expiry:       time.Now().Add(time.Hour),
+		})
+		mockAuthClient := &authtestutils.MockAuthClient{}
+		mockAuthClient.On("BrowserLoginAvailable", auth.GrantTypeAuthorizationCode).Return(browserLoginAvailable)
+		mockAuthClient.On("RefreshTokenSource", mock.Anything, auth.GrantTypeAuthorizationCode, mock.MatchedBy(func(token *oauth2.Token) bool {
+			return token.RefreshToken == "stale-refresh-token"
+		})).Return(refreshedTokenSource, nil)
+		mockClientFactory := &authtestutils.MockAuthClientFactory{}
+		mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
+
+		_, err := callListPopulations(t, middleware.NewAuthMiddleware(mockClientFactory, tokenStore, auth.GrantTypeAuthorizationCode))
+
+		require.NoError(t, err)
+		session, err := tokenStore.GetSession()
+		require.NoError(t, err)
+		assert.Equal(t, "expired-session-id", session.SessionId, "the refreshed session keeps its ID")
+		assert.Equal(t, "refreshed-access-token", session.AccessToken)
+		assert.Equal(t, "rotated-refresh-token", session.RefreshToken)
+		mockAuthClient.AssertNotCalled(t, "TokenSource", mock.Anything, mock.Anything)
+	}
+}
+
+// TestAuthMiddleware_InvalidGrantLogsInAgain verifies that sessions whose refresh token has expired or been
+// revoked are replaced by a new login when one can be started
+func TestAuthMiddleware_InvalidGrantLogsInAgain(t *testing.T) {
+	tokenStore := expiredSessionTokenStore(t)
+	loginTokenSource := testutils.NewStaticTokenSource(&oauth2.Token{
+		AccessToken:  "new-access-token",
+		RefreshToken: "new-refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	})
+	mockAuthClient := &authtestutils.MockAuthClient{}
+	mockAuthClient.On("BrowserLoginAvailable", auth.GrantTypeAuthorizationCode).Return(true)
+	mockAuthClient.On("RefreshTokenSource", mock.Anything, auth.GrantTypeAuthorizationCode, mock.Anything).Return(&failingTokenSource{err: invalidGrantErr}, nil)
+	mockAuthClient.On("TokenSource", mock.Anything, auth.GrantTypeAuthorizationCode).Return(loginTokenSource, nil)
+	mockClientFactory := &authtestutils.MockAuthClientFactory{}
+	mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
+
+	_, err := callListPopulations(t, middleware.NewAuthMiddleware(mockClientFactory, tokenStore, auth.GrantTypeAuthorizationCode))
+
+	require.NoError(t, err)
+	session, err := tokenStore.GetSession()
+	require.NoError(t, err)
+	assert.NotEqual(t, "expired-session-id", session.SessionId)
+	assert.Equal(t, "new-access-token", session.AccessToken)
+	mockAuthClient.AssertExpectations(t)
+}
+
+// TestAuthMiddleware_InvalidGrantRequiresReauthentication verifies that sessions whose refresh token has expired
+// or been revoked are removed, and a re-authentication error is returned when a new login can't be started
+func TestAuthMiddleware_InvalidGrantRequiresReauthentication(t *testing.T) {
+	tokenStore := expiredSessionTokenStore(t)
+	mockAuthClient := &authtestutils.MockAuthClient{}
+	mockAuthClient.On("BrowserLoginAvailable", auth.GrantTypeAuthorizationCode).Return(false)
+	mockAuthClient.On("RefreshTokenSource", mock.Anything, auth.GrantTypeAuthorizationCode, mock.Anything).Return(&failingTokenSource{err: invalidGrantErr}, nil)
+	mockClientFactory := &authtestutils.MockAuthClientFactory{}
+	mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
+
+	result, err := callListPopulations(t, middleware.NewAuthMiddleware(mockClientFactory, tokenStore, auth.GrantTypeAuthorizationCode))
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	var reauthErr *auth.ReauthenticationRequiredError
+	require.ErrorAs(t, err, &reauthErr)
+	assert.True(t, auth.IsInvalidGrant(err))
+	assert.Contains(t, err.Error(), "re-authentication required")
+	assert.Contains(t, err.Error(), "Log in again with the login tool")
+	hasSession, err := tokenStore.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession, "the stale session is removed")
+	mockAuthClient.AssertNotCalled(t, "TokenSource", mock.Anything, mock.Anything)
+}
+
+// TestAuthMiddleware_ExpiredSessionWithoutRefreshToken verifies that expired sessions without a refresh token
+// require re-authentication when a new login can't be started, and are kept for the login tool to replace
+func TestAuthMiddleware_ExpiredSessionWithoutRefreshToken(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		SessionId:   "expired-session-id",
+		AccessToken: "expired-access-token",
+		Expiry:      time.Now().Add(-time.Minute),
+	}))
+	mockAuthClient := &authtestutils.MockAuthClient{}
+	mockAuthClient.On("BrowserLoginAvailable", auth.GrantTypeAuthorizationCode).Return(false)
+	mockClientFactory := &authtestutils.MockAuthClientFactory{}
+	mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
+
+	_, err := callListPopulations(t, middleware.NewAuthMiddleware(mockClientFactory, tokenStore, auth.GrantTypeAuthorizationCode))
+
+	var reauthErr *auth.ReauthenticationRequiredError
+	require.ErrorAs(t, err, &reauthErr)
+	assert.Contains(t, err.Error(), "the auth session has expired")
+	mockAuthClient.AssertNotCalled(t, "RefreshTokenSource", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAuthMiddleware_SessionRefresher verifies that tool calls can refresh the session after they return, for work
+// they defer such as scheduled environment deletions
+func TestAuthMiddleware_SessionRefresher(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStoreWithDefaultSession()
+	refreshedTokenSource := testutils.NewStaticTokenSource(&oauth2.Token{
+		AccessToken:  "refreshed-access-token",
+		RefreshToken: "rotated-refresh-token",
+		Expiry:       time.Now().Add(time.Hour),
+	})
+	mockAuthClient := &authtestutils.MockAuthClient{}
+	mockAuthClient.On("BrowserLoginAvailable", auth.GrantTypeAuthorizationCode).Return(false)
+	mockAuthClient.On("RefreshTokenSource", mock.Anything, auth.GrantTypeAuthorizationCode, mock.Anything).Return(refreshedTokenSource, nil)
+	mockClientFactory := &authtestutils.MockAuthClientFactory{}
+	mockClientFactory.On("NewAuthClient").Return(mockAuthClient, nil)
+	var toolCallCtx context.Context
+	nextHandler := &mockNextHandler{}
+	nextHandler.On("Handle", mock.Anything, "tools/call", mock.Anything).Run(func(args mock.Arguments) {
+		toolCallCtx = args.Get(0).(context.Context)
+	}).Return(&mcp.CallToolResult{}, nil)
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name: "schedule_environment_deletion",
+		},
+	}
+
+	_, err := middleware.NewAuthMiddleware(mockClientFactory, tokenStore, auth.GrantTypeAuthorizationCode).Handler(nextHandler.Handle)(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+	refreshSession := auth.SessionRefresherFromContext(toolCallCtx)
+	require.NotNil(t, refreshSession)
+
+	// A session that is still valid is left as it is
+	require.NoError(t, refreshSession(context.Background()))
+	mockAuthClient.AssertNotCalled(t, "RefreshTokenSource", mock.Anything, mock.Anything, mock.Anything)
+
+	// The session expires before the deferred work runs
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		SessionId:    "expired-session-id",
+		AccessToken:  "expired-access-token",
+		RefreshToken: "stale-refresh-token",
+		Expiry:       time.Now().Add(-time.Minute),
+	}))
+	require.NoError(t, refreshSession(context.Background()))
+	session, err := tokenStore.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed-access-token", session.AccessToken)
+
+	// Sessions that can only be replaced by logging in again are not refreshed
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		SessionId:   "expired-session-id",
+		AccessToken: "expired-access-token",
+		Expiry:      time.Now().Add(-time.Minute),
+	}))
+	err = refreshSession(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no refresh token")
+	mockAuthClient.AssertNotCalled(t, "TokenSource", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,31 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/oauth2"
+)
+
+// IsInvalidGrant returns true if the error is PingOne rejecting a grant with the invalid_grant error, such as
+// a refresh token that has expired or been revoked
+func IsInvalidGrant(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	return errors.As(err, &retrieveErr) && retrieveErr.ErrorCode == "invalid_grant"
+}
+
+// ReauthenticationRequiredError is returned when the stored session can no longer be used and the server
+// cannot start a new login itself, as a browser can't be opened for the authorization code grant.
+type ReauthenticationRequiredError struct {
+	Err error
+}
+
+func (e *ReauthenticationRequiredError) Error() string {
+	return fmt.Sprintf("re-authentication required: %v. Log in again with the login tool once a browser can be opened on this machine, or configure the server to use the device_code grant type, which does not need one", e.Err)
+}
+
+func (e *ReauthenticationRequiredError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package scopes pins the OAuth scopes that the server requests when logging in to PingOne, from scopes configured
+// for every login and for each toolset, and checks that issued access tokens are not granted more scopes than
+// requested, so that deployments can follow least privilege rather than relying on a broad admin scope.
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolsets"
+)
+
+// ParseToolsetScopes parses the scopes needed by toolsets in the form "<toolset>=<scope> <scope>", such as
+// "users=p1:read:user p1:update:user". Each toolset must exist, so that misconfiguration is reported when the
+// server starts rather than silently ignored. Scopes for the same toolset given more than once are combined.
+func ParseToolsetScopes(values []string) (map[string][]string, error) {
+	toolsetScopes := make(map[string][]string)
+	for _, value := range values {
+		toolsetName, scopeValue, found := strings.Cut(value, "=")
+		toolsetName = strings.TrimSpace(toolsetName)
+		scopes := strings.Fields(scopeValue)
+		if !found || toolsetName == "" || len(scopes) == 0 {
+			return nil, fmt.Errorf("invalid toolset scopes %q, expected format <toolset>=<scope> <scope>", value)
+		}
+		if _, err := toolsets.Get(toolsetName); err != nil {
+			return nil, fmt.Errorf("invalid toolset scopes: %w", err)
+		}
+
+		toolsetScopes[toolsetName] = appendUnique(toolsetScopes[toolsetName], scopes...)
+	}
+	return toolsetScopes, nil
+}
+
+// Requested returns the scopes to request when logging in: the login scopes, followed by the scopes of the
+// toolsets whose tools may be called. These are the enabled toolsets, or every toolset when tools are not
+// enabled by toolset or toolsets can be enabled while the server runs. Returns nil if no scopes are configured,
+// in which case the scopes configured by environment variable are requested.
+func Requested(loginScopes []string, toolsetScopes map[string][]string, enabledToolsets []string, dynamicToolsets bool) []string {
+	var requested []string
+	requested = appendUnique(requested, loginScopes...)
+	for _, toolset := range toolsets.List() {
+		if len(enabledToolsets) > 0 && !dynamicToolsets && !slices.Contains(enabledToolsets, toolset.Name) {
+			continue
+		}
+		requested = appendUnique(requested, toolsetScopes[toolset.Name]...)
+	}
+	return requested
+}
+
+// Excess returns the granted scopes that were not requested, in the order they were granted.
+func Excess(granted, requested []string) []string {
+	var excess []string
+	for _, scope := range granted {
+		if !slices.Contains(requested, scope) {
+			excess = append(excess, scope)
+		}
+	}
+	return excess
+}
+
+// WarnExcess logs a warning if the access token is granted scopes that were not requested. Nothing is checked if
+// no scopes were requested, or for tokens that are not JWTs, such as those of the mock backend.
+func WarnExcess(ctx context.Context, accessToken string, requested []string) {
+	if len(requested) == 0 {
+		return
+	}
+	claims, err := auth.ParseAccessTokenClaims(accessToken)
+	if err != nil {
+		return
+	}
+	excess := Excess(strings.Fields(claims.Scope), requested)
+	if len(excess) == 0 {
+		return
+	}
+	logger.FromContext(ctx).Warn("The PingOne access token is granted scopes that the server does not request. Review the scopes granted to the application in PingOne, or log in again if the requested scopes have changed since the session was created",
+		slog.Any("excessScopes", excess),
+		slog.Any("requestedScopes", requested))
+}
+
+// appendUnique appends the scopes that are not empty and not already in the list
+func appendUnique(list []string, scopes ...string) []string {
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope != "" && !slices.Contains(list, scope) {
+			list = append(list, scope)
+		}
+	}
+	return list
+}
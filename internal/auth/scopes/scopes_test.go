@@ -0,0 +1,170 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scopes_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"log/slog"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/scopes"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseToolsetScopes(t *testing.T) {
+	tests := []struct {
+		name            string
+		values          []string
+		expected        map[string][]string
+		wantErrContains string
+	}{
+		{
+			name:     "No values",
+			values:   nil,
+			expected: map[string][]string{},
+		},
+		{
+			name:   "Multiple toolsets",
+			values: []string{"users=p1:read:user  p1:update:user", " roles = p1:read:role "},
+			expected: map[string][]string{
+				"users": {"p1:read:user", "p1:update:user"},
+				"roles": {"p1:read:role"},
+			},
+		},
+		{
+			name:     "Scopes of the same toolset are combined",
+			values:   []string{"users=p1:read:user", "users=p1:read:user p1:update:user"},
+			expected: map[string][]string{"users": {"p1:read:user", "p1:update:user"}},
+		},
+		{
+			name:            "Missing separator",
+			values:          []string{"users"},
+			wantErrContains: `invalid toolset scopes "users", expected format <toolset>=<scope> <scope>`,
+		},
+		{
+			name:            "Missing scopes",
+			values:          []string{"users= "},
+			wantErrContains: "expected format <toolset>=<scope> <scope>",
+		},
+		{
+			name:            "Unknown toolset",
+			values:          []string{"groups=p1:read:group"},
+			wantErrContains: `unknown toolset "groups"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			toolsetScopes, err := scopes.ParseToolsetScopes(tt.values)
+
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, toolsetScopes)
+		})
+	}
+}
+
+func TestRequested(t *testing.T) {
+	toolsetScopes := map[string][]string{
+		"users": {"p1:read:user", "openid"},
+		"roles": {"p1:read:role"},
+	}
+
+	tests := []struct {
+		name            string
+		loginScopes     []string
+		enabledToolsets []string
+		dynamicToolsets bool
+		expected        []string
+	}{
+		{
+			name:            "Enabled toolsets",
+			loginScopes:     []string{"openid"},
+			enabledToolsets: []string{"users"},
+			expected:        []string{"openid", "p1:read:user"},
+		},
+		{
+			name:        "All toolsets when tools are not enabled by toolset",
+			loginScopes: []string{"openid"},
+			expected:    []string{"openid", "p1:read:user", "p1:read:role"},
+		},
+		{
+			name:            "All toolsets with dynamic toolsets",
+			enabledToolsets: []string{"users"},
+			dynamicToolsets: true,
+			expected:        []string{"p1:read:user", "openid", "p1:read:role"},
+		},
+		{
+			name:            "Enabled toolset without scopes",
+			enabledToolsets: []string{"davinci"},
+			expected:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, scopes.Requested(tt.loginScopes, toolsetScopes, tt.enabledToolsets, tt.dynamicToolsets))
+		})
+	}
+}
+
+func TestExcess(t *testing.T) {
+	assert.Equal(t, []string{"p1:update:user", "p1:delete:user"}, scopes.Excess([]string{"openid", "p1:update:user", "p1:read:user", "p1:delete:user"}, []string{"openid", "p1:read:user"}))
+	assert.Empty(t, scopes.Excess([]string{"openid"}, []string{"openid", "p1:read:user"}))
+}
+
+func TestWarnExcess(t *testing.T) {
+	accessToken := "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"scope":"openid p1:read:user p1:delete:user"}`)) + ".signature"
+
+	tests := []struct {
+		name        string
+		accessToken string
+		requested   []string
+		wantWarning bool
+	}{
+		{
+			name:        "Excess scopes",
+			accessToken: accessToken,
+			requested:   []string{"openid", "p1:read:user"},
+			wantWarning: true,
+		},
+		{
+			name:        "Requested scopes",
+			accessToken: accessToken,
+			requested:   []string{"openid", "p1:read:user", "p1:delete:user"},
+		},
+		{
+			name:        "No requested scopes",
+			accessToken: accessToken,
+		},
+		{
+			name:        "Token that is not a JWT",
+			accessToken: "opaque-access-token",
+			requested:   []string{"openid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ctx := logger.ContextWithLogger(context.Background(), slog.New(slog.NewJSONHandler(&buf, nil)))
+
+			scopes.WarnExcess(ctx, tt.accessToken, tt.requested)
+
+			if tt.wantWarning {
+				assert.Contains(t, buf.String(), `"level":"WARN"`)
+				assert.Contains(t, buf.String(), `"excessScopes":["p1:delete:user"]`)
+			} else {
+				assert.Empty(t, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auth
+
+import "context"
+
+// SessionRefresher refreshes the auth session if it has expired, without user interaction, so that work deferred
+// beyond the tool call that requested it, such as a scheduled environment deletion, does not use a stale token.
+type SessionRefresher func(ctx context.Context) error
+
+type sessionRefresherContextKey struct{}
+
+// ContextWithSessionRefresher returns a context that refreshes expired auth sessions with the given refresher.
+func ContextWithSessionRefresher(ctx context.Context, refresher SessionRefresher) context.Context {
+	return context.WithValue(ctx, sessionRefresherContextKey{}, refresher)
+}
+
+// SessionRefresherFromContext returns the session refresher of the context, or nil if there is none.
+func SessionRefresherFromContext(ctx context.Context) SessionRefresher {
+	refresher, _ := ctx.Value(sessionRefresherContextKey{}).(SessionRefresher)
+	return refresher
+}
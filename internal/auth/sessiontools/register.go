@@ -0,0 +1,44 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sessiontools
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// ListTools returns the session management tools. They manage the server's own auth session rather
+// than calling PingOne APIs, so they must be excluded from authentication.
+func ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		LoginDef,
+		LogoutDef,
+		WhoAmIDef,
+	}
+}
+
+// RegisterTools adds the session management tools allowed by the filter to the MCP server.
+func RegisterTools(ctx context.Context, server *mcp.Server, authClientFactory client.AuthClientFactory, tokenStore tokenstore.TokenStore, grantType auth.GrantType, toolFilter *filter.Filter) {
+	if toolFilter.ShouldIncludeTool(&LoginDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("tool", LoginDef.McpTool.Name))
+		mcp.AddTool(server, LoginDef.McpTool, LoginHandler(authClientFactory, tokenStore, grantType))
+	}
+
+	if toolFilter.ShouldIncludeTool(&LogoutDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("tool", LogoutDef.McpTool.Name))
+		mcp.AddTool(server, LogoutDef.McpTool, LogoutHandler(tokenStore))
+	}
+
+	if toolFilter.ShouldIncludeTool(&WhoAmIDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("tool", WhoAmIDef.McpTool.Name))
+		mcp.AddTool(server, WhoAmIDef.McpTool, WhoAmIHandler(tokenStore, grantType))
+	}
+}
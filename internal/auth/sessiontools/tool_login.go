@@ -0,0 +1,82 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sessiontools
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/login"
+	authmiddleware "github.com/pingidentity/pingone-mcp-server/internal/auth/middleware"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var LoginDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "login",
+		Title:        "Log In to PingOne",
+		Description:  "Start a new PingOne login, replacing any existing session, for example to log in as a different administrator or to pick up newly granted roles. Depending on the server configuration this opens a browser, or displays a device authorization URL and code to the user. Other tools log in automatically when there is no session, so this is only needed to force a new login.",
+		InputSchema:  schema.MustGenerateSchema[LoginInput](),
+		OutputSchema: schema.MustGenerateSchema[LoginOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+// LoginInput defines the input parameters for logging in
+type LoginInput struct{}
+
+// LoginOutput represents the new auth session
+type LoginOutput struct {
+	SessionId string `json:"sessionId" jsonschema:"The ID of the new session, recorded in PingOne audit events"`
+	GrantType string `json:"grantType" jsonschema:"The OAuth 2.0 grant type used to log in"`
+	Expiry    string `json:"expiry" jsonschema:"When the session's access token expires (RFC 3339)"`
+}
+
+// LoginHandler forces a new login using auth clients from the provided factory
+func LoginHandler(authClientFactory client.AuthClientFactory, tokenStore tokenstore.TokenStore, grantType auth.GrantType) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input LoginInput,
+) (
+	*mcp.CallToolResult,
+	*LoginOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input LoginInput) (*mcp.CallToolResult, *LoginOutput, error) {
+		authClient, err := authClientFactory.NewAuthClient()
+		if err != nil {
+			toolErr := errs.NewToolError(LoginDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Display any device authorization prompt to the user of the MCP session
+		if grantType == auth.GrantTypeDeviceCode && req != nil {
+			ctx = auth.ContextWithDeviceCodePrompt(ctx, authmiddleware.NewMcpDeviceCodePrompt(req.Session))
+		}
+
+		authSession, err := login.ForceLogin(ctx, authClient, tokenStore, grantType)
+		if err != nil {
+			toolErr := errs.NewToolError(LoginDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &LoginOutput{
+			SessionId: authSession.SessionId,
+			GrantType: grantType.String(),
+			Expiry:    authSession.Expiry.Format(time.RFC3339),
+		}, nil
+	}
+}
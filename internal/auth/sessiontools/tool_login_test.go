@@ -0,0 +1,65 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sessiontools_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/sessiontools"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	authtestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestLoginHandler_ReplacesExistingSession(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	tokenSource := testutils.NewStaticTokenSource(&oauth2.Token{AccessToken: "new-access-token", Expiry: expiry})
+	tokenStore := testutils.NewInMemoryTokenStoreWithDefaultSession()
+	handler := sessiontools.LoginHandler(authtestutils.NewMockAuthClientFactory(tokenSource), tokenStore, auth.GrantTypeAuthorizationCode)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.LoginInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotEqual(t, "default-session-id", output.SessionId)
+	assert.Equal(t, auth.GrantTypeAuthorizationCode.String(), output.GrantType)
+	assert.Equal(t, expiry.Format(time.RFC3339), output.Expiry)
+
+	session, err := tokenStore.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, output.SessionId, session.SessionId)
+	assert.Equal(t, "new-access-token", session.AccessToken)
+}
+
+func TestLoginHandler_AuthClientError(t *testing.T) {
+	authClientFactory := authtestutils.NewEmptyMockAuthClientFactory()
+	authClientFactory.On("NewAuthClient").Return(nil, errors.New("failed to create auth client"))
+	handler := sessiontools.LoginHandler(authClientFactory, testutils.NewInMemoryTokenStore(), auth.GrantTypeAuthorizationCode)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.LoginInput{})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "failed to create auth client")
+}
+
+func TestLoginHandler_TokenSourceError(t *testing.T) {
+	authClient := &authtestutils.MockAuthClient{}
+	authClient.On("TokenSource", mock.Anything, auth.GrantTypeDeviceCode).Return(nil, errors.New("device authorization was declined by the user"))
+	authClientFactory := authtestutils.NewEmptyMockAuthClientFactory()
+	authClientFactory.On("NewAuthClient").Return(authClient, nil)
+	tokenStore := testutils.NewInMemoryTokenStore()
+	handler := sessiontools.LoginHandler(authClientFactory, tokenStore, auth.GrantTypeDeviceCode)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.LoginInput{})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "device authorization was declined by the user")
+	hasSession, err := tokenStore.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession)
+}
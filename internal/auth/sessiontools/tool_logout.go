@@ -0,0 +1,68 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sessiontools
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/logout"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var LogoutDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "logout",
+		Title:        "Log Out of PingOne",
+		Description:  "Log out of PingOne by deleting the stored session. The next tool call that needs PingOne access logs in again.",
+		InputSchema:  schema.MustGenerateSchema[LogoutInput](),
+		OutputSchema: schema.MustGenerateSchema[LogoutOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+// LogoutInput defines the input parameters for logging out
+type LogoutInput struct{}
+
+// LogoutOutput represents the result of logging out
+type LogoutOutput struct {
+	LoggedOut bool `json:"loggedOut" jsonschema:"True if a session was deleted, false if there was no session"`
+}
+
+// LogoutHandler deletes the session in the provided token store
+func LogoutHandler(tokenStore tokenstore.TokenStore) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input LogoutInput,
+) (
+	*mcp.CallToolResult,
+	*LogoutOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input LogoutInput) (*mcp.CallToolResult, *LogoutOutput, error) {
+		hasSession, err := tokenStore.HasSession()
+		if err != nil {
+			toolErr := errs.NewToolError(LogoutDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if err := logout.Logout(ctx, tokenStore); err != nil {
+			toolErr := errs.NewToolError(LogoutDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &LogoutOutput{
+			LoggedOut: hasSession,
+		}, nil
+	}
+}
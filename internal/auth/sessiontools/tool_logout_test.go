@@ -0,0 +1,58 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sessiontools_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/sessiontools"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogoutHandler(t *testing.T) {
+	tests := []struct {
+		name              string
+		tokenStore        *testutils.InMemoryTokenStore
+		expectedLoggedOut bool
+	}{
+		{
+			name:              "Existing session is deleted",
+			tokenStore:        testutils.NewInMemoryTokenStoreWithDefaultSession(),
+			expectedLoggedOut: true,
+		},
+		{
+			name:              "No session",
+			tokenStore:        testutils.NewInMemoryTokenStore(),
+			expectedLoggedOut: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := sessiontools.LogoutHandler(tt.tokenStore)
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.LogoutInput{})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, tt.expectedLoggedOut, output.LoggedOut)
+			hasSession, err := tt.tokenStore.HasSession()
+			require.NoError(t, err)
+			assert.False(t, hasSession)
+		})
+	}
+}
+
+func TestLogoutHandler_DeleteSessionError(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStoreWithDefaultSession()
+	tokenStore.DeleteSessionError = errors.New("keychain is locked")
+	handler := sessiontools.LogoutHandler(tokenStore)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.LogoutInput{})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "keychain is locked")
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sessiontools
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var WhoAmIDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "whoami",
+		Title:        "Show PingOne Session",
+		Description:  "Show who the server is logged in to PingOne as: the authenticated user or client, the environment and organization it authenticated in, the granted scopes and when the session expires. Does not log in if there is no session.",
+		InputSchema:  schema.MustGenerateSchema[WhoAmIInput](),
+		OutputSchema: schema.MustGenerateSchema[WhoAmIOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+// WhoAmIInput defines the input parameters for showing the session
+type WhoAmIInput struct{}
+
+// WhoAmIOutput represents the current auth session
+type WhoAmIOutput struct {
+	Authenticated  bool     `json:"authenticated" jsonschema:"True if there is a session that has not expired"`
+	SessionId      string   `json:"sessionId,omitempty" jsonschema:"The ID of the session, recorded in PingOne audit events"`
+	GrantType      string   `json:"grantType" jsonschema:"The OAuth 2.0 grant type the server logs in with"`
+	Subject        string   `json:"subject,omitempty" jsonschema:"The ID of the authenticated user, or of the client for client credentials"`
+	ClientId       string   `json:"clientId,omitempty" jsonschema:"The ID of the client application that logged in"`
+	EnvironmentId  string   `json:"environmentId,omitempty" jsonschema:"The ID of the environment the session authenticated in"`
+	OrganizationId string   `json:"organizationId,omitempty" jsonschema:"The ID of the PingOne organization"`
+	Scopes         []string `json:"scopes,omitempty" jsonschema:"The scopes granted to the session"`
+	Expiry         string   `json:"expiry,omitempty" jsonschema:"When the session's access token expires (RFC 3339)"`
+	Expired        bool     `json:"expired" jsonschema:"True if the session has expired, in which case the next tool call logs in again"`
+}
+
+// WhoAmIHandler describes the session in the provided token store
+func WhoAmIHandler(tokenStore tokenstore.TokenStore, grantType auth.GrantType) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input WhoAmIInput,
+) (
+	*mcp.CallToolResult,
+	*WhoAmIOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input WhoAmIInput) (*mcp.CallToolResult, *WhoAmIOutput, error) {
+		result := &WhoAmIOutput{
+			GrantType: grantType.String(),
+		}
+
+		hasSession, err := tokenStore.HasSession()
+		if err != nil {
+			toolErr := errs.NewToolError(WhoAmIDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if !hasSession {
+			return nil, result, nil
+		}
+
+		authSession, err := tokenStore.GetSession()
+		if err != nil {
+			toolErr := errs.NewToolError(WhoAmIDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if authSession == nil {
+			// Should not happen as we checked HasSession above
+			toolErr := errs.NewToolError(WhoAmIDef.McpTool.Name, errors.New("token store indicated session exists but returned nil session"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		claims, err := auth.ParseAccessTokenClaims(authSession.AccessToken)
+		if err != nil {
+			toolErr := errs.NewToolError(WhoAmIDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		result.SessionId = authSession.SessionId
+		result.Expiry = authSession.Expiry.Format(time.RFC3339)
+		result.Expired = !authSession.Expiry.After(time.Now())
+		result.Authenticated = !result.Expired
+		result.Subject = claims.Subject
+		result.ClientId = claims.ClientId
+		result.EnvironmentId = claims.EnvironmentId
+		result.OrganizationId = claims.OrganizationId
+		result.Scopes = strings.Fields(claims.Scope)
+
+		return nil, result, nil
+	}
+}
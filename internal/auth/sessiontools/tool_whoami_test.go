@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sessiontools_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/sessiontools"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccessToken(claims string) string {
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func TestWhoAmIHandler(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	tokenStore := testutils.NewInMemoryTokenStore()
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		SessionId:   "session-1",
+		AccessToken: testAccessToken(`{"sub":"user-1","client_id":"client-1","env":"env-1","org":"org-1","scope":"openid p1:read:user"}`),
+		Expiry:      expiry,
+	}))
+	handler := sessiontools.WhoAmIHandler(tokenStore, auth.GrantTypeAuthorizationCode)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.WhoAmIInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, &sessiontools.WhoAmIOutput{
+		Authenticated:  true,
+		SessionId:      "session-1",
+		GrantType:      auth.GrantTypeAuthorizationCode.String(),
+		Subject:        "user-1",
+		ClientId:       "client-1",
+		EnvironmentId:  "env-1",
+		OrganizationId: "org-1",
+		Scopes:         []string{"openid", "p1:read:user"},
+		Expiry:         expiry.Format(time.RFC3339),
+	}, output)
+}
+
+func TestWhoAmIHandler_ExpiredSession(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		SessionId:   "session-1",
+		AccessToken: testAccessToken(`{"sub":"user-1"}`),
+		Expiry:      time.Now().Add(-time.Minute),
+	}))
+	handler := sessiontools.WhoAmIHandler(tokenStore, auth.GrantTypeAuthorizationCode)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.WhoAmIInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.Authenticated)
+	assert.True(t, output.Expired)
+	assert.Equal(t, "user-1", output.Subject)
+}
+
+func TestWhoAmIHandler_NoSession(t *testing.T) {
+	handler := sessiontools.WhoAmIHandler(testutils.NewInMemoryTokenStore(), auth.GrantTypeDeviceCode)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.WhoAmIInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, &sessiontools.WhoAmIOutput{GrantType: auth.GrantTypeDeviceCode.String()}, output)
+}
+
+func TestWhoAmIHandler_AccessTokenNotJWT(t *testing.T) {
+	handler := sessiontools.WhoAmIHandler(testutils.NewInMemoryTokenStoreWithDefaultSession(), auth.GrantTypeAuthorizationCode)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, sessiontools.WhoAmIInput{})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "session access token is not a JWT")
+}
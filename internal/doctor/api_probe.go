@@ -0,0 +1,45 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+)
+
+// APIProbe calls the management API with an access token, to check what the token can access.
+type APIProbe interface {
+	// CountEnvironments returns the number of environments the access token can read
+	CountEnvironments(ctx context.Context, accessToken string) (int, *http.Response, error)
+}
+
+var _ APIProbe = &LegacyAPIProbe{}
+
+// LegacyAPIProbe calls the management API with clients of the legacy SDK, which are used by most tools.
+type LegacyAPIProbe struct {
+	clientFactory legacy.ClientFactory
+}
+
+func NewLegacyAPIProbe(clientFactory legacy.ClientFactory) *LegacyAPIProbe {
+	return &LegacyAPIProbe{clientFactory: clientFactory}
+}
+
+func (p *LegacyAPIProbe) CountEnvironments(ctx context.Context, accessToken string) (int, *http.Response, error) {
+	client, err := p.clientFactory.NewClient(ctx, accessToken)
+	if err != nil {
+		return 0, nil, err
+	}
+	logger.FromContext(ctx).Debug("Calling PingOne API to count environments")
+	page, httpResponse, err := client.ManagementAPIClient.EnvironmentsApi.ReadAllEnvironments(ctx).Limit(1).ExecuteInitialPage()
+	if err != nil {
+		return 0, httpResponse, err
+	}
+	if page == nil || page.Count == nil {
+		return 0, httpResponse, errors.New("no count in response")
+	}
+	return int(*page.Count), httpResponse, nil
+}
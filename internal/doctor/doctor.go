@@ -0,0 +1,374 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package doctor diagnoses the configuration of the server, so that setup problems are reported with the action
+// that fixes them rather than surfacing as tool errors.
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+)
+
+type Status string
+
+const (
+	StatusPass    Status = "pass"
+	StatusWarn    Status = "warn"
+	StatusFail    Status = "fail"
+	StatusSkipped Status = "skipped"
+)
+
+// EnvironmentIdEnvVar is the environment variable of the ID of the environment the server authenticates with
+const EnvironmentIdEnvVar = "PINGONE_MCP_ENVIRONMENT_ID"
+
+const (
+	CheckRootDomain     = "Root domain"
+	CheckEnvironmentId  = "Environment ID"
+	CheckClient         = "Client"
+	CheckRegion         = "Region endpoints"
+	CheckAuthentication = "Authentication"
+	CheckAccessToken    = "Access token"
+	CheckApiAccess      = "API access"
+)
+
+// RootDomains are the root domains of the PingOne regions.
+var RootDomains = []string{"pingone.com", "pingone.eu", "pingone.asia", "pingone.com.au", "pingone.ca", "pingone.sg"}
+
+// Config describes the server configuration to diagnose.
+type Config struct {
+	// RootDomain is the PingOne root domain of the region, such as pingone.com.
+	RootDomain string
+	// EnvironmentId is the ID of the environment of the application the server authenticates with.
+	EnvironmentId string
+	// GrantType is the OAuth grant type used to log in to PingOne.
+	GrantType auth.GrantType
+}
+
+// Check is the result of one diagnostic check.
+type Check struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	// Remediation is the action that fixes a failed check or resolves a warning.
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// Report lists the results of the diagnostic checks, in the order they ran.
+type Report struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+	Checks      []Check   `json:"checks"`
+}
+
+// Resolver looks up the IP addresses of a host. It is implemented by net.Resolver.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Doctor runs the diagnostic checks. A nil resolver skips resolving the region endpoints.
+type Doctor struct {
+	resolver          Resolver
+	authClientFactory client.AuthClientFactory
+	tokenStore        tokenstore.TokenStore
+	apiProbe          APIProbe
+}
+
+func NewDoctor(resolver Resolver, authClientFactory client.AuthClientFactory, tokenStore tokenstore.TokenStore, apiProbe APIProbe) *Doctor {
+	return &Doctor{
+		resolver:          resolver,
+		authClientFactory: authClientFactory,
+		tokenStore:        tokenStore,
+		apiProbe:          apiProbe,
+	}
+}
+
+// Run checks the configuration, then the region endpoints, authentication and the access of the access token to
+// the management API. Checks that depend on an earlier check that failed are skipped.
+func (d *Doctor) Run(ctx context.Context, config Config) *Report {
+	report := &Report{GeneratedAt: time.Now().UTC()}
+
+	rootDomain := d.checkRootDomain(report, config.RootDomain)
+	configured := rootDomain != ""
+	configured = d.checkEnvironmentId(report, config.EnvironmentId) && configured
+	configured = d.checkClient(report, config.GrantType) && configured
+
+	regionResolved := true
+	if rootDomain == "" {
+		report.skip(CheckRegion, "the root domain check failed")
+	} else {
+		regionResolved = d.checkRegion(ctx, report, rootDomain)
+	}
+
+	if !configured || !regionResolved {
+		report.skip(CheckAuthentication, "the configuration or region checks failed")
+		report.skip(CheckAccessToken, "the server is not authenticated")
+		report.skip(CheckApiAccess, "the server is not authenticated")
+		return report
+	}
+
+	accessToken := d.checkAuthentication(ctx, report, config)
+	if accessToken == "" {
+		report.skip(CheckAccessToken, "the server is not authenticated")
+		report.skip(CheckApiAccess, "the server is not authenticated")
+		return report
+	}
+	d.checkAccessToken(report, accessToken, config.EnvironmentId)
+	d.checkApiAccess(ctx, report, accessToken, config)
+	return report
+}
+
+// Failed returns true if any check failed.
+func (r *Report) Failed() bool {
+	return slices.ContainsFunc(r.Checks, func(check Check) bool {
+		return check.Status == StatusFail
+	})
+}
+
+// WriteText writes the report as a table, followed by the remediation of each failed check and warning.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "PingOne MCP server diagnostics, generated %s\n\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintln(tw, "STATUS\tCHECK\tDETAIL")
+	for _, check := range r.Checks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", strings.ToUpper(string(check.Status)), check.Name, check.Detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	var remediations []string
+	for _, check := range r.Checks {
+		if check.Remediation != "" {
+			remediations = append(remediations, fmt.Sprintf("- %s: %s", check.Name, check.Remediation))
+		}
+	}
+	if len(remediations) > 0 {
+		fmt.Fprintln(w, "\nTo fix:")
+		fmt.Fprintln(w, strings.Join(remediations, "\n"))
+	}
+	return nil
+}
+
+func (r *Report) add(name string, status Status, detail string, remediation string) {
+	r.Checks = append(r.Checks, Check{Name: name, Status: status, Detail: detail, Remediation: remediation})
+}
+
+func (r *Report) skip(name string, reason string) {
+	r.add(name, StatusSkipped, "Skipped, as "+reason, "")
+}
+
+// checkRootDomain returns the normalized root domain, or empty if it is not the root domain of a region
+func (d *Doctor) checkRootDomain(report *Report, rootDomain string) string {
+	rootDomain = strings.ToLower(strings.TrimSpace(rootDomain))
+	if rootDomain == "" {
+		report.add(CheckRootDomain, StatusFail, "No PingOne root domain is configured",
+			fmt.Sprintf("Set the %s environment variable to the root domain of the region of your PingOne organization (%s)", profile.RootDomainEnvVar, strings.Join(RootDomains, ", ")))
+		return ""
+	}
+	if !slices.Contains(RootDomains, rootDomain) {
+		report.add(CheckRootDomain, StatusFail, fmt.Sprintf("%s is not the root domain of a PingOne region", rootDomain),
+			fmt.Sprintf("Set the %s environment variable to one of %s", profile.RootDomainEnvVar, strings.Join(RootDomains, ", ")))
+		return ""
+	}
+	report.add(CheckRootDomain, StatusPass, rootDomain, "")
+	return rootDomain
+}
+
+func (d *Doctor) checkEnvironmentId(report *Report, environmentId string) bool {
+	environmentId = strings.TrimSpace(environmentId)
+	if environmentId == "" {
+		report.add(CheckEnvironmentId, StatusFail, "No environment is configured to authenticate with",
+			fmt.Sprintf("Set the %s environment variable to the ID of the environment that contains the server's application", EnvironmentIdEnvVar))
+		return false
+	}
+	if _, err := uuid.Parse(environmentId); err != nil {
+		report.add(CheckEnvironmentId, StatusFail, fmt.Sprintf("%s is not a valid environment ID", environmentId),
+			fmt.Sprintf("Set the %s environment variable to the ID of the environment, which is a UUID shown on the environment's overview page in the PingOne admin console", EnvironmentIdEnvVar))
+		return false
+	}
+	report.add(CheckEnvironmentId, StatusPass, environmentId, "")
+	return true
+}
+
+func (d *Doctor) checkClient(report *Report, grantType auth.GrantType) bool {
+	var clientIdEnvVar string
+	switch grantType {
+	case auth.GrantTypeAuthorizationCode:
+		clientIdEnvVar = profile.AuthorizationCodeClientIdEnvVar
+	case auth.GrantTypeDeviceCode:
+		clientIdEnvVar = profile.DeviceCodeClientIdEnvVar
+	case auth.GrantTypeClientCredentials:
+		clientIdEnvVar = auth.ClientCredentialsClientIdEnvVar
+	default:
+		report.add(CheckClient, StatusFail, fmt.Sprintf("Unsupported grant type %s", grantType), "Use the authorization_code, device_code or client_credentials grant type")
+		return false
+	}
+
+	var missing []string
+	if strings.TrimSpace(os.Getenv(clientIdEnvVar)) == "" {
+		missing = append(missing, clientIdEnvVar)
+	}
+	if grantType == auth.GrantTypeClientCredentials && strings.TrimSpace(os.Getenv(auth.ClientCredentialsClientSecretEnvVar)) == "" {
+		missing = append(missing, auth.ClientCredentialsClientSecretEnvVar)
+	}
+	if len(missing) > 0 {
+		report.add(CheckClient, StatusFail, fmt.Sprintf("The %s grant type requires %s", grantType, strings.Join(missing, " and ")),
+			fmt.Sprintf("Set %s from the configuration of the server's application in the PingOne admin console", strings.Join(missing, " and ")))
+		return false
+	}
+	report.add(CheckClient, StatusPass, fmt.Sprintf("Client ID %s for the %s grant type", os.Getenv(clientIdEnvVar), grantType), "")
+	return true
+}
+
+// checkRegion resolves the authentication and API hosts of the region, returning false if either does not resolve
+func (d *Doctor) checkRegion(ctx context.Context, report *Report, rootDomain string) bool {
+	hosts := []string{"auth." + rootDomain, "api." + rootDomain}
+	if d.resolver == nil {
+		report.skip(CheckRegion, fmt.Sprintf("resolving %s is disabled", strings.Join(hosts, " and ")))
+		return true
+	}
+
+	var failures []string
+	for _, host := range hosts {
+		if _, err := d.resolver.LookupIPAddr(ctx, host); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", host, err))
+		}
+	}
+	if len(failures) > 0 {
+		report.add(CheckRegion, StatusFail, "Failed to resolve "+strings.Join(failures, "; "),
+			fmt.Sprintf("Check the %s environment variable and the DNS and proxy settings of this host. The network-report command lists the connections to allow through firewalls", profile.RootDomainEnvVar))
+		return false
+	}
+	report.add(CheckRegion, StatusPass, "Resolved "+strings.Join(hosts, " and "), "")
+	return true
+}
+
+// checkAuthentication returns an access token of the server, or empty if one could not be obtained.
+// Client credentials are exchanged for a new access token, while the interactive grant types use the stored
+// session, so that running the checks does not open a browser.
+func (d *Doctor) checkAuthentication(ctx context.Context, report *Report, config Config) string {
+	grantType := config.GrantType
+	if grantType.IsInteractive() {
+		return d.checkStoredSession(report, grantType)
+	}
+
+	authClient, err := d.authClientFactory.NewAuthClient()
+	if err != nil {
+		report.add(CheckAuthentication, StatusFail, fmt.Sprintf("Failed to create the auth client: %v", err), "")
+		return ""
+	}
+	accessToken, err := clientCredentialsAccessToken(ctx, authClient, grantType)
+	if err == nil {
+		report.add(CheckAuthentication, StatusPass, "Obtained an access token with client credentials", "")
+		return accessToken
+	}
+	report.add(CheckAuthentication, StatusFail, fmt.Sprintf("Failed to obtain an access token with client credentials: %v", err),
+		fmt.Sprintf("Check that the worker application with client ID %s exists in environment %s and is enabled, and that %s is its current client secret",
+			os.Getenv(auth.ClientCredentialsClientIdEnvVar), config.EnvironmentId, auth.ClientCredentialsClientSecretEnvVar))
+	return ""
+}
+
+func (d *Doctor) checkStoredSession(report *Report, grantType auth.GrantType) string {
+	loginRemediation := fmt.Sprintf("Start the server with the %s grant type and log in when prompted, then run the checks again", grantType)
+	if d.tokenStore == nil {
+		report.add(CheckAuthentication, StatusWarn, "No token store is available to check the login session", loginRemediation)
+		return ""
+	}
+	hasSession, err := d.tokenStore.HasSession()
+	if err != nil {
+		report.add(CheckAuthentication, StatusFail, fmt.Sprintf("Failed to read the login session from the token store: %v", err),
+			"Check the --store-type flag, and for the encrypted_file store the "+tokenstore.PassphraseEnvVar+" environment variable")
+		return ""
+	}
+	if !hasSession {
+		report.add(CheckAuthentication, StatusWarn, "No login session is stored, users are prompted to log in on the first tool call", loginRemediation)
+		return ""
+	}
+	session, err := d.tokenStore.GetSession()
+	if err != nil || session == nil {
+		report.add(CheckAuthentication, StatusFail, fmt.Sprintf("Failed to read the login session from the token store: %v", err), loginRemediation)
+		return ""
+	}
+	if session.Expiry.Before(time.Now()) {
+		report.add(CheckAuthentication, StatusWarn, fmt.Sprintf("The stored login session expired at %s", session.Expiry.Format(time.RFC3339)), loginRemediation)
+		return ""
+	}
+	report.add(CheckAuthentication, StatusPass, fmt.Sprintf("Stored login session is active until %s", session.Expiry.Format(time.RFC3339)), "")
+	return session.AccessToken
+}
+
+func (d *Doctor) checkAccessToken(report *Report, accessToken string, environmentId string) {
+	claims, err := auth.ParseAccessTokenClaims(accessToken)
+	if err != nil {
+		report.add(CheckAccessToken, StatusWarn, err.Error(), "")
+		return
+	}
+	detail := fmt.Sprintf("Issued to %s in environment %s", claims.Principal(), claims.EnvironmentId)
+	if claims.Scope != "" {
+		detail += " with scopes " + claims.Scope
+	}
+	if claims.EnvironmentId != "" && !strings.EqualFold(claims.EnvironmentId, strings.TrimSpace(environmentId)) {
+		report.add(CheckAccessToken, StatusWarn, detail,
+			fmt.Sprintf("The session was issued by another environment than %s, log in again to use the configured environment", EnvironmentIdEnvVar))
+		return
+	}
+	report.add(CheckAccessToken, StatusPass, detail, "")
+}
+
+// checkApiAccess lists the environments the access token can read, which requires a role assigned to the
+// worker application or user
+func (d *Doctor) checkApiAccess(ctx context.Context, report *Report, accessToken string, config Config) {
+	principal := "user"
+	if config.GrantType == auth.GrantTypeClientCredentials {
+		principal = "worker application"
+	}
+	roleRemediation := fmt.Sprintf("Assign the %s an administrator role, such as Environment Admin, Identity Data Admin or Configuration Read Only, in the environments the tools manage", principal)
+
+	count, httpResponse, err := d.apiProbe.CountEnvironments(ctx, accessToken)
+	statusCode := 0
+	if httpResponse != nil {
+		statusCode = httpResponse.StatusCode
+	}
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		report.add(CheckApiAccess, StatusFail, "The management API rejected the access token",
+			fmt.Sprintf("Check that %s is the root domain of the region of environment %s, and log in again", profile.RootDomainEnvVar, config.EnvironmentId))
+	case statusCode == http.StatusForbidden:
+		report.add(CheckApiAccess, StatusFail, "The management API denied reading environments", roleRemediation)
+	case err != nil:
+		report.add(CheckApiAccess, StatusFail, fmt.Sprintf("Failed to call the management API: %v", err), "")
+	case count == 0:
+		report.add(CheckApiAccess, StatusWarn, fmt.Sprintf("The %s cannot read any environment", principal), roleRemediation)
+	default:
+		report.add(CheckApiAccess, StatusPass, fmt.Sprintf("The %s can read %d environment(s)", principal, count), "")
+	}
+}
+
+func clientCredentialsAccessToken(ctx context.Context, authClient client.AuthClient, grantType auth.GrantType) (string, error) {
+	tokenSource, err := authClient.TokenSource(ctx, grantType)
+	if err != nil {
+		return "", err
+	}
+	token, err := tokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+	if token == nil || token.AccessToken == "" {
+		return "", errors.New("no access token was returned")
+	}
+	return token.AccessToken, nil
+}
@@ -0,0 +1,288 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package doctor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/doctor"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mockauth "github.com/pingidentity/pingone-mcp-server/internal/testutils/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const testEnvironmentId = "2f8b6a1e-3c4d-4e5f-8a9b-0c1d2e3f4a5b"
+
+type stubResolver map[string]bool
+
+func (r stubResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if !r[host] {
+		return nil, errors.New("no such host")
+	}
+	return []net.IPAddr{{IP: net.ParseIP("192.0.2.1")}}, nil
+}
+
+var euResolver = stubResolver{"auth.pingone.eu": true, "api.pingone.eu": true}
+
+type stubAPIProbe struct {
+	count      int
+	statusCode int
+	err        error
+	calls      int
+}
+
+func (p *stubAPIProbe) CountEnvironments(ctx context.Context, accessToken string) (int, *http.Response, error) {
+	p.calls++
+	return p.count, &http.Response{StatusCode: p.statusCode}, p.err
+}
+
+func testAccessToken(claims string) string {
+	return "header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func setClientCredentialsEnv(t *testing.T) {
+	t.Setenv(auth.ClientCredentialsClientIdEnvVar, "worker-client-id")
+	t.Setenv(auth.ClientCredentialsClientSecretEnvVar, "worker-secret")
+}
+
+func clientCredentialsConfig() doctor.Config {
+	return doctor.Config{RootDomain: "pingone.eu", EnvironmentId: testEnvironmentId, GrantType: auth.GrantTypeClientCredentials}
+}
+
+func clientCredentialsAuthFactory() *mockauth.MockAuthClientFactory {
+	return mockauth.NewMockAuthClientFactory(testutils.NewStaticTokenSource(&oauth2.Token{
+		AccessToken: testAccessToken(`{"client_id":"worker-client-id","env":"` + testEnvironmentId + `"}`),
+	}))
+}
+
+func statuses(report *doctor.Report) map[string]doctor.Status {
+	result := map[string]doctor.Status{}
+	for _, check := range report.Checks {
+		result[check.Name] = check.Status
+	}
+	return result
+}
+
+func findCheck(t *testing.T, report *doctor.Report, name string) doctor.Check {
+	t.Helper()
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	require.Failf(t, "check not found", "no %s check in the report", name)
+	return doctor.Check{}
+}
+
+func TestRun_ClientCredentialsPass(t *testing.T) {
+	setClientCredentialsEnv(t)
+	probe := &stubAPIProbe{count: 3, statusCode: http.StatusOK}
+
+	report := doctor.NewDoctor(euResolver, clientCredentialsAuthFactory(), nil, probe).Run(context.Background(), clientCredentialsConfig())
+
+	assert.False(t, report.Failed())
+	for _, check := range report.Checks {
+		assert.Equal(t, doctor.StatusPass, check.Status, check.Name)
+		assert.Empty(t, check.Remediation, check.Name)
+	}
+	assert.Len(t, report.Checks, 7)
+	assert.Contains(t, findCheck(t, report, doctor.CheckAccessToken).Detail, "worker-client-id")
+	assert.Equal(t, "The worker application can read 3 environment(s)", findCheck(t, report, doctor.CheckApiAccess).Detail)
+}
+
+func TestRun_ConfigurationFailures(t *testing.T) {
+	t.Setenv(auth.ClientCredentialsClientIdEnvVar, "worker-client-id")
+	t.Setenv(auth.ClientCredentialsClientSecretEnvVar, "")
+	probe := &stubAPIProbe{}
+
+	report := doctor.NewDoctor(euResolver, clientCredentialsAuthFactory(), nil, probe).Run(context.Background(), doctor.Config{
+		RootDomain:    "pingone.example",
+		EnvironmentId: "not-a-uuid",
+		GrantType:     auth.GrantTypeClientCredentials,
+	})
+
+	assert.True(t, report.Failed())
+	assert.Equal(t, map[string]doctor.Status{
+		doctor.CheckRootDomain:     doctor.StatusFail,
+		doctor.CheckEnvironmentId:  doctor.StatusFail,
+		doctor.CheckClient:         doctor.StatusFail,
+		doctor.CheckRegion:         doctor.StatusSkipped,
+		doctor.CheckAuthentication: doctor.StatusSkipped,
+		doctor.CheckAccessToken:    doctor.StatusSkipped,
+		doctor.CheckApiAccess:      doctor.StatusSkipped,
+	}, statuses(report))
+	assert.Contains(t, findCheck(t, report, doctor.CheckRootDomain).Remediation, profile.RootDomainEnvVar)
+	assert.Contains(t, findCheck(t, report, doctor.CheckEnvironmentId).Remediation, doctor.EnvironmentIdEnvVar)
+	assert.Contains(t, findCheck(t, report, doctor.CheckClient).Remediation, auth.ClientCredentialsClientSecretEnvVar)
+	assert.Zero(t, probe.calls)
+}
+
+func TestRun_RegionNotResolved(t *testing.T) {
+	setClientCredentialsEnv(t)
+
+	report := doctor.NewDoctor(stubResolver{"auth.pingone.eu": true}, clientCredentialsAuthFactory(), nil, &stubAPIProbe{}).Run(context.Background(), clientCredentialsConfig())
+
+	check := findCheck(t, report, doctor.CheckRegion)
+	assert.Equal(t, doctor.StatusFail, check.Status)
+	assert.Contains(t, check.Detail, "api.pingone.eu")
+	assert.NotContains(t, check.Detail, "auth.pingone.eu")
+	assert.Equal(t, doctor.StatusSkipped, findCheck(t, report, doctor.CheckAuthentication).Status)
+}
+
+func TestRun_NoResolver(t *testing.T) {
+	setClientCredentialsEnv(t)
+
+	report := doctor.NewDoctor(nil, clientCredentialsAuthFactory(), nil, &stubAPIProbe{count: 1, statusCode: http.StatusOK}).Run(context.Background(), clientCredentialsConfig())
+
+	assert.Equal(t, doctor.StatusSkipped, findCheck(t, report, doctor.CheckRegion).Status)
+	assert.Equal(t, doctor.StatusPass, findCheck(t, report, doctor.CheckApiAccess).Status)
+}
+
+func TestRun_ClientCredentialsRejected(t *testing.T) {
+	setClientCredentialsEnv(t)
+	authClient := &mockauth.MockAuthClient{}
+	authClient.On("TokenSource", mock.Anything, auth.GrantTypeClientCredentials).Return(nil, errors.New("invalid_client"))
+	authFactory := &mockauth.MockAuthClientFactory{}
+	authFactory.On("NewAuthClient").Return(authClient, nil)
+
+	report := doctor.NewDoctor(euResolver, authFactory, nil, &stubAPIProbe{}).Run(context.Background(), clientCredentialsConfig())
+
+	check := findCheck(t, report, doctor.CheckAuthentication)
+	assert.Equal(t, doctor.StatusFail, check.Status)
+	assert.Contains(t, check.Detail, "invalid_client")
+	assert.Contains(t, check.Remediation, "worker-client-id")
+	assert.Equal(t, doctor.StatusSkipped, findCheck(t, report, doctor.CheckApiAccess).Status)
+}
+
+func TestRun_ApiAccess(t *testing.T) {
+	tests := []struct {
+		name                string
+		probe               *stubAPIProbe
+		expectedStatus      doctor.Status
+		expectedRemediation string
+	}{
+		{
+			name:                "Token rejected",
+			probe:               &stubAPIProbe{statusCode: http.StatusUnauthorized, err: errors.New("401 Unauthorized")},
+			expectedStatus:      doctor.StatusFail,
+			expectedRemediation: profile.RootDomainEnvVar,
+		},
+		{
+			name:                "Forbidden",
+			probe:               &stubAPIProbe{statusCode: http.StatusForbidden, err: errors.New("403 Forbidden")},
+			expectedStatus:      doctor.StatusFail,
+			expectedRemediation: "Assign the worker application an administrator role",
+		},
+		{
+			name:                "No roles",
+			probe:               &stubAPIProbe{statusCode: http.StatusOK},
+			expectedStatus:      doctor.StatusWarn,
+			expectedRemediation: "Assign the worker application an administrator role",
+		},
+		{
+			name:           "Request failed",
+			probe:          &stubAPIProbe{err: errors.New("connection refused")},
+			expectedStatus: doctor.StatusFail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setClientCredentialsEnv(t)
+
+			report := doctor.NewDoctor(euResolver, clientCredentialsAuthFactory(), nil, tt.probe).Run(context.Background(), clientCredentialsConfig())
+
+			check := findCheck(t, report, doctor.CheckApiAccess)
+			assert.Equal(t, tt.expectedStatus, check.Status)
+			assert.Contains(t, check.Remediation, tt.expectedRemediation)
+			assert.Equal(t, tt.expectedStatus == doctor.StatusFail, report.Failed())
+		})
+	}
+}
+
+func TestRun_InteractiveSession(t *testing.T) {
+	t.Setenv(profile.AuthorizationCodeClientIdEnvVar, "admin-client-id")
+	config := doctor.Config{RootDomain: "pingone.eu", EnvironmentId: testEnvironmentId, GrantType: auth.GrantTypeAuthorizationCode}
+
+	tests := []struct {
+		name           string
+		session        *auth.AuthSession
+		expectedStatus doctor.Status
+		expectProbed   bool
+	}{
+		{
+			name:           "No session",
+			expectedStatus: doctor.StatusWarn,
+		},
+		{
+			name:           "Expired session",
+			session:        &auth.AuthSession{AccessToken: testAccessToken(`{"sub":"user-1"}`), Expiry: time.Now().Add(-time.Hour)},
+			expectedStatus: doctor.StatusWarn,
+		},
+		{
+			name:           "Active session",
+			session:        &auth.AuthSession{AccessToken: testAccessToken(`{"sub":"user-1","env":"` + testEnvironmentId + `"}`), Expiry: time.Now().Add(time.Hour)},
+			expectedStatus: doctor.StatusPass,
+			expectProbed:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenStore := testutils.NewInMemoryTokenStore()
+			if tt.session != nil {
+				require.NoError(t, tokenStore.PutSession(*tt.session))
+			}
+			probe := &stubAPIProbe{count: 1, statusCode: http.StatusOK}
+
+			report := doctor.NewDoctor(euResolver, mockauth.NewEmptyMockAuthClientFactory(), tokenStore, probe).Run(context.Background(), config)
+
+			assert.Equal(t, tt.expectedStatus, findCheck(t, report, doctor.CheckAuthentication).Status)
+			assert.False(t, report.Failed(), "a missing or expired session should not fail, as users log in when the server runs")
+			assert.Equal(t, tt.expectProbed, probe.calls > 0)
+		})
+	}
+}
+
+func TestRun_SessionFromOtherEnvironment(t *testing.T) {
+	t.Setenv(profile.DeviceCodeClientIdEnvVar, "admin-client-id")
+	tokenStore := testutils.NewInMemoryTokenStore()
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		AccessToken: testAccessToken(`{"sub":"user-1","env":"00000000-0000-0000-0000-000000000000"}`),
+		Expiry:      time.Now().Add(time.Hour),
+	}))
+
+	report := doctor.NewDoctor(euResolver, mockauth.NewEmptyMockAuthClientFactory(), tokenStore, &stubAPIProbe{count: 1, statusCode: http.StatusOK}).Run(context.Background(), doctor.Config{
+		RootDomain:    "pingone.eu",
+		EnvironmentId: testEnvironmentId,
+		GrantType:     auth.GrantTypeDeviceCode,
+	})
+
+	assert.Equal(t, doctor.StatusWarn, findCheck(t, report, doctor.CheckAccessToken).Status)
+}
+
+func TestReport_WriteText(t *testing.T) {
+	report := &doctor.Report{Checks: []doctor.Check{
+		{Name: doctor.CheckRootDomain, Status: doctor.StatusPass, Detail: "pingone.eu"},
+		{Name: doctor.CheckClient, Status: doctor.StatusFail, Detail: "Missing client ID", Remediation: "Set the client ID"},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+
+	assert.Contains(t, out.String(), "PASS")
+	assert.Contains(t, out.String(), "FAIL")
+	assert.Contains(t, out.String(), "To fix:\n- Client: Set the client ID")
+}
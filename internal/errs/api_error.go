@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 )
 
 // ApiError represents a structured API error with HTTP response details.
@@ -31,6 +32,8 @@ type ApiError struct {
 	URL string
 	// ResponseBody contains the raw response body from the API
 	ResponseBody string
+	// CorrelationId is the ID PingOne assigned to the request, which PingOne support can use to find it
+	CorrelationId string
 }
 
 func (e *ApiError) Error() string {
@@ -44,7 +47,10 @@ func (e *ApiError) Error() string {
 
 		originalErrorMsg = parsePingOneErrorMsg(e.OriginalError)
 
-		if originalErrorMsg != "" {
+		if errorResponse := parsePingOneErrorResponse(e.ResponseBody); errorResponse != nil && errorResponse.Message != "" {
+			// The response body has the details of errors of both SDKs, including the attributes they apply to
+			msg = formatPingOneErrorResponse(errorResponse)
+		} else if originalErrorMsg != "" {
 			msg = originalErrorMsg
 		} else if e.ResponseBody != "" {
 			// Append response body if available and not already parsed as a pingone error
@@ -61,6 +67,9 @@ func (e *ApiError) Error() string {
 		if e.Method != "" && e.URL != "" {
 			httpInfo = fmt.Sprintf("%s %s: %s", e.Method, e.URL, httpInfo)
 		}
+		if e.CorrelationId != "" {
+			httpInfo = fmt.Sprintf("%s, correlation ID %s", httpInfo, e.CorrelationId)
+		}
 
 		if msg != "" {
 			msg = fmt.Sprintf("%s (%s)", msg, httpInfo)
@@ -72,6 +81,24 @@ func (e *ApiError) Error() string {
 	return msg
 }
 
+// Details returns the details of the PingOne error response, such as the validation errors of the attributes of
+// the request, or nil if the response has no details
+func (e *ApiError) Details() []ErrorDetail {
+	if errorResponse := parsePingOneErrorResponse(e.ResponseBody); errorResponse != nil {
+		return errorResponse.Details
+	}
+	return nil
+}
+
+// PingOneErrorId returns the ID of the PingOne error response, which PingOne support can use to find the failed
+// request, or an empty string if the response is not a PingOne error
+func (e *ApiError) PingOneErrorId() string {
+	if errorResponse := parsePingOneErrorResponse(e.ResponseBody); errorResponse != nil {
+		return errorResponse.Id
+	}
+	return ""
+}
+
 func (e *ApiError) Unwrap() error {
 	if e == nil {
 		return nil
@@ -89,6 +116,7 @@ func NewApiError(httpResp *http.Response, err error) error {
 	if httpResp != nil {
 		apiErr.StatusCode = httpResp.StatusCode
 		apiErr.Status = httpResp.Status
+		apiErr.CorrelationId = httpResp.Header.Get(sdk.CorrelationIdHeader)
 
 		if httpResp.Request != nil {
 			apiErr.Method = httpResp.Request.Method
@@ -191,6 +219,9 @@ func formatErrorDetails(details []pingone.BadRequestErrorDetail) string {
 	for i, detail := range details {
 		var builder strings.Builder
 		builder.WriteString(fmt.Sprintf("Error detail %d: %s", i+1, detail.GetMessage()))
+		if detail.HasTarget() {
+			builder.WriteString(fmt.Sprintf(" (code: %s; target: %s)", detail.GetCode(), detail.GetTarget()))
+		}
 
 		if detail.HasInnerError() {
 			innerConditions := formatInnerErrorConditions(detail.GetInnerError())
@@ -13,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 )
 
 func TestApiError_Error(t *testing.T) {
@@ -24,6 +25,7 @@ func TestApiError_Error(t *testing.T) {
 		method        string
 		url           string
 		responseBody  string
+		correlationId string
 		expected      string
 	}{
 		{
@@ -56,6 +58,15 @@ func TestApiError_Error(t *testing.T) {
 			url:        "https://api.pingone.com/v1/environments",
 			expected:   "GET https://api.pingone.com/v1/environments: HTTP 500 Internal Server Error",
 		},
+		{
+			name:          "HTTP response with correlation ID",
+			statusCode:    500,
+			status:        "Internal Server Error",
+			method:        "GET",
+			url:           "https://api.pingone.com/v1/environments",
+			correlationId: "8c1a2b3d-correlation",
+			expected:      "GET https://api.pingone.com/v1/environments: HTTP 500 Internal Server Error, correlation ID 8c1a2b3d-correlation",
+		},
 		{
 			name:          "original error with HTTP response and response body",
 			originalError: errors.New("authentication failed"),
@@ -64,6 +75,24 @@ func TestApiError_Error(t *testing.T) {
 			responseBody:  "{\"error\":\"invalid_token\"}",
 			expected:      "Response body: {\"error\":\"invalid_token\"} (HTTP 401 Unauthorized)",
 		},
+		{
+			name:          "PingOne error response with details",
+			originalError: errors.New("400 Bad Request"),
+			statusCode:    400,
+			status:        "Bad Request",
+			method:        "POST",
+			url:           "https://api.pingone.com/v1/environments/env-id/applications",
+			responseBody:  `{"id":"error-id","code":"INVALID_DATA","message":"The request could not be completed. One or more validation errors were in the request.","details":[{"code":"INVALID_VALUE","target":"redirectUris","message":"Must be a valid URI","innerError":{"allowedPattern":"^https://"}}]}`,
+			expected:      "The request could not be completed. One or more validation errors were in the request. [Error detail 1: Must be a valid URI (code: INVALID_VALUE; target: redirectUris; allowedPattern: ^https://)] (POST https://api.pingone.com/v1/environments/env-id/applications: HTTP 400 Bad Request)",
+		},
+		{
+			name:          "PingOne error response without details",
+			originalError: errors.New("404 Not Found"),
+			statusCode:    404,
+			status:        "Not Found",
+			responseBody:  `{"id":"error-id","code":"NOT_FOUND","message":"The requested resource was not found."}`,
+			expected:      "The requested resource was not found. (HTTP 404 Not Found)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,6 +104,7 @@ func TestApiError_Error(t *testing.T) {
 				Method:        tt.method,
 				URL:           tt.url,
 				ResponseBody:  tt.responseBody,
+				CorrelationId: tt.correlationId,
 			}
 
 			result := apiErr.Error()
@@ -85,6 +115,35 @@ func TestApiError_Error(t *testing.T) {
 	}
 }
 
+func TestApiError_Details(t *testing.T) {
+	apiErr := &errs.ApiError{
+		StatusCode:   400,
+		ResponseBody: `{"id":"error-id","code":"INVALID_DATA","message":"Invalid data","details":[{"code":"REQUIRED_VALUE","target":"name","message":"Must be specified"},{"code":"SIZE_LIMIT_EXCEEDED","target":"description","message":"Too long","innerError":{"rangeMaximumValue":1024}}]}`,
+	}
+
+	details := apiErr.Details()
+	if len(details) != 2 {
+		t.Fatalf("Expected 2 details, got: %d", len(details))
+	}
+	if details[0].Code != "REQUIRED_VALUE" || details[0].Target != "name" || details[0].Message != "Must be specified" {
+		t.Errorf("Unexpected first detail: %+v", details[0])
+	}
+	if details[1].Target != "description" || details[1].InnerError["rangeMaximumValue"] != float64(1024) {
+		t.Errorf("Unexpected second detail: %+v", details[1])
+	}
+	if apiErr.PingOneErrorId() != "error-id" {
+		t.Errorf("Expected PingOne error ID %q, got: %q", "error-id", apiErr.PingOneErrorId())
+	}
+
+	notPingOneErr := &errs.ApiError{StatusCode: 400, ResponseBody: "not json"}
+	if notPingOneErr.Details() != nil {
+		t.Errorf("Expected no details, got: %+v", notPingOneErr.Details())
+	}
+	if notPingOneErr.PingOneErrorId() != "" {
+		t.Errorf("Expected no PingOne error ID, got: %q", notPingOneErr.PingOneErrorId())
+	}
+}
+
 func TestNewApiError(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -228,6 +287,23 @@ func TestNewApiError(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "HTTP response with correlation ID",
+			httpResp: &http.Response{
+				StatusCode: 404,
+				Status:     "Not Found",
+				Header:     http.Header{sdk.CorrelationIdHeader: {"8c1a2b3d-correlation"}},
+				Body:       io.NopCloser(bytes.NewBufferString("")),
+			},
+			originalErr:  nil,
+			expectedType: "*errs.ApiError",
+			checkFunc: func(t *testing.T, err error) {
+				apiErr := err.(*errs.ApiError)
+				if apiErr.CorrelationId != "8c1a2b3d-correlation" {
+					t.Errorf("Expected correlation ID '8c1a2b3d-correlation', got: %q", apiErr.CorrelationId)
+				}
+			},
+		},
 		{
 			name: "HTTP response with nil body",
 			httpResp: &http.Response{
@@ -0,0 +1,50 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package errs
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConflictError is returned when a resource has been changed since it was read, so that replacing it with a
+// state based on what was read would discard the other change
+type ConflictError struct {
+	// ResourceType describes the resource in the message, such as population
+	ResourceType string
+	ResourceId   string
+	// ExpectedUpdatedAt is when the resource was last updated as it was read, if known
+	ExpectedUpdatedAt *time.Time
+	// UpdatedAt is when the resource was last updated as it is now, if known
+	UpdatedAt *time.Time
+}
+
+func (e *ConflictError) Error() string {
+	msg := fmt.Sprintf("the %s %s has been changed since it was read", e.ResourceType, e.ResourceId)
+	if e.ExpectedUpdatedAt != nil && e.UpdatedAt != nil {
+		msg = fmt.Sprintf("%s: it was last updated at %s, not %s", msg, e.UpdatedAt.Format(time.RFC3339Nano), e.ExpectedUpdatedAt.Format(time.RFC3339Nano))
+	}
+	return msg
+}
+
+func NewConflictError(resourceType string, resourceId string, expectedUpdatedAt *time.Time, updatedAt *time.Time) error {
+	return &ConflictError{
+		ResourceType:      resourceType,
+		ResourceId:        resourceId,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+		UpdatedAt:         updatedAt,
+	}
+}
+
+// CheckNotChangedSince returns a ConflictError if a resource was last updated at another time than
+// lastKnownUpdatedAt, the update time the caller read with the resource. It returns an error without replacing
+// the resource if PingOne did not return when the resource was last updated, as the change cannot be checked.
+func CheckNotChangedSince(resourceType string, resourceId string, lastKnownUpdatedAt time.Time, updatedAt *time.Time) error {
+	if updatedAt == nil {
+		return fmt.Errorf("unable to check that the %s %s has not been changed since it was read, as PingOne did not return when it was last updated", resourceType, resourceId)
+	}
+	if !updatedAt.Equal(lastKnownUpdatedAt) {
+		return NewConflictError(resourceType, resourceId, &lastKnownUpdatedAt, updatedAt)
+	}
+	return nil
+}
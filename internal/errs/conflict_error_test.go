@@ -0,0 +1,74 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package errs_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+)
+
+func TestConflictError_Error(t *testing.T) {
+	readAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2025, 3, 1, 10, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name              string
+		expectedUpdatedAt *time.Time
+		updatedAt         *time.Time
+		expected          string
+	}{
+		{
+			name:     "update times unknown",
+			expected: "the bill of materials of environment env-1 has been changed since it was read",
+		},
+		{
+			name:              "update times known",
+			expectedUpdatedAt: &readAt,
+			updatedAt:         &updatedAt,
+			expected:          "the bill of materials of environment env-1 has been changed since it was read: it was last updated at 2025-03-01T10:05:00Z, not 2025-03-01T10:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := errs.NewConflictError("bill of materials of environment", "env-1", tt.expectedUpdatedAt, tt.updatedAt)
+			if err.Error() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, err.Error())
+			}
+		})
+	}
+}
+
+func TestCheckNotChangedSince(t *testing.T) {
+	readAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2025, 3, 1, 10, 5, 0, 0, time.UTC)
+
+	t.Run("unchanged", func(t *testing.T) {
+		sameInstant := readAt.In(time.FixedZone("CET", 3600))
+		if err := errs.CheckNotChangedSince("population", "pop-1", readAt, &sameInstant); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		err := errs.CheckNotChangedSince("population", "pop-1", readAt, &updatedAt)
+		var conflictErr *errs.ConflictError
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("Expected a ConflictError, got %v", err)
+		}
+		if !conflictErr.ExpectedUpdatedAt.Equal(readAt) || !conflictErr.UpdatedAt.Equal(updatedAt) {
+			t.Errorf("Expected update times %v and %v, got %v and %v", readAt, updatedAt, conflictErr.ExpectedUpdatedAt, conflictErr.UpdatedAt)
+		}
+	})
+
+	t.Run("update time unknown", func(t *testing.T) {
+		err := errs.CheckNotChangedSince("population", "pop-1", readAt, nil)
+		var conflictErr *errs.ConflictError
+		if err == nil || errors.As(err, &conflictErr) {
+			t.Errorf("Expected an error that is not a ConflictError, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package errs
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+// ErrorCode classifies a tool failure, so that agents can decide how to act on it without parsing the message
+type ErrorCode string
+
+const (
+	// ErrorCodeInvalidInput is returned when PingOne rejects the request as invalid
+	ErrorCodeInvalidInput ErrorCode = "INVALID_INPUT"
+	// ErrorCodeUnauthenticated is returned when PingOne rejects the access token of the session
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	// ErrorCodePermissionDenied is returned when the session is not permitted to perform the request
+	ErrorCodePermissionDenied ErrorCode = "PERMISSION_DENIED"
+	// ErrorCodeNotFound is returned when the requested resource does not exist
+	ErrorCodeNotFound ErrorCode = "NOT_FOUND"
+	// ErrorCodeRateLimited is returned when PingOne rate limits the request
+	ErrorCodeRateLimited ErrorCode = "RATE_LIMITED"
+	// ErrorCodeUnavailable is returned when PingOne is temporarily unable to handle the request
+	ErrorCodeUnavailable ErrorCode = "UNAVAILABLE"
+	// ErrorCodeConflict is returned when the resource has been changed since it was read, so that the change was
+	// not made
+	ErrorCodeConflict ErrorCode = "CONFLICT"
+	// ErrorCodeApiError is returned for other PingOne API errors
+	ErrorCodeApiError ErrorCode = "API_ERROR"
+	// ErrorCodeToolError is returned for failures that are not PingOne API errors
+	ErrorCodeToolError ErrorCode = "TOOL_ERROR"
+)
+
+// ErrorEnvelope is the machine-readable description of a tool failure, returned as the structured content of
+// failed tool calls
+type ErrorEnvelope struct {
+	Code             ErrorCode `json:"code" jsonschema:"The class of the failure, such as NOT_FOUND, PERMISSION_DENIED or RATE_LIMITED"`
+	Message          string    `json:"message" jsonschema:"The error message"`
+	PingOneRequestId string    `json:"pingoneRequestId,omitempty" jsonschema:"The ID of the PingOne error response, which PingOne support can use to find the failed request. Only set for PingOne API errors."`
+	CorrelationId    string    `json:"correlationId,omitempty" jsonschema:"The correlation ID of the failed PingOne API request, which PingOne support can use to find it. Only set for PingOne API errors whose response has one."`
+	HttpStatus       int       `json:"httpStatus,omitempty" jsonschema:"The HTTP status of the PingOne API response. Only set for PingOne API errors."`
+	Retryable        bool      `json:"retryable" jsonschema:"True if the same call may succeed when retried later"`
+	Remediation      string    `json:"remediation,omitempty" jsonschema:"A hint on how to resolve the failure"`
+	// Details are field-level, so that agents can correct the attributes PingOne rejected
+	Details []ErrorDetail `json:"details,omitempty" jsonschema:"The details of the PingOne error response, such as the attributes of the request that are invalid, with the conditions their values must meet. Only set for PingOne API errors with details."`
+}
+
+// NewErrorEnvelope describes the error, classifying it by the HTTP status of the PingOne API response it wraps
+func NewErrorEnvelope(err error) ErrorEnvelope {
+	envelope := ErrorEnvelope{
+		Code:    ErrorCodeToolError,
+		Message: err.Error(),
+	}
+
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		envelope.HttpStatus = apiErr.StatusCode
+		envelope.PingOneRequestId = apiErr.PingOneErrorId()
+		envelope.CorrelationId = apiErr.CorrelationId
+		envelope.Details = apiErr.Details()
+	}
+	var retriesExhaustedErr *sdk.RetriesExhaustedError
+	if envelope.HttpStatus == 0 && errors.As(err, &retriesExhaustedErr) {
+		envelope.HttpStatus = retriesExhaustedErr.StatusCode
+	}
+
+	var conflictErr *ConflictError
+	if errors.As(err, &conflictErr) {
+		envelope.Code = ErrorCodeConflict
+		envelope.Remediation = "The resource has been changed by someone else since it was read, and was not changed to avoid discarding their change. Read the resource again, check that the change is still wanted, and make it again from the current state."
+		return envelope
+	}
+
+	switch status := envelope.HttpStatus; {
+	case status == 0:
+		// Not a PingOne API error
+	case status == http.StatusUnauthorized:
+		envelope.Code = ErrorCodeUnauthenticated
+		envelope.Remediation = "The PingOne session is no longer valid. Call 'login' to log in again, then retry the call."
+	case status == http.StatusForbidden:
+		envelope.Code = ErrorCodePermissionDenied
+		envelope.Remediation = "The PingOne user or client of the session does not have a role that permits this request in the environment. Ask the user to have a role with the required permissions assigned, or use an environment the session has access to. Retrying will not help."
+	case status == http.StatusNotFound:
+		envelope.Code = ErrorCodeNotFound
+		envelope.Remediation = "Check that the IDs in the arguments are correct and belong to the environment, for example by listing the resources of the environment."
+	case status == http.StatusTooManyRequests:
+		envelope.Code = ErrorCodeRateLimited
+		envelope.Retryable = true
+		envelope.Remediation = "PingOne rate limited the request. Wait before retrying, and make fewer calls in parallel."
+	case status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout:
+		envelope.Code = ErrorCodeUnavailable
+		envelope.Retryable = true
+		envelope.Remediation = "PingOne is temporarily unable to handle the request. Retry the call later."
+	case status >= 400 && status < 500:
+		envelope.Code = ErrorCodeInvalidInput
+		envelope.Remediation = "Correct the arguments as described in the message and its details before retrying the call."
+	default:
+		envelope.Code = ErrorCodeApiError
+	}
+	return envelope
+}
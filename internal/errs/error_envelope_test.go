@@ -0,0 +1,175 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package errs_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+func apiErrorWithStatus(statusCode int, body string) error {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Status:     fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+	return errs.NewToolError("get_thing", errs.NewApiError(resp, errors.New("request failed")))
+}
+
+func TestNewErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		name              string
+		err               error
+		expectedCode      errs.ErrorCode
+		expectedStatus    int
+		expectedRetryable bool
+		expectedRequestId string
+		remediation       bool
+	}{
+		{
+			name:              "not found",
+			err:               apiErrorWithStatus(http.StatusNotFound, `{"id":"5c2b7a0e-0b1c-4e2f-9b9a-1f2e3d4c5b6a","code":"NOT_FOUND","message":"Unable to find resource"}`),
+			expectedCode:      errs.ErrorCodeNotFound,
+			expectedStatus:    http.StatusNotFound,
+			expectedRequestId: "5c2b7a0e-0b1c-4e2f-9b9a-1f2e3d4c5b6a",
+			remediation:       true,
+		},
+		{
+			name:           "unauthenticated",
+			err:            apiErrorWithStatus(http.StatusUnauthorized, ""),
+			expectedCode:   errs.ErrorCodeUnauthenticated,
+			expectedStatus: http.StatusUnauthorized,
+			remediation:    true,
+		},
+		{
+			name:           "permission denied",
+			err:            apiErrorWithStatus(http.StatusForbidden, `{"id":"forbidden-id","code":"ACCESS_FAILED"}`),
+			expectedCode:   errs.ErrorCodePermissionDenied,
+			expectedStatus: http.StatusForbidden,
+			// The id of any PingOne error response is returned
+			expectedRequestId: "forbidden-id",
+			remediation:       true,
+		},
+		{
+			name:           "invalid input",
+			err:            apiErrorWithStatus(http.StatusBadRequest, `not json`),
+			expectedCode:   errs.ErrorCodeInvalidInput,
+			expectedStatus: http.StatusBadRequest,
+			remediation:    true,
+		},
+		{
+			name:              "rate limited",
+			err:               apiErrorWithStatus(http.StatusTooManyRequests, ""),
+			expectedCode:      errs.ErrorCodeRateLimited,
+			expectedStatus:    http.StatusTooManyRequests,
+			expectedRetryable: true,
+			remediation:       true,
+		},
+		{
+			name:              "rate limited after retries",
+			err:               errs.NewToolError("get_thing", errs.NewApiError(nil, fmt.Errorf("request failed: %w", &sdk.RetriesExhaustedError{StatusCode: http.StatusTooManyRequests, Attempts: 6}))),
+			expectedCode:      errs.ErrorCodeRateLimited,
+			expectedStatus:    http.StatusTooManyRequests,
+			expectedRetryable: true,
+			remediation:       true,
+		},
+		{
+			name:              "unavailable",
+			err:               apiErrorWithStatus(http.StatusServiceUnavailable, ""),
+			expectedCode:      errs.ErrorCodeUnavailable,
+			expectedStatus:    http.StatusServiceUnavailable,
+			expectedRetryable: true,
+			remediation:       true,
+		},
+		{
+			name:           "internal server error",
+			err:            apiErrorWithStatus(http.StatusInternalServerError, ""),
+			expectedCode:   errs.ErrorCodeApiError,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:         "tool error",
+			err:          errs.NewToolError("get_thing", errors.New("no active auth session found")),
+			expectedCode: errs.ErrorCodeToolError,
+		},
+		{
+			name:         "conflict",
+			err:          errs.NewToolError("add_thing", errs.NewConflictError("thing", "thing-1", nil, nil)),
+			expectedCode: errs.ErrorCodeConflict,
+			remediation:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envelope := errs.NewErrorEnvelope(tt.err)
+
+			if envelope.Code != tt.expectedCode {
+				t.Errorf("Expected code %s, got %s", tt.expectedCode, envelope.Code)
+			}
+			if envelope.Message != tt.err.Error() {
+				t.Errorf("Expected message %q, got %q", tt.err.Error(), envelope.Message)
+			}
+			if envelope.HttpStatus != tt.expectedStatus {
+				t.Errorf("Expected HTTP status %d, got %d", tt.expectedStatus, envelope.HttpStatus)
+			}
+			if envelope.Retryable != tt.expectedRetryable {
+				t.Errorf("Expected retryable %t, got %t", tt.expectedRetryable, envelope.Retryable)
+			}
+			if envelope.PingOneRequestId != tt.expectedRequestId {
+				t.Errorf("Expected PingOne request ID %q, got %q", tt.expectedRequestId, envelope.PingOneRequestId)
+			}
+			if (envelope.Remediation != "") != tt.remediation {
+				t.Errorf("Expected remediation %t, got %q", tt.remediation, envelope.Remediation)
+			}
+		})
+	}
+}
+
+func TestNewErrorEnvelope_CorrelationId(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Status:     "500 Internal Server Error",
+		Header:     http.Header{sdk.CorrelationIdHeader: {"8c1a2b3d-correlation"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}
+
+	envelope := errs.NewErrorEnvelope(errs.NewToolError("get_thing", errs.NewApiError(resp, errors.New("request failed"))))
+
+	if envelope.CorrelationId != "8c1a2b3d-correlation" {
+		t.Errorf("Expected correlation ID %q, got %q", "8c1a2b3d-correlation", envelope.CorrelationId)
+	}
+	if !strings.Contains(envelope.Message, "correlation ID 8c1a2b3d-correlation") {
+		t.Errorf("Expected message to include the correlation ID, got %q", envelope.Message)
+	}
+}
+
+func TestNewErrorEnvelope_Details(t *testing.T) {
+	err := apiErrorWithStatus(http.StatusBadRequest, `{"id":"error-id","code":"INVALID_DATA","message":"Invalid data","details":[{"code":"INVALID_VALUE","target":"grantTypes","message":"Invalid grant type","innerError":{"allowedValues":["AUTHORIZATION_CODE","CLIENT_CREDENTIALS"]}}]}`)
+
+	envelope := errs.NewErrorEnvelope(err)
+
+	if envelope.Code != errs.ErrorCodeInvalidInput {
+		t.Errorf("Expected code %s, got %s", errs.ErrorCodeInvalidInput, envelope.Code)
+	}
+	if len(envelope.Details) != 1 {
+		t.Fatalf("Expected 1 detail, got %d", len(envelope.Details))
+	}
+	detail := envelope.Details[0]
+	if detail.Code != "INVALID_VALUE" || detail.Target != "grantTypes" || detail.Message != "Invalid grant type" {
+		t.Errorf("Unexpected detail: %+v", detail)
+	}
+	if _, ok := detail.InnerError["allowedValues"]; !ok {
+		t.Errorf("Expected inner error allowedValues, got %+v", detail.InnerError)
+	}
+	if !strings.Contains(envelope.Message, "target: grantTypes") {
+		t.Errorf("Expected message to name the target, got %q", envelope.Message)
+	}
+}
@@ -6,10 +6,44 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"sync"
 
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
 )
 
+type errorRecorderContextKey struct{}
+
+// ErrorRecorder records the errors logged with a context, so that the errors tool handlers report can be described
+// after the tool call, when only the error message is left in the tool result
+type ErrorRecorder struct {
+	mu     sync.Mutex
+	errors []error
+}
+
+// ContextWithErrorRecorder returns a context in which logged errors are recorded to the returned recorder
+func ContextWithErrorRecorder(ctx context.Context) (context.Context, *ErrorRecorder) {
+	recorder := &ErrorRecorder{}
+	return context.WithValue(ctx, errorRecorderContextKey{}, recorder), recorder
+}
+
+// Find returns the last recorded error with the message, or nil if no such error was recorded
+func (r *ErrorRecorder) Find(message string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.errors) - 1; i >= 0; i-- {
+		if r.errors[i].Error() == message {
+			return r.errors[i]
+		}
+	}
+	return nil
+}
+
+func (r *ErrorRecorder) record(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, err)
+}
+
 func Log(ctx context.Context, err error) {
 	LogWithLogger(logger.FromContext(ctx), ctx, err)
 }
@@ -18,6 +52,11 @@ func LogWithLogger(logger *slog.Logger, ctx context.Context, err error) {
 	if err == nil {
 		return
 	}
+	if ctx != nil {
+		if recorder, ok := ctx.Value(errorRecorderContextKey{}).(*ErrorRecorder); ok {
+			recorder.record(err)
+		}
+	}
 
 	var attrs []slog.Attr
 
@@ -136,3 +136,26 @@ func TestLogError_NilError(t *testing.T) {
 		t.Errorf("Expected no stderr output for nil error, got: %s", output)
 	}
 }
+
+func TestLog_RecordsErrorsToRecorder(t *testing.T) {
+	ctx, recorder := ContextWithErrorRecorder(context.Background())
+	firstErr := NewToolError("get_thing", errors.New("first"))
+	secondErr := NewToolError("get_thing", errors.New("second"))
+
+	var buf bytes.Buffer
+	testLogger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+	LogWithLogger(testLogger, ctx, firstErr)
+	LogWithLogger(testLogger, ctx, secondErr)
+
+	if found := recorder.Find(firstErr.Error()); found != firstErr {
+		t.Errorf("Expected to find the first error, got: %v", found)
+	}
+	if found := recorder.Find(secondErr.Error()); found != secondErr {
+		t.Errorf("Expected to find the second error, got: %v", found)
+	}
+	if found := recorder.Find("unknown"); found != nil {
+		t.Errorf("Expected no error for an unknown message, got: %v", found)
+	}
+}
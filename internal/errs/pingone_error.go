@@ -0,0 +1,85 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package errs
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrorDetail is a detail of a PingOne API error response, such as the validation error of an attribute of the
+// request
+type ErrorDetail struct {
+	Code       string         `json:"code,omitempty" jsonschema:"The PingOne code of the detail, such as INVALID_VALUE or REQUIRED_VALUE"`
+	Target     string         `json:"target,omitempty" jsonschema:"The attribute of the request the detail applies to, such as name or redirectUris"`
+	Message    string         `json:"message,omitempty" jsonschema:"The message of the detail"`
+	InnerError map[string]any `json:"innerError,omitempty" jsonschema:"The conditions the value of the attribute must meet, such as allowedValues, allowedPattern or rangeMaximumValue"`
+}
+
+// pingOneErrorResponse is the body of a PingOne API error response. The body is the same for both SDKs, so it is
+// parsed from the response body rather than from the error types of either SDK.
+type pingOneErrorResponse struct {
+	Id      string        `json:"id"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Details []ErrorDetail `json:"details"`
+}
+
+// parsePingOneErrorResponse parses a PingOne API error response body, returning nil if the body is not a PingOne
+// error
+func parsePingOneErrorResponse(responseBody string) *pingOneErrorResponse {
+	if responseBody == "" {
+		return nil
+	}
+	var errorResponse pingOneErrorResponse
+	if err := json.Unmarshal([]byte(responseBody), &errorResponse); err != nil {
+		return nil
+	}
+	if errorResponse.Id == "" && errorResponse.Code == "" && errorResponse.Message == "" {
+		return nil
+	}
+	return &errorResponse
+}
+
+// formatPingOneErrorResponse formats the message of a PingOne API error response with its details
+func formatPingOneErrorResponse(errorResponse *pingOneErrorResponse) string {
+	if len(errorResponse.Details) == 0 {
+		return errorResponse.Message
+	}
+	detailMessages := make([]string, 0, len(errorResponse.Details))
+	for i, detail := range errorResponse.Details {
+		detailMessages = append(detailMessages, formatErrorDetail(i, detail))
+	}
+	return errorResponse.Message + " [" + strings.Join(detailMessages, "], [") + "]"
+}
+
+// formatErrorDetail formats a detail with its code, target and inner error conditions, so that the attribute
+// to correct is named in the error message
+func formatErrorDetail(i int, detail ErrorDetail) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("Error detail %d: %s", i+1, detail.Message))
+
+	conditions := make([]string, 0, 2+len(detail.InnerError))
+	if detail.Code != "" {
+		conditions = append(conditions, fmt.Sprintf("code: %s", detail.Code))
+	}
+	if detail.Target != "" {
+		conditions = append(conditions, fmt.Sprintf("target: %s", detail.Target))
+	}
+	innerErrorKeys := make([]string, 0, len(detail.InnerError))
+	for key := range detail.InnerError {
+		innerErrorKeys = append(innerErrorKeys, key)
+	}
+	slices.Sort(innerErrorKeys)
+	for _, key := range innerErrorKeys {
+		conditions = append(conditions, fmt.Sprintf("%s: %v", key, detail.InnerError[key]))
+	}
+	if len(conditions) > 0 {
+		builder.WriteString(" (")
+		builder.WriteString(strings.Join(conditions, "; "))
+		builder.WriteString(")")
+	}
+	return builder.String()
+}
@@ -6,6 +6,8 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
 )
 
 const debugEnvVar = "PINGONE_MCP_DEBUG"
@@ -17,7 +19,10 @@ func init() {
 	envVarValue := os.Getenv(debugEnvVar)
 	debugEnabled := strings.EqualFold(envVarValue, "true")
 
-	handlerOptions := &slog.HandlerOptions{}
+	handlerOptions := &slog.HandlerOptions{
+		// Secrets must never reach the logs, even when debug logging is enabled
+		ReplaceAttr: redact.ReplaceAttr,
+	}
 	if debugEnabled {
 		handlerOptions.Level = slog.LevelDebug
 	}
@@ -0,0 +1,163 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package prompts publishes MCP prompts that guide clients through common PingOne administration workflows
+// with the server's tools.
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// PromptDefinition is a workflow prompt and the tools it guides the client to call
+type PromptDefinition struct {
+	Prompt *mcp.Prompt
+	// Tools are the names of the tools the workflow calls. The prompt is only published when all of them are
+	// enabled, so that clients are not guided to call tools they do not have.
+	Tools []string
+	// message is the text of the prompt message, rendered with the prompt arguments. Arguments that are not
+	// provided render as empty strings.
+	message *template.Template
+}
+
+func newPromptDefinition(prompt *mcp.Prompt, tools []string, message string) PromptDefinition {
+	return PromptDefinition{
+		Prompt:  prompt,
+		Tools:   tools,
+		message: template.Must(template.New(prompt.Name).Funcs(template.FuncMap{"contains": strings.Contains}).Option("missingkey=zero").Parse(message)),
+	}
+}
+
+var OnboardApplicationDef = newPromptDefinition(
+	&mcp.Prompt{
+		Name:        "onboard_application",
+		Title:       "Onboard an OIDC Application",
+		Description: "Create an OIDC application in an environment and check its configuration against the environment's OpenID Connect discovery document.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "environmentId", Title: "Environment ID", Description: "UUID of the environment to create the application in", Required: true},
+			{Name: "applicationName", Title: "Application Name", Description: "Name of the application", Required: true},
+			{Name: "applicationType", Title: "Application Type", Description: "Type of the OIDC application, such as WEB_APP, NATIVE_APP, SINGLE_PAGE_APP or WORKER. Defaults to WEB_APP."},
+			{Name: "redirectUris", Title: "Redirect URIs", Description: "Comma-separated redirect URIs of the application"},
+		},
+	},
+	[]string{"get_environment", "list_applications", "create_oidc_application", "get_oidc_discovery"},
+	`Onboard the OIDC application "{{.applicationName}}" in the PingOne environment {{.environmentId}}.
+
+1. Call get_environment with environmentId {{.environmentId}} to confirm the environment with me, including its name and type. Stop if it is not the environment I expect.
+2. Call list_applications with environmentId {{.environmentId}} and check that no application is already named "{{.applicationName}}". If one is, show it to me and ask whether to continue.
+3. Call create_oidc_application with environmentId {{.environmentId}} and an application named "{{.applicationName}}" of type {{if .applicationType}}{{.applicationType}}{{else}}WEB_APP{{end}}, enabled, with the grant types, response types and token endpoint authentication method suited to that type.{{if .redirectUris}} Set its redirect URIs to: {{.redirectUris}}.{{else}} Ask me for its redirect URIs first if the application type needs them.{{end}} Show me the configuration before creating it.
+4. Call get_oidc_discovery with environmentId {{.environmentId}} and the ID of the created application, and report any checks that fail.
+
+Finish with the application ID, client ID and the issuer, authorization and token endpoints the application should use. Do not show the client secret.`,
+)
+
+var OffboardUserDef = newPromptDefinition(
+	&mcp.Prompt{
+		Name:        "offboard_user",
+		Title:       "Offboard a User",
+		Description: "Find a user, remove the roles assigned to them and disable them, then check the audit trail of the changes.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "environmentId", Title: "Environment ID", Description: "UUID of the environment of the user", Required: true},
+			{Name: "user", Title: "User", Description: "Username or email address of the user", Required: true},
+		},
+	},
+	[]string{"find_user", "list_user_role_assignments", "remove_role_assignment", "set_user_enabled", "query_audit_events"},
+	`Offboard the user "{{.user}}" in the PingOne environment {{.environmentId}}.
+
+1. Call find_user with environmentId {{.environmentId}} and "{{.user}}" as the {{if contains .user "@"}}email{{else}}username{{end}}. Stop if no user or more than one user is found, and confirm the user's name, username and population with me before changing anything.
+2. Call list_user_role_assignments with environmentId {{.environmentId}} and the user's ID, and show me the roles assigned to the user.
+3. Once I confirm, call remove_role_assignment with subjectType USER and the user's ID for each role assignment.
+4. Call set_user_enabled with environmentId {{.environmentId}}, the user's ID and enabled false, so that the user can no longer sign on.
+5. Call query_audit_events with environmentId {{.environmentId}} and a startTime of one hour ago, and check that the role removals and the change to the user are recorded.
+
+This server cannot delete users, so the user is disabled rather than deleted. Finish with a summary of the roles removed and the state of the user.`,
+)
+
+var SetUpMfaForPopulationDef = newPromptDefinition(
+	&mcp.Prompt{
+		Name:        "set_up_mfa_for_population",
+		Title:       "Set Up MFA for a Population",
+		Description: "Check that PingOne MFA is enabled in an environment and enable it if needed, find the population, and report the MFA sign-ons in the environment.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "environmentId", Title: "Environment ID", Description: "UUID of the environment of the population", Required: true},
+			{Name: "populationName", Title: "Population Name", Description: "Name of the population whose users should sign on with MFA", Required: true},
+		},
+	},
+	[]string{"get_environment_services", "add_environment_service", "list_populations", "get_population", "get_mfa_sign_on_metrics"},
+	`Set up MFA for the users of the population "{{.populationName}}" in the PingOne environment {{.environmentId}}.
+
+1. Call list_populations with environmentId {{.environmentId}} and the filter name sw "{{.populationName}}", then call get_population with the ID of the population named exactly "{{.populationName}}". Stop if there is no such population, and confirm its name and user count with me.
+2. Call get_environment_services with environmentId {{.environmentId}} and check whether the PING_ONE_MFA service is enabled.
+3. If it is not, and once I confirm, call add_environment_service with environmentId {{.environmentId}} and a PING_ONE_MFA service.
+4. Call get_mfa_sign_on_metrics with environmentId {{.environmentId}} and a startTime 7 days ago, and report how many sign-ons used MFA.
+
+This server cannot create sign-on policies or MFA policies. Finish by telling me to add an MFA step to the sign-on policy of the applications the population's users sign on to in the PingOne admin console, and to run get_mfa_sign_on_metrics again afterwards to check that their sign-ons use MFA.`,
+)
+
+// ListPrompts returns the definitions of all workflow prompts
+func ListPrompts() []PromptDefinition {
+	return []PromptDefinition{
+		OnboardApplicationDef,
+		OffboardUserDef,
+		SetUpMfaForPopulationDef,
+	}
+}
+
+// RegisterPrompts adds the workflow prompts whose tools are all in enabledTools to the server
+func RegisterPrompts(ctx context.Context, server *mcp.Server, enabledTools []string) {
+	for _, promptDef := range ListPrompts() {
+		if !promptDef.toolsEnabled(enabledTools) {
+			logger.FromContext(ctx).Debug("Skipping prompt, as not all of its tools are enabled", slog.String("prompt", promptDef.Prompt.Name))
+			continue
+		}
+		server.AddPrompt(promptDef.Prompt, promptDef.Handler)
+		logger.FromContext(ctx).Debug("Registered prompt", slog.String("prompt", promptDef.Prompt.Name))
+	}
+}
+
+// Handler returns the prompt message rendered with the arguments of the request
+func (d PromptDefinition) Handler(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	arguments := req.Params.Arguments
+	if arguments == nil {
+		arguments = map[string]string{}
+	}
+	var missing []string
+	for _, argument := range d.Prompt.Arguments {
+		if argument.Required && strings.TrimSpace(arguments[argument.Name]) == "" {
+			missing = append(missing, argument.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("prompt '%s' is missing required arguments: %s", d.Prompt.Name, strings.Join(missing, ", "))
+	}
+
+	var message strings.Builder
+	if err := d.message.Execute(&message, arguments); err != nil {
+		return nil, fmt.Errorf("failed to render prompt '%s': %w", d.Prompt.Name, err)
+	}
+	return &mcp.GetPromptResult{
+		Description: d.Prompt.Description,
+		Messages: []*mcp.PromptMessage{
+			{
+				Role:    "user",
+				Content: &mcp.TextContent{Text: message.String()},
+			},
+		},
+	}, nil
+}
+
+func (d PromptDefinition) toolsEnabled(enabledTools []string) bool {
+	for _, tool := range d.Tools {
+		if !slices.Contains(enabledTools, tool) {
+			return false
+		}
+	}
+	return true
+}
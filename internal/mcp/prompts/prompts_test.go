@@ -0,0 +1,179 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package prompts_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/prompts"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func connectPrompts(t *testing.T, enabledTools []string) *mcp.ClientSession {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "test-version"}, nil)
+	prompts.RegisterPrompts(t.Context(), server, enabledTools)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func allPromptTools() []string {
+	var enabledTools []string
+	for _, promptDef := range prompts.ListPrompts() {
+		enabledTools = append(enabledTools, promptDef.Tools...)
+	}
+	return enabledTools
+}
+
+func TestPrompts_ToolsExist(t *testing.T) {
+	var toolNames []string
+	for _, toolDef := range tools.ListTools() {
+		toolNames = append(toolNames, toolDef.McpTool.Name)
+	}
+	for _, promptDef := range prompts.ListPrompts() {
+		for _, tool := range promptDef.Tools {
+			assert.Contains(t, toolNames, tool, "prompt %s uses unknown tool %s", promptDef.Prompt.Name, tool)
+		}
+	}
+}
+
+func TestPrompts_MessagesOnlyReferenceTheirTools(t *testing.T) {
+	var toolNames []string
+	for _, toolDef := range tools.ListTools() {
+		toolNames = append(toolNames, toolDef.McpTool.Name)
+	}
+	for _, promptDef := range prompts.ListPrompts() {
+		arguments := map[string]string{}
+		for _, argument := range promptDef.Prompt.Arguments {
+			arguments[argument.Name] = "value"
+		}
+		result, err := promptDef.Handler(t.Context(), &mcp.GetPromptRequest{Params: &mcp.GetPromptParams{Name: promptDef.Prompt.Name, Arguments: arguments}})
+		require.NoError(t, err)
+		text := result.Messages[0].Content.(*mcp.TextContent).Text
+		for _, toolName := range toolNames {
+			if slices.Contains(promptDef.Tools, toolName) {
+				continue
+			}
+			assert.NotRegexp(t, `\b`+toolName+`\b`, text, "prompt %s references tool %s that is not in its tools", promptDef.Prompt.Name, toolName)
+		}
+	}
+}
+
+func TestRegisterPrompts_AllToolsEnabled(t *testing.T) {
+	session := connectPrompts(t, allPromptTools())
+
+	result, err := session.ListPrompts(t.Context(), &mcp.ListPromptsParams{})
+	require.NoError(t, err)
+
+	var names []string
+	for _, prompt := range result.Prompts {
+		names = append(names, prompt.Name)
+	}
+	assert.ElementsMatch(t, []string{"onboard_application", "offboard_user", "set_up_mfa_for_population"}, names)
+}
+
+func TestRegisterPrompts_SkipsPromptsWithDisabledTools(t *testing.T) {
+	var enabledTools []string
+	for _, toolDef := range tools.ListEnabledTools(filter.NewFilter(true, nil, nil, nil, nil)) {
+		enabledTools = append(enabledTools, toolDef.McpTool.Name)
+	}
+	enabledTools = append(enabledTools, prompts.OffboardUserDef.Tools...)
+	session := connectPrompts(t, enabledTools)
+
+	result, err := session.ListPrompts(t.Context(), &mcp.ListPromptsParams{})
+	require.NoError(t, err)
+
+	require.Len(t, result.Prompts, 1)
+	assert.Equal(t, "offboard_user", result.Prompts[0].Name)
+}
+
+func TestGetPrompt_OnboardApplication(t *testing.T) {
+	session := connectPrompts(t, allPromptTools())
+
+	result, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{
+		Name: "onboard_application",
+		Arguments: map[string]string{
+			"environmentId":   "11111111-1111-1111-1111-111111111111",
+			"applicationName": "Payroll",
+			"redirectUris":    "https://payroll.example.com/callback",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, mcp.Role("user"), result.Messages[0].Role)
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	assert.Contains(t, text, `Onboard the OIDC application "Payroll" in the PingOne environment 11111111-1111-1111-1111-111111111111.`)
+	assert.Contains(t, text, "of type WEB_APP")
+	assert.Contains(t, text, "Set its redirect URIs to: https://payroll.example.com/callback.")
+	assert.NotContains(t, text, "<no value>")
+}
+
+func TestGetPrompt_OnboardApplication_OptionalArguments(t *testing.T) {
+	session := connectPrompts(t, allPromptTools())
+
+	result, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{
+		Name: "onboard_application",
+		Arguments: map[string]string{
+			"environmentId":   "11111111-1111-1111-1111-111111111111",
+			"applicationName": "Payroll",
+			"applicationType": "WORKER",
+		},
+	})
+	require.NoError(t, err)
+
+	text := result.Messages[0].Content.(*mcp.TextContent).Text
+	assert.Contains(t, text, "of type WORKER")
+	assert.Contains(t, text, "Ask me for its redirect URIs first")
+}
+
+func TestGetPrompt_OffboardUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		expected string
+	}{
+		{name: "Username", user: "jdoe", expected: `"jdoe" as the username`},
+		{name: "Email", user: "jdoe@example.com", expected: `"jdoe@example.com" as the email`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := connectPrompts(t, allPromptTools())
+
+			result, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{
+				Name:      "offboard_user",
+				Arguments: map[string]string{"environmentId": "11111111-1111-1111-1111-111111111111", "user": tt.user},
+			})
+			require.NoError(t, err)
+
+			text := result.Messages[0].Content.(*mcp.TextContent).Text
+			assert.Contains(t, text, tt.expected)
+			assert.Contains(t, text, "This server cannot delete users")
+		})
+	}
+}
+
+func TestGetPrompt_MissingRequiredArguments(t *testing.T) {
+	session := connectPrompts(t, allPromptTools())
+
+	_, err := session.GetPrompt(t.Context(), &mcp.GetPromptParams{
+		Name:      "set_up_mfa_for_population",
+		Arguments: map[string]string{"populationName": " "},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prompt 'set_up_mfa_for_population' is missing required arguments: environmentId, populationName")
+}
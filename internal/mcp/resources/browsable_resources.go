@@ -0,0 +1,367 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	// EnvironmentsURI is the URI of the resource listing the environments, under which the resources of each
+	// environment are browsed.
+	EnvironmentsURI = "pingone://environments"
+
+	environmentIdArgument = "environmentId"
+)
+
+// browsableResource is a PingOne object or list of objects exposed as an MCP resource. It is read by calling
+// the read-only tool that returns it, with the URI template variables as the tool arguments.
+type browsableResource struct {
+	uriTemplate string
+	name        string
+	title       string
+	description string
+	toolName    string
+}
+
+var browsableResourceDefinitions = []browsableResource{
+	{
+		uriTemplate: EnvironmentsURI,
+		name:        "environments",
+		title:       "PingOne Environments",
+		description: "The environments the logged in user can access. Browse an environment at pingone://environments/{environmentId}.",
+		toolName:    "list_environments",
+	},
+	{
+		uriTemplate: EnvironmentsURI + "/{environmentId}",
+		name:        "environment",
+		title:       "PingOne Environment",
+		description: "The configuration of an environment, including its type, region and license.",
+		toolName:    "get_environment",
+	},
+	{
+		uriTemplate: EnvironmentsURI + "/{environmentId}/services",
+		name:        "environment_services",
+		title:       "PingOne Environment Services",
+		description: "The services enabled for an environment (its Bill of Materials).",
+		toolName:    "get_environment_services",
+	},
+	{
+		uriTemplate: EnvironmentsURI + "/{environmentId}/applications",
+		name:        "applications",
+		title:       "PingOne Applications",
+		description: "The applications of an environment. Browse an application at pingone://environments/{environmentId}/applications/{applicationId}.",
+		toolName:    "list_applications",
+	},
+	{
+		uriTemplate: EnvironmentsURI + "/{environmentId}/applications/{applicationId}",
+		name:        "application",
+		title:       "PingOne Application",
+		description: "The configuration of an application.",
+		toolName:    "get_application",
+	},
+	{
+		uriTemplate: EnvironmentsURI + "/{environmentId}/populations",
+		name:        "populations",
+		title:       "PingOne Populations",
+		description: "The populations of an environment. Browse a population at pingone://environments/{environmentId}/populations/{populationId}.",
+		toolName:    "list_populations",
+	},
+	{
+		uriTemplate: EnvironmentsURI + "/{environmentId}/populations/{populationId}",
+		name:        "population",
+		title:       "PingOne Population",
+		description: "The configuration of a population, including its user count.",
+		toolName:    "get_population",
+	},
+}
+
+type toolCallerContextKey struct{}
+
+// toolCaller calls a tool through the full middleware chain of the server, so that resource reads are
+// authenticated and validated like tool calls.
+type toolCaller func(ctx context.Context, toolName string, arguments map[string]string) (*mcp.CallToolResult, error)
+
+// BrowsableResources exposes environments and key objects in them as MCP resources, for clients that prefer
+// browsing resources to calling tools. Each resource is read by calling the read-only tool that returns it, so
+// reads are subject to the same authentication, validation and environment scope as tool calls.
+//
+// Clients can subscribe to resources, and are notified when write tools are called for the environment of a
+// resource through this server. Changes made outside the server, such as in the PingOne admin console, are not
+// notified.
+type BrowsableResources struct {
+	resources []browsableResource
+	writes    map[string]bool
+
+	mu            sync.Mutex
+	server        *mcp.Server
+	subscriptions map[string]int
+}
+
+// NewBrowsableResources creates the resources read by the tools in the list. Resources whose tool is not in
+// the list, such as when it is excluded by the tool filter, are not exposed. Calls of the write tools in the
+// list notify subscribers of the resources of the environment they are made for.
+func NewBrowsableResources(tools []types.ToolDefinition) *BrowsableResources {
+	readTools := make(map[string]bool)
+	b := &BrowsableResources{
+		writes:        make(map[string]bool),
+		subscriptions: make(map[string]int),
+	}
+	for _, tool := range tools {
+		if tool.McpTool == nil {
+			continue
+		}
+		if tool.IsReadOnly() {
+			readTools[tool.McpTool.Name] = true
+		} else {
+			b.writes[tool.McpTool.Name] = true
+		}
+	}
+	for _, resource := range browsableResourceDefinitions {
+		if readTools[resource.toolName] {
+			b.resources = append(b.resources, resource)
+		}
+	}
+	return b
+}
+
+// Register adds the resources and resource templates to the MCP server.
+func (b *BrowsableResources) Register(server *mcp.Server) {
+	b.mu.Lock()
+	b.server = server
+	b.mu.Unlock()
+
+	for _, resource := range b.resources {
+		if !strings.Contains(resource.uriTemplate, "{") {
+			server.AddResource(&mcp.Resource{
+				URI:         resource.uriTemplate,
+				Name:        resource.name,
+				Title:       resource.title,
+				Description: resource.description,
+				MIMEType:    resultMIMEType,
+			}, b.ReadResource)
+			continue
+		}
+		server.AddResourceTemplate(&mcp.ResourceTemplate{
+			URITemplate: resource.uriTemplate,
+			Name:        resource.name,
+			Title:       resource.title,
+			Description: resource.description,
+			MIMEType:    resultMIMEType,
+		}, b.ReadResource)
+	}
+}
+
+// Subscribe records a subscription to a resource. It implements mcp.ServerOptions.SubscribeHandler.
+func (b *BrowsableResources) Subscribe(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	if _, _, ok := b.match(uri); !ok {
+		return mcp.ResourceNotFoundError(uri)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[uri]++
+	return nil
+}
+
+// Unsubscribe removes a subscription to a resource. It implements mcp.ServerOptions.UnsubscribeHandler.
+func (b *BrowsableResources) Unsubscribe(ctx context.Context, req *mcp.UnsubscribeRequest) error {
+	uri := req.Params.URI
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subscriptions[uri] <= 1 {
+		delete(b.subscriptions, uri)
+		return nil
+	}
+	b.subscriptions[uri]--
+	return nil
+}
+
+// ReadResource returns the output of the tool that reads the resource. It implements mcp.ResourceHandler, and
+// must be called with a context prepared by Handler.
+func (b *BrowsableResources) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	resource, arguments, ok := b.match(uri)
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	callTool, ok := ctx.Value(toolCallerContextKey{}).(toolCaller)
+	if !ok {
+		// Should never happen, the middleware prepares the context of every resource read
+		return nil, fmt.Errorf("failed to read resource %s: tool calls are not available", uri)
+	}
+
+	logger.FromContext(ctx).Debug("Reading resource", slog.String("uri", uri), slog.String("tool", resource.toolName))
+
+	result, err := callTool(ctx, resource.toolName, arguments)
+	if err != nil {
+		return nil, err
+	}
+	text, err := resultText(result)
+	if err != nil {
+		return nil, err
+	}
+	if result.IsError {
+		return nil, fmt.Errorf("failed to read resource %s: %s", uri, text)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: resultMIMEType,
+				Text:     text,
+			},
+		},
+	}, nil
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+//
+// Resource reads are given a context that calls tools through the next handler. Calls of write tools notify
+// subscribers of the resources they may have changed, whether or not they succeed, as a failed write may
+// still have changed some resources. This middleware should be added to the MCP server via
+// AddReceivingMiddleware before any other middleware, so that the tool calls made to read resources pass
+// through all of it.
+func (b *BrowsableResources) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		switch method {
+		case "resources/read":
+			readResourceReq, ok := req.(*mcp.ReadResourceRequest)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			callTool := toolCaller(func(ctx context.Context, toolName string, arguments map[string]string) (*mcp.CallToolResult, error) {
+				argumentsJSON, err := json.Marshal(arguments)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal tool arguments: %w", err)
+				}
+				result, err := next(ctx, "tools/call", &mcp.CallToolRequest{
+					Session: readResourceReq.Session,
+					Params:  &mcp.CallToolParamsRaw{Name: toolName, Arguments: argumentsJSON},
+					Extra:   readResourceReq.Extra,
+				})
+				if err != nil {
+					return nil, err
+				}
+				callToolResult, ok := result.(*mcp.CallToolResult)
+				if !ok {
+					return nil, fmt.Errorf("unexpected result type %T for tool %s", result, toolName)
+				}
+				return callToolResult, nil
+			})
+			return next(context.WithValue(ctx, toolCallerContextKey{}, callTool), method, req)
+		case "tools/call":
+			callToolReq, ok := req.(*mcp.CallToolRequest)
+			if !ok || !b.writes[callToolReq.Params.Name] {
+				return next(ctx, method, req)
+			}
+			defer b.notifyChanged(ctx, environmentIdFromArguments(callToolReq.Params.Arguments))
+			return next(ctx, method, req)
+		default:
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// notifyChanged notifies subscribers of the environments resource and the resources of the environment. An
+// empty environment ID notifies subscribers of all resources.
+func (b *BrowsableResources) notifyChanged(ctx context.Context, environmentId string) {
+	b.mu.Lock()
+	server := b.server
+	var uris []string
+	for uri := range b.subscriptions {
+		if environmentId == "" || uri == EnvironmentsURI || isEnvironmentResourceURI(uri, environmentId) {
+			uris = append(uris, uri)
+		}
+	}
+	b.mu.Unlock()
+
+	if server == nil {
+		return
+	}
+	for _, uri := range uris {
+		if err := server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+			logger.FromContext(ctx).Warn("Failed to notify resource subscribers", slog.String("uri", uri), slog.Any("error", err))
+		}
+	}
+}
+
+// match returns the resource with the URI and the tool arguments taken from the URI
+func (b *BrowsableResources) match(uri string) (browsableResource, map[string]string, bool) {
+	segments := strings.Split(uri, "/")
+	for _, resource := range b.resources {
+		templateSegments := strings.Split(resource.uriTemplate, "/")
+		if len(templateSegments) != len(segments) {
+			continue
+		}
+		arguments := map[string]string{}
+		matched := true
+		for i, templateSegment := range templateSegments {
+			if name, ok := strings.CutPrefix(templateSegment, "{"); ok {
+				if segments[i] == "" {
+					matched = false
+					break
+				}
+				arguments[strings.TrimSuffix(name, "}")] = segments[i]
+				continue
+			}
+			if templateSegment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return resource, arguments, true
+		}
+	}
+	return browsableResource{}, nil, false
+}
+
+func isEnvironmentResourceURI(uri string, environmentId string) bool {
+	environmentURI := EnvironmentsURI + "/" + environmentId
+	return strings.EqualFold(uri, environmentURI) || strings.HasPrefix(strings.ToLower(uri), strings.ToLower(environmentURI)+"/")
+}
+
+func environmentIdFromArguments(argumentsJSON json.RawMessage) string {
+	var arguments map[string]any
+	if err := json.Unmarshal(argumentsJSON, &arguments); err != nil {
+		return ""
+	}
+	environmentId, _ := arguments[environmentIdArgument].(string)
+	return environmentId
+}
+
+// resultText returns the structured output of the tool result as JSON, or its text content if it has no
+// structured output
+func resultText(result *mcp.CallToolResult) (string, error) {
+	if result.StructuredContent != nil && !result.IsError {
+		text, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal tool output: %w", err)
+		}
+		return string(text), nil
+	}
+	var texts []string
+	for _, content := range result.Content {
+		if textContent, ok := content.(*mcp.TextContent); ok {
+			texts = append(texts, textContent.Text)
+		}
+	}
+	if len(texts) == 0 {
+		return "", errors.New("tool returned no output")
+	}
+	return strings.Join(texts, "\n"), nil
+}
@@ -0,0 +1,253 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEnvironmentId = "11111111-1111-1111-1111-111111111111"
+
+type testEnvironmentInput struct {
+	EnvironmentId string `json:"environmentId,omitempty"`
+	ApplicationId string `json:"applicationId,omitempty"`
+}
+
+type testEnvironmentOutput struct {
+	Tool          string `json:"tool"`
+	EnvironmentId string `json:"environmentId"`
+	ApplicationId string `json:"applicationId,omitempty"`
+}
+
+func testToolDef(name string, readOnly bool) types.ToolDefinition {
+	return types.ToolDefinition{
+		McpTool: &mcp.Tool{
+			Name:        name,
+			Annotations: &mcp.ToolAnnotations{ReadOnlyHint: readOnly},
+		},
+	}
+}
+
+// browsableResourcesServer serves the browsable resources for the tools, followed by middleware that records
+// the tool calls made, and returns a connected client session
+func browsableResourcesServer(t *testing.T, toolDefs []types.ToolDefinition, clientOptions *mcp.ClientOptions) (*mcp.ClientSession, *[]string) {
+	t.Helper()
+	browsableResources := resources.NewBrowsableResources(toolDefs)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "test-version"}, &mcp.ServerOptions{
+		SubscribeHandler:   browsableResources.Subscribe,
+		UnsubscribeHandler: browsableResources.Unsubscribe,
+	})
+	for _, toolDef := range toolDefs {
+		tool := &mcp.Tool{Name: toolDef.McpTool.Name, Annotations: toolDef.McpTool.Annotations}
+		mcp.AddTool(server, tool, func(ctx context.Context, req *mcp.CallToolRequest, input testEnvironmentInput) (*mcp.CallToolResult, *testEnvironmentOutput, error) {
+			if input.EnvironmentId == "not-found" {
+				return nil, nil, errors.New("environment not found")
+			}
+			return nil, &testEnvironmentOutput{Tool: tool.Name, EnvironmentId: input.EnvironmentId, ApplicationId: input.ApplicationId}, nil
+		})
+	}
+	browsableResources.Register(server)
+
+	var mu sync.Mutex
+	toolCalls := []string{}
+	recordToolCalls := func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/call" {
+				mu.Lock()
+				toolCalls = append(toolCalls, req.(*mcp.CallToolRequest).Params.Name)
+				mu.Unlock()
+			}
+			return next(ctx, method, req)
+		}
+	}
+	server.AddReceivingMiddleware(browsableResources.Handler, recordToolCalls)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "test-version"}, clientOptions)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session, &toolCalls
+}
+
+func allBrowsableToolDefs() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		testToolDef("list_environments", true),
+		testToolDef("get_environment", true),
+		testToolDef("get_environment_services", true),
+		testToolDef("list_applications", true),
+		testToolDef("get_application", true),
+		testToolDef("list_populations", true),
+		testToolDef("get_population", true),
+		testToolDef("update_application", false),
+	}
+}
+
+func TestBrowsableResources_ListsResourcesOfEnabledTools(t *testing.T) {
+	session, _ := browsableResourcesServer(t, []types.ToolDefinition{
+		testToolDef("list_environments", true),
+		testToolDef("list_applications", true),
+		testToolDef("get_application", true),
+		// Resources are only read with read-only tools
+		testToolDef("get_population", false),
+	}, nil)
+
+	resourcesResult, err := session.ListResources(t.Context(), &mcp.ListResourcesParams{})
+	require.NoError(t, err)
+	require.Len(t, resourcesResult.Resources, 1)
+	assert.Equal(t, resources.EnvironmentsURI, resourcesResult.Resources[0].URI)
+	assert.Equal(t, "application/json", resourcesResult.Resources[0].MIMEType)
+
+	templatesResult, err := session.ListResourceTemplates(t.Context(), &mcp.ListResourceTemplatesParams{})
+	require.NoError(t, err)
+	var uriTemplates []string
+	for _, template := range templatesResult.ResourceTemplates {
+		uriTemplates = append(uriTemplates, template.URITemplate)
+	}
+	assert.ElementsMatch(t, []string{
+		"pingone://environments/{environmentId}/applications",
+		"pingone://environments/{environmentId}/applications/{applicationId}",
+	}, uriTemplates)
+}
+
+func TestBrowsableResources_ReadResource(t *testing.T) {
+	tests := []struct {
+		name     string
+		uri      string
+		expected testEnvironmentOutput
+	}{
+		{
+			name:     "Environments",
+			uri:      "pingone://environments",
+			expected: testEnvironmentOutput{Tool: "list_environments"},
+		},
+		{
+			name:     "Environment",
+			uri:      "pingone://environments/" + testEnvironmentId,
+			expected: testEnvironmentOutput{Tool: "get_environment", EnvironmentId: testEnvironmentId},
+		},
+		{
+			name:     "Environment services",
+			uri:      "pingone://environments/" + testEnvironmentId + "/services",
+			expected: testEnvironmentOutput{Tool: "get_environment_services", EnvironmentId: testEnvironmentId},
+		},
+		{
+			name:     "Applications",
+			uri:      "pingone://environments/" + testEnvironmentId + "/applications",
+			expected: testEnvironmentOutput{Tool: "list_applications", EnvironmentId: testEnvironmentId},
+		},
+		{
+			name:     "Application",
+			uri:      "pingone://environments/" + testEnvironmentId + "/applications/22222222-2222-2222-2222-222222222222",
+			expected: testEnvironmentOutput{Tool: "get_application", EnvironmentId: testEnvironmentId, ApplicationId: "22222222-2222-2222-2222-222222222222"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, toolCalls := browsableResourcesServer(t, allBrowsableToolDefs(), nil)
+
+			result, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: tt.uri})
+			require.NoError(t, err)
+
+			require.Len(t, result.Contents, 1)
+			assert.Equal(t, tt.uri, result.Contents[0].URI)
+			assert.Equal(t, "application/json", result.Contents[0].MIMEType)
+			var output testEnvironmentOutput
+			require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &output))
+			assert.Equal(t, tt.expected, output)
+
+			// The tool is called through the middleware that follows
+			assert.Equal(t, []string{tt.expected.Tool}, *toolCalls)
+		})
+	}
+}
+
+func TestBrowsableResources_ReadResource_ToolError(t *testing.T) {
+	session, _ := browsableResourcesServer(t, allBrowsableToolDefs(), nil)
+
+	_, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "pingone://environments/not-found/applications"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read resource pingone://environments/not-found/applications: environment not found")
+}
+
+func TestBrowsableResources_ReadResource_NotFound(t *testing.T) {
+	session, toolCalls := browsableResourcesServer(t, allBrowsableToolDefs(), nil)
+
+	for _, uri := range []string{
+		"pingone://environments/" + testEnvironmentId + "/groups",
+		"pingone://environments//applications",
+	} {
+		_, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: uri})
+		assert.Error(t, err, uri)
+	}
+	assert.Empty(t, *toolCalls)
+}
+
+func TestBrowsableResources_Subscribe_UnknownResource(t *testing.T) {
+	session, _ := browsableResourcesServer(t, allBrowsableToolDefs(), nil)
+
+	err := session.Subscribe(t.Context(), &mcp.SubscribeParams{URI: "pingone://environments/" + testEnvironmentId + "/groups"})
+	assert.Error(t, err)
+}
+
+func TestBrowsableResources_NotifiesSubscribersOfWrites(t *testing.T) {
+	updated := make(chan string, 10)
+	session, _ := browsableResourcesServer(t, allBrowsableToolDefs(), &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(ctx context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+
+	applicationsURI := "pingone://environments/" + testEnvironmentId + "/applications"
+	otherEnvironmentURI := "pingone://environments/33333333-3333-3333-3333-333333333333/applications"
+	for _, uri := range []string{resources.EnvironmentsURI, applicationsURI, otherEnvironmentURI} {
+		require.NoError(t, session.Subscribe(t.Context(), &mcp.SubscribeParams{URI: uri}))
+	}
+
+	// Read-only tools do not notify subscribers
+	_, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_applications", Arguments: map[string]any{"environmentId": testEnvironmentId}})
+	require.NoError(t, err)
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "update_application", Arguments: map[string]any{"environmentId": testEnvironmentId}})
+	require.NoError(t, err)
+
+	var uris []string
+	for range 2 {
+		select {
+		case uri := <-updated:
+			uris = append(uris, uri)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for resource updated notifications")
+		}
+	}
+	assert.ElementsMatch(t, []string{resources.EnvironmentsURI, applicationsURI}, uris)
+
+	require.NoError(t, session.Unsubscribe(t.Context(), &mcp.UnsubscribeParams{URI: applicationsURI}))
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "update_application", Arguments: map[string]any{"environmentId": testEnvironmentId}})
+	require.NoError(t, err)
+	select {
+	case uri := <-updated:
+		assert.Equal(t, resources.EnvironmentsURI, uri)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for resource updated notification")
+	}
+	select {
+	case uri := <-updated:
+		t.Fatalf("unexpected notification for %s", uri)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
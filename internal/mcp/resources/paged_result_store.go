@@ -0,0 +1,200 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// ResultURIPrefix is the URI prefix of paged list result resources.
+	ResultURIPrefix = "pingone://results/"
+
+	// ResultURITemplate is the MCP resource template for reading a page of a stored list result.
+	ResultURITemplate = ResultURIPrefix + "{resultId}{?page}"
+
+	// DefaultMaxStoredResults is the default number of list results retained before the oldest is evicted.
+	DefaultMaxStoredResults = 20
+
+	resultMIMEType = "application/json"
+)
+
+// ResultLink describes a list result stored as a paged MCP resource.
+type ResultLink struct {
+	Uri        string `json:"uri" jsonschema:"The MCP resource URI of the first page of the full result. Read the resource to retrieve the items, then follow nextPageUri until it is empty."`
+	TotalCount int    `json:"totalCount" jsonschema:"The total number of items in the full result"`
+	PageSize   int    `json:"pageSize" jsonschema:"The maximum number of items in each page"`
+	TotalPages int    `json:"totalPages" jsonschema:"The number of pages in the full result"`
+}
+
+// ResultPage is the content of a paged list result resource.
+type ResultPage struct {
+	Items       []json.RawMessage `json:"items"`
+	Page        int               `json:"page"`
+	TotalPages  int               `json:"totalPages"`
+	TotalCount  int               `json:"totalCount"`
+	NextPageUri string            `json:"nextPageUri,omitempty"`
+}
+
+// PagedResultStore holds list results too large to return inline, so that MCP clients can page through them
+// as resources instead of receiving every item in a single tool result.
+//
+// Results are held in memory for the lifetime of the server. The number of stored results is bounded, and the
+// oldest result is evicted when the bound is reached.
+type PagedResultStore struct {
+	mu         sync.Mutex
+	pageSize   int
+	maxResults int
+	results    map[string][]json.RawMessage
+	order      []string
+}
+
+// NewPagedResultStore creates a store that pages results by pageSize items and retains at most maxResults results.
+func NewPagedResultStore(pageSize int, maxResults int) *PagedResultStore {
+	return &PagedResultStore{
+		pageSize:   pageSize,
+		maxResults: maxResults,
+		results:    make(map[string][]json.RawMessage),
+	}
+}
+
+// PageSize returns the maximum number of items in each page.
+func (s *PagedResultStore) PageSize() int {
+	return s.pageSize
+}
+
+// Register adds the paged result resource template to the MCP server.
+func (s *PagedResultStore) Register(server *mcp.Server) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "list_results",
+		Title:       "Paged List Results",
+		Description: "Pages of a list tool result that was too large to return inline. Tools return the URI of the first page; each page includes the URI of the next page.",
+		MIMEType:    resultMIMEType,
+		URITemplate: ResultURITemplate,
+	}, s.ReadResource)
+}
+
+// Clear removes all stored results.
+func (s *PagedResultStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.results)
+	s.order = nil
+}
+
+// store records the items and returns a link to the first page.
+func (s *PagedResultStore) store(items []json.RawMessage) *ResultLink {
+	resultId := uuid.NewString()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.order) >= s.maxResults && len(s.order) > 0 {
+		delete(s.results, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.results[resultId] = items
+	s.order = append(s.order, resultId)
+
+	return &ResultLink{
+		Uri:        resultPageURI(resultId, 1),
+		TotalCount: len(items),
+		PageSize:   s.pageSize,
+		TotalPages: s.totalPages(len(items)),
+	}
+}
+
+// ReadResource returns a page of a stored result. It implements mcp.ResourceHandler.
+func (s *PagedResultStore) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	resultId, page, err := parseResultPageURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	items, ok := s.results[resultId]
+	s.mu.Unlock()
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	totalPages := s.totalPages(len(items))
+	if page > totalPages {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	start := (page - 1) * s.pageSize
+	end := min(start+s.pageSize, len(items))
+	resultPage := ResultPage{
+		Items:      items[start:end],
+		Page:       page,
+		TotalPages: totalPages,
+		TotalCount: len(items),
+	}
+	if page < totalPages {
+		resultPage.NextPageUri = resultPageURI(resultId, page+1)
+	}
+
+	text, err := json.Marshal(resultPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result page: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: resultMIMEType,
+				Text:     string(text),
+			},
+		},
+	}, nil
+}
+
+func (s *PagedResultStore) totalPages(count int) int {
+	if count == 0 {
+		return 1
+	}
+	return (count + s.pageSize - 1) / s.pageSize
+}
+
+func resultPageURI(resultId string, page int) string {
+	return fmt.Sprintf("%s%s?page=%d", ResultURIPrefix, resultId, page)
+}
+
+func parseResultPageURI(uri string) (string, int, error) {
+	if !strings.HasPrefix(uri, ResultURIPrefix) {
+		return "", 0, mcp.ResourceNotFoundError(uri)
+	}
+
+	resultId, rawQuery, _ := strings.Cut(strings.TrimPrefix(uri, ResultURIPrefix), "?")
+	if resultId == "" {
+		return "", 0, mcp.ResourceNotFoundError(uri)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid result URI %q: %w", uri, err)
+	}
+
+	page := 1
+	if pageValue := query.Get("page"); pageValue != "" {
+		page, err = strconv.Atoi(pageValue)
+		if err != nil || page < 1 {
+			return "", 0, fmt.Errorf("invalid page %q in result URI, page must be a positive integer", pageValue)
+		}
+	}
+
+	return resultId, page, nil
+}
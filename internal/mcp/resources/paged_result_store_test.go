@@ -0,0 +1,178 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	Name string `json:"name"`
+}
+
+func testItems(count int) []testItem {
+	items := make([]testItem, 0, count)
+	for i := range count {
+		items = append(items, testItem{Name: fmt.Sprintf("item-%d", i)})
+	}
+	return items
+}
+
+// storeItems stores the items through PageListResult and returns the link to the stored result
+func storeItems(t *testing.T, store *resources.PagedResultStore, items []testItem) *resources.ResultLink {
+	t.Helper()
+	_, link, err := resources.PageListResult(resources.ContextWithResultStore(context.Background(), store), items)
+	require.NoError(t, err)
+	require.NotNil(t, link)
+	return link
+}
+
+// readPage reads a result page resource and decodes its content
+func readPage(t *testing.T, store *resources.PagedResultStore, uri string) (*resources.ResultPage, error) {
+	t.Helper()
+	result, err := store.ReadResource(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: uri},
+	})
+	if err != nil {
+		return nil, err
+	}
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, uri, result.Contents[0].URI)
+	assert.Equal(t, "application/json", result.Contents[0].MIMEType)
+
+	page := &resources.ResultPage{}
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), page))
+	return page, nil
+}
+
+func pageItemNames(t *testing.T, page *resources.ResultPage) []string {
+	t.Helper()
+	var names []string
+	for _, rawItem := range page.Items {
+		item := testItem{}
+		require.NoError(t, json.Unmarshal(rawItem, &item))
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+func TestPagedResultStore_ReadResource_PagesThroughResult(t *testing.T) {
+	store := resources.NewPagedResultStore(2, resources.DefaultMaxStoredResults)
+	link := storeItems(t, store, testItems(5))
+
+	assert.True(t, strings.HasPrefix(link.Uri, resources.ResultURIPrefix))
+	assert.Equal(t, 5, link.TotalCount)
+	assert.Equal(t, 2, link.PageSize)
+	assert.Equal(t, 3, link.TotalPages)
+
+	var names []string
+	uri := link.Uri
+	for pageNumber := 1; uri != ""; pageNumber++ {
+		page, err := readPage(t, store, uri)
+		require.NoError(t, err)
+		assert.Equal(t, pageNumber, page.Page)
+		assert.Equal(t, 3, page.TotalPages)
+		assert.Equal(t, 5, page.TotalCount)
+		names = append(names, pageItemNames(t, page)...)
+		uri = page.NextPageUri
+	}
+
+	assert.Equal(t, []string{"item-0", "item-1", "item-2", "item-3", "item-4"}, names)
+}
+
+func TestPagedResultStore_ReadResource_DefaultsToFirstPage(t *testing.T) {
+	store := resources.NewPagedResultStore(2, resources.DefaultMaxStoredResults)
+	link := storeItems(t, store, testItems(3))
+
+	uri, _, _ := strings.Cut(link.Uri, "?")
+	page, err := readPage(t, store, uri)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.Page)
+	assert.Equal(t, []string{"item-0", "item-1"}, pageItemNames(t, page))
+}
+
+func TestPagedResultStore_ReadResource_Errors(t *testing.T) {
+	store := resources.NewPagedResultStore(2, resources.DefaultMaxStoredResults)
+	link := storeItems(t, store, testItems(3))
+	resultUri, _, _ := strings.Cut(link.Uri, "?")
+
+	tests := []struct {
+		name            string
+		uri             string
+		wantErrContains string
+	}{
+		{
+			name:            "Unknown result",
+			uri:             resources.ResultURIPrefix + "unknown?page=1",
+			wantErrContains: "Resource not found",
+		},
+		{
+			name:            "Page beyond last page",
+			uri:             resultUri + "?page=3",
+			wantErrContains: "Resource not found",
+		},
+		{
+			name:            "Invalid page",
+			uri:             resultUri + "?page=0",
+			wantErrContains: "page must be a positive integer",
+		},
+		{
+			name:            "Non-numeric page",
+			uri:             resultUri + "?page=two",
+			wantErrContains: "page must be a positive integer",
+		},
+		{
+			name:            "Other URI scheme",
+			uri:             "file:///etc/passwd",
+			wantErrContains: "Resource not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readPage(t, store, tt.uri)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestPagedResultStore_EvictsOldestResult(t *testing.T) {
+	store := resources.NewPagedResultStore(1, 2)
+	first := storeItems(t, store, testItems(2))
+	second := storeItems(t, store, testItems(2))
+	third := storeItems(t, store, testItems(2))
+
+	_, err := readPage(t, store, first.Uri)
+	require.Error(t, err, "Oldest result should be evicted")
+	assert.Contains(t, err.Error(), "Resource not found")
+
+	for _, link := range []*resources.ResultLink{second, third} {
+		_, err := readPage(t, store, link.Uri)
+		assert.NoError(t, err)
+	}
+}
+
+func TestPagedResultStore_Clear(t *testing.T) {
+	store := resources.NewPagedResultStore(1, 2)
+	link := storeItems(t, store, testItems(2))
+
+	store.Clear()
+
+	_, err := readPage(t, store, link.Uri)
+	require.Error(t, err, "Cleared result should not be readable")
+	assert.Contains(t, err.Error(), "Resource not found")
+
+	_, err = readPage(t, store, storeItems(t, store, testItems(2)).Uri)
+	assert.NoError(t, err)
+}
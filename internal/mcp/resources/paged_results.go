@@ -0,0 +1,104 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+type resultStoreContextKey struct{}
+
+// ContextWithResultStore returns a context carrying the paged result store used by list tools.
+func ContextWithResultStore(ctx context.Context, store *PagedResultStore) context.Context {
+	return context.WithValue(ctx, resultStoreContextKey{}, store)
+}
+
+// ResultStoreFromContext returns the paged result store from the context, or nil if paged results are disabled.
+func ResultStoreFromContext(ctx context.Context) *PagedResultStore {
+	store, _ := ctx.Value(resultStoreContextKey{}).(*PagedResultStore)
+	return store
+}
+
+// PageListResult stores the full list of items as a paged resource when paged results are enabled and the
+// items do not fit in a single page. It returns the items to include inline, which is the first page when the
+// result is stored, and a link to the stored result. The link is nil when the items are returned inline in full.
+func PageListResult[T any](ctx context.Context, items []T) ([]T, *ResultLink, error) {
+	store := ResultStoreFromContext(ctx)
+	if store == nil || len(items) <= store.PageSize() {
+		return items, nil, nil
+	}
+
+	rawItems := make([]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		rawItem, err := json.Marshal(item)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal list result item: %w", err)
+		}
+		rawItems = append(rawItems, rawItem)
+	}
+
+	link := store.store(rawItems)
+
+	logger.FromContext(ctx).Debug("Stored list result as paged resource",
+		slog.String("uri", link.Uri),
+		slog.Int("totalCount", link.TotalCount),
+		slog.Int("totalPages", link.TotalPages))
+
+	return items[:store.PageSize()], link, nil
+}
+
+// CallToolResult returns a tool result containing the structured output as text, followed by a resource link
+// to the stored result so that MCP clients can page through the remaining items.
+// A nil link returns a nil result, leaving the MCP SDK to build the result from the structured output.
+func (l *ResultLink) CallToolResult(name string, output any) (*mcp.CallToolResult, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	text, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tool output: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(text)},
+			&mcp.ResourceLink{
+				URI:         l.Uri,
+				Name:        name,
+				Description: fmt.Sprintf("Page 1 of %d of the full result of %d items", l.TotalPages, l.TotalCount),
+				MIMEType:    resultMIMEType,
+			},
+		},
+	}, nil
+}
+
+// ResultStoreMiddleware makes the paged result store available to tool handlers through the request context.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type ResultStoreMiddleware struct {
+	store *PagedResultStore
+}
+
+// NewResultStoreMiddleware creates middleware that adds the store to the context of tool calls.
+func NewResultStoreMiddleware(store *PagedResultStore) *ResultStoreMiddleware {
+	return &ResultStoreMiddleware{
+		store: store,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ResultStoreMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.store == nil {
+			return next(ctx, method, req)
+		}
+		return next(ContextWithResultStore(ctx, m.store), method, req)
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testListInput struct {
+	Count int `json:"count"`
+}
+
+type testListOutput struct {
+	Items          []testItem            `json:"items"`
+	ResultResource *resources.ResultLink `json:"resultResource,omitempty"`
+}
+
+func TestPageListResult(t *testing.T) {
+	store := resources.NewPagedResultStore(3, resources.DefaultMaxStoredResults)
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		count         int
+		expectedCount int
+		expectLink    bool
+	}{
+		{
+			name:          "Paged results disabled",
+			ctx:           context.Background(),
+			count:         10,
+			expectedCount: 10,
+		},
+		{
+			name:          "Result fits in a single page",
+			ctx:           resources.ContextWithResultStore(context.Background(), store),
+			count:         3,
+			expectedCount: 3,
+		},
+		{
+			name:          "Result larger than a page",
+			ctx:           resources.ContextWithResultStore(context.Background(), store),
+			count:         10,
+			expectedCount: 3,
+			expectLink:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, link, err := resources.PageListResult(tt.ctx, testItems(tt.count))
+
+			require.NoError(t, err)
+			assert.Equal(t, testItems(tt.count)[:tt.expectedCount], items)
+			if !tt.expectLink {
+				assert.Nil(t, link)
+				return
+			}
+			require.NotNil(t, link)
+			assert.Equal(t, tt.count, link.TotalCount)
+			assert.Equal(t, 4, link.TotalPages)
+		})
+	}
+}
+
+func TestResultLink_CallToolResult_NilLink(t *testing.T) {
+	var link *resources.ResultLink
+
+	result, err := link.CallToolResult("list_things", testListOutput{})
+
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestResultStoreMiddleware_NonToolCall(t *testing.T) {
+	middleware := resources.NewResultStoreMiddleware(resources.NewPagedResultStore(3, resources.DefaultMaxStoredResults))
+
+	var store *resources.PagedResultStore
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		store = resources.ResultStoreFromContext(ctx)
+		return nil, nil
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "initialize", &mcp.InitializeRequest{})
+
+	require.NoError(t, err)
+	assert.Nil(t, store, "Store should only be added to tool calls")
+}
+
+func TestPagedResults_OverMcp(t *testing.T) {
+	store := resources.NewPagedResultStore(2, resources.DefaultMaxStoredResults)
+
+	listHandler := func(ctx context.Context, req *mcp.CallToolRequest, input testListInput) (*mcp.CallToolResult, *testListOutput, error) {
+		output := &testListOutput{}
+		var err error
+		output.Items, output.ResultResource, err = resources.PageListResult(ctx, testItems(input.Count))
+		if err != nil {
+			return nil, nil, err
+		}
+		mcpResult, err := output.ResultResource.CallToolResult("list_things", output)
+		if err != nil {
+			return nil, nil, err
+		}
+		return mcpResult, output, nil
+	}
+
+	server := mcptestutils.TestMcpServer(t)
+	store.Register(server)
+	server.AddReceivingMiddleware(resources.NewResultStoreMiddleware(store).Handler)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "list_things",
+		InputSchema:  schema.MustGenerateSchema[testListInput](),
+		OutputSchema: schema.MustGenerateSchema[testListOutput](),
+	}, listHandler)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = serverSession.Close() }()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = session.Close() }()
+
+	// The resource template is advertised to clients
+	templates, err := session.ListResourceTemplates(t.Context(), nil)
+	require.NoError(t, err)
+	require.Len(t, templates.ResourceTemplates, 1)
+	assert.Equal(t, resources.ResultURITemplate, templates.ResourceTemplates[0].URITemplate)
+
+	t.Run("Small result returned inline", func(t *testing.T) {
+		result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_things", Arguments: testListInput{Count: 2}})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		output := decodeListOutput(t, result)
+		assert.Len(t, output.Items, 2)
+		assert.Nil(t, output.ResultResource)
+		require.Len(t, result.Content, 1)
+	})
+
+	t.Run("Large result returned as resource link", func(t *testing.T) {
+		result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_things", Arguments: testListInput{Count: 5}})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		output := decodeListOutput(t, result)
+		assert.Len(t, output.Items, 2, "Only the first page should be inline")
+		require.NotNil(t, output.ResultResource)
+		assert.Equal(t, 5, output.ResultResource.TotalCount)
+
+		require.Len(t, result.Content, 2)
+		resourceLink, ok := result.Content[1].(*mcp.ResourceLink)
+		require.True(t, ok, "Expected a resource link in the tool result content")
+		assert.Equal(t, output.ResultResource.Uri, resourceLink.URI)
+
+		var names []string
+		uri := resourceLink.URI
+		for uri != "" {
+			readResult, err := session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: uri})
+			require.NoError(t, err)
+			require.Len(t, readResult.Contents, 1)
+
+			page := &resources.ResultPage{}
+			require.NoError(t, json.Unmarshal([]byte(readResult.Contents[0].Text), page))
+			names = append(names, pageItemNames(t, page)...)
+			uri = page.NextPageUri
+		}
+		assert.Equal(t, []string{"item-0", "item-1", "item-2", "item-3", "item-4"}, names)
+	})
+}
+
+func decodeListOutput(t *testing.T, result *mcp.CallToolResult) *testListOutput {
+	t.Helper()
+	output := &testListOutput{}
+	jsonBytes, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsonBytes, output))
+	return output
+}
@@ -0,0 +1,372 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package mockbackend provides an in-memory fake of the PingOne platform APIs, seeded from fixture files,
+// so the server can be run without credentials or network access for demos and development.
+package mockbackend
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+//go:embed fixtures/default.json
+var defaultFixtures []byte
+
+var _ http.RoundTripper = &Backend{}
+
+// Fixtures seeds the mock backend. Resources are keyed by API path (without the version prefix): a JSON
+// array is a collection of resources, such as "/environments", and a JSON object is a single resource
+// read and replaced as a whole, such as "/environments/{environmentId}/billOfMaterials".
+type Fixtures struct {
+	OrganizationId string                     `json:"organizationId"`
+	EnvironmentId  string                     `json:"environmentId"`
+	Resources      map[string]json.RawMessage `json:"resources"`
+}
+
+// Backend is an in-memory PingOne API. It serves the requests of both PingOne SDKs as an HTTP transport,
+// so every tool runs its real request and response handling against the fixture data.
+type Backend struct {
+	organizationId string
+	environmentId  string
+
+	mu          sync.Mutex
+	collections map[string][]map[string]any
+	singletons  map[string]map[string]any
+}
+
+// NewBackend creates a backend seeded from the fixtures file at the provided path, or from the
+// built-in fixtures if the path is empty. The file can also be an environment exported with the
+// export_environment tool.
+func NewBackend(fixturesPath string) (*Backend, error) {
+	data := defaultFixtures
+	if fixturesPath != "" {
+		var err error
+		data, err = os.ReadFile(fixturesPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read mock backend fixtures: %w", err)
+		}
+	}
+
+	var fixtures Fixtures
+	snapshotFixtures, err := fixturesFromSnapshot(data)
+	if err != nil {
+		return nil, err
+	}
+	if snapshotFixtures != nil {
+		fixtures = *snapshotFixtures
+	} else if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse mock backend fixtures: %w", err)
+	}
+	if _, err := uuid.Parse(fixtures.OrganizationId); err != nil {
+		return nil, fmt.Errorf("mock backend fixtures must set organizationId to a UUID: %w", err)
+	}
+	if _, err := uuid.Parse(fixtures.EnvironmentId); err != nil {
+		return nil, fmt.Errorf("mock backend fixtures must set environmentId to a UUID: %w", err)
+	}
+
+	backend := &Backend{
+		organizationId: fixtures.OrganizationId,
+		environmentId:  fixtures.EnvironmentId,
+		collections:    map[string][]map[string]any{},
+		singletons:     map[string]map[string]any{},
+	}
+	for resourcePath, raw := range fixtures.Resources {
+		resourcePath = cleanPath(resourcePath)
+		trimmed := bytes.TrimSpace(raw)
+		switch {
+		case bytes.HasPrefix(trimmed, []byte("[")):
+			var items []map[string]any
+			if err := json.Unmarshal(trimmed, &items); err != nil {
+				return nil, fmt.Errorf("failed to parse mock backend fixture %s: %w", resourcePath, err)
+			}
+			backend.collections[resourcePath] = items
+		case bytes.HasPrefix(trimmed, []byte("{")):
+			var item map[string]any
+			if err := json.Unmarshal(trimmed, &item); err != nil {
+				return nil, fmt.Errorf("failed to parse mock backend fixture %s: %w", resourcePath, err)
+			}
+			backend.singletons[resourcePath] = item
+		default:
+			return nil, fmt.Errorf("mock backend fixture %s must be a JSON array or object", resourcePath)
+		}
+	}
+
+	return backend, nil
+}
+
+// OrganizationId returns the ID of the fake PingOne organization
+func (b *Backend) OrganizationId() string {
+	return b.organizationId
+}
+
+// EnvironmentId returns the ID of the environment the fake session authenticates in
+func (b *Backend) EnvironmentId() string {
+	return b.environmentId
+}
+
+// RoundTrip serves the request from the in-memory resources. The host is ignored, so requests for any
+// PingOne region are served.
+func (b *Backend) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body map[string]any
+	if req.Body != nil {
+		defer req.Body.Close()
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := json.Unmarshal(data, &body); err != nil {
+				return b.errorResponse(req, http.StatusBadRequest, "INVALID_DATA", "The request body is not a JSON object"), nil
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status, result := b.serve(req.Method, cleanPath(strings.TrimPrefix(req.URL.Path, "/v1")), req.URL.Query().Get("filter"), body)
+	if _, ok := result["_embedded"]; ok && status == http.StatusOK {
+		// The SDKs follow the HAL links of collections to page through them, and there is only ever one page
+		result["_links"] = map[string]any{
+			"self": map[string]any{"href": req.URL.String()},
+		}
+	}
+	if result == nil {
+		return b.jsonResponse(req, status, nil), nil
+	}
+	if errorCode, ok := result["code"].(string); ok && status >= http.StatusBadRequest {
+		return b.errorResponse(req, status, errorCode, fmt.Sprint(result["message"])), nil
+	}
+	return b.jsonResponse(req, status, result), nil
+}
+
+// serve applies the request to the resources, returning the response status and body
+func (b *Backend) serve(method, resourcePath, filter string, body map[string]any) (int, map[string]any) {
+	if items, ok := b.collections[resourcePath]; ok {
+		return b.serveCollection(method, resourcePath, items, filter, body)
+	}
+	if item, ok := b.singletons[resourcePath]; ok {
+		switch method {
+		case http.MethodGet:
+			return http.StatusOK, item
+		case http.MethodPut:
+			b.singletons[resourcePath] = b.stamp(body, item)
+			return http.StatusOK, b.singletons[resourcePath]
+		}
+		return methodNotAllowed(method)
+	}
+
+	parentPath, name := path.Split(resourcePath)
+	parentPath = strings.TrimSuffix(parentPath, "/")
+	if index := b.findItem(parentPath, name); index >= 0 {
+		return b.serveItem(method, parentPath, index, body)
+	}
+
+	// Resources below an existing resource are created on first use, such as the role assignments of a user
+	if parent, ok := b.resolveItem(parentPath); ok {
+		switch method {
+		case http.MethodGet, http.MethodPost:
+			return b.serveCollection(method, resourcePath, nil, filter, body)
+		case http.MethodPut:
+			// Attributes with their own endpoint, such as a user's enabled status, are merged into the parent
+			if value, ok := body[name]; ok {
+				parent[name] = value
+				parent["updatedAt"] = now()
+				return http.StatusOK, body
+			}
+			b.singletons[resourcePath] = b.stamp(body, nil)
+			return http.StatusOK, b.singletons[resourcePath]
+		}
+	}
+
+	return notFound()
+}
+
+func (b *Backend) serveCollection(method, resourcePath string, items []map[string]any, filter string, body map[string]any) (int, map[string]any) {
+	switch method {
+	case http.MethodGet:
+		matched := []map[string]any{}
+		clauses, ok := parseFilter(filter)
+		for _, item := range items {
+			if !ok || matchesFilter(item, clauses) {
+				matched = append(matched, item)
+			}
+		}
+		return http.StatusOK, map[string]any{
+			"_embedded": map[string]any{
+				path.Base(resourcePath): matched,
+			},
+			"count": len(matched),
+			"size":  len(matched),
+		}
+	case http.MethodPost:
+		if body == nil {
+			return http.StatusBadRequest, map[string]any{"code": "INVALID_DATA", "message": "A request body is required"}
+		}
+		item := b.stamp(body, nil)
+		item["id"] = uuid.NewString()
+		item["createdAt"] = item["updatedAt"]
+		if environmentId, ok := environmentIdFromPath(resourcePath); ok {
+			item["environment"] = map[string]any{"id": environmentId}
+		}
+		if resourcePath == "/environments" {
+			delete(item, "environment")
+			item["organization"] = map[string]any{"id": b.organizationId}
+			if billOfMaterials, ok := item["billOfMaterials"].(map[string]any); ok {
+				b.singletons[resourcePath+"/"+item["id"].(string)+"/billOfMaterials"] = b.stamp(billOfMaterials, nil)
+			}
+		}
+		b.collections[resourcePath] = append(b.collections[resourcePath], item)
+		return http.StatusCreated, item
+	}
+	return methodNotAllowed(method)
+}
+
+func (b *Backend) serveItem(method, collectionPath string, index int, body map[string]any) (int, map[string]any) {
+	item := b.collections[collectionPath][index]
+	switch method {
+	case http.MethodGet:
+		return http.StatusOK, item
+	case http.MethodPut, http.MethodPatch:
+		if body == nil {
+			return http.StatusBadRequest, map[string]any{"code": "INVALID_DATA", "message": "A request body is required"}
+		}
+		updated := body
+		if method == http.MethodPatch {
+			updated = map[string]any{}
+			for key, value := range item {
+				updated[key] = value
+			}
+			for key, value := range body {
+				updated[key] = value
+			}
+		}
+		updated = b.stamp(updated, item)
+		for _, key := range []string{"id", "environment", "organization"} {
+			if value, ok := item[key]; ok {
+				updated[key] = value
+			}
+		}
+		b.collections[collectionPath][index] = updated
+		return http.StatusOK, updated
+	case http.MethodDelete:
+		itemPath := collectionPath + "/" + fmt.Sprint(item["id"])
+		b.collections[collectionPath] = append(b.collections[collectionPath][:index], b.collections[collectionPath][index+1:]...)
+		// Deleting a resource deletes the resources below it
+		for resourcePath := range b.collections {
+			if strings.HasPrefix(resourcePath, itemPath+"/") {
+				delete(b.collections, resourcePath)
+			}
+		}
+		for resourcePath := range b.singletons {
+			if strings.HasPrefix(resourcePath, itemPath+"/") {
+				delete(b.singletons, resourcePath)
+			}
+		}
+		return http.StatusNoContent, nil
+	}
+	return methodNotAllowed(method)
+}
+
+// findItem returns the index of the resource in the collection with the provided ID or name, or -1.
+// Some resources, such as notification templates, are addressed by name.
+func (b *Backend) findItem(collectionPath, idOrName string) int {
+	for i, item := range b.collections[collectionPath] {
+		if fmt.Sprint(item["id"]) == idOrName || fmt.Sprint(item["name"]) == idOrName {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolveItem returns the resource at the provided path, if it exists in a collection
+func (b *Backend) resolveItem(resourcePath string) (map[string]any, bool) {
+	collectionPath, name := path.Split(resourcePath)
+	collectionPath = strings.TrimSuffix(collectionPath, "/")
+	if index := b.findItem(collectionPath, name); index >= 0 {
+		return b.collections[collectionPath][index], true
+	}
+	return nil, false
+}
+
+// stamp returns a copy of the resource with updated timestamps, keeping the creation time of the
+// previous version if there is one
+func (b *Backend) stamp(resource map[string]any, previous map[string]any) map[string]any {
+	stamped := make(map[string]any, len(resource)+2)
+	for key, value := range resource {
+		stamped[key] = value
+	}
+	timestamp := now()
+	stamped["createdAt"] = timestamp
+	if createdAt, ok := previous["createdAt"]; ok {
+		stamped["createdAt"] = createdAt
+	}
+	stamped["updatedAt"] = timestamp
+	return stamped
+}
+
+func (b *Backend) jsonResponse(req *http.Request, status int, body map[string]any) *http.Response {
+	var data []byte
+	if body != nil {
+		// Resources are decoded from JSON, so they can always be encoded
+		data, _ = json.Marshal(body)
+	}
+	// Like PingOne, every response identifies its request with a correlation ID
+	header := http.Header{"Content-Type": []string{"application/json"}, sdk.CorrelationIdHeader: []string{uuid.NewString()}}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(data)),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}
+}
+
+func (b *Backend) errorResponse(req *http.Request, status int, code, message string) *http.Response {
+	return b.jsonResponse(req, status, map[string]any{
+		"id":      uuid.NewString(),
+		"code":    code,
+		"message": message,
+	})
+}
+
+func notFound() (int, map[string]any) {
+	return http.StatusNotFound, map[string]any{"code": "NOT_FOUND", "message": "The requested resource object cannot be found."}
+}
+
+func methodNotAllowed(method string) (int, map[string]any) {
+	return http.StatusMethodNotAllowed, map[string]any{"code": "METHOD_NOT_ALLOWED", "message": fmt.Sprintf("The request method %s is not supported by the mock backend for this resource.", method)}
+}
+
+// environmentIdFromPath returns the environment ID of an environment-scoped resource path
+func environmentIdFromPath(resourcePath string) (string, bool) {
+	segments := strings.Split(strings.TrimPrefix(resourcePath, "/"), "/")
+	if len(segments) >= 3 && segments[0] == "environments" {
+		return segments[1], true
+	}
+	return "", false
+}
+
+func cleanPath(resourcePath string) string {
+	return strings.TrimSuffix(path.Clean("/"+resourcePath), "/")
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
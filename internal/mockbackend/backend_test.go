@@ -0,0 +1,291 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package mockbackend_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/mockbackend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFixtures = `{
+	"organizationId": "11111111-1111-4111-8111-111111111111",
+	"environmentId": "22222222-2222-4222-8222-222222222222",
+	"resources": {
+		"/environments/22222222-2222-4222-8222-222222222222/users": [
+			{"id": "33333333-3333-4333-8333-333333333331", "username": "alice", "enabled": true, "name": {"given": "Alice"}},
+			{"id": "33333333-3333-4333-8333-333333333332", "username": "bob", "enabled": true, "name": {"given": "Bob"}}
+		],
+		"/environments/22222222-2222-4222-8222-222222222222/users/33333333-3333-4333-8333-333333333331/roleAssignments": [
+			{"id": "44444444-4444-4444-8444-444444444441", "role": {"id": "55555555-5555-4555-8555-555555555551"}}
+		],
+		"/environments/22222222-2222-4222-8222-222222222222/templates": [
+			{"id": "66666666-6666-4666-8666-666666666661", "name": "general"}
+		],
+		"/environments/22222222-2222-4222-8222-222222222222/billOfMaterials": {
+			"products": [{"type": "PING_ONE_BASE"}]
+		}
+	}
+}`
+
+const usersPath = "/v1/environments/22222222-2222-4222-8222-222222222222/users"
+
+func newTestBackend(t *testing.T) *mockbackend.Backend {
+	t.Helper()
+	fixturesPath := filepath.Join(t.TempDir(), "fixtures.json")
+	require.NoError(t, os.WriteFile(fixturesPath, []byte(testFixtures), 0600))
+	backend, err := mockbackend.NewBackend(fixturesPath)
+	require.NoError(t, err)
+	return backend
+}
+
+func doRequest(t *testing.T, backend *mockbackend.Backend, method, path string, query url.Values, body any) (int, map[string]any) {
+	t.Helper()
+
+	var requestBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		requestBody = bytes.NewReader(data)
+	}
+	requestUrl := "https://api.pingone.eu" + path
+	if query != nil {
+		requestUrl += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, requestUrl, requestBody)
+	require.NoError(t, err)
+
+	resp, err := backend.RoundTrip(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	result := map[string]any{}
+	if len(data) > 0 {
+		require.NoError(t, json.Unmarshal(data, &result))
+	}
+	return resp.StatusCode, result
+}
+
+func embeddedItems(t *testing.T, result map[string]any, key string) []any {
+	t.Helper()
+	embedded, ok := result["_embedded"].(map[string]any)
+	require.True(t, ok, "response should contain _embedded")
+	items, ok := embedded[key].([]any)
+	require.True(t, ok, "response should contain embedded %s", key)
+	return items
+}
+
+func TestNewBackend_DefaultFixtures(t *testing.T) {
+	backend, err := mockbackend.NewBackend("")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, backend.OrganizationId())
+	assert.NotEmpty(t, backend.EnvironmentId())
+}
+
+func TestNewBackend_InvalidFixtures(t *testing.T) {
+	tests := []struct {
+		name          string
+		fixtures      string
+		expectedError string
+	}{
+		{
+			name:          "invalid JSON",
+			fixtures:      `{`,
+			expectedError: "failed to parse mock backend fixtures",
+		},
+		{
+			name:          "missing organization ID",
+			fixtures:      `{"environmentId": "22222222-2222-4222-8222-222222222222"}`,
+			expectedError: "organizationId",
+		},
+		{
+			name:          "environment export without environment ID",
+			fixtures:      `{"snapshot": {"environment": {"name": "Staging"}}}`,
+			expectedError: "environment export must have an environment with a UUID id",
+		},
+		{
+			name:          "resource is not an array or object",
+			fixtures:      `{"organizationId": "11111111-1111-4111-8111-111111111111", "environmentId": "22222222-2222-4222-8222-222222222222", "resources": {"/environments": "x"}}`,
+			expectedError: "must be a JSON array or object",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixturesPath := filepath.Join(t.TempDir(), "fixtures.json")
+			require.NoError(t, os.WriteFile(fixturesPath, []byte(tt.fixtures), 0600))
+
+			_, err := mockbackend.NewBackend(fixturesPath)
+
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestNewBackend_EnvironmentSnapshot(t *testing.T) {
+	fixturesPath := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(fixturesPath, []byte(`{
+		"environment": {"id": "22222222-2222-4222-8222-222222222222", "name": "Staging", "type": "SANDBOX", "region": "EU", "licenseId": "77777777-7777-4777-8777-777777777771"},
+		"services": [{"type": "PING_ONE_BASE"}],
+		"populations": [{"id": "88888888-8888-4888-8888-888888888881", "name": "Employees"}]
+	}`), 0600))
+
+	backend, err := mockbackend.NewBackend(fixturesPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, "22222222-2222-4222-8222-222222222222", backend.EnvironmentId())
+	status, result := doRequest(t, backend, http.MethodGet, "/v1/environments", nil, nil)
+	assert.Equal(t, http.StatusOK, status)
+	environments := embeddedItems(t, result, "environments")
+	require.Len(t, environments, 1)
+	environment := environments[0].(map[string]any)
+	assert.Equal(t, "Staging", environment["name"])
+	assert.Equal(t, map[string]any{"id": backend.OrganizationId()}, environment["organization"])
+
+	status, result = doRequest(t, backend, http.MethodGet, "/v1/environments/22222222-2222-4222-8222-222222222222/populations", nil, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, embeddedItems(t, result, "populations"), 1)
+
+	status, result = doRequest(t, backend, http.MethodGet, "/v1/environments/22222222-2222-4222-8222-222222222222/groups", nil, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, embeddedItems(t, result, "groups"))
+}
+
+func TestNewBackend_MissingFile(t *testing.T) {
+	_, err := mockbackend.NewBackend(filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.ErrorContains(t, err, "failed to read mock backend fixtures")
+}
+
+func TestBackend_ListCollection(t *testing.T) {
+	backend := newTestBackend(t)
+
+	status, result := doRequest(t, backend, http.MethodGet, usersPath, nil, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, embeddedItems(t, result, "users"), 2)
+	assert.EqualValues(t, 2, result["count"])
+	assert.Contains(t, result, "_links")
+}
+
+func TestBackend_ListCollectionWithFilter(t *testing.T) {
+	backend := newTestBackend(t)
+
+	status, result := doRequest(t, backend, http.MethodGet, usersPath, url.Values{"filter": {`name.given sw "al"`}}, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	users := embeddedItems(t, result, "users")
+	require.Len(t, users, 1)
+	assert.Equal(t, "alice", users[0].(map[string]any)["username"])
+}
+
+func TestBackend_ListEmptyCollectionBelowResource(t *testing.T) {
+	backend := newTestBackend(t)
+
+	status, result := doRequest(t, backend, http.MethodGet, usersPath+"/33333333-3333-4333-8333-333333333332/roleAssignments", nil, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, embeddedItems(t, result, "roleAssignments"))
+}
+
+func TestBackend_CreateAndReadResource(t *testing.T) {
+	backend := newTestBackend(t)
+
+	status, created := doRequest(t, backend, http.MethodPost, usersPath, nil, map[string]any{"username": "carol"})
+	require.Equal(t, http.StatusCreated, status)
+	assert.NotEmpty(t, created["id"])
+	assert.NotEmpty(t, created["createdAt"])
+	assert.Equal(t, map[string]any{"id": "22222222-2222-4222-8222-222222222222"}, created["environment"])
+
+	status, read := doRequest(t, backend, http.MethodGet, usersPath+"/"+created["id"].(string), nil, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "carol", read["username"])
+}
+
+func TestBackend_ReplaceResourceKeepsIdentity(t *testing.T) {
+	backend := newTestBackend(t)
+	userPath := usersPath + "/33333333-3333-4333-8333-333333333331"
+
+	status, updated := doRequest(t, backend, http.MethodPut, userPath, nil, map[string]any{"username": "alice2", "id": "ignored"})
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "alice2", updated["username"])
+	assert.Equal(t, "33333333-3333-4333-8333-333333333331", updated["id"])
+	assert.NotContains(t, updated, "enabled", "PUT should replace the resource")
+}
+
+func TestBackend_PatchResourceMerges(t *testing.T) {
+	backend := newTestBackend(t)
+	userPath := usersPath + "/33333333-3333-4333-8333-333333333331"
+
+	status, updated := doRequest(t, backend, http.MethodPatch, userPath, nil, map[string]any{"username": "alice2"})
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "alice2", updated["username"])
+	assert.Equal(t, true, updated["enabled"])
+}
+
+func TestBackend_ReplaceAttributeUpdatesParent(t *testing.T) {
+	backend := newTestBackend(t)
+	userPath := usersPath + "/33333333-3333-4333-8333-333333333331"
+
+	status, _ := doRequest(t, backend, http.MethodPut, userPath+"/enabled", nil, map[string]any{"enabled": false})
+	require.Equal(t, http.StatusOK, status)
+
+	_, read := doRequest(t, backend, http.MethodGet, userPath, nil, nil)
+	assert.Equal(t, false, read["enabled"])
+}
+
+func TestBackend_DeleteResourceDeletesChildren(t *testing.T) {
+	backend := newTestBackend(t)
+	userPath := usersPath + "/33333333-3333-4333-8333-333333333331"
+
+	status, _ := doRequest(t, backend, http.MethodDelete, userPath, nil, nil)
+	require.Equal(t, http.StatusNoContent, status)
+
+	status, _ = doRequest(t, backend, http.MethodGet, userPath, nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = doRequest(t, backend, http.MethodGet, userPath+"/roleAssignments", nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestBackend_ResourceAddressedByName(t *testing.T) {
+	backend := newTestBackend(t)
+
+	status, result := doRequest(t, backend, http.MethodGet, "/v1/environments/22222222-2222-4222-8222-222222222222/templates/general/contents", nil, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, embeddedItems(t, result, "contents"))
+}
+
+func TestBackend_ReplaceSingleton(t *testing.T) {
+	backend := newTestBackend(t)
+	billOfMaterialsPath := "/v1/environments/22222222-2222-4222-8222-222222222222/billOfMaterials"
+
+	status, _ := doRequest(t, backend, http.MethodPut, billOfMaterialsPath, nil, map[string]any{"products": []any{map[string]any{"type": "PING_ONE_MFA"}}})
+	require.Equal(t, http.StatusOK, status)
+
+	_, read := doRequest(t, backend, http.MethodGet, billOfMaterialsPath, nil, nil)
+	assert.Equal(t, []any{map[string]any{"type": "PING_ONE_MFA"}}, read["products"])
+}
+
+func TestBackend_NotFound(t *testing.T) {
+	backend := newTestBackend(t)
+
+	status, result := doRequest(t, backend, http.MethodGet, "/v1/environments/77777777-7777-4777-8777-777777777777", nil, nil)
+
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "NOT_FOUND", result["code"])
+	assert.NotEmpty(t, result["id"])
+}
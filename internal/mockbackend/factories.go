@@ -0,0 +1,225 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package mockbackend
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	legacysdk "github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-go-client/config"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"golang.org/x/oauth2"
+)
+
+// mockRootDomain is the PingOne root domain the SDK clients are configured with. No requests leave
+// the process, as every client sends its requests to the backend.
+const mockRootDomain = "pingone.com"
+
+// mockScopes are the scopes of the fake access token
+const mockScopes = "openid p1:read:env p1:update:env p1:create:env p1:read:user p1:update:user p1:create:user"
+
+var (
+	_ sdk.ClientFactory        = &ClientFactory{}
+	_ legacy.ClientFactory     = &LegacyClientFactory{}
+	_ client.AuthClientFactory = &AuthClientFactory{}
+	_ client.AuthClient        = &AuthClient{}
+	_ tokenstore.TokenStore    = &TokenStore{}
+)
+
+// ClientFactory creates PingOne Go client SDK clients that send their requests to a transport in the process,
+// such as the backend, instead of PingOne
+type ClientFactory struct {
+	transport http.RoundTripper
+}
+
+func NewClientFactory(transport http.RoundTripper) *ClientFactory {
+	return &ClientFactory{
+		transport: transport,
+	}
+}
+
+func (f *ClientFactory) NewClient(accessToken string) (*pingone.APIClient, error) {
+	if accessToken == "" {
+		return nil, fmt.Errorf("provided accessToken is empty, client cannot be initialized")
+	}
+	clientConfig := config.NewConfiguration().
+		WithAccessToken(accessToken).
+		WithRootDomain(mockRootDomain)
+	pingOneConfig := pingone.NewConfiguration(clientConfig)
+	// Requests are traced like those of PingOne clients, so that tool traces and the API request log work offline
+	pingOneConfig.HTTPClient = &http.Client{Transport: sdk.NewTracingTransport(f.transport)}
+	return pingone.NewAPIClient(pingOneConfig)
+}
+
+// LegacyClientFactory creates legacy PingOne Go SDK clients that send their requests to a transport in the
+// process, such as the backend, instead of PingOne
+type LegacyClientFactory struct {
+	transport http.RoundTripper
+}
+
+func NewLegacyClientFactory(transport http.RoundTripper) *LegacyClientFactory {
+	return &LegacyClientFactory{
+		transport: transport,
+	}
+}
+
+func (f *LegacyClientFactory) NewClient(ctx context.Context, accessToken string) (*legacysdk.Client, error) {
+	if accessToken == "" {
+		return nil, fmt.Errorf("access token is empty, cannot initialize client")
+	}
+	regionCode := management.ENUMREGIONCODE_NA
+	clientConfig := &legacysdk.Config{
+		RegionCode:  &regionCode,
+		AccessToken: &accessToken,
+	}
+	apiClient, err := clientConfig.APIClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize API client: %w", err)
+	}
+	apiClient.ManagementAPIClient.GetConfig().HTTPClient = &http.Client{Transport: sdk.NewTracingTransport(f.transport)}
+	return apiClient, nil
+}
+
+// AuthClientFactory creates auth clients that log in without user interaction, with fake access tokens
+// for an organization
+type AuthClientFactory struct {
+	organizationId string
+
+	mu            sync.RWMutex
+	environmentId string
+}
+
+// NewAuthClientFactory returns a factory of auth clients that log in to the backend.
+func NewAuthClientFactory(backend *Backend) *AuthClientFactory {
+	return NewStaticAuthClientFactory(backend.OrganizationId(), backend.EnvironmentId())
+}
+
+// NewStaticAuthClientFactory returns a factory of auth clients that log in to an environment of an organization,
+// such as those of recorded API interactions.
+func NewStaticAuthClientFactory(organizationId string, environmentId string) *AuthClientFactory {
+	return &AuthClientFactory{
+		organizationId: organizationId,
+		environmentId:  environmentId,
+	}
+}
+
+func (f *AuthClientFactory) NewAuthClient() (client.AuthClient, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return &AuthClient{
+		organizationId: f.organizationId,
+		environmentId:  f.environmentId,
+	}, nil
+}
+
+// SetEnvironmentId changes the environment that auth clients created by the factory log in to, so
+// profiles can be switched in mock mode.
+func (f *AuthClientFactory) SetEnvironmentId(environmentId string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.environmentId = environmentId
+}
+
+// AuthClient issues fake access tokens. Every grant type logs in immediately.
+type AuthClient struct {
+	organizationId string
+	environmentId  string
+}
+
+func (c *AuthClient) TokenSource(ctx context.Context, grantType auth.GrantType) (oauth2.TokenSource, error) {
+	expiry := time.Now().Add(time.Hour)
+	accessToken, err := fakeAccessToken(map[string]any{
+		"sub":       uuid.NewString(),
+		"client_id": uuid.NewString(),
+		"env":       c.environmentId,
+		"org":       c.organizationId,
+		"scope":     mockScopes,
+		"exp":       expiry.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}), nil
+}
+
+// RefreshTokenSource fails, as the fake access tokens have no refresh tokens
+func (c *AuthClient) RefreshTokenSource(ctx context.Context, grantType auth.GrantType, token *oauth2.Token) (oauth2.TokenSource, error) {
+	return nil, errors.New("the mock backend does not issue refresh tokens")
+}
+
+func (c *AuthClient) BrowserLoginAvailable(grantType auth.GrantType) bool {
+	return true
+}
+
+// fakeAccessToken returns an unsigned JWT with the provided claims, so tools that describe the session
+// can read it like a PingOne access token
+func fakeAccessToken(claims map[string]any) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "none", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload) + ".mock", nil
+}
+
+// TokenStore holds the session in memory, so mock sessions never replace a real stored session
+type TokenStore struct {
+	mu      sync.RWMutex
+	session *auth.AuthSession
+}
+
+func NewTokenStore() *TokenStore {
+	return &TokenStore{}
+}
+
+func (s *TokenStore) PutSession(session auth.AuthSession) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = &session
+	return nil
+}
+
+func (s *TokenStore) GetSession() (*auth.AuthSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.session == nil {
+		return nil, errors.New("mock auth session not found")
+	}
+	return s.session, nil
+}
+
+func (s *TokenStore) HasSession() (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.session != nil, nil
+}
+
+func (s *TokenStore) DeleteSession() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.session = nil
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package mockbackend
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// filterClause is a single '<attribute> <operator> "<value>"' comparison of a SCIM filter
+type filterClause struct {
+	path     []string
+	operator string
+	value    string
+}
+
+var filterClauseRegexp = regexp.MustCompile(`^\s*([A-Za-z][\w.]*)\s+(eq|ne|co|sw|ew|gt|ge|lt|le)\s+("(?:[^"\\]|\\.)*"|\S+)\s*$`)
+
+// parseFilter parses the subset of SCIM filters supported by the mock backend: comparisons joined with 'and',
+// optionally in parentheses. Returns false if the filter uses anything else, in which case it is not applied.
+func parseFilter(filter string) ([]filterClause, bool) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, true
+	}
+	if strings.Contains(strings.ToLower(filter), " or ") || strings.Contains(strings.ToLower(filter), "not(") {
+		return nil, false
+	}
+
+	var clauses []filterClause
+	for _, part := range splitAnd(filter) {
+		part = strings.Trim(strings.TrimSpace(part), "()")
+		match := filterClauseRegexp.FindStringSubmatch(part)
+		if match == nil {
+			return nil, false
+		}
+		value := match[3]
+		if strings.HasPrefix(value, `"`) {
+			value = strings.ReplaceAll(strings.Trim(value, `"`), `\"`, `"`)
+		}
+		clauses = append(clauses, filterClause{
+			path:     strings.Split(match[1], "."),
+			operator: strings.ToLower(match[2]),
+			value:    value,
+		})
+	}
+	return clauses, true
+}
+
+// splitAnd splits a filter on the 'and' operators outside quoted values
+func splitAnd(filter string) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	lower := strings.ToLower(filter)
+	for i := 0; i < len(filter); i++ {
+		switch {
+		case filter[i] == '"' && (i == 0 || filter[i-1] != '\\'):
+			inQuotes = !inQuotes
+		case !inQuotes && strings.HasPrefix(lower[i:], " and "):
+			parts = append(parts, filter[start:i])
+			start = i + len(" and ")
+			i = start - 1
+		}
+	}
+	return append(parts, filter[start:])
+}
+
+// matchesFilter returns true if the resource matches every clause. Clauses on attributes the resource
+// does not have are ignored, as some PingOne filter attributes select a range rather than match a
+// resource attribute, such as the dates of the total identities report.
+func matchesFilter(resource map[string]any, clauses []filterClause) bool {
+	for _, clause := range clauses {
+		values, found := attributeValues(resource, clause.path)
+		if !found {
+			continue
+		}
+		matched := false
+		for _, value := range values {
+			if clause.matches(value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (c filterClause) matches(value any) bool {
+	actual := fmt.Sprint(value)
+	switch c.operator {
+	case "eq":
+		return strings.EqualFold(actual, c.value)
+	case "ne":
+		return !strings.EqualFold(actual, c.value)
+	case "co":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(c.value))
+	case "sw":
+		return strings.HasPrefix(strings.ToLower(actual), strings.ToLower(c.value))
+	case "ew":
+		return strings.HasSuffix(strings.ToLower(actual), strings.ToLower(c.value))
+	}
+	// Ordering comparisons are only made between strings, such as RFC 3339 timestamps
+	if _, ok := value.(string); !ok {
+		return true
+	}
+	compared := strings.Compare(actual, c.value)
+	switch c.operator {
+	case "gt":
+		return compared > 0
+	case "ge":
+		return compared >= 0
+	case "lt":
+		return compared < 0
+	default:
+		return compared <= 0
+	}
+}
+
+// attributeValues returns the values at the attribute path, matching attribute names case-insensitively.
+// Arrays along the path contribute a value per element.
+func attributeValues(value any, path []string) ([]any, bool) {
+	if len(path) == 0 {
+		return []any{value}, true
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if strings.EqualFold(key, path[0]) {
+				return attributeValues(child, path[1:])
+			}
+		}
+	case []any:
+		var values []any
+		found := false
+		for _, element := range v {
+			if elementValues, ok := attributeValues(element, path); ok {
+				values = append(values, elementValues...)
+				found = true
+			}
+		}
+		return values, found
+	}
+	return nil, false
+}
@@ -0,0 +1,71 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package mockbackend
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesFilter(t *testing.T) {
+	resource := map[string]any{
+		"username":   "ada.admin",
+		"enabled":    true,
+		"recordedAt": "2025-10-01T08:12:00.000Z",
+		"name":       map[string]any{"given": "Ada"},
+		"resources": []any{
+			map[string]any{"type": "USER"},
+			map[string]any{"type": "APPLICATION"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		filter   string
+		expected bool
+	}{
+		{name: "empty filter", filter: "", expected: true},
+		{name: "eq", filter: `username eq "ada.admin"`, expected: true},
+		{name: "eq is case-insensitive", filter: `UserName eq "ADA.ADMIN"`, expected: true},
+		{name: "eq mismatch", filter: `username eq "ben"`, expected: false},
+		{name: "ne", filter: `username ne "ben"`, expected: true},
+		{name: "sw", filter: `username sw "ada"`, expected: true},
+		{name: "co", filter: `username co "admin"`, expected: true},
+		{name: "ew", filter: `username ew "admin"`, expected: true},
+		{name: "unquoted boolean", filter: `enabled eq true`, expected: true},
+		{name: "dotted path", filter: `name.given eq "Ada"`, expected: true},
+		{name: "any array element", filter: `resources.type eq "APPLICATION"`, expected: true},
+		{name: "string ordering", filter: `recordedAt gt "2025-09-01T00:00:00Z"`, expected: true},
+		{name: "string ordering mismatch", filter: `recordedAt lt "2025-09-01T00:00:00Z"`, expected: false},
+		{name: "and", filter: `username sw "ada" and enabled eq true`, expected: true},
+		{name: "and with mismatch", filter: `username sw "ada" and enabled eq false`, expected: false},
+		{name: "parentheses", filter: `(username sw "ada") and (name.given eq "Ada")`, expected: true},
+		{name: "and inside quoted value", filter: `username ne "ada and ben"`, expected: true},
+		{name: "missing attribute is ignored", filter: `startDate eq "2025-01-01T00:00:00Z"`, expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clauses, ok := parseFilter(tt.filter)
+			require.True(t, ok)
+
+			assert.Equal(t, tt.expected, matchesFilter(resource, clauses))
+		})
+	}
+}
+
+func TestParseFilter_Unsupported(t *testing.T) {
+	tests := []string{
+		`username eq "a" or username eq "b"`,
+		`not(username eq "a")`,
+		`username pr`,
+	}
+	for _, filter := range tests {
+		t.Run(filter, func(t *testing.T) {
+			_, ok := parseFilter(filter)
+
+			assert.False(t, ok)
+		})
+	}
+}
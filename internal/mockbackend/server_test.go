@@ -0,0 +1,293 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package mockbackend_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/mockbackend"
+	"github.com/pingidentity/pingone-mcp-server/internal/server"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/apirequests"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sandboxEnvironmentId    = "0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f"
+	productionEnvironmentId = "8e7d6c5b-4a3f-4e2d-9c1b-0a9f8e7d6c5b"
+	employeesPopulationId   = "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e51"
+	demoPortalApplicationId = "7f8a9b0c-1d2e-4f3a-8b4c-5d6e7f8a9b81"
+	corporateOidcProviderId = "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d91"
+	sandboxSignOnThemeId    = "4d5e6f7a-8b9c-4d0e-9f1a-2b3c4d5e6fb1"
+	ordersApiResourceId     = "6f7a8b9c-0d1e-4f2a-9b3c-4d5e6f7a8bd1"
+	termsOfServiceId        = "f6a7b8c9-d0e1-4f2a-8b3c-4d5e6f7a8b01"
+	adaAdminUserId          = "6d1e2f3a-4b5c-4d6e-8f7a-9b0c1d2e3f61"
+)
+
+// startMockServer runs the server with every tool against the built-in fixtures, returning a connected client session
+func startMockServer(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	return startMockServerWithFixtures(t, "")
+}
+
+// startMockServerWithFixtures runs the server with every tool against the fixtures file, returning a connected
+// client session
+func startMockServerWithFixtures(t *testing.T, fixturesPath string) *mcp.ClientSession {
+	t.Helper()
+
+	backend, err := mockbackend.NewBackend(fixturesPath)
+	require.NoError(t, err)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- server.Start(ctx, serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           mockbackend.NewClientFactory(backend),
+			LegacySdkClientFactory:  mockbackend.NewLegacyClientFactory(backend),
+			AuthClientFactory:       mockbackend.NewAuthClientFactory(backend),
+			TokenStore:              mockbackend.NewTokenStore(),
+			ToolFilter:              filter.NewFilter(false, nil, nil, nil, nil),
+			GrantType:               auth.GrantTypeAuthorizationCode,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
+	}()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = session.Close()
+		cancel()
+		select {
+		case <-serverDone:
+		case <-time.After(time.Second):
+			t.Error("Mock server did not stop as expected")
+		}
+	})
+	return session
+}
+
+func callTool(t *testing.T, session *mcp.ClientSession, name string, arguments map[string]any) map[string]any {
+	t.Helper()
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      name,
+		Arguments: arguments,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	if result.IsError {
+		text := ""
+		for _, content := range result.Content {
+			if textContent, ok := content.(*mcp.TextContent); ok {
+				text += textContent.Text
+			}
+		}
+		require.Failf(t, "Tool call failed", "%s: %s", name, text)
+	}
+
+	structured, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	output := map[string]any{}
+	require.NoError(t, json.Unmarshal(structured, &output))
+	return output
+}
+
+func TestMockBackend_ReadTools(t *testing.T) {
+	session := startMockServer(t)
+
+	tests := []struct {
+		tool      string
+		arguments map[string]any
+	}{
+		{tool: "list_environments", arguments: map[string]any{}},
+		{tool: "get_environment", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_environment_services", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_total_identities_by_environment", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_license_utilization", arguments: map[string]any{}},
+		{tool: "list_populations", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_population", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "populationId": employeesPopulationId}},
+		{tool: "list_applications", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_application", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "applicationId": demoPortalApplicationId}},
+		{tool: "list_identity_providers", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_identity_provider", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "identityProviderId": corporateOidcProviderId}},
+		{tool: "list_themes", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_theme", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "themeId": sandboxSignOnThemeId}},
+		{tool: "list_resources", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "list_resource_scopes", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "resourceId": ordersApiResourceId}},
+		{tool: "list_agreements", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "get_agreement", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "agreementId": termsOfServiceId}},
+		{tool: "query_audit_events", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "startTime": "2025-01-01T00:00:00Z"}},
+		{tool: "get_resource_state_as_of", arguments: map[string]any{"environmentId": sandboxEnvironmentId, "resourceType": "USER", "resourceId": adaAdminUserId, "asOf": "2025-09-01T00:00:00Z"}},
+		{tool: "get_localization_gaps", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "generate_access_review_packet", arguments: map[string]any{"environmentId": sandboxEnvironmentId}},
+		{tool: "whoami", arguments: map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			callTool(t, session, tt.tool, tt.arguments)
+		})
+	}
+}
+
+func TestMockBackend_ListEnvironmentsReturnsFixtures(t *testing.T) {
+	session := startMockServer(t)
+
+	output := callTool(t, session, "list_environments", map[string]any{})
+
+	environments, ok := output["environments"].([]any)
+	require.True(t, ok, "output should contain environments")
+	assert.Len(t, environments, 2)
+}
+
+func TestMockBackend_LicenseUtilizationReturnsFixtures(t *testing.T) {
+	session := startMockServer(t)
+
+	output := callTool(t, session, "get_license_utilization", map[string]any{"date": "2025-10-01T00:00:00Z"})
+
+	summary, ok := output["summary"].(map[string]any)
+	require.True(t, ok, "output should contain a summary")
+	assert.Equal(t, float64(2), summary["licenses"])
+	assert.Equal(t, float64(1), summary["environments"])
+	assert.Equal(t, float64(4), summary["totalIdentities"])
+	assert.Equal(t, float64(0), summary["environmentsWithoutIdentityCount"])
+	assert.Equal(t, float64(1), summary["skippedEnvironments"])
+
+	// The production guardrail does not allow the identities of the PRODUCTION environment to be read
+	skipped, ok := output["skippedEnvironments"].([]any)
+	require.True(t, ok, "output should contain the skipped environments")
+	require.Len(t, skipped, 1)
+	assert.Equal(t, productionEnvironmentId, skipped[0].(map[string]any)["environmentId"])
+}
+
+func TestMockBackend_ProductionGuardrailApplies(t *testing.T) {
+	session := startMockServer(t)
+
+	_, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "create_population",
+		Arguments: map[string]any{"environmentId": productionEnvironmentId, "name": "Partners"},
+	})
+
+	assert.ErrorContains(t, err, "not allowed against PRODUCTION environments")
+}
+
+func TestMockBackend_WritesAreVisibleToReads(t *testing.T) {
+	session := startMockServer(t)
+
+	created := callTool(t, session, "create_population", map[string]any{
+		"environmentId": sandboxEnvironmentId,
+		"name":          "Partners",
+	})
+	population, ok := created["population"].(map[string]any)
+	require.True(t, ok, "output should contain the created population")
+	populationId, ok := population["id"].(string)
+	require.True(t, ok, "created population should have an ID")
+
+	callTool(t, session, "update_population", map[string]any{
+		"environmentId": sandboxEnvironmentId,
+		"populationId":  populationId,
+		"name":          "Channel Partners",
+	})
+
+	read := callTool(t, session, "get_population", map[string]any{
+		"environmentId": sandboxEnvironmentId,
+		"populationId":  populationId,
+	})
+	assert.Equal(t, "Channel Partners", read["population"].(map[string]any)["name"])
+
+	callTool(t, session, "bulk_create_users", map[string]any{
+		"environmentId": sandboxEnvironmentId,
+		"users": []map[string]any{
+			{"username": "new.user", "email": "new.user@example.com"},
+		},
+	})
+}
+
+func TestMockBackend_UserAttributesAreUpdatedPartially(t *testing.T) {
+	session := startMockServer(t)
+
+	updated := callTool(t, session, "update_user_attributes", map[string]any{
+		"environmentId": sandboxEnvironmentId,
+		"userId":        adaAdminUserId,
+		"title":         "Chief Analyst",
+	})
+	require.Len(t, updated["changes"], 1)
+	assert.Equal(t, "title", updated["changes"].([]any)[0].(map[string]any)["attribute"])
+
+	user := updated["user"].(map[string]any)
+	assert.Equal(t, "Chief Analyst", user["title"])
+	assert.NotEmpty(t, user["name"], "attributes that were not provided should be kept")
+	assert.NotEmpty(t, user["email"])
+}
+
+func TestMockBackend_SeededFromEnvironmentExport(t *testing.T) {
+	export := callTool(t, startMockServer(t), "export_environment", map[string]any{"environmentId": sandboxEnvironmentId})
+	exportJson, err := json.Marshal(export)
+	require.NoError(t, err)
+	exportPath := filepath.Join(t.TempDir(), "export.json")
+	require.NoError(t, os.WriteFile(exportPath, exportJson, 0600))
+
+	session := startMockServerWithFixtures(t, exportPath)
+
+	environments := callTool(t, session, "list_environments", map[string]any{})["environments"].([]any)
+	require.Len(t, environments, 1)
+	assert.Equal(t, sandboxEnvironmentId, environments[0].(map[string]any)["id"])
+
+	snapshot := export["snapshot"].(map[string]any)
+	populations := callTool(t, session, "list_populations", map[string]any{"environmentId": sandboxEnvironmentId})["populations"].([]any)
+	assert.Len(t, populations, len(snapshot["populations"].([]any)))
+
+	reexported := callTool(t, session, "export_environment", map[string]any{"environmentId": sandboxEnvironmentId})
+	assert.Equal(t, snapshot, reexported["snapshot"], "exporting the seeded environment should return the export it was seeded from")
+}
+
+func TestMockBackend_CorrelationIdsAreReported(t *testing.T) {
+	session := startMockServer(t)
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "get_environment",
+		Arguments: map[string]any{"environmentId": sandboxEnvironmentId},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	correlationIds, ok := result.Meta[apirequests.MetaKey].([]any)
+	require.True(t, ok, "the result should have the correlation IDs of its requests")
+	require.NotEmpty(t, correlationIds)
+
+	result, err = session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "get_population",
+		Arguments: map[string]any{"environmentId": sandboxEnvironmentId, "populationId": "00000000-0000-4000-8000-000000000000"},
+	})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	envelope, ok := result.StructuredContent.(map[string]any)
+	require.True(t, ok, "the failed call should have an error envelope")
+	failedCorrelationId, ok := envelope["correlationId"].(string)
+	require.True(t, ok, "the error envelope should have the correlation ID of the failed request")
+	assert.Contains(t, envelope["message"], "correlation ID "+failedCorrelationId)
+
+	requests := callTool(t, session, "get_last_api_requests", map[string]any{"limit": 100})["requests"].([]any)
+	require.NotEmpty(t, requests)
+	mostRecent := requests[0].(map[string]any)
+	assert.Equal(t, "get_population", mostRecent["tool"])
+	assert.Equal(t, failedCorrelationId, mostRecent["correlationId"])
+	assert.EqualValues(t, http.StatusNotFound, mostRecent["statusCode"])
+	assert.True(t, slices.ContainsFunc(requests, func(request any) bool {
+		return request.(map[string]any)["correlationId"] == correlationIds[len(correlationIds)-1]
+	}), "the requests of earlier calls should be listed")
+}
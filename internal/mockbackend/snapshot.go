@@ -0,0 +1,92 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package mockbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentexport"
+)
+
+// snapshotDocument is either the output of the export_environment tool or the snapshot it holds
+type snapshotDocument struct {
+	Snapshot    *environmentexport.EnvironmentSnapshot `json:"snapshot"`
+	Environment *environmentexport.ExportedEnvironment `json:"environment"`
+	Resources   json.RawMessage                        `json:"resources"`
+}
+
+// fixturesFromSnapshot returns the fixtures of an environment exported with the export_environment tool, or nil
+// if the data is not an export. The mock session authenticates in the exported environment, in an organization
+// of its own.
+func fixturesFromSnapshot(data []byte) (*Fixtures, error) {
+	var document snapshotDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse mock backend fixtures: %w", err)
+	}
+	if document.Resources != nil {
+		return nil, nil
+	}
+	snapshot := document.Snapshot
+	if snapshot == nil {
+		if document.Environment == nil {
+			return nil, nil
+		}
+		snapshot = &environmentexport.EnvironmentSnapshot{}
+		if err := json.Unmarshal(data, snapshot); err != nil {
+			return nil, fmt.Errorf("failed to parse environment export: %w", err)
+		}
+	}
+
+	environmentId := snapshot.Environment.Id
+	if _, err := uuid.Parse(environmentId); err != nil {
+		return nil, fmt.Errorf("environment export must have an environment with a UUID id: %w", err)
+	}
+	organizationId := uuid.NewSHA1(uuid.NameSpaceURL, []byte("pingone-mcp-server:mock-organization:"+environmentId)).String()
+
+	// The export has no timestamps, which the PingOne API always returns
+	now := time.Now().UTC().Format(time.RFC3339)
+	environment := map[string]any{
+		"id":           environmentId,
+		"name":         snapshot.Environment.Name,
+		"type":         snapshot.Environment.Type,
+		"region":       snapshot.Environment.Region,
+		"license":      map[string]any{"id": snapshot.Environment.LicenseId},
+		"status":       "ACTIVE",
+		"organization": map[string]any{"id": organizationId},
+		"createdAt":    now,
+		"updatedAt":    now,
+	}
+	if snapshot.Environment.Description != nil {
+		environment["description"] = *snapshot.Environment.Description
+	}
+
+	environmentPath := "/environments/" + environmentId
+	resources := map[string]any{
+		"/environments":                       []any{environment},
+		environmentPath + "/billOfMaterials":  map[string]any{"products": snapshot.Services},
+		environmentPath + "/applications":     snapshot.Applications,
+		environmentPath + "/populations":      snapshot.Populations,
+		environmentPath + "/groups":           snapshot.Groups,
+		environmentPath + "/passwordPolicies": snapshot.PasswordPolicies,
+		environmentPath + "/signOnPolicies":   snapshot.SignOnPolicies,
+	}
+	fixtures := &Fixtures{
+		OrganizationId: organizationId,
+		EnvironmentId:  environmentId,
+		Resources:      map[string]json.RawMessage{},
+	}
+	for resourcePath, resource := range resources {
+		raw, err := json.Marshal(resource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert environment export resource %s: %w", resourcePath, err)
+		}
+		if string(raw) == "null" {
+			raw = []byte("[]")
+		}
+		fixtures.Resources[resourcePath] = raw
+	}
+	return fixtures, nil
+}
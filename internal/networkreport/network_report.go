@@ -0,0 +1,294 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package networkreport lists the network connections the server makes for a configuration, so that network
+// teams can approve firewall changes before the server is deployed.
+package networkreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/applications"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	DirectionOutbound = "outbound"
+	DirectionInbound  = "inbound"
+
+	ProtocolHttps = "HTTPS"
+	ProtocolHttp  = "HTTP"
+
+	// AuthorizationCodeCallbackAddress is the loopback address that receives the redirect of the authorization_code
+	// grant type from the user's browser
+	AuthorizationCodeCallbackAddress = "127.0.0.1:7464"
+)
+
+// Config describes the server configuration to report on.
+type Config struct {
+	// RootDomains are the PingOne root domains of the regions the server connects to, such as pingone.com.
+	RootDomains []string
+	// GrantType is the OAuth grant type used to log in to PingOne.
+	GrantType auth.GrantType
+	// Tools are the enabled PingOne tools.
+	Tools []types.ToolDefinition
+	// OtlpEndpoints are the URLs that OpenTelemetry traces and metrics are exported to.
+	OtlpEndpoints []string
+	// PrometheusAddress is the address that metrics are served on for Prometheus to scrape, if any.
+	PrometheusAddress string
+	// HttpAddress is the address the HTTP transport listens on, or empty for the stdio transport.
+	HttpAddress string
+	// OAuthIssuer is the issuer of the access tokens that authenticate HTTP requests, if any.
+	OAuthIssuer string
+}
+
+// Connection is a network connection the server makes or accepts.
+type Connection struct {
+	Direction string `json:"direction"`
+	Host      string `json:"host"`
+	Port      int    `json:"port"`
+	Protocol  string `json:"protocol"`
+	Purpose   string `json:"purpose"`
+	// Tools are the enabled tools that use the connection, when it is only used by tool calls.
+	Tools []string `json:"tools,omitempty"`
+	// Addresses are the IP addresses the host resolved to when the report was generated.
+	Addresses    []string `json:"addresses,omitempty"`
+	ResolveError string   `json:"resolveError,omitempty"`
+}
+
+// Report lists the network connections of a server configuration.
+type Report struct {
+	GeneratedAt time.Time    `json:"generatedAt"`
+	Connections []Connection `json:"connections"`
+	Notes       []string     `json:"notes"`
+}
+
+// Resolver looks up the IP addresses of a host. It is implemented by net.Resolver.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// Build returns the network connections of the configuration. Addresses are not resolved.
+func Build(config Config) (*Report, error) {
+	if len(config.RootDomains) == 0 {
+		return nil, errors.New("no PingOne root domain is configured")
+	}
+
+	report := &Report{
+		GeneratedAt: time.Now().UTC(),
+		Connections: []Connection{},
+		Notes: []string{
+			"IP addresses are those the hosts resolved to when the report was generated and can change. Prefer firewall rules by hostname where supported.",
+		},
+	}
+
+	var toolNames []string
+	hasOidcDiscoveryTool := false
+	for _, toolDef := range config.Tools {
+		toolNames = append(toolNames, toolDef.McpTool.Name)
+		if toolDef.McpTool.Name == applications.GetOidcDiscoveryDef.McpTool.Name {
+			hasOidcDiscoveryTool = true
+		}
+	}
+
+	rootDomains := make([]string, 0, len(config.RootDomains))
+	for _, rootDomain := range config.RootDomains {
+		rootDomain = strings.ToLower(strings.TrimSpace(rootDomain))
+		if rootDomain != "" && !slices.Contains(rootDomains, rootDomain) {
+			rootDomains = append(rootDomains, rootDomain)
+		}
+	}
+	for _, rootDomain := range rootDomains {
+		report.Connections = append(report.Connections, Connection{
+			Direction: DirectionOutbound,
+			Host:      "auth." + rootDomain,
+			Port:      443,
+			Protocol:  ProtocolHttps,
+			Purpose:   fmt.Sprintf("PingOne authentication: logging in with the %s grant type and refreshing access tokens", config.GrantType),
+		})
+		if len(toolNames) > 0 {
+			report.Connections = append(report.Connections, Connection{
+				Direction: DirectionOutbound,
+				Host:      "api." + rootDomain,
+				Port:      443,
+				Protocol:  ProtocolHttps,
+				Purpose:   "PingOne management API requests of tool calls, and environment lookups that validate them",
+				Tools:     toolNames,
+			})
+		}
+	}
+	if hasOidcDiscoveryTool {
+		report.Notes = append(report.Notes, fmt.Sprintf("The %s tool also retrieves the JSON Web Key Set from the jwks_uri of each discovery document, which is on the PingOne authentication host unless the environment uses a custom domain.", applications.GetOidcDiscoveryDef.McpTool.Name))
+	}
+	if config.GrantType.IsInteractive() {
+		report.Notes = append(report.Notes, "Users log in with a browser, which must also be able to reach the PingOne authentication host.")
+	}
+	if config.GrantType == auth.GrantTypeAuthorizationCode {
+		report.Connections = append(report.Connections, loopbackConnection(AuthorizationCodeCallbackAddress, ProtocolHttp, "Login redirect from the user's browser on the same machine, only while logging in"))
+	}
+
+	if config.OAuthIssuer != "" {
+		issuer, err := parseEndpoint(config.OAuthIssuer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OAuth issuer: %w", err)
+		}
+		issuer.Purpose = "OpenID Connect discovery document and JSON Web Key Set of the OAuth issuer that authenticates HTTP clients"
+		report.Connections = append(report.Connections, issuer)
+		report.Notes = append(report.Notes, "The JSON Web Key Set of the OAuth issuer is retrieved from the jwks_uri of its discovery document, which can be on another host.")
+	}
+
+	for _, otlpEndpoint := range config.OtlpEndpoints {
+		connection, err := parseEndpoint(otlpEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OpenTelemetry endpoint: %w", err)
+		}
+		connection.Purpose = "OpenTelemetry traces and metrics exported with OTLP"
+		report.Connections = append(report.Connections, connection)
+	}
+
+	if config.HttpAddress != "" {
+		connection, err := inboundConnection(config.HttpAddress, ProtocolHttp, "MCP clients connecting to the HTTP transport")
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP address: %w", err)
+		}
+		report.Connections = append(report.Connections, connection)
+	}
+	if config.PrometheusAddress != "" {
+		connection, err := inboundConnection(config.PrometheusAddress, ProtocolHttp, "Prometheus scraping metrics")
+		if err != nil {
+			return nil, fmt.Errorf("invalid Prometheus address: %w", err)
+		}
+		report.Connections = append(report.Connections, connection)
+	}
+
+	return report, nil
+}
+
+// Resolve looks up the IP addresses of the hosts of outbound connections.
+func (r *Report) Resolve(ctx context.Context, resolver Resolver) {
+	for i, connection := range r.Connections {
+		if connection.Direction != DirectionOutbound {
+			continue
+		}
+		if ip := net.ParseIP(connection.Host); ip != nil {
+			r.Connections[i].Addresses = []string{ip.String()}
+			continue
+		}
+		ipAddrs, err := resolver.LookupIPAddr(ctx, connection.Host)
+		if err != nil {
+			r.Connections[i].ResolveError = err.Error()
+			continue
+		}
+		addresses := make([]string, 0, len(ipAddrs))
+		for _, ipAddr := range ipAddrs {
+			if address := ipAddr.IP.String(); !slices.Contains(addresses, address) {
+				addresses = append(addresses, address)
+			}
+		}
+		slices.Sort(addresses)
+		r.Connections[i].Addresses = addresses
+	}
+}
+
+// WriteText writes the report as a table, for pasting into firewall change requests.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "PingOne MCP server network connections, generated %s\n\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintln(tw, "DIRECTION\tHOST\tPORT\tPROTOCOL\tRESOLVED ADDRESSES\tPURPOSE")
+	for _, connection := range r.Connections {
+		addresses := strings.Join(connection.Addresses, ", ")
+		if connection.ResolveError != "" {
+			addresses = "unresolved: " + connection.ResolveError
+		}
+		if addresses == "" {
+			addresses = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n", connection.Direction, connection.Host, connection.Port, connection.Protocol, addresses, connection.Purpose)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, connection := range r.Connections {
+		if len(connection.Tools) > 0 {
+			fmt.Fprintf(w, "\nTools using %s: %s\n", connection.Host, strings.Join(connection.Tools, ", "))
+		}
+	}
+	if len(r.Notes) > 0 {
+		fmt.Fprintln(w, "\nNotes:")
+		for _, note := range r.Notes {
+			fmt.Fprintf(w, "- %s\n", note)
+		}
+	}
+	return nil
+}
+
+// parseEndpoint returns the outbound connection to the host and port of an http or https URL
+func parseEndpoint(rawUrl string) (Connection, error) {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return Connection{}, err
+	}
+	connection := Connection{
+		Direction: DirectionOutbound,
+		Host:      parsed.Hostname(),
+	}
+	switch parsed.Scheme {
+	case "https":
+		connection.Protocol = ProtocolHttps
+		connection.Port = 443
+	case "http":
+		connection.Protocol = ProtocolHttp
+		connection.Port = 80
+	default:
+		return Connection{}, fmt.Errorf("%s must be an http or https URL", rawUrl)
+	}
+	if connection.Host == "" {
+		return Connection{}, fmt.Errorf("%s has no host", rawUrl)
+	}
+	if port := parsed.Port(); port != "" {
+		if connection.Port, err = strconv.Atoi(port); err != nil {
+			return Connection{}, fmt.Errorf("%s has an invalid port", rawUrl)
+		}
+	}
+	return connection, nil
+}
+
+// inboundConnection returns the inbound connection to a listening address in host:port form
+func inboundConnection(address string, protocol string, purpose string) (Connection, error) {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return Connection{}, err
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return Connection{}, fmt.Errorf("invalid port in %s", address)
+	}
+	if host == "" {
+		host = "0.0.0.0"
+	}
+	return Connection{
+		Direction: DirectionInbound,
+		Host:      host,
+		Port:      port,
+		Protocol:  protocol,
+		Purpose:   purpose,
+	}, nil
+}
+
+// loopbackConnection returns an inbound connection on a fixed loopback address
+func loopbackConnection(address string, protocol string, purpose string) Connection {
+	// The address is a constant, so it is always valid
+	connection, _ := inboundConnection(address, protocol, purpose)
+	return connection
+}
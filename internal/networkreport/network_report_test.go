@@ -0,0 +1,144 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package networkreport_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/networkreport"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/applications"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubResolver map[string][]string
+
+func (r stubResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	addresses, ok := r[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	var ipAddrs []net.IPAddr
+	for _, address := range addresses {
+		ipAddrs = append(ipAddrs, net.IPAddr{IP: net.ParseIP(address)})
+	}
+	return ipAddrs, nil
+}
+
+func hosts(report *networkreport.Report) []string {
+	var hosts []string
+	for _, connection := range report.Connections {
+		hosts = append(hosts, connection.Host)
+	}
+	return hosts
+}
+
+func TestBuild_PingOneHosts(t *testing.T) {
+	report, err := networkreport.Build(networkreport.Config{
+		RootDomains: []string{"pingone.eu", " PINGONE.EU ", "pingone.com"},
+		GrantType:   auth.GrantTypeClientCredentials,
+		Tools:       []types.ToolDefinition{environments.ListEnvironmentsDef},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"auth.pingone.eu", "api.pingone.eu", "auth.pingone.com", "api.pingone.com"}, hosts(report))
+	for _, connection := range report.Connections {
+		assert.Equal(t, networkreport.DirectionOutbound, connection.Direction)
+		assert.Equal(t, 443, connection.Port)
+		assert.Equal(t, networkreport.ProtocolHttps, connection.Protocol)
+	}
+	assert.Equal(t, []string{environments.ListEnvironmentsDef.McpTool.Name}, report.Connections[1].Tools)
+}
+
+func TestBuild_NoToolsOnlyNeedsAuthentication(t *testing.T) {
+	report, err := networkreport.Build(networkreport.Config{
+		RootDomains: []string{"pingone.com"},
+		GrantType:   auth.GrantTypeClientCredentials,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"auth.pingone.com"}, hosts(report))
+}
+
+func TestBuild_AuthorizationCodeCallback(t *testing.T) {
+	report, err := networkreport.Build(networkreport.Config{
+		RootDomains: []string{"pingone.com"},
+		GrantType:   auth.GrantTypeAuthorizationCode,
+		Tools:       []types.ToolDefinition{applications.GetOidcDiscoveryDef},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Connections, 3)
+	callback := report.Connections[2]
+	assert.Equal(t, networkreport.DirectionInbound, callback.Direction)
+	assert.Equal(t, "127.0.0.1", callback.Host)
+	assert.Equal(t, 7464, callback.Port)
+	assert.Contains(t, report.Notes, "Users log in with a browser, which must also be able to reach the PingOne authentication host.")
+	assert.Len(t, report.Notes, 3, "the discovery tool should add a note about JSON Web Key Sets")
+}
+
+func TestBuild_OptionalFeatures(t *testing.T) {
+	report, err := networkreport.Build(networkreport.Config{
+		RootDomains:       []string{"pingone.com"},
+		GrantType:         auth.GrantTypeDeviceCode,
+		OtlpEndpoints:     []string{"https://otel.example.com", "http://localhost:4318"},
+		PrometheusAddress: "localhost:9464",
+		HttpAddress:       ":8080",
+		OAuthIssuer:       "https://auth.pingone.com/0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f/as",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Connections, 6)
+	assert.Equal(t, networkreport.Connection{Direction: networkreport.DirectionOutbound, Host: "auth.pingone.com", Port: 443, Protocol: networkreport.ProtocolHttps, Purpose: report.Connections[1].Purpose}, report.Connections[1])
+	assert.Equal(t, networkreport.Connection{Direction: networkreport.DirectionOutbound, Host: "otel.example.com", Port: 443, Protocol: networkreport.ProtocolHttps, Purpose: report.Connections[2].Purpose}, report.Connections[2])
+	assert.Equal(t, networkreport.Connection{Direction: networkreport.DirectionOutbound, Host: "localhost", Port: 4318, Protocol: networkreport.ProtocolHttp, Purpose: report.Connections[3].Purpose}, report.Connections[3])
+	assert.Equal(t, networkreport.Connection{Direction: networkreport.DirectionInbound, Host: "0.0.0.0", Port: 8080, Protocol: networkreport.ProtocolHttp, Purpose: report.Connections[4].Purpose}, report.Connections[4])
+	assert.Equal(t, networkreport.Connection{Direction: networkreport.DirectionInbound, Host: "localhost", Port: 9464, Protocol: networkreport.ProtocolHttp, Purpose: report.Connections[5].Purpose}, report.Connections[5])
+}
+
+func TestBuild_Errors(t *testing.T) {
+	_, err := networkreport.Build(networkreport.Config{})
+	assert.ErrorContains(t, err, "no PingOne root domain is configured")
+
+	_, err = networkreport.Build(networkreport.Config{RootDomains: []string{"pingone.com"}, OtlpEndpoints: []string{"grpc://otel:4317"}})
+	assert.ErrorContains(t, err, "invalid OpenTelemetry endpoint")
+
+	_, err = networkreport.Build(networkreport.Config{RootDomains: []string{"pingone.com"}, HttpAddress: "localhost"})
+	assert.ErrorContains(t, err, "invalid HTTP address")
+}
+
+func TestReport_ResolveAndWriteText(t *testing.T) {
+	report, err := networkreport.Build(networkreport.Config{
+		RootDomains:   []string{"pingone.com"},
+		GrantType:     auth.GrantTypeAuthorizationCode,
+		Tools:         []types.ToolDefinition{environments.ListEnvironmentsDef},
+		OtlpEndpoints: []string{"http://10.0.0.5:4318"},
+	})
+	require.NoError(t, err)
+
+	report.Resolve(context.Background(), stubResolver{
+		"api.pingone.com": {"192.0.2.20", "192.0.2.10", "192.0.2.20"},
+	})
+
+	assert.Equal(t, "no such host", report.Connections[0].ResolveError)
+	assert.Equal(t, []string{"192.0.2.10", "192.0.2.20"}, report.Connections[1].Addresses)
+	assert.Empty(t, report.Connections[2].Addresses, "inbound connections should not be resolved")
+	assert.Equal(t, []string{"10.0.0.5"}, report.Connections[3].Addresses)
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteText(&out))
+	text := out.String()
+	assert.Contains(t, text, "DIRECTION")
+	assert.Contains(t, text, "api.pingone.com")
+	assert.Contains(t, text, "192.0.2.10, 192.0.2.20")
+	assert.Contains(t, text, "unresolved: no such host")
+	assert.Contains(t, text, "Tools using api.pingone.com: list_environments")
+	assert.Contains(t, text, "Notes:")
+}
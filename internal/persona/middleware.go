@@ -0,0 +1,55 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package persona
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolDescriptionMiddleware appends the guidance of a persona to the descriptions of the tools listed to
+// MCP clients. The registered tool definitions are not changed.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type ToolDescriptionMiddleware struct {
+	persona *Persona
+}
+
+// NewToolDescriptionMiddleware creates middleware that tunes tool descriptions to the persona.
+// A nil persona leaves descriptions unchanged.
+func NewToolDescriptionMiddleware(persona *Persona) *ToolDescriptionMiddleware {
+	return &ToolDescriptionMiddleware{
+		persona: persona,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ToolDescriptionMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err != nil || method != "tools/list" || m.persona == nil {
+			return result, err
+		}
+
+		listResult, ok := result.(*mcp.ListToolsResult)
+		if !ok {
+			return result, err
+		}
+
+		tuned := *listResult
+		tuned.Tools = make([]*mcp.Tool, len(listResult.Tools))
+		for i, tool := range listResult.Tools {
+			guidance := m.persona.Guidance(tool.Name)
+			if guidance == "" {
+				tuned.Tools[i] = tool
+				continue
+			}
+			// Copy the tool, as the listed tools are shared with the server's tool registry
+			tunedTool := *tool
+			tunedTool.Description = tool.Description + "\n\n" + guidance
+			tuned.Tools[i] = &tunedTool
+		}
+		return &tuned, nil
+	}
+}
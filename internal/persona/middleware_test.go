@@ -0,0 +1,72 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package persona_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/persona"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listThingsInput struct{}
+
+func listToolsOverMcp(t *testing.T, p *persona.Persona) map[string]string {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(persona.NewToolDescriptionMiddleware(p).Handler)
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input listThingsInput) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	}
+	mcp.AddTool(server, &mcp.Tool{Name: "list_things", Description: "List things"}, handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "create_thing", Description: "Create a thing"}, handler)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+
+	// The registered tools must not be changed, so listing again returns the same descriptions
+	again, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	require.Equal(t, result.Tools, again.Tools)
+
+	descriptions := map[string]string{}
+	for _, tool := range result.Tools {
+		descriptions[tool.Name] = tool.Description
+	}
+	return descriptions
+}
+
+func TestToolDescriptionMiddleware_AppendsGuidance(t *testing.T) {
+	p := &persona.Persona{
+		Name: "test",
+		ToolGuidance: map[string]string{
+			"create_thing": "Only create things in SANDBOX environments.",
+		},
+	}
+
+	descriptions := listToolsOverMcp(t, p)
+
+	assert.Equal(t, "Create a thing\n\nOnly create things in SANDBOX environments.", descriptions["create_thing"])
+	assert.Equal(t, "List things", descriptions["list_things"])
+}
+
+func TestToolDescriptionMiddleware_NilPersona(t *testing.T) {
+	descriptions := listToolsOverMcp(t, nil)
+
+	assert.Equal(t, "Create a thing", descriptions["create_thing"])
+	assert.Equal(t, "List things", descriptions["list_things"])
+}
@@ -0,0 +1,297 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package persona provides predefined bundles of tools for common roles, so that teams get a focused tool
+// surface with guardrails suited to the role without hand-crafting tool allowlists.
+package persona
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// commonTools are the server tools included in every persona, to manage the PingOne session, review
+// the changes made and resources used through the server, follow background jobs, build filters for the
+// tools that take them, and find the PingOne API requests to quote in support cases
+var commonTools = []string{
+	"login",
+	"logout",
+	"whoami",
+	"switch_profile",
+	"query_mutation_audit_log",
+	"show_session_resource_graph",
+	"get_job_status",
+	"list_jobs",
+	"cancel_job",
+	"build_scim_filter",
+	"get_last_api_requests",
+}
+
+// Persona is a curated subset of tools for a role, with guardrails and tool descriptions tuned to it.
+type Persona struct {
+	Name        string
+	Description string
+	// Instructions are sent to MCP clients on initialization to describe the role the server is set up for.
+	Instructions string
+	// Tools are the names of the tools enabled for the persona, in addition to the common session tools.
+	// Write tools are only enabled when read-only mode is disabled.
+	Tools []string
+	// ReadOnly forces read-only mode, even if it is disabled.
+	ReadOnly bool
+	// AllowProductionWrite permits the production access overrides that allow writes to PRODUCTION
+	// environments. Without it, PRODUCTION environments can only be changed according to the production
+	// guardrail.
+	AllowProductionWrite bool
+	// ToolGuidance is appended to the descriptions of tools, keyed by tool name.
+	ToolGuidance map[string]string
+}
+
+var personas = []Persona{
+	{
+		Name:        "helpdesk",
+		Description: "Onboard users, unlock and reset their passwords, and look up environments, populations and audit events for support requests",
+		Instructions: "This PingOne MCP server is set up for helpdesk work: onboarding users into populations, enabling, unlocking and resetting the passwords of users, " +
+			"and looking up environments, populations and audit events to answer support requests. Confirm the environment and population with the user before creating users.",
+		Tools: []string{
+			"list_environments",
+			"get_environment",
+			"list_populations",
+			"get_population",
+			"get_total_identities_by_environment",
+			"count_users",
+			"query_audit_events",
+			"find_user",
+			"set_user_enabled",
+			"update_user_attributes",
+			"unlock_user_password",
+			"get_user_password_status",
+			"check_password_against_policy",
+			"reset_user_password",
+			"bulk_create_users",
+			"import_scim_users",
+		},
+		ToolGuidance: map[string]string{
+			"find_user":                     "Use this to look up the user a support request is about before querying their audit events.",
+			"set_user_enabled":              "Confirm the username with the user before disabling an account.",
+			"update_user_attributes":        "Only pass the attributes the user asked to change.",
+			"unlock_user_password":          "Prefer this over reset_user_password when the user still knows their password but is locked out.",
+			"reset_user_password":           "Keep forceChange on so that a new password is only temporary, and never repeat the password back in chat.",
+			"get_user_password_status":      "Use this first when a user cannot sign on with their password, to tell a lockout from an expired password.",
+			"check_password_against_policy": "Use this to explain which rules a rejected password failed, without repeating the password back in chat.",
+			"bulk_create_users":             "Show the list of users to the user and confirm the population before creating them.",
+			"import_scim_users":             "Run with dryRun first and show the validation results to the user before importing users.",
+			"query_audit_events":            "Use this to find out what happened to a user or application when answering a support request.",
+		},
+	},
+	{
+		Name:        "developer",
+		Description: "Set up applications, test users and populations in development environments",
+		Instructions: "This PingOne MCP server is set up for application developers: creating and configuring OIDC applications, " +
+			"test users and populations in development environments, and checking sign-in configuration. Changes to PRODUCTION environments are blocked.",
+		Tools: []string{
+			"list_environments",
+			"get_environment",
+			"get_environment_services",
+			"create_environment",
+			"clone_environment",
+			"export_environment",
+			"compare_environments",
+			"list_applications",
+			"get_application",
+			"get_oidc_discovery",
+			"create_oidc_application",
+			"update_oidc_application",
+			"list_resources",
+			"list_resource_scopes",
+			"create_resource",
+			"create_resource_scope",
+			"list_davinci_flows",
+			"get_davinci_flow",
+			"list_davinci_flow_policies",
+			"list_decision_endpoints",
+			"get_decision_endpoint",
+			"list_authorization_policies",
+			"get_authorization_policy",
+			"test_authorize_decision",
+			"list_populations",
+			"get_population",
+			"create_population",
+			"bulk_create_users",
+			"bulk_delete_users",
+			"query_audit_events",
+			"verify_webhook_event",
+			"get_localization_gaps",
+			"undo_last_change",
+		},
+		ToolGuidance: map[string]string{
+			"create_environment":      "Create SANDBOX environments for development and testing.",
+			"clone_environment":       "Use this to set up development and demo environments that match an existing environment.",
+			"create_oidc_application": "Create applications in SANDBOX environments; PRODUCTION environments cannot be changed with this configuration.",
+			"update_oidc_application": "Only update applications in SANDBOX environments; PRODUCTION environments cannot be changed with this configuration.",
+			"create_resource":         "Create resources for the APIs of applications in SANDBOX environments; PRODUCTION environments cannot be changed with this configuration.",
+			"bulk_create_users":       "Use this to create test users in SANDBOX environments.",
+			"bulk_delete_users":       "Use this to clean up test users in SANDBOX environments. Show the previewed users to the user before deleting them.",
+		},
+	},
+	{
+		Name:        "security-auditor",
+		Description: "Review audit events, configuration changes and access without making any changes",
+		Instructions: "This PingOne MCP server is set up for security auditing and is read-only: reviewing audit events, configuration changes, " +
+			"application configuration and access. Report findings to the user rather than suggesting tools to change configuration.",
+		Tools: []string{
+			"list_environments",
+			"get_environment",
+			"get_environment_services",
+			"export_environment",
+			"compare_environments",
+			"list_scheduled_environment_deletions",
+			"list_applications",
+			"get_application",
+			"get_oidc_discovery",
+			"list_populations",
+			"get_population",
+			"find_user",
+			"list_identity_providers",
+			"get_identity_provider",
+			"list_resources",
+			"list_resource_scopes",
+			"list_roles",
+			"list_user_role_assignments",
+			"list_group_role_assignments",
+			"list_application_role_assignments",
+			"get_total_identities_by_environment",
+			"query_audit_events",
+			"verify_webhook_event",
+			"get_resource_state_as_of",
+			"get_environment_changes_since",
+			"get_mfa_sign_on_metrics",
+			"generate_access_review_packet",
+			"record_access_review_decisions",
+		},
+		ReadOnly: true,
+		ToolGuidance: map[string]string{
+			"get_environment_changes_since": "Start a review of configuration drift here, then use get_resource_state_as_of to compare individual resources.",
+			"query_mutation_audit_log":      "Use this alongside query_audit_events to tell changes made by agents through this server from changes made by other means.",
+		},
+	},
+	{
+		Name:        "environment-admin",
+		Description: "Manage environments, their services, populations and administrator roles, including PRODUCTION environments that the deployment is approved to change",
+		Instructions: "This PingOne MCP server is set up for environment administration: creating, updating and deleting environments, " +
+			"managing their services, populations and administrator role assignments, and reviewing configuration changes. Check the environment type with get_environment before any change.",
+		Tools: []string{
+			"list_environments",
+			"get_environment",
+			"get_environment_services",
+			"create_environment",
+			"clone_environment",
+			"export_environment",
+			"compare_environments",
+			"update_environment",
+			"update_environment_services",
+			"add_environment_service",
+			"remove_environment_service",
+			"list_scheduled_environment_deletions",
+			"schedule_environment_deletion",
+			"cancel_environment_deletion",
+			"reassign_environment_license",
+			"get_license_utilization",
+			"list_alert_channels",
+			"create_alert_channel",
+			"update_alert_channel",
+			"list_populations",
+			"get_population",
+			"create_population",
+			"update_population",
+			"bulk_delete_populations",
+			"list_themes",
+			"get_theme",
+			"create_theme",
+			"update_theme",
+			"activate_theme",
+			"list_agreements",
+			"get_agreement",
+			"create_agreement",
+			"update_agreement",
+			"set_agreement_enabled",
+			"create_agreement_revision",
+			"list_credential_types",
+			"get_credential_issuer_profile",
+			"create_credential_type",
+			"update_credential_type",
+			"list_user_credentials",
+			"revoke_user_credential",
+			"list_applications",
+			"get_application",
+			"list_identity_providers",
+			"get_identity_provider",
+			"create_identity_provider",
+			"update_identity_provider",
+			"delete_identity_provider",
+			"create_identity_provider_attribute_mapping",
+			"update_identity_provider_attribute_mapping",
+			"delete_identity_provider_attribute_mapping",
+			"list_davinci_flows",
+			"get_davinci_flow",
+			"list_davinci_flow_policies",
+			"get_total_identities_by_environment",
+			"query_audit_events",
+			"get_resource_state_as_of",
+			"get_environment_changes_since",
+			"get_localization_gaps",
+			"list_roles",
+			"list_user_role_assignments",
+			"list_group_role_assignments",
+			"list_application_role_assignments",
+			"assign_role_to_user",
+			"assign_role_to_group",
+			"assign_role_to_application",
+			"remove_role_assignment",
+			"undo_last_change",
+		},
+		AllowProductionWrite: true,
+		ToolGuidance: map[string]string{
+			"schedule_environment_deletion": "Confirm the environment name and type with the user before scheduling its deletion.",
+			"update_environment_services":   "Check which services the environment's applications use with get_environment_services before removing any.",
+			"remove_environment_service":    "Check which services the environment's applications use with get_environment_services before removing any.",
+			"remove_role_assignment":        "Check with list_user_role_assignments that another administrator keeps access to the environment before removing an Environment Admin or Organization Admin role.",
+			"delete_identity_provider":      "Consider disabling the identity provider with update_identity_provider first, as users who sign on with it lose access.",
+			"activate_theme":                "Check the theme with get_theme before activating it, as the sign-on pages of the environment change for every user straight away.",
+			"create_agreement_revision":     "Confirm with the user whether users who already consented must consent again before setting requireReconsent.",
+		},
+	},
+}
+
+// Get returns the persona with the given name.
+func Get(name string) (*Persona, error) {
+	for _, p := range personas {
+		if p.Name == name {
+			return &p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown persona %q, valid personas are: %s", name, strings.Join(Names(), ", "))
+}
+
+// List returns all personas.
+func List() []Persona {
+	return slices.Clone(personas)
+}
+
+// Names returns the names of all personas.
+func Names() []string {
+	names := make([]string, len(personas))
+	for i, p := range personas {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// ToolNames returns the names of all tools enabled by the persona, including the common session tools.
+func (p *Persona) ToolNames() []string {
+	return append(slices.Clone(commonTools), p.Tools...)
+}
+
+// Guidance returns the guidance appended to the description of the tool, or an empty string if there is none.
+func (p *Persona) Guidance(toolName string) string {
+	return p.ToolGuidance[toolName]
+}
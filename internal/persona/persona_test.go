@@ -0,0 +1,95 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package persona_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/sessiontools"
+	"github.com/pingidentity/pingone-mcp-server/internal/persona"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/apirequests"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resourcegraph"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serverToolDefs returns the definitions of all tools the server can register that are subject to the tool filter
+func serverToolDefs() map[string]types.ToolDefinition {
+	toolDefs := map[string]types.ToolDefinition{}
+	for _, toolDef := range tools.ListTools() {
+		toolDefs[toolDef.McpTool.Name] = toolDef
+	}
+	for _, toolDef := range sessiontools.ListTools() {
+		toolDefs[toolDef.McpTool.Name] = toolDef
+	}
+	toolDefs[profile.SwitchProfileDef.McpTool.Name] = profile.SwitchProfileDef
+	toolDefs[auditlog.QueryMutationAuditLogDef.McpTool.Name] = auditlog.QueryMutationAuditLogDef
+	toolDefs[rollback.UndoLastChangeDef.McpTool.Name] = rollback.UndoLastChangeDef
+	toolDefs[resourcegraph.ShowSessionResourceGraphDef.McpTool.Name] = resourcegraph.ShowSessionResourceGraphDef
+	toolDefs[apirequests.GetLastApiRequestsDef.McpTool.Name] = apirequests.GetLastApiRequestsDef
+	for _, toolDef := range jobs.ListTools() {
+		toolDefs[toolDef.McpTool.Name] = toolDef
+	}
+	return toolDefs
+}
+
+func TestPersonas_ToolsExist(t *testing.T) {
+	toolDefs := serverToolDefs()
+	for _, p := range persona.List() {
+		t.Run(p.Name, func(t *testing.T) {
+			assert.NotEmpty(t, p.Description)
+			assert.NotEmpty(t, p.Instructions)
+			for _, toolName := range p.ToolNames() {
+				assert.Contains(t, toolDefs, toolName, "persona tool should be a server tool")
+			}
+			for toolName := range p.ToolGuidance {
+				assert.Contains(t, p.ToolNames(), toolName, "tool guidance should only be given for tools of the persona")
+			}
+		})
+	}
+}
+
+func TestPersonas_ReadOnlyPersonasOnlyHaveReadOnlyTools(t *testing.T) {
+	toolDefs := serverToolDefs()
+	for _, p := range persona.List() {
+		if !p.ReadOnly {
+			continue
+		}
+		t.Run(p.Name, func(t *testing.T) {
+			for _, toolName := range p.Tools {
+				toolDef := toolDefs[toolName]
+				assert.True(t, toolDef.IsReadOnly(), "tool %s of a read-only persona should be read-only", toolName)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	p, err := persona.Get("security-auditor")
+	require.NoError(t, err)
+	assert.Equal(t, "security-auditor", p.Name)
+	assert.True(t, p.ReadOnly)
+	assert.Contains(t, p.ToolNames(), "login")
+	assert.Contains(t, p.ToolNames(), "query_audit_events")
+
+	_, err = persona.Get("unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown persona "unknown"`)
+	assert.Contains(t, err.Error(), "helpdesk, developer, security-auditor, environment-admin")
+}
+
+func TestGet_ReturnsCopy(t *testing.T) {
+	p, err := persona.Get("helpdesk")
+	require.NoError(t, err)
+	p.Tools = nil
+
+	p, err = persona.Get("helpdesk")
+	require.NoError(t, err)
+	assert.NotEmpty(t, p.Tools)
+}
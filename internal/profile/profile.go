@@ -0,0 +1,95 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package profile provides named PingOne connection profiles, so that a single server can be
+// switched between organizations and regions mid-session.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Profile is the PingOne organization and region a server authenticates against.
+type Profile struct {
+	// EnvironmentId is the ID of the environment that contains the login application.
+	EnvironmentId string `json:"environmentId"`
+	// RootDomain is the PingOne root domain of the region, such as pingone.com or pingone.eu.
+	RootDomain string `json:"rootDomain"`
+	// AuthorizationCodeClientId is the client ID of the login application for the authorization_code grant type.
+	AuthorizationCodeClientId string `json:"authorizationCodeClientId,omitempty"`
+	// DeviceCodeClientId is the client ID of the login application for the device_code grant type.
+	DeviceCodeClientId string `json:"deviceCodeClientId,omitempty"`
+	// ClientCredentialsClientId is the client ID of the worker application for the client_credentials grant type.
+	ClientCredentialsClientId string `json:"clientCredentialsClientId,omitempty"`
+	// ClientCredentialsClientSecret is the client secret of the worker application for the client_credentials grant type.
+	ClientCredentialsClientSecret string `json:"clientCredentialsClientSecret,omitempty"`
+}
+
+// Profiles maps a profile name to its profile.
+type Profiles map[string]Profile
+
+// Names returns the profile names in sorted order.
+func (p Profiles) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadProfiles reads profiles from a JSON file. An empty path returns no profiles.
+func LoadProfiles(path string) (Profiles, error) {
+	if path == "" {
+		return Profiles{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles file: %w", err)
+	}
+
+	return ParseProfiles(data)
+}
+
+// ParseProfiles parses profiles from JSON in the form:
+//
+//	{"customer-eu": {"environmentId": "...", "rootDomain": "pingone.eu", "authorizationCodeClientId": "..."}}
+//
+// Each profile must have a login client ID, so that a switch never reuses the login application of
+// another organization.
+func ParseProfiles(data []byte) (Profiles, error) {
+	var profiles Profiles
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("invalid profiles: %w", err)
+	}
+
+	for name, profile := range profiles {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.New("invalid profiles, a profile name must not be empty")
+		}
+		if _, err := uuid.Parse(profile.EnvironmentId); err != nil {
+			return nil, fmt.Errorf("invalid profile %q, environmentId must be a valid UUID: %w", name, err)
+		}
+		if strings.TrimSpace(profile.RootDomain) == "" {
+			return nil, fmt.Errorf("invalid profile %q, rootDomain is required", name)
+		}
+		if profile.AuthorizationCodeClientId == "" && profile.DeviceCodeClientId == "" && profile.ClientCredentialsClientId == "" {
+			return nil, fmt.Errorf("invalid profile %q, authorizationCodeClientId, deviceCodeClientId or clientCredentialsClientId is required", name)
+		}
+		if (profile.ClientCredentialsClientId == "") != (profile.ClientCredentialsClientSecret == "") {
+			return nil, fmt.Errorf("invalid profile %q, clientCredentialsClientId and clientCredentialsClientSecret must be set together", name)
+		}
+	}
+
+	if profiles == nil {
+		profiles = Profiles{}
+	}
+	return profiles, nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package profile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfilesJSON = `{
+	"customer-eu": {"environmentId": "11111111-1111-1111-1111-111111111111", "rootDomain": "pingone.eu", "authorizationCodeClientId": "eu-client"},
+	"customer-na": {"environmentId": "22222222-2222-2222-2222-222222222222", "rootDomain": "pingone.com", "deviceCodeClientId": "na-client"}
+}`
+
+func TestParseProfiles(t *testing.T) {
+	profiles, err := profile.ParseProfiles([]byte(testProfilesJSON))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customer-eu", "customer-na"}, profiles.Names())
+	assert.Equal(t, profile.Profile{
+		EnvironmentId:             "11111111-1111-1111-1111-111111111111",
+		RootDomain:                "pingone.eu",
+		AuthorizationCodeClientId: "eu-client",
+	}, profiles["customer-eu"])
+}
+
+func TestParseProfiles_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		json          string
+		expectedError string
+	}{
+		{
+			name:          "Invalid JSON",
+			json:          `{"customer-eu": }`,
+			expectedError: "invalid profiles",
+		},
+		{
+			name:          "Empty profile name",
+			json:          `{" ": {"environmentId": "11111111-1111-1111-1111-111111111111", "rootDomain": "pingone.eu", "authorizationCodeClientId": "eu-client"}}`,
+			expectedError: "a profile name must not be empty",
+		},
+		{
+			name:          "Invalid environment ID",
+			json:          `{"customer-eu": {"environmentId": "not-a-uuid", "rootDomain": "pingone.eu", "authorizationCodeClientId": "eu-client"}}`,
+			expectedError: "environmentId must be a valid UUID",
+		},
+		{
+			name:          "Missing root domain",
+			json:          `{"customer-eu": {"environmentId": "11111111-1111-1111-1111-111111111111", "authorizationCodeClientId": "eu-client"}}`,
+			expectedError: "rootDomain is required",
+		},
+		{
+			name:          "Missing client ID",
+			json:          `{"customer-eu": {"environmentId": "11111111-1111-1111-1111-111111111111", "rootDomain": "pingone.eu"}}`,
+			expectedError: "authorizationCodeClientId, deviceCodeClientId or clientCredentialsClientId is required",
+		},
+		{
+			name:          "Client credentials without secret",
+			json:          `{"customer-eu": {"environmentId": "11111111-1111-1111-1111-111111111111", "rootDomain": "pingone.eu", "clientCredentialsClientId": "eu-worker"}}`,
+			expectedError: "clientCredentialsClientId and clientCredentialsClientSecret must be set together",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := profile.ParseProfiles([]byte(tt.json))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	t.Run("Empty path returns no profiles", func(t *testing.T) {
+		profiles, err := profile.LoadProfiles("")
+
+		require.NoError(t, err)
+		assert.Empty(t, profiles)
+	})
+
+	t.Run("Profiles are read from the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "profiles.json")
+		require.NoError(t, os.WriteFile(path, []byte(testProfilesJSON), 0600))
+
+		profiles, err := profile.LoadProfiles(path)
+
+		require.NoError(t, err)
+		assert.Len(t, profiles, 2)
+	})
+
+	t.Run("Missing file returns an error", func(t *testing.T) {
+		_, err := profile.LoadProfiles(filepath.Join(t.TempDir(), "missing.json"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read profiles file")
+	})
+}
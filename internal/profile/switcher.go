@@ -0,0 +1,144 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package profile
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+)
+
+// Environment variables read by the PingOne client SDKs that the settings of the active profile take the place of.
+const (
+	RootDomainEnvVar                = "PINGONE_ROOT_DOMAIN"
+	AuthorizationCodeClientIdEnvVar = "PINGONE_AUTHORIZATION_CODE_CLIENT_ID"
+	DeviceCodeClientIdEnvVar        = "PINGONE_DEVICE_CODE_CLIENT_ID"
+)
+
+// AuthEnvironmentSetter is implemented by auth client factories whose login environment can be changed.
+type AuthEnvironmentSetter interface {
+	SetEnvironmentId(environmentId string)
+}
+
+// Switcher changes the profile the server is connected to.
+//
+// Switching sets aside the current auth session before the new profile is applied, so that tokens issued
+// by one organization are never used against another, and restores the session held for the new profile
+// if it has been logged in to before. Otherwise the next tool call logs in to the new profile.
+// Functions registered with OnSwitch are called after each switch to clear any state cached for the
+// previous profile.
+//
+// The switcher is the auth.ClientSettingsProvider of the PingOne client factories, which create clients with the
+// settings of the active profile rather than the environment variables the server was started with. The
+// process environment is not changed.
+type Switcher struct {
+	mu              sync.Mutex
+	profiles        Profiles
+	activeProfile   string
+	sessions        *tokenstore.NamedSessionStore
+	authEnvironment AuthEnvironmentSetter
+	onSwitch        []func()
+}
+
+// NewSwitcher creates a switcher between the given profiles, holding a session for each profile in the
+// given store. No profile is active until the first switch, and the server uses the configuration it was
+// started with.
+func NewSwitcher(profiles Profiles, sessions *tokenstore.NamedSessionStore, authEnvironment AuthEnvironmentSetter) *Switcher {
+	return &Switcher{
+		profiles:        profiles,
+		sessions:        sessions,
+		authEnvironment: authEnvironment,
+	}
+}
+
+// Profiles returns the profiles that can be switched to.
+func (s *Switcher) Profiles() Profiles {
+	return s.profiles
+}
+
+// ClientSettings returns the client settings of the active profile, or nil if no switch has been made and
+// clients use the environment variables the server was started with.
+func (s *Switcher) ClientSettings() *auth.ClientSettings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.activeProfile == "" {
+		return nil
+	}
+	profile := s.profiles[s.activeProfile]
+	return &auth.ClientSettings{
+		RootDomain:                    profile.RootDomain,
+		AuthorizationCodeClientId:     profile.AuthorizationCodeClientId,
+		DeviceCodeClientId:            profile.DeviceCodeClientId,
+		ClientCredentialsClientId:     profile.ClientCredentialsClientId,
+		ClientCredentialsClientSecret: profile.ClientCredentialsClientSecret,
+	}
+}
+
+// ActiveProfile returns the name of the active profile, or an empty string if no switch has been made.
+func (s *Switcher) ActiveProfile() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.activeProfile
+}
+
+// OnSwitch registers a function that is called after each switch.
+func (s *Switcher) OnSwitch(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onSwitch = append(s.onSwitch, fn)
+}
+
+// HeldSessions returns the names of the profiles, other than the active one, with a session that is
+// restored when switching to them.
+func (s *Switcher) HeldSessions() []string {
+	names := []string{}
+	for _, name := range s.sessions.HeldNames() {
+		// The session of the startup configuration can't be switched back to, as it isn't a profile
+		if _, ok := s.profiles[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Switch applies the named profile and activates its session. The session of the current profile is held
+// for switching back, or logged out if logoutCurrent is true. Returns true if a held session for the named
+// profile was restored, so that no login is needed.
+func (s *Switcher) Switch(ctx context.Context, name string, logoutCurrent bool) (Profile, bool, error) {
+	profile, ok := s.profiles[name]
+	if !ok {
+		return Profile{}, false, fmt.Errorf("unknown profile %q, available profiles are %v", name, s.profiles.Names())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	restored, err := s.sessions.Activate(name, !logoutCurrent)
+	if err != nil {
+		return Profile{}, false, fmt.Errorf("failed to change session before switching profile: %w", err)
+	}
+
+	previousProfile := s.activeProfile
+	s.activeProfile = name
+	s.authEnvironment.SetEnvironmentId(profile.EnvironmentId)
+
+	for _, fn := range s.onSwitch {
+		fn()
+	}
+
+	logger.FromContext(ctx).Info("Switched profile",
+		slog.String("previousProfile", previousProfile),
+		slog.String("profile", name),
+		slog.String("rootDomain", profile.RootDomain),
+		slog.Bool("restoredSession", restored))
+
+	return profile, restored, nil
+}
@@ -0,0 +1,157 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package profile_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAuthEnvironment struct {
+	environmentId string
+}
+
+func (e *testAuthEnvironment) SetEnvironmentId(environmentId string) {
+	e.environmentId = environmentId
+}
+
+func newTestSwitcher(t *testing.T) (*profile.Switcher, *tokenstore.NamedSessionStore, *testAuthEnvironment) {
+	t.Helper()
+
+	profiles, err := profile.ParseProfiles([]byte(testProfilesJSON))
+	require.NoError(t, err)
+
+	tokenStore := tokenstore.NewNamedSessionStore(testutils.NewInMemoryTokenStoreWithDefaultSession())
+	authEnvironment := &testAuthEnvironment{}
+	return profile.NewSwitcher(profiles, tokenStore, authEnvironment), tokenStore, authEnvironment
+}
+
+func TestSwitcher_Switch(t *testing.T) {
+	switcher, tokenStore, authEnvironment := newTestSwitcher(t)
+	switchCount := 0
+	switcher.OnSwitch(func() { switchCount++ })
+
+	switched, resumed, err := switcher.Switch(context.Background(), "customer-eu", false)
+
+	require.NoError(t, err)
+	assert.False(t, resumed)
+	assert.Equal(t, "pingone.eu", switched.RootDomain)
+	assert.Equal(t, "customer-eu", switcher.ActiveProfile())
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", authEnvironment.environmentId)
+	assert.Equal(t, 1, switchCount)
+
+	hasSession, err := tokenStore.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession, "Session of the previous profile should be deleted")
+
+	assert.Equal(t, &auth.ClientSettings{
+		RootDomain:                "pingone.eu",
+		AuthorizationCodeClientId: "eu-client",
+	}, switcher.ClientSettings(), "Client IDs not set by the profile should be empty")
+}
+
+func TestSwitcher_Switch_LeavesEnvironmentVariables(t *testing.T) {
+	t.Setenv(profile.RootDomainEnvVar, "pingone.ca")
+	t.Setenv(profile.AuthorizationCodeClientIdEnvVar, "startup-client")
+	switcher, _, _ := newTestSwitcher(t)
+
+	_, _, err := switcher.Switch(context.Background(), "customer-eu", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, "pingone.ca", os.Getenv(profile.RootDomainEnvVar))
+	assert.Equal(t, "startup-client", os.Getenv(profile.AuthorizationCodeClientIdEnvVar))
+}
+
+func TestSwitcher_Switch_ResumesHeldSession(t *testing.T) {
+	switcher, tokenStore, _ := newTestSwitcher(t)
+
+	_, resumed, err := switcher.Switch(context.Background(), "customer-eu", false)
+	require.NoError(t, err)
+	assert.False(t, resumed)
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{SessionId: "eu-session-id", Expiry: time.Now().Add(time.Hour)}))
+
+	_, resumed, err = switcher.Switch(context.Background(), "customer-na", false)
+	require.NoError(t, err)
+	assert.False(t, resumed, "A profile that has not logged in must log in")
+	assert.Equal(t, []string{"customer-eu"}, switcher.HeldSessions(), "The startup session is not a profile")
+	hasSession, err := tokenStore.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession, "Session of the previous profile must not be used")
+
+	_, resumed, err = switcher.Switch(context.Background(), "customer-eu", false)
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	session, err := tokenStore.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, "eu-session-id", session.SessionId)
+}
+
+func TestSwitcher_Switch_LogoutCurrent(t *testing.T) {
+	switcher, tokenStore, _ := newTestSwitcher(t)
+
+	_, _, err := switcher.Switch(context.Background(), "customer-eu", false)
+	require.NoError(t, err)
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{SessionId: "eu-session-id", Expiry: time.Now().Add(time.Hour)}))
+
+	_, _, err = switcher.Switch(context.Background(), "customer-na", true)
+	require.NoError(t, err)
+	assert.Empty(t, switcher.HeldSessions(), "Session of the previous profile should be logged out")
+
+	_, resumed, err := switcher.Switch(context.Background(), "customer-eu", false)
+	require.NoError(t, err)
+	assert.False(t, resumed)
+}
+
+func TestSwitcher_Switch_UnknownProfile(t *testing.T) {
+	switcher, tokenStore, authEnvironment := newTestSwitcher(t)
+
+	_, _, err := switcher.Switch(context.Background(), "customer-ap", false)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown profile "customer-ap"`)
+	assert.Empty(t, switcher.ActiveProfile())
+	assert.Empty(t, authEnvironment.environmentId)
+	assert.Nil(t, switcher.ClientSettings(), "Clients should keep using the environment variables")
+
+	hasSession, err := tokenStore.HasSession()
+	require.NoError(t, err)
+	assert.True(t, hasSession, "Session should be kept when the switch fails")
+}
+
+func TestSwitchProfileTool_OverMcp(t *testing.T) {
+	switcher, _, _ := newTestSwitcher(t)
+	server := mcptestutils.TestMcpServer(t)
+	profile.RegisterSwitchProfileTool(server, switcher)
+
+	_, _, err := switcher.Switch(context.Background(), "customer-eu", false)
+	require.NoError(t, err)
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, profile.SwitchProfileDef.McpTool.Name, profile.SwitchProfileInput{Profile: "customer-na"})
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.False(t, output.IsError)
+
+	structuredJSON, err := json.Marshal(output.StructuredContent)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"previousProfile": "customer-eu", "profile": "customer-na", "rootDomain": "pingone.com", "availableProfiles": ["customer-eu", "customer-na"], "resumedSession": false, "heldSessions": []}`, string(structuredJSON))
+	assert.Equal(t, "customer-na", switcher.ActiveProfile())
+
+	output, err = mcptestutils.CallToolOverMcp(t, server, profile.SwitchProfileDef.McpTool.Name, profile.SwitchProfileInput{Profile: "customer-ap"})
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.True(t, output.IsError)
+	require.NotEmpty(t, output.Content)
+	assert.Contains(t, output.Content[0].(*mcp.TextContent).Text, "unknown profile")
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package profile
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var SwitchProfileDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "switch_profile",
+		Title:        "Switch PingOne Profile",
+		Description:  "Switch the server to a different configured PingOne profile (organization and region). The session of the current profile is kept, so switching back does not need another login, unless 'logout' is true. If the new profile has a kept session it is resumed, otherwise the next tool call logs in to the new profile. Environment IDs from the previous profile are not valid after switching; call 'list_environments' to find environments in the new profile.",
+		InputSchema:  schema.MustGenerateSchema[SwitchProfileInput](),
+		OutputSchema: schema.MustGenerateSchema[SwitchProfileOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+// SwitchProfileInput defines the input parameters for switching profile
+type SwitchProfileInput struct {
+	Profile string `json:"profile" jsonschema:"REQUIRED. The name of the configured profile to switch to."`
+	Logout  bool   `json:"logout,omitempty" jsonschema:"OPTIONAL. Log out of the current profile instead of keeping its session for switching back. Defaults to false."`
+}
+
+// SwitchProfileOutput represents the result of switching profile
+type SwitchProfileOutput struct {
+	PreviousProfile   string   `json:"previousProfile,omitempty" jsonschema:"The profile that was active before the switch. Empty if the server was using its startup configuration."`
+	Profile           string   `json:"profile" jsonschema:"The profile that is now active"`
+	RootDomain        string   `json:"rootDomain" jsonschema:"The PingOne root domain of the active profile's region"`
+	AvailableProfiles []string `json:"availableProfiles" jsonschema:"The names of all configured profiles"`
+	ResumedSession    bool     `json:"resumedSession" jsonschema:"True if a kept session of the profile was resumed, so no login is needed"`
+	HeldSessions      []string `json:"heldSessions" jsonschema:"The names of the other profiles with a kept session, which can be switched to without logging in"`
+}
+
+// SwitchProfileHandler switches the server to the requested profile using the provided switcher
+func SwitchProfileHandler(switcher *Switcher) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input SwitchProfileInput,
+) (
+	*mcp.CallToolResult,
+	*SwitchProfileOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SwitchProfileInput) (*mcp.CallToolResult, *SwitchProfileOutput, error) {
+		previousProfile := switcher.ActiveProfile()
+
+		profile, resumed, err := switcher.Switch(ctx, input.Profile, input.Logout)
+		if err != nil {
+			toolErr := errs.NewToolError(SwitchProfileDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &SwitchProfileOutput{
+			PreviousProfile:   previousProfile,
+			Profile:           input.Profile,
+			RootDomain:        profile.RootDomain,
+			AvailableProfiles: switcher.Profiles().Names(),
+			ResumedSession:    resumed,
+			HeldSessions:      switcher.HeldSessions(),
+		}, nil
+	}
+}
+
+// RegisterSwitchProfileTool adds the switch_profile tool to the MCP server.
+func RegisterSwitchProfileTool(server *mcp.Server, switcher *Switcher) {
+	mcp.AddTool(server, SwitchProfileDef.McpTool, SwitchProfileHandler(switcher))
+}
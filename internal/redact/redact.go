@@ -0,0 +1,95 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package redact
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+)
+
+// RedactedValue replaces the values of sensitive fields
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeyParts are parts of field names whose values are secrets, matched case-insensitively
+var sensitiveKeyParts = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"token",
+	"credential",
+	"privatekey",
+	"apikey",
+	"authorization",
+	"cookie",
+	"seed",
+}
+
+// IsSensitiveKey returns whether the values of fields with the name are secrets, such as passwords, client secrets,
+// access tokens and MFA seeds. Fields that identify a resource, such as passwordPolicyId, are not sensitive.
+func IsSensitiveKey(key string) bool {
+	if key == "id" || strings.HasSuffix(key, "Id") || strings.HasSuffix(key, "Ids") {
+		return false
+	}
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, part := range sensitiveKeyParts {
+		if strings.Contains(normalized, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns a copy of a JSON value, as decoded into an any, with the values of sensitive fields at any
+// depth replaced with RedactedValue.
+func Value(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, nested := range v {
+			if IsSensitiveKey(key) {
+				redacted[key] = RedactedValue
+			} else {
+				redacted[key] = Value(nested)
+			}
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, nested := range v {
+			redacted[i] = Value(nested)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// JSON returns a copy of a JSON document with the values of sensitive fields replaced with RedactedValue.
+// Text that is not a JSON object or array is returned unchanged, as its sensitive values cannot be recognized.
+func JSON(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return text
+	}
+	var value any
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return text
+	}
+	redacted, err := json.Marshal(Value(value))
+	if err != nil {
+		return text
+	}
+	return string(redacted)
+}
+
+// ReplaceAttr masks the values of log attributes with sensitive names, for use as the ReplaceAttr function of
+// slog.HandlerOptions. Only string and other non-scalar values are masked, as flags such as bearerTokenRequired
+// and times such as tokenExpiresAt are not secrets.
+func ReplaceAttr(groups []string, attr slog.Attr) slog.Attr {
+	kind := attr.Value.Kind()
+	if (kind == slog.KindString || kind == slog.KindAny) && IsSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, RedactedValue)
+	}
+	return attr
+}
@@ -0,0 +1,103 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package redact_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSensitiveKey(t *testing.T) {
+	for _, key := range []string{"password", "newPassword", "client_secret", "accessToken", "Authorization", "mfa-seed", "privateKey", "apiKey", "credentials"} {
+		assert.True(t, redact.IsSensitiveKey(key), key)
+	}
+	for _, key := range []string{"name", "id", "passwordPolicyId", "credentialTypeId", "tokenEndpointAuthMethodIds", "description"} {
+		assert.False(t, redact.IsSensitiveKey(key), key)
+	}
+}
+
+func TestValue(t *testing.T) {
+	value := map[string]any{
+		"name":         "Portal",
+		"clientSecret": "s3cr3t",
+		"settings": map[string]any{
+			"admin_password": "hunter2",
+			"color":          "blue",
+		},
+		"devices": []any{
+			map[string]any{"type": "TOTP", "seed": "JBSWY3DPEHPK3PXP"},
+		},
+	}
+
+	redacted := redact.Value(value)
+
+	assert.Equal(t, map[string]any{
+		"name":         "Portal",
+		"clientSecret": redact.RedactedValue,
+		"settings": map[string]any{
+			"admin_password": redact.RedactedValue,
+			"color":          "blue",
+		},
+		"devices": []any{
+			map[string]any{"type": "TOTP", "seed": redact.RedactedValue},
+		},
+	}, redacted)
+	assert.Equal(t, "s3cr3t", value["clientSecret"], "the value should not be changed")
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Object",
+			text:     `{"name":"Portal","clientSecret":"s3cr3t"}`,
+			expected: `{"clientSecret":"[REDACTED]","name":"Portal"}`,
+		},
+		{
+			name:     "Array",
+			text:     `[{"accessToken":"eyJ"}]`,
+			expected: `[{"accessToken":"[REDACTED]"}]`,
+		},
+		{
+			name:     "Not JSON",
+			text:     "Portal has client secret s3cr3t",
+			expected: "Portal has client secret s3cr3t",
+		},
+		{
+			name:     "Invalid JSON",
+			text:     `{"clientSecret":`,
+			expected: `{"clientSecret":`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redact.JSON(tt.text))
+		})
+	}
+}
+
+func TestReplaceAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: redact.ReplaceAttr}))
+
+	logger.Info("Logged in",
+		slog.String("accessToken", "eyJ"),
+		slog.String("passwordPolicyId", "policy-1"),
+		slog.Bool("bearerTokenRequired", true),
+		slog.Time("tokenExpiresAt", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	assert.Contains(t, buf.String(), "accessToken=[REDACTED]")
+	assert.NotContains(t, buf.String(), "eyJ")
+	assert.Contains(t, buf.String(), "passwordPolicyId=policy-1")
+	assert.Contains(t, buf.String(), "bearerTokenRequired=true")
+	assert.Contains(t, buf.String(), "tokenExpiresAt=2025-01-01T00:00:00.000Z")
+}
@@ -0,0 +1,125 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package safemode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// MarkerFileEnvVar overrides the path of the crash marker file
+	MarkerFileEnvVar = "PINGONE_MCP_CRASH_MARKER_FILE"
+
+	defaultMarkerFileName = ".pingone_mcp_crash_marker.json"
+)
+
+// Run describes a run of the server recorded in the crash marker file.
+type Run struct {
+	Pid       int       `json:"pid" jsonschema:"The process ID of the run"`
+	Version   string    `json:"version" jsonschema:"The server version of the run"`
+	StartedAt time.Time `json:"startedAt" jsonschema:"When the run started"`
+}
+
+// marker is the content of the crash marker file
+type marker struct {
+	Run
+	// AbnormalExits counts the consecutive runs before this one that did not exit normally
+	AbnormalExits int `json:"abnormalExits"`
+}
+
+// Detection is the result of checking the crash marker file at startup.
+type Detection struct {
+	// AbnormalExits counts the consecutive runs that did not exit normally, including the previous run
+	AbnormalExits int
+	// LastAbnormalRun is the previous run when it did not exit normally
+	LastAbnormalRun *Run
+}
+
+// CrashMarker detects runs of the server that did not exit normally, such as runs that crashed or were killed.
+//
+// Each run writes the marker file when it starts and removes it when it exits normally, so a marker file found
+// at startup was left by a run that exited abnormally. The file counts consecutive abnormal exits, and is reset
+// by the next normal exit.
+type CrashMarker struct {
+	filePath string
+	now      func() time.Time
+}
+
+// NewCrashMarker creates a crash marker with the file path from the PINGONE_MCP_CRASH_MARKER_FILE environment
+// variable, or the default file path in the user's home directory
+func NewCrashMarker() (*CrashMarker, error) {
+	if filePath := os.Getenv(MarkerFileEnvVar); filePath != "" {
+		return NewCrashMarkerWithPath(filePath), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory when creating crash marker: %w", err)
+	}
+	return NewCrashMarkerWithPath(filepath.Join(homeDir, defaultMarkerFileName)), nil
+}
+
+func NewCrashMarkerWithPath(filePath string) *CrashMarker {
+	return &CrashMarker{
+		filePath: filePath,
+		now:      time.Now,
+	}
+}
+
+// FilePath returns the path of the marker file.
+func (c *CrashMarker) FilePath() string {
+	return c.filePath
+}
+
+// Start checks for a marker left by a previous run that exited abnormally, then writes the marker of this run.
+func (c *CrashMarker) Start(version string) (Detection, error) {
+	var detection Detection
+
+	data, err := os.ReadFile(c.filePath)
+	switch {
+	case err == nil:
+		var previous marker
+		if err := json.Unmarshal(data, &previous); err != nil {
+			// A marker that cannot be read was still left by a run that did not exit normally
+			detection.AbnormalExits = 1
+		} else {
+			detection.AbnormalExits = previous.AbnormalExits + 1
+			detection.LastAbnormalRun = &previous.Run
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return Detection{}, fmt.Errorf("failed to read crash marker file: %w", err)
+	}
+
+	current, err := json.Marshal(marker{
+		Run: Run{
+			Pid:       os.Getpid(),
+			Version:   version,
+			StartedAt: c.now().UTC(),
+		},
+		AbnormalExits: detection.AbnormalExits,
+	})
+	if err != nil {
+		return Detection{}, fmt.Errorf("failed to marshal crash marker: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.filePath), 0700); err != nil {
+		return Detection{}, fmt.Errorf("failed to create directory for crash marker file: %w", err)
+	}
+	if err := os.WriteFile(c.filePath, current, 0600); err != nil {
+		return Detection{}, fmt.Errorf("failed to write crash marker file: %w", err)
+	}
+
+	return detection, nil
+}
+
+// Clear removes the marker file when the run exits normally.
+func (c *CrashMarker) Clear() error {
+	if err := os.Remove(c.filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove crash marker file: %w", err)
+	}
+	return nil
+}
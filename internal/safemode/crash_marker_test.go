@@ -0,0 +1,86 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package safemode_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/safemode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCrashMarker(t *testing.T) *safemode.CrashMarker {
+	t.Helper()
+	return safemode.NewCrashMarkerWithPath(filepath.Join(t.TempDir(), "config", "crash_marker.json"))
+}
+
+func TestCrashMarker_NormalExit(t *testing.T) {
+	marker := testCrashMarker(t)
+
+	detection, err := marker.Start("1.0.0")
+	require.NoError(t, err)
+	assert.Zero(t, detection.AbnormalExits)
+	assert.Nil(t, detection.LastAbnormalRun)
+	assert.FileExists(t, marker.FilePath())
+
+	require.NoError(t, marker.Clear())
+	assert.NoFileExists(t, marker.FilePath())
+
+	detection, err = marker.Start("1.0.0")
+	require.NoError(t, err)
+	assert.Zero(t, detection.AbnormalExits)
+}
+
+func TestCrashMarker_CountsConsecutiveAbnormalExits(t *testing.T) {
+	marker := testCrashMarker(t)
+
+	_, err := marker.Start("1.0.0")
+	require.NoError(t, err)
+	detection, err := marker.Start("1.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, detection.AbnormalExits)
+	require.NotNil(t, detection.LastAbnormalRun)
+	assert.Equal(t, "1.0.0", detection.LastAbnormalRun.Version)
+	assert.Equal(t, os.Getpid(), detection.LastAbnormalRun.Pid)
+	assert.False(t, detection.LastAbnormalRun.StartedAt.IsZero())
+
+	detection, err = marker.Start("1.0.1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, detection.AbnormalExits)
+	assert.Equal(t, "1.0.1", detection.LastAbnormalRun.Version)
+
+	// A normal exit resets the count
+	require.NoError(t, marker.Clear())
+	detection, err = marker.Start("1.0.1")
+	require.NoError(t, err)
+	assert.Zero(t, detection.AbnormalExits)
+}
+
+func TestCrashMarker_UnreadableMarker(t *testing.T) {
+	marker := testCrashMarker(t)
+	require.NoError(t, os.MkdirAll(filepath.Dir(marker.FilePath()), 0700))
+	require.NoError(t, os.WriteFile(marker.FilePath(), []byte("{truncated"), 0600))
+
+	detection, err := marker.Start("1.0.0")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, detection.AbnormalExits)
+	assert.Nil(t, detection.LastAbnormalRun)
+}
+
+func TestCrashMarker_ClearWithoutMarker(t *testing.T) {
+	assert.NoError(t, testCrashMarker(t).Clear())
+}
+
+func TestNewCrashMarker_EnvVarOverride(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "marker.json")
+	t.Setenv(safemode.MarkerFileEnvVar, filePath)
+
+	marker, err := safemode.NewCrashMarker()
+
+	require.NoError(t, err)
+	assert.Equal(t, filePath, marker.FilePath())
+}
@@ -0,0 +1,52 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package safemode starts the server with a reduced feature set after repeated abnormal exits, so that users of
+// stdio MCP clients, which often hide server failures, can find out what went wrong.
+package safemode
+
+import (
+	"fmt"
+)
+
+// DefaultThreshold is the number of consecutive abnormal exits that start the server in safe mode
+const DefaultThreshold = 3
+
+// Restrictions describes how the server is restricted in safe mode
+var Restrictions = []string{
+	"Only read-only tools are available, even if write tools were enabled",
+	"The response cache and the PRODUCTION environment cache are disabled",
+}
+
+// SafeMode describes why the server started in safe mode.
+type SafeMode struct {
+	Detection
+	Threshold  int
+	MarkerFile string
+}
+
+// New returns the safe mode to start the server in, or nil if the detected abnormal exits are below the
+// threshold. A threshold of zero never starts the server in safe mode.
+func New(detection Detection, threshold int, markerFile string) *SafeMode {
+	if threshold <= 0 || detection.AbnormalExits < threshold {
+		return nil
+	}
+	return &SafeMode{
+		Detection:  detection,
+		Threshold:  threshold,
+		MarkerFile: markerFile,
+	}
+}
+
+// Problem describes the detected problem.
+func (s *SafeMode) Problem() string {
+	problem := fmt.Sprintf("The server exited abnormally %d times in a row, for example because it crashed or was killed", s.AbnormalExits)
+	if s.LastAbnormalRun != nil {
+		problem += fmt.Sprintf(". The last of these runs was version %s, started at %s", s.LastAbnormalRun.Version, s.LastAbnormalRun.StartedAt.Format("2006-01-02T15:04:05Z07:00"))
+	}
+	return problem
+}
+
+// Instructions tells MCP clients that the server is in safe mode and how to find out more.
+func (s *SafeMode) Instructions() string {
+	return fmt.Sprintf("The PingOne MCP server started in safe mode, with only read-only tools and caching disabled. %s. Call the '%s' tool to describe the problem and how to resolve it.", s.Problem(), DiagnoseSafeModeDef.McpTool.Name)
+}
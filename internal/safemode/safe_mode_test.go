@@ -0,0 +1,72 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package safemode_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/safemode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name           string
+		abnormalExits  int
+		threshold      int
+		expectSafeMode bool
+	}{
+		{name: "no abnormal exits", abnormalExits: 0, threshold: 3},
+		{name: "below threshold", abnormalExits: 2, threshold: 3},
+		{name: "at threshold", abnormalExits: 3, threshold: 3, expectSafeMode: true},
+		{name: "above threshold", abnormalExits: 5, threshold: 3, expectSafeMode: true},
+		{name: "disabled", abnormalExits: 5, threshold: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			safeMode := safemode.New(safemode.Detection{AbnormalExits: tt.abnormalExits}, tt.threshold, "marker.json")
+
+			if tt.expectSafeMode {
+				require.NotNil(t, safeMode)
+				assert.Equal(t, tt.threshold, safeMode.Threshold)
+			} else {
+				assert.Nil(t, safeMode)
+			}
+		})
+	}
+}
+
+func TestSafeMode_Problem(t *testing.T) {
+	safeMode := safemode.New(safemode.Detection{
+		AbnormalExits: 3,
+		LastAbnormalRun: &safemode.Run{
+			Pid:       1234,
+			Version:   "1.0.0",
+			StartedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}, 3, "marker.json")
+
+	assert.Equal(t, "The server exited abnormally 3 times in a row, for example because it crashed or was killed. The last of these runs was version 1.0.0, started at 2025-01-02T03:04:05Z", safeMode.Problem())
+	assert.Contains(t, safeMode.Instructions(), safeMode.Problem())
+	assert.Contains(t, safeMode.Instructions(), safemode.DiagnoseSafeModeDef.McpTool.Name)
+}
+
+func TestDiagnoseSafeModeHandler(t *testing.T) {
+	safeMode := safemode.New(safemode.Detection{AbnormalExits: 4}, 3, "/home/user/marker.json")
+
+	result, output, err := safemode.DiagnoseSafeModeHandler(safeMode)(context.Background(), &mcp.CallToolRequest{}, safemode.DiagnoseSafeModeInput{})
+
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	require.NotNil(t, output)
+	assert.True(t, output.SafeMode)
+	assert.Equal(t, 4, output.AbnormalExits)
+	assert.Equal(t, 3, output.Threshold)
+	assert.Nil(t, output.LastAbnormalRun)
+	assert.Equal(t, safemode.Restrictions, output.Restrictions)
+	assert.Contains(t, output.NextSteps[3], "/home/user/marker.json")
+}
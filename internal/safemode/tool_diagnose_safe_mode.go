@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package safemode
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var DiagnoseSafeModeDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "diagnose_safe_mode",
+		Title:        "Diagnose Safe Mode",
+		Description:  "Describe why the server started in safe mode after repeated abnormal exits, which features are restricted, and how to resolve the problem. Only available while the server is in safe mode. Share the problem and next steps with the user, as they may not know that the server has been failing.",
+		InputSchema:  schema.MustGenerateSchema[DiagnoseSafeModeInput](),
+		OutputSchema: schema.MustGenerateSchema[DiagnoseSafeModeOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+// DiagnoseSafeModeInput defines the input parameters for diagnosing safe mode
+type DiagnoseSafeModeInput struct{}
+
+// DiagnoseSafeModeOutput describes why the server is in safe mode
+type DiagnoseSafeModeOutput struct {
+	SafeMode        bool     `json:"safeMode" jsonschema:"True, as the tool is only available in safe mode"`
+	Problem         string   `json:"problem" jsonschema:"A description of the detected problem"`
+	AbnormalExits   int      `json:"abnormalExits" jsonschema:"The number of consecutive runs of the server that did not exit normally"`
+	Threshold       int      `json:"threshold" jsonschema:"The number of consecutive abnormal exits that start the server in safe mode"`
+	LastAbnormalRun *Run     `json:"lastAbnormalRun,omitempty" jsonschema:"The last run that did not exit normally, if it could be identified"`
+	Restrictions    []string `json:"restrictions" jsonschema:"How the server is restricted in safe mode"`
+	NextSteps       []string `json:"nextSteps" jsonschema:"Steps to find and resolve the cause of the abnormal exits"`
+}
+
+// DiagnoseSafeModeHandler describes the safe mode the server started in
+func DiagnoseSafeModeHandler(safeMode *SafeMode) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input DiagnoseSafeModeInput,
+) (
+	*mcp.CallToolResult,
+	*DiagnoseSafeModeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DiagnoseSafeModeInput) (*mcp.CallToolResult, *DiagnoseSafeModeOutput, error) {
+		return nil, &DiagnoseSafeModeOutput{
+			SafeMode:        true,
+			Problem:         safeMode.Problem(),
+			AbnormalExits:   safeMode.AbnormalExits,
+			Threshold:       safeMode.Threshold,
+			LastAbnormalRun: safeMode.LastAbnormalRun,
+			Restrictions:    Restrictions,
+			NextSteps: []string{
+				"Check the MCP client's logs of the server for errors and panics, and set PINGONE_MCP_DEBUG=true in the server's environment for more detail",
+				"Check recently changed server configuration, such as output transformer commands, profiles and default bookmarks files",
+				"Check whether the MCP client runs several copies of the server at once, as each copy that starts while another is running is counted as an abnormal exit",
+				"Safe mode ends when the server next starts after this run exits normally. Restart the MCP client once the cause is resolved, or delete the crash marker file " + safeMode.MarkerFile + " while the server is stopped",
+				"If the problem persists, report it in a feedback issue at https://github.com/pingidentity/pingone-mcp-server/issues",
+			},
+		}, nil
+	}
+}
+
+// RegisterDiagnoseSafeModeTool adds the diagnose_safe_mode tool to the MCP server.
+func RegisterDiagnoseSafeModeTool(server *mcp.Server, safeMode *SafeMode) {
+	mcp.AddTool(server, DiagnoseSafeModeDef.McpTool, DiagnoseSafeModeHandler(safeMode))
+}
@@ -0,0 +1,267 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scimfilter
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// comparisonOperators are the SCIM comparison operators, other than 'pr' (present), which takes no value
+var comparisonOperators = []string{"eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le"}
+
+// expression is a node of a parsed SCIM filter
+type expression interface {
+	isExpression()
+}
+
+// comparison compares an attribute with a value, or checks that it is present with the 'pr' operator
+type comparison struct {
+	attribute string
+	operator  string
+}
+
+// logical joins two expressions with 'and' or 'or'
+type logical struct {
+	operator    string
+	left, right expression
+}
+
+// not negates an expression
+type not struct {
+	expression expression
+}
+
+// valuePath filters the values of a multi-valued attribute, such as emails[type eq "work"]
+type valuePath struct {
+	attribute string
+	filter    expression
+}
+
+func (comparison) isExpression() {}
+func (logical) isExpression()    {}
+func (not) isExpression()        {}
+func (valuePath) isExpression()  {}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenOpenParen
+	tokenCloseParen
+	tokenOpenBracket
+	tokenCloseBracket
+	tokenEnd
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+// parse parses a SCIM filter, as defined by RFC 7644 section 3.4.2.2
+func parse(filter string) (expression, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEnd {
+		return nil, unexpected(next)
+	}
+	return expr, nil
+}
+
+func tokenize(filter string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenOpenParen, text: "(", position: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenCloseParen, text: ")", position: i + 1})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{kind: tokenOpenBracket, text: "[", position: i + 1})
+			i++
+		case r == ']':
+			tokens = append(tokens, token{kind: tokenCloseBracket, text: "]", position: i + 1})
+			i++
+		case r == '"':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != '"' {
+				if runes[i] == '\\' {
+					i++
+				}
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start+1)
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: string(runes[start:i]), position: start + 1})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`()[]"`, runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: string(runes[start:i]), position: start + 1})
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, position: len(runes) + 1}), nil
+}
+
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) take() token {
+	t := p.tokens[p.next]
+	if t.kind != tokenEnd {
+		p.next++
+	}
+	return t
+}
+
+// isKeyword returns true if the token is the keyword, which is case-insensitive
+func isKeyword(t token, keyword string) bool {
+	return t.kind == tokenWord && strings.EqualFold(t.text, keyword)
+}
+
+func (p *parser) parseOr() (expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "or") {
+		p.take()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{operator: "or", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (expression, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), "and") {
+		p.take()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logical{operator: "and", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expression, error) {
+	t := p.take()
+	switch {
+	case isKeyword(t, "not"):
+		if open := p.take(); open.kind != tokenOpenParen {
+			return nil, fmt.Errorf("expected '(' after 'not' at position %d", open.position)
+		}
+		expr, err := p.parseGroup()
+		if err != nil {
+			return nil, err
+		}
+		return not{expression: expr}, nil
+	case t.kind == tokenOpenParen:
+		return p.parseGroup()
+	case t.kind == tokenWord:
+		return p.parseAttributeExpression(t)
+	default:
+		return nil, unexpected(t)
+	}
+}
+
+// parseGroup parses the expression of a group, after its opening parenthesis
+func (p *parser) parseGroup() (expression, error) {
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if closing := p.take(); closing.kind != tokenCloseParen {
+		return nil, fmt.Errorf("expected ')' at position %d", closing.position)
+	}
+	return expr, nil
+}
+
+func (p *parser) parseAttributeExpression(attribute token) (expression, error) {
+	next := p.take()
+	switch {
+	case next.kind == tokenOpenBracket:
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.take(); closing.kind != tokenCloseBracket {
+			return nil, fmt.Errorf("expected ']' at position %d", closing.position)
+		}
+		return valuePath{attribute: attribute.text, filter: filter}, nil
+	case isKeyword(next, "pr"):
+		return comparison{attribute: attribute.text, operator: "pr"}, nil
+	case next.kind == tokenWord && slices.Contains(comparisonOperators, strings.ToLower(next.text)):
+		value := p.take()
+		if value.kind != tokenString && !isLiteral(value) {
+			return nil, fmt.Errorf("expected a quoted string, number, true, false or null after '%s' at position %d", next.text, value.position)
+		}
+		return comparison{attribute: attribute.text, operator: strings.ToLower(next.text)}, nil
+	case next.kind == tokenEnd:
+		return nil, fmt.Errorf("expected an operator after '%s' at position %d", attribute.text, next.position)
+	default:
+		return nil, fmt.Errorf("unknown operator '%s' at position %d, expected one of %s or pr", next.text, next.position, strings.Join(comparisonOperators, ", "))
+	}
+}
+
+// isLiteral returns true if the token is a number, boolean or null value
+func isLiteral(t token) bool {
+	if t.kind != tokenWord {
+		return false
+	}
+	switch t.text {
+	case "true", "false", "null":
+		return true
+	}
+	hasDigit := false
+	for _, r := range t.text {
+		if unicode.IsDigit(r) {
+			hasDigit = true
+		} else if !strings.ContainsRune("+-.eE", r) {
+			return false
+		}
+	}
+	return hasDigit
+}
+
+func unexpected(t token) error {
+	if t.kind == tokenEnd {
+		return fmt.Errorf("unexpected end of filter at position %d", t.position)
+	}
+	return fmt.Errorf("unexpected '%s' at position %d", t.text, t.position)
+}
@@ -0,0 +1,53 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scimfilter_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/stretchr/testify/assert"
+)
+
+// The audit activities rules do not restrict attributes or operators, so only the syntax of the filters is checked
+func TestValidate_Syntax(t *testing.T) {
+	valid := []string{
+		`action.type eq "USER.CREATED"`,
+		`result.status EQ "FAILED" AND resources.id eq "abc"`,
+		`(action.type eq "USER.CREATED" or action.type eq "USER.DELETED") and result.status eq "SUCCESS"`,
+		`not (result.status eq "FAILED")`,
+		`recordedAt gt "2025-06-01T00:00:00Z" and recordedAt lt "2025-06-02T00:00:00Z"`,
+		`resources.name eq "with \"quotes\" and (parentheses)"`,
+		`count ge 10 and enabled eq true and deleted eq null and score lt -1.5e3`,
+		`actors.user pr`,
+		`emails[type eq "work" and value co "@example.com"]`,
+	}
+	for _, filter := range valid {
+		t.Run(filter, func(t *testing.T) {
+			assert.NoError(t, scimfilter.AuditActivities.Validate(filter))
+		})
+	}
+
+	invalid := []struct {
+		filter          string
+		wantErrContains string
+	}{
+		{filter: ``, wantErrContains: "unexpected end of filter at position 1"},
+		{filter: `action.type`, wantErrContains: "expected an operator after 'action.type' at position 12"},
+		{filter: `action.type equals "USER.CREATED"`, wantErrContains: "unknown operator 'equals' at position 13"},
+		{filter: `action.type eq USER.CREATED`, wantErrContains: "expected a quoted string, number, true, false or null after 'eq' at position 16"},
+		{filter: `action.type eq "USER.CREATED`, wantErrContains: "unterminated string at position 16"},
+		{filter: `(action.type eq "USER.CREATED"`, wantErrContains: "expected ')' at position 31"},
+		{filter: `action.type eq "USER.CREATED")`, wantErrContains: "unexpected ')' at position 30"},
+		{filter: `action.type eq "USER.CREATED" and`, wantErrContains: "unexpected end of filter at position 34"},
+		{filter: `not result.status eq "FAILED"`, wantErrContains: "expected '(' after 'not' at position 5"},
+		{filter: `emails[type eq "work"`, wantErrContains: "expected ']' at position 22"},
+		{filter: `action.type eq "USER.CREATED" result.status eq "FAILED"`, wantErrContains: "unexpected 'result.status' at position 31"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.filter, func(t *testing.T) {
+			err := scimfilter.AuditActivities.Validate(tt.filter)
+			assert.ErrorContains(t, err, "invalid SCIM filter: "+tt.wantErrContains)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package scimfilter checks SCIM filters against the attributes and operators PingOne supports for an endpoint, so
+// that unsupported filters are rejected with a clear error rather than an opaque bad request from the API.
+package scimfilter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Rules are the attributes and operators an endpoint supports in its filters
+type Rules struct {
+	// Attributes are the operators supported for each attribute, or nil if attributes are not restricted
+	Attributes map[string][]string
+	// LogicalOperators are the supported 'and', 'or' and 'not' operators, or nil if they are not restricted
+	LogicalOperators []string
+}
+
+var (
+	// Environments are the filter rules of the environments endpoint
+	Environments = Rules{
+		Attributes: map[string][]string{
+			"name":            {"sw"},
+			"id":              {"eq"},
+			"organization.id": {"eq"},
+			"license.id":      {"eq"},
+			"status":          {"eq"},
+		},
+		LogicalOperators: []string{"and"},
+	}
+	// Populations are the filter rules of the populations endpoint
+	Populations = Rules{
+		Attributes: map[string][]string{
+			"id":   {"eq"},
+			"name": {"sw"},
+		},
+	}
+	// Users are the filter rules of the users endpoint, which supports filtering on most user attributes, so only
+	// the filter syntax is checked
+	Users = Rules{}
+	// AuditActivities are the filter rules of the audit activities endpoint, which supports attributes of the
+	// events that are not listed here, so only the filter syntax is checked
+	AuditActivities = Rules{}
+)
+
+// Validate parses the filter and checks that the endpoint supports its attributes and operators. All the unsupported
+// parts of the filter are reported.
+func (r Rules) Validate(filter string) error {
+	expr, err := parse(filter)
+	if err != nil {
+		return fmt.Errorf("invalid SCIM filter: %w", err)
+	}
+	if err := errors.Join(r.check(expr)...); err != nil {
+		return fmt.Errorf("unsupported SCIM filter: %w", err)
+	}
+	return nil
+}
+
+func (r Rules) check(expr expression) []error {
+	switch e := expr.(type) {
+	case comparison:
+		if err := r.CheckComparison(e.attribute, e.operator); err != nil {
+			return []error{err}
+		}
+	case logical:
+		// Reported in the order they appear in the filter
+		errs := r.check(e.left)
+		if err := r.CheckLogicalOperator(e.operator); err != nil {
+			errs = append(errs, err)
+		}
+		return append(errs, r.check(e.right)...)
+	case not:
+		errs := []error{}
+		if err := r.CheckLogicalOperator("not"); err != nil {
+			errs = append(errs, err)
+		}
+		return append(errs, r.check(e.expression)...)
+	case valuePath:
+		if r.Attributes != nil {
+			return []error{fmt.Errorf("filtering the values of '%s' is not supported", e.attribute)}
+		}
+	}
+	return nil
+}
+
+// CheckComparison returns an error if the endpoint does not support the operator for the attribute
+func (r Rules) CheckComparison(attribute string, operator string) error {
+	if r.Attributes == nil {
+		return nil
+	}
+	operators, ok := r.Attributes[attribute]
+	if !ok {
+		return fmt.Errorf("filtering by '%s' is not supported; supported attributes are %s", attribute, strings.Join(r.attributeNames(), ", "))
+	}
+	if !slices.Contains(operators, strings.ToLower(operator)) {
+		return fmt.Errorf("'%s' only supports %s", attribute, strings.Join(operators, ", "))
+	}
+	return nil
+}
+
+// CheckLogicalOperator returns an error if the endpoint does not support the 'and', 'or' or 'not' operator
+func (r Rules) CheckLogicalOperator(operator string) error {
+	if r.LogicalOperators == nil || slices.Contains(r.LogicalOperators, strings.ToLower(operator)) {
+		return nil
+	}
+	return fmt.Errorf("'%s' is not supported; supported operators to combine conditions are %s", strings.ToLower(operator), strings.Join(r.LogicalOperators, ", "))
+}
+
+func (r Rules) attributeNames() []string {
+	names := []string{}
+	for name := range r.Attributes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
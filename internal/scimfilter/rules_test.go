@@ -0,0 +1,83 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scimfilter_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules_Validate(t *testing.T) {
+	tests := []struct {
+		name            string
+		rules           scimfilter.Rules
+		filter          string
+		wantErrContains string
+	}{
+		{
+			name:   "supported environments filter",
+			rules:  scimfilter.Environments,
+			filter: `name sw "Dev" and (license.id eq "abc" and status EQ "ACTIVE")`,
+		},
+		{
+			name:            "unsupported environments operators",
+			rules:           scimfilter.Environments,
+			filter:          `name eq "Dev" or not (status eq "DELETE_PENDING")`,
+			wantErrContains: "unsupported SCIM filter: 'name' only supports sw\n'or' is not supported; supported operators to combine conditions are and\n'not' is not supported; supported operators to combine conditions are and",
+		},
+		{
+			name:            "unsupported environments attribute",
+			rules:           scimfilter.Environments,
+			filter:          `type eq "SANDBOX"`,
+			wantErrContains: "unsupported SCIM filter: filtering by 'type' is not supported; supported attributes are id, license.id, name, organization.id, status",
+		},
+		{
+			name:            "unsupported value filter",
+			rules:           scimfilter.Environments,
+			filter:          `billOfMaterials[type eq "PING_ONE_MFA"]`,
+			wantErrContains: "unsupported SCIM filter: filtering the values of 'billOfMaterials' is not supported",
+		},
+		{
+			name:   "supported populations filter",
+			rules:  scimfilter.Populations,
+			filter: `name sw "External" or id eq "abc"`,
+		},
+		{
+			name:            "unsupported populations operator",
+			rules:           scimfilter.Populations,
+			filter:          `id sw "abc"`,
+			wantErrContains: "unsupported SCIM filter: 'id' only supports eq",
+		},
+		{
+			name:            "invalid syntax",
+			rules:           scimfilter.Populations,
+			filter:          `name sw`,
+			wantErrContains: "invalid SCIM filter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate(tt.filter)
+			if tt.wantErrContains == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErrContains)
+		})
+	}
+}
+
+func TestRules_CheckComparison(t *testing.T) {
+	assert.NoError(t, scimfilter.Populations.CheckComparison("name", "SW"))
+	assert.EqualError(t, scimfilter.Populations.CheckComparison("name", "co"), "'name' only supports sw")
+	assert.NoError(t, scimfilter.AuditActivities.CheckComparison("anything", "co"), "attributes are not restricted")
+}
+
+func TestRules_CheckLogicalOperator(t *testing.T) {
+	assert.NoError(t, scimfilter.Environments.CheckLogicalOperator("AND"))
+	assert.EqualError(t, scimfilter.Environments.CheckLogicalOperator("or"), "'or' is not supported; supported operators to combine conditions are and")
+	assert.NoError(t, scimfilter.Populations.CheckLogicalOperator("or"), "logical operators are not restricted")
+}
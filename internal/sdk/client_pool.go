@@ -0,0 +1,116 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk
+
+import (
+	"crypto/sha256"
+	"slices"
+	"sync"
+
+	"github.com/pingidentity/pingone-go-client/pingone"
+)
+
+// DefaultClientPoolSize is the number of access tokens whose clients are kept for reuse. Clients are created per
+// access token, so a few are enough for the current session and the sessions of recently switched profiles.
+const DefaultClientPoolSize = 8
+
+// ClientPool keeps the clients created for the most recently used access tokens, so that the tool calls of a
+// session reuse an authenticated client rather than creating one per call. The clients of the least recently used
+// access token are dropped when the pool is full. Access tokens are only kept as hashes. It is safe for
+// concurrent use.
+type ClientPool[T any] struct {
+	size int
+
+	mu      sync.Mutex
+	clients map[[sha256.Size]byte]T
+	// recent are the keys of the pooled clients, from least to most recently used
+	recent [][sha256.Size]byte
+}
+
+// NewClientPool creates a pool that keeps the clients of up to size access tokens.
+func NewClientPool[T any](size int) *ClientPool[T] {
+	return &ClientPool[T]{
+		size:    max(size, 1),
+		clients: make(map[[sha256.Size]byte]T),
+	}
+}
+
+// Get returns the pooled client of the access token, or creates and pools one with create. Clients that fail
+// to be created are not pooled, so that the next call tries again.
+func (p *ClientPool[T]) Get(accessToken string, create func() (T, error)) (T, error) {
+	key := sha256.Sum256([]byte(accessToken))
+
+	// Creating a client does not call PingOne, so concurrent calls for a new access token wait for it to be
+	// created once rather than each creating their own
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if client, ok := p.clients[key]; ok {
+		p.touch(key)
+		return client, nil
+	}
+
+	client, err := create()
+	if err != nil {
+		return client, err
+	}
+	if len(p.recent) >= p.size {
+		delete(p.clients, p.recent[0])
+		p.recent = p.recent[1:]
+	}
+	p.clients[key] = client
+	p.recent = append(p.recent, key)
+	return client, nil
+}
+
+// Len returns the number of pooled clients.
+func (p *ClientPool[T]) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
+
+// Clear drops all pooled clients, such as after switching profile, when new clients must be created with the
+// configuration of the new profile.
+func (p *ClientPool[T]) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.clients = make(map[[sha256.Size]byte]T)
+	p.recent = nil
+}
+
+// touch marks the key as the most recently used
+func (p *ClientPool[T]) touch(key [sha256.Size]byte) {
+	if i := slices.Index(p.recent, key); i >= 0 {
+		p.recent = append(slices.Delete(p.recent, i, i+1), key)
+	}
+}
+
+var _ ClientFactory = &PooledClientFactory{}
+
+// PooledClientFactory reuses the clients of another factory for calls made with the same access token.
+type PooledClientFactory struct {
+	factory ClientFactory
+	pool    *ClientPool[*pingone.APIClient]
+}
+
+// NewPooledClientFactory creates a factory that pools the clients of factory for up to size access tokens.
+func NewPooledClientFactory(factory ClientFactory, size int) *PooledClientFactory {
+	return &PooledClientFactory{
+		factory: factory,
+		pool:    NewClientPool[*pingone.APIClient](size),
+	}
+}
+
+func (f *PooledClientFactory) NewClient(accessToken string) (*pingone.APIClient, error) {
+	return f.pool.Get(accessToken, func() (*pingone.APIClient, error) {
+		return f.factory.NewClient(accessToken)
+	})
+}
+
+// Clear drops all pooled clients.
+func (f *PooledClientFactory) Clear() {
+	f.pool.Clear()
+}
@@ -0,0 +1,122 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingClientFactory creates a new client per call, counting the calls per access token
+type countingClientFactory struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *countingClientFactory) NewClient(accessToken string) (*pingone.APIClient, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.calls == nil {
+		f.calls = map[string]int{}
+	}
+	f.calls[accessToken]++
+	return &pingone.APIClient{}, nil
+}
+
+func TestClientPool_ReusesClientsPerAccessToken(t *testing.T) {
+	pool := sdk.NewClientPool[*int](sdk.DefaultClientPoolSize)
+	var created atomic.Int32
+	create := func() (*int, error) {
+		client := int(created.Add(1))
+		return &client, nil
+	}
+
+	first, err := pool.Get("token-1", create)
+	require.NoError(t, err)
+	again, err := pool.Get("token-1", create)
+	require.NoError(t, err)
+	other, err := pool.Get("token-2", create)
+	require.NoError(t, err)
+
+	assert.Same(t, first, again)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, int32(2), created.Load())
+	assert.Equal(t, 2, pool.Len())
+}
+
+func TestClientPool_DropsLeastRecentlyUsedClients(t *testing.T) {
+	pool := sdk.NewClientPool[string](2)
+	create := func(client string) func() (string, error) {
+		return func() (string, error) { return client, nil }
+	}
+
+	_, _ = pool.Get("token-1", create("client-1"))
+	_, _ = pool.Get("token-2", create("client-2"))
+	// Using token-1 again makes token-2 the least recently used
+	_, _ = pool.Get("token-1", create("unused"))
+	_, _ = pool.Get("token-3", create("client-3"))
+
+	assert.Equal(t, 2, pool.Len())
+	client, _ := pool.Get("token-1", create("recreated-1"))
+	assert.Equal(t, "client-1", client)
+	client, _ = pool.Get("token-2", create("recreated-2"))
+	assert.Equal(t, "recreated-2", client, "the least recently used client is dropped")
+}
+
+func TestClientPool_ErrorsAreNotPooled(t *testing.T) {
+	pool := sdk.NewClientPool[string](sdk.DefaultClientPoolSize)
+
+	_, err := pool.Get("token-1", func() (string, error) { return "", errors.New("invalid configuration") })
+	require.Error(t, err)
+	assert.Equal(t, 0, pool.Len())
+
+	client, err := pool.Get("token-1", func() (string, error) { return "client-1", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "client-1", client)
+}
+
+func TestClientPool_Clear(t *testing.T) {
+	pool := sdk.NewClientPool[string](sdk.DefaultClientPoolSize)
+	_, _ = pool.Get("token-1", func() (string, error) { return "client-1", nil })
+
+	pool.Clear()
+
+	assert.Equal(t, 0, pool.Len())
+	client, _ := pool.Get("token-1", func() (string, error) { return "recreated-1", nil })
+	assert.Equal(t, "recreated-1", client)
+}
+
+func TestPooledClientFactory_ConcurrentCalls(t *testing.T) {
+	factory := &countingClientFactory{}
+	pooledFactory := sdk.NewPooledClientFactory(factory, sdk.DefaultClientPoolSize)
+
+	clients := make([]*pingone.APIClient, 50)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client, err := pooledFactory.NewClient("token-1")
+			assert.NoError(t, err)
+			clients[i] = client
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, factory.calls["token-1"], "the client is created once for concurrent calls")
+	for _, client := range clients {
+		assert.Same(t, clients[0], client)
+	}
+
+	pooledFactory.Clear()
+	_, err := pooledFactory.NewClient("token-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, factory.calls["token-1"])
+}
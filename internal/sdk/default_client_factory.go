@@ -4,24 +4,49 @@ package sdk
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/pingidentity/pingone-go-client/config"
 	"github.com/pingidentity/pingone-go-client/pingone"
 	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
 )
 
 var _ ClientFactory = &DefaultClientFactory{}
+var _ RetryConfigurable = &DefaultClientFactory{}
+var _ TransportWrapperConfigurable = &DefaultClientFactory{}
+var _ auth.ClientSettingsConfigurable = &DefaultClientFactory{}
 
 type DefaultClientFactory struct {
-	serverVersion string
+	serverVersion          string
+	retryOptions           RetryOptions
+	wrapper                TransportWrapper
+	clientSettingsProvider auth.ClientSettingsProvider
 }
 
 func NewDefaultClientFactory(serverVersion string) *DefaultClientFactory {
 	return &DefaultClientFactory{
 		serverVersion: serverVersion,
+		retryOptions:  DefaultRetryOptions(),
 	}
 }
 
+// SetRetryOptions configures how clients created after the call retry rate limited requests
+func (f *DefaultClientFactory) SetRetryOptions(options RetryOptions) {
+	f.retryOptions = options
+}
+
+// SetTransportWrapper wraps the transport of clients created after the call
+func (f *DefaultClientFactory) SetTransportWrapper(wrapper TransportWrapper) {
+	f.wrapper = wrapper
+}
+
+// SetClientSettingsProvider makes clients created after the call connect to the root domain of the provider's
+// client settings, instead of the root domain configured by environment variable
+func (f *DefaultClientFactory) SetClientSettingsProvider(provider auth.ClientSettingsProvider) {
+	f.clientSettingsProvider = provider
+}
+
 func (f *DefaultClientFactory) NewClient(accessToken string) (*pingone.APIClient, error) {
 	if accessToken == "" {
 		return nil, fmt.Errorf("provided accessToken is empty, client cannot be initialized")
@@ -29,6 +54,19 @@ func (f *DefaultClientFactory) NewClient(accessToken string) (*pingone.APIClient
 	clientConfig := config.NewConfiguration().WithAccessToken(accessToken)
 	pingOneConfig := pingone.NewConfiguration(clientConfig)
 	pingOneConfig.AppendUserAgent(audit.PingOneAPIUserAgent(f.serverVersion))
+	// The root domain of the client settings is set once the environment variables are loaded, so that it takes precedence
+	if f.clientSettingsProvider != nil {
+		if settings := f.clientSettingsProvider.ClientSettings(); settings != nil {
+			clientConfig.WithRootDomain(settings.RootDomain)
+		}
+	}
+	var transport http.RoundTripper = NewRegionTransport(NewTracingTransport(NewRetryTransport(SharedTransport(), f.retryOptions)))
+	if f.wrapper != nil {
+		transport = f.wrapper(transport)
+	}
+	pingOneConfig.HTTPClient = &http.Client{
+		Transport: transport,
+	}
 	apiClient, err := pingone.NewAPIClient(pingOneConfig)
 	if err != nil {
 		return nil, err
@@ -3,9 +3,19 @@
 package sdk
 
 import (
+	"net/http"
+
 	"github.com/pingidentity/pingone-go-client/pingone"
 )
 
 type ClientFactory interface {
 	NewClient(accessToken string) (*pingone.APIClient, error)
 }
+
+// TransportWrapper wraps the transport of the clients of a factory, such as to record their requests
+type TransportWrapper func(transport http.RoundTripper) http.RoundTripper
+
+// TransportWrapperConfigurable is implemented by client factories whose client transports can be wrapped
+type TransportWrapperConfigurable interface {
+	SetTransportWrapper(wrapper TransportWrapper)
+}
@@ -9,15 +9,21 @@ package legacy
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
 	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
 	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 )
 
 var _ ClientFactory = &DefaultClientFactory{}
+var _ sdk.RetryConfigurable = &DefaultClientFactory{}
+var _ sdk.TransportWrapperConfigurable = &DefaultClientFactory{}
+var _ auth.ClientSettingsConfigurable = &DefaultClientFactory{}
 
 // DefaultClientFactory creates PingOne API clients using the legacy SDK (v2).
 // It configures clients with proper authentication, region settings, and user agent
@@ -27,6 +33,20 @@ type DefaultClientFactory struct {
 	// serverVersion is the version of the MCP server, included in the User-Agent header
 	// for API request tracking and debugging purposes.
 	serverVersion string
+
+	// retryOptions configures how requests rejected with 429 Too Many Requests or
+	// 503 Service Unavailable are retried. Unlike the pingone-go-client SDK, the legacy
+	// SDK does not retry requests itself.
+	retryOptions sdk.RetryOptions
+
+	// wrapper wraps the transport of the clients, such as to record their requests.
+	// Nil leaves the transport unwrapped.
+	wrapper sdk.TransportWrapper
+
+	// clientSettingsProvider provides the root domain used in place of the PINGONE_ROOT_DOMAIN
+	// environment variable, such as the root domain of the active profile. Nil, or a provider
+	// without client settings, leaves the root domain to the environment variable.
+	clientSettingsProvider auth.ClientSettingsProvider
 }
 
 // NewDefaultClientFactory creates a new DefaultClientFactory instance.
@@ -44,9 +64,26 @@ type DefaultClientFactory struct {
 func NewDefaultClientFactory(serverVersion string) *DefaultClientFactory {
 	return &DefaultClientFactory{
 		serverVersion: serverVersion,
+		retryOptions:  sdk.DefaultRetryOptions(),
 	}
 }
 
+// SetRetryOptions configures how clients created after the call retry rate limited requests.
+func (f *DefaultClientFactory) SetRetryOptions(options sdk.RetryOptions) {
+	f.retryOptions = options
+}
+
+// SetTransportWrapper wraps the transport of clients created after the call.
+func (f *DefaultClientFactory) SetTransportWrapper(wrapper sdk.TransportWrapper) {
+	f.wrapper = wrapper
+}
+
+// SetClientSettingsProvider makes clients created after the call use the root domain of the
+// provider's client settings in place of the PINGONE_ROOT_DOMAIN environment variable.
+func (f *DefaultClientFactory) SetClientSettingsProvider(provider auth.ClientSettingsProvider) {
+	f.clientSettingsProvider = provider
+}
+
 // NewClient creates a new PingOne API client instance using the legacy SDK.
 // It returns a fully configured client ready to make API calls to PingOne services.
 //
@@ -61,7 +98,8 @@ func NewDefaultClientFactory(serverVersion string) *DefaultClientFactory {
 //
 // External Dependencies:
 // This method requires the PINGONE_ROOT_DOMAIN environment variable to be set to determine
-// the correct regional API endpoints. Supported values are:
+// the correct regional API endpoints, unless the client settings provider of the factory provides
+// a root domain in its place. Supported values are:
 //   - "pingone.com" for North America (NA)
 //   - "pingone.eu" for Europe (EU)
 //   - "pingone.asia" for Asia Pacific (AP)
@@ -90,8 +128,13 @@ func (f *DefaultClientFactory) NewClient(ctx context.Context, accessToken string
 		return nil, fmt.Errorf("access token is empty or contains only whitespace, cannot initialize client")
 	}
 
-	// Retrieve and validate the root domain from environment
+	// Retrieve and validate the root domain from the client settings, or otherwise the environment
 	rootDomain := os.Getenv("PINGONE_ROOT_DOMAIN")
+	if f.clientSettingsProvider != nil {
+		if settings := f.clientSettingsProvider.ClientSettings(); settings != nil {
+			rootDomain = settings.RootDomain
+		}
+	}
 	regionCode, err := f.regionCodeFromRootDomain(rootDomain)
 	if err != nil {
 		return nil, fmt.Errorf("failed to determine region from root domain %q: %w", rootDomain, err)
@@ -115,6 +158,23 @@ func (f *DefaultClientFactory) NewClient(ctx context.Context, accessToken string
 		return nil, fmt.Errorf("failed to initialize API client: %w", err)
 	}
 
+	// Retry rate limited requests, so that long paginated list operations are not
+	// abandoned part way through when PingOne rate limits are reached, trace them for tool calls that request it,
+	// and send them to the region of the tool call's environment when it differs from the configured region
+	var transport http.RoundTripper = sdk.NewRegionTransport(sdk.NewTracingTransport(sdk.NewRetryTransport(sdk.SharedTransport(), f.retryOptions)))
+	if f.wrapper != nil {
+		transport = f.wrapper(transport)
+	}
+	httpClient := &http.Client{
+		Transport: transport,
+	}
+	apiClient.AuthorizeAPIClient.GetConfig().HTTPClient = httpClient
+	apiClient.CredentialsAPIClient.GetConfig().HTTPClient = httpClient
+	apiClient.ManagementAPIClient.GetConfig().HTTPClient = httpClient
+	apiClient.MFAAPIClient.GetConfig().HTTPClient = httpClient
+	apiClient.RiskAPIClient.GetConfig().HTTPClient = httpClient
+	apiClient.VerifyAPIClient.GetConfig().HTTPClient = httpClient
+
 	return apiClient, nil
 }
 
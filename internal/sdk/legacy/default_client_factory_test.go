@@ -4,10 +4,13 @@ package legacy
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"testing"
 
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -72,6 +75,49 @@ func TestDefaultClientFactory_NewClient_MissingEnvironmentVariable(t *testing.T)
 	assert.Contains(t, err.Error(), "empty or contains only whitespace")
 }
 
+func TestDefaultClientFactory_NewClient_TransportWrapper(t *testing.T) {
+	t.Setenv("PINGONE_ROOT_DOMAIN", "pingone.com")
+
+	wrapped := &http.Transport{}
+	var wrappedTransport http.RoundTripper
+	factory := NewDefaultClientFactory("1.0.0")
+	factory.SetTransportWrapper(func(transport http.RoundTripper) http.RoundTripper {
+		wrappedTransport = transport
+		return wrapped
+	})
+
+	client, err := factory.NewClient(context.Background(), "valid-token")
+
+	require.NoError(t, err)
+	assert.IsType(t, &sdk.RegionTransport{}, wrappedTransport, "the wrapper should wrap the region transport")
+	assert.Same(t, wrapped, client.ManagementAPIClient.GetConfig().HTTPClient.Transport)
+}
+
+type testClientSettingsProvider struct {
+	settings *auth.ClientSettings
+}
+
+func (p *testClientSettingsProvider) ClientSettings() *auth.ClientSettings {
+	return p.settings
+}
+
+func TestDefaultClientFactory_NewClient_ClientSettingsProvider(t *testing.T) {
+	t.Setenv("PINGONE_ROOT_DOMAIN", "pingone.com")
+	provider := &testClientSettingsProvider{}
+	factory := NewDefaultClientFactory("1.0.0")
+	factory.SetClientSettingsProvider(provider)
+
+	client, err := factory.NewClient(context.Background(), "valid-token")
+	require.NoError(t, err)
+	assert.Equal(t, management.ENUMREGIONCODE_NA, client.Region.APICode, "the environment variable should be used without client settings")
+
+	provider.settings = &auth.ClientSettings{RootDomain: "pingone.eu"}
+	client, err = factory.NewClient(context.Background(), "valid-token")
+	require.NoError(t, err)
+	assert.Equal(t, management.ENUMREGIONCODE_EU, client.Region.APICode)
+	assert.Equal(t, "pingone.com", os.Getenv("PINGONE_ROOT_DOMAIN"))
+}
+
 func TestDefaultClientFactory_regionCodeFromRootDomain(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package legacy
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultPageConcurrency is the number of lists read at the same time by ForEachPageConcurrently. It is kept low,
+// as PingOne rate limits API requests per environment and rate limited requests are retried with backoff.
+const DefaultPageConcurrency = 4
+
+// ErrStopPages is returned by the visit function of ForEachPageConcurrently to stop reading pages without an error
+var ErrStopPages = errors.New("stop reading pages")
+
+// PagedList starts reading a list of PingOne resources, such as the users of a population
+type PagedList func(ctx context.Context) (management.EntityArrayPagedIterator, error)
+
+// ForEachPageConcurrently calls visit with each page of the lists, reading at most maxConcurrency lists at the same
+// time. PingOne pages are read with opaque cursors, so the pages of a list are read in order; large collections are
+// read concurrently by partitioning them into several lists, such as the users of each population.
+//
+// visit is called with one page at a time, with the index of the list the page belongs to, so it does not need to
+// synchronize access to the results it collects. Reading stops at the first error, which is returned, or when visit
+// returns ErrStopPages.
+func ForEachPageConcurrently(ctx context.Context, maxConcurrency int, lists []PagedList, visit func(list int, embedded *management.EntityArrayEmbedded) error) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(maxConcurrency, 1))
+
+	var (
+		mu sync.Mutex
+		// visitErr is the error visit returned, which stops the reading of all lists
+		visitErr error
+	)
+	for i, list := range lists {
+		group.Go(func() error {
+			if groupCtx.Err() != nil {
+				return groupCtx.Err()
+			}
+			iterator, err := list(groupCtx)
+			if err != nil {
+				return err
+			}
+			for next, err := range iterator {
+				logger.LogHttpResponse(ctx, next.HTTPResponse)
+				mu.Lock()
+				stopped := visitErr != nil
+				mu.Unlock()
+				if stopped {
+					return ErrStopPages
+				}
+				if err != nil && ctx.Err() == nil && groupCtx.Err() != nil {
+					// Another list failed, and its error is the one returned
+					return groupCtx.Err()
+				}
+				if err != nil {
+					apiErr := errs.NewApiError(next.HTTPResponse, err)
+					errs.Log(ctx, apiErr)
+					return apiErr
+				}
+				if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+					// This should never happen, err should be set if no data
+					apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+					errs.Log(ctx, apiErr)
+					return apiErr
+				}
+				mu.Lock()
+				if visitErr == nil {
+					visitErr = visit(i, next.EntityArray.Embedded)
+				}
+				stopped = visitErr != nil
+				mu.Unlock()
+				if stopped {
+					return ErrStopPages
+				}
+			}
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if visitErr != nil {
+		err = visitErr
+	}
+	if errors.Is(err, ErrStopPages) {
+		return nil
+	}
+	return err
+}
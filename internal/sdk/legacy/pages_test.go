@@ -0,0 +1,153 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package legacy_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userPage returns a page with users of the given usernames
+func userPage(usernames ...string) testutils.LegacySdkMockPage {
+	embedded := management.EntityArrayEmbedded{}
+	for _, username := range usernames {
+		embedded.Users = append(embedded.Users, management.User{Username: username})
+	}
+	return testutils.LegacySdkMockPage{
+		EntityArray:  &management.EntityArray{Embedded: &embedded},
+		HTTPResponse: &http.Response{StatusCode: http.StatusOK},
+	}
+}
+
+func pagedList(pages ...testutils.LegacySdkMockPage) legacy.PagedList {
+	return func(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+		return testutils.MockLegacySdkPaginationIterator(pages), nil
+	}
+}
+
+func TestForEachPageConcurrently_ReadsAllLists(t *testing.T) {
+	lists := []legacy.PagedList{
+		pagedList(userPage("a1", "a2"), userPage("a3")),
+		pagedList(userPage("b1")),
+		pagedList(),
+		pagedList(userPage("d1"), userPage("d2"), userPage("d3")),
+	}
+
+	usernames := make([][]string, len(lists))
+	err := legacy.ForEachPageConcurrently(t.Context(), 2, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		for _, user := range embedded.Users {
+			usernames[list] = append(usernames[list], user.Username)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a1", "a2", "a3"}, {"b1"}, nil, {"d1", "d2", "d3"}}, usernames, "pages of a list should be read in order")
+}
+
+func TestForEachPageConcurrently_LimitsConcurrency(t *testing.T) {
+	var active, maxActive atomic.Int32
+	list := func(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+		current := active.Add(1)
+		defer active.Add(-1)
+		for {
+			previous := maxActive.Load()
+			if current <= previous || maxActive.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{userPage("user")}), nil
+	}
+	lists := make([]legacy.PagedList, 10)
+	for i := range lists {
+		lists[i] = list
+	}
+
+	pages := 0
+	err := legacy.ForEachPageConcurrently(t.Context(), 3, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		pages++
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, pages)
+	assert.LessOrEqual(t, maxActive.Load(), int32(3))
+	assert.Greater(t, maxActive.Load(), int32(1), "lists should be read concurrently")
+}
+
+func TestForEachPageConcurrently_StopPages(t *testing.T) {
+	lists := []legacy.PagedList{
+		pagedList(userPage("a1"), userPage("a2"), userPage("a3")),
+	}
+
+	var usernames []string
+	err := legacy.ForEachPageConcurrently(t.Context(), 1, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		usernames = append(usernames, embedded.Users[0].Username)
+		if len(usernames) == 2 {
+			return legacy.ErrStopPages
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a2"}, usernames)
+}
+
+func TestForEachPageConcurrently_VisitError(t *testing.T) {
+	visitErr := errors.New("visit failed")
+	lists := []legacy.PagedList{
+		pagedList(userPage("a1")),
+		pagedList(userPage("b1")),
+	}
+
+	err := legacy.ForEachPageConcurrently(t.Context(), 2, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		return visitErr
+	})
+
+	assert.ErrorIs(t, err, visitErr)
+}
+
+func TestForEachPageConcurrently_ApiError(t *testing.T) {
+	lists := []legacy.PagedList{
+		pagedList(userPage("a1")),
+		pagedList(testutils.LegacySdkMockPage{
+			HTTPResponse: &http.Response{StatusCode: http.StatusForbidden, Status: "403 Forbidden"},
+			Error:        errors.New("forbidden"),
+		}),
+	}
+
+	err := legacy.ForEachPageConcurrently(t.Context(), 1, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		return nil
+	})
+
+	var apiErr *errs.ApiError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+}
+
+func TestForEachPageConcurrently_ListError(t *testing.T) {
+	listErr := errors.New("PingOne client is not initialized")
+	lists := []legacy.PagedList{
+		func(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+			return nil, listErr
+		},
+	}
+
+	err := legacy.ForEachPageConcurrently(t.Context(), 1, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, listErr)
+}
@@ -0,0 +1,38 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package legacy
+
+import (
+	"context"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+var _ ClientFactory = &PooledClientFactory{}
+
+// PooledClientFactory reuses the clients of another factory for calls made with the same access token.
+// The context of the call that creates a client does not control the lifetime of the pooled client.
+type PooledClientFactory struct {
+	factory ClientFactory
+	pool    *sdk.ClientPool[*pingone.Client]
+}
+
+// NewPooledClientFactory creates a factory that pools the clients of factory for up to size access tokens.
+func NewPooledClientFactory(factory ClientFactory, size int) *PooledClientFactory {
+	return &PooledClientFactory{
+		factory: factory,
+		pool:    sdk.NewClientPool[*pingone.Client](size),
+	}
+}
+
+func (f *PooledClientFactory) NewClient(ctx context.Context, accessToken string) (*pingone.Client, error) {
+	return f.pool.Get(accessToken, func() (*pingone.Client, error) {
+		return f.factory.NewClient(ctx, accessToken)
+	})
+}
+
+// Clear drops all pooled clients.
+func (f *PooledClientFactory) Clear() {
+	f.pool.Clear()
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package legacy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPooledClientFactory_NewClient(t *testing.T) {
+	t.Setenv("PINGONE_ROOT_DOMAIN", "pingone.com")
+	factory := NewPooledClientFactory(NewDefaultClientFactory("1.0.0"), sdk.DefaultClientPoolSize)
+
+	client, err := factory.NewClient(context.Background(), "token-1")
+	require.NoError(t, err)
+	again, err := factory.NewClient(context.Background(), "token-1")
+	require.NoError(t, err)
+	other, err := factory.NewClient(context.Background(), "token-2")
+	require.NoError(t, err)
+
+	assert.Same(t, client, again)
+	assert.NotSame(t, client, other)
+
+	factory.Clear()
+	recreated, err := factory.NewClient(context.Background(), "token-1")
+	require.NoError(t, err)
+	assert.NotSame(t, client, recreated)
+}
+
+func TestPooledClientFactory_ErrorsAreNotPooled(t *testing.T) {
+	factory := NewPooledClientFactory(NewDefaultClientFactory("1.0.0"), sdk.DefaultClientPoolSize)
+
+	_, err := factory.NewClient(context.Background(), " ")
+
+	require.Error(t, err)
+	assert.Equal(t, 0, factory.pool.Len())
+}
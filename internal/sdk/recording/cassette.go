@@ -0,0 +1,91 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package recording records PingOne API interactions to a file and replays them, so that bugs reported by users
+// can be reproduced deterministically and tool handlers can be tested offline against realistic payloads.
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+)
+
+// Cassette is the file format of recorded PingOne API interactions.
+type Cassette struct {
+	RecordedAt time.Time `json:"recordedAt"`
+	// OrganizationId and EnvironmentId are read from the claims of the access token the requests were sent with,
+	// so that the session can be described when the interactions are replayed
+	OrganizationId string        `json:"organizationId,omitempty"`
+	EnvironmentId  string        `json:"environmentId,omitempty"`
+	Interactions   []Interaction `json:"interactions"`
+}
+
+// Interaction is a PingOne API request and the response it received, with secrets redacted.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+type Request struct {
+	Method  string      `json:"method"`
+	Url     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// LoadCassette reads recorded interactions from a file.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded API interactions file: %w", err)
+	}
+	cassette := &Cassette{}
+	if err := json.Unmarshal(data, cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse recorded API interactions file %s: %w", path, err)
+	}
+	return cassette, nil
+}
+
+// save writes the cassette to a file, replacing the file so that it is never left partially written
+func (c *Cassette) save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recorded API interactions: %w", err)
+	}
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write recorded API interactions file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("failed to write recorded API interactions file: %w", err)
+	}
+	return nil
+}
+
+// redactHeaders returns a copy of headers with the values of sensitive headers, such as Authorization and
+// Set-Cookie, replaced with redact.RedactedValue
+func redactHeaders(headers http.Header) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+	redacted := make(http.Header, len(headers))
+	for name, values := range headers {
+		if redact.IsSensitiveKey(name) {
+			redacted[name] = []string{redact.RedactedValue}
+		} else {
+			redacted[name] = append([]string{}, values...)
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,123 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package recording
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+)
+
+// Recorder records the PingOne API interactions of the transports it creates to a file. The file is rewritten
+// after each interaction, so that the interactions made before the server stops abnormally are kept.
+// It is safe for concurrent use.
+type Recorder struct {
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecorder returns a recorder that writes interactions to the file at path, replacing the file if it exists.
+func NewRecorder(path string) (*Recorder, error) {
+	recorder := &Recorder{
+		path: path,
+		cassette: &Cassette{
+			RecordedAt:   time.Now().UTC(),
+			Interactions: []Interaction{},
+		},
+	}
+	if err := recorder.cassette.save(path); err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+// Interactions returns the interactions recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction{}, r.cassette.Interactions...)
+}
+
+// Transport returns a transport that sends requests with base and records them.
+//
+// The transport should wrap the retrying transport of a client, so that only the response the client
+// receives is recorded and the interactions replay the same way when retries are configured differently.
+func (r *Recorder) Transport(base http.RoundTripper) http.RoundTripper {
+	return &recordingTransport{
+		recorder: r,
+		base:     base,
+	}
+}
+
+func (r *Recorder) add(req *http.Request, interaction Interaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cassette.EnvironmentId == "" {
+		accessToken, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if claims, err := auth.ParseAccessTokenClaims(accessToken); found && err == nil {
+			r.cassette.OrganizationId = claims.OrganizationId
+			r.cassette.EnvironmentId = claims.EnvironmentId
+		}
+	}
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	if err := r.cassette.save(r.path); err != nil {
+		// Recording must not fail the request
+		logger.FromContext(req.Context()).Warn("Failed to record PingOne API interaction", slog.String("error", err.Error()))
+	}
+}
+
+type recordingTransport struct {
+	recorder *Recorder
+	base     http.RoundTripper
+}
+
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	t.recorder.add(req, Interaction{
+		Request: Request{
+			Method:  req.Method,
+			Url:     req.URL.String(),
+			Headers: redactHeaders(req.Header),
+			Body:    redact.JSON(string(requestBody)),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Headers:    redactHeaders(resp.Header),
+			Body:       redact.JSON(string(responseBody)),
+		},
+	})
+	return resp, nil
+}
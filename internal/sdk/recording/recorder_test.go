@@ -0,0 +1,101 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package recording_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/recording"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOrganizationId = "5f4e3d2c-1b0a-4f9e-8d7c-6b5a4f3e2d1c"
+	testEnvironmentId  = "0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f"
+)
+
+// testAccessToken returns an unsigned JWT with the organization and environment claims of a PingOne access token
+func testAccessToken() string {
+	payload, _ := json.Marshal(map[string]string{"org": testOrganizationId, "env": testEnvironmentId})
+	return "eyJhbGciOiJub25lIn0." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func TestRecorder_RecordsInteractionsWithSecretsRedacted(t *testing.T) {
+	var receivedBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receivedBody = string(body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"app-1","secret":"s3cr3t"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	path := filepath.Join(t.TempDir(), "recorded.json")
+	recorder, err := recording.NewRecorder(path)
+	require.NoError(t, err)
+	client := &http.Client{Transport: recorder.Transport(http.DefaultTransport)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/v1/environments/"+testEnvironmentId+"/applications?limit=1", strings.NewReader(`{"name":"App","password":"p4ss"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testAccessToken())
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"name":"App","password":"p4ss"}`, receivedBody, "the request should be sent unredacted")
+	assert.JSONEq(t, `{"id":"app-1","secret":"s3cr3t"}`, string(body), "the response should be returned unredacted")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "p4ss")
+	assert.NotContains(t, string(data), "s3cr3t")
+	assert.NotContains(t, string(data), testAccessToken())
+	assert.NotContains(t, string(data), "session=abc")
+
+	cassette, err := recording.LoadCassette(path)
+	require.NoError(t, err)
+	assert.Equal(t, testOrganizationId, cassette.OrganizationId)
+	assert.Equal(t, testEnvironmentId, cassette.EnvironmentId)
+	require.Len(t, cassette.Interactions, 1)
+	interaction := cassette.Interactions[0]
+	assert.Equal(t, http.MethodPost, interaction.Request.Method)
+	assert.Equal(t, server.URL+"/v1/environments/"+testEnvironmentId+"/applications?limit=1", interaction.Request.Url)
+	assert.Equal(t, redact.RedactedValue, interaction.Request.Headers.Get("Authorization"))
+	assert.JSONEq(t, `{"name":"App","password":"`+redact.RedactedValue+`"}`, interaction.Request.Body)
+	assert.Equal(t, http.StatusCreated, interaction.Response.StatusCode)
+	assert.Equal(t, "application/json", interaction.Response.Headers.Get("Content-Type"))
+	assert.JSONEq(t, `{"id":"app-1","secret":"`+redact.RedactedValue+`"}`, interaction.Response.Body)
+	assert.Equal(t, cassette.Interactions, recorder.Interactions())
+}
+
+func TestRecorder_ReplacesExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recorded.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"interactions":[{"request":{"method":"GET","url":"/old"}}]}`), 0600))
+
+	_, err := recording.NewRecorder(path)
+	require.NoError(t, err)
+
+	cassette, err := recording.LoadCassette(path)
+	require.NoError(t, err)
+	assert.Empty(t, cassette.Interactions)
+	assert.False(t, cassette.RecordedAt.IsZero())
+}
+
+func TestNewRecorder_InvalidPath(t *testing.T) {
+	_, err := recording.NewRecorder(filepath.Join(t.TempDir(), "missing", "recorded.json"))
+	assert.ErrorContains(t, err, "failed to write recorded API interactions file")
+}
@@ -0,0 +1,122 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package recording
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+)
+
+var _ http.RoundTripper = &Replayer{}
+
+// Replayer is an http.RoundTripper that serves recorded PingOne API interactions instead of sending requests.
+//
+// A request is served the first unused interaction with the same method, path and query, preferring one whose
+// request body matches once redacted. When every matching interaction has been used, the last is served again,
+// so that requests the server repeats, such as reads after a cache expires, are still answered. The host is not
+// compared, so interactions replay with any PingOne region. It is safe for concurrent use.
+type Replayer struct {
+	cassette *Cassette
+
+	mu   sync.Mutex
+	used []bool
+}
+
+// NewReplayer returns a replayer of the interactions recorded in the file at path.
+func NewReplayer(path string) (*Replayer, error) {
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+// OrganizationId returns the organization of the recorded session.
+func (r *Replayer) OrganizationId() string {
+	return r.cassette.OrganizationId
+}
+
+// EnvironmentId returns the environment of the recorded session.
+func (r *Replayer) EnvironmentId() string {
+	return r.cassette.EnvironmentId
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		requestBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	interaction, found := r.match(req.Method, req.URL, redact.JSON(string(requestBody)))
+	if !found {
+		return nil, fmt.Errorf("no recorded PingOne API interaction for %s %s", req.Method, req.URL.RequestURI())
+	}
+
+	return &http.Response{
+		StatusCode:    interaction.Response.StatusCode,
+		Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        interaction.Response.Headers.Clone(),
+		Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// match returns the interaction to serve a request with, and marks it used
+func (r *Replayer) match(method string, requestUrl *url.URL, body string) (Interaction, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lastMatch := -1
+	firstUnused := -1
+	for i, interaction := range r.cassette.Interactions {
+		if interaction.Request.Method != method || !sameRequestUri(interaction.Request.Url, requestUrl) {
+			continue
+		}
+		lastMatch = i
+		if r.used[i] {
+			continue
+		}
+		if interaction.Request.Body == body {
+			r.used[i] = true
+			return interaction, true
+		}
+		if firstUnused < 0 {
+			firstUnused = i
+		}
+	}
+	if firstUnused >= 0 {
+		r.used[firstUnused] = true
+		return r.cassette.Interactions[firstUnused], true
+	}
+	if lastMatch >= 0 {
+		return r.cassette.Interactions[lastMatch], true
+	}
+	return Interaction{}, false
+}
+
+// sameRequestUri returns whether a recorded URL has the path and query of a request
+func sameRequestUri(recordedUrl string, requestUrl *url.URL) bool {
+	recorded, err := url.Parse(recordedUrl)
+	if err != nil {
+		return false
+	}
+	return recorded.Path == requestUrl.Path && recorded.Query().Encode() == requestUrl.Query().Encode()
+}
@@ -0,0 +1,174 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package recording_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/mockbackend"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/recording"
+	"github.com/pingidentity/pingone-mcp-server/internal/server"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCassette(t *testing.T, cassette recording.Cassette) string {
+	t.Helper()
+	data, err := json.Marshal(cassette)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "recorded.json")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func interaction(method string, url string, requestBody string, statusCode int, responseBody string) recording.Interaction {
+	return recording.Interaction{
+		Request:  recording.Request{Method: method, Url: url, Body: requestBody},
+		Response: recording.Response{StatusCode: statusCode, Headers: http.Header{"Content-Type": {"application/json"}}, Body: responseBody},
+	}
+}
+
+func replay(t *testing.T, replayer *recording.Replayer, method string, url string, body string) (int, string, error) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: replayer}).Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+	responseBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(responseBody), nil
+}
+
+func TestReplayer_ServesRecordedInteractions(t *testing.T) {
+	path := writeCassette(t, recording.Cassette{
+		OrganizationId: testOrganizationId,
+		EnvironmentId:  testEnvironmentId,
+		Interactions: []recording.Interaction{
+			interaction(http.MethodGet, "https://api.pingone.com/v1/environments?limit=1", "", http.StatusOK, `{"count":1}`),
+			interaction(http.MethodGet, "https://api.pingone.com/v1/environments?limit=1", "", http.StatusOK, `{"count":2}`),
+			interaction(http.MethodPost, "https://api.pingone.com/v1/environments", `{"name":"A","secret":"[REDACTED]"}`, http.StatusCreated, `{"id":"a"}`),
+			interaction(http.MethodPost, "https://api.pingone.com/v1/environments", `{"name":"B"}`, http.StatusCreated, `{"id":"b"}`),
+		},
+	})
+	replayer, err := recording.NewReplayer(path)
+	require.NoError(t, err)
+	assert.Equal(t, testOrganizationId, replayer.OrganizationId())
+	assert.Equal(t, testEnvironmentId, replayer.EnvironmentId())
+
+	// Interactions are served in order, with the last repeated, whatever the host
+	for _, expected := range []string{`{"count":1}`, `{"count":2}`, `{"count":2}`} {
+		status, body, err := replay(t, replayer, http.MethodGet, "https://api.pingone.eu/v1/environments?limit=1", "")
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.JSONEq(t, expected, body)
+	}
+
+	// Interactions with a matching redacted request body are preferred
+	status, body, err := replay(t, replayer, http.MethodPost, "https://api.pingone.com/v1/environments", `{"name":"B"}`)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.JSONEq(t, `{"id":"b"}`, body)
+	_, body, err = replay(t, replayer, http.MethodPost, "https://api.pingone.com/v1/environments", `{"secret":"other","name":"A"}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"a"}`, body)
+
+	_, _, err = replay(t, replayer, http.MethodGet, "https://api.pingone.com/v1/environments?limit=2", "")
+	assert.ErrorContains(t, err, "no recorded PingOne API interaction for GET /v1/environments?limit=2")
+}
+
+func TestNewReplayer_Errors(t *testing.T) {
+	_, err := recording.NewReplayer(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to read recorded API interactions file")
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0600))
+	_, err = recording.NewReplayer(path)
+	assert.ErrorContains(t, err, "failed to parse recorded API interactions file")
+}
+
+// startServer runs the server with every tool, returning a connected client session
+func startServer(t *testing.T, clientFactory sdk.ClientFactory, legacyClientFactory legacy.ClientFactory, authClientFactory client.AuthClientFactory) *mcp.ClientSession {
+	t.Helper()
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	serverDone := make(chan error, 1)
+	go func() {
+		serverDone <- server.Start(ctx, serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           clientFactory,
+			LegacySdkClientFactory:  legacyClientFactory,
+			AuthClientFactory:       authClientFactory,
+			TokenStore:              mockbackend.NewTokenStore(),
+			ToolFilter:              filter.NewFilter(false, nil, nil, nil, nil),
+			GrantType:               auth.GrantTypeAuthorizationCode,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
+	}()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = session.Close()
+		cancel()
+		select {
+		case <-serverDone:
+		case <-time.After(time.Second):
+			t.Error("Server did not stop as expected")
+		}
+	})
+	return session
+}
+
+func callTool(t *testing.T, session *mcp.ClientSession, name string, arguments map[string]any) any {
+	t.Helper()
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: name, Arguments: arguments})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "%s should succeed", name)
+	return result.StructuredContent
+}
+
+func TestReplayer_ReplaysRecordedToolCalls(t *testing.T) {
+	backend, err := mockbackend.NewBackend("")
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "recorded.json")
+	recorder, err := recording.NewRecorder(path)
+	require.NoError(t, err)
+
+	recordingSession := startServer(t,
+		mockbackend.NewClientFactory(recorder.Transport(backend)), mockbackend.NewLegacyClientFactory(recorder.Transport(backend)), mockbackend.NewAuthClientFactory(backend))
+	recordedEnvironments := callTool(t, recordingSession, "list_environments", map[string]any{})
+	recordedEnvironment := callTool(t, recordingSession, "get_environment", map[string]any{"environmentId": backend.EnvironmentId()})
+	require.NotEmpty(t, recorder.Interactions())
+
+	replayer, err := recording.NewReplayer(path)
+	require.NoError(t, err)
+	assert.Equal(t, backend.OrganizationId(), replayer.OrganizationId())
+	assert.Equal(t, backend.EnvironmentId(), replayer.EnvironmentId())
+
+	replayingSession := startServer(t,
+		mockbackend.NewClientFactory(replayer), mockbackend.NewLegacyClientFactory(replayer), mockbackend.NewStaticAuthClientFactory(replayer.OrganizationId(), replayer.EnvironmentId()))
+	assert.Equal(t, recordedEnvironments, callTool(t, replayingSession, "list_environments", map[string]any{}))
+	assert.Equal(t, recordedEnvironment, callTool(t, replayingSession, "get_environment", map[string]any{"environmentId": backend.EnvironmentId()}))
+}
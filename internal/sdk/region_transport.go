@@ -0,0 +1,110 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// RootDomains are the PingOne root domains by region code, as the region of an environment is reported by
+// the PingOne API.
+var RootDomains = map[string]string{
+	"NA": "pingone.com",
+	"EU": "pingone.eu",
+	"AP": "pingone.asia",
+	"AU": "pingone.com.au",
+	"CA": "pingone.ca",
+	"SG": "pingone.sg",
+}
+
+// RegionCodes returns the codes of the PingOne regions, sorted.
+func RegionCodes() []string {
+	codes := make([]string, 0, len(RootDomains))
+	for code := range RootDomains {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// RootDomainForRegion returns the root domain of a PingOne region, named by its code, such as EU, or by its
+// root domain, such as pingone.eu. Both are matched case-insensitively.
+func RootDomainForRegion(region string) (string, error) {
+	normalized := strings.TrimSpace(region)
+	if rootDomain, ok := RootDomains[strings.ToUpper(normalized)]; ok {
+		return rootDomain, nil
+	}
+	for _, rootDomain := range RootDomains {
+		if strings.EqualFold(rootDomain, normalized) {
+			return rootDomain, nil
+		}
+	}
+	return "", fmt.Errorf("unknown PingOne region %q, must be one of %s or their root domains", region, strings.Join(RegionCodes(), ", "))
+}
+
+type rootDomainContextKey struct{}
+
+// ContextWithRootDomain returns a context whose PingOne API requests are sent to the region of the root domain,
+// instead of the region the client was created for.
+func ContextWithRootDomain(ctx context.Context, rootDomain string) context.Context {
+	return context.WithValue(ctx, rootDomainContextKey{}, rootDomain)
+}
+
+// RootDomainFromContext returns the root domain that requests made with the context are sent to, or an empty
+// string if they are sent to the region the client was created for.
+func RootDomainFromContext(ctx context.Context) string {
+	rootDomain, _ := ctx.Value(rootDomainContextKey{}).(string)
+	return rootDomain
+}
+
+// RegionTransport is an http.RoundTripper that sends PingOne API requests to the region of the root domain of
+// the request context, when it has one, so that a client created for one region can manage environments in
+// another. Requests to hosts that are not PingOne hosts are sent unchanged.
+type RegionTransport struct {
+	base http.RoundTripper
+}
+
+// NewRegionTransport returns a transport that sends requests with base to the region of their context
+func NewRegionTransport(base http.RoundTripper) *RegionTransport {
+	return &RegionTransport{
+		base: base,
+	}
+}
+
+func (t *RegionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rootDomain := RootDomainFromContext(req.Context())
+	if rootDomain == "" {
+		return t.base.RoundTrip(req)
+	}
+	host, ok := regionalHost(req.URL.Host, rootDomain)
+	if !ok || host == req.URL.Host {
+		return t.base.RoundTrip(req)
+	}
+
+	// The request must not be modified, so the URL of a clone is changed
+	regionalReq := req.Clone(req.Context())
+	regionalReq.URL.Host = host
+	regionalReq.Host = ""
+	return t.base.RoundTrip(regionalReq)
+}
+
+// regionalHost returns the host of a PingOne service, such as api.pingone.com, in the region of the root domain,
+// or false if the host is not a PingOne host
+func regionalHost(host string, rootDomain string) (string, bool) {
+	hostname, port, hasPort := strings.Cut(host, ":")
+	for _, candidate := range RootDomains {
+		if !strings.HasSuffix(strings.ToLower(hostname), "."+candidate) {
+			continue
+		}
+		regional := hostname[:len(hostname)-len(candidate)] + rootDomain
+		if hasPort {
+			regional += ":" + port
+		}
+		return regional, true
+	}
+	return "", false
+}
@@ -0,0 +1,88 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRootDomainForRegion(t *testing.T) {
+	tests := []struct {
+		region   string
+		expected string
+	}{
+		{region: "NA", expected: "pingone.com"},
+		{region: "eu", expected: "pingone.eu"},
+		{region: " AP ", expected: "pingone.asia"},
+		{region: "pingone.com.au", expected: "pingone.com.au"},
+		{region: "PingOne.CA", expected: "pingone.ca"},
+		{region: "SG", expected: "pingone.sg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.region, func(t *testing.T) {
+			rootDomain, err := sdk.RootDomainForRegion(tt.region)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rootDomain)
+		})
+	}
+
+	_, err := sdk.RootDomainForRegion("mars")
+	assert.ErrorContains(t, err, `unknown PingOne region "mars", must be one of AP, AU, CA, EU, NA, SG`)
+}
+
+func TestRegionTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		rootDomain   string
+		expectedHost string
+	}{
+		{name: "without a region", url: "https://api.pingone.com/v1/environments", expectedHost: "api.pingone.com"},
+		{name: "api host", url: "https://api.pingone.com/v1/environments", rootDomain: "pingone.eu", expectedHost: "api.pingone.eu"},
+		{name: "from a region with a longer root domain", url: "https://api.pingone.com.au/v1/environments", rootDomain: "pingone.ca", expectedHost: "api.pingone.ca"},
+		{name: "to a region with a longer root domain", url: "https://auth.pingone.com/as/token", rootDomain: "pingone.com.au", expectedHost: "auth.pingone.com.au"},
+		{name: "with a port", url: "https://api.pingone.com:443/v1/environments", rootDomain: "pingone.sg", expectedHost: "api.pingone.sg:443"},
+		{name: "not a PingOne host", url: "https://example.com/v1/environments", rootDomain: "pingone.eu", expectedHost: "example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sentHost string
+			transport := sdk.NewRegionTransport(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				sentHost = req.URL.Host
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+			}))
+
+			ctx := context.Background()
+			if tt.rootDomain != "" {
+				ctx = sdk.ContextWithRootDomain(ctx, tt.rootDomain)
+			}
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			originalHost := req.URL.Host
+
+			resp, err := transport.RoundTrip(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedHost, sentHost)
+			assert.Equal(t, originalHost, req.URL.Host, "the request should not be modified")
+		})
+	}
+}
+
+func TestRootDomainFromContext(t *testing.T) {
+	assert.Empty(t, sdk.RootDomainFromContext(context.Background()))
+	assert.Equal(t, "pingone.eu", sdk.RootDomainFromContext(sdk.ContextWithRootDomain(context.Background(), "pingone.eu")))
+}
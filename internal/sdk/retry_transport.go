@@ -0,0 +1,188 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+const (
+	DefaultMaxRetries  = 5
+	DefaultBaseBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff  = 30 * time.Second
+)
+
+// RetryOptions configures how PingOne API requests that are rate limited or temporarily unavailable are retried
+type RetryOptions struct {
+	// MaxRetries is the number of times a request is retried before giving up. 0 disables retries.
+	MaxRetries int
+	// BaseBackoff is the wait before the first retry when the response has no Retry-After header,
+	// doubled for each following retry
+	BaseBackoff time.Duration
+	// MaxBackoff is the longest wait before a retry. Requests that PingOne asks to retry later than this are not retried.
+	MaxBackoff time.Duration
+}
+
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries:  DefaultMaxRetries,
+		BaseBackoff: DefaultBaseBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// Validate checks the retry options
+func (o RetryOptions) Validate() error {
+	if o.MaxRetries < 0 {
+		return errors.New("max retries must not be negative")
+	}
+	if o.BaseBackoff <= 0 || o.MaxBackoff <= 0 {
+		return errors.New("retry backoff must be greater than zero")
+	}
+	return nil
+}
+
+// RetryConfigurable is implemented by client factories whose clients retry rate limited requests
+type RetryConfigurable interface {
+	SetRetryOptions(options RetryOptions)
+}
+
+// RetriesExhaustedError is returned when a request is still rate limited or unavailable after it has been retried,
+// or when PingOne asks for it to be retried later than the maximum backoff
+type RetriesExhaustedError struct {
+	StatusCode int
+	Attempts   int
+	RetryAfter time.Duration
+}
+
+func (e *RetriesExhaustedError) Error() string {
+	message := fmt.Sprintf("PingOne API request failed with status %d after %d attempts", e.StatusCode, e.Attempts)
+	if e.StatusCode == http.StatusTooManyRequests {
+		message = fmt.Sprintf("PingOne API rate limit exceeded after %d attempts", e.Attempts)
+	}
+	if e.RetryAfter > 0 {
+		message += fmt.Sprintf(", retry after %s", e.RetryAfter)
+	}
+	return message
+}
+
+// RetryTransport retries requests that PingOne rejects with 429 Too Many Requests or 503 Service Unavailable.
+// It waits for the time in the response's Retry-After header, or otherwise backs off exponentially with jitter.
+// Requests are not processed by PingOne when rejected with these statuses, so requests of any method are retried.
+//
+// When the retries are exhausted, the transport returns a RetriesExhaustedError rather than the response,
+// so that SDK clients with retries of their own do not retry the request again.
+type RetryTransport struct {
+	base    http.RoundTripper
+	options RetryOptions
+}
+
+// NewRetryTransport returns a transport that sends requests with base, retrying them as configured by the options
+func NewRetryTransport(base http.RoundTripper, options RetryOptions) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{
+		base:    base,
+		options: options,
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Requests with a body that cannot be read again are sent once
+	canRetry := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	attemptReq := req
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil || !canRetry || !isRetryableStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		retryAfter, hasRetryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if attempt > t.options.MaxRetries || retryAfter > t.options.MaxBackoff {
+			drain(resp)
+			return nil, &RetriesExhaustedError{StatusCode: resp.StatusCode, Attempts: attempt, RetryAfter: retryAfter}
+		}
+		wait := retryAfter
+		if !hasRetryAfter {
+			wait = t.backoff(attempt)
+		}
+		drain(resp)
+
+		logger.FromContext(req.Context()).Warn("PingOne API request was rejected, retrying",
+			slog.String("method", req.Method),
+			slog.String("url", req.URL.String()),
+			slog.Int("statusCode", resp.StatusCode),
+			slog.Int("attempt", attempt),
+			slog.Duration("wait", wait))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if call := sdkCallFromContext(req.Context()); call != nil {
+			call.Retries++
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			attemptReq.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// backoff returns the wait before the retry following the attempt: the base backoff doubled for each
+// earlier retry, capped at the maximum backoff, of which a random half is added as jitter so that
+// concurrent requests rejected together do not retry together
+func (t *RetryTransport) backoff(attempt int) time.Duration {
+	backoff := t.options.BaseBackoff
+	for i := 1; i < attempt && backoff < t.options.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	half := min(backoff, t.options.MaxBackoff) / 2
+	return half + rand.N(half+1)
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// drain reads and closes the body of a response that is not returned, so that its connection can be reused
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	_ = resp.Body.Close()
+}
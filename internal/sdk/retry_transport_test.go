@@ -0,0 +1,160 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryOptions retries quickly, so that tests of backoff without Retry-After headers do not wait
+var testRetryOptions = sdk.RetryOptions{
+	MaxRetries:  3,
+	BaseBackoff: time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+}
+
+// rejectingServer rejects the first requests with the status and headers, then succeeds, and records the bodies it receives
+func rejectingServer(t *testing.T, rejections int, status int, headers map[string]string) (*httptest.Server, *atomic.Int32, *[]string) {
+	t.Helper()
+	var requests atomic.Int32
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if int(requests.Add(1)) <= rejections {
+			for name, value := range headers {
+				w.Header().Set(name, value)
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"code":"REQUEST_LIMITED"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"ok"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests, &bodies
+}
+
+func retryingClient(options sdk.RetryOptions) *http.Client {
+	return &http.Client{Transport: sdk.NewRetryTransport(http.DefaultTransport, options)}
+}
+
+func TestRetryTransport_RetriesRateLimitedRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+	}{
+		{name: "too many requests with retry-after seconds", status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "0"}},
+		{name: "too many requests with retry-after date", status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": time.Now().Add(-time.Second).UTC().Format(http.TimeFormat)}},
+		{name: "too many requests without retry-after", status: http.StatusTooManyRequests},
+		{name: "service unavailable", status: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests, _ := rejectingServer(t, 2, tt.status, tt.headers)
+
+			resp, err := retryingClient(testRetryOptions).Get(server.URL)
+
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			body, _ := io.ReadAll(resp.Body)
+			assert.JSONEq(t, `{"id":"ok"}`, string(body))
+			assert.EqualValues(t, 3, requests.Load())
+		})
+	}
+}
+
+func TestRetryTransport_ResendsRequestBody(t *testing.T) {
+	server, requests, bodies := rejectingServer(t, 1, http.StatusTooManyRequests, map[string]string{"Retry-After": "0"})
+
+	resp, err := retryingClient(testRetryOptions).Post(server.URL, "application/json", strings.NewReader(`{"name":"Test"}`))
+
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.EqualValues(t, 2, requests.Load())
+	assert.Equal(t, []string{`{"name":"Test"}`, `{"name":"Test"}`}, *bodies)
+}
+
+func TestRetryTransport_DoesNotRetryOtherStatuses(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server, requests, _ := rejectingServer(t, 1, status, nil)
+
+			resp, err := retryingClient(testRetryOptions).Get(server.URL)
+
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, status, resp.StatusCode)
+			assert.EqualValues(t, 1, requests.Load())
+		})
+	}
+}
+
+func TestRetryTransport_RetriesExhausted(t *testing.T) {
+	server, requests, _ := rejectingServer(t, 10, http.StatusTooManyRequests, map[string]string{"Retry-After": "0"})
+
+	_, err := retryingClient(testRetryOptions).Get(server.URL)
+
+	var exhaustedErr *sdk.RetriesExhaustedError
+	require.ErrorAs(t, err, &exhaustedErr)
+	assert.Equal(t, http.StatusTooManyRequests, exhaustedErr.StatusCode)
+	assert.Equal(t, 4, exhaustedErr.Attempts)
+	assert.ErrorContains(t, err, "PingOne API rate limit exceeded after 4 attempts")
+	assert.EqualValues(t, 4, requests.Load())
+}
+
+func TestRetryTransport_RetriesDisabled(t *testing.T) {
+	server, requests, _ := rejectingServer(t, 1, http.StatusServiceUnavailable, nil)
+	options := testRetryOptions
+	options.MaxRetries = 0
+
+	_, err := retryingClient(options).Get(server.URL)
+
+	assert.ErrorContains(t, err, "PingOne API request failed with status 503 after 1 attempts")
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestRetryTransport_RetryAfterBeyondMaxBackoff(t *testing.T) {
+	server, requests, _ := rejectingServer(t, 1, http.StatusTooManyRequests, map[string]string{"Retry-After": "120"})
+
+	start := time.Now()
+	_, err := retryingClient(testRetryOptions).Get(server.URL)
+
+	assert.ErrorContains(t, err, "retry after 2m0s")
+	assert.EqualValues(t, 1, requests.Load())
+	assert.Less(t, time.Since(start), testRetryOptions.MaxBackoff, "the request should fail without waiting")
+}
+
+func TestRetryTransport_ContextCancelledWhileWaiting(t *testing.T) {
+	server, requests, _ := rejectingServer(t, 1, http.StatusTooManyRequests, map[string]string{"Retry-After": "3"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = retryingClient(testRetryOptions).Do(req)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.EqualValues(t, 1, requests.Load())
+}
+
+func TestRetryOptions_Validate(t *testing.T) {
+	assert.NoError(t, sdk.DefaultRetryOptions().Validate())
+	assert.NoError(t, sdk.RetryOptions{MaxRetries: 0, BaseBackoff: time.Second, MaxBackoff: time.Second}.Validate())
+	assert.ErrorContains(t, sdk.RetryOptions{MaxRetries: -1, BaseBackoff: time.Second, MaxBackoff: time.Second}.Validate(), "max retries must not be negative")
+	assert.ErrorContains(t, sdk.RetryOptions{MaxRetries: 1, BaseBackoff: time.Second}.Validate(), "retry backoff must be greater than zero")
+}
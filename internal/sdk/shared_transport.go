@@ -0,0 +1,27 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk
+
+import (
+	"net/http"
+)
+
+// DefaultMaxIdleConnsPerHost is the number of idle connections kept open to each PingOne host for reuse. The
+// net/http default of 2 would close and reopen connections to the PingOne API whenever more tool calls run at
+// once, such as the calls of several clients of the HTTP transport.
+const DefaultMaxIdleConnsPerHost = 32
+
+var sharedTransport = newSharedTransport()
+
+// SharedTransport returns the transport that the clients of the default client factories send their requests
+// with, so that connections to PingOne are kept alive and reused across clients and tool calls.
+func SharedTransport() http.RoundTripper {
+	return sharedTransport
+}
+
+func newSharedTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	transport.MaxIdleConns = max(transport.MaxIdleConns, DefaultMaxIdleConnsPerHost)
+	return transport
+}
@@ -0,0 +1,22 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedTransport(t *testing.T) {
+	transport, ok := sdk.SharedTransport().(*http.Transport)
+	require.True(t, ok)
+
+	assert.Equal(t, sdk.DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.GreaterOrEqual(t, transport.MaxIdleConns, sdk.DefaultMaxIdleConnsPerHost)
+	assert.NotSame(t, http.DefaultTransport, transport, "the default transport is not changed")
+	assert.Same(t, sdk.SharedTransport(), sdk.SharedTransport())
+}
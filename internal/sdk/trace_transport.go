@@ -0,0 +1,161 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CorrelationIdHeader is the header of PingOne API responses that identifies the request, which PingOne support
+// can use to find it.
+const CorrelationIdHeader = "Correlation-Id"
+
+// SdkCall describes a PingOne API request made by an SDK client.
+type SdkCall struct {
+	Method        string `json:"method"`
+	Url           string `json:"url"`
+	StatusCode    int    `json:"statusCode,omitempty"`
+	DurationMs    int64  `json:"durationMs"`
+	Retries       int    `json:"retries"`
+	CorrelationId string `json:"correlationId,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// CallTrace collects the PingOne API requests made with a context, so that the requests made by a tool call
+// can be reported with its result. It is safe for concurrent use.
+type CallTrace struct {
+	mu    sync.Mutex
+	calls []SdkCall
+}
+
+// NewCallTrace creates an empty trace.
+func NewCallTrace() *CallTrace {
+	return &CallTrace{
+		calls: []SdkCall{},
+	}
+}
+
+// Calls returns the requests recorded so far, in the order they completed.
+func (t *CallTrace) Calls() []SdkCall {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]SdkCall{}, t.calls...)
+}
+
+func (t *CallTrace) add(call SdkCall) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.calls = append(t.calls, call)
+}
+
+type callTraceContextKey struct{}
+
+type sdkCallContextKey struct{}
+
+// ContextWithCallTrace returns a context that records the PingOne API requests made with it in the trace.
+func ContextWithCallTrace(ctx context.Context, trace *CallTrace) context.Context {
+	return context.WithValue(ctx, callTraceContextKey{}, trace)
+}
+
+// CallTraceFromContext returns the trace of the context, or nil if requests made with it are not traced.
+func CallTraceFromContext(ctx context.Context) *CallTrace {
+	trace, _ := ctx.Value(callTraceContextKey{}).(*CallTrace)
+	return trace
+}
+
+// sdkCallFromContext returns the call being traced for a request, so that retries of the request can be counted
+func sdkCallFromContext(ctx context.Context) *SdkCall {
+	call, _ := ctx.Value(sdkCallContextKey{}).(*SdkCall)
+	return call
+}
+
+// TracingTransport is an http.RoundTripper that records PingOne API requests as OpenTelemetry spans and metrics,
+// and in the CallTrace of the request context when it carries one.
+//
+// The transport should wrap a RetryTransport, so that each traced call covers all of its attempts and
+// counts its retries.
+type TracingTransport struct {
+	base http.RoundTripper
+}
+
+// NewTracingTransport returns a transport that sends requests with base and traces them
+func NewTracingTransport(base http.RoundTripper) *TracingTransport {
+	return &TracingTransport{
+		base: base,
+	}
+}
+
+// The global OpenTelemetry providers do nothing until telemetry is set up, and forward to the configured
+// providers afterwards, so the instruments can be created once for all clients
+var (
+	tracer             = otel.Tracer(telemetry.InstrumentationName)
+	apiRequestDuration = mustFloat64Histogram(otel.Meter(telemetry.InstrumentationName).Float64Histogram("http.client.request.duration",
+		metric.WithDescription("Duration of PingOne API requests, including retries."),
+		metric.WithUnit("s")))
+)
+
+func mustFloat64Histogram(histogram metric.Float64Histogram, err error) metric.Float64Histogram {
+	if err != nil {
+		panic(err)
+	}
+	return histogram
+}
+
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The query is omitted, as filters can hold personal data such as email addresses
+	url := *req.URL
+	url.RawQuery = ""
+	call := &SdkCall{
+		Method: req.Method,
+		Url:    url.String(),
+	}
+
+	ctx, span := tracer.Start(req.Context(), req.Method, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req.WithContext(context.WithValue(ctx, sdkCallContextKey{}, call)))
+	duration := time.Since(start)
+	call.DurationMs = duration.Milliseconds()
+
+	attributes := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", req.URL.Hostname()),
+	}
+	if err != nil {
+		call.Error = err.Error()
+		attributes = append(attributes, attribute.String("error.type", fmt.Sprintf("%T", err)))
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		call.StatusCode = resp.StatusCode
+		call.CorrelationId = resp.Header.Get(CorrelationIdHeader)
+		attributes = append(attributes, attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			attributes = append(attributes, attribute.String("error.type", strconv.Itoa(resp.StatusCode)))
+			span.SetStatus(codes.Error, "")
+		}
+	}
+	apiRequestDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attributes...))
+	span.SetAttributes(attributes...)
+	span.SetAttributes(
+		attribute.String("url.full", call.Url),
+		attribute.Int("http.request.resend_count", call.Retries))
+
+	if callTrace := CallTraceFromContext(req.Context()); callTrace != nil {
+		callTrace.add(*call)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,136 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package sdk_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func tracingClient(options sdk.RetryOptions) *http.Client {
+	return &http.Client{Transport: sdk.NewTracingTransport(sdk.NewRetryTransport(http.DefaultTransport, options))}
+}
+
+func tracedGet(t *testing.T, client *http.Client, ctx context.Context, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return resp
+}
+
+func TestTracingTransport_RecordsCalls(t *testing.T) {
+	server, _, _ := rejectingServer(t, 0, http.StatusTooManyRequests, nil)
+	trace := sdk.NewCallTrace()
+	ctx := sdk.ContextWithCallTrace(context.Background(), trace)
+	client := tracingClient(testRetryOptions)
+
+	tracedGet(t, client, ctx, server.URL+"/environments/env-1/users?filter=email%20eq%20%22user@example.com%22")
+	tracedGet(t, client, ctx, server.URL+"/environments/env-1")
+
+	calls := trace.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, http.MethodGet, calls[0].Method)
+	assert.Equal(t, server.URL+"/environments/env-1/users", calls[0].Url, "the query should be omitted")
+	assert.Equal(t, http.StatusOK, calls[0].StatusCode)
+	assert.Equal(t, 0, calls[0].Retries)
+	assert.Empty(t, calls[0].Error)
+	assert.Equal(t, server.URL+"/environments/env-1", calls[1].Url)
+}
+
+func TestTracingTransport_RecordsCorrelationIds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(sdk.CorrelationIdHeader, "8c1a2b3d-correlation")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+	trace := sdk.NewCallTrace()
+	ctx := sdk.ContextWithCallTrace(context.Background(), trace)
+
+	tracedGet(t, tracingClient(testRetryOptions), ctx, server.URL+"/environments/env-1")
+
+	calls := trace.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, http.StatusNotFound, calls[0].StatusCode)
+	assert.Equal(t, "8c1a2b3d-correlation", calls[0].CorrelationId)
+}
+
+func TestTracingTransport_CountsRetries(t *testing.T) {
+	server, requests, _ := rejectingServer(t, 2, http.StatusTooManyRequests, map[string]string{"Retry-After": "0"})
+	trace := sdk.NewCallTrace()
+	ctx := sdk.ContextWithCallTrace(context.Background(), trace)
+
+	tracedGet(t, tracingClient(testRetryOptions), ctx, server.URL)
+
+	assert.Equal(t, int32(3), requests.Load())
+	calls := trace.Calls()
+	require.Len(t, calls, 1, "retries should be part of the same call")
+	assert.Equal(t, http.StatusOK, calls[0].StatusCode)
+	assert.Equal(t, 2, calls[0].Retries)
+}
+
+func TestTracingTransport_RecordsErrors(t *testing.T) {
+	server, _, _ := rejectingServer(t, 0, http.StatusTooManyRequests, nil)
+	server.Close()
+	trace := sdk.NewCallTrace()
+	ctx := sdk.ContextWithCallTrace(context.Background(), trace)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+	require.NoError(t, err)
+
+	_, err = tracingClient(testRetryOptions).Do(req)
+
+	require.Error(t, err)
+	calls := trace.Calls()
+	require.Len(t, calls, 1)
+	assert.Equal(t, http.MethodPost, calls[0].Method)
+	assert.Zero(t, calls[0].StatusCode)
+	assert.NotEmpty(t, calls[0].Error)
+}
+
+func TestTracingTransport_UntracedContext(t *testing.T) {
+	server, requests, _ := rejectingServer(t, 0, http.StatusTooManyRequests, nil)
+
+	resp := tracedGet(t, tracingClient(testRetryOptions), context.Background(), server.URL)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), requests.Load())
+	assert.Nil(t, sdk.CallTraceFromContext(context.Background()))
+}
+
+// Only this test sets the global tracer provider, as it can only be set once
+func TestTracingTransport_RecordsSpans(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	server, _, _ := rejectingServer(t, 1, http.StatusServiceUnavailable, map[string]string{"Retry-After": "0"})
+
+	tracedGet(t, tracingClient(testRetryOptions), context.Background(), server.URL+"/environments?filter=name%20eq%20%22Test%22")
+
+	var span sdktrace.ReadOnlySpan
+	for _, ended := range spans.Ended() {
+		if ended.SpanKind() == trace.SpanKindClient {
+			span = ended
+		}
+	}
+	require.NotNil(t, span)
+	assert.Equal(t, http.MethodGet, span.Name())
+	attributes := attribute.NewSet(span.Attributes()...)
+	url, _ := attributes.Value("url.full")
+	assert.Equal(t, server.URL+"/environments", url.AsString())
+	statusCode, _ := attributes.Value("http.response.status_code")
+	assert.Equal(t, int64(http.StatusOK), statusCode.AsInt64())
+	retries, _ := attributes.Value("http.request.resend_count")
+	assert.Equal(t, int64(1), retries.AsInt64())
+}
@@ -0,0 +1,242 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+)
+
+const (
+	// BearerTokenSecretName is the name the rotated bearer tokens are held under in the token store
+	BearerTokenSecretName = "http_bearer_tokens"
+	// RotateBearerTokenPath is the endpoint that rotates the stored bearer token of a running server
+	RotateBearerTokenPath = "/admin/rotate-bearer-token"
+	// DefaultBearerTokenOverlap is how long the previous bearer token is still accepted after a rotation,
+	// so that clients can be given the new token without downtime
+	DefaultBearerTokenOverlap = 24 * time.Hour
+
+	// rotateScope is granted to the current bearer token only, so that a previous token cannot rotate
+	rotateScope = "bearer-token:rotate"
+	// bearerTokenBytes is the length of generated bearer tokens before encoding
+	bearerTokenBytes = 32
+	// bearerTokenReloadInterval limits how often requests with an unknown token cause the stored tokens to be
+	// read again, to pick up tokens rotated by another process
+	bearerTokenReloadInterval = 10 * time.Second
+)
+
+// StoredBearerTokens are the bearer tokens held in the token store: the current token and, for an overlap
+// window after a rotation, the token it replaced.
+type StoredBearerTokens struct {
+	Current           string     `json:"current"`
+	RotatedAt         time.Time  `json:"rotatedAt"`
+	Previous          string     `json:"previous,omitempty"`
+	PreviousExpiresAt *time.Time `json:"previousExpiresAt,omitempty"`
+}
+
+// verify returns the token info of the current token or of the previous token within its overlap window
+func (t StoredBearerTokens) verify(token string, now time.Time) (*auth.TokenInfo, bool) {
+	if t.Current != "" && subtle.ConstantTimeCompare([]byte(token), []byte(t.Current)) == 1 {
+		return &auth.TokenInfo{
+			Scopes:     []string{rotateScope},
+			UserID:     "bearer-token",
+			Expiration: now.Add(bearerTokenLifetime),
+		}, true
+	}
+	if t.Previous != "" && t.PreviousExpiresAt != nil && now.Before(*t.PreviousExpiresAt) &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(t.Previous)) == 1 {
+		return &auth.TokenInfo{
+			// Sessions opened with the previous token carry on with the current one
+			UserID:     "bearer-token",
+			Expiration: *t.PreviousExpiresAt,
+		}, true
+	}
+	return nil, false
+}
+
+// LoadBearerTokens reads the bearer tokens from the token store. The error is tokenstore.ErrSecretNotFound if
+// no bearer token has been generated.
+func LoadBearerTokens(store tokenstore.SecretStore) (*StoredBearerTokens, error) {
+	value, err := store.GetSecret(BearerTokenSecretName)
+	if err != nil {
+		return nil, err
+	}
+	var tokens StoredBearerTokens
+	if err := json.Unmarshal([]byte(value), &tokens); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stored HTTP bearer tokens: %w", err)
+	}
+	if tokens.Current == "" {
+		return nil, errors.New("stored HTTP bearer tokens have no current token")
+	}
+	return &tokens, nil
+}
+
+// RotateBearerToken generates a new bearer token and saves it in the token store as the current token. The
+// token it replaces is accepted for the overlap, or is no longer accepted if the overlap is 0. A token
+// still in the overlap window of an earlier rotation is no longer accepted.
+func RotateBearerToken(store tokenstore.SecretStore, overlap time.Duration, now time.Time) (*StoredBearerTokens, error) {
+	if overlap < 0 {
+		return nil, errors.New("bearer token overlap must not be negative")
+	}
+	current, err := LoadBearerTokens(store)
+	if err != nil && !errors.Is(err, tokenstore.ErrSecretNotFound) {
+		return nil, err
+	}
+
+	token := make([]byte, bearerTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate HTTP bearer token: %w", err)
+	}
+	rotated := StoredBearerTokens{
+		Current:   base64.RawURLEncoding.EncodeToString(token),
+		RotatedAt: now.UTC(),
+	}
+	if current != nil && overlap > 0 {
+		expiresAt := now.Add(overlap).UTC()
+		rotated.Previous = current.Current
+		rotated.PreviousExpiresAt = &expiresAt
+	}
+
+	value, err := json.Marshal(rotated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal HTTP bearer tokens: %w", err)
+	}
+	if err := store.PutSecret(BearerTokenSecretName, string(value)); err != nil {
+		return nil, err
+	}
+	return &rotated, nil
+}
+
+// BearerTokenKeyring authenticates requests with the bearer tokens held in the token store, and rotates them
+// for the rotation endpoint. Tokens rotated by another process, such as the rotate-bearer-token command, are
+// picked up when a request first sends the new token, without restarting the server.
+type BearerTokenKeyring struct {
+	store tokenstore.SecretStore
+	now   func() time.Time
+
+	mu       sync.Mutex
+	tokens   StoredBearerTokens
+	loadedAt time.Time
+}
+
+// NewBearerTokenKeyring reads the bearer tokens from the token store. The error is tokenstore.ErrSecretNotFound
+// if no bearer token has been generated.
+func NewBearerTokenKeyring(store tokenstore.SecretStore) (*BearerTokenKeyring, error) {
+	return newBearerTokenKeyring(store, time.Now)
+}
+
+func newBearerTokenKeyring(store tokenstore.SecretStore, now func() time.Time) (*BearerTokenKeyring, error) {
+	tokens, err := LoadBearerTokens(store)
+	if err != nil {
+		return nil, err
+	}
+	return &BearerTokenKeyring{
+		store:    store,
+		now:      now,
+		tokens:   *tokens,
+		loadedAt: now(),
+	}, nil
+}
+
+// verify accepts the current token, and the previous token within its overlap window. An unknown token causes
+// the stored tokens to be read again, at most once per reload interval.
+func (k *BearerTokenKeyring) verify(ctx context.Context, token string, req *http.Request) (*auth.TokenInfo, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	if tokenInfo, ok := k.tokens.verify(token, now); ok {
+		return tokenInfo, nil
+	}
+	if now.Sub(k.loadedAt) < bearerTokenReloadInterval {
+		return nil, auth.ErrInvalidToken
+	}
+	k.loadedAt = now
+	tokens, err := LoadBearerTokens(k.store)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to reload stored HTTP bearer tokens", slog.String("error", err.Error()))
+		return nil, auth.ErrInvalidToken
+	}
+	if tokens.RotatedAt != k.tokens.RotatedAt {
+		logger.FromContext(ctx).Info("Stored HTTP bearer token was rotated", slog.Time("rotatedAt", tokens.RotatedAt))
+	}
+	k.tokens = *tokens
+	if tokenInfo, ok := k.tokens.verify(token, now); ok {
+		return tokenInfo, nil
+	}
+	return nil, auth.ErrInvalidToken
+}
+
+// rotate generates a new current token, saving it in the token store
+func (k *BearerTokenKeyring) rotate(overlap time.Duration) (*StoredBearerTokens, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	tokens, err := RotateBearerToken(k.store, overlap, now)
+	if err != nil {
+		return nil, err
+	}
+	k.tokens = *tokens
+	k.loadedAt = now
+	return tokens, nil
+}
+
+// RotateBearerTokenOutput is the response of the rotation endpoint
+type RotateBearerTokenOutput struct {
+	BearerToken            string     `json:"bearerToken"`
+	RotatedAt              time.Time  `json:"rotatedAt"`
+	PreviousTokenExpiresAt *time.Time `json:"previousTokenExpiresAt,omitempty"`
+}
+
+// rotateBearerTokenHandler rotates the bearer token on POST requests, returning the new token. The overlap
+// query parameter sets how long the replaced token is still accepted, as a duration such as 1h.
+func rotateBearerTokenHandler(ctx context.Context, keyring *BearerTokenKeyring) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		overlap := DefaultBearerTokenOverlap
+		if value := r.URL.Query().Get("overlap"); value != "" {
+			parsed, err := time.ParseDuration(value)
+			if err != nil || parsed < 0 {
+				http.Error(w, "overlap must be a non-negative duration, such as 1h", http.StatusBadRequest)
+				return
+			}
+			overlap = parsed
+		}
+
+		tokens, err := keyring.rotate(overlap)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to rotate HTTP bearer token", slog.String("error", err.Error()))
+			http.Error(w, "failed to rotate bearer token", http.StatusInternalServerError)
+			return
+		}
+		logger.FromContext(ctx).Info("HTTP bearer token rotated",
+			slog.String("remoteAddr", r.RemoteAddr),
+			slog.Duration("overlap", overlap))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(RotateBearerTokenOutput{
+			BearerToken:            tokens.Current,
+			RotatedAt:              tokens.RotatedAt,
+			PreviousTokenExpiresAt: tokens.PreviousExpiresAt,
+		})
+	})
+}
@@ -0,0 +1,200 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapSecretStore is an in-memory tokenstore.SecretStore. The testutils store cannot be used here, as testutils
+// depends on this package through the run command.
+type mapSecretStore struct {
+	mu      sync.Mutex
+	secrets map[string]string
+}
+
+func (s *mapSecretStore) PutSecret(name string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secrets == nil {
+		s.secrets = map[string]string{}
+	}
+	s.secrets[name] = value
+	return nil
+}
+
+func (s *mapSecretStore) GetSecret(name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.secrets[name]
+	if !ok {
+		return "", tokenstore.ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// testClock is a clock that only moves when advanced
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestKeyring(t *testing.T, store tokenstore.SecretStore, clock *testClock) *BearerTokenKeyring {
+	t.Helper()
+	_, err := RotateBearerToken(store, DefaultBearerTokenOverlap, clock.Now())
+	require.NoError(t, err)
+	keyring, err := newBearerTokenKeyring(store, clock.Now)
+	require.NoError(t, err)
+	return keyring
+}
+
+func verifyToken(keyring *BearerTokenKeyring, token string) (*auth.TokenInfo, error) {
+	return keyring.verify(context.Background(), token, nil)
+}
+
+func TestRotateBearerToken(t *testing.T) {
+	store := &mapSecretStore{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	first, err := RotateBearerToken(store, time.Hour, now)
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.Current)
+	assert.Equal(t, now, first.RotatedAt)
+	assert.Empty(t, first.Previous, "the first token replaces no token")
+	assert.Nil(t, first.PreviousExpiresAt)
+
+	second, err := RotateBearerToken(store, time.Hour, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Current, second.Current)
+	assert.Equal(t, first.Current, second.Previous)
+	require.NotNil(t, second.PreviousExpiresAt)
+	assert.Equal(t, now.Add(time.Minute+time.Hour), *second.PreviousExpiresAt)
+
+	stored, err := LoadBearerTokens(store)
+	require.NoError(t, err)
+	assert.Equal(t, second, stored)
+
+	third, err := RotateBearerToken(store, 0, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, third.Previous, "no overlap stops accepting the replaced token")
+	assert.Nil(t, third.PreviousExpiresAt)
+
+	_, err = RotateBearerToken(store, -time.Second, now)
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestLoadBearerTokens_NotFound(t *testing.T) {
+	_, err := LoadBearerTokens(&mapSecretStore{})
+	assert.ErrorIs(t, err, tokenstore.ErrSecretNotFound)
+
+	_, err = NewBearerTokenKeyring(&mapSecretStore{})
+	assert.ErrorIs(t, err, tokenstore.ErrSecretNotFound)
+}
+
+func TestBearerTokenKeyring_Overlap(t *testing.T) {
+	clock := &testClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	keyring := newTestKeyring(t, &mapSecretStore{}, clock)
+	previous := keyring.tokens.Current
+
+	rotated, err := keyring.rotate(time.Hour)
+	require.NoError(t, err)
+
+	tokenInfo, err := verifyToken(keyring, rotated.Current)
+	require.NoError(t, err)
+	assert.Equal(t, []string{rotateScope}, tokenInfo.Scopes, "the current token can rotate")
+
+	tokenInfo, err = verifyToken(keyring, previous)
+	require.NoError(t, err)
+	assert.Empty(t, tokenInfo.Scopes, "the previous token cannot rotate")
+	assert.Equal(t, clock.Now().Add(time.Hour), tokenInfo.Expiration)
+
+	clock.Advance(time.Hour)
+	_, err = verifyToken(keyring, previous)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken, "the previous token expires after the overlap")
+
+	_, err = verifyToken(keyring, "wrong-token")
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestBearerTokenKeyring_ReloadsExternalRotation(t *testing.T) {
+	store := &mapSecretStore{}
+	clock := &testClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	keyring := newTestKeyring(t, store, clock)
+	previous := keyring.tokens.Current
+
+	// Rotated by another process, such as the rotate-bearer-token command
+	rotated, err := RotateBearerToken(store, time.Hour, clock.Now())
+	require.NoError(t, err)
+
+	_, err = verifyToken(keyring, rotated.Current)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken, "the stored tokens are not read again within the reload interval")
+
+	clock.Advance(bearerTokenReloadInterval)
+	_, err = verifyToken(keyring, rotated.Current)
+	require.NoError(t, err)
+	_, err = verifyToken(keyring, previous)
+	require.NoError(t, err, "the replaced token is accepted for the overlap")
+}
+
+func TestRotateBearerTokenEndpoint(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	keyring := newTestKeyring(t, &mapSecretStore{}, clock)
+	current := keyring.tokens.Current
+
+	handler := NewHandler(t.Context(), mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), Options{BearerTokenKeyring: keyring})
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	rotate := func(t *testing.T, method string, token string, query string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequestWithContext(t.Context(), method, httpServer.URL+RotateBearerTokenPath+query, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, rotate(t, http.MethodPost, "wrong-token", "").StatusCode)
+	assert.Equal(t, http.StatusMethodNotAllowed, rotate(t, http.MethodGet, current, "").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, rotate(t, http.MethodPost, current, "?overlap=soon").StatusCode)
+	assert.Equal(t, http.StatusBadRequest, rotate(t, http.MethodPost, current, "?overlap=-1h").StatusCode)
+
+	resp := rotate(t, http.MethodPost, current, "?overlap=1h")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	var output RotateBearerTokenOutput
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&output))
+	assert.NotEqual(t, current, output.BearerToken)
+	require.NotNil(t, output.PreviousTokenExpiresAt)
+	assert.WithinDuration(t, clock.Now().Add(time.Hour), *output.PreviousTokenExpiresAt, time.Second)
+
+	// The replaced token is still accepted within the overlap, but can no longer rotate
+	assert.Equal(t, http.StatusForbidden, rotate(t, http.MethodPost, current, "").StatusCode)
+	assert.Equal(t, http.StatusOK, rotate(t, http.MethodPost, output.BearerToken, "?overlap=0s").StatusCode)
+}
@@ -0,0 +1,249 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package httptransport serves the MCP server to remote clients over HTTP, using the streamable HTTP
+// transport with a fallback to the earlier HTTP+SSE transport.
+package httptransport
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+const (
+	// StreamableHttpPath is the endpoint of the streamable HTTP transport
+	StreamableHttpPath = "/mcp"
+	// SsePath is the endpoint of the HTTP+SSE transport, for clients that do not support streamable HTTP
+	SsePath = "/sse"
+
+	// BearerTokenEnvVar is the environment variable holding the bearer token that clients must send
+	BearerTokenEnvVar = "PINGONE_MCP_HTTP_BEARER_TOKEN"
+
+	DefaultAddress         = "127.0.0.1:8080"
+	DefaultSessionTimeout  = 30 * time.Minute
+	DefaultShutdownTimeout = 10 * time.Second
+
+	// bearerTokenLifetime is the lifetime reported for the static bearer token, which does not expire
+	bearerTokenLifetime = 24 * time.Hour
+)
+
+// Options configures the HTTP transport
+type Options struct {
+	// Address is the host and port to listen on
+	Address string
+	// BearerToken is the token every request must send in its Authorization header. Empty allows
+	// unauthenticated requests, which is only accepted on a loopback address.
+	BearerToken string
+	// BearerTokenKeyring authenticates requests with the rotatable bearer tokens held in the token store instead
+	// of BearerToken, and serves the endpoint that rotates them.
+	BearerTokenKeyring *BearerTokenKeyring
+	// SessionTimeout closes streamable HTTP sessions that receive no requests for this long. 0 keeps idle sessions open.
+	SessionTimeout time.Duration
+	// ShutdownTimeout is how long in-flight requests are given to complete when the server stops
+	ShutdownTimeout time.Duration
+	// OAuth authenticates requests with access tokens issued by an OAuth authorization server instead of
+	// the bearer token. The SSE endpoint is not served with OAuth.
+	OAuth *OAuthOptions
+	// Status serves the status page with the same authentication as the MCP endpoints. Nil does not serve it.
+	Status *Status
+}
+
+// Validate checks the options, so that misconfiguration is reported when the server starts
+func (o Options) Validate() error {
+	host, _, err := net.SplitHostPort(o.Address)
+	if err != nil {
+		return fmt.Errorf("invalid HTTP address %q: %w", o.Address, err)
+	}
+	if o.SessionTimeout < 0 {
+		return errors.New("HTTP session timeout must not be negative")
+	}
+	if o.ShutdownTimeout < 0 {
+		return errors.New("HTTP shutdown timeout must not be negative")
+	}
+	if o.BearerToken != "" && o.BearerTokenKeyring != nil {
+		return fmt.Errorf("the %s environment variable cannot be combined with stored bearer tokens", BearerTokenEnvVar)
+	}
+	if o.OAuth != nil {
+		if o.BearerToken != "" {
+			return fmt.Errorf("the %s environment variable cannot be combined with OAuth", BearerTokenEnvVar)
+		}
+		if o.BearerTokenKeyring != nil {
+			return errors.New("stored bearer tokens cannot be combined with OAuth")
+		}
+		return o.OAuth.Validate()
+	}
+	if strings.TrimSpace(o.BearerToken) == "" && o.BearerTokenKeyring == nil && !isLoopback(host) {
+		return fmt.Errorf("serving HTTP on a non-loopback address requires a bearer token, set with the %s environment variable or generated with the rotate-bearer-token command", BearerTokenEnvVar)
+	}
+	return nil
+}
+
+// NewHandler returns the HTTP handler serving the MCP server on the streamable HTTP and SSE endpoints.
+// When OAuth or a bearer token is configured, every request to the endpoints must be authenticated. With
+// stored bearer tokens, the bearer token rotation endpoint is also served. With a status page, it is served at
+// StatusPath and requires the same authentication.
+func NewHandler(ctx context.Context, server *mcp.Server, opts Options) http.Handler {
+	getServer := func(*http.Request) *mcp.Server {
+		return server
+	}
+	var streamableHandler http.Handler = mcp.NewStreamableHTTPHandler(getServer, &mcp.StreamableHTTPOptions{
+		Logger:         logger.FromContext(ctx),
+		SessionTimeout: opts.SessionTimeout,
+	})
+	var sseHandler http.Handler = mcp.NewSSEHandler(getServer, nil)
+
+	mux := http.NewServeMux()
+	var requireAuth func(http.Handler) http.Handler
+	switch {
+	case opts.OAuth != nil:
+		// Clients discover how to obtain a token from the metadata, so it is served without authentication
+		metadataHandler := auth.ProtectedResourceMetadataHandler(opts.OAuth.protectedResourceMetadata())
+		mux.Handle(ProtectedResourceMetadataPath, metadataHandler)
+		if path := opts.OAuth.resourceMetadataPath(); path != ProtectedResourceMetadataPath {
+			mux.Handle(path, metadataHandler)
+		}
+		verifier := newJwtVerifier(opts.OAuth.Issuer, opts.OAuth.audience())
+		requireAuth = func(handler http.Handler) http.Handler {
+			return requireOAuth(ctx, opts.OAuth, verifier, handler)
+		}
+		mux.Handle(StreamableHttpPath, requireAuth(streamableHandler))
+	case opts.BearerTokenKeyring != nil:
+		requireAuth = auth.RequireBearerToken(opts.BearerTokenKeyring.verify, nil)
+		mux.Handle(StreamableHttpPath, requireAuth(streamableHandler))
+		mux.Handle(SsePath, requireAuth(sseHandler))
+		// Only the current token can rotate, so that a replaced token cannot be used to obtain a new one
+		requireRotateScope := auth.RequireBearerToken(opts.BearerTokenKeyring.verify, &auth.RequireBearerTokenOptions{Scopes: []string{rotateScope}})
+		mux.Handle(RotateBearerTokenPath, requireRotateScope(rotateBearerTokenHandler(ctx, opts.BearerTokenKeyring)))
+	case opts.BearerToken != "":
+		requireAuth = auth.RequireBearerToken(bearerTokenVerifier(opts.BearerToken), nil)
+		mux.Handle(StreamableHttpPath, requireAuth(streamableHandler))
+		mux.Handle(SsePath, requireAuth(sseHandler))
+	default:
+		requireAuth = func(handler http.Handler) http.Handler {
+			return handler
+		}
+		mux.Handle(StreamableHttpPath, streamableHandler)
+		mux.Handle(SsePath, sseHandler)
+	}
+	if opts.Status != nil {
+		mux.Handle(StatusPath, requireAuth(opts.Status.httpHandler(ctx, server)))
+	}
+	return logRequests(ctx, mux)
+}
+
+// Serve listens on the configured address and serves the MCP server until the context is cancelled
+func Serve(ctx context.Context, server *mcp.Server, opts Options) error {
+	listener, err := net.Listen("tcp", opts.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.Address, err)
+	}
+	return ServeListener(ctx, listener, server, opts)
+}
+
+// ServeListener serves the MCP server on the listener until the context is cancelled, then shuts down
+// gracefully: new connections are refused, open streams are closed, and in-flight requests are given
+// up to the shutdown timeout to complete.
+func ServeListener(ctx context.Context, listener net.Listener, server *mcp.Server, opts Options) error {
+	// Streams are long-lived GET requests that would otherwise hold up shutdown until the timeout
+	streamsCtx, closeStreams := context.WithCancel(context.Background())
+	defer closeStreams()
+
+	httpServer := &http.Server{
+		Handler:           closeStreamsOnShutdown(streamsCtx, NewHandler(ctx, server, opts)),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Requests must not be cancelled as soon as the context is, so that they can complete during shutdown
+		BaseContext: func(net.Listener) context.Context {
+			return context.WithoutCancel(ctx)
+		},
+	}
+	httpServer.RegisterOnShutdown(closeStreams)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+	logger.FromContext(ctx).Info("Serving MCP over HTTP",
+		slog.String("address", listener.Addr().String()),
+		slog.String("streamableHttpPath", StreamableHttpPath),
+		slog.String("ssePath", SsePath),
+		slog.Bool("statusPage", opts.Status != nil),
+		slog.Bool("bearerTokenRequired", opts.BearerToken != "" || opts.BearerTokenKeyring != nil),
+		slog.Bool("bearerTokenRotation", opts.BearerTokenKeyring != nil),
+		slog.Bool("oauthRequired", opts.OAuth != nil))
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.FromContext(ctx).Info("Shutting down HTTP server", slog.Duration("shutdownTimeout", opts.ShutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), opts.ShutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		logger.FromContext(ctx).Warn("In-flight requests did not complete before the shutdown timeout, closing their connections", slog.String("error", err.Error()))
+		_ = httpServer.Close()
+	}
+	if err := <-serveErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return ctx.Err()
+}
+
+// bearerTokenVerifier accepts requests that send the configured token
+func bearerTokenVerifier(bearerToken string) auth.TokenVerifier {
+	return func(ctx context.Context, token string, req *http.Request) (*auth.TokenInfo, error) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(bearerToken)) != 1 {
+			return nil, auth.ErrInvalidToken
+		}
+		return &auth.TokenInfo{
+			// All clients share the token, so sessions are tied to the same user
+			UserID:     "bearer-token",
+			Expiration: time.Now().Add(bearerTokenLifetime),
+		}, nil
+	}
+}
+
+// closeStreamsOnShutdown cancels GET requests, which hold open server-to-client streams, when the streams context is cancelled
+func closeStreamsOnShutdown(streamsCtx context.Context, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		stop := context.AfterFunc(streamsCtx, cancel)
+		defer stop()
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func logRequests(ctx context.Context, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(ctx).Debug("HTTP request received",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.String("remoteAddr", r.RemoteAddr),
+			slog.String("mcpSessionId", r.Header.Get("Mcp-Session-Id")))
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,232 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/server/httptransport"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBearerToken = "test-bearer-token"
+
+type EchoInput struct {
+	Message string `json:"message"`
+}
+
+type EchoOutput struct {
+	Message string `json:"message"`
+}
+
+// testServer returns an MCP server with a single echo tool
+func testServer(t *testing.T) *mcp.Server {
+	t.Helper()
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, &mcp.Tool{Name: "echo"}, func(ctx context.Context, req *mcp.CallToolRequest, input EchoInput) (*mcp.CallToolResult, *EchoOutput, error) {
+		return nil, &EchoOutput{Message: input.Message}, nil
+	})
+	return server
+}
+
+// bearerTransport adds a bearer token to every request
+type bearerTransport struct {
+	token string
+}
+
+func (b *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func bearerClient(token string) *http.Client {
+	return &http.Client{Transport: &bearerTransport{token: token}}
+}
+
+func callEcho(t *testing.T, session *mcp.ClientSession) {
+	t.Helper()
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "echo",
+		Arguments: map[string]any{"message": "hello"},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, map[string]any{"message": "hello"}, result.StructuredContent)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		options       httptransport.Options
+		expectedError string
+	}{
+		{
+			name:    "loopback without bearer token",
+			options: httptransport.Options{Address: "127.0.0.1:8080"},
+		},
+		{
+			name:    "localhost without bearer token",
+			options: httptransport.Options{Address: "localhost:8080"},
+		},
+		{
+			name:    "IPv6 loopback without bearer token",
+			options: httptransport.Options{Address: "[::1]:8080"},
+		},
+		{
+			name:    "all interfaces with bearer token",
+			options: httptransport.Options{Address: "0.0.0.0:8080", BearerToken: testBearerToken},
+		},
+		{
+			name:          "all interfaces without bearer token",
+			options:       httptransport.Options{Address: "0.0.0.0:8080"},
+			expectedError: "requires a bearer token",
+		},
+		{
+			name:          "empty host without bearer token",
+			options:       httptransport.Options{Address: ":8080"},
+			expectedError: "requires a bearer token",
+		},
+		{
+			name:          "missing port",
+			options:       httptransport.Options{Address: "127.0.0.1"},
+			expectedError: "invalid HTTP address",
+		},
+		{
+			name:          "negative session timeout",
+			options:       httptransport.Options{Address: "127.0.0.1:8080", SessionTimeout: -time.Second},
+			expectedError: "session timeout must not be negative",
+		},
+		{
+			name:          "negative shutdown timeout",
+			options:       httptransport.Options{Address: "127.0.0.1:8080", ShutdownTimeout: -time.Second},
+			expectedError: "shutdown timeout must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestNewHandler_StreamableHttp(t *testing.T) {
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), testServer(t), httptransport.Options{BearerToken: testBearerToken}))
+	defer httpServer.Close()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:   httpServer.URL + httptransport.StreamableHttpPath,
+		HTTPClient: bearerClient(testBearerToken),
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	assert.NotEmpty(t, session.ID(), "streamable HTTP sessions should have a session ID")
+	callEcho(t, session)
+}
+
+func TestNewHandler_SseFallback(t *testing.T) {
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), testServer(t), httptransport.Options{BearerToken: testBearerToken}))
+	defer httpServer.Close()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), &mcp.SSEClientTransport{
+		Endpoint:   httpServer.URL + httptransport.SsePath,
+		HTTPClient: bearerClient(testBearerToken),
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	callEcho(t, session)
+}
+
+func TestNewHandler_RequiresBearerToken(t *testing.T) {
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), testServer(t), httptransport.Options{BearerToken: testBearerToken}))
+	defer httpServer.Close()
+
+	tests := []struct {
+		name   string
+		client *http.Client
+	}{
+		{name: "missing token", client: http.DefaultClient},
+		{name: "wrong token", client: bearerClient("wrong-token")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, path := range []string{httptransport.StreamableHttpPath, httptransport.SsePath} {
+				resp, err := tt.client.Get(httpServer.URL + path)
+				require.NoError(t, err)
+				resp.Body.Close()
+
+				assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, path)
+			}
+		})
+	}
+}
+
+func TestNewHandler_NoBearerToken(t *testing.T) {
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), testServer(t), httptransport.Options{}))
+	defer httpServer.Close()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint: httpServer.URL + httptransport.StreamableHttpPath,
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	callEcho(t, session)
+}
+
+func TestServeListener_GracefulShutdown(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	serveDone := make(chan error, 1)
+	go func() {
+		serveDone <- httptransport.ServeListener(ctx, listener, testServer(t), httptransport.Options{
+			Address:         listener.Addr().String(),
+			ShutdownTimeout: 5 * time.Second,
+		})
+	}()
+
+	// A connected client holds open a stream, which must not hold up shutdown
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:   "http://" + listener.Addr().String() + httptransport.StreamableHttpPath,
+		MaxRetries: -1,
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+	callEcho(t, session)
+
+	cancel()
+
+	select {
+	case err := <-serveDone:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(3 * time.Second):
+		t.Fatal("server did not shut down before the shutdown timeout")
+	}
+
+	_, err = http.Get("http://" + listener.Addr().String() + httptransport.StreamableHttpPath)
+	assert.Error(t, err, "server should no longer accept connections")
+}
+
+func TestServe_InvalidAddress(t *testing.T) {
+	err := httptransport.Serve(t.Context(), testServer(t), httptransport.Options{Address: "256.0.0.1:99999"})
+
+	assert.ErrorContains(t, err, "failed to listen")
+}
@@ -0,0 +1,373 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+const (
+	// clockSkew is the leeway allowed when checking token expiry and not-before times
+	clockSkew = time.Minute
+	// keysTTL is how long signing keys are cached before they are fetched again
+	keysTTL = time.Hour
+	// keysRefreshInterval is the minimum time between fetches triggered by tokens signed with an unknown key,
+	// so that tokens with made up key IDs cannot be used to flood the authorization server
+	keysRefreshInterval = time.Minute
+	// maxMetadataSize limits the size of the authorization server metadata and key set responses
+	maxMetadataSize = 1 << 20
+)
+
+// jwtVerifier verifies JWT access tokens issued by an OAuth authorization server, using the signing keys
+// published in the key set advertised by the server's OpenID Connect discovery document
+type jwtVerifier struct {
+	issuer     string
+	audience   string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	keysFetched time.Time
+	now         func() time.Time
+}
+
+func newJwtVerifier(issuer, audience string) *jwtVerifier {
+	return &jwtVerifier{
+		issuer:     issuer,
+		audience:   audience,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		now:        time.Now,
+	}
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type jwtClaims struct {
+	Issuer    string   `json:"iss"`
+	Subject   string   `json:"sub"`
+	ClientId  string   `json:"client_id"`
+	Audience  audience `json:"aud"`
+	Scope     string   `json:"scope"`
+	ExpiresAt *int64   `json:"exp"`
+	NotBefore *int64   `json:"nbf"`
+}
+
+// audience is the aud claim, which is either a single string or an array of strings
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return errors.New("aud claim must be a string or an array of strings")
+	}
+	*a = multiple
+	return nil
+}
+
+// Verify checks the token's signature, issuer, audience and validity period, and returns the
+// token's subject, scopes and expiry. Errors that mean the token is not acceptable wrap auth.ErrInvalidToken.
+func (v *jwtVerifier) Verify(ctx context.Context, token string) (*auth.TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalidTokenError("token is not a JWT")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, invalidTokenError("token header is malformed")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidTokenError("token signature is malformed")
+	}
+
+	key, err := v.signingKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, invalidTokenError(err.Error())
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, invalidTokenError("token claims are malformed")
+	}
+	if claims.Issuer != v.issuer {
+		return nil, invalidTokenError("token was not issued by the expected issuer")
+	}
+	if !slices.Contains(claims.Audience, v.audience) {
+		return nil, invalidTokenError("token is not intended for this server")
+	}
+	now := v.now()
+	if claims.ExpiresAt == nil {
+		return nil, invalidTokenError("token has no expiry")
+	}
+	expiresAt := time.Unix(*claims.ExpiresAt, 0)
+	if now.After(expiresAt.Add(clockSkew)) {
+		return nil, invalidTokenError("token has expired")
+	}
+	if claims.NotBefore != nil && now.Add(clockSkew).Before(time.Unix(*claims.NotBefore, 0)) {
+		return nil, invalidTokenError("token is not valid yet")
+	}
+
+	userId := claims.Subject
+	if userId == "" {
+		// Tokens issued with the client credentials grant have no subject
+		userId = claims.ClientId
+	}
+	return &auth.TokenInfo{
+		Scopes:     strings.Fields(claims.Scope),
+		Expiration: expiresAt,
+		UserID:     userId,
+	}, nil
+}
+
+// signingKey returns the key with the ID, fetching the key set again if the key is not known,
+// as the authorization server may have rotated its keys
+func (v *jwtVerifier) signingKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	key, ok := v.keys[kid]
+	stale := now.Sub(v.keysFetched) > keysTTL
+	if ok && !stale {
+		return key, nil
+	}
+	if !stale && now.Sub(v.keysFetched) < keysRefreshInterval {
+		return nil, invalidTokenError("token is signed with an unknown key")
+	}
+
+	keys, err := v.fetchKeys(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to fetch the authorization server signing keys",
+			slog.String("issuer", v.issuer),
+			slog.String("error", err.Error()))
+		if ok {
+			// Keep using the cached key until the key set can be fetched again
+			return key, nil
+		}
+		return nil, fmt.Errorf("failed to fetch the authorization server signing keys: %w", err)
+	}
+	v.keys = keys
+	v.keysFetched = now
+
+	key, ok = v.keys[kid]
+	if !ok {
+		return nil, invalidTokenError("token is signed with an unknown key")
+	}
+	return key, nil
+}
+
+// fetchKeys fetches the signing keys from the key set advertised in the issuer's OpenID Connect discovery document
+func (v *jwtVerifier) fetchKeys(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JwksUri string `json:"jwks_uri"`
+	}
+	if err := v.getJson(ctx, strings.TrimSuffix(v.issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, err
+	}
+	if discovery.JwksUri == "" {
+		return nil, errors.New("the discovery document has no jwks_uri")
+	}
+
+	var keySet struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := v.getJson(ctx, discovery.JwksUri, &keySet); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]crypto.PublicKey{}
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Keys of unsupported types are skipped, tokens signed with them are rejected
+			logger.FromContext(ctx).Debug("Skipping signing key", slog.String("kid", jwk.Kid), slog.String("error", err.Error()))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+func (v *jwtVerifier) getJson(ctx context.Context, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return fmt.Errorf("failed to parse the response from %s: %w", url, err)
+	}
+	return nil
+}
+
+// jsonWebKey is an RSA or EC public key in a JSON Web Key Set
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, errors.New("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// verifySignature verifies a JWS signature made with one of the RS, PS or ES algorithms
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	var hash crypto.Hash
+	switch alg[min(len(alg), 2):] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("token is signed with unsupported algorithm %q", alg)
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(signingInput))
+	digest := hasher.Sum(nil)
+
+	switch {
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return errors.New("token signing algorithm does not match the signing key")
+		}
+		var err error
+		if strings.HasPrefix(alg, "RS") {
+			err = rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature)
+		} else {
+			err = rsa.VerifyPSS(rsaKey, hash, digest, signature, nil)
+		}
+		if err != nil {
+			return errors.New("token signature is invalid")
+		}
+		return nil
+	case strings.HasPrefix(alg, "ES"):
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return errors.New("token signing algorithm does not match the signing key")
+		}
+		size := (ecKey.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("token signature is invalid")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			return errors.New("token signature is invalid")
+		}
+		return nil
+	default:
+		return fmt.Errorf("token is signed with unsupported algorithm %q", alg)
+	}
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, errors.New("key parameter is malformed")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+func invalidTokenError(reason string) error {
+	return fmt.Errorf("%w: %s", auth.ErrInvalidToken, reason)
+}
@@ -0,0 +1,241 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/oauthex"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+)
+
+const (
+	// ProtectedResourceMetadataPath is the well-known path of the OAuth protected resource metadata (RFC 9728)
+	ProtectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+	DefaultReadScope  = "mcp:read"
+	DefaultWriteScope = "mcp:write"
+
+	// maxRequestSize limits the size of requests read to check the scopes needed for their tool calls
+	maxRequestSize = 32 << 20
+)
+
+// OAuthOptions configures the HTTP transport to accept access tokens issued by an OAuth authorization server,
+// such as a PingOne environment, following the MCP authorization specification
+type OAuthOptions struct {
+	// Issuer is the issuer of accepted access tokens, such as https://auth.pingone.com/{environmentId}/as.
+	// Its signing keys are discovered from its OpenID Connect discovery document.
+	Issuer string
+	// Resource is the canonical URL that clients use to reach the MCP server, such as https://mcp.example.com/mcp
+	Resource string
+	// Audience is the audience that accepted access tokens must be issued for. Defaults to the resource.
+	Audience string
+	// ReadScope is the scope that allows calls to read-only tools
+	ReadScope string
+	// WriteScope is the scope that allows calls to all tools, including tools that make changes
+	WriteScope string
+	// Tools identifies which tools are read-only. Tools it does not know are treated as making changes.
+	Tools validation.ToolRegistry
+}
+
+// Validate checks the OAuth options
+func (o *OAuthOptions) Validate() error {
+	issuer, err := url.Parse(o.Issuer)
+	if err != nil || issuer.Host == "" {
+		return fmt.Errorf("invalid OAuth issuer %q, it must be an absolute URL", o.Issuer)
+	}
+	if issuer.Scheme != "https" && !(issuer.Scheme == "http" && isLoopback(issuer.Hostname())) {
+		return fmt.Errorf("invalid OAuth issuer %q, it must use https", o.Issuer)
+	}
+	resource, err := url.Parse(o.Resource)
+	if err != nil || resource.Host == "" || (resource.Scheme != "https" && resource.Scheme != "http") {
+		return fmt.Errorf("invalid OAuth resource %q, it must be the absolute URL of the MCP server", o.Resource)
+	}
+	if resource.Fragment != "" {
+		return fmt.Errorf("invalid OAuth resource %q, it must not have a fragment", o.Resource)
+	}
+	if strings.TrimSpace(o.ReadScope) == "" || strings.TrimSpace(o.WriteScope) == "" {
+		return errors.New("OAuth read and write scopes must not be empty")
+	}
+	if o.ReadScope == o.WriteScope {
+		return errors.New("OAuth read and write scopes must be different")
+	}
+	return nil
+}
+
+func (o *OAuthOptions) audience() string {
+	if o.Audience != "" {
+		return o.Audience
+	}
+	return o.Resource
+}
+
+// resourceMetadataPath is the path of the protected resource metadata of the resource, which inserts
+// the well-known path before the resource path as described in RFC 9728
+func (o *OAuthOptions) resourceMetadataPath() string {
+	resource, _ := url.Parse(o.Resource)
+	return ProtectedResourceMetadataPath + strings.TrimSuffix(resource.EscapedPath(), "/")
+}
+
+// resourceMetadataUrl is the URL of the protected resource metadata, returned to clients in authentication challenges
+func (o *OAuthOptions) resourceMetadataUrl() string {
+	resource, _ := url.Parse(o.Resource)
+	return (&url.URL{Scheme: resource.Scheme, Host: resource.Host}).String() + o.resourceMetadataPath()
+}
+
+func (o *OAuthOptions) protectedResourceMetadata() *oauthex.ProtectedResourceMetadata {
+	return &oauthex.ProtectedResourceMetadata{
+		Resource:               o.Resource,
+		AuthorizationServers:   []string{o.Issuer},
+		ScopesSupported:        []string{o.ReadScope, o.WriteScope},
+		BearerMethodsSupported: []string{"header"},
+		ResourceName:           "PingOne MCP Server",
+	}
+}
+
+type oauthTokenInfoKey struct{}
+
+// requireOAuth authenticates requests with access tokens verified by the verifier, and checks that the
+// token has the scopes for the tools called by the request. Rejected requests receive a challenge
+// pointing to the protected resource metadata, so that clients can discover how to obtain a token.
+func requireOAuth(ctx context.Context, opts *OAuthOptions, verifier *jwtVerifier, handler http.Handler) http.Handler {
+	resourceMetadataUrl := opts.resourceMetadataUrl()
+
+	// The SDK handler reads the verified token from the request, to tie sessions to the user that created them
+	handler = auth.RequireBearerToken(func(ctx context.Context, token string, req *http.Request) (*auth.TokenInfo, error) {
+		tokenInfo, ok := req.Context().Value(oauthTokenInfoKey{}).(*auth.TokenInfo)
+		if !ok {
+			return nil, auth.ErrInvalidToken
+		}
+		return tokenInfo, nil
+	}, nil)(handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields := strings.Fields(r.Header.Get("Authorization"))
+		if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+			writeChallenge(w, http.StatusUnauthorized, resourceMetadataUrl, "", "", "")
+			return
+		}
+
+		tokenInfo, err := verifier.Verify(r.Context(), fields[1])
+		if err != nil {
+			if !errors.Is(err, auth.ErrInvalidToken) {
+				logger.FromContext(ctx).Error("Failed to verify access token", slog.String("error", err.Error()))
+				http.Error(w, "unable to verify the access token", http.StatusServiceUnavailable)
+				return
+			}
+			logger.FromContext(ctx).Debug("Rejected access token", slog.String("error", err.Error()))
+			writeChallenge(w, http.StatusUnauthorized, resourceMetadataUrl, "invalid_token", err.Error(), "")
+			return
+		}
+
+		requiredScope, err := requiredScope(r, opts)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if !hasScope(tokenInfo, requiredScope, opts) {
+			logger.FromContext(ctx).Debug("Rejected request with insufficient scope",
+				slog.String("userId", tokenInfo.UserID),
+				slog.String("requiredScope", requiredScope))
+			writeChallenge(w, http.StatusForbidden, resourceMetadataUrl, "insufficient_scope", "the access token does not have the scope needed for this request", requiredScope)
+			return
+		}
+
+		handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), oauthTokenInfoKey{}, tokenInfo)))
+	})
+}
+
+// requiredScope returns the scope needed for the request: the write scope if it calls a tool that is not read-only,
+// and the read scope otherwise. The request body is restored for the next handler.
+func requiredScope(r *http.Request, opts *OAuthOptions) (string, error) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		return opts.ReadScope, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+	r.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to read request: %w", err)
+	}
+	if len(body) > maxRequestSize {
+		return "", errors.New("request is too large")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	for _, toolName := range calledTools(body) {
+		toolDef := opts.Tools.GetTool(toolName)
+		if toolDef == nil || !toolDef.IsReadOnly() {
+			return opts.WriteScope, nil
+		}
+	}
+	return opts.ReadScope, nil
+}
+
+// calledTools returns the names of the tools called by the JSON-RPC message or batch of messages.
+// Malformed messages are left for the MCP handler to reject.
+func calledTools(body []byte) []string {
+	type message struct {
+		Method string `json:"method"`
+		Params struct {
+			Name string `json:"name"`
+		} `json:"params"`
+	}
+
+	var messages []message
+	if err := json.Unmarshal(body, &messages); err != nil {
+		var single message
+		if err := json.Unmarshal(body, &single); err != nil {
+			return nil
+		}
+		messages = []message{single}
+	}
+
+	var tools []string
+	for _, m := range messages {
+		if m.Method == "tools/call" {
+			tools = append(tools, m.Params.Name)
+		}
+	}
+	return tools
+}
+
+// hasScope reports whether the token has the scope, where the write scope also allows reads
+func hasScope(tokenInfo *auth.TokenInfo, scope string, opts *OAuthOptions) bool {
+	for _, s := range tokenInfo.Scopes {
+		if s == scope || s == opts.WriteScope {
+			return true
+		}
+	}
+	return false
+}
+
+// writeChallenge writes a Bearer authentication challenge as described in RFC 6750 and RFC 9728
+func writeChallenge(w http.ResponseWriter, status int, resourceMetadataUrl, errorCode, errorDescription, scope string) {
+	params := []string{fmt.Sprintf("resource_metadata=%q", resourceMetadataUrl)}
+	if errorCode != "" {
+		params = append(params, fmt.Sprintf("error=%q", errorCode))
+	}
+	if errorDescription != "" {
+		params = append(params, fmt.Sprintf("error_description=%q", errorDescription))
+	}
+	if scope != "" {
+		params = append(params, fmt.Sprintf("scope=%q", scope))
+	}
+	w.Header().Set("WWW-Authenticate", "Bearer "+strings.Join(params, ", "))
+	message := http.StatusText(status)
+	if errorDescription != "" {
+		message = errorDescription
+	}
+	http.Error(w, message, status)
+}
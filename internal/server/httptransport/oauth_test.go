@@ -0,0 +1,428 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/server/httptransport"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResourcePath = "/mcp"
+
+var (
+	readTool  = types.ToolDefinition{McpTool: &mcp.Tool{Name: "echo", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}}
+	writeTool = types.ToolDefinition{McpTool: &mcp.Tool{Name: "write"}}
+)
+
+// testAuthorizationServer serves an OpenID Connect discovery document and key set, and issues tokens signed with its keys
+type testAuthorizationServer struct {
+	issuer   string
+	rsaKey   *rsa.PrivateKey
+	ecKey    *ecdsa.PrivateKey
+	requests int
+}
+
+func newTestAuthorizationServer(t *testing.T) *testAuthorizationServer {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	as := &testAuthorizationServer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/as/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		as.requests++
+		_ = json.NewEncoder(w).Encode(map[string]any{"issuer": as.issuer, "jwks_uri": as.issuer + "/jwks"})
+	})
+	mux.HandleFunc("/as/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]any{
+			{
+				"kty": "RSA",
+				"kid": "rsa-key",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec-key",
+				"crv": "P-256",
+				"x":   base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+			{"kty": "oct", "kid": "symmetric-key", "k": "c2VjcmV0"},
+		}})
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	as.issuer = server.URL + "/as"
+	return as
+}
+
+// claims returns valid claims for a token with the scopes
+func (as *testAuthorizationServer) claims(resource string, scope string) map[string]any {
+	return map[string]any{
+		"iss":   as.issuer,
+		"sub":   "a6f0e4c2-4b1e-4c5d-9a8b-7c6d5e4f3a2b",
+		"aud":   []string{resource},
+		"scope": scope,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+	}
+}
+
+func (as *testAuthorizationServer) signRS256(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+	signingInput := encodeJwtSegments(t, map[string]any{"alg": "RS256", "kid": kid}, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, as.rsaKey, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (as *testAuthorizationServer) signES256(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	signingInput := encodeJwtSegments(t, map[string]any{"alg": "ES256", "kid": "ec-key"}, claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, as.ecKey, digest[:])
+	require.NoError(t, err)
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func encodeJwtSegments(t *testing.T, header, claims map[string]any) string {
+	t.Helper()
+	headerJson, err := json.Marshal(header)
+	require.NoError(t, err)
+	claimsJson, err := json.Marshal(claims)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(headerJson) + "." + base64.RawURLEncoding.EncodeToString(claimsJson)
+}
+
+// oauthTestServer returns an MCP server with a read-only and a write tool
+func oauthTestServer(t *testing.T) *mcp.Server {
+	t.Helper()
+	server := testServer(t)
+	mcp.AddTool(server, writeTool.McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input EchoInput) (*mcp.CallToolResult, *EchoOutput, error) {
+		return nil, &EchoOutput{Message: "written"}, nil
+	})
+	return server
+}
+
+// newOAuthHttpServer serves the MCP server with OAuth, using the resource as the URL of the test server
+func newOAuthHttpServer(t *testing.T, as *testAuthorizationServer) (*httptest.Server, *httptransport.OAuthOptions) {
+	t.Helper()
+	oauth := &httptransport.OAuthOptions{
+		Issuer:     as.issuer,
+		ReadScope:  httptransport.DefaultReadScope,
+		WriteScope: httptransport.DefaultWriteScope,
+		Tools:      validation.NewToolRegistry([]types.ToolDefinition{readTool, writeTool}),
+	}
+	httpServer := httptest.NewUnstartedServer(nil)
+	oauth.Resource = "http://" + httpServer.Listener.Addr().String() + testResourcePath
+	httpServer.Config.Handler = httptransport.NewHandler(t.Context(), oauthTestServer(t), httptransport.Options{OAuth: oauth})
+	httpServer.Start()
+	t.Cleanup(httpServer.Close)
+	return httpServer, oauth
+}
+
+func connectWithToken(t *testing.T, httpServer *httptest.Server, token string) (*mcp.ClientSession, error) {
+	t.Helper()
+	return mcptestutils.TestMcpClient(t).Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:   httpServer.URL + httptransport.StreamableHttpPath,
+		HTTPClient: bearerClient(token),
+		MaxRetries: -1,
+	}, nil)
+}
+
+func TestOAuthOptions_Validate(t *testing.T) {
+	valid := func() httptransport.OAuthOptions {
+		return httptransport.OAuthOptions{
+			Issuer:     "https://auth.pingone.com/0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f/as",
+			Resource:   "https://mcp.example.com/mcp",
+			ReadScope:  httptransport.DefaultReadScope,
+			WriteScope: httptransport.DefaultWriteScope,
+		}
+	}
+	tests := []struct {
+		name          string
+		modify        func(*httptransport.OAuthOptions)
+		expectedError string
+	}{
+		{name: "valid", modify: func(o *httptransport.OAuthOptions) {}},
+		{name: "loopback http issuer", modify: func(o *httptransport.OAuthOptions) { o.Issuer = "http://127.0.0.1:9031/as" }},
+		{name: "relative issuer", modify: func(o *httptransport.OAuthOptions) { o.Issuer = "/as" }, expectedError: "must be an absolute URL"},
+		{name: "http issuer", modify: func(o *httptransport.OAuthOptions) { o.Issuer = "http://auth.example.com/as" }, expectedError: "must use https"},
+		{name: "missing resource", modify: func(o *httptransport.OAuthOptions) { o.Resource = "" }, expectedError: "invalid OAuth resource"},
+		{name: "resource with fragment", modify: func(o *httptransport.OAuthOptions) { o.Resource = "https://mcp.example.com/mcp#x" }, expectedError: "must not have a fragment"},
+		{name: "empty scope", modify: func(o *httptransport.OAuthOptions) { o.ReadScope = " " }, expectedError: "scopes must not be empty"},
+		{name: "same scopes", modify: func(o *httptransport.OAuthOptions) { o.WriteScope = o.ReadScope }, expectedError: "scopes must be different"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := valid()
+			tt.modify(&options)
+
+			err := options.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestOptions_Validate_OAuth(t *testing.T) {
+	oauth := &httptransport.OAuthOptions{
+		Issuer:     "https://auth.pingone.com/0b5c2d6e-3f1a-4b7c-8d9e-2a3b4c5d6e7f/as",
+		Resource:   "https://mcp.example.com/mcp",
+		ReadScope:  httptransport.DefaultReadScope,
+		WriteScope: httptransport.DefaultWriteScope,
+	}
+
+	assert.NoError(t, httptransport.Options{Address: "0.0.0.0:8080", OAuth: oauth}.Validate(), "OAuth should not need a bearer token on non-loopback addresses")
+	assert.ErrorContains(t, httptransport.Options{Address: "0.0.0.0:8080", OAuth: oauth, BearerToken: testBearerToken}.Validate(), "cannot be combined with OAuth")
+}
+
+func TestNewHandler_OAuth_ProtectedResourceMetadata(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	httpServer, oauth := newOAuthHttpServer(t, as)
+
+	for _, path := range []string{httptransport.ProtectedResourceMetadataPath + testResourcePath, httptransport.ProtectedResourceMetadataPath} {
+		t.Run(path, func(t *testing.T) {
+			resp, err := http.Get(httpServer.URL + path)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var metadata map[string]any
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&metadata))
+			assert.Equal(t, oauth.Resource, metadata["resource"])
+			assert.Equal(t, []any{as.issuer}, metadata["authorization_servers"])
+			assert.Equal(t, []any{httptransport.DefaultReadScope, httptransport.DefaultWriteScope}, metadata["scopes_supported"])
+		})
+	}
+}
+
+func TestNewHandler_OAuth_Challenges(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	httpServer, oauth := newOAuthHttpServer(t, as)
+	resourceMetadata := `resource_metadata="` + httpServer.URL + httptransport.ProtectedResourceMetadataPath + testResourcePath + `"`
+
+	expired := as.claims(oauth.Resource, httptransport.DefaultReadScope)
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	wrongIssuer := as.claims(oauth.Resource, httptransport.DefaultReadScope)
+	wrongIssuer["iss"] = "https://auth.example.com/as"
+	wrongAudience := as.claims("https://other.example.com/mcp", httptransport.DefaultReadScope)
+	notYetValid := as.claims(oauth.Resource, httptransport.DefaultReadScope)
+	notYetValid["nbf"] = time.Now().Add(time.Hour).Unix()
+	otherKey := &testAuthorizationServer{issuer: as.issuer}
+	otherKey.rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	valid := as.claims(oauth.Resource, httptransport.DefaultReadScope)
+
+	tests := []struct {
+		name                string
+		token               string
+		expectedStatus      int
+		expectedChallenge   []string
+		unexpectedChallenge string
+	}{
+		{
+			name:                "missing token",
+			expectedStatus:      http.StatusUnauthorized,
+			expectedChallenge:   []string{resourceMetadata},
+			unexpectedChallenge: "error=",
+		},
+		{
+			name:              "malformed token",
+			token:             "not-a-jwt",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{resourceMetadata, `error="invalid_token"`},
+		},
+		{
+			name:              "expired token",
+			token:             as.signRS256(t, "rsa-key", expired),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{`error="invalid_token"`, "token has expired"},
+		},
+		{
+			name:              "token not valid yet",
+			token:             as.signRS256(t, "rsa-key", notYetValid),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{"token is not valid yet"},
+		},
+		{
+			name:              "wrong issuer",
+			token:             as.signRS256(t, "rsa-key", wrongIssuer),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{"token was not issued by the expected issuer"},
+		},
+		{
+			name:              "wrong audience",
+			token:             as.signRS256(t, "rsa-key", wrongAudience),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{"token is not intended for this server"},
+		},
+		{
+			name:              "signed with another key",
+			token:             otherKey.signRS256(t, "rsa-key", valid),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{"token signature is invalid"},
+		},
+		{
+			name:              "unknown key",
+			token:             as.signRS256(t, "unknown-key", valid),
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{"token is signed with an unknown key"},
+		},
+		{
+			name:              "unsigned token",
+			token:             encodeJwtSegments(t, map[string]any{"alg": "none", "kid": "rsa-key"}, valid) + ".",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: []string{`error="invalid_token"`},
+		},
+		{
+			name:              "token without scopes",
+			token:             as.signRS256(t, "rsa-key", as.claims(oauth.Resource, "openid profile")),
+			expectedStatus:    http.StatusForbidden,
+			expectedChallenge: []string{resourceMetadata, `error="insufficient_scope"`, `scope="mcp:read"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, httpServer.URL+httptransport.StreamableHttpPath, nil)
+			require.NoError(t, err)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			challenge := resp.Header.Get("WWW-Authenticate")
+			assert.Regexp(t, "^Bearer ", challenge)
+			for _, expected := range tt.expectedChallenge {
+				assert.Contains(t, challenge, expected)
+			}
+			if tt.unexpectedChallenge != "" {
+				assert.NotContains(t, challenge, tt.unexpectedChallenge)
+			}
+		})
+	}
+}
+
+func TestNewHandler_OAuth_ReadScope(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	httpServer, oauth := newOAuthHttpServer(t, as)
+
+	session, err := connectWithToken(t, httpServer, as.signRS256(t, "rsa-key", as.claims(oauth.Resource, "openid "+httptransport.DefaultReadScope)))
+	require.NoError(t, err)
+	defer session.Close()
+
+	callEcho(t, session)
+
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: writeTool.McpTool.Name, Arguments: map[string]any{"message": "hello"}})
+	assert.ErrorContains(t, err, "Forbidden", "write tools should need the write scope")
+}
+
+func TestNewHandler_OAuth_WriteScope(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	httpServer, oauth := newOAuthHttpServer(t, as)
+
+	// The write scope also allows calls to read-only tools
+	session, err := connectWithToken(t, httpServer, as.signES256(t, as.claims(oauth.Resource, httptransport.DefaultWriteScope)))
+	require.NoError(t, err)
+	defer session.Close()
+
+	callEcho(t, session)
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: writeTool.McpTool.Name, Arguments: map[string]any{"message": "hello"}})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+}
+
+func TestNewHandler_OAuth_UnknownToolNeedsWriteScope(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	httpServer, oauth := newOAuthHttpServer(t, as)
+
+	session, err := connectWithToken(t, httpServer, as.signRS256(t, "rsa-key", as.claims(oauth.Resource, httptransport.DefaultReadScope)))
+	require.NoError(t, err)
+	defer session.Close()
+
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "login", Arguments: map[string]any{}})
+	assert.ErrorContains(t, err, "Forbidden")
+}
+
+func TestNewHandler_OAuth_KeysAreCached(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	httpServer, oauth := newOAuthHttpServer(t, as)
+
+	session, err := connectWithToken(t, httpServer, as.signRS256(t, "rsa-key", as.claims(oauth.Resource, httptransport.DefaultReadScope)))
+	require.NoError(t, err)
+	defer session.Close()
+	callEcho(t, session)
+	callEcho(t, session)
+
+	assert.Equal(t, 1, as.requests, "signing keys should be fetched once")
+}
+
+func TestNewHandler_OAuth_SseNotServed(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	httpServer, _ := newOAuthHttpServer(t, as)
+
+	resp, err := http.Get(httpServer.URL + httptransport.SsePath)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestNewHandler_OAuth_IssuerUnavailable(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	unavailable := httptest.NewServer(http.NotFoundHandler())
+	unavailable.Close()
+	oauth := &httptransport.OAuthOptions{
+		Issuer:     unavailable.URL + "/as",
+		Resource:   "http://127.0.0.1/mcp",
+		ReadScope:  httptransport.DefaultReadScope,
+		WriteScope: httptransport.DefaultWriteScope,
+		Tools:      validation.NewToolRegistry(nil),
+	}
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), oauthTestServer(t), httptransport.Options{OAuth: oauth}))
+	defer httpServer.Close()
+	claims := as.claims(oauth.Resource, httptransport.DefaultReadScope)
+	claims["iss"] = oauth.Issuer
+
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL+httptransport.StreamableHttpPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+as.signRS256(t, "rsa-key", claims))
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
@@ -0,0 +1,211 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport
+
+import (
+	"context"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+)
+
+const (
+	// StatusPath is the endpoint of the status page
+	StatusPath = "/status"
+
+	// DefaultMaxStatusToolCalls is the number of tool calls the status page shows before the oldest are forgotten
+	DefaultMaxStatusToolCalls = 50
+)
+
+// StatusSetting is a guardrail setting shown on the status page
+type StatusSetting struct {
+	Name  string
+	Value string
+}
+
+// StatusToolCall is a tool call shown on the status page. Its arguments, results and errors are not recorded, as
+// they can hold PingOne data such as personal information about users.
+type StatusToolCall struct {
+	Time      time.Time
+	SessionId string
+	Tool      string
+	Duration  time.Duration
+	Succeeded bool
+}
+
+// Status records the recent tool calls of the MCP server and serves the status page at StatusPath, which shows
+// operators the active sessions, the recent tool calls, the guardrail configuration and when the access token of
+// the server's PingOne session expires. It is safe for concurrent use.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware after all other middleware, so that
+// calls rejected by the guardrails are recorded too.
+type Status struct {
+	guardrails   []StatusSetting
+	tokenStore   tokenstore.TokenStore
+	maxToolCalls int
+
+	mutex sync.Mutex
+	// toolCalls are ordered oldest first
+	toolCalls []StatusToolCall
+}
+
+// NewStatus creates a status page that shows the guardrail settings, the expiry of the session in the token store,
+// and up to maxToolCalls recent tool calls.
+func NewStatus(guardrails []StatusSetting, tokenStore tokenstore.TokenStore, maxToolCalls int) *Status {
+	return &Status{
+		guardrails:   guardrails,
+		tokenStore:   tokenStore,
+		maxToolCalls: maxToolCalls,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (s *Status) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		callToolRequest, ok := req.(*mcp.CallToolRequest)
+		if method != "tools/call" || !ok || callToolRequest.Params == nil {
+			return next(ctx, method, req)
+		}
+
+		start := time.Now()
+		result, err := next(ctx, method, req)
+		toolCall := StatusToolCall{
+			Time:      start,
+			Tool:      callToolRequest.Params.Name,
+			Duration:  time.Since(start),
+			Succeeded: err == nil,
+		}
+		if session := req.GetSession(); session != nil {
+			toolCall.SessionId = session.ID()
+		}
+		if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult.IsError {
+			toolCall.Succeeded = false
+		}
+		s.add(toolCall)
+		return result, err
+	}
+}
+
+func (s *Status) add(toolCall StatusToolCall) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.toolCalls = append(s.toolCalls, toolCall)
+	if len(s.toolCalls) > s.maxToolCalls {
+		s.toolCalls = slices.Delete(s.toolCalls, 0, len(s.toolCalls)-s.maxToolCalls)
+	}
+}
+
+// recentToolCalls returns the recorded tool calls, most recent first
+func (s *Status) recentToolCalls() []StatusToolCall {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	toolCalls := slices.Clone(s.toolCalls)
+	slices.Reverse(toolCalls)
+	return toolCalls
+}
+
+type statusSession struct {
+	Id     string
+	Client string
+}
+
+type statusPage struct {
+	Time          time.Time
+	Sessions      []statusSession
+	ToolCalls     []StatusToolCall
+	Guardrails    []StatusSetting
+	SessionExpiry string
+}
+
+var statusTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>PingOne MCP Server Status</title>
+</head>
+<body>
+<h1>PingOne MCP Server Status</h1>
+<p>As of {{.Time.Format "2006-01-02T15:04:05Z07:00"}}</p>
+<h2>PingOne Session</h2>
+<p>{{.SessionExpiry}}</p>
+<h2>Guardrails</h2>
+<table>
+<tr><th>Setting</th><th>Value</th></tr>
+{{range .Guardrails}}<tr><td>{{.Name}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+<h2>Active Sessions</h2>
+{{if .Sessions}}<table>
+<tr><th>Session ID</th><th>Client</th></tr>
+{{range .Sessions}}<tr><td>{{.Id}}</td><td>{{.Client}}</td></tr>
+{{end}}</table>
+{{else}}<p>No active sessions</p>
+{{end}}<h2>Recent Tool Calls</h2>
+{{if .ToolCalls}}<table>
+<tr><th>Time</th><th>Session ID</th><th>Tool</th><th>Duration</th><th>Outcome</th></tr>
+{{range .ToolCalls}}<tr><td>{{.Time.Format "2006-01-02T15:04:05Z07:00"}}</td><td>{{.SessionId}}</td><td>{{.Tool}}</td><td>{{.Duration}}</td><td>{{if .Succeeded}}succeeded{{else}}failed{{end}}</td></tr>
+{{end}}</table>
+{{else}}<p>No tool calls</p>
+{{end}}</body>
+</html>
+`))
+
+// httpHandler returns the HTTP handler serving the status page of the MCP server
+func (s *Status) httpHandler(ctx context.Context, server *mcp.Server) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		page := statusPage{
+			Time:          time.Now().UTC(),
+			ToolCalls:     s.recentToolCalls(),
+			Guardrails:    s.guardrails,
+			SessionExpiry: s.sessionExpiry(),
+		}
+		for session := range server.Sessions() {
+			statusSession := statusSession{Id: session.ID()}
+			if params := session.InitializeParams(); params != nil && params.ClientInfo != nil {
+				statusSession.Client = params.ClientInfo.Name + " " + params.ClientInfo.Version
+			}
+			page.Sessions = append(page.Sessions, statusSession)
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := statusTemplate.Execute(w, page); err != nil {
+			logger.FromContext(ctx).Error("Failed to write the status page", slog.String("error", err.Error()))
+		}
+	})
+}
+
+// sessionExpiry describes when the access token of the PingOne session in the token store expires
+func (s *Status) sessionExpiry() string {
+	if s.tokenStore == nil {
+		return "No PingOne session"
+	}
+	hasSession, err := s.tokenStore.HasSession()
+	if err != nil {
+		return "Unable to read the PingOne session"
+	}
+	if !hasSession {
+		return "No PingOne session"
+	}
+	session, err := s.tokenStore.GetSession()
+	if err != nil || session == nil {
+		return "Unable to read the PingOne session"
+	}
+	expiry := session.Expiry.UTC().Format(time.RFC3339)
+	if session.Expiry.Before(time.Now()) {
+		return "The access token expired at " + expiry
+	}
+	return "The access token expires at " + expiry
+}
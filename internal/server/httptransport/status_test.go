@@ -0,0 +1,186 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package httptransport_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/server/httptransport"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGuardrails = []httptransport.StatusSetting{
+	{Name: "Read-only mode", Value: "true"},
+	{Name: "Production guardrail", Value: "strict"},
+}
+
+// statusTestServer returns an MCP server with an echo tool and a failing tool, recording tool calls to the status page
+func statusTestServer(t *testing.T, status *httptransport.Status) *mcp.Server {
+	t.Helper()
+	server := testServer(t)
+	mcp.AddTool(server, &mcp.Tool{Name: "fail"}, func(ctx context.Context, req *mcp.CallToolRequest, input EchoInput) (*mcp.CallToolResult, *EchoOutput, error) {
+		return nil, nil, errors.New("failed with " + input.Message)
+	})
+	server.AddReceivingMiddleware(status.Handler)
+	return server
+}
+
+func getStatus(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url + httptransport.StatusPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestStatus_Page(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	expiry := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{AccessToken: "secret-access-token", Expiry: expiry}))
+	status := httptransport.NewStatus(testGuardrails, tokenStore, httptransport.DefaultMaxStatusToolCalls)
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), statusTestServer(t, status), httptransport.Options{BearerToken: testBearerToken, Status: status}))
+	defer httpServer.Close()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint:   httpServer.URL + httptransport.StreamableHttpPath,
+		HTTPClient: bearerClient(testBearerToken),
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{"message": "secret-argument"}})
+	require.NoError(t, err)
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "fail", Arguments: map[string]any{"message": "secret-error"}})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+
+	statusCode, body := getStatus(t, bearerClient(testBearerToken), httpServer.URL)
+	require.Equal(t, http.StatusOK, statusCode)
+
+	assert.Contains(t, body, session.ID(), "active sessions should be shown")
+	assert.Contains(t, body, "test-mcp-client v0.0.1-test", "the client of each session should be shown")
+	assert.Contains(t, body, "<td>Read-only mode</td><td>true</td>")
+	assert.Contains(t, body, "<td>Production guardrail</td><td>strict</td>")
+	assert.Contains(t, body, "The access token expires at 2030-01-02T03:04:05Z")
+
+	// The most recent call is shown first
+	failIndex := strings.Index(body, "<td>fail</td>")
+	echoIndex := strings.Index(body, "<td>echo</td>")
+	require.NotEqual(t, -1, failIndex)
+	require.NotEqual(t, -1, echoIndex)
+	assert.Less(t, failIndex, echoIndex)
+	assert.Contains(t, body, "failed")
+	assert.Contains(t, body, "succeeded")
+
+	assert.NotContains(t, body, "secret-argument", "tool call arguments should not be shown")
+	assert.NotContains(t, body, "secret-error", "tool call errors should not be shown")
+	assert.NotContains(t, body, "secret-access-token", "the access token should not be shown")
+}
+
+func TestStatus_MaxToolCalls(t *testing.T) {
+	status := httptransport.NewStatus(testGuardrails, testutils.NewInMemoryTokenStore(), 1)
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), statusTestServer(t, status), httptransport.Options{Status: status}))
+	defer httpServer.Close()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), &mcp.StreamableClientTransport{
+		Endpoint: httpServer.URL + httptransport.StreamableHttpPath,
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	callEcho(t, session)
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "fail", Arguments: map[string]any{"message": "hello"}})
+	require.NoError(t, err)
+
+	statusCode, body := getStatus(t, http.DefaultClient, httpServer.URL)
+	require.Equal(t, http.StatusOK, statusCode)
+	assert.Contains(t, body, "<td>fail</td>")
+	assert.NotContains(t, body, "<td>echo</td>", "the oldest tool calls should be forgotten")
+	assert.Contains(t, body, "No PingOne session")
+}
+
+func TestStatus_ExpiredSession(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{AccessToken: "token", Expiry: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)}))
+	status := httptransport.NewStatus(testGuardrails, tokenStore, httptransport.DefaultMaxStatusToolCalls)
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), statusTestServer(t, status), httptransport.Options{Status: status}))
+	defer httpServer.Close()
+
+	statusCode, body := getStatus(t, http.DefaultClient, httpServer.URL)
+	require.Equal(t, http.StatusOK, statusCode)
+	assert.Contains(t, body, "The access token expired at 2020-01-02T03:04:05Z")
+	assert.Contains(t, body, "No active sessions")
+	assert.Contains(t, body, "No tool calls")
+}
+
+func TestStatus_RequiresBearerToken(t *testing.T) {
+	status := httptransport.NewStatus(testGuardrails, testutils.NewInMemoryTokenStore(), httptransport.DefaultMaxStatusToolCalls)
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), statusTestServer(t, status), httptransport.Options{BearerToken: testBearerToken, Status: status}))
+	defer httpServer.Close()
+
+	statusCode, _ := getStatus(t, http.DefaultClient, httpServer.URL)
+	assert.Equal(t, http.StatusUnauthorized, statusCode, "missing token")
+	statusCode, _ = getStatus(t, bearerClient("wrong-token"), httpServer.URL)
+	assert.Equal(t, http.StatusUnauthorized, statusCode, "wrong token")
+}
+
+func TestStatus_NotServedWithoutStatus(t *testing.T) {
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), testServer(t), httptransport.Options{}))
+	defer httpServer.Close()
+
+	statusCode, _ := getStatus(t, http.DefaultClient, httpServer.URL)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+}
+
+func TestStatus_MethodNotAllowed(t *testing.T) {
+	status := httptransport.NewStatus(testGuardrails, testutils.NewInMemoryTokenStore(), httptransport.DefaultMaxStatusToolCalls)
+	httpServer := httptest.NewServer(httptransport.NewHandler(t.Context(), statusTestServer(t, status), httptransport.Options{Status: status}))
+	defer httpServer.Close()
+
+	resp, err := http.Post(httpServer.URL+httptransport.StatusPath, "text/plain", strings.NewReader(""))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestStatus_OAuth(t *testing.T) {
+	as := newTestAuthorizationServer(t)
+	status := httptransport.NewStatus(testGuardrails, testutils.NewInMemoryTokenStore(), httptransport.DefaultMaxStatusToolCalls)
+	oauth := &httptransport.OAuthOptions{
+		Issuer:     as.issuer,
+		ReadScope:  httptransport.DefaultReadScope,
+		WriteScope: httptransport.DefaultWriteScope,
+		Tools:      validation.NewToolRegistry([]types.ToolDefinition{readTool, writeTool}),
+	}
+	httpServer := httptest.NewUnstartedServer(nil)
+	oauth.Resource = "http://" + httpServer.Listener.Addr().String() + testResourcePath
+	httpServer.Config.Handler = httptransport.NewHandler(t.Context(), statusTestServer(t, status), httptransport.Options{OAuth: oauth, Status: status})
+	httpServer.Start()
+	defer httpServer.Close()
+
+	statusCode, _ := getStatus(t, http.DefaultClient, httpServer.URL)
+	assert.Equal(t, http.StatusUnauthorized, statusCode, "missing token")
+
+	statusCode, _ = getStatus(t, bearerClient(as.signRS256(t, "rsa-key", as.claims(oauth.Resource, "openid"))), httpServer.URL)
+	assert.Equal(t, http.StatusForbidden, statusCode, "the status page should need the read scope")
+
+	statusCode, body := getStatus(t, bearerClient(as.signRS256(t, "rsa-key", as.claims(oauth.Resource, "openid "+httptransport.DefaultReadScope))), httpServer.URL)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Contains(t, body, "PingOne MCP Server Status")
+}
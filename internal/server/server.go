@@ -4,72 +4,777 @@ package server
 
 import (
 	"context"
+	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
 	authmiddleware "github.com/pingidentity/pingone-mcp-server/internal/auth/middleware"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/sessiontools"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/prompts"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
+	"github.com/pingidentity/pingone-mcp-server/internal/persona"
+	"github.com/pingidentity/pingone-mcp-server/internal/profile"
+	"github.com/pingidentity/pingone-mcp-server/internal/safemode"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/apirequests"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/capabilities"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/concurrency"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/confirmation"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultbookmarks"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/descriptionpack"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/errorenvelope"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/fieldselection"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/initialize"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputlimit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputtransform"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/plan"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/readonly"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/redaction"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/region"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resourcegraph"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/responsecache"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/texttemplates"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolpolicy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolsets"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltelemetry"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltimeout"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltrace"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/usagereport"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
 )
 
-func Start(ctx context.Context, version string, transport mcp.Transport, clientFactory sdk.ClientFactory, legacySdkClientFactory legacy.ClientFactory, authClientFactory client.AuthClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter, grantType auth.GrantType) error {
+// Options configures the MCP server
+type Options struct {
+	// Version is the version of the server reported to clients
+	Version string
+	// ClientFactory creates the PingOne API clients of the tools
+	ClientFactory sdk.ClientFactory
+	// LegacySdkClientFactory creates the PingOne API clients of the tools that use the legacy SDK
+	LegacySdkClientFactory legacy.ClientFactory
+	// AuthClientFactory creates the clients that log in to PingOne
+	AuthClientFactory client.AuthClientFactory
+	// TokenStore holds the PingOne session of the server
+	TokenStore tokenstore.TokenStore
+	// ToolFilter determines which tools are registered
+	ToolFilter *filter.Filter
+	// GrantType is the OAuth grant type used to log in to PingOne
+	GrantType auth.GrantType
+	// ProductionGuardrail determines how tool calls for production environments are restricted
+	ProductionGuardrail validation.ProductionGuardrail
+	// ProductionAccessPolicy overrides the restrictions of the production guardrail. The zero value applies no overrides.
+	ProductionAccessPolicy validation.ProductionAccessPolicy
+	// EnvironmentScope limits tool calls to the environments it allows. The zero value allows all environments.
+	EnvironmentScope validation.EnvironmentScope
+	// EnvironmentCacheOptions configures the cache of the environments looked up to validate tool calls
+	EnvironmentCacheOptions validation.EnvironmentCacheOptions
+	// DefaultFilters are the SCIM filters merged into the filter argument of tool calls, by tool name
+	DefaultFilters map[string]string
+	// DefaultBookmarks are added to the Bill of Materials products of environment create and service update tool calls
+	DefaultBookmarks defaultbookmarks.DefaultBookmarks
+	// ListResultPageSize pages the results of list tools as resources. 0 returns all items inline.
+	ListResultPageSize int
+	// ResponseCacheTTL caches the results of read-only tools for this long. 0 disables the cache.
+	ResponseCacheTTL time.Duration
+	// OutputTransformers transform the output of tools, by tool name
+	OutputTransformers outputtransform.OutputTransformers
+	// TextTemplates render the text content of tool results, by tool name
+	TextTemplates texttemplates.TextTemplates
+	// ProfileSwitcher switches between the configured profiles. Nil when no profiles are configured.
+	ProfileSwitcher *profile.Switcher
+	// UsageRecorder records the tool calls for the usage report. Nil does not record them.
+	UsageRecorder *usagereport.Recorder
+	// TraceTools attaches the PingOne API requests of each call to its result
+	TraceTools bool
+	// SafeMode restricts the server after a problem at startup. Nil when the server starts normally.
+	SafeMode *safemode.SafeMode
+	// ConfirmDestructiveTools asks the user to confirm calls of destructive tools
+	ConfirmDestructiveTools bool
+	// AuditLog records write tool calls. Nil does not record them.
+	AuditLog *auditlog.FileLog
+	// Persona tunes the instructions and tool descriptions of the server. Nil uses the defaults.
+	Persona *persona.Persona
+	// DescriptionPack replaces the descriptions of the tools it lists
+	DescriptionPack descriptionpack.Pack
+	// ToolPolicy allows or denies tool calls. Nil allows all calls.
+	ToolPolicy *toolpolicy.Policy
+	// ToolCapabilities determines how the tools the authenticated principal cannot use are listed
+	ToolCapabilities capabilities.Mode
+	// DynamicToolsets registers only the tools of EnabledToolsets at startup, and lets clients enable and disable
+	// toolsets while the server runs
+	DynamicToolsets bool
+	// EnabledToolsets are the toolsets enabled at startup with DynamicToolsets
+	EnabledToolsets []string
+	// ToolTimeouts cancel tool calls that run longer than the timeout of their tool
+	ToolTimeouts tooltimeout.ToolTimeouts
+	// RedactToolResults masks sensitive fields in the tool results returned to clients
+	RedactToolResults bool
+	// MaxOutputBytes truncates tool results larger than this, keeping the rest as resources. 0 disables the limit.
+	MaxOutputBytes int
+	// MultiRegion sends tool calls to the region of their environment, and adds the select_region tool
+	MultiRegion bool
+}
+
+// Start creates the MCP server and runs it on the provided transport until the context is cancelled
+// or the client disconnects.
+func Start(ctx context.Context, transport mcp.Transport, opts Options) error {
+	server, err := NewServer(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info("Starting PingOne MCP server...")
+
+	if err := server.Run(ctx, transport); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NewServer creates the MCP server with its tools and middleware registered, ready to be run on a transport.
+// The context must stay alive for as long as the server runs, as it controls background work such as cache expiry.
+// When DynamicToolsets is set, only the tools of the EnabledToolsets are registered at startup, and clients can
+// enable and disable toolsets while the server runs.
+func NewServer(ctx context.Context, opts Options) (*mcp.Server, error) {
+	serverOptions := &mcp.ServerOptions{
+		Logger: logger.FromContext(ctx),
+	}
+	var instructions []string
+	if opts.SafeMode != nil {
+		// Tell clients about safe mode on initialization, as stdio clients often hide server logs
+		instructions = append(instructions, opts.SafeMode.Instructions())
+	}
+	if opts.Persona != nil {
+		instructions = append(instructions, opts.Persona.Instructions)
+	}
+	serverOptions.Instructions = strings.Join(instructions, "\n\n")
+	enabledTools := tools.ListEnabledTools(opts.ToolFilter)
+	if opts.DynamicToolsets {
+		// Prompts and resources are published for the toolsets enabled at startup
+		var err error
+		enabledTools, err = toolsets.ListEnabledTools(opts.ToolFilter, opts.EnabledToolsets)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Subscriptions are configured with the server options, so the browsable resources are created before the server
+	browsableResources := resources.NewBrowsableResources(enabledTools)
+	serverOptions.SubscribeHandler = browsableResources.Subscribe
+	serverOptions.UnsubscribeHandler = browsableResources.Unsubscribe
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "pingone-mcp-server",
 		Title:   "PingOne MCP Server",
-		Version: version,
-	}, &mcp.ServerOptions{
-		Logger: logger.FromContext(ctx),
-	})
+		Version: opts.Version,
+	}, serverOptions)
+
+	clientFactory, legacySdkClientFactory := setupClientPools(ctx, opts.ClientFactory, opts.LegacySdkClientFactory, opts.ProfileSwitcher)
 
 	logger.FromContext(ctx).Debug("Registering MCP tool collections")
-	err := tools.RegisterCollections(ctx, server, clientFactory, legacySdkClientFactory, tokenStore, toolFilter)
+	err := registerCollections(ctx, server, clientFactory, legacySdkClientFactory, opts.TokenStore, opts.ToolFilter, opts.DynamicToolsets, opts.EnabledToolsets)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	sessiontools.RegisterTools(ctx, server, opts.AuthClientFactory, opts.TokenStore, opts.GrantType, opts.ToolFilter)
+	registerSwitchProfileTool(ctx, server, opts.ProfileSwitcher, opts.ToolFilter)
+	registerDiagnoseSafeModeTool(ctx, server, opts.SafeMode)
+	registerQueryMutationAuditLogTool(ctx, server, opts.AuditLog, opts.ToolFilter)
+	journal := setupRollbackJournal(ctx, server, opts.ProfileSwitcher, opts.ToolFilter)
+	resourceGraph := setupResourceGraph(ctx, server, opts.ProfileSwitcher, opts.ToolFilter)
+	jobManager := setupJobManager(ctx, server, opts.ProfileSwitcher, opts.ToolFilter)
+	regionSelector := setupRegionSelector(ctx, server, clientFactory, opts.TokenStore, opts.MultiRegion, opts.ProfileSwitcher, opts.ToolFilter)
+	apiRequestLog := setupApiRequestLog(ctx, server, opts.ProfileSwitcher, opts.ToolFilter)
+	registerPrompts(ctx, server, enabledTools)
 
 	// Setup middleware
+	browsableResourcesMiddleware := setupBrowsableResourcesMiddleware(ctx, server, browsableResources)
 	invocationMiddleware := setupInvocationMiddleware(ctx, server)
-	authMiddleware := setupAuthMiddleware(ctx, server, authClientFactory, tokenStore, grantType)
-	validationMiddleware := setupValidationMiddleware(ctx, server, clientFactory, tokenStore)
+	redactionMiddleware := setupRedactionMiddleware(ctx, server, opts.RedactToolResults)
+	toolTelemetryMiddleware, err := setupToolTelemetryMiddleware(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	toolTraceMiddleware := setupToolTraceMiddleware(ctx, server, opts.TraceTools)
+	apiRequestsMiddleware := setupApiRequestsMiddleware(ctx, server, apiRequestLog)
+	capabilityMiddleware := setupCapabilityMiddleware(ctx, server, legacySdkClientFactory, opts.TokenStore, opts.ToolCapabilities)
+	personaMiddleware := setupPersonaMiddleware(ctx, server, opts.Persona)
+	descriptionPackMiddleware := setupDescriptionPackMiddleware(ctx, server, opts.DescriptionPack)
+	readOnlyMiddleware := setupReadOnlyMiddleware(ctx, server, opts.ToolFilter)
+	authMiddleware := setupAuthMiddleware(ctx, server, opts.AuthClientFactory, opts.TokenStore, opts.GrantType, opts.ProfileSwitcher, opts.SafeMode, opts.AuditLog, resourceGraph, jobManager, opts.DynamicToolsets, opts.MultiRegion)
+	toolPolicyMiddleware := setupToolPolicyMiddleware(ctx, server, opts.ToolPolicy, opts.AuditLog)
+	auditLogMiddleware := setupAuditLogMiddleware(ctx, server, opts.AuditLog)
+	validationMiddleware := setupValidationMiddleware(ctx, server, clientFactory, opts.TokenStore, opts.ProductionGuardrail, opts.ProductionAccessPolicy, opts.EnvironmentScope, opts.EnvironmentCacheOptions, opts.ProfileSwitcher)
+	planMiddleware := setupPlanMiddleware(ctx, server, opts.ToolFilter, validationMiddleware, journal)
+	regionMiddleware := setupRegionMiddleware(ctx, server, regionSelector)
+	confirmationMiddleware := setupConfirmationMiddleware(ctx, server, clientFactory, opts.TokenStore, opts.ConfirmDestructiveTools, opts.ToolPolicy)
+	rollbackMiddleware := setupRollbackMiddleware(ctx, server, journal)
+	jobsMiddleware := setupJobsMiddleware(ctx, server, jobManager)
+	progressNotificationMiddleware := setupProgressNotificationMiddleware(ctx, server)
+	concurrencyLimitMiddleware := setupConcurrencyLimitMiddleware(ctx, server)
+	toolTimeoutMiddleware := setupToolTimeoutMiddleware(ctx, server, opts.ToolTimeouts)
+	defaultFilterMiddleware := setupDefaultFilterMiddleware(ctx, server, opts.DefaultFilters)
+	defaultBookmarksMiddleware := setupDefaultBookmarksMiddleware(ctx, server, opts.DefaultBookmarks)
+	resultStoreMiddleware := setupResultStoreMiddleware(ctx, server, opts.ListResultPageSize, opts.ProfileSwitcher)
+	textTemplateMiddleware := setupTextTemplateMiddleware(ctx, server, opts.TextTemplates)
+	outputLimitMiddleware := setupOutputLimitMiddleware(ctx, server, opts.MaxOutputBytes, opts.ProfileSwitcher)
+	fieldSelectionMiddleware := setupFieldSelectionMiddleware(ctx, server)
+	outputTransformMiddleware := setupOutputTransformMiddleware(ctx, server, opts.OutputTransformers)
+	resourceGraphMiddleware := setupResourceGraphMiddleware(ctx, server, resourceGraph)
+	usageReportMiddleware := setupUsageReportMiddleware(ctx, server, opts.UsageRecorder)
+	responseCacheMiddleware := setupResponseCacheMiddleware(ctx, server, opts.ResponseCacheTTL, opts.ProfileSwitcher, opts.UsageRecorder)
+	errorEnvelopeMiddleware := setupErrorEnvelopeMiddleware(ctx, server)
 
-	// Register middleware in order: invocation -> auth -> validation
-	// Order matters: invocation sets up logging/audit, auth establishes session, validation checks permissions using the auth context
-	server.AddReceivingMiddleware(invocationMiddleware, authMiddleware, validationMiddleware)
+	// Register middleware in order: plan -> browsable resources -> invocation -> redaction -> tool telemetry -> tool trace -> API requests -> tool capabilities -> persona -> description pack -> read-only -> auth -> tool policy -> audit log -> validation -> region -> confirmation -> rollback -> jobs -> progress notification -> concurrency limit -> tool timeout -> default filter -> default bookmarks -> result store -> text template -> output limit -> field selection -> output transform -> resource graph -> usage report -> response cache -> error envelope
+	// Order matters: invocation sets up logging/audit, auth establishes session, validation checks permissions using the auth context,
+	// and default filters and bookmarks are only applied to calls that are allowed to proceed.
+	// Plans run their steps through all other middleware, so that each step is authenticated, validated, confirmed and recorded like a tool call of the client
+	// Resources are read with tool calls that pass through all other middleware, so they are authenticated and validated like any tool call
+	// Sensitive fields are masked outside all middleware that shape results, in the results the client receives and in the tool logger set up by invocation
+	// Tool telemetry spans and tool traces cover every PingOne API request of a call, including those made to validate its environment
+	// API requests are recorded with the trace of the call, including those of calls that are then rejected, whose correlation IDs are needed most
+	// Tools the principal cannot use are marked after all other changes to their descriptions, and their roles are looked up once the call that logs in has run
+	// Tool descriptions are tuned to the persona for every client, whether or not it has logged in, with the guidance of the persona appended to the descriptions of the description pack
+	// Write tools registered by mistake in read-only mode are rejected before anything else is done for the call, including logging in
+	// Calls denied by the tool policy are rejected and audited once the principal of the session is known, and are not audited again as write tool calls
+	// Write tool calls are audited once the principal of the session is known, including calls that are then rejected
+	// Environments are validated in the server's region, and the requests of calls that passed validation, including those made to describe them for confirmation, are sent to the region of their environment
+	// Users are only asked to confirm calls that passed validation, and calls waiting for confirmation do not hold concurrency slots
+	// Only changes that passed validation and confirmation are recorded to be undone
+	// Jobs are only started by calls that passed validation and confirmation, and keep the undo journal of the call
+	// Progress is only sent for calls that passed validation and confirmation; jobs report their progress to get_job_status instead
+	// Concurrency slots are only taken by calls that passed validation, so rejected calls do not block others
+	// Tool timeouts start once the call may run, so the time taken to log in or to confirm the call is not counted
+	// Output transformers see the full tool output, so that fields they add can be selected and paged
+	// Text templates render the output the client would otherwise receive as JSON, after field selection
+	// Output is limited in the size the client receives it, after fields are selected and transformers add to it, and text templates render the truncated output
+	// The resource graph sees the tool output before it is transformed or reduced to the selected fields
+	// Usage is recorded close to the tool, so that only failures caused by the tool call itself are counted
+	// Responses are cached close to the tool, so that cached results are full tool outputs that are transformed, paged and selected like fresh ones
+	// Errors are described closest to the tool, so that all other middleware see failed calls with their error envelope
+	server.AddReceivingMiddleware(planMiddleware, browsableResourcesMiddleware, invocationMiddleware, redactionMiddleware, toolTelemetryMiddleware, toolTraceMiddleware, apiRequestsMiddleware, capabilityMiddleware, personaMiddleware, descriptionPackMiddleware, readOnlyMiddleware, authMiddleware, toolPolicyMiddleware, auditLogMiddleware, validationMiddleware.Handler, regionMiddleware, confirmationMiddleware, rollbackMiddleware, jobsMiddleware, progressNotificationMiddleware, concurrencyLimitMiddleware, toolTimeoutMiddleware, defaultFilterMiddleware, defaultBookmarksMiddleware, resultStoreMiddleware, textTemplateMiddleware, outputLimitMiddleware, fieldSelectionMiddleware, outputTransformMiddleware, resourceGraphMiddleware, usageReportMiddleware, responseCacheMiddleware, errorEnvelopeMiddleware)
 	logger.FromContext(ctx).Info("Middleware enabled - all tool calls will be authenticated and validated")
 
-	logger.FromContext(ctx).Info("Starting PingOne MCP server...")
+	return server, nil
+}
 
-	if err := server.Run(ctx, transport); err != nil {
+// setupBrowsableResourcesMiddleware registers the environments and the key objects in them as MCP resources that
+// are read with the enabled read-only tools.
+func setupBrowsableResourcesMiddleware(ctx context.Context, server *mcp.Server, browsableResources *resources.BrowsableResources) mcp.Middleware {
+	browsableResources.Register(server)
+	return browsableResources.Handler
+}
+
+func setupInvocationMiddleware(ctx context.Context, server *mcp.Server) mcp.Middleware {
+	invocationMiddleware := initialize.NewToolInvocationMiddleware()
+	return invocationMiddleware.Handler
+}
+
+// setupRedactionMiddleware logs tool calls with their sensitive fields masked, and masks them in the results
+// returned to clients when enabled.
+func setupRedactionMiddleware(ctx context.Context, server *mcp.Server, redactToolResults bool) mcp.Middleware {
+	if redactToolResults {
+		logger.FromContext(ctx).Info("Tool result redaction enabled - sensitive fields will be masked in tool results")
+	}
+	redactionMiddleware := redaction.NewRedactionMiddleware(redactToolResults)
+	return redactionMiddleware.Handler
+}
+
+// setupToolTelemetryMiddleware records tool calls as OpenTelemetry spans and metrics, which are exported once telemetry is set up.
+func setupToolTelemetryMiddleware(ctx context.Context, server *mcp.Server) (mcp.Middleware, error) {
+	toolTelemetryMiddleware, err := tooltelemetry.NewToolTelemetryMiddleware()
+	if err != nil {
+		return nil, err
+	}
+	return toolTelemetryMiddleware.Handler, nil
+}
+
+// setupToolTraceMiddleware attaches execution traces to tool results when tool tracing is enabled.
+func setupToolTraceMiddleware(ctx context.Context, server *mcp.Server, traceTools bool) mcp.Middleware {
+	if traceTools {
+		logger.FromContext(ctx).Info("Tool tracing enabled - tool results will include the PingOne API requests made by each call")
+	}
+	toolTraceMiddleware := tooltrace.NewToolTraceMiddleware(traceTools)
+	return toolTraceMiddleware.Handler
+}
+
+// setupApiRequestsMiddleware records the PingOne API requests of tool calls, and attaches their correlation IDs
+// to tool results.
+func setupApiRequestsMiddleware(ctx context.Context, server *mcp.Server, log *apirequests.Log) mcp.Middleware {
+	apiRequestsMiddleware := apirequests.NewApiRequestsMiddleware(log)
+	return apiRequestsMiddleware.Handler
+}
+
+// setupClientPools pools the PingOne API clients of each session, so that tool calls reuse authenticated clients
+// rather than creating new ones for every call.
+func setupClientPools(ctx context.Context, clientFactory sdk.ClientFactory, legacySdkClientFactory legacy.ClientFactory, profileSwitcher *profile.Switcher) (sdk.ClientFactory, legacy.ClientFactory) {
+	pooledClientFactory := sdk.NewPooledClientFactory(clientFactory, sdk.DefaultClientPoolSize)
+	pooledLegacyClientFactory := legacy.NewPooledClientFactory(legacySdkClientFactory, sdk.DefaultClientPoolSize)
+	if profileSwitcher != nil {
+		// Clients are configured with the root domain of the profile that was active when they were created
+		profileSwitcher.OnSwitch(pooledClientFactory.Clear)
+		profileSwitcher.OnSwitch(pooledLegacyClientFactory.Clear)
+	}
+	logger.FromContext(ctx).Debug("PingOne API client pools created", slog.Int("poolSize", sdk.DefaultClientPoolSize))
+	return pooledClientFactory, pooledLegacyClientFactory
+}
+
+// registerCollections registers the tools of the tool collections. With dynamic toolsets, only the collections of the
+// enabled toolsets are registered, and the enable_toolset and disable_toolset tools are added to change them.
+func registerCollections(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, legacySdkClientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter, dynamicToolsets bool, enabledToolsets []string) error {
+	collectionState := tools.NewCollectionState(ctx, clientFactory, tokenStore)
+	if !dynamicToolsets {
+		return tools.RegisterCollections(ctx, server, clientFactory, legacySdkClientFactory, tokenStore, toolFilter, collectionState)
+	}
+	serverToolsets := toolsets.NewDynamicToolsets(server, clientFactory, legacySdkClientFactory, tokenStore, toolFilter, collectionState)
+	if err := serverToolsets.RegisterCollections(ctx, enabledToolsets); err != nil {
 		return err
 	}
+	if toolFilter.ShouldIncludeTool(&toolsets.EnableToolsetDef) && toolFilter.ShouldIncludeTool(&toolsets.DisableToolsetDef) {
+		toolsets.RegisterToolsetTools(server, serverToolsets)
+	}
+	logger.FromContext(ctx).Info("Dynamic toolsets enabled - toolsets can be enabled and disabled while the server runs", slog.Any("enabledToolsets", enabledToolsets))
 	return nil
+}
 
+// registerSwitchProfileTool adds the switch_profile tool when profiles are configured.
+func registerSwitchProfileTool(ctx context.Context, server *mcp.Server, profileSwitcher *profile.Switcher, toolFilter *filter.Filter) {
+	if profileSwitcher == nil || !toolFilter.ShouldIncludeTool(&profile.SwitchProfileDef) {
+		return
+	}
+	profile.RegisterSwitchProfileTool(server, profileSwitcher)
+	logger.FromContext(ctx).Info("Profile switching enabled", slog.Any("profiles", profileSwitcher.Profiles().Names()))
 }
 
-func setupInvocationMiddleware(ctx context.Context, server *mcp.Server) mcp.Middleware {
-	invocationMiddleware := initialize.NewToolInvocationMiddleware()
-	return invocationMiddleware.Handler
+// registerDiagnoseSafeModeTool adds the diagnose_safe_mode tool when the server starts in safe mode.
+// The tool is not subject to the tool filter, as it is the only way for some clients to find out about safe mode.
+func registerDiagnoseSafeModeTool(ctx context.Context, server *mcp.Server, safeMode *safemode.SafeMode) {
+	if safeMode == nil {
+		return
+	}
+	safemode.RegisterDiagnoseSafeModeTool(server, safeMode)
+	logger.FromContext(ctx).Warn("Started in safe mode", slog.String("problem", safeMode.Problem()), slog.Any("restrictions", safemode.Restrictions))
+}
+
+// registerQueryMutationAuditLogTool adds the query_mutation_audit_log tool when the audit log is enabled.
+func registerQueryMutationAuditLogTool(ctx context.Context, server *mcp.Server, auditLog *auditlog.FileLog, toolFilter *filter.Filter) {
+	if auditLog == nil || !toolFilter.ShouldIncludeTool(&auditlog.QueryMutationAuditLogDef) {
+		return
+	}
+	auditlog.RegisterQueryMutationAuditLogTool(server, auditLog)
+}
+
+// registerPrompts adds the workflow prompts whose tools are all enabled, so that prompts are not published in
+// read-only mode or for personas that do not include the write tools they guide clients to call.
+func registerPrompts(ctx context.Context, server *mcp.Server, enabledTools []types.ToolDefinition) {
+	var enabledToolNames []string
+	for _, toolDef := range enabledTools {
+		enabledToolNames = append(enabledToolNames, toolDef.McpTool.Name)
+	}
+	prompts.RegisterPrompts(ctx, server, enabledToolNames)
+}
+
+// setupRollbackJournal adds the undo_last_change tool and returns the journal that changes are recorded to, or nil
+// when the tool is not enabled, such as in read-only mode.
+func setupRollbackJournal(ctx context.Context, server *mcp.Server, profileSwitcher *profile.Switcher, toolFilter *filter.Filter) *rollback.Journal {
+	if !toolFilter.ShouldIncludeTool(&rollback.UndoLastChangeDef) {
+		return nil
+	}
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	if profileSwitcher != nil {
+		// Changes of the previous profile cannot be undone with the session of another
+		profileSwitcher.OnSwitch(journal.Clear)
+	}
+	rollback.RegisterUndoLastChangeTool(server, journal)
+	logger.FromContext(ctx).Info("Undo enabled - recent updates can be undone with the undo_last_change tool")
+	return journal
+}
+
+// setupRollbackMiddleware lets tool handlers record undoable changes when the undo_last_change tool is enabled.
+func setupRollbackMiddleware(ctx context.Context, server *mcp.Server, journal *rollback.Journal) mcp.Middleware {
+	rollbackMiddleware := rollback.NewJournalMiddleware(journal)
+	return rollbackMiddleware.Handler
+}
+
+// setupResourceGraph adds the show_session_resource_graph tool and returns the graph that tool calls add their
+// resources to, or nil when the tool is not enabled.
+func setupResourceGraph(ctx context.Context, server *mcp.Server, profileSwitcher *profile.Switcher, toolFilter *filter.Filter) *resourcegraph.Graph {
+	if !toolFilter.ShouldIncludeTool(&resourcegraph.ShowSessionResourceGraphDef) {
+		return nil
+	}
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	if profileSwitcher != nil {
+		// Resources of the previous profile are not reachable with the session of another
+		profileSwitcher.OnSwitch(graph.Clear)
+	}
+	resourcegraph.RegisterShowSessionResourceGraphTool(server, graph)
+	return graph
+}
+
+// setupResourceGraphMiddleware adds the resources of tool calls to the graph when the show_session_resource_graph
+// tool is enabled.
+func setupResourceGraphMiddleware(ctx context.Context, server *mcp.Server, graph *resourcegraph.Graph) mcp.Middleware {
+	resourceGraphMiddleware := resourcegraph.NewGraphMiddleware(graph)
+	return resourceGraphMiddleware.Handler
+}
+
+// setupApiRequestLog adds the get_last_api_requests tool and returns the log of the PingOne API requests of tool
+// calls. Requests are logged even when the tool is not enabled, as their correlation IDs are attached to tool results.
+func setupApiRequestLog(ctx context.Context, server *mcp.Server, profileSwitcher *profile.Switcher, toolFilter *filter.Filter) *apirequests.Log {
+	log := apirequests.NewLog(apirequests.DefaultMaxRequests)
+	if profileSwitcher != nil {
+		// Requests of the previous profile were made in another organization
+		profileSwitcher.OnSwitch(log.Clear)
+	}
+	if toolFilter.ShouldIncludeTool(&apirequests.GetLastApiRequestsDef) {
+		apirequests.RegisterGetLastApiRequestsTool(server, log)
+	}
+	return log
 }
 
-func setupAuthMiddleware(ctx context.Context, server *mcp.Server, authClientFactory client.AuthClientFactory, tokenStore tokenstore.TokenStore, grantType auth.GrantType) mcp.Middleware {
+// setupJobManager adds the job tools and returns the manager that runs the jobs of tools called with
+// runInBackground, or nil when the job tools are not enabled.
+func setupJobManager(ctx context.Context, server *mcp.Server, profileSwitcher *profile.Switcher, toolFilter *filter.Filter) *jobs.Manager {
+	if !toolFilter.ShouldIncludeTool(&jobs.GetJobStatusDef) {
+		return nil
+	}
+	manager := jobs.NewManager(jobs.DefaultMaxJobs, jobs.DefaultMaxRunningJobs)
+	if profileSwitcher != nil {
+		// Jobs must not keep using the session of the previous profile
+		profileSwitcher.OnSwitch(manager.Clear)
+	}
+	jobs.RegisterTools(ctx, server, manager, toolFilter)
+	return manager
+}
+
+// setupJobsMiddleware lets tool handlers run in the background when the job tools are enabled.
+func setupJobsMiddleware(ctx context.Context, server *mcp.Server, manager *jobs.Manager) mcp.Middleware {
+	jobsMiddleware := jobs.NewJobsMiddleware(manager)
+	return jobsMiddleware.Handler
+}
+
+// setupProgressNotificationMiddleware sends the progress that tool handlers report to clients that asked for
+// progress notifications.
+func setupProgressNotificationMiddleware(ctx context.Context, server *mcp.Server) mcp.Middleware {
+	progressNotificationMiddleware := progress.NewProgressNotificationMiddleware()
+	return progressNotificationMiddleware.Handler
+}
+
+// setupCapabilityMiddleware marks or hides the tools the authenticated principal cannot use, unless the mode is off.
+func setupCapabilityMiddleware(ctx context.Context, server *mcp.Server, legacySdkClientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolCapabilities capabilities.Mode) mcp.Middleware {
+	if toolCapabilities != capabilities.ModeOff && toolCapabilities != "" {
+		logger.FromContext(ctx).Info("Tool capabilities enabled - tools the authenticated principal's roles do not allow will be adjusted in the tools list",
+			slog.String("mode", string(toolCapabilities)))
+	}
+	rolesFactory := roles.NewPingOneClientRolesWrapperFactory(legacySdkClientFactory, tokenStore)
+	lookup := func(ctx context.Context, subjectType string, environmentId uuid.UUID, subjectId string) ([]string, error) {
+		client, err := rolesFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return roles.AssignedRoleNames(ctx, client, subjectType, environmentId, subjectId)
+	}
+	capabilityMiddleware := capabilities.NewCapabilityMiddleware(toolCapabilities, lookup, tokenStore, tools.ListTools())
+	return capabilityMiddleware.Handler
+}
+
+// setupPersonaMiddleware tunes the descriptions of listed tools to the persona, if one is selected.
+func setupPersonaMiddleware(ctx context.Context, server *mcp.Server, serverPersona *persona.Persona) mcp.Middleware {
+	if serverPersona != nil {
+		logger.FromContext(ctx).Info("Persona enabled", slog.String("persona", serverPersona.Name))
+	}
+	personaMiddleware := persona.NewToolDescriptionMiddleware(serverPersona)
+	return personaMiddleware.Handler
+}
+
+// setupDescriptionPackMiddleware replaces the descriptions of listed tools with those of the description pack
+func setupDescriptionPackMiddleware(ctx context.Context, server *mcp.Server, descriptionPack descriptionpack.Pack) mcp.Middleware {
+	if len(descriptionPack) > 0 {
+		logger.FromContext(ctx).Info("Tool description pack enabled", slog.Int("toolCount", len(descriptionPack)))
+	}
+	descriptionPackMiddleware := descriptionpack.NewToolDescriptionMiddleware(descriptionPack)
+	return descriptionPackMiddleware.Handler
+}
+
+// setupReadOnlyMiddleware rejects calls of tools that are not read-only when the server is in read-only mode, as a
+// safeguard against write tools that are registered despite the tool filter.
+func setupReadOnlyMiddleware(ctx context.Context, server *mcp.Server, toolFilter *filter.Filter) mcp.Middleware {
+	readOnlyMiddleware := readonly.NewReadOnlyMiddleware(toolFilter.ReadOnly, append(tools.ListTools(), ListServerTools()...))
+	return readOnlyMiddleware.Handler
+}
+
+// ListServerTools returns the definitions of the tools the server adds besides those of the tool collections. Some
+// are only registered when their feature is enabled, such as switch_profile when profiles are configured.
+func ListServerTools() []types.ToolDefinition {
+	serverTools := append(sessiontools.ListTools(), toolsets.ListTools()...)
+	serverTools = append(serverTools, jobs.ListTools()...)
+	return append(serverTools,
+		profile.SwitchProfileDef,
+		safemode.DiagnoseSafeModeDef,
+		auditlog.QueryMutationAuditLogDef,
+		rollback.UndoLastChangeDef,
+		plan.ExecutePlanDef,
+		resourcegraph.ShowSessionResourceGraphDef,
+		region.SelectRegionDef,
+		apirequests.GetLastApiRequestsDef,
+	)
+}
+
+func setupAuthMiddleware(ctx context.Context, server *mcp.Server, authClientFactory client.AuthClientFactory, tokenStore tokenstore.TokenStore, grantType auth.GrantType, profileSwitcher *profile.Switcher, safeMode *safemode.SafeMode, auditLog *auditlog.FileLog, resourceGraph *resourcegraph.Graph, jobManager *jobs.Manager, dynamicToolsets bool, multiRegion bool) mcp.Middleware {
 	authMiddleware := authmiddleware.NewAuthMiddleware(authClientFactory, tokenStore, grantType)
+	// Session management tools log in and out themselves
+	for _, toolDef := range sessiontools.ListTools() {
+		authMiddleware.SkipTools(toolDef.McpTool.Name)
+	}
+	if profileSwitcher != nil {
+		// Switching profile logs out, so there is no need to log in to the current profile first
+		authMiddleware.SkipTools(profile.SwitchProfileDef.McpTool.Name)
+	}
+	if safeMode != nil {
+		// Safe mode must be diagnosable without a PingOne session
+		authMiddleware.SkipTools(safemode.DiagnoseSafeModeDef.McpTool.Name)
+	}
+	if auditLog != nil {
+		// The audit log is a local file
+		authMiddleware.SkipTools(auditlog.QueryMutationAuditLogDef.McpTool.Name)
+	}
+	if resourceGraph != nil {
+		// The resource graph is held in memory
+		authMiddleware.SkipTools(resourcegraph.ShowSessionResourceGraphDef.McpTool.Name)
+	}
+	if jobManager != nil {
+		// Jobs are held in memory, and run with the session of the call that started them
+		for _, toolDef := range jobs.ListTools() {
+			authMiddleware.SkipTools(toolDef.McpTool.Name)
+		}
+	}
+	if dynamicToolsets {
+		// Toolsets are enabled and disabled on the server
+		for _, toolDef := range toolsets.ListTools() {
+			authMiddleware.SkipTools(toolDef.McpTool.Name)
+		}
+	}
+	// The last API requests are held in memory
+	authMiddleware.SkipTools(apirequests.GetLastApiRequestsDef.McpTool.Name)
+	if multiRegion {
+		// The region of the session is selected on the server
+		authMiddleware.SkipTools(region.SelectRegionDef.McpTool.Name)
+	}
 	return authMiddleware.Handler
 }
 
-func setupValidationMiddleware(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, tokenStore tokenstore.TokenStore) mcp.Middleware {
-	allTools := tools.ListTools()
+// setupToolPolicyMiddleware enforces the tool policy when one is set, recording denied calls to the audit log
+// when it is enabled.
+func setupToolPolicyMiddleware(ctx context.Context, server *mcp.Server, toolPolicy *toolpolicy.Policy, auditLog *auditlog.FileLog) mcp.Middleware {
+	var sink auditlog.Sink
+	if auditLog != nil {
+		sink = auditLog
+	}
+	if toolPolicy != nil {
+		logger.FromContext(ctx).Info("Tool policy enabled",
+			slog.String("defaultAction", string(toolPolicy.Default)),
+			slog.Int("toolCount", len(toolPolicy.Tools)),
+			slog.Int("categoryCount", len(toolPolicy.Categories)))
+	}
+	toolPolicyMiddleware := toolpolicy.NewToolPolicyMiddleware(toolPolicy, sink)
+	return toolPolicyMiddleware.Handler
+}
+
+// setupAuditLogMiddleware records write tool calls to the audit log when it is enabled.
+func setupAuditLogMiddleware(ctx context.Context, server *mcp.Server, auditLog *auditlog.FileLog) mcp.Middleware {
+	var sink auditlog.Sink
+	if auditLog != nil {
+		sink = auditLog
+		logger.FromContext(ctx).Info("Audit log enabled - write tool calls will be recorded", slog.String("auditLogFile", auditLog.FilePath()))
+	}
+	auditLogMiddleware := auditlog.NewAuditLogMiddleware(sink, append(tools.ListTools(), rollback.UndoLastChangeDef))
+	return auditLogMiddleware.Handler
+}
+
+func setupValidationMiddleware(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, tokenStore tokenstore.TokenStore, productionGuardrail validation.ProductionGuardrail, productionAccessPolicy validation.ProductionAccessPolicy, environmentScope validation.EnvironmentScope, environmentCacheOptions validation.EnvironmentCacheOptions, profileSwitcher *profile.Switcher) *validation.EnvironmentValidationMiddleware {
+	// Undoing a change is validated like the change itself
+	allTools := append(tools.ListTools(), rollback.UndoLastChangeDef)
 	toolRegistry := validation.NewToolRegistry(allTools)
 	environmentsFactory := environments.NewPingOneClientEnvironmentsWrapperFactory(clientFactory, tokenStore)
 
-	validator := validation.NewCachingEnvironmentValidator(environmentsFactory)
-	validationMiddleware := validation.NewEnvironmentValidationMiddleware(validator, toolRegistry)
-	return validationMiddleware.Handler
+	validator := validation.NewCachingEnvironmentValidator(environmentsFactory, productionAccessPolicy, environmentCacheOptions)
+	validator.StartCacheExpiry(ctx)
+	if profileSwitcher != nil {
+		// Environments of the previous profile must be looked up again
+		profileSwitcher.OnSwitch(validator.ClearCache)
+	}
+	return validation.NewEnvironmentValidationMiddleware(validator, toolRegistry, productionGuardrail, environmentScope)
+}
+
+// setupPlanMiddleware adds the execute_plan tool, whose steps are validated like calls of their tools and whose
+// changes are undone with the journal when a step fails, and returns the middleware that runs the steps.
+func setupPlanMiddleware(ctx context.Context, server *mcp.Server, toolFilter *filter.Filter, validationMiddleware *validation.EnvironmentValidationMiddleware, journal *rollback.Journal) mcp.Middleware {
+	if toolFilter.ShouldIncludeTool(&plan.ExecutePlanDef) {
+		plan.RegisterExecutePlanTool(server, tools.ListTools(), validationMiddleware, journal)
+	}
+	planMiddleware := plan.NewPlanMiddleware()
+	return planMiddleware.Handler
+}
+
+// setupRegionSelector adds the select_region tool and returns the selector of the regions that tool calls are sent
+// to, or nil when the server only uses its configured region.
+func setupRegionSelector(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, tokenStore tokenstore.TokenStore, multiRegion bool, profileSwitcher *profile.Switcher, toolFilter *filter.Filter) *region.Selector {
+	if !multiRegion {
+		return nil
+	}
+	selector := region.NewSelector(environments.NewPingOneClientEnvironmentsWrapperFactory(clientFactory, tokenStore))
+	if profileSwitcher != nil {
+		// Environments of the previous profile are in another organization, and the profile sets its own region
+		profileSwitcher.OnSwitch(selector.Clear)
+	}
+	if toolFilter.ShouldIncludeTool(&region.SelectRegionDef) {
+		region.RegisterSelectRegionTool(server, selector)
+	}
+	logger.FromContext(ctx).Info("Multi-region enabled - tool calls will be sent to the region of their environment")
+	return selector
+}
+
+// setupRegionMiddleware sends the PingOne API requests of tool calls to the region of their environment when
+// multiple regions are enabled.
+func setupRegionMiddleware(ctx context.Context, server *mcp.Server, selector *region.Selector) mcp.Middleware {
+	regionMiddleware := region.NewRegionMiddleware(selector)
+	return regionMiddleware.Handler
+}
+
+// setupConfirmationMiddleware asks users to approve calls of destructive tools, unless confirmation is disabled, and
+// calls of the tools the tool policy requires to be confirmed.
+func setupConfirmationMiddleware(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, tokenStore tokenstore.TokenStore, confirmDestructiveTools bool, toolPolicy *toolpolicy.Policy) mcp.Middleware {
+	toolsRequiringConfirmation := tools.ListTools()
+	if !confirmDestructiveTools {
+		toolsRequiringConfirmation = nil
+		logger.FromContext(ctx).Warn("Confirmation of destructive tools disabled - destructive tools will run without the user's approval")
+	}
+	// Confirmed regardless of --confirm-destructive-tools, as the policy is set by the operator for the deployment
+	toolsRequiringConfirmation = append(toolsRequiringConfirmation, toolPolicy.ConfirmedTools(append(tools.ListTools(), ListServerTools()...))...)
+	environmentsFactory := environments.NewPingOneClientEnvironmentsWrapperFactory(clientFactory, tokenStore)
+	confirmationMiddleware := confirmation.NewConfirmationMiddleware(toolsRequiringConfirmation, environmentsFactory)
+	return confirmationMiddleware.Handler
+}
+
+func setupConcurrencyLimitMiddleware(ctx context.Context, server *mcp.Server) mcp.Middleware {
+	concurrencyLimitMiddleware := concurrency.NewConcurrencyLimitMiddleware(tools.ListTools())
+	return concurrencyLimitMiddleware.Handler
+}
+
+// setupToolTimeoutMiddleware cancels tool calls that run longer than the timeout of their tool.
+func setupToolTimeoutMiddleware(ctx context.Context, server *mcp.Server, toolTimeouts tooltimeout.ToolTimeouts) mcp.Middleware {
+	if toolTimeouts.Default <= 0 {
+		logger.FromContext(ctx).Warn("Default tool timeout disabled - tool calls without a timeout of their own run until they complete")
+	}
+	toolTimeoutMiddleware := tooltimeout.NewToolTimeoutMiddleware(toolTimeouts)
+	return toolTimeoutMiddleware.Handler
+}
+
+func setupDefaultFilterMiddleware(ctx context.Context, server *mcp.Server, defaultFilters map[string]string) mcp.Middleware {
+	defaultFilterMiddleware := defaultfilter.NewDefaultFilterMiddleware(defaultFilters)
+	return defaultFilterMiddleware.Handler
+}
+
+func setupDefaultBookmarksMiddleware(ctx context.Context, server *mcp.Server, defaultBookmarks defaultbookmarks.DefaultBookmarks) mcp.Middleware {
+	defaultBookmarksMiddleware := defaultbookmarks.NewDefaultBookmarksMiddleware(defaultBookmarks)
+	return defaultBookmarksMiddleware.Handler
+}
+
+// setupResultStoreMiddleware registers the paged list result resources when a page size is configured.
+// Without a page size, list tools return all items inline.
+func setupResultStoreMiddleware(ctx context.Context, server *mcp.Server, listResultPageSize int, profileSwitcher *profile.Switcher) mcp.Middleware {
+	var store *resources.PagedResultStore
+	if listResultPageSize > 0 {
+		store = resources.NewPagedResultStore(listResultPageSize, resources.DefaultMaxStoredResults)
+		store.Register(server)
+		if profileSwitcher != nil {
+			// Results of the previous profile must not be readable after switching
+			profileSwitcher.OnSwitch(store.Clear)
+		}
+		logger.FromContext(ctx).Info("Paged list results enabled - large list results will be returned as MCP resources")
+	}
+	resultStoreMiddleware := resources.NewResultStoreMiddleware(store)
+	return resultStoreMiddleware.Handler
+}
+
+// setupOutputLimitMiddleware truncates tool results larger than the output size limit, keeping the rest of them
+// as resources, unless the limit is disabled.
+func setupOutputLimitMiddleware(ctx context.Context, server *mcp.Server, maxOutputBytes int, profileSwitcher *profile.Switcher) mcp.Middleware {
+	var store *outputlimit.ContinuationStore
+	if maxOutputBytes > 0 {
+		store = outputlimit.NewContinuationStore(maxOutputBytes, outputlimit.DefaultMaxStoredResults)
+		store.Register(server)
+		if profileSwitcher != nil {
+			// Results of the previous profile must not be readable after switching
+			profileSwitcher.OnSwitch(store.Clear)
+		}
+		logger.FromContext(ctx).Info("Output size limit enabled - larger tool results will be truncated", slog.Int("maxOutputBytes", maxOutputBytes))
+	}
+	outputLimitMiddleware := outputlimit.NewOutputLimitMiddleware(maxOutputBytes, store)
+	return outputLimitMiddleware.Handler
+}
+
+func setupFieldSelectionMiddleware(ctx context.Context, server *mcp.Server) mcp.Middleware {
+	fieldSelectionMiddleware := fieldselection.NewFieldSelectionMiddleware(append(tools.ListTools(), ListServerTools()...))
+	return fieldSelectionMiddleware.Handler
+}
+
+func setupOutputTransformMiddleware(ctx context.Context, server *mcp.Server, outputTransformers outputtransform.OutputTransformers) mcp.Middleware {
+	outputTransformMiddleware := outputtransform.NewOutputTransformMiddleware(outputTransformers)
+	return outputTransformMiddleware.Handler
+}
+
+func setupTextTemplateMiddleware(ctx context.Context, server *mcp.Server, textTemplates texttemplates.TextTemplates) mcp.Middleware {
+	textTemplateMiddleware := texttemplates.NewTextTemplateMiddleware(textTemplates)
+	return textTemplateMiddleware.Handler
+}
+
+// setupResponseCacheMiddleware caches the results of read-only tools when a TTL is configured.
+func setupResponseCacheMiddleware(ctx context.Context, server *mcp.Server, responseCacheTTL time.Duration, profileSwitcher *profile.Switcher, usageRecorder *usagereport.Recorder) mcp.Middleware {
+	var cache *responsecache.ResponseCache
+	if responseCacheTTL > 0 {
+		cache = responsecache.NewResponseCache(responseCacheTTL, responsecache.DefaultMaxCachedResponses)
+		if profileSwitcher != nil {
+			// Results of the previous profile must not be returned after switching
+			profileSwitcher.OnSwitch(cache.Clear)
+		}
+		logger.FromContext(ctx).Info("Response cache enabled - repeated read-only tool calls will be answered from the cache", slog.Duration("ttl", responseCacheTTL))
+	}
+	var statsRecorder responsecache.StatsRecorder
+	if usageRecorder != nil {
+		statsRecorder = usageRecorder
+	}
+	responseCacheMiddleware := responsecache.NewResponseCacheMiddleware(cache, tools.ListTools(), statsRecorder)
+	// Session management tools can change the logged in user, whose permissions may differ
+	for _, toolDef := range sessiontools.ListTools() {
+		responseCacheMiddleware.ClearOnTools(toolDef.McpTool.Name)
+	}
+	// Undoing a change restores resources that may be cached
+	responseCacheMiddleware.ClearOnTools(rollback.UndoLastChangeDef.McpTool.Name)
+	return responseCacheMiddleware.Handler
+}
+
+// setupErrorEnvelopeMiddleware returns failed tool calls with a machine-readable description of the error.
+func setupErrorEnvelopeMiddleware(ctx context.Context, server *mcp.Server) mcp.Middleware {
+	errorEnvelopeMiddleware := errorenvelope.NewErrorEnvelopeMiddleware()
+	return errorEnvelopeMiddleware.Handler
+}
+
+func setupUsageReportMiddleware(ctx context.Context, server *mcp.Server, usageRecorder *usagereport.Recorder) mcp.Middleware {
+	usageReportMiddleware := usagereport.NewUsageReportMiddleware(usageRecorder)
+	return usageReportMiddleware.Handler
 }
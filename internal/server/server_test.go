@@ -4,11 +4,14 @@ package server_test
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/sessiontools"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/server"
@@ -17,6 +20,11 @@ import (
 	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/region"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resourcegraph"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,7 +37,18 @@ func TestServer_MCPClient(t *testing.T) {
 	serverDone := make(chan error, 1)
 	go func() {
 		// Pass in dummy client for now, not testing tool functionality
-		err := server.Start(context.Background(), "test-version", serverTransport, sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), testutils.NewInMemoryTokenStore(), filter.PassthroughFilter(), defaultGrantType)
+		err := server.Start(context.Background(), serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           sdk.NewEmptyClientFactory(),
+			LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+			AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+			TokenStore:              testutils.NewInMemoryTokenStore(),
+			ToolFilter:              filter.PassthroughFilter(),
+			GrantType:               defaultGrantType,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
 		serverDone <- err
 	}()
 
@@ -107,6 +126,25 @@ func TestServer_ToolFiltering(t *testing.T) {
 			expectedTools:   testutils.ReadOnlyToolNames(),
 			unexpectedTools: testutils.WriteToolNames(),
 		},
+		{
+			name:          "undo tool is enabled with write tools",
+			expectedTools: []string{rollback.UndoLastChangeDef.McpTool.Name},
+		},
+		{
+			name:            "read-only mode excludes the undo tool",
+			readOnly:        true,
+			unexpectedTools: []string{rollback.UndoLastChangeDef.McpTool.Name},
+		},
+		{
+			name:          "read-only mode includes the session resource graph tool",
+			readOnly:      true,
+			expectedTools: []string{resourcegraph.ShowSessionResourceGraphDef.McpTool.Name},
+		},
+		{
+			name:          "read-only mode includes the job tools",
+			readOnly:      true,
+			expectedTools: []string{jobs.GetJobStatusDef.McpTool.Name, jobs.ListJobsDef.McpTool.Name, jobs.CancelJobDef.McpTool.Name},
+		},
 		{
 			name:            "read-only mode with excluded read-only tool",
 			readOnly:        true,
@@ -122,7 +160,18 @@ func TestServer_ToolFiltering(t *testing.T) {
 			serverDone := make(chan error, 1)
 			go func() {
 				toolFilter := filter.NewFilter(tt.readOnly, tt.includedTools, tt.excludedTools, tt.includedToolCollections, tt.excludedToolCollections)
-				err := server.Start(context.Background(), "test-version", serverTransport, sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), authtestutils.NewEmptyMockAuthClientFactory(), testutils.NewInMemoryTokenStore(), toolFilter, defaultGrantType)
+				err := server.Start(context.Background(), serverTransport, server.Options{
+					Version:                 "test-version",
+					ClientFactory:           sdk.NewEmptyClientFactory(),
+					LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+					AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+					TokenStore:              testutils.NewInMemoryTokenStore(),
+					ToolFilter:              toolFilter,
+					GrantType:               defaultGrantType,
+					ProductionGuardrail:     validation.ProductionGuardrailStrict,
+					EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+					ConfirmDestructiveTools: true,
+				})
 				serverDone <- err
 			}()
 
@@ -152,3 +201,266 @@ func TestServer_ToolFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_SessionToolsSkipAuthentication(t *testing.T) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	go func() {
+		// The empty auth client factory fails the call if authentication is attempted
+		_ = server.Start(context.Background(), serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           sdk.NewEmptyClientFactory(),
+			LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+			AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+			TokenStore:              testutils.NewInMemoryTokenStore(),
+			ToolFilter:              filter.PassthroughFilter(),
+			GrantType:               defaultGrantType,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: sessiontools.WhoAmIDef.McpTool.Name, Arguments: map[string]any{}})
+	require.NoError(t, err)
+	testutils.AssertMcpCallSuccess(t, err, result)
+	assert.Equal(t, false, result.StructuredContent.(map[string]any)["authenticated"])
+}
+
+func TestServer_ShowSessionResourceGraphSkipsAuthentication(t *testing.T) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	go func() {
+		// The empty auth client factory fails the call if authentication is attempted
+		_ = server.Start(context.Background(), serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           sdk.NewEmptyClientFactory(),
+			LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+			AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+			TokenStore:              testutils.NewInMemoryTokenStore(),
+			ToolFilter:              filter.PassthroughFilter(),
+			GrantType:               defaultGrantType,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: resourcegraph.ShowSessionResourceGraphDef.McpTool.Name, Arguments: map[string]any{}})
+	require.NoError(t, err)
+	testutils.AssertMcpCallSuccess(t, err, result)
+	assert.Empty(t, result.StructuredContent.(map[string]any)["nodes"])
+}
+
+func TestServer_JobToolsSkipAuthentication(t *testing.T) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	go func() {
+		// The empty auth client factory fails the call if authentication is attempted
+		_ = server.Start(context.Background(), serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           sdk.NewEmptyClientFactory(),
+			LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+			AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+			TokenStore:              testutils.NewInMemoryTokenStore(),
+			ToolFilter:              filter.PassthroughFilter(),
+			GrantType:               defaultGrantType,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: jobs.ListJobsDef.McpTool.Name, Arguments: map[string]any{}})
+	require.NoError(t, err)
+	testutils.AssertMcpCallSuccess(t, err, result)
+	assert.Empty(t, result.StructuredContent.(map[string]any)["jobs"])
+}
+
+func TestServer_SelectRegionIsRegisteredWithMultiRegion(t *testing.T) {
+	for _, multiRegion := range []bool{false, true} {
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+		go func() {
+			// The empty auth client factory fails the call if authentication is attempted
+			_ = server.Start(context.Background(), serverTransport, server.Options{
+				Version:                 "test-version",
+				ClientFactory:           sdk.NewEmptyClientFactory(),
+				LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+				AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+				TokenStore:              testutils.NewInMemoryTokenStore(),
+				ToolFilter:              filter.PassthroughFilter(),
+				GrantType:               defaultGrantType,
+				ProductionGuardrail:     validation.ProductionGuardrailStrict,
+				EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+				ConfirmDestructiveTools: true,
+				MultiRegion:             multiRegion,
+			})
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+
+		session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+		require.NoError(t, err)
+
+		listResult, err := session.ListTools(t.Context(), nil)
+		require.NoError(t, err)
+		registered := slices.ContainsFunc(listResult.Tools, func(tool *mcp.Tool) bool { return tool.Name == region.SelectRegionDef.McpTool.Name })
+		assert.Equal(t, multiRegion, registered)
+
+		if multiRegion {
+			result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: region.SelectRegionDef.McpTool.Name, Arguments: map[string]any{"region": "EU"}})
+			testutils.AssertMcpCallSuccess(t, err, result)
+			assert.Equal(t, "pingone.eu", result.StructuredContent.(map[string]any)["rootDomain"])
+		}
+		session.Close()
+	}
+}
+
+func TestServer_BrowsableResourcesAreReadWithAuthenticatedToolCalls(t *testing.T) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	authClientFactory := authtestutils.NewEmptyMockAuthClientFactory()
+	authClientFactory.On("NewAuthClient").Return(nil, errors.New("not logged in"))
+	go func() {
+		_ = server.Start(context.Background(), serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           sdk.NewEmptyClientFactory(),
+			LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+			AuthClientFactory:       authClientFactory,
+			TokenStore:              testutils.NewInMemoryTokenStore(),
+			ToolFilter:              filter.PassthroughFilter(),
+			GrantType:               defaultGrantType,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	resourcesResult, err := session.ListResources(t.Context(), &mcp.ListResourcesParams{})
+	require.NoError(t, err)
+	require.Len(t, resourcesResult.Resources, 1)
+	assert.Equal(t, "pingone://environments", resourcesResult.Resources[0].URI)
+
+	_, err = session.ReadResource(t.Context(), &mcp.ReadResourceParams{URI: "pingone://environments"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not logged in")
+	authClientFactory.AssertCalled(t, "NewAuthClient")
+}
+
+func TestServer_DynamicToolsetsAreEnabledWithoutLogin(t *testing.T) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	go func() {
+		// The empty auth client factory fails the test if the toolset tools try to log in
+		_ = server.Start(context.Background(), serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           sdk.NewEmptyClientFactory(),
+			LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+			AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+			TokenStore:              testutils.NewInMemoryTokenStore(),
+			ToolFilter:              filter.NewFilter(true, nil, nil, nil, nil),
+			GrantType:               defaultGrantType,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+			DynamicToolsets:         true,
+			EnabledToolsets:         []string{"roles"},
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	toolNames := func() []string {
+		toolsResult, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+		require.NoError(t, err)
+		var names []string
+		for _, tool := range toolsResult.Tools {
+			names = append(names, tool.Name)
+		}
+		return names
+	}
+	assert.Contains(t, toolNames(), "list_roles")
+	assert.NotContains(t, toolNames(), "list_environments")
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "enable_toolset", Arguments: map[string]any{"toolset": "environments"}})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, toolNames(), "list_environments")
+	assert.NotContains(t, toolNames(), "create_environment", "write tools should not be enabled in read-only mode")
+}
+
+func TestServer_ReadOnlyModeRejectsWriteToolCalls(t *testing.T) {
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+
+	go func() {
+		_ = server.Start(context.Background(), serverTransport, server.Options{
+			Version:                 "test-version",
+			ClientFactory:           sdk.NewEmptyClientFactory(),
+			LegacySdkClientFactory:  legacy.NewEmptyClientFactory(),
+			AuthClientFactory:       authtestutils.NewEmptyMockAuthClientFactory(),
+			TokenStore:              testutils.NewInMemoryTokenStore(),
+			ToolFilter:              filter.NewFilter(true, nil, nil, nil, nil),
+			GrantType:               defaultGrantType,
+			ProductionGuardrail:     validation.ProductionGuardrailStrict,
+			EnvironmentCacheOptions: validation.DefaultEnvironmentCacheOptions(),
+			ConfirmDestructiveTools: true,
+		})
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	client := mcptestutils.TestMcpClient(t)
+
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	// Read-only tools are called as usual
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: sessiontools.WhoAmIDef.McpTool.Name})
+	require.NoError(t, err)
+	assert.NotNil(t, result)
+
+	// Write tools are rejected before the tool is looked up, and without logging in
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: environments.CreateEnvironmentDef.McpTool.Name, Arguments: map[string]any{}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tool 'create_environment' is not a read-only tool and cannot be called while the server is in read-only mode")
+}
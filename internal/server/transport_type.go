@@ -0,0 +1,39 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package server
+
+import "fmt"
+
+// TransportType is the MCP transport that the server accepts client connections on.
+type TransportType int
+
+const (
+	_ TransportType = iota
+	// TransportTypeStdio serves a single client over stdin/stdout. This is the default.
+	TransportTypeStdio
+	// TransportTypeHttp serves remote clients over the streamable HTTP transport, with an SSE fallback
+	// for clients that only support the earlier HTTP+SSE transport.
+	TransportTypeHttp
+)
+
+func (t TransportType) String() string {
+	switch t {
+	case TransportTypeStdio:
+		return "stdio"
+	case TransportTypeHttp:
+		return "http"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseTransportType(s string) (TransportType, error) {
+	switch s {
+	case "stdio":
+		return TransportTypeStdio, nil
+	case "http":
+		return TransportTypeHttp, nil
+	default:
+		return 0, fmt.Errorf("unable to parse transport type from string: %s", s)
+	}
+}
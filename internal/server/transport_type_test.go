@@ -0,0 +1,37 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package server_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransportType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected server.TransportType
+		wantErr  bool
+	}{
+		{input: "stdio", expected: server.TransportTypeStdio},
+		{input: "http", expected: server.TransportTypeHttp},
+		{input: "sse", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			transportType, err := server.ParseTransportType(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, transportType)
+			assert.Equal(t, tt.input, transportType.String())
+		})
+	}
+}
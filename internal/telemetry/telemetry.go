@@ -0,0 +1,251 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package telemetry exports OpenTelemetry traces and metrics of tool calls and PingOne API requests.
+//
+// Instrumented code uses the global OpenTelemetry providers, which do nothing until Setup is called,
+// so instrumentation has no cost when telemetry is disabled.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// InstrumentationName is the name of the tracer and meter used to instrument the server.
+const InstrumentationName = "github.com/pingidentity/pingone-mcp-server"
+
+const (
+	ServiceName = "pingone-mcp-server"
+
+	SdkDisabledEnvVar         = "OTEL_SDK_DISABLED"
+	TracesExporterEnvVar      = "OTEL_TRACES_EXPORTER"
+	MetricsExporterEnvVar     = "OTEL_METRICS_EXPORTER"
+	OtlpProtocolEnvVar        = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	OtlpEndpointEnvVar        = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	OtlpTracesEndpointEnvVar  = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	OtlpMetricsEndpointEnvVar = "OTEL_EXPORTER_OTLP_METRICS_ENDPOINT"
+	PrometheusHostEnvVar      = "OTEL_EXPORTER_PROMETHEUS_HOST"
+	PrometheusPortEnvVar      = "OTEL_EXPORTER_PROMETHEUS_PORT"
+
+	ExporterOtlp       = "otlp"
+	ExporterPrometheus = "prometheus"
+	ExporterNone       = "none"
+
+	// DefaultOtlpEndpoint is the endpoint that the OTLP over HTTP exporters use when none is configured
+	DefaultOtlpEndpoint = "http://localhost:4318"
+
+	DefaultPrometheusHost = "localhost"
+	DefaultPrometheusPort = "9464"
+	PrometheusMetricsPath = "/metrics"
+
+	// ShutdownTimeout is how long pending telemetry is given to be exported when the server stops
+	ShutdownTimeout = 5 * time.Second
+)
+
+// Shutdown flushes pending telemetry and stops the exporters.
+type Shutdown func(ctx context.Context) error
+
+// Setup configures the global OpenTelemetry providers from the standard OTEL_* environment variables.
+//
+// Traces are exported with OTLP over HTTP unless OTEL_TRACES_EXPORTER is none. Metrics are exported with OTLP over
+// HTTP, or served for Prometheus to scrape when OTEL_METRICS_EXPORTER is prometheus, unless it is none.
+// The OTLP endpoint, headers and export intervals are read by the exporters from the standard variables.
+// Telemetry is not set up when OTEL_SDK_DISABLED is true.
+func Setup(ctx context.Context, version string) (Shutdown, error) {
+	noShutdown := func(context.Context) error { return nil }
+	if strings.EqualFold(os.Getenv(SdkDisabledEnvVar), "true") {
+		logger.FromContext(ctx).Info("OpenTelemetry disabled by " + SdkDisabledEnvVar)
+		return noShutdown, nil
+	}
+
+	if protocol := os.Getenv(OtlpProtocolEnvVar); protocol != "" && protocol != "http/protobuf" {
+		return nil, fmt.Errorf("unsupported %s '%s', only http/protobuf is supported", OtlpProtocolEnvVar, protocol)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", version),
+		),
+		resource.WithTelemetrySDK(),
+		// Environment variables such as OTEL_SERVICE_NAME override the defaults
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenTelemetry resource: %w", err)
+	}
+
+	var shutdowns []Shutdown
+	shutdownAll := func(ctx context.Context) error {
+		var errs []error
+		for _, shutdown := range shutdowns {
+			errs = append(errs, shutdown(ctx))
+		}
+		return errors.Join(errs...)
+	}
+
+	tracesExporter := exporterFromEnv(TracesExporterEnvVar)
+	switch tracesExporter {
+	case ExporterOtlp:
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+		otel.SetTracerProvider(tracerProvider)
+		shutdowns = append(shutdowns, tracerProvider.Shutdown)
+	case ExporterNone:
+	default:
+		return nil, fmt.Errorf("unsupported %s '%s', must be one of: %s, %s", TracesExporterEnvVar, tracesExporter, ExporterOtlp, ExporterNone)
+	}
+
+	metricsExporter := exporterFromEnv(MetricsExporterEnvVar)
+	var reader sdkmetric.Reader
+	switch metricsExporter {
+	case ExporterOtlp:
+		exporter, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			_ = shutdownAll(ctx)
+			return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+		}
+		reader = sdkmetric.NewPeriodicReader(exporter)
+	case ExporterPrometheus:
+		registry := prometheus.NewRegistry()
+		exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+		if err != nil {
+			_ = shutdownAll(ctx)
+			return nil, fmt.Errorf("failed to create Prometheus metric exporter: %w", err)
+		}
+		reader = exporter
+		stopServing, err := servePrometheusMetrics(ctx, registry)
+		if err != nil {
+			_ = shutdownAll(ctx)
+			return nil, err
+		}
+		shutdowns = append(shutdowns, stopServing)
+	case ExporterNone:
+	default:
+		_ = shutdownAll(ctx)
+		return nil, fmt.Errorf("unsupported %s '%s', must be one of: %s, %s, %s", MetricsExporterEnvVar, metricsExporter, ExporterOtlp, ExporterPrometheus, ExporterNone)
+	}
+	if reader != nil {
+		meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res))
+		otel.SetMeterProvider(meterProvider)
+		// Shut down the meter provider first, so that the last metrics are exported while Prometheus is still served
+		shutdowns = append([]Shutdown{meterProvider.Shutdown}, shutdowns...)
+	}
+
+	logger.FromContext(ctx).Info("OpenTelemetry enabled",
+		slog.String("tracesExporter", tracesExporter),
+		slog.String("metricsExporter", metricsExporter))
+
+	return shutdownAll, nil
+}
+
+// Destinations describes where Setup sends or serves telemetry, as configured by the standard OTEL_* environment variables.
+type Destinations struct {
+	// OtlpEndpoints are the URLs that traces and metrics are exported to with OTLP over HTTP.
+	OtlpEndpoints []string
+	// PrometheusAddress is the host and port that metrics are served on for Prometheus to scrape, if any.
+	PrometheusAddress string
+}
+
+// DestinationsFromEnv returns where Setup would send or serve telemetry with the current environment variables.
+func DestinationsFromEnv() Destinations {
+	var destinations Destinations
+	if strings.EqualFold(os.Getenv(SdkDisabledEnvVar), "true") {
+		return destinations
+	}
+
+	addOtlpEndpoint := func(signalEndpointEnvVar string) {
+		endpoint := strings.TrimSpace(os.Getenv(signalEndpointEnvVar))
+		if endpoint == "" {
+			endpoint = strings.TrimSpace(os.Getenv(OtlpEndpointEnvVar))
+		}
+		if endpoint == "" {
+			endpoint = DefaultOtlpEndpoint
+		}
+		if !slices.Contains(destinations.OtlpEndpoints, endpoint) {
+			destinations.OtlpEndpoints = append(destinations.OtlpEndpoints, endpoint)
+		}
+	}
+	if exporterFromEnv(TracesExporterEnvVar) == ExporterOtlp {
+		addOtlpEndpoint(OtlpTracesEndpointEnvVar)
+	}
+	switch exporterFromEnv(MetricsExporterEnvVar) {
+	case ExporterOtlp:
+		addOtlpEndpoint(OtlpMetricsEndpointEnvVar)
+	case ExporterPrometheus:
+		host := os.Getenv(PrometheusHostEnvVar)
+		if host == "" {
+			host = DefaultPrometheusHost
+		}
+		port := os.Getenv(PrometheusPortEnvVar)
+		if port == "" {
+			port = DefaultPrometheusPort
+		}
+		destinations.PrometheusAddress = net.JoinHostPort(host, port)
+	}
+	return destinations
+}
+
+// exporterFromEnv returns the exporter named by the environment variable, defaulting to OTLP as specified by OpenTelemetry
+func exporterFromEnv(envVar string) string {
+	exporter := strings.ToLower(strings.TrimSpace(os.Getenv(envVar)))
+	if exporter == "" {
+		return ExporterOtlp
+	}
+	return exporter
+}
+
+// servePrometheusMetrics serves the metrics of the registry on the standard Prometheus exporter host and port
+func servePrometheusMetrics(ctx context.Context, registry *prometheus.Registry) (Shutdown, error) {
+	host := os.Getenv(PrometheusHostEnvVar)
+	if host == "" {
+		host = DefaultPrometheusHost
+	}
+	port := os.Getenv(PrometheusPortEnvVar)
+	if port == "" {
+		port = DefaultPrometheusPort
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for Prometheus metrics requests: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(PrometheusMetricsPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.FromContext(ctx).Error("Failed to serve Prometheus metrics", slog.String("error", err.Error()))
+		}
+	}()
+	logger.FromContext(ctx).Info("Serving Prometheus metrics", slog.String("address", "http://"+listener.Addr().String()+PrometheusMetricsPath))
+
+	return server.Shutdown, nil
+}
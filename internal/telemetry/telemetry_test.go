@@ -0,0 +1,130 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package telemetry_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup_ExportersDisabled(t *testing.T) {
+	t.Setenv(telemetry.TracesExporterEnvVar, "none")
+	t.Setenv(telemetry.MetricsExporterEnvVar, "none")
+
+	shutdown, err := telemetry.Setup(context.Background(), "test-version")
+
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_SdkDisabled(t *testing.T) {
+	t.Setenv(telemetry.SdkDisabledEnvVar, "true")
+	t.Setenv(telemetry.TracesExporterEnvVar, "unsupported")
+
+	shutdown, err := telemetry.Setup(context.Background(), "test-version")
+
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_PrometheusMetrics(t *testing.T) {
+	t.Setenv(telemetry.TracesExporterEnvVar, "none")
+	t.Setenv(telemetry.MetricsExporterEnvVar, "prometheus")
+	t.Setenv(telemetry.PrometheusHostEnvVar, "127.0.0.1")
+	t.Setenv(telemetry.PrometheusPortEnvVar, "0")
+
+	shutdown, err := telemetry.Setup(context.Background(), "test-version")
+
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_InvalidConfiguration(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		expectedError string
+	}{
+		{
+			name:          "unsupported traces exporter",
+			env:           map[string]string{telemetry.TracesExporterEnvVar: "zipkin", telemetry.MetricsExporterEnvVar: "none"},
+			expectedError: "unsupported OTEL_TRACES_EXPORTER 'zipkin', must be one of: otlp, none",
+		},
+		{
+			name:          "unsupported metrics exporter",
+			env:           map[string]string{telemetry.TracesExporterEnvVar: "none", telemetry.MetricsExporterEnvVar: "console"},
+			expectedError: "unsupported OTEL_METRICS_EXPORTER 'console', must be one of: otlp, prometheus, none",
+		},
+		{
+			name:          "unsupported OTLP protocol",
+			env:           map[string]string{telemetry.OtlpProtocolEnvVar: "grpc"},
+			expectedError: "unsupported OTEL_EXPORTER_OTLP_PROTOCOL 'grpc', only http/protobuf is supported",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := telemetry.Setup(context.Background(), "test-version")
+
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestDestinationsFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected telemetry.Destinations
+	}{
+		{
+			name:     "defaults",
+			expected: telemetry.Destinations{OtlpEndpoints: []string{telemetry.DefaultOtlpEndpoint}},
+		},
+		{
+			name:     "common endpoint",
+			env:      map[string]string{telemetry.OtlpEndpointEnvVar: "https://otel.example.com"},
+			expected: telemetry.Destinations{OtlpEndpoints: []string{"https://otel.example.com"}},
+		},
+		{
+			name: "signal endpoints",
+			env: map[string]string{
+				telemetry.OtlpEndpointEnvVar:        "https://otel.example.com",
+				telemetry.OtlpMetricsEndpointEnvVar: "https://metrics.example.com/v1/metrics",
+			},
+			expected: telemetry.Destinations{OtlpEndpoints: []string{"https://otel.example.com", "https://metrics.example.com/v1/metrics"}},
+		},
+		{
+			name: "prometheus metrics",
+			env: map[string]string{
+				telemetry.TracesExporterEnvVar:  "none",
+				telemetry.MetricsExporterEnvVar: "prometheus",
+				telemetry.PrometheusPortEnvVar:  "9000",
+			},
+			expected: telemetry.Destinations{PrometheusAddress: "localhost:9000"},
+		},
+		{
+			name: "sdk disabled",
+			env:  map[string]string{telemetry.SdkDisabledEnvVar: "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, envVar := range []string{telemetry.SdkDisabledEnvVar, telemetry.TracesExporterEnvVar, telemetry.MetricsExporterEnvVar, telemetry.OtlpEndpointEnvVar, telemetry.OtlpTracesEndpointEnvVar, telemetry.OtlpMetricsEndpointEnvVar, telemetry.PrometheusHostEnvVar, telemetry.PrometheusPortEnvVar} {
+				t.Setenv(envVar, "")
+			}
+			for envVar, value := range tt.env {
+				t.Setenv(envVar, value)
+			}
+
+			assert.Equal(t, tt.expected, telemetry.DestinationsFromEnv())
+		})
+	}
+}
@@ -32,6 +32,14 @@ func (m *MockAuthClient) TokenSource(ctx context.Context, grantType auth.GrantTy
 	return args.Get(0).(oauth2.TokenSource), args.Error(1)
 }
 
+func (m *MockAuthClient) RefreshTokenSource(ctx context.Context, grantType auth.GrantType, token *oauth2.Token) (oauth2.TokenSource, error) {
+	args := m.Called(ctx, grantType, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(oauth2.TokenSource), args.Error(1)
+}
+
 func (m *MockAuthClient) BrowserLoginAvailable(grantType auth.GrantType) bool {
 	args := m.Called(grantType)
 	return args.Bool(0)
@@ -5,6 +5,8 @@ package testutils
 import (
 	"context"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -13,6 +15,7 @@ import (
 	"github.com/pingidentity/pingone-mcp-server/cmd/run"
 	"github.com/pingidentity/pingone-mcp-server/cmd/session"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
+	"github.com/pingidentity/pingone-mcp-server/internal/safemode"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
@@ -27,8 +30,17 @@ func prepareTestCommand(cmd *cobra.Command, args ...string) {
 	cmd.SetArgs(args)
 }
 
+// useTestCrashMarker keeps the crash marker of test runs out of the user's home directory, unless the test set its own
+func useTestCrashMarker(t *testing.T) {
+	t.Helper()
+	if os.Getenv(safemode.MarkerFileEnvVar) == "" {
+		t.Setenv(safemode.MarkerFileEnvVar, filepath.Join(t.TempDir(), "crash_marker.json"))
+	}
+}
+
 func ExecuteCliRootCommand(t *testing.T, ctx context.Context, args ...string) (err error) {
 	t.Helper()
+	useTestCrashMarker(t)
 
 	root := cmd.NewRootCommand(TestServerVersion)
 	prepareTestCommand(root, args...)
@@ -38,6 +50,7 @@ func ExecuteCliRootCommand(t *testing.T, ctx context.Context, args ...string) (e
 
 func ExecuteCliRunCommand(t *testing.T, ctx context.Context, tokenStoreFactory tokenstore.TokenStoreFactory, clientFactory sdk.ClientFactory, legacyClientFactory legacy.ClientFactory, authClientFactory client.AuthClientFactory, transport mcp.Transport, args ...string) (err error) {
 	t.Helper()
+	useTestCrashMarker(t)
 
 	runCmd := run.NewCommand(tokenStoreFactory, clientFactory, legacyClientFactory, authClientFactory, transport, TestServerVersion)
 	prepareTestCommand(runCmd, args...)
@@ -12,13 +12,15 @@ import (
 )
 
 var (
-	ErrSessionNotFound                       = errors.New("session not found")
-	_                  tokenstore.TokenStore = &InMemoryTokenStore{}
+	ErrSessionNotFound                        = errors.New("session not found")
+	_                  tokenstore.TokenStore  = &InMemoryTokenStore{}
+	_                  tokenstore.SecretStore = &InMemoryTokenStore{}
 )
 
 type InMemoryTokenStore struct {
 	mu      sync.RWMutex
 	session *auth.AuthSession
+	secrets map[string]string
 	// Errors to simulate failures for testing
 	PutSessionError    error
 	GetSessionError    error
@@ -81,3 +83,23 @@ func (s *InMemoryTokenStore) DeleteSession() error {
 	s.session = nil
 	return nil
 }
+
+func (s *InMemoryTokenStore) PutSecret(name string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secrets == nil {
+		s.secrets = map[string]string{}
+	}
+	s.secrets[name] = value
+	return nil
+}
+
+func (s *InMemoryTokenStore) GetSecret(name string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.secrets[name]
+	if !ok {
+		return "", tokenstore.ErrSecretNotFound
+	}
+	return value, nil
+}
@@ -2,7 +2,10 @@
 
 package tokenstore
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+)
 
 var _ TokenStoreFactory = &DefaultTokenStoreFactory{}
 
@@ -18,6 +21,8 @@ func (d *DefaultTokenStoreFactory) NewTokenStore(storeType StoreType) (TokenStor
 		return NewKeychainTokenStore()
 	case StoreTypeFile:
 		return NewFileTokenStore()
+	case StoreTypeEncryptedFile:
+		return NewEncryptedFileTokenStore(os.Getenv(PassphraseEnvVar))
 	default:
 		return nil, fmt.Errorf("unsupported token store type when creating token store: %s", storeType.String())
 	}
@@ -0,0 +1,285 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tokenstore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+)
+
+const defaultEncryptedTokenFileName = ".pingone_mcp_session.enc"
+
+// PassphraseEnvVar is the environment variable that holds the passphrase the encrypted file token store
+// derives its encryption key from.
+const PassphraseEnvVar = "PINGONE_MCP_TOKEN_STORE_PASSPHRASE"
+
+const (
+	encryptedFileVersion = 1
+	keyDerivationIters   = 600000
+	keyLength            = 32
+	saltLength           = 16
+)
+
+var (
+	_ TokenStore  = &EncryptedFileTokenStore{}
+	_ SecretStore = &EncryptedFileTokenStore{}
+)
+
+// encryptedSessionFile is the on-disk format of an encrypted auth session.
+// The session is encrypted with AES-256-GCM, using a key derived from the passphrase with PBKDF2.
+type encryptedSessionFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileTokenStore is a file-based implementation of TokenStore that encrypts the auth session,
+// for hosts without an OS keychain where tokens should not be stored in plaintext.
+type EncryptedFileTokenStore struct {
+	filePath   string
+	passphrase string
+
+	// The derived key is cached, as key derivation is deliberately slow
+	mu   sync.Mutex
+	salt []byte
+	key  []byte
+}
+
+// NewEncryptedFileTokenStore creates a new EncryptedFileTokenStore with the default file path in the user's home directory
+func NewEncryptedFileTokenStore(passphrase string) (*EncryptedFileTokenStore, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user home directory when creating encrypted file token store: %w", err)
+	}
+
+	return NewEncryptedFileTokenStoreWithBasePath(homeDir, passphrase)
+}
+
+func NewEncryptedFileTokenStoreWithBasePath(basePath string, passphrase string) (*EncryptedFileTokenStore, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("a passphrase is required for the encrypted file token store, set the %s environment variable", PassphraseEnvVar)
+	}
+	filePath := filepath.Join(basePath, defaultEncryptedTokenFileName)
+	return &EncryptedFileTokenStore{
+		filePath:   filePath,
+		passphrase: passphrase,
+	}, nil
+}
+
+func (f *EncryptedFileTokenStore) PutSession(session auth.AuthSession) error {
+	tokenJSON, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal auth session: %w", err)
+	}
+	return f.writeEncrypted(f.filePath, tokenJSON, "auth session")
+}
+
+func (f *EncryptedFileTokenStore) HasSession() (bool, error) {
+	data, err := os.ReadFile(f.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// No session on filesystem
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read auth session from file: %w", err)
+	}
+
+	var file encryptedSessionFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return false, fmt.Errorf("failed to unmarshal encrypted auth session from file: %w", err)
+	}
+	return true, nil
+}
+
+func (f *EncryptedFileTokenStore) GetSession() (*auth.AuthSession, error) {
+	tokenJSON, err := f.readEncrypted(f.filePath, "auth session")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New("auth session file not found")
+		}
+		return nil, err
+	}
+
+	var session auth.AuthSession
+	if err := json.Unmarshal(tokenJSON, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal auth session from file: %w", err)
+	}
+	return &session, nil
+}
+
+func (f *EncryptedFileTokenStore) DeleteSession() error {
+	err := os.Remove(f.filePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete auth session file: %w", err)
+	}
+	return nil
+}
+
+func (f *EncryptedFileTokenStore) GetFilePath() string {
+	return f.filePath
+}
+
+// PutSecret encrypts the secret into its own file, next to the auth session file, with the same passphrase
+func (f *EncryptedFileTokenStore) PutSecret(name string, value string) error {
+	filePath, err := secretFilePath(f.filePath, name, ".enc")
+	if err != nil {
+		return err
+	}
+	return f.writeEncrypted(filePath, []byte(value), name)
+}
+
+func (f *EncryptedFileTokenStore) GetSecret(name string) (string, error) {
+	filePath, err := secretFilePath(f.filePath, name, ".enc")
+	if err != nil {
+		return "", err
+	}
+	value, err := f.readEncrypted(filePath, name)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrSecretNotFound
+		}
+		return "", err
+	}
+	return string(value), nil
+}
+
+// writeEncrypted encrypts the plaintext and writes it to the file, describing it as what in errors
+func (f *EncryptedFileTokenStore) writeEncrypted(filePath string, plaintext []byte, what string) error {
+	salt, key, err := f.encryptionKey()
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce for %s: %w", what, err)
+	}
+
+	fileJSON, err := json.Marshal(encryptedSessionFile{
+		Version:    encryptedFileVersion,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal encrypted %s: %w", what, err)
+	}
+
+	// Ensure the directory exists, and create it if necessary
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory for %s file: %w", what, err)
+	}
+
+	err = os.WriteFile(filePath, fileJSON, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to save %s to file: %w", what, err)
+	}
+	return nil
+}
+
+// readEncrypted reads the file and returns its decrypted plaintext, describing it as what in errors.
+// The error satisfies os.IsNotExist if the file does not exist.
+func (f *EncryptedFileTokenStore) readEncrypted(filePath string, what string) ([]byte, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read %s from file: %w", what, err)
+	}
+
+	var file encryptedSessionFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal encrypted %s from file: %w", what, err)
+	}
+	if file.Version != encryptedFileVersion {
+		return nil, fmt.Errorf("unsupported encrypted %s file version: %d", what, file.Version)
+	}
+
+	key, err := f.decryptionKey(file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce in encrypted %s file", what)
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s from file, the passphrase may have changed. Delete %s to remove it", what, filePath)
+	}
+	return plaintext, nil
+}
+
+// encryptionKey returns the cached key and its salt, deriving a key with a new salt if none is cached.
+func (f *EncryptedFileTokenStore) encryptionKey() ([]byte, []byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key != nil {
+		return f.salt, f.key, nil
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate salt for auth session: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, f.passphrase, salt, keyDerivationIters, keyLength)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to derive auth session encryption key: %w", err)
+	}
+	f.salt, f.key = salt, key
+	return salt, key, nil
+}
+
+// decryptionKey returns the key for the salt of a stored session, reusing the cached key if the salt matches.
+func (f *EncryptedFileTokenStore) decryptionKey(salt []byte) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.key != nil && bytes.Equal(f.salt, salt) {
+		return f.key, nil
+	}
+	if len(salt) != saltLength {
+		return nil, errors.New("invalid salt in encrypted auth session file")
+	}
+
+	key, err := pbkdf2.Key(sha256.New, f.passphrase, salt, keyDerivationIters, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive auth session encryption key: %w", err)
+	}
+	f.salt, f.key = salt, key
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth session cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth session cipher: %w", err)
+	}
+	return gcm, nil
+}
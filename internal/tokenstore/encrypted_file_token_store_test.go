@@ -0,0 +1,128 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tokenstore_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTempEncryptedTokenStore(t *testing.T, basePath string, passphrase string) *tokenstore.EncryptedFileTokenStore {
+	t.Helper()
+
+	store, err := tokenstore.NewEncryptedFileTokenStoreWithBasePath(basePath, passphrase)
+	require.NoError(t, err, "Failed to create temp EncryptedFileTokenStore")
+	return store
+}
+
+func TestEncryptedFileTokenStore_PutAndGetSession(t *testing.T) {
+	store := createTempEncryptedTokenStore(t, t.TempDir(), "test-passphrase")
+	session := createTestAuthSession()
+
+	err := store.PutSession(session)
+	require.NoError(t, err)
+
+	// Verify tokens are not stored in plaintext
+	data, err := os.ReadFile(store.GetFilePath())
+	require.NoError(t, err, "Should be able to read session file")
+	assert.False(t, strings.Contains(string(data), session.AccessToken), "Access token should not be stored in plaintext")
+	assert.False(t, strings.Contains(string(data), session.RefreshToken), "Refresh token should not be stored in plaintext")
+
+	info, err := os.Stat(store.GetFilePath())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	retrieved, err := store.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, session.AccessToken, retrieved.AccessToken)
+	assert.Equal(t, session.RefreshToken, retrieved.RefreshToken)
+	assert.Equal(t, session.SessionId, retrieved.SessionId)
+	assert.True(t, session.Expiry.Equal(retrieved.Expiry))
+}
+
+func TestEncryptedFileTokenStore_SessionSurvivesRestart(t *testing.T) {
+	basePath := t.TempDir()
+	session := createTestAuthSession()
+	require.NoError(t, createTempEncryptedTokenStore(t, basePath, "test-passphrase").PutSession(session))
+
+	// A new store with the same passphrase, as after a server restart
+	restarted := createTempEncryptedTokenStore(t, basePath, "test-passphrase")
+
+	hasSession, err := restarted.HasSession()
+	require.NoError(t, err)
+	assert.True(t, hasSession)
+
+	retrieved, err := restarted.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, session.AccessToken, retrieved.AccessToken)
+}
+
+func TestEncryptedFileTokenStore_WrongPassphrase(t *testing.T) {
+	basePath := t.TempDir()
+	require.NoError(t, createTempEncryptedTokenStore(t, basePath, "test-passphrase").PutSession(createTestAuthSession()))
+
+	store := createTempEncryptedTokenStore(t, basePath, "other-passphrase")
+
+	_, err := store.GetSession()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decrypt auth session")
+
+	// The session can still be deleted, so that the user can log in again
+	require.NoError(t, store.DeleteSession())
+	hasSession, err := store.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession)
+}
+
+func TestEncryptedFileTokenStore_HasSession(t *testing.T) {
+	store := createTempEncryptedTokenStore(t, t.TempDir(), "test-passphrase")
+
+	hasSession, err := store.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession, "Should not have session initially")
+
+	require.NoError(t, store.PutSession(createTestAuthSession()))
+
+	hasSession, err = store.HasSession()
+	require.NoError(t, err)
+	assert.True(t, hasSession, "Should have session after saving")
+}
+
+func TestEncryptedFileTokenStore_RequiresPassphrase(t *testing.T) {
+	_, err := tokenstore.NewEncryptedFileTokenStoreWithBasePath(t.TempDir(), "")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), tokenstore.PassphraseEnvVar)
+}
+
+func TestEncryptedFileTokenStore_Secrets(t *testing.T) {
+	basePath := t.TempDir()
+	store := createTempEncryptedTokenStore(t, basePath, "test-passphrase")
+
+	_, err := store.GetSecret("http_bearer_tokens")
+	assert.ErrorIs(t, err, tokenstore.ErrSecretNotFound)
+
+	require.NoError(t, store.PutSecret("http_bearer_tokens", "test-secret"))
+	value, err := store.GetSecret("http_bearer_tokens")
+	require.NoError(t, err)
+	assert.Equal(t, "test-secret", value)
+
+	// Verify the secret is not stored in plaintext
+	entries, err := os.ReadDir(basePath)
+	require.NoError(t, err)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(basePath, entry.Name()))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "test-secret")
+	}
+
+	_, err = createTempEncryptedTokenStore(t, basePath, "other-passphrase").GetSecret("http_bearer_tokens")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decrypt http_bearer_tokens")
+}
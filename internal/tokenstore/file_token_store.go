@@ -15,7 +15,8 @@ import (
 const defaultTokenFileName = ".pingone_mcp_session.json"
 
 var (
-	_ TokenStore = &FileTokenStore{}
+	_ TokenStore  = &FileTokenStore{}
+	_ SecretStore = &FileTokenStore{}
 )
 
 type FileTokenStore struct {
@@ -102,3 +103,33 @@ func (f *FileTokenStore) DeleteSession() error {
 func (f *FileTokenStore) GetFilePath() string {
 	return f.filePath
 }
+
+// PutSecret saves the secret in its own file, next to the auth session file
+func (f *FileTokenStore) PutSecret(name string, value string) error {
+	filePath, err := secretFilePath(f.filePath, name, "")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
+		return fmt.Errorf("failed to create directory for %s file: %w", name, err)
+	}
+	if err := os.WriteFile(filePath, []byte(value), 0600); err != nil {
+		return fmt.Errorf("failed to save %s to file: %w", name, err)
+	}
+	return nil
+}
+
+func (f *FileTokenStore) GetSecret(name string) (string, error) {
+	filePath, err := secretFilePath(f.filePath, name, "")
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("failed to read %s from file: %w", name, err)
+	}
+	return string(data), nil
+}
@@ -170,3 +170,33 @@ func TestFileTokenStore_NewFileTokenStore(t *testing.T) {
 		t.Errorf("File path %s is not in home directory %s. Relative path: %s", store.GetFilePath(), homeDir, relPath)
 	}
 }
+
+func TestFileTokenStore_Secrets(t *testing.T) {
+	store := createTempTokenStore(t)
+
+	_, err := store.GetSecret("http_bearer_tokens")
+	assert.ErrorIs(t, err, tokenstore.ErrSecretNotFound)
+
+	require.NoError(t, store.PutSecret("http_bearer_tokens", "test-secret"))
+	value, err := store.GetSecret("http_bearer_tokens")
+	require.NoError(t, err)
+	assert.Equal(t, "test-secret", value)
+
+	// Secrets are held apart from the auth session
+	hasSession, err := store.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession)
+
+	info, err := os.Stat(filepath.Join(filepath.Dir(store.GetFilePath()), ".pingone_mcp_http_bearer_tokens"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestFileTokenStore_InvalidSecretName(t *testing.T) {
+	store := createTempTokenStore(t)
+
+	for _, name := range []string{"", "../session", "Upper", "auth_session"} {
+		err := store.PutSecret(name, "test-secret")
+		assert.ErrorContains(t, err, "invalid secret name", "name %q", name)
+	}
+}
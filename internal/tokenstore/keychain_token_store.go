@@ -15,7 +15,8 @@ const keychainServiceName = "pingone_mcp_server"
 const keychainUsername = "auth_session"
 
 var (
-	_ TokenStore = &KeychainTokenStore{}
+	_ TokenStore  = &KeychainTokenStore{}
+	_ SecretStore = &KeychainTokenStore{}
 )
 
 // KeychainTokenStore provides a keychain-based implementation of TokenStore
@@ -74,3 +75,28 @@ func (k *KeychainTokenStore) DeleteSession() error {
 	}
 	return nil
 }
+
+// PutSecret saves the secret in the keychain, under the server's service and the secret's name
+func (k *KeychainTokenStore) PutSecret(name string, value string) error {
+	if err := validateSecretName(name); err != nil {
+		return err
+	}
+	if err := keyring.Set(keychainServiceName, name, value); err != nil {
+		return fmt.Errorf("failed to save %s to keychain: %w", name, err)
+	}
+	return nil
+}
+
+func (k *KeychainTokenStore) GetSecret(name string) (string, error) {
+	if err := validateSecretName(name); err != nil {
+		return "", err
+	}
+	value, err := keyring.Get(keychainServiceName, name)
+	if err != nil {
+		if errors.Is(err, keyring.ErrNotFound) {
+			return "", ErrSecretNotFound
+		}
+		return "", fmt.Errorf("failed to read %s from keychain: %w", name, err)
+	}
+	return value, nil
+}
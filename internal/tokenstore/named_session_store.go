@@ -0,0 +1,126 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tokenstore
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+)
+
+var (
+	_ TokenStore = &NamedSessionStore{}
+)
+
+// NamedSessionStore holds an auth session for each of several named connections, such as the profiles
+// of different PingOne organizations, of which one is active at a time.
+//
+// The active session is held in the underlying token store and is used by the TokenStore methods.
+// Sessions of inactive names are held in memory, so they are not kept when the server restarts.
+type NamedSessionStore struct {
+	mu     sync.Mutex
+	store  TokenStore
+	active string
+	held   map[string]auth.AuthSession
+}
+
+// NewNamedSessionStore creates a store whose active session is held in the given token store. The session
+// already in the token store, if any, belongs to the unnamed connection the server was started with.
+func NewNamedSessionStore(store TokenStore) *NamedSessionStore {
+	return &NamedSessionStore{
+		store: store,
+		held:  map[string]auth.AuthSession{},
+	}
+}
+
+func (n *NamedSessionStore) PutSession(session auth.AuthSession) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.store.PutSession(session)
+}
+
+func (n *NamedSessionStore) GetSession() (*auth.AuthSession, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.store.GetSession()
+}
+
+func (n *NamedSessionStore) HasSession() (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.store.HasSession()
+}
+
+func (n *NamedSessionStore) DeleteSession() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.store.DeleteSession()
+}
+
+// Active returns the name of the active session, or an empty string for the unnamed startup connection.
+func (n *NamedSessionStore) Active() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.active
+}
+
+// Activate makes the named session active. The current session is held under the current name unless
+// keepCurrent is false, in which case it is deleted. Returns true if a session held for the name was
+// restored, or false if the name has no session and must log in.
+func (n *NamedSessionStore) Activate(name string, keepCurrent bool) (bool, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if keepCurrent {
+		hasSession, err := n.store.HasSession()
+		if err != nil {
+			return false, fmt.Errorf("failed to check for auth session: %w", err)
+		}
+		if hasSession {
+			session, err := n.store.GetSession()
+			if err != nil {
+				return false, fmt.Errorf("failed to get auth session: %w", err)
+			}
+			if session != nil {
+				n.held[n.active] = *session
+			}
+		}
+	} else {
+		delete(n.held, n.active)
+	}
+
+	if err := n.store.DeleteSession(); err != nil {
+		return false, fmt.Errorf("failed to delete auth session: %w", err)
+	}
+
+	session, restored := n.held[name]
+	if restored {
+		if err := n.store.PutSession(session); err != nil {
+			return false, fmt.Errorf("failed to restore auth session: %w", err)
+		}
+		delete(n.held, name)
+	}
+	n.active = name
+
+	return restored, nil
+}
+
+// HeldNames returns the sorted names of the inactive sessions that can be restored without logging in.
+func (n *NamedSessionStore) HeldNames() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	names := make([]string, 0, len(n.held))
+	for name := range n.held {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
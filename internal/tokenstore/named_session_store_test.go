@@ -0,0 +1,72 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tokenstore_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedSessionStore_ActivateHoldsAndRestoresSessions(t *testing.T) {
+	store := tokenstore.NewNamedSessionStore(createTempTokenStore(t))
+	startupSession := createTestAuthSession()
+	require.NoError(t, store.PutSession(startupSession))
+
+	restored, err := store.Activate("customer-eu", true)
+	require.NoError(t, err)
+	assert.False(t, restored, "A name without a held session must log in")
+	assert.Equal(t, "customer-eu", store.Active())
+	hasSession, err := store.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession, "Session of the previous name must not be active")
+	assert.Equal(t, []string{""}, store.HeldNames())
+
+	euSession := createTestAuthSession()
+	euSession.SessionId = "eu-session-id"
+	require.NoError(t, store.PutSession(euSession))
+
+	restored, err = store.Activate("", true)
+	require.NoError(t, err)
+	assert.True(t, restored)
+	session, err := store.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, startupSession.SessionId, session.SessionId)
+	assert.Equal(t, []string{"customer-eu"}, store.HeldNames())
+
+	restored, err = store.Activate("customer-eu", true)
+	require.NoError(t, err)
+	assert.True(t, restored)
+	session, err = store.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, "eu-session-id", session.SessionId)
+}
+
+func TestNamedSessionStore_ActivateWithoutKeepingCurrent(t *testing.T) {
+	store := tokenstore.NewNamedSessionStore(createTempTokenStore(t))
+	require.NoError(t, store.PutSession(createTestAuthSession()))
+
+	restored, err := store.Activate("customer-eu", false)
+	require.NoError(t, err)
+	assert.False(t, restored)
+	assert.Empty(t, store.HeldNames(), "Session of the previous name should be deleted")
+
+	restored, err = store.Activate("", true)
+	require.NoError(t, err)
+	assert.False(t, restored)
+	hasSession, err := store.HasSession()
+	require.NoError(t, err)
+	assert.False(t, hasSession)
+}
+
+func TestNamedSessionStore_ActivateWithoutSession(t *testing.T) {
+	store := tokenstore.NewNamedSessionStore(createTempTokenStore(t))
+
+	restored, err := store.Activate("customer-eu", true)
+
+	require.NoError(t, err)
+	assert.False(t, restored)
+	assert.Empty(t, store.HeldNames(), "No session should be held for a name that never logged in")
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tokenstore
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+)
+
+// ErrSecretNotFound is returned by SecretStore.GetSecret when no secret is stored under the name
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore holds secrets of the server other than the auth session, such as the bearer tokens of the
+// HTTP transport, by name. The keychain, file and encrypted file token stores implement it, so that the
+// secrets are held in the same backend as the auth session.
+type SecretStore interface {
+	PutSecret(name string, value string) error
+	GetSecret(name string) (string, error)
+}
+
+var secretNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateSecretName checks that the name can be used as a keychain account and a file name, and is not
+// that of the auth session
+func validateSecretName(name string) error {
+	if !secretNamePattern.MatchString(name) || name == keychainUsername {
+		return fmt.Errorf("invalid secret name %q", name)
+	}
+	return nil
+}
+
+// secretFilePath returns the path of the file holding the secret in the file-based stores, next to the
+// auth session file
+func secretFilePath(sessionFilePath string, name string, extension string) (string, error) {
+	if err := validateSecretName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(sessionFilePath), ".pingone_mcp_"+name+extension), nil
+}
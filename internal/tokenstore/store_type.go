@@ -10,6 +10,7 @@ const (
 	_ StoreType = iota
 	StoreTypeKeychain
 	StoreTypeFile
+	StoreTypeEncryptedFile
 )
 
 func (t StoreType) String() string {
@@ -18,6 +19,8 @@ func (t StoreType) String() string {
 		return "keychain"
 	case StoreTypeFile:
 		return "file"
+	case StoreTypeEncryptedFile:
+		return "encrypted_file"
 	default:
 		return "unknown"
 	}
@@ -29,6 +32,8 @@ func ParseStoreType(s string) (StoreType, error) {
 		return StoreTypeKeychain, nil
 	case "file":
 		return StoreTypeFile, nil
+	case "encrypted_file":
+		return StoreTypeEncryptedFile, nil
 	default:
 		return 0, fmt.Errorf("unable to parse store type from string: %s", s)
 	}
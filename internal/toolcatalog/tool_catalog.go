@@ -0,0 +1,224 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package toolcatalog describes the tools the server can expose, with their annotations, validation policies and
+// schemas, so that the exposed surface can be reviewed and documented without starting the server.
+package toolcatalog
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/server"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// ServerCollection is the collection of the tools the server adds besides those of the tool collections.
+const ServerCollection = "server"
+
+const (
+	ProductionAccessNotApplicable = "not applicable"
+	ProductionAccessAllowed       = "allowed"
+	ProductionAccessValidated     = "validated"
+)
+
+// ValidationPolicy is the validation policy of a tool, as applied by the validation middleware.
+type ValidationPolicy struct {
+	AllowProductionEnvironmentRead     bool     `json:"allowProductionEnvironmentRead"`
+	AllowProductionEnvironmentWrite    bool     `json:"allowProductionEnvironmentWrite"`
+	ProductionEnvironmentNotApplicable bool     `json:"productionEnvironmentNotApplicable"`
+	ReadEnvironmentIdArguments         []string `json:"readEnvironmentIdArguments,omitempty"`
+}
+
+// Tool describes a tool of the catalog.
+type Tool struct {
+	Name        string               `json:"name"`
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description"`
+	Collection  string               `json:"collection"`
+	ReadOnly    bool                 `json:"readOnly"`
+	Annotations *mcp.ToolAnnotations `json:"annotations,omitempty"`
+	// ValidationPolicy is nil for tools whose environment arguments are always validated.
+	ValidationPolicy        *ValidationPolicy `json:"validationPolicy,omitempty"`
+	RequiresConfirmation    bool              `json:"requiresConfirmation"`
+	ConfirmationArgument    string            `json:"confirmationArgument,omitempty"`
+	RequiredRoles           []string          `json:"requiredRoles,omitempty"`
+	MaxConcurrentExecutions int               `json:"maxConcurrentExecutions,omitempty"`
+	DisableResponseCache    bool              `json:"disableResponseCache,omitempty"`
+	InputSchema             any               `json:"inputSchema,omitempty"`
+	OutputSchema            any               `json:"outputSchema,omitempty"`
+}
+
+// Schema is the JSON schemas of the input and output of a tool.
+type Schema struct {
+	Name         string `json:"name"`
+	InputSchema  any    `json:"inputSchema,omitempty"`
+	OutputSchema any    `json:"outputSchema,omitempty"`
+}
+
+// NewTool returns the catalog entry of the definition of a tool of the collection.
+func NewTool(collection string, toolDef types.ToolDefinition) Tool {
+	tool := Tool{
+		Name:                    toolDef.McpTool.Name,
+		Title:                   toolDef.McpTool.Title,
+		Description:             toolDef.McpTool.Description,
+		Collection:              collection,
+		ReadOnly:                toolDef.IsReadOnly(),
+		Annotations:             toolDef.McpTool.Annotations,
+		RequiresConfirmation:    toolDef.RequiresConfirmation,
+		ConfirmationArgument:    toolDef.ConfirmationArgument,
+		RequiredRoles:           toolDef.RequiredRoles,
+		MaxConcurrentExecutions: toolDef.MaxConcurrentExecutions,
+		DisableResponseCache:    toolDef.DisableResponseCache,
+		InputSchema:             toolDef.McpTool.InputSchema,
+		OutputSchema:            toolDef.McpTool.OutputSchema,
+	}
+	if tool.Title == "" && tool.Annotations != nil {
+		tool.Title = tool.Annotations.Title
+	}
+	if policy := toolDef.ValidationPolicy; policy != nil {
+		tool.ValidationPolicy = &ValidationPolicy{
+			AllowProductionEnvironmentRead:     policy.AllowProductionEnvironmentRead,
+			AllowProductionEnvironmentWrite:    policy.AllowProductionEnvironmentWrite,
+			ProductionEnvironmentNotApplicable: policy.ProductionEnvironmentNotApplicable,
+			ReadEnvironmentIdArguments:         policy.ReadEnvironmentIdArguments,
+		}
+	}
+	return tool
+}
+
+// List returns every tool the server can expose, by collection and then by name, followed by the server tools.
+func List() []Tool {
+	var catalog []Tool
+	collectionNames := tools.ListCollectionNames()
+	slices.Sort(collectionNames)
+	for _, collectionName := range collectionNames {
+		catalog = append(catalog, sortedTools(collectionName, tools.ListCollectionTools(collectionName))...)
+	}
+	return append(catalog, sortedTools(ServerCollection, server.ListServerTools())...)
+}
+
+// Filter returns the tools of the named collections, or all tools if no collections are named.
+func Filter(catalog []Tool, collections []string) ([]Tool, error) {
+	if len(collections) == 0 {
+		return catalog, nil
+	}
+	for _, collection := range collections {
+		if !slices.ContainsFunc(catalog, func(tool Tool) bool { return tool.Collection == collection }) {
+			return nil, fmt.Errorf("unknown tool collection %q", collection)
+		}
+	}
+	var filtered []Tool
+	for _, tool := range catalog {
+		if slices.Contains(collections, tool.Collection) {
+			filtered = append(filtered, tool)
+		}
+	}
+	return filtered, nil
+}
+
+// Schemas returns the schemas of the named tools, or of all tools if no tools are named.
+func Schemas(catalog []Tool, names []string) ([]Schema, error) {
+	var schemas []Schema
+	if len(names) == 0 {
+		for _, tool := range catalog {
+			schemas = append(schemas, tool.Schema())
+		}
+		return schemas, nil
+	}
+	for _, name := range names {
+		index := slices.IndexFunc(catalog, func(tool Tool) bool { return tool.Name == name })
+		if index < 0 {
+			return nil, fmt.Errorf("unknown tool %q", name)
+		}
+		schemas = append(schemas, catalog[index].Schema())
+	}
+	return schemas, nil
+}
+
+// Schema returns the schemas of the input and output of the tool.
+func (t Tool) Schema() Schema {
+	return Schema{Name: t.Name, InputSchema: t.InputSchema, OutputSchema: t.OutputSchema}
+}
+
+// Hints returns the behavior the tool's annotations declare, such as destructive.
+func (t Tool) Hints() []string {
+	var hints []string
+	if t.Annotations == nil {
+		return hints
+	}
+	if t.Annotations.DestructiveHint != nil && *t.Annotations.DestructiveHint && !t.ReadOnly {
+		hints = append(hints, "destructive")
+	}
+	if t.Annotations.IdempotentHint {
+		hints = append(hints, "idempotent")
+	}
+	if t.Annotations.OpenWorldHint != nil && *t.Annotations.OpenWorldHint {
+		hints = append(hints, "open-world")
+	}
+	return hints
+}
+
+// ProductionAccess returns how the tool's calls on production environments are validated: not applicable to tools
+// that do not act on an environment, allowed by the tool's validation policy, or validated against the production
+// guardrail.
+func (t Tool) ProductionAccess() string {
+	if t.ValidationPolicy == nil {
+		return ProductionAccessValidated
+	}
+	if t.ValidationPolicy.ProductionEnvironmentNotApplicable {
+		return ProductionAccessNotApplicable
+	}
+	if (t.ReadOnly && t.ValidationPolicy.AllowProductionEnvironmentRead) || (!t.ReadOnly && t.ValidationPolicy.AllowProductionEnvironmentWrite) {
+		return ProductionAccessAllowed
+	}
+	return ProductionAccessValidated
+}
+
+// WriteText writes the tools as a table, for review.
+func WriteText(w io.Writer, catalog []Tool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tCOLLECTION\tACCESS\tHINTS\tCONFIRMATION\tPRODUCTION ENVIRONMENTS")
+	for _, tool := range catalog {
+		access := "write"
+		if tool.ReadOnly {
+			access = "read-only"
+		}
+		hints := strings.Join(tool.Hints(), ", ")
+		if hints == "" {
+			hints = "-"
+		}
+		confirmation := "-"
+		if tool.RequiresConfirmation {
+			confirmation = "required"
+			if tool.ConfirmationArgument != "" {
+				confirmation = "required with " + tool.ConfirmationArgument
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", tool.Name, tool.Collection, access, hints, confirmation, tool.ProductionAccess())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "\n%d tools\n", len(catalog))
+	if slices.ContainsFunc(catalog, func(tool Tool) bool { return tool.Collection == ServerCollection }) {
+		fmt.Fprintf(w, "Tools of the %s collection are only registered when their feature is enabled.\n", ServerCollection)
+	}
+	return nil
+}
+
+// sortedTools returns the catalog entries of the tools of a collection, sorted by name
+func sortedTools(collection string, toolDefs []types.ToolDefinition) []Tool {
+	catalog := make([]Tool, 0, len(toolDefs))
+	for _, toolDef := range toolDefs {
+		catalog = append(catalog, NewTool(collection, toolDef))
+	}
+	slices.SortFunc(catalog, func(a, b Tool) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return catalog
+}
@@ -0,0 +1,132 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolcatalog_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/sessiontools"
+	"github.com/pingidentity/pingone-mcp-server/internal/server"
+	"github.com/pingidentity/pingone-mcp-server/internal/toolcatalog"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findTool(t *testing.T, catalog []toolcatalog.Tool, name string) toolcatalog.Tool {
+	t.Helper()
+	for _, tool := range catalog {
+		if tool.Name == name {
+			return tool
+		}
+	}
+	require.Failf(t, "tool not found", "no %s tool in the catalog", name)
+	return toolcatalog.Tool{}
+}
+
+func TestList(t *testing.T) {
+	catalog := toolcatalog.List()
+
+	assert.Len(t, catalog, len(tools.ListTools())+len(server.ListServerTools()))
+	listEnvironments := findTool(t, catalog, environments.ListEnvironmentsDef.McpTool.Name)
+	assert.Equal(t, environments.CollectionName, listEnvironments.Collection)
+	assert.True(t, listEnvironments.ReadOnly)
+	assert.NotNil(t, listEnvironments.InputSchema)
+	assert.NotNil(t, listEnvironments.OutputSchema)
+	assert.Equal(t, toolcatalog.ProductionAccessNotApplicable, listEnvironments.ProductionAccess())
+
+	whoami := findTool(t, catalog, sessiontools.WhoAmIDef.McpTool.Name)
+	assert.Equal(t, toolcatalog.ServerCollection, whoami.Collection)
+	assert.Equal(t, toolcatalog.ServerCollection, catalog[len(catalog)-1].Collection, "server tools should be listed last")
+}
+
+func TestNewTool(t *testing.T) {
+	destructive := true
+	tool := toolcatalog.NewTool("things", types.ToolDefinition{
+		McpTool: &mcp.Tool{
+			Name:        "delete_thing",
+			Description: "Deletes a thing",
+			Annotations: &mcp.ToolAnnotations{Title: "Delete Thing", DestructiveHint: &destructive, IdempotentHint: true},
+		},
+		ValidationPolicy:     &types.ToolValidationPolicy{AllowProductionEnvironmentWrite: true, ReadEnvironmentIdArguments: []string{"sourceEnvironmentId"}},
+		RequiresConfirmation: true,
+		ConfirmationArgument: "confirm",
+		RequiredRoles:        []string{"Organization Admin"},
+	})
+
+	assert.Equal(t, "Delete Thing", tool.Title)
+	assert.Equal(t, []string{"Organization Admin"}, tool.RequiredRoles)
+	assert.False(t, tool.ReadOnly)
+	assert.Equal(t, []string{"destructive", "idempotent"}, tool.Hints())
+	assert.Equal(t, toolcatalog.ProductionAccessAllowed, tool.ProductionAccess())
+	assert.Equal(t, &toolcatalog.ValidationPolicy{AllowProductionEnvironmentWrite: true, ReadEnvironmentIdArguments: []string{"sourceEnvironmentId"}}, tool.ValidationPolicy)
+
+	readTool := toolcatalog.NewTool("things", types.ToolDefinition{
+		McpTool:          &mcp.Tool{Name: "get_thing", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}},
+		ValidationPolicy: &types.ToolValidationPolicy{AllowProductionEnvironmentWrite: true},
+	})
+	assert.Equal(t, toolcatalog.ProductionAccessValidated, readTool.ProductionAccess(), "the write policy should not apply to read-only tools")
+	assert.Empty(t, readTool.Hints())
+}
+
+func TestFilter(t *testing.T) {
+	catalog := toolcatalog.List()
+
+	filtered, err := toolcatalog.Filter(catalog, []string{environments.CollectionName})
+	require.NoError(t, err)
+	require.NotEmpty(t, filtered)
+	for _, tool := range filtered {
+		assert.Equal(t, environments.CollectionName, tool.Collection)
+	}
+
+	all, err := toolcatalog.Filter(catalog, nil)
+	require.NoError(t, err)
+	assert.Equal(t, catalog, all)
+
+	_, err = toolcatalog.Filter(catalog, []string{"unknown"})
+	assert.ErrorContains(t, err, `unknown tool collection "unknown"`)
+}
+
+func TestSchemas(t *testing.T) {
+	catalog := toolcatalog.List()
+
+	schemas, err := toolcatalog.Schemas(catalog, []string{environments.ListEnvironmentsDef.McpTool.Name})
+	require.NoError(t, err)
+	require.Len(t, schemas, 1)
+	assert.Equal(t, environments.ListEnvironmentsDef.McpTool.Name, schemas[0].Name)
+	assert.Equal(t, environments.ListEnvironmentsDef.McpTool.InputSchema, schemas[0].InputSchema)
+
+	schemas, err = toolcatalog.Schemas(catalog, nil)
+	require.NoError(t, err)
+	assert.Len(t, schemas, len(catalog))
+
+	_, err = toolcatalog.Schemas(catalog, []string{"unknown"})
+	assert.ErrorContains(t, err, `unknown tool "unknown"`)
+}
+
+func TestWriteText(t *testing.T) {
+	catalog := []toolcatalog.Tool{
+		toolcatalog.NewTool("things", types.ToolDefinition{
+			McpTool:              &mcp.Tool{Name: "delete_thing"},
+			RequiresConfirmation: true,
+			ConfirmationArgument: "confirm",
+		}),
+		toolcatalog.NewTool("things", types.ToolDefinition{
+			McpTool:          &mcp.Tool{Name: "list_things", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}},
+			ValidationPolicy: &types.ToolValidationPolicy{ProductionEnvironmentNotApplicable: true},
+		}),
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, toolcatalog.WriteText(&out, catalog))
+
+	assert.Contains(t, out.String(), "required with confirm")
+	assert.Contains(t, out.String(), "read-only")
+	assert.Contains(t, out.String(), toolcatalog.ProductionAccessNotApplicable)
+	assert.Contains(t, out.String(), "2 tools")
+	assert.NotContains(t, out.String(), "only registered when their feature is enabled")
+}
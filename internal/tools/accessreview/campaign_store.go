@@ -0,0 +1,248 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ItemKindUserRoleAssignment  = "USER_ROLE_ASSIGNMENT"
+	ItemKindGroupRoleAssignment = "GROUP_ROLE_ASSIGNMENT"
+	ItemKindStaleAccount        = "STALE_ACCOUNT"
+
+	DecisionKeep   = "KEEP"
+	DecisionRevoke = "REVOKE"
+)
+
+// maxCampaigns bounds the number of campaigns held in memory; the oldest is evicted first
+const maxCampaigns = 20
+
+// ReviewItem is a single access to be kept or revoked by a reviewer
+type ReviewItem struct {
+	ItemId           string     `json:"itemId" jsonschema:"The ID of the item, used to record a decision"`
+	Kind             string     `json:"kind" jsonschema:"USER_ROLE_ASSIGNMENT, GROUP_ROLE_ASSIGNMENT or STALE_ACCOUNT"`
+	UserId           *string    `json:"userId,omitempty" jsonschema:"The ID of the user"`
+	Username         *string    `json:"username,omitempty" jsonschema:"The username of the user"`
+	GroupId          *string    `json:"groupId,omitempty" jsonschema:"The ID of the group holding the role"`
+	GroupName        *string    `json:"groupName,omitempty" jsonschema:"The name of the group holding the role"`
+	RoleAssignmentId *string    `json:"roleAssignmentId,omitempty" jsonschema:"The ID of the role assignment"`
+	RoleId           *string    `json:"roleId,omitempty" jsonschema:"The ID of the assigned role"`
+	RoleName         *string    `json:"roleName,omitempty" jsonschema:"The name of the assigned role"`
+	ScopeType        *string    `json:"scopeType,omitempty" jsonschema:"The type of resource the role is scoped to, such as ENVIRONMENT or ORGANIZATION"`
+	ScopeId          *string    `json:"scopeId,omitempty" jsonschema:"The ID of the resource the role is scoped to"`
+	LastSignOnAt     *time.Time `json:"lastSignOnAt,omitempty" jsonschema:"When the user last signed on"`
+	Reason           string     `json:"reason" jsonschema:"Why the item is up for review"`
+	Revocable        bool       `json:"revocable" jsonschema:"False if the access cannot be revoked, for example a read-only role assignment"`
+	Revocation       string     `json:"revocation" jsonschema:"The change made if the item is revoked"`
+}
+
+// ReviewDecision is a reviewer's decision on a review item
+type ReviewDecision struct {
+	Decision  string    `json:"decision" jsonschema:"KEEP or REVOKE"`
+	Reviewer  string    `json:"reviewer" jsonschema:"Who made the decision"`
+	Comment   *string   `json:"comment,omitempty" jsonschema:"The reviewer's comment"`
+	DecidedAt time.Time `json:"decidedAt" jsonschema:"When the decision was recorded"`
+	Applied   bool      `json:"applied" jsonschema:"True once a REVOKE decision has been applied in PingOne"`
+}
+
+// CampaignProgress summarises the decisions recorded for a campaign
+type CampaignProgress struct {
+	Total   int `json:"total" jsonschema:"The number of items in the campaign"`
+	Kept    int `json:"kept" jsonschema:"The number of items decided KEEP"`
+	Revoked int `json:"revoked" jsonschema:"The number of items decided REVOKE"`
+	Applied int `json:"applied" jsonschema:"The number of REVOKE decisions applied in PingOne"`
+	Pending int `json:"pending" jsonschema:"The number of items without a decision"`
+}
+
+// campaign is an access review of one environment
+type campaign struct {
+	id            uuid.UUID
+	environmentId uuid.UUID
+	createdAt     time.Time
+	items         []ReviewItem
+	decisions     map[string]*ReviewDecision
+}
+
+// CampaignStore holds access review campaigns in memory, so campaigns are lost when the server restarts
+type CampaignStore struct {
+	mu        sync.Mutex
+	campaigns map[uuid.UUID]*campaign
+	order     []uuid.UUID
+	now       func() time.Time
+}
+
+func NewCampaignStore() *CampaignStore {
+	return &CampaignStore{
+		campaigns: map[uuid.UUID]*campaign{},
+		now:       time.Now,
+	}
+}
+
+// Create stores a new campaign for the items and returns its ID
+func (s *CampaignStore) Create(environmentId uuid.UUID, items []ReviewItem) uuid.UUID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.order) >= maxCampaigns {
+		delete(s.campaigns, s.order[0])
+		s.order = s.order[1:]
+	}
+
+	id := uuid.New()
+	s.campaigns[id] = &campaign{
+		id:            id,
+		environmentId: environmentId,
+		createdAt:     s.now(),
+		items:         slices.Clone(items),
+		decisions:     map[string]*ReviewDecision{},
+	}
+	s.order = append(s.order, id)
+	return id
+}
+
+// EnvironmentId returns the environment a campaign reviews
+func (s *CampaignStore) EnvironmentId(campaignId uuid.UUID) (uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.get(campaignId)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return c.environmentId, nil
+}
+
+// RecordDecisions records reviewer decisions, replacing any earlier decision on the same item.
+// Decisions are validated before any is recorded, and an applied revocation cannot be changed.
+func (s *CampaignStore) RecordDecisions(campaignId uuid.UUID, reviewer string, decisions []ItemDecision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.get(campaignId)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range decisions {
+		if d.Decision != DecisionKeep && d.Decision != DecisionRevoke {
+			return fmt.Errorf("decision for item %s must be %s or %s", d.ItemId, DecisionKeep, DecisionRevoke)
+		}
+		item := c.item(d.ItemId)
+		if item == nil {
+			return fmt.Errorf("item %s is not part of campaign %s", d.ItemId, campaignId)
+		}
+		if d.Decision == DecisionRevoke && !item.Revocable {
+			return fmt.Errorf("item %s cannot be revoked", d.ItemId)
+		}
+		if existing := c.decisions[d.ItemId]; existing != nil && existing.Applied {
+			return fmt.Errorf("item %s has already been revoked", d.ItemId)
+		}
+	}
+
+	decidedAt := s.now()
+	for _, d := range decisions {
+		c.decisions[d.ItemId] = &ReviewDecision{
+			Decision:  d.Decision,
+			Reviewer:  reviewer,
+			Comment:   d.Comment,
+			DecidedAt: decidedAt,
+		}
+	}
+	return nil
+}
+
+// ApprovedRevocations returns the items decided REVOKE that have not yet been applied, and the number already applied
+func (s *CampaignStore) ApprovedRevocations(campaignId uuid.UUID) ([]ReviewItem, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.get(campaignId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var revocations []ReviewItem
+	applied := 0
+	for _, item := range c.items {
+		decision := c.decisions[item.ItemId]
+		if decision == nil || decision.Decision != DecisionRevoke {
+			continue
+		}
+		if decision.Applied {
+			applied++
+			continue
+		}
+		revocations = append(revocations, item)
+	}
+	return revocations, applied, nil
+}
+
+// MarkApplied records that a REVOKE decision has been applied in PingOne
+func (s *CampaignStore) MarkApplied(campaignId uuid.UUID, itemId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.get(campaignId)
+	if err != nil {
+		return err
+	}
+	decision := c.decisions[itemId]
+	if decision == nil || decision.Decision != DecisionRevoke {
+		return fmt.Errorf("item %s has no REVOKE decision", itemId)
+	}
+	decision.Applied = true
+	return nil
+}
+
+// Progress summarises the decisions recorded for a campaign, and returns the IDs of items still pending
+func (s *CampaignStore) Progress(campaignId uuid.UUID) (CampaignProgress, []string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.get(campaignId)
+	if err != nil {
+		return CampaignProgress{}, nil, err
+	}
+
+	progress := CampaignProgress{Total: len(c.items)}
+	pending := []string{}
+	for _, item := range c.items {
+		decision := c.decisions[item.ItemId]
+		switch {
+		case decision == nil:
+			progress.Pending++
+			pending = append(pending, item.ItemId)
+		case decision.Decision == DecisionKeep:
+			progress.Kept++
+		default:
+			progress.Revoked++
+			if decision.Applied {
+				progress.Applied++
+			}
+		}
+	}
+	return progress, pending, nil
+}
+
+func (s *CampaignStore) get(campaignId uuid.UUID) (*campaign, error) {
+	c, ok := s.campaigns[campaignId]
+	if !ok {
+		return nil, fmt.Errorf("access review campaign %s not found, campaigns are not kept after the server restarts", campaignId)
+	}
+	return c, nil
+}
+
+func (c *campaign) item(itemId string) *ReviewItem {
+	for i := range c.items {
+		if c.items[i].ItemId == itemId {
+			return &c.items[i]
+		}
+	}
+	return nil
+}
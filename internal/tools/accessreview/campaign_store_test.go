@@ -0,0 +1,131 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCampaignStore_RecordDecisions(t *testing.T) {
+	tests := []struct {
+		name            string
+		decisions       []accessreview.ItemDecision
+		wantErrContains string
+		wantProgress    accessreview.CampaignProgress
+		wantPending     []string
+	}{
+		{
+			name: "Records keep and revoke decisions",
+			decisions: []accessreview.ItemDecision{
+				{ItemId: "user-role:ra-user", Decision: accessreview.DecisionKeep},
+				{ItemId: "stale:user-stale", Decision: accessreview.DecisionRevoke, Comment: testutils.Pointer("Left the company")},
+			},
+			wantProgress: accessreview.CampaignProgress{Total: 4, Kept: 1, Revoked: 1, Pending: 2},
+			wantPending:  []string{"user-role:ra-readonly", "group-role:ra-group"},
+		},
+		{
+			name: "Later decision replaces an earlier one",
+			decisions: []accessreview.ItemDecision{
+				{ItemId: "stale:user-stale", Decision: accessreview.DecisionRevoke},
+				{ItemId: "stale:user-stale", Decision: accessreview.DecisionKeep},
+			},
+			wantProgress: accessreview.CampaignProgress{Total: 4, Kept: 1, Pending: 3},
+			wantPending:  []string{"user-role:ra-user", "user-role:ra-readonly", "group-role:ra-group"},
+		},
+		{
+			name:            "Unknown decision",
+			decisions:       []accessreview.ItemDecision{{ItemId: "stale:user-stale", Decision: "MAYBE"}},
+			wantErrContains: "must be KEEP or REVOKE",
+		},
+		{
+			name:            "Unknown item",
+			decisions:       []accessreview.ItemDecision{{ItemId: "stale:unknown", Decision: accessreview.DecisionKeep}},
+			wantErrContains: "is not part of campaign",
+		},
+		{
+			name: "Read-only assignment cannot be revoked, and no decision is recorded",
+			decisions: []accessreview.ItemDecision{
+				{ItemId: "stale:user-stale", Decision: accessreview.DecisionRevoke},
+				{ItemId: "user-role:ra-readonly", Decision: accessreview.DecisionRevoke},
+			},
+			wantErrContains: "cannot be revoked",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			store := accessreview.NewCampaignStore()
+			campaignId := store.Create(testEnvironmentId, testReviewItems())
+
+			err := store.RecordDecisions(campaignId, "reviewer@example.com", tc.decisions)
+			progress, pending, progressErr := store.Progress(campaignId)
+			require.NoError(t, progressErr)
+
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrContains)
+				assert.Equal(t, 4, progress.Pending, "No decision should be recorded when any is invalid")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantProgress, progress)
+			assert.Equal(t, tc.wantPending, pending)
+		})
+	}
+}
+
+func TestCampaignStore_AppliedRevocationCannotChange(t *testing.T) {
+	store := accessreview.NewCampaignStore()
+	campaignId := store.Create(testEnvironmentId, testReviewItems())
+	require.NoError(t, store.RecordDecisions(campaignId, "reviewer@example.com", []accessreview.ItemDecision{
+		{ItemId: "stale:user-stale", Decision: accessreview.DecisionRevoke},
+	}))
+
+	revocations, applied, err := store.ApprovedRevocations(campaignId)
+	require.NoError(t, err)
+	require.Len(t, revocations, 1)
+	assert.Equal(t, 0, applied)
+
+	require.NoError(t, store.MarkApplied(campaignId, "stale:user-stale"))
+
+	revocations, applied, err = store.ApprovedRevocations(campaignId)
+	require.NoError(t, err)
+	assert.Empty(t, revocations)
+	assert.Equal(t, 1, applied)
+
+	err = store.RecordDecisions(campaignId, "reviewer@example.com", []accessreview.ItemDecision{
+		{ItemId: "stale:user-stale", Decision: accessreview.DecisionKeep},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already been revoked")
+}
+
+func TestCampaignStore_UnknownCampaign(t *testing.T) {
+	store := accessreview.NewCampaignStore()
+
+	_, err := store.EnvironmentId(uuid.New())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestCampaignStore_EvictsOldestCampaign(t *testing.T) {
+	store := accessreview.NewCampaignStore()
+	first := store.Create(testEnvironmentId, testReviewItems())
+	var last uuid.UUID
+	for range 20 {
+		last = store.Create(testEnvironmentId, testReviewItems())
+	}
+
+	_, err := store.EnvironmentId(first)
+	assert.Error(t, err, "Oldest campaign should be evicted")
+	_, err = store.EnvironmentId(last)
+	assert.NoError(t, err)
+}
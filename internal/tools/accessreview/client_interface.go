@@ -0,0 +1,27 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type AccessReviewClient interface {
+	GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetPopulationUsers(ctx context.Context, environmentId uuid.UUID, populationId string) (management.EntityArrayPagedIterator, error)
+	GetUserRoleAssignments(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error)
+	GetGroups(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetGroupRoleAssignments(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error)
+	GetRoles(ctx context.Context) (management.EntityArrayPagedIterator, error)
+	DeleteUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*http.Response, error)
+	DeleteGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*http.Response, error)
+	DisableUser(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error)
+}
+
+type AccessReviewClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (AccessReviewClient, error)
+}
@@ -0,0 +1,176 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ AccessReviewClient = &PingOneClientAccessReviewWrapper{}
+var _ AccessReviewClientFactory = &PingOneClientAccessReviewWrapperFactory{}
+
+type PingOneClientAccessReviewWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientAccessReviewWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientAccessReviewWrapper(client *pingone.Client) *PingOneClientAccessReviewWrapper {
+	return &PingOneClientAccessReviewWrapper{client: client}
+}
+
+func NewPingOneClientAccessReviewWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientAccessReviewWrapperFactory {
+	return &PingOneClientAccessReviewWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientAccessReviewWrapperFactory) GetAuthenticatedClient(ctx context.Context) (AccessReviewClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientAccessReviewWrapper(client), nil
+}
+
+func (p *PingOneClientAccessReviewWrapper) GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.PopulationsApi.ReadAllPopulations(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve populations",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAccessReviewWrapper) GetPopulationUsers(ctx context.Context, environmentId uuid.UUID, populationId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UsersApi.ReadAllUsers(ctx, environmentId.String()).Filter(fmt.Sprintf(`population.id eq "%s"`, populationId))
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve users of population",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("populationId", populationId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAccessReviewWrapper) GetUserRoleAssignments(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UserRoleAssignmentsApi.ReadUserRoleAssignments(ctx, environmentId.String(), userId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve user role assignments",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAccessReviewWrapper) GetGroups(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupsApi.ReadAllGroups(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve groups",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAccessReviewWrapper) GetGroupRoleAssignments(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupRoleAssignmentsApi.ReadGroupRoleAssignments(ctx, environmentId.String(), groupId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve group role assignments",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAccessReviewWrapper) GetRoles(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.RolesApi.ReadAllRoles(ctx)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve roles")
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAccessReviewWrapper) DeleteUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.UserRoleAssignmentsApi.DeleteUserRoleAssignment(ctx, environmentId.String(), userId, roleAssignmentId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete user role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return deleteRequest.Execute()
+}
+
+func (p *PingOneClientAccessReviewWrapper) DeleteGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.GroupRoleAssignmentsApi.DeleteGroupRoleAssignment(ctx, environmentId.String(), groupId, roleAssignmentId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete group role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return deleteRequest.Execute()
+}
+
+func (p *PingOneClientAccessReviewWrapper) DisableUser(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	enabled := false
+	updateRequest := p.client.ManagementAPIClient.EnableUsersApi.UpdateUserEnabled(ctx, environmentId.String(), userId).UserEnabled(management.UserEnabled{Enabled: &enabled})
+	updateRequest = updateRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	updateRequest = updateRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to disable user",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	_, httpResponse, err := updateRequest.Execute()
+	return httpResponse, err
+}
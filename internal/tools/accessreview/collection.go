@@ -0,0 +1,70 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "access_review"
+
+var _ collections.LegacySdkCollection = &AccessReviewCollection{}
+
+// AccessReviewCollection registers the access review tools. The campaign store is created once by the server, so
+// that campaigns are kept when the tools are registered again.
+type AccessReviewCollection struct {
+	Campaigns *CampaignStore
+}
+
+func (c *AccessReviewCollection) Name() string {
+	return CollectionName
+}
+
+func (c *AccessReviewCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+	if c.Campaigns == nil {
+		return fmt.Errorf("campaign store is nil")
+	}
+
+	accessReviewClientFactory := NewPingOneClientAccessReviewWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&GenerateAccessReviewPacketDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GenerateAccessReviewPacketDef.McpTool.Name))
+		mcp.AddTool(server, GenerateAccessReviewPacketDef.McpTool, GenerateAccessReviewPacketHandler(accessReviewClientFactory, c.Campaigns))
+	}
+
+	if toolFilter.ShouldIncludeTool(&RecordAccessReviewDecisionsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", RecordAccessReviewDecisionsDef.McpTool.Name))
+		mcp.AddTool(server, RecordAccessReviewDecisionsDef.McpTool, RecordAccessReviewDecisionsHandler(c.Campaigns))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ApplyAccessReviewRevocationsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ApplyAccessReviewRevocationsDef.McpTool.Name))
+		mcp.AddTool(server, ApplyAccessReviewRevocationsDef.McpTool, ApplyAccessReviewRevocationsHandler(accessReviewClientFactory, c.Campaigns))
+	}
+
+	return nil
+}
+
+func (c *AccessReviewCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		GenerateAccessReviewPacketDef,
+		RecordAccessReviewDecisionsDef,
+		ApplyAccessReviewRevocationsDef,
+	}
+}
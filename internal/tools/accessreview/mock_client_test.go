@@ -0,0 +1,98 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ accessreview.AccessReviewClient = &mockPingOneClientAccessReviewWrapper{}
+var _ accessreview.AccessReviewClientFactory = &mockPingOneClientAccessReviewWrapperFactory{}
+
+type mockPingOneClientAccessReviewWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientAccessReviewWrapperFactory struct {
+	mockClient accessreview.AccessReviewClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientAccessReviewWrapperFactory(mockClient accessreview.AccessReviewClient, err error) *mockPingOneClientAccessReviewWrapperFactory {
+	return &mockPingOneClientAccessReviewWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientAccessReviewWrapperFactory) GetAuthenticatedClient(ctx context.Context) (accessreview.AccessReviewClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return iteratorResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) GetPopulationUsers(ctx context.Context, environmentId uuid.UUID, populationId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, populationId)
+	return iteratorResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) GetUserRoleAssignments(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, userId)
+	return iteratorResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) GetGroups(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return iteratorResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) GetGroupRoleAssignments(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, groupId)
+	return iteratorResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) GetRoles(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx)
+	return iteratorResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) DeleteUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, roleAssignmentId)
+	return httpResponseResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) DeleteGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId, roleAssignmentId)
+	return httpResponseResult(args)
+}
+
+func (p *mockPingOneClientAccessReviewWrapper) DisableUser(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, userId)
+	return httpResponseResult(args)
+}
+
+func iteratorResult(args mock.Arguments) (management.EntityArrayPagedIterator, error) {
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func httpResponseResult(args mock.Arguments) (*http.Response, error) {
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
@@ -0,0 +1,154 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testEnvironmentId      = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testOtherEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+)
+
+var (
+	testRecentSignOn = time.Now().AddDate(0, 0, -5)
+	testOldSignOn    = time.Now().AddDate(0, 0, -200)
+
+	testAdminUser = management.User{
+		Id:         testutils.Pointer("user-admin"),
+		Username:   "admin",
+		Enabled:    testutils.Pointer(true),
+		LastSignOn: &management.UserLastSignOn{At: &testRecentSignOn},
+	}
+	testStaleUser = management.User{
+		Id:         testutils.Pointer("user-stale"),
+		Username:   "stale",
+		Enabled:    testutils.Pointer(true),
+		LastSignOn: &management.UserLastSignOn{At: &testOldSignOn},
+	}
+	testDisabledStaleUser = management.User{
+		Id:         testutils.Pointer("user-disabled"),
+		Username:   "disabled",
+		Enabled:    testutils.Pointer(false),
+		LastSignOn: &management.UserLastSignOn{At: &testOldSignOn},
+	}
+	testNeverSignedOnUser = management.User{
+		Id:        testutils.Pointer("user-never"),
+		Username:  "never",
+		Enabled:   testutils.Pointer(true),
+		CreatedAt: &testOldSignOn,
+	}
+
+	testGroup = management.Group{
+		Id:   testutils.Pointer("group-1"),
+		Name: "Admins",
+	}
+
+	testEnvironmentAdminRole = management.EntityArrayEmbeddedRolesInner{
+		Role: &management.Role{
+			Id:   testutils.Pointer("role-env-admin"),
+			Name: testutils.Pointer(management.ENUMROLENAME_ENVIRONMENT_ADMIN),
+		},
+	}
+	testCustomRole = management.EntityArrayEmbeddedRolesInner{
+		CustomAdminRole: &management.CustomAdminRole{
+			Id:   testutils.Pointer("role-custom"),
+			Name: "Helpdesk",
+		},
+	}
+
+	testUserRoleAssignment = management.RoleAssignment{
+		Id:    testutils.Pointer("ra-user"),
+		Role:  management.RoleAssignmentRole{Id: "role-env-admin"},
+		Scope: management.RoleAssignmentScope{Id: testEnvironmentId.String(), Type: management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT},
+	}
+	testReadOnlyRoleAssignment = management.RoleAssignment{
+		Id:       testutils.Pointer("ra-readonly"),
+		ReadOnly: testutils.Pointer(true),
+		Role:     management.RoleAssignmentRole{Id: "role-custom"},
+		Scope:    management.RoleAssignmentScope{Id: testEnvironmentId.String(), Type: management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT},
+	}
+	testGroupRoleAssignment = management.RoleAssignment{
+		Id:    testutils.Pointer("ra-group"),
+		Role:  management.RoleAssignmentRole{Id: "role-custom"},
+		Scope: management.RoleAssignmentScope{Id: testEnvironmentId.String(), Type: management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT},
+	}
+)
+
+// mockIterator returns an iterator over a single page with the given contents
+func mockIterator(embedded management.EntityArrayEmbedded) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &embedded},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+// setupEnvironmentMock mocks an environment with an administrator, a stale user, a disabled stale user,
+// a user that never signed on and an administrator group
+func setupEnvironmentMock(m *mockPingOneClientAccessReviewWrapper) {
+	m.On("GetRoles", mock.Anything).Return(mockIterator(management.EntityArrayEmbedded{
+		Roles: []management.EntityArrayEmbeddedRolesInner{testEnvironmentAdminRole, testCustomRole},
+	}), nil)
+	m.On("GetPopulations", mock.Anything, testEnvironmentId).Return(mockIterator(management.EntityArrayEmbedded{
+		Populations: []management.Population{{Id: testutils.Pointer("population-1"), Name: "Default"}},
+	}), nil)
+	m.On("GetPopulationUsers", mock.Anything, testEnvironmentId, "population-1").Return(mockIterator(management.EntityArrayEmbedded{
+		Users: []management.User{testAdminUser, testStaleUser, testDisabledStaleUser, testNeverSignedOnUser},
+	}), nil)
+	m.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, "user-admin").Return(mockIterator(management.EntityArrayEmbedded{
+		RoleAssignments: []management.RoleAssignment{testUserRoleAssignment, testReadOnlyRoleAssignment},
+	}), nil)
+	for _, userId := range []string{"user-stale", "user-disabled", "user-never"} {
+		m.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, userId).Return(mockIterator(management.EntityArrayEmbedded{
+			RoleAssignments: []management.RoleAssignment{},
+		}), nil)
+	}
+	m.On("GetGroups", mock.Anything, testEnvironmentId).Return(mockIterator(management.EntityArrayEmbedded{
+		Groups: []management.Group{testGroup},
+	}), nil)
+	m.On("GetGroupRoleAssignments", mock.Anything, testEnvironmentId, "group-1").Return(mockIterator(management.EntityArrayEmbedded{
+		RoleAssignments: []management.RoleAssignment{testGroupRoleAssignment},
+	}), nil)
+}
+
+// testReviewItems are the items of a campaign over the mocked environment
+func testReviewItems() []accessreview.ReviewItem {
+	return []accessreview.ReviewItem{
+		{
+			ItemId:           "user-role:ra-user",
+			Kind:             accessreview.ItemKindUserRoleAssignment,
+			UserId:           testutils.Pointer("user-admin"),
+			RoleAssignmentId: testutils.Pointer("ra-user"),
+			Revocable:        true,
+		},
+		{
+			ItemId:           "user-role:ra-readonly",
+			Kind:             accessreview.ItemKindUserRoleAssignment,
+			UserId:           testutils.Pointer("user-admin"),
+			RoleAssignmentId: testutils.Pointer("ra-readonly"),
+		},
+		{
+			ItemId:           "group-role:ra-group",
+			Kind:             accessreview.ItemKindGroupRoleAssignment,
+			GroupId:          testutils.Pointer("group-1"),
+			RoleAssignmentId: testutils.Pointer("ra-group"),
+			Revocable:        true,
+		},
+		{
+			ItemId:    "stale:user-stale",
+			Kind:      accessreview.ItemKindStaleAccount,
+			UserId:    testutils.Pointer("user-stale"),
+			Revocable: true,
+		},
+	}
+}
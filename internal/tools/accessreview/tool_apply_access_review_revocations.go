@@ -0,0 +1,178 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	RevocationStatusApplied = "APPLIED"
+	RevocationStatusFailed  = "FAILED"
+)
+
+var ApplyAccessReviewRevocationsDef = types.ToolDefinition{
+	// Concurrent calls for the same campaign could apply the same revocations twice
+	MaxConcurrentExecutions: 1,
+	RequiresConfirmation:    true,
+	McpTool: &mcp.Tool{
+		Name:  "apply_access_review_revocations",
+		Title: "Apply Access Review Revocations",
+		Description: `Apply the REVOKE decisions recorded for an access review campaign in PingOne: delete the revoked user and group role assignments, and disable the revoked stale users. Items decided KEEP and items without a decision are left unchanged.
+
+Each revocation is applied independently; the output reports the result per item, so a failure does not stop the remaining revocations. Revocations already applied are not applied again, so the tool can be called again to retry failures.`,
+		InputSchema:  schema.MustGenerateSchema[ApplyAccessReviewRevocationsInput](),
+		OutputSchema: schema.MustGenerateSchema[ApplyAccessReviewRevocationsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type ApplyAccessReviewRevocationsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID. Must be the environment reviewed by the campaign."`
+	CampaignId    uuid.UUID `json:"campaignId" jsonschema:"REQUIRED. The ID of the campaign returned by generate_access_review_packet."`
+}
+
+type RevocationResult struct {
+	ItemId     string  `json:"itemId" jsonschema:"The ID of the revoked item"`
+	Kind       string  `json:"kind" jsonschema:"The kind of the revoked item"`
+	Revocation string  `json:"revocation" jsonschema:"The change made in PingOne"`
+	Status     string  `json:"status" jsonschema:"APPLIED or FAILED"`
+	Error      *string `json:"error,omitempty" jsonschema:"The reason the revocation failed"`
+}
+
+type ApplyAccessReviewRevocationsOutput struct {
+	CampaignId        uuid.UUID          `json:"campaignId" jsonschema:"The ID of the campaign"`
+	Results           []RevocationResult `json:"results" jsonschema:"The result of each revocation applied by this call"`
+	AppliedCount      int                `json:"appliedCount" jsonschema:"The number of revocations applied by this call"`
+	FailedCount       int                `json:"failedCount" jsonschema:"The number of revocations that failed"`
+	PreviouslyApplied int                `json:"previouslyApplied" jsonschema:"The number of revocations applied by earlier calls"`
+	PendingDecisions  int                `json:"pendingDecisions" jsonschema:"The number of items still waiting for a decision"`
+}
+
+// ApplyAccessReviewRevocationsHandler applies the REVOKE decisions of a campaign using the provided client
+func ApplyAccessReviewRevocationsHandler(accessReviewClientFactory AccessReviewClientFactory, campaigns *CampaignStore) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ApplyAccessReviewRevocationsInput,
+) (
+	*mcp.CallToolResult,
+	*ApplyAccessReviewRevocationsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ApplyAccessReviewRevocationsInput) (*mcp.CallToolResult, *ApplyAccessReviewRevocationsOutput, error) {
+		environmentId, err := campaigns.EnvironmentId(input.CampaignId)
+		if err != nil {
+			toolErr := errs.NewToolError(ApplyAccessReviewRevocationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		// The environment is part of the input so that production environment guardrails apply to it
+		if environmentId != input.EnvironmentId {
+			toolErr := errs.NewToolError(ApplyAccessReviewRevocationsDef.McpTool.Name, fmt.Errorf("campaign %s reviews environment %s, not %s", input.CampaignId, environmentId, input.EnvironmentId))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		revocations, previouslyApplied, err := campaigns.ApprovedRevocations(input.CampaignId)
+		if err != nil {
+			toolErr := errs.NewToolError(ApplyAccessReviewRevocationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		result := ApplyAccessReviewRevocationsOutput{
+			CampaignId:        input.CampaignId,
+			Results:           []RevocationResult{},
+			PreviouslyApplied: previouslyApplied,
+		}
+
+		if len(revocations) > 0 {
+			client, err := accessReviewClientFactory.GetAuthenticatedClient(ctx)
+			if err != nil {
+				toolErr := errs.NewToolError(ApplyAccessReviewRevocationsDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+
+			for i, item := range revocations {
+				progress.Report(ctx, i, len(revocations), "Applying revocations")
+
+				revocationResult := RevocationResult{
+					ItemId:     item.ItemId,
+					Kind:       item.Kind,
+					Revocation: item.Revocation,
+				}
+
+				httpResponse, err := revoke(ctx, client, input.EnvironmentId, item)
+				logger.LogHttpResponse(ctx, httpResponse)
+				if err == nil {
+					err = campaigns.MarkApplied(input.CampaignId, item.ItemId)
+				}
+				if err != nil {
+					logger.FromContext(ctx).Debug("Failed to apply access review revocation",
+						slog.String("itemId", item.ItemId),
+						slog.String("error", err.Error()))
+					errMsg := err.Error()
+					revocationResult.Status = RevocationStatusFailed
+					revocationResult.Error = &errMsg
+					result.FailedCount++
+				} else {
+					revocationResult.Status = RevocationStatusApplied
+					result.AppliedCount++
+				}
+				result.Results = append(result.Results, revocationResult)
+			}
+		}
+
+		progress, _, err := campaigns.Progress(input.CampaignId)
+		if err != nil {
+			toolErr := errs.NewToolError(ApplyAccessReviewRevocationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result.PendingDecisions = progress.Pending
+
+		logger.FromContext(ctx).Debug("Applied access review revocations",
+			slog.String("campaignId", input.CampaignId.String()),
+			slog.Int("applied", result.AppliedCount),
+			slog.Int("failed", result.FailedCount))
+
+		return nil, &result, nil
+	}
+}
+
+// revoke makes the change in PingOne described by the review item
+func revoke(ctx context.Context, client AccessReviewClient, environmentId uuid.UUID, item ReviewItem) (*http.Response, error) {
+	switch item.Kind {
+	case ItemKindUserRoleAssignment:
+		if item.UserId == nil || item.RoleAssignmentId == nil {
+			return nil, errors.New("item is missing the user or role assignment ID")
+		}
+		return client.DeleteUserRoleAssignment(ctx, environmentId, *item.UserId, *item.RoleAssignmentId)
+	case ItemKindGroupRoleAssignment:
+		if item.GroupId == nil || item.RoleAssignmentId == nil {
+			return nil, errors.New("item is missing the group or role assignment ID")
+		}
+		return client.DeleteGroupRoleAssignment(ctx, environmentId, *item.GroupId, *item.RoleAssignmentId)
+	case ItemKindStaleAccount:
+		if item.UserId == nil {
+			return nil, errors.New("item is missing the user ID")
+		}
+		return client.DisableUser(ctx, environmentId, *item.UserId)
+	}
+	return nil, fmt.Errorf("unsupported item kind %s", item.Kind)
+}
@@ -0,0 +1,136 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCampaign stores a campaign over the mocked environment with every revocable item decided REVOKE
+func setupCampaign(t *testing.T) (*accessreview.CampaignStore, uuid.UUID) {
+	t.Helper()
+	campaigns := accessreview.NewCampaignStore()
+	campaignId := campaigns.Create(testEnvironmentId, testReviewItems())
+	require.NoError(t, campaigns.RecordDecisions(campaignId, "reviewer@example.com", []accessreview.ItemDecision{
+		{ItemId: "user-role:ra-user", Decision: accessreview.DecisionRevoke},
+		{ItemId: "group-role:ra-group", Decision: accessreview.DecisionRevoke},
+		{ItemId: "stale:user-stale", Decision: accessreview.DecisionRevoke},
+	}))
+	return campaigns, campaignId
+}
+
+func TestApplyAccessReviewRevocationsHandler_MockClient(t *testing.T) {
+	campaigns, campaignId := setupCampaign(t)
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	mockClient.On("DeleteUserRoleAssignment", mock.Anything, testEnvironmentId, "user-admin", "ra-user").Return(&http.Response{StatusCode: 204}, nil).Once()
+	mockClient.On("DeleteGroupRoleAssignment", mock.Anything, testEnvironmentId, "group-1", "ra-group").Return(&http.Response{StatusCode: 204}, nil).Once()
+	mockClient.On("DisableUser", mock.Anything, testEnvironmentId, "user-stale").Return(&http.Response{StatusCode: 200}, nil).Once()
+	handler := accessreview.ApplyAccessReviewRevocationsHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), campaigns)
+	input := accessreview.ApplyAccessReviewRevocationsInput{EnvironmentId: testEnvironmentId, CampaignId: campaignId}
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	require.NoError(t, err)
+	assert.Nil(t, mcpResult)
+	assert.Equal(t, 3, output.AppliedCount)
+	assert.Equal(t, 0, output.FailedCount)
+	assert.Equal(t, 1, output.PendingDecisions)
+	for _, result := range output.Results {
+		assert.Equal(t, accessreview.RevocationStatusApplied, result.Status, result.ItemId)
+	}
+
+	// Applying again does not repeat the revocations
+	_, output, err = handler(context.Background(), &mcp.CallToolRequest{}, input)
+	require.NoError(t, err)
+	assert.Empty(t, output.Results)
+	assert.Equal(t, 3, output.PreviouslyApplied)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestApplyAccessReviewRevocationsHandler_FailuresAreRetried(t *testing.T) {
+	campaigns, campaignId := setupCampaign(t)
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	mockClient.On("DeleteUserRoleAssignment", mock.Anything, testEnvironmentId, "user-admin", "ra-user").Return(&http.Response{StatusCode: 500}, errors.New("internal server error")).Once()
+	mockClient.On("DeleteGroupRoleAssignment", mock.Anything, testEnvironmentId, "group-1", "ra-group").Return(&http.Response{StatusCode: 204}, nil).Once()
+	mockClient.On("DisableUser", mock.Anything, testEnvironmentId, "user-stale").Return(&http.Response{StatusCode: 200}, nil).Once()
+	handler := accessreview.ApplyAccessReviewRevocationsHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), campaigns)
+	input := accessreview.ApplyAccessReviewRevocationsInput{EnvironmentId: testEnvironmentId, CampaignId: campaignId}
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, output.AppliedCount)
+	assert.Equal(t, 1, output.FailedCount)
+	assert.Equal(t, accessreview.RevocationResult{
+		ItemId: "user-role:ra-user",
+		Kind:   accessreview.ItemKindUserRoleAssignment,
+		Status: accessreview.RevocationStatusFailed,
+		Error:  testutils.Pointer("internal server error"),
+	}, output.Results[0])
+
+	// Only the failed revocation is retried
+	mockClient.On("DeleteUserRoleAssignment", mock.Anything, testEnvironmentId, "user-admin", "ra-user").Return(&http.Response{StatusCode: 204}, nil).Once()
+	_, output, err = handler(context.Background(), &mcp.CallToolRequest{}, input)
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.AppliedCount)
+	assert.Equal(t, 2, output.PreviouslyApplied)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestApplyAccessReviewRevocationsHandler_EnvironmentMismatch(t *testing.T) {
+	campaigns, campaignId := setupCampaign(t)
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	handler := accessreview.ApplyAccessReviewRevocationsHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), campaigns)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.ApplyAccessReviewRevocationsInput{
+		EnvironmentId: testOtherEnvironmentId,
+		CampaignId:    campaignId,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reviews environment")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, output)
+	mockClient.AssertNotCalled(t, "DeleteUserRoleAssignment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApplyAccessReviewRevocationsHandler_UnknownCampaign(t *testing.T) {
+	handler := accessreview.ApplyAccessReviewRevocationsHandler(NewMockPingOneClientAccessReviewWrapperFactory(&mockPingOneClientAccessReviewWrapper{}, nil), accessreview.NewCampaignStore())
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.ApplyAccessReviewRevocationsInput{
+		EnvironmentId: testEnvironmentId,
+		CampaignId:    uuid.New(),
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	assert.Nil(t, output)
+}
+
+func TestApplyAccessReviewRevocationsHandler_GetAuthenticatedClientError(t *testing.T) {
+	campaigns, campaignId := setupCampaign(t)
+	handler := accessreview.ApplyAccessReviewRevocationsHandler(NewMockPingOneClientAccessReviewWrapperFactory(nil, errors.New("failed to get authenticated client")), campaigns)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.ApplyAccessReviewRevocationsInput{
+		EnvironmentId: testEnvironmentId,
+		CampaignId:    campaignId,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get authenticated client")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, output)
+}
@@ -0,0 +1,345 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	defaultStaleAfterDays = 90
+	// maxReviewUsers bounds the users reviewed, as each user's role assignments are read with a separate API call
+	maxReviewUsers = 1000
+)
+
+var GenerateAccessReviewPacketDef = types.ToolDefinition{
+	// Each call creates a new campaign
+	DisableResponseCache: true,
+	McpTool: &mcp.Tool{
+		Name:  "generate_access_review_packet",
+		Title: "Generate PingOne Access Review Packet",
+		Description: `Start an access review campaign for an environment and return the review packet: every administrator role assigned to a user, every administrator role assigned to a group (all members of the group hold the role), and every enabled user that has not signed on within 'staleAfterDays'.
+
+Each item has an 'itemId' and describes the change made if it is revoked: the role assignment is deleted, or the stale user is disabled. Share the packet with reviewers, record their decisions with 'record_access_review_decisions' using the returned 'campaignId', then apply approved revocations with 'apply_access_review_revocations'.
+
+Campaigns are held in memory by the server and are lost when it restarts. Up to 1000 users are reviewed; 'truncated' is true if the environment has more.`,
+		InputSchema:  schema.MustGenerateSchema[GenerateAccessReviewPacketInput](),
+		OutputSchema: schema.MustGenerateSchema[GenerateAccessReviewPacketOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GenerateAccessReviewPacketInput struct {
+	EnvironmentId  uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	StaleAfterDays *int      `json:"staleAfterDays,omitempty" jsonschema:"OPTIONAL. The number of days without a sign-on after which an enabled user is stale. Defaults to 90."`
+}
+
+type AccessReviewSummary struct {
+	Administrators       int `json:"administrators" jsonschema:"The number of users directly assigned at least one role"`
+	UserRoleAssignments  int `json:"userRoleAssignments" jsonschema:"The number of roles assigned to users"`
+	GroupRoleAssignments int `json:"groupRoleAssignments" jsonschema:"The number of roles assigned to groups"`
+	StaleAccounts        int `json:"staleAccounts" jsonschema:"The number of enabled users that have not signed on recently"`
+}
+
+type GenerateAccessReviewPacketOutput struct {
+	CampaignId    uuid.UUID           `json:"campaignId" jsonschema:"The ID of the campaign, used to record decisions and apply revocations"`
+	EnvironmentId uuid.UUID           `json:"environmentId" jsonschema:"The reviewed environment"`
+	GeneratedAt   time.Time           `json:"generatedAt" jsonschema:"When the packet was generated"`
+	StaleBefore   time.Time           `json:"staleBefore" jsonschema:"Enabled users that have not signed on since this time are stale"`
+	Items         []ReviewItem        `json:"items" jsonschema:"The accesses to review"`
+	Summary       AccessReviewSummary `json:"summary" jsonschema:"Counts of the reviewed accesses"`
+	Truncated     bool                `json:"truncated" jsonschema:"True if the environment has more users than could be reviewed"`
+}
+
+// GenerateAccessReviewPacketHandler collects the administrator access in an environment using the provided client,
+// and stores it as a new campaign
+func GenerateAccessReviewPacketHandler(accessReviewClientFactory AccessReviewClientFactory, campaigns *CampaignStore) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GenerateAccessReviewPacketInput,
+) (
+	*mcp.CallToolResult,
+	*GenerateAccessReviewPacketOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GenerateAccessReviewPacketInput) (*mcp.CallToolResult, *GenerateAccessReviewPacketOutput, error) {
+		staleAfterDays := defaultStaleAfterDays
+		if input.StaleAfterDays != nil {
+			staleAfterDays = *input.StaleAfterDays
+		}
+		if staleAfterDays < 1 {
+			toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, errors.New("staleAfterDays must be at least 1"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := accessReviewClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		now := time.Now()
+		result := GenerateAccessReviewPacketOutput{
+			EnvironmentId: input.EnvironmentId,
+			GeneratedAt:   now,
+			StaleBefore:   now.AddDate(0, 0, -staleAfterDays),
+			Items:         []ReviewItem{},
+		}
+
+		logger.FromContext(ctx).Debug("Generating access review packet", slog.String("environmentId", input.EnvironmentId.String()))
+
+		roleNames, err := getRoleNames(ctx, client)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		users, truncated, err := getUsers(ctx, client, input.EnvironmentId)
+		if err != nil {
+			return nil, nil, err
+		}
+		result.Truncated = truncated
+
+		var staleItems []ReviewItem
+		for _, user := range users {
+			if user.Id == nil {
+				continue
+			}
+			assignmentsIterator, err := client.GetUserRoleAssignments(ctx, input.EnvironmentId, *user.Id)
+			if err != nil {
+				toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			assignments := 0
+			err = collections.ForEachPage(ctx, assignmentsIterator, func(embedded *management.EntityArrayEmbedded) bool {
+				for _, assignment := range embedded.RoleAssignments {
+					item := roleAssignmentItem(ItemKindUserRoleAssignment, assignment, roleNames)
+					item.UserId = user.Id
+					item.Username = &user.Username
+					item.LastSignOnAt = lastSignOnAt(user)
+					item.Reason = "User is directly assigned an administrator role"
+					result.Items = append(result.Items, item)
+					assignments++
+				}
+				return true
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			if assignments > 0 {
+				result.Summary.Administrators++
+				result.Summary.UserRoleAssignments += assignments
+			}
+
+			if isStale(user, result.StaleBefore) {
+				staleItems = append(staleItems, ReviewItem{
+					ItemId:       "stale:" + *user.Id,
+					Kind:         ItemKindStaleAccount,
+					UserId:       user.Id,
+					Username:     &user.Username,
+					LastSignOnAt: lastSignOnAt(user),
+					Reason:       fmt.Sprintf("Enabled user has not signed on in the last %d days", staleAfterDays),
+					Revocable:    true,
+					Revocation:   "Disable the user",
+				})
+			}
+		}
+
+		groupsIterator, err := client.GetGroups(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		var groups []management.Group
+		err = collections.ForEachPage(ctx, groupsIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			groups = append(groups, embedded.Groups...)
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, group := range groups {
+			if group.Id == nil {
+				continue
+			}
+			assignmentsIterator, err := client.GetGroupRoleAssignments(ctx, input.EnvironmentId, *group.Id)
+			if err != nil {
+				toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			err = collections.ForEachPage(ctx, assignmentsIterator, func(embedded *management.EntityArrayEmbedded) bool {
+				for _, assignment := range embedded.RoleAssignments {
+					item := roleAssignmentItem(ItemKindGroupRoleAssignment, assignment, roleNames)
+					item.GroupId = group.Id
+					item.GroupName = &group.Name
+					item.Reason = "Group is assigned an administrator role, which every member holds"
+					result.Items = append(result.Items, item)
+					result.Summary.GroupRoleAssignments++
+				}
+				return true
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		result.Items = append(result.Items, staleItems...)
+		result.Summary.StaleAccounts = len(staleItems)
+		result.CampaignId = campaigns.Create(input.EnvironmentId, result.Items)
+
+		logger.FromContext(ctx).Debug("Generated access review packet",
+			slog.String("campaignId", result.CampaignId.String()),
+			slog.Int("items", len(result.Items)),
+			slog.Bool("truncated", result.Truncated))
+
+		return nil, &result, nil
+	}
+}
+
+// getUsers returns up to maxReviewUsers users of the environment, and whether the environment has more. The users
+// of each population are listed concurrently, as large environments have tens of thousands of users.
+func getUsers(ctx context.Context, client AccessReviewClient, environmentId uuid.UUID) ([]management.User, bool, error) {
+	populationsIterator, err := client.GetPopulations(ctx, environmentId)
+	if err != nil {
+		toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, false, toolErr
+	}
+	var populationIds []string
+	err = collections.ForEachPage(ctx, populationsIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, population := range embedded.Populations {
+			if population.Id != nil {
+				populationIds = append(populationIds, *population.Id)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	lists := make([]legacy.PagedList, len(populationIds))
+	for i, populationId := range populationIds {
+		lists[i] = func(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+			usersIterator, err := client.GetPopulationUsers(ctx, environmentId, populationId)
+			if err != nil {
+				toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, toolErr
+			}
+			return usersIterator, nil
+		}
+	}
+	populationUsers := make([][]management.User, len(populationIds))
+	count := 0
+	truncated := false
+	err = legacy.ForEachPageConcurrently(ctx, legacy.DefaultPageConcurrency, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		for _, user := range embedded.Users {
+			if count >= maxReviewUsers {
+				truncated = true
+				return legacy.ErrStopPages
+			}
+			populationUsers[list] = append(populationUsers[list], user)
+			count++
+		}
+		progress.ReportFetched(ctx, count, 0, "users")
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return slices.Concat(populationUsers...), truncated, nil
+}
+
+// getRoleNames returns the names of the built-in and custom administrator roles by ID
+func getRoleNames(ctx context.Context, client AccessReviewClient) (map[string]string, error) {
+	rolesIterator, err := client.GetRoles(ctx)
+	if err != nil {
+		toolErr := errs.NewToolError(GenerateAccessReviewPacketDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	roleNames := map[string]string{}
+	err = collections.ForEachPage(ctx, rolesIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, role := range embedded.Roles {
+			switch {
+			case role.Role != nil && role.Role.Id != nil && role.Role.Name != nil:
+				roleNames[*role.Role.Id] = string(*role.Role.Name)
+			case role.CustomAdminRole != nil && role.CustomAdminRole.Id != nil:
+				roleNames[*role.CustomAdminRole.Id] = role.CustomAdminRole.Name
+			}
+		}
+		return true
+	})
+	return roleNames, err
+}
+
+// roleAssignmentItem returns the review item fields describing a role assignment
+func roleAssignmentItem(kind string, assignment management.RoleAssignment, roleNames map[string]string) ReviewItem {
+	itemId := "user-role:"
+	if kind == ItemKindGroupRoleAssignment {
+		itemId = "group-role:"
+	}
+	if assignment.Id != nil {
+		itemId += *assignment.Id
+	}
+	roleId := assignment.Role.Id
+	scopeType := string(assignment.Scope.Type)
+	scopeId := assignment.Scope.Id
+	item := ReviewItem{
+		ItemId:           itemId,
+		Kind:             kind,
+		RoleAssignmentId: assignment.Id,
+		RoleId:           &roleId,
+		ScopeType:        &scopeType,
+		ScopeId:          &scopeId,
+		Revocable:        assignment.Id != nil && (assignment.ReadOnly == nil || !*assignment.ReadOnly),
+		Revocation:       "Delete the role assignment",
+	}
+	if roleName, ok := roleNames[roleId]; ok {
+		item.RoleName = &roleName
+	}
+	return item
+}
+
+func lastSignOnAt(user management.User) *time.Time {
+	if user.LastSignOn == nil {
+		return nil
+	}
+	return user.LastSignOn.At
+}
+
+// isStale returns true if an enabled user has not signed on since staleBefore. Users that have never
+// signed on are stale once they were created before staleBefore.
+func isStale(user management.User, staleBefore time.Time) bool {
+	if user.Enabled != nil && !*user.Enabled {
+		return false
+	}
+	if at := lastSignOnAt(user); at != nil {
+		return at.Before(staleBefore)
+	}
+	return user.CreatedAt != nil && user.CreatedAt.Before(staleBefore)
+}
@@ -0,0 +1,192 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAccessReviewPacketHandler_MockClient(t *testing.T) {
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	setupEnvironmentMock(mockClient)
+	campaigns := accessreview.NewCampaignStore()
+	handler := accessreview.GenerateAccessReviewPacketHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), campaigns)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.GenerateAccessReviewPacketInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	require.NoError(t, err)
+	assert.Nil(t, mcpResult)
+	require.NotNil(t, output)
+	assert.False(t, output.Truncated)
+	assert.Equal(t, accessreview.AccessReviewSummary{
+		Administrators:       1,
+		UserRoleAssignments:  2,
+		GroupRoleAssignments: 1,
+		StaleAccounts:        2,
+	}, output.Summary)
+
+	itemIds := make([]string, len(output.Items))
+	for i, item := range output.Items {
+		itemIds[i] = item.ItemId
+	}
+	assert.Equal(t, []string{"user-role:ra-user", "user-role:ra-readonly", "group-role:ra-group", "stale:user-stale", "stale:user-never"}, itemIds)
+
+	userItem := output.Items[0]
+	assert.Equal(t, accessreview.ItemKindUserRoleAssignment, userItem.Kind)
+	assert.Equal(t, "admin", *userItem.Username)
+	assert.Equal(t, "Environment Admin", *userItem.RoleName)
+	assert.Equal(t, "ENVIRONMENT", *userItem.ScopeType)
+	assert.True(t, userItem.Revocable)
+
+	assert.False(t, output.Items[1].Revocable, "Read-only role assignments cannot be revoked")
+
+	groupItem := output.Items[2]
+	assert.Equal(t, accessreview.ItemKindGroupRoleAssignment, groupItem.Kind)
+	assert.Equal(t, "Admins", *groupItem.GroupName)
+	assert.Equal(t, "Helpdesk", *groupItem.RoleName)
+
+	staleItem := output.Items[3]
+	assert.Equal(t, accessreview.ItemKindStaleAccount, staleItem.Kind)
+	assert.Equal(t, "Disable the user", staleItem.Revocation)
+	assert.True(t, staleItem.Revocable)
+
+	// The packet is stored as a campaign awaiting decisions
+	progress, _, err := campaigns.Progress(output.CampaignId)
+	require.NoError(t, err)
+	assert.Equal(t, 5, progress.Pending)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestGenerateAccessReviewPacketHandler_StaleAfterDays(t *testing.T) {
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	setupEnvironmentMock(mockClient)
+	handler := accessreview.GenerateAccessReviewPacketHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), accessreview.NewCampaignStore())
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.GenerateAccessReviewPacketInput{
+		EnvironmentId:  testEnvironmentId,
+		StaleAfterDays: testutils.Pointer(365),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, output.Summary.StaleAccounts)
+}
+
+func TestGenerateAccessReviewPacketHandler_ReviewsUsersOfAllPopulations(t *testing.T) {
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	mockClient.On("GetRoles", mock.Anything).Return(mockIterator(management.EntityArrayEmbedded{}), nil)
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId).Return(mockIterator(management.EntityArrayEmbedded{
+		Populations: []management.Population{
+			{Id: testutils.Pointer("population-1"), Name: "Employees"},
+			{Id: testutils.Pointer("population-2"), Name: "Contractors"},
+		},
+	}), nil)
+	mockClient.On("GetPopulationUsers", mock.Anything, testEnvironmentId, "population-1").Return(mockIterator(management.EntityArrayEmbedded{
+		Users: []management.User{testStaleUser},
+	}), nil)
+	mockClient.On("GetPopulationUsers", mock.Anything, testEnvironmentId, "population-2").Return(mockIterator(management.EntityArrayEmbedded{
+		Users: []management.User{testNeverSignedOnUser},
+	}), nil)
+	mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, mock.Anything).Return(mockIterator(management.EntityArrayEmbedded{}), nil)
+	mockClient.On("GetGroups", mock.Anything, testEnvironmentId).Return(mockIterator(management.EntityArrayEmbedded{}), nil)
+	handler := accessreview.GenerateAccessReviewPacketHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), accessreview.NewCampaignStore())
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.GenerateAccessReviewPacketInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, output.Truncated)
+	assert.Equal(t, 2, output.Summary.StaleAccounts)
+	require.Len(t, output.Items, 2)
+	assert.Equal(t, "stale:user-stale", output.Items[0].ItemId, "users should be reviewed in population order")
+	assert.Equal(t, "stale:user-never", output.Items[1].ItemId)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGenerateAccessReviewPacketHandler_InvalidStaleAfterDays(t *testing.T) {
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	handler := accessreview.GenerateAccessReviewPacketHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), accessreview.NewCampaignStore())
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.GenerateAccessReviewPacketInput{
+		EnvironmentId:  testEnvironmentId,
+		StaleAfterDays: testutils.Pointer(0),
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "staleAfterDays must be at least 1")
+	assert.Nil(t, output)
+	mockClient.AssertNotCalled(t, "GetPopulations", mock.Anything, mock.Anything)
+}
+
+func TestGenerateAccessReviewPacketHandler_ViaMcp(t *testing.T) {
+	mockClient := &mockPingOneClientAccessReviewWrapper{}
+	setupEnvironmentMock(mockClient)
+	handler := accessreview.GenerateAccessReviewPacketHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), accessreview.NewCampaignStore())
+
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, accessreview.GenerateAccessReviewPacketDef.McpTool, handler)
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, accessreview.GenerateAccessReviewPacketDef.McpTool.Name, accessreview.GenerateAccessReviewPacketInput{
+		EnvironmentId: testEnvironmentId,
+	})
+	testutils.AssertMcpCallSuccess(t, err, output)
+
+	packet := &accessreview.GenerateAccessReviewPacketOutput{}
+	jsonBytes, err := json.Marshal(output.StructuredContent)
+	require.NoError(t, err, "Failed to marshal structured content")
+	require.NoError(t, json.Unmarshal(jsonBytes, packet), "Failed to unmarshal structured content")
+	assert.Len(t, packet.Items, 5)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGenerateAccessReviewPacketHandler_APIErrors(t *testing.T) {
+	tests := testutils.CommonAPIErrorTestCases()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAccessReviewWrapper{}
+			mockClient.On("GetRoles", mock.Anything).Return(testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+				{HTTPResponse: &http.Response{StatusCode: tt.StatusCode}, Error: tt.ApiError},
+			}), nil)
+			handler := accessreview.GenerateAccessReviewPacketHandler(NewMockPingOneClientAccessReviewWrapperFactory(mockClient, nil), accessreview.NewCampaignStore())
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.GenerateAccessReviewPacketInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.WantErrContains)
+			assert.Nil(t, mcpResult)
+			assert.Nil(t, output)
+		})
+	}
+}
+
+func TestGenerateAccessReviewPacketHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := accessreview.GenerateAccessReviewPacketHandler(NewMockPingOneClientAccessReviewWrapperFactory(nil, errors.New("failed to get authenticated client")), accessreview.NewCampaignStore())
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, accessreview.GenerateAccessReviewPacketInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get authenticated client")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, output)
+}
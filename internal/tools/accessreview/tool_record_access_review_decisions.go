@@ -0,0 +1,105 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var RecordAccessReviewDecisionsDef = types.ToolDefinition{
+	// Each call records decisions in the campaign
+	DisableResponseCache: true,
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "record_access_review_decisions",
+		Title: "Record Access Review Decisions",
+		Description: `Record reviewer decisions for items in an access review campaign started with 'generate_access_review_packet'. Each decision is KEEP or REVOKE for an 'itemId' in the packet; recording a decision again for the same item replaces it, until the revocation has been applied.
+
+Decisions are only recorded by the server and do not change PingOne. Returns the campaign progress and the items still waiting for a decision. Use 'apply_access_review_revocations' to apply REVOKE decisions.`,
+		InputSchema:  schema.MustGenerateSchema[RecordAccessReviewDecisionsInput](),
+		OutputSchema: schema.MustGenerateSchema[RecordAccessReviewDecisionsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ItemDecision struct {
+	ItemId   string  `json:"itemId" jsonschema:"REQUIRED. The ID of the item in the review packet."`
+	Decision string  `json:"decision" jsonschema:"REQUIRED. KEEP or REVOKE."`
+	Comment  *string `json:"comment,omitempty" jsonschema:"OPTIONAL. The reviewer's justification."`
+}
+
+type RecordAccessReviewDecisionsInput struct {
+	CampaignId uuid.UUID      `json:"campaignId" jsonschema:"REQUIRED. The ID of the campaign returned by generate_access_review_packet."`
+	Reviewer   string         `json:"reviewer" jsonschema:"REQUIRED. Who made the decisions, such as the reviewer's email address."`
+	Decisions  []ItemDecision `json:"decisions" jsonschema:"REQUIRED. The decisions to record."`
+}
+
+type RecordAccessReviewDecisionsOutput struct {
+	CampaignId     uuid.UUID        `json:"campaignId" jsonschema:"The ID of the campaign"`
+	Recorded       int              `json:"recorded" jsonschema:"The number of decisions recorded"`
+	Progress       CampaignProgress `json:"progress" jsonschema:"The decisions recorded for the campaign so far"`
+	PendingItemIds []string         `json:"pendingItemIds" jsonschema:"The items still waiting for a decision"`
+}
+
+// RecordAccessReviewDecisionsHandler records reviewer decisions in the provided campaign store
+func RecordAccessReviewDecisionsHandler(campaigns *CampaignStore) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input RecordAccessReviewDecisionsInput,
+) (
+	*mcp.CallToolResult,
+	*RecordAccessReviewDecisionsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RecordAccessReviewDecisionsInput) (*mcp.CallToolResult, *RecordAccessReviewDecisionsOutput, error) {
+		if strings.TrimSpace(input.Reviewer) == "" {
+			toolErr := errs.NewToolError(RecordAccessReviewDecisionsDef.McpTool.Name, errors.New("reviewer is required"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(input.Decisions) == 0 {
+			toolErr := errs.NewToolError(RecordAccessReviewDecisionsDef.McpTool.Name, errors.New("at least one decision is required"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if err := campaigns.RecordDecisions(input.CampaignId, input.Reviewer, input.Decisions); err != nil {
+			toolErr := errs.NewToolError(RecordAccessReviewDecisionsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		progress, pending, err := campaigns.Progress(input.CampaignId)
+		if err != nil {
+			toolErr := errs.NewToolError(RecordAccessReviewDecisionsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Recorded access review decisions",
+			slog.String("campaignId", input.CampaignId.String()),
+			slog.Int("recorded", len(input.Decisions)),
+			slog.Int("pending", progress.Pending))
+
+		return nil, &RecordAccessReviewDecisionsOutput{
+			CampaignId:     input.CampaignId,
+			Recorded:       len(input.Decisions),
+			Progress:       progress,
+			PendingItemIds: pending,
+		}, nil
+	}
+}
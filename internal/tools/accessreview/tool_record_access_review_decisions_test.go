@@ -0,0 +1,135 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package accessreview_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAccessReviewDecisionsHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           func(campaignId uuid.UUID) accessreview.RecordAccessReviewDecisionsInput
+		wantErrContains string
+		wantOutput      func(campaignId uuid.UUID) *accessreview.RecordAccessReviewDecisionsOutput
+	}{
+		{
+			name: "Success - Records decisions and reports progress",
+			input: func(campaignId uuid.UUID) accessreview.RecordAccessReviewDecisionsInput {
+				return accessreview.RecordAccessReviewDecisionsInput{
+					CampaignId: campaignId,
+					Reviewer:   "reviewer@example.com",
+					Decisions: []accessreview.ItemDecision{
+						{ItemId: "user-role:ra-user", Decision: accessreview.DecisionKeep},
+						{ItemId: "user-role:ra-readonly", Decision: accessreview.DecisionKeep},
+						{ItemId: "stale:user-stale", Decision: accessreview.DecisionRevoke},
+					},
+				}
+			},
+			wantOutput: func(campaignId uuid.UUID) *accessreview.RecordAccessReviewDecisionsOutput {
+				return &accessreview.RecordAccessReviewDecisionsOutput{
+					CampaignId:     campaignId,
+					Recorded:       3,
+					Progress:       accessreview.CampaignProgress{Total: 4, Kept: 2, Revoked: 1, Pending: 1},
+					PendingItemIds: []string{"group-role:ra-group"},
+				}
+			},
+		},
+		{
+			name: "Error - Missing reviewer",
+			input: func(campaignId uuid.UUID) accessreview.RecordAccessReviewDecisionsInput {
+				return accessreview.RecordAccessReviewDecisionsInput{
+					CampaignId: campaignId,
+					Decisions:  []accessreview.ItemDecision{{ItemId: "stale:user-stale", Decision: accessreview.DecisionKeep}},
+				}
+			},
+			wantErrContains: "reviewer is required",
+		},
+		{
+			name: "Error - No decisions",
+			input: func(campaignId uuid.UUID) accessreview.RecordAccessReviewDecisionsInput {
+				return accessreview.RecordAccessReviewDecisionsInput{
+					CampaignId: campaignId,
+					Reviewer:   "reviewer@example.com",
+				}
+			},
+			wantErrContains: "at least one decision is required",
+		},
+		{
+			name: "Error - Unknown campaign",
+			input: func(campaignId uuid.UUID) accessreview.RecordAccessReviewDecisionsInput {
+				return accessreview.RecordAccessReviewDecisionsInput{
+					CampaignId: uuid.New(),
+					Reviewer:   "reviewer@example.com",
+					Decisions:  []accessreview.ItemDecision{{ItemId: "stale:user-stale", Decision: accessreview.DecisionKeep}},
+				}
+			},
+			wantErrContains: "not found",
+		},
+		{
+			name: "Error - Read-only assignment cannot be revoked",
+			input: func(campaignId uuid.UUID) accessreview.RecordAccessReviewDecisionsInput {
+				return accessreview.RecordAccessReviewDecisionsInput{
+					CampaignId: campaignId,
+					Reviewer:   "reviewer@example.com",
+					Decisions:  []accessreview.ItemDecision{{ItemId: "user-role:ra-readonly", Decision: accessreview.DecisionRevoke}},
+				}
+			},
+			wantErrContains: "cannot be revoked",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			campaigns := accessreview.NewCampaignStore()
+			campaignId := campaigns.Create(testEnvironmentId, testReviewItems())
+			handler := accessreview.RecordAccessReviewDecisionsHandler(campaigns)
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tc.input(campaignId))
+
+			assert.Nil(t, mcpResult)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrContains)
+				assert.Nil(t, output)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOutput(campaignId), output)
+		})
+
+		t.Run(tc.name+" via MCP", func(t *testing.T) {
+			campaigns := accessreview.NewCampaignStore()
+			campaignId := campaigns.Create(testEnvironmentId, testReviewItems())
+
+			server := mcptestutils.TestMcpServer(t)
+			mcp.AddTool(server, accessreview.RecordAccessReviewDecisionsDef.McpTool, accessreview.RecordAccessReviewDecisionsHandler(campaigns))
+
+			output, err := mcptestutils.CallToolOverMcp(t, server, accessreview.RecordAccessReviewDecisionsDef.McpTool.Name, tc.input(campaignId))
+			require.NoError(t, err, "Expect no error calling tool")
+			require.NotNil(t, output, "Expect non-nil output")
+
+			if tc.wantErrContains != "" {
+				testutils.AssertMcpCallError(t, output, tc.wantErrContains)
+				return
+			}
+			testutils.AssertMcpCallSuccess(t, err, output)
+
+			result := &accessreview.RecordAccessReviewDecisionsOutput{}
+			jsonBytes, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err, "Failed to marshal structured content")
+			require.NoError(t, json.Unmarshal(jsonBytes, result), "Failed to unmarshal structured content")
+			assert.Equal(t, tc.wantOutput(campaignId), result)
+		})
+	}
+}
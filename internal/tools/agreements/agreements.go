@@ -0,0 +1,137 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+type Agreement struct {
+	Id                   string     `json:"id" jsonschema:"The UUID of the agreement"`
+	Name                 string     `json:"name" jsonschema:"The name of the agreement"`
+	Description          *string    `json:"description,omitempty" jsonschema:"The description of the agreement"`
+	Enabled              bool       `json:"enabled" jsonschema:"Whether the agreement is enabled, and so presented to users where it is configured, such as in sign-on policies"`
+	ReconsentPeriodDays  *float32   `json:"reconsentPeriodDays,omitempty" jsonschema:"The number of days until the consent of a user to the agreement expires"`
+	TotalConsents        *int32     `json:"totalConsents,omitempty" jsonschema:"The number of users who have consented to the agreement, as of consentsAggregatedAt"`
+	TotalExpiredConsents *int32     `json:"totalExpiredConsents,omitempty" jsonschema:"The number of users whose consent to the agreement has expired, as of consentsAggregatedAt"`
+	ConsentsAggregatedAt *time.Time `json:"consentsAggregatedAt,omitempty" jsonschema:"When the consent counts were last calculated, typically once a day"`
+	UpdatedAt            *time.Time `json:"updatedAt,omitempty" jsonschema:"When the agreement was last updated, if PingOne returned it"`
+}
+
+type AgreementLanguage struct {
+	Id                string  `json:"id" jsonschema:"The UUID of the agreement language"`
+	Locale            string  `json:"locale" jsonschema:"The language tag of the language, such as en or fr-CA"`
+	DisplayName       string  `json:"displayName" jsonschema:"The title of the agreement presented to users in the language"`
+	Enabled           bool    `json:"enabled" jsonschema:"Whether the agreement is presented to users in the language"`
+	CurrentRevisionId *string `json:"currentRevisionId,omitempty" jsonschema:"The UUID of the revision presented to users for new consents in the language"`
+}
+
+type AgreementRevision struct {
+	Id               string                                      `json:"id" jsonschema:"The UUID of the revision"`
+	ContentType      management.EnumAgreementRevisionContentType `json:"contentType" jsonschema:"The content type of the revision text: text/html or text/plain"`
+	EffectiveAt      time.Time                                   `json:"effectiveAt" jsonschema:"When the revision is first presented to users"`
+	RequireReconsent bool                                        `json:"requireReconsent" jsonschema:"Whether users who consented to an earlier revision must consent again once the revision is effective"`
+	NotValidAfter    *time.Time                                  `json:"notValidAfter,omitempty" jsonschema:"When the revision stops being valid, such as when a later revision requiring reconsent becomes effective"`
+}
+
+func agreementOutput(agreement management.Agreement) Agreement {
+	return Agreement{
+		Id:                   agreement.GetId(),
+		Name:                 agreement.Name,
+		Description:          agreement.Description,
+		Enabled:              agreement.Enabled,
+		ReconsentPeriodDays:  agreement.ReconsentPeriodDays,
+		TotalConsents:        agreement.TotalConsents,
+		TotalExpiredConsents: agreement.TotalExpiredConsents,
+		ConsentsAggregatedAt: agreement.ConsentsAggregatedAt,
+	}
+}
+
+// agreementUpdatedAt returns when the agreement read in the response was last updated, or nil if the response does
+// not say. The legacy SDK does not decode updatedAt of agreements, so it is decoded from the response body, which is
+// left readable.
+func agreementUpdatedAt(httpResponse *http.Response) (*time.Time, error) {
+	if httpResponse == nil || httpResponse.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agreement response: %w", err)
+	}
+	httpResponse.Body = io.NopCloser(bytes.NewReader(body))
+	var agreement struct {
+		UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	}
+	if err := json.Unmarshal(body, &agreement); err != nil {
+		return nil, fmt.Errorf("failed to decode agreement response: %w", err)
+	}
+	return agreement.UpdatedAt, nil
+}
+
+func agreementLanguageOutput(language management.AgreementLanguage) AgreementLanguage {
+	result := AgreementLanguage{
+		Id:          language.GetId(),
+		Locale:      language.Locale,
+		DisplayName: language.DisplayName,
+		Enabled:     language.Enabled,
+	}
+	if language.CurrentRevision != nil {
+		result.CurrentRevisionId = language.CurrentRevision.Id
+	}
+	return result
+}
+
+func agreementRevisionOutput(revision management.AgreementLanguageRevision) AgreementRevision {
+	return AgreementRevision{
+		Id:               revision.GetId(),
+		ContentType:      revision.ContentType,
+		EffectiveAt:      revision.EffectiveAt,
+		RequireReconsent: revision.RequireReconsent,
+		NotValidAfter:    revision.NotValidAfter,
+	}
+}
+
+// agreementUpdate returns the full replacement of an agreement that keeps its current configuration, for
+// updates that change one property
+func agreementUpdate(agreement management.Agreement) management.Agreement {
+	return management.Agreement{
+		Name:                agreement.Name,
+		Description:         agreement.Description,
+		Enabled:             agreement.Enabled,
+		ReconsentPeriodDays: agreement.ReconsentPeriodDays,
+	}
+}
+
+// agreementLanguages returns the languages of an agreement
+func agreementLanguages(ctx context.Context, client AgreementsClient, toolName string, environmentId uuid.UUID, agreementId uuid.UUID) ([]management.AgreementLanguage, error) {
+	pagedIterator, err := client.GetAgreementLanguages(ctx, environmentId, agreementId)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	languages := []management.AgreementLanguage{}
+	err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, inner := range embedded.Languages {
+			if inner.AgreementLanguage != nil {
+				languages = append(languages, *inner.AgreementLanguage)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return languages, nil
+}
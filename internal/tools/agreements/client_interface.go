@@ -0,0 +1,25 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type AgreementsClient interface {
+	GetAgreements(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetAgreement(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID) (*management.Agreement, *http.Response, error)
+	CreateAgreement(ctx context.Context, environmentId uuid.UUID, createRequest management.Agreement) (*management.Agreement, *http.Response, error)
+	UpdateAgreement(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, updateRequest management.Agreement) (*management.Agreement, *http.Response, error)
+	GetAgreementLanguages(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	CreateAgreementLanguage(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, createRequest management.AgreementLanguage) (*management.AgreementLanguage, *http.Response, error)
+	CreateAgreementLanguageRevision(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, languageId uuid.UUID, createRequest management.AgreementLanguageRevision) (*management.AgreementLanguageRevision, *http.Response, error)
+}
+
+type AgreementsClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (AgreementsClient, error)
+}
@@ -0,0 +1,149 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ AgreementsClient = &PingOneClientAgreementsWrapper{}
+var _ AgreementsClientFactory = &PingOneClientAgreementsWrapperFactory{}
+
+type PingOneClientAgreementsWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientAgreementsWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientAgreementsWrapper(client *pingone.Client) *PingOneClientAgreementsWrapper {
+	return &PingOneClientAgreementsWrapper{client: client}
+}
+
+func NewPingOneClientAgreementsWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientAgreementsWrapperFactory {
+	return &PingOneClientAgreementsWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientAgreementsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (AgreementsClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientAgreementsWrapper(client), nil
+}
+
+func (p *PingOneClientAgreementsWrapper) GetAgreements(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.AgreementsResourcesApi.ReadAllAgreements(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve agreements",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAgreementsWrapper) GetAgreement(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID) (*management.Agreement, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.AgreementsResourcesApi.ReadOneAgreement(ctx, environmentId.String(), agreementId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve agreement by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("agreementId", agreementId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientAgreementsWrapper) CreateAgreement(ctx context.Context, environmentId uuid.UUID, createRequest management.Agreement) (*management.Agreement, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.AgreementsResourcesApi.CreateAgreement(ctx, environmentId.String()).Agreement(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create agreement",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("name", createRequest.Name),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientAgreementsWrapper) UpdateAgreement(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, updateRequest management.Agreement) (*management.Agreement, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.AgreementsResourcesApi.UpdateAgreement(ctx, environmentId.String(), agreementId.String()).Agreement(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update agreement",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("agreementId", agreementId.String()),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientAgreementsWrapper) GetAgreementLanguages(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.AgreementLanguagesResourcesApi.ReadAllAgreementLanguages(ctx, environmentId.String(), agreementId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve agreement languages",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("agreementId", agreementId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAgreementsWrapper) CreateAgreementLanguage(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, createRequest management.AgreementLanguage) (*management.AgreementLanguage, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.AgreementLanguagesResourcesApi.CreateAgreementLanguage(ctx, environmentId.String(), agreementId.String()).AgreementLanguage(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create agreement language",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("agreementId", agreementId.String()),
+		slog.String("locale", createRequest.Locale),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientAgreementsWrapper) CreateAgreementLanguageRevision(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, languageId uuid.UUID, createRequest management.AgreementLanguageRevision) (*management.AgreementLanguageRevision, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.AgreementRevisionsResourcesApi.CreateAgreementLanguageRevision(ctx, environmentId.String(), agreementId.String(), languageId.String()).AgreementLanguageRevision(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create agreement language revision",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("agreementId", agreementId.String()),
+		slog.String("languageId", languageId.String()),
+	)
+	return postRequest.Execute()
+}
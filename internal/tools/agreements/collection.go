@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "agreements"
+
+var _ collections.LegacySdkCollection = &AgreementsCollection{}
+
+type AgreementsCollection struct{}
+
+func (c *AgreementsCollection) Name() string {
+	return CollectionName
+}
+
+func (c *AgreementsCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	agreementsClientFactory := NewPingOneClientAgreementsWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListAgreementsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListAgreementsDef.McpTool.Name))
+		mcp.AddTool(server, ListAgreementsDef.McpTool, ListAgreementsHandler(agreementsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetAgreementDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetAgreementDef.McpTool.Name))
+		mcp.AddTool(server, GetAgreementDef.McpTool, GetAgreementHandler(agreementsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateAgreementDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateAgreementDef.McpTool.Name))
+		mcp.AddTool(server, CreateAgreementDef.McpTool, CreateAgreementHandler(agreementsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateAgreementDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateAgreementDef.McpTool.Name))
+		mcp.AddTool(server, UpdateAgreementDef.McpTool, UpdateAgreementHandler(agreementsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&SetAgreementEnabledDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", SetAgreementEnabledDef.McpTool.Name))
+		mcp.AddTool(server, SetAgreementEnabledDef.McpTool, SetAgreementEnabledHandler(agreementsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateAgreementRevisionDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateAgreementRevisionDef.McpTool.Name))
+		mcp.AddTool(server, CreateAgreementRevisionDef.McpTool, CreateAgreementRevisionHandler(agreementsClientFactory))
+	}
+
+	return nil
+}
+
+func (c *AgreementsCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListAgreementsDef,
+		GetAgreementDef,
+		CreateAgreementDef,
+		UpdateAgreementDef,
+		SetAgreementEnabledDef,
+		CreateAgreementRevisionDef,
+	}
+}
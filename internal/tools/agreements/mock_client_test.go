@@ -0,0 +1,108 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ agreements.AgreementsClient = &mockPingOneClientAgreementsWrapper{}
+var _ agreements.AgreementsClientFactory = &mockPingOneClientAgreementsWrapperFactory{}
+
+type mockPingOneClientAgreementsWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientAgreementsWrapperFactory struct {
+	mockClient agreements.AgreementsClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientAgreementsWrapperFactory(mockClient agreements.AgreementsClient, err error) *mockPingOneClientAgreementsWrapperFactory {
+	return &mockPingOneClientAgreementsWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientAgreementsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (agreements.AgreementsClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientAgreementsWrapper) GetAgreements(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientAgreementsWrapper) GetAgreement(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID) (*management.Agreement, *http.Response, error) {
+	args := p.Called(ctx, environmentId, agreementId)
+	return agreementResponse("GetAgreement", args)
+}
+
+func (p *mockPingOneClientAgreementsWrapper) CreateAgreement(ctx context.Context, environmentId uuid.UUID, createRequest management.Agreement) (*management.Agreement, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return agreementResponse("CreateAgreement", args)
+}
+
+func (p *mockPingOneClientAgreementsWrapper) UpdateAgreement(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, updateRequest management.Agreement) (*management.Agreement, *http.Response, error) {
+	args := p.Called(ctx, environmentId, agreementId, updateRequest)
+	return agreementResponse("UpdateAgreement", args)
+}
+
+func (p *mockPingOneClientAgreementsWrapper) GetAgreementLanguages(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, agreementId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientAgreementsWrapper) CreateAgreementLanguage(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, createRequest management.AgreementLanguage) (*management.AgreementLanguage, *http.Response, error) {
+	args := p.Called(ctx, environmentId, agreementId, createRequest)
+	response, ok := args.Get(0).(*management.AgreementLanguage)
+	if !ok && args.Get(0) != nil {
+		panic("CreateAgreementLanguage mock setup error: expected *management.AgreementLanguage or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("CreateAgreementLanguage mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientAgreementsWrapper) CreateAgreementLanguageRevision(ctx context.Context, environmentId uuid.UUID, agreementId uuid.UUID, languageId uuid.UUID, createRequest management.AgreementLanguageRevision) (*management.AgreementLanguageRevision, *http.Response, error) {
+	args := p.Called(ctx, environmentId, agreementId, languageId, createRequest)
+	response, ok := args.Get(0).(*management.AgreementLanguageRevision)
+	if !ok && args.Get(0) != nil {
+		panic("CreateAgreementLanguageRevision mock setup error: expected *management.AgreementLanguageRevision or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("CreateAgreementLanguageRevision mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func agreementResponse(method string, args mock.Arguments) (*management.Agreement, *http.Response, error) {
+	response, ok := args.Get(0).(*management.Agreement)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *management.Agreement or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testAgreementId   = uuid.MustParse("550e8400-e29b-41d4-a716-446655443000")
+	testLanguageId    = uuid.MustParse("550e8400-e29b-41d4-a716-446655443100")
+	testFrLanguageId  = uuid.MustParse("550e8400-e29b-41d4-a716-446655443101")
+	testRevisionId    = uuid.MustParse("550e8400-e29b-41d4-a716-446655443200")
+	testEffectiveAt   = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// testAgreement returns a new enabled terms of service agreement
+func testAgreement() *management.Agreement {
+	return &management.Agreement{
+		Id:                  testutils.Pointer(testAgreementId.String()),
+		Name:                "Terms of Service",
+		Description:         testutils.Pointer("Customer terms of service"),
+		Enabled:             true,
+		ReconsentPeriodDays: testutils.Pointer(float32(365)),
+		TotalConsents:       testutils.Pointer(int32(120)),
+	}
+}
+
+// englishLanguage returns the English language of the test agreement
+func englishLanguage() *management.AgreementLanguage {
+	return &management.AgreementLanguage{
+		Id:              testutils.Pointer(testLanguageId.String()),
+		Agreement:       &management.AgreementLanguageAgreement{Id: testutils.Pointer(testAgreementId.String())},
+		Locale:          "en",
+		DisplayName:     "Terms of Service",
+		Enabled:         true,
+		CurrentRevision: &management.AgreementLanguageCurrentRevision{Id: testutils.Pointer(testRevisionId.String())},
+	}
+}
+
+// testRevision returns a new revision of the English language of the test agreement
+func testRevision() *management.AgreementLanguageRevision {
+	return &management.AgreementLanguageRevision{
+		Id:               testutils.Pointer(testRevisionId.String()),
+		ContentType:      management.ENUMAGREEMENTREVISIONCONTENTTYPE_HTML,
+		EffectiveAt:      testEffectiveAt,
+		RequireReconsent: true,
+		Text:             "<p>Updated terms</p>",
+	}
+}
+
+func agreementsPages(items ...management.Agreement) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Agreements: items}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func languagesPages(languages ...*management.AgreementLanguage) management.EntityArrayPagedIterator {
+	inner := make([]management.EntityArrayEmbeddedLanguagesInner, 0, len(languages))
+	for _, language := range languages {
+		inner = append(inner, management.EntityArrayEmbeddedLanguagesInner{AgreementLanguage: language})
+	}
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Languages: inner}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func errorPages(err error) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{HTTPResponse: &http.Response{StatusCode: 403}, Error: err},
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateAgreementDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "create_agreement",
+		Title: "Create PingOne Agreement",
+		Description: `Create an agreement, such as terms of service, that users consent to. The agreement is created disabled.
+
+To publish it, add the text in the default language of the environment, and in any other languages, with 'create_agreement_revision', then enable it with 'set_agreement_enabled'.`,
+		InputSchema:  schema.MustGenerateSchema[CreateAgreementInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateAgreementOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateAgreementInput struct {
+	EnvironmentId       uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Name                string    `json:"name" jsonschema:"REQUIRED. The name of the agreement, unique within the environment."`
+	Description         *string   `json:"description,omitempty" jsonschema:"OPTIONAL. The description of the agreement."`
+	ReconsentPeriodDays *float32  `json:"reconsentPeriodDays,omitempty" jsonschema:"OPTIONAL. The number of days until the consent of a user expires, after which they must consent again. Consents do not expire if omitted."`
+}
+
+type CreateAgreementOutput struct {
+	Agreement Agreement `json:"agreement" jsonschema:"The created agreement"`
+}
+
+// CreateAgreementHandler creates a PingOne agreement using the provided client
+func CreateAgreementHandler(agreementsClientFactory AgreementsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateAgreementInput,
+) (
+	*mcp.CallToolResult,
+	*CreateAgreementOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateAgreementInput) (*mcp.CallToolResult, *CreateAgreementOutput, error) {
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateAgreementDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating agreement",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("name", input.Name))
+
+		createRequest := management.Agreement{
+			Name:                input.Name,
+			Description:         input.Description,
+			Enabled:             false,
+			ReconsentPeriodDays: input.ReconsentPeriodDays,
+		}
+		agreement, httpResponse, err := client.CreateAgreement(ctx, input.EnvironmentId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if agreement == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no agreement data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &CreateAgreementOutput{
+			Agreement: agreementOutput(*agreement),
+		}, nil
+	}
+}
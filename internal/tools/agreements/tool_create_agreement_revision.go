@@ -0,0 +1,168 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateAgreementRevisionDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "create_agreement_revision",
+		Title: "Create PingOne Agreement Revision",
+		Description: `Add a revision of the text of an agreement in a language, such as updated terms of service. The revision is presented to users from its effective date; with 'requireReconsent', users who consented to an earlier revision must consent again.
+
+If the agreement is not yet available in the language, the language is added, with 'displayName' as the title presented to users. Revisions cannot be changed once created, so check the text with the user before calling.`,
+		InputSchema:  schema.MustGenerateSchema[CreateAgreementRevisionInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateAgreementRevisionOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateAgreementRevisionInput struct {
+	EnvironmentId    uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	AgreementId      uuid.UUID  `json:"agreementId" jsonschema:"REQUIRED. Agreement UUID."`
+	Locale           string     `json:"locale" jsonschema:"REQUIRED. The language tag of the revision, such as en or fr-CA. The language must be enabled in the environment."`
+	Text             string     `json:"text" jsonschema:"REQUIRED. The text of the agreement, as plain text or HTML according to contentType."`
+	ContentType      *string    `json:"contentType,omitempty" jsonschema:"OPTIONAL. The content type of the text: text/html or text/plain. Defaults to text/html."`
+	EffectiveAt      *time.Time `json:"effectiveAt,omitempty" jsonschema:"OPTIONAL. When the revision is first presented to users, as an RFC 3339 timestamp that is not in the past and differs from the effective dates of the other revisions in the language. Defaults to now."`
+	RequireReconsent bool       `json:"requireReconsent,omitempty" jsonschema:"OPTIONAL. Whether users who consented to an earlier revision must consent again once the revision is effective. Defaults to false."`
+	DisplayName      *string    `json:"displayName,omitempty" jsonschema:"OPTIONAL. The title of the agreement presented to users in the language. Required when the agreement is not yet available in the language."`
+}
+
+type CreateAgreementRevisionOutput struct {
+	Language        AgreementLanguage `json:"language" jsonschema:"The agreement language the revision was added to"`
+	LanguageCreated bool              `json:"languageCreated" jsonschema:"True if the language was added to the agreement for the revision"`
+	Revision        AgreementRevision `json:"revision" jsonschema:"The created revision"`
+}
+
+// CreateAgreementRevisionHandler adds a revision to a language of a PingOne agreement, adding the language
+// if needed, using the provided client
+func CreateAgreementRevisionHandler(agreementsClientFactory AgreementsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateAgreementRevisionInput,
+) (
+	*mcp.CallToolResult,
+	*CreateAgreementRevisionOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateAgreementRevisionInput) (*mcp.CallToolResult, *CreateAgreementRevisionOutput, error) {
+		contentType := management.ENUMAGREEMENTREVISIONCONTENTTYPE_HTML
+		if input.ContentType != nil {
+			parsed, err := management.NewEnumAgreementRevisionContentTypeFromValue(*input.ContentType)
+			if err != nil {
+				toolErr := errs.NewToolError(CreateAgreementRevisionDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			contentType = *parsed
+		}
+		effectiveAt := time.Now().UTC()
+		if input.EffectiveAt != nil {
+			effectiveAt = *input.EffectiveAt
+		}
+
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateAgreementRevisionDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		languages, err := agreementLanguages(ctx, client, CreateAgreementRevisionDef.McpTool.Name, input.EnvironmentId, input.AgreementId)
+		if err != nil {
+			return nil, nil, err
+		}
+		var language *management.AgreementLanguage
+		for i := range languages {
+			if strings.EqualFold(languages[i].Locale, input.Locale) {
+				language = &languages[i]
+				break
+			}
+		}
+
+		result := &CreateAgreementRevisionOutput{}
+		if language == nil {
+			if input.DisplayName == nil || *input.DisplayName == "" {
+				toolErr := errs.NewToolError(CreateAgreementRevisionDef.McpTool.Name, fmt.Errorf("the agreement is not available in %s: displayName is required to add the language", input.Locale))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+
+			logger.FromContext(ctx).Debug("Adding agreement language",
+				slog.String("environmentId", input.EnvironmentId.String()),
+				slog.String("agreementId", input.AgreementId.String()),
+				slog.String("locale", input.Locale))
+
+			createRequest := management.AgreementLanguage{
+				Locale:      input.Locale,
+				DisplayName: *input.DisplayName,
+				Enabled:     true,
+			}
+			var httpResponse *http.Response
+			language, httpResponse, err = client.CreateAgreementLanguage(ctx, input.EnvironmentId, input.AgreementId, createRequest)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if language == nil {
+				apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no agreement language data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			result.LanguageCreated = true
+		}
+		languageId, err := uuid.Parse(language.GetId())
+		if err != nil {
+			toolErr := errs.NewToolError(CreateAgreementRevisionDef.McpTool.Name, fmt.Errorf("invalid agreement language ID %q: %w", language.GetId(), err))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating agreement revision",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("agreementId", input.AgreementId.String()),
+			slog.String("languageId", languageId.String()))
+
+		revisionRequest := management.AgreementLanguageRevision{
+			ContentType:      contentType,
+			EffectiveAt:      effectiveAt,
+			RequireReconsent: input.RequireReconsent,
+			Text:             input.Text,
+		}
+		revision, httpResponse, err := client.CreateAgreementLanguageRevision(ctx, input.EnvironmentId, input.AgreementId, languageId, revisionRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if revision == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no agreement revision data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		result.Language = agreementLanguageOutput(*language)
+		result.Revision = agreementRevisionOutput(*revision)
+		return nil, result, nil
+	}
+}
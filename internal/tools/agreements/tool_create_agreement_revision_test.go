@@ -0,0 +1,218 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func frenchLanguage() *management.AgreementLanguage {
+	return &management.AgreementLanguage{
+		Id:          testutils.Pointer(testFrLanguageId.String()),
+		Agreement:   &management.AgreementLanguageAgreement{Id: testutils.Pointer(testAgreementId.String())},
+		Locale:      "fr",
+		DisplayName: "Conditions d'utilisation",
+		Enabled:     true,
+	}
+}
+
+func TestCreateAgreementRevisionHandler_ExistingLanguage(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+	expectedRequest := management.AgreementLanguageRevision{
+		ContentType:      management.ENUMAGREEMENTREVISIONCONTENTTYPE_HTML,
+		EffectiveAt:      testEffectiveAt,
+		RequireReconsent: true,
+		Text:             "<p>Updated terms</p>",
+	}
+	mockClient.On("CreateAgreementLanguageRevision", mock.Anything, testEnvironmentId, testAgreementId, testLanguageId, expectedRequest).Return(testRevision(), &http.Response{StatusCode: 201}, nil)
+
+	handler := agreements.CreateAgreementRevisionHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.CreateAgreementRevisionInput{
+		EnvironmentId:    testEnvironmentId,
+		AgreementId:      testAgreementId,
+		Locale:           "EN",
+		Text:             "<p>Updated terms</p>",
+		EffectiveAt:      testutils.Pointer(testEffectiveAt),
+		RequireReconsent: true,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.LanguageCreated)
+	assert.Equal(t, testLanguageId.String(), output.Language.Id)
+	assert.Equal(t, agreements.AgreementRevision{
+		Id:               testRevisionId.String(),
+		ContentType:      management.ENUMAGREEMENTREVISIONCONTENTTYPE_HTML,
+		EffectiveAt:      testEffectiveAt,
+		RequireReconsent: true,
+	}, output.Revision)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "CreateAgreementLanguage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateAgreementRevisionHandler_NewLanguage(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+	expectedLanguage := management.AgreementLanguage{
+		Locale:      "fr",
+		DisplayName: "Conditions d'utilisation",
+		Enabled:     true,
+	}
+	mockClient.On("CreateAgreementLanguage", mock.Anything, testEnvironmentId, testAgreementId, expectedLanguage).Return(frenchLanguage(), &http.Response{StatusCode: 201}, nil)
+	mockClient.On("CreateAgreementLanguageRevision", mock.Anything, testEnvironmentId, testAgreementId, testFrLanguageId, mock.MatchedBy(func(request management.AgreementLanguageRevision) bool {
+		return request.ContentType == management.ENUMAGREEMENTREVISIONCONTENTTYPE_PLAIN && request.Text == "Conditions"
+	})).Return(testRevision(), &http.Response{StatusCode: 201}, nil)
+
+	handler := agreements.CreateAgreementRevisionHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.CreateAgreementRevisionInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+		Locale:        "fr",
+		Text:          "Conditions",
+		ContentType:   testutils.Pointer("text/plain"),
+		DisplayName:   testutils.Pointer("Conditions d'utilisation"),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.LanguageCreated)
+	assert.Equal(t, testFrLanguageId.String(), output.Language.Id)
+	assert.Equal(t, "fr", output.Language.Locale)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateAgreementRevisionHandler_DefaultEffectiveAt(t *testing.T) {
+	before := time.Now().UTC()
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+	mockClient.On("CreateAgreementLanguageRevision", mock.Anything, testEnvironmentId, testAgreementId, testLanguageId, mock.MatchedBy(func(request management.AgreementLanguageRevision) bool {
+		return !request.EffectiveAt.Before(before) && !request.RequireReconsent
+	})).Return(testRevision(), &http.Response{StatusCode: 201}, nil)
+
+	handler := agreements.CreateAgreementRevisionHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.CreateAgreementRevisionInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+		Locale:        "en",
+		Text:          "<p>Updated terms</p>",
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateAgreementRevisionHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           agreements.CreateAgreementRevisionInput
+		setupMock       func(mockClient *mockPingOneClientAgreementsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Invalid content type",
+			input: agreements.CreateAgreementRevisionInput{
+				Locale:      "en",
+				Text:        "Terms",
+				ContentType: testutils.Pointer("text/markdown"),
+			},
+			setupMock:       func(mockClient *mockPingOneClientAgreementsWrapper) {},
+			wantErrContains: "text/markdown",
+		},
+		{
+			name: "Languages page error",
+			input: agreements.CreateAgreementRevisionInput{
+				Locale: "en",
+				Text:   "Terms",
+			},
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(errorPages(errors.New("agreement not found")), nil)
+			},
+			wantErrContains: "agreement not found",
+		},
+		{
+			name: "Missing display name for new language",
+			input: agreements.CreateAgreementRevisionInput{
+				Locale: "fr",
+				Text:   "Conditions",
+			},
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+			},
+			wantErrContains: "displayName is required",
+		},
+		{
+			name: "Create language error",
+			input: agreements.CreateAgreementRevisionInput{
+				Locale:      "fr",
+				Text:        "Conditions",
+				DisplayName: testutils.Pointer("Conditions d'utilisation"),
+			},
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+				mockClient.On("CreateAgreementLanguage", mock.Anything, testEnvironmentId, testAgreementId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("language is not enabled in the environment"))
+			},
+			wantErrContains: "language is not enabled in the environment",
+		},
+		{
+			name: "Create revision error",
+			input: agreements.CreateAgreementRevisionInput{
+				Locale: "en",
+				Text:   "Terms",
+			},
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+				mockClient.On("CreateAgreementLanguageRevision", mock.Anything, testEnvironmentId, testAgreementId, testLanguageId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("effectiveAt must be unique"))
+			},
+			wantErrContains: "effectiveAt must be unique",
+		},
+		{
+			name: "No revision in response",
+			input: agreements.CreateAgreementRevisionInput{
+				Locale: "en",
+				Text:   "Terms",
+			},
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+				mockClient.On("CreateAgreementLanguageRevision", mock.Anything, testEnvironmentId, testAgreementId, testLanguageId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no agreement revision data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			tt.setupMock(mockClient)
+			tt.input.EnvironmentId = testEnvironmentId
+			tt.input.AgreementId = testAgreementId
+
+			handler := agreements.CreateAgreementRevisionHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateAgreementRevisionHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := agreements.CreateAgreementRevisionHandler(NewMockPingOneClientAgreementsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.CreateAgreementRevisionInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+		Locale:        "en",
+		Text:          "Terms",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,93 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAgreementHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	// Agreements are created disabled
+	expectedRequest := management.Agreement{
+		Name:                "Terms of Service",
+		Description:         testutils.Pointer("Customer terms of service"),
+		Enabled:             false,
+		ReconsentPeriodDays: testutils.Pointer(float32(365)),
+	}
+	created := testAgreement()
+	created.Enabled = false
+	mockClient.On("CreateAgreement", mock.Anything, testEnvironmentId, expectedRequest).Return(created, &http.Response{StatusCode: 201}, nil)
+
+	handler := agreements.CreateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.CreateAgreementInput{
+		EnvironmentId:       testEnvironmentId,
+		Name:                "Terms of Service",
+		Description:         testutils.Pointer("Customer terms of service"),
+		ReconsentPeriodDays: testutils.Pointer(float32(365)),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testAgreementId.String(), output.Agreement.Id)
+	assert.False(t, output.Agreement.Enabled)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateAgreementHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAgreementsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("CreateAgreement", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("name must be unique"))
+			},
+			wantErrContains: "name must be unique",
+		},
+		{
+			name: "No agreement in response",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("CreateAgreement", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no agreement data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := agreements.CreateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.CreateAgreementInput{
+				EnvironmentId: testEnvironmentId,
+				Name:          "Terms of Service",
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateAgreementHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := agreements.CreateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.CreateAgreementInput{
+		EnvironmentId: testEnvironmentId,
+		Name:          "Terms of Service",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetAgreementDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "get_agreement",
+		Title:        "Get PingOne Agreement by ID",
+		Description:  "Retrieve an agreement and its languages, with the current revision presented to users in each language. Use 'list_agreements' first if you need to find the agreement ID.",
+		InputSchema:  schema.MustGenerateSchema[GetAgreementInput](),
+		OutputSchema: schema.MustGenerateSchema[GetAgreementOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetAgreementInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	AgreementId   uuid.UUID `json:"agreementId" jsonschema:"REQUIRED. Agreement UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'agreement.enabled' and 'languages.locale'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetAgreementOutput struct {
+	Agreement Agreement           `json:"agreement" jsonschema:"The agreement"`
+	Languages []AgreementLanguage `json:"languages" jsonschema:"The languages the agreement is available in"`
+}
+
+// GetAgreementHandler retrieves a PingOne agreement and its languages using the provided client
+func GetAgreementHandler(agreementsClientFactory AgreementsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetAgreementInput,
+) (
+	*mcp.CallToolResult,
+	*GetAgreementOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetAgreementInput) (*mcp.CallToolResult, *GetAgreementOutput, error) {
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetAgreementDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Retrieving agreement",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("agreementId", input.AgreementId.String()))
+
+		agreement, httpResponse, err := client.GetAgreement(ctx, input.EnvironmentId, input.AgreementId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if agreement == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no agreement data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		updatedAt, err := agreementUpdatedAt(httpResponse)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Unable to read when the agreement was last updated",
+				slog.String("agreementId", input.AgreementId.String()),
+				slog.Any("error", err))
+		}
+
+		languages, err := agreementLanguages(ctx, client, GetAgreementDef.McpTool.Name, input.EnvironmentId, input.AgreementId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := &GetAgreementOutput{
+			Agreement: agreementOutput(*agreement),
+			Languages: []AgreementLanguage{},
+		}
+		result.Agreement.UpdatedAt = updatedAt
+		for _, language := range languages {
+			result.Languages = append(result.Languages, agreementLanguageOutput(language))
+		}
+		return nil, result, nil
+	}
+}
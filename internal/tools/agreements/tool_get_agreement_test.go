@@ -0,0 +1,98 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAgreementHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(languagesPages(englishLanguage()), nil)
+
+	handler := agreements.GetAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.GetAgreementInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, "Terms of Service", output.Agreement.Name)
+	require.Len(t, output.Languages, 1)
+	assert.Equal(t, agreements.AgreementLanguage{
+		Id:                testLanguageId.String(),
+		Locale:            "en",
+		DisplayName:       "Terms of Service",
+		Enabled:           true,
+		CurrentRevisionId: testutils.Pointer(testRevisionId.String()),
+	}, output.Languages[0])
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetAgreementHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAgreementsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get agreement error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(nil, &http.Response{StatusCode: 404}, errors.New("agreement not found"))
+			},
+			wantErrContains: "agreement not found",
+		},
+		{
+			name: "No agreement in response",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no agreement data in response",
+		},
+		{
+			name: "Languages page error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, testAgreementId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := agreements.GetAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.GetAgreementInput{
+				EnvironmentId: testEnvironmentId,
+				AgreementId:   testAgreementId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAgreementHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := agreements.GetAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.GetAgreementInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
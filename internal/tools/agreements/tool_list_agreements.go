@@ -0,0 +1,86 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListAgreementsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_agreements",
+		Title:        "List PingOne Agreements",
+		Description:  "Lists the agreements of an environment, such as terms of service and privacy policies that users consent to, with whether each is enabled and how many users have consented. Use to discover agreement IDs.",
+		InputSchema:  schema.MustGenerateSchema[ListAgreementsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListAgreementsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListAgreementsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'agreements.id' and 'agreements.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListAgreementsOutput struct {
+	Agreements []Agreement `json:"agreements" jsonschema:"The agreements of the environment"`
+}
+
+// ListAgreementsHandler lists the PingOne agreements of an environment using the provided client
+func ListAgreementsHandler(agreementsClientFactory AgreementsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListAgreementsInput,
+) (
+	*mcp.CallToolResult,
+	*ListAgreementsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListAgreementsInput) (*mcp.CallToolResult, *ListAgreementsOutput, error) {
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListAgreementsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing agreements", slog.String("environmentId", input.EnvironmentId.String()))
+
+		pagedIterator, err := client.GetAgreements(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(ListAgreementsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &ListAgreementsOutput{
+			Agreements: []Agreement{},
+		}
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			logger.FromContext(ctx).Debug("Retrieved agreements page", slog.Int("count", len(embedded.Agreements)))
+			for _, agreement := range embedded.Agreements {
+				result.Agreements = append(result.Agreements, agreementOutput(agreement))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAgreementsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreements", mock.Anything, testEnvironmentId).Return(agreementsPages(*testAgreement()), nil)
+
+	handler := agreements.ListAgreementsHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.ListAgreementsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.Agreements, 1)
+	assert.Equal(t, agreements.Agreement{
+		Id:                  testAgreementId.String(),
+		Name:                "Terms of Service",
+		Description:         testutils.Pointer("Customer terms of service"),
+		Enabled:             true,
+		ReconsentPeriodDays: testutils.Pointer(float32(365)),
+		TotalConsents:       testutils.Pointer(int32(120)),
+	}, output.Agreements[0])
+	mockClient.AssertExpectations(t)
+}
+
+func TestListAgreementsHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreements", mock.Anything, testEnvironmentId).Return(agreementsPages(), nil)
+
+	handler := agreements.ListAgreementsHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.ListAgreementsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Agreements)
+	assert.Empty(t, output.Agreements)
+}
+
+func TestListAgreementsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAgreementsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreements", mock.Anything, testEnvironmentId).Return(nil, errors.New("invalid environment"))
+			},
+			wantErrContains: "invalid environment",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreements", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := agreements.ListAgreementsHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.ListAgreementsInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAgreementsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := agreements.ListAgreementsHandler(NewMockPingOneClientAgreementsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.ListAgreementsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
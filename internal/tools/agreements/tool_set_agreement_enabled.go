@@ -0,0 +1,161 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var SetAgreementEnabledDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "set_agreement_enabled",
+		Title: "Enable or Disable PingOne Agreement",
+		Description: `Enable or disable an agreement. An enabled agreement is presented to users where it is configured, such as in sign-on policies; a disabled agreement is not used anywhere.
+
+An agreement can only be enabled once it has a revision in the default language of the environment, and cannot be disabled while a sign-on policy uses it. If the agreement is already in the requested state, nothing is changed.`,
+		InputSchema:  schema.MustGenerateSchema[SetAgreementEnabledInput](),
+		OutputSchema: schema.MustGenerateSchema[SetAgreementEnabledOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type SetAgreementEnabledInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	AgreementId   uuid.UUID `json:"agreementId" jsonschema:"REQUIRED. Agreement UUID."`
+	Enabled       bool      `json:"enabled" jsonschema:"REQUIRED. True to enable the agreement, false to disable it."`
+}
+
+type SetAgreementEnabledOutput struct {
+	AgreementId     string `json:"agreementId" jsonschema:"The UUID of the agreement"`
+	Name            string `json:"name" jsonschema:"The name of the agreement"`
+	PreviousEnabled bool   `json:"previousEnabled" jsonschema:"Whether the agreement was enabled before the call"`
+	Enabled         bool   `json:"enabled" jsonschema:"Whether the agreement is enabled after the call"`
+	Changed         bool   `json:"changed" jsonschema:"True if the agreement's enabled state was changed, false if it was already as requested"`
+}
+
+// SetAgreementEnabledHandler enables or disables a PingOne agreement using the provided client
+func SetAgreementEnabledHandler(agreementsClientFactory AgreementsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input SetAgreementEnabledInput,
+) (
+	*mcp.CallToolResult,
+	*SetAgreementEnabledOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SetAgreementEnabledInput) (*mcp.CallToolResult, *SetAgreementEnabledOutput, error) {
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(SetAgreementEnabledDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		agreement, httpResponse, err := client.GetAgreement(ctx, input.EnvironmentId, input.AgreementId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if agreement == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no agreement data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		result := &SetAgreementEnabledOutput{
+			AgreementId:     input.AgreementId.String(),
+			Name:            agreement.Name,
+			PreviousEnabled: agreement.Enabled,
+			Enabled:         agreement.Enabled,
+		}
+		if result.PreviousEnabled == input.Enabled {
+			return nil, result, nil
+		}
+
+		logger.FromContext(ctx).Debug("Setting agreement enabled",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("agreementId", input.AgreementId.String()),
+			slog.Bool("enabled", input.Enabled))
+
+		updateRequest := agreementUpdate(*agreement)
+		updateRequest.Enabled = input.Enabled
+		updated, httpResponse, err := client.UpdateAgreement(ctx, input.EnvironmentId, input.AgreementId, updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		result.Enabled = input.Enabled
+		if updated != nil {
+			result.Enabled = updated.Enabled
+		}
+		result.Changed = result.Enabled != result.PreviousEnabled
+
+		if result.Changed {
+			rollback.Record(ctx, rollback.Change{
+				Tool:          SetAgreementEnabledDef.McpTool.Name,
+				EnvironmentId: input.EnvironmentId.String(),
+				ResourceType:  "agreement",
+				ResourceId:    input.AgreementId.String(),
+				Description:   fmt.Sprintf("%s agreement %q", enabledVerb(result.PreviousEnabled), agreement.Name),
+			}, undoSetAgreementEnabled(agreementsClientFactory, input.EnvironmentId, input.AgreementId, result.PreviousEnabled))
+		}
+
+		return nil, result, nil
+	}
+}
+
+func enabledVerb(enabled bool) string {
+	if enabled {
+		return "Enable"
+	}
+	return "Disable"
+}
+
+// undoSetAgreementEnabled returns the function that restores the previous enabled state of an agreement,
+// unless it has been changed again since
+func undoSetAgreementEnabled(agreementsClientFactory AgreementsClientFactory, environmentId uuid.UUID, agreementId uuid.UUID, previousEnabled bool) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, httpResponse, err := client.GetAgreement(ctx, environmentId, agreementId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if current == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no agreement data in response"))
+		}
+		if !force && current.Enabled == previousEnabled {
+			return rollback.ErrChangedSince
+		}
+
+		restoreRequest := agreementUpdate(*current)
+		restoreRequest.Enabled = previousEnabled
+		_, httpResponse, err = client.UpdateAgreement(ctx, environmentId, agreementId, restoreRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func agreementWithEnabled(enabled bool) *management.Agreement {
+	agreement := testAgreement()
+	agreement.Enabled = enabled
+	return agreement
+}
+
+// agreementUpdateWithEnabled returns the update request that sets the enabled state of the test agreement
+func agreementUpdateWithEnabled(enabled bool) management.Agreement {
+	return management.Agreement{
+		Name:                "Terms of Service",
+		Description:         testutils.Pointer("Customer terms of service"),
+		Enabled:             enabled,
+		ReconsentPeriodDays: testutils.Pointer(float32(365)),
+	}
+}
+
+func TestSetAgreementEnabledHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		currentEnabled  bool
+		enabled         bool
+		expectUpdate    bool
+		expectedChanged bool
+	}{
+		{
+			name:            "Disable enabled agreement",
+			currentEnabled:  true,
+			enabled:         false,
+			expectUpdate:    true,
+			expectedChanged: true,
+		},
+		{
+			name:            "Enable disabled agreement",
+			currentEnabled:  false,
+			enabled:         true,
+			expectUpdate:    true,
+			expectedChanged: true,
+		},
+		{
+			name:            "Already enabled",
+			currentEnabled:  true,
+			enabled:         true,
+			expectUpdate:    false,
+			expectedChanged: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(agreementWithEnabled(tt.currentEnabled), &http.Response{StatusCode: 200}, nil)
+			if tt.expectUpdate {
+				mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, agreementUpdateWithEnabled(tt.enabled)).Return(
+					agreementWithEnabled(tt.enabled), &http.Response{StatusCode: 200}, nil)
+			}
+
+			handler := agreements.SetAgreementEnabledHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.SetAgreementEnabledInput{
+				EnvironmentId: testEnvironmentId,
+				AgreementId:   testAgreementId,
+				Enabled:       tt.enabled,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, testAgreementId.String(), output.AgreementId)
+			assert.Equal(t, "Terms of Service", output.Name)
+			assert.Equal(t, tt.currentEnabled, output.PreviousEnabled)
+			assert.Equal(t, tt.enabled, output.Enabled)
+			assert.Equal(t, tt.expectedChanged, output.Changed)
+			mockClient.AssertExpectations(t)
+			if !tt.expectUpdate {
+				mockClient.AssertNotCalled(t, "UpdateAgreement", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestSetAgreementEnabledHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAgreementsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get agreement error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(nil, &http.Response{StatusCode: 404}, errors.New("agreement not found"))
+			},
+			wantErrContains: "agreement not found",
+		},
+		{
+			name: "No agreement in response",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no agreement data in response",
+		},
+		{
+			name: "Update error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(agreementWithEnabled(false), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("agreement has no revision in the default language"))
+			},
+			wantErrContains: "agreement has no revision in the default language",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := agreements.SetAgreementEnabledHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.SetAgreementEnabledInput{
+				EnvironmentId: testEnvironmentId,
+				AgreementId:   testAgreementId,
+				Enabled:       true,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetAgreementEnabledHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := agreements.SetAgreementEnabledHandler(NewMockPingOneClientAgreementsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, agreements.SetAgreementEnabledInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+		Enabled:       true,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestSetAgreementEnabledHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(agreementWithEnabled(true), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, agreementUpdateWithEnabled(false)).Return(
+		agreementWithEnabled(false), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := agreements.SetAgreementEnabledHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, agreements.SetAgreementEnabledInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+		Enabled:       false,
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// The agreement has been enabled again since, so the undo is refused without force
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(agreementWithEnabled(true), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(agreementWithEnabled(false), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, agreementUpdateWithEnabled(true)).Return(
+		agreementWithEnabled(true), &http.Response{StatusCode: 200}, nil).Once()
+
+	change, err := journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, agreements.SetAgreementEnabledDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSetAgreementEnabledHandler_UnchangedDoesNotRecordUndo(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(agreementWithEnabled(false), &http.Response{StatusCode: 200}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := agreements.SetAgreementEnabledHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, agreements.SetAgreementEnabledInput{
+		EnvironmentId: testEnvironmentId,
+		AgreementId:   testAgreementId,
+		Enabled:       false,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, journal.Changes(testEnvironmentId.String()))
+}
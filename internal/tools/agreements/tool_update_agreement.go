@@ -0,0 +1,169 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateAgreementDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "update_agreement",
+		Title: "Update PingOne Agreement by ID",
+		Description: `Update the name, description and reconsent period of an agreement using full replacement (HTTP PUT). Whether the agreement is enabled is not changed; use 'set_agreement_enabled' to enable or disable it.
+
+Omitted optional fields will be cleared; call 'get_agreement' first to fetch the current configuration. The text of an agreement is changed by adding a revision with 'create_agreement_revision'.`,
+		InputSchema:  schema.MustGenerateSchema[UpdateAgreementInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateAgreementOutput](),
+	},
+}
+
+type UpdateAgreementInput struct {
+	EnvironmentId       uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	AgreementId         uuid.UUID  `json:"agreementId" jsonschema:"REQUIRED. Agreement UUID."`
+	Name                string     `json:"name" jsonschema:"REQUIRED. The name of the agreement, unique within the environment."`
+	Description         *string    `json:"description,omitempty" jsonschema:"OPTIONAL. The description of the agreement."`
+	ReconsentPeriodDays *float32   `json:"reconsentPeriodDays,omitempty" jsonschema:"OPTIONAL. The number of days until the consent of a user expires, after which they must consent again. Consents do not expire if omitted."`
+	LastKnownUpdatedAt  *time.Time `json:"lastKnownUpdatedAt,omitempty" jsonschema:"OPTIONAL. The updatedAt of the agreement as last read. If the agreement has been updated since, it is not replaced and the call fails with a CONFLICT error."`
+}
+
+type UpdateAgreementOutput struct {
+	Agreement Agreement `json:"agreement" jsonschema:"The updated agreement"`
+}
+
+// UpdateAgreementHandler replaces the configuration of a PingOne agreement using the provided client
+func UpdateAgreementHandler(agreementsClientFactory AgreementsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateAgreementInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateAgreementOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateAgreementInput) (*mcp.CallToolResult, *UpdateAgreementOutput, error) {
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateAgreementDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the current agreement, to keep whether it is enabled and so that the change can be undone
+		previous, httpResponse, err := client.GetAgreement(ctx, input.EnvironmentId, input.AgreementId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if previous == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no agreement data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if input.LastKnownUpdatedAt != nil {
+			updatedAt, err := agreementUpdatedAt(httpResponse)
+			if err == nil {
+				err = errs.CheckNotChangedSince("agreement", input.AgreementId.String(), *input.LastKnownUpdatedAt, updatedAt)
+			}
+			if err != nil {
+				toolErr := errs.NewToolError(UpdateAgreementDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+
+		logger.FromContext(ctx).Debug("Updating agreement",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("agreementId", input.AgreementId.String()))
+
+		updateRequest := management.Agreement{
+			Name:                input.Name,
+			Description:         input.Description,
+			Enabled:             previous.Enabled,
+			ReconsentPeriodDays: input.ReconsentPeriodDays,
+		}
+		agreement, httpResponse, err := client.UpdateAgreement(ctx, input.EnvironmentId, input.AgreementId, updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if agreement == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no agreement data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          UpdateAgreementDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "agreement",
+			ResourceId:    input.AgreementId.String(),
+			Description:   fmt.Sprintf("Restore the previous configuration of agreement %q", previous.Name),
+		}, undoUpdateAgreement(agreementsClientFactory, input.EnvironmentId, input.AgreementId, *previous, *agreement))
+
+		output := &UpdateAgreementOutput{
+			Agreement: agreementOutput(*agreement),
+		}
+		output.Agreement.UpdatedAt, err = agreementUpdatedAt(httpResponse)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Unable to read when the agreement was last updated",
+				slog.String("agreementId", input.AgreementId.String()),
+				slog.Any("error", err))
+		}
+		return nil, output, nil
+	}
+}
+
+// undoUpdateAgreement returns the function that restores the previous name, description and reconsent period
+// of an agreement, unless they have been changed again since
+func undoUpdateAgreement(agreementsClientFactory AgreementsClientFactory, environmentId uuid.UUID, agreementId uuid.UUID, previous management.Agreement, updated management.Agreement) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := agreementsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, httpResponse, err := client.GetAgreement(ctx, environmentId, agreementId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if current == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no agreement data in response"))
+		}
+		if !force && (current.Name != updated.Name || !reflect.DeepEqual(current.Description, updated.Description) ||
+			!reflect.DeepEqual(current.ReconsentPeriodDays, updated.ReconsentPeriodDays)) {
+			return rollback.ErrChangedSince
+		}
+
+		restoreRequest := management.Agreement{
+			Name:                previous.Name,
+			Description:         previous.Description,
+			Enabled:             current.Enabled,
+			ReconsentPeriodDays: previous.ReconsentPeriodDays,
+		}
+		_, httpResponse, err = client.UpdateAgreement(ctx, environmentId, agreementId, restoreRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
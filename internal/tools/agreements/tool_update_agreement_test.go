@@ -0,0 +1,230 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package agreements_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// renamedAgreement returns the test agreement after the update made in these tests
+func renamedAgreement() *management.Agreement {
+	agreement := testAgreement()
+	agreement.Name = "Customer Terms"
+	agreement.Description = nil
+	agreement.ReconsentPeriodDays = testutils.Pointer(float32(180))
+	return agreement
+}
+
+func updateAgreementInput() agreements.UpdateAgreementInput {
+	return agreements.UpdateAgreementInput{
+		EnvironmentId:       testEnvironmentId,
+		AgreementId:         testAgreementId,
+		Name:                "Customer Terms",
+		ReconsentPeriodDays: testutils.Pointer(float32(180)),
+	}
+}
+
+func TestUpdateAgreementHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), &http.Response{StatusCode: 200}, nil)
+	// The enabled state is kept
+	expectedRequest := management.Agreement{
+		Name:                "Customer Terms",
+		Enabled:             true,
+		ReconsentPeriodDays: testutils.Pointer(float32(180)),
+	}
+	mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, expectedRequest).Return(renamedAgreement(), &http.Response{StatusCode: 200}, nil)
+
+	handler := agreements.UpdateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateAgreementInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, "Customer Terms", output.Agreement.Name)
+	assert.True(t, output.Agreement.Enabled)
+	mockClient.AssertExpectations(t)
+}
+
+// updatedAtResponse returns a response whose body says when the agreement was last updated, as PingOne returns it
+func updatedAtResponse(updatedAt string) *http.Response {
+	body := "{}"
+	if updatedAt != "" {
+		body = fmt.Sprintf(`{"updatedAt":%q}`, updatedAt)
+	}
+	return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestUpdateAgreementHandler_LastKnownUpdatedAt(t *testing.T) {
+	lastKnownUpdatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		currentUpdatedAt string
+		wantReplaced     bool
+		wantConflict     bool
+		wantErrContains  string
+	}{
+		{
+			name:             "Replaces an agreement not changed since",
+			currentUpdatedAt: "2025-06-01T12:00:00Z",
+			wantReplaced:     true,
+		},
+		{
+			name:             "Does not replace an agreement changed since",
+			currentUpdatedAt: "2025-06-01T12:30:00.123Z",
+			wantConflict:     true,
+			wantErrContains:  "has been changed since it was read",
+		},
+		{
+			name:            "Does not replace an agreement without an update time",
+			wantErrContains: "PingOne did not return when it was last updated",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), updatedAtResponse(tc.currentUpdatedAt), nil).Once()
+			if tc.wantReplaced {
+				mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, mock.Anything).Return(renamedAgreement(), updatedAtResponse("2025-06-01T13:00:00Z"), nil).Once()
+			}
+
+			input := updateAgreementInput()
+			input.LastKnownUpdatedAt = &lastKnownUpdatedAt
+			handler := agreements.UpdateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			if tc.wantReplaced {
+				require.NoError(t, err)
+				assert.Equal(t, "Customer Terms", output.Agreement.Name)
+				require.NotNil(t, output.Agreement.UpdatedAt)
+				assert.True(t, output.Agreement.UpdatedAt.Equal(time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC)))
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrContains)
+				var conflictErr *errs.ConflictError
+				assert.Equal(t, tc.wantConflict, errors.As(err, &conflictErr))
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateAgreementHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAgreementsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get agreement error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(nil, &http.Response{StatusCode: 404}, errors.New("agreement not found"))
+			},
+			wantErrContains: "agreement not found",
+		},
+		{
+			name: "Update error",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("name must be unique"))
+			},
+			wantErrContains: "name must be unique",
+		},
+		{
+			name: "No agreement in update response",
+			setupMock: func(mockClient *mockPingOneClientAgreementsWrapper) {
+				mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, mock.Anything).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no agreement data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAgreementsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := agreements.UpdateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateAgreementInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateAgreementHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := agreements.UpdateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateAgreementInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestUpdateAgreementHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, mock.Anything).Return(renamedAgreement(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := agreements.UpdateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, updateAgreementInput())
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// The agreement was disabled since, which the undo keeps
+	current := renamedAgreement()
+	current.Enabled = false
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(current, &http.Response{StatusCode: 200}, nil).Once()
+	restoreRequest := management.Agreement{
+		Name:                "Terms of Service",
+		Description:         testutils.Pointer("Customer terms of service"),
+		Enabled:             false,
+		ReconsentPeriodDays: testutils.Pointer(float32(365)),
+	}
+	mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, restoreRequest).Return(testAgreement(), &http.Response{StatusCode: 200}, nil).Once()
+
+	change, err := journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, agreements.UpdateAgreementDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateAgreementHandler_UndoChangedSince(t *testing.T) {
+	mockClient := &mockPingOneClientAgreementsWrapper{}
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(testAgreement(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateAgreement", mock.Anything, testEnvironmentId, testAgreementId, mock.Anything).Return(renamedAgreement(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := agreements.UpdateAgreementHandler(NewMockPingOneClientAgreementsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, updateAgreementInput())
+	require.NoError(t, err)
+
+	// The agreement was renamed again since, so the undo is refused without force
+	current := renamedAgreement()
+	current.Name = "Terms of Use"
+	mockClient.On("GetAgreement", mock.Anything, testEnvironmentId, testAgreementId).Return(current, &http.Response{StatusCode: 200}, nil).Once()
+
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
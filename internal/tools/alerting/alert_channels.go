@@ -0,0 +1,137 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+)
+
+type AlertChannel struct {
+	Id                string                                 `json:"id" jsonschema:"The UUID of the alert channel"`
+	AlertName         *string                                `json:"alertName,omitempty" jsonschema:"The name of the alert channel"`
+	ChannelType       management.EnumAlertChannelType        `json:"channelType" jsonschema:"How alerts are sent: EMAIL"`
+	Addresses         []string                               `json:"addresses" jsonschema:"The email addresses alerts are sent to"`
+	IncludeSeverities []management.EnumAlertChannelSeverity  `json:"includeSeverities,omitempty" jsonschema:"The severities of the alerts sent to the channel. All severities are sent if empty."`
+	IncludeAlertTypes []management.EnumAlertChannelAlertType `json:"includeAlertTypes,omitempty" jsonschema:"The types of the alerts sent to the channel. All types are sent if empty."`
+	ExcludeAlertTypes []management.EnumAlertChannelAlertType `json:"excludeAlertTypes,omitempty" jsonschema:"The types of the alerts not sent to the channel"`
+}
+
+func alertChannelOutput(alertChannel management.AlertChannel) AlertChannel {
+	return AlertChannel{
+		Id:                alertChannel.GetId(),
+		AlertName:         alertChannel.AlertName,
+		ChannelType:       alertChannel.ChannelType,
+		Addresses:         alertChannel.Addresses,
+		IncludeSeverities: alertChannel.IncludeSeverities,
+		IncludeAlertTypes: alertChannel.IncludeAlertTypes,
+		ExcludeAlertTypes: alertChannel.ExcludeAlertTypes,
+	}
+}
+
+// alertChannelRequest returns the email alert channel to create or replace, returning an error naming the
+// first severity or alert type that PingOne does not support
+func alertChannelRequest(alertName *string, addresses []string, includeSeverities []string, includeAlertTypes []string, excludeAlertTypes []string) (management.AlertChannel, error) {
+	request := management.AlertChannel{
+		AlertName:   alertName,
+		ChannelType: management.ENUMALERTCHANNELTYPE_EMAIL,
+		Addresses:   addresses,
+	}
+	for _, value := range includeSeverities {
+		severity, err := management.NewEnumAlertChannelSeverityFromValue(value)
+		if err != nil {
+			return management.AlertChannel{}, err
+		}
+		request.IncludeSeverities = append(request.IncludeSeverities, *severity)
+	}
+	var err error
+	if request.IncludeAlertTypes, err = alertTypes(includeAlertTypes); err != nil {
+		return management.AlertChannel{}, err
+	}
+	if request.ExcludeAlertTypes, err = alertTypes(excludeAlertTypes); err != nil {
+		return management.AlertChannel{}, err
+	}
+	return request, nil
+}
+
+func alertTypes(values []string) ([]management.EnumAlertChannelAlertType, error) {
+	var result []management.EnumAlertChannelAlertType
+	for _, value := range values {
+		alertType, err := management.NewEnumAlertChannelAlertTypeFromValue(value)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *alertType)
+	}
+	return result, nil
+}
+
+// mustGenerateAlertChannelInputSchema generates the input schema of a tool that configures an alert channel,
+// listing the severities and alert types PingOne supports
+func mustGenerateAlertChannelInputSchema[T any]() *jsonschema.Schema {
+	inputSchema := schema.MustGenerateSchema[T]()
+
+	var severities []any
+	for _, severity := range management.AllowedEnumAlertChannelSeverityEnumValues {
+		severities = append(severities, string(severity))
+	}
+	var alertTypes []any
+	for _, alertType := range management.AllowedEnumAlertChannelAlertTypeEnumValues {
+		alertTypes = append(alertTypes, string(alertType))
+	}
+	for property, enum := range map[string][]any{
+		"includeSeverities": severities,
+		"includeAlertTypes": alertTypes,
+		"excludeAlertTypes": alertTypes,
+	} {
+		propertySchema, exists := inputSchema.Properties[property]
+		if !exists || propertySchema == nil || propertySchema.Items == nil {
+			panic(fmt.Sprintf("%s property not found in alert channel input schema", property))
+		}
+		propertySchema.Items.Enum = enum
+	}
+	return inputSchema
+}
+
+// alertChannels returns the alert channels of an environment
+func alertChannels(ctx context.Context, client AlertingClient, toolName string, environmentId uuid.UUID) ([]management.AlertChannel, error) {
+	pagedIterator, err := client.GetAlertChannels(ctx, environmentId)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	channels := []management.AlertChannel{}
+	err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		channels = append(channels, embedded.AlertChannels...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// alertChannel returns an alert channel of an environment. PingOne has no API to read a single alert channel, so
+// it is found in the list of alert channels.
+func alertChannel(ctx context.Context, client AlertingClient, toolName string, environmentId uuid.UUID, alertChannelId uuid.UUID) (*management.AlertChannel, error) {
+	channels, err := alertChannels(ctx, client, toolName, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	for _, channel := range channels {
+		if channel.GetId() == alertChannelId.String() {
+			return &channel, nil
+		}
+	}
+	toolErr := errs.NewToolError(toolName, fmt.Errorf("alert channel %s not found in environment %s", alertChannelId, environmentId))
+	errs.Log(ctx, toolErr)
+	return nil, toolErr
+}
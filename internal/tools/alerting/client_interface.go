@@ -0,0 +1,21 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type AlertingClient interface {
+	GetAlertChannels(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	CreateAlertChannel(ctx context.Context, environmentId uuid.UUID, createRequest management.AlertChannel) (*management.AlertChannel, *http.Response, error)
+	UpdateAlertChannel(ctx context.Context, environmentId uuid.UUID, alertChannelId uuid.UUID, updateRequest management.AlertChannel) (*management.AlertChannel, *http.Response, error)
+}
+
+type AlertingClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (AlertingClient, error)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ AlertingClient = &PingOneClientAlertingWrapper{}
+var _ AlertingClientFactory = &PingOneClientAlertingWrapperFactory{}
+
+type PingOneClientAlertingWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientAlertingWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientAlertingWrapper(client *pingone.Client) *PingOneClientAlertingWrapper {
+	return &PingOneClientAlertingWrapper{client: client}
+}
+
+func NewPingOneClientAlertingWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientAlertingWrapperFactory {
+	return &PingOneClientAlertingWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientAlertingWrapperFactory) GetAuthenticatedClient(ctx context.Context) (AlertingClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientAlertingWrapper(client), nil
+}
+
+func (p *PingOneClientAlertingWrapper) GetAlertChannels(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.AlertingApi.ReadAllAlertChannels(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve alert channels",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAlertingWrapper) CreateAlertChannel(ctx context.Context, environmentId uuid.UUID, createRequest management.AlertChannel) (*management.AlertChannel, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.AlertingApi.CreateAlertChannel(ctx, environmentId.String()).AlertChannel(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create alert channel",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientAlertingWrapper) UpdateAlertChannel(ctx context.Context, environmentId uuid.UUID, alertChannelId uuid.UUID, updateRequest management.AlertChannel) (*management.AlertChannel, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.AlertingApi.UpdateAlertChannel(ctx, environmentId.String(), alertChannelId.String()).AlertChannel(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update alert channel",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("alertChannelId", alertChannelId.String()),
+	)
+	return putRequest.Execute()
+}
@@ -0,0 +1,63 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "alerting"
+
+var _ collections.LegacySdkCollection = &AlertingCollection{}
+
+type AlertingCollection struct{}
+
+func (c *AlertingCollection) Name() string {
+	return CollectionName
+}
+
+func (c *AlertingCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	alertingClientFactory := NewPingOneClientAlertingWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListAlertChannelsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListAlertChannelsDef.McpTool.Name))
+		mcp.AddTool(server, ListAlertChannelsDef.McpTool, ListAlertChannelsHandler(alertingClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateAlertChannelDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateAlertChannelDef.McpTool.Name))
+		mcp.AddTool(server, CreateAlertChannelDef.McpTool, CreateAlertChannelHandler(alertingClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateAlertChannelDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateAlertChannelDef.McpTool.Name))
+		mcp.AddTool(server, UpdateAlertChannelDef.McpTool, UpdateAlertChannelHandler(alertingClientFactory))
+	}
+
+	return nil
+}
+
+func (c *AlertingCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListAlertChannelsDef,
+		CreateAlertChannelDef,
+		UpdateAlertChannelDef,
+	}
+}
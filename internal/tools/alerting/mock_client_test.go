@@ -0,0 +1,68 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/alerting"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ alerting.AlertingClient = &mockPingOneClientAlertingWrapper{}
+var _ alerting.AlertingClientFactory = &mockPingOneClientAlertingWrapperFactory{}
+
+type mockPingOneClientAlertingWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientAlertingWrapperFactory struct {
+	mockClient alerting.AlertingClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientAlertingWrapperFactory(mockClient alerting.AlertingClient, err error) *mockPingOneClientAlertingWrapperFactory {
+	return &mockPingOneClientAlertingWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientAlertingWrapperFactory) GetAuthenticatedClient(ctx context.Context) (alerting.AlertingClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientAlertingWrapper) GetAlertChannels(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientAlertingWrapper) CreateAlertChannel(ctx context.Context, environmentId uuid.UUID, createRequest management.AlertChannel) (*management.AlertChannel, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return alertChannelResponse("CreateAlertChannel", args)
+}
+
+func (p *mockPingOneClientAlertingWrapper) UpdateAlertChannel(ctx context.Context, environmentId uuid.UUID, alertChannelId uuid.UUID, updateRequest management.AlertChannel) (*management.AlertChannel, *http.Response, error) {
+	args := p.Called(ctx, environmentId, alertChannelId, updateRequest)
+	return alertChannelResponse("UpdateAlertChannel", args)
+}
+
+func alertChannelResponse(method string, args mock.Arguments) (*management.AlertChannel, *http.Response, error) {
+	response, ok := args.Get(0).(*management.AlertChannel)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *management.AlertChannel or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
@@ -0,0 +1,53 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testAlertChannelId = uuid.MustParse("550e8400-e29b-41d4-a716-446655447000")
+	otherChannelId     = uuid.MustParse("550e8400-e29b-41d4-a716-446655447001")
+)
+
+// testAlertChannel returns an alert channel sending certificate expiry alerts to the operations team
+func testAlertChannel() *management.AlertChannel {
+	return &management.AlertChannel{
+		Id:                testutils.Pointer(testAlertChannelId.String()),
+		AlertName:         testutils.Pointer("Operations"),
+		ChannelType:       management.ENUMALERTCHANNELTYPE_EMAIL,
+		Addresses:         []string{"ops@example.com"},
+		IncludeSeverities: []management.EnumAlertChannelSeverity{management.ENUMALERTCHANNELSEVERITY_WARNING, management.ENUMALERTCHANNELSEVERITY_ERROR},
+		IncludeAlertTypes: []management.EnumAlertChannelAlertType{management.ENUMALERTCHANNELALERTTYPE_CERTIFICATE_EXPIRING, management.ENUMALERTCHANNELALERTTYPE_CERTIFICATE_EXPIRED},
+	}
+}
+
+// otherAlertChannel returns an alert channel sending all alerts to the security team
+func otherAlertChannel() *management.AlertChannel {
+	return &management.AlertChannel{
+		Id:          testutils.Pointer(otherChannelId.String()),
+		ChannelType: management.ENUMALERTCHANNELTYPE_EMAIL,
+		Addresses:   []string{"security@example.com"},
+	}
+}
+
+func alertChannelsPages(items ...management.AlertChannel) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{AlertChannels: items}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func errorPages(err error) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{HTTPResponse: &http.Response{StatusCode: 403}, Error: err},
+	})
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateAlertChannelDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "create_alert_channel",
+		Title:        "Create PingOne Alert Channel",
+		Description:  "Create an alert channel, so that PingOne emails alerts of the environment, such as of expiring certificates, license limits and rate limits, to the given addresses. Alerts can be limited to some severities and alert types.",
+		InputSchema:  mustGenerateAlertChannelInputSchema[CreateAlertChannelInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateAlertChannelOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateAlertChannelInput struct {
+	EnvironmentId     uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	AlertName         *string   `json:"alertName,omitempty" jsonschema:"OPTIONAL. The name of the alert channel."`
+	Addresses         []string  `json:"addresses" jsonschema:"REQUIRED. The email addresses to send alerts to."`
+	IncludeSeverities []string  `json:"includeSeverities,omitempty" jsonschema:"OPTIONAL. The severities of the alerts to send. All severities are sent if omitted."`
+	IncludeAlertTypes []string  `json:"includeAlertTypes,omitempty" jsonschema:"OPTIONAL. The types of the alerts to send. All types are sent if omitted."`
+	ExcludeAlertTypes []string  `json:"excludeAlertTypes,omitempty" jsonschema:"OPTIONAL. The types of the alerts not to send."`
+}
+
+type CreateAlertChannelOutput struct {
+	AlertChannel AlertChannel `json:"alertChannel" jsonschema:"The created alert channel"`
+}
+
+// CreateAlertChannelHandler creates a PingOne alert channel using the provided client
+func CreateAlertChannelHandler(alertingClientFactory AlertingClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateAlertChannelInput,
+) (
+	*mcp.CallToolResult,
+	*CreateAlertChannelOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateAlertChannelInput) (*mcp.CallToolResult, *CreateAlertChannelOutput, error) {
+		createRequest, err := alertChannelRequest(input.AlertName, input.Addresses, input.IncludeSeverities, input.IncludeAlertTypes, input.ExcludeAlertTypes)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateAlertChannelDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := alertingClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateAlertChannelDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating alert channel",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("addresses", len(input.Addresses)))
+
+		alertChannel, httpResponse, err := client.CreateAlertChannel(ctx, input.EnvironmentId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if alertChannel == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no alert channel data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &CreateAlertChannelOutput{
+			AlertChannel: alertChannelOutput(*alertChannel),
+		}, nil
+	}
+}
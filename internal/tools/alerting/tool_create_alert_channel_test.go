@@ -0,0 +1,138 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/alerting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func createAlertChannelInput() alerting.CreateAlertChannelInput {
+	return alerting.CreateAlertChannelInput{
+		EnvironmentId:     testEnvironmentId,
+		AlertName:         testutils.Pointer("Operations"),
+		Addresses:         []string{"ops@example.com"},
+		IncludeSeverities: []string{"WARNING", "ERROR"},
+		IncludeAlertTypes: []string{"CERTIFICATE_EXPIRING", "CERTIFICATE_EXPIRED"},
+	}
+}
+
+func TestCreateAlertChannelHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAlertingWrapper{}
+	expectedRequest := *testAlertChannel()
+	expectedRequest.Id = nil
+	mockClient.On("CreateAlertChannel", mock.Anything, testEnvironmentId, expectedRequest).Return(testAlertChannel(), &http.Response{StatusCode: 201}, nil)
+
+	handler := alerting.CreateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, createAlertChannelInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testAlertChannelId.String(), output.AlertChannel.Id)
+	assert.Equal(t, management.ENUMALERTCHANNELTYPE_EMAIL, output.AlertChannel.ChannelType)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateAlertChannelHandler_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		modifyInput     func(input *alerting.CreateAlertChannelInput)
+		wantErrContains string
+	}{
+		{
+			name: "Unsupported severity",
+			modifyInput: func(input *alerting.CreateAlertChannelInput) {
+				input.IncludeSeverities = []string{"CRITICAL"}
+			},
+			wantErrContains: "invalid value 'CRITICAL' for EnumAlertChannelSeverity",
+		},
+		{
+			name: "Unsupported included alert type",
+			modifyInput: func(input *alerting.CreateAlertChannelInput) {
+				input.IncludeAlertTypes = []string{"PASSWORD_EXPIRING"}
+			},
+			wantErrContains: "invalid value 'PASSWORD_EXPIRING' for EnumAlertChannelAlertType",
+		},
+		{
+			name: "Unsupported excluded alert type",
+			modifyInput: func(input *alerting.CreateAlertChannelInput) {
+				input.ExcludeAlertTypes = []string{"license_expired"}
+			},
+			wantErrContains: "invalid value 'license_expired' for EnumAlertChannelAlertType",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAlertingWrapper{}
+			input := createAlertChannelInput()
+			tt.modifyInput(&input)
+
+			handler := alerting.CreateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertNotCalled(t, "CreateAlertChannel", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateAlertChannelHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAlertingWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Create error",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("CreateAlertChannel", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("addresses must be valid email addresses"))
+			},
+			wantErrContains: "addresses must be valid email addresses",
+		},
+		{
+			name: "No alert channel in response",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("CreateAlertChannel", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no alert channel data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAlertingWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := alerting.CreateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, createAlertChannelInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateAlertChannelHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := alerting.CreateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, createAlertChannelInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestCreateAlertChannelDef_InputSchemaEnums(t *testing.T) {
+	inputSchema := alerting.CreateAlertChannelDef.McpTool.InputSchema.(*jsonschema.Schema)
+
+	assert.Contains(t, inputSchema.Properties["includeSeverities"].Items.Enum, "WARNING")
+	assert.Contains(t, inputSchema.Properties["includeAlertTypes"].Items.Enum, "CERTIFICATE_EXPIRING")
+	assert.Contains(t, inputSchema.Properties["excludeAlertTypes"].Items.Enum, "RATE_LIMIT_WARNING")
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListAlertChannelsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_alert_channels",
+		Title:        "List PingOne Alert Channels",
+		Description:  "Lists the alert channels of an environment, with the email addresses that PingOne sends alerts to, such as of expiring certificates, license limits and rate limits, and the severities and alert types sent to each. Use to discover alert channel IDs.",
+		InputSchema:  schema.MustGenerateSchema[ListAlertChannelsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListAlertChannelsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListAlertChannelsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'alertChannels.id' and 'alertChannels.addresses'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListAlertChannelsOutput struct {
+	AlertChannels []AlertChannel `json:"alertChannels" jsonschema:"The alert channels of the environment"`
+}
+
+// ListAlertChannelsHandler lists the PingOne alert channels of an environment using the provided client
+func ListAlertChannelsHandler(alertingClientFactory AlertingClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListAlertChannelsInput,
+) (
+	*mcp.CallToolResult,
+	*ListAlertChannelsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListAlertChannelsInput) (*mcp.CallToolResult, *ListAlertChannelsOutput, error) {
+		client, err := alertingClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListAlertChannelsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing alert channels", slog.String("environmentId", input.EnvironmentId.String()))
+
+		channels, err := alertChannels(ctx, client, ListAlertChannelsDef.McpTool.Name, input.EnvironmentId)
+		if err != nil {
+			return nil, nil, err
+		}
+		result := &ListAlertChannelsOutput{
+			AlertChannels: make([]AlertChannel, 0, len(channels)),
+		}
+		for _, channel := range channels {
+			result.AlertChannels = append(result.AlertChannels, alertChannelOutput(channel))
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/alerting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAlertChannelsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAlertingWrapper{}
+	mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*testAlertChannel(), *otherAlertChannel()), nil)
+
+	handler := alerting.ListAlertChannelsHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, alerting.ListAlertChannelsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.AlertChannels, 2)
+	assert.Equal(t, alerting.AlertChannel{
+		Id:                testAlertChannelId.String(),
+		AlertName:         testutils.Pointer("Operations"),
+		ChannelType:       management.ENUMALERTCHANNELTYPE_EMAIL,
+		Addresses:         []string{"ops@example.com"},
+		IncludeSeverities: []management.EnumAlertChannelSeverity{management.ENUMALERTCHANNELSEVERITY_WARNING, management.ENUMALERTCHANNELSEVERITY_ERROR},
+		IncludeAlertTypes: []management.EnumAlertChannelAlertType{management.ENUMALERTCHANNELALERTTYPE_CERTIFICATE_EXPIRING, management.ENUMALERTCHANNELALERTTYPE_CERTIFICATE_EXPIRED},
+	}, output.AlertChannels[0])
+	assert.Equal(t, []string{"security@example.com"}, output.AlertChannels[1].Addresses)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListAlertChannelsHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientAlertingWrapper{}
+	mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(), nil)
+
+	handler := alerting.ListAlertChannelsHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, alerting.ListAlertChannelsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.AlertChannels)
+	assert.Empty(t, output.AlertChannels)
+}
+
+func TestListAlertChannelsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAlertingWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(nil, errors.New("invalid environment"))
+			},
+			wantErrContains: "invalid environment",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAlertingWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := alerting.ListAlertChannelsHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, alerting.ListAlertChannelsInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAlertChannelsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := alerting.ListAlertChannelsHandler(NewMockPingOneClientAlertingWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, alerting.ListAlertChannelsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
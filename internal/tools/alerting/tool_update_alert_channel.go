@@ -0,0 +1,145 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateAlertChannelDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "update_alert_channel",
+		Title: "Update PingOne Alert Channel by ID",
+		Description: `Update the name, email addresses, severities and alert types of an alert channel using full replacement (HTTP PUT).
+
+Omitted optional fields will be cleared, so that alerts of all severities and types are sent; call 'list_alert_channels' first to fetch the current configuration.`,
+		InputSchema:  mustGenerateAlertChannelInputSchema[UpdateAlertChannelInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateAlertChannelOutput](),
+	},
+}
+
+type UpdateAlertChannelInput struct {
+	EnvironmentId     uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	AlertChannelId    uuid.UUID `json:"alertChannelId" jsonschema:"REQUIRED. Alert channel UUID."`
+	AlertName         *string   `json:"alertName,omitempty" jsonschema:"OPTIONAL. The name of the alert channel."`
+	Addresses         []string  `json:"addresses" jsonschema:"REQUIRED. The email addresses to send alerts to."`
+	IncludeSeverities []string  `json:"includeSeverities,omitempty" jsonschema:"OPTIONAL. The severities of the alerts to send. All severities are sent if omitted."`
+	IncludeAlertTypes []string  `json:"includeAlertTypes,omitempty" jsonschema:"OPTIONAL. The types of the alerts to send. All types are sent if omitted."`
+	ExcludeAlertTypes []string  `json:"excludeAlertTypes,omitempty" jsonschema:"OPTIONAL. The types of the alerts not to send."`
+}
+
+type UpdateAlertChannelOutput struct {
+	AlertChannel AlertChannel `json:"alertChannel" jsonschema:"The updated alert channel"`
+}
+
+// UpdateAlertChannelHandler replaces the configuration of a PingOne alert channel using the provided client
+func UpdateAlertChannelHandler(alertingClientFactory AlertingClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateAlertChannelInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateAlertChannelOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateAlertChannelInput) (*mcp.CallToolResult, *UpdateAlertChannelOutput, error) {
+		updateRequest, err := alertChannelRequest(input.AlertName, input.Addresses, input.IncludeSeverities, input.IncludeAlertTypes, input.ExcludeAlertTypes)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateAlertChannelDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := alertingClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateAlertChannelDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the current alert channel, so that the change can be undone
+		previous, err := alertChannel(ctx, client, UpdateAlertChannelDef.McpTool.Name, input.EnvironmentId, input.AlertChannelId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logger.FromContext(ctx).Debug("Updating alert channel",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("alertChannelId", input.AlertChannelId.String()))
+
+		updated, httpResponse, err := client.UpdateAlertChannel(ctx, input.EnvironmentId, input.AlertChannelId, updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if updated == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no alert channel data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          UpdateAlertChannelDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "alert_channel",
+			ResourceId:    input.AlertChannelId.String(),
+			Description:   fmt.Sprintf("Restore the previous configuration of alert channel %q", previous.GetAlertName()),
+		}, undoUpdateAlertChannel(alertingClientFactory, input.EnvironmentId, input.AlertChannelId, *previous, *updated))
+
+		return nil, &UpdateAlertChannelOutput{
+			AlertChannel: alertChannelOutput(*updated),
+		}, nil
+	}
+}
+
+// undoUpdateAlertChannel returns the function that restores the previous configuration of an alert channel,
+// unless it has been changed again since
+func undoUpdateAlertChannel(alertingClientFactory AlertingClientFactory, environmentId uuid.UUID, alertChannelId uuid.UUID, previous management.AlertChannel, updated management.AlertChannel) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := alertingClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, err := alertChannel(ctx, client, UpdateAlertChannelDef.McpTool.Name, environmentId, alertChannelId)
+		if err != nil {
+			return err
+		}
+		if !force && !reflect.DeepEqual(alertChannelConfiguration(*current), alertChannelConfiguration(updated)) {
+			return rollback.ErrChangedSince
+		}
+
+		_, httpResponse, err := client.UpdateAlertChannel(ctx, environmentId, alertChannelId, alertChannelConfiguration(previous))
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
+
+// alertChannelConfiguration returns the configurable properties of an alert channel, as a full replacement
+func alertChannelConfiguration(alertChannel management.AlertChannel) management.AlertChannel {
+	return management.AlertChannel{
+		AlertName:         alertChannel.AlertName,
+		ChannelType:       alertChannel.ChannelType,
+		Addresses:         alertChannel.Addresses,
+		IncludeSeverities: alertChannel.IncludeSeverities,
+		IncludeAlertTypes: alertChannel.IncludeAlertTypes,
+		ExcludeAlertTypes: alertChannel.ExcludeAlertTypes,
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package alerting_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/alerting"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// updatedAlertChannel returns the test alert channel after the update made in these tests
+func updatedAlertChannel() *management.AlertChannel {
+	alertChannel := testAlertChannel()
+	alertChannel.Addresses = []string{"ops@example.com", "oncall@example.com"}
+	alertChannel.IncludeSeverities = []management.EnumAlertChannelSeverity{management.ENUMALERTCHANNELSEVERITY_ERROR}
+	return alertChannel
+}
+
+func updateAlertChannelInput() alerting.UpdateAlertChannelInput {
+	return alerting.UpdateAlertChannelInput{
+		EnvironmentId:     testEnvironmentId,
+		AlertChannelId:    testAlertChannelId,
+		AlertName:         testutils.Pointer("Operations"),
+		Addresses:         []string{"ops@example.com", "oncall@example.com"},
+		IncludeSeverities: []string{"ERROR"},
+		IncludeAlertTypes: []string{"CERTIFICATE_EXPIRING", "CERTIFICATE_EXPIRED"},
+	}
+}
+
+func TestUpdateAlertChannelHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAlertingWrapper{}
+	mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*otherAlertChannel(), *testAlertChannel()), nil)
+	expectedRequest := *updatedAlertChannel()
+	expectedRequest.Id = nil
+	mockClient.On("UpdateAlertChannel", mock.Anything, testEnvironmentId, testAlertChannelId, expectedRequest).Return(updatedAlertChannel(), &http.Response{StatusCode: 200}, nil)
+
+	handler := alerting.UpdateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateAlertChannelInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []string{"ops@example.com", "oncall@example.com"}, output.AlertChannel.Addresses)
+	assert.Equal(t, []management.EnumAlertChannelSeverity{management.ENUMALERTCHANNELSEVERITY_ERROR}, output.AlertChannel.IncludeSeverities)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateAlertChannelHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAlertingWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "List alert channels error",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+		{
+			name: "Alert channel not found",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*otherAlertChannel()), nil)
+			},
+			wantErrContains: "alert channel 550e8400-e29b-41d4-a716-446655447000 not found",
+		},
+		{
+			name: "Update error",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*testAlertChannel()), nil)
+				mockClient.On("UpdateAlertChannel", mock.Anything, testEnvironmentId, testAlertChannelId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("addresses must be valid email addresses"))
+			},
+			wantErrContains: "addresses must be valid email addresses",
+		},
+		{
+			name: "No alert channel in update response",
+			setupMock: func(mockClient *mockPingOneClientAlertingWrapper) {
+				mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*testAlertChannel()), nil)
+				mockClient.On("UpdateAlertChannel", mock.Anything, testEnvironmentId, testAlertChannelId, mock.Anything).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no alert channel data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAlertingWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := alerting.UpdateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateAlertChannelInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateAlertChannelHandler_InvalidSeverity(t *testing.T) {
+	mockClient := &mockPingOneClientAlertingWrapper{}
+	input := updateAlertChannelInput()
+	input.IncludeSeverities = []string{"FATAL"}
+
+	handler := alerting.UpdateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "invalid value 'FATAL' for EnumAlertChannelSeverity")
+	mockClient.AssertNotCalled(t, "GetAlertChannels", mock.Anything, mock.Anything)
+}
+
+func TestUpdateAlertChannelHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := alerting.UpdateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateAlertChannelInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestUpdateAlertChannelHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientAlertingWrapper{}
+	mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*testAlertChannel()), nil).Once()
+	mockClient.On("UpdateAlertChannel", mock.Anything, testEnvironmentId, testAlertChannelId, mock.Anything).Return(updatedAlertChannel(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := alerting.UpdateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, updateAlertChannelInput())
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*updatedAlertChannel()), nil).Once()
+	restoreRequest := *testAlertChannel()
+	restoreRequest.Id = nil
+	mockClient.On("UpdateAlertChannel", mock.Anything, testEnvironmentId, testAlertChannelId, restoreRequest).Return(testAlertChannel(), &http.Response{StatusCode: 200}, nil).Once()
+
+	change, err := journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, alerting.UpdateAlertChannelDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateAlertChannelHandler_UndoChangedSince(t *testing.T) {
+	mockClient := &mockPingOneClientAlertingWrapper{}
+	mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*testAlertChannel()), nil).Once()
+	mockClient.On("UpdateAlertChannel", mock.Anything, testEnvironmentId, testAlertChannelId, mock.Anything).Return(updatedAlertChannel(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := alerting.UpdateAlertChannelHandler(NewMockPingOneClientAlertingWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, updateAlertChannelInput())
+	require.NoError(t, err)
+
+	// An address was added again since, so the undo is refused without force
+	current := updatedAlertChannel()
+	current.Addresses = append(current.Addresses, "security@example.com")
+	mockClient.On("GetAlertChannels", mock.Anything, testEnvironmentId).Return(alertChannelsPages(*current), nil).Once()
+
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package apirequests keeps the last PingOne API requests made by tool calls, with the correlation IDs PingOne
+// assigned to them, so that users can quote the IDs of failed or slow requests when they open a support case
+// with Ping Identity.
+package apirequests
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+// DefaultMaxRequests is the number of requests the log keeps before the oldest are forgotten.
+const DefaultMaxRequests = 100
+
+// Request is a PingOne API request made by a tool call.
+type Request struct {
+	Time          time.Time `json:"time" jsonschema:"When the request completed"`
+	Tool          string    `json:"tool" jsonschema:"The tool whose call made the request"`
+	Method        string    `json:"method" jsonschema:"The HTTP method of the request"`
+	Url           string    `json:"url" jsonschema:"The URL of the request, without its query"`
+	StatusCode    int       `json:"statusCode,omitempty" jsonschema:"The HTTP status of the response. Not set if no response was received."`
+	DurationMs    int64     `json:"durationMs" jsonschema:"The latency of the request in milliseconds, including retries"`
+	Retries       int       `json:"retries" jsonschema:"The number of times the request was retried"`
+	CorrelationId string    `json:"correlationId,omitempty" jsonschema:"The correlation ID PingOne assigned to the request, which PingOne support can use to find it. Not set if the response has none."`
+	Error         string    `json:"error,omitempty" jsonschema:"Why no response was received"`
+}
+
+// Log holds the last PingOne API requests made by tool calls in memory. It is safe for concurrent use.
+type Log struct {
+	mutex       sync.Mutex
+	maxRequests int
+	// requests are ordered oldest first
+	requests []Request
+}
+
+// NewLog creates a log that keeps up to maxRequests requests.
+func NewLog(maxRequests int) *Log {
+	if maxRequests <= 0 {
+		maxRequests = DefaultMaxRequests
+	}
+	return &Log{
+		maxRequests: maxRequests,
+	}
+}
+
+// MaxRequests returns the number of requests the log keeps.
+func (l *Log) MaxRequests() int {
+	return l.maxRequests
+}
+
+// Add records the requests made by a call of the tool, forgetting the oldest requests beyond the maximum.
+func (l *Log) Add(tool string, completedAt time.Time, calls []sdk.SdkCall) {
+	if len(calls) == 0 {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for _, call := range calls {
+		l.requests = append(l.requests, Request{
+			Time:          completedAt,
+			Tool:          tool,
+			Method:        call.Method,
+			Url:           call.Url,
+			StatusCode:    call.StatusCode,
+			DurationMs:    call.DurationMs,
+			Retries:       call.Retries,
+			CorrelationId: call.CorrelationId,
+			Error:         call.Error,
+		})
+	}
+	if excess := len(l.requests) - l.maxRequests; excess > 0 {
+		l.requests = append([]Request{}, l.requests[excess:]...)
+	}
+}
+
+// Last returns up to limit requests, most recent first.
+func (l *Log) Last(limit int) []Request {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	requests := []Request{}
+	for i := len(l.requests) - 1; i >= 0 && len(requests) < limit; i-- {
+		requests = append(requests, l.requests[i])
+	}
+	return requests
+}
+
+// Clear forgets all requests, such as when the server switches to another profile.
+func (l *Log) Clear() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.requests = nil
+}
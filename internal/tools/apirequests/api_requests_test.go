@@ -0,0 +1,71 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package apirequests_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/apirequests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sdkCall(correlationId string) sdk.SdkCall {
+	return sdk.SdkCall{
+		Method:        http.MethodGet,
+		Url:           "https://api.pingone.com/v1/environments",
+		StatusCode:    http.StatusOK,
+		DurationMs:    42,
+		CorrelationId: correlationId,
+	}
+}
+
+func TestLog_LastReturnsMostRecentFirst(t *testing.T) {
+	log := apirequests.NewLog(0)
+	completedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	log.Add("list_environments", completedAt, []sdk.SdkCall{sdkCall("first"), sdkCall("second")})
+	log.Add("get_environment", completedAt.Add(time.Second), []sdk.SdkCall{sdkCall("third")})
+	log.Add("get_last_api_requests", completedAt.Add(2*time.Second), nil)
+
+	requests := log.Last(10)
+	require.Len(t, requests, 3)
+	assert.Equal(t, "third", requests[0].CorrelationId)
+	assert.Equal(t, "get_environment", requests[0].Tool)
+	assert.Equal(t, completedAt.Add(time.Second), requests[0].Time)
+	assert.Equal(t, "second", requests[1].CorrelationId)
+	assert.Equal(t, "first", requests[2].CorrelationId)
+	assert.Equal(t, "list_environments", requests[2].Tool)
+	assert.Equal(t, http.MethodGet, requests[2].Method)
+	assert.Equal(t, "https://api.pingone.com/v1/environments", requests[2].Url)
+	assert.Equal(t, http.StatusOK, requests[2].StatusCode)
+	assert.Equal(t, int64(42), requests[2].DurationMs)
+
+	requests = log.Last(1)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "third", requests[0].CorrelationId)
+}
+
+func TestLog_ForgetsOldestRequests(t *testing.T) {
+	log := apirequests.NewLog(2)
+
+	log.Add("list_environments", time.Now(), []sdk.SdkCall{sdkCall("first"), sdkCall("second"), sdkCall("third")})
+
+	requests := log.Last(10)
+	require.Len(t, requests, 2)
+	assert.Equal(t, "third", requests[0].CorrelationId)
+	assert.Equal(t, "second", requests[1].CorrelationId)
+}
+
+func TestLog_Clear(t *testing.T) {
+	log := apirequests.NewLog(0)
+	log.Add("list_environments", time.Now(), []sdk.SdkCall{sdkCall("first")})
+
+	log.Clear()
+
+	assert.NotNil(t, log.Last(10))
+	assert.Empty(t, log.Last(10))
+}
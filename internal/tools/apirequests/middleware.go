@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package apirequests
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+// MetaKey is the key of the correlation IDs of the PingOne API requests of a tool call in the _meta of its result.
+const MetaKey = "pingidentity.com/correlationIds"
+
+// ApiRequestsMiddleware records the PingOne API requests made during each tool call in the log, and attaches
+// their correlation IDs to the tool result's _meta, so that they can be quoted in PingOne support cases.
+//
+// Calls that fail with a protocol error have no result to attach the correlation IDs to, so their requests are
+// only recorded in the log.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after the tool trace middleware,
+// whose trace it shares, and before any middleware that makes PingOne API requests, such as environment
+// validation, so that those requests are recorded too.
+type ApiRequestsMiddleware struct {
+	log *Log
+}
+
+// NewApiRequestsMiddleware creates middleware that records the requests of tool calls in the log.
+func NewApiRequestsMiddleware(log *Log) *ApiRequestsMiddleware {
+	return &ApiRequestsMiddleware{
+		log: log,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ApiRequestsMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if m.log == nil || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		// A context carries a single trace, so the trace of the tool trace middleware is shared when it is enabled
+		callTrace := sdk.CallTraceFromContext(ctx)
+		if callTrace == nil {
+			callTrace = sdk.NewCallTrace()
+			ctx = sdk.ContextWithCallTrace(ctx, callTrace)
+		}
+		previousCalls := len(callTrace.Calls())
+
+		result, err := next(ctx, method, req)
+		calls := callTrace.Calls()[previousCalls:]
+		m.log.Add(callToolReq.Params.Name, time.Now(), calls)
+
+		correlationIds := []string{}
+		for _, call := range calls {
+			if call.CorrelationId != "" {
+				correlationIds = append(correlationIds, call.CorrelationId)
+			}
+		}
+		if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult != nil && len(correlationIds) > 0 {
+			if callToolResult.Meta == nil {
+				callToolResult.Meta = mcp.Meta{}
+			}
+			callToolResult.Meta[MetaKey] = correlationIds
+		}
+
+		return result, err
+	}
+}
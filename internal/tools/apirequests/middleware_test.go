@@ -0,0 +1,107 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package apirequests_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/apirequests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callToolRequest(toolName string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: toolName},
+	}
+}
+
+// requestingHandler makes a traced request to the server for each correlation ID, which the server responds with
+func requestingHandler(t *testing.T, correlationIds []string, err error) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		for _, correlationId := range correlationIds {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if correlationId != "" {
+					w.Header().Set(sdk.CorrelationIdHeader, correlationId)
+				}
+			}))
+			httpReq, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/environments", nil)
+			require.NoError(t, reqErr)
+			resp, reqErr := (&http.Client{Transport: sdk.NewTracingTransport(http.DefaultTransport)}).Do(httpReq)
+			require.NoError(t, reqErr)
+			_ = resp.Body.Close()
+			server.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+	}
+}
+
+func TestApiRequestsMiddleware_RecordsRequestsAndAttachesCorrelationIds(t *testing.T) {
+	log := apirequests.NewLog(0)
+	handler := apirequests.NewApiRequestsMiddleware(log).Handler(requestingHandler(t, []string{"first", "", "third"}, nil))
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("list_environments"))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "third"}, result.(*mcp.CallToolResult).Meta[apirequests.MetaKey])
+	requests := log.Last(10)
+	require.Len(t, requests, 3)
+	assert.Equal(t, "third", requests[0].CorrelationId)
+	assert.Equal(t, "list_environments", requests[0].Tool)
+	assert.Equal(t, http.StatusOK, requests[0].StatusCode)
+}
+
+func TestApiRequestsMiddleware_SharesExistingTrace(t *testing.T) {
+	log := apirequests.NewLog(0)
+	callTrace := sdk.NewCallTrace()
+	handler := apirequests.NewApiRequestsMiddleware(log).Handler(requestingHandler(t, []string{"first"}, nil))
+
+	_, err := handler(sdk.ContextWithCallTrace(context.Background(), callTrace), "tools/call", callToolRequest("list_environments"))
+
+	require.NoError(t, err)
+	require.Len(t, callTrace.Calls(), 1, "the requests should be recorded in the existing trace")
+	assert.Len(t, log.Last(10), 1)
+}
+
+func TestApiRequestsMiddleware_NoCorrelationIds(t *testing.T) {
+	handler := apirequests.NewApiRequestsMiddleware(apirequests.NewLog(0)).Handler(requestingHandler(t, nil, nil))
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("get_last_api_requests"))
+
+	require.NoError(t, err)
+	assert.NotContains(t, result.(*mcp.CallToolResult).Meta, apirequests.MetaKey)
+}
+
+func TestApiRequestsMiddleware_RecordsRequestsOfFailedCalls(t *testing.T) {
+	log := apirequests.NewLog(0)
+	handler := apirequests.NewApiRequestsMiddleware(log).Handler(requestingHandler(t, []string{"first"}, errors.New("validation failed")))
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("update_environment"))
+
+	assert.EqualError(t, err, "validation failed")
+	assert.Nil(t, result)
+	requests := log.Last(10)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "first", requests[0].CorrelationId)
+}
+
+func TestApiRequestsMiddleware_NilLog(t *testing.T) {
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		assert.Nil(t, sdk.CallTraceFromContext(ctx))
+		return &mcp.CallToolResult{}, nil
+	}
+	handler := apirequests.NewApiRequestsMiddleware(nil).Handler(next)
+
+	_, err := handler(context.Background(), "tools/call", callToolRequest("list_environments"))
+
+	require.NoError(t, err)
+}
@@ -0,0 +1,74 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package apirequests
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const defaultLimit = 10
+
+var GetLastApiRequestsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_last_api_requests",
+		Title: "Get Last API Requests",
+		Description: `Get the last PingOne API requests made by tool calls on this server, most recent first, with the tool that made each request, its HTTP status, its latency and the correlation ID PingOne assigned to it. Use when the user needs to open a support case with Ping Identity about a failed or slow call, as PingOne support can find a request by its correlation ID, or to find out which requests made a tool call slow.
+
+Only the last 100 requests are kept in memory, and they are lost when the server restarts or switches profile. Request URLs are returned without their query, as filters can hold personal data.`,
+		InputSchema:  schema.MustGenerateSchema[GetLastApiRequestsInput](),
+		OutputSchema: schema.MustGenerateSchema[GetLastApiRequestsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetLastApiRequestsInput struct {
+	Limit  *int     `json:"limit,omitempty" jsonschema:"OPTIONAL. The maximum number of requests to return, from 1 to 100. Defaults to 10."`
+	Fields []string `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'requests.correlationId' and 'requests.durationMs'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetLastApiRequestsOutput struct {
+	Requests []Request `json:"requests" jsonschema:"The last PingOne API requests, most recent first"`
+}
+
+// GetLastApiRequestsHandler returns the last requests of the log
+func GetLastApiRequestsHandler(log *Log) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetLastApiRequestsInput,
+) (
+	*mcp.CallToolResult,
+	*GetLastApiRequestsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetLastApiRequestsInput) (*mcp.CallToolResult, *GetLastApiRequestsOutput, error) {
+		limit := defaultLimit
+		if input.Limit != nil {
+			limit = *input.Limit
+		}
+		if limit < 1 || limit > log.MaxRequests() {
+			toolErr := errs.NewToolError(GetLastApiRequestsDef.McpTool.Name, fmt.Errorf("limit must be between 1 and %d, got %d", log.MaxRequests(), limit))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &GetLastApiRequestsOutput{
+			Requests: log.Last(limit),
+		}, nil
+	}
+}
+
+// RegisterGetLastApiRequestsTool adds the get_last_api_requests tool to the MCP server.
+func RegisterGetLastApiRequestsTool(server *mcp.Server, log *Log) {
+	mcp.AddTool(server, GetLastApiRequestsDef.McpTool, GetLastApiRequestsHandler(log))
+}
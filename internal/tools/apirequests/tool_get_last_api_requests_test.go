@@ -0,0 +1,62 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package apirequests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/apirequests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLastApiRequestsHandler(t *testing.T) {
+	log := apirequests.NewLog(0)
+	for _, correlationId := range []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"} {
+		log.Add("list_environments", time.Now(), []sdk.SdkCall{sdkCall(correlationId)})
+	}
+	handler := apirequests.GetLastApiRequestsHandler(log)
+
+	t.Run("Default limit", func(t *testing.T) {
+		mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, apirequests.GetLastApiRequestsInput{})
+
+		testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+		require.Len(t, output.Requests, 10)
+		assert.Equal(t, "12", output.Requests[0].CorrelationId)
+		assert.Equal(t, "3", output.Requests[9].CorrelationId)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, apirequests.GetLastApiRequestsInput{Limit: testutils.Pointer(2)})
+
+		testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+		require.Len(t, output.Requests, 2)
+		assert.Equal(t, "12", output.Requests[0].CorrelationId)
+		assert.Equal(t, "11", output.Requests[1].CorrelationId)
+	})
+
+	t.Run("Invalid limit", func(t *testing.T) {
+		for _, limit := range []int{0, apirequests.DefaultMaxRequests + 1} {
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, apirequests.GetLastApiRequestsInput{Limit: testutils.Pointer(limit)})
+
+			assert.ErrorContains(t, err, "limit must be between 1 and 100")
+			assert.Nil(t, mcpResult)
+			assert.Nil(t, output)
+		}
+	})
+}
+
+func TestGetLastApiRequestsHandler_NoRequests(t *testing.T) {
+	handler := apirequests.GetLastApiRequestsHandler(apirequests.NewLog(0))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, apirequests.GetLastApiRequestsInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Requests)
+	assert.Empty(t, output.Requests)
+}
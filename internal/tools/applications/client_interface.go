@@ -15,6 +15,8 @@ type ApplicationsClient interface {
 	CreateApplication(ctx context.Context, environmentId uuid.UUID, app management.CreateApplicationRequest) (*management.CreateApplication201Response, *http.Response, error)
 	GetApplication(ctx context.Context, environmentId uuid.UUID, applicationId uuid.UUID) (*management.ReadOneApplication200Response, *http.Response, error)
 	UpdateApplication(ctx context.Context, environmentId uuid.UUID, applicationId uuid.UUID, app management.UpdateApplicationRequest) (*management.ReadOneApplication200Response, *http.Response, error)
+	GetOpenIDConfiguration(ctx context.Context, environmentId uuid.UUID) (map[string]any, *http.Response, error)
+	GetJwks(ctx context.Context, jwksUri string) (map[string]any, *http.Response, error)
 }
 
 type ApplicationsClientFactory interface {
@@ -3,8 +3,12 @@
 package applications
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 
@@ -102,3 +106,58 @@ func (p *PingOneClientApplicationsWrapper) UpdateApplication(ctx context.Context
 	)
 	return updateRequest.Execute()
 }
+
+// maxDiscoveryResponseSize limits the size of discovery documents and key sets read from the authorization server
+const maxDiscoveryResponseSize = 1 << 20
+
+func (p *PingOneClientApplicationsWrapper) GetOpenIDConfiguration(ctx context.Context, environmentId uuid.UUID) (map[string]any, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	discoveryUrl := fmt.Sprintf("https://auth.pingone.%s/%s/as/.well-known/openid-configuration", p.client.Region.URLSuffix, environmentId.String())
+	logger.FromContext(ctx).Debug("Retrieving OpenID Connect discovery document",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return p.getJson(ctx, discoveryUrl)
+}
+
+func (p *PingOneClientApplicationsWrapper) GetJwks(ctx context.Context, jwksUri string) (map[string]any, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	logger.FromContext(ctx).Debug("Retrieving JSON Web Key Set",
+		slog.String("jwksUri", jwksUri),
+	)
+	return p.getJson(ctx, jwksUri)
+}
+
+// getJson retrieves a public JSON document from the PingOne authorization server. The management API's HTTP client
+// is used so that requests are retried in the same way, but no access token is sent as the documents are public.
+func (p *PingOneClientApplicationsWrapper) getJson(ctx context.Context, url string) (map[string]any, *http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	httpResponse, err := p.client.ManagementAPIClient.GetConfig().HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxDiscoveryResponseSize))
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	// Restore the body so that it can be included in API errors
+	httpResponse.Body = io.NopCloser(bytes.NewReader(body))
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, httpResponse, fmt.Errorf("GET %s returned %s", url, httpResponse.Status)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, httpResponse, fmt.Errorf("failed to parse the response from %s: %w", url, err)
+	}
+	return document, httpResponse, nil
+}
@@ -56,6 +56,11 @@ func (c *ApplicationsCollection) RegisterTools(ctx context.Context, server *mcp.
 		mcp.AddTool(server, UpdateApplicationDef.McpTool, UpdateApplicationHandler(applicationsClientFactory))
 	}
 
+	if toolFilter.ShouldIncludeTool(&GetOidcDiscoveryDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetOidcDiscoveryDef.McpTool.Name))
+		mcp.AddTool(server, GetOidcDiscoveryDef.McpTool, GetOidcDiscoveryHandler(applicationsClientFactory))
+	}
+
 	return nil
 }
 
@@ -65,5 +70,6 @@ func (c *ApplicationsCollection) ListTools() []types.ToolDefinition {
 		GetApplicationDef,
 		CreateApplicationDef,
 		UpdateApplicationDef,
+		GetOidcDiscoveryDef,
 	}
 }
@@ -75,6 +75,7 @@ func TestApplicationsCollection_RegisterTools_ReadOnlyToolsMarkedCorrectly(t *te
 	readOnlyTools := []string{
 		"list_applications",
 		"get_application",
+		"get_oidc_discovery",
 	}
 
 	// Define known write tools
@@ -90,3 +90,17 @@ func (p *mockPingOneClientApplicationsWrapper) UpdateApplication(ctx context.Con
 	}
 	return response, httpResponse, args.Error(2)
 }
+
+func (p *mockPingOneClientApplicationsWrapper) GetOpenIDConfiguration(ctx context.Context, environmentId uuid.UUID) (map[string]any, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	response, _ := args.Get(0).(map[string]any)
+	httpResponse, _ := args.Get(1).(*http.Response)
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientApplicationsWrapper) GetJwks(ctx context.Context, jwksUri string) (map[string]any, *http.Response, error) {
+	args := p.Called(ctx, jwksUri)
+	response, _ := args.Get(0).(map[string]any)
+	httpResponse, _ := args.Get(1).(*http.Response)
+	return response, httpResponse, args.Error(2)
+}
@@ -35,6 +35,7 @@ var GetApplicationDef = types.ToolDefinition{
 type GetApplicationInput struct {
 	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment"`
 	ApplicationId uuid.UUID `json:"applicationId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne application"`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'name' and 'grantTypes'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 // GetApplicationHandler retrieves a PingOne application by ID using the provided client
@@ -0,0 +1,380 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package applications
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	CheckStatusPass = "PASS"
+	CheckStatusWarn = "WARN"
+	CheckStatusFail = "FAIL"
+
+	// minRsaKeySize is the smallest RSA signing key size considered strong enough
+	minRsaKeySize = 2048
+	// certificateExpiryWarningPeriod is how long before a signing certificate expires that it is reported
+	certificateExpiryWarningPeriod = 30 * 24 * time.Hour
+)
+
+var GetOidcDiscoveryDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_oidc_discovery",
+		Title: "Get PingOne OpenID Connect Discovery Document and Signing Keys",
+		Description: `Retrieve the OpenID Connect discovery document and the JSON Web Key Set (JWKS) of an environment's authorization server, as seen by relying parties, and run sanity checks on them: issuer and endpoint URLs, signing algorithms, signing key strength and signing certificate expiry.
+
+Provide 'applicationId' to also check that an OIDC application's token endpoint authentication method, grant types and response types are supported by the authorization server. Use when debugging integrations with relying parties, for example token validation or discovery failures. Checks with status FAIL or WARN explain the problem found.`,
+		InputSchema:  schema.MustGenerateSchema[GetOidcDiscoveryInput](),
+		OutputSchema: schema.MustGenerateSchema[GetOidcDiscoveryOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetOidcDiscoveryInput struct {
+	EnvironmentId uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment"`
+	ApplicationId *uuid.UUID `json:"applicationId,omitempty" jsonschema:"OPTIONAL. The unique identifier (UUID) string of an OIDC application in the environment, to check its configuration against the discovery document"`
+	Fields        []string   `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'checks' and 'discovery.issuer'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetOidcDiscoveryOutput struct {
+	Status    string           `json:"status" jsonschema:"The worst status of the checks: PASS, WARN or FAIL"`
+	Checks    []DiscoveryCheck `json:"checks" jsonschema:"The sanity checks run on the discovery document, signing keys and application"`
+	Keys      []SigningKey     `json:"keys" jsonschema:"A summary of each key in the JWKS"`
+	Discovery map[string]any   `json:"discovery" jsonschema:"The OpenID Connect discovery document"`
+	Jwks      map[string]any   `json:"jwks" jsonschema:"The JSON Web Key Set published at the discovery document's jwks_uri"`
+}
+
+type DiscoveryCheck struct {
+	Name    string `json:"name" jsonschema:"The check"`
+	Status  string `json:"status" jsonschema:"PASS, WARN or FAIL"`
+	Message string `json:"message" jsonschema:"What was found"`
+}
+
+type SigningKey struct {
+	Kid                  string     `json:"kid,omitempty" jsonschema:"The key ID"`
+	Kty                  string     `json:"kty" jsonschema:"The key type, such as RSA or EC"`
+	Alg                  string     `json:"alg,omitempty" jsonschema:"The signing algorithm of the key"`
+	Use                  string     `json:"use,omitempty" jsonschema:"The intended use of the key"`
+	KeySize              int        `json:"keySize,omitempty" jsonschema:"The RSA modulus size in bits"`
+	Curve                string     `json:"curve,omitempty" jsonschema:"The elliptic curve of EC keys"`
+	CertificateSubject   string     `json:"certificateSubject,omitempty" jsonschema:"The subject of the key's X.509 certificate"`
+	CertificateNotAfter  *time.Time `json:"certificateNotAfter,omitempty" jsonschema:"When the key's X.509 certificate expires"`
+	CertificateNotBefore *time.Time `json:"certificateNotBefore,omitempty" jsonschema:"When the key's X.509 certificate becomes valid"`
+}
+
+// GetOidcDiscoveryHandler retrieves and checks the discovery document and signing keys of an environment using the provided client
+func GetOidcDiscoveryHandler(applicationsClientFactory ApplicationsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetOidcDiscoveryInput,
+) (
+	*mcp.CallToolResult,
+	*GetOidcDiscoveryOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetOidcDiscoveryInput) (*mcp.CallToolResult, *GetOidcDiscoveryOutput, error) {
+		client, err := applicationsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetOidcDiscoveryDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Fetch the application first, so that an invalid application ID fails before the discovery document is fetched
+		var application *management.ApplicationOIDC
+		if input.ApplicationId != nil {
+			response, httpResponse, err := client.GetApplication(ctx, input.EnvironmentId, *input.ApplicationId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if response == nil {
+				apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no application data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if response.ApplicationOIDC == nil {
+				toolErr := errs.NewToolError(GetOidcDiscoveryDef.McpTool.Name, fmt.Errorf("application %s is not an OpenID Connect application", input.ApplicationId))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			application = response.ApplicationOIDC
+		}
+
+		discovery, httpResponse, err := client.GetOpenIDConfiguration(ctx, input.EnvironmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		jwksUri, _ := discovery["jwks_uri"].(string)
+		if jwksUri == "" {
+			toolErr := errs.NewToolError(GetOidcDiscoveryDef.McpTool.Name, fmt.Errorf("the discovery document has no jwks_uri"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		jwks, httpResponse, err := client.GetJwks(ctx, jwksUri)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		now := time.Now()
+		keys := summarizeKeys(jwks)
+		checks := checkDiscovery(discovery, input.EnvironmentId)
+		checks = append(checks, checkKeys(keys, now)...)
+		if application != nil {
+			checks = append(checks, checkApplication(application, discovery)...)
+		}
+
+		output := &GetOidcDiscoveryOutput{
+			Status:    worstStatus(checks),
+			Checks:    checks,
+			Keys:      keys,
+			Discovery: discovery,
+			Jwks:      jwks,
+		}
+
+		logger.FromContext(ctx).Debug("OpenID Connect discovery document checked",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("status", output.Status))
+
+		return nil, output, nil
+	}
+}
+
+// checkDiscovery checks the issuer, endpoints and ID token signing algorithms of the discovery document
+func checkDiscovery(discovery map[string]any, environmentId uuid.UUID) []DiscoveryCheck {
+	var checks []DiscoveryCheck
+
+	issuer, _ := discovery["issuer"].(string)
+	issuerUrl, err := url.Parse(issuer)
+	switch {
+	case issuer == "" || err != nil:
+		checks = append(checks, DiscoveryCheck{Name: "issuer", Status: CheckStatusFail, Message: "the discovery document has no valid issuer"})
+	case issuerUrl.Scheme != "https":
+		checks = append(checks, DiscoveryCheck{Name: "issuer", Status: CheckStatusFail, Message: fmt.Sprintf("the issuer %s does not use https", issuer)})
+	case !strings.Contains(issuerUrl.Path, environmentId.String()):
+		checks = append(checks, DiscoveryCheck{Name: "issuer", Status: CheckStatusWarn, Message: fmt.Sprintf("the issuer %s does not include the environment ID", issuer)})
+	default:
+		checks = append(checks, DiscoveryCheck{Name: "issuer", Status: CheckStatusPass, Message: fmt.Sprintf("the issuer is %s", issuer)})
+	}
+
+	var missing, insecure []string
+	for _, endpoint := range []string{"authorization_endpoint", "token_endpoint", "userinfo_endpoint", "jwks_uri"} {
+		value, _ := discovery[endpoint].(string)
+		if value == "" {
+			missing = append(missing, endpoint)
+		} else if !strings.HasPrefix(value, "https://") {
+			insecure = append(insecure, endpoint)
+		}
+	}
+	switch {
+	case len(insecure) > 0:
+		checks = append(checks, DiscoveryCheck{Name: "endpoints", Status: CheckStatusFail, Message: "endpoints that do not use https: " + strings.Join(insecure, ", ")})
+	case len(missing) > 0:
+		checks = append(checks, DiscoveryCheck{Name: "endpoints", Status: CheckStatusWarn, Message: "endpoints missing from the discovery document: " + strings.Join(missing, ", ")})
+	default:
+		checks = append(checks, DiscoveryCheck{Name: "endpoints", Status: CheckStatusPass, Message: "the authorization, token, userinfo and JWKS endpoints use https"})
+	}
+
+	algorithms := stringValues(discovery["id_token_signing_alg_values_supported"])
+	asymmetric := slices.ContainsFunc(algorithms, func(alg string) bool {
+		return alg != "none" && !strings.HasPrefix(alg, "HS")
+	})
+	switch {
+	case slices.Contains(algorithms, "none"):
+		checks = append(checks, DiscoveryCheck{Name: "id_token_signing_algorithms", Status: CheckStatusFail, Message: "unsigned ID tokens (alg none) are supported"})
+	case !asymmetric:
+		checks = append(checks, DiscoveryCheck{Name: "id_token_signing_algorithms", Status: CheckStatusWarn, Message: "no asymmetric ID token signing algorithm is supported, so relying parties cannot validate ID tokens with the JWKS"})
+	default:
+		checks = append(checks, DiscoveryCheck{Name: "id_token_signing_algorithms", Status: CheckStatusPass, Message: "supported ID token signing algorithms: " + strings.Join(algorithms, ", ")})
+	}
+	return checks
+}
+
+// checkKeys checks that the key set has signing keys, and the strength and certificate expiry of each key
+func checkKeys(keys []SigningKey, now time.Time) []DiscoveryCheck {
+	signingKeys := 0
+	for _, key := range keys {
+		if key.Use == "" || key.Use == "sig" {
+			signingKeys++
+		}
+	}
+	if signingKeys == 0 {
+		return []DiscoveryCheck{{Name: "signing_keys", Status: CheckStatusFail, Message: "the JWKS has no signing keys"}}
+	}
+	checks := []DiscoveryCheck{{Name: "signing_keys", Status: CheckStatusPass, Message: fmt.Sprintf("the JWKS has %d signing keys", signingKeys)}}
+
+	seenKids := map[string]bool{}
+	for _, key := range keys {
+		name := "key " + key.Kid
+		if seenKids[key.Kid] {
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusWarn, Message: "more than one key has this key ID, so relying parties cannot tell which key signed a token"})
+		}
+		seenKids[key.Kid] = true
+
+		switch {
+		case key.Kty == "oct":
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusFail, Message: "a symmetric key is published in the JWKS"})
+		case key.Kty == "RSA" && key.KeySize < minRsaKeySize:
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusFail, Message: fmt.Sprintf("the RSA key is %d bits, shorter than the minimum of %d bits", key.KeySize, minRsaKeySize)})
+		case key.Alg == "none" || strings.HasPrefix(key.Alg, "HS"):
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusFail, Message: fmt.Sprintf("the key's algorithm %s is not an asymmetric signing algorithm", key.Alg)})
+		case key.CertificateNotAfter != nil && now.After(*key.CertificateNotAfter):
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusFail, Message: fmt.Sprintf("the key's certificate expired at %s", key.CertificateNotAfter.Format(time.RFC3339))})
+		case key.CertificateNotBefore != nil && now.Before(*key.CertificateNotBefore):
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusWarn, Message: fmt.Sprintf("the key's certificate is not valid until %s", key.CertificateNotBefore.Format(time.RFC3339))})
+		case key.CertificateNotAfter != nil && now.Add(certificateExpiryWarningPeriod).After(*key.CertificateNotAfter):
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusWarn, Message: fmt.Sprintf("the key's certificate expires soon, at %s", key.CertificateNotAfter.Format(time.RFC3339))})
+		default:
+			checks = append(checks, DiscoveryCheck{Name: name, Status: CheckStatusPass, Message: "the key is a valid asymmetric signing key"})
+		}
+	}
+	return checks
+}
+
+// checkApplication checks that the authorization server supports the application's configuration
+func checkApplication(application *management.ApplicationOIDC, discovery map[string]any) []DiscoveryCheck {
+	var checks []DiscoveryCheck
+
+	authMethod := strings.ToLower(string(application.TokenEndpointAuthMethod))
+	if slices.Contains(stringValues(discovery["token_endpoint_auth_methods_supported"]), authMethod) {
+		checks = append(checks, DiscoveryCheck{Name: "application_token_endpoint_auth_method", Status: CheckStatusPass, Message: fmt.Sprintf("the token endpoint authentication method %s is supported", authMethod)})
+	} else {
+		checks = append(checks, DiscoveryCheck{Name: "application_token_endpoint_auth_method", Status: CheckStatusFail, Message: fmt.Sprintf("the token endpoint authentication method %s is not in token_endpoint_auth_methods_supported", authMethod)})
+	}
+	if application.TokenEndpointAuthMethod == management.ENUMAPPLICATIONOIDCTOKENAUTHMETHOD_PRIVATE_KEY_JWT &&
+		(application.Jwks == nil || *application.Jwks == "") && (application.JwksUrl == nil || *application.JwksUrl == "") {
+		checks = append(checks, DiscoveryCheck{Name: "application_client_keys", Status: CheckStatusFail, Message: "the application uses private_key_jwt but has no jwks or jwksUrl to validate its client assertions"})
+	}
+
+	supportedGrantTypes := stringValues(discovery["grant_types_supported"])
+	var unsupportedGrantTypes []string
+	for _, grantType := range application.GrantTypes {
+		if !slices.Contains(supportedGrantTypes, discoveryGrantType(grantType)) {
+			unsupportedGrantTypes = append(unsupportedGrantTypes, discoveryGrantType(grantType))
+		}
+	}
+	if len(unsupportedGrantTypes) > 0 {
+		checks = append(checks, DiscoveryCheck{Name: "application_grant_types", Status: CheckStatusFail, Message: "grant types not in grant_types_supported: " + strings.Join(unsupportedGrantTypes, ", ")})
+	} else {
+		checks = append(checks, DiscoveryCheck{Name: "application_grant_types", Status: CheckStatusPass, Message: "the application's grant types are supported"})
+	}
+
+	// Supported response types are space-separated combinations, such as "code id_token"
+	var supportedResponseTypes []string
+	for _, combination := range stringValues(discovery["response_types_supported"]) {
+		supportedResponseTypes = append(supportedResponseTypes, strings.Fields(combination)...)
+	}
+	var unsupportedResponseTypes []string
+	for _, responseType := range application.ResponseTypes {
+		if !slices.Contains(supportedResponseTypes, strings.ToLower(string(responseType))) {
+			unsupportedResponseTypes = append(unsupportedResponseTypes, strings.ToLower(string(responseType)))
+		}
+	}
+	if len(unsupportedResponseTypes) > 0 {
+		checks = append(checks, DiscoveryCheck{Name: "application_response_types", Status: CheckStatusFail, Message: "response types not in response_types_supported: " + strings.Join(unsupportedResponseTypes, ", ")})
+	} else {
+		checks = append(checks, DiscoveryCheck{Name: "application_response_types", Status: CheckStatusPass, Message: "the application's response types are supported"})
+	}
+	return checks
+}
+
+func discoveryGrantType(grantType management.EnumApplicationOIDCGrantType) string {
+	if grantType == management.ENUMAPPLICATIONOIDCGRANTTYPE_DEVICE_CODE {
+		return "urn:ietf:params:oauth:grant-type:device_code"
+	}
+	return strings.ToLower(string(grantType))
+}
+
+// summarizeKeys describes each key in the key set, including the validity period of its X.509 certificate if it has one
+func summarizeKeys(jwks map[string]any) []SigningKey {
+	rawKeys, _ := jwks["keys"].([]any)
+	keys := make([]SigningKey, 0, len(rawKeys))
+	for _, rawKey := range rawKeys {
+		jwk, ok := rawKey.(map[string]any)
+		if !ok {
+			continue
+		}
+		key := SigningKey{
+			Kid:   stringValue(jwk["kid"]),
+			Kty:   stringValue(jwk["kty"]),
+			Alg:   stringValue(jwk["alg"]),
+			Use:   stringValue(jwk["use"]),
+			Curve: stringValue(jwk["crv"]),
+		}
+		if modulus, err := base64.RawURLEncoding.DecodeString(stringValue(jwk["n"])); err == nil && key.Kty == "RSA" {
+			key.KeySize = new(big.Int).SetBytes(modulus).BitLen()
+		}
+		if chain := stringValues(jwk["x5c"]); len(chain) > 0 {
+			// Certificates in x5c use standard base64, not base64url
+			if der, err := base64.StdEncoding.DecodeString(chain[0]); err == nil {
+				if certificate, err := x509.ParseCertificate(der); err == nil {
+					key.CertificateSubject = certificate.Subject.String()
+					key.CertificateNotBefore = &certificate.NotBefore
+					key.CertificateNotAfter = &certificate.NotAfter
+				}
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func worstStatus(checks []DiscoveryCheck) string {
+	status := CheckStatusPass
+	for _, check := range checks {
+		switch check.Status {
+		case CheckStatusFail:
+			return CheckStatusFail
+		case CheckStatusWarn:
+			status = CheckStatusWarn
+		}
+	}
+	return status
+}
+
+func stringValue(value any) string {
+	s, _ := value.(string)
+	return s
+}
+
+func stringValues(value any) []string {
+	values, _ := value.([]any)
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
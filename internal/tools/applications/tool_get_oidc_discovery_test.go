@@ -0,0 +1,352 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package applications_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/applications"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testJwksUri = "https://auth.pingone.com/550e8400-e29b-41d4-a716-446655440000/as/jwks"
+
+func testDiscoveryDocument() map[string]any {
+	issuer := "https://auth.pingone.com/" + testEnvironmentId.String() + "/as"
+	return map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + "/authorize",
+		"token_endpoint":                        issuer + "/token",
+		"userinfo_endpoint":                     issuer + "/userinfo",
+		"jwks_uri":                              testJwksUri,
+		"id_token_signing_alg_values_supported": []any{"RS256"},
+		"token_endpoint_auth_methods_supported": []any{"client_secret_basic", "client_secret_post", "none"},
+		"grant_types_supported":                 []any{"authorization_code", "refresh_token", "client_credentials"},
+		"response_types_supported":              []any{"code", "id_token", "token id_token"},
+	}
+}
+
+// testJwk returns an RSA key of the size, with a certificate valid until notAfter
+func testJwk(t *testing.T, kid string, bits int, notAfter time.Time) map[string]any {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: kid},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return map[string]any{
+		"kid": kid,
+		"kty": "RSA",
+		"alg": "RS256",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   "AQAB",
+		"x5c": []any{base64.StdEncoding.EncodeToString(der)},
+	}
+}
+
+func findCheck(t *testing.T, output *applications.GetOidcDiscoveryOutput, name string) applications.DiscoveryCheck {
+	t.Helper()
+	for _, check := range output.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	require.Failf(t, "check not found", "no check named %s in %v", name, output.Checks)
+	return applications.DiscoveryCheck{}
+}
+
+func mockDiscoverySetup(m *mockPingOneClientApplicationsWrapper, discovery map[string]any, jwks map[string]any) {
+	m.On("GetOpenIDConfiguration", mock.Anything, testEnvironmentId).Return(discovery, &http.Response{StatusCode: 200}, nil)
+	m.On("GetJwks", mock.Anything, testJwksUri).Return(jwks, &http.Response{StatusCode: 200}, nil)
+}
+
+func TestGetOidcDiscoveryHandler_HealthyEnvironment(t *testing.T) {
+	notAfter := time.Now().Add(180 * 24 * time.Hour).UTC().Truncate(time.Second)
+	mockClient := &mockPingOneClientApplicationsWrapper{}
+	mockDiscoverySetup(mockClient, testDiscoveryDocument(), map[string]any{"keys": []any{testJwk(t, "signing-key", 2048, notAfter)}})
+	handler := applications.GetOidcDiscoveryHandler(NewMockPingOneClientApplicationsWrapperFactory(mockClient, nil))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, applications.GetOidcDiscoveryInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, applications.CheckStatusPass, output.Status, "checks: %v", output.Checks)
+	require.Len(t, output.Keys, 1)
+	assert.Equal(t, "signing-key", output.Keys[0].Kid)
+	assert.Equal(t, 2048, output.Keys[0].KeySize)
+	assert.Equal(t, "CN=signing-key", output.Keys[0].CertificateSubject)
+	require.NotNil(t, output.Keys[0].CertificateNotAfter)
+	assert.True(t, notAfter.Equal(*output.Keys[0].CertificateNotAfter))
+	assert.Equal(t, testJwksUri, output.Discovery["jwks_uri"])
+	assert.Len(t, output.Jwks["keys"], 1)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetOidcDiscoveryHandler_Problems(t *testing.T) {
+	tests := []struct {
+		name           string
+		modify         func(t *testing.T, discovery map[string]any, jwks map[string]any)
+		check          string
+		expectedStatus string
+		expectedText   string
+	}{
+		{
+			name: "unsigned ID tokens",
+			modify: func(t *testing.T, d, j map[string]any) {
+				d["id_token_signing_alg_values_supported"] = []any{"RS256", "none"}
+			},
+			check:          "id_token_signing_algorithms",
+			expectedStatus: applications.CheckStatusFail,
+			expectedText:   "alg none",
+		},
+		{
+			name:           "symmetric ID token signing only",
+			modify:         func(t *testing.T, d, j map[string]any) { d["id_token_signing_alg_values_supported"] = []any{"HS256"} },
+			check:          "id_token_signing_algorithms",
+			expectedStatus: applications.CheckStatusWarn,
+			expectedText:   "no asymmetric",
+		},
+		{
+			name: "issuer without https",
+			modify: func(t *testing.T, d, j map[string]any) {
+				d["issuer"] = "http://auth.pingone.com/" + testEnvironmentId.String() + "/as"
+			},
+			check:          "issuer",
+			expectedStatus: applications.CheckStatusFail,
+			expectedText:   "does not use https",
+		},
+		{
+			name: "issuer of another environment",
+			modify: func(t *testing.T, d, j map[string]any) {
+				d["issuer"] = "https://auth.pingone.com/" + uuid.NewString() + "/as"
+			},
+			check:          "issuer",
+			expectedStatus: applications.CheckStatusWarn,
+			expectedText:   "does not include the environment ID",
+		},
+		{
+			name:           "missing userinfo endpoint",
+			modify:         func(t *testing.T, d, j map[string]any) { delete(d, "userinfo_endpoint") },
+			check:          "endpoints",
+			expectedStatus: applications.CheckStatusWarn,
+			expectedText:   "userinfo_endpoint",
+		},
+		{
+			name: "weak RSA key",
+			modify: func(t *testing.T, d, j map[string]any) {
+				j["keys"] = []any{testJwk(t, "signing-key", 1024, time.Now().Add(365*24*time.Hour))}
+			},
+			check:          "key signing-key",
+			expectedStatus: applications.CheckStatusFail,
+			expectedText:   "1024 bits",
+		},
+		{
+			name: "expired certificate",
+			modify: func(t *testing.T, d, j map[string]any) {
+				j["keys"] = []any{testJwk(t, "signing-key", 2048, time.Now().Add(-time.Hour))}
+			},
+			check:          "key signing-key",
+			expectedStatus: applications.CheckStatusFail,
+			expectedText:   "certificate expired",
+		},
+		{
+			name: "certificate expiring soon",
+			modify: func(t *testing.T, d, j map[string]any) {
+				j["keys"] = []any{testJwk(t, "signing-key", 2048, time.Now().Add(7*24*time.Hour))}
+			},
+			check:          "key signing-key",
+			expectedStatus: applications.CheckStatusWarn,
+			expectedText:   "expires soon",
+		},
+		{
+			name: "symmetric key published",
+			modify: func(t *testing.T, d, j map[string]any) {
+				j["keys"] = append(j["keys"].([]any), map[string]any{"kid": "secret", "kty": "oct", "k": "c2VjcmV0"})
+			},
+			check:          "key secret",
+			expectedStatus: applications.CheckStatusFail,
+			expectedText:   "symmetric key",
+		},
+		{
+			name:           "no signing keys",
+			modify:         func(t *testing.T, d, j map[string]any) { j["keys"] = []any{} },
+			check:          "signing_keys",
+			expectedStatus: applications.CheckStatusFail,
+			expectedText:   "no signing keys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discovery := testDiscoveryDocument()
+			jwks := map[string]any{"keys": []any{testJwk(t, "signing-key", 2048, time.Now().Add(365*24*time.Hour))}}
+			tt.modify(t, discovery, jwks)
+			mockClient := &mockPingOneClientApplicationsWrapper{}
+			mockDiscoverySetup(mockClient, discovery, jwks)
+			handler := applications.GetOidcDiscoveryHandler(NewMockPingOneClientApplicationsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, applications.GetOidcDiscoveryInput{EnvironmentId: testEnvironmentId})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			check := findCheck(t, output, tt.check)
+			assert.Equal(t, tt.expectedStatus, check.Status)
+			assert.Contains(t, check.Message, tt.expectedText)
+			assert.Equal(t, tt.expectedStatus, output.Status)
+		})
+	}
+}
+
+func TestGetOidcDiscoveryHandler_Application(t *testing.T) {
+	privateKeyJwtApp := management.ReadOneApplication200Response{
+		ApplicationOIDC: &management.ApplicationOIDC{
+			Name:                    "Device App",
+			Enabled:                 true,
+			Protocol:                management.ENUMAPPLICATIONPROTOCOL_OPENID_CONNECT,
+			Type:                    management.ENUMAPPLICATIONTYPE_NATIVE_APP,
+			GrantTypes:              []management.EnumApplicationOIDCGrantType{management.ENUMAPPLICATIONOIDCGRANTTYPE_DEVICE_CODE},
+			ResponseTypes:           []management.EnumApplicationOIDCResponseType{management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE},
+			TokenEndpointAuthMethod: management.ENUMAPPLICATIONOIDCTOKENAUTHMETHOD_PRIVATE_KEY_JWT,
+		},
+	}
+	webAppOIDC := *testOIDCApp.ApplicationOIDC
+	webAppOIDC.ResponseTypes = []management.EnumApplicationOIDCResponseType{management.ENUMAPPLICATIONOIDCRESPONSETYPE_CODE, management.ENUMAPPLICATIONOIDCRESPONSETYPE_ID_TOKEN}
+	webApp := management.ReadOneApplication200Response{ApplicationOIDC: &webAppOIDC}
+
+	tests := []struct {
+		name             string
+		application      *management.ReadOneApplication200Response
+		expectedStatuses map[string]string
+	}{
+		{
+			name:        "supported configuration",
+			application: &webApp,
+			expectedStatuses: map[string]string{
+				"application_token_endpoint_auth_method": applications.CheckStatusPass,
+				"application_grant_types":                applications.CheckStatusPass,
+				"application_response_types":             applications.CheckStatusPass,
+			},
+		},
+		{
+			name:        "unsupported configuration",
+			application: &privateKeyJwtApp,
+			expectedStatuses: map[string]string{
+				"application_token_endpoint_auth_method": applications.CheckStatusFail,
+				"application_client_keys":                applications.CheckStatusFail,
+				"application_grant_types":                applications.CheckStatusFail,
+				"application_response_types":             applications.CheckStatusPass,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientApplicationsWrapper{}
+			mockGetApplicationSetup(mockClient, testEnvironmentId, testAppId, tt.application, 200, nil)
+			mockDiscoverySetup(mockClient, testDiscoveryDocument(), map[string]any{"keys": []any{testJwk(t, "signing-key", 2048, time.Now().Add(365*24*time.Hour))}})
+			handler := applications.GetOidcDiscoveryHandler(NewMockPingOneClientApplicationsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, applications.GetOidcDiscoveryInput{
+				EnvironmentId: testEnvironmentId,
+				ApplicationId: &testAppId,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			for name, status := range tt.expectedStatuses {
+				assert.Equal(t, status, findCheck(t, output, name).Status, name)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetOidcDiscoveryHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           applications.GetOidcDiscoveryInput
+		setupMock       func(*mockPingOneClientApplicationsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:  "not an OIDC application",
+			input: applications.GetOidcDiscoveryInput{EnvironmentId: testEnvironmentId, ApplicationId: &testAppId},
+			setupMock: func(m *mockPingOneClientApplicationsWrapper) {
+				mockGetApplicationSetup(m, testEnvironmentId, testAppId, &testSAMLApp, 200, nil)
+			},
+			wantErrContains: "is not an OpenID Connect application",
+		},
+		{
+			name:  "application not found",
+			input: applications.GetOidcDiscoveryInput{EnvironmentId: testEnvironmentId, ApplicationId: &testAppId},
+			setupMock: func(m *mockPingOneClientApplicationsWrapper) {
+				mockGetApplicationSetup(m, testEnvironmentId, testAppId, nil, 404, errors.New("application not found"))
+			},
+			wantErrContains: "application not found",
+		},
+		{
+			name:  "discovery document unavailable",
+			input: applications.GetOidcDiscoveryInput{EnvironmentId: testEnvironmentId},
+			setupMock: func(m *mockPingOneClientApplicationsWrapper) {
+				m.On("GetOpenIDConfiguration", mock.Anything, testEnvironmentId).Return(nil, &http.Response{StatusCode: 404}, errors.New("returned 404 Not Found"))
+			},
+			wantErrContains: "returned 404 Not Found",
+		},
+		{
+			name:  "discovery document without jwks_uri",
+			input: applications.GetOidcDiscoveryInput{EnvironmentId: testEnvironmentId},
+			setupMock: func(m *mockPingOneClientApplicationsWrapper) {
+				m.On("GetOpenIDConfiguration", mock.Anything, testEnvironmentId).Return(map[string]any{"issuer": "https://auth.pingone.com"}, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "the discovery document has no jwks_uri",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientApplicationsWrapper{}
+			tt.setupMock(mockClient)
+			handler := applications.GetOidcDiscoveryHandler(NewMockPingOneClientApplicationsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetOidcDiscoveryHandler_ViaMcp(t *testing.T) {
+	mockClient := &mockPingOneClientApplicationsWrapper{}
+	mockDiscoverySetup(mockClient, testDiscoveryDocument(), map[string]any{"keys": []any{testJwk(t, "signing-key", 2048, time.Now().Add(365*24*time.Hour))}})
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, applications.GetOidcDiscoveryDef.McpTool, applications.GetOidcDiscoveryHandler(NewMockPingOneClientApplicationsWrapperFactory(mockClient, nil)))
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, applications.GetOidcDiscoveryDef.McpTool.Name, applications.GetOidcDiscoveryInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertMcpCallSuccess(t, err, output)
+	structured, ok := output.StructuredContent.(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, applications.CheckStatusPass, structured["status"])
+	mockClient.AssertExpectations(t)
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 )
@@ -36,6 +38,7 @@ var ListApplicationsDef = types.ToolDefinition{
 
 type ListApplicationsInput struct {
 	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'applications.id' and 'applications.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 type ApplicationSummary struct {
@@ -47,7 +50,8 @@ type ApplicationSummary struct {
 }
 
 type ListApplicationsOutput struct {
-	Applications []ApplicationSummary `json:"applications" jsonschema:"List of applications with their configuration details"`
+	Applications   []ApplicationSummary  `json:"applications" jsonschema:"List of applications with their configuration details"`
+	ResultResource *resources.ResultLink `json:"resultResource,omitempty" jsonschema:"Present when the result is too large to return inline, in which case applications contains only the first page. Read the resource to page through the full result."`
 }
 
 // ListApplicationsHandler lists all PingOne applications using the provided client
@@ -104,9 +108,24 @@ func ListApplicationsHandler(clientFactory ApplicationsClientFactory) func(
 				}
 				result.Applications = append(result.Applications, *applicationSummary)
 			}
+			progress.ReportFetched(ctx, len(result.Applications), int(next.EntityArray.GetCount()), "applications")
 		}
 
-		return nil, &result, nil
+		// Return large results as a paged resource, when enabled, rather than inlining every item
+		result.Applications, result.ResultResource, err = resources.PageListResult(ctx, result.Applications)
+		if err != nil {
+			toolErr := errs.NewToolError(ListApplicationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		mcpResult, err := result.ResultResource.CallToolResult(ListApplicationsDef.McpTool.Name, result)
+		if err != nil {
+			toolErr := errs.NewToolError(ListApplicationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return mcpResult, &result, nil
 	}
 }
 
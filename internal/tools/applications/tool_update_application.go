@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -27,16 +28,17 @@ WORKFLOW - Required to avoid data loss:
 2. Modify only the fields you want to change
 3. Pass the complete merged object to this tool
 
-Omitted optional fields will be cleared.`,
+Omitted optional fields will be cleared. Pass the 'updatedAt' returned by 'get_application' as 'lastKnownUpdatedAt' so that the application is not replaced if someone else has changed it since.`,
 		InputSchema:  schema.MustGenerateSchema[UpdateApplicationInput](),
 		OutputSchema: schema.MustGenerateSchema[UpdateApplicationOutput](),
 	},
 }
 
 type UpdateApplicationInput struct {
-	EnvironmentId uuid.UUID                  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
-	ApplicationId uuid.UUID                  `json:"applicationId" jsonschema:"REQUIRED. Application UUID."`
-	Application   management.ApplicationOIDC `json:"application" jsonschema:"REQUIRED. The complete OIDC application config with modifications."`
+	EnvironmentId      uuid.UUID                  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ApplicationId      uuid.UUID                  `json:"applicationId" jsonschema:"REQUIRED. Application UUID."`
+	Application        management.ApplicationOIDC `json:"application" jsonschema:"REQUIRED. The complete OIDC application config with modifications."`
+	LastKnownUpdatedAt *time.Time                 `json:"lastKnownUpdatedAt,omitempty" jsonschema:"OPTIONAL. The updatedAt of the application as last read. If the application has been updated since, it is not replaced and the call fails with a CONFLICT error."`
 }
 
 type UpdateApplicationOutput struct {
@@ -65,6 +67,14 @@ func UpdateApplicationHandler(applicationsClientFactory ApplicationsClientFactor
 			slog.String("applicationId", input.ApplicationId.String()),
 		)
 
+		if input.LastKnownUpdatedAt != nil {
+			if err := checkApplicationNotChangedSince(ctx, client, input.EnvironmentId, input.ApplicationId, *input.LastKnownUpdatedAt); err != nil {
+				toolErr := errs.NewToolError(UpdateApplicationDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+
 		updateRequest := management.UpdateApplicationRequest{
 			ApplicationOIDC: &input.Application,
 		}
@@ -99,3 +109,20 @@ func UpdateApplicationHandler(applicationsClientFactory ApplicationsClientFactor
 		return nil, result, nil
 	}
 }
+
+// checkApplicationNotChangedSince reads an OIDC application, and returns a ConflictError if it has been updated
+// since lastKnownUpdatedAt
+func checkApplicationNotChangedSince(ctx context.Context, client ApplicationsClient, environmentId uuid.UUID, applicationId uuid.UUID, lastKnownUpdatedAt time.Time) error {
+	current, httpResponse, err := client.GetApplication(ctx, environmentId, applicationId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		return errs.NewApiError(httpResponse, err)
+	}
+	if current == nil {
+		return errs.NewApiError(httpResponse, fmt.Errorf("no application data in response"))
+	}
+	if current.ApplicationOIDC == nil {
+		return fmt.Errorf("application %s is not an OpenID Connect application", applicationId)
+	}
+	return errs.CheckNotChangedSince("application", applicationId.String(), lastKnownUpdatedAt, current.ApplicationOIDC.UpdatedAt)
+}
@@ -8,10 +8,12 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
 	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
@@ -282,3 +284,66 @@ func TestUpdateApplicationHandler_RealClient(t *testing.T) {
 
 	// Expected values to be compared here
 }
+
+func TestUpdateApplicationHandler_LastKnownUpdatedAt(t *testing.T) {
+	appID := uuid.MustParse(*testOIDCApp.ApplicationOIDC.Id)
+	lastKnownUpdatedAt := *testOIDCApp.ApplicationOIDC.UpdatedAt
+
+	tests := []struct {
+		name            string
+		current         management.ReadOneApplication200Response
+		wantReplaced    bool
+		wantConflict    bool
+		wantErrContains string
+	}{
+		{
+			name:         "Replaces an application not changed since",
+			current:      testOIDCApp,
+			wantReplaced: true,
+		},
+		{
+			name: "Does not replace an application changed since",
+			current: func() management.ReadOneApplication200Response {
+				changed := *testOIDCApp.ApplicationOIDC
+				changed.UpdatedAt = testutils.Pointer(lastKnownUpdatedAt.Add(time.Hour))
+				return management.ReadOneApplication200Response{ApplicationOIDC: &changed}
+			}(),
+			wantConflict:    true,
+			wantErrContains: "has been changed since it was read",
+		},
+		{
+			name:            "Does not replace an application that is not OIDC",
+			current:         management.ReadOneApplication200Response{ApplicationSAML: &management.ApplicationSAML{}},
+			wantErrContains: "is not an OpenID Connect application",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientApplicationsWrapper{}
+			mockClient.On("GetApplication", mock.Anything, testEnvironmentId, appID).Return(&tc.current, &http.Response{StatusCode: 200}, nil).Once()
+			if tc.wantReplaced {
+				mockUpdateApplicationSetup(mockClient, testEnvironmentId, appID, &testOIDCApp, 200, nil)
+			}
+
+			handler := applications.UpdateApplicationHandler(NewMockPingOneClientApplicationsWrapperFactory(mockClient, nil))
+			_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, applications.UpdateApplicationInput{
+				EnvironmentId:      testEnvironmentId,
+				ApplicationId:      appID,
+				Application:        *testOIDCApp.ApplicationOIDC,
+				LastKnownUpdatedAt: &lastKnownUpdatedAt,
+			})
+
+			if tc.wantReplaced {
+				require.NoError(t, err)
+				assertOIDCApplicationMatches(t, testOIDCApp.ApplicationOIDC, &output.Application)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrContains)
+				var conflictErr *errs.ConflictError
+				assert.Equal(t, tc.wantConflict, errors.As(err, &conflictErr))
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+type AuditActivity struct {
+	Id            string                  `json:"id" jsonschema:"The unique identifier of the audit activity"`
+	RecordedAt    string                  `json:"recordedAt" jsonschema:"The time the activity was recorded"`
+	CorrelationId *string                 `json:"correlationId,omitempty" jsonschema:"The correlation ID linking related activities"`
+	Action        AuditActivityAction     `json:"action" jsonschema:"The action that was performed"`
+	Actors        *AuditActivityActors    `json:"actors,omitempty" jsonschema:"The user and/or client that performed the action"`
+	Resources     []AuditActivityResource `json:"resources,omitempty" jsonschema:"The resources affected by the action"`
+	Result        *AuditActivityResult    `json:"result,omitempty" jsonschema:"The outcome of the action"`
+}
+
+type AuditActivityAction struct {
+	Type        string  `json:"type" jsonschema:"The action type, for example USER.DELETED"`
+	Description *string `json:"description,omitempty" jsonschema:"A description of the action"`
+}
+
+type AuditActivityActors struct {
+	Client *AuditActivityActor `json:"client,omitempty" jsonschema:"The client application that performed the action"`
+	User   *AuditActivityActor `json:"user,omitempty" jsonschema:"The user that performed the action"`
+}
+
+type AuditActivityActor struct {
+	Id   *string `json:"id,omitempty" jsonschema:"The unique identifier of the actor"`
+	Name *string `json:"name,omitempty" jsonschema:"The name of the actor"`
+	Type *string `json:"type,omitempty" jsonschema:"The type of the actor"`
+}
+
+type AuditActivityResource struct {
+	Id   *string `json:"id,omitempty" jsonschema:"The unique identifier of the resource"`
+	Name *string `json:"name,omitempty" jsonschema:"The name of the resource"`
+	Type *string `json:"type,omitempty" jsonschema:"The type of the resource"`
+}
+
+type AuditActivityResult struct {
+	Status      *string `json:"status,omitempty" jsonschema:"The result status, for example SUCCESS or FAILED"`
+	Description *string `json:"description,omitempty" jsonschema:"A description of the result"`
+}
@@ -0,0 +1,168 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+const (
+	AuditEventsGroupByActor  = "actor"
+	AuditEventsGroupByAction = "action"
+	AuditEventsGroupByResult = "result"
+
+	AuditEventsTimeBucketHour = "hour"
+	AuditEventsTimeBucketDay  = "day"
+
+	// unknownAuditEventsGroupKey is used when an activity has no value for the grouped attribute
+	unknownAuditEventsGroupKey = "UNKNOWN"
+)
+
+type AuditEventsGroup struct {
+	Key         *string `json:"key,omitempty" jsonschema:"The actor, action type or result status of the group, when grouping by an attribute"`
+	BucketStart *string `json:"bucketStart,omitempty" jsonschema:"The start of the time bucket of the group (RFC 3339), when bucketing by time"`
+	Count       int     `json:"count" jsonschema:"The number of events in the group"`
+}
+
+// auditEventsAggregator counts audit activities by an attribute and/or time bucket,
+// so that trends can be reported without returning the raw events
+type auditEventsAggregator struct {
+	groupBy    string
+	timeBucket string
+	counts     map[auditEventsGroupId]int
+}
+
+type auditEventsGroupId struct {
+	key         string
+	bucketStart time.Time
+}
+
+func newAuditEventsAggregator(groupBy *string, timeBucket *string) (*auditEventsAggregator, error) {
+	aggregator := &auditEventsAggregator{
+		counts: map[auditEventsGroupId]int{},
+	}
+
+	if groupBy != nil && *groupBy != "" {
+		switch *groupBy {
+		case AuditEventsGroupByActor, AuditEventsGroupByAction, AuditEventsGroupByResult:
+			aggregator.groupBy = *groupBy
+		default:
+			return nil, fmt.Errorf("groupBy must be one of %s, %s or %s", AuditEventsGroupByActor, AuditEventsGroupByAction, AuditEventsGroupByResult)
+		}
+	}
+
+	if timeBucket != nil && *timeBucket != "" {
+		switch *timeBucket {
+		case AuditEventsTimeBucketHour, AuditEventsTimeBucketDay:
+			aggregator.timeBucket = *timeBucket
+		default:
+			return nil, fmt.Errorf("timeBucket must be one of %s or %s", AuditEventsTimeBucketHour, AuditEventsTimeBucketDay)
+		}
+	}
+
+	if aggregator.groupBy == "" && aggregator.timeBucket == "" {
+		return nil, nil
+	}
+
+	return aggregator, nil
+}
+
+func (a *auditEventsAggregator) Add(activity AuditActivity) error {
+	id := auditEventsGroupId{}
+
+	if a.groupBy != "" {
+		id.key = groupKey(activity, a.groupBy)
+	}
+
+	if a.timeBucket != "" {
+		recordedAt, err := time.Parse(time.RFC3339, activity.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("audit activity %s has an invalid recordedAt timestamp: %w", activity.Id, err)
+		}
+		id.bucketStart = bucketStart(recordedAt.UTC(), a.timeBucket)
+	}
+
+	a.counts[id]++
+	return nil
+}
+
+// Groups returns the counted groups ordered by time bucket, then by descending count
+func (a *auditEventsAggregator) Groups() []AuditEventsGroup {
+	ids := make([]auditEventsGroupId, 0, len(a.counts))
+	for id := range a.counts {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(x, y auditEventsGroupId) int {
+		if c := x.bucketStart.Compare(y.bucketStart); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.counts[y], a.counts[x]); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.key, y.key)
+	})
+
+	groups := make([]AuditEventsGroup, 0, len(ids))
+	for _, id := range ids {
+		group := AuditEventsGroup{
+			Count: a.counts[id],
+		}
+		if a.groupBy != "" {
+			key := id.key
+			group.Key = &key
+		}
+		if a.timeBucket != "" {
+			bucketStart := id.bucketStart.Format(time.RFC3339)
+			group.BucketStart = &bucketStart
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func groupKey(activity AuditActivity, groupBy string) string {
+	switch groupBy {
+	case AuditEventsGroupByActor:
+		if activity.Actors != nil {
+			if key := actorKey(activity.Actors.User); key != "" {
+				return key
+			}
+			if key := actorKey(activity.Actors.Client); key != "" {
+				return key
+			}
+		}
+	case AuditEventsGroupByAction:
+		if activity.Action.Type != "" {
+			return activity.Action.Type
+		}
+	case AuditEventsGroupByResult:
+		if activity.Result != nil && activity.Result.Status != nil && *activity.Result.Status != "" {
+			return *activity.Result.Status
+		}
+	}
+	return unknownAuditEventsGroupKey
+}
+
+// actorKey identifies an actor by name where available, as that is more meaningful when discussing trends
+func actorKey(actor *AuditActivityActor) string {
+	if actor == nil {
+		return ""
+	}
+	if actor.Name != nil && *actor.Name != "" {
+		return *actor.Name
+	}
+	if actor.Id != nil {
+		return *actor.Id
+	}
+	return ""
+}
+
+func bucketStart(recordedAt time.Time, timeBucket string) time.Time {
+	if timeBucket == AuditEventsTimeBucketDay {
+		return time.Date(recordedAt.Year(), recordedAt.Month(), recordedAt.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return recordedAt.Truncate(time.Hour)
+}
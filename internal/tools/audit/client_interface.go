@@ -0,0 +1,52 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"context"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type AuditClient interface {
+	GetAuditActivities(ctx context.Context, environmentId uuid.UUID, filter string, limit *int32) (AuditActivitiesPagedIterator, error)
+	GetSubscription(ctx context.Context, environmentId uuid.UUID, subscriptionId uuid.UUID) (*management.Subscription, *http.Response, error)
+	GetResource(ctx context.Context, environmentId uuid.UUID, resourceType string, resourceId uuid.UUID) (map[string]any, *http.Response, error)
+}
+
+type AuditClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (AuditClient, error)
+}
+
+// AuditActivitiesPage is a single page of results from the PingOne audit activities API.
+// The legacy SDK does not model this response, so it is decoded directly from the response body.
+type AuditActivitiesPage struct {
+	Embedded *AuditActivitiesPageEmbedded            `json:"_embedded,omitempty"`
+	Links    map[string]management.LinksHATEOASValue `json:"_links,omitempty"`
+}
+
+type AuditActivitiesPageEmbedded struct {
+	Activities []AuditActivity `json:"activities"`
+}
+
+// NextLink returns the HAL link to the next page of results, or nil when on the last page
+func (p *AuditActivitiesPage) NextLink() *management.LinksHATEOASValue {
+	if p == nil || p.Links == nil {
+		return nil
+	}
+	next, ok := p.Links["next"]
+	if !ok || next.Href == "" {
+		return nil
+	}
+	return &next
+}
+
+type AuditActivitiesPagedCursor struct {
+	Page         *AuditActivitiesPage
+	HTTPResponse *http.Response
+}
+
+type AuditActivitiesPagedIterator iter.Seq2[AuditActivitiesPagedCursor, error]
@@ -0,0 +1,194 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ AuditClient = &PingOneClientAuditWrapper{}
+var _ AuditClientFactory = &PingOneClientAuditWrapperFactory{}
+
+type PingOneClientAuditWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientAuditWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientAuditWrapper(client *pingone.Client) *PingOneClientAuditWrapper {
+	return &PingOneClientAuditWrapper{client: client}
+}
+
+func NewPingOneClientAuditWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientAuditWrapperFactory {
+	return &PingOneClientAuditWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientAuditWrapperFactory) GetAuthenticatedClient(ctx context.Context) (AuditClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientAuditWrapper(client), nil
+}
+
+func (p *PingOneClientAuditWrapper) GetAuditActivities(ctx context.Context, environmentId uuid.UUID, filter string, limit *int32) (AuditActivitiesPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.AuditActivitiesApi.EnvironmentsEnvironmentIDActivitiesGet(ctx, environmentId.String()).Filter(filter)
+
+	if limit != nil {
+		getRequest = getRequest.Limit(*limit)
+	}
+
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve audit activities",
+		slog.String("environmentId", environmentId.String()),
+	)
+
+	return func(yield func(AuditActivitiesPagedCursor, error) bool) {
+		cursor := AuditActivitiesPagedCursor{}
+		var err error
+		cursor.HTTPResponse, err = getRequest.Execute()
+		if err == nil {
+			cursor.Page, err = decodeAuditActivitiesPage(cursor.HTTPResponse)
+		}
+		if !yield(cursor, err) || err != nil {
+			return
+		}
+
+		for next := cursor.Page.NextLink(); next != nil; next = cursor.Page.NextLink() {
+			cursor = AuditActivitiesPagedCursor{}
+
+			nextRequest := p.client.ManagementAPIClient.HALApi.ReadHALLink(ctx, *next)
+			nextRequest = nextRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+			nextRequest = nextRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+			logger.FromContext(ctx).Debug("Calling PingOne API to retrieve next page of audit activities",
+				slog.String("environmentId", environmentId.String()),
+			)
+
+			var halResponse any
+			halResponse, cursor.HTTPResponse, err = nextRequest.Execute()
+			if err == nil {
+				cursor.Page, err = convertAuditActivitiesPage(halResponse)
+			}
+			if !yield(cursor, err) || err != nil {
+				return
+			}
+		}
+	}, nil
+}
+
+func (p *PingOneClientAuditWrapper) GetSubscription(ctx context.Context, environmentId uuid.UUID, subscriptionId uuid.UUID) (*management.Subscription, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.SubscriptionsWebhooksApi.ReadOneSubscription(ctx, environmentId.String(), subscriptionId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve webhook subscription by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("subscriptionId", subscriptionId.String()),
+	)
+	return getRequest.Execute()
+}
+
+// GetResource retrieves the current state of a resource of one of the ResourceStateTypes as a JSON object
+func (p *PingOneClientAuditWrapper) GetResource(ctx context.Context, environmentId uuid.UUID, resourceType string, resourceId uuid.UUID) (map[string]any, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve resource by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("resourceType", resourceType),
+		slog.String("resourceId", resourceId.String()),
+	)
+
+	api := p.client.ManagementAPIClient
+	sessionId, transactionId := audit.SessionIdFromContext(ctx), audit.TransactionIdFromContext(ctx)
+
+	var resource any
+	var httpResponse *http.Response
+	var err error
+	switch resourceType {
+	case ResourceTypeApplication:
+		resource, httpResponse, err = api.ApplicationsApi.ReadOneApplication(ctx, environmentId.String(), resourceId.String()).
+			XPingExternalSessionID(sessionId).XPingExternalTransactionID(transactionId).Execute()
+	case ResourceTypeGroup:
+		resource, httpResponse, err = api.GroupsApi.ReadOneGroup(ctx, environmentId.String(), resourceId.String()).
+			XPingExternalSessionID(sessionId).XPingExternalTransactionID(transactionId).Execute()
+	case ResourceTypePopulation:
+		resource, httpResponse, err = api.PopulationsApi.ReadOnePopulation(ctx, environmentId.String(), resourceId.String()).
+			XPingExternalSessionID(sessionId).XPingExternalTransactionID(transactionId).Execute()
+	case ResourceTypeUser:
+		resource, httpResponse, err = api.UsersApi.ReadUser(ctx, environmentId.String(), resourceId.String()).
+			XPingExternalSessionID(sessionId).XPingExternalTransactionID(transactionId).Execute()
+	default:
+		return nil, nil, fmt.Errorf("unsupported resource type %q", resourceType)
+	}
+	if err != nil {
+		return nil, httpResponse, err
+	}
+
+	bytes, err := json.Marshal(resource)
+	if err != nil {
+		return nil, httpResponse, fmt.Errorf("failed to decode resource response: %w", err)
+	}
+	state := map[string]any{}
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return nil, httpResponse, fmt.Errorf("failed to decode resource response: %w", err)
+	}
+	return state, httpResponse, nil
+}
+
+func decodeAuditActivitiesPage(httpResponse *http.Response) (*AuditActivitiesPage, error) {
+	if httpResponse == nil || httpResponse.Body == nil {
+		return nil, errors.New("no audit activities data in response")
+	}
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit activities response: %w", err)
+	}
+	page := &AuditActivitiesPage{}
+	if err := json.Unmarshal(body, page); err != nil {
+		return nil, fmt.Errorf("failed to decode audit activities response: %w", err)
+	}
+	return page, nil
+}
+
+func convertAuditActivitiesPage(halResponse any) (*AuditActivitiesPage, error) {
+	if halResponse == nil {
+		return nil, errors.New("no audit activities data in response")
+	}
+	bytes, err := json.Marshal(halResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode audit activities response: %w", err)
+	}
+	page := &AuditActivitiesPage{}
+	if err := json.Unmarshal(bytes, page); err != nil {
+		return nil, fmt.Errorf("failed to decode audit activities response: %w", err)
+	}
+	return page, nil
+}
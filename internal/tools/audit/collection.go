@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "audit"
+
+var _ collections.LegacySdkCollection = &AuditCollection{}
+
+type AuditCollection struct{}
+
+func (c *AuditCollection) Name() string {
+	return CollectionName
+}
+
+func (c *AuditCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	auditClientFactory := NewPingOneClientAuditWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&QueryAuditEventsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", QueryAuditEventsDef.McpTool.Name))
+		mcp.AddTool(server, QueryAuditEventsDef.McpTool, QueryAuditEventsHandler(auditClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&VerifyWebhookEventDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", VerifyWebhookEventDef.McpTool.Name))
+		mcp.AddTool(server, VerifyWebhookEventDef.McpTool, VerifyWebhookEventHandler(auditClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetResourceStateAsOfDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetResourceStateAsOfDef.McpTool.Name))
+		mcp.AddTool(server, GetResourceStateAsOfDef.McpTool, GetResourceStateAsOfHandler(auditClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetEnvironmentChangesSinceDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetEnvironmentChangesSinceDef.McpTool.Name))
+		mcp.AddTool(server, GetEnvironmentChangesSinceDef.McpTool, GetEnvironmentChangesSinceHandler(auditClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetMfaSignOnMetricsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetMfaSignOnMetricsDef.McpTool.Name))
+		mcp.AddTool(server, GetMfaSignOnMetricsDef.McpTool, GetMfaSignOnMetricsHandler(auditClientFactory))
+	}
+
+	return nil
+}
+
+func (c *AuditCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		QueryAuditEventsDef,
+		VerifyWebhookEventDef,
+		GetResourceStateAsOfDef,
+		GetEnvironmentChangesSinceDef,
+		GetMfaSignOnMetricsDef,
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ audit.AuditClient = &mockPingOneClientAuditWrapper{}
+var _ audit.AuditClientFactory = &mockPingOneClientAuditWrapperFactory{}
+
+type mockPingOneClientAuditWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientAuditWrapperFactory struct {
+	mockClient audit.AuditClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientAuditWrapperFactory(mockClient audit.AuditClient, err error) *mockPingOneClientAuditWrapperFactory {
+	return &mockPingOneClientAuditWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientAuditWrapperFactory) GetAuthenticatedClient(ctx context.Context) (audit.AuditClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientAuditWrapper) GetAuditActivities(ctx context.Context, environmentId uuid.UUID, filter string, limit *int32) (audit.AuditActivitiesPagedIterator, error) {
+	args := p.Called(ctx, environmentId, filter, limit)
+	var response audit.AuditActivitiesPagedIterator
+	response, ok := args.Get(0).(audit.AuditActivitiesPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientAuditWrapper) GetSubscription(ctx context.Context, environmentId uuid.UUID, subscriptionId uuid.UUID) (*management.Subscription, *http.Response, error) {
+	args := p.Called(ctx, environmentId, subscriptionId)
+	var response *management.Subscription
+	response, ok := args.Get(0).(*management.Subscription)
+	if !ok && args.Get(0) != nil {
+		panic("GetSubscription mock setup error: expected *management.Subscription or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetSubscription mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientAuditWrapper) GetResource(ctx context.Context, environmentId uuid.UUID, resourceType string, resourceId uuid.UUID) (map[string]any, *http.Response, error) {
+	args := p.Called(ctx, environmentId, resourceType, resourceId)
+	var response map[string]any
+	response, ok := args.Get(0).(map[string]any)
+	if !ok && args.Get(0) != nil {
+		panic("GetResource mock setup error: expected map[string]any or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetResource mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+)
+
+var (
+	testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testActorUserId   = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+)
+
+var (
+	testActivity1 = audit.AuditActivity{
+		Id:         "a1",
+		RecordedAt: "2025-01-01T10:00:00Z",
+		Action: audit.AuditActivityAction{
+			Type: "USER.DELETED",
+		},
+		Actors: &audit.AuditActivityActors{
+			User: &audit.AuditActivityActor{
+				Id:   testutils.Pointer(testActorUserId.String()),
+				Name: testutils.Pointer("admin@example.com"),
+			},
+		},
+		Resources: []audit.AuditActivityResource{
+			{Id: testutils.Pointer("user-1"), Type: testutils.Pointer("USER")},
+		},
+		Result: &audit.AuditActivityResult{
+			Status: testutils.Pointer("SUCCESS"),
+		},
+	}
+	testActivity2 = audit.AuditActivity{
+		Id:         "a2",
+		RecordedAt: "2025-01-01T11:00:00Z",
+		Action: audit.AuditActivityAction{
+			Type: "USER.DELETED",
+		},
+	}
+	testActivity3 = audit.AuditActivity{
+		Id:         "a3",
+		RecordedAt: "2025-01-01T12:00:00Z",
+		Action: audit.AuditActivityAction{
+			Type: "APPLICATION.UPDATED",
+		},
+	}
+)
+
+// auditActivitiesMockPage is a page returned by the mock audit activities iterator
+type auditActivitiesMockPage struct {
+	Activities []audit.AuditActivity
+	HasNext    bool
+	Error      error
+}
+
+func mockAuditActivitiesIterator(pages []auditActivitiesMockPage) audit.AuditActivitiesPagedIterator {
+	return func(yield func(audit.AuditActivitiesPagedCursor, error) bool) {
+		for _, page := range pages {
+			cursor := audit.AuditActivitiesPagedCursor{
+				Page: &audit.AuditActivitiesPage{
+					Embedded: &audit.AuditActivitiesPageEmbedded{
+						Activities: page.Activities,
+					},
+				},
+				HTTPResponse: &http.Response{StatusCode: 200},
+			}
+			if page.HasNext {
+				cursor.Page.Links = map[string]management.LinksHATEOASValue{
+					"next": {Href: "https://api.pingone.com/v1/environments/env/activities?cursor=next"},
+				}
+			}
+
+			if !yield(cursor, page.Error) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	// Changes are summarized rather than returned, so many events can be scanned without bloating the result
+	defaultChangesSinceLimit = 1000
+	maxChangesSinceLimit     = 10000
+
+	actorTypeUser   = "USER"
+	actorTypeClient = "CLIENT"
+)
+
+var GetEnvironmentChangesSinceDef = types.ToolDefinition{
+	// Audit events keep arriving without write tools being called
+	DisableResponseCache: true,
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_environment_changes_since",
+		Title: "Get PingOne Environment Changes Since",
+		Description: `Summarize the configuration changes made in an environment since a time, as a change log grouped by resource type and by actor. Use to answer questions like "what changed in this environment since Friday?" or "who has been changing applications this week?".
+
+Only successful create, update and delete events are summarized. Changes to users are directory data rather than configuration, so they are excluded unless 'includeUsers' is true.
+
+'resourceTypes' lists, per resource type, the number of resources created, updated and deleted, and each changed resource with the actors that changed it, most recently changed first. 'actors' lists, per user or client application, the number of changes they made and the resource types they changed. Use query_audit_events for the individual events.
+
+'truncated' is true when more events were recorded than 'limit', in which case the summary only covers the oldest events; narrow the time range to summarize the rest.`,
+		InputSchema:  schema.MustGenerateSchema[GetEnvironmentChangesSinceInput](),
+		OutputSchema: schema.MustGenerateSchema[GetEnvironmentChangesSinceOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetEnvironmentChangesSinceInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Since         string    `json:"since" jsonschema:"REQUIRED. Summarize changes made after this time (RFC 3339, e.g. 2025-01-01T00:00:00Z)."`
+	Until         *string   `json:"until,omitempty" jsonschema:"OPTIONAL. Summarize changes made before this time (RFC 3339). Defaults to the current time."`
+	IncludeUsers  *bool     `json:"includeUsers,omitempty" jsonschema:"OPTIONAL. Include changes to users. Defaults to false."`
+	Limit         *int      `json:"limit,omitempty" jsonschema:"OPTIONAL. Maximum number of audit events to scan, 1-10000. Defaults to 1000."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'resourceTypes.resourceType' and 'actors'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ChangeCounts struct {
+	Created int `json:"created" jsonschema:"The number of create events"`
+	Updated int `json:"updated" jsonschema:"The number of update events"`
+	Deleted int `json:"deleted" jsonschema:"The number of delete events"`
+	Total   int `json:"total" jsonschema:"The total number of change events"`
+}
+
+type ChangedResource struct {
+	Id            *string  `json:"id,omitempty" jsonschema:"The unique identifier of the resource"`
+	Name          *string  `json:"name,omitempty" jsonschema:"The name of the resource, as recorded by its most recent change"`
+	LastChangedAt string   `json:"lastChangedAt" jsonschema:"The time of the most recent change (RFC 3339)"`
+	LastAction    string   `json:"lastAction" jsonschema:"The action type of the most recent change, for example APPLICATION.UPDATED"`
+	ChangedBy     []string `json:"changedBy" jsonschema:"The actors that changed the resource"`
+	ChangeCounts
+}
+
+type ResourceTypeChanges struct {
+	ResourceType string            `json:"resourceType" jsonschema:"The resource type, for example APPLICATION"`
+	Resources    []ChangedResource `json:"resources" jsonschema:"The changed resources of this type, most recently changed first"`
+	ChangeCounts
+}
+
+type ActorChanges struct {
+	Actor         string   `json:"actor" jsonschema:"The name or ID of the user or client application that made the changes"`
+	ActorType     string   `json:"actorType" jsonschema:"USER or CLIENT, or UNKNOWN if the events do not record the actor"`
+	ResourceTypes []string `json:"resourceTypes" jsonschema:"The resource types the actor changed"`
+	ChangeCounts
+}
+
+type GetEnvironmentChangesSinceOutput struct {
+	ResourceTypes []ResourceTypeChanges `json:"resourceTypes" jsonschema:"Changes grouped by resource type, most changed first"`
+	Actors        []ActorChanges        `json:"actors" jsonschema:"Changes grouped by actor, most changes first"`
+	Changes       int                   `json:"changes" jsonschema:"The number of change events summarized"`
+	Scanned       int                   `json:"scanned" jsonschema:"The number of audit events scanned, including events that are not configuration changes"`
+	Truncated     bool                  `json:"truncated" jsonschema:"True if more events were recorded than were scanned"`
+	Filter        string                `json:"filter" jsonschema:"The SCIM filter sent to the PingOne audit activities API"`
+}
+
+// GetEnvironmentChangesSinceHandler summarizes the configuration changes recorded in PingOne audit activities using the provided client
+func GetEnvironmentChangesSinceHandler(auditClientFactory AuditClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetEnvironmentChangesSinceInput,
+) (
+	*mcp.CallToolResult,
+	*GetEnvironmentChangesSinceOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetEnvironmentChangesSinceInput) (*mcp.CallToolResult, *GetEnvironmentChangesSinceOutput, error) {
+		now := time.Now()
+		since, err := time.Parse(time.RFC3339, input.Since)
+		if err != nil {
+			toolErr := errs.NewToolError(GetEnvironmentChangesSinceDef.McpTool.Name, fmt.Errorf("since must be an RFC 3339 timestamp: %w", err))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		until := now
+		if input.Until != nil && *input.Until != "" {
+			until, err = time.Parse(time.RFC3339, *input.Until)
+			if err != nil {
+				toolErr := errs.NewToolError(GetEnvironmentChangesSinceDef.McpTool.Name, fmt.Errorf("until must be an RFC 3339 timestamp: %w", err))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+		if !since.Before(until) {
+			toolErr := errs.NewToolError(GetEnvironmentChangesSinceDef.McpTool.Name, errors.New("since must be before until"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		filter, err := buildAuditEventsFilter(QueryAuditEventsInput{
+			StartTime: input.Since,
+			EndTime:   input.Until,
+		}, now)
+		if err != nil {
+			toolErr := errs.NewToolError(GetEnvironmentChangesSinceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		limit := defaultChangesSinceLimit
+		if input.Limit != nil {
+			if *input.Limit < 1 || *input.Limit > maxChangesSinceLimit {
+				toolErr := errs.NewToolError(GetEnvironmentChangesSinceDef.McpTool.Name, fmt.Errorf("limit must be between 1 and %d", maxChangesSinceLimit))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			limit = *input.Limit
+		}
+
+		client, err := auditClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetEnvironmentChangesSinceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Summarizing environment changes",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("filter", filter),
+			slog.Int("limit", limit))
+
+		pageSize := int32(min(limit, maxAuditEventsPageSize))
+		pagedIterator, err := client.GetAuditActivities(ctx, input.EnvironmentId, filter, &pageSize)
+		if err != nil {
+			toolErr := errs.NewToolError(GetEnvironmentChangesSinceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		summarizer := newChangeSummarizer(input.IncludeUsers != nil && *input.IncludeUsers)
+		result := GetEnvironmentChangesSinceOutput{
+			Filter: filter,
+		}
+
+	pages:
+		for next, err := range pagedIterator {
+			logger.LogHttpResponse(ctx, next.HTTPResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(next.HTTPResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.Page == nil {
+				// This should never happen, err should be set if no data
+				apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.Page.Embedded == nil {
+				continue
+			}
+
+			for _, activity := range next.Page.Embedded.Activities {
+				if result.Scanned >= limit {
+					result.Truncated = true
+					break pages
+				}
+				result.Scanned++
+				summarizer.Add(activity)
+			}
+
+			if result.Scanned >= limit && next.Page.NextLink() != nil {
+				result.Truncated = true
+				break
+			}
+		}
+
+		result.ResourceTypes, result.Actors = summarizer.Summary()
+		result.Changes = summarizer.changes
+
+		logger.FromContext(ctx).Debug("Summarized environment changes",
+			slog.Int("scanned", result.Scanned),
+			slog.Int("changes", result.Changes),
+			slog.Bool("truncated", result.Truncated))
+
+		return nil, &result, nil
+	}
+}
+
+// changeSummarizer groups successful resource changes by resource type and by actor
+type changeSummarizer struct {
+	includeUsers  bool
+	changes       int
+	resourceTypes map[string]*ResourceTypeChanges
+	resources     map[string]map[string]*ChangedResource
+	actors        map[actorId]*ActorChanges
+}
+
+type actorId struct {
+	actorType string
+	key       string
+}
+
+func newChangeSummarizer(includeUsers bool) *changeSummarizer {
+	return &changeSummarizer{
+		includeUsers:  includeUsers,
+		resourceTypes: map[string]*ResourceTypeChanges{},
+		resources:     map[string]map[string]*ChangedResource{},
+		actors:        map[actorId]*ActorChanges{},
+	}
+}
+
+func (s *changeSummarizer) Add(activity AuditActivity) {
+	if !isResourceChange(activity) {
+		return
+	}
+	resourceType := changedResourceType(activity)
+	if resourceType == ResourceTypeUser && !s.includeUsers {
+		return
+	}
+	s.changes++
+	verb := resourceChangeVerb(activity)
+	actor := changeActor(activity)
+	actorName := actor.key
+
+	typeChanges, ok := s.resourceTypes[resourceType]
+	if !ok {
+		typeChanges = &ResourceTypeChanges{ResourceType: resourceType}
+		s.resourceTypes[resourceType] = typeChanges
+		s.resources[resourceType] = map[string]*ChangedResource{}
+	}
+	typeChanges.count(verb)
+
+	resource := changedResource(activity, resourceType)
+	resourceKey := unknownAuditEventsGroupKey
+	if resource.Id != nil {
+		resourceKey = *resource.Id
+	}
+	changed, ok := s.resources[resourceType][resourceKey]
+	if !ok {
+		changed = &ChangedResource{Id: resource.Id, ChangedBy: []string{}}
+		s.resources[resourceType][resourceKey] = changed
+	}
+	changed.count(verb)
+	if activity.RecordedAt >= changed.LastChangedAt {
+		// RFC 3339 timestamps in UTC sort lexically
+		changed.LastChangedAt = activity.RecordedAt
+		changed.LastAction = activity.Action.Type
+		if resource.Name != nil {
+			changed.Name = resource.Name
+		}
+	}
+	if !slices.Contains(changed.ChangedBy, actorName) {
+		changed.ChangedBy = append(changed.ChangedBy, actorName)
+	}
+
+	actorChanges, ok := s.actors[actor]
+	if !ok {
+		actorChanges = &ActorChanges{Actor: actor.key, ActorType: actor.actorType, ResourceTypes: []string{}}
+		s.actors[actor] = actorChanges
+	}
+	actorChanges.count(verb)
+	if !slices.Contains(actorChanges.ResourceTypes, resourceType) {
+		actorChanges.ResourceTypes = append(actorChanges.ResourceTypes, resourceType)
+	}
+}
+
+// Summary returns the changes by resource type and by actor, most changed first
+func (s *changeSummarizer) Summary() ([]ResourceTypeChanges, []ActorChanges) {
+	resourceTypes := make([]ResourceTypeChanges, 0, len(s.resourceTypes))
+	for resourceType, typeChanges := range s.resourceTypes {
+		typeChanges.Resources = make([]ChangedResource, 0, len(s.resources[resourceType]))
+		for _, resource := range s.resources[resourceType] {
+			slices.Sort(resource.ChangedBy)
+			typeChanges.Resources = append(typeChanges.Resources, *resource)
+		}
+		slices.SortFunc(typeChanges.Resources, func(a, b ChangedResource) int {
+			if c := strings.Compare(b.LastChangedAt, a.LastChangedAt); c != 0 {
+				return c
+			}
+			return strings.Compare(resourceSortKey(a), resourceSortKey(b))
+		})
+		resourceTypes = append(resourceTypes, *typeChanges)
+	}
+	slices.SortFunc(resourceTypes, func(a, b ResourceTypeChanges) int {
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ResourceType, b.ResourceType)
+	})
+
+	actors := make([]ActorChanges, 0, len(s.actors))
+	for _, actorChanges := range s.actors {
+		slices.Sort(actorChanges.ResourceTypes)
+		actors = append(actors, *actorChanges)
+	}
+	slices.SortFunc(actors, func(a, b ActorChanges) int {
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Actor, b.Actor)
+	})
+
+	return resourceTypes, actors
+}
+
+func (c *ChangeCounts) count(verb string) {
+	switch verb {
+	case "CREATED":
+		c.Created++
+	case "UPDATED":
+		c.Updated++
+	case "DELETED":
+		c.Deleted++
+	}
+	c.Total++
+}
+
+// changedResourceType returns the resource type of the action, for example APPLICATION for APPLICATION.UPDATED
+func changedResourceType(activity AuditActivity) string {
+	actionType := activity.Action.Type
+	if i := strings.LastIndex(actionType, "."); i > 0 {
+		return actionType[:i]
+	}
+	return unknownAuditEventsGroupKey
+}
+
+// changedResource returns the resource of the activity matching the action's resource type, as activities
+// can also list related resources such as the environment
+func changedResource(activity AuditActivity, resourceType string) AuditActivityResource {
+	for _, resource := range activity.Resources {
+		if resource.Type != nil && strings.EqualFold(*resource.Type, resourceType) {
+			return resource
+		}
+	}
+	if len(activity.Resources) > 0 {
+		return activity.Resources[0]
+	}
+	return AuditActivityResource{}
+}
+
+// changeActor returns the user that made the change, or the client application when no user was involved
+func changeActor(activity AuditActivity) actorId {
+	if activity.Actors != nil {
+		if key := actorKey(activity.Actors.User); key != "" {
+			return actorId{actorType: actorTypeUser, key: key}
+		}
+		if key := actorKey(activity.Actors.Client); key != "" {
+			return actorId{actorType: actorTypeClient, key: key}
+		}
+	}
+	return actorId{actorType: unknownAuditEventsGroupKey, key: unknownAuditEventsGroupKey}
+}
+
+func resourceSortKey(resource ChangedResource) string {
+	if resource.Id != nil {
+		return *resource.Id
+	}
+	return ""
+}
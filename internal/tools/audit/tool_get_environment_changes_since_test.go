@@ -0,0 +1,291 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func baseGetEnvironmentChangesSinceInput() audit.GetEnvironmentChangesSinceInput {
+	return audit.GetEnvironmentChangesSinceInput{
+		EnvironmentId: testEnvironmentId,
+		Since:         testStartTime,
+		Until:         testutils.Pointer(testEndTime),
+	}
+}
+
+func testChange(id, recordedAt, actionType, resourceId, resourceName, actorName string) audit.AuditActivity {
+	resourceType, _, _ := strings.Cut(actionType, ".")
+	return audit.AuditActivity{
+		Id:         id,
+		RecordedAt: recordedAt,
+		Action:     audit.AuditActivityAction{Type: actionType},
+		Actors: &audit.AuditActivityActors{
+			User: &audit.AuditActivityActor{Name: testutils.Pointer(actorName)},
+		},
+		Resources: []audit.AuditActivityResource{
+			{Id: testutils.Pointer(testEnvironmentId.String()), Type: testutils.Pointer("ENVIRONMENT")},
+			{Id: testutils.Pointer(resourceId), Name: testutils.Pointer(resourceName), Type: testutils.Pointer(resourceType)},
+		},
+		Result: &audit.AuditActivityResult{Status: testutils.Pointer("SUCCESS")},
+	}
+}
+
+var (
+	appCreated     = testChange("c1", "2025-01-01T09:00:00Z", "APPLICATION.CREATED", "app-1", "Portal", "alice@example.com")
+	appUpdated     = testChange("u1", "2025-01-01T10:00:00Z", "APPLICATION.UPDATED", "app-1", "Customer Portal", "bob@example.com")
+	otherAppDelete = testChange("d1", "2025-01-01T11:00:00Z", "APPLICATION.DELETED", "app-2", "Legacy", "alice@example.com")
+	popUpdated     = testChange("u2", "2025-01-01T12:00:00Z", "POPULATION.UPDATED", "pop-1", "Staff", "alice@example.com")
+	userUpdated    = testChange("u3", "2025-01-01T13:00:00Z", "USER.UPDATED", "user-1", "jdoe", "bob@example.com")
+)
+
+func TestGetEnvironmentChangesSinceHandler_MockClient(t *testing.T) {
+	failedUpdate := testChange("f1", "2025-01-01T14:00:00Z", "APPLICATION.UPDATED", "app-1", "Customer Portal", "bob@example.com")
+	failedUpdate.Result.Status = testutils.Pointer("FAILED")
+	clientChange := testChange("c2", "2025-01-01T15:00:00Z", "POPULATION.CREATED", "pop-2", "Contractors", "")
+	clientChange.Actors = &audit.AuditActivityActors{Client: &audit.AuditActivityActor{Id: testutils.Pointer("worker-app")}}
+	signOn := testChange("s1", "2025-01-01T09:30:00Z", "USER.ACCESS_ALLOWED", "user-1", "jdoe", "jdoe")
+
+	pages := []auditActivitiesMockPage{
+		{Activities: []audit.AuditActivity{appCreated, appUpdated, signOn}, HasNext: true},
+		{Activities: []audit.AuditActivity{otherAppDelete, popUpdated, userUpdated, failedUpdate, clientChange}},
+	}
+
+	expectedResourceTypes := []audit.ResourceTypeChanges{
+		{
+			ResourceType: "APPLICATION",
+			Resources: []audit.ChangedResource{
+				{
+					Id:            testutils.Pointer("app-2"),
+					Name:          testutils.Pointer("Legacy"),
+					LastChangedAt: "2025-01-01T11:00:00Z",
+					LastAction:    "APPLICATION.DELETED",
+					ChangedBy:     []string{"alice@example.com"},
+					ChangeCounts:  audit.ChangeCounts{Deleted: 1, Total: 1},
+				},
+				{
+					Id:            testutils.Pointer("app-1"),
+					Name:          testutils.Pointer("Customer Portal"),
+					LastChangedAt: "2025-01-01T10:00:00Z",
+					LastAction:    "APPLICATION.UPDATED",
+					ChangedBy:     []string{"alice@example.com", "bob@example.com"},
+					ChangeCounts:  audit.ChangeCounts{Created: 1, Updated: 1, Total: 2},
+				},
+			},
+			ChangeCounts: audit.ChangeCounts{Created: 1, Updated: 1, Deleted: 1, Total: 3},
+		},
+		{
+			ResourceType: "POPULATION",
+			Resources: []audit.ChangedResource{
+				{
+					Id:            testutils.Pointer("pop-2"),
+					Name:          testutils.Pointer("Contractors"),
+					LastChangedAt: "2025-01-01T15:00:00Z",
+					LastAction:    "POPULATION.CREATED",
+					ChangedBy:     []string{"worker-app"},
+					ChangeCounts:  audit.ChangeCounts{Created: 1, Total: 1},
+				},
+				{
+					Id:            testutils.Pointer("pop-1"),
+					Name:          testutils.Pointer("Staff"),
+					LastChangedAt: "2025-01-01T12:00:00Z",
+					LastAction:    "POPULATION.UPDATED",
+					ChangedBy:     []string{"alice@example.com"},
+					ChangeCounts:  audit.ChangeCounts{Updated: 1, Total: 1},
+				},
+			},
+			ChangeCounts: audit.ChangeCounts{Created: 1, Updated: 1, Total: 2},
+		},
+	}
+	expectedActors := []audit.ActorChanges{
+		{
+			Actor:         "alice@example.com",
+			ActorType:     "USER",
+			ResourceTypes: []string{"APPLICATION", "POPULATION"},
+			ChangeCounts:  audit.ChangeCounts{Created: 1, Updated: 1, Deleted: 1, Total: 3},
+		},
+		{
+			Actor:         "bob@example.com",
+			ActorType:     "USER",
+			ResourceTypes: []string{"APPLICATION"},
+			ChangeCounts:  audit.ChangeCounts{Updated: 1, Total: 1},
+		},
+		{
+			Actor:         "worker-app",
+			ActorType:     "CLIENT",
+			ResourceTypes: []string{"POPULATION"},
+			ChangeCounts:  audit.ChangeCounts{Created: 1, Total: 1},
+		},
+	}
+
+	setupMock := func(mockClient *mockPingOneClientAuditWrapper) {
+		expectedPageSize := int32(1000)
+		mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, testTimeRange, &expectedPageSize).
+			Return(mockAuditActivitiesIterator(pages), nil)
+	}
+
+	t.Run("Direct handler", func(t *testing.T) {
+		mockClient := &mockPingOneClientAuditWrapper{}
+		setupMock(mockClient)
+
+		handler := audit.GetEnvironmentChangesSinceHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+		mcpResult, structuredResponse, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetEnvironmentChangesSinceInput())
+
+		testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, structuredResponse)
+		assert.Equal(t, expectedResourceTypes, structuredResponse.ResourceTypes)
+		assert.Equal(t, expectedActors, structuredResponse.Actors)
+		assert.Equal(t, 5, structuredResponse.Changes)
+		assert.Equal(t, 8, structuredResponse.Scanned)
+		assert.False(t, structuredResponse.Truncated)
+		assert.Equal(t, testTimeRange, structuredResponse.Filter)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Via MCP", func(t *testing.T) {
+		mockClient := &mockPingOneClientAuditWrapper{}
+		setupMock(mockClient)
+
+		handler := audit.GetEnvironmentChangesSinceHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+		server := mcptestutils.TestMcpServer(t)
+		mcp.AddTool(server, audit.GetEnvironmentChangesSinceDef.McpTool, handler)
+
+		output, err := mcptestutils.CallToolOverMcp(t, server, audit.GetEnvironmentChangesSinceDef.McpTool.Name, baseGetEnvironmentChangesSinceInput())
+		testutils.AssertMcpCallSuccess(t, err, output)
+
+		outputChanges := &audit.GetEnvironmentChangesSinceOutput{}
+		jsonBytes, err := json.Marshal(output.StructuredContent)
+		require.NoError(t, err, "Failed to marshal structured content")
+		require.NoError(t, json.Unmarshal(jsonBytes, outputChanges), "Failed to unmarshal structured content")
+
+		assert.Equal(t, expectedResourceTypes, outputChanges.ResourceTypes)
+		assert.Equal(t, expectedActors, outputChanges.Actors)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestGetEnvironmentChangesSinceHandler_IncludeUsers(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, testTimeRange, mock.Anything).
+		Return(mockAuditActivitiesIterator([]auditActivitiesMockPage{{Activities: []audit.AuditActivity{appUpdated, userUpdated}}}), nil)
+
+	input := baseGetEnvironmentChangesSinceInput()
+	input.IncludeUsers = testutils.Pointer(true)
+
+	handler := audit.GetEnvironmentChangesSinceHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+	mcpResult, structuredResponse, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, structuredResponse)
+	assert.Equal(t, 2, structuredResponse.Changes)
+	require.Len(t, structuredResponse.ResourceTypes, 2)
+	assert.Equal(t, "APPLICATION", structuredResponse.ResourceTypes[0].ResourceType)
+	assert.Equal(t, "USER", structuredResponse.ResourceTypes[1].ResourceType)
+	require.Len(t, structuredResponse.Actors, 1)
+	assert.Equal(t, []string{"APPLICATION", "USER"}, structuredResponse.Actors[0].ResourceTypes)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetEnvironmentChangesSinceHandler_Truncated(t *testing.T) {
+	expectedPageSize := int32(2)
+	mockClient := &mockPingOneClientAuditWrapper{}
+	mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, testTimeRange, &expectedPageSize).
+		Return(mockAuditActivitiesIterator([]auditActivitiesMockPage{
+			{Activities: []audit.AuditActivity{appCreated, appUpdated}, HasNext: true},
+			{Activities: []audit.AuditActivity{otherAppDelete}},
+		}), nil)
+
+	input := baseGetEnvironmentChangesSinceInput()
+	input.Limit = testutils.Pointer(2)
+
+	handler := audit.GetEnvironmentChangesSinceHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+	mcpResult, structuredResponse, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, structuredResponse)
+	assert.True(t, structuredResponse.Truncated)
+	assert.Equal(t, 2, structuredResponse.Scanned)
+	assert.Equal(t, 2, structuredResponse.Changes)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetEnvironmentChangesSinceHandler_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(*audit.GetEnvironmentChangesSinceInput)
+		wantErrContains string
+	}{
+		{
+			name:            "Invalid since",
+			modify:          func(input *audit.GetEnvironmentChangesSinceInput) { input.Since = "last week" },
+			wantErrContains: "since must be an RFC 3339 timestamp",
+		},
+		{
+			name:            "Invalid until",
+			modify:          func(input *audit.GetEnvironmentChangesSinceInput) { input.Until = testutils.Pointer("now") },
+			wantErrContains: "until must be an RFC 3339 timestamp",
+		},
+		{
+			name: "Since after until",
+			modify: func(input *audit.GetEnvironmentChangesSinceInput) {
+				input.Since, input.Until = testEndTime, testutils.Pointer(testStartTime)
+			},
+			wantErrContains: "since must be before until",
+		},
+		{
+			name:            "Limit too large",
+			modify:          func(input *audit.GetEnvironmentChangesSinceInput) { input.Limit = testutils.Pointer(10001) },
+			wantErrContains: "limit must be between 1 and 10000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			handler := audit.GetEnvironmentChangesSinceHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+			input := baseGetEnvironmentChangesSinceInput()
+			tt.modify(&input)
+
+			mcpResult, response, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, response, tt.wantErrContains)
+			mockClient.AssertNotCalled(t, "GetAuditActivities", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetEnvironmentChangesSinceHandler_APIErrors(t *testing.T) {
+	tests := testutils.CommonAPIErrorTestCases()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			mockClient.On("GetAuditActivities", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.ApiError)
+			handler := audit.GetEnvironmentChangesSinceHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+			mcpResult, response, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetEnvironmentChangesSinceInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, response, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetEnvironmentChangesSinceHandler_GetAuthenticatedClientError(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	handler := audit.GetEnvironmentChangesSinceHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, errors.New("failed to get authenticated client")))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetEnvironmentChangesSinceInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "failed to get authenticated client")
+}
@@ -0,0 +1,380 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	// Metrics are counted rather than returned, so many events can be scanned without bloating the result
+	defaultMfaSignOnMetricsLimit = 1000
+	maxMfaSignOnMetricsLimit     = 10000
+
+	resultStatusSuccess = "SUCCESS"
+	resultStatusFailed  = "FAILED"
+)
+
+// mfaMethods are the MFA methods recognised in audit event action types and descriptions, most specific first
+var mfaMethods = []string{"FIDO2", "OATH_TOKEN", "TOTP", "WHATSAPP", "VOICE", "SMS", "EMAIL", "MOBILE"}
+
+var GetMfaSignOnMetricsDef = types.ToolDefinition{
+	// Audit events keep arriving without write tools being called
+	DisableResponseCache: true,
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_mfa_sign_on_metrics",
+		Title: "Get PingOne MFA Sign-On Metrics",
+		Description: `Report MFA sign-on success and failure counts and rates in an environment within a time range, in total, per MFA method and per action type, from the audit events recorded in the environment. Use to monitor the health of an MFA rollout, such as "are SMS one-time passcodes failing more since Monday?".
+
+By default an audit event is counted as an MFA sign-on when its action type includes MFA, or includes AUTHENTICATION and names an MFA method. The method is taken from the action type or description: FIDO2, OATH_TOKEN, TOTP, WHATSAPP, VOICE, SMS, EMAIL or MOBILE, or UNKNOWN if none is named. To count exactly the action types the environment records, find them with query_audit_events grouped by action and pass them in 'actionTypes'.
+
+Events with result status SUCCESS count as succeeded and FAILED as failed; rates are of the succeeded and failed events. Set 'timeBucket' (hour or day) to also report the counts per time bucket.
+
+'truncated' is true when more events were recorded than 'limit', in which case the metrics only cover the events scanned; narrow the time range to cover the rest.`,
+		InputSchema:  schema.MustGenerateSchema[GetMfaSignOnMetricsInput](),
+		OutputSchema: schema.MustGenerateSchema[GetMfaSignOnMetricsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetMfaSignOnMetricsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	StartTime     string    `json:"startTime" jsonschema:"REQUIRED. Start of the time range (RFC 3339, e.g. 2025-01-01T00:00:00Z)."`
+	EndTime       *string   `json:"endTime,omitempty" jsonschema:"OPTIONAL. End of the time range (RFC 3339). Defaults to the current time."`
+	ActionTypes   []string  `json:"actionTypes,omitempty" jsonschema:"OPTIONAL. Only count events of these action types as MFA sign-ons. Defaults to the events whose action type and description identify them as MFA sign-ons."`
+	TimeBucket    *string   `json:"timeBucket,omitempty" jsonschema:"OPTIONAL. Also report the counts per hour or day."`
+	Limit         *int      `json:"limit,omitempty" jsonschema:"OPTIONAL. Maximum number of audit events to scan, 1-10000. Defaults to 1000."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'total' and 'methods.method'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type MfaSignOnCounts struct {
+	Total       int      `json:"total" jsonschema:"The number of MFA sign-on events"`
+	Succeeded   int      `json:"succeeded" jsonschema:"The number of events with result status SUCCESS"`
+	Failed      int      `json:"failed" jsonschema:"The number of events with result status FAILED"`
+	Other       int      `json:"other" jsonschema:"The number of events with another or no result status"`
+	SuccessRate *float64 `json:"successRate,omitempty" jsonschema:"The fraction of succeeded and failed events that succeeded, from 0 to 1. Absent when there are none"`
+	FailureRate *float64 `json:"failureRate,omitempty" jsonschema:"The fraction of succeeded and failed events that failed, from 0 to 1. Absent when there are none"`
+}
+
+type MfaMethodMetrics struct {
+	Method string `json:"method" jsonschema:"The MFA method, for example SMS or FIDO2, or UNKNOWN"`
+	MfaSignOnCounts
+}
+
+type MfaActionTypeMetrics struct {
+	ActionType string `json:"actionType" jsonschema:"The action type of the events"`
+	MfaSignOnCounts
+}
+
+type MfaTimeBucketMetrics struct {
+	BucketStart string `json:"bucketStart" jsonschema:"The start of the time bucket (RFC 3339)"`
+	MfaSignOnCounts
+}
+
+type GetMfaSignOnMetricsOutput struct {
+	Total       MfaSignOnCounts        `json:"total" jsonschema:"The counts of all MFA sign-on events"`
+	Methods     []MfaMethodMetrics     `json:"methods" jsonschema:"The counts per MFA method, most events first"`
+	ActionTypes []MfaActionTypeMetrics `json:"actionTypes" jsonschema:"The counts per action type, most events first"`
+	TimeBuckets []MfaTimeBucketMetrics `json:"timeBuckets,omitempty" jsonschema:"The counts per time bucket in time order, when timeBucket is set"`
+	Scanned     int                    `json:"scanned" jsonschema:"The number of audit events scanned, including events that are not MFA sign-ons"`
+	Truncated   bool                   `json:"truncated" jsonschema:"True if more events were recorded than were scanned"`
+	Filter      string                 `json:"filter" jsonschema:"The SCIM filter sent to the PingOne audit activities API"`
+}
+
+// GetMfaSignOnMetricsHandler reports MFA sign-on metrics from PingOne audit activities using the provided client
+func GetMfaSignOnMetricsHandler(auditClientFactory AuditClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetMfaSignOnMetricsInput,
+) (
+	*mcp.CallToolResult,
+	*GetMfaSignOnMetricsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetMfaSignOnMetricsInput) (*mcp.CallToolResult, *GetMfaSignOnMetricsOutput, error) {
+		filter, err := buildMfaSignOnMetricsFilter(input, time.Now())
+		if err != nil {
+			toolErr := errs.NewToolError(GetMfaSignOnMetricsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		timeBucket := ""
+		if input.TimeBucket != nil && *input.TimeBucket != "" {
+			if *input.TimeBucket != AuditEventsTimeBucketHour && *input.TimeBucket != AuditEventsTimeBucketDay {
+				toolErr := errs.NewToolError(GetMfaSignOnMetricsDef.McpTool.Name, fmt.Errorf("timeBucket must be one of %s or %s", AuditEventsTimeBucketHour, AuditEventsTimeBucketDay))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			timeBucket = *input.TimeBucket
+		}
+
+		limit := defaultMfaSignOnMetricsLimit
+		if input.Limit != nil {
+			if *input.Limit < 1 || *input.Limit > maxMfaSignOnMetricsLimit {
+				toolErr := errs.NewToolError(GetMfaSignOnMetricsDef.McpTool.Name, fmt.Errorf("limit must be between 1 and %d", maxMfaSignOnMetricsLimit))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			limit = *input.Limit
+		}
+
+		client, err := auditClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetMfaSignOnMetricsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Reporting MFA sign-on metrics",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("filter", filter),
+			slog.Int("limit", limit))
+
+		pageSize := int32(min(limit, maxAuditEventsPageSize))
+		pagedIterator, err := client.GetAuditActivities(ctx, input.EnvironmentId, filter, &pageSize)
+		if err != nil {
+			toolErr := errs.NewToolError(GetMfaSignOnMetricsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		metrics := newMfaSignOnMetrics(len(input.ActionTypes) > 0, timeBucket)
+		result := GetMfaSignOnMetricsOutput{
+			Filter: filter,
+		}
+
+	pages:
+		for next, err := range pagedIterator {
+			logger.LogHttpResponse(ctx, next.HTTPResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(next.HTTPResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.Page == nil {
+				// This should never happen, err should be set if no data
+				apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.Page.Embedded == nil {
+				continue
+			}
+
+			for _, activity := range next.Page.Embedded.Activities {
+				if result.Scanned >= limit {
+					result.Truncated = true
+					break pages
+				}
+				result.Scanned++
+				if err := metrics.Add(activity); err != nil {
+					toolErr := errs.NewToolError(GetMfaSignOnMetricsDef.McpTool.Name, err)
+					errs.Log(ctx, toolErr)
+					return nil, nil, toolErr
+				}
+			}
+
+			if result.Scanned >= limit && next.Page.NextLink() != nil {
+				result.Truncated = true
+				break
+			}
+		}
+
+		result.Total, result.Methods, result.ActionTypes, result.TimeBuckets = metrics.Summary()
+
+		logger.FromContext(ctx).Debug("Reported MFA sign-on metrics",
+			slog.Int("scanned", result.Scanned),
+			slog.Int("signOns", result.Total.Total),
+			slog.Bool("truncated", result.Truncated))
+
+		return nil, &result, nil
+	}
+}
+
+// buildMfaSignOnMetricsFilter returns the audit activities filter for the time range, restricted to the
+// requested action types if any
+func buildMfaSignOnMetricsFilter(input GetMfaSignOnMetricsInput, now time.Time) (string, error) {
+	var actionTypes []string
+	for _, actionType := range input.ActionTypes {
+		if actionType = strings.TrimSpace(actionType); actionType != "" {
+			actionTypes = append(actionTypes, fmt.Sprintf("action.type eq %s", strconv.Quote(actionType)))
+		}
+	}
+	var actionTypesFilter *string
+	if len(actionTypes) > 0 {
+		joined := strings.Join(actionTypes, " or ")
+		actionTypesFilter = &joined
+	}
+	return buildAuditEventsFilter(QueryAuditEventsInput{
+		StartTime: input.StartTime,
+		EndTime:   input.EndTime,
+		Filter:    actionTypesFilter,
+	}, now)
+}
+
+// mfaSignOnMetrics counts MFA sign-on events in total, per method, per action type and per time bucket
+type mfaSignOnMetrics struct {
+	// countAll counts every event as an MFA sign-on, as the events have already been filtered by action type
+	countAll    bool
+	timeBucket  string
+	total       MfaSignOnCounts
+	methods     map[string]*MfaSignOnCounts
+	actionTypes map[string]*MfaSignOnCounts
+	timeBuckets map[time.Time]*MfaSignOnCounts
+}
+
+func newMfaSignOnMetrics(countAll bool, timeBucket string) *mfaSignOnMetrics {
+	return &mfaSignOnMetrics{
+		countAll:    countAll,
+		timeBucket:  timeBucket,
+		methods:     map[string]*MfaSignOnCounts{},
+		actionTypes: map[string]*MfaSignOnCounts{},
+		timeBuckets: map[time.Time]*MfaSignOnCounts{},
+	}
+}
+
+func (m *mfaSignOnMetrics) Add(activity AuditActivity) error {
+	method := mfaMethod(activity)
+	if !m.countAll && !isMfaSignOn(activity, method) {
+		return nil
+	}
+
+	status := ""
+	if activity.Result != nil && activity.Result.Status != nil {
+		status = *activity.Result.Status
+	}
+
+	counts := []*MfaSignOnCounts{
+		&m.total,
+		countsFor(m.methods, method),
+		countsFor(m.actionTypes, activity.Action.Type),
+	}
+	if m.timeBucket != "" {
+		recordedAt, err := time.Parse(time.RFC3339, activity.RecordedAt)
+		if err != nil {
+			return fmt.Errorf("audit activity %s has an invalid recordedAt timestamp: %w", activity.Id, err)
+		}
+		counts = append(counts, countsFor(m.timeBuckets, bucketStart(recordedAt.UTC(), m.timeBucket)))
+	}
+	for _, c := range counts {
+		c.add(status)
+	}
+	return nil
+}
+
+// Summary returns the total, the counts per method and per action type with the most events first, and the
+// counts per time bucket in time order
+func (m *mfaSignOnMetrics) Summary() (MfaSignOnCounts, []MfaMethodMetrics, []MfaActionTypeMetrics, []MfaTimeBucketMetrics) {
+	total := m.total.withRates()
+
+	methods := []MfaMethodMetrics{}
+	for method, counts := range m.methods {
+		methods = append(methods, MfaMethodMetrics{Method: method, MfaSignOnCounts: counts.withRates()})
+	}
+	slices.SortFunc(methods, func(x, y MfaMethodMetrics) int {
+		return cmp.Or(cmp.Compare(y.Total, x.Total), cmp.Compare(x.Method, y.Method))
+	})
+
+	actionTypes := []MfaActionTypeMetrics{}
+	for actionType, counts := range m.actionTypes {
+		actionTypes = append(actionTypes, MfaActionTypeMetrics{ActionType: actionType, MfaSignOnCounts: counts.withRates()})
+	}
+	slices.SortFunc(actionTypes, func(x, y MfaActionTypeMetrics) int {
+		return cmp.Or(cmp.Compare(y.Total, x.Total), cmp.Compare(x.ActionType, y.ActionType))
+	})
+
+	var timeBuckets []MfaTimeBucketMetrics
+	if m.timeBucket != "" {
+		starts := make([]time.Time, 0, len(m.timeBuckets))
+		for start := range m.timeBuckets {
+			starts = append(starts, start)
+		}
+		slices.SortFunc(starts, time.Time.Compare)
+		timeBuckets = []MfaTimeBucketMetrics{}
+		for _, start := range starts {
+			timeBuckets = append(timeBuckets, MfaTimeBucketMetrics{BucketStart: start.Format(time.RFC3339), MfaSignOnCounts: m.timeBuckets[start].withRates()})
+		}
+	}
+
+	return total, methods, actionTypes, timeBuckets
+}
+
+func countsFor[K comparable](counts map[K]*MfaSignOnCounts, key K) *MfaSignOnCounts {
+	c, ok := counts[key]
+	if !ok {
+		c = &MfaSignOnCounts{}
+		counts[key] = c
+	}
+	return c
+}
+
+func (c *MfaSignOnCounts) add(status string) {
+	c.Total++
+	switch strings.ToUpper(status) {
+	case resultStatusSuccess:
+		c.Succeeded++
+	case resultStatusFailed:
+		c.Failed++
+	default:
+		c.Other++
+	}
+}
+
+func (c MfaSignOnCounts) withRates() MfaSignOnCounts {
+	if completed := c.Succeeded + c.Failed; completed > 0 {
+		successRate := float64(c.Succeeded) / float64(completed)
+		failureRate := float64(c.Failed) / float64(completed)
+		c.SuccessRate = &successRate
+		c.FailureRate = &failureRate
+	}
+	return c
+}
+
+// isMfaSignOn reports whether the activity is an MFA sign-on, from its action type and the method it names
+func isMfaSignOn(activity AuditActivity, method string) bool {
+	actionType := strings.ToUpper(activity.Action.Type)
+	if strings.Contains(actionType, "MFA") {
+		return true
+	}
+	return strings.Contains(actionType, "AUTHENTICATION") && method != unknownAuditEventsGroupKey
+}
+
+// mfaMethod returns the MFA method named by the action type or description of the activity, or UNKNOWN
+func mfaMethod(activity AuditActivity) string {
+	texts := []string{activity.Action.Type}
+	if activity.Action.Description != nil {
+		texts = append(texts, *activity.Action.Description)
+	}
+	for _, text := range texts {
+		words := strings.FieldsFunc(strings.ToUpper(text), func(r rune) bool {
+			return !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_')
+		})
+		for _, method := range mfaMethods {
+			if slices.Contains(words, method) {
+				return method
+			}
+		}
+	}
+	return unknownAuditEventsGroupKey
+}
@@ -0,0 +1,225 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func baseGetMfaSignOnMetricsInput() audit.GetMfaSignOnMetricsInput {
+	return audit.GetMfaSignOnMetricsInput{
+		EnvironmentId: testEnvironmentId,
+		StartTime:     testStartTime,
+		EndTime:       testutils.Pointer(testEndTime),
+	}
+}
+
+// mfaEvent returns an audit activity with the given action, description and result status
+func mfaEvent(id string, recordedAt string, actionType string, description string, status string) audit.AuditActivity {
+	activity := audit.AuditActivity{
+		Id:         id,
+		RecordedAt: recordedAt,
+		Action: audit.AuditActivityAction{
+			Type: actionType,
+		},
+	}
+	if description != "" {
+		activity.Action.Description = testutils.Pointer(description)
+	}
+	if status != "" {
+		activity.Result = &audit.AuditActivityResult{Status: testutils.Pointer(status)}
+	}
+	return activity
+}
+
+func TestGetMfaSignOnMetricsHandler_MockClient(t *testing.T) {
+	pages := []auditActivitiesMockPage{
+		{Activities: []audit.AuditActivity{
+			mfaEvent("m1", "2025-01-01T09:10:00Z", "MFA.CHECK", "SMS one-time passcode checked", "SUCCESS"),
+			mfaEvent("m2", "2025-01-01T09:20:00Z", "MFA.CHECK", "SMS one-time passcode checked", "FAILED"),
+			mfaEvent("m3", "2025-01-01T09:30:00Z", "MFA.CHECK", "SMS one-time passcode checked", "SUCCESS"),
+			testActivity1,
+		}, HasNext: true},
+		{Activities: []audit.AuditActivity{
+			mfaEvent("m4", "2025-01-01T10:05:00Z", "AUTHENTICATION.FIDO2.CHECK", "", "SUCCESS"),
+			mfaEvent("m5", "2025-01-01T10:15:00Z", "MFA.CHECK", "", ""),
+			// Authentication events that name no MFA method are not MFA sign-ons
+			mfaEvent("s1", "2025-01-01T10:20:00Z", "AUTHENTICATION.PASSWORD.CHECK", "", "SUCCESS"),
+			// Device management events are not sign-ons
+			mfaEvent("d1", "2025-01-01T10:25:00Z", "DEVICE.CREATED", "SMS device created", "SUCCESS"),
+		}},
+	}
+
+	mockClient := &mockPingOneClientAuditWrapper{}
+	expectedPageSize := int32(1000)
+	mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, testTimeRange, &expectedPageSize).
+		Return(mockAuditActivitiesIterator(pages), nil)
+
+	input := baseGetMfaSignOnMetricsInput()
+	input.TimeBucket = testutils.Pointer("hour")
+	handler := audit.GetMfaSignOnMetricsHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 8, output.Scanned)
+	assert.False(t, output.Truncated)
+	assert.Equal(t, testTimeRange, output.Filter)
+
+	assert.Equal(t, 5, output.Total.Total)
+	assert.Equal(t, 3, output.Total.Succeeded)
+	assert.Equal(t, 1, output.Total.Failed)
+	assert.Equal(t, 1, output.Total.Other)
+	require.NotNil(t, output.Total.SuccessRate)
+	assert.InDelta(t, 0.75, *output.Total.SuccessRate, 0.0001)
+	assert.InDelta(t, 0.25, *output.Total.FailureRate, 0.0001)
+
+	require.Len(t, output.Methods, 3)
+	assert.Equal(t, "SMS", output.Methods[0].Method)
+	assert.Equal(t, 3, output.Methods[0].Total)
+	assert.Equal(t, "FIDO2", output.Methods[1].Method)
+	assert.Equal(t, "UNKNOWN", output.Methods[2].Method)
+	assert.Nil(t, output.Methods[2].SuccessRate, "rates should be absent without succeeded or failed events")
+
+	require.Len(t, output.ActionTypes, 2)
+	assert.Equal(t, "MFA.CHECK", output.ActionTypes[0].ActionType)
+	assert.Equal(t, 4, output.ActionTypes[0].Total)
+	assert.Equal(t, "AUTHENTICATION.FIDO2.CHECK", output.ActionTypes[1].ActionType)
+
+	require.Len(t, output.TimeBuckets, 2)
+	assert.Equal(t, "2025-01-01T09:00:00Z", output.TimeBuckets[0].BucketStart)
+	assert.Equal(t, 3, output.TimeBuckets[0].Total)
+	assert.Equal(t, "2025-01-01T10:00:00Z", output.TimeBuckets[1].BucketStart)
+	assert.Equal(t, 2, output.TimeBuckets[1].Total)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetMfaSignOnMetricsHandler_ActionTypes(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	expectedFilter := testTimeRange + ` and (action.type eq "OTP.CHECKED" or action.type eq "PUSH.CONFIRMED")`
+	mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, expectedFilter, mock.Anything).
+		Return(mockAuditActivitiesIterator([]auditActivitiesMockPage{
+			{Activities: []audit.AuditActivity{
+				mfaEvent("o1", "2025-01-01T09:00:00Z", "OTP.CHECKED", "Email one-time passcode checked", "SUCCESS"),
+				mfaEvent("p1", "2025-01-01T09:05:00Z", "PUSH.CONFIRMED", "", "FAILED"),
+			}},
+		}), nil)
+
+	input := baseGetMfaSignOnMetricsInput()
+	input.ActionTypes = []string{"OTP.CHECKED", " PUSH.CONFIRMED ", ""}
+	handler := audit.GetMfaSignOnMetricsHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 2, output.Total.Total, "every event of the requested action types should be counted")
+	require.Len(t, output.Methods, 2)
+	assert.ElementsMatch(t, []string{"EMAIL", "UNKNOWN"}, []string{output.Methods[0].Method, output.Methods[1].Method})
+	assert.Nil(t, output.TimeBuckets)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetMfaSignOnMetricsHandler_Truncated(t *testing.T) {
+	expectedPageSize := int32(2)
+	mockClient := &mockPingOneClientAuditWrapper{}
+	mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, testTimeRange, &expectedPageSize).
+		Return(mockAuditActivitiesIterator([]auditActivitiesMockPage{
+			{Activities: []audit.AuditActivity{
+				mfaEvent("m1", "2025-01-01T09:10:00Z", "MFA.CHECK", "", "SUCCESS"),
+				mfaEvent("m2", "2025-01-01T09:20:00Z", "MFA.CHECK", "", "SUCCESS"),
+			}, HasNext: true},
+			{Activities: []audit.AuditActivity{
+				mfaEvent("m3", "2025-01-01T09:30:00Z", "MFA.CHECK", "", "SUCCESS"),
+			}},
+		}), nil)
+
+	input := baseGetMfaSignOnMetricsInput()
+	input.Limit = testutils.Pointer(2)
+	handler := audit.GetMfaSignOnMetricsHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.Truncated)
+	assert.Equal(t, 2, output.Scanned)
+	assert.Equal(t, 2, output.Total.Total)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetMfaSignOnMetricsHandler_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(*audit.GetMfaSignOnMetricsInput)
+		wantErrContains string
+	}{
+		{
+			name:            "Invalid start time",
+			modify:          func(input *audit.GetMfaSignOnMetricsInput) { input.StartTime = "yesterday" },
+			wantErrContains: "startTime must be an RFC 3339 timestamp",
+		},
+		{
+			name: "Start after end",
+			modify: func(input *audit.GetMfaSignOnMetricsInput) {
+				input.StartTime, input.EndTime = testEndTime, testutils.Pointer(testStartTime)
+			},
+			wantErrContains: "startTime must be before endTime",
+		},
+		{
+			name:            "Invalid time bucket",
+			modify:          func(input *audit.GetMfaSignOnMetricsInput) { input.TimeBucket = testutils.Pointer("week") },
+			wantErrContains: "timeBucket must be one of hour or day",
+		},
+		{
+			name:            "Limit too large",
+			modify:          func(input *audit.GetMfaSignOnMetricsInput) { input.Limit = testutils.Pointer(10001) },
+			wantErrContains: "limit must be between 1 and 10000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			handler := audit.GetMfaSignOnMetricsHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+			input := baseGetMfaSignOnMetricsInput()
+			tt.modify(&input)
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertNotCalled(t, "GetAuditActivities", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetMfaSignOnMetricsHandler_APIErrors(t *testing.T) {
+	tests := testutils.CommonAPIErrorTestCases()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			mockClient.On("GetAuditActivities", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.ApiError)
+			handler := audit.GetMfaSignOnMetricsHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetMfaSignOnMetricsInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetMfaSignOnMetricsHandler_GetAuthenticatedClientError(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	handler := audit.GetMfaSignOnMetricsHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, errors.New("failed to get authenticated client")))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetMfaSignOnMetricsInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "failed to get authenticated client")
+}
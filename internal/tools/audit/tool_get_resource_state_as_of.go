@@ -0,0 +1,247 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	ResourceTypeApplication = "APPLICATION"
+	ResourceTypeGroup       = "GROUP"
+	ResourceTypePopulation  = "POPULATION"
+	ResourceTypeUser        = "USER"
+)
+
+// ResourceStateTypes are the resource types whose state can be reconstructed
+var ResourceStateTypes = []string{ResourceTypeApplication, ResourceTypeGroup, ResourceTypePopulation, ResourceTypeUser}
+
+var GetResourceStateAsOfDef = types.ToolDefinition{
+	// Audit events keep arriving, so the state as of a recent time can change
+	DisableResponseCache: true,
+	McpTool: &mcp.Tool{
+		Name:  "get_resource_state_as_of",
+		Title: "Get PingOne Resource State As Of Time",
+		Description: `Reconstruct the approximate state of an application, group, population or user at a past time, by replaying its audit trail backwards from its current state. Use to answer questions like "what did application xyz look like before Tuesday?".
+
+Returns the current state, the reconstructed state at 'asOf', and the successful create, update and delete events recorded for the resource since then, newest first.
+
+PingOne audit events record which resource changed but not the previous field values, so updates cannot be reversed field by field. 'exact' is true only when no update or delete happened since 'asOf'; otherwise 'reconstructedState' is a best effort (the current state, or the details recorded by the delete event), and the listed changes show what to investigate. 'existedAsOf' is false if the resource was created after 'asOf'.`,
+		InputSchema:  schema.MustGenerateSchema[GetResourceStateAsOfInput](),
+		OutputSchema: schema.MustGenerateSchema[GetResourceStateAsOfOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetResourceStateAsOfInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ResourceType  string    `json:"resourceType" jsonschema:"REQUIRED. The type of the resource: APPLICATION, GROUP, POPULATION or USER."`
+	ResourceId    uuid.UUID `json:"resourceId" jsonschema:"REQUIRED. The UUID of the resource."`
+	AsOf          string    `json:"asOf" jsonschema:"REQUIRED. The time to reconstruct the resource state at (RFC 3339, e.g. 2025-01-01T00:00:00Z)."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'reconstructedState.name' and 'changes'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetResourceStateAsOfOutput struct {
+	CurrentState       map[string]any  `json:"currentState,omitempty" jsonschema:"The current state of the resource. Omitted if the resource no longer exists"`
+	ReconstructedState map[string]any  `json:"reconstructedState,omitempty" jsonschema:"The reconstructed state of the resource at asOf. Omitted if the resource did not exist, or its state is unknown"`
+	ExistedAsOf        bool            `json:"existedAsOf" jsonschema:"True if the resource existed at asOf"`
+	Exact              bool            `json:"exact" jsonschema:"True if no update or delete was recorded since asOf, so the reconstructed state is exact"`
+	Changes            []AuditActivity `json:"changes" jsonschema:"The successful create, update and delete events for the resource since asOf, newest first"`
+	Truncated          bool            `json:"truncated" jsonschema:"True if there were more events since asOf than could be replayed, in which case the reconstruction is incomplete"`
+	Filter             string          `json:"filter" jsonschema:"The SCIM filter sent to the PingOne audit activities API"`
+}
+
+// GetResourceStateAsOfHandler reconstructs a previous state of a PingOne resource from its audit trail using the provided client
+func GetResourceStateAsOfHandler(auditClientFactory AuditClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetResourceStateAsOfInput,
+) (
+	*mcp.CallToolResult,
+	*GetResourceStateAsOfOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetResourceStateAsOfInput) (*mcp.CallToolResult, *GetResourceStateAsOfOutput, error) {
+		if !slices.Contains(ResourceStateTypes, input.ResourceType) {
+			toolErr := errs.NewToolError(GetResourceStateAsOfDef.McpTool.Name, fmt.Errorf("resourceType must be one of %s", strings.Join(ResourceStateTypes, ", ")))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		now := time.Now()
+		asOf, err := time.Parse(time.RFC3339, input.AsOf)
+		if err != nil {
+			toolErr := errs.NewToolError(GetResourceStateAsOfDef.McpTool.Name, fmt.Errorf("asOf must be an RFC 3339 timestamp: %w", err))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if !asOf.Before(now) {
+			toolErr := errs.NewToolError(GetResourceStateAsOfDef.McpTool.Name, errors.New("asOf must be in the past"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		resourceFilter := fmt.Sprintf("resources.id eq %s", strconv.Quote(input.ResourceId.String()))
+		filter, err := buildAuditEventsFilter(QueryAuditEventsInput{
+			StartTime: input.AsOf,
+			Filter:    &resourceFilter,
+		}, now)
+		if err != nil {
+			toolErr := errs.NewToolError(GetResourceStateAsOfDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := auditClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetResourceStateAsOfDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Retrieving current resource state",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("resourceType", input.ResourceType),
+			slog.String("resourceId", input.ResourceId.String()))
+
+		currentState, httpResponse, err := client.GetResource(ctx, input.EnvironmentId, input.ResourceType, input.ResourceId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			// A deleted resource can still be reconstructed from its audit trail
+			if httpResponse == nil || httpResponse.StatusCode != http.StatusNotFound {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			currentState = nil
+		}
+
+		pageSize := int32(maxAuditEventsPageSize)
+		pagedIterator, err := client.GetAuditActivities(ctx, input.EnvironmentId, filter, &pageSize)
+		if err != nil {
+			toolErr := errs.NewToolError(GetResourceStateAsOfDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		result := GetResourceStateAsOfOutput{
+			CurrentState: currentState,
+			Changes:      []AuditActivity{},
+			Filter:       filter,
+		}
+		count := 0
+
+	pages:
+		for next, err := range pagedIterator {
+			logger.LogHttpResponse(ctx, next.HTTPResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(next.HTTPResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.Page == nil {
+				// This should never happen, err should be set if no data
+				apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.Page.Embedded == nil {
+				continue
+			}
+
+			for _, activity := range next.Page.Embedded.Activities {
+				if count >= maxAuditEventsLimit {
+					result.Truncated = true
+					break pages
+				}
+				count++
+				if isResourceChange(activity) {
+					result.Changes = append(result.Changes, activity)
+				}
+			}
+		}
+
+		// Replay newest first, RFC 3339 timestamps in UTC sort lexically
+		slices.SortStableFunc(result.Changes, func(a, b AuditActivity) int {
+			return strings.Compare(b.RecordedAt, a.RecordedAt)
+		})
+
+		result.ReconstructedState, result.ExistedAsOf, result.Exact = reconstructResourceState(currentState, input.ResourceId, result.Changes)
+		if result.Truncated {
+			result.Exact = false
+		}
+
+		logger.FromContext(ctx).Debug("Reconstructed resource state",
+			slog.String("resourceId", input.ResourceId.String()),
+			slog.Int("changes", len(result.Changes)),
+			slog.Bool("exact", result.Exact))
+
+		return nil, &result, nil
+	}
+}
+
+// isResourceChange returns true if the activity successfully created, updated or deleted a resource
+func isResourceChange(activity AuditActivity) bool {
+	if activity.Result != nil && activity.Result.Status != nil && *activity.Result.Status != "SUCCESS" {
+		return false
+	}
+	switch resourceChangeVerb(activity) {
+	case "CREATED", "UPDATED", "DELETED":
+		return true
+	}
+	return false
+}
+
+// resourceChangeVerb returns the last segment of the action type, for example DELETED for USER.DELETED
+func resourceChangeVerb(activity AuditActivity) string {
+	actionType := activity.Action.Type
+	return actionType[strings.LastIndex(actionType, ".")+1:]
+}
+
+// reconstructResourceState replays the changes, newest first, backwards from the current state.
+// It returns the reconstructed state, whether the resource existed before the oldest change, and whether
+// the reconstructed state is exact.
+func reconstructResourceState(currentState map[string]any, resourceId uuid.UUID, changes []AuditActivity) (map[string]any, bool, bool) {
+	state, existed, exact := currentState, currentState != nil, true
+	for _, change := range changes {
+		switch resourceChangeVerb(change) {
+		case "CREATED":
+			state, existed = nil, false
+		case "DELETED":
+			// The resource existed before it was deleted, and the event records its last known name
+			state, existed, exact = deletedResourceState(resourceId, change), true, false
+		default:
+			// Audit events do not record previous field values, so an update cannot be reversed
+			exact = false
+		}
+	}
+	return state, existed, exact
+}
+
+// deletedResourceState returns the details of a deleted resource recorded by its delete event
+func deletedResourceState(resourceId uuid.UUID, change AuditActivity) map[string]any {
+	state := map[string]any{"id": resourceId.String()}
+	for _, resource := range change.Resources {
+		if resource.Id != nil && *resource.Id == resourceId.String() && resource.Name != nil {
+			state["name"] = *resource.Name
+		}
+	}
+	return state
+}
@@ -0,0 +1,257 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testResourceId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440003")
+
+// The end of the time range is the current time, so only the start and resource criteria are matched
+func matchesResourceFilter(filter string) bool {
+	return strings.HasPrefix(filter, `recordedAt gt "2025-01-01T00:00:00Z" and recordedAt lt `) &&
+		strings.HasSuffix(filter, ` and (resources.id eq "550e8400-e29b-41d4-a716-446655440003")`)
+}
+
+func baseGetResourceStateAsOfInput() audit.GetResourceStateAsOfInput {
+	return audit.GetResourceStateAsOfInput{
+		EnvironmentId: testEnvironmentId,
+		ResourceType:  audit.ResourceTypeApplication,
+		ResourceId:    testResourceId,
+		AsOf:          testStartTime,
+	}
+}
+
+func testResourceActivity(id, recordedAt, actionType string, status string) audit.AuditActivity {
+	return audit.AuditActivity{
+		Id:         id,
+		RecordedAt: recordedAt,
+		Action:     audit.AuditActivityAction{Type: actionType},
+		Resources: []audit.AuditActivityResource{
+			{Id: testutils.Pointer(testResourceId.String()), Name: testutils.Pointer("My App"), Type: testutils.Pointer("APPLICATION")},
+		},
+		Result: &audit.AuditActivityResult{Status: testutils.Pointer(status)},
+	}
+}
+
+func TestGetResourceStateAsOfHandler_MockClient(t *testing.T) {
+	currentState := map[string]any{"id": testResourceId.String(), "name": "My App", "enabled": true}
+	created := testResourceActivity("c1", "2025-01-02T10:00:00Z", "APPLICATION.CREATED", "SUCCESS")
+	updated := testResourceActivity("u1", "2025-01-03T10:00:00Z", "APPLICATION.UPDATED", "SUCCESS")
+	failedUpdate := testResourceActivity("u2", "2025-01-03T11:00:00Z", "APPLICATION.UPDATED", "FAILED")
+	deleted := testResourceActivity("d1", "2025-01-04T10:00:00Z", "APPLICATION.DELETED", "SUCCESS")
+	signOn := testResourceActivity("s1", "2025-01-03T12:00:00Z", "USER.ACCESS_ALLOWED", "SUCCESS")
+
+	testCases := []struct {
+		name                       string
+		currentState               map[string]any
+		notFound                   bool
+		pages                      []auditActivitiesMockPage
+		expectedReconstructedState map[string]any
+		expectedExistedAsOf        bool
+		expectedExact              bool
+		expectedChanges            []audit.AuditActivity
+	}{
+		{
+			name:                       "Unchanged resource is exact",
+			currentState:               currentState,
+			pages:                      []auditActivitiesMockPage{{Activities: []audit.AuditActivity{signOn, failedUpdate}}},
+			expectedReconstructedState: currentState,
+			expectedExistedAsOf:        true,
+			expectedExact:              true,
+			expectedChanges:            []audit.AuditActivity{},
+		},
+		{
+			name:                       "Updated resource is approximate",
+			currentState:               currentState,
+			pages:                      []auditActivitiesMockPage{{Activities: []audit.AuditActivity{updated, signOn}}},
+			expectedReconstructedState: currentState,
+			expectedExistedAsOf:        true,
+			expectedChanges:            []audit.AuditActivity{updated},
+		},
+		{
+			name:                "Resource created since asOf did not exist",
+			currentState:        currentState,
+			pages:               []auditActivitiesMockPage{{Activities: []audit.AuditActivity{created}}, {Activities: []audit.AuditActivity{updated}}},
+			expectedExistedAsOf: false,
+			expectedChanges:     []audit.AuditActivity{updated, created},
+		},
+		{
+			name:                       "Deleted resource reconstructed from delete event",
+			notFound:                   true,
+			pages:                      []auditActivitiesMockPage{{Activities: []audit.AuditActivity{updated, deleted}}},
+			expectedReconstructedState: map[string]any{"id": testResourceId.String(), "name": "My App"},
+			expectedExistedAsOf:        true,
+			expectedChanges:            []audit.AuditActivity{deleted, updated},
+		},
+		{
+			name:                "Resource deleted before asOf",
+			notFound:            true,
+			pages:               []auditActivitiesMockPage{{}},
+			expectedExistedAsOf: false,
+			expectedExact:       true,
+			expectedChanges:     []audit.AuditActivity{},
+		},
+	}
+
+	for _, tc := range testCases {
+		setupMock := func(mockClient *mockPingOneClientAuditWrapper) {
+			if tc.notFound {
+				mockClient.On("GetResource", mock.Anything, testEnvironmentId, audit.ResourceTypeApplication, testResourceId).Return(nil, &http.Response{StatusCode: http.StatusNotFound}, errors.New("404 Not Found"))
+			} else {
+				mockClient.On("GetResource", mock.Anything, testEnvironmentId, audit.ResourceTypeApplication, testResourceId).Return(tc.currentState, &http.Response{StatusCode: http.StatusOK}, nil)
+			}
+			mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, mock.MatchedBy(matchesResourceFilter), mock.Anything).Return(mockAuditActivitiesIterator(tc.pages), nil)
+		}
+
+		assertOutput := func(t *testing.T, output *audit.GetResourceStateAsOfOutput) {
+			assert.Equal(t, tc.currentState, output.CurrentState)
+			assert.Equal(t, tc.expectedReconstructedState, output.ReconstructedState)
+			assert.Equal(t, tc.expectedExistedAsOf, output.ExistedAsOf)
+			assert.Equal(t, tc.expectedExact, output.Exact)
+			assert.Equal(t, tc.expectedChanges, output.Changes)
+			assert.False(t, output.Truncated)
+			assert.True(t, matchesResourceFilter(output.Filter), "Unexpected filter %s", output.Filter)
+		}
+
+		// Test calling the handler directly
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			setupMock(mockClient)
+
+			handler := audit.GetResourceStateAsOfHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+			mcpResult, structuredResponse, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetResourceStateAsOfInput())
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, structuredResponse)
+			assertOutput(t, structuredResponse)
+			mockClient.AssertExpectations(t)
+		})
+
+		// Test via call over MCP
+		t.Run(tc.name+" via MCP", func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			setupMock(mockClient)
+
+			handler := audit.GetResourceStateAsOfHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+			server := mcptestutils.TestMcpServer(t)
+			mcp.AddTool(server, audit.GetResourceStateAsOfDef.McpTool, handler)
+
+			output, err := mcptestutils.CallToolOverMcp(t, server, audit.GetResourceStateAsOfDef.McpTool.Name, baseGetResourceStateAsOfInput())
+			require.NoError(t, err, "Expect no error calling tool")
+			require.NotNil(t, output, "Expect non-nil output")
+			testutils.AssertMcpCallSuccess(t, err, output)
+
+			outputResult := &audit.GetResourceStateAsOfOutput{}
+			jsonBytes, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err, "Failed to marshal structured content")
+			err = json.Unmarshal(jsonBytes, outputResult)
+			require.NoError(t, err, "Failed to unmarshal structured content")
+
+			assertOutput(t, outputResult)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetResourceStateAsOfHandler_Truncated(t *testing.T) {
+	activities := make([]audit.AuditActivity, 1001)
+	for i := range activities {
+		activities[i] = testResourceActivity("s", "2025-01-03T12:00:00Z", "USER.ACCESS_ALLOWED", "SUCCESS")
+	}
+	currentState := map[string]any{"id": testResourceId.String()}
+
+	mockClient := &mockPingOneClientAuditWrapper{}
+	mockClient.On("GetResource", mock.Anything, testEnvironmentId, audit.ResourceTypeApplication, testResourceId).Return(currentState, &http.Response{StatusCode: http.StatusOK}, nil)
+	mockClient.On("GetAuditActivities", mock.Anything, testEnvironmentId, mock.Anything, mock.Anything).Return(mockAuditActivitiesIterator([]auditActivitiesMockPage{{Activities: activities}}), nil)
+	handler := audit.GetResourceStateAsOfHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetResourceStateAsOfInput())
+
+	require.NoError(t, err)
+	assert.True(t, output.Truncated)
+	assert.False(t, output.Exact)
+}
+
+func TestGetResourceStateAsOfHandler_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(*audit.GetResourceStateAsOfInput)
+		wantErrContains string
+	}{
+		{
+			name:            "Unsupported resource type",
+			modify:          func(input *audit.GetResourceStateAsOfInput) { input.ResourceType = "ENVIRONMENT" },
+			wantErrContains: "resourceType must be one of APPLICATION, GROUP, POPULATION, USER",
+		},
+		{
+			name:            "Invalid asOf",
+			modify:          func(input *audit.GetResourceStateAsOfInput) { input.AsOf = "last tuesday" },
+			wantErrContains: "asOf must be an RFC 3339 timestamp",
+		},
+		{
+			name:            "asOf in the future",
+			modify:          func(input *audit.GetResourceStateAsOfInput) { input.AsOf = "2999-01-01T00:00:00Z" },
+			wantErrContains: "asOf must be in the past",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			handler := audit.GetResourceStateAsOfHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+			input := baseGetResourceStateAsOfInput()
+			tt.modify(&input)
+
+			mcpResult, response, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, response, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetResourceStateAsOfHandler_APIErrors(t *testing.T) {
+	tests := testutils.CommonAPIErrorTestCases()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			mockClient.On("GetResource", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, tt.ApiError)
+			handler := audit.GetResourceStateAsOfHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+			mcpResult, response, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetResourceStateAsOfInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, response, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetResourceStateAsOfHandler_GetAuthenticatedClientError(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	clientFactoryErr := errors.New("failed to get authenticated client")
+	handler := audit.GetResourceStateAsOfHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, clientFactoryErr))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, baseGetResourceStateAsOfInput())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get authenticated client")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, output)
+}
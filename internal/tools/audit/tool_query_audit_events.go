@@ -0,0 +1,243 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	defaultAuditEventsLimit = 100
+	maxAuditEventsLimit     = 1000
+
+	// Aggregated queries only return counts, so can summarize more events without bloating the result
+	defaultAggregatedAuditEventsLimit = 1000
+	maxAggregatedAuditEventsLimit     = 10000
+
+	// maxAuditEventsPageSize is the largest page size accepted by the PingOne audit activities API
+	maxAuditEventsPageSize = 1000
+)
+
+var QueryAuditEventsDef = types.ToolDefinition{
+	// Audit events keep arriving without write tools being called
+	DisableResponseCache: true,
+	McpTool: &mcp.Tool{
+		Name:  "query_audit_events",
+		Title: "Query PingOne Audit Events",
+		Description: `Query audit activity events recorded in an environment within a time range. Use to answer questions like "who deleted users yesterday" or "what changed on application xyz".
+
+Optionally narrow results by actor user ID, actor client ID, action type (e.g. USER.DELETED, APPLICATION.UPDATED) or an additional SCIM filter, which is combined with the other criteria using 'and'. Additional filter examples: resources.id eq "resource-uuid", correlationId eq "correlation-id", result.status eq "FAILED".
+
+Results are ordered by the API and capped at 'limit'; 'truncated' is true when more events matched than were returned, in which case narrow the time range or filter.
+
+To discuss trends over many events, set 'groupBy' (actor, action or result) and/or 'timeBucket' (hour or day) to return event counts per group in 'groups' instead of the raw events. In this mode 'limit' caps the number of events counted, up to 10000.`,
+		InputSchema:  schema.MustGenerateSchema[QueryAuditEventsInput](),
+		OutputSchema: schema.MustGenerateSchema[QueryAuditEventsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type QueryAuditEventsInput struct {
+	EnvironmentId uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	StartTime     string     `json:"startTime" jsonschema:"REQUIRED. Start of the time range (RFC 3339, e.g. 2025-01-01T00:00:00Z)."`
+	EndTime       *string    `json:"endTime,omitempty" jsonschema:"OPTIONAL. End of the time range (RFC 3339). Defaults to the current time."`
+	ActorUserId   *uuid.UUID `json:"actorUserId,omitempty" jsonschema:"OPTIONAL. Only return events performed by this user UUID."`
+	ActorClientId *uuid.UUID `json:"actorClientId,omitempty" jsonschema:"OPTIONAL. Only return events performed by this client application UUID."`
+	ActionType    *string    `json:"actionType,omitempty" jsonschema:"OPTIONAL. Only return events of this action type, e.g. USER.DELETED."`
+	Filter        *string    `json:"filter,omitempty" jsonschema:"OPTIONAL. Additional SCIM filter combined with the other criteria using 'and'."`
+	Limit         *int       `json:"limit,omitempty" jsonschema:"OPTIONAL. Maximum number of events to return, 1-1000. Defaults to 100. When aggregating, the maximum number of events to count, 1-10000, defaulting to 1000."`
+	GroupBy       *string    `json:"groupBy,omitempty" jsonschema:"OPTIONAL. Return event counts grouped by actor, action or result instead of the raw events."`
+	TimeBucket    *string    `json:"timeBucket,omitempty" jsonschema:"OPTIONAL. Return event counts per hour or day instead of the raw events. Can be combined with groupBy."`
+}
+
+type QueryAuditEventsOutput struct {
+	Activities []AuditActivity    `json:"activities" jsonschema:"List of audit activity events matching the query. Empty when aggregating"`
+	Groups     []AuditEventsGroup `json:"groups,omitempty" jsonschema:"Event counts per group, when aggregating with groupBy or timeBucket"`
+	Count      int                `json:"count" jsonschema:"The number of events returned, or counted when aggregating"`
+	Truncated  bool               `json:"truncated" jsonschema:"True if more events matched the query than were returned"`
+	Filter     string             `json:"filter" jsonschema:"The SCIM filter sent to the PingOne API"`
+}
+
+// QueryAuditEventsHandler queries PingOne audit activities using the provided client
+func QueryAuditEventsHandler(auditClientFactory AuditClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input QueryAuditEventsInput,
+) (
+	*mcp.CallToolResult,
+	*QueryAuditEventsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input QueryAuditEventsInput) (*mcp.CallToolResult, *QueryAuditEventsOutput, error) {
+		filter, err := buildAuditEventsFilter(input, time.Now())
+		if err != nil {
+			toolErr := errs.NewToolError(QueryAuditEventsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		aggregator, err := newAuditEventsAggregator(input.GroupBy, input.TimeBucket)
+		if err != nil {
+			toolErr := errs.NewToolError(QueryAuditEventsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		limit, maxLimit := defaultAuditEventsLimit, maxAuditEventsLimit
+		if aggregator != nil {
+			limit, maxLimit = defaultAggregatedAuditEventsLimit, maxAggregatedAuditEventsLimit
+		}
+		if input.Limit != nil {
+			if *input.Limit < 1 || *input.Limit > maxLimit {
+				toolErr := errs.NewToolError(QueryAuditEventsDef.McpTool.Name, fmt.Errorf("limit must be between 1 and %d", maxLimit))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			limit = *input.Limit
+		}
+
+		client, err := auditClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(QueryAuditEventsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Querying audit events",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("filter", filter),
+			slog.Int("limit", limit),
+			slog.Bool("aggregated", aggregator != nil),
+		)
+
+		pageSize := int32(min(limit, maxAuditEventsPageSize))
+		pagedIterator, err := client.GetAuditActivities(ctx, input.EnvironmentId, filter, &pageSize)
+		if err != nil {
+			toolErr := errs.NewToolError(QueryAuditEventsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		result := QueryAuditEventsOutput{
+			Activities: []AuditActivity{},
+			Filter:     filter,
+		}
+		count := 0
+
+	pages:
+		for next, err := range pagedIterator {
+			logger.LogHttpResponse(ctx, next.HTTPResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(next.HTTPResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.Page == nil {
+				// This should never happen, err should be set if no data
+				apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+
+			// An empty result set may omit the embedded activities entirely
+			if next.Page.Embedded == nil {
+				continue
+			}
+			logger.FromContext(ctx).Debug("Retrieved audit events page", slog.Int("count", len(next.Page.Embedded.Activities)))
+
+			for _, activity := range next.Page.Embedded.Activities {
+				if count >= limit {
+					result.Truncated = true
+					break pages
+				}
+				if aggregator != nil {
+					if err := aggregator.Add(activity); err != nil {
+						toolErr := errs.NewToolError(QueryAuditEventsDef.McpTool.Name, err)
+						errs.Log(ctx, toolErr)
+						return nil, nil, toolErr
+					}
+				} else {
+					result.Activities = append(result.Activities, activity)
+				}
+				count++
+			}
+			progress.ReportFetched(ctx, count, 0, "audit events")
+
+			if count >= limit && next.Page.NextLink() != nil {
+				result.Truncated = true
+				break
+			}
+		}
+
+		result.Count = count
+		if aggregator != nil {
+			result.Groups = aggregator.Groups()
+		}
+
+		return nil, &result, nil
+	}
+}
+
+// buildAuditEventsFilter combines the structured query inputs into a single SCIM filter
+// accepted by the PingOne audit activities API, which always requires a recordedAt range
+func buildAuditEventsFilter(input QueryAuditEventsInput, now time.Time) (string, error) {
+	startTime, err := time.Parse(time.RFC3339, input.StartTime)
+	if err != nil {
+		return "", fmt.Errorf("startTime must be an RFC 3339 timestamp: %w", err)
+	}
+
+	endTime := now.UTC()
+	if input.EndTime != nil && *input.EndTime != "" {
+		endTime, err = time.Parse(time.RFC3339, *input.EndTime)
+		if err != nil {
+			return "", fmt.Errorf("endTime must be an RFC 3339 timestamp: %w", err)
+		}
+	}
+
+	if !startTime.Before(endTime) {
+		return "", errors.New("startTime must be before endTime")
+	}
+
+	clauses := []string{
+		fmt.Sprintf("recordedAt gt %s", strconv.Quote(startTime.UTC().Format(time.RFC3339))),
+		fmt.Sprintf("recordedAt lt %s", strconv.Quote(endTime.UTC().Format(time.RFC3339))),
+	}
+
+	if input.ActorUserId != nil {
+		clauses = append(clauses, fmt.Sprintf("actors.user.id eq %s", strconv.Quote(input.ActorUserId.String())))
+	}
+
+	if input.ActorClientId != nil {
+		clauses = append(clauses, fmt.Sprintf("actors.client.id eq %s", strconv.Quote(input.ActorClientId.String())))
+	}
+
+	if input.ActionType != nil && *input.ActionType != "" {
+		clauses = append(clauses, fmt.Sprintf("action.type eq %s", strconv.Quote(*input.ActionType)))
+	}
+
+	if input.Filter != nil && strings.TrimSpace(*input.Filter) != "" {
+		if err := scimfilter.AuditActivities.Validate(*input.Filter); err != nil {
+			return "", err
+		}
+		clauses = append(clauses, fmt.Sprintf("(%s)", strings.TrimSpace(*input.Filter)))
+	}
+
+	return strings.Join(clauses, " and "), nil
+}
@@ -0,0 +1,207 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	WebhookHeaderStatusMatched    = "MATCHED"
+	WebhookHeaderStatusMismatched = "MISMATCHED"
+	WebhookHeaderStatusMissing    = "MISSING"
+)
+
+var VerifyWebhookEventDef = types.ToolDefinition{
+	// Received headers carry webhook secrets, which must not be retained in cache keys
+	DisableResponseCache: true,
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "verify_webhook_event",
+		Title: "Verify PingOne Webhook Event",
+		Description: `Verify a webhook request received from a PingOne webhook subscription, and decode its payload into a structured audit event. Use to debug webhook receivers.
+
+PingOne authenticates webhook requests with the custom headers configured on the subscription (for example an Authorization header), and optionally a TLS client certificate. This tool checks that the received headers match the subscription's configured headers; header values are never returned. TLS client certificates are verified during the TLS handshake and cannot be verified from the payload, so 'tlsClientAuthConfigured' only reports whether the receiver should expect one.
+
+The payload is decoded according to the subscription's format (ACTIVITY or SPLUNK). If it cannot be decoded, 'decodeError' explains why.`,
+		InputSchema:  schema.MustGenerateSchema[VerifyWebhookEventInput](),
+		OutputSchema: schema.MustGenerateSchema[VerifyWebhookEventOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type VerifyWebhookEventInput struct {
+	EnvironmentId  uuid.UUID         `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	SubscriptionId uuid.UUID         `json:"subscriptionId" jsonschema:"REQUIRED. UUID of the webhook subscription that sent the request."`
+	Headers        map[string]string `json:"headers,omitempty" jsonschema:"OPTIONAL. The HTTP headers of the received request, by header name. Header names are matched case-insensitively."`
+	Payload        string            `json:"payload" jsonschema:"REQUIRED. The raw JSON body of the received request."`
+}
+
+type VerifyWebhookEventOutput struct {
+	SubscriptionName        string               `json:"subscriptionName" jsonschema:"The name of the webhook subscription"`
+	Format                  string               `json:"format" jsonschema:"The payload format of the subscription (ACTIVITY, SPLUNK or NEWRELIC)"`
+	Verified                bool                 `json:"verified" jsonschema:"True if the subscription has authentication headers configured and all of them matched the received headers"`
+	HeaderChecks            []WebhookHeaderCheck `json:"headerChecks" jsonschema:"The result of checking each header configured on the subscription"`
+	TlsClientAuthConfigured bool                 `json:"tlsClientAuthConfigured" jsonschema:"True if the subscription presents a TLS client certificate, which the receiver must verify during the TLS handshake"`
+	Event                   *AuditActivity       `json:"event,omitempty" jsonschema:"The audit event decoded from the payload"`
+	DecodeError             *string              `json:"decodeError,omitempty" jsonschema:"Why the payload could not be decoded into an audit event"`
+}
+
+type WebhookHeaderCheck struct {
+	Name   string `json:"name" jsonschema:"The header name configured on the subscription"`
+	Status string `json:"status" jsonschema:"MATCHED if the received value matches, MISMATCHED if it differs, or MISSING if the header was not received"`
+}
+
+// VerifyWebhookEventHandler verifies a received webhook request against its PingOne subscription using the provided client
+func VerifyWebhookEventHandler(auditClientFactory AuditClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input VerifyWebhookEventInput,
+) (
+	*mcp.CallToolResult,
+	*VerifyWebhookEventOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input VerifyWebhookEventInput) (*mcp.CallToolResult, *VerifyWebhookEventOutput, error) {
+		client, err := auditClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(VerifyWebhookEventDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Retrieving webhook subscription",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("subscriptionId", input.SubscriptionId.String()))
+
+		subscription, httpResponse, err := client.GetSubscription(ctx, input.EnvironmentId, input.SubscriptionId)
+		logger.LogHttpResponse(ctx, httpResponse)
+
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		if subscription == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no subscription data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		headerChecks := checkWebhookHeaders(subscription.HttpEndpoint.Headers, input.Headers)
+		verified := len(headerChecks) > 0
+		for _, check := range headerChecks {
+			if check.Status != WebhookHeaderStatusMatched {
+				verified = false
+			}
+		}
+
+		result := &VerifyWebhookEventOutput{
+			SubscriptionName:        subscription.Name,
+			Format:                  string(subscription.Format),
+			Verified:                verified,
+			HeaderChecks:            headerChecks,
+			TlsClientAuthConfigured: subscription.TlsClientAuthKeyPair != nil,
+		}
+
+		event, err := decodeWebhookPayload(subscription.Format, input.Payload)
+		if err != nil {
+			decodeError := err.Error()
+			result.DecodeError = &decodeError
+		} else {
+			result.Event = event
+		}
+
+		logger.FromContext(ctx).Debug("Webhook event verified",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("subscriptionId", input.SubscriptionId.String()),
+			slog.Bool("verified", verified),
+			slog.Bool("decoded", event != nil))
+
+		return nil, result, nil
+	}
+}
+
+// checkWebhookHeaders compares each header configured on the subscription with the received headers.
+// Values are compared in constant time, and only the outcome of each comparison is returned.
+func checkWebhookHeaders(configured *map[string]string, received map[string]string) []WebhookHeaderCheck {
+	if configured == nil {
+		return []WebhookHeaderCheck{}
+	}
+
+	receivedByName := make(map[string]string, len(received))
+	for name, value := range received {
+		receivedByName[http.CanonicalHeaderKey(name)] = value
+	}
+
+	checks := make([]WebhookHeaderCheck, 0, len(*configured))
+	for name, expected := range *configured {
+		check := WebhookHeaderCheck{Name: name}
+		actual, ok := receivedByName[http.CanonicalHeaderKey(name)]
+		switch {
+		case !ok:
+			check.Status = WebhookHeaderStatusMissing
+		case subtle.ConstantTimeCompare([]byte(actual), []byte(expected)) == 1:
+			check.Status = WebhookHeaderStatusMatched
+		default:
+			check.Status = WebhookHeaderStatusMismatched
+		}
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+	return checks
+}
+
+// decodeWebhookPayload decodes the audit event from a webhook payload in the given subscription format.
+func decodeWebhookPayload(format management.EnumSubscriptionFormat, payload string) (*AuditActivity, error) {
+	var eventJSON json.RawMessage
+	switch format {
+	case management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY:
+		eventJSON = json.RawMessage(payload)
+	case management.ENUMSUBSCRIPTIONFORMAT_SPLUNK:
+		// Splunk HTTP Event Collector payloads hold the activity in the event field
+		var envelope struct {
+			Event json.RawMessage `json:"event"`
+		}
+		if err := json.Unmarshal([]byte(payload), &envelope); err != nil {
+			return nil, fmt.Errorf("payload is not valid JSON: %w", err)
+		}
+		if len(envelope.Event) == 0 {
+			return nil, errors.New("SPLUNK format payload has no event field")
+		}
+		eventJSON = envelope.Event
+	default:
+		return nil, fmt.Errorf("decoding %s format payloads is not supported", format)
+	}
+
+	var event AuditActivity
+	if err := json.Unmarshal(eventJSON, &event); err != nil {
+		return nil, fmt.Errorf("payload is not a valid audit event: %w", err)
+	}
+	if event.Id == "" || event.Action.Type == "" {
+		return nil, errors.New("payload is not an audit event, the id and action.type fields are required")
+	}
+	return &event, nil
+}
@@ -0,0 +1,290 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testSubscriptionId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+
+const (
+	testActivityPayload = `{"id":"a1","recordedAt":"2025-01-01T10:00:00Z","action":{"type":"USER.DELETED"}}`
+	testSplunkPayload   = `{"time":1735725600,"source":"pingone","event":` + testActivityPayload + `}`
+)
+
+func testSubscription(format management.EnumSubscriptionFormat, headers map[string]string) *management.Subscription {
+	subscription := &management.Subscription{
+		Name:    "Audit receiver",
+		Enabled: true,
+		Format:  format,
+		HttpEndpoint: management.SubscriptionHttpEndpoint{
+			Url: "https://receiver.example.com/webhook",
+		},
+	}
+	if headers != nil {
+		subscription.HttpEndpoint.Headers = &headers
+	}
+	return subscription
+}
+
+func baseVerifyWebhookEventInput() audit.VerifyWebhookEventInput {
+	return audit.VerifyWebhookEventInput{
+		EnvironmentId:  testEnvironmentId,
+		SubscriptionId: testSubscriptionId,
+		Headers: map[string]string{
+			"authorization": "Bearer secret",
+			"Content-Type":  "application/json",
+		},
+		Payload: testActivityPayload,
+	}
+}
+
+func TestVerifyWebhookEventHandler_MockClient(t *testing.T) {
+	expectedEvent := &audit.AuditActivity{
+		Id:         "a1",
+		RecordedAt: "2025-01-01T10:00:00Z",
+		Action:     audit.AuditActivityAction{Type: "USER.DELETED"},
+	}
+
+	testCases := []struct {
+		name                  string
+		input                 audit.VerifyWebhookEventInput
+		subscription          *management.Subscription
+		expectedVerified      bool
+		expectedHeaderChecks  []audit.WebhookHeaderCheck
+		expectedEvent         *audit.AuditActivity
+		expectedDecodeErrorIn string
+	}{
+		{
+			name:             "Success - Header matched case-insensitively",
+			input:            baseVerifyWebhookEventInput(),
+			subscription:     testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, map[string]string{"Authorization": "Bearer secret"}),
+			expectedVerified: true,
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMatched},
+			},
+			expectedEvent: expectedEvent,
+		},
+		{
+			name:         "Header mismatched",
+			input:        baseVerifyWebhookEventInput(),
+			subscription: testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, map[string]string{"Authorization": "Bearer other"}),
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMismatched},
+			},
+			expectedEvent: expectedEvent,
+		},
+		{
+			name:         "Header missing",
+			input:        baseVerifyWebhookEventInput(),
+			subscription: testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, map[string]string{"Authorization": "Bearer secret", "X-Tenant": "acme"}),
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMatched},
+				{Name: "X-Tenant", Status: audit.WebhookHeaderStatusMissing},
+			},
+			expectedEvent: expectedEvent,
+		},
+		{
+			name:                 "No headers configured is not verified",
+			input:                baseVerifyWebhookEventInput(),
+			subscription:         testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, nil),
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{},
+			expectedEvent:        expectedEvent,
+		},
+		{
+			name: "Success - Splunk format",
+			input: func() audit.VerifyWebhookEventInput {
+				input := baseVerifyWebhookEventInput()
+				input.Payload = testSplunkPayload
+				return input
+			}(),
+			subscription:     testSubscription(management.ENUMSUBSCRIPTIONFORMAT_SPLUNK, map[string]string{"Authorization": "Bearer secret"}),
+			expectedVerified: true,
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMatched},
+			},
+			expectedEvent: expectedEvent,
+		},
+		{
+			name:             "Splunk format without event",
+			input:            baseVerifyWebhookEventInput(),
+			subscription:     testSubscription(management.ENUMSUBSCRIPTIONFORMAT_SPLUNK, map[string]string{"Authorization": "Bearer secret"}),
+			expectedVerified: true,
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMatched},
+			},
+			expectedDecodeErrorIn: "SPLUNK format payload has no event field",
+		},
+		{
+			name:             "New Relic format not decoded",
+			input:            baseVerifyWebhookEventInput(),
+			subscription:     testSubscription(management.ENUMSUBSCRIPTIONFORMAT_NEWRELIC, map[string]string{"Authorization": "Bearer secret"}),
+			expectedVerified: true,
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMatched},
+			},
+			expectedDecodeErrorIn: "decoding NEWRELIC format payloads is not supported",
+		},
+		{
+			name: "Payload not an audit event",
+			input: func() audit.VerifyWebhookEventInput {
+				input := baseVerifyWebhookEventInput()
+				input.Payload = `{"hello":"world"}`
+				return input
+			}(),
+			subscription:     testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, map[string]string{"Authorization": "Bearer secret"}),
+			expectedVerified: true,
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMatched},
+			},
+			expectedDecodeErrorIn: "payload is not an audit event",
+		},
+		{
+			name: "Payload not JSON",
+			input: func() audit.VerifyWebhookEventInput {
+				input := baseVerifyWebhookEventInput()
+				input.Payload = "not json"
+				return input
+			}(),
+			subscription:     testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, map[string]string{"Authorization": "Bearer secret"}),
+			expectedVerified: true,
+			expectedHeaderChecks: []audit.WebhookHeaderCheck{
+				{Name: "Authorization", Status: audit.WebhookHeaderStatusMatched},
+			},
+			expectedDecodeErrorIn: "payload is not a valid audit event",
+		},
+	}
+
+	for _, tc := range testCases {
+		setupMock := func(mockClient *mockPingOneClientAuditWrapper) {
+			mockClient.On("GetSubscription", mock.Anything, testEnvironmentId, testSubscriptionId).Return(tc.subscription, &http.Response{StatusCode: 200}, nil)
+		}
+
+		assertOutput := func(t *testing.T, output *audit.VerifyWebhookEventOutput) {
+			assert.Equal(t, tc.subscription.Name, output.SubscriptionName)
+			assert.Equal(t, string(tc.subscription.Format), output.Format)
+			assert.Equal(t, tc.expectedVerified, output.Verified)
+			assert.Equal(t, tc.expectedHeaderChecks, output.HeaderChecks)
+			assert.False(t, output.TlsClientAuthConfigured)
+			assert.Equal(t, tc.expectedEvent, output.Event)
+			if tc.expectedDecodeErrorIn != "" {
+				require.NotNil(t, output.DecodeError)
+				assert.Contains(t, *output.DecodeError, tc.expectedDecodeErrorIn)
+			} else {
+				assert.Nil(t, output.DecodeError)
+			}
+		}
+
+		// Test calling the handler directly
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			setupMock(mockClient)
+
+			handler := audit.VerifyWebhookEventHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+			mcpResult, structuredResponse, err := handler(context.Background(), &mcp.CallToolRequest{}, tc.input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, structuredResponse)
+			assertOutput(t, structuredResponse)
+			mockClient.AssertExpectations(t)
+		})
+
+		// Test via call over MCP
+		t.Run(tc.name+" via MCP", func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			setupMock(mockClient)
+
+			handler := audit.VerifyWebhookEventHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+			server := mcptestutils.TestMcpServer(t)
+			mcp.AddTool(server, audit.VerifyWebhookEventDef.McpTool, handler)
+
+			output, err := mcptestutils.CallToolOverMcp(t, server, audit.VerifyWebhookEventDef.McpTool.Name, tc.input)
+			require.NoError(t, err, "Expect no error calling tool")
+			require.NotNil(t, output, "Expect non-nil output")
+			testutils.AssertMcpCallSuccess(t, err, output)
+
+			outputResult := &audit.VerifyWebhookEventOutput{}
+			jsonBytes, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err, "Failed to marshal structured content")
+			err = json.Unmarshal(jsonBytes, outputResult)
+			require.NoError(t, err, "Failed to unmarshal structured content")
+
+			assertOutput(t, outputResult)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVerifyWebhookEventHandler_HeaderValuesNotReturned(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	subscription := testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, map[string]string{"Authorization": "Bearer expected-secret"})
+	mockClient.On("GetSubscription", mock.Anything, testEnvironmentId, testSubscriptionId).Return(subscription, &http.Response{StatusCode: 200}, nil)
+	handler := audit.VerifyWebhookEventHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+	input := baseVerifyWebhookEventInput()
+	input.Headers["Authorization"] = "Bearer received-secret"
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+	require.NoError(t, err)
+
+	outputJSON, err := json.Marshal(output)
+	require.NoError(t, err)
+	assert.NotContains(t, string(outputJSON), "expected-secret")
+	assert.NotContains(t, string(outputJSON), "received-secret")
+}
+
+func TestVerifyWebhookEventHandler_TlsClientAuthConfigured(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	subscription := testSubscription(management.ENUMSUBSCRIPTIONFORMAT_ACTIVITY, map[string]string{"Authorization": "Bearer secret"})
+	subscription.TlsClientAuthKeyPair = &management.SubscriptionTlsClientAuthKeyPair{}
+	mockClient.On("GetSubscription", mock.Anything, testEnvironmentId, testSubscriptionId).Return(subscription, &http.Response{StatusCode: 200}, nil)
+	handler := audit.VerifyWebhookEventHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, baseVerifyWebhookEventInput())
+
+	require.NoError(t, err)
+	assert.True(t, output.TlsClientAuthConfigured)
+}
+
+func TestVerifyWebhookEventHandler_APIErrors(t *testing.T) {
+	tests := testutils.CommonAPIErrorTestCases()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuditWrapper{}
+			mockClient.On("GetSubscription", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, tt.ApiError)
+			handler := audit.VerifyWebhookEventHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, nil))
+
+			mcpResult, response, err := handler(context.Background(), &mcp.CallToolRequest{}, baseVerifyWebhookEventInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, response, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestVerifyWebhookEventHandler_GetAuthenticatedClientError(t *testing.T) {
+	mockClient := &mockPingOneClientAuditWrapper{}
+	clientFactoryErr := errors.New("failed to get authenticated client")
+	handler := audit.VerifyWebhookEventHandler(NewMockPingOneClientAuditWrapperFactory(mockClient, clientFactoryErr))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, baseVerifyWebhookEventInput())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get authenticated client")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, output)
+}
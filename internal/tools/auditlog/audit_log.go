@@ -0,0 +1,140 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+	// StatusDenied is the status of calls denied by the tool policy, which are recorded for any tool
+	StatusDenied = "denied"
+)
+
+// Entry records a call of a tool that changes PingOne configuration, or a call the tool policy denied.
+type Entry struct {
+	Timestamp     time.Time      `json:"timestamp"`
+	Tool          string         `json:"tool"`
+	EnvironmentId string         `json:"environmentId,omitempty"`
+	Arguments     map[string]any `json:"arguments,omitempty"`
+	// Principal is who the PingOne session acts as: the user, or the client for client credentials.
+	Principal string `json:"principal,omitempty"`
+	// ClientUser is the user of the MCP client, when the HTTP transport authenticates clients with OAuth.
+	ClientUser    string   `json:"clientUser,omitempty"`
+	SessionId     string   `json:"sessionId,omitempty"`
+	TransactionId string   `json:"transactionId,omitempty"`
+	Status        string   `json:"status"`
+	Error         string   `json:"error,omitempty"`
+	ResourceIds   []string `json:"resourceIds,omitempty"`
+	DurationMs    int64    `json:"durationMs"`
+}
+
+// Sink records audit entries.
+type Sink interface {
+	Write(entry Entry) error
+}
+
+var _ Sink = &FileLog{}
+
+// FileLog is an append-only audit log stored as one JSON entry per line. It is safe for concurrent use.
+type FileLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileLog creates the audit log at path, or opens it if it exists, so that an unwritable path is reported
+// when the server starts rather than when the first entry is written.
+func NewFileLog(path string) (*FileLog, error) {
+	if path == "" {
+		return nil, errors.New("audit log file path is empty")
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &FileLog{
+		path: path,
+	}, nil
+}
+
+// FilePath returns the path of the audit log file.
+func (l *FileLog) FilePath() string {
+	return l.path
+}
+
+// Write appends the entry to the log. The file is opened for each entry, so that the log can be rotated
+// while the server runs.
+func (l *FileLog) Write(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write audit log entry: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write audit log entry: %w", err)
+	}
+	return nil
+}
+
+// Entries returns the entries of the log in the order they were written, and the number of lines that are
+// not valid entries, such as a line cut short when the server was killed while writing it.
+func (l *FileLog) Entries() ([]Entry, int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Entry{}, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	defer file.Close()
+
+	entries := []Entry{}
+	invalidLines := 0
+	// Lines are read whole rather than with a Scanner, as entries of large arguments can exceed its buffer
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var entry Entry
+			if jsonErr := json.Unmarshal(line, &entry); jsonErr != nil {
+				invalidLines++
+			} else {
+				entries = append(entries, entry)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read audit log file: %w", err)
+		}
+	}
+	return entries, invalidLines, nil
+}
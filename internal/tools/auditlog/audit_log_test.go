@@ -0,0 +1,92 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auditlog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFileLog_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	log, err := auditlog.NewFileLog(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, path, log.FilePath())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestNewFileLog_InvalidPath(t *testing.T) {
+	_, err := auditlog.NewFileLog("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "audit log file path is empty")
+
+	_, err = auditlog.NewFileLog(filepath.Join(t.TempDir(), "missing", "audit.jsonl"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to open audit log file")
+}
+
+func TestFileLog_WriteAppendsEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"tool":"existing_entry","status":"success"}`+"\n"), 0600))
+	log, err := auditlog.NewFileLog(path)
+	require.NoError(t, err)
+
+	entry := auditlog.Entry{
+		Timestamp:     time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Tool:          "create_population",
+		EnvironmentId: "env-1",
+		Arguments:     map[string]any{"name": "Customers"},
+		Principal:     "user-1",
+		Status:        auditlog.StatusSuccess,
+		ResourceIds:   []string{"9a3c8a3e-3b3f-4c7a-9a3c-8a3e3b3f4c7a"},
+		DurationMs:    42,
+	}
+	require.NoError(t, log.Write(entry))
+
+	entries, invalidLines, err := log.Entries()
+	require.NoError(t, err)
+	assert.Equal(t, 0, invalidLines)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "existing_entry", entries[0].Tool)
+	assert.Equal(t, entry, entries[1])
+}
+
+func TestFileLog_EntriesSkipsInvalidLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(
+		`{"tool":"create_population","status":"success"}`+"\n"+
+			"\n"+
+			`{"tool":"update_popul`), 0600))
+	log, err := auditlog.NewFileLog(path)
+	require.NoError(t, err)
+
+	entries, invalidLines, err := log.Entries()
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, invalidLines)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "create_population", entries[0].Tool)
+}
+
+func TestFileLog_EntriesOfDeletedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log, err := auditlog.NewFileLog(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(path))
+
+	entries, invalidLines, err := log.Entries()
+
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, 0, invalidLines)
+}
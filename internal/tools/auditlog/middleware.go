@@ -0,0 +1,156 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auditlog
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// AuditLogMiddleware records every call of a write tool to a Sink, so that operators can reconstruct what
+// an agent changed: when, with which arguments, as which principal, whether it succeeded, and which
+// resources it affected. Sensitive arguments are redacted before they are recorded.
+//
+// Calls of read-only tools are not recorded. Calls are recorded when they complete, including calls that
+// were rejected, such as by environment validation.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after authentication, so
+// that the principal of the call is known, and before any middleware that rejects calls.
+type AuditLogMiddleware struct {
+	sink          Sink
+	mutationTools map[string]bool
+}
+
+// NewAuditLogMiddleware creates middleware that records the calls of the write tools in toolDefs to the sink.
+// A nil sink records nothing.
+func NewAuditLogMiddleware(sink Sink, toolDefs []types.ToolDefinition) *AuditLogMiddleware {
+	mutationTools := make(map[string]bool)
+	for _, toolDef := range toolDefs {
+		if !toolDef.IsReadOnly() {
+			mutationTools[toolDef.McpTool.Name] = true
+		}
+	}
+	return &AuditLogMiddleware{
+		sink:          sink,
+		mutationTools: mutationTools,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *AuditLogMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.sink == nil {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || !m.mutationTools[callToolReq.Params.Name] {
+			return next(ctx, method, req)
+		}
+
+		start := time.Now()
+		result, err := next(ctx, method, req)
+
+		entry := newEntry(ctx, callToolReq, start)
+		entry.Status = StatusSuccess
+		entry.DurationMs = time.Since(start).Milliseconds()
+
+		var structuredContent any
+		callToolResult, _ := result.(*mcp.CallToolResult)
+		switch {
+		case err != nil:
+			entry.Status = StatusError
+			entry.Error = err.Error()
+		case callToolResult != nil && callToolResult.IsError:
+			entry.Status = StatusError
+			entry.Error = resultText(callToolResult)
+		case callToolResult != nil:
+			structuredContent = jsonValue(callToolResult.StructuredContent)
+		}
+		entry.ResourceIds = resourceIds(jsonValue(callToolReq.Params.Arguments), structuredContent)
+
+		// The call has already completed, so a failure to record it is logged rather than failing the call
+		write(ctx, m.sink, entry)
+
+		return result, err
+	}
+}
+
+// RecordDenied records a call of any tool that was denied before it ran, with the reason it was denied. A nil
+// sink records nothing.
+func RecordDenied(ctx context.Context, sink Sink, req *mcp.CallToolRequest, reason string) {
+	if sink == nil {
+		return
+	}
+	entry := newEntry(ctx, req, time.Now())
+	entry.Status = StatusDenied
+	entry.Error = reason
+	entry.ResourceIds = resourceIds(jsonValue(req.Params.Arguments), nil)
+	write(ctx, sink, entry)
+}
+
+// newEntry returns the entry of a call started at start, without its outcome
+func newEntry(ctx context.Context, req *mcp.CallToolRequest, start time.Time) Entry {
+	entry := Entry{
+		Timestamp:     start.UTC(),
+		Tool:          req.Params.Name,
+		Arguments:     redactArguments(req.Params.Arguments),
+		Principal:     audit.PrincipalFromContext(ctx),
+		SessionId:     audit.SessionIdFromContext(ctx),
+		TransactionId: audit.TransactionIdFromContext(ctx),
+	}
+	if environmentId, ok := entry.Arguments["environmentId"].(string); ok {
+		entry.EnvironmentId = environmentId
+	}
+	if req.Extra != nil && req.Extra.TokenInfo != nil {
+		entry.ClientUser = req.Extra.TokenInfo.UserID
+	}
+	return entry
+}
+
+// write writes the entry to the sink, logging rather than returning a failure to write it
+func write(ctx context.Context, sink Sink, entry Entry) {
+	if err := sink.Write(entry); err != nil {
+		logger.FromContext(ctx).Error("Failed to write audit log entry",
+			slog.String("tool", entry.Tool),
+			slog.String("error", err.Error()))
+	}
+}
+
+// resultText returns the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if textContent, ok := content.(*mcp.TextContent); ok {
+			texts = append(texts, textContent.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// jsonValue returns the value as decoded from JSON, so that typed tool outputs can be walked like arguments
+func jsonValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	data, ok := value.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return nil
+		}
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
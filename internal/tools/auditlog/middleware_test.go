@@ -0,0 +1,233 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auditlog_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEnvironmentId = "0b0c9c7e-4e3a-4f0e-9a3d-1f2b3c4d5e6f"
+	testApplicationId = "7c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+)
+
+type createThingInput struct {
+	EnvironmentId string         `json:"environmentId"`
+	Name          string         `json:"name"`
+	ClientSecret  string         `json:"clientSecret,omitempty"`
+	Settings      map[string]any `json:"settings,omitempty"`
+}
+
+type createThingOutput struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+var (
+	createThingDef = types.ToolDefinition{
+		McpTool: &mcp.Tool{Name: "create_thing", Description: "Create a thing"},
+	}
+	listThingsDef = types.ToolDefinition{
+		McpTool: &mcp.Tool{Name: "list_things", Description: "List things", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}},
+	}
+)
+
+// newAuditedServer returns a server whose calls are audited to a new log, acting as the given principal
+func newAuditedServer(t *testing.T, principal string) (*mcp.Server, *auditlog.FileLog) {
+	t.Helper()
+
+	log, err := auditlog.NewFileLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+
+	server := mcptestutils.TestMcpServer(t)
+	authenticate := func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			ctx = audit.ContextWithPrincipal(ctx, principal)
+			ctx = audit.ContextWithSessionId(ctx, "session-1")
+			return next(ctx, method, req)
+		}
+	}
+	server.AddReceivingMiddleware(authenticate, auditlog.NewAuditLogMiddleware(log, []types.ToolDefinition{createThingDef, listThingsDef}).Handler)
+
+	mcp.AddTool(server, createThingDef.McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input createThingInput) (*mcp.CallToolResult, *createThingOutput, error) {
+		if input.Name == "existing" {
+			return nil, nil, errors.New("thing already exists")
+		}
+		return nil, &createThingOutput{Id: testApplicationId, Name: input.Name}, nil
+	})
+	mcp.AddTool(server, listThingsDef.McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input createThingInput) (*mcp.CallToolResult, any, error) {
+		return nil, map[string]any{"things": []string{}}, nil
+	})
+	return server, log
+}
+
+func TestAuditLogMiddleware_RecordsWriteToolCalls(t *testing.T) {
+	server, log := newAuditedServer(t, "user-1")
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, "create_thing", createThingInput{
+		EnvironmentId: testEnvironmentId,
+		Name:          "new",
+		ClientSecret:  "s3cr3t",
+		Settings:      map[string]any{"admin_password": "hunter2", "color": "blue"},
+	})
+	require.NoError(t, err)
+	require.False(t, output.IsError)
+
+	output, err = mcptestutils.CallToolOverMcp(t, server, "create_thing", createThingInput{EnvironmentId: testEnvironmentId, Name: "existing"})
+	require.NoError(t, err)
+	require.True(t, output.IsError)
+
+	entries, _, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	success := entries[0]
+	assert.Equal(t, "create_thing", success.Tool)
+	assert.Equal(t, testEnvironmentId, success.EnvironmentId)
+	assert.Equal(t, "user-1", success.Principal)
+	assert.Equal(t, "session-1", success.SessionId)
+	assert.Equal(t, auditlog.StatusSuccess, success.Status)
+	assert.Empty(t, success.Error)
+	assert.False(t, success.Timestamp.IsZero())
+	assert.Equal(t, map[string]any{
+		"environmentId": testEnvironmentId,
+		"name":          "new",
+		"clientSecret":  auditlog.RedactedValue,
+		"settings":      map[string]any{"admin_password": auditlog.RedactedValue, "color": "blue"},
+	}, success.Arguments)
+	assert.Equal(t, []string{testEnvironmentId, testApplicationId}, success.ResourceIds)
+
+	failure := entries[1]
+	assert.Equal(t, auditlog.StatusError, failure.Status)
+	assert.Contains(t, failure.Error, "thing already exists")
+	assert.Equal(t, []string{testEnvironmentId}, failure.ResourceIds)
+}
+
+func TestAuditLogMiddleware_DoesNotRecordReadOnlyTools(t *testing.T) {
+	server, log := newAuditedServer(t, "user-1")
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, "list_things", createThingInput{EnvironmentId: testEnvironmentId})
+	require.NoError(t, err)
+	require.False(t, output.IsError)
+
+	entries, _, err := log.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestAuditLogMiddleware_RecordsRejectedCalls(t *testing.T) {
+	log, err := auditlog.NewFileLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	middleware := auditlog.NewAuditLogMiddleware(log, []types.ToolDefinition{createThingDef})
+
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return nil, errors.New("environment validation failed: PRODUCTION environment")
+	}
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "create_thing",
+			Arguments: []byte(`{"environmentId":"` + testEnvironmentId + `"}`),
+		},
+	}
+
+	_, err = middleware.Handler(next)(context.Background(), "tools/call", req)
+	require.Error(t, err)
+
+	entries, _, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, auditlog.StatusError, entries[0].Status)
+	assert.Equal(t, "environment validation failed: PRODUCTION environment", entries[0].Error)
+	assert.Equal(t, testEnvironmentId, entries[0].EnvironmentId)
+}
+
+func TestAuditLogMiddleware_NilSink(t *testing.T) {
+	middleware := auditlog.NewAuditLogMiddleware(nil, []types.ToolDefinition{createThingDef})
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name: "create_thing",
+		},
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	assert.NoError(t, err)
+	assert.True(t, nextCalled)
+}
+
+func TestAuditLogMiddleware_TruncatesLongArguments(t *testing.T) {
+	log, err := auditlog.NewFileLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	middleware := auditlog.NewAuditLogMiddleware(log, []types.ToolDefinition{createThingDef})
+
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{}, nil
+	}
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "create_thing",
+			Arguments: []byte(`{"csv":"` + strings.Repeat("a", 1500) + `"}`),
+		},
+	}
+
+	_, err = middleware.Handler(next)(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+
+	entries, _, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, strings.Repeat("a", 1000)+"... (1500 characters)", entries[0].Arguments["csv"])
+}
+
+func TestRecordDenied(t *testing.T) {
+	log, err := auditlog.NewFileLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "list_things",
+			Arguments: []byte(`{"environmentId":"` + testEnvironmentId + `","clientSecret":"s3cr3t"}`),
+		},
+	}
+	ctx := audit.ContextWithPrincipal(context.Background(), "user-1")
+
+	auditlog.RecordDenied(ctx, log, req, "denied by the tool policy")
+
+	entries, _, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "list_things", entries[0].Tool)
+	assert.Equal(t, auditlog.StatusDenied, entries[0].Status)
+	assert.Equal(t, "denied by the tool policy", entries[0].Error)
+	assert.Equal(t, "user-1", entries[0].Principal)
+	assert.Equal(t, testEnvironmentId, entries[0].EnvironmentId)
+	assert.Equal(t, auditlog.RedactedValue, entries[0].Arguments["clientSecret"])
+	assert.Equal(t, []string{testEnvironmentId}, entries[0].ResourceIds)
+}
+
+func TestRecordDenied_NilSink(t *testing.T) {
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "create_thing"}}
+
+	assert.NotPanics(t, func() {
+		auditlog.RecordDenied(context.Background(), nil, req, "denied by the tool policy")
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auditlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+)
+
+const (
+	// RedactedValue replaces the values of sensitive arguments in audit entries
+	RedactedValue = redact.RedactedValue
+
+	// maxArgumentLength limits the string arguments recorded in audit entries, so that bulk payloads such as
+	// CSV text do not grow the log by their whole size
+	maxArgumentLength = 1000
+)
+
+// redactArguments returns a copy of the arguments with the values of sensitive arguments, at any depth,
+// replaced and long strings truncated
+func redactArguments(argsJSON json.RawMessage) map[string]any {
+	if len(argsJSON) == 0 {
+		return nil
+	}
+	var args map[string]any
+	if err := json.Unmarshal(argsJSON, &args); err != nil {
+		return nil
+	}
+	redacted, _ := redactValue(args).(map[string]any)
+	return redacted
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, nested := range v {
+			if redact.IsSensitiveKey(key) {
+				redacted[key] = RedactedValue
+			} else {
+				redacted[key] = redactValue(nested)
+			}
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, nested := range v {
+			redacted[i] = redactValue(nested)
+		}
+		return redacted
+	case string:
+		if len(v) > maxArgumentLength {
+			return fmt.Sprintf("%s... (%d characters)", v[:maxArgumentLength], len(v))
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// resourceIds returns the IDs of the resources that a call refers to in its arguments or structured output:
+// the sorted UUID values of fields named "id" or ending in "Id", at any depth
+func resourceIds(values ...any) []string {
+	var ids []string
+	seen := map[string]bool{}
+	var walk func(key string, value any)
+	walk = func(key string, value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			for nestedKey, nested := range v {
+				walk(nestedKey, nested)
+			}
+		case []any:
+			for _, nested := range v {
+				walk(key, nested)
+			}
+		case string:
+			if (key == "id" || strings.HasSuffix(key, "Id")) && !seen[v] {
+				if _, err := uuid.Parse(v); err == nil {
+					seen[v] = true
+					ids = append(ids, v)
+				}
+			}
+		}
+	}
+	for _, value := range values {
+		walk("", value)
+	}
+	slices.Sort(ids)
+	return ids
+}
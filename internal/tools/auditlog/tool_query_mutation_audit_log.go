@@ -0,0 +1,138 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auditlog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	defaultQueryLimit = 50
+	maxQueryLimit     = 500
+)
+
+var QueryMutationAuditLogDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "query_mutation_audit_log",
+		Title: "Query Mutation Audit Log",
+		Description: `Query the local audit log of write tool calls made through this MCP server, to reconstruct what an agent changed. Each entry records when a write tool was called, its arguments with sensitive values redacted, the principal of the PingOne session, whether the call succeeded, and the IDs of the affected resources.
+
+Only calls made through this server are recorded, and only while the audit log is enabled. For changes made by any means, such as the admin console or other API clients, use query_audit_events or get_environment_changes_since instead.
+
+Filter by tool, environment, affected resource, status and time range. The most recent matching entries are returned, oldest first.`,
+		InputSchema:  schema.MustGenerateSchema[QueryMutationAuditLogInput](),
+		OutputSchema: schema.MustGenerateSchema[QueryMutationAuditLogOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+// QueryMutationAuditLogInput defines the filters of an audit log query
+type QueryMutationAuditLogInput struct {
+	Tool          *string    `json:"tool,omitempty" jsonschema:"OPTIONAL. Only return calls of this tool."`
+	EnvironmentId *string    `json:"environmentId,omitempty" jsonschema:"OPTIONAL. Only return calls made against this environment UUID."`
+	ResourceId    *string    `json:"resourceId,omitempty" jsonschema:"OPTIONAL. Only return calls that affected the resource with this UUID."`
+	Status        *string    `json:"status,omitempty" jsonschema:"OPTIONAL. Only return calls with this status: success, error, or denied for calls the tool policy denied."`
+	Since         *time.Time `json:"since,omitempty" jsonschema:"OPTIONAL. Only return calls made at or after this time (RFC 3339)."`
+	Until         *time.Time `json:"until,omitempty" jsonschema:"OPTIONAL. Only return calls made before this time (RFC 3339)."`
+	Limit         *int       `json:"limit,omitempty" jsonschema:"OPTIONAL. The maximum number of entries to return, from 1 to 500. Defaults to 50."`
+}
+
+// QueryMutationAuditLogOutput holds the matching audit log entries
+type QueryMutationAuditLogOutput struct {
+	Entries      []Entry `json:"entries" jsonschema:"The most recent matching entries, oldest first"`
+	MatchCount   int     `json:"matchCount" jsonschema:"The number of entries matching the filters, including those not returned because of the limit"`
+	Truncated    bool    `json:"truncated" jsonschema:"True if older matching entries were not returned because of the limit"`
+	InvalidLines int     `json:"invalidLines,omitempty" jsonschema:"The number of lines of the audit log file that could not be read, such as a line cut short when the server was stopped"`
+	LogFile      string  `json:"logFile" jsonschema:"The path of the audit log file"`
+}
+
+// QueryMutationAuditLogHandler queries the entries of the audit log file
+func QueryMutationAuditLogHandler(log *FileLog) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input QueryMutationAuditLogInput,
+) (
+	*mcp.CallToolResult,
+	*QueryMutationAuditLogOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input QueryMutationAuditLogInput) (*mcp.CallToolResult, *QueryMutationAuditLogOutput, error) {
+		limit := defaultQueryLimit
+		if input.Limit != nil {
+			limit = *input.Limit
+		}
+		if limit < 1 || limit > maxQueryLimit {
+			toolErr := errs.NewToolError(QueryMutationAuditLogDef.McpTool.Name, fmt.Errorf("limit must be between 1 and %d, got %d", maxQueryLimit, limit))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if input.Status != nil && *input.Status != StatusSuccess && *input.Status != StatusError && *input.Status != StatusDenied {
+			toolErr := errs.NewToolError(QueryMutationAuditLogDef.McpTool.Name, fmt.Errorf("status must be %s, %s or %s, got %q", StatusSuccess, StatusError, StatusDenied, *input.Status))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		entries, invalidLines, err := log.Entries()
+		if err != nil {
+			toolErr := errs.NewToolError(QueryMutationAuditLogDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		matches := slices.DeleteFunc(entries, func(entry Entry) bool {
+			return !input.matches(entry)
+		})
+
+		output := &QueryMutationAuditLogOutput{
+			Entries:      matches,
+			MatchCount:   len(matches),
+			InvalidLines: invalidLines,
+			LogFile:      log.FilePath(),
+		}
+		if len(matches) > limit {
+			output.Entries = matches[len(matches)-limit:]
+			output.Truncated = true
+		}
+		return nil, output, nil
+	}
+}
+
+func (input QueryMutationAuditLogInput) matches(entry Entry) bool {
+	if input.Tool != nil && entry.Tool != *input.Tool {
+		return false
+	}
+	if input.EnvironmentId != nil && entry.EnvironmentId != *input.EnvironmentId {
+		return false
+	}
+	if input.ResourceId != nil && !slices.Contains(entry.ResourceIds, *input.ResourceId) {
+		return false
+	}
+	if input.Status != nil && entry.Status != *input.Status {
+		return false
+	}
+	if input.Since != nil && entry.Timestamp.Before(*input.Since) {
+		return false
+	}
+	if input.Until != nil && !entry.Timestamp.Before(*input.Until) {
+		return false
+	}
+	return true
+}
+
+// RegisterQueryMutationAuditLogTool adds the query_mutation_audit_log tool to the MCP server.
+func RegisterQueryMutationAuditLogTool(server *mcp.Server, log *FileLog) {
+	mcp.AddTool(server, QueryMutationAuditLogDef.McpTool, QueryMutationAuditLogHandler(log))
+}
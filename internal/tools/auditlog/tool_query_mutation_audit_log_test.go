@@ -0,0 +1,156 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package auditlog_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogStart = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestLog returns a log of calls made one minute apart, alternating between two environments
+func newTestLog(t *testing.T, count int) *auditlog.FileLog {
+	t.Helper()
+
+	log, err := auditlog.NewFileLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	for i := range count {
+		entry := auditlog.Entry{
+			Timestamp:     testLogStart.Add(time.Duration(i) * time.Minute),
+			Tool:          "create_thing",
+			EnvironmentId: "env-a",
+			Status:        auditlog.StatusSuccess,
+			ResourceIds:   []string{fmt.Sprintf("resource-%d", i)},
+		}
+		if i%2 == 1 {
+			entry.Tool = "update_thing"
+			entry.EnvironmentId = "env-b"
+			entry.Status = auditlog.StatusError
+		}
+		require.NoError(t, log.Write(entry))
+	}
+	return log
+}
+
+func TestQueryMutationAuditLogHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           auditlog.QueryMutationAuditLogInput
+		wantResourceIds []string
+		wantMatchCount  int
+		wantTruncated   bool
+		wantErrContains string
+	}{
+		{
+			name:            "All entries",
+			input:           auditlog.QueryMutationAuditLogInput{},
+			wantResourceIds: []string{"resource-0", "resource-1", "resource-2", "resource-3", "resource-4", "resource-5"},
+			wantMatchCount:  6,
+		},
+		{
+			name:            "Filter by tool",
+			input:           auditlog.QueryMutationAuditLogInput{Tool: testutils.Pointer("update_thing")},
+			wantResourceIds: []string{"resource-1", "resource-3", "resource-5"},
+			wantMatchCount:  3,
+		},
+		{
+			name:            "Filter by environment and status",
+			input:           auditlog.QueryMutationAuditLogInput{EnvironmentId: testutils.Pointer("env-a"), Status: testutils.Pointer(auditlog.StatusSuccess)},
+			wantResourceIds: []string{"resource-0", "resource-2", "resource-4"},
+			wantMatchCount:  3,
+		},
+		{
+			name:            "Filter by resource",
+			input:           auditlog.QueryMutationAuditLogInput{ResourceId: testutils.Pointer("resource-4")},
+			wantResourceIds: []string{"resource-4"},
+			wantMatchCount:  1,
+		},
+		{
+			name: "Filter by time range",
+			input: auditlog.QueryMutationAuditLogInput{
+				Since: testutils.Pointer(testLogStart.Add(2 * time.Minute)),
+				Until: testutils.Pointer(testLogStart.Add(4 * time.Minute)),
+			},
+			wantResourceIds: []string{"resource-2", "resource-3"},
+			wantMatchCount:  2,
+		},
+		{
+			name:            "Limit returns the most recent entries",
+			input:           auditlog.QueryMutationAuditLogInput{Limit: testutils.Pointer(2)},
+			wantResourceIds: []string{"resource-4", "resource-5"},
+			wantMatchCount:  6,
+			wantTruncated:   true,
+		},
+		{
+			name:            "Error - Limit out of range",
+			input:           auditlog.QueryMutationAuditLogInput{Limit: testutils.Pointer(0)},
+			wantErrContains: "limit must be between 1 and 500, got 0",
+		},
+		{
+			name:            "Error - Unknown status",
+			input:           auditlog.QueryMutationAuditLogInput{Status: testutils.Pointer("failed")},
+			wantErrContains: "status must be success, error or denied",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			log := newTestLog(t, 6)
+			handler := auditlog.QueryMutationAuditLogHandler(log)
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tc.input)
+
+			assert.Nil(t, mcpResult)
+			if tc.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrContains)
+				assert.Nil(t, output)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			resourceIds := []string{}
+			for _, entry := range output.Entries {
+				resourceIds = append(resourceIds, entry.ResourceIds...)
+			}
+			assert.Equal(t, tc.wantResourceIds, resourceIds)
+			assert.Equal(t, tc.wantMatchCount, output.MatchCount)
+			assert.Equal(t, tc.wantTruncated, output.Truncated)
+			assert.Equal(t, log.FilePath(), output.LogFile)
+		})
+	}
+}
+
+func TestQueryMutationAuditLogHandler_OverMcp(t *testing.T) {
+	log := newTestLog(t, 3)
+	server := mcptestutils.TestMcpServer(t)
+	auditlog.RegisterQueryMutationAuditLogTool(server, log)
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, auditlog.QueryMutationAuditLogDef.McpTool.Name, map[string]any{
+		"tool":  "create_thing",
+		"since": testLogStart.Add(time.Minute).Format(time.RFC3339),
+	})
+	testutils.AssertMcpCallSuccess(t, err, output)
+
+	result := &auditlog.QueryMutationAuditLogOutput{}
+	jsonBytes, err := json.Marshal(output.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsonBytes, result))
+
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, []string{"resource-2"}, result.Entries[0].ResourceIds)
+	assert.True(t, result.Entries[0].Timestamp.Equal(testLogStart.Add(2*time.Minute)))
+}
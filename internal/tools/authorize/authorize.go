@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	authorizeapi "github.com/patrickcping/pingone-go-sdk-v2/authorize"
+)
+
+// DecisionEndpoint is a PingOne Authorize decision endpoint, which evaluates decision requests with a policy
+type DecisionEndpoint struct {
+	Id                     string  `json:"id" jsonschema:"The decision endpoint ID"`
+	Name                   string  `json:"name" jsonschema:"The name of the decision endpoint"`
+	Description            string  `json:"description,omitempty" jsonschema:"The description of the decision endpoint"`
+	AlternateId            *string `json:"alternateId,omitempty" jsonschema:"An alternative, fixed identifier of the decision endpoint"`
+	PolicyId               *string `json:"policyId,omitempty" jsonschema:"The ID of the root policy the decision endpoint evaluates"`
+	AuthorizationVersionId *string `json:"authorizationVersionId,omitempty" jsonschema:"The ID of the version of the policies the decision endpoint evaluates. Not set if the endpoint evaluates the latest version."`
+	RecordRecentRequests   bool    `json:"recordRecentRequests" jsonschema:"True if the decision endpoint records its recent decision requests and responses"`
+	Owned                  *bool   `json:"owned,omitempty" jsonschema:"True if the decision endpoint can only be changed by PingOne-owned clients"`
+}
+
+// DecisionRequest is a request for a decision, sent to a decision endpoint
+type DecisionRequest struct {
+	Parameters  map[string]any       `json:"parameters,omitempty"`
+	UserContext *DecisionUserContext `json:"userContext,omitempty"`
+}
+
+// DecisionUserContext is the user a decision is requested for
+type DecisionUserContext struct {
+	User DecisionUser `json:"user"`
+}
+
+// DecisionUser identifies the user of a decision request by their PingOne user ID
+type DecisionUser struct {
+	Id string `json:"id"`
+}
+
+func decisionEndpointOutput(decisionEndpoint authorizeapi.DecisionEndpoint) DecisionEndpoint {
+	output := DecisionEndpoint{
+		Id:                   decisionEndpoint.GetId(),
+		Name:                 decisionEndpoint.Name,
+		Description:          decisionEndpoint.Description,
+		AlternateId:          decisionEndpoint.AlternateId,
+		PolicyId:             decisionEndpoint.PolicyId,
+		RecordRecentRequests: decisionEndpoint.RecordRecentRequests,
+		Owned:                decisionEndpoint.Owned,
+	}
+	if decisionEndpoint.AuthorizationVersion != nil {
+		output.AuthorizationVersionId = decisionEndpoint.AuthorizationVersion.Id
+	}
+	return output
+}
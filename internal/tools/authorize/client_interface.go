@@ -0,0 +1,23 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	authorizeapi "github.com/patrickcping/pingone-go-sdk-v2/authorize"
+)
+
+type AuthorizeClient interface {
+	GetDecisionEndpoints(ctx context.Context, environmentId uuid.UUID) (authorizeapi.EntityArrayPagedIterator, error)
+	GetDecisionEndpoint(ctx context.Context, environmentId uuid.UUID, decisionEndpointId uuid.UUID) (*authorizeapi.DecisionEndpoint, *http.Response, error)
+	GetPolicies(ctx context.Context, environmentId uuid.UUID) ([]map[string]any, *http.Response, error)
+	GetPolicy(ctx context.Context, environmentId uuid.UUID, policyId uuid.UUID) (map[string]any, *http.Response, error)
+	EvaluateDecision(ctx context.Context, environmentId uuid.UUID, decisionEndpointId uuid.UUID, decisionRequest DecisionRequest) (map[string]any, *http.Response, error)
+}
+
+type AuthorizeClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (AuthorizeClient, error)
+}
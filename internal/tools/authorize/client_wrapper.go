@@ -0,0 +1,217 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	authorizeapi "github.com/patrickcping/pingone-go-sdk-v2/authorize"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ AuthorizeClient = &PingOneClientAuthorizeWrapper{}
+var _ AuthorizeClientFactory = &PingOneClientAuthorizeWrapperFactory{}
+
+type PingOneClientAuthorizeWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientAuthorizeWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientAuthorizeWrapper(client *pingone.Client) *PingOneClientAuthorizeWrapper {
+	return &PingOneClientAuthorizeWrapper{client: client}
+}
+
+func NewPingOneClientAuthorizeWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientAuthorizeWrapperFactory {
+	return &PingOneClientAuthorizeWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientAuthorizeWrapperFactory) GetAuthenticatedClient(ctx context.Context) (AuthorizeClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientAuthorizeWrapper(client), nil
+}
+
+func (p *PingOneClientAuthorizeWrapper) GetDecisionEndpoints(ctx context.Context, environmentId uuid.UUID) (authorizeapi.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.AuthorizeAPIClient.PolicyDecisionManagementApi.ReadAllDecisionEndpoints(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve decision endpoints",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientAuthorizeWrapper) GetDecisionEndpoint(ctx context.Context, environmentId uuid.UUID, decisionEndpointId uuid.UUID) (*authorizeapi.DecisionEndpoint, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.AuthorizeAPIClient.PolicyDecisionManagementApi.ReadOneDecisionEndpoint(ctx, environmentId.String(), decisionEndpointId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve decision endpoint",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("decisionEndpointId", decisionEndpointId.String()),
+	)
+	return getRequest.Execute()
+}
+
+// GetPolicies reads the policies of the Authorize policy editor. The policy editor API is not in the Authorize SDK,
+// so its pages are read directly, following the next link of each page.
+func (p *PingOneClientAuthorizeWrapper) GetPolicies(ctx context.Context, environmentId uuid.UUID) ([]map[string]any, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	apiUrl, err := p.apiUrl()
+	if err != nil {
+		return nil, nil, err
+	}
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve authorization policies",
+		slog.String("environmentId", environmentId.String()),
+	)
+
+	policies := []map[string]any{}
+	pageUrl := fmt.Sprintf("%s/environments/%s/authorize/policyEditor/policies", apiUrl, environmentId.String())
+	for {
+		page, httpResponse, err := p.doJson(ctx, http.MethodGet, pageUrl, nil)
+		if err != nil {
+			return nil, httpResponse, err
+		}
+		if embedded, ok := page["_embedded"].(map[string]any); ok {
+			items, _ := embedded["policies"].([]any)
+			for _, item := range items {
+				if policy, ok := item.(map[string]any); ok {
+					policies = append(policies, policy)
+				}
+			}
+		}
+		pageUrl = nextLink(page)
+		if pageUrl == "" {
+			return policies, httpResponse, nil
+		}
+	}
+}
+
+func (p *PingOneClientAuthorizeWrapper) GetPolicy(ctx context.Context, environmentId uuid.UUID, policyId uuid.UUID) (map[string]any, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	apiUrl, err := p.apiUrl()
+	if err != nil {
+		return nil, nil, err
+	}
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve authorization policy",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("policyId", policyId.String()),
+	)
+	return p.doJson(ctx, http.MethodGet, fmt.Sprintf("%s/environments/%s/authorize/policyEditor/policies/%s", apiUrl, environmentId.String(), policyId.String()), nil)
+}
+
+// EvaluateDecision sends a decision request to a decision endpoint. Decisions are evaluated by the PingOne
+// orchestrate API, which is not in the Authorize SDK.
+func (p *PingOneClientAuthorizeWrapper) EvaluateDecision(ctx context.Context, environmentId uuid.UUID, decisionEndpointId uuid.UUID, decisionRequest DecisionRequest) (map[string]any, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	decisionUrl := fmt.Sprintf("https://orchestrate-api.pingone.%s/v1/environments/%s/decisionEndpoints/%s", p.client.Region.URLSuffix, environmentId.String(), decisionEndpointId.String())
+	logger.FromContext(ctx).Debug("Calling PingOne API to evaluate decision",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("decisionEndpointId", decisionEndpointId.String()),
+	)
+	return p.doJson(ctx, http.MethodPost, decisionUrl, decisionRequest)
+}
+
+// maxAuthorizeResponseSize limits the size of policy editor and decision responses read from PingOne
+const maxAuthorizeResponseSize = 10 << 20
+
+// apiUrl returns the base URL of the PingOne API for the region of the client
+func (p *PingOneClientAuthorizeWrapper) apiUrl() (string, error) {
+	config := p.client.AuthorizeAPIClient.GetConfig()
+	return config.ServerURL(config.DefaultServerIndex, nil)
+}
+
+// doJson sends a request to the PingOne API with the access token, user agent and audit headers of the Authorize SDK
+// client, and parses the JSON response. The SDK's HTTP client is used so that requests are retried in the same way.
+func (p *PingOneClientAuthorizeWrapper) doJson(ctx context.Context, method string, url string, requestBody any) (map[string]any, *http.Response, error) {
+	var body io.Reader
+	if requestBody != nil {
+		requestJson, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, nil, err
+		}
+		body = bytes.NewReader(requestJson)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	config := p.client.AuthorizeAPIClient.GetConfig()
+	for header, value := range config.DefaultHeader {
+		req.Header.Set(header, value)
+	}
+	req.Header.Set("User-Agent", config.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if sessionId := audit.SessionIdFromContext(ctx); sessionId != "" {
+		req.Header.Set("X-Ping-External-Session-ID", sessionId)
+	}
+	if transactionId := audit.TransactionIdFromContext(ctx); transactionId != "" {
+		req.Header.Set("X-Ping-External-Transaction-ID", transactionId)
+	}
+
+	httpResponse, err := config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer httpResponse.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxAuthorizeResponseSize))
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	// Restore the body so that it can be included in API errors
+	httpResponse.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return nil, httpResponse, fmt.Errorf("%s %s returned %s", method, url, httpResponse.Status)
+	}
+
+	var document map[string]any
+	if err := json.Unmarshal(responseBody, &document); err != nil {
+		return nil, httpResponse, fmt.Errorf("failed to parse the response from %s: %w", url, err)
+	}
+	return document, httpResponse, nil
+}
+
+// nextLink returns the URL of the next page of a PingOne list response, or an empty string for the last page
+func nextLink(page map[string]any) string {
+	links, _ := page["_links"].(map[string]any)
+	next, _ := links["next"].(map[string]any)
+	href, _ := next["href"].(string)
+	return href
+}
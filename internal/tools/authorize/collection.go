@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "authorize"
+
+var _ collections.LegacySdkCollection = &AuthorizeCollection{}
+
+type AuthorizeCollection struct{}
+
+func (c *AuthorizeCollection) Name() string {
+	return CollectionName
+}
+
+func (c *AuthorizeCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	authorizeClientFactory := NewPingOneClientAuthorizeWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListDecisionEndpointsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListDecisionEndpointsDef.McpTool.Name))
+		mcp.AddTool(server, ListDecisionEndpointsDef.McpTool, ListDecisionEndpointsHandler(authorizeClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetDecisionEndpointDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetDecisionEndpointDef.McpTool.Name))
+		mcp.AddTool(server, GetDecisionEndpointDef.McpTool, GetDecisionEndpointHandler(authorizeClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListAuthorizationPoliciesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListAuthorizationPoliciesDef.McpTool.Name))
+		mcp.AddTool(server, ListAuthorizationPoliciesDef.McpTool, ListAuthorizationPoliciesHandler(authorizeClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetAuthorizationPolicyDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetAuthorizationPolicyDef.McpTool.Name))
+		mcp.AddTool(server, GetAuthorizationPolicyDef.McpTool, GetAuthorizationPolicyHandler(authorizeClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&TestAuthorizeDecisionDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", TestAuthorizeDecisionDef.McpTool.Name))
+		mcp.AddTool(server, TestAuthorizeDecisionDef.McpTool, TestAuthorizeDecisionHandler(authorizeClientFactory))
+	}
+
+	return nil
+}
+
+func (c *AuthorizeCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListDecisionEndpointsDef,
+		GetDecisionEndpointDef,
+		ListAuthorizationPoliciesDef,
+		GetAuthorizationPolicyDef,
+		TestAuthorizeDecisionDef,
+	}
+}
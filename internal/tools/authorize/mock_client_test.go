@@ -0,0 +1,90 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	authorizeapi "github.com/patrickcping/pingone-go-sdk-v2/authorize"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ authorize.AuthorizeClient = &mockPingOneClientAuthorizeWrapper{}
+var _ authorize.AuthorizeClientFactory = &mockPingOneClientAuthorizeWrapperFactory{}
+
+type mockPingOneClientAuthorizeWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientAuthorizeWrapperFactory struct {
+	mockClient authorize.AuthorizeClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientAuthorizeWrapperFactory(mockClient authorize.AuthorizeClient, err error) *mockPingOneClientAuthorizeWrapperFactory {
+	return &mockPingOneClientAuthorizeWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientAuthorizeWrapperFactory) GetAuthenticatedClient(ctx context.Context) (authorize.AuthorizeClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientAuthorizeWrapper) GetDecisionEndpoints(ctx context.Context, environmentId uuid.UUID) (authorizeapi.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	iterator, ok := args.Get(0).(authorizeapi.EntityArrayPagedIterator)
+	if !ok && args.Get(0) != nil {
+		panic("GetDecisionEndpoints mock setup error: expected authorizeapi.EntityArrayPagedIterator or nil")
+	}
+	return iterator, args.Error(1)
+}
+
+func (p *mockPingOneClientAuthorizeWrapper) GetDecisionEndpoint(ctx context.Context, environmentId uuid.UUID, decisionEndpointId uuid.UUID) (*authorizeapi.DecisionEndpoint, *http.Response, error) {
+	args := p.Called(ctx, environmentId, decisionEndpointId)
+	response, ok := args.Get(0).(*authorizeapi.DecisionEndpoint)
+	if !ok && args.Get(0) != nil {
+		panic("GetDecisionEndpoint mock setup error: expected *authorizeapi.DecisionEndpoint or nil")
+	}
+	return response, httpResponse("GetDecisionEndpoint", args), args.Error(2)
+}
+
+func (p *mockPingOneClientAuthorizeWrapper) GetPolicies(ctx context.Context, environmentId uuid.UUID) ([]map[string]any, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).([]map[string]any)
+	if !ok && args.Get(0) != nil {
+		panic("GetPolicies mock setup error: expected []map[string]any or nil")
+	}
+	return response, httpResponse("GetPolicies", args), args.Error(2)
+}
+
+func (p *mockPingOneClientAuthorizeWrapper) GetPolicy(ctx context.Context, environmentId uuid.UUID, policyId uuid.UUID) (map[string]any, *http.Response, error) {
+	args := p.Called(ctx, environmentId, policyId)
+	response, ok := args.Get(0).(map[string]any)
+	if !ok && args.Get(0) != nil {
+		panic("GetPolicy mock setup error: expected map[string]any or nil")
+	}
+	return response, httpResponse("GetPolicy", args), args.Error(2)
+}
+
+func (p *mockPingOneClientAuthorizeWrapper) EvaluateDecision(ctx context.Context, environmentId uuid.UUID, decisionEndpointId uuid.UUID, decisionRequest authorize.DecisionRequest) (map[string]any, *http.Response, error) {
+	args := p.Called(ctx, environmentId, decisionEndpointId, decisionRequest)
+	response, ok := args.Get(0).(map[string]any)
+	if !ok && args.Get(0) != nil {
+		panic("EvaluateDecision mock setup error: expected map[string]any or nil")
+	}
+	return response, httpResponse("EvaluateDecision", args), args.Error(2)
+}
+
+func httpResponse(method string, args mock.Arguments) *http.Response {
+	response, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response
+}
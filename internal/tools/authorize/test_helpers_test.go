@@ -0,0 +1,57 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	authorizeapi "github.com/patrickcping/pingone-go-sdk-v2/authorize"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId      = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testDecisionEndpointId = uuid.MustParse("550e8400-e29b-41d4-a716-446655449000")
+	testPolicyId           = uuid.MustParse("550e8400-e29b-41d4-a716-446655449100")
+	testUserId             = uuid.MustParse("550e8400-e29b-41d4-a716-446655449200")
+)
+
+// testDecisionEndpoint returns a decision endpoint that evaluates the test policy
+func testDecisionEndpoint() *authorizeapi.DecisionEndpoint {
+	return &authorizeapi.DecisionEndpoint{
+		Id:                   testutils.Pointer(testDecisionEndpointId.String()),
+		Name:                 "Invoices API",
+		Description:          "Decisions for the invoices API",
+		PolicyId:             testutils.Pointer(testPolicyId.String()),
+		AuthorizationVersion: &authorizeapi.DecisionEndpointAuthorizationVersion{Id: testutils.Pointer("version-7")},
+		RecordRecentRequests: true,
+	}
+}
+
+// testPolicy returns a policy as returned by the policy editor API
+func testPolicy() map[string]any {
+	return map[string]any{
+		"id":   testPolicyId.String(),
+		"name": "Invoices",
+		"combiningAlgorithm": map[string]any{
+			"algorithm": "DENY_OVERRIDES",
+		},
+	}
+}
+
+// decisionEndpointsPages returns a single page of decision endpoints
+func decisionEndpointsPages(decisionEndpoints ...authorizeapi.DecisionEndpoint) authorizeapi.EntityArrayPagedIterator {
+	return func(yield func(authorizeapi.PagedCursor, error) bool) {
+		yield(authorizeapi.PagedCursor{
+			EntityArray:  &authorizeapi.EntityArray{Embedded: &authorizeapi.EntityArrayEmbedded{DecisionEndpoints: decisionEndpoints}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		}, nil)
+	}
+}
+
+func errorPages(err error) authorizeapi.EntityArrayPagedIterator {
+	return func(yield func(authorizeapi.PagedCursor, error) bool) {
+		yield(authorizeapi.PagedCursor{HTTPResponse: &http.Response{StatusCode: 403}}, err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetAuthorizationPolicyDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "get_authorization_policy",
+		Title:        "Get PingOne Authorize Policy by ID",
+		Description:  "Retrieve a policy of the PingOne Authorize policy editor, as returned by PingOne, with its rules, conditions, combining algorithm and child policies. Use 'list_authorization_policies' or the policy ID of a decision endpoint to find the policy ID.",
+		InputSchema:  schema.MustGenerateSchema[GetAuthorizationPolicyInput](),
+		OutputSchema: schema.MustGenerateSchema[GetAuthorizationPolicyOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetAuthorizationPolicyInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	PolicyId      uuid.UUID `json:"policyId" jsonschema:"REQUIRED. Policy UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'policy.name' and 'policy.children'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetAuthorizationPolicyOutput struct {
+	Policy map[string]any `json:"policy" jsonschema:"The policy, as returned by the PingOne Authorize policy editor API"`
+}
+
+// GetAuthorizationPolicyHandler retrieves a PingOne Authorize policy using the provided client
+func GetAuthorizationPolicyHandler(authorizeClientFactory AuthorizeClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetAuthorizationPolicyInput,
+) (
+	*mcp.CallToolResult,
+	*GetAuthorizationPolicyOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetAuthorizationPolicyInput) (*mcp.CallToolResult, *GetAuthorizationPolicyOutput, error) {
+		client, err := authorizeClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetAuthorizationPolicyDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Getting authorization policy",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("policyId", input.PolicyId.String()))
+
+		policy, httpResponse, err := client.GetPolicy(ctx, input.EnvironmentId, input.PolicyId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if policy == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no policy data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &GetAuthorizationPolicyOutput{
+			Policy: policy,
+		}, nil
+	}
+}
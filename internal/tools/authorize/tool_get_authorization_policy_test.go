@@ -0,0 +1,67 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getAuthorizationPolicyInput() authorize.GetAuthorizationPolicyInput {
+	return authorize.GetAuthorizationPolicyInput{
+		EnvironmentId: testEnvironmentId,
+		PolicyId:      testPolicyId,
+	}
+}
+
+func TestGetAuthorizationPolicyHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetPolicy", mock.Anything, testEnvironmentId, testPolicyId).Return(testPolicy(), &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.GetAuthorizationPolicyHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getAuthorizationPolicyInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testPolicy(), output.Policy)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetAuthorizationPolicyHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuthorizeWrapper{}
+			mockClient.On("GetPolicy", mock.Anything, testEnvironmentId, testPolicyId).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := authorize.GetAuthorizationPolicyHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getAuthorizationPolicyInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetAuthorizationPolicyHandler_NoPolicyInResponse(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetPolicy", mock.Anything, testEnvironmentId, testPolicyId).Return(nil, &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.GetAuthorizationPolicyHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getAuthorizationPolicyInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "no policy data in response")
+}
+
+func TestGetAuthorizationPolicyHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := authorize.GetAuthorizationPolicyHandler(NewMockPingOneClientAuthorizeWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getAuthorizationPolicyInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,83 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetDecisionEndpointDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "get_decision_endpoint",
+		Title:        "Get PingOne Authorize Decision Endpoint by ID",
+		Description:  "Retrieve a PingOne Authorize decision endpoint, with the root policy and policy version it evaluates. Use 'list_decision_endpoints' first if you need to find the decision endpoint ID.",
+		InputSchema:  schema.MustGenerateSchema[GetDecisionEndpointInput](),
+		OutputSchema: schema.MustGenerateSchema[GetDecisionEndpointOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetDecisionEndpointInput struct {
+	EnvironmentId      uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	DecisionEndpointId uuid.UUID `json:"decisionEndpointId" jsonschema:"REQUIRED. Decision endpoint UUID."`
+	Fields             []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'decisionEndpoint.policyId'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetDecisionEndpointOutput struct {
+	DecisionEndpoint DecisionEndpoint `json:"decisionEndpoint" jsonschema:"The decision endpoint"`
+}
+
+// GetDecisionEndpointHandler retrieves a PingOne Authorize decision endpoint using the provided client
+func GetDecisionEndpointHandler(authorizeClientFactory AuthorizeClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetDecisionEndpointInput,
+) (
+	*mcp.CallToolResult,
+	*GetDecisionEndpointOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetDecisionEndpointInput) (*mcp.CallToolResult, *GetDecisionEndpointOutput, error) {
+		client, err := authorizeClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetDecisionEndpointDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Getting decision endpoint",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("decisionEndpointId", input.DecisionEndpointId.String()))
+
+		decisionEndpoint, httpResponse, err := client.GetDecisionEndpoint(ctx, input.EnvironmentId, input.DecisionEndpointId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if decisionEndpoint == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no decision endpoint data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &GetDecisionEndpointOutput{
+			DecisionEndpoint: decisionEndpointOutput(*decisionEndpoint),
+		}, nil
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func getDecisionEndpointInput() authorize.GetDecisionEndpointInput {
+	return authorize.GetDecisionEndpointInput{
+		EnvironmentId:      testEnvironmentId,
+		DecisionEndpointId: testDecisionEndpointId,
+	}
+}
+
+func TestGetDecisionEndpointHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetDecisionEndpoint", mock.Anything, testEnvironmentId, testDecisionEndpointId).Return(testDecisionEndpoint(), &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.GetDecisionEndpointHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getDecisionEndpointInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testDecisionEndpointId.String(), output.DecisionEndpoint.Id)
+	assert.Equal(t, testutils.Pointer(testPolicyId.String()), output.DecisionEndpoint.PolicyId)
+	assert.Equal(t, testutils.Pointer("version-7"), output.DecisionEndpoint.AuthorizationVersionId)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetDecisionEndpointHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuthorizeWrapper{}
+			mockClient.On("GetDecisionEndpoint", mock.Anything, testEnvironmentId, testDecisionEndpointId).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := authorize.GetDecisionEndpointHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getDecisionEndpointInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetDecisionEndpointHandler_NoDecisionEndpointInResponse(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetDecisionEndpoint", mock.Anything, testEnvironmentId, testDecisionEndpointId).Return(nil, &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.GetDecisionEndpointHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getDecisionEndpointInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "no decision endpoint data in response")
+}
+
+func TestGetDecisionEndpointHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := authorize.GetDecisionEndpointHandler(NewMockPingOneClientAuthorizeWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, getDecisionEndpointInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
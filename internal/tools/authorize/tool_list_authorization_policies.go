@@ -0,0 +1,77 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListAuthorizationPoliciesDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_authorization_policies",
+		Title:        "List PingOne Authorize Policies",
+		Description:  "Lists the policies of the PingOne Authorize policy editor of an environment, as returned by PingOne, including their rules, conditions and child policies. Use 'fields' such as 'policies.id' and 'policies.name' for an overview of large policy trees. Only applies to environments with the PingOne Authorize service.",
+		InputSchema:  schema.MustGenerateSchema[ListAuthorizationPoliciesInput](),
+		OutputSchema: schema.MustGenerateSchema[ListAuthorizationPoliciesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListAuthorizationPoliciesInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'policies.id' and 'policies.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListAuthorizationPoliciesOutput struct {
+	Policies []map[string]any `json:"policies" jsonschema:"The policies of the environment, as returned by the PingOne Authorize policy editor API"`
+}
+
+// ListAuthorizationPoliciesHandler lists the PingOne Authorize policies of an environment using the provided client
+func ListAuthorizationPoliciesHandler(authorizeClientFactory AuthorizeClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListAuthorizationPoliciesInput,
+) (
+	*mcp.CallToolResult,
+	*ListAuthorizationPoliciesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListAuthorizationPoliciesInput) (*mcp.CallToolResult, *ListAuthorizationPoliciesOutput, error) {
+		client, err := authorizeClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListAuthorizationPoliciesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing authorization policies", slog.String("environmentId", input.EnvironmentId.String()))
+
+		policies, httpResponse, err := client.GetPolicies(ctx, input.EnvironmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if policies == nil {
+			policies = []map[string]any{}
+		}
+
+		return nil, &ListAuthorizationPoliciesOutput{
+			Policies: policies,
+		}, nil
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListAuthorizationPoliciesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetPolicies", mock.Anything, testEnvironmentId).Return([]map[string]any{testPolicy()}, &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.ListAuthorizationPoliciesHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListAuthorizationPoliciesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []map[string]any{testPolicy()}, output.Policies)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListAuthorizationPoliciesHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetPolicies", mock.Anything, testEnvironmentId).Return(nil, &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.ListAuthorizationPoliciesHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListAuthorizationPoliciesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Policies)
+	assert.Empty(t, output.Policies)
+}
+
+func TestListAuthorizationPoliciesHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuthorizeWrapper{}
+			mockClient.On("GetPolicies", mock.Anything, testEnvironmentId).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := authorize.ListAuthorizationPoliciesHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListAuthorizationPoliciesInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListAuthorizationPoliciesHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := authorize.ListAuthorizationPoliciesHandler(NewMockPingOneClientAuthorizeWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListAuthorizationPoliciesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
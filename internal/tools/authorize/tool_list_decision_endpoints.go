@@ -0,0 +1,86 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	authorizeapi "github.com/patrickcping/pingone-go-sdk-v2/authorize"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListDecisionEndpointsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_decision_endpoints",
+		Title:        "List PingOne Authorize Decision Endpoints",
+		Description:  "Lists the PingOne Authorize decision endpoints of an environment, with the root policy each evaluates. Use to discover decision endpoint IDs for 'test_authorize_decision'. Only applies to environments with the PingOne Authorize service.",
+		InputSchema:  schema.MustGenerateSchema[ListDecisionEndpointsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListDecisionEndpointsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListDecisionEndpointsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'decisionEndpoints.id' and 'decisionEndpoints.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListDecisionEndpointsOutput struct {
+	DecisionEndpoints []DecisionEndpoint `json:"decisionEndpoints" jsonschema:"The decision endpoints of the environment"`
+}
+
+// ListDecisionEndpointsHandler lists the PingOne Authorize decision endpoints of an environment using the provided
+// client
+func ListDecisionEndpointsHandler(authorizeClientFactory AuthorizeClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListDecisionEndpointsInput,
+) (
+	*mcp.CallToolResult,
+	*ListDecisionEndpointsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListDecisionEndpointsInput) (*mcp.CallToolResult, *ListDecisionEndpointsOutput, error) {
+		client, err := authorizeClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListDecisionEndpointsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing decision endpoints", slog.String("environmentId", input.EnvironmentId.String()))
+
+		pagedIterator, err := client.GetDecisionEndpoints(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(ListDecisionEndpointsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &ListDecisionEndpointsOutput{
+			DecisionEndpoints: []DecisionEndpoint{},
+		}
+		err = collections.ForEachAuthorizePage(ctx, pagedIterator, func(embedded *authorizeapi.EntityArrayEmbedded) bool {
+			for _, decisionEndpoint := range embedded.DecisionEndpoints {
+				result.DecisionEndpoints = append(result.DecisionEndpoints, decisionEndpointOutput(decisionEndpoint))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, result, nil
+	}
+}
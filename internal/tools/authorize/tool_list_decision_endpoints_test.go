@@ -0,0 +1,99 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListDecisionEndpointsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetDecisionEndpoints", mock.Anything, testEnvironmentId).Return(decisionEndpointsPages(*testDecisionEndpoint()), nil)
+
+	handler := authorize.ListDecisionEndpointsHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListDecisionEndpointsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []authorize.DecisionEndpoint{
+		{
+			Id:                     testDecisionEndpointId.String(),
+			Name:                   "Invoices API",
+			Description:            "Decisions for the invoices API",
+			PolicyId:               testutils.Pointer(testPolicyId.String()),
+			AuthorizationVersionId: testutils.Pointer("version-7"),
+			RecordRecentRequests:   true,
+		},
+	}, output.DecisionEndpoints)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListDecisionEndpointsHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("GetDecisionEndpoints", mock.Anything, testEnvironmentId).Return(decisionEndpointsPages(), nil)
+
+	handler := authorize.ListDecisionEndpointsHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListDecisionEndpointsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.DecisionEndpoints)
+	assert.Empty(t, output.DecisionEndpoints)
+}
+
+func TestListDecisionEndpointsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientAuthorizeWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientAuthorizeWrapper) {
+				mockClient.On("GetDecisionEndpoints", mock.Anything, testEnvironmentId).Return(nil, errors.New("invalid environment"))
+			},
+			wantErrContains: "invalid environment",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientAuthorizeWrapper) {
+				mockClient.On("GetDecisionEndpoints", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuthorizeWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := authorize.ListDecisionEndpointsHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListDecisionEndpointsInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListDecisionEndpointsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := authorize.ListDecisionEndpointsHandler(NewMockPingOneClientAuthorizeWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, authorize.ListDecisionEndpointsInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,101 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var TestAuthorizeDecisionDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "test_authorize_decision",
+		Title: "Test PingOne Authorize Decision",
+		Description: `Evaluate a sample decision request against a PingOne Authorize decision endpoint, returning the decision, such as PERMIT or DENY, and the full decision response with its statements. Use to check the effect of policy changes.
+
+Parameters are the named inputs the policies of the endpoint expect, such as an action or resource. The decision is only evaluated and nothing is changed, but it is recorded in the recent decisions of the endpoint if recording is enabled.`,
+		InputSchema:  schema.MustGenerateSchema[TestAuthorizeDecisionInput](),
+		OutputSchema: schema.MustGenerateSchema[TestAuthorizeDecisionOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type TestAuthorizeDecisionInput struct {
+	EnvironmentId      uuid.UUID      `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	DecisionEndpointId uuid.UUID      `json:"decisionEndpointId" jsonschema:"REQUIRED. UUID of the decision endpoint to evaluate the request with."`
+	Parameters         map[string]any `json:"parameters,omitempty" jsonschema:"OPTIONAL. The parameters of the decision request, by the names the policies of the decision endpoint use, such as {\"Action\": \"read\", \"Resource\": \"invoices\"}."`
+	UserId             *uuid.UUID     `json:"userId,omitempty" jsonschema:"OPTIONAL. UUID of the PingOne user to evaluate the decision for, for policies that use user attributes."`
+}
+
+type TestAuthorizeDecisionOutput struct {
+	Decision *string        `json:"decision,omitempty" jsonschema:"The decision, such as PERMIT, DENY, INDETERMINATE or NOT_APPLICABLE"`
+	Response map[string]any `json:"response" jsonschema:"The full decision response returned by PingOne, including the statements and status of the decision"`
+}
+
+// TestAuthorizeDecisionHandler evaluates a decision request with a PingOne Authorize decision endpoint using the
+// provided client
+func TestAuthorizeDecisionHandler(authorizeClientFactory AuthorizeClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input TestAuthorizeDecisionInput,
+) (
+	*mcp.CallToolResult,
+	*TestAuthorizeDecisionOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input TestAuthorizeDecisionInput) (*mcp.CallToolResult, *TestAuthorizeDecisionOutput, error) {
+		client, err := authorizeClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(TestAuthorizeDecisionDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		decisionRequest := DecisionRequest{
+			Parameters: input.Parameters,
+		}
+		if input.UserId != nil {
+			decisionRequest.UserContext = &DecisionUserContext{
+				User: DecisionUser{Id: input.UserId.String()},
+			}
+		}
+
+		logger.FromContext(ctx).Debug("Evaluating decision",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("decisionEndpointId", input.DecisionEndpointId.String()))
+
+		response, httpResponse, err := client.EvaluateDecision(ctx, input.EnvironmentId, input.DecisionEndpointId, decisionRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if response == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no decision data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		result := &TestAuthorizeDecisionOutput{
+			Response: response,
+		}
+		if decision, ok := response["decision"].(string); ok {
+			result.Decision = &decision
+		}
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package authorize_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testAuthorizeDecisionInput() authorize.TestAuthorizeDecisionInput {
+	return authorize.TestAuthorizeDecisionInput{
+		EnvironmentId:      testEnvironmentId,
+		DecisionEndpointId: testDecisionEndpointId,
+		Parameters: map[string]any{
+			"Action":   "read",
+			"Resource": "invoices",
+		},
+	}
+}
+
+func TestTestAuthorizeDecisionHandler(t *testing.T) {
+	decisionResponse := map[string]any{
+		"id":         "decision-1",
+		"decision":   "PERMIT",
+		"statements": []any{},
+	}
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("EvaluateDecision", mock.Anything, testEnvironmentId, testDecisionEndpointId, authorize.DecisionRequest{
+		Parameters: map[string]any{
+			"Action":   "read",
+			"Resource": "invoices",
+		},
+	}).Return(decisionResponse, &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.TestAuthorizeDecisionHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, testAuthorizeDecisionInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testutils.Pointer("PERMIT"), output.Decision)
+	assert.Equal(t, decisionResponse, output.Response)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTestAuthorizeDecisionHandler_User(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("EvaluateDecision", mock.Anything, testEnvironmentId, testDecisionEndpointId, mock.MatchedBy(func(decisionRequest authorize.DecisionRequest) bool {
+		return decisionRequest.UserContext != nil && decisionRequest.UserContext.User.Id == testUserId.String()
+	})).Return(map[string]any{"decision": "DENY"}, &http.Response{StatusCode: 200}, nil)
+	input := testAuthorizeDecisionInput()
+	input.UserId = &testUserId
+
+	handler := authorize.TestAuthorizeDecisionHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testutils.Pointer("DENY"), output.Decision)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTestAuthorizeDecisionHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientAuthorizeWrapper{}
+			mockClient.On("EvaluateDecision", mock.Anything, testEnvironmentId, testDecisionEndpointId, mock.Anything).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := authorize.TestAuthorizeDecisionHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, testAuthorizeDecisionInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTestAuthorizeDecisionHandler_NoDecisionInResponse(t *testing.T) {
+	mockClient := &mockPingOneClientAuthorizeWrapper{}
+	mockClient.On("EvaluateDecision", mock.Anything, testEnvironmentId, testDecisionEndpointId, mock.Anything).Return(nil, &http.Response{StatusCode: 200}, nil)
+
+	handler := authorize.TestAuthorizeDecisionHandler(NewMockPingOneClientAuthorizeWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, testAuthorizeDecisionInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "no decision data in response")
+}
+
+func TestTestAuthorizeDecisionHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := authorize.TestAuthorizeDecisionHandler(NewMockPingOneClientAuthorizeWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, testAuthorizeDecisionInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,23 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type BrandingThemesClient interface {
+	GetBrandingThemes(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetBrandingTheme(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID) (*management.BrandingTheme, *http.Response, error)
+	CreateBrandingTheme(ctx context.Context, environmentId uuid.UUID, createRequest management.BrandingTheme) (*management.BrandingTheme, *http.Response, error)
+	UpdateBrandingTheme(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID, updateRequest management.BrandingTheme) (*management.BrandingTheme, *http.Response, error)
+	UpdateBrandingThemeDefault(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID, isDefault bool) (*management.BrandingThemeDefault, *http.Response, error)
+}
+
+type BrandingThemesClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (BrandingThemesClient, error)
+}
@@ -0,0 +1,119 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ BrandingThemesClient = &PingOneClientBrandingThemesWrapper{}
+var _ BrandingThemesClientFactory = &PingOneClientBrandingThemesWrapperFactory{}
+
+type PingOneClientBrandingThemesWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientBrandingThemesWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientBrandingThemesWrapper(client *pingone.Client) *PingOneClientBrandingThemesWrapper {
+	return &PingOneClientBrandingThemesWrapper{client: client}
+}
+
+func NewPingOneClientBrandingThemesWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientBrandingThemesWrapperFactory {
+	return &PingOneClientBrandingThemesWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientBrandingThemesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (BrandingThemesClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientBrandingThemesWrapper(client), nil
+}
+
+func (p *PingOneClientBrandingThemesWrapper) GetBrandingThemes(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.BrandingThemesApi.ReadBrandingThemes(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve branding themes",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientBrandingThemesWrapper) GetBrandingTheme(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID) (*management.BrandingTheme, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.BrandingThemesApi.ReadOneBrandingTheme(ctx, environmentId.String(), themeId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve branding theme by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("themeId", themeId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientBrandingThemesWrapper) CreateBrandingTheme(ctx context.Context, environmentId uuid.UUID, createRequest management.BrandingTheme) (*management.BrandingTheme, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.BrandingThemesApi.CreateBrandingTheme(ctx, environmentId.String()).BrandingTheme(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create branding theme",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientBrandingThemesWrapper) UpdateBrandingTheme(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID, updateRequest management.BrandingTheme) (*management.BrandingTheme, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.BrandingThemesApi.UpdateBrandingTheme(ctx, environmentId.String(), themeId.String()).BrandingTheme(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update branding theme",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("themeId", themeId.String()),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientBrandingThemesWrapper) UpdateBrandingThemeDefault(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID, isDefault bool) (*management.BrandingThemeDefault, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.BrandingThemesApi.UpdateBrandingThemeDefault(ctx, environmentId.String(), themeId.String()).BrandingThemeDefault(management.BrandingThemeDefault{Default: isDefault})
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to set the default branding theme",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("themeId", themeId.String()),
+		slog.Bool("default", isDefault),
+	)
+	return putRequest.Execute()
+}
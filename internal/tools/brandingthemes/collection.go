@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "branding_themes"
+
+var _ collections.LegacySdkCollection = &BrandingThemesCollection{}
+
+type BrandingThemesCollection struct{}
+
+func (c *BrandingThemesCollection) Name() string {
+	return CollectionName
+}
+
+func (c *BrandingThemesCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	brandingThemesClientFactory := NewPingOneClientBrandingThemesWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListThemesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListThemesDef.McpTool.Name))
+		mcp.AddTool(server, ListThemesDef.McpTool, ListThemesHandler(brandingThemesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetThemeDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetThemeDef.McpTool.Name))
+		mcp.AddTool(server, GetThemeDef.McpTool, GetThemeHandler(brandingThemesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateThemeDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateThemeDef.McpTool.Name))
+		mcp.AddTool(server, CreateThemeDef.McpTool, CreateThemeHandler(brandingThemesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateThemeDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateThemeDef.McpTool.Name))
+		mcp.AddTool(server, UpdateThemeDef.McpTool, UpdateThemeHandler(brandingThemesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ActivateThemeDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ActivateThemeDef.McpTool.Name))
+		mcp.AddTool(server, ActivateThemeDef.McpTool, ActivateThemeHandler(brandingThemesClientFactory))
+	}
+
+	return nil
+}
+
+func (c *BrandingThemesCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListThemesDef,
+		GetThemeDef,
+		CreateThemeDef,
+		UpdateThemeDef,
+		ActivateThemeDef,
+	}
+}
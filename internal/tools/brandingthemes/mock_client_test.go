@@ -0,0 +1,86 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ brandingthemes.BrandingThemesClient = &mockPingOneClientBrandingThemesWrapper{}
+var _ brandingthemes.BrandingThemesClientFactory = &mockPingOneClientBrandingThemesWrapperFactory{}
+
+type mockPingOneClientBrandingThemesWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientBrandingThemesWrapperFactory struct {
+	mockClient brandingthemes.BrandingThemesClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientBrandingThemesWrapperFactory(mockClient brandingthemes.BrandingThemesClient, err error) *mockPingOneClientBrandingThemesWrapperFactory {
+	return &mockPingOneClientBrandingThemesWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientBrandingThemesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (brandingthemes.BrandingThemesClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientBrandingThemesWrapper) GetBrandingThemes(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientBrandingThemesWrapper) GetBrandingTheme(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID) (*management.BrandingTheme, *http.Response, error) {
+	args := p.Called(ctx, environmentId, themeId)
+	return themeResponse("GetBrandingTheme", args)
+}
+
+func (p *mockPingOneClientBrandingThemesWrapper) CreateBrandingTheme(ctx context.Context, environmentId uuid.UUID, createRequest management.BrandingTheme) (*management.BrandingTheme, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return themeResponse("CreateBrandingTheme", args)
+}
+
+func (p *mockPingOneClientBrandingThemesWrapper) UpdateBrandingTheme(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID, updateRequest management.BrandingTheme) (*management.BrandingTheme, *http.Response, error) {
+	args := p.Called(ctx, environmentId, themeId, updateRequest)
+	return themeResponse("UpdateBrandingTheme", args)
+}
+
+func (p *mockPingOneClientBrandingThemesWrapper) UpdateBrandingThemeDefault(ctx context.Context, environmentId uuid.UUID, themeId uuid.UUID, isDefault bool) (*management.BrandingThemeDefault, *http.Response, error) {
+	args := p.Called(ctx, environmentId, themeId, isDefault)
+	response, ok := args.Get(0).(*management.BrandingThemeDefault)
+	if !ok && args.Get(0) != nil {
+		panic("UpdateBrandingThemeDefault mock setup error: expected *management.BrandingThemeDefault or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("UpdateBrandingThemeDefault mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func themeResponse(method string, args mock.Arguments) (*management.BrandingTheme, *http.Response, error) {
+	response, ok := args.Get(0).(*management.BrandingTheme)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *management.BrandingTheme or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
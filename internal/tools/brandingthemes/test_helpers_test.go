@@ -0,0 +1,78 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testThemeId        = uuid.MustParse("550e8400-e29b-41d4-a716-446655441000")
+	testActiveThemeId  = uuid.MustParse("550e8400-e29b-41d4-a716-446655441001")
+	testLogoImageId    = "550e8400-e29b-41d4-a716-446655441100"
+	testLogoImageHref  = "https://uploads.pingone.com/environments/550e8400-e29b-41d4-a716-446655440000/images/logo.png"
+	testBackgroundHref = "https://uploads.pingone.com/environments/550e8400-e29b-41d4-a716-446655440000/images/background.jpg"
+)
+
+// testConfiguration returns a theme configuration with a logo image and a background color
+func testConfiguration() management.BrandingThemeConfiguration {
+	return management.BrandingThemeConfiguration{
+		Name:             testutils.Pointer("Customer Portal"),
+		BackgroundType:   management.ENUMBRANDINGTHEMEBACKGROUNDTYPE_COLOR,
+		BackgroundColor:  testutils.Pointer("#EDEDED"),
+		BodyTextColor:    "#263956",
+		ButtonColor:      "#2E5EA6",
+		ButtonTextColor:  "#FFFFFF",
+		CardColor:        "#FFFFFF",
+		HeadingTextColor: "#686F77",
+		LinkTextColor:    "#2E5EA6",
+		LogoType:         management.ENUMBRANDINGLOGOTYPE_IMAGE,
+		Logo:             &management.BrandingThemeConfigurationLogo{Id: testLogoImageId, Href: testLogoImageHref},
+	}
+}
+
+// testTheme returns a new inactive theme as returned by the API
+func testTheme() *management.BrandingTheme {
+	return &management.BrandingTheme{
+		Links:         &map[string]management.LinksHATEOASValue{"self": {Href: "https://api.pingone.com/v1/environments/550e8400-e29b-41d4-a716-446655440000/themes/550e8400-e29b-41d4-a716-446655441000"}},
+		Id:            testutils.Pointer(testThemeId.String()),
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Default:       false,
+		Configuration: testConfiguration(),
+	}
+}
+
+// activeTheme returns a new active theme with a background image, as returned by the API
+func activeTheme() *management.BrandingTheme {
+	configuration := testConfiguration()
+	configuration.Name = testutils.Pointer("Corporate")
+	configuration.BackgroundType = management.ENUMBRANDINGTHEMEBACKGROUNDTYPE_IMAGE
+	configuration.BackgroundColor = nil
+	configuration.BackgroundImage = &management.BrandingThemeConfigurationBackgroundImage{Id: "550e8400-e29b-41d4-a716-446655441101", Href: testBackgroundHref}
+	return &management.BrandingTheme{
+		Id:            testutils.Pointer(testActiveThemeId.String()),
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_DEFAULT,
+		Default:       true,
+		Configuration: configuration,
+	}
+}
+
+func themesPages(themes ...management.BrandingTheme) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Themes: themes}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func errorPages(err error) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{HTTPResponse: &http.Response{StatusCode: 403}, Error: err},
+	})
+}
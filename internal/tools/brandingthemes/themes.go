@@ -0,0 +1,74 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"errors"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type ThemeSummary struct {
+	Id              string                                                `json:"id" jsonschema:"The UUID of the theme"`
+	Name            *string                                               `json:"name,omitempty" jsonschema:"The name of the theme"`
+	Template        management.EnumBrandingThemeTemplate                  `json:"template" jsonschema:"The template the theme is based on: default, focus, mural, slate or split"`
+	Default         bool                                                  `json:"default" jsonschema:"Whether the theme is the active theme of the environment, used on sign-on pages of applications and populations without their own theme"`
+	Logo            *management.BrandingThemeConfigurationLogo            `json:"logo,omitempty" jsonschema:"The ID and URL of the logo image, when the logo type is IMAGE"`
+	BackgroundImage *management.BrandingThemeConfigurationBackgroundImage `json:"backgroundImage,omitempty" jsonschema:"The ID and URL of the background image, when the background type is IMAGE"`
+}
+
+type Theme struct {
+	Id            string                                `json:"id" jsonschema:"The UUID of the theme"`
+	Template      management.EnumBrandingThemeTemplate  `json:"template" jsonschema:"The template the theme is based on: default, focus, mural, slate or split"`
+	Default       bool                                  `json:"default" jsonschema:"Whether the theme is the active theme of the environment"`
+	Configuration management.BrandingThemeConfiguration `json:"configuration" jsonschema:"The name, colors, logo, background and footer of the theme"`
+}
+
+func themeSummary(theme management.BrandingTheme) ThemeSummary {
+	return ThemeSummary{
+		Id:              theme.GetId(),
+		Name:            theme.Configuration.Name,
+		Template:        theme.Template,
+		Default:         theme.Default,
+		Logo:            theme.Configuration.Logo,
+		BackgroundImage: theme.Configuration.BackgroundImage,
+	}
+}
+
+func themeOutput(theme management.BrandingTheme) Theme {
+	return Theme{
+		Id:            theme.GetId(),
+		Template:      theme.Template,
+		Default:       theme.Default,
+		Configuration: theme.Configuration,
+	}
+}
+
+// validateConfiguration checks that the image references and colors required by the logo and background
+// types are set, so that a theme that PingOne would reject is not sent
+func validateConfiguration(configuration management.BrandingThemeConfiguration) error {
+	var validationErrs []error
+	switch configuration.LogoType {
+	case management.ENUMBRANDINGLOGOTYPE_IMAGE:
+		if configuration.Logo == nil || configuration.Logo.Id == "" || configuration.Logo.Href == "" {
+			validationErrs = append(validationErrs, errors.New("logo with the image ID and href is required when logoType is IMAGE"))
+		}
+	case management.ENUMBRANDINGLOGOTYPE_NONE:
+	default:
+		validationErrs = append(validationErrs, errors.New("logoType must be IMAGE or NONE"))
+	}
+	switch configuration.BackgroundType {
+	case management.ENUMBRANDINGTHEMEBACKGROUNDTYPE_IMAGE:
+		if configuration.BackgroundImage == nil || configuration.BackgroundImage.Id == "" || configuration.BackgroundImage.Href == "" {
+			validationErrs = append(validationErrs, errors.New("backgroundImage with the image ID and href is required when backgroundType is IMAGE"))
+		}
+	case management.ENUMBRANDINGTHEMEBACKGROUNDTYPE_COLOR:
+		if configuration.BackgroundColor == nil || *configuration.BackgroundColor == "" {
+			validationErrs = append(validationErrs, errors.New("backgroundColor is required when backgroundType is COLOR"))
+		}
+	case management.ENUMBRANDINGTHEMEBACKGROUNDTYPE_NONE, management.ENUMBRANDINGTHEMEBACKGROUNDTYPE_DEFAULT:
+	default:
+		validationErrs = append(validationErrs, errors.New("backgroundType must be NONE, COLOR, IMAGE or DEFAULT"))
+	}
+	return errors.Join(validationErrs...)
+}
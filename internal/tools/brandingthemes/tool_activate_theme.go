@@ -0,0 +1,172 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ActivateThemeDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "activate_theme",
+		Title: "Activate PingOne Branding Theme",
+		Description: `Make a branding theme the active theme of an environment, replacing the active theme on the sign-on pages of every application and population without a theme of its own. The change is visible to users straight away.
+
+Use 'list_themes' to find the theme ID and the currently active theme. The previously active theme can be restored with undo_last_change.`,
+		InputSchema:  schema.MustGenerateSchema[ActivateThemeInput](),
+		OutputSchema: schema.MustGenerateSchema[ActivateThemeOutput](),
+	},
+}
+
+type ActivateThemeInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ThemeId       uuid.UUID `json:"themeId" jsonschema:"REQUIRED. UUID of the theme to activate."`
+}
+
+type ActivateThemeOutput struct {
+	Theme                 ThemeSummary `json:"theme" jsonschema:"The activated branding theme"`
+	PreviousActiveThemeId *string      `json:"previousActiveThemeId,omitempty" jsonschema:"The UUID of the theme that was active before, if any. Not set when the theme was already active"`
+	AlreadyActive         bool         `json:"alreadyActive" jsonschema:"Whether the theme was already the active theme, in which case nothing was changed"`
+}
+
+// ActivateThemeHandler makes a PingOne branding theme the active theme of its environment using the provided client
+func ActivateThemeHandler(brandingThemesClientFactory BrandingThemesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ActivateThemeInput,
+) (
+	*mcp.CallToolResult,
+	*ActivateThemeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ActivateThemeInput) (*mcp.CallToolResult, *ActivateThemeOutput, error) {
+		client, err := brandingThemesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ActivateThemeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the themes first, to find the active theme to restore on undo
+		pagedIterator, err := client.GetBrandingThemes(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(ActivateThemeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		var theme, previous *management.BrandingTheme
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			for _, t := range embedded.Themes {
+				if t.GetId() == input.ThemeId.String() {
+					theme = &t
+				}
+				if t.Default {
+					previous = &t
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if theme == nil {
+			toolErr := errs.NewToolError(ActivateThemeDef.McpTool.Name, fmt.Errorf("theme %s not found in environment %s", input.ThemeId, input.EnvironmentId))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if theme.Default {
+			return nil, &ActivateThemeOutput{
+				Theme:         themeSummary(*theme),
+				AlreadyActive: true,
+			}, nil
+		}
+
+		logger.FromContext(ctx).Debug("Activating branding theme",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("themeId", input.ThemeId.String()))
+
+		_, httpResponse, err := client.UpdateBrandingThemeDefault(ctx, input.EnvironmentId, input.ThemeId, true)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		var previousId *string
+		description := fmt.Sprintf("Deactivate theme %q", theme.Configuration.GetName())
+		if previous != nil {
+			previousId = previous.Id
+			description = fmt.Sprintf("Activate theme %q again", previous.Configuration.GetName())
+		}
+		rollback.Record(ctx, rollback.Change{
+			Tool:          ActivateThemeDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "theme",
+			ResourceId:    input.ThemeId.String(),
+			Description:   description,
+		}, undoActivateTheme(brandingThemesClientFactory, input.EnvironmentId, input.ThemeId, previousId))
+
+		theme.Default = true
+		return nil, &ActivateThemeOutput{
+			Theme:                 themeSummary(*theme),
+			PreviousActiveThemeId: previousId,
+		}, nil
+	}
+}
+
+// undoActivateTheme returns the function that activates the previously active theme again, or deactivates the
+// theme if no theme was active before, unless another theme has been activated since
+func undoActivateTheme(brandingThemesClientFactory BrandingThemesClientFactory, environmentId uuid.UUID, themeId uuid.UUID, previousId *string) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := brandingThemesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			current, httpResponse, err := client.GetBrandingTheme(ctx, environmentId, themeId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			if current == nil {
+				return errs.NewApiError(httpResponse, fmt.Errorf("no theme data in response"))
+			}
+			if !current.Default {
+				return rollback.ErrChangedSince
+			}
+		}
+
+		if previousId == nil {
+			_, httpResponse, err := client.UpdateBrandingThemeDefault(ctx, environmentId, themeId, false)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			return nil
+		}
+		previousThemeId, err := uuid.Parse(*previousId)
+		if err != nil {
+			return fmt.Errorf("invalid previous theme ID %q: %w", *previousId, err)
+		}
+		_, httpResponse, err := client.UpdateBrandingThemeDefault(ctx, environmentId, previousThemeId, true)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivateThemeHandler(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(*activeTheme(), *testTheme()), nil)
+	mockClient.On("UpdateBrandingThemeDefault", mock.Anything, testEnvironmentId, testThemeId, true).Return(&management.BrandingThemeDefault{Default: true}, &http.Response{StatusCode: 200}, nil)
+
+	handler := brandingthemes.ActivateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ActivateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testThemeId.String(), output.Theme.Id)
+	assert.True(t, output.Theme.Default)
+	assert.Equal(t, testutils.Pointer(testActiveThemeId.String()), output.PreviousActiveThemeId)
+	assert.False(t, output.AlreadyActive)
+	mockClient.AssertExpectations(t)
+}
+
+func TestActivateThemeHandler_AlreadyActive(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(*activeTheme(), *testTheme()), nil)
+
+	handler := brandingthemes.ActivateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ActivateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testActiveThemeId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.AlreadyActive)
+	assert.Nil(t, output.PreviousActiveThemeId)
+	mockClient.AssertNotCalled(t, "UpdateBrandingThemeDefault", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestActivateThemeHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientBrandingThemesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Theme not found",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(*activeTheme()), nil)
+			},
+			wantErrContains: "not found",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+		{
+			name: "Activate error",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(*testTheme()), nil)
+				mockClient.On("UpdateBrandingThemeDefault", mock.Anything, testEnvironmentId, testThemeId, true).Return(nil, &http.Response{StatusCode: 403}, errors.New("insufficient permissions"))
+			},
+			wantErrContains: "insufficient permissions",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientBrandingThemesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := brandingthemes.ActivateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ActivateThemeInput{
+				EnvironmentId: testEnvironmentId,
+				ThemeId:       testThemeId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestActivateThemeHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := brandingthemes.ActivateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ActivateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestActivateThemeHandler_RecordsUndo(t *testing.T) {
+	tests := []struct {
+		name        string
+		themes      []management.BrandingTheme
+		expectUndo  func(mockClient *mockPingOneClientBrandingThemesWrapper)
+		description string
+	}{
+		{
+			name:   "previous theme activated again",
+			themes: []management.BrandingTheme{*activeTheme(), *testTheme()},
+			expectUndo: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("UpdateBrandingThemeDefault", mock.Anything, testEnvironmentId, testActiveThemeId, true).Return(&management.BrandingThemeDefault{Default: true}, &http.Response{StatusCode: 200}, nil).Once()
+			},
+		},
+		{
+			name:   "theme deactivated without a previous theme",
+			themes: []management.BrandingTheme{*testTheme()},
+			expectUndo: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("UpdateBrandingThemeDefault", mock.Anything, testEnvironmentId, testThemeId, false).Return(&management.BrandingThemeDefault{Default: false}, &http.Response{StatusCode: 200}, nil).Once()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientBrandingThemesWrapper{}
+			mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(tt.themes...), nil)
+			mockClient.On("UpdateBrandingThemeDefault", mock.Anything, testEnvironmentId, testThemeId, true).Return(&management.BrandingThemeDefault{Default: true}, &http.Response{StatusCode: 200}, nil).Once()
+			journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+			ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+			handler := brandingthemes.ActivateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+			_, _, err := handler(ctx, &mcp.CallToolRequest{}, brandingthemes.ActivateThemeInput{
+				EnvironmentId: testEnvironmentId,
+				ThemeId:       testThemeId,
+			})
+			require.NoError(t, err)
+			require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+			activated := testTheme()
+			activated.Default = true
+			mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(activated, &http.Response{StatusCode: 200}, nil).Once()
+			tt.expectUndo(mockClient)
+			_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+			require.NoError(t, err)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestActivateThemeHandler_UndoChangedSince(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(*activeTheme(), *testTheme()), nil)
+	mockClient.On("UpdateBrandingThemeDefault", mock.Anything, testEnvironmentId, testThemeId, true).Return(&management.BrandingThemeDefault{Default: true}, &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := brandingthemes.ActivateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, brandingthemes.ActivateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+	})
+	require.NoError(t, err)
+
+	// Another theme has been activated since, so the undo is refused unless forced
+	mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(testTheme(), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,95 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateThemeDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "create_theme",
+		Title: "Create PingOne Branding Theme",
+		Description: `Create a branding theme that styles the sign-on pages users see. Colors are hexadecimal color codes, such as #2E5EA6.
+
+Logo and background images are referenced by the ID and URL of an image already uploaded to the environment, such as an image referenced by another theme. Set 'logoType' to IMAGE with 'logo', or NONE, and 'backgroundType' to IMAGE with 'backgroundImage', COLOR with 'backgroundColor', NONE or DEFAULT.
+
+The theme is not active until 'activate_theme' is called, but can be set as the theme of a population straight away.`,
+		InputSchema:  schema.MustGenerateSchema[CreateThemeInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateThemeOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateThemeInput struct {
+	EnvironmentId uuid.UUID                             `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Template      management.EnumBrandingThemeTemplate  `json:"template" jsonschema:"REQUIRED. The template the theme is based on: default, focus, mural, slate or split."`
+	Configuration management.BrandingThemeConfiguration `json:"configuration" jsonschema:"REQUIRED. The name, colors, logo, background and footer of the theme."`
+}
+
+type CreateThemeOutput struct {
+	Theme Theme `json:"theme" jsonschema:"The created branding theme"`
+}
+
+// CreateThemeHandler creates a PingOne branding theme using the provided client
+func CreateThemeHandler(brandingThemesClientFactory BrandingThemesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateThemeInput,
+) (
+	*mcp.CallToolResult,
+	*CreateThemeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateThemeInput) (*mcp.CallToolResult, *CreateThemeOutput, error) {
+		if err := validateConfiguration(input.Configuration); err != nil {
+			toolErr := errs.NewToolError(CreateThemeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := brandingThemesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateThemeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating branding theme",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("template", string(input.Template)))
+
+		createRequest := management.BrandingTheme{
+			Template:      input.Template,
+			Configuration: input.Configuration,
+		}
+		theme, httpResponse, err := client.CreateBrandingTheme(ctx, input.EnvironmentId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if theme == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no theme data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &CreateThemeOutput{
+			Theme: themeOutput(*theme),
+		}, nil
+	}
+}
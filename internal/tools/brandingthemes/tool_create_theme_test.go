@@ -0,0 +1,122 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateThemeHandler(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	expectedRequest := management.BrandingTheme{
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: testConfiguration(),
+	}
+	mockClient.On("CreateBrandingTheme", mock.Anything, testEnvironmentId, expectedRequest).Return(testTheme(), &http.Response{StatusCode: 201}, nil)
+
+	handler := brandingthemes.CreateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.CreateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: testConfiguration(),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testThemeId.String(), output.Theme.Id)
+	assert.False(t, output.Theme.Default)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateThemeHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		configuration   func() management.BrandingThemeConfiguration
+		setupMock       func(mockClient *mockPingOneClientBrandingThemesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Logo image without reference",
+			configuration: func() management.BrandingThemeConfiguration {
+				configuration := testConfiguration()
+				configuration.Logo = nil
+				return configuration
+			},
+			wantErrContains: "logo with the image ID and href is required",
+		},
+		{
+			name: "Background image without reference",
+			configuration: func() management.BrandingThemeConfiguration {
+				configuration := testConfiguration()
+				configuration.BackgroundType = management.ENUMBRANDINGTHEMEBACKGROUNDTYPE_IMAGE
+				return configuration
+			},
+			wantErrContains: "backgroundImage with the image ID and href is required",
+		},
+		{
+			name: "Background color missing",
+			configuration: func() management.BrandingThemeConfiguration {
+				configuration := testConfiguration()
+				configuration.BackgroundColor = nil
+				return configuration
+			},
+			wantErrContains: "backgroundColor is required",
+		},
+		{
+			name: "Missing logo type",
+			configuration: func() management.BrandingThemeConfiguration {
+				configuration := testConfiguration()
+				configuration.LogoType = ""
+				return configuration
+			},
+			wantErrContains: "logoType must be IMAGE or NONE",
+		},
+		{
+			name:          "API error",
+			configuration: testConfiguration,
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("CreateBrandingTheme", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("invalid color"))
+			},
+			wantErrContains: "invalid color",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientBrandingThemesWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := brandingthemes.CreateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.CreateThemeInput{
+				EnvironmentId: testEnvironmentId,
+				Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+				Configuration: tt.configuration(),
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateThemeHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := brandingthemes.CreateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.CreateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: testConfiguration(),
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
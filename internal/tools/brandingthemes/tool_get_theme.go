@@ -0,0 +1,83 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetThemeDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "get_theme",
+		Title:        "Get PingOne Branding Theme by ID",
+		Description:  "Retrieve the configuration of a branding theme: its template, colors, footer, and the IDs and URLs of its logo and background images. Use 'list_themes' first if you need to find the theme ID. Call before 'update_theme' to get the current configuration.",
+		InputSchema:  schema.MustGenerateSchema[GetThemeInput](),
+		OutputSchema: schema.MustGenerateSchema[GetThemeOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetThemeInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ThemeId       uuid.UUID `json:"themeId" jsonschema:"REQUIRED. Theme UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'theme.configuration.name' and 'theme.configuration.logo'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetThemeOutput struct {
+	Theme Theme `json:"theme" jsonschema:"The branding theme"`
+}
+
+// GetThemeHandler retrieves a PingOne branding theme using the provided client
+func GetThemeHandler(brandingThemesClientFactory BrandingThemesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetThemeInput,
+) (
+	*mcp.CallToolResult,
+	*GetThemeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetThemeInput) (*mcp.CallToolResult, *GetThemeOutput, error) {
+		client, err := brandingThemesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetThemeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Retrieving branding theme",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("themeId", input.ThemeId.String()))
+
+		theme, httpResponse, err := client.GetBrandingTheme(ctx, input.EnvironmentId, input.ThemeId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if theme == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no theme data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &GetThemeOutput{
+			Theme: themeOutput(*theme),
+		}, nil
+	}
+}
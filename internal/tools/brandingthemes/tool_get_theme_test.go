@@ -0,0 +1,86 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetThemeHandler(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(testTheme(), &http.Response{StatusCode: 200}, nil)
+
+	handler := brandingthemes.GetThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.GetThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, brandingthemes.Theme{
+		Id:            testThemeId.String(),
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Default:       false,
+		Configuration: testConfiguration(),
+	}, output.Theme)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetThemeHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientBrandingThemesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(nil, &http.Response{StatusCode: 404}, errors.New("theme not found"))
+			},
+			wantErrContains: "theme not found",
+		},
+		{
+			name: "No theme in response",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no theme data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientBrandingThemesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := brandingthemes.GetThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.GetThemeInput{
+				EnvironmentId: testEnvironmentId,
+				ThemeId:       testThemeId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetThemeHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := brandingthemes.GetThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.GetThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,86 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListThemesDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_themes",
+		Title:        "List PingOne Branding Themes",
+		Description:  "Lists the branding themes of an environment, which style the sign-on pages users see, with the theme that is active and the logo and background images each theme references. Use to discover theme IDs, such as the theme ID of a population.",
+		InputSchema:  schema.MustGenerateSchema[ListThemesInput](),
+		OutputSchema: schema.MustGenerateSchema[ListThemesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListThemesInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'themes.id' and 'themes.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListThemesOutput struct {
+	Themes []ThemeSummary `json:"themes" jsonschema:"The branding themes of the environment"`
+}
+
+// ListThemesHandler lists the PingOne branding themes of an environment using the provided client
+func ListThemesHandler(brandingThemesClientFactory BrandingThemesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListThemesInput,
+) (
+	*mcp.CallToolResult,
+	*ListThemesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListThemesInput) (*mcp.CallToolResult, *ListThemesOutput, error) {
+		client, err := brandingThemesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListThemesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing branding themes", slog.String("environmentId", input.EnvironmentId.String()))
+
+		pagedIterator, err := client.GetBrandingThemes(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(ListThemesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &ListThemesOutput{
+			Themes: []ThemeSummary{},
+		}
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			logger.FromContext(ctx).Debug("Retrieved branding themes page", slog.Int("count", len(embedded.Themes)))
+			for _, theme := range embedded.Themes {
+				result.Themes = append(result.Themes, themeSummary(theme))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListThemesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(*activeTheme(), *testTheme()), nil)
+
+	handler := brandingthemes.ListThemesHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ListThemesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.Themes, 2)
+	assert.Equal(t, testActiveThemeId.String(), output.Themes[0].Id)
+	assert.True(t, output.Themes[0].Default)
+	require.NotNil(t, output.Themes[0].BackgroundImage)
+	assert.Equal(t, testBackgroundHref, output.Themes[0].BackgroundImage.Href)
+	assert.Equal(t, brandingthemes.ThemeSummary{
+		Id:       testThemeId.String(),
+		Name:     testutils.Pointer("Customer Portal"),
+		Template: management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Default:  false,
+		Logo:     &management.BrandingThemeConfigurationLogo{Id: testLogoImageId, Href: testLogoImageHref},
+	}, output.Themes[1])
+	mockClient.AssertExpectations(t)
+}
+
+func TestListThemesHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(themesPages(), nil)
+
+	handler := brandingthemes.ListThemesHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ListThemesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Themes)
+	assert.Empty(t, output.Themes)
+}
+
+func TestListThemesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientBrandingThemesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(nil, errors.New("invalid environment"))
+			},
+			wantErrContains: "invalid environment",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingThemes", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientBrandingThemesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := brandingthemes.ListThemesHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ListThemesInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListThemesHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := brandingthemes.ListThemesHandler(NewMockPingOneClientBrandingThemesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.ListThemesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
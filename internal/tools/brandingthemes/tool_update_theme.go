@@ -0,0 +1,156 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateThemeDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "update_theme",
+		Title: "Update PingOne Branding Theme by ID",
+		Description: `Update the template and configuration of a branding theme using full replacement (HTTP PUT). Whether the theme is active is not changed; use 'activate_theme' to make it active.
+
+WORKFLOW - Required to avoid data loss:
+1. Call 'get_theme' to fetch current configuration
+2. Modify only the fields you want to change
+3. Pass the complete merged 'configuration' object to this tool
+
+Omitted optional fields will be cleared. Logo and background images are referenced by the ID and URL of an image already uploaded to the environment.`,
+		InputSchema:  schema.MustGenerateSchema[UpdateThemeInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateThemeOutput](),
+	},
+}
+
+type UpdateThemeInput struct {
+	EnvironmentId uuid.UUID                             `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ThemeId       uuid.UUID                             `json:"themeId" jsonschema:"REQUIRED. Theme UUID."`
+	Template      management.EnumBrandingThemeTemplate  `json:"template" jsonschema:"REQUIRED. The template the theme is based on: default, focus, mural, slate or split."`
+	Configuration management.BrandingThemeConfiguration `json:"configuration" jsonschema:"REQUIRED. The complete theme configuration with modifications."`
+}
+
+type UpdateThemeOutput struct {
+	Theme Theme `json:"theme" jsonschema:"The updated branding theme"`
+}
+
+// UpdateThemeHandler replaces the configuration of a PingOne branding theme using the provided client
+func UpdateThemeHandler(brandingThemesClientFactory BrandingThemesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateThemeInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateThemeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateThemeInput) (*mcp.CallToolResult, *UpdateThemeOutput, error) {
+		if err := validateConfiguration(input.Configuration); err != nil {
+			toolErr := errs.NewToolError(UpdateThemeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := brandingThemesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateThemeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the current theme, to keep whether it is active and so that the change can be undone
+		previous, httpResponse, err := client.GetBrandingTheme(ctx, input.EnvironmentId, input.ThemeId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if previous == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no theme data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		logger.FromContext(ctx).Debug("Updating branding theme",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("themeId", input.ThemeId.String()))
+
+		updateRequest := management.BrandingTheme{
+			Template:      input.Template,
+			Default:       previous.Default,
+			Configuration: input.Configuration,
+		}
+		theme, httpResponse, err := client.UpdateBrandingTheme(ctx, input.EnvironmentId, input.ThemeId, updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if theme == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no theme data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          UpdateThemeDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "theme",
+			ResourceId:    input.ThemeId.String(),
+			Description:   fmt.Sprintf("Restore the previous configuration of theme %q", previous.Configuration.GetName()),
+		}, undoUpdateTheme(brandingThemesClientFactory, input.EnvironmentId, input.ThemeId, *previous, *theme))
+
+		return nil, &UpdateThemeOutput{
+			Theme: themeOutput(*theme),
+		}, nil
+	}
+}
+
+// undoUpdateTheme returns the function that restores the previous template and configuration of a theme,
+// unless the theme has been changed again since
+func undoUpdateTheme(brandingThemesClientFactory BrandingThemesClientFactory, environmentId uuid.UUID, themeId uuid.UUID, previous management.BrandingTheme, updated management.BrandingTheme) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := brandingThemesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, httpResponse, err := client.GetBrandingTheme(ctx, environmentId, themeId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if current == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no theme data in response"))
+		}
+		if !force && (current.Template != updated.Template || !reflect.DeepEqual(current.Configuration, updated.Configuration)) {
+			return rollback.ErrChangedSince
+		}
+
+		restoreRequest := management.BrandingTheme{
+			Template:      previous.Template,
+			Default:       current.Default,
+			Configuration: previous.Configuration,
+		}
+		_, httpResponse, err = client.UpdateBrandingTheme(ctx, environmentId, themeId, restoreRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
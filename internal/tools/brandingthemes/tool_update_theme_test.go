@@ -0,0 +1,168 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package brandingthemes_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// updatedConfiguration returns the test configuration with a new button color
+func updatedConfiguration() management.BrandingThemeConfiguration {
+	configuration := testConfiguration()
+	configuration.ButtonColor = "#B3282D"
+	return configuration
+}
+
+func updatedTheme() *management.BrandingTheme {
+	theme := testTheme()
+	theme.Configuration = updatedConfiguration()
+	return theme
+}
+
+func TestUpdateThemeHandler(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	active := activeTheme()
+	mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testActiveThemeId).Return(active, &http.Response{StatusCode: 200}, nil)
+	// Whether the theme is active is kept
+	expectedRequest := management.BrandingTheme{
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Default:       true,
+		Configuration: updatedConfiguration(),
+	}
+	updated := updatedTheme()
+	updated.Default = true
+	mockClient.On("UpdateBrandingTheme", mock.Anything, testEnvironmentId, testActiveThemeId, expectedRequest).Return(updated, &http.Response{StatusCode: 200}, nil)
+
+	handler := brandingthemes.UpdateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.UpdateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testActiveThemeId,
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: updatedConfiguration(),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, "#B3282D", output.Theme.Configuration.ButtonColor)
+	assert.True(t, output.Theme.Default)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateThemeHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientBrandingThemesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get theme error",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(nil, &http.Response{StatusCode: 404}, errors.New("theme not found"))
+			},
+			wantErrContains: "theme not found",
+		},
+		{
+			name: "Update error",
+			setupMock: func(mockClient *mockPingOneClientBrandingThemesWrapper) {
+				mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(testTheme(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateBrandingTheme", mock.Anything, testEnvironmentId, testThemeId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("invalid color"))
+			},
+			wantErrContains: "invalid color",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientBrandingThemesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := brandingthemes.UpdateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.UpdateThemeInput{
+				EnvironmentId: testEnvironmentId,
+				ThemeId:       testThemeId,
+				Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+				Configuration: updatedConfiguration(),
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateThemeHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := brandingthemes.UpdateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, brandingthemes.UpdateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: updatedConfiguration(),
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestUpdateThemeHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(testTheme(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateBrandingTheme", mock.Anything, testEnvironmentId, testThemeId, mock.Anything).Return(updatedTheme(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := brandingthemes.UpdateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, brandingthemes.UpdateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: updatedConfiguration(),
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// Undoing the update restores the previous configuration
+	restoreRequest := management.BrandingTheme{
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: testConfiguration(),
+	}
+	mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(updatedTheme(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateBrandingTheme", mock.Anything, testEnvironmentId, testThemeId, restoreRequest).Return(testTheme(), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateThemeHandler_UndoChangedSince(t *testing.T) {
+	changedAgain := updatedTheme()
+	changedAgain.Configuration.ButtonColor = "#000000"
+	mockClient := &mockPingOneClientBrandingThemesWrapper{}
+	mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(testTheme(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateBrandingTheme", mock.Anything, testEnvironmentId, testThemeId, mock.Anything).Return(updatedTheme(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := brandingthemes.UpdateThemeHandler(NewMockPingOneClientBrandingThemesWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, brandingthemes.UpdateThemeInput{
+		EnvironmentId: testEnvironmentId,
+		ThemeId:       testThemeId,
+		Template:      management.ENUMBRANDINGTHEMETEMPLATE_SPLIT,
+		Configuration: updatedConfiguration(),
+	})
+	require.NoError(t, err)
+
+	// The theme has been changed again since, so the undo is refused unless forced
+	mockClient.On("GetBrandingTheme", mock.Anything, testEnvironmentId, testThemeId).Return(changedAgain, &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
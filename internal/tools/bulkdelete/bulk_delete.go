@@ -0,0 +1,36 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package bulkdelete holds what the bulk delete tools share: the confirmation token that is required before
+// resources are deleted and the limit on the resources deleted per call.
+package bulkdelete
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxResources is the number of resources a bulk delete tool deletes per call. Calls matching more resources delete
+// the first MaxResources, and are called again to delete the rest.
+const MaxResources = 100
+
+// ConfirmationTokenArgument is the argument of the bulk delete tools that confirms the deletion
+const ConfirmationTokenArgument = "confirmationToken"
+
+// ErrConfirmationTokenMismatch is returned when the confirmation token was not returned for the resources that
+// match the call
+var ErrConfirmationTokenMismatch = errors.New("the confirmation token does not match the resources to delete, which may have changed since it was returned; call the tool again without a confirmation token to review them")
+
+// ConfirmationToken returns the token that confirms the deletion of the resources with the given IDs in the
+// environment. It is a hash of the IDs, so a token only confirms the deletion of the resources it was returned for,
+// in any order.
+func ConfirmationToken(environmentId uuid.UUID, resourceIds []string) string {
+	sortedIds := slices.Clone(resourceIds)
+	slices.Sort(sortedIds)
+	hash := sha256.Sum256([]byte(environmentId.String() + "\n" + strings.Join(sortedIds, "\n")))
+	return hex.EncodeToString(hash[:])
+}
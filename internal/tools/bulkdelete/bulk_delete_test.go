@@ -0,0 +1,30 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package bulkdelete_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/bulkdelete"
+	"github.com/stretchr/testify/assert"
+)
+
+var testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+func TestConfirmationToken(t *testing.T) {
+	token := bulkdelete.ConfirmationToken(testEnvironmentId, []string{"a", "b", "c"})
+
+	assert.Len(t, token, 64)
+	assert.Equal(t, token, bulkdelete.ConfirmationToken(testEnvironmentId, []string{"c", "a", "b"}), "the order of the IDs should not matter")
+	assert.NotEqual(t, token, bulkdelete.ConfirmationToken(testEnvironmentId, []string{"a", "b"}), "the token should change if the IDs change")
+	assert.NotEqual(t, token, bulkdelete.ConfirmationToken(uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"), []string{"a", "b", "c"}), "the token should change with the environment")
+}
+
+func TestConfirmationToken_DoesNotChangeIds(t *testing.T) {
+	ids := []string{"c", "a", "b"}
+
+	bulkdelete.ConfirmationToken(testEnvironmentId, ids)
+
+	assert.Equal(t, []string{"c", "a", "b"}, ids)
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package capabilities works out which tools the authenticated PingOne principal cannot use, from the
+// administrator roles assigned to it, so that agents are not offered tools whose calls PingOne always denies.
+package capabilities
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// Mode is what is done with the tools the authenticated principal cannot use.
+type Mode string
+
+const (
+	// ModeOff lists all tools, without looking up the roles of the principal
+	ModeOff Mode = "off"
+	// ModeMark lists all tools, noting in their descriptions the tools the principal cannot use
+	ModeMark Mode = "mark"
+	// ModeHide does not list the tools the principal cannot use
+	ModeHide Mode = "hide"
+)
+
+var modes = []Mode{ModeOff, ModeMark, ModeHide}
+
+// ParseMode parses a mode, which is case-insensitive.
+func ParseMode(value string) (Mode, error) {
+	mode := Mode(strings.ToLower(strings.TrimSpace(value)))
+	if !slices.Contains(modes, mode) {
+		return "", fmt.Errorf("invalid tool capabilities mode %q, must be one of %s, %s or %s", value, ModeOff, ModeMark, ModeHide)
+	}
+	return mode, nil
+}
+
+// readOnlyRoles are the built-in roles that grant no permission to make changes
+var readOnlyRoles = []string{
+	string(management.ENUMROLENAME_CONFIGURATION_READ_ONLY),
+	string(management.ENUMROLENAME_IDENTITY_DATA_READ_ONLY),
+	string(management.ENUMROLENAME_DA_VINCI_ADMIN_READ_ONLY),
+}
+
+// UnavailableTools returns the reason each tool of toolDefs cannot be used by a principal assigned the named roles,
+// for the tools whose calls PingOne would always deny:
+//   - tools that declare RequiredRoles, when the principal is assigned none of them
+//   - write tools, when the principal is only assigned read-only roles
+//
+// Custom roles may grant any permission, so principals assigned one are assumed to be able to use every tool.
+// Nothing is inferred for principals without roles.
+func UnavailableTools(roleNames []string, toolDefs []types.ToolDefinition) map[string]string {
+	unavailable := map[string]string{}
+	if len(roleNames) == 0 || slices.ContainsFunc(roleNames, isCustomRole) {
+		return unavailable
+	}
+	readOnly := !slices.ContainsFunc(roleNames, func(roleName string) bool { return !slices.Contains(readOnlyRoles, roleName) })
+
+	for _, toolDef := range toolDefs {
+		toolName := toolDef.McpTool.Name
+		switch {
+		case len(toolDef.RequiredRoles) > 0 && !slices.ContainsFunc(toolDef.RequiredRoles, func(role string) bool { return slices.Contains(roleNames, role) }):
+			unavailable[toolName] = fmt.Sprintf("it requires the %s role, which the principal is not assigned", strings.Join(toolDef.RequiredRoles, " or "))
+		case readOnly && !toolDef.IsReadOnly():
+			unavailable[toolName] = "it makes changes, and the principal is only assigned read-only roles"
+		}
+	}
+	return unavailable
+}
+
+// isCustomRole returns true if the role is not a built-in role, including roles whose names could not be looked up
+func isCustomRole(roleName string) bool {
+	return !slices.Contains(management.AllowedEnumRoleNameEnumValues, management.EnumRoleName(roleName))
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package capabilities_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/capabilities"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "list_things", Description: "List things", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}},
+	{McpTool: &mcp.Tool{Name: "update_thing", Description: "Update a thing"}},
+	{McpTool: &mcp.Tool{Name: "create_environment", Description: "Create an environment"}, RequiredRoles: []string{"Organization Admin"}},
+}
+
+func TestParseMode(t *testing.T) {
+	for value, want := range map[string]capabilities.Mode{
+		"off":    capabilities.ModeOff,
+		"mark":   capabilities.ModeMark,
+		" HIDE ": capabilities.ModeHide,
+	} {
+		mode, err := capabilities.ParseMode(value)
+		require.NoError(t, err)
+		assert.Equal(t, want, mode)
+	}
+
+	_, err := capabilities.ParseMode("remove")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid tool capabilities mode "remove"`)
+}
+
+func TestUnavailableTools(t *testing.T) {
+	tests := []struct {
+		name      string
+		roleNames []string
+		want      []string
+	}{
+		{
+			name:      "Organization Admin",
+			roleNames: []string{"Organization Admin"},
+			want:      []string{},
+		},
+		{
+			name:      "Environment Admin",
+			roleNames: []string{"Environment Admin", "Identity Data Admin"},
+			want:      []string{"create_environment"},
+		},
+		{
+			name:      "Read-only roles",
+			roleNames: []string{"Configuration Read Only", "Identity Data Read Only"},
+			want:      []string{"update_thing", "create_environment"},
+		},
+		{
+			name:      "Read-only and write roles",
+			roleNames: []string{"Identity Data Read Only", "Identity Data Admin"},
+			want:      []string{"create_environment"},
+		},
+		{
+			name:      "Custom role",
+			roleNames: []string{"Configuration Read Only", "Password Reset Operator"},
+			want:      []string{},
+		},
+		{
+			name:      "No roles",
+			roleNames: []string{},
+			want:      []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unavailable := capabilities.UnavailableTools(tt.roleNames, testToolDefs)
+
+			toolNames := []string{}
+			for toolName, reason := range unavailable {
+				toolNames = append(toolNames, toolName)
+				assert.NotEmpty(t, reason)
+			}
+			assert.ElementsMatch(t, tt.want, toolNames)
+		})
+	}
+}
+
+func TestUnavailableTools_Reasons(t *testing.T) {
+	unavailable := capabilities.UnavailableTools([]string{"Identity Data Read Only"}, testToolDefs)
+
+	assert.Equal(t, "it requires the Organization Admin role, which the principal is not assigned", unavailable["create_environment"])
+	assert.Equal(t, "it makes changes, and the principal is only assigned read-only roles", unavailable["update_thing"])
+}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package capabilities
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// RoleLookup returns the names of the roles assigned to a user or application in an environment, with the subject
+// type of roles.SubjectTypeUser or roles.SubjectTypeApplication.
+type RoleLookup func(ctx context.Context, subjectType string, environmentId uuid.UUID, subjectId string) ([]string, error)
+
+// CapabilityMiddleware marks or hides the tools that the authenticated principal cannot use in the tools listed
+// to MCP clients. The roles of the principal are looked up once there is a login session, on the first tool
+// listing or call, and again whenever the session is of another principal, such as after switching profile.
+//
+// Calls are not rejected, as PingOne has the final say on what the principal may do. If the roles cannot be
+// looked up, for example because the principal may not read role assignments, all tools are listed as they are.
+//
+// Clients that list tools before the first login see the tools the principal cannot use when they next list tools.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, outside the middleware that
+// replaces tool descriptions, so that the descriptions of unavailable tools are marked last.
+type CapabilityMiddleware struct {
+	mode       Mode
+	lookup     RoleLookup
+	tokenStore tokenstore.TokenStore
+	toolDefs   []types.ToolDefinition
+
+	mu sync.Mutex
+	// principal is the principal whose roles were looked up, or empty if none were
+	principal   string
+	unavailable map[string]string
+}
+
+// NewCapabilityMiddleware creates middleware that judges the tools of toolDefs by the roles of the principal of
+// the session in the token store, looked up with lookup.
+func NewCapabilityMiddleware(mode Mode, lookup RoleLookup, tokenStore tokenstore.TokenStore, toolDefs []types.ToolDefinition) *CapabilityMiddleware {
+	return &CapabilityMiddleware{
+		mode:        mode,
+		lookup:      lookup,
+		tokenStore:  tokenStore,
+		toolDefs:    toolDefs,
+		unavailable: map[string]string{},
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *CapabilityMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if m.mode == ModeOff || m.mode == "" {
+			return next(ctx, method, req)
+		}
+
+		switch method {
+		case "tools/call":
+			// The first call may log in, so the roles are looked up once it has run
+			result, err := next(ctx, method, req)
+			m.negotiate(ctx)
+			return result, err
+		case "tools/list":
+			m.negotiate(ctx)
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+			listResult, ok := result.(*mcp.ListToolsResult)
+			if !ok {
+				return result, err
+			}
+			return m.apply(listResult), nil
+		}
+
+		return next(ctx, method, req)
+	}
+}
+
+// negotiate looks up the roles of the principal of the current session, unless they have already been looked up
+func (m *CapabilityMiddleware) negotiate(ctx context.Context) {
+	hasSession, err := m.tokenStore.HasSession()
+	if err != nil || !hasSession {
+		return
+	}
+	session, err := m.tokenStore.GetSession()
+	if err != nil || session == nil || !session.Expiry.After(time.Now()) {
+		return
+	}
+	// Tokens that are not JWTs, such as those of the mock backend, have no principal to look up
+	claims, err := auth.ParseAccessTokenClaims(session.AccessToken)
+	if err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	principal := claims.Principal()
+	if principal == "" || principal == m.principal {
+		return
+	}
+	// The lookup is only tried once per principal, as principals that may not read their roles never will
+	m.principal = principal
+	m.unavailable = map[string]string{}
+
+	subjectType, subjectId := roles.SubjectTypeApplication, claims.ClientId
+	if claims.Subject != "" && claims.Subject != claims.ClientId {
+		subjectType, subjectId = roles.SubjectTypeUser, claims.Subject
+	}
+	environmentId, err := uuid.Parse(claims.EnvironmentId)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to look up the roles of the authenticated principal, all tools are listed",
+			slog.String("error", fmt.Sprintf("invalid environment ID %q in access token", claims.EnvironmentId)))
+		return
+	}
+	roleNames, err := m.lookup(ctx, subjectType, environmentId, subjectId)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to look up the roles of the authenticated principal, all tools are listed",
+			slog.String("error", err.Error()))
+		return
+	}
+
+	m.unavailable = UnavailableTools(roleNames, m.toolDefs)
+	logger.FromContext(ctx).Info("Tool capabilities of the authenticated principal looked up",
+		slog.String("subjectType", subjectType),
+		slog.Int("roleCount", len(roleNames)),
+		slog.Int("unavailableToolCount", len(m.unavailable)),
+		slog.String("mode", string(m.mode)))
+}
+
+// apply marks or hides the unavailable tools of the listed tools. The tools are copied rather than changed, as
+// the listed tools are shared with the server's tool registry.
+func (m *CapabilityMiddleware) apply(listResult *mcp.ListToolsResult) *mcp.ListToolsResult {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.unavailable) == 0 {
+		return listResult
+	}
+
+	applied := *listResult
+	applied.Tools = make([]*mcp.Tool, 0, len(listResult.Tools))
+	for _, tool := range listResult.Tools {
+		reason, ok := m.unavailable[tool.Name]
+		switch {
+		case !ok:
+			applied.Tools = append(applied.Tools, tool)
+		case m.mode == ModeMark:
+			marked := *tool
+			marked.Description = fmt.Sprintf("%s\n\nUNAVAILABLE: the authenticated PingOne principal cannot use this tool, as %s. Calls are expected to be denied by PingOne.", tool.Description, reason)
+			applied.Tools = append(applied.Tools, &marked)
+		}
+	}
+	return &applied
+}
@@ -0,0 +1,151 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package capabilities_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/capabilities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEnvironmentId = "550e8400-e29b-41d4-a716-446655440000"
+
+func testAccessToken(claims string) string {
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func putSession(t *testing.T, tokenStore *testutils.InMemoryTokenStore, claims string) {
+	t.Helper()
+	require.NoError(t, tokenStore.PutSession(auth.AuthSession{
+		SessionId:   "session-1",
+		AccessToken: testAccessToken(claims),
+		Expiry:      time.Now().Add(time.Hour),
+	}))
+}
+
+// lookupRecorder returns the roles of each subject, recording the lookups
+type lookupRecorder struct {
+	roles   map[string][]string
+	err     error
+	lookups []string
+}
+
+func (l *lookupRecorder) lookup(ctx context.Context, subjectType string, environmentId uuid.UUID, subjectId string) ([]string, error) {
+	l.lookups = append(l.lookups, subjectType+" "+environmentId.String()+" "+subjectId)
+	return l.roles[subjectId], l.err
+}
+
+func newCapabilityServer(t *testing.T, mode capabilities.Mode, lookup capabilities.RoleLookup, tokenStore *testutils.InMemoryTokenStore) *mcp.ClientSession {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(capabilities.NewCapabilityMiddleware(mode, lookup, tokenStore, testToolDefs).Handler)
+	for _, toolDef := range testToolDefs {
+		mcp.AddTool(server, toolDef.McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+			return nil, map[string]any{"ok": true}, nil
+		})
+	}
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func listTools(t *testing.T, session *mcp.ClientSession) map[string]*mcp.Tool {
+	t.Helper()
+	result, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	tools := map[string]*mcp.Tool{}
+	for _, tool := range result.Tools {
+		tools[tool.Name] = tool
+	}
+	return tools
+}
+
+func TestCapabilityMiddleware_MarksUnavailableTools(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	putSession(t, tokenStore, `{"sub":"user-1","client_id":"client-1","env":"`+testEnvironmentId+`"}`)
+	recorder := &lookupRecorder{roles: map[string][]string{"user-1": {"Environment Admin"}}}
+
+	session := newCapabilityServer(t, capabilities.ModeMark, recorder.lookup, tokenStore)
+	tools := listTools(t, session)
+
+	require.Len(t, tools, 3)
+	assert.Equal(t, "Create an environment\n\nUNAVAILABLE: the authenticated PingOne principal cannot use this tool, as it requires the Organization Admin role, which the principal is not assigned. Calls are expected to be denied by PingOne.", tools["create_environment"].Description)
+	assert.Equal(t, "Update a thing", tools["update_thing"].Description)
+
+	// The roles are only looked up once for the principal
+	listTools(t, session)
+	assert.Equal(t, []string{"USER " + testEnvironmentId + " user-1"}, recorder.lookups)
+}
+
+func TestCapabilityMiddleware_HidesUnavailableTools(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	putSession(t, tokenStore, `{"sub":"client-1","client_id":"client-1","env":"`+testEnvironmentId+`"}`)
+	recorder := &lookupRecorder{roles: map[string][]string{"client-1": {"Identity Data Read Only"}}}
+
+	session := newCapabilityServer(t, capabilities.ModeHide, recorder.lookup, tokenStore)
+	tools := listTools(t, session)
+
+	assert.Len(t, tools, 1)
+	assert.Contains(t, tools, "list_things")
+	assert.Equal(t, []string{"APPLICATION " + testEnvironmentId + " client-1"}, recorder.lookups)
+}
+
+func TestCapabilityMiddleware_LooksUpRolesAfterLogin(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	recorder := &lookupRecorder{roles: map[string][]string{"user-1": {"Environment Admin"}, "user-2": {"Organization Admin"}}}
+
+	session := newCapabilityServer(t, capabilities.ModeHide, recorder.lookup, tokenStore)
+	assert.Len(t, listTools(t, session), 3, "all tools are listed before the first login")
+
+	// The first call logs in
+	putSession(t, tokenStore, `{"sub":"user-1","env":"`+testEnvironmentId+`"}`)
+	_, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_things", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.NotContains(t, listTools(t, session), "create_environment")
+
+	// Another principal logs in, such as after switching profile
+	putSession(t, tokenStore, `{"sub":"user-2","env":"`+testEnvironmentId+`"}`)
+	assert.Contains(t, listTools(t, session), "create_environment")
+	assert.Len(t, recorder.lookups, 2)
+}
+
+func TestCapabilityMiddleware_LookupFails(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	putSession(t, tokenStore, `{"sub":"user-1","env":"`+testEnvironmentId+`"}`)
+	recorder := &lookupRecorder{err: errors.New("forbidden")}
+
+	session := newCapabilityServer(t, capabilities.ModeHide, recorder.lookup, tokenStore)
+
+	assert.Len(t, listTools(t, session), 3)
+	assert.Len(t, listTools(t, session), 3)
+	assert.Len(t, recorder.lookups, 1, "the lookup is not retried for the principal")
+}
+
+func TestCapabilityMiddleware_Off(t *testing.T) {
+	tokenStore := testutils.NewInMemoryTokenStore()
+	putSession(t, tokenStore, `{"sub":"user-1","env":"`+testEnvironmentId+`"}`)
+	recorder := &lookupRecorder{roles: map[string][]string{"user-1": {"Identity Data Read Only"}}}
+
+	session := newCapabilityServer(t, capabilities.ModeOff, recorder.lookup, tokenStore)
+
+	assert.Len(t, listTools(t, session), 3)
+	assert.Empty(t, recorder.lookups)
+}
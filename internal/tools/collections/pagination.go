@@ -0,0 +1,74 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package collections
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	authorizeapi "github.com/patrickcping/pingone-go-sdk-v2/authorize"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// ForEachPage calls visit with each page of a management API list until visit returns false
+func ForEachPage(ctx context.Context, iterator management.EntityArrayPagedIterator, visit func(embedded *management.EntityArrayEmbedded) bool) error {
+	return forEachPage(ctx, iterator, func(cursor management.PagedCursor) (*http.Response, bool, *management.EntityArrayEmbedded) {
+		if cursor.EntityArray == nil {
+			return cursor.HTTPResponse, false, nil
+		}
+		return cursor.HTTPResponse, true, cursor.EntityArray.Embedded
+	}, visit)
+}
+
+// ForEachCredentialsPage calls visit with each page of a credentials API list until visit returns false
+func ForEachCredentialsPage(ctx context.Context, iterator credentialsapi.EntityArrayPagedIterator, visit func(embedded *credentialsapi.EntityArrayEmbedded) bool) error {
+	return forEachPage(ctx, iterator, func(cursor credentialsapi.PagedCursor) (*http.Response, bool, *credentialsapi.EntityArrayEmbedded) {
+		if cursor.EntityArray == nil {
+			return cursor.HTTPResponse, false, nil
+		}
+		return cursor.HTTPResponse, true, cursor.EntityArray.Embedded
+	}, visit)
+}
+
+// ForEachAuthorizePage calls visit with each page of an authorize API list until visit returns false
+func ForEachAuthorizePage(ctx context.Context, iterator authorizeapi.EntityArrayPagedIterator, visit func(embedded *authorizeapi.EntityArrayEmbedded) bool) error {
+	return forEachPage(ctx, iterator, func(cursor authorizeapi.PagedCursor) (*http.Response, bool, *authorizeapi.EntityArrayEmbedded) {
+		if cursor.EntityArray == nil {
+			return cursor.HTTPResponse, false, nil
+		}
+		return cursor.HTTPResponse, true, cursor.EntityArray.Embedded
+	}, visit)
+}
+
+// forEachPage implements the paging of the SDKs, which each define their own cursor and page types. The page
+// function returns the HTTP response of a cursor, whether it has an entity array, and the embedded page.
+//
+// A page without embedded data, such as the memberships of a group that is a member of no group, is skipped.
+func forEachPage[Cursor, Embedded any](ctx context.Context, iterator func(yield func(Cursor, error) bool), page func(Cursor) (*http.Response, bool, *Embedded), visit func(embedded *Embedded) bool) error {
+	for next, err := range iterator {
+		httpResponse, hasData, embedded := page(next)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return apiErr
+		}
+		if !hasData {
+			// This should never happen, err should be set if no data
+			apiErr := errs.NewApiError(httpResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return apiErr
+		}
+		if embedded == nil {
+			continue
+		}
+		if !visit(embedded) {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package collections_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pages(cursors ...management.PagedCursor) management.EntityArrayPagedIterator {
+	return func(yield func(management.PagedCursor, error) bool) {
+		for _, cursor := range cursors {
+			if !yield(cursor, nil) {
+				return
+			}
+		}
+	}
+}
+
+func groupsPage(names ...string) management.PagedCursor {
+	embedded := &management.EntityArrayEmbedded{}
+	for _, name := range names {
+		embedded.Groups = append(embedded.Groups, management.Group{Name: name})
+	}
+	return management.PagedCursor{EntityArray: &management.EntityArray{Embedded: embedded}}
+}
+
+func TestForEachPage_VisitsEveryPage(t *testing.T) {
+	var names []string
+	err := collections.ForEachPage(context.Background(), pages(groupsPage("a", "b"), groupsPage("c")), func(embedded *management.EntityArrayEmbedded) bool {
+		for _, group := range embedded.Groups {
+			names = append(names, group.Name)
+		}
+		return true
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+}
+
+func TestForEachPage_SkipsPagesWithoutEmbeddedData(t *testing.T) {
+	visits := 0
+	err := collections.ForEachPage(context.Background(), pages(management.PagedCursor{EntityArray: &management.EntityArray{}}), func(embedded *management.EntityArrayEmbedded) bool {
+		visits++
+		return true
+	})
+
+	require.NoError(t, err)
+	assert.Zero(t, visits)
+}
+
+func TestForEachPage_StopsWhenVisitReturnsFalse(t *testing.T) {
+	visits := 0
+	err := collections.ForEachPage(context.Background(), pages(groupsPage("a"), groupsPage("b")), func(embedded *management.EntityArrayEmbedded) bool {
+		visits++
+		return false
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, visits)
+}
+
+func TestForEachPage_NoData(t *testing.T) {
+	err := collections.ForEachPage(context.Background(), pages(management.PagedCursor{}), func(embedded *management.EntityArrayEmbedded) bool {
+		return true
+	})
+
+	assert.ErrorContains(t, err, "no data in response")
+}
+
+func TestForEachPage_Error(t *testing.T) {
+	iterator := func(yield func(management.PagedCursor, error) bool) {
+		yield(management.PagedCursor{}, errors.New("request failed"))
+	}
+
+	err := collections.ForEachPage(context.Background(), iterator, func(embedded *management.EntityArrayEmbedded) bool {
+		return true
+	})
+
+	assert.ErrorContains(t, err, "request failed")
+}
@@ -0,0 +1,80 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// LimitReachedError is returned when a tool is called while the maximum number of its calls are already running.
+type LimitReachedError struct {
+	ToolName string
+	Limit    int
+}
+
+func (e *LimitReachedError) Error() string {
+	return fmt.Sprintf("tool '%s' is limited to %d concurrent call(s) and that many are already running; wait for them to complete before calling it again", e.ToolName, e.Limit)
+}
+
+// ConcurrencyLimitMiddleware enforces the MaxConcurrentExecutions declared by tool definitions, so that
+// an agent cannot start more parallel heavy operations against the tenant than the tool allows.
+//
+// Calls beyond the limit are rejected immediately rather than queued, so that the agent is told to wait
+// for its running calls instead of silently stacking further work behind them.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+// Only tools that declare a limit are affected.
+type ConcurrencyLimitMiddleware struct {
+	slots map[string]chan struct{}
+}
+
+// NewConcurrencyLimitMiddleware creates middleware for the tools in the list that declare a concurrency limit.
+func NewConcurrencyLimitMiddleware(tools []types.ToolDefinition) *ConcurrencyLimitMiddleware {
+	m := &ConcurrencyLimitMiddleware{
+		slots: make(map[string]chan struct{}),
+	}
+	for _, tool := range tools {
+		if tool.McpTool == nil || tool.MaxConcurrentExecutions <= 0 {
+			continue
+		}
+		m.slots[tool.McpTool.Name] = make(chan struct{}, tool.MaxConcurrentExecutions)
+	}
+	return m
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ConcurrencyLimitMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		toolName := callToolReq.Params.Name
+		slots, ok := m.slots[toolName]
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		default:
+			logger.FromContext(ctx).Warn("Rejected tool call over concurrency limit",
+				slog.String("tool", toolName),
+				slog.Int("limit", cap(slots)))
+			return nil, fmt.Errorf("concurrency limit reached: %w", &LimitReachedError{ToolName: toolName, Limit: cap(slots)})
+		}
+
+		return next(ctx, method, req)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package concurrency_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/concurrency"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "clone_environment"}, MaxConcurrentExecutions: 1},
+	{McpTool: &mcp.Tool{Name: "bulk_create_things"}, MaxConcurrentExecutions: 2},
+	{McpTool: &mcp.Tool{Name: "list_things"}},
+}
+
+func callToolRequest(toolName string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: toolName},
+	}
+}
+
+// blockingHandler holds every call until released, signalling each call as it starts
+type blockingHandler struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (h *blockingHandler) next(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+	h.started <- struct{}{}
+	<-h.release
+	return &mcp.CallToolResult{}, nil
+}
+
+// startCalls starts the calls in the background and waits for them to reach the tool
+func startCalls(t *testing.T, handler mcp.MethodHandler, blocking *blockingHandler, toolName string, count int) *sync.WaitGroup {
+	t.Helper()
+	var wg sync.WaitGroup
+	for range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := handler(context.Background(), "tools/call", callToolRequest(toolName))
+			assert.NoError(t, err)
+		}()
+		<-blocking.started
+	}
+	return &wg
+}
+
+func TestConcurrencyLimitMiddleware_RejectsCallsOverLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolName string
+		limit    int
+	}{
+		{name: "single execution", toolName: "clone_environment", limit: 1},
+		{name: "multiple executions", toolName: "bulk_create_things", limit: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocking := newBlockingHandler()
+			handler := concurrency.NewConcurrencyLimitMiddleware(testToolDefs).Handler(blocking.next)
+			running := startCalls(t, handler, blocking, tt.toolName, tt.limit)
+
+			_, err := handler(context.Background(), "tools/call", callToolRequest(tt.toolName))
+
+			var limitErr *concurrency.LimitReachedError
+			require.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, tt.toolName, limitErr.ToolName)
+			assert.Equal(t, tt.limit, limitErr.Limit)
+			assert.ErrorContains(t, err, "wait for them to complete")
+
+			close(blocking.release)
+			running.Wait()
+		})
+	}
+}
+
+func TestConcurrencyLimitMiddleware_ReleasesSlotWhenCallCompletes(t *testing.T) {
+	blocking := newBlockingHandler()
+	handler := concurrency.NewConcurrencyLimitMiddleware(testToolDefs).Handler(blocking.next)
+	running := startCalls(t, handler, blocking, "clone_environment", 1)
+
+	close(blocking.release)
+	running.Wait()
+
+	_, err := handler(context.Background(), "tools/call", callToolRequest("clone_environment"))
+	assert.NoError(t, err)
+}
+
+func TestConcurrencyLimitMiddleware_ReleasesSlotWhenCallFails(t *testing.T) {
+	failing := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return nil, assert.AnError
+	}
+	handler := concurrency.NewConcurrencyLimitMiddleware(testToolDefs).Handler(failing)
+
+	for range 3 {
+		_, err := handler(context.Background(), "tools/call", callToolRequest("clone_environment"))
+		assert.ErrorIs(t, err, assert.AnError)
+	}
+}
+
+func TestConcurrencyLimitMiddleware_LimitsAreIndependentPerTool(t *testing.T) {
+	blocking := newBlockingHandler()
+	handler := concurrency.NewConcurrencyLimitMiddleware(testToolDefs).Handler(blocking.next)
+	running := startCalls(t, handler, blocking, "clone_environment", 1)
+
+	// Another limited tool and an unlimited tool still run while clone_environment is at its limit
+	more := startCalls(t, handler, blocking, "bulk_create_things", 1)
+	unlimited := startCalls(t, handler, blocking, "list_things", 5)
+
+	close(blocking.release)
+	running.Wait()
+	more.Wait()
+	unlimited.Wait()
+}
+
+func TestConcurrencyLimitMiddleware_IgnoresOtherMethods(t *testing.T) {
+	nextCalls := 0
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalls++
+		return nil, nil
+	}
+	handler := concurrency.NewConcurrencyLimitMiddleware(testToolDefs).Handler(next)
+
+	_, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, nextCalls)
+}
@@ -0,0 +1,234 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package confirmation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	environmentIdArgument = "environmentId"
+	confirmProperty       = "confirm"
+
+	// maxArgumentsLength limits the arguments shown in the confirmation prompt, so that long arguments
+	// such as lists of users do not hide the target
+	maxArgumentsLength = 1000
+)
+
+// ErrElicitationNotSupported is returned for calls of tools that require confirmation from clients that
+// cannot ask the user for it.
+var ErrElicitationNotSupported = errors.New("the MCP client does not support elicitation, which is needed to ask the user to confirm destructive tools")
+
+// NotApprovedError is returned when the user does not approve a tool call.
+type NotApprovedError struct {
+	ToolName string
+	// Action is the user's response to the confirmation prompt: accept, decline or cancel
+	Action string
+}
+
+func (e *NotApprovedError) Error() string {
+	return fmt.Sprintf("the user did not approve the call of %s (%s), so it was not run", e.ToolName, e.Action)
+}
+
+// Target is the PingOne environment that a tool call acts on, as shown to the user.
+type Target struct {
+	Id   string
+	Name string
+	Type string
+}
+
+func (t Target) String() string {
+	if t.Name == "" {
+		return fmt.Sprintf("environment %s", t.Id)
+	}
+	return fmt.Sprintf("environment '%s' (ID: %s, type: %s)", t.Name, t.Id, t.Type)
+}
+
+// ConfirmationMiddleware asks the user to approve each call of a tool whose definition sets RequiresConfirmation,
+// using MCP elicitation, before the call runs. The prompt describes the tool, the environment it acts on and
+// its other arguments. Calls are only run if the user accepts and confirms the prompt. Tools that set a
+// ConfirmationArgument are only confirmed when called with it.
+//
+// Calls from clients that do not support elicitation are rejected, as destructive tools must not run without
+// the user's approval.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after environment validation,
+// so that the user is not asked to approve calls that would be rejected anyway, and before concurrency limits,
+// so that calls waiting for the user do not hold up other calls.
+type ConfirmationMiddleware struct {
+	tools        map[string]*types.ToolDefinition
+	environments environments.EnvironmentsClientFactory
+}
+
+// NewConfirmationMiddleware creates middleware that asks for confirmation of the tools that require it.
+// Environments are looked up with the client factory to describe the target of each call.
+func NewConfirmationMiddleware(tools []types.ToolDefinition, environmentsFactory environments.EnvironmentsClientFactory) *ConfirmationMiddleware {
+	m := &ConfirmationMiddleware{
+		tools:        map[string]*types.ToolDefinition{},
+		environments: environmentsFactory,
+	}
+	for i := range tools {
+		if tools[i].RequiresConfirmation {
+			m.tools[tools[i].McpTool.Name] = &tools[i]
+		}
+	}
+	return m
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ConfirmationMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		toolDef, ok := m.tools[callToolReq.Params.Name]
+		if !ok {
+			return next(ctx, method, req)
+		}
+		if toolDef.ConfirmationArgument != "" && !hasArgument(callToolReq.Params.Arguments, toolDef.ConfirmationArgument) {
+			// A preview, which changes nothing
+			return next(ctx, method, req)
+		}
+
+		if err := m.confirm(ctx, callToolReq, toolDef); err != nil {
+			return nil, fmt.Errorf("confirmation failed: %w", err)
+		}
+
+		return next(ctx, method, req)
+	}
+}
+
+func (m *ConfirmationMiddleware) confirm(ctx context.Context, req *mcp.CallToolRequest, toolDef *types.ToolDefinition) error {
+	toolName := toolDef.McpTool.Name
+	if req.Session == nil || !supportsElicitation(req.Session.InitializeParams()) {
+		logger.FromContext(ctx).Warn("Tool requires confirmation, but the client does not support elicitation",
+			slog.String("tool", toolName))
+		return ErrElicitationNotSupported
+	}
+
+	result, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Message:         m.prompt(ctx, toolDef, req.Params.Arguments),
+		RequestedSchema: confirmSchema(toolName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ask the user for confirmation: %w", err)
+	}
+
+	if result.Action != "accept" || result.Content[confirmProperty] != true {
+		logger.FromContext(ctx).Info("Tool call not approved by the user",
+			slog.String("tool", toolName),
+			slog.String("action", result.Action))
+		return &NotApprovedError{ToolName: toolName, Action: result.Action}
+	}
+
+	logger.FromContext(ctx).Info("Tool call approved by the user", slog.String("tool", toolName))
+	return nil
+}
+
+// prompt describes the call to the user
+func (m *ConfirmationMiddleware) prompt(ctx context.Context, toolDef *types.ToolDefinition, argsJSON json.RawMessage) string {
+	var args map[string]any
+	_ = json.Unmarshal(argsJSON, &args)
+
+	var prompt strings.Builder
+	title := toolDef.McpTool.Title
+	if title == "" {
+		title = toolDef.McpTool.Name
+	}
+	// Tools that are not destructive are only confirmed because the server's tool policy requires it
+	if annotations := toolDef.McpTool.Annotations; annotations != nil && annotations.DestructiveHint != nil && *annotations.DestructiveHint {
+		fmt.Fprintf(&prompt, "The assistant wants to run '%s' (%s), which is destructive and may not be reversible.", title, toolDef.McpTool.Name)
+	} else {
+		fmt.Fprintf(&prompt, "The assistant wants to run '%s' (%s), which requires your approval.", title, toolDef.McpTool.Name)
+	}
+
+	if environmentId, ok := args[environmentIdArgument].(string); ok {
+		fmt.Fprintf(&prompt, "\n\nTarget: %s", m.describeEnvironment(ctx, environmentId))
+		delete(args, environmentIdArgument)
+	}
+
+	if len(args) > 0 {
+		argumentsJSON, err := json.Marshal(args)
+		if err == nil {
+			arguments := string(argumentsJSON)
+			if len(arguments) > maxArgumentsLength {
+				arguments = arguments[:maxArgumentsLength] + "..."
+			}
+			fmt.Fprintf(&prompt, "\n\nArguments: %s", arguments)
+		}
+	}
+
+	return prompt.String()
+}
+
+// describeEnvironment looks up the environment, falling back to its ID if it cannot be looked up
+func (m *ConfirmationMiddleware) describeEnvironment(ctx context.Context, environmentId string) Target {
+	target := Target{Id: environmentId}
+
+	id, err := uuid.Parse(environmentId)
+	if err != nil {
+		return target
+	}
+	client, err := m.environments.GetAuthenticatedClient(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Debug("Failed to get client to describe confirmation target", slog.String("error", err.Error()))
+		return target
+	}
+	env, httpResponse, err := client.GetEnvironment(ctx, id)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil || env == nil {
+		logger.FromContext(ctx).Debug("Failed to look up confirmation target environment", slog.String("environmentId", environmentId))
+		return target
+	}
+
+	target.Name = env.Name
+	target.Type = string(env.Type)
+	return target
+}
+
+// hasArgument returns true if the arguments set the named argument to a value other than null or an empty string
+func hasArgument(argsJSON json.RawMessage, name string) bool {
+	var args map[string]any
+	if err := json.Unmarshal(argsJSON, &args); err != nil {
+		return false
+	}
+	value, ok := args[name]
+	return ok && value != nil && value != ""
+}
+
+func supportsElicitation(params *mcp.InitializeParams) bool {
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// confirmSchema asks the user to tick a confirm box. The box is not required by the schema, as the MCP SDK
+// validates the content of declined and cancelled elicitations too, but calls are only run if it is ticked.
+func confirmSchema(toolName string) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			confirmProperty: {
+				Type:        "boolean",
+				Title:       "Run " + toolName,
+				Description: "Confirm that the tool should run on the target described above",
+			},
+		},
+	}
+}
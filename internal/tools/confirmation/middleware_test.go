@@ -0,0 +1,202 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package confirmation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/confirmation"
+	envtestutils "github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testEnvironmentId = uuid.MustParse("11111111-2222-3333-4444-555555555555")
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "delete_thing", Title: "Delete Thing"}, RequiresConfirmation: true},
+	{McpTool: &mcp.Tool{Name: "list_things"}},
+	{McpTool: &mcp.Tool{Name: "bulk_delete_things"}, RequiresConfirmation: true, ConfirmationArgument: "confirmationToken"},
+	{McpTool: &mcp.Tool{Name: "purge_thing", Annotations: &mcp.ToolAnnotations{DestructiveHint: &destructive}}, RequiresConfirmation: true},
+}
+
+var destructive = true
+
+type testServer struct {
+	session   *mcp.ClientSession
+	calls     map[string]int
+	elicitReq *mcp.ElicitParams
+}
+
+// newTestServer serves the test tools behind the confirmation middleware to a client that answers
+// elicitation requests with the result, or a client without elicitation support if the result is nil
+func newTestServer(t *testing.T, environmentsFactory *envtestutils.MockEnvironmentsClientFactory, elicitResult *mcp.ElicitResult) *testServer {
+	t.Helper()
+	ts := &testServer{calls: map[string]int{}}
+
+	server := mcptestutils.TestMcpServer(t)
+	for _, toolDef := range testToolDefs {
+		name := toolDef.McpTool.Name
+		mcp.AddTool(server, &mcp.Tool{Name: name}, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+			ts.calls[name]++
+			return nil, map[string]any{"ok": true}, nil
+		})
+	}
+	server.AddReceivingMiddleware(confirmation.NewConfirmationMiddleware(testToolDefs, environmentsFactory).Handler)
+
+	clientOptions := &mcp.ClientOptions{}
+	if elicitResult != nil {
+		clientOptions.ElicitationHandler = func(ctx context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			ts.elicitReq = req.Params
+			return elicitResult, nil
+		}
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1-test"}, clientOptions)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	ts.session, err = client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ts.session.Close() })
+	return ts
+}
+
+func sandboxEnvironmentsFactory() *envtestutils.MockEnvironmentsClientFactory {
+	client := &envtestutils.MockEnvironmentsClient{}
+	client.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(&pingone.EnvironmentResponse{
+		Name: "Development",
+		Type: pingone.ENVIRONMENTTYPEVALUE_SANDBOX,
+	}, nil, nil)
+	return envtestutils.NewMockEnvironmentsClientFactory(client, nil)
+}
+
+func callTool(t *testing.T, ts *testServer, name string) error {
+	t.Helper()
+	_, err := ts.session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name: name,
+		Arguments: map[string]any{
+			"environmentId": testEnvironmentId.String(),
+			"reason":        "no longer needed",
+		},
+	})
+	return err
+}
+
+func TestConfirmationMiddleware_Approved(t *testing.T) {
+	ts := newTestServer(t, sandboxEnvironmentsFactory(), &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}})
+
+	require.NoError(t, callTool(t, ts, "delete_thing"))
+
+	assert.Equal(t, 1, ts.calls["delete_thing"])
+	require.NotNil(t, ts.elicitReq)
+	assert.Contains(t, ts.elicitReq.Message, "'Delete Thing' (delete_thing)")
+	assert.Contains(t, ts.elicitReq.Message, "Target: environment 'Development' (ID: "+testEnvironmentId.String()+", type: SANDBOX)")
+	assert.Contains(t, ts.elicitReq.Message, `Arguments: {"reason":"no longer needed"}`)
+}
+
+func TestConfirmationMiddleware_PromptDescribesDestructiveTools(t *testing.T) {
+	ts := newTestServer(t, sandboxEnvironmentsFactory(), &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}})
+
+	require.NoError(t, callTool(t, ts, "purge_thing"))
+	require.NotNil(t, ts.elicitReq)
+	assert.Contains(t, ts.elicitReq.Message, "which is destructive and may not be reversible")
+
+	// Tools that are not destructive are confirmed when the tool policy requires it
+	require.NoError(t, callTool(t, ts, "delete_thing"))
+	assert.Contains(t, ts.elicitReq.Message, "which requires your approval")
+}
+
+func TestConfirmationMiddleware_NotApproved(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *mcp.ElicitResult
+		action string
+	}{
+		{name: "declined", result: &mcp.ElicitResult{Action: "decline"}, action: "decline"},
+		{name: "cancelled", result: &mcp.ElicitResult{Action: "cancel"}, action: "cancel"},
+		{name: "accepted without confirming", result: &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": false}}, action: "accept"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, sandboxEnvironmentsFactory(), tt.result)
+
+			err := callTool(t, ts, "delete_thing")
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "confirmation failed: the user did not approve the call of delete_thing ("+tt.action+"), so it was not run")
+			assert.Zero(t, ts.calls["delete_thing"])
+		})
+	}
+}
+
+func TestConfirmationMiddleware_ElicitationNotSupported(t *testing.T) {
+	ts := newTestServer(t, sandboxEnvironmentsFactory(), nil)
+
+	err := callTool(t, ts, "delete_thing")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), confirmation.ErrElicitationNotSupported.Error())
+	assert.Zero(t, ts.calls["delete_thing"])
+}
+
+func TestConfirmationMiddleware_EnvironmentLookupFails(t *testing.T) {
+	ts := newTestServer(t, envtestutils.NewMockEnvironmentsClientFactory(nil, errors.New("not logged in")), &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}})
+
+	require.NoError(t, callTool(t, ts, "delete_thing"))
+
+	assert.Equal(t, 1, ts.calls["delete_thing"])
+	assert.Contains(t, ts.elicitReq.Message, "Target: environment "+testEnvironmentId.String())
+}
+
+func TestConfirmationMiddleware_ToolsWithoutConfirmation(t *testing.T) {
+	ts := newTestServer(t, sandboxEnvironmentsFactory(), nil)
+
+	require.NoError(t, callTool(t, ts, "list_things"))
+
+	assert.Equal(t, 1, ts.calls["list_things"])
+}
+
+func TestConfirmationMiddleware_ConfirmationArgument(t *testing.T) {
+	ts := newTestServer(t, sandboxEnvironmentsFactory(), &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}})
+
+	for _, token := range []any{nil, ""} {
+		_, err := ts.session.CallTool(t.Context(), &mcp.CallToolParams{
+			Name:      "bulk_delete_things",
+			Arguments: map[string]any{"environmentId": testEnvironmentId.String(), "confirmationToken": token},
+		})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, ts.calls["bulk_delete_things"])
+	assert.Nil(t, ts.elicitReq, "calls without the confirmation argument should not be confirmed")
+
+	_, err := ts.session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "bulk_delete_things",
+		Arguments: map[string]any{"environmentId": testEnvironmentId.String(), "confirmationToken": "abc123"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, ts.calls["bulk_delete_things"])
+	require.NotNil(t, ts.elicitReq)
+	assert.Contains(t, ts.elicitReq.Message, `Arguments: {"confirmationToken":"abc123"}`)
+}
+
+func TestConfirmationMiddleware_ConfirmationArgumentNotApproved(t *testing.T) {
+	ts := newTestServer(t, sandboxEnvironmentsFactory(), &mcp.ElicitResult{Action: "decline"})
+
+	_, err := ts.session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "bulk_delete_things",
+		Arguments: map[string]any{"environmentId": testEnvironmentId.String(), "confirmationToken": "abc123"},
+	})
+
+	require.Error(t, err)
+	assert.Zero(t, ts.calls["bulk_delete_things"])
+}
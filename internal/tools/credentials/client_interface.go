@@ -0,0 +1,26 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+)
+
+type CredentialsClient interface {
+	GetCredentialTypes(ctx context.Context, environmentId uuid.UUID) (credentialsapi.EntityArrayPagedIterator, error)
+	GetCredentialType(ctx context.Context, environmentId uuid.UUID, credentialTypeId uuid.UUID) (*credentialsapi.CredentialType, *http.Response, error)
+	CreateCredentialType(ctx context.Context, environmentId uuid.UUID, createRequest credentialsapi.CredentialType) (*credentialsapi.CredentialType, *http.Response, error)
+	UpdateCredentialType(ctx context.Context, environmentId uuid.UUID, credentialTypeId uuid.UUID, updateRequest credentialsapi.CredentialType) (*credentialsapi.CredentialType, *http.Response, error)
+	GetCredentialIssuerProfile(ctx context.Context, environmentId uuid.UUID) (*credentialsapi.CredentialIssuerProfile, *http.Response, error)
+	GetUserCredentials(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID) (credentialsapi.EntityArrayPagedIterator, error)
+	GetUserCredential(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID, credentialId uuid.UUID) (*credentialsapi.UserCredential, *http.Response, error)
+	UpdateUserCredential(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID, credentialId uuid.UUID, updateRequest credentialsapi.UserCredential) (*credentialsapi.UserCredential, *http.Response, error)
+}
+
+type CredentialsClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (CredentialsClient, error)
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ CredentialsClient = &PingOneClientCredentialsWrapper{}
+var _ CredentialsClientFactory = &PingOneClientCredentialsWrapperFactory{}
+
+type PingOneClientCredentialsWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientCredentialsWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientCredentialsWrapper(client *pingone.Client) *PingOneClientCredentialsWrapper {
+	return &PingOneClientCredentialsWrapper{client: client}
+}
+
+func NewPingOneClientCredentialsWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientCredentialsWrapperFactory {
+	return &PingOneClientCredentialsWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientCredentialsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (CredentialsClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientCredentialsWrapper(client), nil
+}
+
+func (p *PingOneClientCredentialsWrapper) GetCredentialTypes(ctx context.Context, environmentId uuid.UUID) (credentialsapi.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.CredentialsAPIClient.CredentialTypesApi.ReadAllCredentialTypes(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve credential types",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientCredentialsWrapper) GetCredentialType(ctx context.Context, environmentId uuid.UUID, credentialTypeId uuid.UUID) (*credentialsapi.CredentialType, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.CredentialsAPIClient.CredentialTypesApi.ReadOneCredentialType(ctx, environmentId.String(), credentialTypeId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve credential type by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("credentialTypeId", credentialTypeId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientCredentialsWrapper) CreateCredentialType(ctx context.Context, environmentId uuid.UUID, createRequest credentialsapi.CredentialType) (*credentialsapi.CredentialType, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.CredentialsAPIClient.CredentialTypesApi.CreateCredentialType(ctx, environmentId.String()).CredentialType(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create credential type",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("title", createRequest.Title),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientCredentialsWrapper) UpdateCredentialType(ctx context.Context, environmentId uuid.UUID, credentialTypeId uuid.UUID, updateRequest credentialsapi.CredentialType) (*credentialsapi.CredentialType, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.CredentialsAPIClient.CredentialTypesApi.UpdateCredentialType(ctx, environmentId.String(), credentialTypeId.String()).CredentialType(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update credential type",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("credentialTypeId", credentialTypeId.String()),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientCredentialsWrapper) GetCredentialIssuerProfile(ctx context.Context, environmentId uuid.UUID) (*credentialsapi.CredentialIssuerProfile, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.CredentialsAPIClient.CredentialIssuersApi.ReadCredentialIssuerProfile(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve credential issuer profile",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientCredentialsWrapper) GetUserCredentials(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID) (credentialsapi.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.CredentialsAPIClient.UserCredentialsApi.ReadAllUserCredentials(ctx, environmentId.String(), userId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve user credentials",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientCredentialsWrapper) GetUserCredential(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID, credentialId uuid.UUID) (*credentialsapi.UserCredential, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.CredentialsAPIClient.UserCredentialsApi.ReadOneUserCredential(ctx, environmentId.String(), userId.String(), credentialId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve user credential by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId.String()),
+		slog.String("credentialId", credentialId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientCredentialsWrapper) UpdateUserCredential(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID, credentialId uuid.UUID, updateRequest credentialsapi.UserCredential) (*credentialsapi.UserCredential, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.CredentialsAPIClient.UserCredentialsApi.UpdateUserCredential(ctx, environmentId.String(), userId.String(), credentialId.String()).UserCredential(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update user credential",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId.String()),
+		slog.String("credentialId", credentialId.String()),
+	)
+	return putRequest.Execute()
+}
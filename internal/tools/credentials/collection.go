@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "credentials"
+
+var _ collections.LegacySdkCollection = &CredentialsCollection{}
+
+type CredentialsCollection struct{}
+
+func (c *CredentialsCollection) Name() string {
+	return CollectionName
+}
+
+func (c *CredentialsCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	credentialsClientFactory := NewPingOneClientCredentialsWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListCredentialTypesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListCredentialTypesDef.McpTool.Name))
+		mcp.AddTool(server, ListCredentialTypesDef.McpTool, ListCredentialTypesHandler(credentialsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateCredentialTypeDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateCredentialTypeDef.McpTool.Name))
+		mcp.AddTool(server, CreateCredentialTypeDef.McpTool, CreateCredentialTypeHandler(credentialsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateCredentialTypeDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateCredentialTypeDef.McpTool.Name))
+		mcp.AddTool(server, UpdateCredentialTypeDef.McpTool, UpdateCredentialTypeHandler(credentialsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetCredentialIssuerProfileDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetCredentialIssuerProfileDef.McpTool.Name))
+		mcp.AddTool(server, GetCredentialIssuerProfileDef.McpTool, GetCredentialIssuerProfileHandler(credentialsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListUserCredentialsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListUserCredentialsDef.McpTool.Name))
+		mcp.AddTool(server, ListUserCredentialsDef.McpTool, ListUserCredentialsHandler(credentialsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&RevokeUserCredentialDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", RevokeUserCredentialDef.McpTool.Name))
+		mcp.AddTool(server, RevokeUserCredentialDef.McpTool, RevokeUserCredentialHandler(credentialsClientFactory))
+	}
+
+	return nil
+}
+
+func (c *CredentialsCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListCredentialTypesDef,
+		CreateCredentialTypeDef,
+		UpdateCredentialTypeDef,
+		GetCredentialIssuerProfileDef,
+		ListUserCredentialsDef,
+		RevokeUserCredentialDef,
+	}
+}
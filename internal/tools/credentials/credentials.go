@@ -0,0 +1,263 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+)
+
+// userCredentialStatusRevoked is the status of a user credential that has been revoked, so that verifiers no
+// longer accept it
+const userCredentialStatusRevoked = "REVOKED"
+
+type CredentialType struct {
+	Id                 string            `json:"id" jsonschema:"The UUID of the credential type"`
+	Title              string            `json:"title" jsonschema:"The title of the credential type, which verifiers use to request credentials of the type from wallets"`
+	Description        *string           `json:"description,omitempty" jsonschema:"The description of the credential type"`
+	CardType           *string           `json:"cardType,omitempty" jsonschema:"The descriptor of the credential type, such as proof of employment"`
+	IssuerName         *string           `json:"issuerName,omitempty" jsonschema:"The issuer name shown on credentials of the type"`
+	ManagementMode     *string           `json:"managementMode,omitempty" jsonschema:"How credentials of the type are issued: AUTOMATED by issuance rules, or MANAGED by calls to the PingOne API"`
+	CardDesignTemplate string            `json:"cardDesignTemplate" jsonschema:"The SVG image of the credential card, with placeholders for the fields shown on it"`
+	CardColor          *string           `json:"cardColor,omitempty" jsonschema:"The background color of the credential card"`
+	TextColor          *string           `json:"textColor,omitempty" jsonschema:"The text color of the credential card"`
+	BackgroundImage    *string           `json:"backgroundImage,omitempty" jsonschema:"The URL of the background image of the credential card"`
+	LogoImage          *string           `json:"logoImage,omitempty" jsonschema:"The URL of the logo image of the credential card"`
+	CredentialFields   []CredentialField `json:"credentialFields" jsonschema:"The fields of credentials of the type"`
+	CreatedAt          *time.Time        `json:"createdAt,omitempty" jsonschema:"When the credential type was created"`
+	UpdatedAt          *time.Time        `json:"updatedAt,omitempty" jsonschema:"When the credential type was last updated"`
+	DeletedAt          *time.Time        `json:"deletedAt,omitempty" jsonschema:"When the credential type was deleted. Deleted credential types are kept for the credentials already issued."`
+}
+
+type CredentialField struct {
+	Id        string  `json:"id" jsonschema:"The identifier of the field"`
+	Title     string  `json:"title" jsonschema:"The title of the field, also used in the card design template placeholders"`
+	Type      string  `json:"type" jsonschema:"The type of the field: 'Alphanumeric Text' with a fixed value, 'Directory Attribute' with the value of a user attribute, or 'Issued Timestamp'"`
+	Attribute *string `json:"attribute,omitempty" jsonschema:"The user attribute, or PingOne expression, of a Directory Attribute field"`
+	Value     *string `json:"value,omitempty" jsonschema:"The value of an Alphanumeric Text field"`
+	IsVisible bool    `json:"isVisible" jsonschema:"Whether the field is shown to viewers of the credential"`
+	Required  *bool   `json:"required,omitempty" jsonschema:"Whether the field must have a value"`
+}
+
+type CredentialIssuerProfile struct {
+	Id        string     `json:"id" jsonschema:"The UUID of the credential issuer"`
+	Name      string     `json:"name" jsonschema:"The name of the credential issuer, included in the credentials it issues"`
+	SiteUrl   *string    `json:"siteUrl,omitempty" jsonschema:"The base URL of the credential issuer"`
+	CreatedAt *time.Time `json:"createdAt,omitempty" jsonschema:"When the issuer profile was created"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty" jsonschema:"When the issuer profile was last updated"`
+}
+
+type UserCredential struct {
+	Id               string     `json:"id" jsonschema:"The UUID of the credential"`
+	CredentialTypeId *string    `json:"credentialTypeId,omitempty" jsonschema:"The UUID of the credential type of the credential"`
+	Status           *string    `json:"status,omitempty" jsonschema:"The status of the credential, such as PENDING, ACTIVE, REVOKED or EXPIRED"`
+	CreatedAt        *time.Time `json:"createdAt,omitempty" jsonschema:"When the credential was issued"`
+	UpdatedAt        *time.Time `json:"updatedAt,omitempty" jsonschema:"When the credential was last updated"`
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty" jsonschema:"When the credential expires"`
+}
+
+func credentialTypeOutput(credentialType credentialsapi.CredentialType) CredentialType {
+	result := CredentialType{
+		Id:                 credentialType.GetId(),
+		Title:              credentialType.Title,
+		Description:        credentialType.Description,
+		CardType:           credentialType.CardType,
+		IssuerName:         credentialType.IssuerName,
+		CardDesignTemplate: credentialType.CardDesignTemplate,
+		CardColor:          credentialType.Metadata.CardColor,
+		TextColor:          credentialType.Metadata.TextColor,
+		BackgroundImage:    credentialType.Metadata.BackgroundImage,
+		LogoImage:          credentialType.Metadata.LogoImage,
+		CredentialFields:   make([]CredentialField, 0, len(credentialType.Metadata.Fields)),
+		CreatedAt:          credentialType.CreatedAt,
+		UpdatedAt:          credentialType.UpdatedAt,
+		DeletedAt:          credentialType.DeletedAt,
+	}
+	if credentialType.Management != nil && credentialType.Management.Mode != nil {
+		mode := string(*credentialType.Management.Mode)
+		result.ManagementMode = &mode
+	}
+	for _, field := range credentialType.Metadata.Fields {
+		result.CredentialFields = append(result.CredentialFields, CredentialField{
+			Id:        field.Id,
+			Title:     field.Title,
+			Type:      string(field.Type),
+			Attribute: field.Attribute,
+			Value:     field.Value,
+			IsVisible: field.IsVisible,
+			Required:  field.Required,
+		})
+	}
+	return result
+}
+
+func credentialIssuerProfileOutput(profile credentialsapi.CredentialIssuerProfile) CredentialIssuerProfile {
+	return CredentialIssuerProfile{
+		Id:        profile.GetId(),
+		Name:      profile.Name,
+		SiteUrl:   profile.SiteUrl,
+		CreatedAt: profile.CreatedAt,
+		UpdatedAt: profile.UpdatedAt,
+	}
+}
+
+func userCredentialOutput(credential credentialsapi.UserCredential) UserCredential {
+	result := UserCredential{
+		Id:        credential.GetId(),
+		Status:    credential.Status,
+		CreatedAt: credential.CreatedAt,
+		UpdatedAt: credential.UpdatedAt,
+		ExpiresAt: credential.ExpiresAt,
+	}
+	if credential.CredentialType != nil {
+		result.CredentialTypeId = &credential.CredentialType.Id
+	}
+	return result
+}
+
+// CredentialFieldInput is a field of a credential type to create or replace
+type CredentialFieldInput struct {
+	Id        string  `json:"id" jsonschema:"REQUIRED. The identifier of the field, unique within the credential type."`
+	Title     string  `json:"title" jsonschema:"REQUIRED. The title of the field. Reference it in the card design template as ${fields[i].title}."`
+	Type      string  `json:"type" jsonschema:"REQUIRED. The type of the field: 'Alphanumeric Text' with a fixed value, 'Directory Attribute' with the value of a user attribute, or 'Issued Timestamp'."`
+	Attribute *string `json:"attribute,omitempty" jsonschema:"OPTIONAL. The user attribute, such as name.given, or PingOne expression of a Directory Attribute field."`
+	Value     *string `json:"value,omitempty" jsonschema:"OPTIONAL. The value of an Alphanumeric Text field."`
+	IsVisible bool    `json:"isVisible" jsonschema:"REQUIRED. Whether the field is shown to viewers of the credential."`
+	Required  *bool   `json:"required,omitempty" jsonschema:"OPTIONAL. Whether the field must have a value."`
+}
+
+// credentialTypeConfiguration is the configuration of a credential type that the create and update tools set
+type credentialTypeConfiguration struct {
+	title              string
+	description        *string
+	cardType           *string
+	issuerName         *string
+	managementMode     *string
+	cardDesignTemplate string
+	cardColor          *string
+	textColor          *string
+	backgroundImage    *string
+	logoImage          *string
+	credentialFields   []CredentialFieldInput
+}
+
+// credentialTypeRequest returns the credential type to create or replace, returning an error if a field type or
+// management mode is not supported by PingOne
+func credentialTypeRequest(configuration credentialTypeConfiguration) (credentialsapi.CredentialType, error) {
+	request := credentialsapi.CredentialType{
+		Title:              configuration.title,
+		Description:        configuration.description,
+		CardType:           configuration.cardType,
+		IssuerName:         configuration.issuerName,
+		CardDesignTemplate: configuration.cardDesignTemplate,
+		Metadata: credentialsapi.CredentialTypeMetaData{
+			CardColor:       configuration.cardColor,
+			TextColor:       configuration.textColor,
+			BackgroundImage: configuration.backgroundImage,
+			LogoImage:       configuration.logoImage,
+		},
+	}
+	if configuration.managementMode != nil {
+		mode, err := credentialsapi.NewEnumCredentialTypeManagementModeFromValue(*configuration.managementMode)
+		if err != nil {
+			return credentialsapi.CredentialType{}, err
+		}
+		request.Management = &credentialsapi.CredentialTypeManagement{Mode: mode}
+	}
+	for _, field := range configuration.credentialFields {
+		fieldType, err := credentialsapi.NewEnumCredentialTypeMetaDataFieldsTypeFromValue(field.Type)
+		if err != nil {
+			return credentialsapi.CredentialType{}, err
+		}
+		request.Metadata.Fields = append(request.Metadata.Fields, credentialsapi.CredentialTypeMetaDataFieldsInner{
+			Id:        field.Id,
+			Title:     field.Title,
+			Type:      *fieldType,
+			Attribute: field.Attribute,
+			Value:     field.Value,
+			IsVisible: field.IsVisible,
+			Required:  field.Required,
+		})
+	}
+	return request, nil
+}
+
+// listItems returns the items of a paged list of credential types or user credentials
+func listItems(ctx context.Context, toolName string, list func() (credentialsapi.EntityArrayPagedIterator, error)) ([]credentialsapi.EntityArrayEmbeddedItemsInner, error) {
+	pagedIterator, err := list()
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	items := []credentialsapi.EntityArrayEmbeddedItemsInner{}
+	err = collections.ForEachCredentialsPage(ctx, pagedIterator, func(embedded *credentialsapi.EntityArrayEmbedded) bool {
+		items = append(items, embedded.Items...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// keepUnmanagedConfiguration copies the configuration of a credential type that the tools do not set, such as
+// its expiration, into the full replacement of the credential type
+func keepUnmanagedConfiguration(request *credentialsapi.CredentialType, existing credentialsapi.CredentialType) {
+	request.Metadata.Name = existing.Metadata.Name
+	request.Metadata.Description = existing.Metadata.Description
+	request.Metadata.BgOpacityPercent = existing.Metadata.BgOpacityPercent
+	request.Metadata.Columns = existing.Metadata.Columns
+	request.Expiration = existing.Expiration
+	request.Multiple = existing.Multiple
+	request.OnDelete = existing.OnDelete
+}
+
+// existingCredentialType reads a credential type, so that an update can keep its other configuration and be undone
+func existingCredentialType(ctx context.Context, client CredentialsClient, environmentId uuid.UUID, credentialTypeId uuid.UUID) (*credentialsapi.CredentialType, error) {
+	credentialType, httpResponse, err := client.GetCredentialType(ctx, environmentId, credentialTypeId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	if credentialType == nil {
+		apiErr := errs.NewApiError(httpResponse, errors.New("no credential type data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	return credentialType, nil
+}
+
+// mustGenerateCredentialTypeInputSchema generates the input schema of a tool that configures a credential type,
+// listing the management modes and field types PingOne supports
+func mustGenerateCredentialTypeInputSchema[T any]() *jsonschema.Schema {
+	inputSchema := schema.MustGenerateSchema[T]()
+
+	managementModeSchema, exists := inputSchema.Properties["managementMode"]
+	if !exists || managementModeSchema == nil {
+		panic("managementMode property not found in credential type input schema")
+	}
+	for _, mode := range credentialsapi.AllowedEnumCredentialTypeManagementModeEnumValues {
+		managementModeSchema.Enum = append(managementModeSchema.Enum, string(mode))
+	}
+
+	credentialFieldsSchema, exists := inputSchema.Properties["credentialFields"]
+	if !exists || credentialFieldsSchema == nil || credentialFieldsSchema.Items == nil || credentialFieldsSchema.Items.Properties["type"] == nil {
+		panic("credentialFields type property not found in credential type input schema")
+	}
+	for _, fieldType := range credentialsapi.AllowedEnumCredentialTypeMetaDataFieldsTypeEnumValues {
+		credentialFieldsSchema.Items.Properties["type"].Enum = append(credentialFieldsSchema.Items.Properties["type"].Enum, string(fieldType))
+	}
+	return inputSchema
+}
@@ -0,0 +1,117 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ credentials.CredentialsClient = &mockPingOneClientCredentialsWrapper{}
+var _ credentials.CredentialsClientFactory = &mockPingOneClientCredentialsWrapperFactory{}
+
+type mockPingOneClientCredentialsWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientCredentialsWrapperFactory struct {
+	mockClient credentials.CredentialsClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientCredentialsWrapperFactory(mockClient credentials.CredentialsClient, err error) *mockPingOneClientCredentialsWrapperFactory {
+	return &mockPingOneClientCredentialsWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientCredentialsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (credentials.CredentialsClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientCredentialsWrapper) GetCredentialTypes(ctx context.Context, environmentId uuid.UUID) (credentialsapi.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).(credentialsapi.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientCredentialsWrapper) GetCredentialType(ctx context.Context, environmentId uuid.UUID, credentialTypeId uuid.UUID) (*credentialsapi.CredentialType, *http.Response, error) {
+	args := p.Called(ctx, environmentId, credentialTypeId)
+	response, ok := args.Get(0).(*credentialsapi.CredentialType)
+	if !ok && args.Get(0) != nil {
+		panic("GetCredentialType mock setup error: expected *credentialsapi.CredentialType or nil")
+	}
+	return response, httpResponse("GetCredentialType", args), args.Error(2)
+}
+
+func (p *mockPingOneClientCredentialsWrapper) CreateCredentialType(ctx context.Context, environmentId uuid.UUID, createRequest credentialsapi.CredentialType) (*credentialsapi.CredentialType, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	response, ok := args.Get(0).(*credentialsapi.CredentialType)
+	if !ok && args.Get(0) != nil {
+		panic("CreateCredentialType mock setup error: expected *credentialsapi.CredentialType or nil")
+	}
+	return response, httpResponse("CreateCredentialType", args), args.Error(2)
+}
+
+func (p *mockPingOneClientCredentialsWrapper) UpdateCredentialType(ctx context.Context, environmentId uuid.UUID, credentialTypeId uuid.UUID, updateRequest credentialsapi.CredentialType) (*credentialsapi.CredentialType, *http.Response, error) {
+	args := p.Called(ctx, environmentId, credentialTypeId, updateRequest)
+	response, ok := args.Get(0).(*credentialsapi.CredentialType)
+	if !ok && args.Get(0) != nil {
+		panic("UpdateCredentialType mock setup error: expected *credentialsapi.CredentialType or nil")
+	}
+	return response, httpResponse("UpdateCredentialType", args), args.Error(2)
+}
+
+func (p *mockPingOneClientCredentialsWrapper) GetCredentialIssuerProfile(ctx context.Context, environmentId uuid.UUID) (*credentialsapi.CredentialIssuerProfile, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).(*credentialsapi.CredentialIssuerProfile)
+	if !ok && args.Get(0) != nil {
+		panic("GetCredentialIssuerProfile mock setup error: expected *credentialsapi.CredentialIssuerProfile or nil")
+	}
+	return response, httpResponse("GetCredentialIssuerProfile", args), args.Error(2)
+}
+
+func (p *mockPingOneClientCredentialsWrapper) GetUserCredentials(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID) (credentialsapi.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, userId)
+	response, ok := args.Get(0).(credentialsapi.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientCredentialsWrapper) GetUserCredential(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID, credentialId uuid.UUID) (*credentialsapi.UserCredential, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, credentialId)
+	response, ok := args.Get(0).(*credentialsapi.UserCredential)
+	if !ok && args.Get(0) != nil {
+		panic("GetUserCredential mock setup error: expected *credentialsapi.UserCredential or nil")
+	}
+	return response, httpResponse("GetUserCredential", args), args.Error(2)
+}
+
+func (p *mockPingOneClientCredentialsWrapper) UpdateUserCredential(ctx context.Context, environmentId uuid.UUID, userId uuid.UUID, credentialId uuid.UUID, updateRequest credentialsapi.UserCredential) (*credentialsapi.UserCredential, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, credentialId, updateRequest)
+	response, ok := args.Get(0).(*credentialsapi.UserCredential)
+	if !ok && args.Get(0) != nil {
+		panic("UpdateUserCredential mock setup error: expected *credentialsapi.UserCredential or nil")
+	}
+	return response, httpResponse("UpdateUserCredential", args), args.Error(2)
+}
+
+func httpResponse(method string, args mock.Arguments) *http.Response {
+	response, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testCredentialTypeId = uuid.MustParse("550e8400-e29b-41d4-a716-446655448000")
+	testUserId           = uuid.MustParse("550e8400-e29b-41d4-a716-446655448100")
+	testCredentialId     = uuid.MustParse("550e8400-e29b-41d4-a716-446655448200")
+	testIssuedAt         = time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+)
+
+// testCredentialType returns an employee card credential type that expires after a year
+func testCredentialType() *credentialsapi.CredentialType {
+	automated := credentialsapi.ENUMCREDENTIALTYPEMANAGEMENTMODE_AUTOMATED
+	return &credentialsapi.CredentialType{
+		Id:                 testutils.Pointer(testCredentialTypeId.String()),
+		Title:              "Employee Card",
+		Description:        testutils.Pointer("Proof of employment"),
+		CardType:           testutils.Pointer("EmployeeCard"),
+		CardDesignTemplate: `<svg xmlns="http://www.w3.org/2000/svg"><text>${fields[0].value}</text></svg>`,
+		Management:         &credentialsapi.CredentialTypeManagement{Mode: &automated},
+		Metadata: credentialsapi.CredentialTypeMetaData{
+			Name:      testutils.Pointer("Employee Card"),
+			CardColor: testutils.Pointer("#000000"),
+			TextColor: testutils.Pointer("#eff0f1"),
+			Columns:   testutils.Pointer(int32(1)),
+			Version:   testutils.Pointer(int32(3)),
+			Fields: []credentialsapi.CredentialTypeMetaDataFieldsInner{
+				{
+					Id:        "field-1",
+					Title:     "Name",
+					Type:      credentialsapi.ENUMCREDENTIALTYPEMETADATAFIELDSTYPE_DIRECTORY_ATTRIBUTE,
+					Attribute: testutils.Pointer("name.formatted"),
+					IsVisible: true,
+				},
+			},
+		},
+		Expiration: &credentialsapi.CredentialTypeExpiration{
+			Type:       credentialsapi.ENUMCREDENTIALTYPEEXPIRATIONTYPE_HARD,
+			Expression: testutils.Pointer("${#datetime.now().plusYears(1)}"),
+		},
+		CreatedAt: &testIssuedAt,
+	}
+}
+
+// testUserCredential returns an active credential of the test credential type issued to the test user
+func testUserCredential(status string) *credentialsapi.UserCredential {
+	return &credentialsapi.UserCredential{
+		Id:             testutils.Pointer(testCredentialId.String()),
+		CredentialType: &credentialsapi.CredentialDigitalWalletNotificationResultsInnerNotification{Id: testCredentialTypeId.String()},
+		User:           &credentialsapi.CredentialDigitalWalletNotificationResultsInnerNotification{Id: testUserId.String()},
+		Status:         &status,
+		CreatedAt:      &testIssuedAt,
+	}
+}
+
+// itemsPages returns a single page of credential types and user credentials
+func itemsPages(items ...credentialsapi.EntityArrayEmbeddedItemsInner) credentialsapi.EntityArrayPagedIterator {
+	return func(yield func(credentialsapi.PagedCursor, error) bool) {
+		yield(credentialsapi.PagedCursor{
+			EntityArray:  &credentialsapi.EntityArray{Embedded: &credentialsapi.EntityArrayEmbedded{Items: items}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		}, nil)
+	}
+}
+
+func errorPages(err error) credentialsapi.EntityArrayPagedIterator {
+	return func(yield func(credentialsapi.PagedCursor, error) bool) {
+		yield(credentialsapi.PagedCursor{HTTPResponse: &http.Response{StatusCode: 403}}, err)
+	}
+}
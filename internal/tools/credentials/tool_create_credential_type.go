@@ -0,0 +1,110 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateCredentialTypeDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "create_credential_type",
+		Title: "Create PingOne Credential Type",
+		Description: `Create a verifiable credential type, such as an employee or membership card, in an environment with the PingOne Credentials service. Credentials of the type show the fields on a card designed by an SVG template.
+
+Fields of type 'Directory Attribute' take their value from a user attribute when a credential is issued. Check the issuer name first with 'get_credential_issuer_profile'.`,
+		InputSchema:  mustGenerateCredentialTypeInputSchema[CreateCredentialTypeInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateCredentialTypeOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateCredentialTypeInput struct {
+	EnvironmentId      uuid.UUID              `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Title              string                 `json:"title" jsonschema:"REQUIRED. The title of the credential type, which verifiers use to request credentials of the type from wallets."`
+	Description        *string                `json:"description,omitempty" jsonschema:"OPTIONAL. The description of the credential type."`
+	CardType           *string                `json:"cardType,omitempty" jsonschema:"OPTIONAL. The descriptor of the credential type, such as proof of employment or proof of insurance."`
+	IssuerName         *string                `json:"issuerName,omitempty" jsonschema:"OPTIONAL. The issuer name shown on credentials of the type, if different from the issuer profile name."`
+	ManagementMode     *string                `json:"managementMode,omitempty" jsonschema:"OPTIONAL. How credentials of the type are issued: AUTOMATED by issuance rules, or MANAGED by calls to the PingOne API."`
+	CardDesignTemplate string                 `json:"cardDesignTemplate" jsonschema:"REQUIRED. The SVG image of the credential card, with placeholders such as ${cardColor} and ${fields[0].value} for the values shown on it."`
+	CardColor          *string                `json:"cardColor,omitempty" jsonschema:"OPTIONAL. The background color of the credential card, such as #000000."`
+	TextColor          *string                `json:"textColor,omitempty" jsonschema:"OPTIONAL. The text color of the credential card, such as #eff0f1."`
+	BackgroundImage    *string                `json:"backgroundImage,omitempty" jsonschema:"OPTIONAL. The URL of the background image of the credential card."`
+	LogoImage          *string                `json:"logoImage,omitempty" jsonschema:"OPTIONAL. The URL of the logo image of the credential card."`
+	CredentialFields   []CredentialFieldInput `json:"credentialFields,omitempty" jsonschema:"OPTIONAL. The fields of credentials of the type."`
+}
+
+type CreateCredentialTypeOutput struct {
+	CredentialType CredentialType `json:"credentialType" jsonschema:"The created credential type"`
+}
+
+// CreateCredentialTypeHandler creates a PingOne credential type using the provided client
+func CreateCredentialTypeHandler(credentialsClientFactory CredentialsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateCredentialTypeInput,
+) (
+	*mcp.CallToolResult,
+	*CreateCredentialTypeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateCredentialTypeInput) (*mcp.CallToolResult, *CreateCredentialTypeOutput, error) {
+		createRequest, err := credentialTypeRequest(credentialTypeConfiguration{
+			title:              input.Title,
+			description:        input.Description,
+			cardType:           input.CardType,
+			issuerName:         input.IssuerName,
+			managementMode:     input.ManagementMode,
+			cardDesignTemplate: input.CardDesignTemplate,
+			cardColor:          input.CardColor,
+			textColor:          input.TextColor,
+			backgroundImage:    input.BackgroundImage,
+			logoImage:          input.LogoImage,
+			credentialFields:   input.CredentialFields,
+		})
+		if err != nil {
+			toolErr := errs.NewToolError(CreateCredentialTypeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := credentialsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateCredentialTypeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating credential type",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("title", input.Title))
+
+		credentialType, httpResponse, err := client.CreateCredentialType(ctx, input.EnvironmentId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if credentialType == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no credential type data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &CreateCredentialTypeOutput{
+			CredentialType: credentialTypeOutput(*credentialType),
+		}, nil
+	}
+}
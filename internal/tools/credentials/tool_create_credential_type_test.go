@@ -0,0 +1,142 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createCredentialTypeInput() credentials.CreateCredentialTypeInput {
+	return credentials.CreateCredentialTypeInput{
+		EnvironmentId:      testEnvironmentId,
+		Title:              "Employee Card",
+		Description:        testutils.Pointer("Proof of employment"),
+		CardType:           testutils.Pointer("EmployeeCard"),
+		ManagementMode:     testutils.Pointer("AUTOMATED"),
+		CardDesignTemplate: `<svg xmlns="http://www.w3.org/2000/svg"><text>${fields[0].value}</text></svg>`,
+		CardColor:          testutils.Pointer("#000000"),
+		TextColor:          testutils.Pointer("#eff0f1"),
+		CredentialFields: []credentials.CredentialFieldInput{
+			{
+				Id:        "field-1",
+				Title:     "Name",
+				Type:      "Directory Attribute",
+				Attribute: testutils.Pointer("name.formatted"),
+				IsVisible: true,
+			},
+		},
+	}
+}
+
+func TestCreateCredentialTypeHandler(t *testing.T) {
+	automated := credentialsapi.ENUMCREDENTIALTYPEMANAGEMENTMODE_AUTOMATED
+	expectedRequest := credentialsapi.CredentialType{
+		Title:              "Employee Card",
+		Description:        testutils.Pointer("Proof of employment"),
+		CardType:           testutils.Pointer("EmployeeCard"),
+		CardDesignTemplate: `<svg xmlns="http://www.w3.org/2000/svg"><text>${fields[0].value}</text></svg>`,
+		Management:         &credentialsapi.CredentialTypeManagement{Mode: &automated},
+		Metadata: credentialsapi.CredentialTypeMetaData{
+			CardColor: testutils.Pointer("#000000"),
+			TextColor: testutils.Pointer("#eff0f1"),
+			Fields: []credentialsapi.CredentialTypeMetaDataFieldsInner{
+				{
+					Id:        "field-1",
+					Title:     "Name",
+					Type:      credentialsapi.ENUMCREDENTIALTYPEMETADATAFIELDSTYPE_DIRECTORY_ATTRIBUTE,
+					Attribute: testutils.Pointer("name.formatted"),
+					IsVisible: true,
+				},
+			},
+		},
+	}
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("CreateCredentialType", mock.Anything, testEnvironmentId, expectedRequest).Return(testCredentialType(), &http.Response{StatusCode: 201}, nil)
+
+	handler := credentials.CreateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, createCredentialTypeInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testCredentialTypeId.String(), output.CredentialType.Id)
+	assert.Equal(t, "Employee Card", output.CredentialType.Title)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateCredentialTypeHandler_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		modify          func(input *credentials.CreateCredentialTypeInput)
+		wantErrContains string
+	}{
+		{
+			name: "Invalid management mode",
+			modify: func(input *credentials.CreateCredentialTypeInput) {
+				input.ManagementMode = testutils.Pointer("MANUAL")
+			},
+			wantErrContains: "invalid value 'MANUAL' for EnumCredentialTypeManagementMode",
+		},
+		{
+			name: "Invalid field type",
+			modify: func(input *credentials.CreateCredentialTypeInput) {
+				input.CredentialFields[0].Type = "Image"
+			},
+			wantErrContains: "invalid value 'Image' for EnumCredentialTypeMetaDataFieldsType",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			input := createCredentialTypeInput()
+			tt.modify(&input)
+
+			handler := credentials.CreateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertNotCalled(t, "CreateCredentialType", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateCredentialTypeHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			mockClient.On("CreateCredentialType", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := credentials.CreateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, createCredentialTypeInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateCredentialTypeHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := credentials.CreateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, createCredentialTypeInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestCreateCredentialTypeDef_InputSchemaEnums(t *testing.T) {
+	inputSchema, ok := credentials.CreateCredentialTypeDef.McpTool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok)
+
+	assert.ElementsMatch(t, []any{"AUTOMATED", "MANAGED"}, inputSchema.Properties["managementMode"].Enum)
+	assert.Contains(t, inputSchema.Properties["credentialFields"].Items.Properties["type"].Enum, "Directory Attribute")
+}
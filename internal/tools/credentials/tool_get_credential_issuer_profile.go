@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetCredentialIssuerProfileDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "get_credential_issuer_profile",
+		Title:        "Get PingOne Credential Issuer Profile",
+		Description:  "Retrieve the credential issuer profile of an environment with the PingOne Credentials service: the issuer name and site URL included in the verifiable credentials the environment issues.",
+		InputSchema:  schema.MustGenerateSchema[GetCredentialIssuerProfileInput](),
+		OutputSchema: schema.MustGenerateSchema[GetCredentialIssuerProfileOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetCredentialIssuerProfileInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'issuerProfile.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetCredentialIssuerProfileOutput struct {
+	IssuerProfile CredentialIssuerProfile `json:"issuerProfile" jsonschema:"The credential issuer profile of the environment"`
+}
+
+// GetCredentialIssuerProfileHandler retrieves the PingOne credential issuer profile of an environment using the
+// provided client
+func GetCredentialIssuerProfileHandler(credentialsClientFactory CredentialsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetCredentialIssuerProfileInput,
+) (
+	*mcp.CallToolResult,
+	*GetCredentialIssuerProfileOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetCredentialIssuerProfileInput) (*mcp.CallToolResult, *GetCredentialIssuerProfileOutput, error) {
+		client, err := credentialsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetCredentialIssuerProfileDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Getting credential issuer profile", slog.String("environmentId", input.EnvironmentId.String()))
+
+		profile, httpResponse, err := client.GetCredentialIssuerProfile(ctx, input.EnvironmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if profile == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no credential issuer profile data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &GetCredentialIssuerProfileOutput{
+			IssuerProfile: credentialIssuerProfileOutput(*profile),
+		}, nil
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCredentialIssuerProfileHandler(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialIssuerProfile", mock.Anything, testEnvironmentId).Return(&credentialsapi.CredentialIssuerProfile{
+		Id:      testutils.Pointer("550e8400-e29b-41d4-a716-446655448300"),
+		Name:    "Example Corp",
+		SiteUrl: testutils.Pointer("https://example.com"),
+	}, &http.Response{StatusCode: 200}, nil)
+
+	handler := credentials.GetCredentialIssuerProfileHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.GetCredentialIssuerProfileInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, credentials.CredentialIssuerProfile{
+		Id:      "550e8400-e29b-41d4-a716-446655448300",
+		Name:    "Example Corp",
+		SiteUrl: testutils.Pointer("https://example.com"),
+	}, output.IssuerProfile)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetCredentialIssuerProfileHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			mockClient.On("GetCredentialIssuerProfile", mock.Anything, testEnvironmentId).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := credentials.GetCredentialIssuerProfileHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.GetCredentialIssuerProfileInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetCredentialIssuerProfileHandler_NoProfileInResponse(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialIssuerProfile", mock.Anything, testEnvironmentId).Return(nil, &http.Response{StatusCode: 200}, nil)
+
+	handler := credentials.GetCredentialIssuerProfileHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.GetCredentialIssuerProfileInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "no credential issuer profile data in response")
+}
+
+func TestGetCredentialIssuerProfileHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := credentials.GetCredentialIssuerProfileHandler(NewMockPingOneClientCredentialsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.GetCredentialIssuerProfileInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,80 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListCredentialTypesDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_credential_types",
+		Title:        "List PingOne Credential Types",
+		Description:  "Lists the verifiable credential types of an environment with the PingOne Credentials service, such as employee or membership cards, with their fields and card design. Use to discover credential type IDs.",
+		InputSchema:  schema.MustGenerateSchema[ListCredentialTypesInput](),
+		OutputSchema: schema.MustGenerateSchema[ListCredentialTypesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListCredentialTypesInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'credentialTypes.id' and 'credentialTypes.title'. Use to reduce response size when only some attributes are needed, as card design templates can be large. Defaults to all attributes."`
+}
+
+type ListCredentialTypesOutput struct {
+	CredentialTypes []CredentialType `json:"credentialTypes" jsonschema:"The credential types of the environment"`
+}
+
+// ListCredentialTypesHandler lists the PingOne credential types of an environment using the provided client
+func ListCredentialTypesHandler(credentialsClientFactory CredentialsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListCredentialTypesInput,
+) (
+	*mcp.CallToolResult,
+	*ListCredentialTypesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListCredentialTypesInput) (*mcp.CallToolResult, *ListCredentialTypesOutput, error) {
+		client, err := credentialsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListCredentialTypesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing credential types", slog.String("environmentId", input.EnvironmentId.String()))
+
+		items, err := listItems(ctx, ListCredentialTypesDef.McpTool.Name, func() (credentialsapi.EntityArrayPagedIterator, error) {
+			return client.GetCredentialTypes(ctx, input.EnvironmentId)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		result := &ListCredentialTypesOutput{
+			CredentialTypes: []CredentialType{},
+		}
+		for _, item := range items {
+			if item.CredentialType != nil {
+				result.CredentialTypes = append(result.CredentialTypes, credentialTypeOutput(*item.CredentialType))
+			}
+		}
+
+		return nil, result, nil
+	}
+}
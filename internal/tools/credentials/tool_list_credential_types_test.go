@@ -0,0 +1,106 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCredentialTypesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialTypes", mock.Anything, testEnvironmentId).Return(itemsPages(credentialsapi.EntityArrayEmbeddedItemsInner{CredentialType: testCredentialType()}), nil)
+
+	handler := credentials.ListCredentialTypesHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListCredentialTypesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.CredentialTypes, 1)
+	credentialType := output.CredentialTypes[0]
+	assert.Equal(t, testCredentialTypeId.String(), credentialType.Id)
+	assert.Equal(t, "Employee Card", credentialType.Title)
+	assert.Equal(t, testutils.Pointer("AUTOMATED"), credentialType.ManagementMode)
+	assert.Equal(t, testutils.Pointer("#000000"), credentialType.CardColor)
+	assert.Equal(t, []credentials.CredentialField{
+		{
+			Id:        "field-1",
+			Title:     "Name",
+			Type:      "Directory Attribute",
+			Attribute: testutils.Pointer("name.formatted"),
+			IsVisible: true,
+		},
+	}, credentialType.CredentialFields)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListCredentialTypesHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialTypes", mock.Anything, testEnvironmentId).Return(itemsPages(), nil)
+
+	handler := credentials.ListCredentialTypesHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListCredentialTypesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.CredentialTypes)
+	assert.Empty(t, output.CredentialTypes)
+}
+
+func TestListCredentialTypesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientCredentialsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientCredentialsWrapper) {
+				mockClient.On("GetCredentialTypes", mock.Anything, testEnvironmentId).Return(nil, errors.New("invalid environment"))
+			},
+			wantErrContains: "invalid environment",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientCredentialsWrapper) {
+				mockClient.On("GetCredentialTypes", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := credentials.ListCredentialTypesHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListCredentialTypesInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListCredentialTypesHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := credentials.ListCredentialTypesHandler(NewMockPingOneClientCredentialsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListCredentialTypesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,80 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListUserCredentialsDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "list_user_credentials",
+		Title:        "List PingOne User Credentials",
+		Description:  "Lists the verifiable credentials issued to a user, with their credential type, status and expiry. Use 'find_user' to find the user ID, and 'list_credential_types' to name the credential types.",
+		InputSchema:  schema.MustGenerateSchema[ListUserCredentialsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListUserCredentialsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListUserCredentialsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. User UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'credentials.id' and 'credentials.status'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListUserCredentialsOutput struct {
+	Credentials []UserCredential `json:"credentials" jsonschema:"The credentials issued to the user"`
+}
+
+// ListUserCredentialsHandler lists the PingOne credentials issued to a user using the provided client
+func ListUserCredentialsHandler(credentialsClientFactory CredentialsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListUserCredentialsInput,
+) (
+	*mcp.CallToolResult,
+	*ListUserCredentialsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListUserCredentialsInput) (*mcp.CallToolResult, *ListUserCredentialsOutput, error) {
+		client, err := credentialsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListUserCredentialsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing user credentials",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()))
+
+		items, err := listItems(ctx, ListUserCredentialsDef.McpTool.Name, func() (credentialsapi.EntityArrayPagedIterator, error) {
+			return client.GetUserCredentials(ctx, input.EnvironmentId, input.UserId)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		result := &ListUserCredentialsOutput{
+			Credentials: []UserCredential{},
+		}
+		for _, item := range items {
+			if item.UserCredential != nil {
+				result.Credentials = append(result.Credentials, userCredentialOutput(*item.UserCredential))
+			}
+		}
+
+		return nil, result, nil
+	}
+}
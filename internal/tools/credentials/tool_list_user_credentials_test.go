@@ -0,0 +1,77 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListUserCredentialsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetUserCredentials", mock.Anything, testEnvironmentId, testUserId).Return(itemsPages(credentialsapi.EntityArrayEmbeddedItemsInner{UserCredential: testUserCredential("ACTIVE")}), nil)
+
+	handler := credentials.ListUserCredentialsHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListUserCredentialsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []credentials.UserCredential{
+		{
+			Id:               testCredentialId.String(),
+			CredentialTypeId: testutils.Pointer(testCredentialTypeId.String()),
+			Status:           testutils.Pointer("ACTIVE"),
+			CreatedAt:        &testIssuedAt,
+		},
+	}, output.Credentials)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListUserCredentialsHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetUserCredentials", mock.Anything, testEnvironmentId, testUserId).Return(itemsPages(), nil)
+
+	handler := credentials.ListUserCredentialsHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListUserCredentialsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Credentials)
+	assert.Empty(t, output.Credentials)
+}
+
+func TestListUserCredentialsHandler_PageError(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetUserCredentials", mock.Anything, testEnvironmentId, testUserId).Return(errorPages(errors.New("user not found")), nil)
+
+	handler := credentials.ListUserCredentialsHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListUserCredentialsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "user not found")
+	mockClient.AssertExpectations(t)
+}
+
+func TestListUserCredentialsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := credentials.ListUserCredentialsHandler(NewMockPingOneClientCredentialsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, credentials.ListUserCredentialsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
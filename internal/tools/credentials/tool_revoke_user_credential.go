@@ -0,0 +1,109 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var RevokeUserCredentialDef = types.ToolDefinition{
+	RequiresConfirmation: true,
+	McpTool: &mcp.Tool{
+		Name:  "revoke_user_credential",
+		Title: "Revoke PingOne User Credential",
+		Description: `Revoke a verifiable credential issued to a user, so that verifiers no longer accept it, for example when the user leaves or loses their device. The revocation cannot be undone; a new credential must be issued instead.
+
+Use 'list_user_credentials' to find the credential ID. Credentials that are already revoked are returned unchanged.`,
+		InputSchema:  schema.MustGenerateSchema[RevokeUserCredentialInput](),
+		OutputSchema: schema.MustGenerateSchema[RevokeUserCredentialOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type RevokeUserCredentialInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. UUID of the user the credential was issued to."`
+	CredentialId  uuid.UUID `json:"credentialId" jsonschema:"REQUIRED. UUID of the credential to revoke."`
+}
+
+type RevokeUserCredentialOutput struct {
+	Credential     UserCredential `json:"credential" jsonschema:"The revoked credential"`
+	AlreadyRevoked bool           `json:"alreadyRevoked" jsonschema:"True if the credential was already revoked, so was not changed"`
+}
+
+// RevokeUserCredentialHandler revokes a PingOne user credential using the provided client
+func RevokeUserCredentialHandler(credentialsClientFactory CredentialsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input RevokeUserCredentialInput,
+) (
+	*mcp.CallToolResult,
+	*RevokeUserCredentialOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RevokeUserCredentialInput) (*mcp.CallToolResult, *RevokeUserCredentialOutput, error) {
+		client, err := credentialsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(RevokeUserCredentialDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		credential, httpResponse, err := client.GetUserCredential(ctx, input.EnvironmentId, input.UserId, input.CredentialId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if credential == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user credential data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if credential.GetStatus() == userCredentialStatusRevoked {
+			return nil, &RevokeUserCredentialOutput{
+				Credential:     userCredentialOutput(*credential),
+				AlreadyRevoked: true,
+			}, nil
+		}
+
+		logger.FromContext(ctx).Debug("Revoking user credential",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()),
+			slog.String("credentialId", input.CredentialId.String()))
+
+		status := userCredentialStatusRevoked
+		revoked, httpResponse, err := client.UpdateUserCredential(ctx, input.EnvironmentId, input.UserId, input.CredentialId, credentialsapi.UserCredential{
+			Status: &status,
+		})
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if revoked == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user credential data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &RevokeUserCredentialOutput{
+			Credential: userCredentialOutput(*revoked),
+		}, nil
+	}
+}
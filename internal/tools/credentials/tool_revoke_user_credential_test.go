@@ -0,0 +1,92 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func revokeUserCredentialInput() credentials.RevokeUserCredentialInput {
+	return credentials.RevokeUserCredentialInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		CredentialId:  testCredentialId,
+	}
+}
+
+func TestRevokeUserCredentialHandler(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetUserCredential", mock.Anything, testEnvironmentId, testUserId, testCredentialId).Return(testUserCredential("ACTIVE"), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("UpdateUserCredential", mock.Anything, testEnvironmentId, testUserId, testCredentialId, credentialsapi.UserCredential{
+		Status: testutils.Pointer("REVOKED"),
+	}).Return(testUserCredential("REVOKED"), &http.Response{StatusCode: 200}, nil)
+
+	handler := credentials.RevokeUserCredentialHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, revokeUserCredentialInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.AlreadyRevoked)
+	assert.Equal(t, testutils.Pointer("REVOKED"), output.Credential.Status)
+	mockClient.AssertExpectations(t)
+}
+
+func TestRevokeUserCredentialHandler_AlreadyRevoked(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetUserCredential", mock.Anything, testEnvironmentId, testUserId, testCredentialId).Return(testUserCredential("REVOKED"), &http.Response{StatusCode: 200}, nil)
+
+	handler := credentials.RevokeUserCredentialHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, revokeUserCredentialInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.AlreadyRevoked)
+	assert.Equal(t, testCredentialId.String(), output.Credential.Id)
+	mockClient.AssertNotCalled(t, "UpdateUserCredential", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRevokeUserCredentialHandler_GetUserCredentialAPIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			mockClient.On("GetUserCredential", mock.Anything, testEnvironmentId, testUserId, testCredentialId).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := credentials.RevokeUserCredentialHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, revokeUserCredentialInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertNotCalled(t, "UpdateUserCredential", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRevokeUserCredentialHandler_UpdateAPIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			mockClient.On("GetUserCredential", mock.Anything, testEnvironmentId, testUserId, testCredentialId).Return(testUserCredential("ACTIVE"), &http.Response{StatusCode: 200}, nil)
+			mockClient.On("UpdateUserCredential", mock.Anything, testEnvironmentId, testUserId, testCredentialId, mock.Anything).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := credentials.RevokeUserCredentialHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, revokeUserCredentialInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRevokeUserCredentialHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := credentials.RevokeUserCredentialHandler(NewMockPingOneClientCredentialsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, revokeUserCredentialInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,180 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateCredentialTypeDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "update_credential_type",
+		Title: "Update PingOne Credential Type by ID",
+		Description: `Update the title, description, card design and fields of a verifiable credential type using full replacement (HTTP PUT). The expiration and other settings of the credential type that this tool does not set are kept.
+
+Omitted optional fields will be cleared; call 'list_credential_types' first to fetch the current configuration, and pass its 'updatedAt' as 'lastKnownUpdatedAt' so that the credential type is not replaced if someone else has changed it since. Credentials already issued keep the version of the credential type they were issued with.`,
+		InputSchema:  mustGenerateCredentialTypeInputSchema[UpdateCredentialTypeInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateCredentialTypeOutput](),
+	},
+}
+
+type UpdateCredentialTypeInput struct {
+	EnvironmentId      uuid.UUID              `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	CredentialTypeId   uuid.UUID              `json:"credentialTypeId" jsonschema:"REQUIRED. Credential type UUID."`
+	Title              string                 `json:"title" jsonschema:"REQUIRED. The title of the credential type, which verifiers use to request credentials of the type from wallets."`
+	Description        *string                `json:"description,omitempty" jsonschema:"OPTIONAL. The description of the credential type."`
+	CardType           *string                `json:"cardType,omitempty" jsonschema:"OPTIONAL. The descriptor of the credential type, such as proof of employment or proof of insurance."`
+	IssuerName         *string                `json:"issuerName,omitempty" jsonschema:"OPTIONAL. The issuer name shown on credentials of the type, if different from the issuer profile name."`
+	ManagementMode     *string                `json:"managementMode,omitempty" jsonschema:"OPTIONAL. How credentials of the type are issued: AUTOMATED by issuance rules, or MANAGED by calls to the PingOne API."`
+	CardDesignTemplate string                 `json:"cardDesignTemplate" jsonschema:"REQUIRED. The SVG image of the credential card, with placeholders such as ${cardColor} and ${fields[0].value} for the values shown on it."`
+	CardColor          *string                `json:"cardColor,omitempty" jsonschema:"OPTIONAL. The background color of the credential card, such as #000000."`
+	TextColor          *string                `json:"textColor,omitempty" jsonschema:"OPTIONAL. The text color of the credential card, such as #eff0f1."`
+	BackgroundImage    *string                `json:"backgroundImage,omitempty" jsonschema:"OPTIONAL. The URL of the background image of the credential card."`
+	LogoImage          *string                `json:"logoImage,omitempty" jsonschema:"OPTIONAL. The URL of the logo image of the credential card."`
+	CredentialFields   []CredentialFieldInput `json:"credentialFields,omitempty" jsonschema:"OPTIONAL. The fields of credentials of the type."`
+	LastKnownUpdatedAt *time.Time             `json:"lastKnownUpdatedAt,omitempty" jsonschema:"OPTIONAL. The updatedAt of the credential type as last read. If the credential type has been updated since, it is not replaced and the call fails with a CONFLICT error."`
+}
+
+type UpdateCredentialTypeOutput struct {
+	CredentialType CredentialType `json:"credentialType" jsonschema:"The updated credential type"`
+}
+
+// UpdateCredentialTypeHandler replaces the configuration of a PingOne credential type using the provided client
+func UpdateCredentialTypeHandler(credentialsClientFactory CredentialsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateCredentialTypeInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateCredentialTypeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateCredentialTypeInput) (*mcp.CallToolResult, *UpdateCredentialTypeOutput, error) {
+		updateRequest, err := credentialTypeRequest(credentialTypeConfiguration{
+			title:              input.Title,
+			description:        input.Description,
+			cardType:           input.CardType,
+			issuerName:         input.IssuerName,
+			managementMode:     input.ManagementMode,
+			cardDesignTemplate: input.CardDesignTemplate,
+			cardColor:          input.CardColor,
+			textColor:          input.TextColor,
+			backgroundImage:    input.BackgroundImage,
+			logoImage:          input.LogoImage,
+			credentialFields:   input.CredentialFields,
+		})
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateCredentialTypeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := credentialsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateCredentialTypeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the current credential type, to keep the settings the tool does not set, to check it against the
+		// last known update and so that the change can be undone
+		previous, err := existingCredentialType(ctx, client, input.EnvironmentId, input.CredentialTypeId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if input.LastKnownUpdatedAt != nil {
+			if err := errs.CheckNotChangedSince("credential type", input.CredentialTypeId.String(), *input.LastKnownUpdatedAt, previous.UpdatedAt); err != nil {
+				toolErr := errs.NewToolError(UpdateCredentialTypeDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+		keepUnmanagedConfiguration(&updateRequest, *previous)
+
+		logger.FromContext(ctx).Debug("Updating credential type",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("credentialTypeId", input.CredentialTypeId.String()))
+
+		credentialType, httpResponse, err := client.UpdateCredentialType(ctx, input.EnvironmentId, input.CredentialTypeId, updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if credentialType == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no credential type data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          UpdateCredentialTypeDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "credential_type",
+			ResourceId:    input.CredentialTypeId.String(),
+			Description:   fmt.Sprintf("Restore the previous configuration of credential type %q", previous.Title),
+		}, undoUpdateCredentialType(credentialsClientFactory, input.EnvironmentId, input.CredentialTypeId, *previous, *credentialType))
+
+		return nil, &UpdateCredentialTypeOutput{
+			CredentialType: credentialTypeOutput(*credentialType),
+		}, nil
+	}
+}
+
+// undoUpdateCredentialType returns the function that restores the previous configuration of a credential type,
+// unless it has been changed again since
+func undoUpdateCredentialType(credentialsClientFactory CredentialsClientFactory, environmentId uuid.UUID, credentialTypeId uuid.UUID, previous credentialsapi.CredentialType, updated credentialsapi.CredentialType) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := credentialsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, err := existingCredentialType(ctx, client, environmentId, credentialTypeId)
+		if err != nil {
+			return err
+		}
+		if !force && !reflect.DeepEqual(credentialTypeReplacement(*current), credentialTypeReplacement(updated)) {
+			return rollback.ErrChangedSince
+		}
+
+		_, httpResponse, err := client.UpdateCredentialType(ctx, environmentId, credentialTypeId, credentialTypeReplacement(previous))
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
+
+// credentialTypeReplacement returns the configurable properties of a credential type, as a full replacement
+func credentialTypeReplacement(credentialType credentialsapi.CredentialType) credentialsapi.CredentialType {
+	metadata := credentialType.Metadata
+	metadata.Version = nil
+	return credentialsapi.CredentialType{
+		Title:              credentialType.Title,
+		Description:        credentialType.Description,
+		CardType:           credentialType.CardType,
+		IssuerName:         credentialType.IssuerName,
+		CardDesignTemplate: credentialType.CardDesignTemplate,
+		Management:         credentialType.Management,
+		Metadata:           metadata,
+		Expiration:         credentialType.Expiration,
+		Multiple:           credentialType.Multiple,
+		OnDelete:           credentialType.OnDelete,
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package credentials_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	credentialsapi "github.com/patrickcping/pingone-go-sdk-v2/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func updateCredentialTypeInput() credentials.UpdateCredentialTypeInput {
+	return credentials.UpdateCredentialTypeInput{
+		EnvironmentId:      testEnvironmentId,
+		CredentialTypeId:   testCredentialTypeId,
+		Title:              "Employee Badge",
+		CardDesignTemplate: `<svg xmlns="http://www.w3.org/2000/svg"><text>${fields[0].value}</text></svg>`,
+		CardColor:          testutils.Pointer("#1a1a1a"),
+		CredentialFields: []credentials.CredentialFieldInput{
+			{
+				Id:        "field-1",
+				Title:     "Name",
+				Type:      "Directory Attribute",
+				Attribute: testutils.Pointer("name.formatted"),
+				IsVisible: true,
+			},
+		},
+	}
+}
+
+// updatedCredentialType returns the test credential type after updateCredentialTypeInput is applied
+func updatedCredentialType() *credentialsapi.CredentialType {
+	credentialType := testCredentialType()
+	credentialType.Title = "Employee Badge"
+	credentialType.Description = nil
+	credentialType.CardType = nil
+	credentialType.Management = nil
+	credentialType.Metadata.CardColor = testutils.Pointer("#1a1a1a")
+	credentialType.Metadata.TextColor = nil
+	credentialType.Metadata.Version = testutils.Pointer(int32(4))
+	return credentialType
+}
+
+func TestUpdateCredentialTypeHandler_KeepsUnmanagedConfiguration(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(testCredentialType(), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.MatchedBy(func(request credentialsapi.CredentialType) bool {
+		return request.Title == "Employee Badge" &&
+			request.Description == nil &&
+			assert.ObjectsAreEqual(testCredentialType().Expiration, request.Expiration) &&
+			assert.ObjectsAreEqual(testutils.Pointer("Employee Card"), request.Metadata.Name) &&
+			assert.ObjectsAreEqual(testutils.Pointer(int32(1)), request.Metadata.Columns) &&
+			assert.ObjectsAreEqual(testutils.Pointer("#1a1a1a"), request.Metadata.CardColor)
+	})).Return(updatedCredentialType(), &http.Response{StatusCode: 200}, nil)
+
+	handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateCredentialTypeInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, "Employee Badge", output.CredentialType.Title)
+	assert.Equal(t, testutils.Pointer("#1a1a1a"), output.CredentialType.CardColor)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateCredentialTypeHandler_GetCredentialTypeAPIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateCredentialTypeInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertNotCalled(t, "UpdateCredentialType", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdateCredentialTypeHandler_UpdateAPIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(testCredentialType(), &http.Response{StatusCode: 200}, nil)
+			mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.Anything).Return(nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateCredentialTypeInput())
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateCredentialTypeHandler_InvalidFieldType(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	input := updateCredentialTypeInput()
+	input.CredentialFields[0].Type = "Image"
+
+	handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "invalid value 'Image' for EnumCredentialTypeMetaDataFieldsType")
+	mockClient.AssertNotCalled(t, "GetCredentialType", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateCredentialTypeHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, updateCredentialTypeInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestUpdateCredentialTypeHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(testCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.Anything).Return(updatedCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, updateCredentialTypeInput())
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// The previous configuration is restored without its read-only properties
+	mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(updatedCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.MatchedBy(func(request credentialsapi.CredentialType) bool {
+		return request.Id == nil && request.CreatedAt == nil && request.Metadata.Version == nil &&
+			request.Title == "Employee Card" &&
+			assert.ObjectsAreEqual(testutils.Pointer("Proof of employment"), request.Description) &&
+			assert.ObjectsAreEqual(testutils.Pointer("#eff0f1"), request.Metadata.TextColor)
+	})).Return(testCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+
+	change, err := journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, credentials.UpdateCredentialTypeDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateCredentialTypeHandler_UndoChangedSince(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(testCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.Anything).Return(updatedCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, updateCredentialTypeInput())
+	require.NoError(t, err)
+
+	// The title was changed again since, so the undo is refused without force
+	current := updatedCredentialType()
+	current.Title = "Staff Card"
+	current.Metadata.Version = testutils.Pointer(int32(5))
+	mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(current, &http.Response{StatusCode: 200}, nil).Once()
+
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateCredentialTypeHandler_UndoIgnoresVersion(t *testing.T) {
+	mockClient := &mockPingOneClientCredentialsWrapper{}
+	mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(testCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.Anything).Return(updatedCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, updateCredentialTypeInput())
+	require.NoError(t, err)
+
+	// Only the version differs, which PingOne sets on every update
+	current := updatedCredentialType()
+	current.Metadata.Version = testutils.Pointer(int32(9))
+	mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(current, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.Anything).Return(testCredentialType(), &http.Response{StatusCode: 200}, nil).Once()
+
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateCredentialTypeHandler_LastKnownUpdatedAt(t *testing.T) {
+	lastKnownUpdatedAt := time.Date(2025, 4, 1, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		currentUpdatedAt time.Time
+		wantReplaced     bool
+	}{
+		{
+			name:             "Replaces a credential type not changed since",
+			currentUpdatedAt: lastKnownUpdatedAt,
+			wantReplaced:     true,
+		},
+		{
+			name:             "Does not replace a credential type changed since",
+			currentUpdatedAt: lastKnownUpdatedAt.Add(time.Second),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			current := testCredentialType()
+			current.UpdatedAt = &tc.currentUpdatedAt
+			mockClient := &mockPingOneClientCredentialsWrapper{}
+			mockClient.On("GetCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId).Return(current, &http.Response{StatusCode: 200}, nil)
+			if tc.wantReplaced {
+				mockClient.On("UpdateCredentialType", mock.Anything, testEnvironmentId, testCredentialTypeId, mock.Anything).Return(updatedCredentialType(), &http.Response{StatusCode: 200}, nil)
+			}
+
+			input := updateCredentialTypeInput()
+			input.LastKnownUpdatedAt = &lastKnownUpdatedAt
+			handler := credentials.UpdateCredentialTypeHandler(NewMockPingOneClientCredentialsWrapperFactory(mockClient, nil))
+			_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			if tc.wantReplaced {
+				require.NoError(t, err)
+				assert.Equal(t, "Employee Badge", output.CredentialType.Title)
+			} else {
+				var conflictErr *errs.ConflictError
+				require.ErrorAs(t, err, &conflictErr)
+				assert.Equal(t, "credential type", conflictErr.ResourceType)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
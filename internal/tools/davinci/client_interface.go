@@ -0,0 +1,23 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+)
+
+type DaVinciClient interface {
+	GetEnvironmentServices(ctx context.Context, environmentId uuid.UUID) (*pingone.EnvironmentBillOfMaterialsResponse, *http.Response, error)
+	GetFlows(ctx context.Context, environmentId uuid.UUID) (*pingone.DaVinciFlowCollection, *http.Response, error)
+	GetFlow(ctx context.Context, environmentId uuid.UUID, flowId string) (*pingone.DaVinciFlowResponse, *http.Response, error)
+	GetApplications(ctx context.Context, environmentId uuid.UUID) (*pingone.DaVinciApplicationCollectionResponse, *http.Response, error)
+	GetFlowPolicies(ctx context.Context, environmentId uuid.UUID, applicationId string) (*pingone.DaVinciFlowPolicyCollection, *http.Response, error)
+}
+
+type DaVinciClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (DaVinciClient, error)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ DaVinciClient = &PingOneClientDaVinciWrapper{}
+var _ DaVinciClientFactory = &PingOneClientDaVinciWrapperFactory{}
+
+type PingOneClientDaVinciWrapper struct {
+	client *pingone.APIClient
+}
+
+type PingOneClientDaVinciWrapperFactory struct {
+	clientFactory sdk.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientDaVinciWrapper(client *pingone.APIClient) *PingOneClientDaVinciWrapper {
+	return &PingOneClientDaVinciWrapper{client: client}
+}
+
+func NewPingOneClientDaVinciWrapperFactory(clientFactory sdk.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientDaVinciWrapperFactory {
+	return &PingOneClientDaVinciWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientDaVinciWrapperFactory) GetAuthenticatedClient(ctx context.Context) (DaVinciClient, error) {
+	client, err := collections.InitializeAuthenticatedClient(f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientDaVinciWrapper(client), nil
+}
+
+func (p *PingOneClientDaVinciWrapper) GetEnvironmentServices(ctx context.Context, environmentId uuid.UUID) (*pingone.EnvironmentBillOfMaterialsResponse, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.EnvironmentsApi.GetBillOfMaterialsByEnvironmentId(ctx, environmentId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve environment services by ID",
+		slog.String("environmentId", environmentId.String()))
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientDaVinciWrapper) GetFlows(ctx context.Context, environmentId uuid.UUID) (*pingone.DaVinciFlowCollection, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.DaVinciFlowsApi.GetFlows(ctx, environmentId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to list DaVinci flows",
+		slog.String("environmentId", environmentId.String()))
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientDaVinciWrapper) GetFlow(ctx context.Context, environmentId uuid.UUID, flowId string) (*pingone.DaVinciFlowResponse, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.DaVinciFlowsApi.GetFlowById(ctx, environmentId, flowId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve DaVinci flow by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("flowId", flowId))
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientDaVinciWrapper) GetApplications(ctx context.Context, environmentId uuid.UUID) (*pingone.DaVinciApplicationCollectionResponse, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.DaVinciApplicationsApi.GetDavinciApplications(ctx, environmentId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to list DaVinci applications",
+		slog.String("environmentId", environmentId.String()))
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientDaVinciWrapper) GetFlowPolicies(ctx context.Context, environmentId uuid.UUID, applicationId string) (*pingone.DaVinciFlowPolicyCollection, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.DaVinciApplicationsApi.GetFlowPoliciesByDavinciApplicationId(ctx, environmentId, applicationId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to list DaVinci flow policies by application ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("applicationId", applicationId))
+	return getRequest.Execute()
+}
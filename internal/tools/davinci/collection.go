@@ -0,0 +1,63 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "davinci"
+
+var _ collections.Collection = &DaVinciCollection{}
+
+type DaVinciCollection struct{}
+
+func (c *DaVinciCollection) Name() string {
+	return CollectionName
+}
+
+func (c *DaVinciCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	daVinciClientFactory := NewPingOneClientDaVinciWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListDaVinciFlowsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListDaVinciFlowsDef.McpTool.Name))
+		mcp.AddTool(server, ListDaVinciFlowsDef.McpTool, ListDaVinciFlowsHandler(daVinciClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetDaVinciFlowDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetDaVinciFlowDef.McpTool.Name))
+		mcp.AddTool(server, GetDaVinciFlowDef.McpTool, GetDaVinciFlowHandler(daVinciClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListDaVinciFlowPoliciesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListDaVinciFlowPoliciesDef.McpTool.Name))
+		mcp.AddTool(server, ListDaVinciFlowPoliciesDef.McpTool, ListDaVinciFlowPoliciesHandler(daVinciClientFactory))
+	}
+
+	return nil
+}
+
+func (c *DaVinciCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListDaVinciFlowsDef,
+		GetDaVinciFlowDef,
+		ListDaVinciFlowPoliciesDef,
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+type FlowSummary struct {
+	Id                 string     `json:"id" jsonschema:"The ID of the flow"`
+	Name               string     `json:"name" jsonschema:"The name of the flow"`
+	Description        *string    `json:"description,omitempty" jsonschema:"The description of the flow"`
+	Enabled            *bool      `json:"enabled,omitempty" jsonschema:"Whether the flow is enabled"`
+	CurrentVersion     *float32   `json:"currentVersion,omitempty" jsonschema:"The version of the flow being edited"`
+	PublishedVersion   *float32   `json:"publishedVersion,omitempty" jsonschema:"The deployed version of the flow, used by flow policies"`
+	DeployedAt         *time.Time `json:"deployedAt,omitempty" jsonschema:"When the flow was last deployed"`
+	UpdatedAt          *time.Time `json:"updatedAt,omitempty" jsonschema:"When the flow was last updated"`
+	LinterErrorCount   *float32   `json:"linterErrorCount,omitempty" jsonschema:"The number of errors the DaVinci flow linter found in the flow"`
+	LinterWarningCount *float32   `json:"linterWarningCount,omitempty" jsonschema:"The number of warnings the DaVinci flow linter found in the flow"`
+}
+
+type FlowPolicy struct {
+	Id                string                                              `json:"id" jsonschema:"The ID of the flow policy"`
+	Name              string                                              `json:"name" jsonschema:"The name of the flow policy"`
+	Status            pingone.DaVinciFlowPolicyResponseStatus             `json:"status" jsonschema:"Whether the flow policy is enabled or disabled"`
+	ApplicationId     string                                              `json:"applicationId" jsonschema:"The ID of the DaVinci application the flow policy belongs to"`
+	ApplicationName   string                                              `json:"applicationName" jsonschema:"The name of the DaVinci application the flow policy belongs to"`
+	FlowDistributions []pingone.DaVinciFlowPolicyResponseFlowDistribution `json:"flowDistributions" jsonschema:"The flows the policy runs, each with the flow ID, flow version and weight"`
+	Trigger           *pingone.DaVinciFlowPolicyResponseTrigger           `json:"trigger,omitempty" jsonschema:"The trigger of the flow policy"`
+	UpdatedAt         *time.Time                                          `json:"updatedAt,omitempty" jsonschema:"When the flow policy was last updated"`
+}
+
+func flowSummary(flow pingone.DaVinciFlowResponse) FlowSummary {
+	return FlowSummary{
+		Id:                 flow.Id,
+		Name:               flow.Name,
+		Description:        flow.Description,
+		Enabled:            flow.Enabled,
+		CurrentVersion:     flow.CurrentVersion,
+		PublishedVersion:   flow.PublishedVersion,
+		DeployedAt:         flow.DeployedAt,
+		UpdatedAt:          flow.UpdatedAt,
+		LinterErrorCount:   flow.DvlinterErrorCount,
+		LinterWarningCount: flow.DvlinterWarningCount,
+	}
+}
+
+func flowPolicyOutput(application pingone.DaVinciApplicationResponse, flowPolicy pingone.DaVinciFlowPolicyResponse) FlowPolicy {
+	return FlowPolicy{
+		Id:                flowPolicy.Id,
+		Name:              flowPolicy.Name,
+		Status:            flowPolicy.Status,
+		ApplicationId:     application.Id,
+		ApplicationName:   application.Name,
+		FlowDistributions: flowPolicy.FlowDistributions,
+		Trigger:           flowPolicy.Trigger,
+		UpdatedAt:         flowPolicy.UpdatedAt,
+	}
+}
+
+// requireDaVinci checks that DaVinci is one of the services of the environment, so that environments without
+// it get a clear error rather than the error of the DaVinci API
+func requireDaVinci(ctx context.Context, client DaVinciClient, toolName string, environmentId uuid.UUID) error {
+	services, httpResponse, err := client.GetEnvironmentServices(ctx, environmentId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return apiErr
+	}
+	if services == nil {
+		apiErr := errs.NewApiError(httpResponse, errors.New("no environment services data in response"))
+		errs.Log(ctx, apiErr)
+		return apiErr
+	}
+	for _, product := range services.Products {
+		if product.Type == pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_DAVINCI {
+			return nil
+		}
+	}
+	toolErr := errs.NewToolError(toolName, fmt.Errorf("DaVinci is not one of the services of environment %s; use 'get_environment_services' to see the services of the environment", environmentId))
+	errs.Log(ctx, toolErr)
+	return toolErr
+}
+
+// flowDefinition returns the flow as JSON values, without its links. The SDK flow model is not returned, as its
+// settings do not match their generated schema.
+func flowDefinition(flow pingone.DaVinciFlowResponse) (map[string]any, error) {
+	flowJson, err := json.Marshal(flow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal DaVinci flow: %w", err)
+	}
+	var definition map[string]any
+	if err := json.Unmarshal(flowJson, &definition); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal DaVinci flow: %w", err)
+	}
+	delete(definition, "_links")
+	return definition, nil
+}
@@ -0,0 +1,74 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/davinci"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ davinci.DaVinciClient = &mockPingOneClientDaVinciWrapper{}
+var _ davinci.DaVinciClientFactory = &mockPingOneClientDaVinciWrapperFactory{}
+
+type mockPingOneClientDaVinciWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientDaVinciWrapperFactory struct {
+	mockClient davinci.DaVinciClient
+	err        error
+}
+
+// NewMockPingOneClientDaVinciWrapperFactory directly returns the provided mock client and error
+func NewMockPingOneClientDaVinciWrapperFactory(mockClient davinci.DaVinciClient, err error) *mockPingOneClientDaVinciWrapperFactory {
+	return &mockPingOneClientDaVinciWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientDaVinciWrapperFactory) GetAuthenticatedClient(ctx context.Context) (davinci.DaVinciClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientDaVinciWrapper) GetEnvironmentServices(ctx context.Context, environmentId uuid.UUID) (*pingone.EnvironmentBillOfMaterialsResponse, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return mockResponse[pingone.EnvironmentBillOfMaterialsResponse](args)
+}
+
+func (p *mockPingOneClientDaVinciWrapper) GetFlows(ctx context.Context, environmentId uuid.UUID) (*pingone.DaVinciFlowCollection, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return mockResponse[pingone.DaVinciFlowCollection](args)
+}
+
+func (p *mockPingOneClientDaVinciWrapper) GetFlow(ctx context.Context, environmentId uuid.UUID, flowId string) (*pingone.DaVinciFlowResponse, *http.Response, error) {
+	args := p.Called(ctx, environmentId, flowId)
+	return mockResponse[pingone.DaVinciFlowResponse](args)
+}
+
+func (p *mockPingOneClientDaVinciWrapper) GetApplications(ctx context.Context, environmentId uuid.UUID) (*pingone.DaVinciApplicationCollectionResponse, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return mockResponse[pingone.DaVinciApplicationCollectionResponse](args)
+}
+
+func (p *mockPingOneClientDaVinciWrapper) GetFlowPolicies(ctx context.Context, environmentId uuid.UUID, applicationId string) (*pingone.DaVinciFlowPolicyCollection, *http.Response, error) {
+	args := p.Called(ctx, environmentId, applicationId)
+	return mockResponse[pingone.DaVinciFlowPolicyCollection](args)
+}
+
+func mockResponse[T any](args mock.Arguments) (*T, *http.Response, error) {
+	var response *T
+	if args.Get(0) != nil {
+		response = args.Get(0).(*T)
+	}
+	var httpResponse *http.Response
+	if args.Get(1) != nil {
+		httpResponse = args.Get(1).(*http.Response)
+	}
+	return response, httpResponse, args.Error(2)
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/stretchr/testify/mock"
+)
+
+// Test data shared across all tests
+var (
+	testEnvId            = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	testFlowId           = "c6b3c2a1f0e94d8b9a7c6d5e4f3a2b10"
+	testOtherFlowId      = "c6b3c2a1f0e94d8b9a7c6d5e4f3a2b20"
+	testApplicationId    = "d7c4b3a2e1f04a9b8c7d6e5f4a3b2c10"
+	testOtherAppId       = "d7c4b3a2e1f04a9b8c7d6e5f4a3b2c20"
+	testFlowPolicyId     = "e8d5c4b3a2f14b0c9d8e7f6a5b4c3d10"
+	testOtherPolicyId    = "e8d5c4b3a2f14b0c9d8e7f6a5b4c3d20"
+	testFlowUpdatedAt    = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	testDaVinciProducts  = []pingone.EnvironmentBillOfMaterialsProduct{{Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE}, {Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_DAVINCI}}
+	testBaseOnlyProducts = []pingone.EnvironmentBillOfMaterialsProduct{{Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE}}
+)
+
+// mockEnvironmentServices configures the environment services returned for the DaVinci check
+func mockEnvironmentServices(m *mockPingOneClientDaVinciWrapper, products []pingone.EnvironmentBillOfMaterialsProduct) {
+	m.On("GetEnvironmentServices", mock.Anything, testEnvId).Return(&pingone.EnvironmentBillOfMaterialsResponse{Products: products}, &http.Response{StatusCode: 200}, nil)
+}
+
+// testFlow returns an enabled, deployed test flow
+func testFlow(id string, name string) pingone.DaVinciFlowResponse {
+	return pingone.DaVinciFlowResponse{
+		Id:                 id,
+		Name:               name,
+		Description:        testutils.Pointer("Registration and sign-on"),
+		Enabled:            testutils.Pointer(true),
+		CurrentVersion:     testutils.Pointer(float32(4)),
+		PublishedVersion:   testutils.Pointer(float32(3)),
+		UpdatedAt:          testutils.Pointer(testFlowUpdatedAt),
+		DvlinterErrorCount: testutils.Pointer(float32(1)),
+	}
+}
+
+func testApplication(id string, name string) pingone.DaVinciApplicationResponse {
+	return pingone.DaVinciApplicationResponse{
+		Id:   id,
+		Name: name,
+		ApiKey: pingone.DaVinciApplicationResponseApiKey{
+			Enabled: true,
+			Value:   "secret-api-key",
+		},
+	}
+}
+
+// testFlowPolicy returns an enabled flow policy that runs the given flow
+func testFlowPolicy(id string, name string, flowId string) pingone.DaVinciFlowPolicyResponse {
+	return pingone.DaVinciFlowPolicyResponse{
+		Id:     id,
+		Name:   name,
+		Status: pingone.DAVINCIFLOWPOLICYRESPONSESTATUS_ENABLED,
+		FlowDistributions: []pingone.DaVinciFlowPolicyResponseFlowDistribution{
+			{Id: flowId, Version: 3, Weight: testutils.Pointer(float32(100))},
+		},
+	}
+}
+
+func applicationsResponse(applications ...pingone.DaVinciApplicationResponse) *pingone.DaVinciApplicationCollectionResponse {
+	return &pingone.DaVinciApplicationCollectionResponse{
+		Embedded: pingone.DaVinciApplicationCollectionResponseEmbedded{DavinciApplications: applications},
+	}
+}
+
+func flowPoliciesResponse(flowPolicies ...pingone.DaVinciFlowPolicyResponse) *pingone.DaVinciFlowPolicyCollection {
+	return &pingone.DaVinciFlowPolicyCollection{
+		Embedded: pingone.DaVinciFlowPolicyCollectionEmbedded{FlowPolicies: flowPolicies},
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetDaVinciFlowDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "get_davinci_flow",
+		Title:        "Get DaVinci Flow by ID",
+		Description:  "Retrieve the full definition of a DaVinci flow: its graph of nodes and the connections between them, the connectors it uses, its settings, trigger, and input and output schemas, and the linter errors found in its nodes. Only applies to environments with the DaVinci service. Use 'list_davinci_flows' first if you need to find the flow ID. The definition can be large; use 'fields' to return only the attributes needed.",
+		InputSchema:  schema.MustGenerateSchema[GetDaVinciFlowInput](),
+		OutputSchema: schema.MustGenerateSchema[GetDaVinciFlowOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetDaVinciFlowInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	FlowId        string    `json:"flowId" jsonschema:"REQUIRED. DaVinci flow ID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'flow.name' and 'flow.graphData.elements.nodes'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetDaVinciFlowOutput struct {
+	Flow map[string]any `json:"flow" jsonschema:"The DaVinci flow definition, including its graph data, settings and trigger"`
+}
+
+// GetDaVinciFlowHandler retrieves the definition of a DaVinci flow using the provided client
+func GetDaVinciFlowHandler(daVinciClientFactory DaVinciClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetDaVinciFlowInput,
+) (
+	*mcp.CallToolResult,
+	*GetDaVinciFlowOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetDaVinciFlowInput) (*mcp.CallToolResult, *GetDaVinciFlowOutput, error) {
+		if input.FlowId == "" {
+			toolErr := errs.NewToolError(GetDaVinciFlowDef.McpTool.Name, errors.New("flowId is required"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := daVinciClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetDaVinciFlowDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if err := requireDaVinci(ctx, client, GetDaVinciFlowDef.McpTool.Name, input.EnvironmentId); err != nil {
+			return nil, nil, err
+		}
+
+		logger.FromContext(ctx).Debug("Retrieving DaVinci flow",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("flowId", input.FlowId))
+
+		flow, httpResponse, err := client.GetFlow(ctx, input.EnvironmentId, input.FlowId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if flow == nil {
+			apiErr := errs.NewApiError(httpResponse, errors.New("no flow data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		definition, err := flowDefinition(*flow)
+		if err != nil {
+			toolErr := errs.NewToolError(GetDaVinciFlowDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &GetDaVinciFlowOutput{
+			Flow: definition,
+		}, nil
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/davinci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDaVinciFlowHandler(t *testing.T) {
+	flow := testFlow(testFlowId, "Registration")
+	flow.Trigger = &pingone.DaVinciFlowTriggerResponse{Type: pingone.DAVINCIFLOWTRIGGERRESPONSETYPE_AUTHENTICATION}
+	flow.Settings = &pingone.DaVinciFlowSettingsResponse{UseCSP: &pingone.DaVinciFlowSettingsResponseUseCSP{Bool: testutils.Pointer(true)}}
+	mockClient := &mockPingOneClientDaVinciWrapper{}
+	mockEnvironmentServices(mockClient, testDaVinciProducts)
+	mockClient.On("GetFlow", mock.Anything, testEnvId, testFlowId).Return(&flow, &http.Response{StatusCode: 200}, nil)
+
+	handler := davinci.GetDaVinciFlowHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.GetDaVinciFlowInput{
+		EnvironmentId: testEnvId,
+		FlowId:        testFlowId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testFlowId, output.Flow["id"])
+	assert.Equal(t, "Registration", output.Flow["name"])
+	assert.Equal(t, map[string]any{"type": "AUTHENTICATION"}, output.Flow["trigger"])
+	assert.Equal(t, map[string]any{"useCSP": true}, output.Flow["settings"])
+	assert.NotContains(t, output.Flow, "_links")
+	mockClient.AssertExpectations(t)
+
+	// The output is checked against the output schema before it is returned to the client
+	resolved, err := davinci.GetDaVinciFlowDef.McpTool.OutputSchema.(*jsonschema.Schema).Resolve(nil)
+	require.NoError(t, err)
+	outputJson, err := json.Marshal(output)
+	require.NoError(t, err)
+	var value any
+	require.NoError(t, json.Unmarshal(outputJson, &value))
+	assert.NoError(t, resolved.Validate(value))
+}
+
+func TestGetDaVinciFlowHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		flowId          string
+		setupMock       func(mockClient *mockPingOneClientDaVinciWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Missing flow ID",
+			flowId:          "",
+			setupMock:       func(mockClient *mockPingOneClientDaVinciWrapper) {},
+			wantErrContains: "flowId is required",
+		},
+		{
+			name:   "DaVinci not in environment services",
+			flowId: testFlowId,
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testBaseOnlyProducts)
+			},
+			wantErrContains: "DaVinci is not one of the services of environment",
+		},
+		{
+			name:   "API error",
+			flowId: testFlowId,
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockClient.On("GetFlow", mock.Anything, testEnvId, testFlowId).Return(nil, &http.Response{StatusCode: 404}, errors.New("flow not found"))
+			},
+			wantErrContains: "flow not found",
+		},
+		{
+			name:   "No flow in response",
+			flowId: testFlowId,
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockClient.On("GetFlow", mock.Anything, testEnvId, testFlowId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no flow data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientDaVinciWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := davinci.GetDaVinciFlowHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.GetDaVinciFlowInput{
+				EnvironmentId: testEnvId,
+				FlowId:        tt.flowId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetDaVinciFlowHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := davinci.GetDaVinciFlowHandler(NewMockPingOneClientDaVinciWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.GetDaVinciFlowInput{
+		EnvironmentId: testEnvId,
+		FlowId:        testFlowId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,128 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListDaVinciFlowPoliciesDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_davinci_flow_policies",
+		Title:        "List DaVinci Flow Policies",
+		Description:  "Lists the flow policies of the DaVinci applications of an environment, with the flows and flow versions each policy runs. Only applies to environments with the DaVinci service. Filter by DaVinci application, or by flow to find the policies that run a flow, such as to check which version of a flow users are given.",
+		InputSchema:  schema.MustGenerateSchema[ListDaVinciFlowPoliciesInput](),
+		OutputSchema: schema.MustGenerateSchema[ListDaVinciFlowPoliciesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListDaVinciFlowPoliciesInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ApplicationId *string   `json:"applicationId,omitempty" jsonschema:"OPTIONAL. DaVinci application ID. Only the flow policies of the application are returned. Defaults to the flow policies of every DaVinci application."`
+	FlowId        *string   `json:"flowId,omitempty" jsonschema:"OPTIONAL. DaVinci flow ID. Only the flow policies that run the flow are returned."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'flowPolicies.id' and 'flowPolicies.flowDistributions'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListDaVinciFlowPoliciesOutput struct {
+	FlowPolicies []FlowPolicy `json:"flowPolicies" jsonschema:"The flow policies of the DaVinci applications"`
+}
+
+// ListDaVinciFlowPoliciesHandler lists the flow policies of the DaVinci applications of an environment using the
+// provided client
+func ListDaVinciFlowPoliciesHandler(daVinciClientFactory DaVinciClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListDaVinciFlowPoliciesInput,
+) (
+	*mcp.CallToolResult,
+	*ListDaVinciFlowPoliciesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListDaVinciFlowPoliciesInput) (*mcp.CallToolResult, *ListDaVinciFlowPoliciesOutput, error) {
+		client, err := daVinciClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListDaVinciFlowPoliciesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if err := requireDaVinci(ctx, client, ListDaVinciFlowPoliciesDef.McpTool.Name, input.EnvironmentId); err != nil {
+			return nil, nil, err
+		}
+
+		logger.FromContext(ctx).Debug("Listing DaVinci flow policies", slog.String("environmentId", input.EnvironmentId.String()))
+
+		applications, httpResponse, err := client.GetApplications(ctx, input.EnvironmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if applications == nil {
+			apiErr := errs.NewApiError(httpResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		selected := applications.Embedded.DavinciApplications
+		if input.ApplicationId != nil {
+			index := slices.IndexFunc(selected, func(application pingone.DaVinciApplicationResponse) bool {
+				return application.Id == *input.ApplicationId
+			})
+			if index < 0 {
+				toolErr := errs.NewToolError(ListDaVinciFlowPoliciesDef.McpTool.Name, fmt.Errorf("DaVinci application %q not found", *input.ApplicationId))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			selected = selected[index : index+1]
+		}
+
+		result := &ListDaVinciFlowPoliciesOutput{
+			FlowPolicies: []FlowPolicy{},
+		}
+		for _, application := range selected {
+			flowPolicies, httpResponse, err := client.GetFlowPolicies(ctx, input.EnvironmentId, application.Id)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if flowPolicies == nil {
+				apiErr := errs.NewApiError(httpResponse, errors.New("no data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+
+			for _, flowPolicy := range flowPolicies.Embedded.FlowPolicies {
+				if input.FlowId != nil && !slices.ContainsFunc(flowPolicy.FlowDistributions, func(distribution pingone.DaVinciFlowPolicyResponseFlowDistribution) bool {
+					return distribution.Id == *input.FlowId
+				}) {
+					continue
+				}
+				result.FlowPolicies = append(result.FlowPolicies, flowPolicyOutput(application, flowPolicy))
+			}
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/davinci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockTwoApplications configures two DaVinci applications, each with one flow policy running a different flow
+func mockTwoApplications(m *mockPingOneClientDaVinciWrapper) {
+	m.On("GetApplications", mock.Anything, testEnvId).Return(applicationsResponse(
+		testApplication(testApplicationId, "Customer Portal"),
+		testApplication(testOtherAppId, "Partner Portal"),
+	), &http.Response{StatusCode: 200}, nil)
+	m.On("GetFlowPolicies", mock.Anything, testEnvId, testApplicationId).Return(flowPoliciesResponse(
+		testFlowPolicy(testFlowPolicyId, "Sign On", testFlowId),
+	), &http.Response{StatusCode: 200}, nil).Maybe()
+	m.On("GetFlowPolicies", mock.Anything, testEnvId, testOtherAppId).Return(flowPoliciesResponse(
+		testFlowPolicy(testOtherPolicyId, "Partner Sign On", testOtherFlowId),
+	), &http.Response{StatusCode: 200}, nil).Maybe()
+}
+
+func TestListDaVinciFlowPoliciesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientDaVinciWrapper{}
+	mockEnvironmentServices(mockClient, testDaVinciProducts)
+	mockTwoApplications(mockClient)
+
+	handler := davinci.ListDaVinciFlowPoliciesHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.ListDaVinciFlowPoliciesInput{
+		EnvironmentId: testEnvId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.FlowPolicies, 2)
+	policy := output.FlowPolicies[0]
+	assert.Equal(t, testFlowPolicyId, policy.Id)
+	assert.Equal(t, "Sign On", policy.Name)
+	assert.Equal(t, testApplicationId, policy.ApplicationId)
+	assert.Equal(t, "Customer Portal", policy.ApplicationName)
+	require.Len(t, policy.FlowDistributions, 1)
+	assert.Equal(t, testFlowId, policy.FlowDistributions[0].Id)
+	assert.Equal(t, "Partner Portal", output.FlowPolicies[1].ApplicationName)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListDaVinciFlowPoliciesHandler_Filters(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            davinci.ListDaVinciFlowPoliciesInput
+		expectedPolicyId string
+	}{
+		{
+			name:             "By application",
+			input:            davinci.ListDaVinciFlowPoliciesInput{ApplicationId: testutils.Pointer(testOtherAppId)},
+			expectedPolicyId: testOtherPolicyId,
+		},
+		{
+			name:             "By flow",
+			input:            davinci.ListDaVinciFlowPoliciesInput{FlowId: testutils.Pointer(testFlowId)},
+			expectedPolicyId: testFlowPolicyId,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientDaVinciWrapper{}
+			mockEnvironmentServices(mockClient, testDaVinciProducts)
+			mockTwoApplications(mockClient)
+			tt.input.EnvironmentId = testEnvId
+
+			handler := davinci.ListDaVinciFlowPoliciesHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			require.Len(t, output.FlowPolicies, 1)
+			assert.Equal(t, tt.expectedPolicyId, output.FlowPolicies[0].Id)
+		})
+	}
+}
+
+func TestListDaVinciFlowPoliciesHandler_ApplicationFilterSkipsOtherApplications(t *testing.T) {
+	mockClient := &mockPingOneClientDaVinciWrapper{}
+	mockEnvironmentServices(mockClient, testDaVinciProducts)
+	mockTwoApplications(mockClient)
+
+	handler := davinci.ListDaVinciFlowPoliciesHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+	_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.ListDaVinciFlowPoliciesInput{
+		EnvironmentId: testEnvId,
+		ApplicationId: testutils.Pointer(testApplicationId),
+	})
+
+	require.NoError(t, err)
+	mockClient.AssertNotCalled(t, "GetFlowPolicies", mock.Anything, testEnvId, testOtherAppId)
+}
+
+func TestListDaVinciFlowPoliciesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           davinci.ListDaVinciFlowPoliciesInput
+		setupMock       func(mockClient *mockPingOneClientDaVinciWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "DaVinci not in environment services",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testBaseOnlyProducts)
+			},
+			wantErrContains: "DaVinci is not one of the services of environment",
+		},
+		{
+			name: "Applications API error",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockClient.On("GetApplications", mock.Anything, testEnvId).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+		{
+			name:  "Application not found",
+			input: davinci.ListDaVinciFlowPoliciesInput{ApplicationId: testutils.Pointer("unknown")},
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockTwoApplications(mockClient)
+			},
+			wantErrContains: `DaVinci application "unknown" not found`,
+		},
+		{
+			name: "Flow policies API error",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockClient.On("GetApplications", mock.Anything, testEnvId).Return(applicationsResponse(testApplication(testApplicationId, "Customer Portal")), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetFlowPolicies", mock.Anything, testEnvId, testApplicationId).Return(nil, &http.Response{StatusCode: 500}, errors.New("internal error"))
+			},
+			wantErrContains: "internal error",
+		},
+		{
+			name: "No flow policies in response",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockClient.On("GetApplications", mock.Anything, testEnvId).Return(applicationsResponse(testApplication(testApplicationId, "Customer Portal")), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetFlowPolicies", mock.Anything, testEnvId, testApplicationId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientDaVinciWrapper{}
+			tt.setupMock(mockClient)
+			tt.input.EnvironmentId = testEnvId
+
+			handler := davinci.ListDaVinciFlowPoliciesHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListDaVinciFlowPoliciesHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := davinci.ListDaVinciFlowPoliciesHandler(NewMockPingOneClientDaVinciWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.ListDaVinciFlowPoliciesInput{
+		EnvironmentId: testEnvId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,89 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListDaVinciFlowsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_davinci_flows",
+		Title:        "List DaVinci Flows",
+		Description:  "Lists the DaVinci flows of an environment with whether each is enabled, its current and deployed versions, and the number of errors and warnings the DaVinci flow linter found. Only applies to environments with the DaVinci service. Use to discover flow IDs for 'get_davinci_flow', or to find flows with linter errors when debugging orchestration issues.",
+		InputSchema:  schema.MustGenerateSchema[ListDaVinciFlowsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListDaVinciFlowsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListDaVinciFlowsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'flows.id' and 'flows.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListDaVinciFlowsOutput struct {
+	Flows []FlowSummary `json:"flows" jsonschema:"The DaVinci flows of the environment"`
+}
+
+// ListDaVinciFlowsHandler lists the DaVinci flows of an environment using the provided client
+func ListDaVinciFlowsHandler(daVinciClientFactory DaVinciClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListDaVinciFlowsInput,
+) (
+	*mcp.CallToolResult,
+	*ListDaVinciFlowsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListDaVinciFlowsInput) (*mcp.CallToolResult, *ListDaVinciFlowsOutput, error) {
+		client, err := daVinciClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListDaVinciFlowsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if err := requireDaVinci(ctx, client, ListDaVinciFlowsDef.McpTool.Name, input.EnvironmentId); err != nil {
+			return nil, nil, err
+		}
+
+		logger.FromContext(ctx).Debug("Listing DaVinci flows", slog.String("environmentId", input.EnvironmentId.String()))
+
+		flows, httpResponse, err := client.GetFlows(ctx, input.EnvironmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if flows == nil {
+			apiErr := errs.NewApiError(httpResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		result := &ListDaVinciFlowsOutput{
+			Flows: []FlowSummary{},
+		}
+		for _, flow := range flows.Embedded.Flows {
+			result.Flows = append(result.Flows, flowSummary(flow))
+		}
+
+		return nil, result, nil
+	}
+}
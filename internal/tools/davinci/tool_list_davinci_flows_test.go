@@ -0,0 +1,130 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package davinci_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/davinci"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListDaVinciFlowsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientDaVinciWrapper{}
+	mockEnvironmentServices(mockClient, testDaVinciProducts)
+	mockClient.On("GetFlows", mock.Anything, testEnvId).Return(&pingone.DaVinciFlowCollection{
+		Embedded: pingone.DaVinciFlowCollectionEmbedded{Flows: []pingone.DaVinciFlowResponse{testFlow(testFlowId, "Registration")}},
+	}, &http.Response{StatusCode: 200}, nil)
+
+	handler := davinci.ListDaVinciFlowsHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.ListDaVinciFlowsInput{
+		EnvironmentId: testEnvId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.Flows, 1)
+	assert.Equal(t, davinci.FlowSummary{
+		Id:               testFlowId,
+		Name:             "Registration",
+		Description:      testutils.Pointer("Registration and sign-on"),
+		Enabled:          testutils.Pointer(true),
+		CurrentVersion:   testutils.Pointer(float32(4)),
+		PublishedVersion: testutils.Pointer(float32(3)),
+		UpdatedAt:        testutils.Pointer(testFlowUpdatedAt),
+		LinterErrorCount: testutils.Pointer(float32(1)),
+	}, output.Flows[0])
+	mockClient.AssertExpectations(t)
+}
+
+func TestListDaVinciFlowsHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientDaVinciWrapper{}
+	mockEnvironmentServices(mockClient, testDaVinciProducts)
+	mockClient.On("GetFlows", mock.Anything, testEnvId).Return(&pingone.DaVinciFlowCollection{}, &http.Response{StatusCode: 200}, nil)
+
+	handler := davinci.ListDaVinciFlowsHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.ListDaVinciFlowsInput{
+		EnvironmentId: testEnvId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Flows)
+	assert.Empty(t, output.Flows)
+}
+
+func TestListDaVinciFlowsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientDaVinciWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "DaVinci not in environment services",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testBaseOnlyProducts)
+			},
+			wantErrContains: "DaVinci is not one of the services of environment",
+		},
+		{
+			name: "Environment services error",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockClient.On("GetEnvironmentServices", mock.Anything, testEnvId).Return(nil, &http.Response{StatusCode: 404}, errors.New("environment not found"))
+			},
+			wantErrContains: "environment not found",
+		},
+		{
+			name: "No environment services in response",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockClient.On("GetEnvironmentServices", mock.Anything, testEnvId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no environment services data in response",
+		},
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockClient.On("GetFlows", mock.Anything, testEnvId).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+		{
+			name: "No flows in response",
+			setupMock: func(mockClient *mockPingOneClientDaVinciWrapper) {
+				mockEnvironmentServices(mockClient, testDaVinciProducts)
+				mockClient.On("GetFlows", mock.Anything, testEnvId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientDaVinciWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := davinci.ListDaVinciFlowsHandler(NewMockPingOneClientDaVinciWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.ListDaVinciFlowsInput{
+				EnvironmentId: testEnvId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListDaVinciFlowsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := davinci.ListDaVinciFlowsHandler(NewMockPingOneClientDaVinciWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, davinci.ListDaVinciFlowsInput{
+		EnvironmentId: testEnvId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
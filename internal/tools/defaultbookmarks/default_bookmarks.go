@@ -0,0 +1,98 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultbookmarks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pingidentity/pingone-go-client/pingone"
+)
+
+// MaxBookmarksPerProduct is the maximum number of bookmarks PingOne accepts for a single Bill of Materials product.
+const MaxBookmarksPerProduct = 5
+
+// DefaultBookmarks maps a Bill of Materials product type to the bookmarks that should be added
+// to that product whenever an environment is created or its services are updated.
+type DefaultBookmarks map[pingone.EnvironmentBillOfMaterialsProductType][]pingone.EnvironmentBillOfMaterialsProductBookmark
+
+// LoadDefaultBookmarks reads default bookmarks from a JSON file. An empty path returns no default bookmarks.
+func LoadDefaultBookmarks(path string) (DefaultBookmarks, error) {
+	if path == "" {
+		return DefaultBookmarks{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read default bookmarks file: %w", err)
+	}
+
+	return ParseDefaultBookmarks(data)
+}
+
+// ParseDefaultBookmarks parses default bookmarks from JSON in the form:
+//
+//	{"PING_ONE_BASE": [{"name": "Runbook", "href": "https://example.com/runbook"}]}
+//
+// Product types, bookmark fields and the per-product limit are validated so that misconfiguration
+// is reported when the server starts rather than when an environment is created.
+func ParseDefaultBookmarks(data []byte) (DefaultBookmarks, error) {
+	var raw map[string][]pingone.EnvironmentBillOfMaterialsProductBookmark
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid default bookmarks: %w", err)
+	}
+
+	defaultBookmarks := make(DefaultBookmarks, len(raw))
+	for productTypeValue, bookmarks := range raw {
+		productType, err := pingone.NewEnvironmentBillOfMaterialsProductTypeFromValue(productTypeValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default bookmarks for unknown product type %q", productTypeValue)
+		}
+		if len(bookmarks) > MaxBookmarksPerProduct {
+			return nil, fmt.Errorf("invalid default bookmarks for product type %q, a maximum of %d bookmarks can be specified per product", productTypeValue, MaxBookmarksPerProduct)
+		}
+		for _, bookmark := range bookmarks {
+			if strings.TrimSpace(bookmark.Name) == "" || strings.TrimSpace(bookmark.Href) == "" {
+				return nil, fmt.Errorf("invalid default bookmarks for product type %q, each bookmark requires a name and href", productTypeValue)
+			}
+		}
+		defaultBookmarks[*productType] = bookmarks
+	}
+
+	return defaultBookmarks, nil
+}
+
+// Merge returns the bookmarks for a product with the default bookmarks for the given product types appended.
+// Bookmarks already present, matched by name or href, are not duplicated and the existing bookmarks are
+// always retained. Default bookmarks that would exceed MaxBookmarksPerProduct are dropped, and reported
+// in the second return value.
+func (d DefaultBookmarks) Merge(existing []pingone.EnvironmentBillOfMaterialsProductBookmark, productTypes ...pingone.EnvironmentBillOfMaterialsProductType) ([]pingone.EnvironmentBillOfMaterialsProductBookmark, []pingone.EnvironmentBillOfMaterialsProductBookmark) {
+	merged := append([]pingone.EnvironmentBillOfMaterialsProductBookmark{}, existing...)
+	var dropped []pingone.EnvironmentBillOfMaterialsProductBookmark
+
+	for _, productType := range productTypes {
+		for _, bookmark := range d[productType] {
+			if containsBookmark(merged, bookmark) {
+				continue
+			}
+			if len(merged) >= MaxBookmarksPerProduct {
+				dropped = append(dropped, bookmark)
+				continue
+			}
+			merged = append(merged, bookmark)
+		}
+	}
+
+	return merged, dropped
+}
+
+func containsBookmark(bookmarks []pingone.EnvironmentBillOfMaterialsProductBookmark, bookmark pingone.EnvironmentBillOfMaterialsProductBookmark) bool {
+	for _, existing := range bookmarks {
+		if strings.EqualFold(existing.Name, bookmark.Name) || existing.Href == bookmark.Href {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultbookmarks_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultbookmarks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bookmark(name, href string) pingone.EnvironmentBillOfMaterialsProductBookmark {
+	return pingone.EnvironmentBillOfMaterialsProductBookmark{Name: name, Href: href}
+}
+
+// bookmarkPairs returns the name and href of each bookmark, ignoring additional properties set on unmarshal
+func bookmarkPairs(bookmarks []pingone.EnvironmentBillOfMaterialsProductBookmark) [][2]string {
+	pairs := make([][2]string, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		pairs = append(pairs, [2]string{b.Name, b.Href})
+	}
+	return pairs
+}
+
+func assertDefaultBookmarksEqual(t *testing.T, expected, actual defaultbookmarks.DefaultBookmarks) {
+	t.Helper()
+	require.Len(t, actual, len(expected))
+	for productType, bookmarks := range expected {
+		assert.Equal(t, bookmarkPairs(bookmarks), bookmarkPairs(actual[productType]), "Bookmarks for %s should match", productType)
+	}
+}
+
+func TestParseDefaultBookmarks(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expected        defaultbookmarks.DefaultBookmarks
+		wantErrContains string
+	}{
+		{
+			name:     "Empty object",
+			input:    `{}`,
+			expected: defaultbookmarks.DefaultBookmarks{},
+		},
+		{
+			name:  "Bookmarks for multiple products",
+			input: `{"PING_ONE_BASE": [{"name": "Runbook", "href": "https://example.com/runbook"}], "PING_ONE_DAVINCI": [{"name": "Flow Guide", "href": "https://example.com/flows"}]}`,
+			expected: defaultbookmarks.DefaultBookmarks{
+				pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE:    {bookmark("Runbook", "https://example.com/runbook")},
+				pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_DAVINCI: {bookmark("Flow Guide", "https://example.com/flows")},
+			},
+		},
+		{
+			name:            "Invalid JSON",
+			input:           `not json`,
+			wantErrContains: "invalid default bookmarks",
+		},
+		{
+			name:            "Unknown product type",
+			input:           `{"PING_ONE_UNKNOWN": [{"name": "Runbook", "href": "https://example.com/runbook"}]}`,
+			wantErrContains: `invalid default bookmarks for unknown product type "PING_ONE_UNKNOWN"`,
+		},
+		{
+			name:            "Missing name",
+			input:           `{"PING_ONE_BASE": [{"name": " ", "href": "https://example.com/runbook"}]}`,
+			wantErrContains: "each bookmark requires a name and href",
+		},
+		{
+			name: "Too many bookmarks",
+			input: `{"PING_ONE_BASE": [
+				{"name": "1", "href": "https://example.com/1"},
+				{"name": "2", "href": "https://example.com/2"},
+				{"name": "3", "href": "https://example.com/3"},
+				{"name": "4", "href": "https://example.com/4"},
+				{"name": "5", "href": "https://example.com/5"},
+				{"name": "6", "href": "https://example.com/6"}
+			]}`,
+			wantErrContains: "a maximum of 5 bookmarks can be specified per product",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := defaultbookmarks.ParseDefaultBookmarks([]byte(tt.input))
+
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			assertDefaultBookmarksEqual(t, tt.expected, result)
+		})
+	}
+}
+
+func TestLoadDefaultBookmarks(t *testing.T) {
+	t.Run("Empty path returns no bookmarks", func(t *testing.T) {
+		result, err := defaultbookmarks.LoadDefaultBookmarks("")
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Reads bookmarks from file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "bookmarks.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"PING_ONE_BASE": [{"name": "Runbook", "href": "https://example.com/runbook"}]}`), 0600))
+
+		result, err := defaultbookmarks.LoadDefaultBookmarks(path)
+		require.NoError(t, err)
+		assertDefaultBookmarksEqual(t, defaultbookmarks.DefaultBookmarks{
+			pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE: {bookmark("Runbook", "https://example.com/runbook")},
+		}, result)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		result, err := defaultbookmarks.LoadDefaultBookmarks(filepath.Join(t.TempDir(), "missing.json"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read default bookmarks file")
+		assert.Nil(t, result)
+	})
+}
+
+func TestDefaultBookmarks_Merge(t *testing.T) {
+	defaults := defaultbookmarks.DefaultBookmarks{
+		pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE: {
+			bookmark("Runbook", "https://example.com/runbook"),
+			bookmark("Support", "https://example.com/support"),
+		},
+		pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_VERIFY: {
+			bookmark("Verify Guide", "https://example.com/verify"),
+		},
+		pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_CREDENTIALS: {
+			bookmark("Credentials Guide", "https://example.com/credentials"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		existing        []pingone.EnvironmentBillOfMaterialsProductBookmark
+		productTypes    []pingone.EnvironmentBillOfMaterialsProductType
+		expected        []pingone.EnvironmentBillOfMaterialsProductBookmark
+		expectedDropped int
+	}{
+		{
+			name:         "Defaults added when no bookmarks exist",
+			productTypes: []pingone.EnvironmentBillOfMaterialsProductType{pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE},
+			expected: []pingone.EnvironmentBillOfMaterialsProductBookmark{
+				bookmark("Runbook", "https://example.com/runbook"),
+				bookmark("Support", "https://example.com/support"),
+			},
+		},
+		{
+			name:         "Existing bookmarks retained and duplicates skipped",
+			existing:     []pingone.EnvironmentBillOfMaterialsProductBookmark{bookmark("runbook", "https://example.com/custom-runbook")},
+			productTypes: []pingone.EnvironmentBillOfMaterialsProductType{pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE},
+			expected: []pingone.EnvironmentBillOfMaterialsProductBookmark{
+				bookmark("runbook", "https://example.com/custom-runbook"),
+				bookmark("Support", "https://example.com/support"),
+			},
+		},
+		{
+			name:         "Defaults for multiple product types combined",
+			productTypes: []pingone.EnvironmentBillOfMaterialsProductType{pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_VERIFY, pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_CREDENTIALS},
+			expected: []pingone.EnvironmentBillOfMaterialsProductBookmark{
+				bookmark("Verify Guide", "https://example.com/verify"),
+				bookmark("Credentials Guide", "https://example.com/credentials"),
+			},
+		},
+		{
+			name: "Defaults dropped when limit reached",
+			existing: []pingone.EnvironmentBillOfMaterialsProductBookmark{
+				bookmark("1", "https://example.com/1"),
+				bookmark("2", "https://example.com/2"),
+				bookmark("3", "https://example.com/3"),
+				bookmark("4", "https://example.com/4"),
+			},
+			productTypes: []pingone.EnvironmentBillOfMaterialsProductType{pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE},
+			expected: []pingone.EnvironmentBillOfMaterialsProductBookmark{
+				bookmark("1", "https://example.com/1"),
+				bookmark("2", "https://example.com/2"),
+				bookmark("3", "https://example.com/3"),
+				bookmark("4", "https://example.com/4"),
+				bookmark("Runbook", "https://example.com/runbook"),
+			},
+			expectedDropped: 1,
+		},
+		{
+			name:         "Product type without defaults unchanged",
+			existing:     []pingone.EnvironmentBillOfMaterialsProductBookmark{bookmark("Custom", "https://example.com/custom")},
+			productTypes: []pingone.EnvironmentBillOfMaterialsProductType{pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_DAVINCI},
+			expected:     []pingone.EnvironmentBillOfMaterialsProductBookmark{bookmark("Custom", "https://example.com/custom")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, dropped := defaults.Merge(tt.existing, tt.productTypes...)
+			assert.Equal(t, tt.expected, merged)
+			assert.Len(t, dropped, tt.expectedDropped)
+		})
+	}
+}
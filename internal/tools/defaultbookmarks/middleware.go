@@ -0,0 +1,258 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultbookmarks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+)
+
+// DefaultBookmarksMiddleware adds operator-configured default bookmarks to the Bill of Materials products
+// of environment create and service update tool calls, so that environments created or modified by the AI
+// carry the organization's standard console links.
+//
+// Only products included in the tool call receive default bookmarks. An environment created without a
+// Bill of Materials receives the default bookmarks of the products of the PingOne default Bill of Materials
+// once it has been created.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type DefaultBookmarksMiddleware struct {
+	defaultBookmarks DefaultBookmarks
+}
+
+// NewDefaultBookmarksMiddleware creates middleware from the default bookmarks returned by LoadDefaultBookmarks.
+func NewDefaultBookmarksMiddleware(defaultBookmarks DefaultBookmarks) *DefaultBookmarksMiddleware {
+	return &DefaultBookmarksMiddleware{
+		defaultBookmarks: defaultBookmarks,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *DefaultBookmarksMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || len(m.defaultBookmarks) == 0 {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		toolName := callToolReq.Params.Name
+		var arguments json.RawMessage
+		var err error
+		switch toolName {
+		case environments.CreateEnvironmentDef.McpTool.Name:
+			arguments, err = m.applyToCreateEnvironment(ctx, callToolReq.Params.Arguments)
+			if err == nil && !hasBillOfMaterials(arguments) {
+				ctx = environments.ContextWithDefaultServicesChange(ctx, m.defaultServicesChange(ctx))
+			}
+		case environments.UpdateEnvironmentServicesDef.McpTool.Name:
+			arguments, err = m.applyToUpdateEnvironmentServices(ctx, callToolReq.Params.Arguments)
+		case environments.AddEnvironmentServiceDef.McpTool.Name:
+			arguments, err = m.applyToAddEnvironmentService(ctx, callToolReq.Params.Arguments)
+		default:
+			return next(ctx, method, req)
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to apply default bookmarks",
+				slog.String("tool", toolName),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("default bookmarks failed: %w", err)
+		}
+		callToolReq.Params.Arguments = arguments
+
+		logger.FromContext(ctx).Debug("Applied default bookmarks to tool call", slog.String("tool", toolName))
+
+		return next(ctx, method, req)
+	}
+}
+
+// applyToCreateEnvironment adds default bookmarks to each product of the billOfMaterials argument.
+func (m *DefaultBookmarksMiddleware) applyToCreateEnvironment(ctx context.Context, argsJSON json.RawMessage) (json.RawMessage, error) {
+	args, err := unmarshalArguments(argsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	billOfMaterials, ok := args["billOfMaterials"].(map[string]any)
+	if !ok {
+		return argsJSON, nil
+	}
+	products, ok := billOfMaterials["products"].([]any)
+	if !ok {
+		return argsJSON, nil
+	}
+
+	if err := m.applyToProducts(ctx, products, parseProductTypes); err != nil {
+		return nil, err
+	}
+
+	return marshalArguments(args)
+}
+
+// defaultServicesChange returns the change that adds default bookmarks to each product of the default Bill of
+// Materials of an environment created without one.
+func (m *DefaultBookmarksMiddleware) defaultServicesChange(ctx context.Context) func(products []pingone.EnvironmentBillOfMaterialsProduct) ([]pingone.EnvironmentBillOfMaterialsProduct, bool) {
+	return func(products []pingone.EnvironmentBillOfMaterialsProduct) ([]pingone.EnvironmentBillOfMaterialsProduct, bool) {
+		changed := false
+		products = slices.Clone(products)
+		for i, product := range products {
+			merged, dropped := m.defaultBookmarks.Merge(product.Bookmarks, product.Type)
+			if len(dropped) > 0 {
+				logger.FromContext(ctx).Warn("Default bookmarks not applied as the product bookmark limit was reached",
+					slog.String("productType", string(product.Type)),
+					slog.Int("droppedCount", len(dropped)),
+					slog.Int("maxBookmarks", MaxBookmarksPerProduct))
+			}
+			if len(merged) > len(product.Bookmarks) {
+				products[i].Bookmarks = merged
+				changed = true
+			}
+		}
+		return products, changed
+	}
+}
+
+// applyToUpdateEnvironmentServices adds default bookmarks to each entry of the services argument.
+// The NEO service receives the default bookmarks of both the Verify and Credentials products.
+func (m *DefaultBookmarksMiddleware) applyToUpdateEnvironmentServices(ctx context.Context, argsJSON json.RawMessage) (json.RawMessage, error) {
+	args, err := unmarshalArguments(argsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	services, ok := args["services"].([]any)
+	if !ok {
+		return argsJSON, nil
+	}
+
+	if err := m.applyToProducts(ctx, services, parseServiceTypes); err != nil {
+		return nil, err
+	}
+
+	return marshalArguments(args)
+}
+
+// applyToAddEnvironmentService adds default bookmarks to the service argument.
+// The NEO service receives the default bookmarks of both the Verify and Credentials products.
+func (m *DefaultBookmarksMiddleware) applyToAddEnvironmentService(ctx context.Context, argsJSON json.RawMessage) (json.RawMessage, error) {
+	args, err := unmarshalArguments(argsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	service, ok := args["service"].(map[string]any)
+	if !ok {
+		return argsJSON, nil
+	}
+
+	if err := m.applyToProducts(ctx, []any{service}, parseServiceTypes); err != nil {
+		return nil, err
+	}
+
+	return marshalArguments(args)
+}
+
+// applyToProducts merges default bookmarks into the bookmarks of each product object in place.
+// Products with an unrecognized type are left unchanged so that the tool can report the invalid value.
+func (m *DefaultBookmarksMiddleware) applyToProducts(ctx context.Context, products []any, productTypesFor func(string) []pingone.EnvironmentBillOfMaterialsProductType) error {
+	for _, rawProduct := range products {
+		product, ok := rawProduct.(map[string]any)
+		if !ok {
+			continue
+		}
+		productTypeValue, ok := product["type"].(string)
+		if !ok {
+			continue
+		}
+		productTypes := productTypesFor(productTypeValue)
+		if len(productTypes) == 0 {
+			continue
+		}
+
+		var existing []pingone.EnvironmentBillOfMaterialsProductBookmark
+		if rawBookmarks, ok := product["bookmarks"]; ok && rawBookmarks != nil {
+			bookmarksJSON, err := json.Marshal(rawBookmarks)
+			if err != nil {
+				return fmt.Errorf("failed to marshal bookmarks: %w", err)
+			}
+			if err := json.Unmarshal(bookmarksJSON, &existing); err != nil {
+				return fmt.Errorf("invalid bookmarks for product type %q: %w", productTypeValue, err)
+			}
+		}
+
+		merged, dropped := m.defaultBookmarks.Merge(existing, productTypes...)
+		if len(dropped) > 0 {
+			logger.FromContext(ctx).Warn("Default bookmarks not applied as the product bookmark limit was reached",
+				slog.String("productType", productTypeValue),
+				slog.Int("droppedCount", len(dropped)),
+				slog.Int("maxBookmarks", MaxBookmarksPerProduct))
+		}
+		if len(merged) > 0 {
+			product["bookmarks"] = merged
+		}
+	}
+	return nil
+}
+
+// parseServiceTypes returns the product types of a service type of the services tools, where NEO stands for both
+// the Verify and Credentials products.
+func parseServiceTypes(value string) []pingone.EnvironmentBillOfMaterialsProductType {
+	if value == environments.NeoServiceValue {
+		return []pingone.EnvironmentBillOfMaterialsProductType{
+			pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_VERIFY,
+			pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_CREDENTIALS,
+		}
+	}
+	return parseProductTypes(value)
+}
+
+func parseProductTypes(value string) []pingone.EnvironmentBillOfMaterialsProductType {
+	productType, err := pingone.NewEnvironmentBillOfMaterialsProductTypeFromValue(value)
+	if err != nil {
+		return nil
+	}
+	return []pingone.EnvironmentBillOfMaterialsProductType{*productType}
+}
+
+// hasBillOfMaterials reports whether the arguments of a create_environment call include a Bill of Materials
+func hasBillOfMaterials(argsJSON json.RawMessage) bool {
+	args, err := unmarshalArguments(argsJSON)
+	if err != nil {
+		return false
+	}
+	billOfMaterials, ok := args["billOfMaterials"]
+	return ok && billOfMaterials != nil
+}
+
+func unmarshalArguments(argsJSON json.RawMessage) (map[string]any, error) {
+	args := map[string]any{}
+	if len(argsJSON) == 0 {
+		return args, nil
+	}
+	if err := json.Unmarshal(argsJSON, &args); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+	}
+	if args == nil {
+		args = map[string]any{}
+	}
+	return args, nil
+}
+
+func marshalArguments(args map[string]any) (json.RawMessage, error) {
+	result, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	return result, nil
+}
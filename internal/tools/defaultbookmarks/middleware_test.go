@@ -0,0 +1,220 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultbookmarks_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultbookmarks"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	envtestutils "github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testDefaultBookmarks = defaultbookmarks.DefaultBookmarks{
+	pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE: {
+		bookmark("Runbook", "https://example.com/runbook"),
+	},
+	pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_VERIFY: {
+		bookmark("Verify Guide", "https://example.com/verify"),
+	},
+}
+
+type servicesEchoOutput struct {
+	Services []environments.EnvironmentServiceInput `json:"services"`
+}
+
+// callWithCapturedArguments runs the middleware for a tool call and returns the arguments passed to the next handler
+func callWithCapturedArguments(t *testing.T, middleware *defaultbookmarks.DefaultBookmarksMiddleware, toolName string, arguments string) map[string]any {
+	t.Helper()
+
+	var captured json.RawMessage
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		captured = req.(*mcp.CallToolRequest).Params.Arguments
+		return nil, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      toolName,
+			Arguments: json.RawMessage(arguments),
+		},
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+
+	args := map[string]any{}
+	require.NoError(t, json.Unmarshal(captured, &args))
+	return args
+}
+
+func TestDefaultBookmarksMiddleware_CreateEnvironment(t *testing.T) {
+	middleware := defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks)
+
+	args := callWithCapturedArguments(t, middleware, environments.CreateEnvironmentDef.McpTool.Name, `{
+		"name": "Test",
+		"billOfMaterials": {"products": [
+			{"type": "PING_ONE_BASE", "bookmarks": [{"name": "Custom", "href": "https://example.com/custom"}]},
+			{"type": "PING_ONE_DAVINCI"}
+		]}
+	}`)
+
+	products := args["billOfMaterials"].(map[string]any)["products"].([]any)
+	require.Len(t, products, 2)
+	assert.Equal(t, []any{
+		map[string]any{"name": "Custom", "href": "https://example.com/custom"},
+		map[string]any{"name": "Runbook", "href": "https://example.com/runbook"},
+	}, products[0].(map[string]any)["bookmarks"])
+	assert.NotContains(t, products[1].(map[string]any), "bookmarks", "Products without defaults should be unchanged")
+	assert.Equal(t, "Test", args["name"])
+}
+
+func TestDefaultBookmarksMiddleware_CreateEnvironmentWithoutBillOfMaterials(t *testing.T) {
+	middleware := defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks)
+
+	args := callWithCapturedArguments(t, middleware, environments.CreateEnvironmentDef.McpTool.Name, `{"name": "Test"}`)
+
+	assert.Equal(t, map[string]any{"name": "Test"}, args)
+}
+
+func TestDefaultBookmarksMiddleware_OtherToolUnchanged(t *testing.T) {
+	middleware := defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks)
+
+	args := callWithCapturedArguments(t, middleware, "update_environment", `{"services": [{"type": "PING_ONE_BASE"}]}`)
+
+	assert.Equal(t, map[string]any{"services": []any{map[string]any{"type": "PING_ONE_BASE"}}}, args)
+}
+
+func TestDefaultBookmarksMiddleware_InvalidBookmarks(t *testing.T) {
+	middleware := defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks)
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      environments.UpdateEnvironmentServicesDef.McpTool.Name,
+			Arguments: json.RawMessage(`{"services": [{"type": "PING_ONE_BASE", "bookmarks": "not-a-list"}]}`),
+		},
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "default bookmarks failed")
+	assert.False(t, nextCalled)
+}
+
+func TestDefaultBookmarksMiddleware_NonToolCall(t *testing.T) {
+	middleware := defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks)
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "initialize", &mcp.InitializeRequest{})
+
+	assert.NoError(t, err)
+	assert.True(t, nextCalled)
+}
+
+func TestDefaultBookmarksMiddleware_UpdateEnvironmentServices_OverMcp(t *testing.T) {
+	echoHandler := func(ctx context.Context, req *mcp.CallToolRequest, input environments.UpdateEnvironmentServicesInput) (*mcp.CallToolResult, *servicesEchoOutput, error) {
+		return nil, &servicesEchoOutput{Services: input.Services}, nil
+	}
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks).Handler)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         environments.UpdateEnvironmentServicesDef.McpTool.Name,
+		InputSchema:  environments.UpdateEnvironmentServicesDef.McpTool.InputSchema,
+		OutputSchema: schema.MustGenerateSchema[servicesEchoOutput](),
+	}, echoHandler)
+
+	input := environments.UpdateEnvironmentServicesInput{
+		EnvironmentId: uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		Services: []environments.EnvironmentServiceInput{
+			{Type: "PING_ONE_BASE"},
+			{Type: environments.NeoServiceValue},
+			{Type: "PING_ONE_MFA"},
+		},
+	}
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, environments.UpdateEnvironmentServicesDef.McpTool.Name, input)
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	require.False(t, output.IsError)
+
+	result := &servicesEchoOutput{}
+	jsonBytes, err := json.Marshal(output.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsonBytes, result))
+
+	require.Len(t, result.Services, 3)
+	assert.Equal(t, [][2]string{{"Runbook", "https://example.com/runbook"}}, bookmarkPairs(result.Services[0].Bookmarks))
+	assert.Equal(t, [][2]string{{"Verify Guide", "https://example.com/verify"}}, bookmarkPairs(result.Services[1].Bookmarks))
+	assert.Empty(t, result.Services[2].Bookmarks)
+}
+
+func TestDefaultBookmarksMiddleware_AddEnvironmentService(t *testing.T) {
+	middleware := defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks)
+
+	args := callWithCapturedArguments(t, middleware, environments.AddEnvironmentServiceDef.McpTool.Name, `{
+		"environmentId": "550e8400-e29b-41d4-a716-446655440000",
+		"service": {"type": "NEO"}
+	}`)
+
+	assert.Equal(t, []any{
+		map[string]any{"name": "Verify Guide", "href": "https://example.com/verify"},
+	}, args["service"].(map[string]any)["bookmarks"])
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", args["environmentId"])
+}
+
+func TestDefaultBookmarksMiddleware_CreateEnvironmentWithoutBillOfMaterials_OverMcp(t *testing.T) {
+	createdEnvID := uuid.MustParse("550e8400-e29b-41d4-a716-446655441001")
+	defaultServices := &pingone.EnvironmentBillOfMaterialsResponse{Products: []pingone.EnvironmentBillOfMaterialsProduct{
+		{Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE},
+		{Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_MFA},
+	}}
+
+	mockClient := &envtestutils.MockEnvironmentsClient{}
+	mockClient.On("CreateEnvironment", mock.Anything, mock.Anything).Return(&pingone.EnvironmentResponse{Id: createdEnvID, Name: "Test"}, nil, nil).Once()
+	mockClient.On("GetEnvironmentServices", mock.Anything, createdEnvID).Return(defaultServices, nil, nil).Twice()
+	var replaced *pingone.EnvironmentBillOfMaterialsReplaceRequest
+	mockClient.On("UpdateEnvironmentServices", mock.Anything, createdEnvID, mock.Anything).Run(func(args mock.Arguments) {
+		replaced = args.Get(2).(*pingone.EnvironmentBillOfMaterialsReplaceRequest)
+	}).Return(&pingone.EnvironmentBillOfMaterialsResponse{}, nil, nil).Once()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(defaultbookmarks.NewDefaultBookmarksMiddleware(testDefaultBookmarks).Handler)
+	mcp.AddTool(server, environments.CreateEnvironmentDef.McpTool, environments.CreateEnvironmentHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil)))
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, environments.CreateEnvironmentDef.McpTool.Name, environments.CreateEnvironmentInput{
+		Name:    "Test",
+		Region:  pingone.ENVIRONMENTREGIONCODE_NA,
+		License: *pingone.NewEnvironmentLicense(uuid.MustParse("550e8400-e29b-41d4-a716-446655440900")),
+	})
+	require.NoError(t, err)
+	require.False(t, output.IsError)
+
+	require.NotNil(t, replaced, "the default services should be replaced with bookmarks added")
+	require.Len(t, replaced.Products, 2)
+	assert.Equal(t, [][2]string{{"Runbook", "https://example.com/runbook"}}, bookmarkPairs(replaced.Products[0].Bookmarks))
+	assert.Empty(t, replaced.Products[1].Bookmarks, "Products without defaults should be unchanged")
+	mockClient.AssertExpectations(t)
+}
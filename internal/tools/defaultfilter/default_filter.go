@@ -0,0 +1,78 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultfilter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// FilterArgumentName is the tool input argument that default filters are merged into.
+const FilterArgumentName = "filter"
+
+// ParseDefaultFilters parses default filter definitions in the form "<tool name>=<SCIM filter>".
+// Each tool must exist in toolDefs and accept a filter argument, so that misconfiguration is
+// reported when the server starts rather than silently ignored.
+// Multiple definitions for the same tool are combined with 'and'.
+func ParseDefaultFilters(values []string, toolDefs []types.ToolDefinition) (map[string]string, error) {
+	defaultFilters := make(map[string]string)
+	if len(values) == 0 {
+		return defaultFilters, nil
+	}
+
+	toolsByName := make(map[string]*types.ToolDefinition)
+	for i := range toolDefs {
+		toolsByName[toolDefs[i].McpTool.Name] = &toolDefs[i]
+	}
+
+	for _, value := range values {
+		toolName, filter, found := strings.Cut(value, "=")
+		toolName = strings.TrimSpace(toolName)
+		filter = strings.TrimSpace(filter)
+		if !found || toolName == "" || filter == "" {
+			return nil, fmt.Errorf("invalid default filter %q, expected format <tool name>=<SCIM filter>", value)
+		}
+
+		toolDef, ok := toolsByName[toolName]
+		if !ok {
+			return nil, fmt.Errorf("invalid default filter for unknown tool %q", toolName)
+		}
+		if !acceptsFilterArgument(toolDef) {
+			return nil, fmt.Errorf("invalid default filter for tool %q, tool does not accept a %s argument", toolName, FilterArgumentName)
+		}
+
+		defaultFilters[toolName] = MergeFilters(defaultFilters[toolName], filter)
+	}
+
+	return defaultFilters, nil
+}
+
+// MergeFilters combines a default filter with a user-provided filter so that both must match.
+// Either filter may be empty, in which case the other is returned unchanged.
+func MergeFilters(defaultFilter string, userFilter string) string {
+	defaultFilter = strings.TrimSpace(defaultFilter)
+	userFilter = strings.TrimSpace(userFilter)
+	switch {
+	case defaultFilter == "":
+		return userFilter
+	case userFilter == "":
+		return defaultFilter
+	default:
+		return fmt.Sprintf("(%s) and (%s)", defaultFilter, userFilter)
+	}
+}
+
+func acceptsFilterArgument(toolDef *types.ToolDefinition) bool {
+	if toolDef.McpTool == nil {
+		return false
+	}
+	inputSchema, ok := toolDef.McpTool.InputSchema.(*jsonschema.Schema)
+	if !ok || inputSchema == nil {
+		return false
+	}
+	_, ok = inputSchema.Properties[FilterArgumentName]
+	return ok
+}
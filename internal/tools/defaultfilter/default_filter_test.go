@@ -0,0 +1,107 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultfilter_test
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type filterableToolInput struct {
+	Filter *string `json:"filter,omitempty"`
+}
+
+type nonFilterableToolInput struct {
+	Name string `json:"name"`
+}
+
+var testToolDefs = []types.ToolDefinition{
+	{
+		McpTool: &mcp.Tool{
+			Name:        "list_things",
+			InputSchema: schema.MustGenerateSchema[filterableToolInput](),
+		},
+	},
+	{
+		McpTool: &mcp.Tool{
+			Name:        "create_thing",
+			InputSchema: schema.MustGenerateSchema[nonFilterableToolInput](),
+		},
+	},
+}
+
+func TestParseDefaultFilters(t *testing.T) {
+	tests := []struct {
+		name            string
+		values          []string
+		expected        map[string]string
+		wantErrContains string
+	}{
+		{
+			name:     "No values",
+			values:   nil,
+			expected: map[string]string{},
+		},
+		{
+			name:     "Single filter",
+			values:   []string{`list_things=name sw "BU1"`},
+			expected: map[string]string{"list_things": `name sw "BU1"`},
+		},
+		{
+			name:     "Filter containing equals sign",
+			values:   []string{`list_things = name eq "a=b"`},
+			expected: map[string]string{"list_things": `name eq "a=b"`},
+		},
+		{
+			name:     "Multiple filters for the same tool are combined",
+			values:   []string{`list_things=enabled eq true`, `list_things=name sw "BU1"`},
+			expected: map[string]string{"list_things": `(enabled eq true) and (name sw "BU1")`},
+		},
+		{
+			name:            "Missing separator",
+			values:          []string{"list_things"},
+			wantErrContains: "expected format <tool name>=<SCIM filter>",
+		},
+		{
+			name:            "Empty filter",
+			values:          []string{"list_things= "},
+			wantErrContains: "expected format <tool name>=<SCIM filter>",
+		},
+		{
+			name:            "Unknown tool",
+			values:          []string{"list_unknown=enabled eq true"},
+			wantErrContains: `unknown tool "list_unknown"`,
+		},
+		{
+			name:            "Tool without filter argument",
+			values:          []string{"create_thing=enabled eq true"},
+			wantErrContains: "tool does not accept a filter argument",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := defaultfilter.ParseDefaultFilters(tt.values, testToolDefs)
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMergeFilters(t *testing.T) {
+	assert.Equal(t, "", defaultfilter.MergeFilters("", ""))
+	assert.Equal(t, "a eq 1", defaultfilter.MergeFilters("a eq 1", ""))
+	assert.Equal(t, "b eq 2", defaultfilter.MergeFilters(" ", "b eq 2"))
+	assert.Equal(t, "(a eq 1) and (b eq 2)", defaultfilter.MergeFilters("a eq 1", "b eq 2"))
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultfilter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// DefaultFilterMiddleware merges operator-configured default SCIM filters into the filter
+// argument of tool calls, so that data scoping policies apply to everything the AI can list.
+// When the caller provides its own filter, both filters must match.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type DefaultFilterMiddleware struct {
+	defaultFilters map[string]string
+}
+
+// NewDefaultFilterMiddleware creates middleware from a map of tool name to default SCIM filter,
+// as returned by ParseDefaultFilters.
+func NewDefaultFilterMiddleware(defaultFilters map[string]string) *DefaultFilterMiddleware {
+	return &DefaultFilterMiddleware{
+		defaultFilters: defaultFilters,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *DefaultFilterMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || len(m.defaultFilters) == 0 {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			// Should never happen for tools/call method, but filter scoping is mandatory, so we fail the call
+			return nil, fmt.Errorf("default filter failed: %w", fmt.Errorf("invalid tool call request"))
+		}
+
+		toolName := callToolReq.Params.Name
+		defaultFilter, ok := m.defaultFilters[toolName]
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		arguments, err := applyDefaultFilter(callToolReq.Params.Arguments, defaultFilter)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to apply default filter",
+				slog.String("tool", toolName),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("default filter failed: %w", err)
+		}
+		callToolReq.Params.Arguments = arguments
+
+		logger.FromContext(ctx).Debug("Applied default filter to tool call",
+			slog.String("tool", toolName),
+			slog.String("defaultFilter", defaultFilter))
+
+		return next(ctx, method, req)
+	}
+}
+
+// applyDefaultFilter returns the tool call arguments with the default filter merged into the filter argument.
+func applyDefaultFilter(argsJSON json.RawMessage, defaultFilter string) (json.RawMessage, error) {
+	args := map[string]any{}
+	if len(argsJSON) > 0 {
+		if err := json.Unmarshal(argsJSON, &args); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+		}
+		if args == nil {
+			args = map[string]any{}
+		}
+	}
+
+	userFilter := ""
+	if rawFilter, ok := args[FilterArgumentName]; ok && rawFilter != nil {
+		filterStr, ok := rawFilter.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s argument must be a string", FilterArgumentName)
+		}
+		userFilter = filterStr
+	}
+
+	args[FilterArgumentName] = MergeFilters(defaultFilter, userFilter)
+
+	result, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package defaultfilter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/defaultfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testToolOutput struct {
+	Filter string `json:"filter"`
+}
+
+func newFilterEchoServer(t *testing.T, defaultFilters map[string]string) *mcp.Server {
+	t.Helper()
+
+	echoHandler := func(ctx context.Context, req *mcp.CallToolRequest, input filterableToolInput) (*mcp.CallToolResult, *testToolOutput, error) {
+		output := &testToolOutput{}
+		if input.Filter != nil {
+			output.Filter = *input.Filter
+		}
+		return nil, output, nil
+	}
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(defaultfilter.NewDefaultFilterMiddleware(defaultFilters).Handler)
+	for _, name := range []string{"list_things", "list_other_things"} {
+		mcp.AddTool(server, &mcp.Tool{
+			Name:         name,
+			InputSchema:  schema.MustGenerateSchema[filterableToolInput](),
+			OutputSchema: schema.MustGenerateSchema[testToolOutput](),
+		}, echoHandler)
+	}
+	return server
+}
+
+func TestDefaultFilterMiddleware_OverMcp(t *testing.T) {
+	defaultFilters := map[string]string{
+		"list_things": `population.id eq "bu-1"`,
+	}
+
+	tests := []struct {
+		name           string
+		toolName       string
+		input          filterableToolInput
+		expectedFilter string
+	}{
+		{
+			name:           "Default filter applied when no filter provided",
+			toolName:       "list_things",
+			input:          filterableToolInput{},
+			expectedFilter: `population.id eq "bu-1"`,
+		},
+		{
+			name:           "Default filter merged with provided filter",
+			toolName:       "list_things",
+			input:          filterableToolInput{Filter: testutils.Pointer(`name sw "A"`)},
+			expectedFilter: `(population.id eq "bu-1") and (name sw "A")`,
+		},
+		{
+			name:           "Tool without default filter is unchanged",
+			toolName:       "list_other_things",
+			input:          filterableToolInput{Filter: testutils.Pointer(`name sw "A"`)},
+			expectedFilter: `name sw "A"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFilterEchoServer(t, defaultFilters)
+
+			output, err := mcptestutils.CallToolOverMcp(t, server, tt.toolName, tt.input)
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			assert.False(t, output.IsError)
+
+			structured, ok := output.StructuredContent.(map[string]any)
+			require.True(t, ok, "Expected structured content to be an object")
+			assert.Equal(t, tt.expectedFilter, structured["filter"])
+		})
+	}
+}
+
+func TestDefaultFilterMiddleware_InvalidFilterArgument(t *testing.T) {
+	middleware := defaultfilter.NewDefaultFilterMiddleware(map[string]string{"list_things": "enabled eq true"})
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "list_things",
+			Arguments: []byte(`{"filter": 123}`),
+		},
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "filter argument must be a string")
+	assert.False(t, nextCalled)
+}
+
+func TestDefaultFilterMiddleware_NonToolCall(t *testing.T) {
+	middleware := defaultfilter.NewDefaultFilterMiddleware(map[string]string{"list_things": "enabled eq true"})
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "initialize", &mcp.InitializeRequest{})
+
+	assert.NoError(t, err)
+	assert.True(t, nextCalled)
+}
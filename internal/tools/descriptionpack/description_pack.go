@@ -0,0 +1,118 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package descriptionpack replaces the descriptions of tools and of their input parameters with those of an
+// operator-provided pack, such as descriptions translated for non-English administrators or written in an
+// enterprise's own terminology, so that agents choose and call tools in the words of the deployment.
+package descriptionpack
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ToolDescriptions are the replacement title, description and parameter descriptions of a tool. Empty values keep
+// the server's own.
+type ToolDescriptions struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	// Parameters are the replacement descriptions of the tool's input parameters, keyed by parameter name
+	Parameters map[string]string `yaml:"parameters"`
+}
+
+// Pack maps a tool name to the replacement descriptions of that tool.
+type Pack map[string]ToolDescriptions
+
+// LoadPack reads a description pack from a JSON or YAML file. An empty path returns an empty pack.
+func LoadPack(path string, toolDefs []types.ToolDefinition) (Pack, error) {
+	if path == "" {
+		return Pack{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool descriptions file: %w", err)
+	}
+
+	return ParsePack(data, toolDefs)
+}
+
+// ParsePack parses a description pack from YAML, or from JSON, which is read as YAML, in the form:
+//
+//	{"list_populations": {"description": "List the business units...", "parameters": {"filter": "..."}}}
+//
+// Each tool must exist in toolDefs and each parameter in the tool's input schema, so that a pack written for
+// another version of the server is reported when the server starts rather than silently ignored.
+func ParsePack(data []byte, toolDefs []types.ToolDefinition) (Pack, error) {
+	pack := Pack{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&pack); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid tool descriptions: %w", err)
+	}
+
+	tools := make(map[string]*mcp.Tool, len(toolDefs))
+	for _, toolDef := range toolDefs {
+		tools[toolDef.McpTool.Name] = toolDef.McpTool
+	}
+
+	// Sorted, so that the same error is reported for a pack on every start
+	for _, toolName := range slices.Sorted(maps.Keys(pack)) {
+		descriptions := pack[toolName]
+		tool, ok := tools[toolName]
+		if !ok {
+			return nil, fmt.Errorf("invalid tool descriptions for unknown tool %q", toolName)
+		}
+		if strings.TrimSpace(descriptions.Title) == "" && strings.TrimSpace(descriptions.Description) == "" && len(descriptions.Parameters) == 0 {
+			return nil, fmt.Errorf("invalid tool descriptions for tool %q, a title, description or parameter description is required", toolName)
+		}
+		inputSchema, _ := tool.InputSchema.(*jsonschema.Schema)
+		for _, parameter := range slices.Sorted(maps.Keys(descriptions.Parameters)) {
+			if inputSchema == nil || inputSchema.Properties[parameter] == nil {
+				return nil, fmt.Errorf("invalid tool descriptions for tool %q, unknown parameter %q", toolName, parameter)
+			}
+			if strings.TrimSpace(descriptions.Parameters[parameter]) == "" {
+				return nil, fmt.Errorf("invalid tool descriptions for tool %q, the description of parameter %q must not be empty", toolName, parameter)
+			}
+		}
+	}
+
+	return pack, nil
+}
+
+// Apply returns the tool with the descriptions of the pack, or the tool itself if the pack has none for it. The
+// tool is copied rather than changed, as the listed tools are shared with the server's tool registry.
+func (p Pack) Apply(tool *mcp.Tool) *mcp.Tool {
+	descriptions, ok := p[tool.Name]
+	if !ok {
+		return tool
+	}
+
+	applied := *tool
+	if strings.TrimSpace(descriptions.Title) != "" {
+		applied.Title = descriptions.Title
+	}
+	if strings.TrimSpace(descriptions.Description) != "" {
+		applied.Description = descriptions.Description
+	}
+	if inputSchema, ok := tool.InputSchema.(*jsonschema.Schema); ok && len(descriptions.Parameters) > 0 {
+		appliedSchema := inputSchema.CloneSchemas()
+		for parameter, description := range descriptions.Parameters {
+			if property := appliedSchema.Properties[parameter]; property != nil {
+				property.Description = description
+			}
+		}
+		applied.InputSchema = appliedSchema
+	}
+	return &applied
+}
@@ -0,0 +1,167 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package descriptionpack_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/descriptionpack"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listThingsInput struct {
+	Filter *string `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter."`
+	Limit  *int    `json:"limit,omitempty" jsonschema:"OPTIONAL. Maximum number of things."`
+}
+
+func listThingsTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_things",
+		Title:       "List Things",
+		Description: "List things",
+		InputSchema: schema.MustGenerateSchema[listThingsInput](),
+	}
+}
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: listThingsTool()},
+	{McpTool: &mcp.Tool{Name: "create_thing", Description: "Create a thing"}},
+}
+
+func TestParsePack(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		wantTools       []string
+		wantErrContains string
+	}{
+		{
+			name:      "JSON",
+			data:      `{"list_things": {"description": "Dinge auflisten", "parameters": {"filter": "SCIM-Filter."}}, "create_thing": {"title": "Ding erstellen"}}`,
+			wantTools: []string{"list_things", "create_thing"},
+		},
+		{
+			name: "YAML",
+			data: `
+list_things:
+  description: List the business units
+  parameters:
+    limit: Maximum number of business units.
+`,
+			wantTools: []string{"list_things"},
+		},
+		{
+			name:      "Empty",
+			data:      ``,
+			wantTools: []string{},
+		},
+		{
+			name:            "Invalid",
+			data:            `[`,
+			wantErrContains: "invalid tool descriptions",
+		},
+		{
+			name:            "Unknown attribute",
+			data:            `{"list_things": {"summary": "Dinge auflisten"}}`,
+			wantErrContains: "invalid tool descriptions",
+		},
+		{
+			name:            "Unknown tool",
+			data:            `{"delete_things": {"description": "Dinge löschen"}}`,
+			wantErrContains: `unknown tool "delete_things"`,
+		},
+		{
+			name:            "Unknown parameter",
+			data:            `{"list_things": {"parameters": {"environmentId": "Umgebung."}}}`,
+			wantErrContains: `unknown parameter "environmentId"`,
+		},
+		{
+			name:            "Parameter of a tool without parameters",
+			data:            `{"create_thing": {"parameters": {"name": "Name."}}}`,
+			wantErrContains: `unknown parameter "name"`,
+		},
+		{
+			name:            "Empty parameter description",
+			data:            `{"list_things": {"parameters": {"filter": " "}}}`,
+			wantErrContains: `the description of parameter "filter" must not be empty`,
+		},
+		{
+			name:            "No descriptions",
+			data:            `{"list_things": {}}`,
+			wantErrContains: "a title, description or parameter description is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pack, err := descriptionpack.ParsePack([]byte(tt.data), testToolDefs)
+
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			tools := []string{}
+			for toolName := range pack {
+				tools = append(tools, toolName)
+			}
+			assert.ElementsMatch(t, tt.wantTools, tools)
+		})
+	}
+}
+
+func TestLoadPack(t *testing.T) {
+	t.Run("Empty path", func(t *testing.T) {
+		pack, err := descriptionpack.LoadPack("", testToolDefs)
+		require.NoError(t, err)
+		assert.Empty(t, pack)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "descriptions.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("create_thing:\n  description: Ein Ding erstellen\n"), 0600))
+
+		pack, err := descriptionpack.LoadPack(path, testToolDefs)
+		require.NoError(t, err)
+		assert.Equal(t, "Ein Ding erstellen", pack["create_thing"].Description)
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := descriptionpack.LoadPack(filepath.Join(t.TempDir(), "missing.json"), testToolDefs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read tool descriptions file")
+	})
+}
+
+func TestPackApply(t *testing.T) {
+	pack := descriptionpack.Pack{
+		"list_things": {
+			Description: "Dinge auflisten",
+			Parameters:  map[string]string{"filter": "SCIM-Filter."},
+		},
+	}
+	tool := listThingsTool()
+
+	applied := pack.Apply(tool)
+
+	assert.Equal(t, "List Things", applied.Title, "an empty title keeps the server's own")
+	assert.Equal(t, "Dinge auflisten", applied.Description)
+	appliedSchema := applied.InputSchema.(*jsonschema.Schema)
+	assert.Equal(t, "SCIM-Filter.", appliedSchema.Properties["filter"].Description)
+	assert.Equal(t, "OPTIONAL. Maximum number of things.", appliedSchema.Properties["limit"].Description)
+
+	// The tool itself is not changed
+	assert.Equal(t, "List things", tool.Description)
+	assert.Equal(t, "OPTIONAL. SCIM filter.", tool.InputSchema.(*jsonschema.Schema).Properties["filter"].Description)
+
+	other := &mcp.Tool{Name: "create_thing", Description: "Create a thing"}
+	assert.Same(t, other, pack.Apply(other), "tools without descriptions in the pack are returned as they are")
+}
@@ -0,0 +1,47 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package descriptionpack
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ToolDescriptionMiddleware replaces the descriptions of the tools listed to MCP clients with those of a
+// description pack. The registered tool definitions are not changed.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type ToolDescriptionMiddleware struct {
+	pack Pack
+}
+
+// NewToolDescriptionMiddleware creates middleware that applies the description pack to listed tools.
+// An empty pack leaves descriptions unchanged.
+func NewToolDescriptionMiddleware(pack Pack) *ToolDescriptionMiddleware {
+	return &ToolDescriptionMiddleware{
+		pack: pack,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ToolDescriptionMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err != nil || method != "tools/list" || len(m.pack) == 0 {
+			return result, err
+		}
+
+		listResult, ok := result.(*mcp.ListToolsResult)
+		if !ok {
+			return result, err
+		}
+
+		applied := *listResult
+		applied.Tools = make([]*mcp.Tool, len(listResult.Tools))
+		for i, tool := range listResult.Tools {
+			applied.Tools[i] = m.pack.Apply(tool)
+		}
+		return &applied, nil
+	}
+}
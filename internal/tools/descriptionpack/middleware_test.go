@@ -0,0 +1,91 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package descriptionpack_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/descriptionpack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func listToolsOverMcp(t *testing.T, pack descriptionpack.Pack) map[string]*mcp.Tool {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(descriptionpack.NewToolDescriptionMiddleware(pack).Handler)
+	listHandler := func(ctx context.Context, req *mcp.CallToolRequest, input listThingsInput) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	}
+	createHandler := func(ctx context.Context, req *mcp.CallToolRequest, input struct{}) (*mcp.CallToolResult, any, error) {
+		return nil, nil, nil
+	}
+	mcp.AddTool(server, listThingsTool(), listHandler)
+	mcp.AddTool(server, &mcp.Tool{Name: "create_thing", Description: "Create a thing"}, createHandler)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+
+	// The registered tools must not be changed, so listing again returns the same descriptions
+	again, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	require.Equal(t, result.Tools, again.Tools)
+
+	tools := map[string]*mcp.Tool{}
+	for _, tool := range result.Tools {
+		tools[tool.Name] = tool
+	}
+	return tools
+}
+
+// parameterDescription returns the description of a parameter of a tool listed to a client
+func parameterDescription(t *testing.T, tool *mcp.Tool, parameter string) string {
+	t.Helper()
+	inputSchema, ok := tool.InputSchema.(map[string]any)
+	require.True(t, ok)
+	properties, ok := inputSchema["properties"].(map[string]any)
+	require.True(t, ok)
+	property, ok := properties[parameter].(map[string]any)
+	require.True(t, ok)
+	description, _ := property["description"].(string)
+	return description
+}
+
+func TestToolDescriptionMiddleware_AppliesPack(t *testing.T) {
+	pack := descriptionpack.Pack{
+		"list_things": {
+			Title:       "Dinge auflisten",
+			Description: "Listet die Dinge einer Umgebung auf.",
+			Parameters:  map[string]string{"filter": "SCIM-Filter der Dinge."},
+		},
+	}
+
+	tools := listToolsOverMcp(t, pack)
+
+	assert.Equal(t, "Dinge auflisten", tools["list_things"].Title)
+	assert.Equal(t, "Listet die Dinge einer Umgebung auf.", tools["list_things"].Description)
+	assert.Equal(t, "SCIM-Filter der Dinge.", parameterDescription(t, tools["list_things"], "filter"))
+	assert.Equal(t, "OPTIONAL. Maximum number of things.", parameterDescription(t, tools["list_things"], "limit"))
+	assert.Equal(t, "Create a thing", tools["create_thing"].Description)
+}
+
+func TestToolDescriptionMiddleware_EmptyPack(t *testing.T) {
+	tools := listToolsOverMcp(t, nil)
+
+	assert.Equal(t, "List things", tools["list_things"].Description)
+	assert.Equal(t, "OPTIONAL. SCIM filter.", parameterDescription(t, tools["list_things"], "filter"))
+	assert.Equal(t, "Create a thing", tools["create_thing"].Description)
+}
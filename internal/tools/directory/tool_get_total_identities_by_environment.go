@@ -38,6 +38,7 @@ type GetTotalIdentitiesByEnvironmentInput struct {
 	EnvironmentId uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) of the PingOne environment to query for identity counts."`
 	StartDate     *time.Time `json:"startDate,omitempty" jsonschema:"OPTIONAL. The start date of the date range for counting identities in ISO 8601 format with timezone (e.g., '2024-01-01T00:00:00Z'). If neither date is provided, defaults to today at midnight UTC. If dates are specified, at least one must be set."`
 	EndDate       *time.Time `json:"endDate,omitempty" jsonschema:"OPTIONAL. The end date of the date range for counting identities in ISO 8601 format with timezone (e.g., '2024-12-31T23:59:59Z'). If neither date is provided, remains unset. If dates are specified, at least one must be set."`
+	Fields        []string   `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'totalIdentitiesReport.date' and 'totalIdentitiesReport.totalIdentities'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 // GetTotalIdentitiesByEnvironmentOutput represents the result of retrieving total identities count for an environment
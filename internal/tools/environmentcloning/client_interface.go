@@ -0,0 +1,29 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentcloning
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type EnvironmentCloningClient interface {
+	GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error)
+	GetBillOfMaterials(ctx context.Context, environmentId uuid.UUID) (*management.BillOfMaterials, *http.Response, error)
+	CreateEnvironment(ctx context.Context, createRequest management.Environment) (*management.Environment, *http.Response, error)
+	GetPasswordPolicies(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	CreatePasswordPolicy(ctx context.Context, environmentId uuid.UUID, createRequest management.PasswordPolicy) (*management.PasswordPolicy, *http.Response, error)
+	UpdatePasswordPolicy(ctx context.Context, environmentId uuid.UUID, passwordPolicyId string, updateRequest management.PasswordPolicy) (*management.PasswordPolicy, *http.Response, error)
+	GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	CreatePopulation(ctx context.Context, environmentId uuid.UUID, createRequest management.Population) (*management.Population, *http.Response, error)
+	UpdatePopulation(ctx context.Context, environmentId uuid.UUID, populationId string, updateRequest management.Population) (*management.Population, *http.Response, error)
+	GetApplication(ctx context.Context, environmentId uuid.UUID, applicationId uuid.UUID) (*management.ReadOneApplication200Response, *http.Response, error)
+	CreateApplication(ctx context.Context, environmentId uuid.UUID, createRequest management.CreateApplicationRequest) (*management.CreateApplication201Response, *http.Response, error)
+}
+
+type EnvironmentCloningClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (EnvironmentCloningClient, error)
+}
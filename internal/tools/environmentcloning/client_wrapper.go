@@ -0,0 +1,199 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentcloning
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	pingonelegacy "github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ EnvironmentCloningClient = &PingOneClientEnvironmentCloningWrapper{}
+var _ EnvironmentCloningClientFactory = &PingOneClientEnvironmentCloningWrapperFactory{}
+
+type PingOneClientEnvironmentCloningWrapper struct {
+	client *pingonelegacy.Client
+}
+
+type PingOneClientEnvironmentCloningWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientEnvironmentCloningWrapper(client *pingonelegacy.Client) *PingOneClientEnvironmentCloningWrapper {
+	return &PingOneClientEnvironmentCloningWrapper{client: client}
+}
+
+func NewPingOneClientEnvironmentCloningWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientEnvironmentCloningWrapperFactory {
+	return &PingOneClientEnvironmentCloningWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientEnvironmentCloningWrapperFactory) GetAuthenticatedClient(ctx context.Context) (EnvironmentCloningClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientEnvironmentCloningWrapper(client), nil
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.EnvironmentsApi.ReadOneEnvironment(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve environment by ID",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) GetBillOfMaterials(ctx context.Context, environmentId uuid.UUID) (*management.BillOfMaterials, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.BillOfMaterialsBOMApi.ReadOneBillOfMaterials(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve environment bill of materials",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) CreateEnvironment(ctx context.Context, createRequest management.Environment) (*management.Environment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.EnvironmentsApi.CreateEnvironmentActiveLicense(ctx).Environment(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create environment",
+		slog.String("name", createRequest.Name),
+		slog.String("type", string(createRequest.Type)),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) GetPasswordPolicies(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.PasswordPoliciesApi.ReadAllPasswordPolicies(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve password policies",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) CreatePasswordPolicy(ctx context.Context, environmentId uuid.UUID, createRequest management.PasswordPolicy) (*management.PasswordPolicy, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.PasswordPoliciesApi.CreatePasswordPolicy(ctx, environmentId.String()).PasswordPolicy(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create password policy",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("name", createRequest.Name),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) UpdatePasswordPolicy(ctx context.Context, environmentId uuid.UUID, passwordPolicyId string, updateRequest management.PasswordPolicy) (*management.PasswordPolicy, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.PasswordPoliciesApi.UpdatePasswordPolicy(ctx, environmentId.String(), passwordPolicyId).PasswordPolicy(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update password policy by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("passwordPolicyId", passwordPolicyId),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.PopulationsApi.ReadAllPopulations(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve populations",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) CreatePopulation(ctx context.Context, environmentId uuid.UUID, createRequest management.Population) (*management.Population, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.PopulationsApi.CreatePopulation(ctx, environmentId.String()).Population(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create population",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("name", createRequest.Name),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) UpdatePopulation(ctx context.Context, environmentId uuid.UUID, populationId string, updateRequest management.Population) (*management.Population, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.PopulationsApi.UpdatePopulation(ctx, environmentId.String(), populationId).Population(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update population by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("populationId", populationId),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) GetApplication(ctx context.Context, environmentId uuid.UUID, applicationId uuid.UUID) (*management.ReadOneApplication200Response, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.ApplicationsApi.ReadOneApplication(ctx, environmentId.String(), applicationId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve application by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("applicationId", applicationId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientEnvironmentCloningWrapper) CreateApplication(ctx context.Context, environmentId uuid.UUID, createRequest management.CreateApplicationRequest) (*management.CreateApplication201Response, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.ApplicationsApi.CreateApplication(ctx, environmentId.String()).CreateApplicationRequest(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create application",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return postRequest.Execute()
+}
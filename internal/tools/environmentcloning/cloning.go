@@ -0,0 +1,216 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentcloning
+
+import (
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type ResourceType string
+
+const (
+	ResourceTypeEnvironment    ResourceType = "ENVIRONMENT"
+	ResourceTypePasswordPolicy ResourceType = "PASSWORD_POLICY"
+	ResourceTypePopulation     ResourceType = "POPULATION"
+	ResourceTypeApplication    ResourceType = "APPLICATION"
+)
+
+type CloneStatus string
+
+const (
+	// CloneStatusCreated is a resource created in the new environment
+	CloneStatusCreated CloneStatus = "CREATED"
+	// CloneStatusUpdated is a resource of the same name that PingOne created with the new environment, updated to
+	// match the source resource
+	CloneStatusUpdated CloneStatus = "UPDATED"
+	// CloneStatusSkipped is a resource that cannot be cloned
+	CloneStatusSkipped CloneStatus = "SKIPPED"
+	// CloneStatusFailed is a resource that PingOne rejected
+	CloneStatusFailed CloneStatus = "FAILED"
+)
+
+// ClonedEnvironment is the new environment. The SDK environment model is not returned, as its region does not
+// match its generated schema.
+type ClonedEnvironment struct {
+	Id          string                         `json:"id" jsonschema:"The UUID of the new environment"`
+	Name        string                         `json:"name" jsonschema:"The name of the new environment"`
+	Description *string                        `json:"description,omitempty" jsonschema:"The description of the new environment"`
+	Type        management.EnumEnvironmentType `json:"type" jsonschema:"The type of the new environment, always SANDBOX"`
+	Region      string                         `json:"region" jsonschema:"The region code of the new environment, the region of the source environment"`
+	LicenseId   string                         `json:"licenseId" jsonschema:"The UUID of the license of the new environment"`
+}
+
+func clonedEnvironment(environment management.Environment) ClonedEnvironment {
+	cloned := ClonedEnvironment{
+		Id:          environment.GetId(),
+		Name:        environment.Name,
+		Description: environment.Description,
+		Type:        environment.Type,
+		LicenseId:   environment.License.Id,
+	}
+	if environment.Region.EnumRegionCode != nil {
+		cloned.Region = string(*environment.Region.EnumRegionCode)
+	} else if environment.Region.String != nil {
+		cloned.Region = *environment.Region.String
+	}
+	return cloned
+}
+
+type ClonedResource struct {
+	ResourceType ResourceType `json:"resourceType" jsonschema:"The type of resource: ENVIRONMENT, PASSWORD_POLICY, POPULATION or APPLICATION"`
+	Name         string       `json:"name" jsonschema:"The name of the resource"`
+	SourceId     string       `json:"sourceId" jsonschema:"The UUID of the resource in the source environment"`
+	TargetId     *string      `json:"targetId,omitempty" jsonschema:"The UUID of the resource in the new environment, when it was created or updated"`
+	Status       CloneStatus  `json:"status" jsonschema:"CREATED, UPDATED when a resource of the same name created with the new environment was updated to match, SKIPPED when the resource cannot be cloned, or FAILED"`
+	Message      string       `json:"message,omitempty" jsonschema:"Why the resource was skipped or failed, or the settings that were not copied"`
+}
+
+// cloneResult records the outcome of cloning a resource, with the notes of the settings that were not copied
+func cloneResult(resourceType ResourceType, name string, sourceId string, targetId *string, status CloneStatus, notes []string) ClonedResource {
+	return ClonedResource{
+		ResourceType: resourceType,
+		Name:         name,
+		SourceId:     sourceId,
+		TargetId:     targetId,
+		Status:       status,
+		Message:      strings.Join(notes, "; "),
+	}
+}
+
+// billOfMaterialsCreateRequest returns the products of the source bill of materials, without the IDs and PingID
+// deployment that belong to the source environment
+func billOfMaterialsCreateRequest(billOfMaterials management.BillOfMaterials) *management.BillOfMaterials {
+	products := make([]management.BillOfMaterialsProductsInner, 0, len(billOfMaterials.Products))
+	for _, product := range billOfMaterials.Products {
+		product.Id = nil
+		product.Deployment = nil
+		products = append(products, product)
+	}
+	return &management.BillOfMaterials{
+		SolutionType: billOfMaterials.SolutionType,
+		Products:     products,
+	}
+}
+
+// passwordPolicyRequest returns the source password policy without its read-only attributes
+func passwordPolicyRequest(policy management.PasswordPolicy) management.PasswordPolicy {
+	policy.Links = nil
+	policy.Id = nil
+	policy.Environment = nil
+	policy.CreatedAt = nil
+	policy.UpdatedAt = nil
+	policy.PopulationCount = nil
+	policy.CurrentPassword = nil
+	policy.NewPassword = nil
+	return policy
+}
+
+// populationRequest returns the source population without its read-only attributes, with the password policy
+// reference mapped to the new environment. Themes are not cloned, so the theme reference is removed.
+func populationRequest(population management.Population, passwordPolicyIds map[string]string) (management.Population, []string) {
+	var notes []string
+	population.Links = nil
+	population.Id = nil
+	population.Environment = nil
+	population.CreatedAt = nil
+	population.UpdatedAt = nil
+	population.UserCount = nil
+	if population.PasswordPolicy != nil {
+		if targetId, ok := passwordPolicyIds[population.PasswordPolicy.Id]; ok {
+			population.PasswordPolicy = &management.PopulationPasswordPolicy{Id: targetId}
+		} else {
+			population.PasswordPolicy = nil
+			notes = append(notes, "the password policy was not copied, as it was not cloned")
+		}
+	}
+	if population.Theme != nil {
+		population.Theme = nil
+		notes = append(notes, "the branding theme was not copied, as themes are not cloned")
+	}
+	return population, notes
+}
+
+// applicationCreateRequest returns the request that recreates the source application, with the settings that
+// reference resources of the source environment removed and described in the notes. The client ID and secret are
+// not copied, so that PingOne generates new credentials. The request is nil for applications that cannot be cloned.
+func applicationCreateRequest(application management.ReadOneApplication200Response) (string, *management.CreateApplicationRequest, []string) {
+	var notes []string
+	switch {
+	case application.ApplicationOIDC != nil:
+		app := *application.ApplicationOIDC
+		app.Links, app.Id, app.Environment, app.CreatedAt, app.UpdatedAt = nil, nil, nil, nil, nil
+		app.ClientId, app.ClientSecret = nil, nil
+		app.AccessControl, notes = withoutGroupAccessControl(app.AccessControl, notes)
+		if app.Signing != nil {
+			app.Signing = nil
+			notes = append(notes, "the signing key rotation policy was not copied, as it belongs to the source environment")
+		}
+		return app.Name, &management.CreateApplicationRequest{ApplicationOIDC: &app}, notes
+	case application.ApplicationSAML != nil:
+		app := *application.ApplicationSAML
+		app.Links, app.Id, app.Environment, app.CreatedAt, app.UpdatedAt = nil, nil, nil, nil, nil
+		app.AccessControl, notes = withoutGroupAccessControl(app.AccessControl, notes)
+		if app.IdpSigning != nil {
+			app.IdpSigning = nil
+			notes = append(notes, "the IdP signing key was not copied, as it belongs to the source environment")
+		}
+		if app.SpVerification != nil {
+			app.SpVerification = nil
+			notes = append(notes, "the SP verification certificates were not copied, as they belong to the source environment")
+		}
+		if app.SpEncryption != nil {
+			app.SpEncryption = nil
+			notes = append(notes, "the SP encryption certificate was not copied, as it belongs to the source environment")
+		}
+		return app.Name, &management.CreateApplicationRequest{ApplicationSAML: &app}, notes
+	case application.ApplicationExternalLink != nil:
+		app := *application.ApplicationExternalLink
+		app.Links, app.Id, app.Environment, app.CreatedAt, app.UpdatedAt = nil, nil, nil, nil, nil
+		app.AccessControl, notes = withoutGroupAccessControl(app.AccessControl, notes)
+		return app.Name, &management.CreateApplicationRequest{ApplicationExternalLink: &app}, notes
+	case application.ApplicationWSFED != nil:
+		return application.ApplicationWSFED.Name, nil,
+			[]string{"WS-Fed applications are not cloned, as they require a signing key of the source environment"}
+	case application.ApplicationPingOnePortal != nil:
+		return application.ApplicationPingOnePortal.Name, nil,
+			[]string{"built-in applications are created with the environment and are not cloned"}
+	case application.ApplicationPingOneSelfService != nil:
+		return application.ApplicationPingOneSelfService.Name, nil,
+			[]string{"built-in applications are created with the environment and are not cloned"}
+	case application.ApplicationPingOneAdminConsole != nil:
+		return "PingOne Admin Console", nil,
+			[]string{"built-in applications are created with the environment and are not cloned"}
+	}
+	return "", nil, []string{"unsupported application type"}
+}
+
+// withoutGroupAccessControl removes the group access control, as groups are not cloned
+func withoutGroupAccessControl(accessControl *management.ApplicationAccessControl, notes []string) (*management.ApplicationAccessControl, []string) {
+	if accessControl == nil || accessControl.Group == nil {
+		return accessControl, notes
+	}
+	notes = append(notes, "the group access control was not copied, as groups are not cloned")
+	if accessControl.Role == nil {
+		return nil, notes
+	}
+	return &management.ApplicationAccessControl{Role: accessControl.Role}, notes
+}
+
+// createdApplicationId returns the ID of the application in the create response
+func createdApplicationId(response *management.CreateApplication201Response) *string {
+	switch {
+	case response == nil:
+		return nil
+	case response.ApplicationOIDC != nil:
+		return response.ApplicationOIDC.Id
+	case response.ApplicationSAML != nil:
+		return response.ApplicationSAML.Id
+	case response.ApplicationExternalLink != nil:
+		return response.ApplicationExternalLink.Id
+	case response.ApplicationWSFED != nil:
+		return response.ApplicationWSFED.Id
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentcloning
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "environment_cloning"
+
+var _ collections.LegacySdkCollection = &EnvironmentCloningCollection{}
+
+type EnvironmentCloningCollection struct{}
+
+func (c *EnvironmentCloningCollection) Name() string {
+	return CollectionName
+}
+
+func (c *EnvironmentCloningCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	environmentCloningClientFactory := NewPingOneClientEnvironmentCloningWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&CloneEnvironmentDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CloneEnvironmentDef.McpTool.Name))
+		mcp.AddTool(server, CloneEnvironmentDef.McpTool, CloneEnvironmentHandler(environmentCloningClientFactory))
+	}
+
+	return nil
+}
+
+func (c *EnvironmentCloningCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		CloneEnvironmentDef,
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentcloning_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentcloning"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ environmentcloning.EnvironmentCloningClient = &mockPingOneClientEnvironmentCloningWrapper{}
+var _ environmentcloning.EnvironmentCloningClientFactory = &mockPingOneClientEnvironmentCloningWrapperFactory{}
+
+type mockPingOneClientEnvironmentCloningWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientEnvironmentCloningWrapperFactory struct {
+	mockClient environmentcloning.EnvironmentCloningClient
+	err        error
+}
+
+// NewMockPingOneClientEnvironmentCloningWrapperFactory directly returns the provided mock client and error
+func NewMockPingOneClientEnvironmentCloningWrapperFactory(mockClient environmentcloning.EnvironmentCloningClient, err error) *mockPingOneClientEnvironmentCloningWrapperFactory {
+	return &mockPingOneClientEnvironmentCloningWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientEnvironmentCloningWrapperFactory) GetAuthenticatedClient(ctx context.Context) (environmentcloning.EnvironmentCloningClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return mockResponse[management.Environment](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) GetBillOfMaterials(ctx context.Context, environmentId uuid.UUID) (*management.BillOfMaterials, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return mockResponse[management.BillOfMaterials](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) CreateEnvironment(ctx context.Context, createRequest management.Environment) (*management.Environment, *http.Response, error) {
+	args := p.Called(ctx, createRequest)
+	return mockResponse[management.Environment](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) GetPasswordPolicies(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return mockIterator(args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) CreatePasswordPolicy(ctx context.Context, environmentId uuid.UUID, createRequest management.PasswordPolicy) (*management.PasswordPolicy, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return mockResponse[management.PasswordPolicy](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) UpdatePasswordPolicy(ctx context.Context, environmentId uuid.UUID, passwordPolicyId string, updateRequest management.PasswordPolicy) (*management.PasswordPolicy, *http.Response, error) {
+	args := p.Called(ctx, environmentId, passwordPolicyId, updateRequest)
+	return mockResponse[management.PasswordPolicy](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return mockIterator(args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) CreatePopulation(ctx context.Context, environmentId uuid.UUID, createRequest management.Population) (*management.Population, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return mockResponse[management.Population](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) UpdatePopulation(ctx context.Context, environmentId uuid.UUID, populationId string, updateRequest management.Population) (*management.Population, *http.Response, error) {
+	args := p.Called(ctx, environmentId, populationId, updateRequest)
+	return mockResponse[management.Population](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) GetApplication(ctx context.Context, environmentId uuid.UUID, applicationId uuid.UUID) (*management.ReadOneApplication200Response, *http.Response, error) {
+	args := p.Called(ctx, environmentId, applicationId)
+	return mockResponse[management.ReadOneApplication200Response](args)
+}
+
+func (p *mockPingOneClientEnvironmentCloningWrapper) CreateApplication(ctx context.Context, environmentId uuid.UUID, createRequest management.CreateApplicationRequest) (*management.CreateApplication201Response, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return mockResponse[management.CreateApplication201Response](args)
+}
+
+func mockResponse[T any](args mock.Arguments) (*T, *http.Response, error) {
+	var response *T
+	if args.Get(0) != nil {
+		response = args.Get(0).(*T)
+	}
+	var httpResponse *http.Response
+	if args.Get(1) != nil {
+		httpResponse = args.Get(1).(*http.Response)
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func mockIterator(args mock.Arguments) (management.EntityArrayPagedIterator, error) {
+	var iterator management.EntityArrayPagedIterator
+	if args.Get(0) != nil {
+		iterator = args.Get(0).(management.EntityArrayPagedIterator)
+	}
+	return iterator, args.Error(1)
+}
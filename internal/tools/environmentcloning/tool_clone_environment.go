@@ -0,0 +1,377 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentcloning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CloneEnvironmentDef = types.ToolDefinition{
+	// Environment creation consumes license quota and provisions services, so only one is created at a time
+	MaxConcurrentExecutions: 1,
+	// Environments are created in the organization, which only Organization Admins can do
+	RequiredRoles: []string{"Organization Admin"},
+	ValidationPolicy: &types.ToolValidationPolicy{
+		// The source environment is only read, changes are made in the new sandbox environment
+		AllowProductionEnvironmentWrite: true,
+		CreatesEnvironment:              true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "clone_environment",
+		Title:        "Clone PingOne Environment",
+		Description:  "Create a new sandbox environment from a source environment, copying its services (bill of materials), password policies and populations, and the applications listed in 'applicationIds'. Password policies and populations of the same name that PingOne creates with the environment are updated to match the source. Applications get a new client ID and secret; settings that reference keys, certificates, groups or themes of the source environment are not copied. Returns the new environment and a result per resource, as a resource that fails does not stop the others. Requires license quota. Cloning takes a while; set runInBackground to clone in a background job and follow it with get_job_status.",
+		InputSchema:  schema.MustGenerateSchema[CloneEnvironmentInput](),
+		OutputSchema: schema.MustGenerateSchema[CloneEnvironmentOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CloneEnvironmentInput struct {
+	EnvironmentId   uuid.UUID   `json:"environmentId" jsonschema:"REQUIRED. Source environment UUID. It is only read."`
+	Name            string      `json:"name" jsonschema:"REQUIRED. Name of the new environment, must be unique within organization."`
+	Description     *string     `json:"description,omitempty" jsonschema:"OPTIONAL. Description of the new environment. Defaults to the description of the source environment."`
+	LicenseId       *uuid.UUID  `json:"licenseId,omitempty" jsonschema:"OPTIONAL. License UUID of the new environment. Defaults to the license of the source environment."`
+	ApplicationIds  []uuid.UUID `json:"applicationIds,omitempty" jsonschema:"OPTIONAL. UUIDs of the source applications to clone. OIDC, SAML and external link applications can be cloned. No applications are cloned by default."`
+	RunInBackground bool        `json:"runInBackground,omitempty" jsonschema:"OPTIONAL. Clone the environment in a background job and return its ID straight away, instead of waiting for the clone to complete. Follow the job with get_job_status. Defaults to false."`
+}
+
+type CloneEnvironmentOutput struct {
+	Job         *jobs.Job          `json:"job,omitempty" jsonschema:"The background job cloning the environment, if runInBackground was set. The new environment and resources are returned by get_job_status once the job has succeeded"`
+	Environment *ClonedEnvironment `json:"environment,omitempty" jsonschema:"The new sandbox environment"`
+	Resources   []ClonedResource   `json:"resources" jsonschema:"The result of cloning each resource"`
+}
+
+// CloneEnvironmentHandler creates a new sandbox environment from a source environment using the provided client
+func CloneEnvironmentHandler(environmentCloningClientFactory EnvironmentCloningClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CloneEnvironmentInput,
+) (
+	*mcp.CallToolResult,
+	*CloneEnvironmentOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CloneEnvironmentInput) (*mcp.CallToolResult, *CloneEnvironmentOutput, error) {
+		client, err := environmentCloningClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CloneEnvironmentDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if input.RunInBackground {
+			job, err := jobs.Start(ctx, CloneEnvironmentDef, input.EnvironmentId.String(), func(ctx context.Context) (any, error) {
+				return cloneEnvironment(ctx, client, input)
+			})
+			if err != nil {
+				toolErr := errs.NewToolError(CloneEnvironmentDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			return nil, &CloneEnvironmentOutput{
+				Job:       &job,
+				Resources: []ClonedResource{},
+			}, nil
+		}
+
+		result, err := cloneEnvironment(ctx, client, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
+
+// cloneEnvironment creates the new sandbox environment and clones the resources of the source environment into it
+func cloneEnvironment(ctx context.Context, client EnvironmentCloningClient, input CloneEnvironmentInput) (*CloneEnvironmentOutput, error) {
+	logger.FromContext(ctx).Debug("Cloning environment",
+		slog.String("environmentId", input.EnvironmentId.String()),
+		slog.String("name", input.Name),
+		slog.Int("applications", len(input.ApplicationIds)))
+
+	// The whole source is read before the new environment is created, so that a source that cannot be read
+	// does not leave a partial clone
+	source, err := readSource(ctx, client, input)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.Report(ctx, 0, 0, "Creating the environment")
+
+	// SANDBOX is hardcoded as PRODUCTION environments are not supported via this MCP tool
+	createRequest := management.Environment{
+		Name:            input.Name,
+		Description:     source.environment.Description,
+		Icon:            source.environment.Icon,
+		License:         source.environment.License,
+		Region:          source.environment.Region,
+		Type:            management.ENUMENVIRONMENTTYPE_SANDBOX,
+		BillOfMaterials: billOfMaterialsCreateRequest(source.billOfMaterials),
+	}
+	if input.Description != nil {
+		createRequest.Description = input.Description
+	}
+	if input.LicenseId != nil {
+		createRequest.License = management.EnvironmentLicense{Id: input.LicenseId.String()}
+	}
+
+	environment, httpResponse, err := client.CreateEnvironment(ctx, createRequest)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	if environment == nil || environment.Id == nil {
+		apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	targetId, err := uuid.Parse(*environment.Id)
+	if err != nil {
+		toolErr := errs.NewToolError(CloneEnvironmentDef.McpTool.Name, fmt.Errorf("invalid environment ID in response: %w", err))
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+
+	logger.FromContext(ctx).Debug("Cloned environment created",
+		slog.String("environmentId", targetId.String()),
+		slog.String("name", environment.Name))
+
+	total := 1 + len(source.passwordPolicies) + len(source.populations) + len(input.ApplicationIds)
+	progress.Report(ctx, 1, total, "Cloning password policies")
+	resources := []ClonedResource{
+		cloneResult(ResourceTypeEnvironment, environment.Name, input.EnvironmentId.String(), environment.Id, CloneStatusCreated, nil),
+	}
+	passwordPolicyResources, passwordPolicyIds := clonePasswordPolicies(ctx, client, targetId, source.passwordPolicies)
+	resources = append(resources, passwordPolicyResources...)
+	progress.Report(ctx, len(resources), total, "Cloning populations")
+	resources = append(resources, clonePopulations(ctx, client, targetId, source.populations, passwordPolicyIds)...)
+	progress.Report(ctx, len(resources), total, "Cloning applications")
+	resources = append(resources, cloneApplications(ctx, client, targetId, input.ApplicationIds, source.applications)...)
+
+	cloned := clonedEnvironment(*environment)
+	return &CloneEnvironmentOutput{
+		Environment: &cloned,
+		Resources:   resources,
+	}, nil
+}
+
+type cloneSource struct {
+	environment      management.Environment
+	billOfMaterials  management.BillOfMaterials
+	passwordPolicies []management.PasswordPolicy
+	populations      []management.Population
+	applications     map[uuid.UUID]management.ReadOneApplication200Response
+}
+
+func readSource(ctx context.Context, client EnvironmentCloningClient, input CloneEnvironmentInput) (*cloneSource, error) {
+	environment, httpResponse, err := client.GetEnvironment(ctx, input.EnvironmentId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	if environment == nil {
+		apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+
+	billOfMaterials, httpResponse, err := client.GetBillOfMaterials(ctx, input.EnvironmentId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	if billOfMaterials == nil {
+		apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no bill of materials data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+
+	source := &cloneSource{
+		environment:     *environment,
+		billOfMaterials: *billOfMaterials,
+		applications:    map[uuid.UUID]management.ReadOneApplication200Response{},
+	}
+
+	passwordPolicies, err := readPasswordPolicies(ctx, client, input.EnvironmentId)
+	if err != nil {
+		return nil, err
+	}
+	source.passwordPolicies = passwordPolicies
+
+	populations, err := readPopulations(ctx, client, input.EnvironmentId)
+	if err != nil {
+		return nil, err
+	}
+	source.populations = populations
+
+	for _, applicationId := range input.ApplicationIds {
+		application, httpResponse, err := client.GetApplication(ctx, input.EnvironmentId, applicationId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		if application == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no application data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		source.applications[applicationId] = *application
+	}
+
+	return source, nil
+}
+
+func readPasswordPolicies(ctx context.Context, client EnvironmentCloningClient, environmentId uuid.UUID) ([]management.PasswordPolicy, error) {
+	pagedIterator, err := client.GetPasswordPolicies(ctx, environmentId)
+	if err != nil {
+		toolErr := errs.NewToolError(CloneEnvironmentDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	var passwordPolicies []management.PasswordPolicy
+	err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		passwordPolicies = append(passwordPolicies, embedded.PasswordPolicies...)
+		return true
+	})
+	return passwordPolicies, err
+}
+
+func readPopulations(ctx context.Context, client EnvironmentCloningClient, environmentId uuid.UUID) ([]management.Population, error) {
+	pagedIterator, err := client.GetPopulations(ctx, environmentId)
+	if err != nil {
+		toolErr := errs.NewToolError(CloneEnvironmentDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	var populations []management.Population
+	err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		populations = append(populations, embedded.Populations...)
+		return true
+	})
+	return populations, err
+}
+
+// clonePasswordPolicies recreates the source password policies in the target environment, returning the ID in the
+// target environment of each source password policy that was cloned
+func clonePasswordPolicies(ctx context.Context, client EnvironmentCloningClient, targetId uuid.UUID, passwordPolicies []management.PasswordPolicy) ([]ClonedResource, map[string]string) {
+	existing := map[string]string{}
+	if targetPolicies, err := readPasswordPolicies(ctx, client, targetId); err == nil {
+		for _, policy := range targetPolicies {
+			existing[policy.Name] = policy.GetId()
+		}
+	}
+
+	var resources []ClonedResource
+	passwordPolicyIds := map[string]string{}
+	for _, policy := range passwordPolicies {
+		request := passwordPolicyRequest(policy)
+		var response *management.PasswordPolicy
+		var httpResponse *http.Response
+		var err error
+		status := CloneStatusCreated
+		if existingId, ok := existing[policy.Name]; ok {
+			status = CloneStatusUpdated
+			response, httpResponse, err = client.UpdatePasswordPolicy(ctx, targetId, existingId, request)
+		} else {
+			response, httpResponse, err = client.CreatePasswordPolicy(ctx, targetId, request)
+		}
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil || response == nil {
+			resources = append(resources, cloneResult(ResourceTypePasswordPolicy, policy.Name, policy.GetId(), nil, CloneStatusFailed, []string{failureMessage(ctx, httpResponse, err)}))
+			continue
+		}
+		passwordPolicyIds[policy.GetId()] = response.GetId()
+		resources = append(resources, cloneResult(ResourceTypePasswordPolicy, policy.Name, policy.GetId(), response.Id, status, nil))
+	}
+	return resources, passwordPolicyIds
+}
+
+// clonePopulations recreates the source populations in the target environment
+func clonePopulations(ctx context.Context, client EnvironmentCloningClient, targetId uuid.UUID, populations []management.Population, passwordPolicyIds map[string]string) []ClonedResource {
+	existing := map[string]string{}
+	if targetPopulations, err := readPopulations(ctx, client, targetId); err == nil {
+		for _, population := range targetPopulations {
+			existing[population.Name] = population.GetId()
+		}
+	}
+
+	var resources []ClonedResource
+	for _, population := range populations {
+		request, notes := populationRequest(population, passwordPolicyIds)
+		var response *management.Population
+		var httpResponse *http.Response
+		var err error
+		status := CloneStatusCreated
+		if existingId, ok := existing[population.Name]; ok {
+			status = CloneStatusUpdated
+			response, httpResponse, err = client.UpdatePopulation(ctx, targetId, existingId, request)
+		} else {
+			response, httpResponse, err = client.CreatePopulation(ctx, targetId, request)
+		}
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil || response == nil {
+			resources = append(resources, cloneResult(ResourceTypePopulation, population.Name, population.GetId(), nil, CloneStatusFailed, []string{failureMessage(ctx, httpResponse, err)}))
+			continue
+		}
+		resources = append(resources, cloneResult(ResourceTypePopulation, population.Name, population.GetId(), response.Id, status, notes))
+	}
+	return resources
+}
+
+// cloneApplications creates the source applications in the target environment
+func cloneApplications(ctx context.Context, client EnvironmentCloningClient, targetId uuid.UUID, applicationIds []uuid.UUID, applications map[uuid.UUID]management.ReadOneApplication200Response) []ClonedResource {
+	var resources []ClonedResource
+	for _, applicationId := range applicationIds {
+		sourceId := applicationId.String()
+		name, request, notes := applicationCreateRequest(applications[applicationId])
+		if request == nil {
+			resources = append(resources, cloneResult(ResourceTypeApplication, name, sourceId, nil, CloneStatusSkipped, notes))
+			continue
+		}
+		response, httpResponse, err := client.CreateApplication(ctx, targetId, *request)
+		logger.LogHttpResponse(ctx, httpResponse)
+		createdId := createdApplicationId(response)
+		if err != nil || createdId == nil {
+			resources = append(resources, cloneResult(ResourceTypeApplication, name, sourceId, nil, CloneStatusFailed, []string{failureMessage(ctx, httpResponse, err)}))
+			continue
+		}
+		resources = append(resources, cloneResult(ResourceTypeApplication, name, sourceId, createdId, CloneStatusCreated, notes))
+	}
+	return resources
+}
+
+// failureMessage describes why PingOne did not create or update a resource. The error is logged, but does not fail
+// the tool call, so that the other resources are still cloned.
+func failureMessage(ctx context.Context, httpResponse *http.Response, err error) string {
+	if err == nil {
+		err = errors.New("no data in response")
+	}
+	apiErr := errs.NewApiError(httpResponse, err)
+	errs.Log(ctx, apiErr)
+	return apiErr.Error()
+}
@@ -0,0 +1,377 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentcloning_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentcloning"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSourceEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testTargetEnvironmentId = uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	testLicenseId           = "11111111-1111-4111-8111-111111111111"
+	testOidcApplicationId   = uuid.MustParse("a1b2c3d4-e5f6-4789-8abc-def012345678")
+	testWsFedApplicationId  = uuid.MustParse("b2c3d4e5-f6a7-4890-9bcd-ef0123456789")
+)
+
+func testSourceEnvironment() *management.Environment {
+	region := management.ENUMREGIONCODE_EU
+	return &management.Environment{
+		Id:          testutils.Pointer(testSourceEnvironmentId.String()),
+		Name:        "Demo",
+		Description: testutils.Pointer("Demo environment"),
+		License:     management.EnvironmentLicense{Id: testLicenseId},
+		Region:      management.EnumRegionCodeAsEnvironmentRegion(&region),
+		Type:        management.ENUMENVIRONMENTTYPE_PRODUCTION,
+	}
+}
+
+func testBillOfMaterials() *management.BillOfMaterials {
+	return &management.BillOfMaterials{
+		CreatedAt: testutils.Pointer("2025-01-01T00:00:00Z"),
+		Products: []management.BillOfMaterialsProductsInner{
+			{Id: testutils.Pointer("bom-product-1"), Type: management.ENUMPRODUCTTYPE_ONE_BASE},
+			{
+				Id:         testutils.Pointer("bom-product-2"),
+				Type:       management.ENUMPRODUCTTYPE_ONE_ID,
+				Deployment: &management.BillOfMaterialsProductsInnerDeployment{Id: testutils.Pointer("pingid-deployment")},
+			},
+		},
+	}
+}
+
+func testPasswordPolicy(id string, name string) management.PasswordPolicy {
+	return management.PasswordPolicy{
+		Id:                   testutils.Pointer(id),
+		Name:                 name,
+		Environment:          &management.ObjectEnvironment{Id: testutils.Pointer(testSourceEnvironmentId.String())},
+		ExcludesCommonlyUsed: true,
+		PopulationCount:      testutils.Pointer(int32(2)),
+	}
+}
+
+func testPopulation(id string, name string, passwordPolicyId string) management.Population {
+	return management.Population{
+		Id:             testutils.Pointer(id),
+		Name:           name,
+		UserCount:      testutils.Pointer(int32(40)),
+		PasswordPolicy: &management.PopulationPasswordPolicy{Id: passwordPolicyId},
+		Theme:          &management.PopulationTheme{Id: testutils.Pointer("theme-1")},
+	}
+}
+
+func pagesOf(embedded management.EntityArrayEmbedded) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &embedded},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+// mockSource sets up the reads of the source environment, with a Standard and a Custom password policy, and a
+// population using the Custom policy
+func mockSource(mockClient *mockPingOneClientEnvironmentCloningWrapper) {
+	mockClient.On("GetEnvironment", mock.Anything, testSourceEnvironmentId).Return(testSourceEnvironment(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetBillOfMaterials", mock.Anything, testSourceEnvironmentId).Return(testBillOfMaterials(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetPasswordPolicies", mock.Anything, testSourceEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		PasswordPolicies: []management.PasswordPolicy{
+			testPasswordPolicy("source-standard", "Standard"),
+			testPasswordPolicy("source-custom", "Custom"),
+		},
+	}), nil).Once()
+	mockClient.On("GetPopulations", mock.Anything, testSourceEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		Populations: []management.Population{testPopulation("source-population", "Employees", "source-custom")},
+	}), nil).Once()
+}
+
+// mockTarget sets up the creation of the new environment, which PingOne creates with a Standard password policy
+func mockTarget(mockClient *mockPingOneClientEnvironmentCloningWrapper) {
+	region := management.ENUMREGIONCODE_EU
+	mockClient.On("CreateEnvironment", mock.Anything, mock.Anything).Return(&management.Environment{
+		Id:      testutils.Pointer(testTargetEnvironmentId.String()),
+		Name:    "Demo Copy",
+		License: management.EnvironmentLicense{Id: testLicenseId},
+		Region:  management.EnumRegionCodeAsEnvironmentRegion(&region),
+		Type:    management.ENUMENVIRONMENTTYPE_SANDBOX,
+	}, &http.Response{StatusCode: 201}, nil).Once()
+	mockClient.On("GetPasswordPolicies", mock.Anything, testTargetEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		PasswordPolicies: []management.PasswordPolicy{testPasswordPolicy("target-standard", "Standard")},
+	}), nil).Once()
+	mockClient.On("GetPopulations", mock.Anything, testTargetEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		Populations: []management.Population{},
+	}), nil).Once()
+}
+
+func findResource(t *testing.T, resources []environmentcloning.ClonedResource, resourceType environmentcloning.ResourceType, name string) environmentcloning.ClonedResource {
+	t.Helper()
+	for _, resource := range resources {
+		if resource.ResourceType == resourceType && resource.Name == name {
+			return resource
+		}
+	}
+	require.Failf(t, "resource not found", "%s %s", resourceType, name)
+	return environmentcloning.ClonedResource{}
+}
+
+func TestCloneEnvironmentHandler(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentCloningWrapper{}
+	mockSource(mockClient)
+	mockClient.On("GetApplication", mock.Anything, testSourceEnvironmentId, testOidcApplicationId).Return(&management.ReadOneApplication200Response{
+		ApplicationOIDC: &management.ApplicationOIDC{
+			Id:           testutils.Pointer(testOidcApplicationId.String()),
+			Name:         "Web Portal",
+			Protocol:     management.ENUMAPPLICATIONPROTOCOL_OPENID_CONNECT,
+			Type:         management.ENUMAPPLICATIONTYPE_WEB_APP,
+			ClientId:     testutils.Pointer(testOidcApplicationId.String()),
+			ClientSecret: testutils.Pointer("source-secret"),
+			RedirectUris: []string{"https://portal.example.com/callback"},
+			AccessControl: &management.ApplicationAccessControl{
+				Group: &management.ApplicationAccessControlGroup{Type: management.ENUMAPPLICATIONACCESSCONTROLGROUPTYPE_ANY_GROUP},
+			},
+		},
+	}, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetApplication", mock.Anything, testSourceEnvironmentId, testWsFedApplicationId).Return(&management.ReadOneApplication200Response{
+		ApplicationWSFED: &management.ApplicationWSFED{
+			Id:   testutils.Pointer(testWsFedApplicationId.String()),
+			Name: "SharePoint",
+		},
+	}, &http.Response{StatusCode: 200}, nil).Once()
+	mockTarget(mockClient)
+
+	mockClient.On("UpdatePasswordPolicy", mock.Anything, testTargetEnvironmentId, "target-standard", mock.MatchedBy(func(req management.PasswordPolicy) bool {
+		return req.Name == "Standard" && req.Id == nil && req.Environment == nil && req.PopulationCount == nil
+	})).Return(&management.PasswordPolicy{Id: testutils.Pointer("target-standard"), Name: "Standard"}, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("CreatePasswordPolicy", mock.Anything, testTargetEnvironmentId, mock.MatchedBy(func(req management.PasswordPolicy) bool {
+		return req.Name == "Custom" && req.Id == nil && req.ExcludesCommonlyUsed
+	})).Return(&management.PasswordPolicy{Id: testutils.Pointer("target-custom"), Name: "Custom"}, &http.Response{StatusCode: 201}, nil).Once()
+	mockClient.On("CreatePopulation", mock.Anything, testTargetEnvironmentId, mock.MatchedBy(func(req management.Population) bool {
+		return req.Name == "Employees" && req.Id == nil && req.UserCount == nil && req.Theme == nil &&
+			req.PasswordPolicy != nil && req.PasswordPolicy.Id == "target-custom"
+	})).Return(&management.Population{Id: testutils.Pointer("target-population"), Name: "Employees"}, &http.Response{StatusCode: 201}, nil).Once()
+	mockClient.On("CreateApplication", mock.Anything, testTargetEnvironmentId, mock.MatchedBy(func(req management.CreateApplicationRequest) bool {
+		app := req.ApplicationOIDC
+		return app != nil && app.Name == "Web Portal" && app.Id == nil && app.ClientId == nil && app.ClientSecret == nil &&
+			app.AccessControl == nil && len(app.RedirectUris) == 1
+	})).Return(&management.CreateApplication201Response{
+		ApplicationOIDC: &management.ApplicationOIDC{Id: testutils.Pointer("target-application"), Name: "Web Portal"},
+	}, &http.Response{StatusCode: 201}, nil).Once()
+
+	handler := environmentcloning.CloneEnvironmentHandler(NewMockPingOneClientEnvironmentCloningWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentcloning.CloneEnvironmentInput{
+		EnvironmentId:  testSourceEnvironmentId,
+		Name:           "Demo Copy",
+		ApplicationIds: []uuid.UUID{testOidcApplicationId, testWsFedApplicationId},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+
+	createRequest := mockClient.Calls[indexOfCall(mockClient, "CreateEnvironment")].Arguments.Get(1).(management.Environment)
+	assert.Equal(t, "Demo Copy", createRequest.Name)
+	assert.Equal(t, management.ENUMENVIRONMENTTYPE_SANDBOX, createRequest.Type, "clones are always sandbox environments")
+	assert.Equal(t, testLicenseId, createRequest.License.Id)
+	assert.Equal(t, "Demo environment", *createRequest.Description)
+	require.NotNil(t, createRequest.BillOfMaterials)
+	require.Len(t, createRequest.BillOfMaterials.Products, 2)
+	for _, product := range createRequest.BillOfMaterials.Products {
+		assert.Nil(t, product.Id)
+		assert.Nil(t, product.Deployment)
+	}
+
+	assert.Equal(t, testTargetEnvironmentId.String(), output.Environment.Id)
+	assert.Equal(t, "EU", output.Environment.Region)
+	assertMatchesOutputSchema(t, output)
+	require.Len(t, output.Resources, 6)
+	assert.Equal(t, environmentcloning.CloneStatusCreated, findResource(t, output.Resources, environmentcloning.ResourceTypeEnvironment, "Demo Copy").Status)
+
+	standard := findResource(t, output.Resources, environmentcloning.ResourceTypePasswordPolicy, "Standard")
+	assert.Equal(t, environmentcloning.CloneStatusUpdated, standard.Status)
+	assert.Equal(t, "target-standard", *standard.TargetId)
+	assert.Equal(t, environmentcloning.CloneStatusCreated, findResource(t, output.Resources, environmentcloning.ResourceTypePasswordPolicy, "Custom").Status)
+
+	population := findResource(t, output.Resources, environmentcloning.ResourceTypePopulation, "Employees")
+	assert.Equal(t, environmentcloning.CloneStatusCreated, population.Status)
+	assert.Equal(t, "source-population", population.SourceId)
+	assert.Contains(t, population.Message, "branding theme was not copied")
+
+	webPortal := findResource(t, output.Resources, environmentcloning.ResourceTypeApplication, "Web Portal")
+	assert.Equal(t, environmentcloning.CloneStatusCreated, webPortal.Status)
+	assert.Equal(t, "target-application", *webPortal.TargetId)
+	assert.Contains(t, webPortal.Message, "group access control was not copied")
+
+	sharePoint := findResource(t, output.Resources, environmentcloning.ResourceTypeApplication, "SharePoint")
+	assert.Equal(t, environmentcloning.CloneStatusSkipped, sharePoint.Status)
+	assert.Nil(t, sharePoint.TargetId)
+	assert.Contains(t, sharePoint.Message, "WS-Fed applications are not cloned")
+}
+
+func TestCloneEnvironmentHandler_Overrides(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentCloningWrapper{}
+	mockSource(mockClient)
+	mockTarget(mockClient)
+	mockClient.On("UpdatePasswordPolicy", mock.Anything, testTargetEnvironmentId, "target-standard", mock.Anything).Return(&management.PasswordPolicy{Id: testutils.Pointer("target-standard")}, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("CreatePasswordPolicy", mock.Anything, testTargetEnvironmentId, mock.Anything).Return(&management.PasswordPolicy{Id: testutils.Pointer("target-custom")}, &http.Response{StatusCode: 201}, nil).Once()
+	mockClient.On("CreatePopulation", mock.Anything, testTargetEnvironmentId, mock.Anything).Return(&management.Population{Id: testutils.Pointer("target-population")}, &http.Response{StatusCode: 201}, nil).Once()
+
+	licenseId := uuid.MustParse("22222222-2222-4222-8222-222222222222")
+	handler := environmentcloning.CloneEnvironmentHandler(NewMockPingOneClientEnvironmentCloningWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentcloning.CloneEnvironmentInput{
+		EnvironmentId: testSourceEnvironmentId,
+		Name:          "Demo Copy",
+		Description:   testutils.Pointer("Berlin demo"),
+		LicenseId:     &licenseId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "GetApplication", mock.Anything, mock.Anything, mock.Anything)
+
+	createRequest := mockClient.Calls[indexOfCall(mockClient, "CreateEnvironment")].Arguments.Get(1).(management.Environment)
+	assert.Equal(t, licenseId.String(), createRequest.License.Id)
+	assert.Equal(t, "Berlin demo", *createRequest.Description)
+	assert.Len(t, output.Resources, 4, "no applications are cloned by default")
+}
+
+func TestCloneEnvironmentHandler_ResourceFailureDoesNotStopClone(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentCloningWrapper{}
+	mockSource(mockClient)
+	mockTarget(mockClient)
+	mockClient.On("UpdatePasswordPolicy", mock.Anything, testTargetEnvironmentId, "target-standard", mock.Anything).Return(&management.PasswordPolicy{Id: testutils.Pointer("target-standard")}, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("CreatePasswordPolicy", mock.Anything, testTargetEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 400, Status: "400 Bad Request"}, errors.New("invalid password policy")).Once()
+	mockClient.On("CreatePopulation", mock.Anything, testTargetEnvironmentId, mock.MatchedBy(func(req management.Population) bool {
+		return req.PasswordPolicy == nil
+	})).Return(&management.Population{Id: testutils.Pointer("target-population")}, &http.Response{StatusCode: 201}, nil).Once()
+
+	handler := environmentcloning.CloneEnvironmentHandler(NewMockPingOneClientEnvironmentCloningWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentcloning.CloneEnvironmentInput{
+		EnvironmentId: testSourceEnvironmentId,
+		Name:          "Demo Copy",
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+
+	custom := findResource(t, output.Resources, environmentcloning.ResourceTypePasswordPolicy, "Custom")
+	assert.Equal(t, environmentcloning.CloneStatusFailed, custom.Status)
+	assert.Nil(t, custom.TargetId)
+	assert.Contains(t, custom.Message, "invalid password policy")
+
+	population := findResource(t, output.Resources, environmentcloning.ResourceTypePopulation, "Employees")
+	assert.Equal(t, environmentcloning.CloneStatusCreated, population.Status)
+	assert.Contains(t, population.Message, "password policy was not copied")
+}
+
+func TestCloneEnvironmentHandler_RunInBackground(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentCloningWrapper{}
+	mockSource(mockClient)
+	mockTarget(mockClient)
+	mockClient.On("UpdatePasswordPolicy", mock.Anything, testTargetEnvironmentId, "target-standard", mock.Anything).Return(&management.PasswordPolicy{Id: testutils.Pointer("target-standard")}, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("CreatePasswordPolicy", mock.Anything, testTargetEnvironmentId, mock.Anything).Return(&management.PasswordPolicy{Id: testutils.Pointer("target-custom")}, &http.Response{StatusCode: 201}, nil).Once()
+	mockClient.On("CreatePopulation", mock.Anything, testTargetEnvironmentId, mock.Anything).Return(&management.Population{Id: testutils.Pointer("target-population")}, &http.Response{StatusCode: 201}, nil).Once()
+	manager := jobs.NewManager(0, 0)
+
+	handler := environmentcloning.CloneEnvironmentHandler(NewMockPingOneClientEnvironmentCloningWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(jobs.ContextWithManager(context.Background(), manager), &mcp.CallToolRequest{}, environmentcloning.CloneEnvironmentInput{
+		EnvironmentId:   testSourceEnvironmentId,
+		Name:            "Demo Copy",
+		RunInBackground: true,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.Job)
+	assert.Equal(t, environmentcloning.CloneEnvironmentDef.McpTool.Name, output.Job.Tool)
+	assert.Equal(t, testSourceEnvironmentId.String(), output.Job.EnvironmentId)
+	assert.Nil(t, output.Environment, "the new environment is returned by the job")
+	assertMatchesOutputSchema(t, output)
+
+	job, err := manager.Wait(t.Context(), output.Job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status)
+	assert.Equal(t, &progress.Progress{Completed: 4, Total: 4, Message: "Cloning applications"}, job.Progress)
+	require.IsType(t, &environmentcloning.CloneEnvironmentOutput{}, job.Result)
+	result := job.Result.(*environmentcloning.CloneEnvironmentOutput)
+	assert.Equal(t, testTargetEnvironmentId.String(), result.Environment.Id)
+	assert.Len(t, result.Resources, 4)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCloneEnvironmentHandler_SourceReadErrorCreatesNothing(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentCloningWrapper{}
+	mockSource(mockClient)
+	mockClient.On("GetApplication", mock.Anything, testSourceEnvironmentId, testOidcApplicationId).Return(nil, &http.Response{StatusCode: 404, Status: "404 Not Found"}, errors.New("application not found")).Once()
+
+	handler := environmentcloning.CloneEnvironmentHandler(NewMockPingOneClientEnvironmentCloningWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentcloning.CloneEnvironmentInput{
+		EnvironmentId:  testSourceEnvironmentId,
+		Name:           "Demo Copy",
+		ApplicationIds: []uuid.UUID{testOidcApplicationId},
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "application not found")
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "CreateEnvironment", mock.Anything, mock.Anything)
+}
+
+func TestCloneEnvironmentHandler_CreateEnvironmentError(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentCloningWrapper{}
+	mockSource(mockClient)
+	mockClient.On("CreateEnvironment", mock.Anything, mock.Anything).Return(nil, &http.Response{StatusCode: 400, Status: "400 Bad Request"}, errors.New("license quota exceeded")).Once()
+
+	handler := environmentcloning.CloneEnvironmentHandler(NewMockPingOneClientEnvironmentCloningWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentcloning.CloneEnvironmentInput{
+		EnvironmentId: testSourceEnvironmentId,
+		Name:          "Demo Copy",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "license quota exceeded")
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "CreatePasswordPolicy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCloneEnvironmentHandler_ClientFactoryError(t *testing.T) {
+	handler := environmentcloning.CloneEnvironmentHandler(NewMockPingOneClientEnvironmentCloningWrapperFactory(nil, errors.New("not authenticated")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentcloning.CloneEnvironmentInput{
+		EnvironmentId: testSourceEnvironmentId,
+		Name:          "Demo Copy",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "not authenticated")
+}
+
+// assertMatchesOutputSchema checks the output as the MCP server does before returning it to the client
+func assertMatchesOutputSchema(t *testing.T, output *environmentcloning.CloneEnvironmentOutput) {
+	t.Helper()
+	resolved, err := environmentcloning.CloneEnvironmentDef.McpTool.OutputSchema.(*jsonschema.Schema).Resolve(nil)
+	require.NoError(t, err)
+	outputJson, err := json.Marshal(output)
+	require.NoError(t, err)
+	var value any
+	require.NoError(t, json.Unmarshal(outputJson, &value))
+	assert.NoError(t, resolved.Validate(value))
+}
+
+func indexOfCall(mockClient *mockPingOneClientEnvironmentCloningWrapper, method string) int {
+	for i, call := range mockClient.Calls {
+		if call.Method == method {
+			return i
+		}
+	}
+	return -1
+}
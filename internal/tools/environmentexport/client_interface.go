@@ -0,0 +1,25 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type EnvironmentExportClient interface {
+	GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error)
+	GetBillOfMaterials(ctx context.Context, environmentId uuid.UUID) (*management.BillOfMaterials, *http.Response, error)
+	GetApplications(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetGroups(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetPasswordPolicies(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetSignOnPolicies(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+}
+
+type EnvironmentExportClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (EnvironmentExportClient, error)
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "environment_export"
+
+var _ collections.LegacySdkCollection = &EnvironmentExportCollection{}
+
+type EnvironmentExportCollection struct{}
+
+func (c *EnvironmentExportCollection) Name() string {
+	return CollectionName
+}
+
+func (c *EnvironmentExportCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	environmentExportClientFactory := NewPingOneClientEnvironmentExportWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ExportEnvironmentDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ExportEnvironmentDef.McpTool.Name))
+		mcp.AddTool(server, ExportEnvironmentDef.McpTool, ExportEnvironmentHandler(environmentExportClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CompareEnvironmentsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CompareEnvironmentsDef.McpTool.Name))
+		mcp.AddTool(server, CompareEnvironmentsDef.McpTool, CompareEnvironmentsHandler(environmentExportClientFactory))
+	}
+
+	return nil
+}
+
+func (c *EnvironmentExportCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ExportEnvironmentDef,
+		CompareEnvironmentsDef,
+	}
+}
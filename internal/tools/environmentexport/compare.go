@@ -0,0 +1,203 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+)
+
+const (
+	ResourceTypeService        = "SERVICE"
+	ResourceTypePasswordPolicy = "PASSWORD_POLICY"
+	ResourceTypePopulation     = "POPULATION"
+	ResourceTypeGroup          = "GROUP"
+	ResourceTypeSignOnPolicy   = "SIGN_ON_POLICY"
+	ResourceTypeApplication    = "APPLICATION"
+
+	DifferenceOnlyInEnvironment = "ONLY_IN_ENVIRONMENT"
+	DifferenceOnlyInComparison  = "ONLY_IN_COMPARISON"
+	DifferenceChanged           = "CHANGED"
+)
+
+// ResourceDifference is a resource that is only in one of the compared environments, or whose configuration differs
+type ResourceDifference struct {
+	ResourceType string                `json:"resourceType" jsonschema:"The type of the resource: SERVICE, PASSWORD_POLICY, POPULATION, GROUP, SIGN_ON_POLICY or APPLICATION"`
+	Name         string                `json:"name" jsonschema:"The name of the resource, or the product type of a service"`
+	Status       string                `json:"status" jsonschema:"ONLY_IN_ENVIRONMENT if the resource is only in the environment, ONLY_IN_COMPARISON if it is only in the environment or snapshot compared to, or CHANGED if its configuration differs"`
+	Id           *string               `json:"id,omitempty" jsonschema:"The UUID of the resource in the environment"`
+	CompareToId  *string               `json:"compareToId,omitempty" jsonschema:"The UUID of the resource in the environment or snapshot compared to"`
+	Attributes   []AttributeDifference `json:"attributes,omitempty" jsonschema:"The attributes with different values, for CHANGED resources"`
+}
+
+// AttributeDifference is an attribute of a resource with different values in the compared environments
+type AttributeDifference struct {
+	Path           string `json:"path" jsonschema:"The dot-separated JSON path of the attribute in the resource"`
+	Value          any    `json:"value,omitempty" jsonschema:"The value in the environment, not set if the attribute is not set"`
+	CompareToValue any    `json:"compareToValue,omitempty" jsonschema:"The value in the environment or snapshot compared to, not set if the attribute is not set"`
+}
+
+// ignoredAttributes are the attributes that differ between environments whatever their configuration, such as IDs,
+// timestamps and counts. They are not compared at any depth, so that references to other resources by ID are not
+// reported as differences either.
+var ignoredAttributes = []string{
+	"_links",
+	"environment",
+	"id",
+	"createdAt",
+	"updatedAt",
+	"clientId",
+	"clientSecret",
+	"userCount",
+	"populationCount",
+	"directMemberCounts",
+	"totalMemberCounts",
+}
+
+// compareSnapshots returns the differences between the resources of two snapshots. Resources are matched by name,
+// and services by product type.
+func compareSnapshots(snapshot EnvironmentSnapshot, compareTo EnvironmentSnapshot) ([]ResourceDifference, error) {
+	resourceTypes := []struct {
+		resourceType string
+		nameKey      string
+		resources    func(snapshot EnvironmentSnapshot) any
+	}{
+		{ResourceTypeService, "type", func(snapshot EnvironmentSnapshot) any { return snapshot.Services }},
+		{ResourceTypePasswordPolicy, "name", func(snapshot EnvironmentSnapshot) any { return snapshot.PasswordPolicies }},
+		{ResourceTypePopulation, "name", func(snapshot EnvironmentSnapshot) any { return snapshot.Populations }},
+		{ResourceTypeGroup, "name", func(snapshot EnvironmentSnapshot) any { return snapshot.Groups }},
+		{ResourceTypeSignOnPolicy, "name", func(snapshot EnvironmentSnapshot) any { return snapshot.SignOnPolicies }},
+		{ResourceTypeApplication, "name", func(snapshot EnvironmentSnapshot) any { return snapshot.Applications }},
+	}
+
+	differences := []ResourceDifference{}
+	for _, t := range resourceTypes {
+		resources, err := jsonResources(t.resources(snapshot))
+		if err != nil {
+			return nil, err
+		}
+		compareToResources, err := jsonResources(t.resources(compareTo))
+		if err != nil {
+			return nil, err
+		}
+		differences = append(differences, compareResources(t.resourceType, t.nameKey, resources, compareToResources)...)
+	}
+	return differences, nil
+}
+
+// compareResources matches resources by name, pairing resources with the same name in order
+func compareResources(resourceType string, nameKey string, resources []map[string]any, compareToResources []map[string]any) []ResourceDifference {
+	unmatched := slices.Clone(compareToResources)
+	differences := []ResourceDifference{}
+	for _, resource := range resources {
+		name, _ := resource[nameKey].(string)
+		index := slices.IndexFunc(unmatched, func(compareToResource map[string]any) bool {
+			compareToName, _ := compareToResource[nameKey].(string)
+			return compareToName == name
+		})
+		if index < 0 {
+			differences = append(differences, ResourceDifference{
+				ResourceType: resourceType,
+				Name:         name,
+				Status:       DifferenceOnlyInEnvironment,
+				Id:           resourceId(resource),
+			})
+			continue
+		}
+		compareToResource := unmatched[index]
+		unmatched = slices.Delete(unmatched, index, index+1)
+		if attributes := compareAttributes(resource, compareToResource); len(attributes) > 0 {
+			differences = append(differences, ResourceDifference{
+				ResourceType: resourceType,
+				Name:         name,
+				Status:       DifferenceChanged,
+				Id:           resourceId(resource),
+				CompareToId:  resourceId(compareToResource),
+				Attributes:   attributes,
+			})
+		}
+	}
+	for _, compareToResource := range unmatched {
+		name, _ := compareToResource[nameKey].(string)
+		differences = append(differences, ResourceDifference{
+			ResourceType: resourceType,
+			Name:         name,
+			Status:       DifferenceOnlyInComparison,
+			CompareToId:  resourceId(compareToResource),
+		})
+	}
+	return differences
+}
+
+// compareAttributes returns the attributes with different values, sorted by path. Objects are compared attribute by
+// attribute, and arrays as a whole.
+func compareAttributes(resource map[string]any, compareToResource map[string]any) []AttributeDifference {
+	values := map[string]any{}
+	flattenAttributes("", resource, values)
+	compareToValues := map[string]any{}
+	flattenAttributes("", compareToResource, compareToValues)
+
+	paths := []string{}
+	for path := range values {
+		paths = append(paths, path)
+	}
+	for path := range compareToValues {
+		if _, ok := values[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	attributes := []AttributeDifference{}
+	for _, path := range paths {
+		if !reflect.DeepEqual(values[path], compareToValues[path]) {
+			attributes = append(attributes, AttributeDifference{
+				Path:           path,
+				Value:          values[path],
+				CompareToValue: compareToValues[path],
+			})
+		}
+	}
+	return attributes
+}
+
+// flattenAttributes adds the attribute values of the object to values by their dot-separated path
+func flattenAttributes(prefix string, object map[string]any, values map[string]any) {
+	for key, value := range object {
+		if slices.Contains(ignoredAttributes, key) || value == nil {
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flattenAttributes(path, nested, values)
+			continue
+		}
+		values[path] = value
+	}
+}
+
+// jsonResources returns the resources as JSON values, so that resources of any type can be compared
+func jsonResources(resources any) ([]map[string]any, error) {
+	resourcesJson, err := json.Marshal(resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resources: %w", err)
+	}
+	jsonResources := []map[string]any{}
+	if err := json.Unmarshal(resourcesJson, &jsonResources); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resources: %w", err)
+	}
+	return jsonResources, nil
+}
+
+func resourceId(resource map[string]any) *string {
+	if id, ok := resource["id"].(string); ok && id != "" {
+		return &id
+	}
+	return nil
+}
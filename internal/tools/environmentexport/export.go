@@ -0,0 +1,248 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+)
+
+// EnvironmentSnapshot is the configuration of an environment. The links and environment references of each resource,
+// and application secrets, are not included.
+type EnvironmentSnapshot struct {
+	Environment      ExportedEnvironment                       `json:"environment" jsonschema:"The environment"`
+	Services         []management.BillOfMaterialsProductsInner `json:"services" jsonschema:"The services of the environment, from its bill of materials"`
+	Applications     []map[string]any                          `json:"applications" jsonschema:"The applications of the environment, without client secrets"`
+	Populations      []management.Population                   `json:"populations" jsonschema:"The populations of the environment"`
+	Groups           []management.Group                        `json:"groups" jsonschema:"The groups of the environment"`
+	PasswordPolicies []management.PasswordPolicy               `json:"passwordPolicies" jsonschema:"The password policies of the environment"`
+	SignOnPolicies   []management.SignOnPolicy                 `json:"signOnPolicies" jsonschema:"The sign-on policies of the environment"`
+}
+
+// ExportedEnvironment is the environment of a snapshot. The SDK environment model is not returned, as its region
+// does not match its generated schema.
+type ExportedEnvironment struct {
+	Id          string                         `json:"id" jsonschema:"The UUID of the environment"`
+	Name        string                         `json:"name" jsonschema:"The name of the environment"`
+	Description *string                        `json:"description,omitempty" jsonschema:"The description of the environment"`
+	Type        management.EnumEnvironmentType `json:"type" jsonschema:"The type of the environment: SANDBOX or PRODUCTION"`
+	Region      string                         `json:"region" jsonschema:"The region code of the environment"`
+	LicenseId   string                         `json:"licenseId" jsonschema:"The UUID of the license of the environment"`
+}
+
+func exportedEnvironment(environment management.Environment) ExportedEnvironment {
+	exported := ExportedEnvironment{
+		Id:          environment.GetId(),
+		Name:        environment.Name,
+		Description: environment.Description,
+		Type:        environment.Type,
+		LicenseId:   environment.License.Id,
+	}
+	if environment.Region.EnumRegionCode != nil {
+		exported.Region = string(*environment.Region.EnumRegionCode)
+	} else if environment.Region.String != nil {
+		exported.Region = *environment.Region.String
+	}
+	return exported
+}
+
+// exportedApplication returns the application as JSON values, without its links, environment and client secret.
+// Applications are returned as JSON values as the SDK application model is one of several application types.
+func exportedApplication(application management.ReadOneApplication200Response) (map[string]any, error) {
+	applicationJson, err := json.Marshal(application)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal application: %w", err)
+	}
+	var exported map[string]any
+	if err := json.Unmarshal(applicationJson, &exported); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal application: %w", err)
+	}
+	delete(exported, "_links")
+	delete(exported, "environment")
+	delete(exported, "clientSecret")
+	return exported, nil
+}
+
+// readSnapshot reads the configuration of an environment. Errors are returned as errors of the named tool.
+func readSnapshot(ctx context.Context, client EnvironmentExportClient, toolName string, environmentId uuid.UUID) (*EnvironmentSnapshot, error) {
+	environment, httpResponse, err := client.GetEnvironment(ctx, environmentId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	if environment == nil {
+		apiErr := errs.NewApiError(httpResponse, errors.New("no environment data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+
+	billOfMaterials, httpResponse, err := client.GetBillOfMaterials(ctx, environmentId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	if billOfMaterials == nil {
+		apiErr := errs.NewApiError(httpResponse, errors.New("no bill of materials data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+
+	snapshot := EnvironmentSnapshot{
+		Environment:      exportedEnvironment(*environment),
+		Services:         []management.BillOfMaterialsProductsInner{},
+		Applications:     []map[string]any{},
+		Populations:      []management.Population{},
+		Groups:           []management.Group{},
+		PasswordPolicies: []management.PasswordPolicy{},
+		SignOnPolicies:   []management.SignOnPolicy{},
+	}
+	snapshot.Services = append(snapshot.Services, billOfMaterials.Products...)
+
+	// The resource types are listed concurrently, and the pages of each type in order
+	lists := []legacy.PagedList{
+		pagedList(toolName, client.GetApplications, environmentId),
+		pagedList(toolName, client.GetPopulations, environmentId),
+		pagedList(toolName, client.GetGroups, environmentId),
+		pagedList(toolName, client.GetPasswordPolicies, environmentId),
+		pagedList(toolName, client.GetSignOnPolicies, environmentId),
+	}
+	err = legacy.ForEachPageConcurrently(ctx, legacy.DefaultPageConcurrency, lists, func(list int, embedded *management.EntityArrayEmbedded) error {
+		for _, application := range embedded.Applications {
+			exported, err := exportedApplication(application)
+			if err != nil {
+				toolErr := errs.NewToolError(toolName, err)
+				errs.Log(ctx, toolErr)
+				return toolErr
+			}
+			snapshot.Applications = append(snapshot.Applications, exported)
+		}
+		for _, population := range embedded.Populations {
+			population.Links, population.Environment = nil, nil
+			snapshot.Populations = append(snapshot.Populations, population)
+		}
+		for _, group := range embedded.Groups {
+			group.Links, group.Environment = nil, nil
+			snapshot.Groups = append(snapshot.Groups, group)
+		}
+		for _, policy := range embedded.PasswordPolicies {
+			policy.Links, policy.Environment = nil, nil
+			snapshot.PasswordPolicies = append(snapshot.PasswordPolicies, policy)
+		}
+		for _, policy := range embedded.SignOnPolicies {
+			policy.Links, policy.Environment = nil, nil
+			snapshot.SignOnPolicies = append(snapshot.SignOnPolicies, policy)
+		}
+		// The total number of resources is not known until every list has been read
+		resourcesRead := len(snapshot.Applications) + len(snapshot.Populations) + len(snapshot.Groups) + len(snapshot.PasswordPolicies) + len(snapshot.SignOnPolicies)
+		progress.Report(ctx, resourcesRead, 0, "Reading the resources of the environment")
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &snapshot, nil
+}
+
+// pagedList lists the resources listed by getAll
+func pagedList(
+	toolName string,
+	getAll func(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error),
+	environmentId uuid.UUID,
+) legacy.PagedList {
+	return func(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+		pagedIterator, err := getAll(ctx, environmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(toolName, err)
+			errs.Log(ctx, toolErr)
+			return nil, toolErr
+		}
+		return pagedIterator, nil
+	}
+}
+
+// builtInApplicationTypes are the types of the applications PingOne creates in every environment, which are not
+// imported as pingone_application resources
+var builtInApplicationTypes = []string{
+	string(management.ENUMAPPLICATIONTYPE_PING_ONE_SELF_SERVICE),
+	string(management.ENUMAPPLICATIONTYPE_PING_ONE_ADMIN_CONSOLE),
+	string(management.ENUMAPPLICATIONTYPE_PING_ONE_PORTAL),
+}
+
+// terraformImports renders the snapshot as Terraform import blocks for the pingone provider. Terraform generates the
+// resource configuration from the import blocks, so that the configuration matches the provider version in use.
+func terraformImports(snapshot EnvironmentSnapshot) string {
+	var b strings.Builder
+	environmentId := snapshot.Environment.Id
+	fmt.Fprintf(&b, "# Terraform import blocks for the PingOne environment %q (%s)\n", snapshot.Environment.Name, environmentId)
+	b.WriteString("# Requires Terraform 1.5 or later and the pingidentity/pingone provider. Generate the resource configuration with:\n")
+	b.WriteString("#   terraform plan -generate-config-out=generated.tf\n")
+
+	names := terraformNames{}
+	writeImport := func(resourceType string, name string, importId string) {
+		fmt.Fprintf(&b, "\nimport {\n  to = %s.%s\n  id = %q\n}\n", resourceType, names.unique(resourceType, name), importId)
+	}
+
+	writeImport("pingone_environment", snapshot.Environment.Name, environmentId)
+	for _, policy := range snapshot.PasswordPolicies {
+		writeImport("pingone_password_policy", policy.Name, environmentId+"/"+policy.GetId())
+	}
+	for _, population := range snapshot.Populations {
+		writeImport("pingone_population", population.Name, environmentId+"/"+population.GetId())
+	}
+	for _, group := range snapshot.Groups {
+		writeImport("pingone_group", group.Name, environmentId+"/"+group.GetId())
+	}
+	for _, policy := range snapshot.SignOnPolicies {
+		writeImport("pingone_sign_on_policy", policy.Name, environmentId+"/"+policy.GetId())
+	}
+	skipped := 0
+	for _, application := range snapshot.Applications {
+		applicationType, _ := application["type"].(string)
+		id, _ := application["id"].(string)
+		name, _ := application["name"].(string)
+		if id == "" || slices.Contains(builtInApplicationTypes, applicationType) {
+			skipped++
+			continue
+		}
+		writeImport("pingone_application", name, environmentId+"/"+id)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(&b, "\n# %d built-in PingOne applications are not imported\n", skipped)
+	}
+	return b.String()
+}
+
+var terraformNameInvalidCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// terraformNames gives resources Terraform names derived from their PingOne names, unique for each resource type
+type terraformNames map[string]bool
+
+func (n terraformNames) unique(resourceType string, name string) string {
+	base := strings.Trim(terraformNameInvalidCharacters.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if base == "" || (base[0] >= '0' && base[0] <= '9') {
+		base = "r_" + base
+	}
+	unique := base
+	for i := 2; n[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", base, i)
+	}
+	n[resourceType+"."+unique] = true
+	return unique
+}
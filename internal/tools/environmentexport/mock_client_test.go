@@ -0,0 +1,92 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentexport"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ environmentexport.EnvironmentExportClient = &mockPingOneClientEnvironmentExportWrapper{}
+var _ environmentexport.EnvironmentExportClientFactory = &mockPingOneClientEnvironmentExportWrapperFactory{}
+
+type mockPingOneClientEnvironmentExportWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientEnvironmentExportWrapperFactory struct {
+	mockClient environmentexport.EnvironmentExportClient
+	err        error
+}
+
+// NewMockPingOneClientEnvironmentExportWrapperFactory directly returns the provided mock client and error
+func NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient environmentexport.EnvironmentExportClient, err error) *mockPingOneClientEnvironmentExportWrapperFactory {
+	return &mockPingOneClientEnvironmentExportWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientEnvironmentExportWrapperFactory) GetAuthenticatedClient(ctx context.Context) (environmentexport.EnvironmentExportClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientEnvironmentExportWrapper) GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return mockResponse[management.Environment](args)
+}
+
+func (p *mockPingOneClientEnvironmentExportWrapper) GetBillOfMaterials(ctx context.Context, environmentId uuid.UUID) (*management.BillOfMaterials, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return mockResponse[management.BillOfMaterials](args)
+}
+
+func (p *mockPingOneClientEnvironmentExportWrapper) GetApplications(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return mockIterator(args)
+}
+
+func (p *mockPingOneClientEnvironmentExportWrapper) GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return mockIterator(args)
+}
+
+func (p *mockPingOneClientEnvironmentExportWrapper) GetGroups(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return mockIterator(args)
+}
+
+func (p *mockPingOneClientEnvironmentExportWrapper) GetPasswordPolicies(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return mockIterator(args)
+}
+
+func (p *mockPingOneClientEnvironmentExportWrapper) GetSignOnPolicies(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return mockIterator(args)
+}
+
+func mockResponse[T any](args mock.Arguments) (*T, *http.Response, error) {
+	var response *T
+	if args.Get(0) != nil {
+		response = args.Get(0).(*T)
+	}
+	var httpResponse *http.Response
+	if args.Get(1) != nil {
+		httpResponse = args.Get(1).(*http.Response)
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func mockIterator(args mock.Arguments) (management.EntityArrayPagedIterator, error) {
+	var iterator management.EntityArrayPagedIterator
+	if args.Get(0) != nil {
+		iterator = args.Get(0).(management.EntityArrayPagedIterator)
+	}
+	return iterator, args.Error(1)
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CompareEnvironmentsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+		ReadEnvironmentIdArguments:     []string{"compareToEnvironmentId"},
+	},
+	McpTool: &mcp.Tool{
+		Name:  "compare_environments",
+		Title: "Compare PingOne Environment Configurations",
+		Description: `Compare the configuration of an environment with another environment, or with a snapshot returned by 'export_environment', and return the differences in services, password policies, populations, groups, sign-on policies and applications.
+Resources are matched by name, and services by product type. IDs, client IDs and secrets, timestamps and counts differ between environments and are not compared. Use before promoting configuration from one environment to another, or to find configuration drift since a snapshot was exported.`,
+		InputSchema:  schema.MustGenerateSchema[CompareEnvironmentsInput](),
+		OutputSchema: schema.MustGenerateSchema[CompareEnvironmentsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type CompareEnvironmentsInput struct {
+	EnvironmentId          uuid.UUID            `json:"environmentId" jsonschema:"REQUIRED. Environment UUID, such as the environment configuration is promoted from."`
+	CompareToEnvironmentId *uuid.UUID           `json:"compareToEnvironmentId,omitempty" jsonschema:"OPTIONAL. UUID of the environment to compare with, such as the environment configuration is promoted to. Either compareToEnvironmentId or compareToSnapshot is required."`
+	CompareToSnapshot      *EnvironmentSnapshot `json:"compareToSnapshot,omitempty" jsonschema:"OPTIONAL. A snapshot returned by 'export_environment' to compare with, such as an earlier snapshot of the same environment. Either compareToEnvironmentId or compareToSnapshot is required."`
+}
+
+type CompareEnvironmentsOutput struct {
+	Environment ExportedEnvironment  `json:"environment" jsonschema:"The environment"`
+	CompareTo   ExportedEnvironment  `json:"compareTo" jsonschema:"The environment compared with, or the environment of the snapshot compared with"`
+	Differences []ResourceDifference `json:"differences" jsonschema:"The resources that are only in one of the environments or whose configuration differs. Empty if the configurations match."`
+}
+
+// CompareEnvironmentsHandler compares the configuration of a PingOne environment with another environment or a
+// snapshot using the provided client
+func CompareEnvironmentsHandler(environmentExportClientFactory EnvironmentExportClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CompareEnvironmentsInput,
+) (
+	*mcp.CallToolResult,
+	*CompareEnvironmentsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CompareEnvironmentsInput) (*mcp.CallToolResult, *CompareEnvironmentsOutput, error) {
+		if (input.CompareToEnvironmentId == nil) == (input.CompareToSnapshot == nil) {
+			toolErr := errs.NewToolError(CompareEnvironmentsDef.McpTool.Name, errors.New("exactly one of compareToEnvironmentId or compareToSnapshot is required"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := environmentExportClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CompareEnvironmentsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Comparing environments", slog.String("environmentId", input.EnvironmentId.String()))
+
+		snapshot, err := readSnapshot(ctx, client, CompareEnvironmentsDef.McpTool.Name, input.EnvironmentId)
+		if err != nil {
+			return nil, nil, err
+		}
+		compareTo := input.CompareToSnapshot
+		if input.CompareToEnvironmentId != nil {
+			compareTo, err = readSnapshot(ctx, client, CompareEnvironmentsDef.McpTool.Name, *input.CompareToEnvironmentId)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		differences, err := compareSnapshots(*snapshot, *compareTo)
+		if err != nil {
+			toolErr := errs.NewToolError(CompareEnvironmentsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Compared environments",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("compareToEnvironmentId", compareTo.Environment.Id),
+			slog.Int("differences", len(differences)))
+
+		return nil, &CompareEnvironmentsOutput{
+			Environment: snapshot.Environment,
+			CompareTo:   compareTo.Environment,
+			Differences: differences,
+		}, nil
+	}
+}
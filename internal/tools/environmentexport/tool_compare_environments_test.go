@@ -0,0 +1,260 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentexport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testCompareToEnvironmentId = uuid.MustParse("660e8400-e29b-41d4-a716-446655440000")
+
+type testEnvironmentResources struct {
+	name         string
+	products     []management.EnumProductType
+	populations  []management.Population
+	groups       []management.Group
+	applications []management.ReadOneApplication200Response
+}
+
+func mockEnvironmentResources(mockClient *mockPingOneClientEnvironmentExportWrapper, environmentId uuid.UUID, resources testEnvironmentResources) {
+	mockClient.On("GetEnvironment", mock.Anything, environmentId).Return(&management.Environment{
+		Id:      testutils.Pointer(environmentId.String()),
+		Name:    resources.name,
+		License: management.EnvironmentLicense{Id: testLicenseId},
+		Type:    management.ENUMENVIRONMENTTYPE_SANDBOX,
+	}, &http.Response{StatusCode: 200}, nil).Once()
+	billOfMaterials := &management.BillOfMaterials{}
+	for _, product := range resources.products {
+		billOfMaterials.Products = append(billOfMaterials.Products, management.BillOfMaterialsProductsInner{Id: testutils.Pointer(uuid.NewString()), Type: product})
+	}
+	mockClient.On("GetBillOfMaterials", mock.Anything, environmentId).Return(billOfMaterials, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetApplications", mock.Anything, environmentId).Return(pagesOf(management.EntityArrayEmbedded{Applications: resources.applications}), nil).Once()
+	mockClient.On("GetPopulations", mock.Anything, environmentId).Return(pagesOf(management.EntityArrayEmbedded{Populations: resources.populations}), nil).Once()
+	mockClient.On("GetGroups", mock.Anything, environmentId).Return(pagesOf(management.EntityArrayEmbedded{Groups: resources.groups}), nil).Once()
+	mockClient.On("GetPasswordPolicies", mock.Anything, environmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		PasswordPolicies: []management.PasswordPolicy{{Id: testutils.Pointer(uuid.NewString()), Name: "Standard"}},
+	}), nil).Once()
+	mockClient.On("GetSignOnPolicies", mock.Anything, environmentId).Return(pagesOf(management.EntityArrayEmbedded{}), nil).Once()
+}
+
+func webPortalApplication(id string, clientId string, redirectUri string) management.ReadOneApplication200Response {
+	return management.ReadOneApplication200Response{ApplicationOIDC: &management.ApplicationOIDC{
+		Id:                      testutils.Pointer(id),
+		Name:                    "Web Portal",
+		Enabled:                 true,
+		Protocol:                management.ENUMAPPLICATIONPROTOCOL_OPENID_CONNECT,
+		Type:                    management.ENUMAPPLICATIONTYPE_WEB_APP,
+		ClientId:                testutils.Pointer(clientId),
+		RedirectUris:            []string{redirectUri},
+		TokenEndpointAuthMethod: management.ENUMAPPLICATIONOIDCTOKENAUTHMETHOD_CLIENT_SECRET_BASIC,
+	}}
+}
+
+func sandboxResources() testEnvironmentResources {
+	return testEnvironmentResources{
+		name:     "Sandbox",
+		products: []management.EnumProductType{management.ENUMPRODUCTTYPE_ONE_BASE},
+		populations: []management.Population{
+			{Id: testutils.Pointer("pop-1"), Name: "Employees", Description: testutils.Pointer("Staff"), UserCount: testutils.Pointer(int32(10))},
+		},
+		groups: []management.Group{{Id: testutils.Pointer("group-1"), Name: "Admins"}},
+		applications: []management.ReadOneApplication200Response{
+			webPortalApplication("app-1", "client-1", "https://sandbox.example.com/callback"),
+		},
+	}
+}
+
+func TestCompareEnvironmentsHandler_Environment(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+	mockEnvironmentResources(mockClient, testEnvironmentId, sandboxResources())
+	mockEnvironmentResources(mockClient, testCompareToEnvironmentId, testEnvironmentResources{
+		name:     "Staging",
+		products: []management.EnumProductType{management.ENUMPRODUCTTYPE_ONE_BASE, management.ENUMPRODUCTTYPE_ONE_MFA},
+		populations: []management.Population{
+			{Id: testutils.Pointer("pop-2"), Name: "Employees", Description: testutils.Pointer("All staff"), UserCount: testutils.Pointer(int32(250))},
+			{Id: testutils.Pointer("pop-3"), Name: "Contractors"},
+		},
+		applications: []management.ReadOneApplication200Response{
+			webPortalApplication("app-2", "client-2", "https://staging.example.com/callback"),
+		},
+	})
+
+	handler := environmentexport.CompareEnvironmentsHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentexport.CompareEnvironmentsInput{
+		EnvironmentId:          testEnvironmentId,
+		CompareToEnvironmentId: &testCompareToEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, "Sandbox", output.Environment.Name)
+	assert.Equal(t, "Staging", output.CompareTo.Name)
+
+	// IDs, client IDs and user counts differ between environments, and are not reported
+	assert.Equal(t, []environmentexport.ResourceDifference{
+		{
+			ResourceType: environmentexport.ResourceTypeService,
+			Name:         "PING_ONE_MFA",
+			Status:       environmentexport.DifferenceOnlyInComparison,
+			CompareToId:  output.Differences[0].CompareToId,
+		},
+		{
+			ResourceType: environmentexport.ResourceTypePopulation,
+			Name:         "Employees",
+			Status:       environmentexport.DifferenceChanged,
+			Id:           testutils.Pointer("pop-1"),
+			CompareToId:  testutils.Pointer("pop-2"),
+			Attributes: []environmentexport.AttributeDifference{
+				{Path: "description", Value: "Staff", CompareToValue: "All staff"},
+			},
+		},
+		{
+			ResourceType: environmentexport.ResourceTypePopulation,
+			Name:         "Contractors",
+			Status:       environmentexport.DifferenceOnlyInComparison,
+			CompareToId:  testutils.Pointer("pop-3"),
+		},
+		{
+			ResourceType: environmentexport.ResourceTypeGroup,
+			Name:         "Admins",
+			Status:       environmentexport.DifferenceOnlyInEnvironment,
+			Id:           testutils.Pointer("group-1"),
+		},
+		{
+			ResourceType: environmentexport.ResourceTypeApplication,
+			Name:         "Web Portal",
+			Status:       environmentexport.DifferenceChanged,
+			Id:           testutils.Pointer("app-1"),
+			CompareToId:  testutils.Pointer("app-2"),
+			Attributes: []environmentexport.AttributeDifference{
+				{Path: "redirectUris", Value: []any{"https://sandbox.example.com/callback"}, CompareToValue: []any{"https://staging.example.com/callback"}},
+			},
+		},
+	}, output.Differences)
+
+	// The output is checked against the output schema before it is returned to the client
+	resolved, err := environmentexport.CompareEnvironmentsDef.McpTool.OutputSchema.(*jsonschema.Schema).Resolve(nil)
+	require.NoError(t, err)
+	outputJson, err := json.Marshal(output)
+	require.NoError(t, err)
+	var value any
+	require.NoError(t, json.Unmarshal(outputJson, &value))
+	assert.NoError(t, resolved.Validate(value))
+}
+
+func TestCompareEnvironmentsHandler_Snapshot(t *testing.T) {
+	// Export the environment, then compare it with the exported snapshot
+	exportClient := &mockPingOneClientEnvironmentExportWrapper{}
+	mockEnvironmentResources(exportClient, testEnvironmentId, sandboxResources())
+	_, exported, err := environmentexport.ExportEnvironmentHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(exportClient, nil))(
+		context.Background(), &mcp.CallToolRequest{}, environmentexport.ExportEnvironmentInput{EnvironmentId: testEnvironmentId})
+	require.NoError(t, err)
+
+	// The snapshot is passed through the tool arguments, so must match the input schema
+	input := environmentexport.CompareEnvironmentsInput{
+		EnvironmentId:     testEnvironmentId,
+		CompareToSnapshot: exported.Snapshot,
+	}
+	resolved, err := environmentexport.CompareEnvironmentsDef.McpTool.InputSchema.(*jsonschema.Schema).Resolve(nil)
+	require.NoError(t, err)
+	inputJson, err := json.Marshal(input)
+	require.NoError(t, err)
+	var value any
+	require.NoError(t, json.Unmarshal(inputJson, &value))
+	require.NoError(t, resolved.Validate(value))
+	var unmarshalledInput environmentexport.CompareEnvironmentsInput
+	require.NoError(t, json.Unmarshal(inputJson, &unmarshalledInput))
+
+	changed := sandboxResources()
+	changed.populations[0].Description = testutils.Pointer("Employees and interns")
+	mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+	mockEnvironmentResources(mockClient, testEnvironmentId, changed)
+
+	handler := environmentexport.CompareEnvironmentsHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, unmarshalledInput)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+	assert.Equal(t, testEnvironmentId.String(), output.CompareTo.Id)
+	assert.Equal(t, []environmentexport.ResourceDifference{
+		{
+			ResourceType: environmentexport.ResourceTypePopulation,
+			Name:         "Employees",
+			Status:       environmentexport.DifferenceChanged,
+			Id:           testutils.Pointer("pop-1"),
+			CompareToId:  testutils.Pointer("pop-1"),
+			Attributes: []environmentexport.AttributeDifference{
+				{Path: "description", Value: "Employees and interns", CompareToValue: "Staff"},
+			},
+		},
+	}, output.Differences)
+}
+
+func TestCompareEnvironmentsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           environmentexport.CompareEnvironmentsInput
+		setupMock       func(mockClient *mockPingOneClientEnvironmentExportWrapper)
+		clientErr       error
+		wantErrContains string
+	}{
+		{
+			name:            "nothing to compare with",
+			input:           environmentexport.CompareEnvironmentsInput{EnvironmentId: testEnvironmentId},
+			wantErrContains: "exactly one of compareToEnvironmentId or compareToSnapshot is required",
+		},
+		{
+			name: "environment and snapshot to compare with",
+			input: environmentexport.CompareEnvironmentsInput{
+				EnvironmentId:          testEnvironmentId,
+				CompareToEnvironmentId: &testCompareToEnvironmentId,
+				CompareToSnapshot:      &environmentexport.EnvironmentSnapshot{},
+			},
+			wantErrContains: "exactly one of compareToEnvironmentId or compareToSnapshot is required",
+		},
+		{
+			name:            "client factory error",
+			input:           environmentexport.CompareEnvironmentsInput{EnvironmentId: testEnvironmentId, CompareToEnvironmentId: &testCompareToEnvironmentId},
+			clientErr:       errors.New("not authenticated"),
+			wantErrContains: "not authenticated",
+		},
+		{
+			name:  "environment to compare with not found",
+			input: environmentexport.CompareEnvironmentsInput{EnvironmentId: testEnvironmentId, CompareToEnvironmentId: &testCompareToEnvironmentId},
+			setupMock: func(mockClient *mockPingOneClientEnvironmentExportWrapper) {
+				mockEnvironmentResources(mockClient, testEnvironmentId, sandboxResources())
+				mockClient.On("GetEnvironment", mock.Anything, testCompareToEnvironmentId).Return(nil, &http.Response{StatusCode: 404}, errors.New("environment not found"))
+			},
+			wantErrContains: "environment not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := environmentexport.CompareEnvironmentsHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, tt.clientErr))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ExportEnvironmentDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "export_environment",
+		Title: "Export PingOne Environment Configuration",
+		Description: `Export the configuration of an environment as a JSON snapshot: its services, applications, populations, groups, password policies and sign-on policies. Application client secrets are not included.
+Set includeTerraform to also return Terraform import blocks for the pingone provider, from which 'terraform plan -generate-config-out' generates the resource configuration, to bring an environment under infrastructure as code.
+Exporting a large environment takes a while; set runInBackground to export it in a background job and follow it with get_job_status.`,
+		InputSchema:  schema.MustGenerateSchema[ExportEnvironmentInput](),
+		OutputSchema: schema.MustGenerateSchema[ExportEnvironmentOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ExportEnvironmentInput struct {
+	EnvironmentId    uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IncludeTerraform *bool     `json:"includeTerraform,omitempty" jsonschema:"OPTIONAL. Also return Terraform import blocks for the environment and its resources. Defaults to false."`
+	RunInBackground  bool      `json:"runInBackground,omitempty" jsonschema:"OPTIONAL. Export the environment in a background job and return its ID straight away, instead of waiting for the export to complete. Follow the job with get_job_status. Defaults to false."`
+}
+
+type ExportEnvironmentOutput struct {
+	Job       *jobs.Job            `json:"job,omitempty" jsonschema:"The background job exporting the environment, if runInBackground was set. The snapshot is returned by get_job_status once the job has succeeded"`
+	Snapshot  *EnvironmentSnapshot `json:"snapshot,omitempty" jsonschema:"The configuration of the environment"`
+	Terraform *string              `json:"terraform,omitempty" jsonschema:"Terraform import blocks for the pingone provider, for the environment and its password policies, populations, groups, sign-on policies and applications other than the built-in PingOne applications, if requested"`
+}
+
+// ExportEnvironmentHandler exports the configuration of a PingOne environment using the provided client
+func ExportEnvironmentHandler(environmentExportClientFactory EnvironmentExportClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ExportEnvironmentInput,
+) (
+	*mcp.CallToolResult,
+	*ExportEnvironmentOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExportEnvironmentInput) (*mcp.CallToolResult, *ExportEnvironmentOutput, error) {
+		client, err := environmentExportClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ExportEnvironmentDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if input.RunInBackground {
+			job, err := jobs.Start(ctx, ExportEnvironmentDef, input.EnvironmentId.String(), func(ctx context.Context) (any, error) {
+				return exportEnvironment(ctx, client, input)
+			})
+			if err != nil {
+				toolErr := errs.NewToolError(ExportEnvironmentDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			return nil, &ExportEnvironmentOutput{
+				Job: &job,
+			}, nil
+		}
+
+		result, err := exportEnvironment(ctx, client, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
+
+// exportEnvironment reads the configuration of the environment, and its Terraform import blocks if requested
+func exportEnvironment(ctx context.Context, client EnvironmentExportClient, input ExportEnvironmentInput) (*ExportEnvironmentOutput, error) {
+	logger.FromContext(ctx).Debug("Exporting environment", slog.String("environmentId", input.EnvironmentId.String()))
+
+	snapshot, err := readSnapshot(ctx, client, ExportEnvironmentDef.McpTool.Name, input.EnvironmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Debug("Exported environment",
+		slog.String("environmentId", input.EnvironmentId.String()),
+		slog.Int("applications", len(snapshot.Applications)),
+		slog.Int("populations", len(snapshot.Populations)),
+		slog.Int("groups", len(snapshot.Groups)))
+
+	result := &ExportEnvironmentOutput{
+		Snapshot: snapshot,
+	}
+	if input.IncludeTerraform != nil && *input.IncludeTerraform {
+		terraform := terraformImports(*snapshot)
+		result.Terraform = &terraform
+	}
+
+	return result, nil
+}
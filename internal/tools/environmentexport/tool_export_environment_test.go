@@ -0,0 +1,254 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environmentexport_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentexport"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testLicenseId     = "11111111-1111-4111-8111-111111111111"
+)
+
+func pagesOf(embedded management.EntityArrayEmbedded) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &embedded},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func environmentLinks() *map[string]management.LinksHATEOASValue {
+	return &map[string]management.LinksHATEOASValue{"self": {Href: "https://api.pingone.com/v1/environments/" + testEnvironmentId.String()}}
+}
+
+func mockEnvironment(mockClient *mockPingOneClientEnvironmentExportWrapper) {
+	region := management.ENUMREGIONCODE_EU
+	mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(&management.Environment{
+		Id:      testutils.Pointer(testEnvironmentId.String()),
+		Name:    "Demo",
+		License: management.EnvironmentLicense{Id: testLicenseId},
+		Region:  management.EnumRegionCodeAsEnvironmentRegion(&region),
+		Type:    management.ENUMENVIRONMENTTYPE_SANDBOX,
+	}, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetBillOfMaterials", mock.Anything, testEnvironmentId).Return(&management.BillOfMaterials{
+		Products: []management.BillOfMaterialsProductsInner{{Type: management.ENUMPRODUCTTYPE_ONE_BASE}},
+	}, &http.Response{StatusCode: 200}, nil).Once()
+}
+
+func mockResources(mockClient *mockPingOneClientEnvironmentExportWrapper) {
+	mockClient.On("GetApplications", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		Applications: []management.ReadOneApplication200Response{
+			{ApplicationOIDC: &management.ApplicationOIDC{
+				Links:                   environmentLinks(),
+				Id:                      testutils.Pointer("a1b2c3d4-e5f6-4789-8abc-def012345678"),
+				Name:                    "Web Portal",
+				Protocol:                management.ENUMAPPLICATIONPROTOCOL_OPENID_CONNECT,
+				Type:                    management.ENUMAPPLICATIONTYPE_WEB_APP,
+				ClientSecret:            testutils.Pointer("secret"),
+				TokenEndpointAuthMethod: management.ENUMAPPLICATIONOIDCTOKENAUTHMETHOD_CLIENT_SECRET_BASIC,
+			}},
+			{ApplicationPingOnePortal: &management.ApplicationPingOnePortal{
+				Id:       testutils.Pointer("b2c3d4e5-f6a7-4890-9bcd-ef0123456789"),
+				Name:     "PingOne Application Portal",
+				Protocol: management.ENUMAPPLICATIONPROTOCOL_OPENID_CONNECT,
+				Type:     management.ENUMAPPLICATIONTYPE_PING_ONE_PORTAL,
+			}},
+		},
+	}), nil).Once()
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		Populations: []management.Population{
+			{Links: environmentLinks(), Id: testutils.Pointer("pop-1"), Name: "Employees"},
+			{Id: testutils.Pointer("pop-2"), Name: "employees!"},
+		},
+	}), nil).Once()
+	mockClient.On("GetGroups", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		Groups: []management.Group{{Id: testutils.Pointer("group-1"), Name: "2FA Admins"}},
+	}), nil).Once()
+	mockClient.On("GetPasswordPolicies", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		PasswordPolicies: []management.PasswordPolicy{{Id: testutils.Pointer("policy-1"), Name: "Standard"}},
+	}), nil).Once()
+	mockClient.On("GetSignOnPolicies", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{
+		SignOnPolicies: []management.SignOnPolicy{{Id: testutils.Pointer("sop-1"), Name: "Single_Factor"}},
+	}), nil).Once()
+}
+
+func TestExportEnvironmentHandler(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+	mockEnvironment(mockClient)
+	mockResources(mockClient)
+
+	handler := environmentexport.ExportEnvironmentHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentexport.ExportEnvironmentInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+	assert.Nil(t, output.Terraform, "Terraform is only returned when requested")
+
+	snapshot := output.Snapshot
+	assert.Equal(t, environmentexport.ExportedEnvironment{
+		Id:        testEnvironmentId.String(),
+		Name:      "Demo",
+		Type:      management.ENUMENVIRONMENTTYPE_SANDBOX,
+		Region:    "EU",
+		LicenseId: testLicenseId,
+	}, snapshot.Environment)
+	assert.Len(t, snapshot.Services, 1)
+	require.Len(t, snapshot.Applications, 2)
+	assert.Equal(t, "Web Portal", snapshot.Applications[0]["name"])
+	assert.NotContains(t, snapshot.Applications[0], "clientSecret", "client secrets are not exported")
+	assert.NotContains(t, snapshot.Applications[0], "_links")
+	require.Len(t, snapshot.Populations, 2)
+	assert.Nil(t, snapshot.Populations[0].Links)
+	assert.Len(t, snapshot.Groups, 1)
+	assert.Len(t, snapshot.PasswordPolicies, 1)
+	assert.Len(t, snapshot.SignOnPolicies, 1)
+
+	// The output is checked against the output schema before it is returned to the client
+	resolved, err := environmentexport.ExportEnvironmentDef.McpTool.OutputSchema.(*jsonschema.Schema).Resolve(nil)
+	require.NoError(t, err)
+	outputJson, err := json.Marshal(output)
+	require.NoError(t, err)
+	var value any
+	require.NoError(t, json.Unmarshal(outputJson, &value))
+	assert.NoError(t, resolved.Validate(value))
+}
+
+func TestExportEnvironmentHandler_Terraform(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+	mockEnvironment(mockClient)
+	mockResources(mockClient)
+
+	handler := environmentexport.ExportEnvironmentHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentexport.ExportEnvironmentInput{
+		EnvironmentId:    testEnvironmentId,
+		IncludeTerraform: testutils.Pointer(true),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.Terraform)
+	terraform := *output.Terraform
+
+	environmentId := testEnvironmentId.String()
+	assert.Contains(t, terraform, "terraform plan -generate-config-out=generated.tf")
+	assert.Contains(t, terraform, "import {\n  to = pingone_environment.demo\n  id = \""+environmentId+"\"\n}")
+	assert.Contains(t, terraform, "import {\n  to = pingone_password_policy.standard\n  id = \""+environmentId+"/policy-1\"\n}")
+	assert.Contains(t, terraform, "to = pingone_population.employees\n  id = \""+environmentId+"/pop-1\"")
+	assert.Contains(t, terraform, "to = pingone_population.employees_2\n  id = \""+environmentId+"/pop-2\"", "names are unique per resource type")
+	assert.Contains(t, terraform, "to = pingone_group.r_2fa_admins\n", "names start with a letter")
+	assert.Contains(t, terraform, "to = pingone_sign_on_policy.single_factor\n")
+	assert.Contains(t, terraform, "to = pingone_application.web_portal\n  id = \""+environmentId+"/a1b2c3d4-e5f6-4789-8abc-def012345678\"")
+	assert.NotContains(t, terraform, "b2c3d4e5-f6a7-4890-9bcd-ef0123456789", "built-in applications are not imported")
+	assert.Contains(t, terraform, "# 1 built-in PingOne applications are not imported")
+}
+
+func TestExportEnvironmentHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientEnvironmentExportWrapper)
+		clientErr       error
+		wantErrContains string
+	}{
+		{
+			name:            "client factory error",
+			clientErr:       errors.New("not authenticated"),
+			wantErrContains: "not authenticated",
+		},
+		{
+			name: "environment not found",
+			setupMock: func(mockClient *mockPingOneClientEnvironmentExportWrapper) {
+				mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(nil, &http.Response{StatusCode: 404}, errors.New("environment not found"))
+			},
+			wantErrContains: "environment not found",
+		},
+		{
+			name: "group page error",
+			setupMock: func(mockClient *mockPingOneClientEnvironmentExportWrapper) {
+				mockEnvironment(mockClient)
+				// The resource types are listed concurrently, so the other types may not be listed before the groups fail
+				mockClient.On("GetApplications", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{}), nil).Maybe()
+				mockClient.On("GetPopulations", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{}), nil).Maybe()
+				mockClient.On("GetGroups", mock.Anything, testEnvironmentId).Return(testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+					{HTTPResponse: &http.Response{StatusCode: 403}, Error: errors.New("forbidden")},
+				}), nil)
+				mockClient.On("GetPasswordPolicies", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{}), nil).Maybe()
+				mockClient.On("GetSignOnPolicies", mock.Anything, testEnvironmentId).Return(pagesOf(management.EntityArrayEmbedded{}), nil).Maybe()
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := environmentexport.ExportEnvironmentHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, tt.clientErr))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentexport.ExportEnvironmentInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestExportEnvironmentHandler_RunInBackground(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+	mockEnvironment(mockClient)
+	mockResources(mockClient)
+	manager := jobs.NewManager(0, 0)
+
+	handler := environmentexport.ExportEnvironmentHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(jobs.ContextWithManager(context.Background(), manager), &mcp.CallToolRequest{}, environmentexport.ExportEnvironmentInput{
+		EnvironmentId:   testEnvironmentId,
+		RunInBackground: true,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.Job)
+	assert.Equal(t, environmentexport.ExportEnvironmentDef.McpTool.Name, output.Job.Tool)
+	assert.Nil(t, output.Snapshot, "the snapshot is returned by the job")
+
+	job, err := manager.Wait(t.Context(), output.Job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status)
+	require.IsType(t, &environmentexport.ExportEnvironmentOutput{}, job.Result)
+	assert.Equal(t, "Demo", job.Result.(*environmentexport.ExportEnvironmentOutput).Snapshot.Environment.Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestExportEnvironmentHandler_RunInBackgroundNotEnabled(t *testing.T) {
+	mockClient := &mockPingOneClientEnvironmentExportWrapper{}
+
+	handler := environmentexport.ExportEnvironmentHandler(NewMockPingOneClientEnvironmentExportWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environmentexport.ExportEnvironmentInput{
+		EnvironmentId:   testEnvironmentId,
+		RunInBackground: true,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "background jobs are not enabled")
+	mockClient.AssertNotCalled(t, "GetEnvironment", mock.Anything, mock.Anything)
+}
@@ -113,6 +113,18 @@ func (p *PingOneClientEnvironmentsWrapper) UpdateEnvironment(ctx context.Context
 	return replaceRequest.Execute()
 }
 
+func (p *PingOneClientEnvironmentsWrapper) DeleteEnvironment(ctx context.Context, environmentId uuid.UUID) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.EnvironmentsApi.DeleteEnvironmentById(ctx, environmentId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete environment by ID",
+		slog.String("environmentId", environmentId.String()))
+	return deleteRequest.Execute()
+}
+
 func (p *PingOneClientEnvironmentsWrapper) GetEnvironmentServices(ctx context.Context, environmentId uuid.UUID) (*pingone.EnvironmentBillOfMaterialsResponse, *http.Response, error) {
 	if p.client == nil {
 		return nil, nil, errors.New("PingOne client is not initialized")
@@ -20,7 +20,11 @@ const CollectionName = "environments"
 
 var _ collections.Collection = &EnvironmentsCollection{}
 
-type EnvironmentsCollection struct{}
+// EnvironmentsCollection registers the environment tools. The deletion scheduler is created once by the server, so
+// that scheduled deletions can still be listed and cancelled after the tools are registered again.
+type EnvironmentsCollection struct {
+	DeletionScheduler *DeletionScheduler
+}
 
 func (c *EnvironmentsCollection) Name() string {
 	return CollectionName
@@ -33,6 +37,9 @@ func (c *EnvironmentsCollection) RegisterTools(ctx context.Context, server *mcp.
 	if tokenStore == nil {
 		return fmt.Errorf("token store is nil")
 	}
+	if c.DeletionScheduler == nil {
+		return fmt.Errorf("deletion scheduler is nil")
+	}
 
 	environmentsClientFactory := NewPingOneClientEnvironmentsWrapperFactory(clientFactory, tokenStore)
 
@@ -66,6 +73,31 @@ func (c *EnvironmentsCollection) RegisterTools(ctx context.Context, server *mcp.
 		mcp.AddTool(server, UpdateEnvironmentServicesDef.McpTool, UpdateEnvironmentServicesHandler(environmentsClientFactory))
 	}
 
+	if toolFilter.ShouldIncludeTool(&AddEnvironmentServiceDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", AddEnvironmentServiceDef.McpTool.Name))
+		mcp.AddTool(server, AddEnvironmentServiceDef.McpTool, AddEnvironmentServiceHandler(environmentsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&RemoveEnvironmentServiceDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", RemoveEnvironmentServiceDef.McpTool.Name))
+		mcp.AddTool(server, RemoveEnvironmentServiceDef.McpTool, RemoveEnvironmentServiceHandler(environmentsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ScheduleEnvironmentDeletionDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ScheduleEnvironmentDeletionDef.McpTool.Name))
+		mcp.AddTool(server, ScheduleEnvironmentDeletionDef.McpTool, ScheduleEnvironmentDeletionHandler(environmentsClientFactory, c.DeletionScheduler))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CancelEnvironmentDeletionDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CancelEnvironmentDeletionDef.McpTool.Name))
+		mcp.AddTool(server, CancelEnvironmentDeletionDef.McpTool, CancelEnvironmentDeletionHandler(c.DeletionScheduler))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListScheduledEnvironmentDeletionsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListScheduledEnvironmentDeletionsDef.McpTool.Name))
+		mcp.AddTool(server, ListScheduledEnvironmentDeletionsDef.McpTool, ListScheduledEnvironmentDeletionsHandler(c.DeletionScheduler))
+	}
+
 	return nil
 }
 
@@ -77,5 +109,10 @@ func (c *EnvironmentsCollection) ListTools() []types.ToolDefinition {
 		UpdateEnvironmentDef,
 		GetEnvironmentServicesDef,
 		UpdateEnvironmentServicesDef,
+		AddEnvironmentServiceDef,
+		RemoveEnvironmentServiceDef,
+		ScheduleEnvironmentDeletionDef,
+		CancelEnvironmentDeletionDef,
+		ListScheduledEnvironmentDeletionsDef,
 	}
 }
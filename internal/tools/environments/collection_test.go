@@ -67,6 +67,20 @@ func TestEnvironmentsCollection_RegisterTools_NilTokenStore(t *testing.T) {
 	assert.Contains(t, err.Error(), "token store is nil")
 }
 
+func TestEnvironmentsCollection_RegisterTools_NilDeletionScheduler(t *testing.T) {
+	collection := &environments.EnvironmentsCollection{}
+	server := mcp.NewServer(&mcp.Implementation{
+		Name:    "test-server",
+		Version: "v0.0.1",
+	}, nil)
+	toolFilter := filter.PassthroughFilter()
+
+	err := collection.RegisterTools(t.Context(), server, sdk.NewEmptyClientFactory(), testutils.NewInMemoryTokenStore(), toolFilter)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "deletion scheduler is nil")
+}
+
 func TestEnvironmentsCollection_RegisterTools_ReadOnlyToolsMarkedCorrectly(t *testing.T) {
 	collection := &environments.EnvironmentsCollection{}
 	tools := collection.ListTools()
@@ -76,6 +90,7 @@ func TestEnvironmentsCollection_RegisterTools_ReadOnlyToolsMarkedCorrectly(t *te
 		"list_environments",
 		"get_environment",
 		"get_environment_services",
+		"list_scheduled_environment_deletions",
 	}
 
 	// Define known write tools
@@ -83,6 +98,10 @@ func TestEnvironmentsCollection_RegisterTools_ReadOnlyToolsMarkedCorrectly(t *te
 		"create_environment",
 		"update_environment",
 		"update_environment_services",
+		"add_environment_service",
+		"remove_environment_service",
+		"schedule_environment_deletion",
+		"cancel_environment_deletion",
 	}
 
 	for _, tool := range tools {
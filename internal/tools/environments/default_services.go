@@ -0,0 +1,47 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+type defaultServicesChangeContextKey struct{}
+
+// ContextWithDefaultServicesChange returns a context in which create_environment changes the services of an
+// environment created without a Bill of Materials, once PingOne has given it the default Bill of Materials. The
+// change returns the changed products, or false if the products are already as wanted.
+func ContextWithDefaultServicesChange(ctx context.Context, change func(products []pingone.EnvironmentBillOfMaterialsProduct) ([]pingone.EnvironmentBillOfMaterialsProduct, bool)) context.Context {
+	return context.WithValue(ctx, defaultServicesChangeContextKey{}, serviceChange(change))
+}
+
+func defaultServicesChangeFromContext(ctx context.Context) (serviceChange, bool) {
+	change, ok := ctx.Value(defaultServicesChangeContextKey{}).(serviceChange)
+	return change, ok && change != nil
+}
+
+// changeDefaultServices applies the change of the context to the default services of a created environment, and
+// returns the changed services, or nil if they were not changed. A failure is logged rather than returned, as the
+// environment has been created.
+func changeDefaultServices(ctx context.Context, client EnvironmentsClient, environmentId uuid.UUID, change serviceChange) *pingone.EnvironmentBillOfMaterialsResponse {
+	unlock := lockEnvironmentServices(environmentId)
+	defer unlock()
+
+	_, updated, err := changeEnvironmentServices(ctx, client, environmentId, change)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Unable to change the default services of the created environment",
+			slog.String("environmentId", environmentId.String()),
+			slog.Any("error", err))
+		return nil
+	}
+	if updated != nil {
+		logger.FromContext(ctx).Debug("Changed the default services of the created environment",
+			slog.String("environmentId", environmentId.String()))
+	}
+	return updated
+}
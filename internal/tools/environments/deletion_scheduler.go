@@ -0,0 +1,183 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+const (
+	DeletionStatusScheduled = "SCHEDULED"
+	DeletionStatusDeleting  = "DELETING"
+	DeletionStatusDeleted   = "DELETED"
+	DeletionStatusFailed    = "FAILED"
+	DeletionStatusCancelled = "CANCELLED"
+)
+
+// ScheduledDeletion is an environment deletion that runs once its grace period has passed
+type ScheduledDeletion struct {
+	EnvironmentId   uuid.UUID  `json:"environmentId" jsonschema:"The ID of the environment"`
+	EnvironmentName string     `json:"environmentName" jsonschema:"The name of the environment when the deletion was scheduled"`
+	RequestedBy     *string    `json:"requestedBy,omitempty" jsonschema:"Who requested the deletion"`
+	Reason          *string    `json:"reason,omitempty" jsonschema:"Why the environment is being deleted"`
+	ScheduledAt     time.Time  `json:"scheduledAt" jsonschema:"When the deletion was scheduled"`
+	DeleteAt        time.Time  `json:"deleteAt" jsonschema:"When the environment will be, or was due to be, deleted"`
+	Status          string     `json:"status" jsonschema:"SCHEDULED, DELETING, DELETED, FAILED or CANCELLED"`
+	CompletedAt     *time.Time `json:"completedAt,omitempty" jsonschema:"When the deletion completed, failed or was cancelled"`
+	Error           *string    `json:"error,omitempty" jsonschema:"Why the deletion failed"`
+}
+
+// DeleteEnvironmentFunc deletes an environment when its scheduled deletion is due
+type DeleteEnvironmentFunc func(ctx context.Context, environmentId uuid.UUID) error
+
+// scheduledDeletion is a deletion with the timer that runs it
+type scheduledDeletion struct {
+	ScheduledDeletion
+	timer *time.Timer
+	// refreshSession refreshes the auth session before the deletion runs, as the access token of the
+	// tool call that scheduled it has usually expired by then. Nil leaves the session as it is.
+	refreshSession auth.SessionRefresher
+}
+
+// DeletionScheduler holds scheduled environment deletions in memory and runs each one when it is due.
+// Deletions are lost when the server stops, so an environment is never deleted by a server that has
+// restarted since its deletion was scheduled.
+type DeletionScheduler struct {
+	mu                sync.Mutex
+	ctx               context.Context
+	deleteEnvironment DeleteEnvironmentFunc
+	deletions         map[uuid.UUID]*scheduledDeletion
+	now               func() time.Time
+}
+
+// NewDeletionScheduler returns a scheduler that runs due deletions with deleteEnvironment.
+// The context provides the logger for deletions, which run outside any tool call.
+func NewDeletionScheduler(ctx context.Context, deleteEnvironment DeleteEnvironmentFunc) *DeletionScheduler {
+	return &DeletionScheduler{
+		ctx:               context.WithoutCancel(ctx),
+		deleteEnvironment: deleteEnvironment,
+		deletions:         map[uuid.UUID]*scheduledDeletion{},
+		now:               time.Now,
+	}
+}
+
+// Schedule schedules the environment to be deleted after the grace period. An environment can only have
+// one pending deletion, but can be scheduled again once an earlier deletion has failed or been cancelled.
+// The session refresher of the context, if any, refreshes the auth session before the deletion runs.
+func (s *DeletionScheduler) Schedule(ctx context.Context, environmentId uuid.UUID, environmentName string, gracePeriod time.Duration, requestedBy, reason *string) (ScheduledDeletion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.deletions[environmentId]; ok {
+		switch existing.Status {
+		case DeletionStatusScheduled:
+			return ScheduledDeletion{}, fmt.Errorf("environment %s is already scheduled for deletion at %s", environmentId, existing.DeleteAt.Format(time.RFC3339))
+		case DeletionStatusDeleting, DeletionStatusDeleted:
+			return ScheduledDeletion{}, fmt.Errorf("environment %s has already been deleted by a scheduled deletion", environmentId)
+		}
+	}
+
+	scheduledAt := s.now()
+	deletion := &scheduledDeletion{
+		ScheduledDeletion: ScheduledDeletion{
+			EnvironmentId:   environmentId,
+			EnvironmentName: environmentName,
+			RequestedBy:     requestedBy,
+			Reason:          reason,
+			ScheduledAt:     scheduledAt,
+			DeleteAt:        scheduledAt.Add(gracePeriod),
+			Status:          DeletionStatusScheduled,
+		},
+		refreshSession: auth.SessionRefresherFromContext(ctx),
+	}
+	deletion.timer = time.AfterFunc(gracePeriod, func() {
+		s.run(deletion)
+	})
+	s.deletions[environmentId] = deletion
+	return deletion.ScheduledDeletion, nil
+}
+
+// Cancel cancels the pending deletion of the environment
+func (s *DeletionScheduler) Cancel(environmentId uuid.UUID) (ScheduledDeletion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deletion, ok := s.deletions[environmentId]
+	if !ok || deletion.Status == DeletionStatusCancelled || deletion.Status == DeletionStatusFailed {
+		return ScheduledDeletion{}, fmt.Errorf("environment %s has no pending deletion, scheduled deletions are not kept after the server restarts", environmentId)
+	}
+	if deletion.Status != DeletionStatusScheduled {
+		return ScheduledDeletion{}, fmt.Errorf("the deletion of environment %s can no longer be cancelled, its status is %s", environmentId, deletion.Status)
+	}
+
+	deletion.timer.Stop()
+	completedAt := s.now()
+	deletion.Status = DeletionStatusCancelled
+	deletion.CompletedAt = &completedAt
+	return deletion.ScheduledDeletion, nil
+}
+
+// List returns every deletion scheduled since the server started, ordered by when they are due
+func (s *DeletionScheduler) List() []ScheduledDeletion {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deletions := make([]ScheduledDeletion, 0, len(s.deletions))
+	for _, deletion := range s.deletions {
+		deletions = append(deletions, deletion.ScheduledDeletion)
+	}
+	slices.SortFunc(deletions, func(a, b ScheduledDeletion) int {
+		return a.DeleteAt.Compare(b.DeleteAt)
+	})
+	return deletions
+}
+
+// run deletes the environment, unless the deletion was cancelled or replaced before it became due
+func (s *DeletionScheduler) run(deletion *scheduledDeletion) {
+	s.mu.Lock()
+	if s.deletions[deletion.EnvironmentId] != deletion || deletion.Status != DeletionStatusScheduled {
+		s.mu.Unlock()
+		return
+	}
+	deletion.Status = DeletionStatusDeleting
+	s.mu.Unlock()
+
+	logger.FromContext(s.ctx).Info("Running scheduled environment deletion",
+		slog.String("environmentId", deletion.EnvironmentId.String()),
+		slog.String("environmentName", deletion.EnvironmentName))
+	var err error
+	if deletion.refreshSession != nil {
+		if refreshErr := deletion.refreshSession(s.ctx); refreshErr != nil {
+			err = fmt.Errorf("failed to refresh the auth session before deleting the environment: %w", refreshErr)
+		}
+	}
+	if err == nil {
+		err = s.deleteEnvironment(s.ctx, deletion.EnvironmentId)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	completedAt := s.now()
+	deletion.CompletedAt = &completedAt
+	if err != nil {
+		logger.FromContext(s.ctx).Error("Scheduled environment deletion failed",
+			slog.String("environmentId", deletion.EnvironmentId.String()),
+			slog.String("error", err.Error()))
+		errorMessage := err.Error()
+		deletion.Status = DeletionStatusFailed
+		deletion.Error = &errorMessage
+		return
+	}
+	logger.FromContext(s.ctx).Info("Scheduled environment deletion completed",
+		slog.String("environmentId", deletion.EnvironmentId.String()))
+	deletion.Status = DeletionStatusDeleted
+}
@@ -0,0 +1,189 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingDeleter records the environments deleted by a scheduler
+type recordingDeleter struct {
+	mu      sync.Mutex
+	deleted []uuid.UUID
+	err     error
+}
+
+func (d *recordingDeleter) delete(ctx context.Context, environmentId uuid.UUID) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deleted = append(d.deleted, environmentId)
+	return d.err
+}
+
+func (d *recordingDeleter) deletedEnvironments() []uuid.UUID {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]uuid.UUID{}, d.deleted...)
+}
+
+func waitForStatus(t *testing.T, scheduler *environments.DeletionScheduler, environmentId uuid.UUID, status string) environments.ScheduledDeletion {
+	t.Helper()
+	var found environments.ScheduledDeletion
+	require.Eventually(t, func() bool {
+		for _, deletion := range scheduler.List() {
+			if deletion.EnvironmentId == environmentId && deletion.Status == status {
+				found = deletion
+				return true
+			}
+		}
+		return false
+	}, 2*time.Second, 5*time.Millisecond)
+	return found
+}
+
+func TestDeletionScheduler_RunsDeletionWhenDue(t *testing.T) {
+	deleter := &recordingDeleter{}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+	reason := "Project finished"
+
+	deletion, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, 10*time.Millisecond, nil, &reason)
+	require.NoError(t, err)
+	assert.Equal(t, environments.DeletionStatusScheduled, deletion.Status)
+	assert.Equal(t, deletion.ScheduledAt.Add(10*time.Millisecond), deletion.DeleteAt)
+
+	deleted := waitForStatus(t, scheduler, testEnv1.id, environments.DeletionStatusDeleted)
+
+	assert.Equal(t, []uuid.UUID{testEnv1.id}, deleter.deletedEnvironments())
+	assert.NotNil(t, deleted.CompletedAt)
+	assert.Equal(t, &reason, deleted.Reason)
+}
+
+func TestDeletionScheduler_RecordsFailedDeletion(t *testing.T) {
+	deleter := &recordingDeleter{err: errors.New("environment not found")}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+
+	_, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Millisecond, nil, nil)
+	require.NoError(t, err)
+
+	failed := waitForStatus(t, scheduler, testEnv1.id, environments.DeletionStatusFailed)
+	require.NotNil(t, failed.Error)
+	assert.Equal(t, "environment not found", *failed.Error)
+
+	// A failed deletion can be scheduled again
+	_, err = scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Hour, nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestDeletionScheduler_RefreshesSessionBeforeDeletion(t *testing.T) {
+	deleter := &recordingDeleter{}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+	var refreshedBeforeDeletion bool
+	ctx := auth.ContextWithSessionRefresher(t.Context(), func(ctx context.Context) error {
+		refreshedBeforeDeletion = len(deleter.deletedEnvironments()) == 0
+		return nil
+	})
+
+	_, err := scheduler.Schedule(ctx, testEnv1.id, testEnv1.name, time.Millisecond, nil, nil)
+	require.NoError(t, err)
+
+	waitForStatus(t, scheduler, testEnv1.id, environments.DeletionStatusDeleted)
+	assert.True(t, refreshedBeforeDeletion)
+}
+
+func TestDeletionScheduler_SessionRefreshFailurePreventsDeletion(t *testing.T) {
+	deleter := &recordingDeleter{}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+	ctx := auth.ContextWithSessionRefresher(t.Context(), func(ctx context.Context) error {
+		return errors.New("the auth session has no refresh token")
+	})
+
+	_, err := scheduler.Schedule(ctx, testEnv1.id, testEnv1.name, time.Millisecond, nil, nil)
+	require.NoError(t, err)
+
+	failed := waitForStatus(t, scheduler, testEnv1.id, environments.DeletionStatusFailed)
+	assert.Empty(t, deleter.deletedEnvironments())
+	require.NotNil(t, failed.Error)
+	assert.Contains(t, *failed.Error, "failed to refresh the auth session before deleting the environment")
+}
+
+func TestDeletionScheduler_CancelPreventsDeletion(t *testing.T) {
+	deleter := &recordingDeleter{}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+
+	_, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, 50*time.Millisecond, nil, nil)
+	require.NoError(t, err)
+
+	cancelled, err := scheduler.Cancel(testEnv1.id)
+	require.NoError(t, err)
+	assert.Equal(t, environments.DeletionStatusCancelled, cancelled.Status)
+	assert.NotNil(t, cancelled.CompletedAt)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Empty(t, deleter.deletedEnvironments())
+
+	_, err = scheduler.Cancel(testEnv1.id)
+	assert.ErrorContains(t, err, "has no pending deletion")
+}
+
+func TestDeletionScheduler_RescheduleAfterCancel(t *testing.T) {
+	deleter := &recordingDeleter{}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+
+	_, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Hour, nil, nil)
+	require.NoError(t, err)
+	_, err = scheduler.Cancel(testEnv1.id)
+	require.NoError(t, err)
+
+	_, err = scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Millisecond, nil, nil)
+	require.NoError(t, err)
+
+	waitForStatus(t, scheduler, testEnv1.id, environments.DeletionStatusDeleted)
+	assert.Equal(t, []uuid.UUID{testEnv1.id}, deleter.deletedEnvironments())
+}
+
+func TestDeletionScheduler_ScheduleErrors(t *testing.T) {
+	deleter := &recordingDeleter{}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+
+	_, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Hour, nil, nil)
+	require.NoError(t, err)
+	_, err = scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Hour, nil, nil)
+	assert.ErrorContains(t, err, "is already scheduled for deletion")
+
+	_, err = scheduler.Schedule(t.Context(), testEnv3.id, testEnv3.name, time.Millisecond, nil, nil)
+	require.NoError(t, err)
+	waitForStatus(t, scheduler, testEnv3.id, environments.DeletionStatusDeleted)
+	_, err = scheduler.Schedule(t.Context(), testEnv3.id, testEnv3.name, time.Hour, nil, nil)
+	assert.ErrorContains(t, err, "has already been deleted")
+
+	_, err = scheduler.Cancel(testEnv3.id)
+	assert.ErrorContains(t, err, "can no longer be cancelled")
+
+	_, err = scheduler.Cancel(testEnv2.id)
+	assert.ErrorContains(t, err, "has no pending deletion")
+}
+
+func TestDeletionScheduler_ListOrdersByDueTime(t *testing.T) {
+	scheduler := environments.NewDeletionScheduler(t.Context(), (&recordingDeleter{}).delete)
+
+	_, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, 2*time.Hour, nil, nil)
+	require.NoError(t, err)
+	_, err = scheduler.Schedule(t.Context(), testEnv3.id, testEnv3.name, time.Hour, nil, nil)
+	require.NoError(t, err)
+
+	deletions := scheduler.List()
+
+	require.Len(t, deletions, 2)
+	assert.Equal(t, testEnv3.id, deletions[0].EnvironmentId)
+	assert.Equal(t, testEnv1.id, deletions[1].EnvironmentId)
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type environmentFilterContextKey struct{}
+
+// ContextWithEnvironmentFilter returns a context in which tools that list or count the environments of the
+// organization only include the environments that the filter allows, such as the environments within an
+// environment scope.
+func ContextWithEnvironmentFilter(ctx context.Context, allows func(environmentId uuid.UUID) bool) context.Context {
+	return context.WithValue(ctx, environmentFilterContextKey{}, allows)
+}
+
+// EnvironmentFilterFromContext returns the environment filter of the context, or nil if all environments of the
+// organization are included.
+func EnvironmentFilterFromContext(ctx context.Context) func(environmentId uuid.UUID) bool {
+	allows, _ := ctx.Value(environmentFilterContextKey{}).(func(environmentId uuid.UUID) bool)
+	return allows
+}
+
+type environmentReadValidatorContextKey struct{}
+
+// ContextWithEnvironmentReadValidator returns a context in which tools that read from each environment of the
+// organization, such as to report its license utilization, validate an environment with the validator before reading
+// from it, and skip the environment if the validator returns an error.
+func ContextWithEnvironmentReadValidator(ctx context.Context, validate func(ctx context.Context, environmentId uuid.UUID) error) context.Context {
+	return context.WithValue(ctx, environmentReadValidatorContextKey{}, validate)
+}
+
+// EnvironmentReadValidatorFromContext returns the environment read validator of the context, or nil if environments
+// are read without validation.
+func EnvironmentReadValidatorFromContext(ctx context.Context) func(ctx context.Context, environmentId uuid.UUID) error {
+	validate, _ := ctx.Value(environmentReadValidatorContextKey{}).(func(ctx context.Context, environmentId uuid.UUID) error)
+	return validate
+}
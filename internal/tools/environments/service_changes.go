@@ -0,0 +1,153 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// maxServiceChangeAttempts is the number of times a change to the services of an environment is made, from the
+// services as read again each time, while they keep being changed by someone else
+const maxServiceChangeAttempts = 3
+
+// servicesConflictResourceType describes the services of an environment in conflict errors
+const servicesConflictResourceType = "bill of materials of environment"
+
+// serviceLocks serializes the changes this server makes to the services of each environment, so that concurrent
+// tool calls do not replace the services with what each read before the other's change
+var serviceLocks = struct {
+	mutex sync.Mutex
+	locks map[uuid.UUID]*sync.Mutex
+}{
+	locks: map[uuid.UUID]*sync.Mutex{},
+}
+
+// lockEnvironmentServices waits until no other tool call is changing the services of the environment, and returns
+// the function that lets the next one change them
+func lockEnvironmentServices(environmentId uuid.UUID) func() {
+	serviceLocks.mutex.Lock()
+	lock, ok := serviceLocks.locks[environmentId]
+	if !ok {
+		lock = &sync.Mutex{}
+		serviceLocks.locks[environmentId] = lock
+	}
+	serviceLocks.mutex.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// serviceChange changes the products of the bill of materials of an environment. It returns the changed products,
+// or false if the products are already as wanted.
+type serviceChange func(products []pingone.EnvironmentBillOfMaterialsProduct) ([]pingone.EnvironmentBillOfMaterialsProduct, bool)
+
+// changeEnvironmentServices reads the services of an environment, changes them and replaces them with the changed
+// services. It returns the services as they were before the change, and as replaced, which are nil if the change
+// left the services as they were.
+//
+// The services API does not support conditional updates such as If-Match, so the services are read again just
+// before they are replaced. If they have been changed in the meantime, the change is made again from the services
+// as read then, and a ConflictError is returned if they are still changing after maxServiceChangeAttempts.
+func changeEnvironmentServices(ctx context.Context, client EnvironmentsClient, environmentId uuid.UUID, change serviceChange) (*pingone.EnvironmentBillOfMaterialsResponse, *pingone.EnvironmentBillOfMaterialsResponse, error) {
+	current, err := getEnvironmentServices(ctx, client, environmentId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		products, changed := change(current.Products)
+		if !changed {
+			return current, nil, nil
+		}
+
+		latest, err := getEnvironmentServices(ctx, client, environmentId)
+		if err != nil {
+			return nil, nil, err
+		}
+		if servicesChangedSince(current, latest) {
+			if attempt == maxServiceChangeAttempts {
+				return nil, nil, errs.NewConflictError(servicesConflictResourceType, environmentId.String(), current.UpdatedAt, latest.UpdatedAt)
+			}
+			logger.FromContext(ctx).Debug("Environment services changed while being changed, changing them again",
+				slog.String("environmentId", environmentId.String()),
+				slog.Int("attempt", attempt))
+			current = latest
+			continue
+		}
+
+		updated, httpResponse, err := client.UpdateEnvironmentServices(ctx, environmentId, &pingone.EnvironmentBillOfMaterialsReplaceRequest{
+			Products: products,
+		})
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return nil, nil, errs.NewApiError(httpResponse, err)
+		}
+		if updated == nil {
+			return nil, nil, errs.NewApiError(httpResponse, fmt.Errorf("no services data in response"))
+		}
+		return current, updated, nil
+	}
+}
+
+func getEnvironmentServices(ctx context.Context, client EnvironmentsClient, environmentId uuid.UUID) (*pingone.EnvironmentBillOfMaterialsResponse, error) {
+	services, httpResponse, err := client.GetEnvironmentServices(ctx, environmentId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		return nil, errs.NewApiError(httpResponse, err)
+	}
+	if services == nil {
+		return nil, errs.NewApiError(httpResponse, fmt.Errorf("no services data in response from get"))
+	}
+	return services, nil
+}
+
+// servicesChangedSince reports whether the services of an environment have been updated between two reads. The
+// update times are compared when PingOne returns them, and the product types otherwise.
+func servicesChangedSince(before *pingone.EnvironmentBillOfMaterialsResponse, after *pingone.EnvironmentBillOfMaterialsResponse) bool {
+	if before.UpdatedAt != nil && after.UpdatedAt != nil {
+		return !before.UpdatedAt.Equal(*after.UpdatedAt)
+	}
+	if len(before.Products) != len(after.Products) {
+		return true
+	}
+	for i := range before.Products {
+		if before.Products[i].Type != after.Products[i].Type {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceProductTypes returns the product types of a service type value, expanding NEO into the Verify and
+// Credentials products it represents
+func serviceProductTypes(serviceType string) ([]pingone.EnvironmentBillOfMaterialsProductType, error) {
+	if serviceType == NeoServiceValue {
+		return []pingone.EnvironmentBillOfMaterialsProductType{
+			pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_CREDENTIALS,
+			pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_VERIFY,
+		}, nil
+	}
+	productType, err := pingone.NewEnvironmentBillOfMaterialsProductTypeFromValue(serviceType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service value: %s", serviceType)
+	}
+	return []pingone.EnvironmentBillOfMaterialsProductType{*productType}, nil
+}
+
+// serviceTypeSchemaEnum returns the service type values the services tools accept
+func serviceTypeSchemaEnum() []any {
+	var itemsEnum []any
+	for _, val := range pingone.AllowedEnvironmentBillOfMaterialsProductTypeEnumValues {
+		itemsEnum = append(itemsEnum, string(val))
+	}
+	// Add Neo value, representing Verify and Credentials combined
+	return append(itemsEnum, NeoServiceValue)
+}
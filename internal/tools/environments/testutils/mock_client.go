@@ -114,6 +114,19 @@ func (m *MockEnvironmentsClient) UpdateEnvironment(ctx context.Context, environm
 	return envResponse, httpResponse, args.Error(2)
 }
 
+// DeleteEnvironment deletes an environment and all of its resources.
+// Returns the HTTP response details and any error encountered.
+// The ctx parameter provides context for the API operation including cancellation and timeouts.
+// The environmentId parameter specifies the UUID of the environment to delete.
+func (m *MockEnvironmentsClient) DeleteEnvironment(ctx context.Context, environmentId uuid.UUID) (*http.Response, error) {
+	args := m.Called(ctx, environmentId)
+	var httpResponse *http.Response
+	if args.Get(0) != nil {
+		httpResponse = args.Get(0).(*http.Response)
+	}
+	return httpResponse, args.Error(1)
+}
+
 // GetEnvironmentServices retrieves the bill of materials (enabled services) for an environment.
 // Returns the bill of materials response, HTTP response details, and any error encountered.
 // The ctx parameter provides context for the API operation including cancellation and timeouts.
@@ -0,0 +1,137 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var AddEnvironmentServiceDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "add_environment_service",
+		Title:        "Add a Service to a PingOne Environment",
+		Description:  "Add a service to a PingOne environment's Bill of Materials, by the environment's unique ID, keeping the services the environment already has and their configuration. Unlike update_environment_services, which replaces all the services of the environment, the services are read just before they are changed, so that services added or removed by someone else in the meantime are kept. Adding a service the environment already has leaves its services unchanged.",
+		InputSchema:  mustGenerateAddEnvironmentServiceInputSchema(),
+		OutputSchema: schema.MustGenerateSchema[AddEnvironmentServiceOutput](),
+	},
+}
+
+type AddEnvironmentServiceInput struct {
+	EnvironmentId uuid.UUID               `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment"`
+	Service       EnvironmentServiceInput `json:"service" jsonschema:"REQUIRED. The service to add. Note that 'NEO' adds both the 'PING_ONE_VERIFY' and 'PING_ONE_CREDENTIALS' services."`
+}
+
+type AddEnvironmentServiceOutput struct {
+	Services pingone.EnvironmentBillOfMaterialsResponse `json:"services" jsonschema:"The bill of materials for the environment after the change, including products and solution type"`
+	Changed  bool                                       `json:"changed" jsonschema:"False if the environment already had the service, so its services were left unchanged"`
+}
+
+func mustGenerateAddEnvironmentServiceInputSchema() *jsonschema.Schema {
+	baseSchema := schema.MustGenerateSchema[AddEnvironmentServiceInput]()
+
+	if baseSchema.Properties == nil {
+		panic("baseSchema.Properties is nil when generating AddEnvironmentServiceInput schema")
+	}
+	serviceSchema, exists := baseSchema.Properties["service"]
+	if !exists || serviceSchema == nil || serviceSchema.Properties == nil || serviceSchema.Properties["type"] == nil {
+		panic("type property not found in service schema for AddEnvironmentServiceInput")
+	}
+	serviceSchema.Properties["type"].Enum = serviceTypeSchemaEnum()
+
+	return baseSchema
+}
+
+// AddEnvironmentServiceHandler adds a service to a PingOne environment using the provided client
+func AddEnvironmentServiceHandler(environmentsClientFactory EnvironmentsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input AddEnvironmentServiceInput,
+) (
+	*mcp.CallToolResult,
+	*AddEnvironmentServiceOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AddEnvironmentServiceInput) (*mcp.CallToolResult, *AddEnvironmentServiceOutput, error) {
+		productTypes, err := serviceProductTypes(input.Service.Type)
+		if err != nil {
+			toolErr := errs.NewToolError(AddEnvironmentServiceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := environmentsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(AddEnvironmentServiceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		unlock := lockEnvironmentServices(input.EnvironmentId)
+		defer unlock()
+
+		logger.FromContext(ctx).Debug("Adding environment service",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("service", input.Service.Type))
+
+		previous, updated, err := changeEnvironmentServices(ctx, client, input.EnvironmentId, func(products []pingone.EnvironmentBillOfMaterialsProduct) ([]pingone.EnvironmentBillOfMaterialsProduct, bool) {
+			changed := false
+			products = slices.Clone(products)
+			for _, productType := range productTypes {
+				if slices.ContainsFunc(products, func(product pingone.EnvironmentBillOfMaterialsProduct) bool { return product.Type == productType }) {
+					continue
+				}
+				products = append(products, input.Service.toBOMProductWithType(productType))
+				changed = true
+			}
+			return products, changed
+		})
+		if err != nil {
+			toolErr := errs.NewToolError(AddEnvironmentServiceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if updated == nil {
+			logger.FromContext(ctx).Debug("Environment already has the service",
+				slog.String("environmentId", input.EnvironmentId.String()),
+				slog.String("service", input.Service.Type))
+			previous.Links = nil
+			return nil, &AddEnvironmentServiceOutput{
+				Services: *previous,
+			}, nil
+		}
+
+		logger.FromContext(ctx).Debug("Environment service added successfully",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("service", input.Service.Type))
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          AddEnvironmentServiceDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "environmentServices",
+			ResourceId:    input.EnvironmentId.String(),
+			Description:   fmt.Sprintf("Remove the added %s service", input.Service.Type),
+		}, undoUpdateEnvironmentServices(environmentsClientFactory, input.EnvironmentId, previous.Products, updated.UpdatedAt))
+
+		// Filter out _links field from response
+		updated.Links = nil
+
+		return nil, &AddEnvironmentServiceOutput{
+			Services: *updated,
+			Changed:  true,
+		}, nil
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	envtestutils "github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// servicesResponse returns a bill of materials with products of the given types, last updated at the given time
+func servicesResponse(updatedAt time.Time, productTypes ...pingone.EnvironmentBillOfMaterialsProductType) *pingone.EnvironmentBillOfMaterialsResponse {
+	products := []pingone.EnvironmentBillOfMaterialsProduct{}
+	for _, productType := range productTypes {
+		products = append(products, pingone.EnvironmentBillOfMaterialsProduct{Type: productType})
+	}
+	return &pingone.EnvironmentBillOfMaterialsResponse{
+		Products:  products,
+		UpdatedAt: &updatedAt,
+	}
+}
+
+// productTypesMatcher matches replace requests with products of exactly the given types, in order
+func productTypesMatcher(productTypes ...pingone.EnvironmentBillOfMaterialsProductType) func(*pingone.EnvironmentBillOfMaterialsReplaceRequest) bool {
+	return func(req *pingone.EnvironmentBillOfMaterialsReplaceRequest) bool {
+		if len(req.Products) != len(productTypes) {
+			return false
+		}
+		for i, product := range req.Products {
+			if product.Type != productTypes[i] {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+var (
+	servicesReadAt    = time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	servicesChangedAt = time.Date(2025, 3, 1, 10, 1, 0, 0, time.UTC)
+	servicesUpdatedAt = time.Date(2025, 3, 1, 10, 2, 0, 0, time.UTC)
+)
+
+func TestAddEnvironmentServiceHandler_MockClient(t *testing.T) {
+	base := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE
+	mfa := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_MFA
+	risk := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_RISK
+	credentials := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_CREDENTIALS
+	verify := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_VERIFY
+
+	tests := []struct {
+		name            string
+		serviceType     string
+		setupMock       func(*envtestutils.MockEnvironmentsClient)
+		wantErr         bool
+		wantConflict    bool
+		wantChanged     bool
+		wantProductsLen int
+	}{
+		{
+			name:        "Adds the service and keeps the existing services",
+			serviceType: string(mfa),
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentServicesSetup(m, testEnv1.id, servicesResponse(servicesReadAt, base), 200, nil)
+				mockUpdateEnvironmentServicesSetup(m, testEnv1.id, productTypesMatcher(base, mfa), servicesResponse(servicesUpdatedAt, base, mfa), 200, nil)
+			},
+			wantChanged:     true,
+			wantProductsLen: 2,
+		},
+		{
+			name:        "NEO adds the Credentials and Verify services",
+			serviceType: environments.NeoServiceValue,
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentServicesSetup(m, testEnv1.id, servicesResponse(servicesReadAt, base), 200, nil)
+				mockUpdateEnvironmentServicesSetup(m, testEnv1.id, productTypesMatcher(base, credentials, verify), servicesResponse(servicesUpdatedAt, base, credentials, verify), 200, nil)
+			},
+			wantChanged:     true,
+			wantProductsLen: 3,
+		},
+		{
+			name:        "Service already added leaves the services unchanged",
+			serviceType: string(base),
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentServicesSetup(m, testEnv1.id, servicesResponse(servicesReadAt, base, mfa), 200, nil)
+			},
+			wantChanged:     false,
+			wantProductsLen: 2,
+		},
+		{
+			name:        "Services changed concurrently are read again and kept",
+			serviceType: string(mfa),
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				m.On("GetEnvironmentServices", mock.Anything, testEnv1.id).Return(servicesResponse(servicesReadAt, base), &http.Response{StatusCode: 200}, nil).Once()
+				m.On("GetEnvironmentServices", mock.Anything, testEnv1.id).Return(servicesResponse(servicesChangedAt, base, risk), &http.Response{StatusCode: 200}, nil)
+				mockUpdateEnvironmentServicesSetup(m, testEnv1.id, productTypesMatcher(base, risk, mfa), servicesResponse(servicesUpdatedAt, base, risk, mfa), 200, nil)
+			},
+			wantChanged:     true,
+			wantProductsLen: 3,
+		},
+		{
+			name:        "Services that keep changing fail with a conflict",
+			serviceType: string(mfa),
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				for i := range 4 {
+					changedAt := servicesReadAt.Add(time.Duration(i) * time.Minute)
+					m.On("GetEnvironmentServices", mock.Anything, testEnv1.id).Return(servicesResponse(changedAt, base), &http.Response{StatusCode: 200}, nil).Once()
+				}
+			},
+			wantErr:      true,
+			wantConflict: true,
+		},
+		{
+			name:        "Get services error",
+			serviceType: string(mfa),
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentServicesSetup(m, testEnv1.id, nil, 404, errors.New("environment not found"))
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Invalid service type",
+			serviceType: "NOT_A_SERVICE",
+			setupMock:   func(m *envtestutils.MockEnvironmentsClient) {},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &envtestutils.MockEnvironmentsClient{}
+			tt.setupMock(mockClient)
+			handler := environments.AddEnvironmentServiceHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environments.AddEnvironmentServiceInput{
+				EnvironmentId: testEnv1.id,
+				Service:       environments.EnvironmentServiceInput{Type: tt.serviceType},
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, output)
+				var conflictErr *errs.ConflictError
+				assert.Equal(t, tt.wantConflict, errors.As(err, &conflictErr))
+				mockClient.AssertNotCalled(t, "UpdateEnvironmentServices", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, mcpResult)
+			require.NotNil(t, output)
+			assert.Equal(t, tt.wantChanged, output.Changed)
+			assert.Len(t, output.Services.Products, tt.wantProductsLen)
+			if !tt.wantChanged {
+				mockClient.AssertNotCalled(t, "UpdateEnvironmentServices", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddEnvironmentServiceHandler_RecordsUndo(t *testing.T) {
+	base := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE
+	mfa := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_MFA
+
+	mockClient := &envtestutils.MockEnvironmentsClient{}
+	mockClient.On("GetEnvironmentServices", mock.Anything, testEnv1.id).Return(servicesResponse(servicesReadAt, base), &http.Response{StatusCode: 200}, nil).Twice()
+	mockUpdateEnvironmentServicesSetup(mockClient, testEnv1.id, productTypesMatcher(base, mfa), servicesResponse(servicesUpdatedAt, base, mfa), 200, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := environments.AddEnvironmentServiceHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, environments.AddEnvironmentServiceInput{
+		EnvironmentId: testEnv1.id,
+		Service:       environments.EnvironmentServiceInput{Type: string(mfa)},
+	})
+	require.NoError(t, err)
+
+	mockClient.On("GetEnvironmentServices", mock.Anything, testEnv1.id).Return(servicesResponse(servicesUpdatedAt, base, mfa), &http.Response{StatusCode: 200}, nil).Once()
+	mockUpdateEnvironmentServicesSetup(mockClient, testEnv1.id, productTypesMatcher(base), servicesResponse(servicesUpdatedAt.Add(time.Minute), base), 200, nil)
+
+	change, err := journal.Undo(context.Background(), testEnv1.id.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, environments.AddEnvironmentServiceDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
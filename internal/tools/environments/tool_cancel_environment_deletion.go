@@ -0,0 +1,66 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CancelEnvironmentDeletionDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		// Cancelling only changes the server's schedule, and must work even if the environment can no longer be read
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "cancel_environment_deletion",
+		Title:        "Cancel PingOne Environment Deletion",
+		Description:  "Cancel an environment deletion scheduled with 'schedule_environment_deletion' before its grace period ends, so the environment is kept. Deletions that have already run cannot be cancelled.",
+		InputSchema:  schema.MustGenerateSchema[CancelEnvironmentDeletionInput](),
+		OutputSchema: schema.MustGenerateSchema[CancelEnvironmentDeletionOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type CancelEnvironmentDeletionInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment whose deletion to cancel."`
+}
+
+type CancelEnvironmentDeletionOutput struct {
+	Deletion ScheduledDeletion `json:"deletion" jsonschema:"The cancelled deletion"`
+}
+
+// CancelEnvironmentDeletionHandler cancels a scheduled environment deletion in the provided scheduler
+func CancelEnvironmentDeletionHandler(scheduler *DeletionScheduler) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CancelEnvironmentDeletionInput,
+) (
+	*mcp.CallToolResult,
+	*CancelEnvironmentDeletionOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CancelEnvironmentDeletionInput) (*mcp.CallToolResult, *CancelEnvironmentDeletionOutput, error) {
+		deletion, err := scheduler.Cancel(input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(CancelEnvironmentDeletionDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Info("Environment deletion cancelled",
+			slog.String("environmentId", input.EnvironmentId.String()))
+
+		return nil, &CancelEnvironmentDeletionOutput{Deletion: deletion}, nil
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelEnvironmentDeletionHandler(t *testing.T) {
+	deleter := &recordingDeleter{}
+	scheduler := environments.NewDeletionScheduler(t.Context(), deleter.delete)
+	_, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Hour, nil, nil)
+	require.NoError(t, err)
+	handler := environments.CancelEnvironmentDeletionHandler(scheduler)
+
+	mcpResult, output, err := handler(t.Context(), &mcp.CallToolRequest{}, environments.CancelEnvironmentDeletionInput{EnvironmentId: testEnv1.id})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testEnv1.id, output.Deletion.EnvironmentId)
+	assert.Equal(t, environments.DeletionStatusCancelled, output.Deletion.Status)
+	assert.Empty(t, deleter.deletedEnvironments())
+}
+
+func TestCancelEnvironmentDeletionHandler_NotScheduled(t *testing.T) {
+	scheduler := environments.NewDeletionScheduler(t.Context(), (&recordingDeleter{}).delete)
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, environments.CancelEnvironmentDeletionDef.McpTool, environments.CancelEnvironmentDeletionHandler(scheduler))
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, environments.CancelEnvironmentDeletionDef.McpTool.Name, environments.CancelEnvironmentDeletionInput{EnvironmentId: testEnv1.id})
+
+	require.NoError(t, err)
+	testutils.AssertMcpCallError(t, output, "has no pending deletion")
+}
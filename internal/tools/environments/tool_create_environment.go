@@ -16,8 +16,13 @@ import (
 )
 
 var CreateEnvironmentDef = types.ToolDefinition{
+	// Environment creation consumes license quota and provisions services, so only one is created at a time
+	MaxConcurrentExecutions: 1,
+	// Environments are created in the organization, which only Organization Admins can do
+	RequiredRoles: []string{"Organization Admin"},
 	ValidationPolicy: &types.ToolValidationPolicy{
 		ProductionEnvironmentNotApplicable: true, // Tool does not act on an existing environment
+		CreatesEnvironment:                 true,
 	},
 	McpTool: &mcp.Tool{
 		Name:         "create_environment",
@@ -109,6 +114,15 @@ func CreateEnvironmentHandler(environmentsClientFactory EnvironmentsClientFactor
 			slog.String("environmentId", envResponse.Id.String()),
 			slog.String("name", envResponse.Name))
 
+		if input.BillOfMaterials == nil {
+			if change, ok := defaultServicesChangeFromContext(ctx); ok {
+				updated := changeDefaultServices(ctx, client, envResponse.Id, change)
+				if updated != nil && envResponse.BillOfMaterials != nil {
+					envResponse.BillOfMaterials.Products = updated.Products
+				}
+			}
+		}
+
 		// Filter out _links field from response
 		envResponse.Links = nil
 
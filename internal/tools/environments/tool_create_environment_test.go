@@ -464,3 +464,64 @@ func TestCreateEnvironmentHandler_RealClient(t *testing.T) {
 	// Clean up: Delete the created environment
 	// Note: You would need to implement deletion or manually clean up
 }
+
+func TestCreateEnvironmentHandler_DefaultServicesChange(t *testing.T) {
+	createdEnvID := uuid.MustParse("550e8400-e29b-41d4-a716-446655441001")
+	bookmark := pingone.EnvironmentBillOfMaterialsProductBookmark{Name: "Runbook", Href: "https://example.com/runbook"}
+	addBookmark := func(products []pingone.EnvironmentBillOfMaterialsProduct) ([]pingone.EnvironmentBillOfMaterialsProduct, bool) {
+		changed := append([]pingone.EnvironmentBillOfMaterialsProduct{}, products...)
+		changed[0].Bookmarks = []pingone.EnvironmentBillOfMaterialsProductBookmark{bookmark}
+		return changed, true
+	}
+	defaultServices := servicesResponse(servicesReadAt, pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE)
+	updatedServices := servicesResponse(servicesUpdatedAt, pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE)
+	updatedServices.Products[0].Bookmarks = []pingone.EnvironmentBillOfMaterialsProductBookmark{bookmark}
+
+	t.Run("Changes the default services of an environment created without a Bill of Materials", func(t *testing.T) {
+		mockClient := &envtestutils.MockEnvironmentsClient{}
+		mockCreateEnvironmentSetup(mockClient, func(req *pingone.EnvironmentCreateRequest) bool {
+			return req.BillOfMaterials == nil
+		}, &pingone.EnvironmentResponse{
+			Id:              createdEnvID,
+			Name:            "New Test Environment",
+			BillOfMaterials: &pingone.EnvironmentBillOfMaterials{Products: defaultServices.Products},
+		}, 201, nil)
+		mockClient.On("GetEnvironmentServices", mock.Anything, createdEnvID).Return(defaultServices, nil, nil).Twice()
+		mockClient.On("UpdateEnvironmentServices", mock.Anything, createdEnvID, mock.MatchedBy(func(req *pingone.EnvironmentBillOfMaterialsReplaceRequest) bool {
+			return len(req.Products) == 1 && len(req.Products[0].Bookmarks) == 1 && req.Products[0].Bookmarks[0].Name == bookmark.Name
+		})).Return(updatedServices, nil, nil).Once()
+
+		ctx := environments.ContextWithDefaultServicesChange(context.Background(), addBookmark)
+		handler := environments.CreateEnvironmentHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+		_, output, err := handler(ctx, &mcp.CallToolRequest{}, environments.CreateEnvironmentInput{
+			Name:    "New Test Environment",
+			Region:  pingone.ENVIRONMENTREGIONCODE_NA,
+			License: *pingone.NewEnvironmentLicense(testLicenseID),
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, output.Environment.BillOfMaterials)
+		assert.Equal(t, updatedServices.Products, output.Environment.BillOfMaterials.Products)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Leaves a given Bill of Materials to the caller", func(t *testing.T) {
+		mockClient := &envtestutils.MockEnvironmentsClient{}
+		mockCreateEnvironmentSetup(mockClient, func(req *pingone.EnvironmentCreateRequest) bool {
+			return req.BillOfMaterials != nil
+		}, &pingone.EnvironmentResponse{Id: createdEnvID, Name: "New Test Environment"}, 201, nil)
+
+		ctx := environments.ContextWithDefaultServicesChange(context.Background(), addBookmark)
+		handler := environments.CreateEnvironmentHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+		_, _, err := handler(ctx, &mcp.CallToolRequest{}, environments.CreateEnvironmentInput{
+			Name:            "New Test Environment",
+			Region:          pingone.ENVIRONMENTREGIONCODE_NA,
+			License:         *pingone.NewEnvironmentLicense(testLicenseID),
+			BillOfMaterials: &pingone.EnvironmentBillOfMaterials{Products: defaultServices.Products},
+		})
+
+		require.NoError(t, err)
+		mockClient.AssertNotCalled(t, "GetEnvironmentServices", mock.Anything, mock.Anything)
+		mockClient.AssertExpectations(t)
+	})
+}
@@ -35,6 +35,7 @@ var GetEnvironmentDef = types.ToolDefinition{
 // GetEnvironmentInput defines the input parameters for retrieving an environment by ID
 type GetEnvironmentInput struct {
 	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. UUID format (e.g., '123e4567-e89b-12d3-a456-426614174000')."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'environment.name' and 'environment.type'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 // GetEnvironmentOutput represents the result of retrieving an environment
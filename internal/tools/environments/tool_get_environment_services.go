@@ -35,6 +35,7 @@ var GetEnvironmentServicesDef = types.ToolDefinition{
 // GetEnvironmentServicesInput defines the input parameters for retrieving environment services by ID
 type GetEnvironmentServicesInput struct {
 	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment"`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'services.products.type'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 // GetEnvironmentServicesOutput represents the result of retrieving environment services
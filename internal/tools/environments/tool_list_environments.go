@@ -13,6 +13,9 @@ import (
 	"github.com/pingidentity/pingone-go-client/pingone"
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 )
@@ -46,7 +49,8 @@ Returns: Array of environments with ID, name, type, region, license, and metadat
 
 // ListEnvironmentsInput defines the input parameters for listing environments
 type ListEnvironmentsInput struct {
-	Filter *string `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter. Only filters that 'name' with 'sw' (starts with); 'id', 'organization.id', 'license.id', 'status' with 'eq' (equals); 'and' to combine are valid."`
+	Filter *string  `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter. Only filters that 'name' with 'sw' (starts with); 'id', 'organization.id', 'license.id', 'status' with 'eq' (equals); 'and' to combine are valid."`
+	Fields []string `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'environments.id' and 'environments.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 // EnvironmentSummary contains the essential fields of an environment
@@ -60,7 +64,8 @@ type EnvironmentSummary struct {
 
 // ListEnvironmentsOutput represents the result of listing environments
 type ListEnvironmentsOutput struct {
-	Environments []EnvironmentSummary `json:"environments" jsonschema:"List of environments with their basic details"`
+	Environments   []EnvironmentSummary  `json:"environments" jsonschema:"List of environments with their basic details"`
+	ResultResource *resources.ResultLink `json:"resultResource,omitempty" jsonschema:"Present when the result is too large to return inline, in which case environments contains only the first page. Read the resource to page through the full result."`
 }
 
 // ListEnvironmentsHandler lists all PingOne environments using the provided client
@@ -74,6 +79,14 @@ func ListEnvironmentsHandler(environmentsClientFactory EnvironmentsClientFactory
 	error,
 ) {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input ListEnvironmentsInput) (*mcp.CallToolResult, *ListEnvironmentsOutput, error) {
+		if input.Filter != nil {
+			if err := scimfilter.Environments.Validate(*input.Filter); err != nil {
+				toolErr := errs.NewToolError(ListEnvironmentsDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+
 		client, err := environmentsClientFactory.GetAuthenticatedClient(ctx)
 		if err != nil {
 			toolErr := errs.NewToolError(ListEnvironmentsDef.McpTool.Name, err)
@@ -94,7 +107,8 @@ func ListEnvironmentsHandler(environmentsClientFactory EnvironmentsClientFactory
 			return nil, nil, toolErr
 		}
 
-		// Aggregate all pages into one response
+		// Aggregate all pages into one response, leaving out environments the environment filter does not allow
+		allows := EnvironmentFilterFromContext(ctx)
 		result := ListEnvironmentsOutput{
 			Environments: []EnvironmentSummary{},
 		}
@@ -118,6 +132,9 @@ func ListEnvironmentsHandler(environmentsClientFactory EnvironmentsClientFactory
 				slog.Int("count", len(next.Data.Embedded.Environments)))
 
 			for _, env := range next.Data.Embedded.Environments {
+				if allows != nil && !allows(env.Id) {
+					continue
+				}
 				result.Environments = append(result.Environments, EnvironmentSummary{
 					Id:        env.Id,
 					Name:      env.Name,
@@ -126,8 +143,23 @@ func ListEnvironmentsHandler(environmentsClientFactory EnvironmentsClientFactory
 					Status:    env.Status,
 				})
 			}
+			progress.ReportFetched(ctx, len(result.Environments), int(next.Data.GetCount()), "environments")
+		}
+
+		// Return large results as a paged resource, when enabled, rather than inlining every item
+		result.Environments, result.ResultResource, err = resources.PageListResult(ctx, result.Environments)
+		if err != nil {
+			toolErr := errs.NewToolError(ListEnvironmentsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		mcpResult, err := result.ResultResource.CallToolResult(ListEnvironmentsDef.McpTool.Name, result)
+		if err != nil {
+			toolErr := errs.NewToolError(ListEnvironmentsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
 		}
 
-		return nil, &result, nil
+		return mcpResult, &result, nil
 	}
 }
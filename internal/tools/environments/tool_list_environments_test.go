@@ -21,6 +21,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-go-client/pingone"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
@@ -70,6 +71,14 @@ func TestListEnvironmentsHandler_MockClient(t *testing.T) {
 			wantEnvCount:     1,
 			wantEnvironments: []environmentTestData{testEnv1},
 		},
+		{
+			// Rejected before the API is called
+			name:            "with unsupported filter",
+			filter:          testutils.Pointer(`name co "Test" or status eq "ACTIVE"`),
+			setupMock:       func(*envtestutils.MockEnvironmentsClient, *string) {},
+			wantErr:         true,
+			wantErrContains: "unsupported SCIM filter: 'name' only supports sw\n'or' is not supported",
+		},
 	}
 
 	for _, tt := range tests {
@@ -149,6 +158,25 @@ func TestListEnvironmentsHandler_MockClient(t *testing.T) {
 	}
 }
 
+func TestListEnvironmentsHandler_EnvironmentFilter(t *testing.T) {
+	mockClient := &envtestutils.MockEnvironmentsClient{}
+	mockListEnvironmentsSetup(t, nil,
+		[]environmentTestData{testEnv1, testEnv2},
+		[]environmentTestData{testEnv3})(mockClient, nil)
+	handler := environments.ListEnvironmentsHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+	ctx := environments.ContextWithEnvironmentFilter(context.Background(), func(environmentId uuid.UUID) bool {
+		return environmentId != testEnv2.id
+	})
+
+	mcpResult, response, err := handler(ctx, &mcp.CallToolRequest{}, environments.ListEnvironmentsInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, response)
+	require.Len(t, response.Environments, 2)
+	assertEnvironmentSummaryMatches(t, testEnv1, response.Environments[0])
+	assertEnvironmentSummaryMatches(t, testEnv3, response.Environments[1])
+	mockClient.AssertExpectations(t)
+}
+
 func TestListEnvironmentsHandler_PaginationErrorMidStream(t *testing.T) {
 	mockClient := &envtestutils.MockEnvironmentsClient{}
 
@@ -0,0 +1,60 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListScheduledEnvironmentDeletionsDef = types.ToolDefinition{
+	// Scheduled deletions run when their grace period ends, without a tool being called
+	DisableResponseCache: true,
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_scheduled_environment_deletions",
+		Title:        "List Scheduled PingOne Environment Deletions",
+		Description:  "List the environment deletions scheduled with 'schedule_environment_deletion' since the MCP server started, ordered by when they are due, including deletions that have run, failed or been cancelled. Use to review pending deletions before their grace period ends.",
+		InputSchema:  schema.MustGenerateSchema[ListScheduledEnvironmentDeletionsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListScheduledEnvironmentDeletionsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListScheduledEnvironmentDeletionsInput struct {
+	Status *string  `json:"status,omitempty" jsonschema:"OPTIONAL. Only return deletions with this status: SCHEDULED, DELETING, DELETED, FAILED or CANCELLED."`
+	Fields []string `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'deletions.environmentName' and 'deletions.deleteAt'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListScheduledEnvironmentDeletionsOutput struct {
+	Deletions []ScheduledDeletion `json:"deletions" jsonschema:"The scheduled deletions"`
+}
+
+// ListScheduledEnvironmentDeletionsHandler lists the deletions held by the provided scheduler
+func ListScheduledEnvironmentDeletionsHandler(scheduler *DeletionScheduler) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListScheduledEnvironmentDeletionsInput,
+) (
+	*mcp.CallToolResult,
+	*ListScheduledEnvironmentDeletionsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListScheduledEnvironmentDeletionsInput) (*mcp.CallToolResult, *ListScheduledEnvironmentDeletionsOutput, error) {
+		deletions := []ScheduledDeletion{}
+		for _, deletion := range scheduler.List() {
+			if input.Status != nil && *input.Status != deletion.Status {
+				continue
+			}
+			deletions = append(deletions, deletion)
+		}
+		return nil, &ListScheduledEnvironmentDeletionsOutput{Deletions: deletions}, nil
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListScheduledEnvironmentDeletionsHandler(t *testing.T) {
+	scheduler := environments.NewDeletionScheduler(t.Context(), (&recordingDeleter{}).delete)
+	_, err := scheduler.Schedule(t.Context(), testEnv1.id, testEnv1.name, time.Hour, nil, nil)
+	require.NoError(t, err)
+	_, err = scheduler.Schedule(t.Context(), testEnv3.id, testEnv3.name, time.Hour, nil, nil)
+	require.NoError(t, err)
+	_, err = scheduler.Cancel(testEnv3.id)
+	require.NoError(t, err)
+	handler := environments.ListScheduledEnvironmentDeletionsHandler(scheduler)
+
+	tests := []struct {
+		name        string
+		status      *string
+		expectedIds []string
+	}{
+		{name: "All deletions", expectedIds: []string{testEnv1.id.String(), testEnv3.id.String()}},
+		{name: "Pending deletions", status: testutils.Pointer(environments.DeletionStatusScheduled), expectedIds: []string{testEnv1.id.String()}},
+		{name: "No matching deletions", status: testutils.Pointer(environments.DeletionStatusDeleted), expectedIds: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcpResult, output, err := handler(t.Context(), &mcp.CallToolRequest{}, environments.ListScheduledEnvironmentDeletionsInput{Status: tt.status})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			ids := []string{}
+			for _, deletion := range output.Deletions {
+				ids = append(ids, deletion.EnvironmentId.String())
+			}
+			assert.ElementsMatch(t, tt.expectedIds, ids)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var RemoveEnvironmentServiceDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "remove_environment_service",
+		Title:        "Remove a Service from a PingOne Environment",
+		Description:  "Remove a service from a PingOne environment's Bill of Materials, by the environment's unique ID, keeping the other services of the environment and their configuration. Unlike update_environment_services, which replaces all the services of the environment, the services are read just before they are changed, so that services added or removed by someone else in the meantime are kept. Removing a service the environment does not have leaves its services unchanged.",
+		InputSchema:  mustGenerateRemoveEnvironmentServiceInputSchema(),
+		OutputSchema: schema.MustGenerateSchema[RemoveEnvironmentServiceOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type RemoveEnvironmentServiceInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment"`
+	ServiceType   string    `json:"serviceType" jsonschema:"REQUIRED. The product type value of the service to remove. Note that 'NEO' removes both the 'PING_ONE_VERIFY' and 'PING_ONE_CREDENTIALS' services."`
+}
+
+type RemoveEnvironmentServiceOutput struct {
+	Services pingone.EnvironmentBillOfMaterialsResponse `json:"services" jsonschema:"The bill of materials for the environment after the change, including products and solution type"`
+	Changed  bool                                       `json:"changed" jsonschema:"False if the environment did not have the service, so its services were left unchanged"`
+}
+
+func mustGenerateRemoveEnvironmentServiceInputSchema() *jsonschema.Schema {
+	baseSchema := schema.MustGenerateSchema[RemoveEnvironmentServiceInput]()
+
+	if baseSchema.Properties == nil || baseSchema.Properties["serviceType"] == nil {
+		panic("serviceType property not found in RemoveEnvironmentServiceInput schema")
+	}
+	baseSchema.Properties["serviceType"].Enum = serviceTypeSchemaEnum()
+
+	return baseSchema
+}
+
+// RemoveEnvironmentServiceHandler removes a service from a PingOne environment using the provided client
+func RemoveEnvironmentServiceHandler(environmentsClientFactory EnvironmentsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input RemoveEnvironmentServiceInput,
+) (
+	*mcp.CallToolResult,
+	*RemoveEnvironmentServiceOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RemoveEnvironmentServiceInput) (*mcp.CallToolResult, *RemoveEnvironmentServiceOutput, error) {
+		productTypes, err := serviceProductTypes(input.ServiceType)
+		if err != nil {
+			toolErr := errs.NewToolError(RemoveEnvironmentServiceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := environmentsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(RemoveEnvironmentServiceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		unlock := lockEnvironmentServices(input.EnvironmentId)
+		defer unlock()
+
+		logger.FromContext(ctx).Debug("Removing environment service",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("service", input.ServiceType))
+
+		previous, updated, err := changeEnvironmentServices(ctx, client, input.EnvironmentId, func(products []pingone.EnvironmentBillOfMaterialsProduct) ([]pingone.EnvironmentBillOfMaterialsProduct, bool) {
+			remaining := slices.DeleteFunc(slices.Clone(products), func(product pingone.EnvironmentBillOfMaterialsProduct) bool {
+				return slices.Contains(productTypes, product.Type)
+			})
+			return remaining, len(remaining) != len(products)
+		})
+		if err != nil {
+			toolErr := errs.NewToolError(RemoveEnvironmentServiceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		if updated == nil {
+			logger.FromContext(ctx).Debug("Environment does not have the service",
+				slog.String("environmentId", input.EnvironmentId.String()),
+				slog.String("service", input.ServiceType))
+			previous.Links = nil
+			return nil, &RemoveEnvironmentServiceOutput{
+				Services: *previous,
+			}, nil
+		}
+
+		logger.FromContext(ctx).Debug("Environment service removed successfully",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("service", input.ServiceType))
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          RemoveEnvironmentServiceDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "environmentServices",
+			ResourceId:    input.EnvironmentId.String(),
+			Description:   fmt.Sprintf("Add the removed %s service again, with its previous configuration", input.ServiceType),
+		}, undoUpdateEnvironmentServices(environmentsClientFactory, input.EnvironmentId, previous.Products, updated.UpdatedAt))
+
+		// Filter out _links field from response
+		updated.Links = nil
+
+		return nil, &RemoveEnvironmentServiceOutput{
+			Services: *updated,
+			Changed:  true,
+		}, nil
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	envtestutils "github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveEnvironmentServiceHandler_MockClient(t *testing.T) {
+	base := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE
+	mfa := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_MFA
+	credentials := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_CREDENTIALS
+	verify := pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_VERIFY
+
+	tests := []struct {
+		name            string
+		serviceType     string
+		setupMock       func(*envtestutils.MockEnvironmentsClient)
+		wantErr         bool
+		wantChanged     bool
+		wantProductsLen int
+	}{
+		{
+			name:        "Removes the service and keeps the other services",
+			serviceType: string(mfa),
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentServicesSetup(m, testEnv1.id, servicesResponse(servicesReadAt, base, mfa), 200, nil)
+				mockUpdateEnvironmentServicesSetup(m, testEnv1.id, productTypesMatcher(base), servicesResponse(servicesUpdatedAt, base), 200, nil)
+			},
+			wantChanged:     true,
+			wantProductsLen: 1,
+		},
+		{
+			name:        "NEO removes the Credentials and Verify services",
+			serviceType: environments.NeoServiceValue,
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentServicesSetup(m, testEnv1.id, servicesResponse(servicesReadAt, base, credentials, verify), 200, nil)
+				mockUpdateEnvironmentServicesSetup(m, testEnv1.id, productTypesMatcher(base), servicesResponse(servicesUpdatedAt, base), 200, nil)
+			},
+			wantChanged:     true,
+			wantProductsLen: 1,
+		},
+		{
+			name:        "Service not added leaves the services unchanged",
+			serviceType: string(mfa),
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentServicesSetup(m, testEnv1.id, servicesResponse(servicesReadAt, base), 200, nil)
+			},
+			wantChanged:     false,
+			wantProductsLen: 1,
+		},
+		{
+			name:        "Invalid service type",
+			serviceType: "NOT_A_SERVICE",
+			setupMock:   func(m *envtestutils.MockEnvironmentsClient) {},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &envtestutils.MockEnvironmentsClient{}
+			tt.setupMock(mockClient)
+			handler := environments.RemoveEnvironmentServiceHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environments.RemoveEnvironmentServiceInput{
+				EnvironmentId: testEnv1.id,
+				ServiceType:   tt.serviceType,
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Nil(t, output)
+				return
+			}
+			require.NoError(t, err)
+			assert.Nil(t, mcpResult)
+			require.NotNil(t, output)
+			assert.Equal(t, tt.wantChanged, output.Changed)
+			assert.Len(t, output.Services.Products, tt.wantProductsLen)
+			if !tt.wantChanged {
+				mockClient.AssertNotCalled(t, "UpdateEnvironmentServices", mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
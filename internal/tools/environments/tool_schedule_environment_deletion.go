@@ -0,0 +1,155 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	DefaultDeletionGracePeriodHours = 72
+	MinDeletionGracePeriodHours     = 1
+	MaxDeletionGracePeriodHours     = 720
+)
+
+var ScheduleEnvironmentDeletionDef = types.ToolDefinition{
+	RequiresConfirmation: true,
+	McpTool: &mcp.Tool{
+		Name:  "schedule_environment_deletion",
+		Title: "Schedule PingOne Environment Deletion",
+		Description: `Schedule a sandbox environment and all of its resources to be deleted after a grace period, giving the team a window to review the deletion and cancel it with 'cancel_environment_deletion'. Deletion cannot be undone once it runs.
+
+The deletion is held by this MCP server and only runs if the server is still running when the grace period ends; it is lost if the server restarts. It is not run if the environment has been promoted to PRODUCTION in the meantime. The server refreshes its PingOne session before deleting; if the session has expired and can only be renewed by logging in again, the deletion fails. Use 'list_scheduled_environment_deletions' to review pending deletions.`,
+		InputSchema:  schema.MustGenerateSchema[ScheduleEnvironmentDeletionInput](),
+		OutputSchema: schema.MustGenerateSchema[ScheduleEnvironmentDeletionOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type ScheduleEnvironmentDeletionInput struct {
+	EnvironmentId    uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment to delete."`
+	GracePeriodHours *int      `json:"gracePeriodHours,omitempty" jsonschema:"OPTIONAL. Hours to wait before deleting the environment, between 1 and 720. Defaults to 72."`
+	RequestedBy      *string   `json:"requestedBy,omitempty" jsonschema:"OPTIONAL. Who requested the deletion, such as their email address, shown to reviewers."`
+	Reason           *string   `json:"reason,omitempty" jsonschema:"OPTIONAL. Why the environment is being deleted, shown to reviewers."`
+}
+
+type ScheduleEnvironmentDeletionOutput struct {
+	Deletion ScheduledDeletion `json:"deletion" jsonschema:"The scheduled deletion"`
+}
+
+// ScheduleEnvironmentDeletionHandler schedules the deletion of a PingOne environment in the provided scheduler
+func ScheduleEnvironmentDeletionHandler(environmentsClientFactory EnvironmentsClientFactory, scheduler *DeletionScheduler) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ScheduleEnvironmentDeletionInput,
+) (
+	*mcp.CallToolResult,
+	*ScheduleEnvironmentDeletionOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ScheduleEnvironmentDeletionInput) (*mcp.CallToolResult, *ScheduleEnvironmentDeletionOutput, error) {
+		gracePeriodHours := DefaultDeletionGracePeriodHours
+		if input.GracePeriodHours != nil {
+			gracePeriodHours = *input.GracePeriodHours
+		}
+		if gracePeriodHours < MinDeletionGracePeriodHours || gracePeriodHours > MaxDeletionGracePeriodHours {
+			toolErr := errs.NewToolError(ScheduleEnvironmentDeletionDef.McpTool.Name, fmt.Errorf("gracePeriodHours must be between %d and %d", MinDeletionGracePeriodHours, MaxDeletionGracePeriodHours))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := environmentsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ScheduleEnvironmentDeletionDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		environment, httpResponse, err := client.GetEnvironment(ctx, input.EnvironmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if environment == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if environment.Type == pingone.ENVIRONMENTTYPEVALUE_PRODUCTION {
+			toolErr := errs.NewToolError(ScheduleEnvironmentDeletionDef.McpTool.Name, fmt.Errorf("environment %s is a PRODUCTION environment and cannot be scheduled for deletion", input.EnvironmentId))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		deletion, err := scheduler.Schedule(ctx, input.EnvironmentId, environment.Name, time.Duration(gracePeriodHours)*time.Hour, input.RequestedBy, input.Reason)
+		if err != nil {
+			toolErr := errs.NewToolError(ScheduleEnvironmentDeletionDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Info("Environment deletion scheduled",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("environmentName", environment.Name),
+			slog.Time("deleteAt", deletion.DeleteAt))
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          ScheduleEnvironmentDeletionDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "environment",
+			ResourceId:    input.EnvironmentId.String(),
+			Description:   fmt.Sprintf("Cancel the deletion of environment %q scheduled for %s", environment.Name, deletion.DeleteAt.Format(time.RFC3339)),
+		}, func(ctx context.Context, force bool) error {
+			_, err := scheduler.Cancel(input.EnvironmentId)
+			return err
+		})
+
+		return nil, &ScheduleEnvironmentDeletionOutput{Deletion: deletion}, nil
+	}
+}
+
+// DeleteScheduledEnvironment returns the function the deletion scheduler uses to delete an environment once its
+// grace period has passed. The environment is checked again first, as it may have been promoted to PRODUCTION since.
+func DeleteScheduledEnvironment(environmentsClientFactory EnvironmentsClientFactory) DeleteEnvironmentFunc {
+	return func(ctx context.Context, environmentId uuid.UUID) error {
+		client, err := environmentsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		environment, httpResponse, err := client.GetEnvironment(ctx, environmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if environment == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+		}
+		if environment.Type == pingone.ENVIRONMENTTYPEVALUE_PRODUCTION {
+			return fmt.Errorf("environment %s was promoted to PRODUCTION after its deletion was scheduled, and has not been deleted", environmentId)
+		}
+
+		httpResponse, err = client.DeleteEnvironment(ctx, environmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package environments_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	envtestutils "github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleEnvironmentDeletionHandler_MockClient(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           environments.ScheduleEnvironmentDeletionInput
+		setupMock       func(*envtestutils.MockEnvironmentsClient)
+		wantErr         bool
+		wantErrContains string
+		validateOutput  func(*testing.T, *environments.ScheduleEnvironmentDeletionOutput)
+	}{
+		{
+			name:  "Success - Default grace period",
+			input: environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv1.id},
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				response := createEnvironmentResponse(t, testEnv1)
+				mockGetEnvironmentSetup(m, testEnv1.id, &response, 200, nil)
+			},
+			validateOutput: func(t *testing.T, output *environments.ScheduleEnvironmentDeletionOutput) {
+				assert.Equal(t, testEnv1.id, output.Deletion.EnvironmentId)
+				assert.Equal(t, testEnv1.name, output.Deletion.EnvironmentName)
+				assert.Equal(t, environments.DeletionStatusScheduled, output.Deletion.Status)
+				assert.Equal(t, 72*time.Hour, output.Deletion.DeleteAt.Sub(output.Deletion.ScheduledAt))
+			},
+		},
+		{
+			name: "Success - Custom grace period with requester and reason",
+			input: environments.ScheduleEnvironmentDeletionInput{
+				EnvironmentId:    testEnv1.id,
+				GracePeriodHours: testutils.Pointer(24),
+				RequestedBy:      testutils.Pointer("alice@example.com"),
+				Reason:           testutils.Pointer("Load test finished"),
+			},
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				response := createEnvironmentResponse(t, testEnv1)
+				mockGetEnvironmentSetup(m, testEnv1.id, &response, 200, nil)
+			},
+			validateOutput: func(t *testing.T, output *environments.ScheduleEnvironmentDeletionOutput) {
+				assert.Equal(t, 24*time.Hour, output.Deletion.DeleteAt.Sub(output.Deletion.ScheduledAt))
+				assert.Equal(t, testutils.Pointer("alice@example.com"), output.Deletion.RequestedBy)
+				assert.Equal(t, testutils.Pointer("Load test finished"), output.Deletion.Reason)
+			},
+		},
+		{
+			name:            "Error - Grace period too short",
+			input:           environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv1.id, GracePeriodHours: testutils.Pointer(0)},
+			setupMock:       func(m *envtestutils.MockEnvironmentsClient) {},
+			wantErr:         true,
+			wantErrContains: "gracePeriodHours must be between 1 and 720",
+		},
+		{
+			name:            "Error - Grace period too long",
+			input:           environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv1.id, GracePeriodHours: testutils.Pointer(721)},
+			setupMock:       func(m *envtestutils.MockEnvironmentsClient) {},
+			wantErr:         true,
+			wantErrContains: "gracePeriodHours must be between 1 and 720",
+		},
+		{
+			name:  "Error - Production environment",
+			input: environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv2.id},
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				response := createEnvironmentResponse(t, testEnv2)
+				mockGetEnvironmentSetup(m, testEnv2.id, &response, 200, nil)
+			},
+			wantErr:         true,
+			wantErrContains: "is a PRODUCTION environment and cannot be scheduled for deletion",
+		},
+		{
+			name:  "Error - Environment not found",
+			input: environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv1.id},
+			setupMock: func(m *envtestutils.MockEnvironmentsClient) {
+				mockGetEnvironmentSetup(m, testEnv1.id, nil, 404, errors.New("environment not found"))
+			},
+			wantErr:         true,
+			wantErrContains: "environment not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &envtestutils.MockEnvironmentsClient{}
+			tt.setupMock(mockClient)
+			factory := envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil)
+			scheduler := environments.NewDeletionScheduler(t.Context(), environments.DeleteScheduledEnvironment(factory))
+			handler := environments.ScheduleEnvironmentDeletionHandler(factory, scheduler)
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr {
+				testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+				assert.Empty(t, scheduler.List())
+				mockClient.AssertExpectations(t)
+				return
+			}
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			tt.validateOutput(t, output)
+			assert.Len(t, scheduler.List(), 1)
+			mockClient.AssertExpectations(t)
+		})
+		t.Run(tt.name+" via MCP", func(t *testing.T) {
+			mockClient := &envtestutils.MockEnvironmentsClient{}
+			tt.setupMock(mockClient)
+			factory := envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil)
+			scheduler := environments.NewDeletionScheduler(t.Context(), environments.DeleteScheduledEnvironment(factory))
+
+			server := mcptestutils.TestMcpServer(t)
+			mcp.AddTool(server, environments.ScheduleEnvironmentDeletionDef.McpTool, environments.ScheduleEnvironmentDeletionHandler(factory, scheduler))
+
+			output, err := mcptestutils.CallToolOverMcp(t, server, environments.ScheduleEnvironmentDeletionDef.McpTool.Name, tt.input)
+
+			require.NoError(t, err, "Expect no error calling tool")
+			require.NotNil(t, output, "Expect non-nil output")
+
+			if tt.wantErr {
+				testutils.AssertMcpCallError(t, output, tt.wantErrContains)
+				mockClient.AssertExpectations(t)
+				return
+			}
+
+			testutils.AssertMcpCallSuccess(t, err, output)
+			scheduleOutput := &environments.ScheduleEnvironmentDeletionOutput{}
+			jsonBytes, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err, "Failed to marshal structured content")
+			require.NoError(t, json.Unmarshal(jsonBytes, scheduleOutput), "Failed to unmarshal structured content")
+			tt.validateOutput(t, scheduleOutput)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduleEnvironmentDeletionHandler_AlreadyScheduled(t *testing.T) {
+	mockClient := &envtestutils.MockEnvironmentsClient{}
+	response := createEnvironmentResponse(t, testEnv1)
+	mockGetEnvironmentSetup(mockClient, testEnv1.id, &response, 200, nil)
+	factory := envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil)
+	scheduler := environments.NewDeletionScheduler(t.Context(), environments.DeleteScheduledEnvironment(factory))
+	handler := environments.ScheduleEnvironmentDeletionHandler(factory, scheduler)
+	input := environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv1.id}
+
+	_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, input)
+	require.NoError(t, err)
+
+	mcpResult, output, err := handler(t.Context(), &mcp.CallToolRequest{}, input)
+	testutils.AssertHandlerError(t, err, mcpResult, output, "is already scheduled for deletion")
+}
+
+func TestScheduleEnvironmentDeletionHandler_GetAuthenticatedClientError(t *testing.T) {
+	factory := envtestutils.NewMockEnvironmentsClientFactory(nil, errors.New("not authenticated"))
+	scheduler := environments.NewDeletionScheduler(t.Context(), environments.DeleteScheduledEnvironment(factory))
+	handler := environments.ScheduleEnvironmentDeletionHandler(factory, scheduler)
+
+	mcpResult, output, err := handler(t.Context(), &mcp.CallToolRequest{}, environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv1.id})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "not authenticated")
+}
+
+func TestDeleteScheduledEnvironment(t *testing.T) {
+	tests := []struct {
+		name            string
+		environment     environmentTestData
+		setupMock       func(*envtestutils.MockEnvironmentsClient, uuid.UUID)
+		wantErrContains string
+	}{
+		{
+			name:        "Deletes sandbox environment",
+			environment: testEnv1,
+			setupMock: func(m *envtestutils.MockEnvironmentsClient, envID uuid.UUID) {
+				m.On("DeleteEnvironment", mock.Anything, envID).Return(&http.Response{StatusCode: 204}, nil)
+			},
+		},
+		{
+			name:            "Does not delete environment promoted to production",
+			environment:     testEnv2,
+			setupMock:       func(m *envtestutils.MockEnvironmentsClient, envID uuid.UUID) {},
+			wantErrContains: "was promoted to PRODUCTION after its deletion was scheduled",
+		},
+		{
+			name:        "Delete fails",
+			environment: testEnv1,
+			setupMock: func(m *envtestutils.MockEnvironmentsClient, envID uuid.UUID) {
+				m.On("DeleteEnvironment", mock.Anything, envID).Return(&http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &envtestutils.MockEnvironmentsClient{}
+			response := createEnvironmentResponse(t, tt.environment)
+			mockGetEnvironmentSetup(mockClient, tt.environment.id, &response, 200, nil)
+			tt.setupMock(mockClient, tt.environment.id)
+
+			err := environments.DeleteScheduledEnvironment(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))(t.Context(), tt.environment.id)
+
+			if tt.wantErrContains != "" {
+				assert.ErrorContains(t, err, tt.wantErrContains)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduleEnvironmentDeletionHandler_RecordsUndo(t *testing.T) {
+	mockClient := &envtestutils.MockEnvironmentsClient{}
+	response := createEnvironmentResponse(t, testEnv1)
+	mockGetEnvironmentSetup(mockClient, testEnv1.id, &response, 200, nil)
+	factory := envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil)
+	scheduler := environments.NewDeletionScheduler(t.Context(), environments.DeleteScheduledEnvironment(factory))
+	handler := environments.ScheduleEnvironmentDeletionHandler(factory, scheduler)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(t.Context(), journal)
+
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, environments.ScheduleEnvironmentDeletionInput{EnvironmentId: testEnv1.id})
+	require.NoError(t, err)
+
+	_, err = journal.Undo(t.Context(), testEnv1.id.String(), "", false)
+	require.NoError(t, err)
+	deletions := scheduler.List()
+	require.Len(t, deletions, 1)
+	assert.Equal(t, environments.DeletionStatusCancelled, deletions[0].Status)
+}
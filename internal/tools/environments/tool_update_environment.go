@@ -6,12 +6,15 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-go-client/pingone"
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 )
@@ -27,7 +30,7 @@ WORKFLOW - Required to avoid data loss:
 2. Modify only the fields you want to change
 3. Pass the complete merged object to this tool
 
-Omitted optional fields will be cleared. Common updates: name, description, type (SANDBOX→PRODUCTION is permanent). Cannot change: region, ID.`,
+Omitted optional fields will be cleared. Pass the 'updatedAt' returned by 'get_environment' as 'lastKnownUpdatedAt' so that the environment is not replaced if someone else has changed it since. Common updates: name, description, type (SANDBOX→PRODUCTION is permanent). Cannot change: region, ID.`,
 		InputSchema:  schema.MustGenerateSchema[UpdateEnvironmentInput](),
 		OutputSchema: schema.MustGenerateSchema[UpdateEnvironmentOutput](),
 	},
@@ -44,6 +47,9 @@ type UpdateEnvironmentInput struct {
 	Region          pingone.EnvironmentRegionCode                     `json:"region" jsonschema:"REQUIRED. Region code (NA/CA/EU/AU/SG/AP). Set at creation, immutable."`
 	Status          *pingone.EnvironmentStatusValue                   `json:"status,omitempty" jsonschema:"OPTIONAL. ACTIVE or DELETE_PENDING. For PRODUCTION environments, use Update Environment Status endpoint instead."`
 	Type            pingone.EnvironmentTypeValue                      `json:"type" jsonschema:"REQUIRED. PRODUCTION or SANDBOX. SANDBOX can be promoted to PRODUCTION (permanent, cannot revert)."`
+	// LastKnownUpdatedAt is compared with the update time of the environment as read just before it is replaced,
+	// as the PingOne client does not support conditional updates such as If-Match
+	LastKnownUpdatedAt *time.Time `json:"lastKnownUpdatedAt,omitempty" jsonschema:"OPTIONAL. The updatedAt of the environment as last read. If the environment has been updated since, it is not replaced and the call fails with a CONFLICT error."`
 }
 
 // UpdateEnvironmentOutput represents the result of updating an environment
@@ -75,6 +81,27 @@ func UpdateEnvironmentHandler(environmentsClientFactory EnvironmentsClientFactor
 			slog.String("region", string(input.Region)),
 			slog.String("type", string(input.Type)))
 
+		// Fetch the configuration being replaced, so that it can be checked against the last known update and the
+		// change can be undone
+		var previous *pingone.EnvironmentResponse
+		if input.LastKnownUpdatedAt != nil {
+			previous, err = unchangedEnvironment(ctx, client, input.EnvironmentId, *input.LastKnownUpdatedAt)
+			if err != nil {
+				toolErr := errs.NewToolError(UpdateEnvironmentDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		} else if rollback.IsRecording(ctx) {
+			var httpResponse *http.Response
+			previous, httpResponse, err = client.GetEnvironment(ctx, input.EnvironmentId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				logger.FromContext(ctx).Warn("Unable to fetch the environment before updating it, the update cannot be undone",
+					slog.String("environmentId", input.EnvironmentId.String()),
+					slog.Any("error", err))
+			}
+		}
+
 		// Build the environment replace request
 		replaceRequest := pingone.NewEnvironmentReplaceRequest(
 			input.Name,
@@ -122,6 +149,16 @@ func UpdateEnvironmentHandler(environmentsClientFactory EnvironmentsClientFactor
 			slog.String("type", string(input.Type)),
 		)
 
+		if previous != nil {
+			rollback.Record(ctx, rollback.Change{
+				Tool:          UpdateEnvironmentDef.McpTool.Name,
+				EnvironmentId: input.EnvironmentId.String(),
+				ResourceType:  "environment",
+				ResourceId:    input.EnvironmentId.String(),
+				Description:   fmt.Sprintf("Restore the previous name, description, icon and license of environment %q", previous.Name),
+			}, undoUpdateEnvironment(environmentsClientFactory, input.EnvironmentId, *previous, environment.UpdatedAt))
+		}
+
 		// Filter out _links field from response
 		environment.Links = nil
 
@@ -132,3 +169,55 @@ func UpdateEnvironmentHandler(environmentsClientFactory EnvironmentsClientFactor
 		return nil, result, nil
 	}
 }
+
+// unchangedEnvironment reads an environment, and returns a ConflictError if it has been updated since
+// lastKnownUpdatedAt
+func unchangedEnvironment(ctx context.Context, client EnvironmentsClient, environmentId uuid.UUID, lastKnownUpdatedAt time.Time) (*pingone.EnvironmentResponse, error) {
+	current, httpResponse, err := client.GetEnvironment(ctx, environmentId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		return nil, errs.NewApiError(httpResponse, err)
+	}
+	if current == nil {
+		return nil, errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+	}
+	if err := errs.CheckNotChangedSince("environment", environmentId.String(), lastKnownUpdatedAt, &current.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// undoUpdateEnvironment returns the function that restores the name, description, icon and license of an
+// environment replaced by an update, unless the environment has been updated again since. The type is not
+// restored, as an environment promoted to PRODUCTION cannot be reverted to SANDBOX.
+func undoUpdateEnvironment(environmentsClientFactory EnvironmentsClientFactory, environmentId uuid.UUID, previous pingone.EnvironmentResponse, updatedAt time.Time) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := environmentsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, httpResponse, err := client.GetEnvironment(ctx, environmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if current == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+		}
+		if !force && !current.UpdatedAt.Equal(updatedAt) {
+			return rollback.ErrChangedSince
+		}
+
+		restoreRequest := pingone.NewEnvironmentReplaceRequest(previous.Name, current.Region, current.Type)
+		restoreRequest.Description = previous.Description
+		restoreRequest.Icon = previous.Icon
+		restoreRequest.License = previous.License
+		_, httpResponse, err = client.UpdateEnvironment(ctx, environmentId, restoreRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/google/uuid"
@@ -13,6 +14,7 @@ import (
 	"github.com/pingidentity/pingone-go-client/pingone"
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 )
@@ -21,7 +23,7 @@ var UpdateEnvironmentServicesDef = types.ToolDefinition{
 	McpTool: &mcp.Tool{
 		Name:         "update_environment_services",
 		Title:        "Update PingOne Environment Services by ID",
-		Description:  "Update the services assigned to a PingOne environment (update's the environment's Bill of Materials) by the environment's unique ID. IMPORTANT: when changing the services for an environment, include any optional fields you wish to retain from the existing configuration, as omitting them remove those fields from the configuration.",
+		Description:  "Update the services assigned to a PingOne environment (update's the environment's Bill of Materials) by the environment's unique ID. IMPORTANT: when changing the services for an environment, include any optional fields you wish to retain from the existing configuration, as omitting them remove those fields from the configuration. To add or remove a single service, use add_environment_service or remove_environment_service instead.",
 		InputSchema:  mustGenerateUpdateEnvironmentServicesInputSchema(),
 		OutputSchema: schema.MustGenerateSchema[UpdateEnvironmentServicesOutput](),
 	},
@@ -60,13 +62,7 @@ func mustGenerateUpdateEnvironmentServicesInputSchema() *jsonschema.Schema {
 	if servicesSchema.Items.Properties == nil || servicesSchema.Items.Properties["type"] == nil {
 		panic("type property not found in services item schema for UpdateEnvironmentServicesInput")
 	}
-	var itemsEnum []any
-	for _, val := range pingone.AllowedEnvironmentBillOfMaterialsProductTypeEnumValues {
-		itemsEnum = append(itemsEnum, string(val))
-	}
-	// Add Neo value, representing Verify and Credentials combined
-	itemsEnum = append(itemsEnum, NeoServiceValue)
-	servicesSchema.Items.Properties["type"].Enum = itemsEnum
+	servicesSchema.Items.Properties["type"].Enum = serviceTypeSchemaEnum()
 
 	return baseSchema
 }
@@ -99,6 +95,9 @@ func UpdateEnvironmentServicesHandler(environmentsClientFactory EnvironmentsClie
 			return nil, nil, toolErr
 		}
 
+		unlock := lockEnvironmentServices(input.EnvironmentId)
+		defer unlock()
+
 		// First, get current environment services to preserve existing configurations
 		currentServices, httpResponse, err := client.GetEnvironmentServices(ctx, input.EnvironmentId)
 		logger.LogHttpResponse(ctx, httpResponse)
@@ -179,6 +178,14 @@ func UpdateEnvironmentServicesHandler(environmentsClientFactory EnvironmentsClie
 			slog.String("environmentId", input.EnvironmentId.String()),
 			slog.Int("productCount", len(services.Products)))
 
+		rollback.Record(ctx, rollback.Change{
+			Tool:          UpdateEnvironmentServicesDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "environmentServices",
+			ResourceId:    input.EnvironmentId.String(),
+			Description:   fmt.Sprintf("Restore the previous %d services of the environment", len(currentServices.Products)),
+		}, undoUpdateEnvironmentServices(environmentsClientFactory, input.EnvironmentId, currentServices.Products, services.UpdatedAt))
+
 		// Filter out _links field from response
 		services.Links = nil
 
@@ -189,3 +196,38 @@ func UpdateEnvironmentServicesHandler(environmentsClientFactory EnvironmentsClie
 		return nil, result, nil
 	}
 }
+
+// undoUpdateEnvironmentServices returns the function that restores the services of an environment replaced by an
+// update, unless the services have been updated again since
+func undoUpdateEnvironmentServices(environmentsClientFactory EnvironmentsClientFactory, environmentId uuid.UUID, previousProducts []pingone.EnvironmentBillOfMaterialsProduct, updatedAt *time.Time) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := environmentsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force && updatedAt != nil {
+			current, httpResponse, err := client.GetEnvironmentServices(ctx, environmentId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			if current == nil {
+				return errs.NewApiError(httpResponse, fmt.Errorf("no services data in response"))
+			}
+			if current.UpdatedAt == nil || !current.UpdatedAt.Equal(*updatedAt) {
+				return rollback.ErrChangedSince
+			}
+		}
+
+		restoreRequest := pingone.EnvironmentBillOfMaterialsReplaceRequest{
+			Products: previousProducts,
+		}
+		_, httpResponse, err := client.UpdateEnvironmentServices(ctx, environmentId, &restoreRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
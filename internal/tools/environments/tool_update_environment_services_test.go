@@ -6,7 +6,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -16,7 +18,9 @@ import (
 	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
 	envtestutils "github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -628,3 +632,46 @@ func TestUpdateEnvironmentServicesHandler_RealClient(t *testing.T) {
 	require.NotNil(t, output.Services)
 	assert.NotEmpty(t, output.Services.Products, "Environment should have at least one product/service")
 }
+
+func TestUpdateEnvironmentServicesHandler_RecordsUndo(t *testing.T) {
+	updatedAt := time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC)
+	previous := &pingone.EnvironmentBillOfMaterialsResponse{
+		Products: []pingone.EnvironmentBillOfMaterialsProduct{
+			{Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE},
+			{Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_MFA},
+		},
+	}
+	updated := &pingone.EnvironmentBillOfMaterialsResponse{
+		Products: []pingone.EnvironmentBillOfMaterialsProduct{
+			{Type: pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE},
+		},
+		UpdatedAt: &updatedAt,
+	}
+
+	mockClient := &envtestutils.MockEnvironmentsClient{}
+	mockClient.On("GetEnvironmentServices", mock.Anything, testEnv1.id).Return(previous, &http.Response{StatusCode: 200}, nil).Once()
+	mockUpdateEnvironmentServicesSetup(mockClient, testEnv1.id, func(req *pingone.EnvironmentBillOfMaterialsReplaceRequest) bool {
+		return len(req.Products) == 1
+	}, updated, 200, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := environments.UpdateEnvironmentServicesHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, environments.UpdateEnvironmentServicesInput{
+		EnvironmentId: testEnv1.id,
+		Services: []environments.EnvironmentServiceInput{
+			{Type: string(pingone.ENVIRONMENTBILLOFMATERIALSPRODUCTTYPE_PING_ONE_BASE)},
+		},
+	})
+	require.NoError(t, err)
+
+	mockClient.On("GetEnvironmentServices", mock.Anything, testEnv1.id).Return(updated, &http.Response{StatusCode: 200}, nil).Once()
+	mockUpdateEnvironmentServicesSetup(mockClient, testEnv1.id, func(req *pingone.EnvironmentBillOfMaterialsReplaceRequest) bool {
+		return len(req.Products) == 2
+	}, previous, 200, nil)
+
+	change, err := journal.Undo(context.Background(), testEnv1.id.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, environments.UpdateEnvironmentServicesDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
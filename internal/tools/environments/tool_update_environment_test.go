@@ -6,16 +6,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
 	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
 	envtestutils "github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -549,3 +553,100 @@ func TestUpdateEnvironmentHandler_RealClient(t *testing.T) {
 	assert.Equal(t, testEnvironmentId, response.Environment.Id, "Environment ID should match")
 	assert.Equal(t, input.Name, response.Environment.Name, "Environment name should be updated")
 }
+
+func TestUpdateEnvironmentHandler_RecordsUndo(t *testing.T) {
+	previous := createEnvironmentResponse(t, testEnv1)
+	previous.Description = testutils.Pointer("Previous description")
+	previous.UpdatedAt = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	updated := createEnvironmentResponse(t, testEnv1)
+	updated.Name = "Updated Environment"
+	updated.Type = pingone.ENVIRONMENTTYPEVALUE_PRODUCTION
+	updated.UpdatedAt = time.Date(2025, 6, 1, 13, 0, 0, 0, time.UTC)
+
+	mockClient := &envtestutils.MockEnvironmentsClient{}
+	mockClient.On("GetEnvironment", mock.Anything, testEnv1.id).Return(&previous, &http.Response{StatusCode: 200}, nil).Once()
+	mockUpdateEnvironmentSetup(mockClient, testEnv1.id, func(req *pingone.EnvironmentReplaceRequest) bool {
+		return req.Name == "Updated Environment"
+	}, &updated, 200, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := environments.UpdateEnvironmentHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, environments.UpdateEnvironmentInput{
+		EnvironmentId: testEnv1.id,
+		Name:          "Updated Environment",
+		Region:        testEnv1.region,
+		Type:          pingone.ENVIRONMENTTYPEVALUE_PRODUCTION,
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnv1.id.String()), 1)
+
+	// The environment stays PRODUCTION, as promotion cannot be reverted
+	mockClient.On("GetEnvironment", mock.Anything, testEnv1.id).Return(&updated, &http.Response{StatusCode: 200}, nil).Once()
+	mockUpdateEnvironmentSetup(mockClient, testEnv1.id, func(req *pingone.EnvironmentReplaceRequest) bool {
+		return req.Name == testEnv1.name &&
+			req.Type == pingone.ENVIRONMENTTYPEVALUE_PRODUCTION &&
+			req.Description != nil && *req.Description == "Previous description"
+	}, &previous, 200, nil)
+
+	change, err := journal.Undo(context.Background(), testEnv1.id.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, environments.UpdateEnvironmentDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateEnvironmentHandler_LastKnownUpdatedAt(t *testing.T) {
+	lastKnownUpdatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	updated := createEnvironmentResponse(t, testEnv1)
+	updated.Name = "Updated Environment"
+
+	tests := []struct {
+		name             string
+		currentUpdatedAt time.Time
+		wantReplaced     bool
+	}{
+		{
+			name:             "Replaces an environment not changed since",
+			currentUpdatedAt: lastKnownUpdatedAt,
+			wantReplaced:     true,
+		},
+		{
+			name:             "Does not replace an environment changed since",
+			currentUpdatedAt: lastKnownUpdatedAt.Add(time.Minute),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			current := createEnvironmentResponse(t, testEnv1)
+			current.UpdatedAt = tc.currentUpdatedAt
+			mockClient := &envtestutils.MockEnvironmentsClient{}
+			mockClient.On("GetEnvironment", mock.Anything, testEnv1.id).Return(&current, &http.Response{StatusCode: 200}, nil).Once()
+			if tc.wantReplaced {
+				mockUpdateEnvironmentSetup(mockClient, testEnv1.id, func(req *pingone.EnvironmentReplaceRequest) bool {
+					return req.Name == "Updated Environment"
+				}, &updated, 200, nil)
+			}
+
+			handler := environments.UpdateEnvironmentHandler(envtestutils.NewMockEnvironmentsClientFactory(mockClient, nil))
+			_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, environments.UpdateEnvironmentInput{
+				EnvironmentId:      testEnv1.id,
+				Name:               "Updated Environment",
+				Region:             testEnv1.region,
+				Type:               testEnv1.envType,
+				LastKnownUpdatedAt: &lastKnownUpdatedAt,
+			})
+
+			if tc.wantReplaced {
+				require.NoError(t, err)
+				assert.Equal(t, "Updated Environment", output.Environment.Name)
+			} else {
+				var conflictErr *errs.ConflictError
+				require.ErrorAs(t, err, &conflictErr)
+				assert.Equal(t, "environment", conflictErr.ResourceType)
+				assert.Equal(t, tc.currentUpdatedAt, *conflictErr.UpdatedAt)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package errorenvelope
+
+import (
+	"context"
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+)
+
+// ErrorEnvelopeMiddleware returns failed tool calls with an errs.ErrorEnvelope as their structured content, so
+// that agents can tell permission errors from missing resources and rate limiting without parsing the message.
+// The text content of failed calls is left unchanged.
+//
+// Tool handlers report their errors with errs.Log, so the middleware records the errors logged during the call to
+// describe the error behind the message of the failed call. Failures whose error was not logged are described from
+// the message alone.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, closest to the tools.
+type ErrorEnvelopeMiddleware struct{}
+
+func NewErrorEnvelopeMiddleware() *ErrorEnvelopeMiddleware {
+	return &ErrorEnvelopeMiddleware{}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ErrorEnvelopeMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		ctx, recorder := errs.ContextWithErrorRecorder(ctx)
+		result, err := next(ctx, method, req)
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if err != nil || !ok || callToolResult == nil || !callToolResult.IsError || callToolResult.StructuredContent != nil {
+			return result, err
+		}
+
+		message := errorMessage(callToolResult)
+		toolErr := recorder.Find(message)
+		if toolErr == nil {
+			toolErr = errors.New(message)
+		}
+		callToolResult.StructuredContent = errs.NewErrorEnvelope(toolErr)
+		return callToolResult, nil
+	}
+}
+
+// errorMessage returns the text of a failed tool call, which is the message of the error returned by its handler
+func errorMessage(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if textContent, ok := content.(*mcp.TextContent); ok {
+			return textContent.Text
+		}
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package errorenvelope_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/errorenvelope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getThingInput struct {
+	ThingId string `json:"thingId"`
+}
+
+type getThingOutput struct {
+	Name string `json:"name"`
+}
+
+// errorEnvelopeServer serves a get_thing tool that fails with the error returned by fail, logging it if log is set
+func errorEnvelopeServer(t *testing.T, fail func(ctx context.Context) error, log bool) *mcp.ClientSession {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "test-version"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_thing"}, func(ctx context.Context, req *mcp.CallToolRequest, input getThingInput) (*mcp.CallToolResult, *getThingOutput, error) {
+		if err := fail(ctx); err != nil {
+			if log {
+				errs.Log(ctx, err)
+			}
+			return nil, nil, err
+		}
+		return nil, &getThingOutput{Name: "thing"}, nil
+	})
+	server.AddReceivingMiddleware(errorenvelope.NewErrorEnvelopeMiddleware().Handler)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "test-version"}, nil)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func callGetThing(t *testing.T, session *mcp.ClientSession) *mcp.CallToolResult {
+	t.Helper()
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "get_thing", Arguments: map[string]any{"thingId": "1"}})
+	require.NoError(t, err)
+	return result
+}
+
+func envelopeOf(t *testing.T, result *mcp.CallToolResult) map[string]any {
+	t.Helper()
+	envelope, ok := result.StructuredContent.(map[string]any)
+	require.True(t, ok, "structured content should be an error envelope")
+	return envelope
+}
+
+func notFoundError() error {
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Status:     "404 Not Found",
+		Body:       io.NopCloser(strings.NewReader(`{"id":"request-id","code":"NOT_FOUND","message":"Unable to find resource"}`)),
+	}
+	return errs.NewToolError("get_thing", errs.NewApiError(resp, errors.New("not found")))
+}
+
+func TestErrorEnvelopeMiddleware_DescribesLoggedErrors(t *testing.T) {
+	toolErr := notFoundError()
+	session := errorEnvelopeServer(t, func(ctx context.Context) error { return toolErr }, true)
+
+	result := callGetThing(t, session)
+	assert.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, toolErr.Error(), result.Content[0].(*mcp.TextContent).Text, "the text content should be unchanged")
+
+	envelope := envelopeOf(t, result)
+	assert.Equal(t, "NOT_FOUND", envelope["code"])
+	assert.Equal(t, toolErr.Error(), envelope["message"])
+	assert.Equal(t, "request-id", envelope["pingoneRequestId"])
+	assert.Equal(t, float64(http.StatusNotFound), envelope["httpStatus"])
+	assert.Equal(t, false, envelope["retryable"])
+	assert.NotEmpty(t, envelope["remediation"])
+}
+
+func TestErrorEnvelopeMiddleware_DescribesErrorsThatWereNotLogged(t *testing.T) {
+	session := errorEnvelopeServer(t, func(ctx context.Context) error { return notFoundError() }, false)
+
+	result := callGetThing(t, session)
+	assert.True(t, result.IsError)
+
+	envelope := envelopeOf(t, result)
+	assert.Equal(t, "TOOL_ERROR", envelope["code"])
+	assert.Equal(t, notFoundError().Error(), envelope["message"])
+	assert.NotContains(t, envelope, "httpStatus")
+}
+
+func TestErrorEnvelopeMiddleware_DescribesTheReturnedError(t *testing.T) {
+	rateLimitedErr := errs.NewToolError("get_thing", errs.NewApiError(&http.Response{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"}, errors.New("rate limited")))
+	session := errorEnvelopeServer(t, func(ctx context.Context) error {
+		// An error that is logged but not returned is not described
+		errs.Log(ctx, errs.NewToolError("get_thing", fmt.Errorf("unrelated")))
+		return rateLimitedErr
+	}, true)
+
+	envelope := envelopeOf(t, callGetThing(t, session))
+	assert.Equal(t, "RATE_LIMITED", envelope["code"])
+	assert.Equal(t, true, envelope["retryable"])
+}
+
+func TestErrorEnvelopeMiddleware_LeavesSuccessfulCallsUnchanged(t *testing.T) {
+	session := errorEnvelopeServer(t, func(ctx context.Context) error { return nil }, true)
+
+	result := callGetThing(t, session)
+	assert.False(t, result.IsError)
+	assert.Equal(t, map[string]any{"name": "thing"}, result.StructuredContent)
+}
@@ -0,0 +1,96 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package fieldselection
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// FieldsArgument is the name of the optional tool input argument listing the output attributes to return.
+const FieldsArgument = "fields"
+
+// Selection is a parsed set of field paths, held as a tree keyed by JSON attribute name.
+// A node without children selects the attribute and everything beneath it.
+type Selection map[string]Selection
+
+// ParseFields parses dot-separated JSON paths, such as "environments.name", into a Selection.
+// A leading "$." is accepted and ignored. Selecting an attribute also selects all of its children,
+// so "environment" and "environment.name" together select the whole environment.
+func ParseFields(fields []string) (Selection, error) {
+	selection := Selection{}
+	for _, field := range fields {
+		path := strings.TrimPrefix(strings.TrimSpace(field), "$.")
+		if path == "" {
+			return nil, fmt.Errorf("field paths must not be empty")
+		}
+
+		segments := strings.Split(path, ".")
+		node := selection
+		for i, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field path %q, path segments must not be empty", field)
+			}
+			child, ok := node[segment]
+			if ok && len(child) == 0 {
+				// The attribute is already selected in full
+				break
+			}
+			if i == len(segments)-1 {
+				node[segment] = Selection{}
+				break
+			}
+			if !ok {
+				child = Selection{}
+				node[segment] = child
+			}
+			node = child
+		}
+	}
+	return selection, nil
+}
+
+// Project returns the JSON document retaining only the selected attributes.
+// Arrays are traversed, so a path applies to every element of an array it passes through.
+// Selected attributes that are not present in the document are ignored.
+func (s Selection) Project(data json.RawMessage) (json.RawMessage, error) {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal output for field selection: %w", err)
+	}
+
+	projected, err := json.Marshal(s.project(value))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal selected fields: %w", err)
+	}
+	return projected, nil
+}
+
+func (s Selection) project(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(s))
+		for key, child := range s {
+			attribute, ok := typedValue[key]
+			if !ok {
+				continue
+			}
+			if len(child) == 0 {
+				result[key] = attribute
+			} else {
+				result[key] = child.project(attribute)
+			}
+		}
+		return result
+	case []any:
+		result := make([]any, 0, len(typedValue))
+		for _, element := range typedValue {
+			result = append(result, s.project(element))
+		}
+		return result
+	default:
+		// Scalar values have no attributes to select from, and are returned unchanged
+		return value
+	}
+}
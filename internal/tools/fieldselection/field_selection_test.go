@@ -0,0 +1,142 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package fieldselection_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/fieldselection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name            string
+		fields          []string
+		expected        fieldselection.Selection
+		wantErrContains string
+	}{
+		{
+			name:     "No fields",
+			fields:   nil,
+			expected: fieldselection.Selection{},
+		},
+		{
+			name:   "Nested paths share a parent",
+			fields: []string{"environments.id", "environments.name"},
+			expected: fieldselection.Selection{
+				"environments": {"id": {}, "name": {}},
+			},
+		},
+		{
+			name:   "Leading root and whitespace are ignored",
+			fields: []string{" $.environment.name "},
+			expected: fieldselection.Selection{
+				"environment": {"name": {}},
+			},
+		},
+		{
+			name:   "Parent selected after child selects the whole parent",
+			fields: []string{"environment.name", "environment"},
+			expected: fieldselection.Selection{
+				"environment": {},
+			},
+		},
+		{
+			name:   "Child selected after parent is ignored",
+			fields: []string{"environment", "environment.name"},
+			expected: fieldselection.Selection{
+				"environment": {},
+			},
+		},
+		{
+			name:            "Empty path",
+			fields:          []string{""},
+			wantErrContains: "field paths must not be empty",
+		},
+		{
+			name:            "Empty path segment",
+			fields:          []string{"environment..name"},
+			wantErrContains: "path segments must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selection, err := fieldselection.ParseFields(tt.fields)
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, selection)
+		})
+	}
+}
+
+func TestSelection_Project(t *testing.T) {
+	document := `{
+		"environments": [
+			{"id": "env-1", "name": "Dev", "region": {"code": "NA", "name": "North America"}},
+			{"id": "env-2", "name": "Prod", "region": {"code": "EU", "name": "Europe"}}
+		],
+		"resultResource": {"uri": "pingone://results/1", "totalCount": 2}
+	}`
+
+	tests := []struct {
+		name     string
+		fields   []string
+		expected string
+	}{
+		{
+			name:     "Attributes of array elements",
+			fields:   []string{"environments.id", "environments.name"},
+			expected: `{"environments": [{"id": "env-1", "name": "Dev"}, {"id": "env-2", "name": "Prod"}]}`,
+		},
+		{
+			name:     "Nested attributes of array elements",
+			fields:   []string{"environments.region.code"},
+			expected: `{"environments": [{"region": {"code": "NA"}}, {"region": {"code": "EU"}}]}`,
+		},
+		{
+			name:     "Whole attribute",
+			fields:   []string{"resultResource"},
+			expected: `{"resultResource": {"uri": "pingone://results/1", "totalCount": 2}}`,
+		},
+		{
+			name:     "Missing attributes are ignored",
+			fields:   []string{"environments.id", "environments.unknown", "unknown"},
+			expected: `{"environments": [{"id": "env-1"}, {"id": "env-2"}]}`,
+		},
+		{
+			name:     "Path through a scalar returns the scalar",
+			fields:   []string{"environments.name.first"},
+			expected: `{"environments": [{"name": "Dev"}, {"name": "Prod"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selection, err := fieldselection.ParseFields(tt.fields)
+			require.NoError(t, err)
+
+			projected, err := selection.Project(json.RawMessage(document))
+
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(projected))
+		})
+	}
+}
+
+func TestSelection_Project_InvalidJSON(t *testing.T) {
+	selection, err := fieldselection.ParseFields([]string{"name"})
+	require.NoError(t, err)
+
+	_, err = selection.Project(json.RawMessage(`not json`))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to unmarshal output for field selection")
+}
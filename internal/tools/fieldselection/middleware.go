@@ -0,0 +1,235 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package fieldselection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// FieldSelectionMiddleware projects the output of tool calls down to the attributes requested
+// in the fields argument, so that verbose PingOne resources do not consume the AI's context
+// when only a few attributes are needed.
+//
+// Projection is applied to the tool result after the tool has run, as the structured output is
+// validated against the tool's full output schema when it is produced. It applies to the structured
+// content of the result and its JSON text content, or to the JSON text content of tools without
+// structured output.
+//
+// As a projected result can omit any attribute, the output schemas of the tools are listed to MCP clients
+// without required attributes. The registered tool definitions are not changed, so the full output is still
+// validated against the full schema.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+// Only tools that declare a fields input argument are projected.
+type FieldSelectionMiddleware struct {
+	tools map[string]bool
+	// outputSchemas holds the projections of the registered output schemas, by registered output schema
+	outputSchemas map[*jsonschema.Schema]*jsonschema.Schema
+}
+
+// NewFieldSelectionMiddleware creates middleware for the tools in the list that declare a fields input argument.
+func NewFieldSelectionMiddleware(tools []types.ToolDefinition) *FieldSelectionMiddleware {
+	m := &FieldSelectionMiddleware{
+		tools:         make(map[string]bool),
+		outputSchemas: make(map[*jsonschema.Schema]*jsonschema.Schema),
+	}
+	for _, tool := range tools {
+		if SupportsFieldSelection(tool) {
+			m.tools[tool.McpTool.Name] = true
+			if outputSchema, ok := tool.McpTool.OutputSchema.(*jsonschema.Schema); ok && outputSchema != nil {
+				m.outputSchemas[outputSchema] = projectedOutputSchema(outputSchema)
+			}
+		}
+	}
+	return m
+}
+
+// SupportsFieldSelection returns true if the tool's input schema declares the fields argument.
+func SupportsFieldSelection(tool types.ToolDefinition) bool {
+	if tool.McpTool == nil {
+		return false
+	}
+	inputSchema, ok := tool.McpTool.InputSchema.(*jsonschema.Schema)
+	if !ok || inputSchema == nil {
+		return false
+	}
+	_, ok = inputSchema.Properties[FieldsArgument]
+	return ok
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *FieldSelectionMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method == "tools/list" {
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+			return m.listProjectedOutputSchemas(result), nil
+		}
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || !m.tools[callToolReq.Params.Name] {
+			return next(ctx, method, req)
+		}
+
+		toolName := callToolReq.Params.Name
+		fields, err := fieldsFromArguments(callToolReq.Params.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("field selection failed: %w", err)
+		}
+		if len(fields) == 0 {
+			return next(ctx, method, req)
+		}
+		selection, err := ParseFields(fields)
+		if err != nil {
+			return nil, fmt.Errorf("field selection failed: %w", err)
+		}
+
+		result, err := next(ctx, method, req)
+		if err != nil {
+			return result, err
+		}
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callToolResult == nil || callToolResult.IsError {
+			return result, err
+		}
+
+		if err := projectResult(callToolResult, selection); err != nil {
+			logger.FromContext(ctx).Error("Failed to apply field selection",
+				slog.String("tool", toolName),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("field selection failed: %w", err)
+		}
+
+		logger.FromContext(ctx).Debug("Applied field selection to tool result",
+			slog.String("tool", toolName),
+			slog.Any("fields", fields))
+
+		return callToolResult, nil
+	}
+}
+
+// listProjectedOutputSchemas replaces the output schemas of the listed tools that declare a fields input argument
+// with their projected output schemas.
+func (m *FieldSelectionMiddleware) listProjectedOutputSchemas(result mcp.Result) mcp.Result {
+	listResult, ok := result.(*mcp.ListToolsResult)
+	if !ok || len(m.tools) == 0 {
+		return result
+	}
+
+	projected := *listResult
+	projected.Tools = make([]*mcp.Tool, len(listResult.Tools))
+	for i, tool := range listResult.Tools {
+		outputSchema, ok := tool.OutputSchema.(*jsonschema.Schema)
+		if !m.tools[tool.Name] || !ok || outputSchema == nil {
+			projected.Tools[i] = tool
+			continue
+		}
+		// The listed output schema differs from the registered one when an inner middleware, such as an
+		// output transformer, has replaced it
+		projectedSchema, ok := m.outputSchemas[outputSchema]
+		if !ok {
+			projectedSchema = projectedOutputSchema(outputSchema)
+		}
+		projectedTool := *tool
+		projectedTool.OutputSchema = projectedSchema
+		projected.Tools[i] = &projectedTool
+	}
+	return &projected
+}
+
+// projectedOutputSchema returns a copy of an output schema without required attributes at any level, which
+// validates the results of any field selection.
+func projectedOutputSchema(outputSchema *jsonschema.Schema) *jsonschema.Schema {
+	projected := outputSchema.CloneSchemas()
+	removeRequired(projected)
+	return projected
+}
+
+func removeRequired(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+	s.Required = nil
+	for _, child := range s.Properties {
+		removeRequired(child)
+	}
+	for _, child := range s.PatternProperties {
+		removeRequired(child)
+	}
+	for _, child := range s.Defs {
+		removeRequired(child)
+	}
+	for _, child := range s.Definitions {
+		removeRequired(child)
+	}
+	for _, children := range [][]*jsonschema.Schema{s.PrefixItems, s.AllOf, s.AnyOf, s.OneOf} {
+		for _, child := range children {
+			removeRequired(child)
+		}
+	}
+	removeRequired(s.Items)
+	removeRequired(s.AdditionalProperties)
+}
+
+// fieldsFromArguments returns the fields argument of a tool call, or nil if it is not set.
+func fieldsFromArguments(argsJSON json.RawMessage) ([]string, error) {
+	if len(argsJSON) == 0 {
+		return nil, nil
+	}
+	var args struct {
+		Fields []string `json:"fields"`
+	}
+	if err := json.Unmarshal(argsJSON, &args); err != nil {
+		return nil, fmt.Errorf("invalid fields argument: %w", err)
+	}
+	return args.Fields, nil
+}
+
+// projectResult replaces the structured content of the result, and the first text content when it holds
+// the JSON output, with the selected fields. The remaining content, such as resource links, is unchanged.
+func projectResult(result *mcp.CallToolResult, selection Selection) error {
+	var textContent *mcp.TextContent
+	if len(result.Content) > 0 {
+		textContent, _ = result.Content[0].(*mcp.TextContent)
+	}
+
+	if result.StructuredContent != nil {
+		structuredJSON, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return fmt.Errorf("failed to marshal structured content: %w", err)
+		}
+		projected, err := selection.Project(structuredJSON)
+		if err != nil {
+			return err
+		}
+		result.StructuredContent = projected
+		if textContent != nil {
+			textContent.Text = string(projected)
+		}
+		return nil
+	}
+
+	if textContent == nil || !json.Valid([]byte(textContent.Text)) {
+		// Output is not JSON, so there are no fields to select
+		return nil
+	}
+	projected, err := selection.Project(json.RawMessage(textContent.Text))
+	if err != nil {
+		return err
+	}
+	textContent.Text = string(projected)
+	return nil
+}
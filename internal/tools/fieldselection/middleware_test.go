@@ -0,0 +1,265 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package fieldselection_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/fieldselection"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type selectableToolInput struct {
+	Fields []string `json:"fields,omitempty"`
+}
+
+type nonSelectableToolInput struct {
+	Name string `json:"name"`
+}
+
+type testThing struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type testToolOutput struct {
+	Things []testThing `json:"things"`
+}
+
+var testThings = []testThing{
+	{Id: "thing-1", Name: "First", Description: "The first thing"},
+	{Id: "thing-2", Name: "Second", Description: "The second thing"},
+}
+
+var testToolDefs = []types.ToolDefinition{
+	{
+		McpTool: &mcp.Tool{
+			Name:         "list_things",
+			InputSchema:  schema.MustGenerateSchema[selectableToolInput](),
+			OutputSchema: schema.MustGenerateSchema[testToolOutput](),
+		},
+	},
+	{
+		McpTool: &mcp.Tool{
+			Name:        "get_thing_text",
+			InputSchema: schema.MustGenerateSchema[selectableToolInput](),
+		},
+	},
+	{
+		McpTool: &mcp.Tool{
+			Name:         "create_thing",
+			InputSchema:  schema.MustGenerateSchema[nonSelectableToolInput](),
+			OutputSchema: schema.MustGenerateSchema[testToolOutput](),
+		},
+	},
+}
+
+func newThingsServer(t *testing.T) *mcp.Server {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(fieldselection.NewFieldSelectionMiddleware(testToolDefs).Handler)
+
+	mcp.AddTool(server, testToolDefs[0].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input selectableToolInput) (*mcp.CallToolResult, *testToolOutput, error) {
+		return nil, &testToolOutput{Things: testThings}, nil
+	})
+	mcp.AddTool(server, testToolDefs[1].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input selectableToolInput) (*mcp.CallToolResult, any, error) {
+		thingJSON, err := json.Marshal(testThings[0])
+		require.NoError(t, err)
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: string(thingJSON)}},
+		}, nil, nil
+	})
+	mcp.AddTool(server, testToolDefs[2].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, *testToolOutput, error) {
+		return nil, &testToolOutput{Things: testThings}, nil
+	})
+	return server
+}
+
+func TestSupportsFieldSelection(t *testing.T) {
+	assert.True(t, fieldselection.SupportsFieldSelection(testToolDefs[0]))
+	assert.True(t, fieldselection.SupportsFieldSelection(testToolDefs[1]))
+	assert.False(t, fieldselection.SupportsFieldSelection(testToolDefs[2]))
+	assert.False(t, fieldselection.SupportsFieldSelection(types.ToolDefinition{}))
+}
+
+func TestFieldSelectionMiddleware_OverMcp(t *testing.T) {
+	tests := []struct {
+		name               string
+		toolName           string
+		input              any
+		expectedText       string
+		expectedStructured string
+	}{
+		{
+			name:               "Structured output is projected",
+			toolName:           "list_things",
+			input:              selectableToolInput{Fields: []string{"things.id", "things.name"}},
+			expectedText:       `{"things": [{"id": "thing-1", "name": "First"}, {"id": "thing-2", "name": "Second"}]}`,
+			expectedStructured: `{"things": [{"id": "thing-1", "name": "First"}, {"id": "thing-2", "name": "Second"}]}`,
+		},
+		{
+			name:               "No fields returns the full output",
+			toolName:           "list_things",
+			input:              selectableToolInput{},
+			expectedText:       `{"things": [{"id": "thing-1", "name": "First", "description": "The first thing"}, {"id": "thing-2", "name": "Second", "description": "The second thing"}]}`,
+			expectedStructured: `{"things": [{"id": "thing-1", "name": "First", "description": "The first thing"}, {"id": "thing-2", "name": "Second", "description": "The second thing"}]}`,
+		},
+		{
+			name:         "Text output is projected",
+			toolName:     "get_thing_text",
+			input:        selectableToolInput{Fields: []string{"name"}},
+			expectedText: `{"name": "First"}`,
+		},
+		{
+			name:               "Tool without fields argument is unchanged",
+			toolName:           "create_thing",
+			input:              map[string]any{"name": "Third", "fields": []string{"things.id"}},
+			expectedText:       `{"things": [{"id": "thing-1", "name": "First", "description": "The first thing"}, {"id": "thing-2", "name": "Second", "description": "The second thing"}]}`,
+			expectedStructured: `{"things": [{"id": "thing-1", "name": "First", "description": "The first thing"}, {"id": "thing-2", "name": "Second", "description": "The second thing"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newThingsServer(t)
+
+			output, err := mcptestutils.CallToolOverMcp(t, server, tt.toolName, tt.input)
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			assert.False(t, output.IsError)
+
+			require.NotEmpty(t, output.Content)
+			textContent, ok := output.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "Expected text content")
+			assert.JSONEq(t, tt.expectedText, textContent.Text)
+
+			if tt.expectedStructured == "" {
+				assert.Nil(t, output.StructuredContent)
+				return
+			}
+			structuredJSON, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedStructured, string(structuredJSON))
+		})
+	}
+}
+
+func TestFieldSelectionMiddleware_InvalidFields(t *testing.T) {
+	middleware := fieldselection.NewFieldSelectionMiddleware(testToolDefs)
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "list_things",
+			Arguments: []byte(`{"fields": ["things..id"]}`),
+		},
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field selection failed")
+	assert.False(t, nextCalled)
+}
+
+func TestFieldSelectionMiddleware_ToolErrorUnchanged(t *testing.T) {
+	middleware := fieldselection.NewFieldSelectionMiddleware(testToolDefs)
+
+	errorResult := &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: `{"error": "not found"}`}},
+	}
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return errorResult, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "list_things",
+			Arguments: []byte(`{"fields": ["things.id"]}`),
+		},
+	}
+
+	result, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	require.NoError(t, err)
+	assert.Equal(t, errorResult, result)
+	assert.Equal(t, `{"error": "not found"}`, errorResult.Content[0].(*mcp.TextContent).Text)
+}
+
+func TestFieldSelectionMiddleware_NonToolCall(t *testing.T) {
+	middleware := fieldselection.NewFieldSelectionMiddleware(testToolDefs)
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "initialize", &mcp.InitializeRequest{})
+
+	assert.NoError(t, err)
+	assert.True(t, nextCalled)
+}
+
+func TestFieldSelectionMiddleware_ProjectedResultMatchesListedOutputSchema(t *testing.T) {
+	server := newThingsServer(t)
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	listResult, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	outputSchemas := map[string]*jsonschema.Schema{}
+	for _, tool := range listResult.Tools {
+		if tool.OutputSchema == nil {
+			continue
+		}
+		schemaJSON, err := json.Marshal(tool.OutputSchema)
+		require.NoError(t, err)
+		outputSchema := &jsonschema.Schema{}
+		require.NoError(t, json.Unmarshal(schemaJSON, outputSchema))
+		outputSchemas[tool.Name] = outputSchema
+	}
+	require.Contains(t, outputSchemas, "list_things")
+	require.Contains(t, outputSchemas, "create_thing")
+	assert.NotEmpty(t, outputSchemas["create_thing"].Required, "Tools without a fields argument should keep their output schema")
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "list_things",
+		Arguments: selectableToolInput{Fields: []string{"things.name"}},
+	})
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	structuredJSON, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var structured map[string]any
+	require.NoError(t, json.Unmarshal(structuredJSON, &structured))
+
+	listedSchema, err := outputSchemas["list_things"].Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, listedSchema.Validate(structured), "The projected result should match the listed output schema")
+
+	fullSchema, err := testToolDefs[0].McpTool.OutputSchema.(*jsonschema.Schema).Resolve(nil)
+	require.NoError(t, err)
+	assert.Error(t, fullSchema.Validate(structured), "The projected result omits attributes the full output schema requires")
+}
@@ -0,0 +1,31 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type GroupsClient interface {
+	CreateGroup(ctx context.Context, environmentId uuid.UUID, createRequest management.Group) (*management.Group, *http.Response, error)
+	GetGroup(ctx context.Context, environmentId uuid.UUID, groupId string) (*management.Group, *http.Response, error)
+	UpdateGroup(ctx context.Context, environmentId uuid.UUID, groupId string, updateRequest management.Group) (*management.Group, *http.Response, error)
+	// GetMatchingUsers reads the first page of the users matching the SCIM filter, whose count is the number of
+	// users matching in the whole environment
+	GetMatchingUsers(ctx context.Context, environmentId uuid.UUID, filter string, limit int32) (*management.EntityArray, *http.Response, error)
+	// GetGroupMemberships lists the groups the group is directly nested in
+	GetGroupMemberships(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error)
+	// AddGroupToGroup nests the group in the parent group, so that the members of the group are also members of the parent group
+	AddGroupToGroup(ctx context.Context, environmentId uuid.UUID, groupId string, parentGroupId string) (*management.GroupNesting, *http.Response, error)
+	RemoveGroupFromGroup(ctx context.Context, environmentId uuid.UUID, groupId string, parentGroupId string) (*http.Response, error)
+	// GetUserGroupMemberships lists the groups the user is a member of, as reported by PingOne
+	GetUserGroupMemberships(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error)
+}
+
+type GroupsClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (GroupsClient, error)
+}
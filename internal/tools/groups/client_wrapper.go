@@ -0,0 +1,162 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ GroupsClient = &PingOneClientGroupsWrapper{}
+var _ GroupsClientFactory = &PingOneClientGroupsWrapperFactory{}
+
+type PingOneClientGroupsWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientGroupsWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientGroupsWrapper(client *pingone.Client) *PingOneClientGroupsWrapper {
+	return &PingOneClientGroupsWrapper{client: client}
+}
+
+func NewPingOneClientGroupsWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientGroupsWrapperFactory {
+	return &PingOneClientGroupsWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientGroupsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (GroupsClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientGroupsWrapper(client), nil
+}
+
+func (p *PingOneClientGroupsWrapper) CreateGroup(ctx context.Context, environmentId uuid.UUID, createRequest management.Group) (*management.Group, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.GroupsApi.CreateGroup(ctx, environmentId.String()).Group(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create group",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientGroupsWrapper) GetGroup(ctx context.Context, environmentId uuid.UUID, groupId string) (*management.Group, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupsApi.ReadOneGroup(ctx, environmentId.String(), groupId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve group",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientGroupsWrapper) UpdateGroup(ctx context.Context, environmentId uuid.UUID, groupId string, updateRequest management.Group) (*management.Group, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.GroupsApi.UpdateGroup(ctx, environmentId.String(), groupId).Group(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update group",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientGroupsWrapper) GetMatchingUsers(ctx context.Context, environmentId uuid.UUID, filter string, limit int32) (*management.EntityArray, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UsersApi.ReadAllUsers(ctx, environmentId.String()).Filter(filter).Limit(limit)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve users matching group filter",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.ExecuteInitialPage()
+}
+
+func (p *PingOneClientGroupsWrapper) GetGroupMemberships(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupsApi.ReadGroupNesting(ctx, environmentId.String(), groupId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve group memberships of group",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientGroupsWrapper) AddGroupToGroup(ctx context.Context, environmentId uuid.UUID, groupId string, parentGroupId string) (*management.GroupNesting, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.GroupsApi.CreateGroupNesting(ctx, environmentId.String(), groupId).GroupNesting(management.GroupNesting{Id: parentGroupId})
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to add group to group",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+		slog.String("parentGroupId", parentGroupId),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientGroupsWrapper) RemoveGroupFromGroup(ctx context.Context, environmentId uuid.UUID, groupId string, parentGroupId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.GroupsApi.DeleteGroupNesting(ctx, environmentId.String(), groupId, parentGroupId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to remove group from group",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+		slog.String("parentGroupId", parentGroupId),
+	)
+	return deleteRequest.Execute()
+}
+
+func (p *PingOneClientGroupsWrapper) GetUserGroupMemberships(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupMembershipApi.ReadAllGroupMembershipsForUser(ctx, environmentId.String(), userId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve group memberships of user",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return getRequest.Execute(), nil
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "groups"
+
+var _ collections.LegacySdkCollection = &GroupsCollection{}
+
+type GroupsCollection struct{}
+
+func (c *GroupsCollection) Name() string {
+	return CollectionName
+}
+
+func (c *GroupsCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	groupsClientFactory := NewPingOneClientGroupsWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListGroupMembershipsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListGroupMembershipsDef.McpTool.Name))
+		mcp.AddTool(server, ListGroupMembershipsDef.McpTool, ListGroupMembershipsHandler(groupsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListUserGroupsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListUserGroupsDef.McpTool.Name))
+		mcp.AddTool(server, ListUserGroupsDef.McpTool, ListUserGroupsHandler(groupsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&PreviewGroupMembershipDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", PreviewGroupMembershipDef.McpTool.Name))
+		mcp.AddTool(server, PreviewGroupMembershipDef.McpTool, PreviewGroupMembershipHandler(groupsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateGroupDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateGroupDef.McpTool.Name))
+		mcp.AddTool(server, CreateGroupDef.McpTool, CreateGroupHandler(groupsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateGroupDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateGroupDef.McpTool.Name))
+		mcp.AddTool(server, UpdateGroupDef.McpTool, UpdateGroupHandler(groupsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&AddGroupToGroupDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", AddGroupToGroupDef.McpTool.Name))
+		mcp.AddTool(server, AddGroupToGroupDef.McpTool, AddGroupToGroupHandler(groupsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&RemoveGroupFromGroupDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", RemoveGroupFromGroupDef.McpTool.Name))
+		mcp.AddTool(server, RemoveGroupFromGroupDef.McpTool, RemoveGroupFromGroupHandler(groupsClientFactory))
+	}
+
+	return nil
+}
+
+func (c *GroupsCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListGroupMembershipsDef,
+		ListUserGroupsDef,
+		PreviewGroupMembershipDef,
+		CreateGroupDef,
+		UpdateGroupDef,
+		AddGroupToGroupDef,
+		RemoveGroupFromGroupDef,
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+type GroupMembershipSummary struct {
+	Id     string   `json:"id" jsonschema:"The UUID of the group"`
+	Name   *string  `json:"name,omitempty" jsonschema:"The name of the group, if PingOne reported it"`
+	Direct bool     `json:"direct" jsonschema:"True if the user or group is a member of the group itself, false if the membership is inherited through nested groups"`
+	Via    []string `json:"via,omitempty" jsonschema:"For inherited memberships, the UUIDs of the groups the membership is inherited through, starting with the group joined directly. Not set if PingOne reported the inherited membership without the groups it is inherited through."`
+}
+
+type ListGroupMembershipsOutput struct {
+	Groups []GroupMembershipSummary `json:"groups" jsonschema:"The groups the user or group is a member of, direct memberships first, empty if none"`
+}
+
+// directMemberships returns the direct memberships of the pages, and the memberships PingOne reported as inherited
+// separately
+func directMemberships(ctx context.Context, iterator management.EntityArrayPagedIterator) ([]GroupMembershipSummary, []GroupMembershipSummary, error) {
+	direct := []GroupMembershipSummary{}
+	indirect := []GroupMembershipSummary{}
+	err := collections.ForEachPage(ctx, iterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, membership := range embedded.GroupMemberships {
+			summary := GroupMembershipSummary{Id: membership.Id, Name: membership.Name, Direct: true}
+			if membership.Type != nil && *membership.Type == management.ENUMGROUPMEMBERSHIPTYPE_INDIRECT {
+				summary.Direct = false
+				indirect = append(indirect, summary)
+				continue
+			}
+			direct = append(direct, summary)
+		}
+		return true
+	})
+	return direct, indirect, err
+}
+
+// transitiveMemberships follows the nesting of the directly joined groups to the groups whose membership they
+// inherit, breadth first so that each group is reached through the shortest chain of nested groups. Inherited
+// memberships that PingOne reported but that were not reached are added without the groups they are inherited through.
+func transitiveMemberships(ctx context.Context, toolName string, client GroupsClient, environmentId uuid.UUID, direct []GroupMembershipSummary, reported []GroupMembershipSummary) ([]GroupMembershipSummary, error) {
+	memberships := append([]GroupMembershipSummary{}, direct...)
+	seen := map[string]bool{}
+	for _, membership := range direct {
+		seen[membership.Id] = true
+	}
+
+	for i := 0; i < len(memberships); i++ {
+		group := memberships[i]
+		iterator, err := client.GetGroupMemberships(ctx, environmentId, group.Id)
+		if err != nil {
+			return nil, errs.NewToolError(toolName, err)
+		}
+		parents, reportedParents, err := directMemberships(ctx, iterator)
+		if err != nil {
+			return nil, err
+		}
+		reported = append(reported, reportedParents...)
+		for _, parent := range parents {
+			if seen[parent.Id] {
+				continue
+			}
+			seen[parent.Id] = true
+			via := append([]string{}, group.Via...)
+			memberships = append(memberships, GroupMembershipSummary{
+				Id:   parent.Id,
+				Name: parent.Name,
+				Via:  append(via, group.Id),
+			})
+		}
+	}
+
+	for _, membership := range reported {
+		if !seen[membership.Id] {
+			seen[membership.Id] = true
+			memberships = append(memberships, membership)
+		}
+	}
+	return memberships, nil
+}
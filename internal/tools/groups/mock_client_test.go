@@ -0,0 +1,118 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ groups.GroupsClient = &mockPingOneClientGroupsWrapper{}
+var _ groups.GroupsClientFactory = &mockPingOneClientGroupsWrapperFactory{}
+
+type mockPingOneClientGroupsWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientGroupsWrapperFactory struct {
+	mockClient groups.GroupsClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientGroupsWrapperFactory(mockClient groups.GroupsClient, err error) *mockPingOneClientGroupsWrapperFactory {
+	return &mockPingOneClientGroupsWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientGroupsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (groups.GroupsClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientGroupsWrapper) CreateGroup(ctx context.Context, environmentId uuid.UUID, createRequest management.Group) (*management.Group, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return groupResponse("CreateGroup", args)
+}
+
+func (p *mockPingOneClientGroupsWrapper) GetGroup(ctx context.Context, environmentId uuid.UUID, groupId string) (*management.Group, *http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId)
+	return groupResponse("GetGroup", args)
+}
+
+func (p *mockPingOneClientGroupsWrapper) UpdateGroup(ctx context.Context, environmentId uuid.UUID, groupId string, updateRequest management.Group) (*management.Group, *http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId, updateRequest)
+	return groupResponse("UpdateGroup", args)
+}
+
+func (p *mockPingOneClientGroupsWrapper) GetMatchingUsers(ctx context.Context, environmentId uuid.UUID, filter string, limit int32) (*management.EntityArray, *http.Response, error) {
+	args := p.Called(ctx, environmentId, filter, limit)
+	response, ok := args.Get(0).(*management.EntityArray)
+	if !ok && args.Get(0) != nil {
+		panic("GetMatchingUsers mock setup error: expected *management.EntityArray or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetMatchingUsers mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientGroupsWrapper) GetGroupMemberships(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, groupId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientGroupsWrapper) AddGroupToGroup(ctx context.Context, environmentId uuid.UUID, groupId string, parentGroupId string) (*management.GroupNesting, *http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId, parentGroupId)
+	response, ok := args.Get(0).(*management.GroupNesting)
+	if !ok && args.Get(0) != nil {
+		panic("AddGroupToGroup mock setup error: expected *management.GroupNesting or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("AddGroupToGroup mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientGroupsWrapper) RemoveGroupFromGroup(ctx context.Context, environmentId uuid.UUID, groupId string, parentGroupId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId, parentGroupId)
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("RemoveGroupFromGroup mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func (p *mockPingOneClientGroupsWrapper) GetUserGroupMemberships(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, userId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+// groupResponse returns the group, HTTP response and error the mocked method was set up with
+func groupResponse(method string, args mock.Arguments) (*management.Group, *http.Response, error) {
+	response, ok := args.Get(0).(*management.Group)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *management.Group or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
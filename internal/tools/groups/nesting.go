@@ -0,0 +1,59 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+)
+
+type GroupNestingInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the groups."`
+	GroupId       uuid.UUID `json:"groupId" jsonschema:"REQUIRED. UUID of the nested group, whose members inherit the membership of the parent group."`
+	ParentGroupId uuid.UUID `json:"parentGroupId" jsonschema:"REQUIRED. UUID of the parent group the group is nested in."`
+}
+
+type GroupNestingOutput struct {
+	GroupId       string `json:"groupId" jsonschema:"The UUID of the nested group"`
+	ParentGroupId string `json:"parentGroupId" jsonschema:"The UUID of the parent group"`
+	Nested        bool   `json:"nested" jsonschema:"True if the group is now nested in the parent group, false if it was removed from it"`
+}
+
+// validateGroupNesting rejects nesting a group in itself, which PingOne would reject with a less helpful error
+func validateGroupNesting(input GroupNestingInput) error {
+	if input.GroupId == input.ParentGroupId {
+		return errors.New("groupId and parentGroupId must be different groups")
+	}
+	return nil
+}
+
+// undoGroupNesting returns the function that reverts a change to the nesting of a group, by removing the group
+// from the parent group if it was added, or adding it again if it was removed. A nesting has no attributes that
+// could have changed since, so there is nothing to check first.
+func undoGroupNesting(groupsClientFactory GroupsClientFactory, environmentId uuid.UUID, groupId string, parentGroupId string, added bool) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+		if added {
+			httpResponse, err := client.RemoveGroupFromGroup(ctx, environmentId, groupId, parentGroupId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			return nil
+		}
+		_, httpResponse, err := client.AddGroupToGroup(ctx, environmentId, groupId, parentGroupId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testUserId        = uuid.MustParse("550e8400-e29b-41d4-a716-446655440200")
+	// The groups are nested as: engineering in staff, staff in everyone
+	testEngineeringGroupId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440301")
+	testStaffGroupId       = uuid.MustParse("550e8400-e29b-41d4-a716-446655440302")
+	testEveryoneGroupId    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440303")
+)
+
+func membership(groupId uuid.UUID, name string, membershipType management.EnumGroupMembershipType) management.GroupMembership {
+	return management.GroupMembership{
+		Id:   groupId.String(),
+		Name: testutils.Pointer(name),
+		Type: membershipType.Ptr(),
+	}
+}
+
+func membershipsPages(memberships ...management.GroupMembership) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{GroupMemberships: memberships}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func errorPages(err error) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{HTTPResponse: &http.Response{StatusCode: 403}, Error: err},
+	})
+}
+
+const testUserFilter = `department eq "Engineering"`
+
+func matchingUsersPage(count int, users ...management.User) *management.EntityArray {
+	return &management.EntityArray{
+		Count:    testutils.Pointer(float32(count)),
+		Embedded: &management.EntityArrayEmbedded{Users: users},
+	}
+}
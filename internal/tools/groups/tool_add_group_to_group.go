@@ -0,0 +1,84 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var AddGroupToGroupDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "add_group_to_group",
+		Title: "Add PingOne Group to Group",
+		Description: `Nest a group in a parent group, so that the members of the group also become members of the parent group, and are granted the roles and access of the parent group. Use list_group_memberships to check the groups the group is already nested in.
+
+The nesting can be removed with remove_group_from_group, or with undo_last_change.`,
+		InputSchema:  schema.MustGenerateSchema[GroupNestingInput](),
+		OutputSchema: schema.MustGenerateSchema[GroupNestingOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+// AddGroupToGroupHandler nests a PingOne group in another group using the provided client
+func AddGroupToGroupHandler(groupsClientFactory GroupsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GroupNestingInput,
+) (
+	*mcp.CallToolResult,
+	*GroupNestingOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GroupNestingInput) (*mcp.CallToolResult, *GroupNestingOutput, error) {
+		if err := validateGroupNesting(input); err != nil {
+			toolErr := errs.NewToolError(AddGroupToGroupDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(AddGroupToGroupDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Adding group to group",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("groupId", input.GroupId.String()),
+			slog.String("parentGroupId", input.ParentGroupId.String()))
+
+		_, httpResponse, err := client.AddGroupToGroup(ctx, input.EnvironmentId, input.GroupId.String(), input.ParentGroupId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          AddGroupToGroupDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "groupNesting",
+			ResourceId:    input.GroupId.String(),
+			Description:   fmt.Sprintf("Remove group %s from group %s", input.GroupId, input.ParentGroupId),
+		}, undoGroupNesting(groupsClientFactory, input.EnvironmentId, input.GroupId.String(), input.ParentGroupId.String(), true))
+
+		return nil, &GroupNestingOutput{
+			GroupId:       input.GroupId.String(),
+			ParentGroupId: input.ParentGroupId.String(),
+			Nested:        true,
+		}, nil
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddGroupToGroupHandler(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("AddGroupToGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), testStaffGroupId.String()).Return(&management.GroupNesting{Id: testStaffGroupId.String()}, &http.Response{StatusCode: 201}, nil)
+
+	handler := groups.AddGroupToGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.GroupNestingInput{
+		EnvironmentId: testEnvironmentId,
+		GroupId:       testEngineeringGroupId,
+		ParentGroupId: testStaffGroupId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, &groups.GroupNestingOutput{
+		GroupId:       testEngineeringGroupId.String(),
+		ParentGroupId: testStaffGroupId.String(),
+		Nested:        true,
+	}, output)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAddGroupToGroupHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           groups.GroupNestingInput
+		factoryErr      error
+		setupMock       func(mockClient *mockPingOneClientGroupsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Same group",
+			input:           groups.GroupNestingInput{EnvironmentId: testEnvironmentId, GroupId: testStaffGroupId, ParentGroupId: testStaffGroupId},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "must be different groups",
+		},
+		{
+			name:            "Client error",
+			input:           groups.GroupNestingInput{EnvironmentId: testEnvironmentId, GroupId: testEngineeringGroupId, ParentGroupId: testStaffGroupId},
+			factoryErr:      errors.New("authentication failed"),
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "authentication failed",
+		},
+		{
+			name:  "API error",
+			input: groups.GroupNestingInput{EnvironmentId: testEnvironmentId, GroupId: testEngineeringGroupId, ParentGroupId: testStaffGroupId},
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("AddGroupToGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), testStaffGroupId.String()).Return(nil, &http.Response{StatusCode: 400}, errors.New("invalid nesting"))
+			},
+			wantErrContains: "invalid nesting",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := groups.AddGroupToGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, tt.factoryErr))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAddGroupToGroupHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("AddGroupToGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), testStaffGroupId.String()).Return(&management.GroupNesting{Id: testStaffGroupId.String()}, &http.Response{StatusCode: 201}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := groups.AddGroupToGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, groups.GroupNestingInput{
+		EnvironmentId: testEnvironmentId,
+		GroupId:       testEngineeringGroupId,
+		ParentGroupId: testStaffGroupId,
+	})
+	require.NoError(t, err)
+
+	changes := journal.Changes(testEnvironmentId.String())
+	require.Len(t, changes, 1)
+	assert.Equal(t, "groupNesting", changes[0].ResourceType)
+	assert.Equal(t, testEngineeringGroupId.String(), changes[0].ResourceId)
+
+	// Undoing the nesting removes the group from the parent group
+	mockClient.On("RemoveGroupFromGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), testStaffGroupId.String()).Return(&http.Response{StatusCode: 204}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
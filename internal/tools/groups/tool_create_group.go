@@ -0,0 +1,121 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateGroupDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "create_group",
+		Title: "Create PingOne Group",
+		Description: `Create a group in an environment, or in a population of the environment. Only 'name' and 'environmentId' are required.
+
+Set 'userFilter' to create a dynamic group, whose members are the users matching a SCIM filter. The number of users matching the filter is returned with the group. Use preview_group_membership first to check which users a filter matches.`,
+		InputSchema:  schema.MustGenerateSchema[CreateGroupInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateGroupOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateGroupInput struct {
+	EnvironmentId uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Name          string     `json:"name" jsonschema:"REQUIRED. Group name."`
+	Description   *string    `json:"description,omitempty" jsonschema:"OPTIONAL. Description."`
+	PopulationId  *uuid.UUID `json:"populationId,omitempty" jsonschema:"OPTIONAL. UUID of the population the group belongs to. The group belongs to the environment if omitted."`
+	ExternalId    *string    `json:"externalId,omitempty" jsonschema:"OPTIONAL. Identifier of the group in an external system."`
+	UserFilter    *string    `json:"userFilter,omitempty" jsonschema:"OPTIONAL. A SCIM filter on user attributes. Users matching the filter are dynamically added to the group."`
+}
+
+type CreateGroupOutput struct {
+	Group             management.Group `json:"group" jsonschema:"The created group details including ID, name, and user filter"`
+	MatchingUserCount *int             `json:"matchingUserCount,omitempty" jsonschema:"For dynamic groups, the number of users in the environment matching the user filter when the group was created. Not set if the users could not be counted."`
+}
+
+// CreateGroupHandler creates a new PingOne group using the provided client
+func CreateGroupHandler(groupsClientFactory GroupsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateGroupInput,
+) (
+	*mcp.CallToolResult,
+	*CreateGroupOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateGroupInput) (*mcp.CallToolResult, *CreateGroupOutput, error) {
+		if input.UserFilter != nil {
+			if err := validateUserFilter(*input.UserFilter); err != nil {
+				toolErr := errs.NewToolError(CreateGroupDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateGroupDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating group",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("name", input.Name),
+		)
+
+		createRequest := management.Group{
+			Name:        input.Name,
+			Description: input.Description,
+			ExternalId:  input.ExternalId,
+			UserFilter:  input.UserFilter,
+		}
+		if input.PopulationId != nil {
+			createRequest.Population = &management.GroupPopulation{Id: input.PopulationId.String()}
+		}
+
+		groupResponse, httpResponse, err := client.CreateGroup(ctx, input.EnvironmentId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		if groupResponse == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no group data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		logger.FromContext(ctx).Debug("Group created successfully",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("groupId", groupResponse.GetId()),
+			slog.String("name", groupResponse.Name))
+
+		// Filter out _links field from response
+		groupResponse.Links = nil
+
+		result := &CreateGroupOutput{
+			Group: *groupResponse,
+		}
+		if input.UserFilter != nil {
+			result.MatchingUserCount = matchingUserCount(ctx, client, input.EnvironmentId, *input.UserFilter)
+		}
+
+		return nil, result, nil
+	}
+}
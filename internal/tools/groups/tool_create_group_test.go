@@ -0,0 +1,138 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateGroupHandler(t *testing.T) {
+	populationId := uuid.MustParse("550e8400-e29b-41d4-a716-446655440100")
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("CreateGroup", mock.Anything, testEnvironmentId, management.Group{
+		Name:        "Engineering",
+		Description: testutils.Pointer("All engineers"),
+		Population:  &management.GroupPopulation{Id: populationId.String()},
+		UserFilter:  testutils.Pointer(testUserFilter),
+	}).Return(&management.Group{
+		Id:         testutils.Pointer(testEngineeringGroupId.String()),
+		Name:       "Engineering",
+		UserFilter: testutils.Pointer(testUserFilter),
+		Links:      &map[string]management.LinksHATEOASValue{},
+	}, &http.Response{StatusCode: 201}, nil)
+	mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, testUserFilter, int32(1)).Return(matchingUsersPage(42), &http.Response{StatusCode: 200}, nil)
+
+	handler := groups.CreateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.CreateGroupInput{
+		EnvironmentId: testEnvironmentId,
+		Name:          "Engineering",
+		Description:   testutils.Pointer("All engineers"),
+		PopulationId:  &populationId,
+		UserFilter:    testutils.Pointer(testUserFilter),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testEngineeringGroupId.String(), output.Group.GetId())
+	assert.Nil(t, output.Group.Links, "links are removed from the response")
+	assert.Equal(t, testutils.Pointer(42), output.MatchingUserCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateGroupHandler_StaticGroup(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("CreateGroup", mock.Anything, testEnvironmentId, management.Group{Name: "Staff"}).Return(&management.Group{
+		Id:   testutils.Pointer(testStaffGroupId.String()),
+		Name: "Staff",
+	}, &http.Response{StatusCode: 201}, nil)
+
+	handler := groups.CreateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.CreateGroupInput{
+		EnvironmentId: testEnvironmentId,
+		Name:          "Staff",
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Nil(t, output.MatchingUserCount, "users are only counted for dynamic groups")
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateGroupHandler_CountError(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("CreateGroup", mock.Anything, testEnvironmentId, mock.Anything).Return(&management.Group{
+		Id:   testutils.Pointer(testEngineeringGroupId.String()),
+		Name: "Engineering",
+	}, &http.Response{StatusCode: 201}, nil)
+	mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, testUserFilter, int32(1)).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+
+	handler := groups.CreateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.CreateGroupInput{
+		EnvironmentId: testEnvironmentId,
+		Name:          "Engineering",
+		UserFilter:    testutils.Pointer(testUserFilter),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Nil(t, output.MatchingUserCount, "the group is created even if its users cannot be counted")
+}
+
+func TestCreateGroupHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           groups.CreateGroupInput
+		setupMock       func(mockClient *mockPingOneClientGroupsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Invalid filter",
+			input:           groups.CreateGroupInput{EnvironmentId: testEnvironmentId, Name: "Engineering", UserFilter: testutils.Pointer(`department eq`)},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "invalid SCIM filter",
+		},
+		{
+			name:            "Empty filter",
+			input:           groups.CreateGroupInput{EnvironmentId: testEnvironmentId, Name: "Engineering", UserFilter: testutils.Pointer("")},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "userFilter must not be empty",
+		},
+		{
+			name:  "API error",
+			input: groups.CreateGroupInput{EnvironmentId: testEnvironmentId, Name: "Engineering"},
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("CreateGroup", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("name already in use"))
+			},
+			wantErrContains: "name already in use",
+		},
+		{
+			name:  "No group in response",
+			input: groups.CreateGroupInput{EnvironmentId: testEnvironmentId, Name: "Engineering"},
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("CreateGroup", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no group data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := groups.CreateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
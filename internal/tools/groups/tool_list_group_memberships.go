@@ -0,0 +1,88 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListGroupMembershipsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "list_group_memberships",
+		Title: "List PingOne Group Memberships of Group",
+		Description: `List the groups a group is nested in, whose membership the members of the group inherit. Set 'transitive' to also follow the nesting of those groups, to list every group the members of the group inherit membership of, with the groups each membership is inherited through.
+
+Use before add_group_to_group or remove_group_from_group to check the current nesting of a group.`,
+		InputSchema:  schema.MustGenerateSchema[ListGroupMembershipsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListGroupMembershipsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListGroupMembershipsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the group."`
+	GroupId       uuid.UUID `json:"groupId" jsonschema:"REQUIRED. Group UUID."`
+	Transitive    *bool     `json:"transitive,omitempty" jsonschema:"OPTIONAL. Also list the groups whose membership is inherited through nested groups. Defaults to false."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'groups.id' and 'groups.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+// ListGroupMembershipsHandler lists the groups a PingOne group is nested in using the provided client
+func ListGroupMembershipsHandler(groupsClientFactory GroupsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListGroupMembershipsInput,
+) (
+	*mcp.CallToolResult,
+	*ListGroupMembershipsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListGroupMembershipsInput) (*mcp.CallToolResult, *ListGroupMembershipsOutput, error) {
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListGroupMembershipsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		transitive := input.Transitive != nil && *input.Transitive
+		logger.FromContext(ctx).Debug("Listing group memberships of group",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("groupId", input.GroupId.String()),
+			slog.Bool("transitive", transitive))
+
+		pagedIterator, err := client.GetGroupMemberships(ctx, input.EnvironmentId, input.GroupId.String())
+		if err != nil {
+			toolErr := errs.NewToolError(ListGroupMembershipsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		direct, reported, err := directMemberships(ctx, pagedIterator)
+		if err != nil {
+			errs.Log(ctx, err)
+			return nil, nil, err
+		}
+		if !transitive {
+			return nil, &ListGroupMembershipsOutput{Groups: direct}, nil
+		}
+
+		memberships, err := transitiveMemberships(ctx, ListGroupMembershipsDef.McpTool.Name, client, input.EnvironmentId, direct, reported)
+		if err != nil {
+			errs.Log(ctx, err)
+			return nil, nil, err
+		}
+		return nil, &ListGroupMembershipsOutput{Groups: memberships}, nil
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListGroupMembershipsHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		transitive *bool
+		want       []groups.GroupMembershipSummary
+	}{
+		{
+			name: "Direct memberships",
+			want: []groups.GroupMembershipSummary{
+				{Id: testStaffGroupId.String(), Name: testutils.Pointer("Staff"), Direct: true},
+			},
+		},
+		{
+			name:       "Transitive memberships",
+			transitive: testutils.Pointer(true),
+			want: []groups.GroupMembershipSummary{
+				{Id: testStaffGroupId.String(), Name: testutils.Pointer("Staff"), Direct: true},
+				{Id: testEveryoneGroupId.String(), Name: testutils.Pointer("Everyone"), Via: []string{testStaffGroupId.String()}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(membershipsPages(
+				membership(testStaffGroupId, "Staff", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+			), nil)
+			mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testStaffGroupId.String()).Return(membershipsPages(
+				membership(testEveryoneGroupId, "Everyone", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+			), nil).Maybe()
+			mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEveryoneGroupId.String()).Return(membershipsPages(), nil).Maybe()
+
+			handler := groups.ListGroupMembershipsHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.ListGroupMembershipsInput{
+				EnvironmentId: testEnvironmentId,
+				GroupId:       testEngineeringGroupId,
+				Transitive:    tt.transitive,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, tt.want, output.Groups)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListGroupMembershipsHandler_NoMemberships(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEveryoneGroupId.String()).Return(testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{EntityArray: &management.EntityArray{}},
+	}), nil)
+
+	handler := groups.ListGroupMembershipsHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.ListGroupMembershipsInput{
+		EnvironmentId: testEnvironmentId,
+		GroupId:       testEveryoneGroupId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Groups)
+	assert.Empty(t, output.Groups)
+}
+
+func TestListGroupMembershipsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		factoryErr      error
+		setupMock       func(mockClient *mockPingOneClientGroupsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Client error",
+			factoryErr:      errors.New("not logged in"),
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "not logged in",
+		},
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(errorPages(errors.New("group not found")), nil)
+			},
+			wantErrContains: "group not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := groups.ListGroupMembershipsHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, tt.factoryErr))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.ListGroupMembershipsInput{
+				EnvironmentId: testEnvironmentId,
+				GroupId:       testEngineeringGroupId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
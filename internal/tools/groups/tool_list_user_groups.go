@@ -0,0 +1,82 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListUserGroupsDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "list_user_groups",
+		Title: "List PingOne User Groups",
+		Description: `List every group a user belongs to, both the groups the user was added to and the groups whose membership the user inherits because those groups are nested in them, with the groups each inherited membership comes through. Use to explain why a user has access granted to a group, or before removing a user from a group.
+
+Use find_user to look up the user ID from a username or email address.`,
+		InputSchema:  schema.MustGenerateSchema[ListUserGroupsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListGroupMembershipsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListUserGroupsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the user."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. User UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'groups.id' and 'groups.direct'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+// ListUserGroupsHandler lists the groups a PingOne user belongs to, directly or through nested groups, using the provided client
+func ListUserGroupsHandler(groupsClientFactory GroupsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListUserGroupsInput,
+) (
+	*mcp.CallToolResult,
+	*ListGroupMembershipsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListUserGroupsInput) (*mcp.CallToolResult, *ListGroupMembershipsOutput, error) {
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListUserGroupsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing groups of user",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()))
+
+		pagedIterator, err := client.GetUserGroupMemberships(ctx, input.EnvironmentId, input.UserId.String())
+		if err != nil {
+			toolErr := errs.NewToolError(ListUserGroupsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		direct, reported, err := directMemberships(ctx, pagedIterator)
+		if err != nil {
+			errs.Log(ctx, err)
+			return nil, nil, err
+		}
+
+		memberships, err := transitiveMemberships(ctx, ListUserGroupsDef.McpTool.Name, client, input.EnvironmentId, direct, reported)
+		if err != nil {
+			errs.Log(ctx, err)
+			return nil, nil, err
+		}
+
+		logger.FromContext(ctx).Debug("Listed groups of user", slog.Int("count", len(memberships)))
+
+		return nil, &ListGroupMembershipsOutput{Groups: memberships}, nil
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListUserGroupsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetUserGroupMemberships", mock.Anything, testEnvironmentId, testUserId.String()).Return(membershipsPages(
+		membership(testEngineeringGroupId, "Engineering", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+		membership(testStaffGroupId, "Staff", management.ENUMGROUPMEMBERSHIPTYPE_INDIRECT),
+	), nil)
+	mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(membershipsPages(
+		membership(testStaffGroupId, "Staff", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+	), nil)
+	mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testStaffGroupId.String()).Return(membershipsPages(
+		membership(testEveryoneGroupId, "Everyone", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+	), nil)
+	mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEveryoneGroupId.String()).Return(membershipsPages(), nil)
+
+	handler := groups.ListUserGroupsHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.ListUserGroupsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []groups.GroupMembershipSummary{
+		{Id: testEngineeringGroupId.String(), Name: testutils.Pointer("Engineering"), Direct: true},
+		{Id: testStaffGroupId.String(), Name: testutils.Pointer("Staff"), Via: []string{testEngineeringGroupId.String()}},
+		{Id: testEveryoneGroupId.String(), Name: testutils.Pointer("Everyone"), Via: []string{testEngineeringGroupId.String(), testStaffGroupId.String()}},
+	}, output.Groups)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListUserGroupsHandler_ReportedIndirectMembershipNotReached(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetUserGroupMemberships", mock.Anything, testEnvironmentId, testUserId.String()).Return(membershipsPages(
+		membership(testEveryoneGroupId, "Everyone", management.ENUMGROUPMEMBERSHIPTYPE_INDIRECT),
+	), nil)
+
+	handler := groups.ListUserGroupsHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.ListUserGroupsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []groups.GroupMembershipSummary{
+		{Id: testEveryoneGroupId.String(), Name: testutils.Pointer("Everyone")},
+	}, output.Groups)
+}
+
+func TestListUserGroupsHandler_NestingCycle(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetUserGroupMemberships", mock.Anything, testEnvironmentId, testUserId.String()).Return(membershipsPages(
+		membership(testEngineeringGroupId, "Engineering", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+	), nil)
+	mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(membershipsPages(
+		membership(testStaffGroupId, "Staff", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+	), nil)
+	mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testStaffGroupId.String()).Return(membershipsPages(
+		membership(testEngineeringGroupId, "Engineering", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+	), nil)
+
+	handler := groups.ListUserGroupsHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.ListUserGroupsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, output.Groups, 2, "each group is listed once")
+	mockClient.AssertNumberOfCalls(t, "GetGroupMemberships", 2)
+}
+
+func TestListUserGroupsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientGroupsWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "User memberships error",
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("GetUserGroupMemberships", mock.Anything, testEnvironmentId, testUserId.String()).Return(errorPages(errors.New("user not found")), nil)
+			},
+			wantErrContains: "user not found",
+		},
+		{
+			name: "Group memberships error",
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("GetUserGroupMemberships", mock.Anything, testEnvironmentId, testUserId.String()).Return(membershipsPages(
+					membership(testEngineeringGroupId, "Engineering", management.ENUMGROUPMEMBERSHIPTYPE_DIRECT),
+				), nil)
+				mockClient.On("GetGroupMemberships", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := groups.ListUserGroupsHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.ListUserGroupsInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	defaultPreviewLimit = 20
+	maxPreviewLimit     = 100
+)
+
+var PreviewGroupMembershipDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "preview_group_membership",
+		Title: "Preview PingOne Dynamic Group Membership",
+		Description: `Preview the users a dynamic group filter matches before saving it with create_group or update_group. Returns the number of matching users in the environment and the first matching users. Nothing is changed.
+
+Filter examples:
+- department eq "Engineering"
+- population.id eq "<population UUID>" and title sw "Manager"`,
+		InputSchema:  schema.MustGenerateSchema[PreviewGroupMembershipInput](),
+		OutputSchema: schema.MustGenerateSchema[PreviewGroupMembershipOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type PreviewGroupMembershipInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserFilter    string    `json:"userFilter" jsonschema:"REQUIRED. The candidate SCIM filter on user attributes."`
+	Limit         *int      `json:"limit,omitempty" jsonschema:"OPTIONAL. The maximum number of matching users to return, from 1 to 100. Defaults to 20."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'matchingUserCount' and 'users.username'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type MatchingUser struct {
+	Id           string  `json:"id" jsonschema:"The UUID of the user"`
+	Username     string  `json:"username,omitempty" jsonschema:"The username of the user"`
+	Email        string  `json:"email,omitempty" jsonschema:"The email address of the user"`
+	GivenName    *string `json:"givenName,omitempty" jsonschema:"The given (first) name of the user"`
+	FamilyName   *string `json:"familyName,omitempty" jsonschema:"The family (last) name of the user"`
+	PopulationId *string `json:"populationId,omitempty" jsonschema:"The UUID of the population the user belongs to"`
+}
+
+type PreviewGroupMembershipOutput struct {
+	UserFilter        string         `json:"userFilter" jsonschema:"The SCIM filter the users were matched with"`
+	MatchingUserCount int            `json:"matchingUserCount" jsonschema:"The number of users in the environment matching the filter"`
+	Users             []MatchingUser `json:"users" jsonschema:"The first users matching the filter, up to the limit, empty if none matched"`
+	Truncated         bool           `json:"truncated" jsonschema:"True if more users match the filter than are returned"`
+}
+
+// PreviewGroupMembershipHandler lists the PingOne users matching a candidate group filter using the provided client
+func PreviewGroupMembershipHandler(groupsClientFactory GroupsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input PreviewGroupMembershipInput,
+) (
+	*mcp.CallToolResult,
+	*PreviewGroupMembershipOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input PreviewGroupMembershipInput) (*mcp.CallToolResult, *PreviewGroupMembershipOutput, error) {
+		if err := validateUserFilter(input.UserFilter); err != nil {
+			toolErr := errs.NewToolError(PreviewGroupMembershipDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		limit := defaultPreviewLimit
+		if input.Limit != nil {
+			if *input.Limit < 1 || *input.Limit > maxPreviewLimit {
+				toolErr := errs.NewToolError(PreviewGroupMembershipDef.McpTool.Name, fmt.Errorf("limit must be between 1 and %d", maxPreviewLimit))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			limit = *input.Limit
+		}
+
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(PreviewGroupMembershipDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Previewing group membership",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userFilter", input.UserFilter))
+
+		page, httpResponse, err := client.GetMatchingUsers(ctx, input.EnvironmentId, input.UserFilter, int32(limit))
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if page == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		users := []MatchingUser{}
+		if page.Embedded != nil {
+			for _, user := range page.Embedded.Users {
+				if len(users) == limit {
+					break
+				}
+				matchingUser := MatchingUser{
+					Id:       user.GetId(),
+					Username: user.GetUsername(),
+					Email:    user.GetEmail(),
+				}
+				if user.Name != nil {
+					matchingUser.GivenName = user.Name.Given
+					matchingUser.FamilyName = user.Name.Family
+				}
+				if user.Population != nil {
+					matchingUser.PopulationId = &user.Population.Id
+				}
+				users = append(users, matchingUser)
+			}
+		}
+
+		count := len(users)
+		if page.Count != nil {
+			count = int(*page.Count)
+		}
+
+		return nil, &PreviewGroupMembershipOutput{
+			UserFilter:        input.UserFilter,
+			MatchingUserCount: count,
+			Users:             users,
+			Truncated:         count > len(users),
+		}, nil
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPreviewGroupMembershipHandler(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, testUserFilter, int32(2)).Return(matchingUsersPage(3,
+		management.User{
+			Id:         testutils.Pointer(testUserId.String()),
+			Username:   "alice",
+			Email:      "alice@example.com",
+			Name:       &management.UserName{Given: testutils.Pointer("Alice"), Family: testutils.Pointer("Smith")},
+			Population: &management.UserPopulation{Id: "550e8400-e29b-41d4-a716-446655440100"},
+		},
+		management.User{Id: testutils.Pointer("550e8400-e29b-41d4-a716-446655440201"), Username: "bob", Email: "bob@example.com"},
+	), &http.Response{StatusCode: 200}, nil)
+
+	handler := groups.PreviewGroupMembershipHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.PreviewGroupMembershipInput{
+		EnvironmentId: testEnvironmentId,
+		UserFilter:    testUserFilter,
+		Limit:         testutils.Pointer(2),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, &groups.PreviewGroupMembershipOutput{
+		UserFilter:        testUserFilter,
+		MatchingUserCount: 3,
+		Users: []groups.MatchingUser{
+			{
+				Id:           testUserId.String(),
+				Username:     "alice",
+				Email:        "alice@example.com",
+				GivenName:    testutils.Pointer("Alice"),
+				FamilyName:   testutils.Pointer("Smith"),
+				PopulationId: testutils.Pointer("550e8400-e29b-41d4-a716-446655440100"),
+			},
+			{Id: "550e8400-e29b-41d4-a716-446655440201", Username: "bob", Email: "bob@example.com"},
+		},
+		Truncated: true,
+	}, output)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPreviewGroupMembershipHandler_NoMatches(t *testing.T) {
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, testUserFilter, int32(20)).Return(&management.EntityArray{
+		Count: testutils.Pointer(float32(0)),
+	}, &http.Response{StatusCode: 200}, nil)
+
+	handler := groups.PreviewGroupMembershipHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, groups.PreviewGroupMembershipInput{
+		EnvironmentId: testEnvironmentId,
+		UserFilter:    testUserFilter,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 0, output.MatchingUserCount)
+	assert.NotNil(t, output.Users)
+	assert.Empty(t, output.Users)
+	assert.False(t, output.Truncated)
+	mockClient.AssertExpectations(t)
+}
+
+func TestPreviewGroupMembershipHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           groups.PreviewGroupMembershipInput
+		setupMock       func(mockClient *mockPingOneClientGroupsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Empty filter",
+			input:           groups.PreviewGroupMembershipInput{EnvironmentId: testEnvironmentId},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "userFilter must not be empty",
+		},
+		{
+			name:            "Invalid filter",
+			input:           groups.PreviewGroupMembershipInput{EnvironmentId: testEnvironmentId, UserFilter: `(department eq "Engineering"`},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "invalid SCIM filter",
+		},
+		{
+			name:            "Limit too large",
+			input:           groups.PreviewGroupMembershipInput{EnvironmentId: testEnvironmentId, UserFilter: testUserFilter, Limit: testutils.Pointer(101)},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "limit must be between 1 and 100",
+		},
+		{
+			name:  "API error",
+			input: groups.PreviewGroupMembershipInput{EnvironmentId: testEnvironmentId, UserFilter: testUserFilter},
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, testUserFilter, int32(20)).Return(nil, &http.Response{StatusCode: 400}, errors.New("unsupported attribute"))
+			},
+			wantErrContains: "unsupported attribute",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := groups.PreviewGroupMembershipHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var RemoveGroupFromGroupDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "remove_group_from_group",
+		Title: "Remove PingOne Group from Group",
+		Description: `Remove a group from a parent group it is nested in, so that the members of the group no longer inherit the membership, roles and access of the parent group, unless they are members of it directly or through another group. The groups themselves are not deleted. Use list_group_memberships to find the groups the group is nested in.
+
+The nesting can be restored with undo_last_change.`,
+		InputSchema:  schema.MustGenerateSchema[GroupNestingInput](),
+		OutputSchema: schema.MustGenerateSchema[GroupNestingOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+// RemoveGroupFromGroupHandler removes a PingOne group from a group it is nested in using the provided client
+func RemoveGroupFromGroupHandler(groupsClientFactory GroupsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GroupNestingInput,
+) (
+	*mcp.CallToolResult,
+	*GroupNestingOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GroupNestingInput) (*mcp.CallToolResult, *GroupNestingOutput, error) {
+		if err := validateGroupNesting(input); err != nil {
+			toolErr := errs.NewToolError(RemoveGroupFromGroupDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(RemoveGroupFromGroupDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Removing group from group",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("groupId", input.GroupId.String()),
+			slog.String("parentGroupId", input.ParentGroupId.String()))
+
+		httpResponse, err := client.RemoveGroupFromGroup(ctx, input.EnvironmentId, input.GroupId.String(), input.ParentGroupId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          RemoveGroupFromGroupDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "groupNesting",
+			ResourceId:    input.GroupId.String(),
+			Description:   fmt.Sprintf("Add group %s to group %s again", input.GroupId, input.ParentGroupId),
+		}, undoGroupNesting(groupsClientFactory, input.EnvironmentId, input.GroupId.String(), input.ParentGroupId.String(), false))
+
+		return nil, &GroupNestingOutput{
+			GroupId:       input.GroupId.String(),
+			ParentGroupId: input.ParentGroupId.String(),
+			Nested:        false,
+		}, nil
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateGroupDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "update_group",
+		Title: "Update PingOne Group by ID",
+		Description: `Update only the given attributes of a group, keeping all other attributes. Only 'environmentId' and 'groupId' are required.
+
+Set 'userFilter' to make the group dynamic or change which users it dynamically includes, or set it to an empty string to remove the filter, so that the group only has the members added to it directly. The number of users matching the filter is returned with the group. Use preview_group_membership first to check which users a new filter matches.`,
+		InputSchema:  schema.MustGenerateSchema[UpdateGroupInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateGroupOutput](),
+	},
+}
+
+type UpdateGroupInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	GroupId       uuid.UUID `json:"groupId" jsonschema:"REQUIRED. Group UUID."`
+	Name          *string   `json:"name,omitempty" jsonschema:"OPTIONAL. New group name."`
+	Description   *string   `json:"description,omitempty" jsonschema:"OPTIONAL. New description."`
+	ExternalId    *string   `json:"externalId,omitempty" jsonschema:"OPTIONAL. New identifier of the group in an external system."`
+	UserFilter    *string   `json:"userFilter,omitempty" jsonschema:"OPTIONAL. New SCIM filter on user attributes. Users matching the filter are dynamically added to the group. An empty string removes the filter."`
+}
+
+type UpdateGroupOutput struct {
+	Group             management.Group `json:"group" jsonschema:"The updated group configuration"`
+	MatchingUserCount *int             `json:"matchingUserCount,omitempty" jsonschema:"For dynamic groups, the number of users in the environment matching the user filter after the update. Not set if the users could not be counted."`
+}
+
+// UpdateGroupHandler updates the given attributes of a PingOne group using the provided client
+func UpdateGroupHandler(groupsClientFactory GroupsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateGroupInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateGroupOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateGroupInput) (*mcp.CallToolResult, *UpdateGroupOutput, error) {
+		if input.UserFilter != nil && *input.UserFilter != "" {
+			if err := validateUserFilter(*input.UserFilter); err != nil {
+				toolErr := errs.NewToolError(UpdateGroupDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+		if input.Name == nil && input.Description == nil && input.ExternalId == nil && input.UserFilter == nil {
+			toolErr := errs.NewToolError(UpdateGroupDef.McpTool.Name, fmt.Errorf("at least one of name, description, externalId or userFilter must be provided"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateGroupDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Updating group",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("groupId", input.GroupId.String()),
+		)
+
+		// The group is replaced by the update, so the attributes that are not changed are read first
+		previous, httpResponse, err := client.GetGroup(ctx, input.EnvironmentId, input.GroupId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if previous == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no group data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		updateRequest := groupUpdateRequest(*previous)
+		if input.Name != nil {
+			updateRequest.Name = *input.Name
+		}
+		if input.Description != nil {
+			updateRequest.Description = input.Description
+		}
+		if input.ExternalId != nil {
+			updateRequest.ExternalId = input.ExternalId
+		}
+		if input.UserFilter != nil {
+			updateRequest.UserFilter = input.UserFilter
+			if *input.UserFilter == "" {
+				updateRequest.UserFilter = nil
+			}
+		}
+
+		groupResponse, httpResponse, err := client.UpdateGroup(ctx, input.EnvironmentId, input.GroupId.String(), updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		if groupResponse == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no group data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		logger.FromContext(ctx).Debug("Group updated successfully",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("groupId", input.GroupId.String()),
+		)
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          UpdateGroupDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "group",
+			ResourceId:    input.GroupId.String(),
+			Description:   fmt.Sprintf("Restore the previous configuration of group %q", previous.Name),
+		}, undoUpdateGroup(groupsClientFactory, input.EnvironmentId, input.GroupId.String(), groupUpdateRequest(*previous), groupUpdateRequest(*groupResponse)))
+
+		// Filter out _links field from response
+		groupResponse.Links = nil
+
+		result := &UpdateGroupOutput{
+			Group: *groupResponse,
+		}
+		if groupResponse.UserFilter != nil && *groupResponse.UserFilter != "" {
+			result.MatchingUserCount = matchingUserCount(ctx, client, input.EnvironmentId, *groupResponse.UserFilter)
+		}
+
+		return nil, result, nil
+	}
+}
+
+// groupUpdateRequest returns the attributes of the group that an update replaces
+func groupUpdateRequest(group management.Group) management.Group {
+	return management.Group{
+		Name:        group.Name,
+		Description: group.Description,
+		Population:  group.Population,
+		ExternalId:  group.ExternalId,
+		UserFilter:  group.UserFilter,
+		CustomData:  group.CustomData,
+	}
+}
+
+// undoUpdateGroup returns the function that restores the attributes of a group replaced by an update, unless the
+// name, description, external ID or user filter of the group have been changed again since. Groups have no
+// last update time to compare instead.
+func undoUpdateGroup(groupsClientFactory GroupsClientFactory, environmentId uuid.UUID, groupId string, previous management.Group, updated management.Group) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := groupsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			current, httpResponse, err := client.GetGroup(ctx, environmentId, groupId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			if current == nil {
+				return errs.NewApiError(httpResponse, fmt.Errorf("no group data in response"))
+			}
+			if current.Name != updated.Name ||
+				current.GetDescription() != updated.GetDescription() ||
+				current.GetExternalId() != updated.GetExternalId() ||
+				current.GetUserFilter() != updated.GetUserFilter() {
+				return rollback.ErrChangedSince
+			}
+		}
+
+		_, httpResponse, err := client.UpdateGroup(ctx, environmentId, groupId, previous)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
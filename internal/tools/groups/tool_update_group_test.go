@@ -0,0 +1,209 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// existingGroup returns the engineering group as it is before the update
+func existingGroup() *management.Group {
+	return &management.Group{
+		Id:          testutils.Pointer(testEngineeringGroupId.String()),
+		Name:        "Engineering",
+		Description: testutils.Pointer("All engineers"),
+		ExternalId:  testutils.Pointer("eng-01"),
+		UserFilter:  testutils.Pointer(`department eq "R&D"`),
+	}
+}
+
+func TestUpdateGroupHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       groups.UpdateGroupInput
+		wantRequest management.Group
+		wantCount   *int
+	}{
+		{
+			name:  "Change filter",
+			input: groups.UpdateGroupInput{UserFilter: testutils.Pointer(testUserFilter)},
+			wantRequest: management.Group{
+				Name:        "Engineering",
+				Description: testutils.Pointer("All engineers"),
+				ExternalId:  testutils.Pointer("eng-01"),
+				UserFilter:  testutils.Pointer(testUserFilter),
+			},
+			wantCount: testutils.Pointer(7),
+		},
+		{
+			name:  "Remove filter",
+			input: groups.UpdateGroupInput{UserFilter: testutils.Pointer("")},
+			wantRequest: management.Group{
+				Name:        "Engineering",
+				Description: testutils.Pointer("All engineers"),
+				ExternalId:  testutils.Pointer("eng-01"),
+			},
+		},
+		{
+			name:  "Rename keeps filter",
+			input: groups.UpdateGroupInput{Name: testutils.Pointer("Engineering Team")},
+			wantRequest: management.Group{
+				Name:        "Engineering Team",
+				Description: testutils.Pointer("All engineers"),
+				ExternalId:  testutils.Pointer("eng-01"),
+				UserFilter:  testutils.Pointer(`department eq "R&D"`),
+			},
+			wantCount: testutils.Pointer(7),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := tt.wantRequest
+			updated.Id = testutils.Pointer(testEngineeringGroupId.String())
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			mockClient.On("GetGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(existingGroup(), &http.Response{StatusCode: 200}, nil)
+			mockClient.On("UpdateGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), tt.wantRequest).Return(&updated, &http.Response{StatusCode: 200}, nil)
+			if tt.wantCount != nil {
+				mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, *tt.wantRequest.UserFilter, int32(1)).Return(matchingUsersPage(*tt.wantCount), &http.Response{StatusCode: 200}, nil)
+			}
+
+			input := tt.input
+			input.EnvironmentId = testEnvironmentId
+			input.GroupId = testEngineeringGroupId
+			handler := groups.UpdateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, updated, output.Group)
+			assert.Equal(t, tt.wantCount, output.MatchingUserCount)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateGroupHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           groups.UpdateGroupInput
+		setupMock       func(mockClient *mockPingOneClientGroupsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Nothing to update",
+			input:           groups.UpdateGroupInput{EnvironmentId: testEnvironmentId, GroupId: testEngineeringGroupId},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "at least one of",
+		},
+		{
+			name:            "Invalid filter",
+			input:           groups.UpdateGroupInput{EnvironmentId: testEnvironmentId, GroupId: testEngineeringGroupId, UserFilter: testutils.Pointer(`department xx "R&D"`)},
+			setupMock:       func(mockClient *mockPingOneClientGroupsWrapper) {},
+			wantErrContains: "invalid SCIM filter",
+		},
+		{
+			name:  "Get group error",
+			input: groups.UpdateGroupInput{EnvironmentId: testEnvironmentId, GroupId: testEngineeringGroupId, Name: testutils.Pointer("Engineering Team")},
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("GetGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("group not found"))
+			},
+			wantErrContains: "group not found",
+		},
+		{
+			name:  "Update error",
+			input: groups.UpdateGroupInput{EnvironmentId: testEnvironmentId, GroupId: testEngineeringGroupId, Name: testutils.Pointer("Engineering Team")},
+			setupMock: func(mockClient *mockPingOneClientGroupsWrapper) {
+				mockClient.On("GetGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(existingGroup(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("name already in use"))
+			},
+			wantErrContains: "name already in use",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientGroupsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := groups.UpdateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateGroupHandler_RecordsUndo(t *testing.T) {
+	updated := existingGroup()
+	updated.UserFilter = testutils.Pointer(testUserFilter)
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(existingGroup(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), mock.Anything).Return(updated, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, testUserFilter, int32(1)).Return(matchingUsersPage(7), &http.Response{StatusCode: 200}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := groups.UpdateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, groups.UpdateGroupInput{
+		EnvironmentId: testEnvironmentId,
+		GroupId:       testEngineeringGroupId,
+		UserFilter:    testutils.Pointer(testUserFilter),
+	})
+	require.NoError(t, err)
+
+	changes := journal.Changes(testEnvironmentId.String())
+	require.Len(t, changes, 1)
+	assert.Equal(t, "group", changes[0].ResourceType)
+	assert.Equal(t, testEngineeringGroupId.String(), changes[0].ResourceId)
+
+	// Undoing the update restores the previous filter
+	mockClient.On("GetGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(updated, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), management.Group{
+		Name:        "Engineering",
+		Description: testutils.Pointer("All engineers"),
+		ExternalId:  testutils.Pointer("eng-01"),
+		UserFilter:  testutils.Pointer(`department eq "R&D"`),
+	}).Return(existingGroup(), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateGroupHandler_UndoChangedSince(t *testing.T) {
+	updated := existingGroup()
+	updated.Name = "Engineering Team"
+	renamedAgain := existingGroup()
+	renamedAgain.Name = "Platform Engineering"
+	mockClient := &mockPingOneClientGroupsWrapper{}
+	mockClient.On("GetGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(existingGroup(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String(), mock.Anything).Return(updated, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetMatchingUsers", mock.Anything, testEnvironmentId, mock.Anything, int32(1)).Return(matchingUsersPage(7), &http.Response{StatusCode: 200}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := groups.UpdateGroupHandler(NewMockPingOneClientGroupsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, groups.UpdateGroupInput{
+		EnvironmentId: testEnvironmentId,
+		GroupId:       testEngineeringGroupId,
+		Name:          testutils.Pointer("Engineering Team"),
+	})
+	require.NoError(t, err)
+
+	mockClient.On("GetGroup", mock.Anything, testEnvironmentId, testEngineeringGroupId.String()).Return(renamedAgain, &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	assert.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
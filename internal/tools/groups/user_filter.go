@@ -0,0 +1,36 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package groups
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+)
+
+// validateUserFilter checks the syntax of a dynamic group filter, which filters users
+func validateUserFilter(filter string) error {
+	if filter == "" {
+		return errors.New("userFilter must not be empty")
+	}
+	return scimfilter.Users.Validate(filter)
+}
+
+// matchingUserCount returns the number of users matching the filter, or nil if they could not be counted. The
+// count is only a projection of the size of the group, so failing to read it is logged rather than returned.
+func matchingUserCount(ctx context.Context, client GroupsClient, environmentId uuid.UUID, filter string) *int {
+	page, httpResponse, err := client.GetMatchingUsers(ctx, environmentId, filter, 1)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil || page == nil || page.Count == nil {
+		logger.FromContext(ctx).Warn("Unable to count the users matching the group filter",
+			slog.String("environmentId", environmentId.String()),
+			slog.Any("error", err))
+		return nil
+	}
+	count := int(*page.Count)
+	return &count
+}
@@ -0,0 +1,28 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type IdentityProvidersClient interface {
+	GetIdentityProviders(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (*management.IdentityProvider, *http.Response, error)
+	CreateIdentityProvider(ctx context.Context, environmentId uuid.UUID, createRequest management.IdentityProvider) (*management.IdentityProvider, *http.Response, error)
+	UpdateIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, updateRequest management.IdentityProvider) (*management.IdentityProvider, *http.Response, error)
+	DeleteIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (*http.Response, error)
+	GetIdentityProviderAttributes(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID) (*management.IdentityProviderAttribute, *http.Response, error)
+	CreateIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, createRequest management.IdentityProviderAttribute) (*management.IdentityProviderAttribute, *http.Response, error)
+	UpdateIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID, updateRequest management.IdentityProviderAttribute) (*management.IdentityProviderAttribute, *http.Response, error)
+	DeleteIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID) (*http.Response, error)
+}
+
+type IdentityProvidersClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (IdentityProvidersClient, error)
+}
@@ -0,0 +1,192 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ IdentityProvidersClient = &PingOneClientIdentityProvidersWrapper{}
+var _ IdentityProvidersClientFactory = &PingOneClientIdentityProvidersWrapperFactory{}
+
+type PingOneClientIdentityProvidersWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientIdentityProvidersWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientIdentityProvidersWrapper(client *pingone.Client) *PingOneClientIdentityProvidersWrapper {
+	return &PingOneClientIdentityProvidersWrapper{client: client}
+}
+
+func NewPingOneClientIdentityProvidersWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientIdentityProvidersWrapperFactory {
+	return &PingOneClientIdentityProvidersWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientIdentityProvidersWrapperFactory) GetAuthenticatedClient(ctx context.Context) (IdentityProvidersClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientIdentityProvidersWrapper(client), nil
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) GetIdentityProviders(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.IdentityProvidersApi.ReadAllIdentityProviders(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve identity providers",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) GetIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (*management.IdentityProvider, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.IdentityProvidersApi.ReadOneIdentityProvider(ctx, environmentId.String(), identityProviderId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve identity provider by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) CreateIdentityProvider(ctx context.Context, environmentId uuid.UUID, createRequest management.IdentityProvider) (*management.IdentityProvider, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.IdentityProvidersApi.CreateIdentityProvider(ctx, environmentId.String()).IdentityProvider(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create identity provider",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) UpdateIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, updateRequest management.IdentityProvider) (*management.IdentityProvider, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.IdentityProvidersApi.UpdateIdentityProvider(ctx, environmentId.String(), identityProviderId.String()).IdentityProvider(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update identity provider",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) DeleteIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.IdentityProvidersApi.DeleteIdentityProvider(ctx, environmentId.String(), identityProviderId.String())
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete identity provider",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+	)
+	return deleteRequest.Execute()
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) GetIdentityProviderAttributes(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.IdentityProviderAttributesApi.ReadAllIdentityProviderAttributes(ctx, environmentId.String(), identityProviderId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve identity provider attribute mappings",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) GetIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID) (*management.IdentityProviderAttribute, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.IdentityProviderAttributesApi.ReadOneIdentityProviderAttribute(ctx, environmentId.String(), identityProviderId.String(), attributeId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve identity provider attribute mapping by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+		slog.String("attributeId", attributeId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) CreateIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, createRequest management.IdentityProviderAttribute) (*management.IdentityProviderAttribute, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.IdentityProviderAttributesApi.CreateIdentityProviderAttribute(ctx, environmentId.String(), identityProviderId.String()).IdentityProviderAttribute(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create identity provider attribute mapping",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+		slog.String("name", createRequest.Name),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) UpdateIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID, updateRequest management.IdentityProviderAttribute) (*management.IdentityProviderAttribute, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.IdentityProviderAttributesApi.UpdateIdentityProviderAttribute(ctx, environmentId.String(), identityProviderId.String(), attributeId.String()).IdentityProviderAttribute(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update identity provider attribute mapping",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+		slog.String("attributeId", attributeId.String()),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientIdentityProvidersWrapper) DeleteIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.IdentityProviderAttributesApi.DeleteIdentityProviderAttribute(ctx, environmentId.String(), identityProviderId.String(), attributeId.String())
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete identity provider attribute mapping",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("identityProviderId", identityProviderId.String()),
+		slog.String("attributeId", attributeId.String()),
+	)
+	return deleteRequest.Execute()
+}
@@ -0,0 +1,93 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "identity_providers"
+
+var _ collections.LegacySdkCollection = &IdentityProvidersCollection{}
+
+type IdentityProvidersCollection struct{}
+
+func (c *IdentityProvidersCollection) Name() string {
+	return CollectionName
+}
+
+func (c *IdentityProvidersCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	identityProvidersClientFactory := NewPingOneClientIdentityProvidersWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListIdentityProvidersDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListIdentityProvidersDef.McpTool.Name))
+		mcp.AddTool(server, ListIdentityProvidersDef.McpTool, ListIdentityProvidersHandler(identityProvidersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetIdentityProviderDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetIdentityProviderDef.McpTool.Name))
+		mcp.AddTool(server, GetIdentityProviderDef.McpTool, GetIdentityProviderHandler(identityProvidersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateIdentityProviderDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateIdentityProviderDef.McpTool.Name))
+		mcp.AddTool(server, CreateIdentityProviderDef.McpTool, CreateIdentityProviderHandler(identityProvidersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateIdentityProviderDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateIdentityProviderDef.McpTool.Name))
+		mcp.AddTool(server, UpdateIdentityProviderDef.McpTool, UpdateIdentityProviderHandler(identityProvidersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&DeleteIdentityProviderDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", DeleteIdentityProviderDef.McpTool.Name))
+		mcp.AddTool(server, DeleteIdentityProviderDef.McpTool, DeleteIdentityProviderHandler(identityProvidersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateIdentityProviderAttributeMappingDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateIdentityProviderAttributeMappingDef.McpTool.Name))
+		mcp.AddTool(server, CreateIdentityProviderAttributeMappingDef.McpTool, CreateIdentityProviderAttributeMappingHandler(identityProvidersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateIdentityProviderAttributeMappingDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateIdentityProviderAttributeMappingDef.McpTool.Name))
+		mcp.AddTool(server, UpdateIdentityProviderAttributeMappingDef.McpTool, UpdateIdentityProviderAttributeMappingHandler(identityProvidersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&DeleteIdentityProviderAttributeMappingDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", DeleteIdentityProviderAttributeMappingDef.McpTool.Name))
+		mcp.AddTool(server, DeleteIdentityProviderAttributeMappingDef.McpTool, DeleteIdentityProviderAttributeMappingHandler(identityProvidersClientFactory))
+	}
+
+	return nil
+}
+
+func (c *IdentityProvidersCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListIdentityProvidersDef,
+		GetIdentityProviderDef,
+		CreateIdentityProviderDef,
+		UpdateIdentityProviderDef,
+		DeleteIdentityProviderDef,
+		CreateIdentityProviderAttributeMappingDef,
+		UpdateIdentityProviderAttributeMappingDef,
+		DeleteIdentityProviderAttributeMappingDef,
+	}
+}
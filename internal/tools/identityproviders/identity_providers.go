@@ -0,0 +1,254 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// socialTypes are the identity provider types configured with only a client ID and secret
+var socialTypes = []management.EnumIdentityProviderExt{
+	management.ENUMIDENTITYPROVIDEREXT_GOOGLE,
+	management.ENUMIDENTITYPROVIDEREXT_LINKEDIN,
+	management.ENUMIDENTITYPROVIDEREXT_LINKEDIN_OIDC,
+	management.ENUMIDENTITYPROVIDEREXT_TWITTER,
+	management.ENUMIDENTITYPROVIDEREXT_AMAZON,
+	management.ENUMIDENTITYPROVIDEREXT_YAHOO,
+	management.ENUMIDENTITYPROVIDEREXT_GITHUB,
+}
+
+// IdentityProviderConfig is the configuration of an external identity provider, set in the attribute of its kind
+type IdentityProviderConfig struct {
+	Oidc      *management.IdentityProviderOIDC                 `json:"oidc,omitempty" jsonschema:"The configuration of an OpenID Connect identity provider, of type OPENID_CONNECT"`
+	Saml      *management.IdentityProviderSAML                 `json:"saml,omitempty" jsonschema:"The configuration of a SAML identity provider, of type SAML"`
+	Social    *management.IdentityProviderClientIDClientSecret `json:"social,omitempty" jsonschema:"The configuration of a social identity provider with a client ID and secret, of type GOOGLE, LINKEDIN, LINKEDIN_OIDC, TWITTER, AMAZON, YAHOO or GITHUB"`
+	Apple     *management.IdentityProviderApple                `json:"apple,omitempty" jsonschema:"The configuration of a Sign in with Apple identity provider, of type APPLE"`
+	Facebook  *management.IdentityProviderFacebook             `json:"facebook,omitempty" jsonschema:"The configuration of a Facebook identity provider, of type FACEBOOK"`
+	Microsoft *management.IdentityProviderMicrosoft            `json:"microsoft,omitempty" jsonschema:"The configuration of a Microsoft identity provider, of type MICROSOFT"`
+	Paypal    *management.IdentityProviderPaypal               `json:"paypal,omitempty" jsonschema:"The configuration of a PayPal identity provider, of type PAYPAL"`
+}
+
+type IdentityProviderSummary struct {
+	Id          string                             `json:"id" jsonschema:"The UUID of the identity provider"`
+	Name        string                             `json:"name" jsonschema:"The name of the identity provider"`
+	Type        management.EnumIdentityProviderExt `json:"type" jsonschema:"The type of the identity provider, such as OPENID_CONNECT, SAML or GOOGLE"`
+	Enabled     bool                               `json:"enabled" jsonschema:"Whether users can sign on with the identity provider"`
+	Description *string                            `json:"description,omitempty" jsonschema:"The description of the identity provider"`
+	CreatedAt   *string                            `json:"createdAt,omitempty" jsonschema:"The creation timestamp of the identity provider"`
+}
+
+type AttributeMapping struct {
+	Id          string                                                `json:"id" jsonschema:"The UUID of the attribute mapping"`
+	Name        string                                                `json:"name" jsonschema:"The name of the PingOne user attribute the mapping sets, such as email or name.given"`
+	Value       string                                                `json:"value" jsonschema:"The value the attribute is set to, as a placeholder expression such as ${providerAttributes.email}"`
+	Update      management.EnumIdentityProviderAttributeMappingUpdate `json:"update" jsonschema:"When the attribute is updated on sign on: EMPTY_ONLY when the user attribute is empty, or ALWAYS"`
+	MappingType *management.EnumIdentityProviderAttributeMappingType  `json:"mappingType,omitempty" jsonschema:"CORE for the mappings PingOne creates with the identity provider, or CUSTOM"`
+}
+
+// identityProvider returns the identity provider of the configuration, with the type of the configured kind
+// where none is given
+func (c IdentityProviderConfig) identityProvider() (*management.IdentityProvider, error) {
+	var configured []management.IdentityProvider
+	var typeErrs []error
+	if c.Oidc != nil {
+		typeErrs = append(typeErrs, checkType(&c.Oidc.Type, "oidc", management.ENUMIDENTITYPROVIDEREXT_OPENID_CONNECT))
+		configured = append(configured, management.IdentityProviderOIDCAsIdentityProvider(c.Oidc))
+	}
+	if c.Saml != nil {
+		typeErrs = append(typeErrs, checkType(&c.Saml.Type, "saml", management.ENUMIDENTITYPROVIDEREXT_SAML))
+		configured = append(configured, management.IdentityProviderSAMLAsIdentityProvider(c.Saml))
+	}
+	if c.Social != nil {
+		if !slices.Contains(socialTypes, c.Social.Type) {
+			typeErrs = append(typeErrs, fmt.Errorf("invalid social identity provider type %q, must be one of %s", c.Social.Type, joinTypes(socialTypes)))
+		}
+		configured = append(configured, management.IdentityProviderClientIDClientSecretAsIdentityProvider(c.Social))
+	}
+	if c.Apple != nil {
+		typeErrs = append(typeErrs, checkType(&c.Apple.Type, "apple", management.ENUMIDENTITYPROVIDEREXT_APPLE))
+		configured = append(configured, management.IdentityProviderAppleAsIdentityProvider(c.Apple))
+	}
+	if c.Facebook != nil {
+		typeErrs = append(typeErrs, checkType(&c.Facebook.Type, "facebook", management.ENUMIDENTITYPROVIDEREXT_FACEBOOK))
+		configured = append(configured, management.IdentityProviderFacebookAsIdentityProvider(c.Facebook))
+	}
+	if c.Microsoft != nil {
+		typeErrs = append(typeErrs, checkType(&c.Microsoft.Type, "microsoft", management.ENUMIDENTITYPROVIDEREXT_MICROSOFT))
+		configured = append(configured, management.IdentityProviderMicrosoftAsIdentityProvider(c.Microsoft))
+	}
+	if c.Paypal != nil {
+		typeErrs = append(typeErrs, checkType(&c.Paypal.Type, "paypal", management.ENUMIDENTITYPROVIDEREXT_PAYPAL))
+		configured = append(configured, management.IdentityProviderPaypalAsIdentityProvider(c.Paypal))
+	}
+	if len(configured) != 1 {
+		return nil, errors.New("exactly one of oidc, saml, social, apple, facebook, microsoft or paypal must be set")
+	}
+	if err := errors.Join(typeErrs...); err != nil {
+		return nil, err
+	}
+	return &configured[0], nil
+}
+
+// checkType sets the type of a configuration that has none, and checks the type of one that has
+func checkType(configType *management.EnumIdentityProviderExt, kind string, expected management.EnumIdentityProviderExt) error {
+	if *configType == "" {
+		*configType = expected
+		return nil
+	}
+	if *configType != expected {
+		return fmt.Errorf("invalid %s identity provider type %q, must be %s", kind, *configType, expected)
+	}
+	return nil
+}
+
+func joinTypes(types []management.EnumIdentityProviderExt) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// identityProviderConfig returns the configuration of an identity provider for tool output. Secrets and links
+// are removed, so that credentials of the external provider are not returned to the client.
+func identityProviderConfig(identityProvider management.IdentityProvider) (*IdentityProviderConfig, error) {
+	config := &IdentityProviderConfig{}
+	switch {
+	case identityProvider.IdentityProviderOIDC != nil:
+		config.Oidc = identityProvider.IdentityProviderOIDC
+		config.Oidc.Links = nil
+		config.Oidc.ClientSecret = ""
+	case identityProvider.IdentityProviderSAML != nil:
+		config.Saml = identityProvider.IdentityProviderSAML
+		config.Saml.Links = nil
+	case identityProvider.IdentityProviderClientIDClientSecret != nil:
+		config.Social = identityProvider.IdentityProviderClientIDClientSecret
+		config.Social.Links = nil
+		config.Social.ClientSecret = ""
+	case identityProvider.IdentityProviderApple != nil:
+		config.Apple = identityProvider.IdentityProviderApple
+		config.Apple.Links = nil
+		config.Apple.ClientSecretSigningKey = ""
+	case identityProvider.IdentityProviderFacebook != nil:
+		config.Facebook = identityProvider.IdentityProviderFacebook
+		config.Facebook.Links = nil
+		config.Facebook.AppSecret = ""
+	case identityProvider.IdentityProviderMicrosoft != nil:
+		config.Microsoft = identityProvider.IdentityProviderMicrosoft
+		config.Microsoft.Links = nil
+		config.Microsoft.ClientSecret = ""
+	case identityProvider.IdentityProviderPaypal != nil:
+		config.Paypal = identityProvider.IdentityProviderPaypal
+		config.Paypal.Links = nil
+		config.Paypal.ClientSecret = ""
+	default:
+		return nil, errors.New("unknown identity provider type in response")
+	}
+	return config, nil
+}
+
+// keepSecrets sets the secrets left empty in an update to those of the current configuration, so that an
+// update based on the output of get_identity_provider does not clear them. The type of an identity provider
+// cannot be changed.
+func keepSecrets(update *management.IdentityProvider, current management.IdentityProvider) error {
+	switch {
+	case update.IdentityProviderOIDC != nil && current.IdentityProviderOIDC != nil:
+		if update.IdentityProviderOIDC.ClientSecret == "" {
+			update.IdentityProviderOIDC.ClientSecret = current.IdentityProviderOIDC.ClientSecret
+		}
+	case update.IdentityProviderSAML != nil && current.IdentityProviderSAML != nil:
+		// SAML identity providers have no secrets
+	case update.IdentityProviderClientIDClientSecret != nil && current.IdentityProviderClientIDClientSecret != nil:
+		if update.IdentityProviderClientIDClientSecret.Type != current.IdentityProviderClientIDClientSecret.Type {
+			return fmt.Errorf("the type of an identity provider cannot be changed from %s to %s", current.IdentityProviderClientIDClientSecret.Type, update.IdentityProviderClientIDClientSecret.Type)
+		}
+		if update.IdentityProviderClientIDClientSecret.ClientSecret == "" {
+			update.IdentityProviderClientIDClientSecret.ClientSecret = current.IdentityProviderClientIDClientSecret.ClientSecret
+		}
+	case update.IdentityProviderApple != nil && current.IdentityProviderApple != nil:
+		if update.IdentityProviderApple.ClientSecretSigningKey == "" {
+			update.IdentityProviderApple.ClientSecretSigningKey = current.IdentityProviderApple.ClientSecretSigningKey
+		}
+	case update.IdentityProviderFacebook != nil && current.IdentityProviderFacebook != nil:
+		if update.IdentityProviderFacebook.AppSecret == "" {
+			update.IdentityProviderFacebook.AppSecret = current.IdentityProviderFacebook.AppSecret
+		}
+	case update.IdentityProviderMicrosoft != nil && current.IdentityProviderMicrosoft != nil:
+		if update.IdentityProviderMicrosoft.ClientSecret == "" {
+			update.IdentityProviderMicrosoft.ClientSecret = current.IdentityProviderMicrosoft.ClientSecret
+		}
+	case update.IdentityProviderPaypal != nil && current.IdentityProviderPaypal != nil:
+		if update.IdentityProviderPaypal.ClientSecret == "" {
+			update.IdentityProviderPaypal.ClientSecret = current.IdentityProviderPaypal.ClientSecret
+		}
+	default:
+		currentSummary, err := identityProviderSummary(current)
+		if err != nil {
+			return err
+		}
+		updateSummary, err := identityProviderSummary(*update)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("the type of an identity provider cannot be changed from %s to %s", currentSummary.Type, updateSummary.Type)
+	}
+	return nil
+}
+
+// identityProviderSummary returns the attributes common to all types of identity provider
+func identityProviderSummary(identityProvider management.IdentityProvider) (*IdentityProviderSummary, error) {
+	common, err := identityProviderCommon(identityProvider)
+	if err != nil {
+		return nil, err
+	}
+	return &IdentityProviderSummary{
+		Id:          common.GetId(),
+		Name:        common.Name,
+		Type:        common.Type,
+		Enabled:     common.Enabled,
+		Description: common.Description,
+		CreatedAt:   common.CreatedAt,
+	}, nil
+}
+
+// identityProviderUpdatedAt returns when an identity provider was last updated, or nil if PingOne did not return it
+func identityProviderUpdatedAt(identityProvider management.IdentityProvider) (*time.Time, error) {
+	common, err := identityProviderCommon(identityProvider)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := time.Parse(time.RFC3339, common.GetUpdatedAt())
+	if err != nil {
+		return nil, nil
+	}
+	return &updatedAt, nil
+}
+
+func identityProviderCommon(identityProvider management.IdentityProvider) (*management.IdentityProviderCommon, error) {
+	data, err := json.Marshal(identityProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal identity provider: %w", err)
+	}
+	var common management.IdentityProviderCommon
+	if err := json.Unmarshal(data, &common); err != nil {
+		return nil, fmt.Errorf("unknown identity provider type in response: %w", err)
+	}
+	return &common, nil
+}
+
+func attributeMapping(attribute management.IdentityProviderAttribute) AttributeMapping {
+	return AttributeMapping{
+		Id:          attribute.GetId(),
+		Name:        attribute.Name,
+		Value:       attribute.Value,
+		Update:      attribute.Update,
+		MappingType: attribute.MappingType,
+	}
+}
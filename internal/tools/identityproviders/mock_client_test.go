@@ -0,0 +1,127 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ identityproviders.IdentityProvidersClient = &mockPingOneClientIdentityProvidersWrapper{}
+var _ identityproviders.IdentityProvidersClientFactory = &mockPingOneClientIdentityProvidersWrapperFactory{}
+
+type mockPingOneClientIdentityProvidersWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientIdentityProvidersWrapperFactory struct {
+	mockClient identityproviders.IdentityProvidersClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient identityproviders.IdentityProvidersClient, err error) *mockPingOneClientIdentityProvidersWrapperFactory {
+	return &mockPingOneClientIdentityProvidersWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientIdentityProvidersWrapperFactory) GetAuthenticatedClient(ctx context.Context) (identityproviders.IdentityProvidersClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) GetIdentityProviders(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) GetIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (*management.IdentityProvider, *http.Response, error) {
+	args := p.Called(ctx, environmentId, identityProviderId)
+	return identityProviderResponse("GetIdentityProvider", args)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) CreateIdentityProvider(ctx context.Context, environmentId uuid.UUID, createRequest management.IdentityProvider) (*management.IdentityProvider, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	return identityProviderResponse("CreateIdentityProvider", args)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) UpdateIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, updateRequest management.IdentityProvider) (*management.IdentityProvider, *http.Response, error) {
+	args := p.Called(ctx, environmentId, identityProviderId, updateRequest)
+	return identityProviderResponse("UpdateIdentityProvider", args)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) DeleteIdentityProvider(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, identityProviderId)
+	return httpResponse("DeleteIdentityProvider", args)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) GetIdentityProviderAttributes(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, identityProviderId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) GetIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID) (*management.IdentityProviderAttribute, *http.Response, error) {
+	args := p.Called(ctx, environmentId, identityProviderId, attributeId)
+	return attributeResponse("GetIdentityProviderAttribute", args)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) CreateIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, createRequest management.IdentityProviderAttribute) (*management.IdentityProviderAttribute, *http.Response, error) {
+	args := p.Called(ctx, environmentId, identityProviderId, createRequest)
+	return attributeResponse("CreateIdentityProviderAttribute", args)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) UpdateIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID, updateRequest management.IdentityProviderAttribute) (*management.IdentityProviderAttribute, *http.Response, error) {
+	args := p.Called(ctx, environmentId, identityProviderId, attributeId, updateRequest)
+	return attributeResponse("UpdateIdentityProviderAttribute", args)
+}
+
+func (p *mockPingOneClientIdentityProvidersWrapper) DeleteIdentityProviderAttribute(ctx context.Context, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeId uuid.UUID) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, identityProviderId, attributeId)
+	return httpResponse("DeleteIdentityProviderAttribute", args)
+}
+
+func identityProviderResponse(method string, args mock.Arguments) (*management.IdentityProvider, *http.Response, error) {
+	response, ok := args.Get(0).(*management.IdentityProvider)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *management.IdentityProvider or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func attributeResponse(method string, args mock.Arguments) (*management.IdentityProviderAttribute, *http.Response, error) {
+	response, ok := args.Get(0).(*management.IdentityProviderAttribute)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *management.IdentityProviderAttribute or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func httpResponse(method string, args mock.Arguments) (*http.Response, error) {
+	response, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, args.Error(1)
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId      = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testIdentityProviderId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440700")
+	testSamlProviderId     = uuid.MustParse("550e8400-e29b-41d4-a716-446655440701")
+	testAttributeMappingId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440800")
+)
+
+// oidcIdentityProvider returns a new OpenID Connect identity provider as returned by the API, with its client secret
+func oidcIdentityProvider() *management.IdentityProvider {
+	identityProvider := management.IdentityProviderOIDCAsIdentityProvider(&management.IdentityProviderOIDC{
+		Links:                   &map[string]management.LinksHATEOASValue{"self": {Href: "https://api.pingone.com/v1/environments/550e8400-e29b-41d4-a716-446655440000/identityProviders/550e8400-e29b-41d4-a716-446655440700"}},
+		Id:                      testutils.Pointer(testIdentityProviderId.String()),
+		Name:                    "Corporate OIDC",
+		Enabled:                 true,
+		Type:                    management.ENUMIDENTITYPROVIDEREXT_OPENID_CONNECT,
+		CreatedAt:               testutils.Pointer("2025-01-10T09:00:00.000Z"),
+		AuthorizationEndpoint:   "https://idp.example.com/authorize",
+		ClientId:                "mcp-client",
+		ClientSecret:            "oidc-secret",
+		Issuer:                  "https://idp.example.com",
+		JwksEndpoint:            "https://idp.example.com/jwks",
+		Scopes:                  []string{"openid", "email"},
+		TokenEndpoint:           "https://idp.example.com/token",
+		TokenEndpointAuthMethod: management.ENUMIDENTITYPROVIDEROIDCTOKENAUTHMETHOD_CLIENT_SECRET_BASIC,
+	})
+	return &identityProvider
+}
+
+// samlIdentityProvider returns a new SAML identity provider as returned by the API
+func samlIdentityProvider() *management.IdentityProvider {
+	identityProvider := management.IdentityProviderSAMLAsIdentityProvider(&management.IdentityProviderSAML{
+		Id:          testutils.Pointer(testSamlProviderId.String()),
+		Name:        "Partner SAML",
+		Enabled:     false,
+		Type:        management.ENUMIDENTITYPROVIDEREXT_SAML,
+		IdpEntityId: "https://partner.example.com/saml",
+		IdpVerification: management.IdentityProviderSAMLAllOfIdpVerification{
+			Certificates: []management.IdentityProviderSAMLAllOfIdpVerificationCertificates{{Id: "550e8400-e29b-41d4-a716-446655440900"}},
+		},
+		SpEntityId:  "https://auth.pingone.com/550e8400-e29b-41d4-a716-446655440000",
+		SsoBinding:  management.ENUMIDENTITYPROVIDERSAMLSSOBINDING_POST,
+		SsoEndpoint: "https://partner.example.com/saml/sso",
+	})
+	return &identityProvider
+}
+
+// googleIdentityProvider returns a new Google identity provider as returned by the API, with its client secret
+func googleIdentityProvider() *management.IdentityProvider {
+	identityProvider := management.IdentityProviderClientIDClientSecretAsIdentityProvider(&management.IdentityProviderClientIDClientSecret{
+		Id:           testutils.Pointer(testIdentityProviderId.String()),
+		Name:         "Google",
+		Enabled:      true,
+		Type:         management.ENUMIDENTITYPROVIDEREXT_GOOGLE,
+		ClientId:     "google-client",
+		ClientSecret: "google-secret",
+	})
+	return &identityProvider
+}
+
+// testAttributeMapping returns a new attribute mapping of the OpenID Connect identity provider
+func testAttributeMapping() *management.IdentityProviderAttribute {
+	return &management.IdentityProviderAttribute{
+		Id:               testutils.Pointer(testAttributeMappingId.String()),
+		Name:             "email",
+		Value:            "${providerAttributes.email}",
+		Update:           management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_EMPTY_ONLY,
+		MappingType:      management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGTYPE_CUSTOM.Ptr(),
+		IdentityProvider: &management.IdentityProviderAttributeIdentityProvider{Id: testutils.Pointer(testIdentityProviderId.String())},
+	}
+}
+
+func identityProvidersPages(identityProviders ...management.IdentityProvider) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{IdentityProviders: identityProviders}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func attributesPages(attributes ...management.EntityArrayEmbeddedAttributesInner) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Attributes: attributes}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func errorPages(err error) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{HTTPResponse: &http.Response{StatusCode: 403}, Error: err},
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateIdentityProviderDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "create_identity_provider",
+		Title: "Create PingOne Identity Provider",
+		Description: `Create an external identity provider that users of an environment can sign on with. Set the configuration in exactly one of 'oidc', 'saml', 'social' (GOOGLE, LINKEDIN, LINKEDIN_OIDC, TWITTER, AMAZON, YAHOO or GITHUB), 'apple', 'facebook', 'microsoft' or 'paypal'. The type can be left empty except for social providers.
+
+PingOne creates the core attribute mappings of the provider; use 'create_identity_provider_attribute_mapping' to map further attributes.`,
+		InputSchema:  schema.MustGenerateSchema[CreateIdentityProviderInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateIdentityProviderOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateIdentityProviderInput struct {
+	EnvironmentId    uuid.UUID              `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IdentityProvider IdentityProviderConfig `json:"identityProvider" jsonschema:"REQUIRED. The identity provider configuration, in the attribute of its kind"`
+}
+
+type CreateIdentityProviderOutput struct {
+	IdentityProvider IdentityProviderConfig `json:"identityProvider" jsonschema:"The created identity provider, without secrets"`
+}
+
+// CreateIdentityProviderHandler creates a PingOne identity provider using the provided client
+func CreateIdentityProviderHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateIdentityProviderInput,
+) (
+	*mcp.CallToolResult,
+	*CreateIdentityProviderOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateIdentityProviderInput) (*mcp.CallToolResult, *CreateIdentityProviderOutput, error) {
+		createRequest, err := input.IdentityProvider.identityProvider()
+		if err != nil {
+			toolErr := errs.NewToolError(CreateIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating identity provider",
+			slog.String("environmentId", input.EnvironmentId.String()))
+
+		identityProvider, httpResponse, err := client.CreateIdentityProvider(ctx, input.EnvironmentId, *createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if identityProvider == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no identity provider data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		config, err := identityProviderConfig(*identityProvider)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &CreateIdentityProviderOutput{
+			IdentityProvider: *config,
+		}, nil
+	}
+}
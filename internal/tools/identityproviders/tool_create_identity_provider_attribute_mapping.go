@@ -0,0 +1,100 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateIdentityProviderAttributeMappingDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "create_identity_provider_attribute_mapping",
+		Title:        "Create PingOne Identity Provider Attribute Mapping",
+		Description:  "Map an attribute of an external identity provider to a PingOne user attribute, which is set from the provider on sign on. Each PingOne user attribute can be mapped once per identity provider; use 'get_identity_provider' to see the existing mappings.",
+		InputSchema:  schema.MustGenerateSchema[CreateIdentityProviderAttributeMappingInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateIdentityProviderAttributeMappingOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateIdentityProviderAttributeMappingInput struct {
+	EnvironmentId      uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IdentityProviderId uuid.UUID `json:"identityProviderId" jsonschema:"REQUIRED. Identity provider UUID."`
+	Name               string    `json:"name" jsonschema:"REQUIRED. The name of the PingOne user attribute to set, such as email or name.given."`
+	Value              string    `json:"value" jsonschema:"REQUIRED. The value to set the attribute to, as a placeholder expression of the provider's attributes such as ${providerAttributes.email}. For SAML providers, ${samlAssertion.subject} is the subject of the assertion."`
+	Update             *string   `json:"update,omitempty" jsonschema:"OPTIONAL. When the attribute is updated on sign on: EMPTY_ONLY when the user attribute is empty, or ALWAYS. Defaults to EMPTY_ONLY."`
+}
+
+type CreateIdentityProviderAttributeMappingOutput struct {
+	AttributeMapping AttributeMapping `json:"attributeMapping" jsonschema:"The created attribute mapping"`
+}
+
+// CreateIdentityProviderAttributeMappingHandler creates an attribute mapping of a PingOne identity provider using the provided client
+func CreateIdentityProviderAttributeMappingHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateIdentityProviderAttributeMappingInput,
+) (
+	*mcp.CallToolResult,
+	*CreateIdentityProviderAttributeMappingOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateIdentityProviderAttributeMappingInput) (*mcp.CallToolResult, *CreateIdentityProviderAttributeMappingOutput, error) {
+		update := management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_EMPTY_ONLY
+		if input.Update != nil {
+			parsed, err := management.NewEnumIdentityProviderAttributeMappingUpdateFromValue(*input.Update)
+			if err != nil {
+				toolErr := errs.NewToolError(CreateIdentityProviderAttributeMappingDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			update = *parsed
+		}
+
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateIdentityProviderAttributeMappingDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating identity provider attribute mapping",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("identityProviderId", input.IdentityProviderId.String()),
+			slog.String("name", input.Name))
+
+		createRequest := management.IdentityProviderAttribute{
+			Name:   input.Name,
+			Value:  input.Value,
+			Update: update,
+		}
+		attribute, httpResponse, err := client.CreateIdentityProviderAttribute(ctx, input.EnvironmentId, input.IdentityProviderId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if attribute == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no attribute mapping data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &CreateIdentityProviderAttributeMappingOutput{
+			AttributeMapping: attributeMapping(*attribute),
+		}, nil
+	}
+}
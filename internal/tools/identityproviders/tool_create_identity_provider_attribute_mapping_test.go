@@ -0,0 +1,118 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateIdentityProviderAttributeMappingHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		update         *string
+		expectedUpdate management.EnumIdentityProviderAttributeMappingUpdate
+	}{
+		{name: "Default update", expectedUpdate: management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_EMPTY_ONLY},
+		{name: "Always update", update: testutils.Pointer("ALWAYS"), expectedUpdate: management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_ALWAYS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectedRequest := management.IdentityProviderAttribute{
+				Name:   "email",
+				Value:  "${providerAttributes.email}",
+				Update: tt.expectedUpdate,
+			}
+			created := testAttributeMapping()
+			created.Update = tt.expectedUpdate
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			mockClient.On("CreateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, expectedRequest).Return(created, &http.Response{StatusCode: 201}, nil)
+
+			handler := identityproviders.CreateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.CreateIdentityProviderAttributeMappingInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+				Name:               "email",
+				Value:              "${providerAttributes.email}",
+				Update:             tt.update,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, testAttributeMappingId.String(), output.AttributeMapping.Id)
+			assert.Equal(t, tt.expectedUpdate, output.AttributeMapping.Update)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateIdentityProviderAttributeMappingHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		update          *string
+		setupMock       func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Invalid update",
+			update:          testutils.Pointer("SOMETIMES"),
+			wantErrContains: "SOMETIMES",
+		},
+		{
+			name: "Create error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("CreateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("attribute is already mapped"))
+			},
+			wantErrContains: "attribute is already mapped",
+		},
+		{
+			name: "No attribute mapping data in response",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("CreateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no attribute mapping data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := identityproviders.CreateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.CreateIdentityProviderAttributeMappingInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+				Name:               "email",
+				Value:              "${providerAttributes.email}",
+				Update:             tt.update,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateIdentityProviderAttributeMappingHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.CreateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.CreateIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		Name:               "email",
+		Value:              "${providerAttributes.email}",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,145 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateIdentityProviderHandler(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	// The type of the configured kind is set where none is given
+	expectedRequest := management.IdentityProviderFacebookAsIdentityProvider(&management.IdentityProviderFacebook{
+		Name:      "Facebook",
+		Enabled:   true,
+		Type:      management.ENUMIDENTITYPROVIDEREXT_FACEBOOK,
+		AppId:     "facebook-app",
+		AppSecret: "facebook-secret",
+	})
+	created := management.IdentityProviderFacebookAsIdentityProvider(&management.IdentityProviderFacebook{
+		Id:        testutils.Pointer(testIdentityProviderId.String()),
+		Name:      "Facebook",
+		Enabled:   true,
+		Type:      management.ENUMIDENTITYPROVIDEREXT_FACEBOOK,
+		AppId:     "facebook-app",
+		AppSecret: "facebook-secret",
+	})
+	mockClient.On("CreateIdentityProvider", mock.Anything, testEnvironmentId, expectedRequest).Return(&created, &http.Response{StatusCode: 201}, nil)
+
+	handler := identityproviders.CreateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.CreateIdentityProviderInput{
+		EnvironmentId: testEnvironmentId,
+		IdentityProvider: identityproviders.IdentityProviderConfig{
+			Facebook: &management.IdentityProviderFacebook{
+				Name:      "Facebook",
+				Enabled:   true,
+				AppId:     "facebook-app",
+				AppSecret: "facebook-secret",
+			},
+		},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.IdentityProvider.Facebook)
+	assert.Equal(t, testIdentityProviderId.String(), output.IdentityProvider.Facebook.GetId())
+	assert.Empty(t, output.IdentityProvider.Facebook.AppSecret, "Secrets should not be returned")
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateIdentityProviderHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name             string
+		identityProvider identityproviders.IdentityProviderConfig
+		setupMock        func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains  string
+	}{
+		{
+			name:             "No configuration",
+			identityProvider: identityproviders.IdentityProviderConfig{},
+			wantErrContains:  "exactly one of oidc, saml, social, apple, facebook, microsoft or paypal must be set",
+		},
+		{
+			name: "Several configurations",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Facebook: &management.IdentityProviderFacebook{Name: "Facebook"},
+				Paypal:   &management.IdentityProviderPaypal{Name: "PayPal"},
+			},
+			wantErrContains: "exactly one of oidc, saml, social, apple, facebook, microsoft or paypal must be set",
+		},
+		{
+			name: "Type of another kind",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Oidc: &management.IdentityProviderOIDC{Name: "OIDC", Type: management.ENUMIDENTITYPROVIDEREXT_SAML},
+			},
+			wantErrContains: "invalid oidc identity provider type \"SAML\", must be OPENID_CONNECT",
+		},
+		{
+			name: "Social provider without type",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Social: &management.IdentityProviderClientIDClientSecret{Name: "Social"},
+			},
+			wantErrContains: "invalid social identity provider type \"\", must be one of GOOGLE, LINKEDIN, LINKEDIN_OIDC, TWITTER, AMAZON, YAHOO, GITHUB",
+		},
+		{
+			name: "Create error",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Paypal: &management.IdentityProviderPaypal{Name: "PayPal"},
+			},
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("CreateIdentityProvider", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("invalid client ID"))
+			},
+			wantErrContains: "invalid client ID",
+		},
+		{
+			name: "No identity provider data in response",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Paypal: &management.IdentityProviderPaypal{Name: "PayPal"},
+			},
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("CreateIdentityProvider", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no identity provider data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := identityproviders.CreateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.CreateIdentityProviderInput{
+				EnvironmentId:    testEnvironmentId,
+				IdentityProvider: tt.identityProvider,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateIdentityProviderHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.CreateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.CreateIdentityProviderInput{
+		EnvironmentId: testEnvironmentId,
+		IdentityProvider: identityproviders.IdentityProviderConfig{
+			Paypal: &management.IdentityProviderPaypal{Name: "PayPal"},
+		},
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,98 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var DeleteIdentityProviderDef = types.ToolDefinition{
+	RequiresConfirmation: true,
+	McpTool: &mcp.Tool{
+		Name:  "delete_identity_provider",
+		Title: "Delete PingOne Identity Provider",
+		Description: `Delete an external identity provider and its attribute mappings. Users can no longer sign on with the provider, and sign-on policies that use it must be changed. The deletion cannot be undone.
+
+Consider disabling the provider with 'update_identity_provider' instead if it may be needed again.`,
+		InputSchema:  schema.MustGenerateSchema[DeleteIdentityProviderInput](),
+		OutputSchema: schema.MustGenerateSchema[DeleteIdentityProviderOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type DeleteIdentityProviderInput struct {
+	EnvironmentId      uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IdentityProviderId uuid.UUID `json:"identityProviderId" jsonschema:"REQUIRED. UUID of the identity provider to delete."`
+}
+
+type DeleteIdentityProviderOutput struct {
+	IdentityProvider IdentityProviderSummary `json:"identityProvider" jsonschema:"The deleted identity provider"`
+}
+
+// DeleteIdentityProviderHandler deletes a PingOne identity provider using the provided client
+func DeleteIdentityProviderHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input DeleteIdentityProviderInput,
+) (
+	*mcp.CallToolResult,
+	*DeleteIdentityProviderOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteIdentityProviderInput) (*mcp.CallToolResult, *DeleteIdentityProviderOutput, error) {
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(DeleteIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the identity provider first, to report what was deleted
+		identityProvider, httpResponse, err := client.GetIdentityProvider(ctx, input.EnvironmentId, input.IdentityProviderId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if identityProvider == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no identity provider data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		summary, err := identityProviderSummary(*identityProvider)
+		if err != nil {
+			toolErr := errs.NewToolError(DeleteIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Deleting identity provider",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("identityProviderId", input.IdentityProviderId.String()),
+			slog.String("type", string(summary.Type)))
+
+		httpResponse, err = client.DeleteIdentityProvider(ctx, input.EnvironmentId, input.IdentityProviderId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &DeleteIdentityProviderOutput{
+			IdentityProvider: *summary,
+		}, nil
+	}
+}
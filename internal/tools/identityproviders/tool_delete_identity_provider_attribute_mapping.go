@@ -0,0 +1,124 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var DeleteIdentityProviderAttributeMappingDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "delete_identity_provider_attribute_mapping",
+		Title: "Delete PingOne Identity Provider Attribute Mapping",
+		Description: `Delete an attribute mapping of an identity provider, so that the PingOne user attribute is no longer set from the provider on sign on. Existing user attribute values are not changed. Use 'get_identity_provider' to find the attribute mapping ID.
+
+The deleted mapping can be restored with undo_last_change, which creates the same mapping again under a new attribute mapping ID.`,
+		InputSchema:  schema.MustGenerateSchema[DeleteIdentityProviderAttributeMappingInput](),
+		OutputSchema: schema.MustGenerateSchema[DeleteIdentityProviderAttributeMappingOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type DeleteIdentityProviderAttributeMappingInput struct {
+	EnvironmentId      uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IdentityProviderId uuid.UUID `json:"identityProviderId" jsonschema:"REQUIRED. Identity provider UUID."`
+	AttributeMappingId uuid.UUID `json:"attributeMappingId" jsonschema:"REQUIRED. UUID of the attribute mapping to delete."`
+}
+
+type DeleteIdentityProviderAttributeMappingOutput struct {
+	AttributeMapping AttributeMapping `json:"attributeMapping" jsonschema:"The deleted attribute mapping"`
+}
+
+// DeleteIdentityProviderAttributeMappingHandler deletes an attribute mapping of a PingOne identity provider using the provided client
+func DeleteIdentityProviderAttributeMappingHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input DeleteIdentityProviderAttributeMappingInput,
+) (
+	*mcp.CallToolResult,
+	*DeleteIdentityProviderAttributeMappingOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteIdentityProviderAttributeMappingInput) (*mcp.CallToolResult, *DeleteIdentityProviderAttributeMappingOutput, error) {
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(DeleteIdentityProviderAttributeMappingDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the mapping first, to create the same mapping again on undo
+		attribute, httpResponse, err := client.GetIdentityProviderAttribute(ctx, input.EnvironmentId, input.IdentityProviderId, input.AttributeMappingId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if attribute == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no attribute mapping data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		logger.FromContext(ctx).Debug("Deleting identity provider attribute mapping",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("identityProviderId", input.IdentityProviderId.String()),
+			slog.String("attributeMappingId", input.AttributeMappingId.String()))
+
+		httpResponse, err = client.DeleteIdentityProviderAttribute(ctx, input.EnvironmentId, input.IdentityProviderId, input.AttributeMappingId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          DeleteIdentityProviderAttributeMappingDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "identityProviderAttributeMapping",
+			ResourceId:    input.AttributeMappingId.String(),
+			Description:   fmt.Sprintf("Map attribute %s to %s again", attribute.Name, attribute.Value),
+		}, undoDeleteIdentityProviderAttributeMapping(identityProvidersClientFactory, input.EnvironmentId, input.IdentityProviderId, *attribute))
+
+		return nil, &DeleteIdentityProviderAttributeMappingOutput{
+			AttributeMapping: attributeMapping(*attribute),
+		}, nil
+	}
+}
+
+// undoDeleteIdentityProviderAttributeMapping returns the function that creates the deleted mapping again.
+// The API rejects a second mapping of the same attribute, so there is nothing to check before creating it.
+func undoDeleteIdentityProviderAttributeMapping(identityProvidersClientFactory IdentityProvidersClientFactory, environmentId uuid.UUID, identityProviderId uuid.UUID, deleted management.IdentityProviderAttribute) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+		createRequest := management.IdentityProviderAttribute{
+			Name:   deleted.Name,
+			Value:  deleted.Value,
+			Update: deleted.Update,
+		}
+		_, httpResponse, err := client.CreateIdentityProviderAttribute(ctx, environmentId, identityProviderId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
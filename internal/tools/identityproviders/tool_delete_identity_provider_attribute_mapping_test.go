@@ -0,0 +1,124 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteIdentityProviderAttributeMappingHandler(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("DeleteIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(&http.Response{StatusCode: 204}, nil)
+
+	handler := identityproviders.DeleteIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.DeleteIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		AttributeMappingId: testAttributeMappingId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testAttributeMappingId.String(), output.AttributeMapping.Id)
+	assert.Equal(t, "email", output.AttributeMapping.Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteIdentityProviderAttributeMappingHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get attribute mapping error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(nil, &http.Response{StatusCode: 404}, errors.New("attribute mapping not found"))
+			},
+			wantErrContains: "attribute mapping not found",
+		},
+		{
+			name: "No attribute mapping data in response",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no attribute mapping data in response",
+		},
+		{
+			name: "Delete error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("DeleteIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(&http.Response{StatusCode: 400}, errors.New("core attribute mappings cannot be deleted"))
+			},
+			wantErrContains: "core attribute mappings cannot be deleted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := identityproviders.DeleteIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.DeleteIdentityProviderAttributeMappingInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+				AttributeMappingId: testAttributeMappingId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteIdentityProviderAttributeMappingHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.DeleteIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.DeleteIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		AttributeMappingId: testAttributeMappingId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestDeleteIdentityProviderAttributeMappingHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("DeleteIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(&http.Response{StatusCode: 204}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := identityproviders.DeleteIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, identityproviders.DeleteIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		AttributeMappingId: testAttributeMappingId,
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// Undoing the deletion creates the same mapping again
+	recreated := management.IdentityProviderAttribute{
+		Name:   "email",
+		Value:  "${providerAttributes.email}",
+		Update: management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_EMPTY_ONLY,
+	}
+	mockClient.On("CreateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, recreated).Return(testAttributeMapping(), &http.Response{StatusCode: 201}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeleteIdentityProviderHandler(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testSamlProviderId).Return(samlIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("DeleteIdentityProvider", mock.Anything, testEnvironmentId, testSamlProviderId).Return(&http.Response{StatusCode: 204}, nil)
+
+	handler := identityproviders.DeleteIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.DeleteIdentityProviderInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testSamlProviderId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testSamlProviderId.String(), output.IdentityProvider.Id)
+	assert.Equal(t, "Partner SAML", output.IdentityProvider.Name)
+	assert.Equal(t, management.ENUMIDENTITYPROVIDEREXT_SAML, output.IdentityProvider.Type)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDeleteIdentityProviderHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get identity provider error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testSamlProviderId).Return(nil, &http.Response{StatusCode: 404}, errors.New("identity provider not found"))
+			},
+			wantErrContains: "identity provider not found",
+		},
+		{
+			name: "No identity provider data in response",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testSamlProviderId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no identity provider data in response",
+		},
+		{
+			name: "Delete error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testSamlProviderId).Return(samlIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("DeleteIdentityProvider", mock.Anything, testEnvironmentId, testSamlProviderId).Return(&http.Response{StatusCode: 400}, errors.New("identity provider is used by a sign-on policy"))
+			},
+			wantErrContains: "identity provider is used by a sign-on policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := identityproviders.DeleteIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.DeleteIdentityProviderInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testSamlProviderId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteIdentityProviderHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.DeleteIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.DeleteIdentityProviderInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testSamlProviderId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,114 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetIdentityProviderDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_identity_provider",
+		Title: "Get PingOne Identity Provider by ID",
+		Description: `Retrieve the configuration of an external identity provider and the attribute mappings that set PingOne user attributes from the provider's attributes on sign on. Use 'list_identity_providers' first if you need to find the identity provider ID. Call before 'update_identity_provider' to get the current configuration.
+
+Secrets of the external provider, such as client secrets, are not returned.`,
+		InputSchema:  schema.MustGenerateSchema[GetIdentityProviderInput](),
+		OutputSchema: schema.MustGenerateSchema[GetIdentityProviderOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetIdentityProviderInput struct {
+	EnvironmentId      uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IdentityProviderId uuid.UUID `json:"identityProviderId" jsonschema:"REQUIRED. Identity provider UUID."`
+	Fields             []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'identityProvider' and 'attributeMappings.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetIdentityProviderOutput struct {
+	IdentityProvider  IdentityProviderConfig `json:"identityProvider" jsonschema:"The configuration of the identity provider, in the attribute of its kind"`
+	AttributeMappings []AttributeMapping     `json:"attributeMappings" jsonschema:"The mappings of the identity provider's attributes to PingOne user attributes"`
+}
+
+// GetIdentityProviderHandler retrieves a PingOne identity provider and its attribute mappings using the provided client
+func GetIdentityProviderHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetIdentityProviderInput,
+) (
+	*mcp.CallToolResult,
+	*GetIdentityProviderOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetIdentityProviderInput) (*mcp.CallToolResult, *GetIdentityProviderOutput, error) {
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Retrieving identity provider",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("identityProviderId", input.IdentityProviderId.String()))
+
+		identityProvider, httpResponse, err := client.GetIdentityProvider(ctx, input.EnvironmentId, input.IdentityProviderId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if identityProvider == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no identity provider data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		config, err := identityProviderConfig(*identityProvider)
+		if err != nil {
+			toolErr := errs.NewToolError(GetIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		pagedIterator, err := client.GetIdentityProviderAttributes(ctx, input.EnvironmentId, input.IdentityProviderId)
+		if err != nil {
+			toolErr := errs.NewToolError(GetIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &GetIdentityProviderOutput{
+			IdentityProvider:  *config,
+			AttributeMappings: []AttributeMapping{},
+		}
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			for _, attribute := range embedded.Attributes {
+				if attribute.IdentityProviderAttribute != nil {
+					result.AttributeMappings = append(result.AttributeMappings, attributeMapping(*attribute.IdentityProviderAttribute))
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIdentityProviderHandler(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(oidcIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetIdentityProviderAttributes", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(attributesPages(
+		management.EntityArrayEmbeddedAttributesInner{IdentityProviderAttribute: testAttributeMapping()},
+	), nil)
+
+	handler := identityproviders.GetIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.GetIdentityProviderInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.IdentityProvider.Oidc)
+	assert.Nil(t, output.IdentityProvider.Saml)
+	assert.Equal(t, "Corporate OIDC", output.IdentityProvider.Oidc.Name)
+	assert.Equal(t, "mcp-client", output.IdentityProvider.Oidc.ClientId)
+	assert.Empty(t, output.IdentityProvider.Oidc.ClientSecret, "Secrets should not be returned")
+	assert.Nil(t, output.IdentityProvider.Oidc.Links)
+	require.Len(t, output.AttributeMappings, 1)
+	assert.Equal(t, identityproviders.AttributeMapping{
+		Id:          testAttributeMappingId.String(),
+		Name:        "email",
+		Value:       "${providerAttributes.email}",
+		Update:      management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_EMPTY_ONLY,
+		MappingType: management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGTYPE_CUSTOM.Ptr(),
+	}, output.AttributeMappings[0])
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetIdentityProviderHandler_SecretsRemovedForEachKind(t *testing.T) {
+	tests := []struct {
+		name             string
+		identityProvider *management.IdentityProvider
+		assertOutput     func(t *testing.T, config identityproviders.IdentityProviderConfig)
+	}{
+		{
+			name:             "SAML",
+			identityProvider: samlIdentityProvider(),
+			assertOutput: func(t *testing.T, config identityproviders.IdentityProviderConfig) {
+				require.NotNil(t, config.Saml)
+				assert.Equal(t, "https://partner.example.com/saml", config.Saml.IdpEntityId)
+			},
+		},
+		{
+			name:             "Social",
+			identityProvider: googleIdentityProvider(),
+			assertOutput: func(t *testing.T, config identityproviders.IdentityProviderConfig) {
+				require.NotNil(t, config.Social)
+				assert.Equal(t, management.ENUMIDENTITYPROVIDEREXT_GOOGLE, config.Social.Type)
+				assert.Empty(t, config.Social.ClientSecret)
+			},
+		},
+		{
+			name: "Facebook",
+			identityProvider: func() *management.IdentityProvider {
+				identityProvider := management.IdentityProviderFacebookAsIdentityProvider(&management.IdentityProviderFacebook{
+					Name:      "Facebook",
+					Type:      management.ENUMIDENTITYPROVIDEREXT_FACEBOOK,
+					AppId:     "facebook-app",
+					AppSecret: "facebook-secret",
+				})
+				return &identityProvider
+			}(),
+			assertOutput: func(t *testing.T, config identityproviders.IdentityProviderConfig) {
+				require.NotNil(t, config.Facebook)
+				assert.Equal(t, "facebook-app", config.Facebook.AppId)
+				assert.Empty(t, config.Facebook.AppSecret)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(tt.identityProvider, &http.Response{StatusCode: 200}, nil)
+			mockClient.On("GetIdentityProviderAttributes", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(attributesPages(), nil)
+
+			handler := identityproviders.GetIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.GetIdentityProviderInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			tt.assertOutput(t, output.IdentityProvider)
+			assert.NotNil(t, output.AttributeMappings)
+		})
+	}
+}
+
+func TestGetIdentityProviderHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get identity provider error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(nil, &http.Response{StatusCode: 404}, errors.New("identity provider not found"))
+			},
+			wantErrContains: "identity provider not found",
+		},
+		{
+			name: "No identity provider data in response",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no identity provider data in response",
+		},
+		{
+			name: "Attribute mappings page error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(oidcIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetIdentityProviderAttributes", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := identityproviders.GetIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.GetIdentityProviderInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetIdentityProviderHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.GetIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.GetIdentityProviderInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,97 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListIdentityProvidersDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_identity_providers",
+		Title:        "List PingOne Identity Providers",
+		Description:  "Lists the external identity providers users of an environment can sign on with, such as OpenID Connect, SAML and social providers. Use to discover identity provider IDs before reading or changing their configuration.",
+		InputSchema:  schema.MustGenerateSchema[ListIdentityProvidersInput](),
+		OutputSchema: schema.MustGenerateSchema[ListIdentityProvidersOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListIdentityProvidersInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'identityProviders.id' and 'identityProviders.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListIdentityProvidersOutput struct {
+	IdentityProviders []IdentityProviderSummary `json:"identityProviders" jsonschema:"The identity providers of the environment"`
+}
+
+// ListIdentityProvidersHandler lists the PingOne identity providers of an environment using the provided client
+func ListIdentityProvidersHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListIdentityProvidersInput,
+) (
+	*mcp.CallToolResult,
+	*ListIdentityProvidersOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListIdentityProvidersInput) (*mcp.CallToolResult, *ListIdentityProvidersOutput, error) {
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListIdentityProvidersDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing identity providers", slog.String("environmentId", input.EnvironmentId.String()))
+
+		pagedIterator, err := client.GetIdentityProviders(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(ListIdentityProvidersDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &ListIdentityProvidersOutput{
+			IdentityProviders: []IdentityProviderSummary{},
+		}
+		var summaryErr error
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			logger.FromContext(ctx).Debug("Retrieved identity providers page", slog.Int("count", len(embedded.IdentityProviders)))
+			for _, identityProvider := range embedded.IdentityProviders {
+				summary, err := identityProviderSummary(identityProvider)
+				if err != nil {
+					summaryErr = err
+					return false
+				}
+				result.IdentityProviders = append(result.IdentityProviders, *summary)
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		if summaryErr != nil {
+			toolErr := errs.NewToolError(ListIdentityProvidersDef.McpTool.Name, summaryErr)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListIdentityProvidersHandler(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProviders", mock.Anything, testEnvironmentId).Return(identityProvidersPages(*oidcIdentityProvider(), *samlIdentityProvider()), nil)
+
+	handler := identityproviders.ListIdentityProvidersHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.ListIdentityProvidersInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.IdentityProviders, 2)
+	assert.Equal(t, identityproviders.IdentityProviderSummary{
+		Id:        testIdentityProviderId.String(),
+		Name:      "Corporate OIDC",
+		Type:      management.ENUMIDENTITYPROVIDEREXT_OPENID_CONNECT,
+		Enabled:   true,
+		CreatedAt: testutils.Pointer("2025-01-10T09:00:00.000Z"),
+	}, output.IdentityProviders[0])
+	assert.Equal(t, testSamlProviderId.String(), output.IdentityProviders[1].Id)
+	assert.Equal(t, management.ENUMIDENTITYPROVIDEREXT_SAML, output.IdentityProviders[1].Type)
+	assert.False(t, output.IdentityProviders[1].Enabled)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListIdentityProvidersHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProviders", mock.Anything, testEnvironmentId).Return(identityProvidersPages(), nil)
+
+	handler := identityproviders.ListIdentityProvidersHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.ListIdentityProvidersInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.IdentityProviders)
+	assert.Empty(t, output.IdentityProviders)
+}
+
+func TestListIdentityProvidersHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProviders", mock.Anything, testEnvironmentId).Return(nil, errors.New("invalid environment"))
+			},
+			wantErrContains: "invalid environment",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProviders", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := identityproviders.ListIdentityProvidersHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.ListIdentityProvidersInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListIdentityProvidersHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.ListIdentityProvidersHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.ListIdentityProvidersInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
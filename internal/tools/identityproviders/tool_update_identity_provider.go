@@ -0,0 +1,130 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateIdentityProviderDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "update_identity_provider",
+		Title: "Update PingOne Identity Provider by ID",
+		Description: `Update the configuration of an external identity provider using full replacement (HTTP PUT). Attribute mappings are not changed.
+
+WORKFLOW - Required to avoid data loss:
+1. Call 'get_identity_provider' to fetch current configuration
+2. Modify only the fields you want to change
+3. Pass the complete merged 'identityProvider' object to this tool
+
+Omitted optional fields will be cleared. Pass the 'updatedAt' returned by 'get_identity_provider' as 'lastKnownUpdatedAt' so that the identity provider is not replaced if someone else has changed it since. Secrets left empty, such as 'clientSecret', keep their current value. The type of an identity provider cannot be changed.`,
+		InputSchema:  schema.MustGenerateSchema[UpdateIdentityProviderInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateIdentityProviderOutput](),
+	},
+}
+
+type UpdateIdentityProviderInput struct {
+	EnvironmentId      uuid.UUID              `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IdentityProviderId uuid.UUID              `json:"identityProviderId" jsonschema:"REQUIRED. Identity provider UUID."`
+	IdentityProvider   IdentityProviderConfig `json:"identityProvider" jsonschema:"REQUIRED. The complete identity provider configuration with modifications, in the attribute of its kind"`
+	LastKnownUpdatedAt *time.Time             `json:"lastKnownUpdatedAt,omitempty" jsonschema:"OPTIONAL. The updatedAt of the identity provider as last read. If the identity provider has been updated since, it is not replaced and the call fails with a CONFLICT error."`
+}
+
+type UpdateIdentityProviderOutput struct {
+	IdentityProvider IdentityProviderConfig `json:"identityProvider" jsonschema:"The updated identity provider, without secrets"`
+}
+
+// UpdateIdentityProviderHandler replaces the configuration of a PingOne identity provider using the provided client
+func UpdateIdentityProviderHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateIdentityProviderInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateIdentityProviderOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateIdentityProviderInput) (*mcp.CallToolResult, *UpdateIdentityProviderOutput, error) {
+		updateRequest, err := input.IdentityProvider.identityProvider()
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the current configuration, to keep the secrets that are not returned by the read tools and to check
+		// it against the last known update
+		current, httpResponse, err := client.GetIdentityProvider(ctx, input.EnvironmentId, input.IdentityProviderId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if current == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no identity provider data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if input.LastKnownUpdatedAt != nil {
+			updatedAt, err := identityProviderUpdatedAt(*current)
+			if err == nil {
+				err = errs.CheckNotChangedSince("identity provider", input.IdentityProviderId.String(), *input.LastKnownUpdatedAt, updatedAt)
+			}
+			if err != nil {
+				toolErr := errs.NewToolError(UpdateIdentityProviderDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+		if err := keepSecrets(updateRequest, *current); err != nil {
+			toolErr := errs.NewToolError(UpdateIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Updating identity provider",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("identityProviderId", input.IdentityProviderId.String()))
+
+		identityProvider, httpResponse, err := client.UpdateIdentityProvider(ctx, input.EnvironmentId, input.IdentityProviderId, *updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if identityProvider == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no identity provider data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		config, err := identityProviderConfig(*identityProvider)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateIdentityProviderDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &UpdateIdentityProviderOutput{
+			IdentityProvider: *config,
+		}, nil
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateIdentityProviderAttributeMappingDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "update_identity_provider_attribute_mapping",
+		Title:        "Update PingOne Identity Provider Attribute Mapping",
+		Description:  "Change the value an identity provider attribute mapping sets its PingOne user attribute to, or when it is updated. The mapped user attribute cannot be changed; delete the mapping and create another instead. Use 'get_identity_provider' to find the attribute mapping ID. The change can be reverted with undo_last_change.",
+		InputSchema:  schema.MustGenerateSchema[UpdateIdentityProviderAttributeMappingInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateIdentityProviderAttributeMappingOutput](),
+	},
+}
+
+type UpdateIdentityProviderAttributeMappingInput struct {
+	EnvironmentId      uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IdentityProviderId uuid.UUID `json:"identityProviderId" jsonschema:"REQUIRED. Identity provider UUID."`
+	AttributeMappingId uuid.UUID `json:"attributeMappingId" jsonschema:"REQUIRED. Attribute mapping UUID."`
+	Value              string    `json:"value" jsonschema:"REQUIRED. The value to set the attribute to, as a placeholder expression of the provider's attributes such as ${providerAttributes.email}."`
+	Update             *string   `json:"update,omitempty" jsonschema:"OPTIONAL. When the attribute is updated on sign on: EMPTY_ONLY when the user attribute is empty, or ALWAYS. Defaults to the current setting."`
+}
+
+type UpdateIdentityProviderAttributeMappingOutput struct {
+	AttributeMapping AttributeMapping `json:"attributeMapping" jsonschema:"The updated attribute mapping"`
+}
+
+// UpdateIdentityProviderAttributeMappingHandler updates an attribute mapping of a PingOne identity provider using the provided client
+func UpdateIdentityProviderAttributeMappingHandler(identityProvidersClientFactory IdentityProvidersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateIdentityProviderAttributeMappingInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateIdentityProviderAttributeMappingOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateIdentityProviderAttributeMappingInput) (*mcp.CallToolResult, *UpdateIdentityProviderAttributeMappingOutput, error) {
+		var update *management.EnumIdentityProviderAttributeMappingUpdate
+		if input.Update != nil {
+			parsed, err := management.NewEnumIdentityProviderAttributeMappingUpdateFromValue(*input.Update)
+			if err != nil {
+				toolErr := errs.NewToolError(UpdateIdentityProviderAttributeMappingDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			update = parsed
+		}
+
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateIdentityProviderAttributeMappingDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// Read the mapping first, to keep the mapped attribute and restore the previous value on undo
+		previous, httpResponse, err := client.GetIdentityProviderAttribute(ctx, input.EnvironmentId, input.IdentityProviderId, input.AttributeMappingId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if previous == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no attribute mapping data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		logger.FromContext(ctx).Debug("Updating identity provider attribute mapping",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("identityProviderId", input.IdentityProviderId.String()),
+			slog.String("attributeMappingId", input.AttributeMappingId.String()))
+
+		updateRequest := management.IdentityProviderAttribute{
+			Name:   previous.Name,
+			Value:  input.Value,
+			Update: previous.Update,
+		}
+		if update != nil {
+			updateRequest.Update = *update
+		}
+		attribute, httpResponse, err := client.UpdateIdentityProviderAttribute(ctx, input.EnvironmentId, input.IdentityProviderId, input.AttributeMappingId, updateRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if attribute == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no attribute mapping data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          UpdateIdentityProviderAttributeMappingDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "identityProviderAttributeMapping",
+			ResourceId:    input.AttributeMappingId.String(),
+			Description:   fmt.Sprintf("Restore the mapping of attribute %s to %s", previous.Name, previous.Value),
+		}, undoUpdateIdentityProviderAttributeMapping(identityProvidersClientFactory, input.EnvironmentId, input.IdentityProviderId, input.AttributeMappingId, *previous, *attribute))
+
+		return nil, &UpdateIdentityProviderAttributeMappingOutput{
+			AttributeMapping: attributeMapping(*attribute),
+		}, nil
+	}
+}
+
+// undoUpdateIdentityProviderAttributeMapping returns the function that restores the previous value of an attribute
+// mapping, unless the mapping has been changed again since
+func undoUpdateIdentityProviderAttributeMapping(identityProvidersClientFactory IdentityProvidersClientFactory, environmentId uuid.UUID, identityProviderId uuid.UUID, attributeMappingId uuid.UUID, previous management.IdentityProviderAttribute, updated management.IdentityProviderAttribute) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := identityProvidersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			current, httpResponse, err := client.GetIdentityProviderAttribute(ctx, environmentId, identityProviderId, attributeMappingId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			if current == nil {
+				return errs.NewApiError(httpResponse, fmt.Errorf("no attribute mapping data in response"))
+			}
+			if current.Value != updated.Value || current.Update != updated.Update {
+				return rollback.ErrChangedSince
+			}
+		}
+
+		restoreRequest := management.IdentityProviderAttribute{
+			Name:   previous.Name,
+			Value:  previous.Value,
+			Update: previous.Update,
+		}
+		_, httpResponse, err := client.UpdateIdentityProviderAttribute(ctx, environmentId, identityProviderId, attributeMappingId, restoreRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
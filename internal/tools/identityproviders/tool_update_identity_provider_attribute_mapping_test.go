@@ -0,0 +1,169 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIdentityProviderAttributeMappingHandler(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil)
+	// The mapped attribute and the current update setting are kept
+	expectedRequest := management.IdentityProviderAttribute{
+		Name:   "email",
+		Value:  "${providerAttributes.mail}",
+		Update: management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_EMPTY_ONLY,
+	}
+	updated := testAttributeMapping()
+	updated.Value = "${providerAttributes.mail}"
+	mockClient.On("UpdateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId, expectedRequest).Return(updated, &http.Response{StatusCode: 200}, nil)
+
+	handler := identityproviders.UpdateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		AttributeMappingId: testAttributeMappingId,
+		Value:              "${providerAttributes.mail}",
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, "${providerAttributes.mail}", output.AttributeMapping.Value)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateIdentityProviderAttributeMappingHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		update          *string
+		setupMock       func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Invalid update",
+			update:          testutils.Pointer("NEVER"),
+			wantErrContains: "NEVER",
+		},
+		{
+			name: "Get attribute mapping error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(nil, &http.Response{StatusCode: 404}, errors.New("attribute mapping not found"))
+			},
+			wantErrContains: "attribute mapping not found",
+		},
+		{
+			name: "Update error",
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("invalid placeholder"))
+			},
+			wantErrContains: "invalid placeholder",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := identityproviders.UpdateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderAttributeMappingInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+				AttributeMappingId: testAttributeMappingId,
+				Value:              "${providerAttributes.mail}",
+				Update:             tt.update,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateIdentityProviderAttributeMappingHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.UpdateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		AttributeMappingId: testAttributeMappingId,
+		Value:              "${providerAttributes.mail}",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestUpdateIdentityProviderAttributeMappingHandler_RecordsUndo(t *testing.T) {
+	updated := testAttributeMapping()
+	updated.Value = "${providerAttributes.mail}"
+	updated.Update = management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_ALWAYS
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId, mock.Anything).Return(updated, &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := identityproviders.UpdateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		AttributeMappingId: testAttributeMappingId,
+		Value:              "${providerAttributes.mail}",
+		Update:             testutils.Pointer("ALWAYS"),
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// Undoing the update restores the previous value and update setting
+	restoreRequest := management.IdentityProviderAttribute{
+		Name:   "email",
+		Value:  "${providerAttributes.email}",
+		Update: management.ENUMIDENTITYPROVIDERATTRIBUTEMAPPINGUPDATE_EMPTY_ONLY,
+	}
+	mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(updated, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId, restoreRequest).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateIdentityProviderAttributeMappingHandler_UndoChangedSince(t *testing.T) {
+	updated := testAttributeMapping()
+	updated.Value = "${providerAttributes.mail}"
+	changedAgain := testAttributeMapping()
+	changedAgain.Value = "${providerAttributes.upn}"
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(testAttributeMapping(), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId, mock.Anything).Return(updated, &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := identityproviders.UpdateIdentityProviderAttributeMappingHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderAttributeMappingInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		AttributeMappingId: testAttributeMappingId,
+		Value:              "${providerAttributes.mail}",
+	})
+	require.NoError(t, err)
+
+	// The mapping has been changed again since, so the undo is refused unless forced
+	mockClient.On("GetIdentityProviderAttribute", mock.Anything, testEnvironmentId, testIdentityProviderId, testAttributeMappingId).Return(changedAgain, &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
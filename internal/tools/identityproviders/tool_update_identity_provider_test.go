@@ -0,0 +1,219 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package identityproviders_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIdentityProviderHandler_KeepsCurrentSecret(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(oidcIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+
+	// The configuration as returned by get_identity_provider, without its secret, with a changed scope
+	config := *oidcIdentityProvider().IdentityProviderOIDC
+	config.Links = nil
+	config.ClientSecret = ""
+	config.Scopes = []string{"openid", "email", "profile"}
+
+	expected := *oidcIdentityProvider().IdentityProviderOIDC
+	expected.Links = nil
+	expected.Scopes = []string{"openid", "email", "profile"}
+	expectedRequest := management.IdentityProviderOIDCAsIdentityProvider(&expected)
+	updated := oidcIdentityProvider()
+	updated.IdentityProviderOIDC.Scopes = expected.Scopes
+	mockClient.On("UpdateIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId, expectedRequest).Return(updated, &http.Response{StatusCode: 200}, nil)
+
+	handler := identityproviders.UpdateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		IdentityProvider:   identityproviders.IdentityProviderConfig{Oidc: &config},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.IdentityProvider.Oidc)
+	assert.Equal(t, []string{"openid", "email", "profile"}, output.IdentityProvider.Oidc.Scopes)
+	assert.Empty(t, output.IdentityProvider.Oidc.ClientSecret, "Secrets should not be returned")
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateIdentityProviderHandler_ReplacesGivenSecret(t *testing.T) {
+	mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+	mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(googleIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+
+	config := *googleIdentityProvider().IdentityProviderClientIDClientSecret
+	config.ClientSecret = "rotated-secret"
+	expectedRequest := management.IdentityProviderClientIDClientSecretAsIdentityProvider(&config)
+	mockClient.On("UpdateIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId, expectedRequest).Return(googleIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+
+	handler := identityproviders.UpdateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		IdentityProvider:   identityproviders.IdentityProviderConfig{Social: &config},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateIdentityProviderHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name             string
+		identityProvider identityproviders.IdentityProviderConfig
+		setupMock        func(mockClient *mockPingOneClientIdentityProvidersWrapper)
+		wantErrContains  string
+	}{
+		{
+			name:             "No configuration",
+			identityProvider: identityproviders.IdentityProviderConfig{},
+			wantErrContains:  "exactly one of oidc, saml, social, apple, facebook, microsoft or paypal must be set",
+		},
+		{
+			name: "Get identity provider error",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Oidc: &management.IdentityProviderOIDC{Name: "Corporate OIDC"},
+			},
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(nil, &http.Response{StatusCode: 404}, errors.New("identity provider not found"))
+			},
+			wantErrContains: "identity provider not found",
+		},
+		{
+			name: "Change of kind",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Saml: &management.IdentityProviderSAML{Name: "Corporate SAML"},
+			},
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(oidcIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "the type of an identity provider cannot be changed from OPENID_CONNECT to SAML",
+		},
+		{
+			name: "Change of social provider",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Social: &management.IdentityProviderClientIDClientSecret{Name: "GitHub", Type: management.ENUMIDENTITYPROVIDEREXT_GITHUB},
+			},
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(googleIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "the type of an identity provider cannot be changed from GOOGLE to GITHUB",
+		},
+		{
+			name: "Update error",
+			identityProvider: identityproviders.IdentityProviderConfig{
+				Oidc: &management.IdentityProviderOIDC{Name: "Corporate OIDC"},
+			},
+			setupMock: func(mockClient *mockPingOneClientIdentityProvidersWrapper) {
+				mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(oidcIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("issuer is required"))
+			},
+			wantErrContains: "issuer is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := identityproviders.UpdateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+				IdentityProvider:   tt.identityProvider,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateIdentityProviderHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := identityproviders.UpdateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderInput{
+		EnvironmentId:      testEnvironmentId,
+		IdentityProviderId: testIdentityProviderId,
+		IdentityProvider: identityproviders.IdentityProviderConfig{
+			Oidc: &management.IdentityProviderOIDC{Name: "Corporate OIDC"},
+		},
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestUpdateIdentityProviderHandler_LastKnownUpdatedAt(t *testing.T) {
+	lastKnownUpdatedAt := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		currentUpdatedAt *string
+		wantReplaced     bool
+		wantConflict     bool
+		wantErrContains  string
+	}{
+		{
+			name:             "Replaces an identity provider not changed since",
+			currentUpdatedAt: testutils.Pointer("2025-02-01T10:00:00.000Z"),
+			wantReplaced:     true,
+		},
+		{
+			name:             "Does not replace an identity provider changed since",
+			currentUpdatedAt: testutils.Pointer("2025-02-01T10:00:00.250Z"),
+			wantConflict:     true,
+			wantErrContains:  "has been changed since it was read",
+		},
+		{
+			name:            "Does not replace an identity provider without an update time",
+			wantErrContains: "PingOne did not return when it was last updated",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			current := googleIdentityProvider()
+			current.IdentityProviderClientIDClientSecret.UpdatedAt = tc.currentUpdatedAt
+			mockClient := &mockPingOneClientIdentityProvidersWrapper{}
+			mockClient.On("GetIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId).Return(current, &http.Response{StatusCode: 200}, nil)
+			if tc.wantReplaced {
+				mockClient.On("UpdateIdentityProvider", mock.Anything, testEnvironmentId, testIdentityProviderId, mock.Anything).Return(googleIdentityProvider(), &http.Response{StatusCode: 200}, nil)
+			}
+
+			config := *googleIdentityProvider().IdentityProviderClientIDClientSecret
+			handler := identityproviders.UpdateIdentityProviderHandler(NewMockPingOneClientIdentityProvidersWrapperFactory(mockClient, nil))
+			_, _, err := handler(context.Background(), &mcp.CallToolRequest{}, identityproviders.UpdateIdentityProviderInput{
+				EnvironmentId:      testEnvironmentId,
+				IdentityProviderId: testIdentityProviderId,
+				IdentityProvider:   identityproviders.IdentityProviderConfig{Social: &config},
+				LastKnownUpdatedAt: &lastKnownUpdatedAt,
+			})
+
+			if tc.wantReplaced {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrContains)
+				var conflictErr *errs.ConflictError
+				assert.Equal(t, tc.wantConflict, errors.As(err, &conflictErr))
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -4,13 +4,16 @@ package initialize
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/pingidentity/pingone-mcp-server/internal/audit"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/client"
 	"github.com/pingidentity/pingone-mcp-server/internal/auth/login"
+	"github.com/pingidentity/pingone-mcp-server/internal/auth/logout"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
 )
@@ -30,8 +33,11 @@ func AuthContextInitializer(authClientFactory client.AuthClientFactory, tokenSto
 func InitializeAuthContext(ctx context.Context, authClient client.AuthClient, tokenStore tokenstore.TokenStore, grantType auth.GrantType) (context.Context, error) {
 	var authSession *auth.AuthSession
 	var err error
-	// If browser login is available, we can attempt to auto-login if no valid session exists
-	if authClient.BrowserLoginAvailable(grantType) {
+	// If browser login is available, we can attempt to auto-login if no valid session exists.
+	// The device authorization grant does not require a browser on this machine, as the user can
+	// complete authorization on another device using the prompt displayed to them, and
+	// non-interactive grant types such as client credentials do not require a user at all
+	if !grantType.IsInteractive() || grantType == auth.GrantTypeDeviceCode || authClient.BrowserLoginAvailable(grantType) {
 		authSession, err = login.LoginIfNecessary(ctx, authClient, tokenStore, grantType)
 		if err != nil {
 			return nil, fmt.Errorf("failed to login: %w", err)
@@ -48,7 +54,39 @@ func InitializeAuthContext(ctx context.Context, authClient client.AuthClient, to
 		if err != nil {
 			return nil, fmt.Errorf("failed to get auth session: %w", err)
 		}
+		if !authSession.Expiry.After(time.Now()) {
+			authSession, err = refreshExpiredSession(ctx, authClient, tokenStore, grantType, *authSession)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	ctx = audit.ContextWithSessionId(ctx, authSession.SessionId)
+	// Tokens that are not JWTs, such as those of the mock backend, have no principal to record
+	if claims, err := auth.ParseAccessTokenClaims(authSession.AccessToken); err == nil {
+		ctx = audit.ContextWithPrincipal(ctx, claims.Principal())
+	}
 	return logger.ContextWithLogger(ctx, logger.FromContext(ctx).With(slog.String("sessionId", authSession.SessionId))), nil
 }
+
+// refreshExpiredSession refreshes an expired session when a new login can't be started. Sessions whose refresh
+// token has expired or been revoked are removed from the token store, as they can never be used again.
+func refreshExpiredSession(ctx context.Context, authClient client.AuthClient, tokenStore tokenstore.TokenStore, grantType auth.GrantType, authSession auth.AuthSession) (*auth.AuthSession, error) {
+	if authSession.RefreshToken == "" {
+		return nil, &auth.ReauthenticationRequiredError{Err: errors.New("the auth session has expired")}
+	}
+	refreshedSession, err := login.RefreshSession(ctx, authClient, tokenStore, grantType, authSession)
+	if err == nil {
+		return refreshedSession, nil
+	}
+	if !auth.IsInvalidGrant(err) {
+		return nil, fmt.Errorf("failed to refresh auth session: %w", err)
+	}
+
+	logger.FromContext(ctx).Warn("The refresh token of the auth session has expired or been revoked. Removing the session",
+		slog.String("sessionId", authSession.SessionId))
+	if err := logout.Logout(ctx, tokenStore); err != nil {
+		return nil, fmt.Errorf("failed to remove auth session: %w", err)
+	}
+	return nil, &auth.ReauthenticationRequiredError{Err: fmt.Errorf("the auth session has expired and could not be refreshed: %w", err)}
+}
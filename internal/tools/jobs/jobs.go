@@ -0,0 +1,285 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package jobs runs long tool operations, such as cloning or exporting an environment, in the background, so
+// that the tool call returns a job ID straight away instead of blocking until the MCP client times out. The
+// progress, result and errors of jobs are read with the job tools.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// DefaultMaxJobs is the number of finished jobs the manager keeps before the oldest are forgotten.
+const DefaultMaxJobs = 50
+
+// DefaultMaxRunningJobs is the number of jobs that can run at the same time, across all tools.
+const DefaultMaxRunningJobs = 4
+
+// ErrNotEnabled is returned when a tool call asks to run in the background, but background jobs are not enabled.
+var ErrNotEnabled = errors.New("background jobs are not enabled on this server; call the tool again without runInBackground")
+
+type Status string
+
+const (
+	StatusRunning   Status = "RUNNING"
+	StatusSucceeded Status = "SUCCEEDED"
+	StatusFailed    Status = "FAILED"
+	StatusCancelled Status = "CANCELLED"
+)
+
+// RunFunc runs the operation of a job, returning the tool output. It should stop when the context is cancelled.
+type RunFunc func(ctx context.Context) (any, error)
+
+// Job is a tool operation running in the background.
+type Job struct {
+	Id            string             `json:"id" jsonschema:"The ID of the job"`
+	Tool          string             `json:"tool" jsonschema:"The tool that started the job"`
+	EnvironmentId string             `json:"environmentId,omitempty" jsonschema:"The UUID of the environment the job operates on"`
+	Status        Status             `json:"status" jsonschema:"The status of the job: RUNNING, SUCCEEDED, FAILED or CANCELLED"`
+	Progress      *progress.Progress `json:"progress,omitempty" jsonschema:"The progress last reported by the job"`
+	StartedAt     time.Time          `json:"startedAt" jsonschema:"When the job started"`
+	CompletedAt   *time.Time         `json:"completedAt,omitempty" jsonschema:"When the job succeeded, failed or was cancelled"`
+	Principal     string             `json:"principal,omitempty" jsonschema:"The principal of the PingOne session that started the job"`
+	Result        any                `json:"result,omitempty" jsonschema:"The output of the tool, once the job has succeeded"`
+	Error         *string            `json:"error,omitempty" jsonschema:"Why the job failed"`
+}
+
+type jobEntry struct {
+	job    Job
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Manager runs jobs and holds them in memory. Jobs are lost when the server restarts.
+type Manager struct {
+	mutex          sync.Mutex
+	maxJobs        int
+	maxRunningJobs int
+	// entries are ordered oldest first
+	entries []*jobEntry
+}
+
+// NewManager creates a manager that keeps up to maxJobs finished jobs and runs up to maxRunningJobs at a time.
+func NewManager(maxJobs int, maxRunningJobs int) *Manager {
+	if maxJobs <= 0 {
+		maxJobs = DefaultMaxJobs
+	}
+	if maxRunningJobs <= 0 {
+		maxRunningJobs = DefaultMaxRunningJobs
+	}
+	return &Manager{
+		maxJobs:        maxJobs,
+		maxRunningJobs: maxRunningJobs,
+	}
+}
+
+// Start runs a job of the tool in a new goroutine and returns it straight away. Jobs of a tool with
+// MaxConcurrentExecutions are limited to the same number of running jobs, as the concurrency limit of the tool
+// call is released once the job has started.
+//
+// The job runs with the values of the context, such as its logger and PingOne session, but is not cancelled with
+// it, so that it outlives the tool call. It is cancelled with Cancel.
+func (m *Manager) Start(ctx context.Context, toolDef types.ToolDefinition, environmentId string, run RunFunc) (Job, error) {
+	if m == nil {
+		return Job{}, ErrNotEnabled
+	}
+	toolName := toolDef.McpTool.Name
+
+	m.mutex.Lock()
+	running, runningOfTool := 0, 0
+	for _, entry := range m.entries {
+		if entry.job.Status == StatusRunning {
+			running++
+			if entry.job.Tool == toolName {
+				runningOfTool++
+			}
+		}
+	}
+	if running >= m.maxRunningJobs {
+		m.mutex.Unlock()
+		return Job{}, fmt.Errorf("%d jobs are already running, which is the most that can run at the same time; wait for a job to complete, or cancel one with cancel_job", running)
+	}
+	if toolDef.MaxConcurrentExecutions > 0 && runningOfTool >= toolDef.MaxConcurrentExecutions {
+		m.mutex.Unlock()
+		return Job{}, fmt.Errorf("%d %s jobs are already running, which is the most that can run at the same time; wait for a job to complete, or cancel one with cancel_job", runningOfTool, toolName)
+	}
+
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	entry := &jobEntry{
+		job: Job{
+			Id:            uuid.NewString(),
+			Tool:          toolName,
+			EnvironmentId: environmentId,
+			Status:        StatusRunning,
+			StartedAt:     time.Now().UTC(),
+			Principal:     audit.PrincipalFromContext(ctx),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	m.entries = append(m.entries, entry)
+	m.forgetFinished()
+	job := entry.job
+	m.mutex.Unlock()
+
+	// The progress of the job is read with get_job_status, rather than sent to the client of the tool call
+	go m.run(progress.ContextWithReporter(jobCtx, func(reported progress.Progress) {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		entry.job.Progress = &reported
+	}), entry, run)
+
+	return job, nil
+}
+
+func (m *Manager) run(ctx context.Context, entry *jobEntry, run RunFunc) {
+	defer close(entry.done)
+	defer entry.cancel()
+
+	result, err := run(ctx)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	completedAt := time.Now().UTC()
+	entry.job.CompletedAt = &completedAt
+	switch {
+	case ctx.Err() != nil:
+		entry.job.Status = StatusCancelled
+	case err != nil:
+		errMsg := err.Error()
+		entry.job.Status = StatusFailed
+		entry.job.Error = &errMsg
+	default:
+		entry.job.Status = StatusSucceeded
+		entry.job.Result = result
+	}
+	m.forgetFinished()
+}
+
+// Get returns the job with the given ID.
+func (m *Manager) Get(jobId string) (Job, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	entry, err := m.find(jobId)
+	if err != nil {
+		return Job{}, err
+	}
+	return entry.job, nil
+}
+
+// List returns the jobs, most recently started first.
+func (m *Manager) List() []Job {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	jobs := []Job{}
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		jobs = append(jobs, m.entries[i].job)
+	}
+	return jobs
+}
+
+// Cancel cancels a running job and waits for it to stop, or for the context to be done. Changes the job made
+// before it was cancelled are kept.
+func (m *Manager) Cancel(ctx context.Context, jobId string) (Job, error) {
+	m.mutex.Lock()
+	entry, err := m.find(jobId)
+	if err == nil && entry.job.Status != StatusRunning {
+		err = fmt.Errorf("job %s is not running, its status is %s", jobId, entry.job.Status)
+	}
+	m.mutex.Unlock()
+	if err != nil {
+		return Job{}, err
+	}
+
+	entry.cancel()
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+	return m.Get(jobId)
+}
+
+// Wait waits for a job to complete, or for the context to be done, and returns it.
+func (m *Manager) Wait(ctx context.Context, jobId string) (Job, error) {
+	m.mutex.Lock()
+	entry, err := m.find(jobId)
+	m.mutex.Unlock()
+	if err != nil {
+		return Job{}, err
+	}
+	select {
+	case <-entry.done:
+	case <-ctx.Done():
+		return Job{}, ctx.Err()
+	}
+	return m.Get(jobId)
+}
+
+// Clear cancels the running jobs and forgets all jobs, such as when the server switches to another PingOne
+// profile whose session must not be used by the jobs of the previous one.
+func (m *Manager) Clear() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, entry := range m.entries {
+		entry.cancel()
+	}
+	m.entries = nil
+}
+
+func (m *Manager) find(jobId string) (*jobEntry, error) {
+	for _, entry := range m.entries {
+		if entry.job.Id == jobId {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("no job %s found; it may have been forgotten as older than the last %d jobs, or the server may have restarted", jobId, m.maxJobs)
+}
+
+// forgetFinished removes the oldest finished jobs beyond the maximum. Running jobs are never forgotten.
+func (m *Manager) forgetFinished() {
+	finished := 0
+	for _, entry := range m.entries {
+		if entry.job.Status != StatusRunning {
+			finished++
+		}
+	}
+	m.entries = slices.DeleteFunc(m.entries, func(entry *jobEntry) bool {
+		if finished <= m.maxJobs || entry.job.Status == StatusRunning {
+			return false
+		}
+		finished--
+		return true
+	})
+}
+
+type managerContextKey struct{}
+
+// ContextWithManager returns a context whose tool handlers can start jobs with the manager.
+func ContextWithManager(ctx context.Context, manager *Manager) context.Context {
+	return context.WithValue(ctx, managerContextKey{}, manager)
+}
+
+// ManagerFromContext returns the manager of the context, or nil if jobs are not enabled.
+func ManagerFromContext(ctx context.Context) *Manager {
+	if ctx == nil {
+		return nil
+	}
+	manager, _ := ctx.Value(managerContextKey{}).(*Manager)
+	return manager
+}
+
+// Start starts a job with the manager of the context, returning ErrNotEnabled if there is none.
+func Start(ctx context.Context, toolDef types.ToolDefinition, environmentId string, run RunFunc) (Job, error) {
+	return ManagerFromContext(ctx).Start(ctx, toolDef, environmentId, run)
+}
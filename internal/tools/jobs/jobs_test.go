@@ -0,0 +1,188 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToolDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{Name: "clone_thing"},
+}
+
+var testLimitedToolDef = types.ToolDefinition{
+	MaxConcurrentExecutions: 1,
+	McpTool:                 &mcp.Tool{Name: "import_things"},
+}
+
+// blockingRun returns a job that runs until it is cancelled or released
+func blockingRun(release <-chan struct{}) jobs.RunFunc {
+	return func(ctx context.Context) (any, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-release:
+			return "released", nil
+		}
+	}
+}
+
+func TestManager_Start_Succeeded(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	ctx, cancel := context.WithCancel(audit.ContextWithPrincipal(context.Background(), "admin@example.com"))
+
+	job, err := manager.Start(ctx, testToolDef, "env-a", func(ctx context.Context) (any, error) {
+		progress.Report(ctx, 2, 3, "Cloning things")
+		return map[string]any{"cloned": 3}, nil
+	})
+	// The job outlives the tool call that started it
+	cancel()
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, job.Id)
+	assert.Equal(t, "clone_thing", job.Tool)
+	assert.Equal(t, "env-a", job.EnvironmentId)
+	assert.Equal(t, "admin@example.com", job.Principal)
+	assert.False(t, job.StartedAt.IsZero())
+
+	job, err = manager.Wait(t.Context(), job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status)
+	assert.Equal(t, map[string]any{"cloned": 3}, job.Result)
+	assert.Equal(t, &progress.Progress{Completed: 2, Total: 3, Message: "Cloning things"}, job.Progress)
+	assert.NotNil(t, job.CompletedAt)
+	assert.Nil(t, job.Error)
+}
+
+func TestManager_Start_Failed(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+
+	job, err := manager.Start(context.Background(), testToolDef, "env-a", func(ctx context.Context) (any, error) {
+		return nil, errors.New("quota exceeded")
+	})
+	require.NoError(t, err)
+
+	job, err = manager.Wait(t.Context(), job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusFailed, job.Status)
+	require.NotNil(t, job.Error)
+	assert.Equal(t, "quota exceeded", *job.Error)
+	assert.Nil(t, job.Result)
+}
+
+func TestManager_Start_NilManager(t *testing.T) {
+	var manager *jobs.Manager
+	_, err := manager.Start(context.Background(), testToolDef, "env-a", blockingRun(nil))
+	assert.ErrorIs(t, err, jobs.ErrNotEnabled)
+
+	_, err = jobs.Start(context.Background(), testToolDef, "env-a", blockingRun(nil))
+	assert.ErrorIs(t, err, jobs.ErrNotEnabled)
+}
+
+func TestManager_Start_MaxRunningJobs(t *testing.T) {
+	manager := jobs.NewManager(0, 2)
+	release := make(chan struct{})
+	defer close(release)
+
+	for range 2 {
+		_, err := manager.Start(context.Background(), testToolDef, "env-a", blockingRun(release))
+		require.NoError(t, err)
+	}
+	_, err := manager.Start(context.Background(), testToolDef, "env-a", blockingRun(release))
+	assert.ErrorContains(t, err, "2 jobs are already running")
+}
+
+func TestManager_Start_MaxConcurrentExecutions(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	release := make(chan struct{})
+
+	first, err := manager.Start(context.Background(), testLimitedToolDef, "env-a", blockingRun(release))
+	require.NoError(t, err)
+	_, err = manager.Start(context.Background(), testLimitedToolDef, "env-b", blockingRun(release))
+	assert.ErrorContains(t, err, "1 import_things jobs are already running")
+
+	// Jobs of other tools are not limited
+	_, err = manager.Start(context.Background(), testToolDef, "env-a", blockingRun(release))
+	require.NoError(t, err)
+
+	close(release)
+	_, err = manager.Wait(t.Context(), first.Id)
+	require.NoError(t, err)
+	_, err = manager.Start(context.Background(), testLimitedToolDef, "env-b", blockingRun(nil))
+	assert.NoError(t, err, "the job should start once the running job has completed")
+	manager.Clear()
+}
+
+func TestManager_Cancel(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	job, err := manager.Start(context.Background(), testToolDef, "env-a", blockingRun(nil))
+	require.NoError(t, err)
+
+	job, err = manager.Cancel(t.Context(), job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusCancelled, job.Status)
+	assert.NotNil(t, job.CompletedAt)
+
+	_, err = manager.Cancel(t.Context(), job.Id)
+	assert.ErrorContains(t, err, "is not running, its status is CANCELLED")
+	_, err = manager.Cancel(t.Context(), "unknown")
+	assert.ErrorContains(t, err, "no job unknown found")
+}
+
+func TestManager_List_ForgetsOldestFinishedJobs(t *testing.T) {
+	manager := jobs.NewManager(2, 0)
+	running, err := manager.Start(context.Background(), testToolDef, "env-a", blockingRun(nil))
+	require.NoError(t, err)
+
+	var finishedIds []string
+	for range 3 {
+		job, err := manager.Start(context.Background(), testToolDef, "env-a", func(ctx context.Context) (any, error) {
+			return nil, nil
+		})
+		require.NoError(t, err)
+		_, err = manager.Wait(t.Context(), job.Id)
+		require.NoError(t, err)
+		finishedIds = append(finishedIds, job.Id)
+	}
+
+	var ids []string
+	for _, job := range manager.List() {
+		ids = append(ids, job.Id)
+	}
+	assert.Equal(t, []string{finishedIds[2], finishedIds[1], running.Id}, ids, "running jobs should never be forgotten")
+	_, err = manager.Get(finishedIds[0])
+	assert.ErrorContains(t, err, "older than the last 2 jobs")
+	manager.Clear()
+}
+
+func TestManager_Clear(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	stopped := make(chan struct{})
+	_, err := manager.Start(context.Background(), testToolDef, "env-a", func(ctx context.Context) (any, error) {
+		<-ctx.Done()
+		close(stopped)
+		return nil, ctx.Err()
+	})
+	require.NoError(t, err)
+
+	manager.Clear()
+
+	<-stopped
+	assert.Empty(t, manager.List())
+}
+
+func TestManagerFromContext(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	assert.Same(t, manager, jobs.ManagerFromContext(jobs.ContextWithManager(context.Background(), manager)))
+	assert.Nil(t, jobs.ManagerFromContext(context.Background()))
+}
@@ -0,0 +1,35 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// JobsMiddleware adds the job manager to the context of tool calls, so that tool handlers can run long
+// operations in the background.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type JobsMiddleware struct {
+	manager *Manager
+}
+
+// NewJobsMiddleware creates middleware that lets tool handlers start jobs with the manager. With a nil manager,
+// tool handlers cannot start jobs.
+func NewJobsMiddleware(manager *Manager) *JobsMiddleware {
+	return &JobsMiddleware{
+		manager: manager,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *JobsMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.manager == nil {
+			return next(ctx, method, req)
+		}
+		return next(ContextWithManager(ctx, m.manager), method, req)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobsMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		manager        *jobs.Manager
+		wantHadManager bool
+	}{
+		{
+			name:           "Tool handlers can start jobs",
+			manager:        jobs.NewManager(0, 0),
+			wantHadManager: true,
+		},
+		{
+			name:           "Tool handlers cannot start jobs without a manager",
+			manager:        nil,
+			wantHadManager: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var manager *jobs.Manager
+			server := mcptestutils.TestMcpServer(t)
+			mcp.AddTool(server, &mcp.Tool{Name: "clone_thing"}, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+				manager = jobs.ManagerFromContext(ctx)
+				return nil, map[string]any{"ok": true}, nil
+			})
+			server.AddReceivingMiddleware(jobs.NewJobsMiddleware(tt.manager).Handler)
+
+			result, err := mcptestutils.CallToolOverMcp(t, server, "clone_thing", map[string]any{})
+
+			require.NoError(t, err)
+			assert.False(t, result.IsError)
+			assert.Equal(t, tt.wantHadManager, manager != nil)
+		})
+	}
+}
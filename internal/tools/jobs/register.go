@@ -0,0 +1,39 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+func ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		GetJobStatusDef,
+		ListJobsDef,
+		CancelJobDef,
+	}
+}
+
+// RegisterTools adds the job tools allowed by the filter to the MCP server.
+func RegisterTools(ctx context.Context, server *mcp.Server, manager *Manager, toolFilter *filter.Filter) {
+	if toolFilter.ShouldIncludeTool(&GetJobStatusDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("tool", GetJobStatusDef.McpTool.Name))
+		mcp.AddTool(server, GetJobStatusDef.McpTool, GetJobStatusHandler(manager))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListJobsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("tool", ListJobsDef.McpTool.Name))
+		mcp.AddTool(server, ListJobsDef.McpTool, ListJobsHandler(manager))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CancelJobDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("tool", CancelJobDef.McpTool.Name))
+		mcp.AddTool(server, CancelJobDef.McpTool, CancelJobHandler(manager))
+	}
+}
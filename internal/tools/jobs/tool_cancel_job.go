@@ -0,0 +1,64 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CancelJobDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "cancel_job",
+		Title:        "Cancel Job",
+		Description:  `Cancel a running job started by a tool called with runInBackground, and wait for it to stop. The job stops before its next PingOne API request; changes it made before it was cancelled are kept, so check the resources of the environment after cancelling a job that makes changes.`,
+		InputSchema:  schema.MustGenerateSchema[CancelJobInput](),
+		OutputSchema: schema.MustGenerateSchema[CancelJobOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			// Cancelling a job only stops work of this server, it does not change PingOne
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type CancelJobInput struct {
+	JobId string `json:"jobId" jsonschema:"REQUIRED. The ID of the running job to cancel."`
+}
+
+type CancelJobOutput struct {
+	Job Job `json:"job" jsonschema:"The job, once it has stopped"`
+}
+
+// CancelJobHandler cancels a job of the manager
+func CancelJobHandler(manager *Manager) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CancelJobInput,
+) (
+	*mcp.CallToolResult,
+	*CancelJobOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CancelJobInput) (*mcp.CallToolResult, *CancelJobOutput, error) {
+		logger.FromContext(ctx).Debug("Cancelling job", slog.String("jobId", input.JobId))
+
+		job, err := manager.Cancel(ctx, input.JobId)
+		if err != nil {
+			toolErr := errs.NewToolError(CancelJobDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		return nil, &CancelJobOutput{
+			Job: job,
+		}, nil
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelJobHandler(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	job, err := manager.Start(context.Background(), testToolDef, "env-a", blockingRun(nil))
+	require.NoError(t, err)
+
+	handler := jobs.CancelJobHandler(manager)
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, jobs.CancelJobInput{JobId: job.Id})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, job.Id, output.Job.Id)
+	assert.Equal(t, jobs.StatusCancelled, output.Job.Status)
+}
+
+func TestCancelJobHandler_NotRunning(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	job, err := manager.Start(context.Background(), testToolDef, "env-a", func(ctx context.Context) (any, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	_, err = manager.Wait(t.Context(), job.Id)
+	require.NoError(t, err)
+
+	handler := jobs.CancelJobHandler(manager)
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, jobs.CancelJobInput{JobId: job.Id})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "is not running, its status is SUCCEEDED")
+}
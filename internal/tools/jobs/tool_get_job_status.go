@@ -0,0 +1,61 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetJobStatusDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_job_status",
+		Title: "Get Job Status",
+		Description: `Get the status and progress of a job started by a tool called with runInBackground, such as clone_environment, export_environment or import_scim_users. Once the job has succeeded, 'result' holds the output the tool would have returned had it not run in the background; if it failed, 'error' says why.
+
+Poll this tool while the job is RUNNING, waiting a few seconds between calls. Jobs are held in memory and are lost when the server restarts.`,
+		InputSchema:  schema.MustGenerateSchema[GetJobStatusInput](),
+		OutputSchema: schema.MustGenerateSchema[GetJobStatusOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetJobStatusInput struct {
+	JobId string `json:"jobId" jsonschema:"REQUIRED. The ID of the job, as returned by the tool that started it."`
+}
+
+type GetJobStatusOutput struct {
+	Job Job `json:"job" jsonschema:"The job"`
+}
+
+// GetJobStatusHandler returns a job of the manager
+func GetJobStatusHandler(manager *Manager) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetJobStatusInput,
+) (
+	*mcp.CallToolResult,
+	*GetJobStatusOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetJobStatusInput) (*mcp.CallToolResult, *GetJobStatusOutput, error) {
+		job, err := manager.Get(input.JobId)
+		if err != nil {
+			toolErr := errs.NewToolError(GetJobStatusDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		return nil, &GetJobStatusOutput{
+			Job: job,
+		}, nil
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobStatusHandler(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	job, err := manager.Start(context.Background(), testToolDef, "env-a", func(ctx context.Context) (any, error) {
+		return "cloned", nil
+	})
+	require.NoError(t, err)
+	_, err = manager.Wait(t.Context(), job.Id)
+	require.NoError(t, err)
+
+	handler := jobs.GetJobStatusHandler(manager)
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, jobs.GetJobStatusInput{JobId: job.Id})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, job.Id, output.Job.Id)
+	assert.Equal(t, jobs.StatusSucceeded, output.Job.Status)
+	assert.Equal(t, "cloned", output.Job.Result)
+}
+
+func TestGetJobStatusHandler_NotFound(t *testing.T) {
+	handler := jobs.GetJobStatusHandler(jobs.NewManager(0, 0))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, jobs.GetJobStatusInput{JobId: "unknown"})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "no job unknown found")
+}
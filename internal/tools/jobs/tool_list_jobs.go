@@ -0,0 +1,63 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListJobsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "list_jobs",
+		Title: "List Jobs",
+		Description: `List the jobs started by tools called with runInBackground, most recently started first, with their status and progress. Use to find a job whose ID was lost, or to see which jobs are still running. Results of succeeded jobs are only returned by get_job_status.
+
+Running jobs and the last 50 finished jobs are kept in memory, and are lost when the server restarts or switches profile.`,
+		InputSchema:  schema.MustGenerateSchema[ListJobsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListJobsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListJobsInput struct {
+	Status *Status `json:"status,omitempty" jsonschema:"OPTIONAL. Only list jobs with this status: RUNNING, SUCCEEDED, FAILED or CANCELLED."`
+}
+
+type ListJobsOutput struct {
+	Jobs []Job `json:"jobs" jsonschema:"The jobs, most recently started first, without their results"`
+}
+
+// ListJobsHandler lists the jobs of the manager
+func ListJobsHandler(manager *Manager) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListJobsInput,
+) (
+	*mcp.CallToolResult,
+	*ListJobsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListJobsInput) (*mcp.CallToolResult, *ListJobsOutput, error) {
+		result := &ListJobsOutput{
+			Jobs: []Job{},
+		}
+		for _, job := range manager.List() {
+			if input.Status != nil && job.Status != *input.Status {
+				continue
+			}
+			// Results can be large, so they are only returned for a single job
+			job.Result = nil
+			result.Jobs = append(result.Jobs, job)
+		}
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package jobs_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListJobsHandler(t *testing.T) {
+	manager := jobs.NewManager(0, 0)
+	succeeded, err := manager.Start(context.Background(), testToolDef, "env-a", func(ctx context.Context) (any, error) {
+		return "cloned", nil
+	})
+	require.NoError(t, err)
+	_, err = manager.Wait(t.Context(), succeeded.Id)
+	require.NoError(t, err)
+	running, err := manager.Start(context.Background(), testToolDef, "env-a", blockingRun(nil))
+	require.NoError(t, err)
+	defer manager.Clear()
+
+	handler := jobs.ListJobsHandler(manager)
+
+	t.Run("All jobs", func(t *testing.T) {
+		mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, jobs.ListJobsInput{})
+
+		testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+		require.Len(t, output.Jobs, 2)
+		assert.Equal(t, running.Id, output.Jobs[0].Id)
+		assert.Equal(t, succeeded.Id, output.Jobs[1].Id)
+		assert.Nil(t, output.Jobs[1].Result, "results should only be returned by get_job_status")
+	})
+
+	t.Run("Filtered by status", func(t *testing.T) {
+		mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, jobs.ListJobsInput{Status: testutils.Pointer(jobs.StatusRunning)})
+
+		testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+		require.Len(t, output.Jobs, 1)
+		assert.Equal(t, running.Id, output.Jobs[0].Id)
+	})
+}
+
+func TestListJobsHandler_NoJobs(t *testing.T) {
+	handler := jobs.ListJobsHandler(jobs.NewManager(0, 0))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, jobs.ListJobsInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Jobs)
+	assert.Empty(t, output.Jobs)
+}
@@ -0,0 +1,26 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-go-client/pingone"
+)
+
+type LicensesClient interface {
+	GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error)
+	UpdateEnvironment(ctx context.Context, environmentId uuid.UUID, updateRequest management.Environment) (*management.Environment, *http.Response, error)
+	GetLicense(ctx context.Context, organizationId string, licenseId string) (*management.License, *http.Response, error)
+	GetEnvironments(ctx context.Context) (management.EntityArrayPagedIterator, error)
+	GetLicenses(ctx context.Context, organizationId string) (management.EntityArrayPagedIterator, error)
+	// GetTotalIdentities returns the daily total identities counts of an environment for the date range of the filter
+	GetTotalIdentities(ctx context.Context, environmentId uuid.UUID, filter string) (*pingone.DirectoryTotalIdentitiesCountCollectionResponse, *http.Response, error)
+}
+
+type LicensesClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (LicensesClient, error)
+}
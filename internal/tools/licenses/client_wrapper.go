@@ -0,0 +1,154 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	pingonelegacy "github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ LicensesClient = &PingOneClientLicensesWrapper{}
+var _ LicensesClientFactory = &PingOneClientLicensesWrapperFactory{}
+
+type PingOneClientLicensesWrapper struct {
+	client *pingonelegacy.Client
+}
+
+type PingOneClientLicensesWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientLicensesWrapper(client *pingonelegacy.Client) *PingOneClientLicensesWrapper {
+	return &PingOneClientLicensesWrapper{client: client}
+}
+
+func NewPingOneClientLicensesWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientLicensesWrapperFactory {
+	return &PingOneClientLicensesWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientLicensesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (LicensesClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientLicensesWrapper(client), nil
+}
+
+func (p *PingOneClientLicensesWrapper) GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.EnvironmentsApi.ReadOneEnvironment(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve environment by ID",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientLicensesWrapper) UpdateEnvironment(ctx context.Context, environmentId uuid.UUID, updateRequest management.Environment) (*management.Environment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.EnvironmentsApi.UpdateEnvironment(ctx, environmentId.String()).Environment(updateRequest)
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update environment by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("licenseId", updateRequest.License.Id),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientLicensesWrapper) GetLicense(ctx context.Context, organizationId string, licenseId string) (*management.License, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.LicensesApi.ReadOneLicense(ctx, organizationId, licenseId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve license by ID",
+		slog.String("organizationId", organizationId),
+		slog.String("licenseId", licenseId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientLicensesWrapper) GetEnvironments(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.EnvironmentsApi.ReadAllEnvironments(ctx)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve environments")
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientLicensesWrapper) GetLicenses(ctx context.Context, organizationId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.LicensesApi.ReadAllLicenses(ctx, organizationId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve licenses",
+		slog.String("organizationId", organizationId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientLicensesWrapper) GetTotalIdentities(ctx context.Context, environmentId uuid.UUID, filter string) (*pingone.DirectoryTotalIdentitiesCountCollectionResponse, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.TotalIdentitiesApi.EnvironmentsEnvironmentIDTotalIdentitiesGet(ctx, environmentId.String()).Filter(filter)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve total identities",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("filter", filter),
+	)
+	// The legacy SDK does not decode the total identities response, so it is decoded here
+	httpResponse, err := getRequest.Execute()
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	report, err := decodeTotalIdentities(httpResponse)
+	return report, httpResponse, err
+}
+
+func decodeTotalIdentities(httpResponse *http.Response) (*pingone.DirectoryTotalIdentitiesCountCollectionResponse, error) {
+	if httpResponse == nil || httpResponse.Body == nil {
+		return nil, errors.New("no total identities data in response")
+	}
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read total identities response: %w", err)
+	}
+	report := &pingone.DirectoryTotalIdentitiesCountCollectionResponse{}
+	if err := json.Unmarshal(body, report); err != nil {
+		return nil, fmt.Errorf("failed to decode total identities response: %w", err)
+	}
+	return report, nil
+}
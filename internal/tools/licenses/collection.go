@@ -0,0 +1,57 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "licenses"
+
+var _ collections.LegacySdkCollection = &LicensesCollection{}
+
+type LicensesCollection struct{}
+
+func (c *LicensesCollection) Name() string {
+	return CollectionName
+}
+
+func (c *LicensesCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	licensesClientFactory := NewPingOneClientLicensesWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&GetLicenseUtilizationDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetLicenseUtilizationDef.McpTool.Name))
+		mcp.AddTool(server, GetLicenseUtilizationDef.McpTool, GetLicenseUtilizationHandler(licensesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ReassignEnvironmentLicenseDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ReassignEnvironmentLicenseDef.McpTool.Name))
+		mcp.AddTool(server, ReassignEnvironmentLicenseDef.McpTool, ReassignEnvironmentLicenseHandler(licensesClientFactory))
+	}
+
+	return nil
+}
+
+func (c *LicensesCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		GetLicenseUtilizationDef,
+		ReassignEnvironmentLicenseDef,
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/licenses"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ licenses.LicensesClient = &mockPingOneClientLicensesWrapper{}
+var _ licenses.LicensesClientFactory = &mockPingOneClientLicensesWrapperFactory{}
+
+type mockPingOneClientLicensesWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientLicensesWrapperFactory struct {
+	mockClient licenses.LicensesClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientLicensesWrapperFactory(mockClient licenses.LicensesClient, err error) *mockPingOneClientLicensesWrapperFactory {
+	return &mockPingOneClientLicensesWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientLicensesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (licenses.LicensesClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientLicensesWrapper) GetEnvironment(ctx context.Context, environmentId uuid.UUID) (*management.Environment, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	var response *management.Environment
+	response, ok := args.Get(0).(*management.Environment)
+	if !ok && args.Get(0) != nil {
+		panic("GetEnvironment mock setup error: expected *management.Environment or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetEnvironment mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientLicensesWrapper) UpdateEnvironment(ctx context.Context, environmentId uuid.UUID, updateRequest management.Environment) (*management.Environment, *http.Response, error) {
+	args := p.Called(ctx, environmentId, updateRequest)
+	var response *management.Environment
+	response, ok := args.Get(0).(*management.Environment)
+	if !ok && args.Get(0) != nil {
+		panic("UpdateEnvironment mock setup error: expected *management.Environment or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("UpdateEnvironment mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientLicensesWrapper) GetLicense(ctx context.Context, organizationId string, licenseId string) (*management.License, *http.Response, error) {
+	args := p.Called(ctx, organizationId, licenseId)
+	var response *management.License
+	response, ok := args.Get(0).(*management.License)
+	if !ok && args.Get(0) != nil {
+		panic("GetLicense mock setup error: expected *management.License or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetLicense mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientLicensesWrapper) GetEnvironments(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx)
+	var response management.EntityArrayPagedIterator
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok && args.Get(0) != nil {
+		panic("GetEnvironments mock setup error: expected management.EntityArrayPagedIterator or nil")
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientLicensesWrapper) GetLicenses(ctx context.Context, organizationId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, organizationId)
+	var response management.EntityArrayPagedIterator
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok && args.Get(0) != nil {
+		panic("GetLicenses mock setup error: expected management.EntityArrayPagedIterator or nil")
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientLicensesWrapper) GetTotalIdentities(ctx context.Context, environmentId uuid.UUID, filter string) (*pingone.DirectoryTotalIdentitiesCountCollectionResponse, *http.Response, error) {
+	args := p.Called(ctx, environmentId, filter)
+	var response *pingone.DirectoryTotalIdentitiesCountCollectionResponse
+	response, ok := args.Get(0).(*pingone.DirectoryTotalIdentitiesCountCollectionResponse)
+	if !ok && args.Get(0) != nil {
+		panic("GetTotalIdentities mock setup error: expected *pingone.DirectoryTotalIdentitiesCountCollectionResponse or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetTotalIdentities mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
@@ -0,0 +1,375 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetLicenseUtilizationDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_license_utilization",
+		Title: "Get PingOne License Utilization",
+		Description: `Report license utilization across all environments of the organization in one call: for each license, the environments assigned to it against the environments it allows, and the total identities of each environment against the identities the license allows per environment. Use for license reviews and true-up reports instead of reading each license and environment separately.
+
+Total identities are the identities stored in an environment on the given day, not active identities, so they are not comparable with the annual or monthly active identities a license includes, which are reported for reference only. An environment whose total identities cannot be read is reported with the error rather than failing the report.
+
+Each environment is validated before its total identities are read, as if a tool had been called for it. Environments the server is not permitted to read from, such as PRODUCTION environments under the production guardrail or environments outside the server's environment scope, are left out of the licenses and totals, and listed in skippedEnvironments with their ID and the reason only.
+
+Set includeCsv to also return the report as CSV text with one row per environment, for spreadsheets.`,
+		InputSchema:  schema.MustGenerateSchema[GetLicenseUtilizationInput](),
+		OutputSchema: schema.MustGenerateSchema[GetLicenseUtilizationOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetLicenseUtilizationInput struct {
+	Date       *time.Time `json:"date,omitempty" jsonschema:"OPTIONAL. The day to count total identities for, in ISO 8601 format with timezone (e.g., '2025-10-01T00:00:00Z'). Defaults to today at midnight UTC."`
+	IncludeCsv *bool      `json:"includeCsv,omitempty" jsonschema:"OPTIONAL. Also return the report as CSV text with one row per environment. Defaults to false."`
+	Fields     []string   `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'summary' and 'licenses.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+// EnvironmentUtilization is the identity utilization of an environment against its license
+type EnvironmentUtilization struct {
+	EnvironmentId              string   `json:"environmentId" jsonschema:"The UUID of the environment"`
+	Name                       string   `json:"name" jsonschema:"The name of the environment"`
+	Type                       string   `json:"type" jsonschema:"The environment type: PRODUCTION or SANDBOX"`
+	Region                     string   `json:"region,omitempty" jsonschema:"The region code of the environment, such as NA or EU"`
+	TotalIdentities            *int32   `json:"totalIdentities,omitempty" jsonschema:"The total identities of the environment on the day, if they could be read"`
+	IdentityUtilizationPercent *float64 `json:"identityUtilizationPercent,omitempty" jsonschema:"The total identities as a percentage of the identities the license allows per environment, if limited"`
+	IdentityCountError         string   `json:"identityCountError,omitempty" jsonschema:"Why the total identities of the environment could not be read"`
+}
+
+// LicenseUtilization is the utilization of a license by the environments assigned to it
+type LicenseUtilization struct {
+	LicenseId                       string                        `json:"licenseId" jsonschema:"The UUID of the license"`
+	Name                            string                        `json:"name,omitempty" jsonschema:"The name of the license, empty if environments are assigned to a license that was not returned"`
+	Package                         *string                       `json:"package,omitempty" jsonschema:"The license package, such as TRIAL or GLOBAL"`
+	Status                          *management.EnumLicenseStatus `json:"status,omitempty" jsonschema:"The license status: ACTIVE, EXPIRED, FUTURE or TERMINATED"`
+	ExpiresAt                       *time.Time                    `json:"expiresAt,omitempty" jsonschema:"When the license expires"`
+	AssignedEnvironments            int                           `json:"assignedEnvironments" jsonschema:"The number of environments assigned to the license"`
+	MaxEnvironments                 *int32                        `json:"maxEnvironments,omitempty" jsonschema:"The maximum number of environments the license allows, if limited"`
+	EnvironmentUtilizationPercent   *float64                      `json:"environmentUtilizationPercent,omitempty" jsonschema:"The assigned environments as a percentage of the maximum, if limited"`
+	MaxIdentitiesPerEnvironment     *int32                        `json:"maxIdentitiesPerEnvironment,omitempty" jsonschema:"The maximum total identities the license allows in each environment, if limited"`
+	AnnualActiveIdentitiesIncluded  *int32                        `json:"annualActiveIdentitiesIncluded,omitempty" jsonschema:"The annual active identities the license includes, for reference"`
+	MonthlyActiveIdentitiesIncluded *int32                        `json:"monthlyActiveIdentitiesIncluded,omitempty" jsonschema:"The monthly active identities the license includes, for reference"`
+	TotalIdentities                 int64                         `json:"totalIdentities" jsonschema:"The total identities of the environments assigned to the license whose counts could be read"`
+	Environments                    []EnvironmentUtilization      `json:"environments" jsonschema:"The environments assigned to the license"`
+}
+
+// SkippedEnvironment is an environment left out of the report, as the server is not permitted to read from it.
+// Only its ID is reported, so that the report does not describe environments the server must not act on.
+type SkippedEnvironment struct {
+	EnvironmentId string `json:"environmentId" jsonschema:"The UUID of the environment"`
+	Reason        string `json:"reason" jsonschema:"Why the environment was skipped"`
+}
+
+type LicenseUtilizationSummary struct {
+	Licenses                         int   `json:"licenses" jsonschema:"The number of licenses reported"`
+	Environments                     int   `json:"environments" jsonschema:"The number of environments reported"`
+	TotalIdentities                  int64 `json:"totalIdentities" jsonschema:"The total identities of all environments whose counts could be read"`
+	EnvironmentsWithoutIdentityCount int   `json:"environmentsWithoutIdentityCount" jsonschema:"The number of environments whose total identities could not be read"`
+	SkippedEnvironments              int   `json:"skippedEnvironments" jsonschema:"The number of environments left out of the report, as the server is not permitted to read from them"`
+}
+
+type GetLicenseUtilizationOutput struct {
+	OrganizationId      string                    `json:"organizationId" jsonschema:"The UUID of the organization"`
+	Date                time.Time                 `json:"date" jsonschema:"The day total identities were counted for"`
+	Summary             LicenseUtilizationSummary `json:"summary" jsonschema:"The totals of the report"`
+	Licenses            []LicenseUtilization      `json:"licenses" jsonschema:"The utilization of each license of the organization, and of licenses that environments are assigned to but were not returned"`
+	SkippedEnvironments []SkippedEnvironment      `json:"skippedEnvironments,omitempty" jsonschema:"The environments left out of the report, such as PRODUCTION environments under the production guardrail or environments outside the environment scope of the server"`
+	Csv                 *string                   `json:"csv,omitempty" jsonschema:"The report as CSV text with a header row and one row per environment, and a row for each license without environments, if requested"`
+}
+
+// GetLicenseUtilizationHandler reports the utilization of the PingOne licenses of the organization using the provided client
+func GetLicenseUtilizationHandler(licensesClientFactory LicensesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetLicenseUtilizationInput,
+) (
+	*mcp.CallToolResult,
+	*GetLicenseUtilizationOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetLicenseUtilizationInput) (*mcp.CallToolResult, *GetLicenseUtilizationOutput, error) {
+		client, err := licensesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetLicenseUtilizationDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		date := time.Now().UTC()
+		if input.Date != nil {
+			date = input.Date.UTC()
+		}
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+		environmentsIterator, err := client.GetEnvironments(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetLicenseUtilizationDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		var environments []management.Environment
+		err = collections.ForEachPage(ctx, environmentsIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			environments = append(environments, embedded.Environments...)
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		organizationId := ""
+		for _, environment := range environments {
+			if environment.Organization != nil && environment.Organization.GetId() != "" {
+				organizationId = environment.Organization.GetId()
+				break
+			}
+		}
+		if organizationId == "" {
+			toolErr := errs.NewToolError(GetLicenseUtilizationDef.McpTool.Name, errors.New("unable to determine the organization, no environment with an organization was returned"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		licensesIterator, err := client.GetLicenses(ctx, organizationId)
+		if err != nil {
+			toolErr := errs.NewToolError(GetLicenseUtilizationDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		output := &GetLicenseUtilizationOutput{
+			OrganizationId: organizationId,
+			Date:           date,
+			Licenses:       []LicenseUtilization{},
+		}
+		licenseIndexes := map[string]int{}
+		err = collections.ForEachPage(ctx, licensesIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			for _, license := range embedded.Licenses {
+				licenseIndexes[strings.ToLower(license.GetId())] = len(output.Licenses)
+				output.Licenses = append(output.Licenses, licenseUtilization(license))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		logger.FromContext(ctx).Debug("Counting total identities of environments",
+			slog.String("organizationId", organizationId),
+			slog.Int("environments", len(environments)),
+			slog.Int("licenses", len(output.Licenses)),
+			slog.Time("date", date))
+
+		filter := fmt.Sprintf("startDate eq \"%s\"", date.Format("2006-01-02T15:04:05-07:00"))
+		reported := 0
+		for _, environment := range environments {
+			if reason := skipReason(ctx, environment); reason != "" {
+				logger.FromContext(ctx).Info("Skipping environment the server is not permitted to read from",
+					slog.String("environmentId", environment.GetId()),
+					slog.String("reason", reason))
+				output.SkippedEnvironments = append(output.SkippedEnvironments, SkippedEnvironment{
+					EnvironmentId: environment.GetId(),
+					Reason:        reason,
+				})
+				continue
+			}
+			reported++
+
+			licenseId := environment.License.Id
+			index, ok := licenseIndexes[strings.ToLower(licenseId)]
+			if !ok {
+				index = len(output.Licenses)
+				licenseIndexes[strings.ToLower(licenseId)] = index
+				output.Licenses = append(output.Licenses, LicenseUtilization{LicenseId: licenseId, Environments: []EnvironmentUtilization{}})
+			}
+			license := &output.Licenses[index]
+
+			utilization := EnvironmentUtilization{
+				EnvironmentId: environment.GetId(),
+				Name:          environment.Name,
+				Type:          string(environment.Type),
+				Region:        environmentRegion(environment),
+			}
+			totalIdentities, err := getTotalIdentities(ctx, client, environment.GetId(), filter)
+			if err != nil {
+				logger.FromContext(ctx).Warn("Unable to retrieve the total identities of the environment",
+					slog.String("environmentId", environment.GetId()),
+					slog.Any("error", err))
+				utilization.IdentityCountError = err.Error()
+				output.Summary.EnvironmentsWithoutIdentityCount++
+			} else {
+				utilization.TotalIdentities = &totalIdentities
+				utilization.IdentityUtilizationPercent = percentOf(int64(totalIdentities), license.MaxIdentitiesPerEnvironment)
+				license.TotalIdentities += int64(totalIdentities)
+				output.Summary.TotalIdentities += int64(totalIdentities)
+			}
+			license.Environments = append(license.Environments, utilization)
+			license.AssignedEnvironments++
+		}
+
+		for i := range output.Licenses {
+			output.Licenses[i].EnvironmentUtilizationPercent = percentOf(int64(output.Licenses[i].AssignedEnvironments), output.Licenses[i].MaxEnvironments)
+		}
+		output.Summary.Licenses = len(output.Licenses)
+		output.Summary.Environments = reported
+		output.Summary.SkippedEnvironments = len(output.SkippedEnvironments)
+
+		if input.IncludeCsv != nil && *input.IncludeCsv {
+			report, err := licenseUtilizationCsv(output.Licenses)
+			if err != nil {
+				toolErr := errs.NewToolError(GetLicenseUtilizationDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			output.Csv = &report
+		}
+
+		return nil, output, nil
+	}
+}
+
+// skipReason returns why the environment must be left out of the report, or an empty string if it can be read from.
+// The reason does not include the validation error, as it may describe the environment.
+func skipReason(ctx context.Context, environment management.Environment) string {
+	environmentId, err := uuid.Parse(environment.GetId())
+	if err != nil {
+		return fmt.Sprintf("invalid environment ID %q", environment.GetId())
+	}
+	if allows := environments.EnvironmentFilterFromContext(ctx); allows != nil && !allows(environmentId) {
+		return "the environment is outside the environment scope of the server"
+	}
+	validate := environments.EnvironmentReadValidatorFromContext(ctx)
+	if validate == nil {
+		return ""
+	}
+	if err := validate(ctx, environmentId); err != nil {
+		logger.FromContext(ctx).Debug("Environment read validation failed",
+			slog.String("environmentId", environmentId.String()),
+			slog.String("error", err.Error()))
+		if environment.Type == management.ENUMENVIRONMENTTYPE_PRODUCTION {
+			return "reading from PRODUCTION environments is not allowed by the production guardrail of the server"
+		}
+		return "the server is not permitted to read from the environment"
+	}
+	return ""
+}
+
+// getTotalIdentities returns the total identities of an environment on the day of the filter
+func getTotalIdentities(ctx context.Context, client LicensesClient, environmentId string, filter string) (int32, error) {
+	parsedEnvironmentId, err := uuid.Parse(environmentId)
+	if err != nil {
+		return 0, fmt.Errorf("invalid environment ID %q: %w", environmentId, err)
+	}
+	report, httpResponse, err := client.GetTotalIdentities(ctx, parsedEnvironmentId, filter)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		return 0, errs.NewApiError(httpResponse, err)
+	}
+	if report == nil || report.Embedded == nil || len(report.Embedded.TotalIdentities) == 0 || report.Embedded.TotalIdentities[0].TotalIdentities == nil {
+		return 0, errors.New("no total identities count for the day")
+	}
+	return *report.Embedded.TotalIdentities[0].TotalIdentities, nil
+}
+
+func licenseUtilization(license management.License) LicenseUtilization {
+	utilization := LicenseUtilization{
+		LicenseId:    license.GetId(),
+		Name:         license.Name,
+		Package:      license.Package,
+		Status:       license.Status,
+		ExpiresAt:    license.ExpiresAt,
+		Environments: []EnvironmentUtilization{},
+	}
+	if license.Environments != nil {
+		utilization.MaxEnvironments = license.Environments.Max
+	}
+	if license.Users != nil {
+		utilization.MaxIdentitiesPerEnvironment = license.Users.Max
+		utilization.AnnualActiveIdentitiesIncluded = license.Users.AnnualActiveIncluded
+		utilization.MonthlyActiveIdentitiesIncluded = license.Users.MonthlyActiveIncluded
+	}
+	return utilization
+}
+
+// percentOf returns the value as a percentage of the limit rounded to one decimal place, or nil if there is no limit
+func percentOf(value int64, limit *int32) *float64 {
+	if limit == nil || *limit <= 0 {
+		return nil
+	}
+	percent := math.Round(float64(value)*1000/float64(*limit)) / 10
+	return &percent
+}
+
+// licenseUtilizationCsv returns the report as CSV text with one row per environment, and a row for each license
+// without environments
+func licenseUtilizationCsv(licenses []LicenseUtilization) (string, error) {
+	var report strings.Builder
+	writer := csv.NewWriter(&report)
+	rows := [][]string{{
+		"licenseId", "licenseName", "licenseStatus", "assignedEnvironments", "maxEnvironments", "maxIdentitiesPerEnvironment",
+		"environmentId", "environmentName", "environmentType", "region", "totalIdentities", "identityUtilizationPercent", "identityCountError",
+	}}
+	for _, license := range licenses {
+		licenseColumns := []string{
+			license.LicenseId,
+			license.Name,
+			csvValue(license.Status),
+			strconv.Itoa(license.AssignedEnvironments),
+			csvValue(license.MaxEnvironments),
+			csvValue(license.MaxIdentitiesPerEnvironment),
+		}
+		if len(license.Environments) == 0 {
+			rows = append(rows, append(licenseColumns, "", "", "", "", "", "", ""))
+			continue
+		}
+		for _, environment := range license.Environments {
+			rows = append(rows, append(slices.Clone(licenseColumns),
+				environment.EnvironmentId,
+				environment.Name,
+				environment.Type,
+				environment.Region,
+				csvValue(environment.TotalIdentities),
+				csvValue(environment.IdentityUtilizationPercent),
+				environment.IdentityCountError,
+			))
+		}
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return "", fmt.Errorf("failed to write license utilization CSV: %w", err)
+	}
+	return report.String(), nil
+}
+
+// csvValue returns the CSV column of an optional value, empty if it is not set
+func csvValue[T int32 | float64 | management.EnumLicenseStatus](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(*value)
+}
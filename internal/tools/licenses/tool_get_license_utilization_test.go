@@ -0,0 +1,284 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/licenses"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSecondEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	testUnlistedLicenseId   = "33333333-3333-4333-8333-333333333333"
+	testUtilizationDate     = time.Date(2025, time.October, 1, 0, 0, 0, 0, time.UTC)
+	testUtilizationFilter   = `startDate eq "2025-10-01T00:00:00+00:00"`
+)
+
+func utilizationEnvironment(environmentId uuid.UUID, name string, licenseId string) management.Environment {
+	environment := testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, licenseId)
+	environment.Id = testutils.Pointer(environmentId.String())
+	environment.Name = name
+	return *environment
+}
+
+func utilizationLicense(licenseId string, maxEnvironments int32, maxIdentities int32) management.License {
+	license := testLicense(licenseId, 0, maxEnvironments)
+	license.Users = &management.LicenseUsers{
+		Max:                  testutils.Pointer(maxIdentities),
+		AnnualActiveIncluded: testutils.Pointer(int32(50000)),
+	}
+	return *license
+}
+
+func environmentsPages(environments ...management.Environment) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Environments: environments}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func licensesPages(licenseList ...management.License) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Licenses: licenseList}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func totalIdentitiesResponse(count int32) *pingone.DirectoryTotalIdentitiesCountCollectionResponse {
+	return &pingone.DirectoryTotalIdentitiesCountCollectionResponse{
+		Embedded: &pingone.DirectoryTotalIdentitiesCountCollectionResponseEmbedded{
+			TotalIdentities: []pingone.DirectoryTotalIdentitiesCountResponse{{TotalIdentities: testutils.Pointer(count)}},
+		},
+	}
+}
+
+func TestGetLicenseUtilizationHandler(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockClient.On("GetEnvironments", mock.Anything).Return(environmentsPages(
+		utilizationEnvironment(testEnvironmentId, "Staging", testCurrentLicenseId),
+		utilizationEnvironment(testSecondEnvironmentId, "Legacy", testUnlistedLicenseId),
+	), nil)
+	mockClient.On("GetLicenses", mock.Anything, testOrganizationId).Return(licensesPages(
+		utilizationLicense(testCurrentLicenseId, 4, 1000),
+		utilizationLicense(testTargetLicenseId.String(), 10, 500),
+	), nil)
+	mockClient.On("GetTotalIdentities", mock.Anything, testEnvironmentId, testUtilizationFilter).Return(totalIdentitiesResponse(250), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetTotalIdentities", mock.Anything, testSecondEnvironmentId, testUtilizationFilter).Return(totalIdentitiesResponse(40), &http.Response{StatusCode: 200}, nil)
+
+	handler := licenses.GetLicenseUtilizationHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	date := testUtilizationDate.Add(15 * time.Hour)
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.GetLicenseUtilizationInput{Date: &date})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testOrganizationId, output.OrganizationId)
+	assert.Equal(t, testUtilizationDate, output.Date)
+	assert.Equal(t, licenses.LicenseUtilizationSummary{Licenses: 3, Environments: 2, TotalIdentities: 290}, output.Summary)
+	require.Len(t, output.Licenses, 3)
+
+	current := output.Licenses[0]
+	assert.Equal(t, testCurrentLicenseId, current.LicenseId)
+	assert.Equal(t, 1, current.AssignedEnvironments)
+	assert.Equal(t, testutils.Pointer(25.0), current.EnvironmentUtilizationPercent)
+	assert.Equal(t, testutils.Pointer(int32(1000)), current.MaxIdentitiesPerEnvironment)
+	assert.Equal(t, testutils.Pointer(int32(50000)), current.AnnualActiveIdentitiesIncluded)
+	assert.Equal(t, int64(250), current.TotalIdentities)
+	assert.Equal(t, []licenses.EnvironmentUtilization{
+		{
+			EnvironmentId:              testEnvironmentId.String(),
+			Name:                       "Staging",
+			Type:                       "SANDBOX",
+			Region:                     "NA",
+			TotalIdentities:            testutils.Pointer(int32(250)),
+			IdentityUtilizationPercent: testutils.Pointer(25.0),
+		},
+	}, current.Environments)
+
+	unused := output.Licenses[1]
+	assert.Equal(t, testTargetLicenseId.String(), unused.LicenseId)
+	assert.Equal(t, 0, unused.AssignedEnvironments)
+	assert.Equal(t, testutils.Pointer(0.0), unused.EnvironmentUtilizationPercent)
+	assert.Empty(t, unused.Environments)
+
+	unlisted := output.Licenses[2]
+	assert.Equal(t, testUnlistedLicenseId, unlisted.LicenseId)
+	assert.Empty(t, unlisted.Name)
+	assert.Equal(t, 1, unlisted.AssignedEnvironments)
+	assert.Nil(t, unlisted.EnvironmentUtilizationPercent)
+	require.Len(t, unlisted.Environments, 1)
+	assert.Nil(t, unlisted.Environments[0].IdentityUtilizationPercent)
+
+	assert.Nil(t, output.Csv)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetLicenseUtilizationHandler_IdentityCountErrorReportedOnEnvironment(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockClient.On("GetEnvironments", mock.Anything).Return(environmentsPages(
+		utilizationEnvironment(testEnvironmentId, "Staging", testCurrentLicenseId),
+		utilizationEnvironment(testSecondEnvironmentId, "Development", testCurrentLicenseId),
+	), nil)
+	mockClient.On("GetLicenses", mock.Anything, testOrganizationId).Return(licensesPages(utilizationLicense(testCurrentLicenseId, 4, 1000)), nil)
+	mockClient.On("GetTotalIdentities", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+	mockClient.On("GetTotalIdentities", mock.Anything, testSecondEnvironmentId, mock.Anything).Return(&pingone.DirectoryTotalIdentitiesCountCollectionResponse{}, &http.Response{StatusCode: 200}, nil)
+
+	handler := licenses.GetLicenseUtilizationHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.GetLicenseUtilizationInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 2, output.Summary.EnvironmentsWithoutIdentityCount)
+	assert.Equal(t, int64(0), output.Summary.TotalIdentities)
+	require.Len(t, output.Licenses, 1)
+	require.Len(t, output.Licenses[0].Environments, 2)
+	assert.Contains(t, output.Licenses[0].Environments[0].IdentityCountError, "forbidden")
+	assert.Nil(t, output.Licenses[0].Environments[0].TotalIdentities)
+	assert.Equal(t, "no total identities count for the day", output.Licenses[0].Environments[1].IdentityCountError)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetLicenseUtilizationHandler_SkipsEnvironmentsNotPermitted(t *testing.T) {
+	outOfScopeEnvironmentId := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	production := utilizationEnvironment(testSecondEnvironmentId, "Customers", testCurrentLicenseId)
+	production.Type = management.ENUMENVIRONMENTTYPE_PRODUCTION
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockClient.On("GetEnvironments", mock.Anything).Return(environmentsPages(
+		utilizationEnvironment(testEnvironmentId, "Staging", testCurrentLicenseId),
+		production,
+		utilizationEnvironment(outOfScopeEnvironmentId, "Partner", testCurrentLicenseId),
+	), nil)
+	mockClient.On("GetLicenses", mock.Anything, testOrganizationId).Return(licensesPages(utilizationLicense(testCurrentLicenseId, 4, 1000)), nil)
+	mockClient.On("GetTotalIdentities", mock.Anything, testEnvironmentId, mock.Anything).Return(totalIdentitiesResponse(250), &http.Response{StatusCode: 200}, nil)
+
+	ctx := environments.ContextWithEnvironmentFilter(context.Background(), func(environmentId uuid.UUID) bool {
+		return environmentId != outOfScopeEnvironmentId
+	})
+	var validated []uuid.UUID
+	ctx = environments.ContextWithEnvironmentReadValidator(ctx, func(ctx context.Context, environmentId uuid.UUID) error {
+		validated = append(validated, environmentId)
+		if environmentId == testSecondEnvironmentId {
+			return errors.New("this read operation is not allowed against PRODUCTION environments (environment ID: x, name: Customers)")
+		}
+		return nil
+	})
+	handler := licenses.GetLicenseUtilizationHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(ctx, &mcp.CallToolRequest{}, licenses.GetLicenseUtilizationInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []uuid.UUID{testEnvironmentId, testSecondEnvironmentId}, validated, "out of scope environments should not be validated")
+	assert.Equal(t, licenses.LicenseUtilizationSummary{Licenses: 1, Environments: 1, TotalIdentities: 250, SkippedEnvironments: 2}, output.Summary)
+	require.Len(t, output.Licenses, 1)
+	assert.Equal(t, 1, output.Licenses[0].AssignedEnvironments)
+	require.Len(t, output.Licenses[0].Environments, 1)
+	assert.Equal(t, testEnvironmentId.String(), output.Licenses[0].Environments[0].EnvironmentId)
+	assert.Equal(t, []licenses.SkippedEnvironment{
+		{EnvironmentId: testSecondEnvironmentId.String(), Reason: "reading from PRODUCTION environments is not allowed by the production guardrail of the server"},
+		{EnvironmentId: outOfScopeEnvironmentId.String(), Reason: "the environment is outside the environment scope of the server"},
+	}, output.SkippedEnvironments, "skipped environments should only be described by their ID")
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "GetTotalIdentities", mock.Anything, testSecondEnvironmentId, mock.Anything)
+	mockClient.AssertNotCalled(t, "GetTotalIdentities", mock.Anything, outOfScopeEnvironmentId, mock.Anything)
+}
+
+func TestGetLicenseUtilizationHandler_IncludeCsv(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockClient.On("GetEnvironments", mock.Anything).Return(environmentsPages(
+		utilizationEnvironment(testEnvironmentId, "Staging, EU", testCurrentLicenseId),
+	), nil)
+	mockClient.On("GetLicenses", mock.Anything, testOrganizationId).Return(licensesPages(
+		utilizationLicense(testCurrentLicenseId, 4, 1000),
+		utilizationLicense(testTargetLicenseId.String(), 10, 500),
+	), nil)
+	mockClient.On("GetTotalIdentities", mock.Anything, testEnvironmentId, testUtilizationFilter).Return(totalIdentitiesResponse(333), &http.Response{StatusCode: 200}, nil)
+
+	handler := licenses.GetLicenseUtilizationHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.GetLicenseUtilizationInput{
+		Date:       &testUtilizationDate,
+		IncludeCsv: testutils.Pointer(true),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.Csv)
+	assert.Equal(t, "licenseId,licenseName,licenseStatus,assignedEnvironments,maxEnvironments,maxIdentitiesPerEnvironment,environmentId,environmentName,environmentType,region,totalIdentities,identityUtilizationPercent,identityCountError\n"+
+		testCurrentLicenseId+",License 1111,ACTIVE,1,4,1000,"+testEnvironmentId.String()+",\"Staging, EU\",SANDBOX,NA,333,33.3,\n"+
+		testTargetLicenseId.String()+",License 2222,ACTIVE,0,10,500,,,,,,,\n", *output.Csv)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetLicenseUtilizationHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientLicensesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Environments client error",
+			setupMock: func(mockClient *mockPingOneClientLicensesWrapper) {
+				mockClient.On("GetEnvironments", mock.Anything).Return(nil, errors.New("client error"))
+			},
+			wantErrContains: "client error",
+		},
+		{
+			name: "Environments API error",
+			setupMock: func(mockClient *mockPingOneClientLicensesWrapper) {
+				mockClient.On("GetEnvironments", mock.Anything).Return(testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+					{HTTPResponse: &http.Response{StatusCode: 403}, Error: errors.New("forbidden")},
+				}), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+		{
+			name: "No organization",
+			setupMock: func(mockClient *mockPingOneClientLicensesWrapper) {
+				mockClient.On("GetEnvironments", mock.Anything).Return(environmentsPages(), nil)
+			},
+			wantErrContains: "unable to determine the organization",
+		},
+		{
+			name: "Licenses API error",
+			setupMock: func(mockClient *mockPingOneClientLicensesWrapper) {
+				mockClient.On("GetEnvironments", mock.Anything).Return(environmentsPages(utilizationEnvironment(testEnvironmentId, "Staging", testCurrentLicenseId)), nil)
+				mockClient.On("GetLicenses", mock.Anything, testOrganizationId).Return(testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+					{HTTPResponse: &http.Response{StatusCode: 403}, Error: errors.New("licenses forbidden")},
+				}), nil)
+			},
+			wantErrContains: "licenses forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientLicensesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := licenses.GetLicenseUtilizationHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.GetLicenseUtilizationInput{})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetLicenseUtilizationHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := licenses.GetLicenseUtilizationHandler(NewMockPingOneClientLicensesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.GetLicenseUtilizationInput{})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,330 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	CheckOrganization        = "organization"
+	CheckStatus              = "status"
+	CheckEnvironmentCapacity = "environmentCapacity"
+	CheckRegion              = "region"
+	CheckProduction          = "production"
+)
+
+var ReassignEnvironmentLicenseDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "reassign_environment_license",
+		Title: "Reassign PingOne Environment License",
+		Description: `Move an environment to a different license, such as when a license is renewed or replaced at a true-up. The environment's name, description, icon, region and type are kept.
+
+The target license is checked first: it must belong to the environment's organization, be ACTIVE, have capacity for another environment, include the environment's region, and allow PRODUCTION environments if the environment is one. The environment is not moved if any check fails. Run with dryRun first to see the checks and the license assignment before and after without changing anything.
+
+License IDs can be found in the license of environments returned by 'get_environment' or 'list_environments'.`,
+		InputSchema:  schema.MustGenerateSchema[ReassignEnvironmentLicenseInput](),
+		OutputSchema: schema.MustGenerateSchema[ReassignEnvironmentLicenseOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type ReassignEnvironmentLicenseInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The UUID of the environment to move to another license."`
+	LicenseId     uuid.UUID `json:"licenseId" jsonschema:"REQUIRED. The UUID of the license to move the environment to."`
+	DryRun        *bool     `json:"dryRun,omitempty" jsonschema:"OPTIONAL. Check the target license and report the assignment before and after without moving the environment. Defaults to false."`
+}
+
+// LicenseAssignment describes a license an environment is assigned to
+type LicenseAssignment struct {
+	LicenseId            string                        `json:"licenseId" jsonschema:"The UUID of the license"`
+	Name                 string                        `json:"name,omitempty" jsonschema:"The name of the license"`
+	Package              *string                       `json:"package,omitempty" jsonschema:"The license package, such as TRIAL or GLOBAL"`
+	Status               *management.EnumLicenseStatus `json:"status,omitempty" jsonschema:"The license status: ACTIVE, EXPIRED, FUTURE or TERMINATED"`
+	ExpiresAt            *time.Time                    `json:"expiresAt,omitempty" jsonschema:"When the license expires"`
+	AssignedEnvironments *int32                        `json:"assignedEnvironments,omitempty" jsonschema:"The number of environments assigned to the license when it was checked, before the reassignment"`
+	MaxEnvironments      *int32                        `json:"maxEnvironments,omitempty" jsonschema:"The maximum number of environments the license allows, if limited"`
+}
+
+// LicenseCheck is the result of checking that the target license can take the environment
+type LicenseCheck struct {
+	Check  string `json:"check" jsonschema:"The check: organization, status, environmentCapacity, region or production"`
+	Passed bool   `json:"passed" jsonschema:"True if the target license passed the check"`
+	Detail string `json:"detail" jsonschema:"What was checked, and why the check failed"`
+}
+
+type ReassignEnvironmentLicenseOutput struct {
+	EnvironmentId   string            `json:"environmentId" jsonschema:"The UUID of the environment"`
+	EnvironmentName string            `json:"environmentName" jsonschema:"The name of the environment"`
+	Before          LicenseAssignment `json:"before" jsonschema:"The license the environment was assigned to before the reassignment"`
+	After           LicenseAssignment `json:"after" jsonschema:"The license the environment is assigned to after the reassignment, or would be in a dry run"`
+	Checks          []LicenseCheck    `json:"checks" jsonschema:"The checks of the target license"`
+	Reassigned      bool              `json:"reassigned" jsonschema:"True if the environment was moved to the target license, false in a dry run"`
+}
+
+// ReassignEnvironmentLicenseHandler moves a PingOne environment to another license using the provided client
+func ReassignEnvironmentLicenseHandler(licensesClientFactory LicensesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ReassignEnvironmentLicenseInput,
+) (
+	*mcp.CallToolResult,
+	*ReassignEnvironmentLicenseOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ReassignEnvironmentLicenseInput) (*mcp.CallToolResult, *ReassignEnvironmentLicenseOutput, error) {
+		client, err := licensesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ReassignEnvironmentLicenseDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		environment, httpResponse, err := client.GetEnvironment(ctx, input.EnvironmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if environment == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if environment.Organization == nil || environment.Organization.GetId() == "" {
+			toolErr := errs.NewToolError(ReassignEnvironmentLicenseDef.McpTool.Name, fmt.Errorf("environment %s has no organization in the response", input.EnvironmentId))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		organizationId := environment.Organization.GetId()
+		previousLicenseId := environment.License.Id
+		if strings.EqualFold(previousLicenseId, input.LicenseId.String()) {
+			toolErr := errs.NewToolError(ReassignEnvironmentLicenseDef.McpTool.Name, fmt.Errorf("environment %s is already assigned to license %s", input.EnvironmentId, input.LicenseId))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		// The current license is only reported, so the reassignment goes ahead if it cannot be read,
+		// such as when it has been terminated
+		before := LicenseAssignment{LicenseId: previousLicenseId}
+		previousLicense, httpResponse, err := client.GetLicense(ctx, organizationId, previousLicenseId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Unable to retrieve the current license of the environment",
+				slog.String("licenseId", previousLicenseId),
+				slog.Any("error", err))
+		} else if previousLicense != nil {
+			before = licenseAssignment(*previousLicense)
+		}
+
+		targetLicense, httpResponse, err := client.GetLicense(ctx, organizationId, input.LicenseId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if targetLicense == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no license data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		checks := checkTargetLicense(*environment, *targetLicense)
+		output := &ReassignEnvironmentLicenseOutput{
+			EnvironmentId:   input.EnvironmentId.String(),
+			EnvironmentName: environment.Name,
+			Before:          before,
+			After:           licenseAssignment(*targetLicense),
+			Checks:          checks,
+		}
+
+		if input.DryRun != nil && *input.DryRun {
+			return nil, output, nil
+		}
+
+		var failures []string
+		for _, check := range checks {
+			if !check.Passed {
+				failures = append(failures, check.Detail)
+			}
+		}
+		if len(failures) > 0 {
+			toolErr := errs.NewToolError(ReassignEnvironmentLicenseDef.McpTool.Name, fmt.Errorf("environment %s cannot be moved to license %s: %s", input.EnvironmentId, input.LicenseId, strings.Join(failures, "; ")))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Reassigning environment license",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("previousLicenseId", previousLicenseId),
+			slog.String("licenseId", input.LicenseId.String()))
+
+		_, httpResponse, err = client.UpdateEnvironment(ctx, input.EnvironmentId, environmentWithLicense(*environment, input.LicenseId.String()))
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		logger.FromContext(ctx).Info("Environment license reassigned",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("previousLicenseId", previousLicenseId),
+			slog.String("licenseId", input.LicenseId.String()))
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          ReassignEnvironmentLicenseDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "environment",
+			ResourceId:    input.EnvironmentId.String(),
+			Description:   fmt.Sprintf("Move environment %q back to license %s", environment.Name, previousLicenseId),
+		}, undoReassignEnvironmentLicense(licensesClientFactory, input.EnvironmentId, previousLicenseId, input.LicenseId.String()))
+
+		output.Reassigned = true
+		return nil, output, nil
+	}
+}
+
+// checkTargetLicense checks that the license can take the environment
+func checkTargetLicense(environment management.Environment, license management.License) []LicenseCheck {
+	var checks []LicenseCheck
+
+	organizationCheck := LicenseCheck{Check: CheckOrganization, Passed: true, Detail: "the license belongs to the environment's organization"}
+	if license.Organization != nil && license.Organization.GetId() != environment.Organization.GetId() {
+		organizationCheck.Passed = false
+		organizationCheck.Detail = fmt.Sprintf("the license belongs to organization %s, not the environment's organization %s", license.Organization.GetId(), environment.Organization.GetId())
+	}
+	checks = append(checks, organizationCheck)
+
+	statusCheck := LicenseCheck{Check: CheckStatus, Passed: true, Detail: "the license is ACTIVE"}
+	if license.GetStatus() != management.ENUMLICENSESTATUS_ACTIVE {
+		statusCheck.Passed = false
+		statusCheck.Detail = fmt.Sprintf("the license status is %s, not ACTIVE", license.GetStatus())
+	}
+	checks = append(checks, statusCheck)
+
+	capacityCheck := LicenseCheck{Check: CheckEnvironmentCapacity, Passed: true, Detail: "the license does not limit the number of environments"}
+	if license.Environments != nil && license.Environments.Max != nil {
+		assigned := license.GetAssignedEnvironmentsCount()
+		maxEnvironments := *license.Environments.Max
+		capacityCheck.Detail = fmt.Sprintf("the license has %d of %d environments assigned", assigned, maxEnvironments)
+		if assigned >= maxEnvironments {
+			capacityCheck.Passed = false
+			capacityCheck.Detail = fmt.Sprintf("the license already has %d of %d environments assigned", assigned, maxEnvironments)
+		}
+	}
+	checks = append(checks, capacityCheck)
+
+	region := environmentRegion(environment)
+	regionCheck := LicenseCheck{Check: CheckRegion, Passed: true, Detail: "the license does not restrict regions"}
+	if license.Environments != nil && len(license.Environments.Regions) > 0 {
+		regionCheck.Detail = fmt.Sprintf("the license includes the environment's region %s", region)
+		if !slices.Contains(license.Environments.Regions, licenseRegion(region)) {
+			regionCheck.Passed = false
+			regionCheck.Detail = fmt.Sprintf("the license does not include the environment's region %s", region)
+		}
+	}
+	checks = append(checks, regionCheck)
+
+	if environment.Type == management.ENUMENVIRONMENTTYPE_PRODUCTION {
+		productionCheck := LicenseCheck{Check: CheckProduction, Passed: true, Detail: "the license allows PRODUCTION environments"}
+		if license.Environments != nil && license.Environments.AllowProduction != nil && !*license.Environments.AllowProduction {
+			productionCheck.Passed = false
+			productionCheck.Detail = "the license does not allow PRODUCTION environments"
+		}
+		checks = append(checks, productionCheck)
+	}
+
+	return checks
+}
+
+// environmentRegion returns the region code of the environment, such as NA
+func environmentRegion(environment management.Environment) string {
+	if environment.Region.EnumRegionCode != nil {
+		return string(*environment.Region.EnumRegionCode)
+	}
+	if environment.Region.String != nil {
+		return *environment.Region.String
+	}
+	return ""
+}
+
+// licenseRegion returns the license region of an environment region code, which differ for North America
+func licenseRegion(region string) management.EnumRegionCodeLicense {
+	if region == string(management.ENUMREGIONCODE_NA) {
+		return management.ENUMREGIONCODELICENSE_NORTH_AMERICA
+	}
+	return management.EnumRegionCodeLicense(region)
+}
+
+func licenseAssignment(license management.License) LicenseAssignment {
+	assignment := LicenseAssignment{
+		LicenseId:            license.GetId(),
+		Name:                 license.Name,
+		Package:              license.Package,
+		Status:               license.Status,
+		ExpiresAt:            license.ExpiresAt,
+		AssignedEnvironments: license.AssignedEnvironmentsCount,
+	}
+	if license.Environments != nil {
+		assignment.MaxEnvironments = license.Environments.Max
+	}
+	return assignment
+}
+
+// environmentWithLicense returns the update request that assigns the environment to the license, keeping its
+// other configuration. Services are managed separately and are not changed.
+func environmentWithLicense(environment management.Environment, licenseId string) management.Environment {
+	updateRequest := management.NewEnvironment(management.EnvironmentLicense{Id: licenseId}, environment.Name, environment.Region, environment.Type)
+	updateRequest.Description = environment.Description
+	updateRequest.Icon = environment.Icon
+	return *updateRequest
+}
+
+// undoReassignEnvironmentLicense returns the function that moves an environment back to its previous license,
+// unless it has been moved to another license since
+func undoReassignEnvironmentLicense(licensesClientFactory LicensesClientFactory, environmentId uuid.UUID, previousLicenseId string, licenseId string) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := licensesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		environment, httpResponse, err := client.GetEnvironment(ctx, environmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if environment == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no environment data in response"))
+		}
+		if !force && !strings.EqualFold(environment.License.Id, licenseId) {
+			return rollback.ErrChangedSince
+		}
+
+		_, httpResponse, err = client.UpdateEnvironment(ctx, environmentId, environmentWithLicense(*environment, previousLicenseId))
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package licenses_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/licenses"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEnvironmentId     = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testOrganizationId    = "7d9e8f10-1a2b-4c3d-8e9f-0a1b2c3d4e5f"
+	testCurrentLicenseId  = "11111111-1111-4111-8111-111111111111"
+	testTargetLicenseId   = uuid.MustParse("22222222-2222-4222-8222-222222222222")
+	testOtherOrganization = "99999999-9999-4999-8999-999999999999"
+)
+
+func testEnvironment(environmentType management.EnumEnvironmentType, licenseId string) *management.Environment {
+	region := management.ENUMREGIONCODE_NA
+	return &management.Environment{
+		Id:           testutils.Pointer(testEnvironmentId.String()),
+		Name:         "Test Environment",
+		Description:  testutils.Pointer("Environment description"),
+		Icon:         testutils.Pointer("https://example.com/icon.png"),
+		License:      management.EnvironmentLicense{Id: licenseId},
+		Organization: &management.EnvironmentOrganization{Id: testutils.Pointer(testOrganizationId)},
+		Region:       management.EnumRegionCodeAsEnvironmentRegion(&region),
+		Type:         environmentType,
+	}
+}
+
+func testLicense(licenseId string, assigned int32, maxEnvironments int32) *management.License {
+	return &management.License{
+		Id:                        testutils.Pointer(licenseId),
+		Name:                      "License " + licenseId[:4],
+		Package:                   testutils.Pointer("GLOBAL"),
+		Status:                    testutils.Pointer(management.ENUMLICENSESTATUS_ACTIVE),
+		Organization:              &management.ObjectOrganization{Id: testutils.Pointer(testOrganizationId)},
+		AssignedEnvironmentsCount: testutils.Pointer(assigned),
+		Environments: &management.LicenseEnvironments{
+			Max:             testutils.Pointer(maxEnvironments),
+			Regions:         []management.EnumRegionCodeLicense{management.ENUMREGIONCODELICENSE_NORTH_AMERICA, management.ENUMREGIONCODELICENSE_EU},
+			AllowProduction: testutils.Pointer(true),
+		},
+	}
+}
+
+func mockLicenseLookups(mockClient *mockPingOneClientLicensesWrapper, environment *management.Environment, target *management.License) {
+	mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(environment, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetLicense", mock.Anything, testOrganizationId, testCurrentLicenseId).Return(testLicense(testCurrentLicenseId, 3, 5), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetLicense", mock.Anything, testOrganizationId, testTargetLicenseId.String()).Return(target, &http.Response{StatusCode: 200}, nil).Once()
+}
+
+func failedChecks(checks []licenses.LicenseCheck) []string {
+	failed := []string{}
+	for _, check := range checks {
+		if !check.Passed {
+			failed = append(failed, check.Check)
+		}
+	}
+	return failed
+}
+
+func TestReassignEnvironmentLicenseHandler(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	environment := testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, testCurrentLicenseId)
+	mockLicenseLookups(mockClient, environment, testLicense(testTargetLicenseId.String(), 1, 10))
+	mockClient.On("UpdateEnvironment", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.Environment) bool {
+		return req.License.Id == testTargetLicenseId.String() &&
+			req.Name == environment.Name &&
+			req.Type == management.ENUMENVIRONMENTTYPE_SANDBOX &&
+			req.Description != nil && *req.Description == "Environment description" &&
+			req.Icon != nil && *req.Icon == "https://example.com/icon.png" &&
+			req.BillOfMaterials == nil
+	})).Return(environment, &http.Response{StatusCode: 200}, nil).Once()
+
+	handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+		EnvironmentId: testEnvironmentId,
+		LicenseId:     testTargetLicenseId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.Reassigned)
+	assert.Equal(t, "Test Environment", output.EnvironmentName)
+	assert.Equal(t, testCurrentLicenseId, output.Before.LicenseId)
+	assert.Equal(t, int32(3), *output.Before.AssignedEnvironments)
+	assert.Equal(t, testTargetLicenseId.String(), output.After.LicenseId)
+	assert.Equal(t, int32(10), *output.After.MaxEnvironments)
+	assert.Empty(t, failedChecks(output.Checks))
+	assert.Len(t, output.Checks, 4, "the production check only applies to PRODUCTION environments")
+	mockClient.AssertExpectations(t)
+}
+
+func TestReassignEnvironmentLicenseHandler_DryRun(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockLicenseLookups(mockClient, testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, testCurrentLicenseId), testLicense(testTargetLicenseId.String(), 10, 10))
+
+	handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+		EnvironmentId: testEnvironmentId,
+		LicenseId:     testTargetLicenseId,
+		DryRun:        testutils.Pointer(true),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.Reassigned)
+	assert.Equal(t, []string{licenses.CheckEnvironmentCapacity}, failedChecks(output.Checks), "a dry run should report failed checks")
+	mockClient.AssertNotCalled(t, "UpdateEnvironment", mock.Anything, mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestReassignEnvironmentLicenseHandler_FailedChecks(t *testing.T) {
+	tests := []struct {
+		name            string
+		environmentType management.EnumEnvironmentType
+		modifyLicense   func(license *management.License)
+		wantCheck       string
+		wantErrContains string
+	}{
+		{
+			name:            "Capacity reached",
+			environmentType: management.ENUMENVIRONMENTTYPE_SANDBOX,
+			modifyLicense: func(license *management.License) {
+				license.AssignedEnvironmentsCount = testutils.Pointer(int32(5))
+				license.Environments.Max = testutils.Pointer(int32(5))
+			},
+			wantCheck:       licenses.CheckEnvironmentCapacity,
+			wantErrContains: "already has 5 of 5 environments assigned",
+		},
+		{
+			name:            "Expired license",
+			environmentType: management.ENUMENVIRONMENTTYPE_SANDBOX,
+			modifyLicense: func(license *management.License) {
+				license.Status = testutils.Pointer(management.ENUMLICENSESTATUS_EXPIRED)
+			},
+			wantCheck:       licenses.CheckStatus,
+			wantErrContains: "the license status is EXPIRED, not ACTIVE",
+		},
+		{
+			name:            "Region not included",
+			environmentType: management.ENUMENVIRONMENTTYPE_SANDBOX,
+			modifyLicense: func(license *management.License) {
+				license.Environments.Regions = []management.EnumRegionCodeLicense{management.ENUMREGIONCODELICENSE_EU}
+			},
+			wantCheck:       licenses.CheckRegion,
+			wantErrContains: "does not include the environment's region NA",
+		},
+		{
+			name:            "Production not allowed",
+			environmentType: management.ENUMENVIRONMENTTYPE_PRODUCTION,
+			modifyLicense: func(license *management.License) {
+				license.Environments.AllowProduction = testutils.Pointer(false)
+			},
+			wantCheck:       licenses.CheckProduction,
+			wantErrContains: "does not allow PRODUCTION environments",
+		},
+		{
+			name:            "Other organization",
+			environmentType: management.ENUMENVIRONMENTTYPE_SANDBOX,
+			modifyLicense: func(license *management.License) {
+				license.Organization = &management.ObjectOrganization{Id: testutils.Pointer(testOtherOrganization)}
+			},
+			wantCheck:       licenses.CheckOrganization,
+			wantErrContains: "belongs to organization " + testOtherOrganization,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := testLicense(testTargetLicenseId.String(), 1, 5)
+			tt.modifyLicense(target)
+
+			mockClient := &mockPingOneClientLicensesWrapper{}
+			mockLicenseLookups(mockClient, testEnvironment(tt.environmentType, testCurrentLicenseId), target)
+			handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+
+			_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+				EnvironmentId: testEnvironmentId,
+				LicenseId:     testTargetLicenseId,
+				DryRun:        testutils.Pointer(true),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.wantCheck}, failedChecks(output.Checks))
+
+			mockLicenseLookups(mockClient, testEnvironment(tt.environmentType, testCurrentLicenseId), target)
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+				EnvironmentId: testEnvironmentId,
+				LicenseId:     testTargetLicenseId,
+			})
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertNotCalled(t, "UpdateEnvironment", mock.Anything, mock.Anything, mock.Anything)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReassignEnvironmentLicenseHandler_AlreadyAssigned(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, testTargetLicenseId.String()), &http.Response{StatusCode: 200}, nil).Once()
+
+	handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+		EnvironmentId: testEnvironmentId,
+		LicenseId:     testTargetLicenseId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "is already assigned to license")
+	mockClient.AssertExpectations(t)
+}
+
+func TestReassignEnvironmentLicenseHandler_CurrentLicenseUnavailable(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, testCurrentLicenseId), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetLicense", mock.Anything, testOrganizationId, testCurrentLicenseId).Return(nil, &http.Response{StatusCode: 404}, errors.New("not found")).Once()
+	mockClient.On("GetLicense", mock.Anything, testOrganizationId, testTargetLicenseId.String()).Return(testLicense(testTargetLicenseId.String(), 1, 5), &http.Response{StatusCode: 200}, nil).Once()
+
+	handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+		EnvironmentId: testEnvironmentId,
+		LicenseId:     testTargetLicenseId,
+		DryRun:        testutils.Pointer(true),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testCurrentLicenseId, output.Before.LicenseId, "the current license ID should be reported even if it cannot be read")
+	assert.Empty(t, output.Before.Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestReassignEnvironmentLicenseHandler_APIErrors(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, testCurrentLicenseId), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetLicense", mock.Anything, testOrganizationId, testCurrentLicenseId).Return(testLicense(testCurrentLicenseId, 3, 5), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetLicense", mock.Anything, testOrganizationId, testTargetLicenseId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("license not found")).Once()
+
+	handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+		EnvironmentId: testEnvironmentId,
+		LicenseId:     testTargetLicenseId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "license not found")
+	mockClient.AssertExpectations(t)
+}
+
+func TestReassignEnvironmentLicenseHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+		EnvironmentId: testEnvironmentId,
+		LicenseId:     testTargetLicenseId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestReassignEnvironmentLicenseHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientLicensesWrapper{}
+	environment := testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, testCurrentLicenseId)
+	mockLicenseLookups(mockClient, environment, testLicense(testTargetLicenseId.String(), 1, 5))
+	mockClient.On("UpdateEnvironment", mock.Anything, testEnvironmentId, mock.Anything).Return(environment, &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := licenses.ReassignEnvironmentLicenseHandler(NewMockPingOneClientLicensesWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, licenses.ReassignEnvironmentLicenseInput{
+		EnvironmentId: testEnvironmentId,
+		LicenseId:     testTargetLicenseId,
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// The environment has been moved to yet another license since, so it is not moved back without force
+	movedAgain := testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, "33333333-3333-4333-8333-333333333333")
+	mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(movedAgain, &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+
+	reassigned := testEnvironment(management.ENUMENVIRONMENTTYPE_SANDBOX, testTargetLicenseId.String())
+	mockClient.On("GetEnvironment", mock.Anything, testEnvironmentId).Return(reassigned, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateEnvironment", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.Environment) bool {
+		return req.License.Id == testCurrentLicenseId
+	})).Return(environment, &http.Response{StatusCode: 200}, nil).Once()
+
+	change, err := journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, licenses.ReassignEnvironmentLicenseDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,22 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package localization
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type LocalizationClient interface {
+	GetLanguages(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetAgreements(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetAgreementLanguages(ctx context.Context, environmentId uuid.UUID, agreementId string) (management.EntityArrayPagedIterator, error)
+	GetTemplates(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetTemplateContents(ctx context.Context, environmentId uuid.UUID, templateName management.EnumTemplateName) (management.EntityArrayPagedIterator, error)
+}
+
+type LocalizationClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (LocalizationClient, error)
+}
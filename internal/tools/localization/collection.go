@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package localization
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "localization"
+
+var _ collections.LegacySdkCollection = &LocalizationCollection{}
+
+type LocalizationCollection struct{}
+
+func (c *LocalizationCollection) Name() string {
+	return CollectionName
+}
+
+func (c *LocalizationCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	localizationClientFactory := NewPingOneClientLocalizationWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&GetLocalizationGapsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetLocalizationGapsDef.McpTool.Name))
+		mcp.AddTool(server, GetLocalizationGapsDef.McpTool, GetLocalizationGapsHandler(localizationClientFactory))
+	}
+
+	return nil
+}
+
+func (c *LocalizationCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		GetLocalizationGapsDef,
+	}
+}
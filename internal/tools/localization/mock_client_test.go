@@ -0,0 +1,69 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package localization_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/localization"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ localization.LocalizationClient = &mockPingOneClientLocalizationWrapper{}
+var _ localization.LocalizationClientFactory = &mockPingOneClientLocalizationWrapperFactory{}
+
+type mockPingOneClientLocalizationWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientLocalizationWrapperFactory struct {
+	mockClient localization.LocalizationClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientLocalizationWrapperFactory(mockClient localization.LocalizationClient, err error) *mockPingOneClientLocalizationWrapperFactory {
+	return &mockPingOneClientLocalizationWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientLocalizationWrapperFactory) GetAuthenticatedClient(ctx context.Context) (localization.LocalizationClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientLocalizationWrapper) GetLanguages(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return pagedIteratorResult(args)
+}
+
+func (p *mockPingOneClientLocalizationWrapper) GetAgreements(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return pagedIteratorResult(args)
+}
+
+func (p *mockPingOneClientLocalizationWrapper) GetAgreementLanguages(ctx context.Context, environmentId uuid.UUID, agreementId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, agreementId)
+	return pagedIteratorResult(args)
+}
+
+func (p *mockPingOneClientLocalizationWrapper) GetTemplates(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return pagedIteratorResult(args)
+}
+
+func (p *mockPingOneClientLocalizationWrapper) GetTemplateContents(ctx context.Context, environmentId uuid.UUID, templateName management.EnumTemplateName) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, templateName)
+	return pagedIteratorResult(args)
+}
+
+func pagedIteratorResult(args mock.Arguments) (management.EntityArrayPagedIterator, error) {
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
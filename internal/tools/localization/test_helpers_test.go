@@ -0,0 +1,145 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package localization_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/stretchr/testify/mock"
+)
+
+var testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+var (
+	testLanguageEn = management.Language{
+		Id:      testutils.Pointer("language-en"),
+		Locale:  "en",
+		Name:    testutils.Pointer("English"),
+		Enabled: true,
+		Default: true,
+	}
+	testLanguageFr = management.Language{
+		Id:      testutils.Pointer("language-fr"),
+		Locale:  "fr",
+		Name:    testutils.Pointer("French"),
+		Enabled: true,
+	}
+	testLanguageDeDisabled = management.Language{
+		Id:      testutils.Pointer("language-de"),
+		Locale:  "de",
+		Name:    testutils.Pointer("German"),
+		Enabled: false,
+	}
+
+	testAgreementTerms = management.Agreement{
+		Id:      testutils.Pointer("agreement-terms"),
+		Name:    "Terms of Service",
+		Enabled: true,
+	}
+	testAgreementPrivacy = management.Agreement{
+		Id:      testutils.Pointer("agreement-privacy"),
+		Name:    "Privacy Policy",
+		Enabled: false,
+	}
+
+	testTemplateVerification = management.Template{
+		Id:          testutils.Pointer("email_verification_user"),
+		DisplayName: "Email Verification - User",
+	}
+	testTemplateRecovery = management.Template{
+		Id:          testutils.Pointer("recovery_code_template"),
+		DisplayName: "Recovery Code",
+	}
+)
+
+func createMockPage(embedded management.EntityArrayEmbedded) testutils.LegacySdkMockPage {
+	return testutils.LegacySdkMockPage{
+		EntityArray: &management.EntityArray{
+			Embedded: &embedded,
+		},
+		HTTPResponse: &http.Response{StatusCode: 200},
+		Error:        nil,
+	}
+}
+
+func mockIterator(embedded ...management.EntityArrayEmbedded) management.EntityArrayPagedIterator {
+	pages := make([]testutils.LegacySdkMockPage, len(embedded))
+	for i, pageEmbedded := range embedded {
+		pages[i] = createMockPage(pageEmbedded)
+	}
+	return testutils.MockLegacySdkPaginationIterator(pages)
+}
+
+func languagesEmbedded(languages ...management.Language) management.EntityArrayEmbedded {
+	inner := make([]management.EntityArrayEmbeddedLanguagesInner, len(languages))
+	for i := range languages {
+		inner[i] = management.EntityArrayEmbeddedLanguagesInner{Language: &languages[i]}
+	}
+	return management.EntityArrayEmbedded{Languages: inner}
+}
+
+func agreementLanguagesEmbedded(enabled bool, locales ...string) management.EntityArrayEmbedded {
+	inner := make([]management.EntityArrayEmbeddedLanguagesInner, len(locales))
+	for i, locale := range locales {
+		inner[i] = management.EntityArrayEmbeddedLanguagesInner{
+			AgreementLanguage: &management.AgreementLanguage{
+				Id:          testutils.Pointer("agreement-language-" + locale),
+				Locale:      locale,
+				DisplayName: locale,
+				Enabled:     enabled,
+			},
+		}
+	}
+	return management.EntityArrayEmbedded{Languages: inner}
+}
+
+func emailContentsEmbedded(locales ...string) management.EntityArrayEmbedded {
+	contents := make([]management.TemplateContent, len(locales))
+	for i, locale := range locales {
+		contents[i] = management.TemplateContent{
+			TemplateContentEmail: &management.TemplateContentEmail{
+				Locale:         locale,
+				DeliveryMethod: management.ENUMTEMPLATECONTENTDELIVERYMETHOD_EMAIL,
+			},
+		}
+	}
+	return management.EntityArrayEmbedded{Contents: contents}
+}
+
+func smsContentsEmbedded(locales ...string) management.EntityArrayEmbedded {
+	contents := make([]management.TemplateContent, len(locales))
+	for i, locale := range locales {
+		contents[i] = management.TemplateContent{
+			TemplateContentSMS: &management.TemplateContentSMS{
+				Locale:         locale,
+				DeliveryMethod: management.ENUMTEMPLATECONTENTDELIVERYMETHOD_SMS,
+			},
+		}
+	}
+	return management.EntityArrayEmbedded{Contents: contents}
+}
+
+// setupGapsMock configures an environment with English and French enabled and German disabled, where:
+//   - the terms agreement is translated into English and French
+//   - the privacy agreement is translated into English only, with a disabled French translation
+//   - the verification template has English email content and French SMS content
+//   - the recovery template has English content only, and German content that is ignored
+func setupGapsMock(mockClient *mockPingOneClientLocalizationWrapper) {
+	mockClient.On("GetLanguages", mock.Anything, testEnvironmentId).Return(
+		mockIterator(languagesEmbedded(testLanguageEn, testLanguageFr, testLanguageDeDisabled)), nil)
+	mockClient.On("GetAgreements", mock.Anything, testEnvironmentId).Return(
+		mockIterator(management.EntityArrayEmbedded{Agreements: []management.Agreement{testAgreementTerms, testAgreementPrivacy}}), nil)
+	mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, *testAgreementTerms.Id).Return(
+		mockIterator(agreementLanguagesEmbedded(true, "en", "fr")), nil)
+	mockClient.On("GetAgreementLanguages", mock.Anything, testEnvironmentId, *testAgreementPrivacy.Id).Return(
+		mockIterator(agreementLanguagesEmbedded(true, "en"), agreementLanguagesEmbedded(false, "fr")), nil)
+	mockClient.On("GetTemplates", mock.Anything, testEnvironmentId).Return(
+		mockIterator(management.EntityArrayEmbedded{Templates: []management.Template{testTemplateVerification, testTemplateRecovery}}), nil)
+	mockClient.On("GetTemplateContents", mock.Anything, testEnvironmentId, management.EnumTemplateName(*testTemplateVerification.Id)).Return(
+		mockIterator(emailContentsEmbedded("en"), smsContentsEmbedded("FR")), nil)
+	mockClient.On("GetTemplateContents", mock.Anything, testEnvironmentId, management.EnumTemplateName(*testTemplateRecovery.Id)).Return(
+		mockIterator(emailContentsEmbedded("en", "de")), nil)
+}
@@ -0,0 +1,283 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package localization
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetLocalizationGapsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_localization_gaps",
+		Title: "Get PingOne Localization Gaps",
+		Description: `Report which agreements and notification templates lack translations for the enabled languages of an environment.
+
+Use to find missing translations before enabling a language, or to review localization coverage across agreements and notification templates.
+
+An agreement is missing a language when it has no enabled agreement language for that locale. A notification template is missing a language when it has no content in that locale for any delivery method.
+
+Returns: the enabled languages, and for each agreement and notification template the translated and missing locales. By default only resources with missing translations are returned.`,
+		InputSchema:  schema.MustGenerateSchema[GetLocalizationGapsInput](),
+		OutputSchema: schema.MustGenerateSchema[GetLocalizationGapsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetLocalizationGapsInput struct {
+	EnvironmentId   uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	IncludeComplete *bool     `json:"includeComplete,omitempty" jsonschema:"OPTIONAL. Include agreements and notification templates that are translated for every enabled language. Defaults to false."`
+	Fields          []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'agreements.name' and 'agreements.missingLocales'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type LocalizationLanguage struct {
+	Locale  string  `json:"locale" jsonschema:"The locale of the language, such as 'en' or 'fr-CA'"`
+	Name    *string `json:"name,omitempty" jsonschema:"The name of the language"`
+	Default bool    `json:"default" jsonschema:"Whether this is the default language of the environment"`
+}
+
+type LocalizationStatus struct {
+	Id                string   `json:"id" jsonschema:"The agreement ID, or notification template name"`
+	Name              string   `json:"name" jsonschema:"The display name of the agreement or notification template"`
+	Enabled           *bool    `json:"enabled,omitempty" jsonschema:"Whether the agreement is enabled. Not set for notification templates"`
+	TranslatedLocales []string `json:"translatedLocales" jsonschema:"Enabled locales with a translation"`
+	MissingLocales    []string `json:"missingLocales" jsonschema:"Enabled locales without a translation"`
+}
+
+type GetLocalizationGapsOutput struct {
+	Languages             []LocalizationLanguage `json:"languages" jsonschema:"The enabled languages of the environment"`
+	Agreements            []LocalizationStatus   `json:"agreements" jsonschema:"Translation coverage of each agreement"`
+	NotificationTemplates []LocalizationStatus   `json:"notificationTemplates" jsonschema:"Translation coverage of each notification template"`
+	MissingCount          int                    `json:"missingCount" jsonschema:"The total number of missing translations across agreements and notification templates"`
+}
+
+// GetLocalizationGapsHandler reports missing agreement and notification template translations using the provided client
+func GetLocalizationGapsHandler(localizationClientFactory LocalizationClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetLocalizationGapsInput,
+) (
+	*mcp.CallToolResult,
+	*GetLocalizationGapsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetLocalizationGapsInput) (*mcp.CallToolResult, *GetLocalizationGapsOutput, error) {
+		client, err := localizationClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetLocalizationGapsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		includeComplete := input.IncludeComplete != nil && *input.IncludeComplete
+
+		logger.FromContext(ctx).Debug("Getting localization gaps",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Bool("includeComplete", includeComplete))
+
+		result := &GetLocalizationGapsOutput{
+			Languages:             []LocalizationLanguage{},
+			Agreements:            []LocalizationStatus{},
+			NotificationTemplates: []LocalizationStatus{},
+		}
+
+		// Enabled languages define the locales every resource is expected to be translated into
+		languagesIterator, err := client.GetLanguages(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(GetLocalizationGapsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		err = collectPages(ctx, languagesIterator, func(embedded *management.EntityArrayEmbedded) {
+			for _, inner := range embedded.Languages {
+				if inner.Language == nil || !inner.Language.Enabled {
+					continue
+				}
+				result.Languages = append(result.Languages, LocalizationLanguage{
+					Locale:  inner.Language.Locale,
+					Name:    inner.Language.Name,
+					Default: inner.Language.Default,
+				})
+			}
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		enabledLocales := make([]string, 0, len(result.Languages))
+		for _, language := range result.Languages {
+			enabledLocales = append(enabledLocales, language.Locale)
+		}
+
+		// Agreements are translated by agreement languages
+		agreementsIterator, err := client.GetAgreements(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(GetLocalizationGapsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		var agreements []management.Agreement
+		err = collectPages(ctx, agreementsIterator, func(embedded *management.EntityArrayEmbedded) {
+			agreements = append(agreements, embedded.Agreements...)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, agreement := range agreements {
+			if agreement.Id == nil {
+				continue
+			}
+			agreementLanguagesIterator, err := client.GetAgreementLanguages(ctx, input.EnvironmentId, *agreement.Id)
+			if err != nil {
+				toolErr := errs.NewToolError(GetLocalizationGapsDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			var translatedLocales []string
+			err = collectPages(ctx, agreementLanguagesIterator, func(embedded *management.EntityArrayEmbedded) {
+				for _, inner := range embedded.Languages {
+					if inner.AgreementLanguage == nil || !inner.AgreementLanguage.Enabled {
+						continue
+					}
+					translatedLocales = append(translatedLocales, inner.AgreementLanguage.Locale)
+				}
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			enabled := agreement.Enabled
+			status := newLocalizationStatus(*agreement.Id, agreement.Name, enabledLocales, translatedLocales)
+			status.Enabled = &enabled
+			result.MissingCount += len(status.MissingLocales)
+			if includeComplete || len(status.MissingLocales) > 0 {
+				result.Agreements = append(result.Agreements, status)
+			}
+		}
+
+		// Notification templates are translated by template contents
+		templatesIterator, err := client.GetTemplates(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(GetLocalizationGapsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		var templates []management.Template
+		err = collectPages(ctx, templatesIterator, func(embedded *management.EntityArrayEmbedded) {
+			templates = append(templates, embedded.Templates...)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, template := range templates {
+			if template.Id == nil {
+				continue
+			}
+			contentsIterator, err := client.GetTemplateContents(ctx, input.EnvironmentId, management.EnumTemplateName(*template.Id))
+			if err != nil {
+				toolErr := errs.NewToolError(GetLocalizationGapsDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			var translatedLocales []string
+			err = collectPages(ctx, contentsIterator, func(embedded *management.EntityArrayEmbedded) {
+				for _, content := range embedded.Contents {
+					if locale := templateContentLocale(content); locale != "" {
+						translatedLocales = append(translatedLocales, locale)
+					}
+				}
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			status := newLocalizationStatus(*template.Id, template.DisplayName, enabledLocales, translatedLocales)
+			result.MissingCount += len(status.MissingLocales)
+			if includeComplete || len(status.MissingLocales) > 0 {
+				result.NotificationTemplates = append(result.NotificationTemplates, status)
+			}
+		}
+
+		logger.FromContext(ctx).Debug("Localization gaps retrieved successfully",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("languageCount", len(result.Languages)),
+			slog.Int("missingCount", result.MissingCount))
+
+		return nil, result, nil
+	}
+}
+
+// collectPages reads every page of a paged API response, passing the embedded data of each page to collect.
+// API errors are logged and returned in the same form as other tool handlers.
+func collectPages(ctx context.Context, pagedIterator management.EntityArrayPagedIterator, collect func(embedded *management.EntityArrayEmbedded)) error {
+	for next, err := range pagedIterator {
+		logger.LogHttpResponse(ctx, next.HTTPResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, err)
+			errs.Log(ctx, apiErr)
+			return apiErr
+		}
+		if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+			// This should never happen, err should be set if no data
+			apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return apiErr
+		}
+		collect(next.EntityArray.Embedded)
+	}
+	return nil
+}
+
+// newLocalizationStatus compares the translated locales of a resource against the enabled locales.
+// Locales are compared case-insensitively, and translations for locales that are not enabled are ignored.
+func newLocalizationStatus(id string, name string, enabledLocales []string, translatedLocales []string) LocalizationStatus {
+	status := LocalizationStatus{
+		Id:                id,
+		Name:              name,
+		TranslatedLocales: []string{},
+		MissingLocales:    []string{},
+	}
+	for _, locale := range enabledLocales {
+		translated := slices.ContainsFunc(translatedLocales, func(translatedLocale string) bool {
+			return strings.EqualFold(translatedLocale, locale)
+		})
+		if translated {
+			status.TranslatedLocales = append(status.TranslatedLocales, locale)
+		} else {
+			status.MissingLocales = append(status.MissingLocales, locale)
+		}
+	}
+	return status
+}
+
+func templateContentLocale(content management.TemplateContent) string {
+	switch {
+	case content.TemplateContentEmail != nil:
+		return content.TemplateContentEmail.Locale
+	case content.TemplateContentPush != nil:
+		return content.TemplateContentPush.Locale
+	case content.TemplateContentSMS != nil:
+		return content.TemplateContentSMS.Locale
+	case content.TemplateContentVoice != nil:
+		return content.TemplateContentVoice.Locale
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputlimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// ContinuationURIPrefix is the URI prefix of truncated result continuation resources.
+	ContinuationURIPrefix = "pingone://truncated-results/"
+
+	// ContinuationURITemplate is the MCP resource template for reading the continuation of a truncated result.
+	ContinuationURITemplate = ContinuationURIPrefix + "{resultId}{?offset}"
+
+	// DefaultMaxStoredResults is the default number of truncated results retained before the oldest is evicted.
+	DefaultMaxStoredResults = 20
+
+	continuationMIMEType = "application/json"
+
+	// continuationOverhead is reserved for the fields of a continuation besides its items or text
+	continuationOverhead = 1024
+)
+
+// Continuation is the content of a truncated result continuation resource.
+type Continuation struct {
+	Items       []json.RawMessage `json:"items,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	Offset      int               `json:"offset"`
+	TotalCount  int               `json:"totalCount,omitempty"`
+	TotalLength int               `json:"totalLength,omitempty"`
+	NextUri     string            `json:"nextUri,omitempty"`
+}
+
+// storedResult is the truncated part of a result: either the full list of items or the full text
+type storedResult struct {
+	items []json.RawMessage
+	text  string
+}
+
+// ContinuationStore holds the full content of tool results that were truncated to the output size limit, so that
+// MCP clients can read the rest of the result as resources, each within the limit.
+//
+// Results are held in memory for the lifetime of the server. The number of stored results is bounded, and the
+// oldest result is evicted when the bound is reached.
+type ContinuationStore struct {
+	mu         sync.Mutex
+	maxBytes   int
+	maxResults int
+	results    map[string]storedResult
+	order      []string
+}
+
+// NewContinuationStore creates a store whose continuations are at most about maxBytes in size, and that retains
+// at most maxResults results.
+func NewContinuationStore(maxBytes int, maxResults int) *ContinuationStore {
+	return &ContinuationStore{
+		maxBytes:   maxBytes,
+		maxResults: maxResults,
+		results:    make(map[string]storedResult),
+	}
+}
+
+// Register adds the continuation resource template to the MCP server.
+func (s *ContinuationStore) Register(server *mcp.Server) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "truncated_results",
+		Title:       "Truncated Result Continuations",
+		Description: "The rest of a tool result that was truncated because it exceeded the output size limit. Truncated results return the URI of the continuation; each continuation includes the URI of the next one.",
+		MIMEType:    continuationMIMEType,
+		URITemplate: ContinuationURITemplate,
+	}, s.ReadResource)
+}
+
+// Clear removes all stored results.
+func (s *ContinuationStore) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.results)
+	s.order = nil
+}
+
+// storeItems records the full list of items of a truncated result and returns the URI of the continuation
+// from the first item that was not returned.
+func (s *ContinuationStore) storeItems(items []json.RawMessage, offset int) string {
+	return continuationURI(s.store(storedResult{items: items}), offset)
+}
+
+// storeText records the full text of a truncated result and returns the URI of the continuation from the
+// first byte that was not returned.
+func (s *ContinuationStore) storeText(text string, offset int) string {
+	return continuationURI(s.store(storedResult{text: text}), offset)
+}
+
+func (s *ContinuationStore) store(result storedResult) string {
+	resultId := uuid.NewString()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.order) >= s.maxResults && len(s.order) > 0 {
+		delete(s.results, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.results[resultId] = result
+	s.order = append(s.order, resultId)
+	return resultId
+}
+
+// ReadResource returns the continuation of a stored result from the offset in its URI. It implements
+// mcp.ResourceHandler.
+func (s *ContinuationStore) ReadResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	resultId, offset, err := parseContinuationURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	result, ok := s.results[resultId]
+	s.mu.Unlock()
+	if !ok {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	var continuation Continuation
+	if result.items != nil {
+		if offset >= len(result.items) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		end := fittingItems(result.items, offset, s.maxBytes-continuationOverhead)
+		continuation = Continuation{
+			Items:      result.items[offset:end],
+			Offset:     offset,
+			TotalCount: len(result.items),
+		}
+		if end < len(result.items) {
+			continuation.NextUri = continuationURI(resultId, end)
+		}
+	} else {
+		if offset >= len(result.text) || !utf8.RuneStart(result.text[offset]) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		end := fittingText(result.text, offset, s.maxBytes-continuationOverhead)
+		continuation = Continuation{
+			Text:        result.text[offset:end],
+			Offset:      offset,
+			TotalLength: len(result.text),
+		}
+		if end < len(result.text) {
+			continuation.NextUri = continuationURI(resultId, end)
+		}
+	}
+
+	text, err := json.Marshal(continuation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result continuation: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: continuationMIMEType,
+				Text:     string(text),
+			},
+		},
+	}, nil
+}
+
+// fittingItems returns the end of the items from the offset that fit in maxBytes as a JSON array. At least one
+// item is included, so that reading the continuations always progresses.
+func fittingItems(items []json.RawMessage, offset int, maxBytes int) int {
+	end := offset
+	size := 2
+	for end < len(items) {
+		size += len(items[end]) + 1
+		if size > maxBytes && end > offset {
+			break
+		}
+		end++
+	}
+	return end
+}
+
+// fittingText returns the end of the text from the offset that fits in maxBytes, ending at a character boundary.
+// At least one character is included, so that reading the continuations always progresses.
+func fittingText(text string, offset int, maxBytes int) int {
+	end := min(offset+max(maxBytes, 1), len(text))
+	for end < len(text) && end > offset && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	if end == offset {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		end = offset + size
+	}
+	return end
+}
+
+func continuationURI(resultId string, offset int) string {
+	return fmt.Sprintf("%s%s?offset=%d", ContinuationURIPrefix, resultId, offset)
+}
+
+func parseContinuationURI(uri string) (string, int, error) {
+	if !strings.HasPrefix(uri, ContinuationURIPrefix) {
+		return "", 0, mcp.ResourceNotFoundError(uri)
+	}
+
+	resultId, rawQuery, _ := strings.Cut(strings.TrimPrefix(uri, ContinuationURIPrefix), "?")
+	if resultId == "" {
+		return "", 0, mcp.ResourceNotFoundError(uri)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid continuation URI %q: %w", uri, err)
+	}
+
+	offset := 0
+	if offsetValue := query.Get("offset"); offsetValue != "" {
+		offset, err = strconv.Atoi(offsetValue)
+		if err != nil || offset < 0 {
+			return "", 0, fmt.Errorf("invalid offset %q in continuation URI, offset must be a non-negative integer", offsetValue)
+		}
+	}
+
+	return resultId, offset, nil
+}
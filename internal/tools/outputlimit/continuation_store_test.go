@@ -0,0 +1,66 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputlimit_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputlimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readContinuation(t *testing.T, store *outputlimit.ContinuationStore, uri string) outputlimit.Continuation {
+	t.Helper()
+	result, err := store.ReadResource(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: uri},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	assert.Equal(t, uri, result.Contents[0].URI)
+
+	var continuation outputlimit.Continuation
+	require.NoError(t, json.Unmarshal([]byte(result.Contents[0].Text), &continuation))
+	return continuation
+}
+
+func TestContinuationStore_ReadResource_NotFound(t *testing.T) {
+	store := outputlimit.NewContinuationStore(2000, outputlimit.DefaultMaxStoredResults)
+
+	_, err := store.ReadResource(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: outputlimit.ContinuationURIPrefix + "unknown?offset=1"},
+	})
+	assert.Error(t, err)
+
+	_, err = store.ReadResource(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: outputlimit.ContinuationURIPrefix + "unknown?offset=-1"},
+	})
+	assert.ErrorContains(t, err, "offset must be a non-negative integer")
+}
+
+func TestContinuationStore_Clear(t *testing.T) {
+	store := outputlimit.NewContinuationStore(2000, outputlimit.DefaultMaxStoredResults)
+	uri := truncatedContinuationUri(t, store)
+
+	store.Clear()
+
+	_, err := store.ReadResource(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: uri},
+	})
+	assert.Error(t, err)
+}
+
+func TestContinuationStore_EvictsOldestResult(t *testing.T) {
+	store := outputlimit.NewContinuationStore(2000, 1)
+	first := truncatedContinuationUri(t, store)
+	second := truncatedContinuationUri(t, store)
+
+	_, err := store.ReadResource(context.Background(), &mcp.ReadResourceRequest{
+		Params: &mcp.ReadResourceParams{URI: first},
+	})
+	assert.Error(t, err)
+	assert.NotEmpty(t, readContinuation(t, store, second).Items)
+}
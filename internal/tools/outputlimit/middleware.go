@@ -0,0 +1,185 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputlimit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// OutputLimitMiddleware truncates tool results larger than the output size limit, so that large list results are
+// not dropped by MCP clients or flood the agent's context.
+//
+// The largest list in the structured output is reduced to the items that fit in the limit, and a truncation field
+// is added to the output with a summary and the URI of a continuation resource holding the remaining items. The
+// JSON text content is replaced with the truncated output, and a resource link to the continuation is added.
+// Results without structured output have their text truncated instead. Structured output without a list to
+// truncate, such as a single very large resource, is returned in full.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, outside any middleware that
+// reduces or adds to tool output, so that the limit applies to the output the client receives.
+type OutputLimitMiddleware struct {
+	maxBytes int
+	store    *ContinuationStore
+}
+
+// NewOutputLimitMiddleware creates middleware that truncates tool results larger than maxBytes and keeps the rest
+// of the results in the store. A maxBytes of 0 or a nil store disables truncation.
+func NewOutputLimitMiddleware(maxBytes int, store *ContinuationStore) *OutputLimitMiddleware {
+	return &OutputLimitMiddleware{
+		maxBytes: maxBytes,
+		store:    store,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *OutputLimitMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.maxBytes <= 0 || m.store == nil {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		toolName := callToolReq.Params.Name
+
+		result, err := next(ctx, method, req)
+		if err != nil {
+			return result, err
+		}
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callToolResult == nil || callToolResult.IsError {
+			return result, err
+		}
+
+		var truncated *mcp.CallToolResult
+		if callToolResult.StructuredContent != nil {
+			truncated, err = m.truncateStructuredResult(ctx, toolName, callToolResult)
+		} else {
+			truncated = m.truncateTextResult(ctx, toolName, callToolResult)
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to truncate tool result",
+				slog.String("tool", toolName),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("output limit failed: %w", err)
+		}
+		if truncated == nil {
+			return result, nil
+		}
+		return truncated, nil
+	}
+}
+
+// truncateStructuredResult returns a copy of the result with the largest list of its structured output truncated to
+// the limit, or nil if the result is within the limit or has no list to truncate
+func (m *OutputLimitMiddleware) truncateStructuredResult(ctx context.Context, toolName string, result *mcp.CallToolResult) (*mcp.CallToolResult, error) {
+	structuredJSON, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal structured content: %w", err)
+	}
+	if len(structuredJSON) <= m.maxBytes {
+		return nil, nil
+	}
+
+	var output map[string]json.RawMessage
+	field, ok := "", false
+	if err := json.Unmarshal(structuredJSON, &output); err == nil {
+		field, ok = largestListField(output)
+	}
+	if !ok {
+		logger.FromContext(ctx).Warn("Tool result exceeds the output size limit but has no list to truncate",
+			slog.String("tool", toolName),
+			slog.Int("size", len(structuredJSON)),
+			slog.Int("maxBytes", m.maxBytes))
+		return nil, nil
+	}
+
+	truncatedOutput, items, kept, err := truncateList(output, field, m.maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	truncation := Truncation{
+		Field:           field,
+		ReturnedCount:   kept,
+		TotalCount:      len(items),
+		ContinuationUri: m.store.storeItems(items, kept),
+		Summary: fmt.Sprintf("The output exceeded the limit of %d bytes, so only %d of the %d %s were returned. Read the continuationUri resource for the remaining items, following nextUri until it is empty, or narrow the call, such as with a filter.",
+			m.maxBytes, kept, len(items), field),
+	}
+	if truncatedOutput[TruncationField], err = json.Marshal(truncation); err != nil {
+		return nil, fmt.Errorf("failed to marshal truncation: %w", err)
+	}
+	truncatedJSON, err := json.Marshal(truncatedOutput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal truncated output: %w", err)
+	}
+
+	logger.FromContext(ctx).Info("Truncated tool result to the output size limit",
+		slog.String("tool", toolName),
+		slog.String("field", field),
+		slog.Int("size", len(structuredJSON)),
+		slog.Int("maxBytes", m.maxBytes),
+		slog.Int("returnedCount", kept),
+		slog.Int("totalCount", len(items)))
+
+	truncated := *result
+	truncated.StructuredContent = json.RawMessage(truncatedJSON)
+	truncated.Content = make([]mcp.Content, 0, len(result.Content)+1)
+	for i, content := range result.Content {
+		if _, ok := content.(*mcp.TextContent); ok && i == 0 {
+			// The first text content holds the JSON output
+			content = &mcp.TextContent{Text: string(truncatedJSON)}
+		}
+		truncated.Content = append(truncated.Content, content)
+	}
+	truncated.Content = append(truncated.Content, continuationLink(toolName, truncation.ContinuationUri,
+		fmt.Sprintf("The remaining %d of the %d %s of the truncated result", len(items)-kept, len(items), field)))
+	return &truncated, nil
+}
+
+// truncateTextResult returns a copy of the result with its text truncated to the limit, or nil if the result is
+// within the limit
+func (m *OutputLimitMiddleware) truncateTextResult(ctx context.Context, toolName string, result *mcp.CallToolResult) *mcp.CallToolResult {
+	if len(result.Content) == 0 {
+		return nil
+	}
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	if !ok || len(textContent.Text) <= m.maxBytes {
+		return nil
+	}
+
+	text := textContent.Text
+	end := truncateText(text, m.maxBytes)
+	continuationUri := m.store.storeText(text, end)
+
+	logger.FromContext(ctx).Info("Truncated tool result text to the output size limit",
+		slog.String("tool", toolName),
+		slog.Int("size", len(text)),
+		slog.Int("maxBytes", m.maxBytes))
+
+	truncated := *result
+	truncated.Content = append([]mcp.Content{
+		&mcp.TextContent{Text: fmt.Sprintf("%s\n\n[The output exceeded the limit of %d bytes, so only the first %d of %d bytes were returned. Read the %s resource for the rest, following nextUri until it is empty.]",
+			text[:end], m.maxBytes, end, len(text), continuationUri)},
+	}, result.Content[1:]...)
+	truncated.Content = append(truncated.Content, continuationLink(toolName, continuationUri,
+		fmt.Sprintf("The remaining %d of the %d bytes of the truncated result", len(text)-end, len(text))))
+	return &truncated
+}
+
+func continuationLink(toolName string, uri string, description string) *mcp.ResourceLink {
+	return &mcp.ResourceLink{
+		URI:         uri,
+		Name:        toolName,
+		Description: description,
+		MIMEType:    continuationMIMEType,
+	}
+}
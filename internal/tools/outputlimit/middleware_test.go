@@ -0,0 +1,191 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputlimit_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputlimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMaxBytes = 2000
+
+type thing struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type listThingsOutput struct {
+	EnvironmentId string   `json:"environmentId"`
+	Things        []thing  `json:"things"`
+	Tags          []string `json:"tags"`
+}
+
+func testThings(count int) []thing {
+	things := make([]thing, count)
+	for i := range things {
+		things[i] = thing{Id: fmt.Sprintf("thing-%03d", i), Name: strings.Repeat("x", 60)}
+	}
+	return things
+}
+
+// listResult returns the result a list tool handler returns for the output, with the output as JSON text
+func listResult(t *testing.T, output any) *mcp.CallToolResult {
+	t.Helper()
+	text, err := json.Marshal(output)
+	require.NoError(t, err)
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: string(text)}},
+		StructuredContent: output,
+	}
+}
+
+func callTool(t *testing.T, store *outputlimit.ContinuationStore, result *mcp.CallToolResult) *mcp.CallToolResult {
+	t.Helper()
+	handler := outputlimit.NewOutputLimitMiddleware(testMaxBytes, store).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return result, nil
+	})
+	returned, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "list_things"},
+	})
+	require.NoError(t, err)
+	callToolResult, ok := returned.(*mcp.CallToolResult)
+	require.True(t, ok)
+	return callToolResult
+}
+
+// truncatedContinuationUri truncates a large list result and returns the URI of its continuation
+func truncatedContinuationUri(t *testing.T, store *outputlimit.ContinuationStore) string {
+	t.Helper()
+	result := callTool(t, store, listResult(t, listThingsOutput{EnvironmentId: "env-1", Things: testThings(100)}))
+	var output struct {
+		Truncation outputlimit.Truncation `json:"truncation"`
+	}
+	require.NoError(t, json.Unmarshal(result.StructuredContent.(json.RawMessage), &output))
+	return output.Truncation.ContinuationUri
+}
+
+func TestOutputLimitMiddleware_TruncatesLargestList(t *testing.T) {
+	store := outputlimit.NewContinuationStore(testMaxBytes, outputlimit.DefaultMaxStoredResults)
+	things := testThings(100)
+	original := listResult(t, listThingsOutput{EnvironmentId: "env-1", Things: things, Tags: []string{"a", "b"}})
+
+	result := callTool(t, store, original)
+
+	structuredJSON, ok := result.StructuredContent.(json.RawMessage)
+	require.True(t, ok)
+	assert.LessOrEqual(t, len(structuredJSON), testMaxBytes)
+	var output struct {
+		listThingsOutput
+		Truncation outputlimit.Truncation `json:"truncation"`
+	}
+	require.NoError(t, json.Unmarshal(structuredJSON, &output))
+	assert.Equal(t, "env-1", output.EnvironmentId)
+	assert.Equal(t, []string{"a", "b"}, output.Tags, "smaller lists should not be truncated")
+	require.NotEmpty(t, output.Things)
+	assert.Equal(t, things[:len(output.Things)], output.Things)
+
+	truncation := output.Truncation
+	assert.Equal(t, "things", truncation.Field)
+	assert.Equal(t, len(output.Things), truncation.ReturnedCount)
+	assert.Equal(t, 100, truncation.TotalCount)
+	assert.Contains(t, truncation.Summary, fmt.Sprintf("only %d of the 100 things were returned", len(output.Things)))
+
+	require.Len(t, result.Content, 2)
+	assert.JSONEq(t, string(structuredJSON), result.Content[0].(*mcp.TextContent).Text)
+	link, ok := result.Content[1].(*mcp.ResourceLink)
+	require.True(t, ok)
+	assert.Equal(t, truncation.ContinuationUri, link.URI)
+
+	// The remaining items are read from the continuations, each within the limit
+	var remaining []thing
+	for uri := truncation.ContinuationUri; uri != ""; {
+		continuation := readContinuation(t, store, uri)
+		assert.Equal(t, 100, continuation.TotalCount)
+		for _, item := range continuation.Items {
+			var th thing
+			require.NoError(t, json.Unmarshal(item, &th))
+			remaining = append(remaining, th)
+		}
+		uri = continuation.NextUri
+	}
+	assert.Equal(t, things[len(output.Things):], remaining)
+
+	assert.Len(t, original.Content, 1, "the result of the tool should not be changed, as it may be cached")
+}
+
+func TestOutputLimitMiddleware_TruncatesText(t *testing.T) {
+	store := outputlimit.NewContinuationStore(testMaxBytes, outputlimit.DefaultMaxStoredResults)
+	text := strings.Repeat("é", 3000)
+
+	result := callTool(t, store, &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text}}})
+
+	require.Len(t, result.Content, 2)
+	truncatedText := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, truncatedText, "[The output exceeded the limit of 2000 bytes")
+	link, ok := result.Content[1].(*mcp.ResourceLink)
+	require.True(t, ok)
+
+	var rest strings.Builder
+	for uri := link.URI; uri != ""; {
+		continuation := readContinuation(t, store, uri)
+		assert.Equal(t, len(text), continuation.TotalLength)
+		rest.WriteString(continuation.Text)
+		uri = continuation.NextUri
+	}
+	returned, _, found := strings.Cut(truncatedText, "\n\n[The output exceeded")
+	require.True(t, found)
+	assert.Equal(t, text, returned+rest.String())
+}
+
+func TestOutputLimitMiddleware_NotTruncated(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxBytes int
+		result   *mcp.CallToolResult
+	}{
+		{
+			name:     "Within limit",
+			maxBytes: testMaxBytes,
+			result:   listResult(t, listThingsOutput{Things: testThings(2)}),
+		},
+		{
+			name:     "No list to truncate",
+			maxBytes: testMaxBytes,
+			result:   listResult(t, thing{Id: "thing-1", Name: strings.Repeat("x", 3000)}),
+		},
+		{
+			name:     "Error result",
+			maxBytes: testMaxBytes,
+			result:   &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: strings.Repeat("x", 3000)}}},
+		},
+		{
+			name:     "Limit disabled",
+			maxBytes: 0,
+			result:   listResult(t, listThingsOutput{Things: testThings(100)}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := outputlimit.NewContinuationStore(testMaxBytes, outputlimit.DefaultMaxStoredResults)
+			handler := outputlimit.NewOutputLimitMiddleware(tt.maxBytes, store).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				return tt.result, nil
+			})
+
+			result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+				Params: &mcp.CallToolParamsRaw{Name: "list_things"},
+			})
+
+			require.NoError(t, err)
+			assert.Same(t, tt.result, result)
+		})
+	}
+}
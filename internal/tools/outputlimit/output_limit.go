@@ -0,0 +1,114 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputlimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultMaxOutputBytes is the default size limit of tool output, above which results are truncated.
+	DefaultMaxOutputBytes = 500000
+
+	// BytesPerToken approximates the number of bytes of JSON output in a token, to apply token limits to output
+	BytesPerToken = 4
+
+	// TruncationField is the field added to truncated structured output to describe the truncation
+	TruncationField = "truncation"
+
+	// truncationOverhead is reserved for the truncation field added to truncated output
+	truncationOverhead = 1024
+)
+
+// Truncation describes how a tool result was truncated, and where to read the rest of it.
+type Truncation struct {
+	// Field is the list field of the output that was truncated
+	Field string `json:"field"`
+	// ReturnedCount is the number of items of the list that were returned
+	ReturnedCount int `json:"returnedCount"`
+	// TotalCount is the total number of items of the list
+	TotalCount int `json:"totalCount"`
+	// ContinuationUri is the MCP resource URI of the rest of the list
+	ContinuationUri string `json:"continuationUri"`
+	// Summary describes the truncation and how to get the rest of the list, for the agent
+	Summary string `json:"summary"`
+}
+
+// MaxBytes returns the output size limit in bytes from the configured byte and token limits, which is the
+// smaller of the two. A limit of 0 is not applied, and 0 is returned when neither limit is applied.
+func MaxBytes(maxBytes int, maxTokens int) int {
+	tokenBytes := maxTokens * BytesPerToken
+	switch {
+	case maxBytes <= 0:
+		return max(tokenBytes, 0)
+	case tokenBytes <= 0:
+		return maxBytes
+	default:
+		return min(maxBytes, tokenBytes)
+	}
+}
+
+// largestListField returns the name of the list field of the output that is largest when marshalled, or false if
+// the output has no list fields with items.
+func largestListField(output map[string]json.RawMessage) (string, bool) {
+	var largest string
+	largestSize := 0
+	for name, value := range output {
+		var items []json.RawMessage
+		if err := json.Unmarshal(value, &items); err != nil || len(items) == 0 {
+			continue
+		}
+		if len(value) > largestSize {
+			largest = name
+			largestSize = len(value)
+		}
+	}
+	return largest, largest != ""
+}
+
+// truncateList returns the output with the list field reduced to the items that fit in maxBytes together with the
+// other fields of the output, along with all items of the list and the number that were kept.
+func truncateList(output map[string]json.RawMessage, field string, maxBytes int) (map[string]json.RawMessage, []json.RawMessage, int, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(output[field], &items); err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to unmarshal list field %q: %w", field, err)
+	}
+
+	truncated := make(map[string]json.RawMessage, len(output))
+	for name, value := range output {
+		truncated[name] = value
+	}
+	truncated[field] = json.RawMessage("[]")
+	base, err := json.Marshal(truncated)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to marshal truncated output: %w", err)
+	}
+
+	kept := 0
+	size := len(base) + truncationOverhead
+	for kept < len(items) {
+		size += len(items[kept]) + 1
+		if size > maxBytes {
+			break
+		}
+		kept++
+	}
+
+	keptItems, err := json.Marshal(items[:kept])
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to marshal truncated list field %q: %w", field, err)
+	}
+	truncated[field] = keptItems
+	return truncated, items, kept, nil
+}
+
+// truncateText returns the length of the start of the text that fits in maxBytes, ending at a character boundary.
+func truncateText(text string, maxBytes int) int {
+	end := min(max(maxBytes-truncationOverhead, 0), len(text))
+	for end > 0 && end < len(text) && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return end
+}
@@ -0,0 +1,31 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputlimit_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputlimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		maxBytes  int
+		maxTokens int
+		expected  int
+	}{
+		{name: "Bytes only", maxBytes: 100000, expected: 100000},
+		{name: "Tokens only", maxTokens: 10000, expected: 40000},
+		{name: "Tokens smaller", maxBytes: 100000, maxTokens: 10000, expected: 40000},
+		{name: "Bytes smaller", maxBytes: 20000, maxTokens: 10000, expected: 20000},
+		{name: "Disabled", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, outputlimit.MaxBytes(tt.maxBytes, tt.maxTokens))
+		})
+	}
+}
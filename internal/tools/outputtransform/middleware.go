@@ -0,0 +1,168 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputtransform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// OutputTransformMiddleware applies operator-configured transformers to the output of tool calls,
+// so that deployments can enrich or reshape tool outputs without forking the server.
+//
+// Transformers are applied to the structured content of the result and its JSON text content, or to
+// the JSON text content of tools without structured output. If a transformer fails, the tool call fails
+// rather than returning the untransformed output, as the transformer may be relied on to redact data.
+//
+// As transformed structured content no longer matches the tool's output schema, tools with a transformer are
+// listed to MCP clients with the output schema of the transformer, or without an output schema if the
+// transformer does not describe its output. Transformed structured content that does not match the output
+// schema of the transformer fails the tool call.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type OutputTransformMiddleware struct {
+	transformers OutputTransformers
+}
+
+// NewOutputTransformMiddleware creates middleware from the transformers returned by LoadOutputTransformers.
+func NewOutputTransformMiddleware(transformers OutputTransformers) *OutputTransformMiddleware {
+	return &OutputTransformMiddleware{
+		transformers: transformers,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *OutputTransformMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if len(m.transformers) == 0 {
+			return next(ctx, method, req)
+		}
+		if method == "tools/list" {
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+			return m.listTransformedOutputSchemas(result), nil
+		}
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		toolName := callToolReq.Params.Name
+		transformer, ok := m.transformers[toolName]
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		result, err := next(ctx, method, req)
+		if err != nil {
+			return result, err
+		}
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callToolResult == nil || callToolResult.IsError {
+			return result, err
+		}
+
+		if err := transformResult(ctx, toolName, callToolResult, transformer); err != nil {
+			logger.FromContext(ctx).Error("Failed to apply output transformer",
+				slog.String("tool", toolName),
+				slog.String("error", err.Error()))
+			return nil, fmt.Errorf("output transformer failed: %w", err)
+		}
+
+		logger.FromContext(ctx).Debug("Applied output transformer to tool result", slog.String("tool", toolName))
+
+		return callToolResult, nil
+	}
+}
+
+// listTransformedOutputSchemas replaces the output schemas of the listed tools that have a transformer with the
+// output schema of the transformer, or removes them if the transformer does not describe its output.
+func (m *OutputTransformMiddleware) listTransformedOutputSchemas(result mcp.Result) mcp.Result {
+	listResult, ok := result.(*mcp.ListToolsResult)
+	if !ok {
+		return result
+	}
+
+	transformed := *listResult
+	transformed.Tools = make([]*mcp.Tool, len(listResult.Tools))
+	for i, tool := range listResult.Tools {
+		transformer, ok := m.transformers[tool.Name]
+		if !ok || tool.OutputSchema == nil {
+			transformed.Tools[i] = tool
+			continue
+		}
+		transformedTool := *tool
+		transformedTool.OutputSchema = nil
+		if outputSchema := transformerOutputSchema(transformer); outputSchema != nil {
+			transformedTool.OutputSchema = outputSchema.Schema()
+		}
+		transformed.Tools[i] = &transformedTool
+	}
+	return &transformed
+}
+
+// transformerOutputSchema returns the output schema of the transformer, or nil if it does not describe its output.
+func transformerOutputSchema(transformer Transformer) *jsonschema.Resolved {
+	schemaTransformer, ok := transformer.(SchemaTransformer)
+	if !ok {
+		return nil
+	}
+	return schemaTransformer.OutputSchema()
+}
+
+// transformResult replaces the structured content of the result, and the first text content when it holds
+// the JSON output, with the transformed output. The remaining content, such as resource links, is unchanged.
+func transformResult(ctx context.Context, toolName string, result *mcp.CallToolResult, transformer Transformer) error {
+	var textContent *mcp.TextContent
+	if len(result.Content) > 0 {
+		textContent, _ = result.Content[0].(*mcp.TextContent)
+	}
+
+	if result.StructuredContent != nil {
+		structuredJSON, err := json.Marshal(result.StructuredContent)
+		if err != nil {
+			return fmt.Errorf("failed to marshal structured content: %w", err)
+		}
+		transformed, err := transformer.Transform(ctx, toolName, structuredJSON)
+		if err != nil {
+			return err
+		}
+		if outputSchema := transformerOutputSchema(transformer); outputSchema != nil {
+			var value any
+			if err := json.Unmarshal(transformed, &value); err != nil {
+				return fmt.Errorf("failed to unmarshal transformed output: %w", err)
+			}
+			if err := outputSchema.Validate(value); err != nil {
+				return fmt.Errorf("transformed output does not match the output schema of the transformer: %w", err)
+			}
+		}
+		result.StructuredContent = transformed
+		if textContent != nil {
+			textContent.Text = string(transformed)
+		}
+		return nil
+	}
+
+	if textContent == nil || !json.Valid([]byte(textContent.Text)) {
+		// Output is not JSON, so there is nothing to transform
+		return nil
+	}
+	transformed, err := transformer.Transform(ctx, toolName, json.RawMessage(textContent.Text))
+	if err != nil {
+		return err
+	}
+	textContent.Text = string(transformed)
+	return nil
+}
@@ -0,0 +1,276 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputtransform_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputtransform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testThing struct {
+	Id string `json:"id"`
+}
+
+type testToolOutput struct {
+	Things []testThing `json:"things"`
+}
+
+// taggingTransformer tags the output with the tool name, standing in for a deployment's enrichment hook
+type taggingTransformer struct {
+	err error
+}
+
+func (t *taggingTransformer) Transform(ctx context.Context, toolName string, output json.RawMessage) (json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	var value map[string]any
+	if err := json.Unmarshal(output, &value); err != nil {
+		return nil, err
+	}
+	value["transformedBy"] = toolName
+	return json.Marshal(value)
+}
+
+// describedTaggingTransformer is a taggingTransformer that describes its output with an output schema
+type describedTaggingTransformer struct {
+	taggingTransformer
+	outputSchema *jsonschema.Resolved
+}
+
+func (t *describedTaggingTransformer) OutputSchema() *jsonschema.Resolved {
+	return t.outputSchema
+}
+
+func newDescribedTaggingTransformer(t *testing.T, outputSchema string) *describedTaggingTransformer {
+	t.Helper()
+
+	schema := &jsonschema.Schema{}
+	require.NoError(t, json.Unmarshal([]byte(outputSchema), schema))
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	return &describedTaggingTransformer{outputSchema: resolved}
+}
+
+func newThingsServer(t *testing.T, transformers outputtransform.OutputTransformers) *mcp.Server {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(outputtransform.NewOutputTransformMiddleware(transformers).Handler)
+
+	mcp.AddTool(server, testToolDefs[0].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, *testToolOutput, error) {
+		return nil, &testToolOutput{Things: []testThing{{Id: "thing-1"}}}, nil
+	})
+	mcp.AddTool(server, testToolDefs[1].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: `{"id": "thing-1"}`}},
+		}, nil, nil
+	})
+	return server
+}
+
+func TestOutputTransformMiddleware_OverMcp(t *testing.T) {
+	tests := []struct {
+		name               string
+		transformers       outputtransform.OutputTransformers
+		toolName           string
+		expectedText       string
+		expectedStructured string
+	}{
+		{
+			name:               "Structured output is transformed",
+			transformers:       outputtransform.OutputTransformers{"list_things": &taggingTransformer{}},
+			toolName:           "list_things",
+			expectedText:       `{"things": [{"id": "thing-1"}], "transformedBy": "list_things"}`,
+			expectedStructured: `{"things": [{"id": "thing-1"}], "transformedBy": "list_things"}`,
+		},
+		{
+			name:         "Text output is transformed",
+			transformers: outputtransform.OutputTransformers{"get_thing_text": &taggingTransformer{}},
+			toolName:     "get_thing_text",
+			expectedText: `{"id": "thing-1", "transformedBy": "get_thing_text"}`,
+		},
+		{
+			name:               "Tool without transformer is unchanged",
+			transformers:       outputtransform.OutputTransformers{"get_thing_text": &taggingTransformer{}},
+			toolName:           "list_things",
+			expectedText:       `{"things": [{"id": "thing-1"}]}`,
+			expectedStructured: `{"things": [{"id": "thing-1"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newThingsServer(t, tt.transformers)
+
+			output, err := mcptestutils.CallToolOverMcp(t, server, tt.toolName, map[string]any{})
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			assert.False(t, output.IsError)
+
+			require.NotEmpty(t, output.Content)
+			textContent, ok := output.Content[0].(*mcp.TextContent)
+			require.True(t, ok, "Expected text content")
+			assert.JSONEq(t, tt.expectedText, textContent.Text)
+
+			if tt.expectedStructured == "" {
+				assert.Nil(t, output.StructuredContent)
+				return
+			}
+			structuredJSON, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedStructured, string(structuredJSON))
+		})
+	}
+}
+
+func TestOutputTransformMiddleware_TransformerError(t *testing.T) {
+	middleware := outputtransform.NewOutputTransformMiddleware(outputtransform.OutputTransformers{
+		"list_things": &taggingTransformer{err: errors.New("lookup unavailable")},
+	})
+
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{StructuredContent: map[string]any{"things": []any{}}}, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "list_things",
+			Arguments: []byte(`{}`),
+		},
+	}
+
+	result, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "output transformer failed: lookup unavailable")
+	assert.Nil(t, result)
+}
+
+func TestOutputTransformMiddleware_ToolErrorUnchanged(t *testing.T) {
+	middleware := outputtransform.NewOutputTransformMiddleware(outputtransform.OutputTransformers{
+		"list_things": &taggingTransformer{},
+	})
+
+	errorResult := &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: `{"error": "not found"}`}},
+	}
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return errorResult, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "list_things",
+			Arguments: []byte(`{}`),
+		},
+	}
+
+	result, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	require.NoError(t, err)
+	assert.Equal(t, errorResult, result)
+}
+
+func TestOutputTransformMiddleware_ListedOutputSchema(t *testing.T) {
+	transformedSchema := `{"type": "object", "required": ["things", "transformedBy"], "properties": {"things": {"type": "array"}, "transformedBy": {"type": "string"}}}`
+
+	tests := []struct {
+		name                     string
+		transformer              outputtransform.Transformer
+		wantOutputSchema         bool
+		wantOutputSchemaRequires []string
+	}{
+		{
+			name:        "Transformer without an output schema",
+			transformer: &taggingTransformer{},
+		},
+		{
+			name:                     "Transformer with an output schema",
+			transformer:              newDescribedTaggingTransformer(t, transformedSchema),
+			wantOutputSchema:         true,
+			wantOutputSchemaRequires: []string{"things", "transformedBy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newThingsServer(t, outputtransform.OutputTransformers{
+				"list_things":    tt.transformer,
+				"get_thing_text": tt.transformer,
+			})
+			serverTransport, clientTransport := mcp.NewInMemoryTransports()
+			serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+			require.NoError(t, err)
+			defer serverSession.Close()
+			session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+			require.NoError(t, err)
+			defer session.Close()
+
+			listResult, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+			require.NoError(t, err)
+			listedTools := map[string]*mcp.Tool{}
+			for _, tool := range listResult.Tools {
+				listedTools[tool.Name] = tool
+			}
+			require.Contains(t, listedTools, "list_things")
+			require.Contains(t, listedTools, "get_thing_text")
+			assert.Nil(t, listedTools["get_thing_text"].OutputSchema, "Tools without structured output should not gain an output schema")
+
+			if !tt.wantOutputSchema {
+				assert.Nil(t, listedTools["list_things"].OutputSchema, "The tool's own output schema does not describe the transformed output")
+				return
+			}
+			require.NotNil(t, listedTools["list_things"].OutputSchema)
+			schemaJSON, err := json.Marshal(listedTools["list_things"].OutputSchema)
+			require.NoError(t, err)
+			listedSchema := &jsonschema.Schema{}
+			require.NoError(t, json.Unmarshal(schemaJSON, listedSchema))
+			assert.ElementsMatch(t, tt.wantOutputSchemaRequires, listedSchema.Required)
+
+			result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_things", Arguments: map[string]any{}})
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			structuredJSON, err := json.Marshal(result.StructuredContent)
+			require.NoError(t, err)
+			var structured map[string]any
+			require.NoError(t, json.Unmarshal(structuredJSON, &structured))
+			resolved, err := listedSchema.Resolve(nil)
+			require.NoError(t, err)
+			assert.NoError(t, resolved.Validate(structured), "The transformed result should match the listed output schema")
+		})
+	}
+}
+
+func TestOutputTransformMiddleware_OutputSchemaMismatch(t *testing.T) {
+	middleware := outputtransform.NewOutputTransformMiddleware(outputtransform.OutputTransformers{
+		"list_things": newDescribedTaggingTransformer(t, `{"type": "object", "required": ["owner"]}`),
+	})
+
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{StructuredContent: map[string]any{"things": []any{}}}, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "list_things",
+			Arguments: []byte(`{}`),
+		},
+	}
+
+	result, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transformed output does not match the output schema of the transformer")
+	assert.Nil(t, result)
+}
@@ -0,0 +1,160 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputtransform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// DefaultTimeout is the time a transformer script is allowed to run when no timeout is configured.
+const DefaultTimeout = 10 * time.Second
+
+// ToolNameEnvVar is the environment variable that holds the name of the tool whose output is being transformed.
+const ToolNameEnvVar = "PINGONE_MCP_TOOL_NAME"
+
+// Transformer post-processes the JSON output of a tool, for example to enrich PingOne IDs with
+// business names held outside of PingOne. The transformed output must also be JSON.
+type Transformer interface {
+	Transform(ctx context.Context, toolName string, output json.RawMessage) (json.RawMessage, error)
+}
+
+// SchemaTransformer is a Transformer whose transformed output is described by an output schema, which is listed
+// to MCP clients in place of the tool's own output schema.
+type SchemaTransformer interface {
+	Transformer
+	// OutputSchema returns the resolved schema of the transformed output, or nil if it is not described.
+	OutputSchema() *jsonschema.Resolved
+}
+
+// OutputTransformers maps a tool name to the transformer applied to that tool's output.
+type OutputTransformers map[string]Transformer
+
+// scriptTransformerConfig is the configuration of a script transformer in the output transformers file.
+type scriptTransformerConfig struct {
+	Command        []string           `json:"command"`
+	TimeoutSeconds int                `json:"timeoutSeconds,omitempty"`
+	OutputSchema   *jsonschema.Schema `json:"outputSchema,omitempty"`
+}
+
+// LoadOutputTransformers reads script transformers from a JSON file. An empty path returns no transformers.
+func LoadOutputTransformers(path string, toolDefs []types.ToolDefinition) (OutputTransformers, error) {
+	if path == "" {
+		return OutputTransformers{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output transformers file: %w", err)
+	}
+
+	return ParseOutputTransformers(data, toolDefs)
+}
+
+// ParseOutputTransformers parses script transformers from JSON in the form:
+//
+//	{"list_populations": {"command": ["/opt/hooks/population-names.sh"], "timeoutSeconds": 5, "outputSchema": {...}}}
+//
+// Each tool must exist in toolDefs, and each output schema must be valid, so that misconfiguration is
+// reported when the server starts rather than silently ignored.
+func ParseOutputTransformers(data []byte, toolDefs []types.ToolDefinition) (OutputTransformers, error) {
+	var raw map[string]scriptTransformerConfig
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid output transformers: %w", err)
+	}
+
+	toolNames := make(map[string]bool, len(toolDefs))
+	for _, toolDef := range toolDefs {
+		toolNames[toolDef.McpTool.Name] = true
+	}
+
+	transformers := make(OutputTransformers, len(raw))
+	for toolName, config := range raw {
+		if !toolNames[toolName] {
+			return nil, fmt.Errorf("invalid output transformer for unknown tool %q", toolName)
+		}
+		if len(config.Command) == 0 || strings.TrimSpace(config.Command[0]) == "" {
+			return nil, fmt.Errorf("invalid output transformer for tool %q, a command is required", toolName)
+		}
+		if config.TimeoutSeconds < 0 {
+			return nil, fmt.Errorf("invalid output transformer for tool %q, timeoutSeconds must not be negative", toolName)
+		}
+
+		timeout := DefaultTimeout
+		if config.TimeoutSeconds > 0 {
+			timeout = time.Duration(config.TimeoutSeconds) * time.Second
+		}
+		transformer := NewScriptTransformer(config.Command, timeout)
+		if config.OutputSchema != nil {
+			outputSchema, err := config.OutputSchema.Resolve(nil)
+			if err != nil {
+				return nil, fmt.Errorf("invalid output transformer for tool %q, invalid outputSchema: %w", toolName, err)
+			}
+			transformer.outputSchema = outputSchema
+		}
+		transformers[toolName] = transformer
+	}
+
+	return transformers, nil
+}
+
+// ScriptTransformer transforms tool output with an external command. The command receives the tool
+// output JSON on stdin and must write the transformed JSON to stdout. The tool name is available to
+// the command in the ToolNameEnvVar environment variable.
+type ScriptTransformer struct {
+	command      []string
+	timeout      time.Duration
+	outputSchema *jsonschema.Resolved
+}
+
+var _ SchemaTransformer = &ScriptTransformer{}
+
+func NewScriptTransformer(command []string, timeout time.Duration) *ScriptTransformer {
+	return &ScriptTransformer{
+		command: command,
+		timeout: timeout,
+	}
+}
+
+// OutputSchema returns the resolved outputSchema configured for the transformer, or nil if none is configured.
+func (t *ScriptTransformer) OutputSchema() *jsonschema.Resolved {
+	return t.outputSchema
+}
+
+func (t *ScriptTransformer) Transform(ctx context.Context, toolName string, output json.RawMessage) (json.RawMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, t.command[0], t.command[1:]...)
+	cmd.Env = append(os.Environ(), ToolNameEnvVar+"="+toolName)
+	cmd.Stdin = bytes.NewReader(output)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("transformer command timed out after %s", t.timeout)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("transformer command failed: %w: %s", err, message)
+		}
+		return nil, fmt.Errorf("transformer command failed: %w", err)
+	}
+
+	transformed := bytes.TrimSpace(stdout.Bytes())
+	if !json.Valid(transformed) {
+		return nil, errors.New("transformer command did not write valid JSON to stdout")
+	}
+	return transformed, nil
+}
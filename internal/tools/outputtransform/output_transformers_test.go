@@ -0,0 +1,143 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package outputtransform_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/outputtransform"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "list_things"}},
+	{McpTool: &mcp.Tool{Name: "get_thing_text"}},
+}
+
+func TestParseOutputTransformers(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		wantTools       []string
+		wantErrContains string
+	}{
+		{
+			name:      "Valid transformers",
+			data:      `{"list_things": {"command": ["jq", "."]}, "get_thing_text": {"command": ["cat"], "timeoutSeconds": 5}}`,
+			wantTools: []string{"list_things", "get_thing_text"},
+		},
+		{
+			name:      "Empty",
+			data:      `{}`,
+			wantTools: []string{},
+		},
+		{
+			name:            "Invalid JSON",
+			data:            `[`,
+			wantErrContains: "invalid output transformers",
+		},
+		{
+			name:            "Unknown tool",
+			data:            `{"delete_things": {"command": ["cat"]}}`,
+			wantErrContains: `unknown tool "delete_things"`,
+		},
+		{
+			name:            "Missing command",
+			data:            `{"list_things": {"command": []}}`,
+			wantErrContains: "a command is required",
+		},
+		{
+			name:      "Output schema",
+			data:      `{"list_things": {"command": ["jq", "."], "outputSchema": {"type": "object", "required": ["things"]}}}`,
+			wantTools: []string{"list_things"},
+		},
+		{
+			name:            "Invalid output schema",
+			data:            `{"list_things": {"command": ["cat"], "outputSchema": {"type": "object", "$ref": "#/$defs/missing"}}}`,
+			wantErrContains: "invalid outputSchema",
+		},
+		{
+			name:            "Negative timeout",
+			data:            `{"list_things": {"command": ["cat"], "timeoutSeconds": -1}}`,
+			wantErrContains: "timeoutSeconds must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transformers, err := outputtransform.ParseOutputTransformers([]byte(tt.data), testToolDefs)
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, transformers, len(tt.wantTools))
+			for _, toolName := range tt.wantTools {
+				assert.Contains(t, transformers, toolName)
+			}
+		})
+	}
+}
+
+func TestLoadOutputTransformers_EmptyPath(t *testing.T) {
+	transformers, err := outputtransform.LoadOutputTransformers("", testToolDefs)
+	require.NoError(t, err)
+	assert.Empty(t, transformers)
+}
+
+func TestScriptTransformer_Transform(t *testing.T) {
+	tests := []struct {
+		name            string
+		command         []string
+		expected        string
+		wantErrContains string
+	}{
+		{
+			name:     "Output replaced with command stdout",
+			command:  []string{"sh", "-c", `cat > /dev/null; printf '{"tool": "%s"}' "$PINGONE_MCP_TOOL_NAME"`},
+			expected: `{"tool": "list_things"}`,
+		},
+		{
+			name:     "Output passed on stdin",
+			command:  []string{"cat"},
+			expected: `{"things": []}`,
+		},
+		{
+			name:            "Command fails",
+			command:         []string{"sh", "-c", "echo broken >&2; exit 1"},
+			wantErrContains: "transformer command failed: exit status 1: broken",
+		},
+		{
+			name:            "Command writes invalid JSON",
+			command:         []string{"echo", "not json"},
+			wantErrContains: "did not write valid JSON",
+		},
+		{
+			name:            "Command times out",
+			command:         []string{"sleep", "5"},
+			wantErrContains: "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transformer := outputtransform.NewScriptTransformer(tt.command, 500*time.Millisecond)
+
+			output, err := transformer.Transform(context.Background(), "list_things", json.RawMessage(`{"things": []}`))
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(output))
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package plan runs plans of several tool calls as one, validating every step before the first is run and undoing
+// the changes of the steps that ran when a later step fails.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolCaller lists and calls the tools of the server on the session of the plan
+type toolCaller struct {
+	listTools func(ctx context.Context) ([]*mcp.Tool, error)
+	callTool  func(ctx context.Context, toolName string, arguments json.RawMessage) (*mcp.CallToolResult, error)
+}
+
+type toolCallerContextKey struct{}
+
+// PlanMiddleware lets the execute_plan tool call the steps of a plan through the next handler, so that each step
+// is authenticated, validated, confirmed and recorded like a tool call of the client.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware before any other middleware, so
+// that the steps of plans pass through all of it.
+type PlanMiddleware struct{}
+
+// NewPlanMiddleware creates middleware that runs the steps of plans through the next handler.
+func NewPlanMiddleware() *PlanMiddleware {
+	return &PlanMiddleware{}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *PlanMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || callToolReq.Params.Name != ExecutePlanDef.McpTool.Name {
+			return next(ctx, method, req)
+		}
+
+		caller := toolCaller{
+			listTools: func(ctx context.Context) ([]*mcp.Tool, error) {
+				tools := []*mcp.Tool{}
+				cursor := ""
+				for {
+					result, err := next(ctx, "tools/list", &mcp.ListToolsRequest{
+						Session: callToolReq.Session,
+						Params:  &mcp.ListToolsParams{Cursor: cursor},
+						Extra:   callToolReq.Extra,
+					})
+					if err != nil {
+						return nil, err
+					}
+					listToolsResult, ok := result.(*mcp.ListToolsResult)
+					if !ok {
+						return nil, fmt.Errorf("unexpected result type %T for tools/list", result)
+					}
+					tools = append(tools, listToolsResult.Tools...)
+					if listToolsResult.NextCursor == "" {
+						return tools, nil
+					}
+					cursor = listToolsResult.NextCursor
+				}
+			},
+			callTool: func(ctx context.Context, toolName string, arguments json.RawMessage) (*mcp.CallToolResult, error) {
+				result, err := next(ctx, "tools/call", &mcp.CallToolRequest{
+					Session: callToolReq.Session,
+					Params:  &mcp.CallToolParamsRaw{Name: toolName, Arguments: arguments},
+					Extra:   callToolReq.Extra,
+				})
+				if err != nil {
+					return nil, err
+				}
+				callToolResult, ok := result.(*mcp.CallToolResult)
+				if !ok {
+					return nil, fmt.Errorf("unexpected result type %T for tool %s", result, toolName)
+				}
+				return callToolResult, nil
+			},
+		}
+		return next(context.WithValue(ctx, toolCallerContextKey{}, caller), method, req)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package plan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/plan"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanMiddleware_StepsPassThroughTheNextMiddleware(t *testing.T) {
+	var calledTools []string
+	var listCount int
+	s := newPlanTestServer(t, nil, func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch method {
+			case "tools/call":
+				calledTools = append(calledTools, req.(*mcp.CallToolRequest).Params.Name)
+			case "tools/list":
+				listCount++
+			}
+			return next(ctx, method, req)
+		}
+	})
+
+	result, output := executePlan(t, s.server, plan.ExecutePlanInput{
+		Steps: []plan.Step{thingStep("update_thing", "one"), thingStep("update_thing", "two")},
+	})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, plan.StatusSucceeded, output.Status)
+	assert.Equal(t, []string{"execute_plan", "update_thing", "update_thing"}, calledTools)
+	assert.Equal(t, 1, listCount, "the enabled tools should be listed through the next middleware")
+}
@@ -0,0 +1,306 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// MaxSteps is the number of steps a plan can have.
+const MaxSteps = 20
+
+var ExecutePlanDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "execute_plan",
+		Title: "Execute Plan",
+		Description: `Run several tool calls as one plan, such as the steps of setting up an application with its populations, groups and role assignments. Every step is validated before the first is run: the tool must be enabled, the arguments must match its input schema, and the environment it acts on must pass the same checks as when the tool is called directly. If any step is invalid, no step is run. Run with dryRun to only validate the plan.
+
+Steps are run in order, and each passes through the same checks, confirmations and audit logging as a direct call of its tool. When a step fails, the remaining steps are skipped and, unless rollbackOnFailure is false, the changes of the steps that ran are undone in reverse order. Only the changes that undo_last_change can undo are rolled back: created resources are not deleted, and a change is not undone if the resource has been changed again since. Check the status of each step to see what was rolled back.
+
+Steps can only call the PingOne tools of the tool collections, not server tools such as login or execute_plan itself. A plan has at most 20 steps.`,
+		InputSchema:  schema.MustGenerateSchema[ExecutePlanInput](),
+		OutputSchema: schema.MustGenerateSchema[ExecutePlanOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type ExecutePlanInput struct {
+	Steps             []Step `json:"steps" jsonschema:"REQUIRED. The tool calls to make, in the order to make them."`
+	RollbackOnFailure *bool  `json:"rollbackOnFailure,omitempty" jsonschema:"OPTIONAL. Undo the changes of the steps that ran when a step fails. Defaults to true."`
+	DryRun            *bool  `json:"dryRun,omitempty" jsonschema:"OPTIONAL. Validate the steps without running them. Defaults to false."`
+}
+
+// Step is a tool call of a plan.
+type Step struct {
+	Tool      string         `json:"tool" jsonschema:"REQUIRED. The name of the tool to call, such as update_population."`
+	Arguments map[string]any `json:"arguments,omitempty" jsonschema:"OPTIONAL. The arguments of the tool call, as they would be passed to the tool directly."`
+}
+
+// Status is the status of a plan.
+type Status string
+
+const (
+	StatusInvalid   Status = "INVALID"
+	StatusValid     Status = "VALID"
+	StatusSucceeded Status = "SUCCEEDED"
+	StatusFailed    Status = "FAILED"
+)
+
+// StepStatus is the status of a step of a plan.
+type StepStatus string
+
+const (
+	StepStatusInvalid        StepStatus = "INVALID"
+	StepStatusValid          StepStatus = "VALID"
+	StepStatusSucceeded      StepStatus = "SUCCEEDED"
+	StepStatusFailed         StepStatus = "FAILED"
+	StepStatusSkipped        StepStatus = "SKIPPED"
+	StepStatusRolledBack     StepStatus = "ROLLED_BACK"
+	StepStatusRollbackFailed StepStatus = "ROLLBACK_FAILED"
+)
+
+// StepResult is the outcome of a step of a plan.
+type StepResult struct {
+	Step          int        `json:"step" jsonschema:"The position of the step in the plan, starting at 1"`
+	Tool          string     `json:"tool" jsonschema:"The tool the step calls"`
+	Status        StepStatus `json:"status" jsonschema:"The status of the step: INVALID or VALID when the plan was not run, otherwise SUCCEEDED, FAILED, SKIPPED when an earlier step failed, ROLLED_BACK when its changes were undone, or ROLLBACK_FAILED when some of its changes could not be undone"`
+	Result        any        `json:"result,omitempty" jsonschema:"The output of the tool"`
+	Error         string     `json:"error,omitempty" jsonschema:"Why the step is invalid or failed"`
+	ChangeIds     []string   `json:"changeIds,omitempty" jsonschema:"The IDs of the undoable changes the step made"`
+	RollbackError string     `json:"rollbackError,omitempty" jsonschema:"Why the changes of the step could not be undone"`
+}
+
+type ExecutePlanOutput struct {
+	Status Status       `json:"status" jsonschema:"The status of the plan: INVALID if a step is invalid and no step was run, VALID if a dry run found every step valid, SUCCEEDED if every step succeeded, or FAILED if a step failed"`
+	Steps  []StepResult `json:"steps" jsonschema:"The outcome of each step, in the order of the plan"`
+}
+
+// StepValidator validates the environment a tool call acts on before it is made, as the environment validation
+// middleware does when the call is made.
+type StepValidator interface {
+	ValidateToolCall(ctx context.Context, toolName string, arguments json.RawMessage) error
+}
+
+// ExecutePlanHandler runs plans of calls of the given tools. Steps are validated with the validator, if there is
+// one, and the changes of a failed plan are undone with the journal, if there is one.
+func ExecutePlanHandler(toolDefs []types.ToolDefinition, validator StepValidator, journal *rollback.Journal) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ExecutePlanInput,
+) (
+	*mcp.CallToolResult,
+	*ExecutePlanOutput,
+	error,
+) {
+	registry := map[string]*types.ToolDefinition{}
+	for i := range toolDefs {
+		registry[toolDefs[i].McpTool.Name] = &toolDefs[i]
+	}
+
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ExecutePlanInput) (*mcp.CallToolResult, *ExecutePlanOutput, error) {
+		caller, ok := ctx.Value(toolCallerContextKey{}).(toolCaller)
+		if !ok {
+			toolErr := errs.NewToolError(ExecutePlanDef.McpTool.Name, errors.New("plans cannot be run, as the server does not add the plan middleware"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(input.Steps) == 0 || len(input.Steps) > MaxSteps {
+			toolErr := errs.NewToolError(ExecutePlanDef.McpTool.Name, fmt.Errorf("a plan must have between 1 and %d steps, got %d", MaxSteps, len(input.Steps)))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		enabledTools, err := caller.listTools(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ExecutePlanDef.McpTool.Name, fmt.Errorf("failed to list the enabled tools: %w", err))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		output := &ExecutePlanOutput{
+			Status: StatusValid,
+			Steps:  make([]StepResult, len(input.Steps)),
+		}
+		arguments := make([]json.RawMessage, len(input.Steps))
+		for i, step := range input.Steps {
+			output.Steps[i] = StepResult{
+				Step:   i + 1,
+				Tool:   step.Tool,
+				Status: StepStatusValid,
+			}
+			arguments[i], err = validateStep(ctx, step, registry, enabledTools, validator)
+			if err != nil {
+				output.Status = StatusInvalid
+				output.Steps[i].Status = StepStatusInvalid
+				output.Steps[i].Error = err.Error()
+			}
+		}
+		if output.Status == StatusInvalid {
+			logger.FromContext(ctx).Info("Plan not run as some of its steps are invalid", slog.Int("steps", len(input.Steps)))
+			return &mcp.CallToolResult{IsError: true}, output, nil
+		}
+		if input.DryRun != nil && *input.DryRun {
+			return nil, output, nil
+		}
+
+		failedStep := -1
+		stepChanges := make([][]rollback.Change, len(input.Steps))
+		for i, step := range input.Steps {
+			result := &output.Steps[i]
+			if failedStep >= 0 {
+				result.Status = StepStatusSkipped
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				failedStep = i
+				result.Status = StepStatusFailed
+				result.Error = fmt.Sprintf("the plan was cancelled before the step was run: %v", err)
+				continue
+			}
+
+			stepCtx, recorder := rollback.ContextWithChangeRecorder(ctx)
+			callToolResult, err := caller.callTool(stepCtx, step.Tool, arguments[i])
+			stepChanges[i] = recorder.Changes()
+			for _, change := range stepChanges[i] {
+				result.ChangeIds = append(result.ChangeIds, change.Id)
+			}
+			if callToolResult != nil {
+				result.Result = callToolResult.StructuredContent
+			}
+
+			switch {
+			case err != nil:
+				result.Error = err.Error()
+			case callToolResult.IsError:
+				result.Error = resultText(callToolResult)
+			default:
+				result.Status = StepStatusSucceeded
+				if result.Result == nil {
+					result.Result = resultText(callToolResult)
+				}
+				continue
+			}
+			failedStep = i
+			result.Status = StepStatusFailed
+			logger.FromContext(ctx).Warn("Plan step failed",
+				slog.Int("step", i+1),
+				slog.String("tool", step.Tool),
+				slog.String("error", result.Error))
+		}
+
+		if failedStep < 0 {
+			output.Status = StatusSucceeded
+			return nil, output, nil
+		}
+		output.Status = StatusFailed
+
+		if journal != nil && (input.RollbackOnFailure == nil || *input.RollbackOnFailure) {
+			// Roll back even when the plan failed because it was cancelled or timed out
+			rollbackCtx := context.WithoutCancel(ctx)
+			for i := failedStep; i >= 0; i-- {
+				rollbackStep(rollbackCtx, journal, &output.Steps[i], stepChanges[i])
+			}
+		}
+		return &mcp.CallToolResult{IsError: true}, output, nil
+	}
+}
+
+// validateStep checks that the tool of the step can be called with its arguments, and returns the arguments to
+// call it with.
+func validateStep(ctx context.Context, step Step, registry map[string]*types.ToolDefinition, enabledTools []*mcp.Tool, validator StepValidator) (json.RawMessage, error) {
+	toolDef, ok := registry[step.Tool]
+	if !ok {
+		return nil, fmt.Errorf("unknown tool %q; steps can only call the PingOne tools of the tool collections", step.Tool)
+	}
+	if !slices.ContainsFunc(enabledTools, func(tool *mcp.Tool) bool { return tool.Name == step.Tool }) {
+		return nil, fmt.Errorf("tool %s is not enabled", step.Tool)
+	}
+
+	args := step.Arguments
+	if args == nil {
+		args = map[string]any{}
+	}
+	if inputSchema, ok := toolDef.McpTool.InputSchema.(*jsonschema.Schema); ok {
+		resolved, err := inputSchema.Resolve(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the input schema of tool %s: %w", step.Tool, err)
+		}
+		if err := resolved.Validate(args); err != nil {
+			return nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	if validator != nil {
+		if err := validator.ValidateToolCall(ctx, step.Tool, arguments); err != nil {
+			return nil, err
+		}
+	}
+	return arguments, nil
+}
+
+// rollbackStep undoes the changes of a step, most recent first. Steps without undoable changes are left as they are.
+func rollbackStep(ctx context.Context, journal *rollback.Journal, result *StepResult, changes []rollback.Change) {
+	if len(changes) == 0 {
+		return
+	}
+	var undoErrs []error
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+		if _, err := journal.Undo(ctx, change.EnvironmentId, change.Id, false); err != nil {
+			undoErrs = append(undoErrs, fmt.Errorf("change %s: %w", change.Id, err))
+		}
+	}
+	if len(undoErrs) > 0 {
+		result.Status = StepStatusRollbackFailed
+		result.RollbackError = errors.Join(undoErrs...).Error()
+		logger.FromContext(ctx).Warn("Failed to roll back plan step",
+			slog.Int("step", result.Step),
+			slog.String("tool", result.Tool),
+			slog.String("error", result.RollbackError))
+		return
+	}
+	result.Status = StepStatusRolledBack
+	logger.FromContext(ctx).Info("Plan step rolled back",
+		slog.Int("step", result.Step),
+		slog.String("tool", result.Tool),
+		slog.Int("changes", len(changes)))
+}
+
+// resultText returns the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if textContent, ok := content.(*mcp.TextContent); ok {
+			texts = append(texts, textContent.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// RegisterExecutePlanTool adds the execute_plan tool to the MCP server.
+func RegisterExecutePlanTool(server *mcp.Server, toolDefs []types.ToolDefinition, validator StepValidator, journal *rollback.Journal) {
+	mcp.AddTool(server, ExecutePlanDef.McpTool, ExecutePlanHandler(toolDefs, validator, journal))
+}
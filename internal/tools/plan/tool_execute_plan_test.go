@@ -0,0 +1,243 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package plan_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/plan"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+
+type thingInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The UUID of the environment."`
+	Name          string    `json:"name" jsonschema:"REQUIRED. The name of the thing."`
+}
+
+var (
+	updateThingDef = types.ToolDefinition{McpTool: &mcp.Tool{Name: "update_thing", InputSchema: schema.MustGenerateSchema[thingInput]()}}
+	failThingDef   = types.ToolDefinition{McpTool: &mcp.Tool{Name: "fail_thing", InputSchema: schema.MustGenerateSchema[thingInput]()}}
+	// hiddenThingDef is a tool of the collections that is not registered on the test server
+	hiddenThingDef = types.ToolDefinition{McpTool: &mcp.Tool{Name: "hidden_thing", InputSchema: schema.MustGenerateSchema[thingInput]()}}
+)
+
+type fakeStepValidator struct {
+	err error
+}
+
+func (v fakeStepValidator) ValidateToolCall(ctx context.Context, toolName string, arguments json.RawMessage) error {
+	return v.err
+}
+
+// planTestServer holds a server with tools that count their calls and the times their changes were undone
+type planTestServer struct {
+	server    *mcp.Server
+	calls     []string
+	undoCount int
+}
+
+// newPlanTestServer creates the test server, with the given middleware added after the plan middleware
+func newPlanTestServer(t *testing.T, validator plan.StepValidator, middleware ...mcp.Middleware) *planTestServer {
+	t.Helper()
+	s := &planTestServer{server: mcptestutils.TestMcpServer(t)}
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+
+	mcp.AddTool(s.server, updateThingDef.McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input thingInput) (*mcp.CallToolResult, map[string]any, error) {
+		s.calls = append(s.calls, "update_thing:"+input.Name)
+		rollback.Record(ctx, rollback.Change{
+			Tool:          "update_thing",
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "thing",
+			ResourceId:    input.Name,
+		}, func(ctx context.Context, force bool) error {
+			s.undoCount++
+			return nil
+		})
+		return nil, map[string]any{"name": input.Name}, nil
+	})
+	mcp.AddTool(s.server, failThingDef.McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input thingInput) (*mcp.CallToolResult, map[string]any, error) {
+		s.calls = append(s.calls, "fail_thing:"+input.Name)
+		return nil, nil, errors.New("thing failed")
+	})
+	plan.RegisterExecutePlanTool(s.server, []types.ToolDefinition{updateThingDef, failThingDef, hiddenThingDef}, validator, journal)
+	s.server.AddReceivingMiddleware(append([]mcp.Middleware{plan.NewPlanMiddleware().Handler, rollback.NewJournalMiddleware(journal).Handler}, middleware...)...)
+	return s
+}
+
+func thingStep(tool string, name string) plan.Step {
+	return plan.Step{
+		Tool: tool,
+		Arguments: map[string]any{
+			"environmentId": testEnvironmentId.String(),
+			"name":          name,
+		},
+	}
+}
+
+func executePlan(t *testing.T, server *mcp.Server, input plan.ExecutePlanInput) (*mcp.CallToolResult, plan.ExecutePlanOutput) {
+	t.Helper()
+	result, err := mcptestutils.CallToolOverMcp(t, server, plan.ExecutePlanDef.McpTool.Name, input)
+	require.NoError(t, err)
+	require.NotNil(t, result.StructuredContent)
+
+	structuredJSON, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var output plan.ExecutePlanOutput
+	require.NoError(t, json.Unmarshal(structuredJSON, &output))
+	return result, output
+}
+
+func stepStatuses(output plan.ExecutePlanOutput) []plan.StepStatus {
+	statuses := []plan.StepStatus{}
+	for _, step := range output.Steps {
+		statuses = append(statuses, step.Status)
+	}
+	return statuses
+}
+
+func TestExecutePlan_Succeeds(t *testing.T) {
+	s := newPlanTestServer(t, nil)
+
+	result, output := executePlan(t, s.server, plan.ExecutePlanInput{
+		Steps: []plan.Step{thingStep("update_thing", "one"), thingStep("update_thing", "two")},
+	})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, plan.StatusSucceeded, output.Status)
+	assert.Equal(t, []plan.StepStatus{plan.StepStatusSucceeded, plan.StepStatusSucceeded}, stepStatuses(output))
+	assert.Equal(t, []string{"update_thing:one", "update_thing:two"}, s.calls)
+	assert.Equal(t, map[string]any{"name": "two"}, output.Steps[1].Result)
+	assert.Len(t, output.Steps[0].ChangeIds, 1)
+	assert.Zero(t, s.undoCount)
+}
+
+func TestExecutePlan_RollsBackOnFailure(t *testing.T) {
+	s := newPlanTestServer(t, nil)
+
+	result, output := executePlan(t, s.server, plan.ExecutePlanInput{
+		Steps: []plan.Step{thingStep("update_thing", "one"), thingStep("fail_thing", "two"), thingStep("update_thing", "three")},
+	})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, plan.StatusFailed, output.Status)
+	assert.Equal(t, []plan.StepStatus{plan.StepStatusRolledBack, plan.StepStatusFailed, plan.StepStatusSkipped}, stepStatuses(output))
+	assert.Contains(t, output.Steps[1].Error, "thing failed")
+	assert.Equal(t, []string{"update_thing:one", "fail_thing:two"}, s.calls, "steps after the failed step should not be run")
+	assert.Equal(t, 1, s.undoCount)
+}
+
+func TestExecutePlan_WithoutRollback(t *testing.T) {
+	s := newPlanTestServer(t, nil)
+
+	result, output := executePlan(t, s.server, plan.ExecutePlanInput{
+		Steps:             []plan.Step{thingStep("update_thing", "one"), thingStep("fail_thing", "two")},
+		RollbackOnFailure: func() *bool { b := false; return &b }(),
+	})
+
+	assert.True(t, result.IsError)
+	assert.Equal(t, plan.StatusFailed, output.Status)
+	assert.Equal(t, []plan.StepStatus{plan.StepStatusSucceeded, plan.StepStatusFailed}, stepStatuses(output))
+	assert.Zero(t, s.undoCount)
+}
+
+func TestExecutePlan_InvalidStepsAreNotRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator plan.StepValidator
+		step      plan.Step
+		wantError string
+	}{
+		{
+			name:      "Unknown tool",
+			step:      thingStep("login", "two"),
+			wantError: `unknown tool "login"`,
+		},
+		{
+			name:      "Tool not enabled",
+			step:      thingStep("hidden_thing", "two"),
+			wantError: "tool hidden_thing is not enabled",
+		},
+		{
+			name:      "Arguments not matching the input schema",
+			step:      plan.Step{Tool: "update_thing", Arguments: map[string]any{"environmentId": testEnvironmentId.String()}},
+			wantError: "invalid arguments",
+		},
+		{
+			name:      "Environment validation fails",
+			validator: fakeStepValidator{err: errors.New("environment validation failed: production environment")},
+			step:      thingStep("update_thing", "two"),
+			wantError: "production environment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newPlanTestServer(t, tt.validator)
+
+			result, output := executePlan(t, s.server, plan.ExecutePlanInput{
+				Steps: []plan.Step{thingStep("update_thing", "one"), tt.step},
+			})
+
+			assert.True(t, result.IsError)
+			assert.Equal(t, plan.StatusInvalid, output.Status)
+			require.Len(t, output.Steps, 2)
+			assert.Contains(t, output.Steps[1].Error, tt.wantError)
+			assert.Equal(t, plan.StepStatusInvalid, output.Steps[1].Status)
+			assert.Empty(t, s.calls, "no step should be run when a step is invalid")
+		})
+	}
+}
+
+func TestExecutePlan_DryRun(t *testing.T) {
+	s := newPlanTestServer(t, nil)
+
+	result, output := executePlan(t, s.server, plan.ExecutePlanInput{
+		Steps:  []plan.Step{thingStep("update_thing", "one"), thingStep("fail_thing", "two")},
+		DryRun: func() *bool { b := true; return &b }(),
+	})
+
+	assert.False(t, result.IsError)
+	assert.Equal(t, plan.StatusValid, output.Status)
+	assert.Equal(t, []plan.StepStatus{plan.StepStatusValid, plan.StepStatusValid}, stepStatuses(output))
+	assert.Empty(t, s.calls)
+}
+
+func TestExecutePlan_Errors(t *testing.T) {
+	t.Run("Too many steps", func(t *testing.T) {
+		s := newPlanTestServer(t, nil)
+		steps := []plan.Step{}
+		for range plan.MaxSteps + 1 {
+			steps = append(steps, thingStep("update_thing", "one"))
+		}
+
+		result, err := mcptestutils.CallToolOverMcp(t, s.server, plan.ExecutePlanDef.McpTool.Name, plan.ExecutePlanInput{Steps: steps})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+		assert.Empty(t, s.calls)
+	})
+
+	t.Run("Without the plan middleware", func(t *testing.T) {
+		server := mcptestutils.TestMcpServer(t)
+		plan.RegisterExecutePlanTool(server, []types.ToolDefinition{updateThingDef}, nil, nil)
+
+		result, err := mcptestutils.CallToolOverMcp(t, server, plan.ExecutePlanDef.McpTool.Name, plan.ExecutePlanInput{
+			Steps: []plan.Step{thingStep("update_thing", "one")},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
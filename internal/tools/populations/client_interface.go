@@ -15,6 +15,7 @@ type PopulationsClient interface {
 	CreatePopulation(ctx context.Context, environmentId uuid.UUID, createRequest management.Population) (*management.Population, *http.Response, error)
 	GetPopulation(ctx context.Context, environmentId uuid.UUID, populationId uuid.UUID) (*management.Population, *http.Response, error)
 	UpdatePopulation(ctx context.Context, environmentId uuid.UUID, populationId uuid.UUID, updateRequest management.Population) (*management.Population, *http.Response, error)
+	DeletePopulation(ctx context.Context, environmentId uuid.UUID, populationId uuid.UUID) (*http.Response, error)
 }
 
 type PopulationsClientFactory interface {
@@ -108,3 +108,17 @@ func (p *PingOneClientPopulationsWrapper) UpdatePopulation(ctx context.Context,
 	)
 	return putRequest.Execute()
 }
+
+func (p *PingOneClientPopulationsWrapper) DeletePopulation(ctx context.Context, environmentId uuid.UUID, populationId uuid.UUID) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.PopulationsApi.DeletePopulation(ctx, environmentId.String(), populationId.String())
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete population by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("populationId", populationId.String()),
+	)
+	return deleteRequest.Execute()
+}
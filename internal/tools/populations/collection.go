@@ -56,6 +56,16 @@ func (c *PopulationsCollection) RegisterTools(ctx context.Context, server *mcp.S
 		mcp.AddTool(server, UpdatePopulationDef.McpTool, UpdatePopulationHandler(populationsClientFactory))
 	}
 
+	if toolFilter.ShouldIncludeTool(&SetDefaultPopulationDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", SetDefaultPopulationDef.McpTool.Name))
+		mcp.AddTool(server, SetDefaultPopulationDef.McpTool, SetDefaultPopulationHandler(populationsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&BulkDeletePopulationsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", BulkDeletePopulationsDef.McpTool.Name))
+		mcp.AddTool(server, BulkDeletePopulationsDef.McpTool, BulkDeletePopulationsHandler(populationsClientFactory))
+	}
+
 	return nil
 }
 
@@ -65,5 +75,7 @@ func (c *PopulationsCollection) ListTools() []types.ToolDefinition {
 		CreatePopulationDef,
 		GetPopulationDef,
 		UpdatePopulationDef,
+		SetDefaultPopulationDef,
+		BulkDeletePopulationsDef,
 	}
 }
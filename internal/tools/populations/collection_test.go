@@ -81,6 +81,8 @@ func TestPopulationsCollection_RegisterTools_ReadOnlyToolsMarkedCorrectly(t *tes
 	writeTools := []string{
 		"create_population",
 		"update_population",
+		"set_default_population",
+		"bulk_delete_populations",
 	}
 
 	for _, tool := range tools {
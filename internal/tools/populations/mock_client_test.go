@@ -90,3 +90,13 @@ func (p *mockPingOneClientPopulationsWrapper) UpdatePopulation(ctx context.Conte
 	}
 	return response, httpResponse, args.Error(2)
 }
+
+func (p *mockPingOneClientPopulationsWrapper) DeletePopulation(ctx context.Context, environmentId uuid.UUID, populationId uuid.UUID) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, populationId)
+	var httpResponse *http.Response
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("DeletePopulation mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
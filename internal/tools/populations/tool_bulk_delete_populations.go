@@ -0,0 +1,264 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package populations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/bulkdelete"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var BulkDeletePopulationsDef = types.ToolDefinition{
+	// Each call makes up to 100 delete requests, so parallel batches are limited to protect the tenant's rate limits
+	MaxConcurrentExecutions: 4,
+	// Only the call that deletes the populations is confirmed, not the call that previews them
+	RequiresConfirmation: true,
+	ConfirmationArgument: bulkdelete.ConfirmationTokenArgument,
+	McpTool: &mcp.Tool{
+		Name:  "bulk_delete_populations",
+		Title: "Bulk Delete PingOne Populations",
+		Description: `Delete multiple populations in an environment, matched by a SCIM filter or listed by ID, up to 100 per call. Use to clean up test populations in sandbox environments.
+
+Deletion takes two calls. First call without 'confirmationToken' to preview the populations that would be deleted; nothing is deleted, and the output includes a confirmation token for exactly those populations. Review the populations with the user, then call again with the same arguments and the token to delete them. The token is refused if the matching populations have changed since it was returned.
+
+Filter examples: name sw "Test", id eq "pop-uuid". Supported: 'id' with 'eq', 'name' with 'sw'. A filter matching more than 100 populations deletes the first 100; 'moreMatching' is true when populations remain. Each population is deleted independently and the output reports the result per population, so a population PingOne refuses to delete, such as the default population, does not stop the others. Deleted populations cannot be restored.`,
+		InputSchema:  schema.MustGenerateSchema[BulkDeletePopulationsInput](),
+		OutputSchema: schema.MustGenerateSchema[BulkDeletePopulationsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type BulkDeletePopulationsInput struct {
+	EnvironmentId     uuid.UUID   `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Filter            *string     `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter matching the populations to delete. Supported: 'id' with 'eq', 'name' with 'sw'. Required if 'populationIds' is not provided."`
+	PopulationIds     []uuid.UUID `json:"populationIds,omitempty" jsonschema:"OPTIONAL. UUIDs of the populations to delete, up to 100. Required if 'filter' is not provided."`
+	ConfirmationToken *string     `json:"confirmationToken,omitempty" jsonschema:"OPTIONAL. The confirmation token returned by a previous call with the same arguments. Omit to preview the populations that would be deleted."`
+}
+
+type BulkDeletePopulationResult struct {
+	PopulationId string  `json:"populationId" jsonschema:"The ID of the population"`
+	Name         string  `json:"name" jsonschema:"The name of the population"`
+	Deleted      bool    `json:"deleted" jsonschema:"Whether the population was deleted"`
+	Error        *string `json:"error,omitempty" jsonschema:"The reason the population could not be deleted"`
+}
+
+type BulkDeletePopulationsOutput struct {
+	DryRun            bool                         `json:"dryRun" jsonschema:"Whether this was a preview, in which case no populations were deleted"`
+	ConfirmationToken *string                      `json:"confirmationToken,omitempty" jsonschema:"The token to pass back to delete the previewed populations, set on previews"`
+	Populations       []PopulationSummary          `json:"populations,omitempty" jsonschema:"The populations that would be deleted, set on previews"`
+	MoreMatching      bool                         `json:"moreMatching" jsonschema:"Whether the filter matches more populations than are deleted per call"`
+	Results           []BulkDeletePopulationResult `json:"results,omitempty" jsonschema:"Per-population results, set when populations were deleted"`
+	DeletedCount      int                          `json:"deletedCount" jsonschema:"The number of populations deleted"`
+	FailureCount      int                          `json:"failureCount" jsonschema:"The number of populations that could not be deleted"`
+}
+
+// BulkDeletePopulationsHandler previews, or deletes on confirmation, multiple PingOne populations using the provided
+// client, reporting per-population results
+func BulkDeletePopulationsHandler(populationsClientFactory PopulationsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input BulkDeletePopulationsInput,
+) (
+	*mcp.CallToolResult,
+	*BulkDeletePopulationsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BulkDeletePopulationsInput) (*mcp.CallToolResult, *BulkDeletePopulationsOutput, error) {
+		hasFilter := input.Filter != nil && strings.TrimSpace(*input.Filter) != ""
+		if hasFilter == (len(input.PopulationIds) > 0) {
+			toolErr := errs.NewToolError(BulkDeletePopulationsDef.McpTool.Name, errors.New("exactly one of 'filter' or 'populationIds' must be provided"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(input.PopulationIds) > bulkdelete.MaxResources {
+			toolErr := errs.NewToolError(BulkDeletePopulationsDef.McpTool.Name, fmt.Errorf("a maximum of %d populations can be deleted per call, got %d", bulkdelete.MaxResources, len(input.PopulationIds)))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := populationsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(BulkDeletePopulationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		var pops []PopulationSummary
+		var moreMatching bool
+		if hasFilter {
+			filter := strings.TrimSpace(*input.Filter)
+			pops, moreMatching, err = populationsMatchingFilter(ctx, client, input.EnvironmentId, filter)
+		} else {
+			pops, err = populationsWithIds(ctx, client, input.EnvironmentId, input.PopulationIds)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		populationIds := make([]string, 0, len(pops))
+		for _, pop := range pops {
+			if pop.Id != nil {
+				populationIds = append(populationIds, *pop.Id)
+			}
+		}
+		token := bulkdelete.ConfirmationToken(input.EnvironmentId, populationIds)
+
+		if input.ConfirmationToken == nil || *input.ConfirmationToken == "" {
+			logger.FromContext(ctx).Debug("Previewing bulk population deletion",
+				slog.String("environmentId", input.EnvironmentId.String()),
+				slog.Int("count", len(pops)),
+			)
+			return nil, &BulkDeletePopulationsOutput{
+				DryRun:            true,
+				ConfirmationToken: &token,
+				Populations:       pops,
+				MoreMatching:      moreMatching,
+			}, nil
+		}
+
+		if *input.ConfirmationToken != token {
+			toolErr := errs.NewToolError(BulkDeletePopulationsDef.McpTool.Name, bulkdelete.ErrConfirmationTokenMismatch)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Bulk deleting populations",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("count", len(pops)),
+		)
+
+		result := &BulkDeletePopulationsOutput{
+			MoreMatching: moreMatching,
+			Results:      make([]BulkDeletePopulationResult, 0, len(pops)),
+		}
+		for i, pop := range pops {
+			// Stop deleting populations if the request has been cancelled
+			if ctx.Err() != nil {
+				toolErr := errs.NewToolError(BulkDeletePopulationsDef.McpTool.Name, ctx.Err())
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			progress.Report(ctx, i, len(pops), "Deleting populations")
+			if pop.Id == nil {
+				continue
+			}
+
+			popResult := BulkDeletePopulationResult{
+				PopulationId: *pop.Id,
+				Name:         pop.Name,
+			}
+
+			deleteErr := deleteBulkPopulation(ctx, client, input.EnvironmentId, *pop.Id)
+			if deleteErr != nil {
+				errMsg := deleteErr.Error()
+				popResult.Error = &errMsg
+				result.FailureCount++
+			} else {
+				popResult.Deleted = true
+				result.DeletedCount++
+			}
+			result.Results = append(result.Results, popResult)
+		}
+
+		logger.FromContext(ctx).Debug("Bulk population deletion completed",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("deletedCount", result.DeletedCount),
+			slog.Int("failureCount", result.FailureCount),
+		)
+
+		return nil, result, nil
+	}
+}
+
+func deleteBulkPopulation(ctx context.Context, client PopulationsClient, environmentId uuid.UUID, populationId string) error {
+	parsedId, err := uuid.Parse(populationId)
+	if err != nil {
+		return fmt.Errorf("invalid population ID %q: %w", populationId, err)
+	}
+
+	httpResponse, err := client.DeletePopulation(ctx, environmentId, parsedId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return apiErr
+	}
+	return nil
+}
+
+// populationsMatchingFilter returns up to bulkdelete.MaxResources populations matching the SCIM filter, and whether
+// more populations match it
+func populationsMatchingFilter(ctx context.Context, client PopulationsClient, environmentId uuid.UUID, filter string) ([]PopulationSummary, bool, error) {
+	pagedIterator, err := client.GetPopulations(ctx, environmentId, &filter)
+	if err != nil {
+		toolErr := errs.NewToolError(BulkDeletePopulationsDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, false, toolErr
+	}
+
+	pops := []PopulationSummary{}
+	for next, err := range pagedIterator {
+		logger.LogHttpResponse(ctx, next.HTTPResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, false, apiErr
+		}
+		if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, false, apiErr
+		}
+		for _, pop := range next.EntityArray.Embedded.Populations {
+			if len(pops) == bulkdelete.MaxResources {
+				return pops, true, nil
+			}
+			pops = append(pops, PopulationSummary{
+				Id:        pop.Id,
+				Name:      pop.Name,
+				Default:   pop.Default,
+				CreatedAt: pop.CreatedAt,
+			})
+		}
+	}
+	return pops, false, nil
+}
+
+// populationsWithIds returns the populations with the given IDs, failing if any of them cannot be read
+func populationsWithIds(ctx context.Context, client PopulationsClient, environmentId uuid.UUID, populationIds []uuid.UUID) ([]PopulationSummary, error) {
+	pops := make([]PopulationSummary, 0, len(populationIds))
+	for _, populationId := range populationIds {
+		popResponse, httpResponse, err := client.GetPopulation(ctx, environmentId, populationId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		if popResponse == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no population data in response for population %s", populationId))
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		pops = append(pops, PopulationSummary{
+			Id:        popResponse.Id,
+			Name:      popResponse.Name,
+			Default:   popResponse.Default,
+			CreatedAt: popResponse.CreatedAt,
+		})
+	}
+	return pops, nil
+}
@@ -0,0 +1,227 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package populations_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/bulkdelete"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testBulkDeleteFilter = `name sw "Test"`
+
+var (
+	testDeletePopId1 = uuid.MustParse("550e8400-e29b-41d4-a716-446655440101")
+	testDeletePopId2 = uuid.MustParse("550e8400-e29b-41d4-a716-446655440102")
+)
+
+func deletablePopulation(id uuid.UUID, name string) management.Population {
+	return management.Population{
+		Id:   testutils.Pointer(id.String()),
+		Name: name,
+	}
+}
+
+func bulkDeleteFilterInput() populations.BulkDeletePopulationsInput {
+	return populations.BulkDeletePopulationsInput{
+		EnvironmentId: testEnvironmentId,
+		Filter:        testutils.Pointer(testBulkDeleteFilter),
+	}
+}
+
+func mockTestPopulations(mockClient *mockPingOneClientPopulationsWrapper) {
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId, testutils.Pointer(testBulkDeleteFilter)).Return(
+		testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+			createMockPage([]management.Population{deletablePopulation(testDeletePopId1, "Test A")}),
+			createMockPage([]management.Population{deletablePopulation(testDeletePopId2, "Test B")}),
+		}), nil)
+}
+
+func TestBulkDeletePopulationsHandler_Preview(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockTestPopulations(mockClient)
+
+	handler := populations.BulkDeletePopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, bulkDeleteFilterInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.DryRun)
+	assert.False(t, output.MoreMatching)
+	require.NotNil(t, output.ConfirmationToken)
+	assert.Equal(t, bulkdelete.ConfirmationToken(testEnvironmentId, []string{testDeletePopId1.String(), testDeletePopId2.String()}), *output.ConfirmationToken)
+	require.Len(t, output.Populations, 2)
+	assert.Equal(t, "Test A", output.Populations[0].Name)
+	assert.Equal(t, "Test B", output.Populations[1].Name)
+	assert.Empty(t, output.Results)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "DeletePopulation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkDeletePopulationsHandler_Delete(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockTestPopulations(mockClient)
+	mockClient.On("DeletePopulation", mock.Anything, testEnvironmentId, testDeletePopId1).Return(&http.Response{StatusCode: 204}, nil)
+	mockClient.On("DeletePopulation", mock.Anything, testEnvironmentId, testDeletePopId2).Return(&http.Response{StatusCode: 400}, errors.New("population contains users"))
+
+	input := bulkDeleteFilterInput()
+	input.ConfirmationToken = testutils.Pointer(bulkdelete.ConfirmationToken(testEnvironmentId, []string{testDeletePopId2.String(), testDeletePopId1.String()}))
+
+	handler := populations.BulkDeletePopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.DryRun)
+	assert.Nil(t, output.ConfirmationToken)
+	require.Len(t, output.Results, 2)
+	assert.Equal(t, populations.BulkDeletePopulationResult{PopulationId: testDeletePopId1.String(), Name: "Test A", Deleted: true}, output.Results[0])
+	assert.Equal(t, testDeletePopId2.String(), output.Results[1].PopulationId)
+	assert.False(t, output.Results[1].Deleted)
+	require.NotNil(t, output.Results[1].Error)
+	assert.Contains(t, *output.Results[1].Error, "population contains users")
+	assert.Equal(t, 1, output.DeletedCount)
+	assert.Equal(t, 1, output.FailureCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBulkDeletePopulationsHandler_DeleteByIds(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	for i, popId := range []uuid.UUID{testDeletePopId1, testDeletePopId2} {
+		pop := deletablePopulation(popId, fmt.Sprintf("Test %d", i))
+		mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, popId).Return(&pop, &http.Response{StatusCode: 200}, nil)
+		mockClient.On("DeletePopulation", mock.Anything, testEnvironmentId, popId).Return(&http.Response{StatusCode: 204}, nil)
+	}
+
+	input := populations.BulkDeletePopulationsInput{
+		EnvironmentId:     testEnvironmentId,
+		PopulationIds:     []uuid.UUID{testDeletePopId1, testDeletePopId2},
+		ConfirmationToken: testutils.Pointer(bulkdelete.ConfirmationToken(testEnvironmentId, []string{testDeletePopId1.String(), testDeletePopId2.String()})),
+	}
+
+	handler := populations.BulkDeletePopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 2, output.DeletedCount)
+	assert.Equal(t, 0, output.FailureCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBulkDeletePopulationsHandler_ConfirmationTokenMismatch(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockTestPopulations(mockClient)
+
+	// The token was returned before the second population matched the filter
+	input := bulkDeleteFilterInput()
+	input.ConfirmationToken = testutils.Pointer(bulkdelete.ConfirmationToken(testEnvironmentId, []string{testDeletePopId1.String()}))
+
+	handler := populations.BulkDeletePopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "confirmation token does not match")
+	assert.ErrorIs(t, err, bulkdelete.ErrConfirmationTokenMismatch)
+	mockClient.AssertNotCalled(t, "DeletePopulation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkDeletePopulationsHandler_MoreMatching(t *testing.T) {
+	var matching []management.Population
+	for i := range bulkdelete.MaxResources + 5 {
+		matching = append(matching, deletablePopulation(uuid.New(), fmt.Sprintf("Test %d", i)))
+	}
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId, mock.Anything).Return(
+		testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{createMockPage(matching)}), nil)
+
+	handler := populations.BulkDeletePopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, bulkDeleteFilterInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.MoreMatching)
+	assert.Len(t, output.Populations, bulkdelete.MaxResources)
+}
+
+func TestBulkDeletePopulationsHandler_Errors(t *testing.T) {
+	tooManyIds := make([]uuid.UUID, bulkdelete.MaxResources+1)
+	for i := range tooManyIds {
+		tooManyIds[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name            string
+		input           populations.BulkDeletePopulationsInput
+		setupMock       func(mockClient *mockPingOneClientPopulationsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "No filter or population IDs",
+			input:           populations.BulkDeletePopulationsInput{Filter: testutils.Pointer(" ")},
+			wantErrContains: "exactly one of 'filter' or 'populationIds' must be provided",
+		},
+		{
+			name:            "Both filter and population IDs",
+			input:           populations.BulkDeletePopulationsInput{Filter: testutils.Pointer(testBulkDeleteFilter), PopulationIds: []uuid.UUID{testDeletePopId1}},
+			wantErrContains: "exactly one of 'filter' or 'populationIds' must be provided",
+		},
+		{
+			name:            "Too many population IDs",
+			input:           populations.BulkDeletePopulationsInput{PopulationIds: tooManyIds},
+			wantErrContains: "a maximum of 100 populations can be deleted per call, got 101",
+		},
+		{
+			name:  "List populations error",
+			input: populations.BulkDeletePopulationsInput{Filter: testutils.Pointer(testBulkDeleteFilter)},
+			setupMock: func(mockClient *mockPingOneClientPopulationsWrapper) {
+				mockClient.On("GetPopulations", mock.Anything, testEnvironmentId, mock.Anything).Return(
+					testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{{
+						HTTPResponse: &http.Response{StatusCode: 400},
+						Error:        errors.New("invalid filter"),
+					}}), nil)
+			},
+			wantErrContains: "invalid filter",
+		},
+		{
+			name:  "Population not found",
+			input: populations.BulkDeletePopulationsInput{PopulationIds: []uuid.UUID{testDeletePopId1}},
+			setupMock: func(mockClient *mockPingOneClientPopulationsWrapper) {
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testDeletePopId1).Return(nil, &http.Response{StatusCode: 404}, errors.New("population not found"))
+			},
+			wantErrContains: "population not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientPopulationsWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			input := tt.input
+			input.EnvironmentId = testEnvironmentId
+			handler := populations.BulkDeletePopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+			mockClient.AssertNotCalled(t, "DeletePopulation", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBulkDeletePopulationsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := populations.BulkDeletePopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, bulkDeleteFilterInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
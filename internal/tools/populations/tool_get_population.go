@@ -35,6 +35,7 @@ var GetPopulationDef = types.ToolDefinition{
 type GetPopulationInput struct {
 	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne environment"`
 	PopulationId  uuid.UUID `json:"populationId" jsonschema:"REQUIRED. The unique identifier (UUID) string of the PingOne population"`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'population.name' and 'population.userCount'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 type GetPopulationOutput struct {
@@ -11,6 +11,9 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 )
@@ -34,6 +37,7 @@ var ListPopulationsDef = types.ToolDefinition{
 type ListPopulationsInput struct {
 	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
 	Filter        *string   `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter. Supported: 'id' with 'eq', 'name' with 'sw'."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'populations.id' and 'populations.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
 }
 
 type PopulationSummary struct {
@@ -44,7 +48,8 @@ type PopulationSummary struct {
 }
 
 type ListPopulationsOutput struct {
-	Populations []PopulationSummary `json:"populations" jsonschema:"List of populations with their id and name"`
+	Populations    []PopulationSummary   `json:"populations" jsonschema:"List of populations with their id and name"`
+	ResultResource *resources.ResultLink `json:"resultResource,omitempty" jsonschema:"Present when the result is too large to return inline, in which case populations contains only the first page. Read the resource to page through the full result."`
 }
 
 // ListPopulationsHandler lists all PingOne populations using the provided client
@@ -58,6 +63,14 @@ func ListPopulationsHandler(populationsClientFactory PopulationsClientFactory) f
 	error,
 ) {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input ListPopulationsInput) (*mcp.CallToolResult, *ListPopulationsOutput, error) {
+		if input.Filter != nil {
+			if err := scimfilter.Populations.Validate(*input.Filter); err != nil {
+				toolErr := errs.NewToolError(ListPopulationsDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+
 		client, err := populationsClientFactory.GetAuthenticatedClient(ctx)
 		if err != nil {
 			toolErr := errs.NewToolError(ListPopulationsDef.McpTool.Name, err)
@@ -107,8 +120,23 @@ func ListPopulationsHandler(populationsClientFactory PopulationsClientFactory) f
 					CreatedAt: pop.CreatedAt,
 				})
 			}
+			progress.ReportFetched(ctx, len(result.Populations), int(next.EntityArray.GetCount()), "populations")
+		}
+
+		// Return large results as a paged resource, when enabled, rather than inlining every item
+		result.Populations, result.ResultResource, err = resources.PageListResult(ctx, result.Populations)
+		if err != nil {
+			toolErr := errs.NewToolError(ListPopulationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		mcpResult, err := result.ResultResource.CallToolResult(ListPopulationsDef.McpTool.Name, result)
+		if err != nil {
+			toolErr := errs.NewToolError(ListPopulationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
 		}
 
-		return nil, &result, nil
+		return mcpResult, &result, nil
 	}
 }
@@ -11,10 +11,12 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/mcp/resources"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
 	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -234,6 +236,63 @@ func TestListPopulationsHandler_PaginationErrorMidStream(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestListPopulationsHandler_ReportsProgress(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	page1 := createMockPage([]management.Population{testPop1, testPop2OnlyRequiredFields})
+	page1.EntityArray.Count = testutils.Pointer(float32(3))
+	page2 := createMockPage([]management.Population{testPop3})
+	page2.EntityArray.Count = testutils.Pointer(float32(3))
+	mockClient.On("GetPopulations", mock.Anything, mock.Anything, mock.Anything).
+		Return(testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{page1, page2}), nil)
+
+	var reported []progress.Progress
+	ctx := progress.ContextWithReporter(context.Background(), func(p progress.Progress) {
+		reported = append(reported, p)
+	})
+
+	handler := populations.ListPopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, populations.ListPopulationsInput{EnvironmentId: testEnvironmentId})
+
+	require.NoError(t, err)
+	assert.Equal(t, []progress.Progress{
+		{Completed: 2, Total: 3, Message: "Fetched 2 of 3 populations"},
+		{Completed: 3, Total: 3, Message: "Fetched 3 of 3 populations"},
+	}, reported)
+}
+
+func TestListPopulationsHandler_PagedResultResource(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	setupSuccessfulMock(mockClient, [][]management.Population{
+		{testPop1, testPop2OnlyRequiredFields},
+		{testPop3, testPop4},
+	})
+
+	store := resources.NewPagedResultStore(3, resources.DefaultMaxStoredResults)
+	ctx := resources.ContextWithResultStore(context.Background(), store)
+
+	handler := populations.ListPopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	input := populations.ListPopulationsInput{
+		EnvironmentId: testEnvironmentId,
+	}
+
+	mcpResult, response, err := handler(ctx, &mcp.CallToolRequest{}, input)
+
+	require.NoError(t, err)
+	require.NotNil(t, response)
+	assert.Len(t, response.Populations, 3, "Only the first page should be returned inline")
+	require.NotNil(t, response.ResultResource)
+	assert.Equal(t, 4, response.ResultResource.TotalCount)
+	assert.Equal(t, 2, response.ResultResource.TotalPages)
+
+	require.NotNil(t, mcpResult, "Result should include a resource link")
+	require.Len(t, mcpResult.Content, 2)
+	resourceLink, ok := mcpResult.Content[1].(*mcp.ResourceLink)
+	require.True(t, ok)
+	assert.Equal(t, response.ResultResource.Uri, resourceLink.URI)
+
+	mockClient.AssertExpectations(t)
+}
+
 func TestListPopulationsHandler_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
@@ -282,6 +341,45 @@ func TestListPopulationsHandler_APIErrors(t *testing.T) {
 	}
 }
 
+func TestListPopulationsHandler_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name            string
+		filter          string
+		wantErrContains string
+	}{
+		{
+			name:            "unsupported attribute",
+			filter:          `description sw "External"`,
+			wantErrContains: "unsupported SCIM filter: filtering by 'description' is not supported; supported attributes are id, name",
+		},
+		{
+			name:            "unsupported operator",
+			filter:          `name eq "External"`,
+			wantErrContains: "unsupported SCIM filter: 'name' only supports sw",
+		},
+		{
+			name:            "invalid syntax",
+			filter:          `name sw External"`,
+			wantErrContains: "invalid SCIM filter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientPopulationsWrapper{}
+			handler := populations.ListPopulationsHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+
+			mcpResult, response, err := handler(context.Background(), &mcp.CallToolRequest{}, populations.ListPopulationsInput{
+				EnvironmentId: testEnvironmentId,
+				Filter:        &tt.filter,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, response, tt.wantErrContains)
+			mockClient.AssertNotCalled(t, "GetPopulations", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestListPopulationsHandler_GetAuthenticatedClientError(t *testing.T) {
 	mockClient := &mockPingOneClientPopulationsWrapper{}
 	clientFactoryErr := errors.New("failed to get authenticated client")
@@ -0,0 +1,224 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package populations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var SetDefaultPopulationDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "set_default_population",
+		Title: "Set PingOne Default Population",
+		Description: `Make a population the default population of its environment, keeping its other configuration. The default population is used for users created without a population.
+
+The output names the population that was the default before, so the change can be reverted with this tool or with undo_last_change.`,
+		InputSchema:  schema.MustGenerateSchema[SetDefaultPopulationInput](),
+		OutputSchema: schema.MustGenerateSchema[SetDefaultPopulationOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type SetDefaultPopulationInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	PopulationId  uuid.UUID `json:"populationId" jsonschema:"REQUIRED. UUID of the population to make the default."`
+}
+
+type SetDefaultPopulationOutput struct {
+	Population                management.Population `json:"population" jsonschema:"The new default population"`
+	PreviousDefaultPopulation *PopulationSummary    `json:"previousDefaultPopulation,omitempty" jsonschema:"The population that was the default before, if there was one and it was a different population"`
+	Changed                   bool                  `json:"changed" jsonschema:"False if the population already was the default population"`
+}
+
+// SetDefaultPopulationHandler makes a PingOne population the default population of its environment using the
+// provided client
+func SetDefaultPopulationHandler(populationsClientFactory PopulationsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input SetDefaultPopulationInput,
+) (
+	*mcp.CallToolResult,
+	*SetDefaultPopulationOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SetDefaultPopulationInput) (*mcp.CallToolResult, *SetDefaultPopulationOutput, error) {
+		client, err := populationsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(SetDefaultPopulationDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Setting default population",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("populationId", input.PopulationId.String()),
+		)
+
+		population, httpResponse, err := client.GetPopulation(ctx, input.EnvironmentId, input.PopulationId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if population == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no population data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		if population.GetDefault() {
+			population.Links = nil
+			return nil, &SetDefaultPopulationOutput{
+				Population: *population,
+			}, nil
+		}
+
+		previousDefault, err := findDefaultPopulation(ctx, client, input.EnvironmentId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		populationResponse, err := makeDefaultPopulation(ctx, client, input.EnvironmentId, input.PopulationId, *population)
+		if err != nil {
+			errs.Log(ctx, err)
+			return nil, nil, err
+		}
+
+		logger.FromContext(ctx).Debug("Default population set successfully",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("populationId", input.PopulationId.String()),
+		)
+
+		result := &SetDefaultPopulationOutput{
+			Changed: true,
+		}
+		if previousDefault != nil && previousDefault.Id != nil {
+			result.PreviousDefaultPopulation = &PopulationSummary{
+				Id:        previousDefault.Id,
+				Name:      previousDefault.Name,
+				Default:   previousDefault.Default,
+				CreatedAt: previousDefault.CreatedAt,
+			}
+			previousDefaultId, err := uuid.Parse(*previousDefault.Id)
+			if err == nil {
+				rollback.Record(ctx, rollback.Change{
+					Tool:          SetDefaultPopulationDef.McpTool.Name,
+					EnvironmentId: input.EnvironmentId.String(),
+					ResourceType:  "population",
+					ResourceId:    previousDefault.GetId(),
+					Description:   fmt.Sprintf("Make population %q the default population again", previousDefault.Name),
+				}, undoSetDefaultPopulation(populationsClientFactory, input.EnvironmentId, input.PopulationId, previousDefaultId))
+			}
+		}
+
+		// Filter out _links field from response
+		populationResponse.Links = nil
+		result.Population = *populationResponse
+
+		return nil, result, nil
+	}
+}
+
+// findDefaultPopulation returns the default population of the environment, or nil if there is none
+func findDefaultPopulation(ctx context.Context, client PopulationsClient, environmentId uuid.UUID) (*management.Population, error) {
+	pagedIterator, err := client.GetPopulations(ctx, environmentId, nil)
+	if err != nil {
+		toolErr := errs.NewToolError(SetDefaultPopulationDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+
+	for next, err := range pagedIterator {
+		logger.LogHttpResponse(ctx, next.HTTPResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		for _, population := range next.EntityArray.Embedded.Populations {
+			if population.GetDefault() {
+				return &population, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// makeDefaultPopulation replaces the population with the same configuration marked as the default population
+func makeDefaultPopulation(ctx context.Context, client PopulationsClient, environmentId uuid.UUID, populationId uuid.UUID, population management.Population) (*management.Population, error) {
+	updateRequest := management.Population{
+		Name:                   population.Name,
+		AlternativeIdentifiers: population.AlternativeIdentifiers,
+		Description:            population.Description,
+		PreferredLanguage:      population.PreferredLanguage,
+		PasswordPolicy:         population.PasswordPolicy,
+		Theme:                  population.Theme,
+		Default:                func() *bool { b := true; return &b }(),
+	}
+	populationResponse, httpResponse, err := client.UpdatePopulation(ctx, environmentId, populationId, updateRequest)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		return nil, errs.NewApiError(httpResponse, err)
+	}
+	if populationResponse == nil {
+		return nil, errs.NewApiError(httpResponse, fmt.Errorf("no population data in response"))
+	}
+	return populationResponse, nil
+}
+
+// undoSetDefaultPopulation returns the function that makes the previous default population the default again,
+// unless another population has been made the default since
+func undoSetDefaultPopulation(populationsClientFactory PopulationsClientFactory, environmentId uuid.UUID, populationId uuid.UUID, previousDefaultId uuid.UUID) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := populationsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			current, httpResponse, err := client.GetPopulation(ctx, environmentId, populationId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			if current == nil {
+				return errs.NewApiError(httpResponse, fmt.Errorf("no population data in response"))
+			}
+			if !current.GetDefault() {
+				return rollback.ErrChangedSince
+			}
+		}
+
+		previousDefault, httpResponse, err := client.GetPopulation(ctx, environmentId, previousDefaultId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if previousDefault == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no population data in response"))
+		}
+		_, err = makeDefaultPopulation(ctx, client, environmentId, previousDefaultId, *previousDefault)
+		return err
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package populations_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testCustomersPopulationId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440101")
+	testEmployeesPopulationId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440102")
+)
+
+func customersPopulation(isDefault bool) *management.Population {
+	return &management.Population{
+		Id:          testutils.Pointer(testCustomersPopulationId.String()),
+		Name:        "Customers",
+		Description: testutils.Pointer("External customers"),
+		Default:     testutils.Pointer(isDefault),
+	}
+}
+
+func employeesPopulation(isDefault bool) *management.Population {
+	return &management.Population{
+		Id:      testutils.Pointer(testEmployeesPopulationId.String()),
+		Name:    "Employees",
+		Default: testutils.Pointer(isDefault),
+	}
+}
+
+func populationsPages(populations ...management.Population) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Populations: populations}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func TestSetDefaultPopulationHandler(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(customersPopulation(false), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId, (*string)(nil)).Return(populationsPages(*customersPopulation(false), *employeesPopulation(true)), nil)
+	mockClient.On("UpdatePopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId, management.Population{
+		Name:        "Customers",
+		Description: testutils.Pointer("External customers"),
+		Default:     testutils.Pointer(true),
+	}).Return(customersPopulation(true), &http.Response{StatusCode: 200}, nil)
+
+	handler := populations.SetDefaultPopulationHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, populations.SetDefaultPopulationInput{
+		EnvironmentId: testEnvironmentId,
+		PopulationId:  testCustomersPopulationId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.Changed)
+	assert.True(t, output.Population.GetDefault())
+	require.NotNil(t, output.PreviousDefaultPopulation)
+	assert.Equal(t, testEmployeesPopulationId.String(), *output.PreviousDefaultPopulation.Id)
+	assert.Equal(t, "Employees", output.PreviousDefaultPopulation.Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSetDefaultPopulationHandler_AlreadyDefault(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(customersPopulation(true), &http.Response{StatusCode: 200}, nil)
+
+	handler := populations.SetDefaultPopulationHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, populations.SetDefaultPopulationInput{
+		EnvironmentId: testEnvironmentId,
+		PopulationId:  testCustomersPopulationId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.Changed)
+	assert.Nil(t, output.PreviousDefaultPopulation)
+	mockClient.AssertNotCalled(t, "UpdatePopulation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetDefaultPopulationHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		factoryErr      error
+		setupMock       func(mockClient *mockPingOneClientPopulationsWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Client error",
+			factoryErr:      errors.New("authentication failed"),
+			setupMock:       func(mockClient *mockPingOneClientPopulationsWrapper) {},
+			wantErrContains: "authentication failed",
+		},
+		{
+			name: "Get population error",
+			setupMock: func(mockClient *mockPingOneClientPopulationsWrapper) {
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(nil, &http.Response{StatusCode: 404}, errors.New("population not found"))
+			},
+			wantErrContains: "population not found",
+		},
+		{
+			name: "Update error",
+			setupMock: func(mockClient *mockPingOneClientPopulationsWrapper) {
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(customersPopulation(false), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetPopulations", mock.Anything, testEnvironmentId, (*string)(nil)).Return(populationsPages(*employeesPopulation(true)), nil)
+				mockClient.On("UpdatePopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId, mock.Anything).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientPopulationsWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := populations.SetDefaultPopulationHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, tt.factoryErr))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, populations.SetDefaultPopulationInput{
+				EnvironmentId: testEnvironmentId,
+				PopulationId:  testCustomersPopulationId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetDefaultPopulationHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(customersPopulation(false), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId, (*string)(nil)).Return(populationsPages(*employeesPopulation(true)), nil)
+	mockClient.On("UpdatePopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId, mock.Anything).Return(customersPopulation(true), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := populations.SetDefaultPopulationHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, populations.SetDefaultPopulationInput{
+		EnvironmentId: testEnvironmentId,
+		PopulationId:  testCustomersPopulationId,
+	})
+	require.NoError(t, err)
+
+	changes := journal.Changes(testEnvironmentId.String())
+	require.Len(t, changes, 1)
+	assert.Equal(t, "population", changes[0].ResourceType)
+	assert.Equal(t, testEmployeesPopulationId.String(), changes[0].ResourceId)
+
+	// Undoing the change makes the previous default population the default again
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(customersPopulation(true), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testEmployeesPopulationId).Return(employeesPopulation(false), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdatePopulation", mock.Anything, testEnvironmentId, testEmployeesPopulationId, management.Population{
+		Name:    "Employees",
+		Default: testutils.Pointer(true),
+	}).Return(employeesPopulation(true), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSetDefaultPopulationHandler_UndoChangedSince(t *testing.T) {
+	mockClient := &mockPingOneClientPopulationsWrapper{}
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(customersPopulation(false), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId, (*string)(nil)).Return(populationsPages(*employeesPopulation(true)), nil)
+	mockClient.On("UpdatePopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId, mock.Anything).Return(customersPopulation(true), &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := populations.SetDefaultPopulationHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, populations.SetDefaultPopulationInput{
+		EnvironmentId: testEnvironmentId,
+		PopulationId:  testCustomersPopulationId,
+	})
+	require.NoError(t, err)
+
+	// Another population has been made the default since
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testCustomersPopulationId).Return(customersPopulation(false), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	assert.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
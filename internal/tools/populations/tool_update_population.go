@@ -6,12 +6,15 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
 	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 )
@@ -27,7 +30,7 @@ WORKFLOW - Required to avoid data loss:
 2. Modify only the fields you want to change
 3. Pass the complete merged object to this tool
 
-Omitted optional fields will be cleared.`,
+Omitted optional fields will be cleared. Pass the 'updatedAt' returned by 'get_population' as 'lastKnownUpdatedAt' so that the population is not replaced if someone else has changed it since.`,
 		InputSchema:  schema.MustGenerateSchema[UpdatePopulationInput](),
 		OutputSchema: schema.MustGenerateSchema[UpdatePopulationOutput](),
 	},
@@ -42,6 +45,7 @@ type UpdatePopulationInput struct {
 	PreferredLanguage      *string                              `json:"preferredLanguage,omitempty" jsonschema:"OPTIONAL. Locale code. Defaults to environment setting if omitted."`
 	PasswordPolicy         *management.PopulationPasswordPolicy `json:"passwordPolicy,omitempty" jsonschema:"OPTIONAL. Reference to password policy."`
 	Theme                  *management.PopulationTheme          `json:"theme,omitempty" jsonschema:"OPTIONAL. Reference to theme."`
+	LastKnownUpdatedAt     *time.Time                           `json:"lastKnownUpdatedAt,omitempty" jsonschema:"OPTIONAL. The updatedAt of the population as last read. If the population has been updated since, it is not replaced and the call fails with a CONFLICT error."`
 }
 
 type UpdatePopulationOutput struct {
@@ -71,6 +75,27 @@ func UpdatePopulationHandler(populationsClientFactory PopulationsClientFactory)
 			slog.String("populationId", input.PopulationId.String()),
 		)
 
+		// Fetch the configuration being replaced, so that it can be checked against the last known update and the
+		// change can be undone
+		var previous *management.Population
+		if input.LastKnownUpdatedAt != nil {
+			previous, err = unchangedPopulation(ctx, client, input.EnvironmentId, input.PopulationId, *input.LastKnownUpdatedAt)
+			if err != nil {
+				toolErr := errs.NewToolError(UpdatePopulationDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		} else if rollback.IsRecording(ctx) {
+			var httpResponse *http.Response
+			previous, httpResponse, err = client.GetPopulation(ctx, input.EnvironmentId, input.PopulationId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				logger.FromContext(ctx).Warn("Unable to fetch the population before updating it, the update cannot be undone",
+					slog.String("populationId", input.PopulationId.String()),
+					slog.Any("error", err))
+			}
+		}
+
 		updateRequest := management.Population{
 			Name:                   input.Name,
 			AlternativeIdentifiers: input.AlternativeIdentifiers,
@@ -101,6 +126,16 @@ func UpdatePopulationHandler(populationsClientFactory PopulationsClientFactory)
 			slog.String("populationId", input.PopulationId.String()),
 		)
 
+		if previous != nil {
+			rollback.Record(ctx, rollback.Change{
+				Tool:          UpdatePopulationDef.McpTool.Name,
+				EnvironmentId: input.EnvironmentId.String(),
+				ResourceType:  "population",
+				ResourceId:    input.PopulationId.String(),
+				Description:   fmt.Sprintf("Restore the previous configuration of population %q", previous.Name),
+			}, undoUpdatePopulation(populationsClientFactory, input.EnvironmentId, input.PopulationId, *previous, populationResponse.GetUpdatedAt()))
+		}
+
 		// Filter out _links field from response
 		populationResponse.Links = nil
 
@@ -111,3 +146,64 @@ func UpdatePopulationHandler(populationsClientFactory PopulationsClientFactory)
 		return nil, result, nil
 	}
 }
+
+// unchangedPopulation reads a population, and returns a ConflictError if it has been updated since lastKnownUpdatedAt
+func unchangedPopulation(ctx context.Context, client PopulationsClient, environmentId uuid.UUID, populationId uuid.UUID, lastKnownUpdatedAt time.Time) (*management.Population, error) {
+	current, httpResponse, err := client.GetPopulation(ctx, environmentId, populationId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		return nil, errs.NewApiError(httpResponse, err)
+	}
+	if current == nil {
+		return nil, errs.NewApiError(httpResponse, fmt.Errorf("no population data in response"))
+	}
+
+	var updatedAt *time.Time
+	if parsed, err := time.Parse(time.RFC3339, current.GetUpdatedAt()); err == nil {
+		updatedAt = &parsed
+	}
+	if err := errs.CheckNotChangedSince("population", populationId.String(), lastKnownUpdatedAt, updatedAt); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// undoUpdatePopulation returns the function that restores the configuration of a population replaced by an
+// update, unless the population has been updated again since
+func undoUpdatePopulation(populationsClientFactory PopulationsClientFactory, environmentId uuid.UUID, populationId uuid.UUID, previous management.Population, updatedAt string) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := populationsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			current, httpResponse, err := client.GetPopulation(ctx, environmentId, populationId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			if current == nil {
+				return errs.NewApiError(httpResponse, fmt.Errorf("no population data in response"))
+			}
+			if current.GetUpdatedAt() != updatedAt {
+				return rollback.ErrChangedSince
+			}
+		}
+
+		restoreRequest := management.Population{
+			Name:                   previous.Name,
+			AlternativeIdentifiers: previous.AlternativeIdentifiers,
+			Description:            previous.Description,
+			PreferredLanguage:      previous.PreferredLanguage,
+			PasswordPolicy:         previous.PasswordPolicy,
+			Theme:                  previous.Theme,
+		}
+		_, httpResponse, err := client.UpdatePopulation(ctx, environmentId, populationId, restoreRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -8,14 +8,17 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
 	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -282,3 +285,136 @@ func TestUpdatePopulationHandler_RealClient(t *testing.T) {
 	assert.Equal(t, testPopulationId.String(), *response.Population.Id, "Population ID should match")
 	assert.Equal(t, "Updated Test Population", response.Population.Name, "Population name should be updated")
 }
+
+func TestUpdatePopulationHandler_RecordsUndo(t *testing.T) {
+	popID := uuid.MustParse(*testPop1.Id)
+	previous := testPop1
+	previous.UpdatedAt = testutils.Pointer("2025-06-01T12:00:00Z")
+	updated := management.Population{Name: "Updated Name", Id: testPop1.Id, UpdatedAt: testutils.Pointer("2025-06-01T13:00:00Z")}
+	restoreRequest := management.Population{Name: previous.Name, Description: previous.Description}
+
+	tests := []struct {
+		name             string
+		currentUpdatedAt string
+		force            bool
+		wantRestored     bool
+	}{
+		{
+			name:             "Restores the previous configuration",
+			currentUpdatedAt: "2025-06-01T13:00:00Z",
+			wantRestored:     true,
+		},
+		{
+			name:             "Does not restore a population changed since",
+			currentUpdatedAt: "2025-06-01T14:00:00Z",
+		},
+		{
+			name:             "Restores a population changed since with force",
+			currentUpdatedAt: "2025-06-01T14:00:00Z",
+			force:            true,
+			wantRestored:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientPopulationsWrapper{}
+			httpResp := &http.Response{StatusCode: 200}
+			mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, popID).Return(&previous, httpResp, nil).Once()
+			mockUpdatePopulationSetup(mockClient, testEnvironmentId, popID, management.Population{Name: "Updated Name"}, &updated, 200, nil)
+			journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+			ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+			handler := populations.UpdatePopulationHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+			_, _, err := handler(ctx, &mcp.CallToolRequest{}, populations.UpdatePopulationInput{
+				EnvironmentId: testEnvironmentId,
+				PopulationId:  popID,
+				Name:          "Updated Name",
+			})
+			require.NoError(t, err)
+
+			changes := journal.Changes(testEnvironmentId.String())
+			require.Len(t, changes, 1)
+			assert.Equal(t, populations.UpdatePopulationDef.McpTool.Name, changes[0].Tool)
+			assert.Equal(t, popID.String(), changes[0].ResourceId)
+
+			if !tc.force {
+				current := updated
+				current.UpdatedAt = testutils.Pointer(tc.currentUpdatedAt)
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, popID).Return(&current, httpResp, nil).Once()
+			}
+			if tc.wantRestored {
+				mockUpdatePopulationSetup(mockClient, testEnvironmentId, popID, restoreRequest, &previous, 200, nil)
+			}
+
+			_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", tc.force)
+			if tc.wantRestored {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, rollback.ErrChangedSince)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdatePopulationHandler_LastKnownUpdatedAt(t *testing.T) {
+	popID := uuid.MustParse(*testPop1.Id)
+	lastKnownUpdatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	updated := management.Population{Name: "Updated Name", Id: testPop1.Id, UpdatedAt: testutils.Pointer("2025-06-01T13:00:00Z")}
+
+	tests := []struct {
+		name             string
+		currentUpdatedAt *string
+		wantReplaced     bool
+		wantConflict     bool
+		wantErrContains  string
+	}{
+		{
+			name:             "Replaces a population not changed since",
+			currentUpdatedAt: testutils.Pointer("2025-06-01T12:00:00Z"),
+			wantReplaced:     true,
+		},
+		{
+			name:             "Does not replace a population changed since",
+			currentUpdatedAt: testutils.Pointer("2025-06-01T12:30:00.123Z"),
+			wantConflict:     true,
+			wantErrContains:  "has been changed since it was read",
+		},
+		{
+			name:            "Does not replace a population without an update time",
+			wantErrContains: "PingOne did not return when it was last updated",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientPopulationsWrapper{}
+			current := testPop1
+			current.UpdatedAt = tc.currentUpdatedAt
+			mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, popID).Return(&current, &http.Response{StatusCode: 200}, nil).Once()
+			if tc.wantReplaced {
+				mockUpdatePopulationSetup(mockClient, testEnvironmentId, popID, management.Population{Name: "Updated Name"}, &updated, 200, nil)
+			}
+
+			handler := populations.UpdatePopulationHandler(NewMockPingOneClientPopulationsWrapperFactory(mockClient, nil))
+			_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, populations.UpdatePopulationInput{
+				EnvironmentId:      testEnvironmentId,
+				PopulationId:       popID,
+				Name:               "Updated Name",
+				LastKnownUpdatedAt: &lastKnownUpdatedAt,
+			})
+
+			if tc.wantReplaced {
+				require.NoError(t, err)
+				assert.Equal(t, "Updated Name", output.Population.Name)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErrContains)
+				var conflictErr *errs.ConflictError
+				assert.Equal(t, tc.wantConflict, errors.As(err, &conflictErr))
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package progress
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// ProgressNotificationMiddleware sends the progress that tool handlers report as MCP progress notifications,
+// when the client asked for them by sending a progress token with the tool call. Tool calls without a progress
+// token are passed through unchanged.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type ProgressNotificationMiddleware struct{}
+
+// NewProgressNotificationMiddleware creates middleware that sends the progress of tool calls to the client.
+func NewProgressNotificationMiddleware() *ProgressNotificationMiddleware {
+	return &ProgressNotificationMiddleware{}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ProgressNotificationMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || callToolReq.Session == nil {
+			return next(ctx, method, req)
+		}
+
+		progressToken := callToolReq.Params.GetProgressToken()
+		if progressToken == nil {
+			return next(ctx, method, req)
+		}
+
+		ctx = ContextWithReporter(ctx, func(progress Progress) {
+			err := callToolReq.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: progressToken,
+				Progress:      float64(progress.Completed),
+				Total:         float64(progress.Total),
+				Message:       progress.Message,
+			})
+			if err != nil {
+				logger.FromContext(ctx).Debug("Failed to send progress notification",
+					slog.String("tool", callToolReq.Params.Name),
+					slog.String("error", err.Error()))
+			}
+		})
+		return next(ctx, method, req)
+	}
+}
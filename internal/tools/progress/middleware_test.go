@@ -0,0 +1,79 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package progress_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProgressTestSession serves a tool that reports progress, behind the progress notification middleware, to a
+// client that sends the progress notifications it receives on the channel
+func newProgressTestSession(t *testing.T, notifications chan<- *mcp.ProgressNotificationParams) *mcp.ClientSession {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, &mcp.Tool{Name: "list_things"}, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+		progress.ReportFetched(ctx, 2400, 10000, "things")
+		return nil, map[string]any{"ok": true}, nil
+	})
+	server.AddReceivingMiddleware(progress.NewProgressNotificationMiddleware().Handler)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.1-test"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, req *mcp.ProgressNotificationClientRequest) {
+			notifications <- req.Params
+		},
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestProgressNotificationMiddleware(t *testing.T) {
+	notifications := make(chan *mcp.ProgressNotificationParams, 1)
+	session := newProgressTestSession(t, notifications)
+
+	params := &mcp.CallToolParams{Name: "list_things", Arguments: map[string]any{}, Meta: mcp.Meta{}}
+	params.SetProgressToken("progress-1")
+	result, err := session.CallTool(t.Context(), params)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	select {
+	case notification := <-notifications:
+		assert.Equal(t, "progress-1", notification.ProgressToken)
+		assert.Equal(t, float64(2400), notification.Progress)
+		assert.Equal(t, float64(10000), notification.Total)
+		assert.Equal(t, "Fetched 2,400 of 10,000 things", notification.Message)
+	case <-time.After(time.Second):
+		t.Fatal("no progress notification was received")
+	}
+}
+
+func TestProgressNotificationMiddleware_NoProgressToken(t *testing.T) {
+	notifications := make(chan *mcp.ProgressNotificationParams, 1)
+	session := newProgressTestSession(t, notifications)
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "list_things", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+
+	select {
+	case <-notifications:
+		t.Fatal("progress should only be sent when the client asked for it")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package progress lets tool handlers report the progress of long operations, such as reading many pages of a
+// list or changing resources in bulk, to MCP clients that asked for progress notifications, and to the background
+// jobs the operations run in.
+package progress
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// Progress is the progress last reported by a tool handler.
+type Progress struct {
+	Completed int    `json:"completed" jsonschema:"The number of steps completed"`
+	Total     int    `json:"total,omitempty" jsonschema:"The total number of steps, if known"`
+	Message   string `json:"message,omitempty" jsonschema:"What the operation is doing"`
+}
+
+// Reporter receives the progress reported by a tool handler.
+type Reporter func(progress Progress)
+
+type reporterContextKey struct{}
+
+// ContextWithReporter returns a context whose tool handlers report their progress to the reporter, replacing the
+// reporter of the parent context.
+func ContextWithReporter(ctx context.Context, reporter Reporter) context.Context {
+	return context.WithValue(ctx, reporterContextKey{}, reporter)
+}
+
+// Report reports the progress of the tool call or job of the context, if its progress is followed.
+// Total is 0 if the number of steps is not known.
+func Report(ctx context.Context, completed int, total int, message string) {
+	if ctx == nil {
+		return
+	}
+	if reporter, ok := ctx.Value(reporterContextKey{}).(Reporter); ok {
+		reporter(Progress{
+			Completed: completed,
+			Total:     total,
+			Message:   message,
+		})
+	}
+}
+
+// ReportFetched reports the number of resources read so far from a paged list, such as "Fetched 2,400 of 10,000
+// users". Total is the count of matching resources returned with the pages of PingOne lists, or 0 if the list does
+// not return it.
+func ReportFetched(ctx context.Context, fetched int, total int, resources string) {
+	if total < fetched {
+		Report(ctx, fetched, 0, fmt.Sprintf("Fetched %s %s", formatCount(fetched), resources))
+		return
+	}
+	Report(ctx, fetched, total, fmt.Sprintf("Fetched %s of %s %s", formatCount(fetched), formatCount(total), resources))
+}
+
+// formatCount formats a count with thousands separators
+func formatCount(count int) string {
+	if count < 0 {
+		return "-" + formatCount(-count)
+	}
+	digits := strconv.Itoa(count)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+	return digits
+}
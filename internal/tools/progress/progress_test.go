@@ -0,0 +1,80 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package progress_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/stretchr/testify/assert"
+)
+
+// reportedTo returns a context that records the progress reported to it
+func reportedTo(reported *[]progress.Progress) context.Context {
+	return progress.ContextWithReporter(context.Background(), func(p progress.Progress) {
+		*reported = append(*reported, p)
+	})
+}
+
+func TestReport(t *testing.T) {
+	var reported []progress.Progress
+	progress.Report(reportedTo(&reported), 3, 10, "Deleting users")
+
+	assert.Equal(t, []progress.Progress{{Completed: 3, Total: 10, Message: "Deleting users"}}, reported)
+}
+
+func TestReport_NotFollowed(t *testing.T) {
+	assert.NotPanics(t, func() {
+		progress.Report(context.Background(), 1, 2, "Working")
+	})
+}
+
+func TestReport_ReplacedReporter(t *testing.T) {
+	var outer, inner []progress.Progress
+	ctx := progress.ContextWithReporter(reportedTo(&outer), func(p progress.Progress) {
+		inner = append(inner, p)
+	})
+	progress.Report(ctx, 1, 2, "Working")
+
+	assert.Empty(t, outer)
+	assert.Len(t, inner, 1)
+}
+
+func TestReportFetched(t *testing.T) {
+	tests := []struct {
+		name      string
+		fetched   int
+		total     int
+		wantTotal int
+		wantMsg   string
+	}{
+		{
+			name:      "Total known",
+			fetched:   2400,
+			total:     10000,
+			wantTotal: 10000,
+			wantMsg:   "Fetched 2,400 of 10,000 users",
+		},
+		{
+			name:    "Total unknown",
+			fetched: 1234567,
+			wantMsg: "Fetched 1,234,567 users",
+		},
+		{
+			name:    "Total less than fetched",
+			fetched: 150,
+			total:   100,
+			wantMsg: "Fetched 150 users",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []progress.Progress
+			progress.ReportFetched(reportedTo(&reported), tt.fetched, tt.total, "users")
+
+			assert.Equal(t, []progress.Progress{{Completed: tt.fetched, Total: tt.wantTotal, Message: tt.wantMsg}}, reported)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package readonly
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// WriteToolBlockedError is returned when a tool that is not known to be read-only is called while the server is
+// in read-only mode.
+type WriteToolBlockedError struct {
+	ToolName string
+}
+
+func (e *WriteToolBlockedError) Error() string {
+	return fmt.Sprintf("tool '%s' is not a read-only tool and cannot be called while the server is in read-only mode; the server must be restarted with --disable-read-only to use write tools", e.ToolName)
+}
+
+// ReadOnlyMiddleware rejects calls of tools that are not annotated as read-only while the server is in read-only
+// mode. Write tools are not registered in read-only mode, so the middleware only rejects calls when a tool is
+// registered by mistake, such as by a tool collection that does not apply the tool filter.
+//
+// Tools that are not in the tool definitions the middleware is created with are rejected, so that tools are only
+// called in read-only mode when they are known to be read-only.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type ReadOnlyMiddleware struct {
+	readOnly      bool
+	readOnlyTools map[string]bool
+}
+
+// NewReadOnlyMiddleware creates middleware that enforces read-only mode when readOnly is set, for the tools in the
+// list that are annotated as read-only.
+func NewReadOnlyMiddleware(readOnly bool, tools []types.ToolDefinition) *ReadOnlyMiddleware {
+	m := &ReadOnlyMiddleware{
+		readOnly:      readOnly,
+		readOnlyTools: make(map[string]bool),
+	}
+	for _, tool := range tools {
+		if tool.McpTool == nil || !tool.IsReadOnly() {
+			continue
+		}
+		m.readOnlyTools[tool.McpTool.Name] = true
+	}
+	return m
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ReadOnlyMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if !m.readOnly || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			// Should never happen for tools/call method, but read-only mode is mandatory, so we fail the call
+			return nil, fmt.Errorf("read-only mode enforcement failed: %w", fmt.Errorf("invalid tool call request"))
+		}
+		toolName := callToolReq.Params.Name
+		if !m.readOnlyTools[toolName] {
+			logger.FromContext(ctx).Warn("Rejected call of tool that is not read-only in read-only mode",
+				slog.String("tool", toolName))
+			return nil, fmt.Errorf("read-only mode: %w", &WriteToolBlockedError{ToolName: toolName})
+		}
+
+		return next(ctx, method, req)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package readonly_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/readonly"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "list_things", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}},
+	{McpTool: &mcp.Tool{Name: "update_thing", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: false}}},
+	{McpTool: &mcp.Tool{Name: "delete_thing"}},
+}
+
+func callToolRequest(toolName string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: toolName},
+	}
+}
+
+// recordingHandler records the names of the tools that reach it
+type recordingHandler struct {
+	calls []string
+}
+
+func (h *recordingHandler) next(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+	if callToolReq, ok := req.(*mcp.CallToolRequest); ok {
+		h.calls = append(h.calls, callToolReq.Params.Name)
+	}
+	return &mcp.CallToolResult{}, nil
+}
+
+func TestReadOnlyMiddleware_AllowsReadOnlyTools(t *testing.T) {
+	recording := &recordingHandler{}
+	handler := readonly.NewReadOnlyMiddleware(true, testToolDefs).Handler(recording.next)
+
+	_, err := handler(context.Background(), "tools/call", callToolRequest("list_things"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"list_things"}, recording.calls)
+}
+
+func TestReadOnlyMiddleware_BlocksToolsThatAreNotReadOnly(t *testing.T) {
+	for _, toolName := range []string{"update_thing", "delete_thing", "unknown_tool"} {
+		t.Run(toolName, func(t *testing.T) {
+			recording := &recordingHandler{}
+			handler := readonly.NewReadOnlyMiddleware(true, testToolDefs).Handler(recording.next)
+
+			_, err := handler(context.Background(), "tools/call", callToolRequest(toolName))
+			require.Error(t, err)
+			var blockedErr *readonly.WriteToolBlockedError
+			require.ErrorAs(t, err, &blockedErr)
+			assert.Equal(t, toolName, blockedErr.ToolName)
+			assert.Contains(t, err.Error(), "cannot be called while the server is in read-only mode")
+			assert.Empty(t, recording.calls, "blocked calls should not reach the tool")
+		})
+	}
+}
+
+func TestReadOnlyMiddleware_AllowsAllToolsWhenNotReadOnly(t *testing.T) {
+	recording := &recordingHandler{}
+	handler := readonly.NewReadOnlyMiddleware(false, testToolDefs).Handler(recording.next)
+
+	for _, toolName := range []string{"list_things", "update_thing", "unknown_tool"} {
+		_, err := handler(context.Background(), "tools/call", callToolRequest(toolName))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"list_things", "update_thing", "unknown_tool"}, recording.calls)
+}
+
+func TestReadOnlyMiddleware_PassesThroughOtherMethods(t *testing.T) {
+	called := false
+	handler := readonly.NewReadOnlyMiddleware(true, testToolDefs).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		called = true
+		return &mcp.ListToolsResult{}, nil
+	})
+
+	_, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	assert.True(t, called)
+}
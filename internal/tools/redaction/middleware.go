@@ -0,0 +1,132 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package redaction
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+)
+
+// RedactionMiddleware masks the values of sensitive fields, such as passwords, secrets, tokens and MFA seeds, in
+// tool inputs and outputs. The arguments and structured results of tool calls are logged at debug level with their
+// sensitive fields masked, so that calls can be debugged without secrets reaching the logs.
+//
+// When results are redacted, sensitive fields are also masked in the structured content and JSON text content of
+// tool results and in the JSON contents of read resources, such as paged list results, before they are returned to
+// the client. Text that is not JSON, such as text rendered by text templates, is returned unchanged.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, outside any middleware that shapes
+// tool results, so that results are redacted in the form the client receives them.
+type RedactionMiddleware struct {
+	redactResults bool
+}
+
+// NewRedactionMiddleware creates middleware that logs tool calls with their sensitive fields masked, and masks
+// them in results returned to the client when redactResults is set.
+func NewRedactionMiddleware(redactResults bool) *RedactionMiddleware {
+	return &RedactionMiddleware{
+		redactResults: redactResults,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *RedactionMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		switch method {
+		case "tools/call":
+			callToolReq, ok := req.(*mcp.CallToolRequest)
+			if !ok {
+				return next(ctx, method, req)
+			}
+			debug := logger.FromContext(ctx).Enabled(ctx, slog.LevelDebug)
+			if debug {
+				logger.FromContext(ctx).Debug("Tool call arguments",
+					slog.Any("arguments", redact.Value(jsonValue(callToolReq.Params.Arguments))))
+			}
+
+			result, err := next(ctx, method, req)
+			callToolResult, ok := result.(*mcp.CallToolResult)
+			if err != nil || !ok || callToolResult == nil || (!debug && !m.redactResults) {
+				return result, err
+			}
+			structuredContent := redact.Value(structuredValue(callToolResult.StructuredContent))
+			if debug {
+				logger.FromContext(ctx).Debug("Tool call result",
+					slog.Bool("isError", callToolResult.IsError),
+					slog.Any("structuredContent", structuredContent))
+			}
+			if m.redactResults {
+				return redactToolResult(callToolResult, structuredContent), nil
+			}
+			return callToolResult, nil
+		case "resources/read":
+			result, err := next(ctx, method, req)
+			readResourceResult, ok := result.(*mcp.ReadResourceResult)
+			if err != nil || !ok || readResourceResult == nil || !m.redactResults {
+				return result, err
+			}
+			redacted := *readResourceResult
+			redacted.Contents = make([]*mcp.ResourceContents, len(readResourceResult.Contents))
+			for i, contents := range readResourceResult.Contents {
+				if contents != nil && contents.Text != "" {
+					redactedContents := *contents
+					redactedContents.Text = redact.JSON(contents.Text)
+					contents = &redactedContents
+				}
+				redacted.Contents[i] = contents
+			}
+			return &redacted, nil
+		default:
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// redactToolResult returns a copy of the result with its structured content replaced by the redacted copy and the
+// sensitive fields of its JSON text content masked. The result itself is left unchanged, as it may be cached.
+func redactToolResult(result *mcp.CallToolResult, structuredContent any) *mcp.CallToolResult {
+	redacted := *result
+	if result.StructuredContent != nil {
+		redacted.StructuredContent = structuredContent
+	}
+	redacted.Content = make([]mcp.Content, len(result.Content))
+	for i, content := range result.Content {
+		if textContent, ok := content.(*mcp.TextContent); ok {
+			redactedTextContent := *textContent
+			redactedTextContent.Text = redact.JSON(textContent.Text)
+			content = &redactedTextContent
+		}
+		redacted.Content[i] = content
+	}
+	return &redacted
+}
+
+// jsonValue decodes the raw JSON arguments of a call, returning nil if they cannot be decoded
+func jsonValue(raw json.RawMessage) any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// structuredValue returns the structured content of a result as a decoded JSON value, as handlers return their
+// output structs as structured content
+func structuredValue(structuredContent any) any {
+	if structuredContent == nil {
+		return nil
+	}
+	raw, err := json.Marshal(structuredContent)
+	if err != nil {
+		return nil
+	}
+	return jsonValue(raw)
+}
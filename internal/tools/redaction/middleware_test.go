@@ -0,0 +1,133 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package redaction_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/redact"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/redaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type applicationOutput struct {
+	Name         string `json:"name"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+var testOutput = applicationOutput{Name: "Portal", ClientSecret: "s3cr3t"}
+
+func callToolRequest(arguments string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "get_application", Arguments: json.RawMessage(arguments)},
+	}
+}
+
+// toolResult returns the result a tool handler returns for the test output, with the output as JSON text
+func toolResult(t *testing.T) *mcp.CallToolResult {
+	t.Helper()
+	text, err := json.Marshal(testOutput)
+	require.NoError(t, err)
+	return &mcp.CallToolResult{
+		Content:           []mcp.Content{&mcp.TextContent{Text: string(text)}},
+		StructuredContent: testOutput,
+	}
+}
+
+// debugContext returns a context that logs at debug level to the buffer
+func debugContext(buf *bytes.Buffer) context.Context {
+	return logger.ContextWithLogger(context.Background(), slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+func TestRedactionMiddleware_RedactsResults(t *testing.T) {
+	result := toolResult(t)
+	handler := redaction.NewRedactionMiddleware(true).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return result, nil
+	})
+
+	redacted, err := handler(context.Background(), "tools/call", callToolRequest(`{}`))
+
+	require.NoError(t, err)
+	callToolResult, ok := redacted.(*mcp.CallToolResult)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"name": "Portal", "clientSecret": redact.RedactedValue}, callToolResult.StructuredContent)
+	require.Len(t, callToolResult.Content, 1)
+	assert.JSONEq(t, `{"name":"Portal","clientSecret":"[REDACTED]"}`, callToolResult.Content[0].(*mcp.TextContent).Text)
+
+	assert.Equal(t, testOutput, result.StructuredContent, "the result of the tool should not be changed, as it may be cached")
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "s3cr3t")
+}
+
+func TestRedactionMiddleware_ResultsNotRedacted(t *testing.T) {
+	result := toolResult(t)
+	handler := redaction.NewRedactionMiddleware(false).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return result, nil
+	})
+
+	returned, err := handler(context.Background(), "tools/call", callToolRequest(`{}`))
+
+	require.NoError(t, err)
+	assert.Same(t, result, returned)
+}
+
+func TestRedactionMiddleware_LogsRedactedCalls(t *testing.T) {
+	var buf bytes.Buffer
+	handler := redaction.NewRedactionMiddleware(false).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return toolResult(t), nil
+	})
+
+	_, err := handler(debugContext(&buf), "tools/call", callToolRequest(`{"environmentId":"env-1","credentials":{"password":"hunter2"}}`))
+
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), `"msg":"Tool call arguments","arguments":{"credentials":"[REDACTED]","environmentId":"env-1"}`)
+	assert.Contains(t, buf.String(), `"msg":"Tool call result","isError":false,"structuredContent":{"clientSecret":"[REDACTED]","name":"Portal"}`)
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "s3cr3t")
+}
+
+func TestRedactionMiddleware_RedactsReadResources(t *testing.T) {
+	tests := []struct {
+		name          string
+		redactResults bool
+		expectedText  string
+	}{
+		{
+			name:          "Results redacted",
+			redactResults: true,
+			expectedText:  `{"items":[{"clientSecret":"[REDACTED]","name":"Portal"}]}`,
+		},
+		{
+			name:          "Results not redacted",
+			redactResults: false,
+			expectedText:  `{"items":[{"clientSecret":"s3cr3t","name":"Portal"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := redaction.NewRedactionMiddleware(tt.redactResults).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				return &mcp.ReadResourceResult{
+					Contents: []*mcp.ResourceContents{
+						{URI: "pingone://results/1?page=2", MIMEType: "application/json", Text: `{"items":[{"clientSecret":"s3cr3t","name":"Portal"}]}`},
+					},
+				}, nil
+			})
+
+			result, err := handler(context.Background(), "resources/read", &mcp.ReadResourceRequest{})
+
+			require.NoError(t, err)
+			readResourceResult, ok := result.(*mcp.ReadResourceResult)
+			require.True(t, ok)
+			require.Len(t, readResourceResult.Contents, 1)
+			assert.Equal(t, "pingone://results/1?page=2", readResourceResult.Contents[0].URI)
+			assert.Equal(t, tt.expectedText, readResourceResult.Contents[0].Text)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package region
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+// RegionMiddleware sends the PingOne API requests of tool calls to the region of the environment named by their
+// environmentId argument, and the requests of other calls to the region selected for the session. Calls whose
+// environment region cannot be looked up, such as calls naming an environment that does not exist, are sent to
+// the region selected for the session, so that the tool reports the error.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after the auth middleware, as
+// the regions of environments are looked up with the session's access token.
+type RegionMiddleware struct {
+	selector *Selector
+}
+
+// NewRegionMiddleware creates middleware that selects the region of tool calls with the selector. Calls are sent to
+// the server's region when the selector is nil.
+func NewRegionMiddleware(selector *Selector) *RegionMiddleware {
+	return &RegionMiddleware{
+		selector: selector,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *RegionMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if m.selector == nil || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		rootDomain := m.selector.SessionRootDomain()
+		if environmentId, ok := environmentIdArgument(callToolReq.Params.Arguments); ok {
+			environmentRootDomain, err := m.selector.EnvironmentRootDomain(ctx, environmentId)
+			if err != nil {
+				logger.FromContext(ctx).Debug("Region of environment not found, using the session's region",
+					slog.String("environmentId", environmentId.String()),
+					slog.String("error", err.Error()))
+			} else {
+				rootDomain = environmentRootDomain
+			}
+		}
+		if rootDomain == "" {
+			return next(ctx, method, req)
+		}
+
+		logger.FromContext(ctx).Debug("Sending PingOne API requests of tool call to region",
+			slog.String("tool", callToolReq.Params.Name),
+			slog.String("rootDomain", rootDomain))
+		return next(sdk.ContextWithRootDomain(ctx, rootDomain), method, req)
+	}
+}
+
+// environmentIdArgument returns the environmentId argument of a tool call, or false if it has none
+func environmentIdArgument(argsJSON json.RawMessage) (uuid.UUID, bool) {
+	var args struct {
+		EnvironmentId string `json:"environmentId"`
+	}
+	if err := json.Unmarshal(argsJSON, &args); err != nil || args.EnvironmentId == "" {
+		return uuid.UUID{}, false
+	}
+	environmentId, err := uuid.Parse(args.EnvironmentId)
+	if err != nil {
+		return uuid.UUID{}, false
+	}
+	return environmentId, true
+}
@@ -0,0 +1,58 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package region_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/region"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// callRootDomain calls a tool through the middleware, returning the root domain of the context the tool is called with
+func callRootDomain(t *testing.T, middleware *region.RegionMiddleware, arguments map[string]any) string {
+	t.Helper()
+	rawArguments, err := json.Marshal(arguments)
+	require.NoError(t, err)
+
+	var rootDomain string
+	handler := middleware.Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		rootDomain = sdk.RootDomainFromContext(ctx)
+		return &mcp.CallToolResult{}, nil
+	})
+	_, err = handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "test_tool", Arguments: rawArguments},
+	})
+	require.NoError(t, err)
+	return rootDomain
+}
+
+func TestRegionMiddleware(t *testing.T) {
+	environmentId := uuid.New()
+	selector, _ := newSelector(t, environmentId, pingone.ENVIRONMENTREGIONCODE_AU)
+	middleware := region.NewRegionMiddleware(selector)
+
+	assert.Empty(t, callRootDomain(t, middleware, map[string]any{}), "calls without an environment should use the server's region")
+	assert.Equal(t, "pingone.com.au", callRootDomain(t, middleware, map[string]any{"environmentId": environmentId.String()}))
+	assert.Empty(t, callRootDomain(t, middleware, map[string]any{"environmentId": uuid.NewString()}), "calls with an unknown environment should use the server's region")
+	assert.Empty(t, callRootDomain(t, middleware, map[string]any{"environmentId": "not-a-uuid"}))
+
+	_, err := selector.SelectSessionRegion("EU")
+	require.NoError(t, err)
+	assert.Equal(t, "pingone.eu", callRootDomain(t, middleware, map[string]any{}))
+	assert.Equal(t, "pingone.com.au", callRootDomain(t, middleware, map[string]any{"environmentId": environmentId.String()}), "the environment's region should take precedence")
+	assert.Equal(t, "pingone.eu", callRootDomain(t, middleware, map[string]any{"environmentId": uuid.NewString()}))
+}
+
+func TestRegionMiddleware_WithoutSelector(t *testing.T) {
+	middleware := region.NewRegionMiddleware(nil)
+
+	assert.Empty(t, callRootDomain(t, middleware, map[string]any{"environmentId": uuid.NewString()}))
+}
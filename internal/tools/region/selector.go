@@ -0,0 +1,101 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package region sends the PingOne API requests of tool calls to the region of the environment they act on, so
+// that organizations with environments in multiple geographies can be managed from one server.
+package region
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+)
+
+// Selector selects the region that the PingOne API requests of a tool call are sent to.
+//
+// The region of an environment is looked up with the PingOne API of the server's region and cached, as the
+// region of an environment never changes. Calls that do not act on an environment are sent to the region
+// selected for the session, or to the server's region if none is selected. It is safe for concurrent use.
+type Selector struct {
+	environmentsFactory environments.EnvironmentsClientFactory
+
+	mu                 sync.Mutex
+	sessionRootDomain  string
+	environmentDomains map[uuid.UUID]string
+}
+
+// NewSelector creates a selector that looks up the regions of environments with clients from the factory.
+func NewSelector(environmentsFactory environments.EnvironmentsClientFactory) *Selector {
+	return &Selector{
+		environmentsFactory: environmentsFactory,
+		environmentDomains:  map[uuid.UUID]string{},
+	}
+}
+
+// SessionRootDomain returns the root domain of the region selected for the session, or an empty string if the
+// server's region is used.
+func (s *Selector) SessionRootDomain() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessionRootDomain
+}
+
+// SelectSessionRegion selects the region, by code or root domain, that calls which do not act on an environment
+// are sent to, and returns its root domain. An empty region selects the server's region again.
+func (s *Selector) SelectSessionRegion(region string) (string, error) {
+	rootDomain := ""
+	if region != "" {
+		var err error
+		rootDomain, err = sdk.RootDomainForRegion(region)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessionRootDomain = rootDomain
+	return rootDomain, nil
+}
+
+// EnvironmentRootDomain returns the root domain of the region of the environment.
+func (s *Selector) EnvironmentRootDomain(ctx context.Context, environmentId uuid.UUID) (string, error) {
+	s.mu.Lock()
+	rootDomain, ok := s.environmentDomains[environmentId]
+	s.mu.Unlock()
+	if ok {
+		return rootDomain, nil
+	}
+
+	// The environment is looked up in the server's region rather than in the region selected for the session
+	ctx = sdk.ContextWithRootDomain(ctx, "")
+	client, err := s.environmentsFactory.GetAuthenticatedClient(ctx)
+	if err != nil {
+		return "", err
+	}
+	environment, _, err := client.GetEnvironment(ctx, environmentId)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the region of environment %s: %w", environmentId, err)
+	}
+	rootDomain, err = sdk.RootDomainForRegion(string(environment.GetRegion()))
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.environmentDomains[environmentId] = rootDomain
+	return rootDomain, nil
+}
+
+// Clear forgets the region selected for the session and the regions of environments, such as when the server
+// switches to another profile.
+func (s *Selector) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessionRootDomain = ""
+	clear(s.environmentDomains)
+}
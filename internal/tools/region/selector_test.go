@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package region_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/region"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newSelector returns a selector whose environments client reports the environment in the region
+func newSelector(t *testing.T, environmentId uuid.UUID, regionCode pingone.EnvironmentRegionCode) (*region.Selector, *testutils.MockEnvironmentsClient) {
+	t.Helper()
+	client := &testutils.MockEnvironmentsClient{}
+	client.On("GetEnvironment", mock.Anything, environmentId).Return(&pingone.EnvironmentResponse{Id: environmentId, Region: regionCode}, nil, nil)
+	client.On("GetEnvironment", mock.Anything, mock.Anything).Return(nil, nil, errors.New("environment not found"))
+	return region.NewSelector(testutils.NewMockEnvironmentsClientFactory(client, nil)), client
+}
+
+func TestSelector_EnvironmentRootDomain(t *testing.T) {
+	environmentId := uuid.New()
+	selector, client := newSelector(t, environmentId, pingone.ENVIRONMENTREGIONCODE_EU)
+
+	rootDomain, err := selector.EnvironmentRootDomain(context.Background(), environmentId)
+	require.NoError(t, err)
+	assert.Equal(t, "pingone.eu", rootDomain)
+
+	// The region is cached
+	rootDomain, err = selector.EnvironmentRootDomain(context.Background(), environmentId)
+	require.NoError(t, err)
+	assert.Equal(t, "pingone.eu", rootDomain)
+	client.AssertNumberOfCalls(t, "GetEnvironment", 1)
+
+	// The region is looked up again after the selector is cleared
+	selector.Clear()
+	_, err = selector.EnvironmentRootDomain(context.Background(), environmentId)
+	require.NoError(t, err)
+	client.AssertNumberOfCalls(t, "GetEnvironment", 2)
+
+	_, err = selector.EnvironmentRootDomain(context.Background(), uuid.New())
+	assert.ErrorContains(t, err, "failed to look up the region of environment")
+}
+
+func TestSelector_EnvironmentRootDomain_ClientError(t *testing.T) {
+	selector := region.NewSelector(testutils.NewMockEnvironmentsClientFactory(nil, errors.New("no active auth session")))
+
+	_, err := selector.EnvironmentRootDomain(context.Background(), uuid.New())
+	assert.ErrorContains(t, err, "no active auth session")
+}
+
+func TestSelector_SelectSessionRegion(t *testing.T) {
+	selector, _ := newSelector(t, uuid.New(), pingone.ENVIRONMENTREGIONCODE_NA)
+	assert.Empty(t, selector.SessionRootDomain())
+
+	rootDomain, err := selector.SelectSessionRegion("ca")
+	require.NoError(t, err)
+	assert.Equal(t, "pingone.ca", rootDomain)
+	assert.Equal(t, "pingone.ca", selector.SessionRootDomain())
+
+	_, err = selector.SelectSessionRegion("mars")
+	assert.ErrorContains(t, err, "unknown PingOne region")
+	assert.Equal(t, "pingone.ca", selector.SessionRootDomain(), "an unknown region should not change the selection")
+
+	rootDomain, err = selector.SelectSessionRegion("")
+	require.NoError(t, err)
+	assert.Empty(t, rootDomain)
+	assert.Empty(t, selector.SessionRootDomain())
+
+	_, err = selector.SelectSessionRegion("EU")
+	require.NoError(t, err)
+	selector.Clear()
+	assert.Empty(t, selector.SessionRootDomain())
+}
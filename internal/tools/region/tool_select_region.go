@@ -0,0 +1,70 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package region
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var SelectRegionDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "select_region",
+		Title:        "Select PingOne Region",
+		Description:  "Select the PingOne region that calls of tools that do not take an 'environmentId', such as 'list_environments', are sent to for the rest of the session. Calls of tools that take an 'environmentId' are always sent to the region of that environment, so this tool is only needed to manage an organization's environments in another geography. Call without a region to use the server's configured region again.",
+		InputSchema:  schema.MustGenerateSchema[SelectRegionInput](),
+		OutputSchema: schema.MustGenerateSchema[SelectRegionOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+// SelectRegionInput defines the input parameters for selecting the region of the session
+type SelectRegionInput struct {
+	Region string `json:"region,omitempty" jsonschema:"OPTIONAL. The region to select, as a region code (NA, EU, AP, AU, CA or SG) or a PingOne root domain such as pingone.eu. Omit to use the server's configured region."`
+}
+
+// SelectRegionOutput represents the region selected for the session
+type SelectRegionOutput struct {
+	RootDomain string   `json:"rootDomain,omitempty" jsonschema:"The PingOne root domain of the selected region. Empty if the server's configured region is used."`
+	Regions    []string `json:"regions" jsonschema:"The codes of the regions that can be selected"`
+}
+
+// SelectRegionHandler selects the region of the session with the selector
+func SelectRegionHandler(selector *Selector) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input SelectRegionInput,
+) (
+	*mcp.CallToolResult,
+	*SelectRegionOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SelectRegionInput) (*mcp.CallToolResult, *SelectRegionOutput, error) {
+		rootDomain, err := selector.SelectSessionRegion(input.Region)
+		if err != nil {
+			toolErr := errs.NewToolError(SelectRegionDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &SelectRegionOutput{
+			RootDomain: rootDomain,
+			Regions:    sdk.RegionCodes(),
+		}, nil
+	}
+}
+
+// RegisterSelectRegionTool adds the select_region tool to the MCP server.
+func RegisterSelectRegionTool(server *mcp.Server, selector *Selector) {
+	mcp.AddTool(server, SelectRegionDef.McpTool, SelectRegionHandler(selector))
+}
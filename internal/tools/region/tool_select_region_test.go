@@ -0,0 +1,40 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package region_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-go-client/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/region"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectRegionHandler(t *testing.T) {
+	selector, _ := newSelector(t, uuid.New(), pingone.ENVIRONMENTREGIONCODE_NA)
+	handler := region.SelectRegionHandler(selector)
+
+	_, output, err := handler(context.Background(), nil, region.SelectRegionInput{Region: "pingone.sg"})
+	require.NoError(t, err)
+	assert.Equal(t, "pingone.sg", output.RootDomain)
+	assert.Equal(t, sdk.RegionCodes(), output.Regions)
+	assert.Equal(t, "pingone.sg", selector.SessionRootDomain())
+
+	_, output, err = handler(context.Background(), nil, region.SelectRegionInput{})
+	require.NoError(t, err)
+	assert.Empty(t, output.RootDomain)
+	assert.Empty(t, selector.SessionRootDomain())
+
+	_, _, err = handler(context.Background(), nil, region.SelectRegionInput{Region: "mars"})
+	assert.ErrorContains(t, err, "unknown PingOne region")
+}
+
+func TestSelectRegionDef(t *testing.T) {
+	assert.Equal(t, "select_region", region.SelectRegionDef.McpTool.Name)
+	assert.True(t, region.SelectRegionDef.IsReadOnly())
+	assert.True(t, region.SelectRegionDef.ValidationPolicy.ProductionEnvironmentNotApplicable)
+}
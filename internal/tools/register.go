@@ -11,34 +11,91 @@ import (
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
 	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
 	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/alerting"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/applications"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/davinci"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/directory"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentcloning"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentexport"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/licenses"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/localization"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resources"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/scimfilters"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/search"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/statistics"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
 )
 
+// CollectionState is the state of the tool collections that outlives the registration of their tools, such as when
+// a toolset is disabled and enabled again
+type CollectionState struct {
+	DeletionScheduler *environments.DeletionScheduler
+	Campaigns         *accessreview.CampaignStore
+}
+
+// NewCollectionState creates the state of the tool collections of a server
+func NewCollectionState(ctx context.Context, clientFactory sdk.ClientFactory, tokenStore tokenstore.TokenStore) *CollectionState {
+	environmentsClientFactory := environments.NewPingOneClientEnvironmentsWrapperFactory(clientFactory, tokenStore)
+	return &CollectionState{
+		DeletionScheduler: environments.NewDeletionScheduler(ctx, environments.DeleteScheduledEnvironment(environmentsClientFactory)),
+		Campaigns:         accessreview.NewCampaignStore(),
+	}
+}
+
 // getDefaultCollections creates SDK collections
-func getDefaultCollections() []collections.Collection {
+func getDefaultCollections(state CollectionState) []collections.Collection {
 	return []collections.Collection{
+		&davinci.DaVinciCollection{},
 		&directory.DirectoryCollection{},
-		&environments.EnvironmentsCollection{},
+		&environments.EnvironmentsCollection{DeletionScheduler: state.DeletionScheduler},
 	}
 }
 
 // getLegacySdkCollections creates legacy SDK collections
-func getLegacySdkCollections() []collections.LegacySdkCollection {
+func getLegacySdkCollections(state CollectionState) []collections.LegacySdkCollection {
 	return []collections.LegacySdkCollection{
+		&accessreview.AccessReviewCollection{Campaigns: state.Campaigns},
+		&agreements.AgreementsCollection{},
+		&alerting.AlertingCollection{},
 		&applications.ApplicationsCollection{},
+		&audit.AuditCollection{},
+		&authorize.AuthorizeCollection{},
+		&brandingthemes.BrandingThemesCollection{},
+		&credentials.CredentialsCollection{},
+		&environmentcloning.EnvironmentCloningCollection{},
+		&environmentexport.EnvironmentExportCollection{},
+		&groups.GroupsCollection{},
+		&identityproviders.IdentityProvidersCollection{},
+		&licenses.LicensesCollection{},
+		&localization.LocalizationCollection{},
 		&populations.PopulationsCollection{},
+		&resources.ResourcesCollection{},
+		&roles.RolesCollection{},
+		&scimfilters.ScimFiltersCollection{},
+		&search.SearchCollection{},
+		&statistics.StatisticsCollection{},
+		&users.UsersCollection{},
 	}
 }
 
-func RegisterCollections(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, legacySdkClientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+// RegisterCollections registers the tools of the collections included by the tool filter, sharing the collection state
+func RegisterCollections(ctx context.Context, server *mcp.Server, clientFactory sdk.ClientFactory, legacySdkClientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter, state *CollectionState) error {
 	// Get SDK collections
-	defaultCollections := getDefaultCollections()
+	defaultCollections := getDefaultCollections(*state)
 
 	for _, collection := range defaultCollections {
 		if !toolFilter.ShouldIncludeCollection(collection.Name()) {
@@ -53,7 +110,7 @@ func RegisterCollections(ctx context.Context, server *mcp.Server, clientFactory
 	}
 
 	// Get legacy SDK collections
-	legacyCollections := getLegacySdkCollections()
+	legacyCollections := getLegacySdkCollections(*state)
 
 	for _, collection := range legacyCollections {
 		if !toolFilter.ShouldIncludeCollection(collection.Name()) {
@@ -71,15 +128,65 @@ func RegisterCollections(ctx context.Context, server *mcp.Server, clientFactory
 
 func ListTools() []types.ToolDefinition {
 	var tools []types.ToolDefinition
-	defaultCollections := getDefaultCollections()
+	defaultCollections := getDefaultCollections(CollectionState{})
 	for _, collection := range defaultCollections {
 		tools = append(tools, collection.ListTools()...)
 	}
 
 	// List tools from legacy collections
-	legacyCollections := getLegacySdkCollections()
+	legacyCollections := getLegacySdkCollections(CollectionState{})
 	for _, collection := range legacyCollections {
 		tools = append(tools, collection.ListTools()...)
 	}
 	return tools
 }
+
+// ListCollectionNames returns the names of all tool collections.
+func ListCollectionNames() []string {
+	var names []string
+	for _, collection := range getDefaultCollections(CollectionState{}) {
+		names = append(names, collection.Name())
+	}
+	for _, collection := range getLegacySdkCollections(CollectionState{}) {
+		names = append(names, collection.Name())
+	}
+	return names
+}
+
+// ListCollectionTools returns the definitions of the tools of the named tool collection, or nil if there is no
+// such collection.
+func ListCollectionTools(collectionName string) []types.ToolDefinition {
+	for _, collection := range getDefaultCollections(CollectionState{}) {
+		if collection.Name() == collectionName {
+			return collection.ListTools()
+		}
+	}
+	for _, collection := range getLegacySdkCollections(CollectionState{}) {
+		if collection.Name() == collectionName {
+			return collection.ListTools()
+		}
+	}
+	return nil
+}
+
+// ListEnabledTools returns the definitions of the tools that RegisterCollections registers with the filter.
+func ListEnabledTools(toolFilter *filter.Filter) []types.ToolDefinition {
+	var tools []types.ToolDefinition
+	addTools := func(collectionName string, collectionTools []types.ToolDefinition) {
+		if !toolFilter.ShouldIncludeCollection(collectionName) {
+			return
+		}
+		for _, toolDef := range collectionTools {
+			if toolFilter.ShouldIncludeTool(&toolDef) {
+				tools = append(tools, toolDef)
+			}
+		}
+	}
+	for _, collection := range getDefaultCollections(CollectionState{}) {
+		addTools(collection.Name(), collection.ListTools())
+	}
+	for _, collection := range getLegacySdkCollections(CollectionState{}) {
+		addTools(collection.Name(), collection.ListTools())
+	}
+	return tools
+}
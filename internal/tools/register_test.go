@@ -3,14 +3,37 @@
 package tools_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/accessreview"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/agreements"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/alerting"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/applications"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/authorize"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/brandingthemes"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/credentials"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/davinci"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/directory"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentcloning"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environmentexport"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/fieldselection"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/groups"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/identityproviders"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/licenses"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/localization"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resources"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/scimfilters"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/search"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/statistics"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,9 +45,29 @@ func TestAllToolsRegistered(t *testing.T) {
 	// Get tools from individual collections
 	var expectedTools []types.ToolDefinition
 	expectedTools = append(expectedTools, (&directory.DirectoryCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&davinci.DaVinciCollection{}).ListTools()...)
 	expectedTools = append(expectedTools, (&environments.EnvironmentsCollection{}).ListTools()...)
 	expectedTools = append(expectedTools, (&populations.PopulationsCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&roles.RolesCollection{}).ListTools()...)
 	expectedTools = append(expectedTools, (&applications.ApplicationsCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&agreements.AgreementsCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&alerting.AlertingCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&accessreview.AccessReviewCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&audit.AuditCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&authorize.AuthorizeCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&brandingthemes.BrandingThemesCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&credentials.CredentialsCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&environmentcloning.EnvironmentCloningCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&environmentexport.EnvironmentExportCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&groups.GroupsCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&identityproviders.IdentityProvidersCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&licenses.LicensesCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&localization.LocalizationCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&resources.ResourcesCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&scimfilters.ScimFiltersCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&search.SearchCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&statistics.StatisticsCollection{}).ListTools()...)
+	expectedTools = append(expectedTools, (&users.UsersCollection{}).ListTools()...)
 
 	// Verify lists match
 	if len(allTools) != len(expectedTools) {
@@ -54,6 +97,43 @@ func TestAllToolsRegistered(t *testing.T) {
 	}
 }
 
+func TestListCollectionNames(t *testing.T) {
+	collectionNames := tools.ListCollectionNames()
+	assert.Contains(t, collectionNames, environments.CollectionName)
+	assert.Contains(t, collectionNames, users.CollectionName)
+}
+
+func TestListCollectionTools(t *testing.T) {
+	assert.Contains(t, tools.ListCollectionTools(environments.CollectionName), environments.ListEnvironmentsDef)
+	assert.Nil(t, tools.ListCollectionTools("unknown"))
+
+	var toolCount int
+	for _, collectionName := range tools.ListCollectionNames() {
+		toolCount += len(tools.ListCollectionTools(collectionName))
+	}
+	assert.Equal(t, len(tools.ListTools()), toolCount)
+}
+
+func TestListEnabledTools(t *testing.T) {
+	toolNames := func(toolDefs []types.ToolDefinition) []string {
+		names := make([]string, len(toolDefs))
+		for i, toolDef := range toolDefs {
+			names[i] = toolDef.McpTool.Name
+		}
+		return names
+	}
+
+	assert.Len(t, tools.ListEnabledTools(filter.PassthroughFilter()), len(tools.ListTools()))
+
+	readOnly := toolNames(tools.ListEnabledTools(filter.NewFilter(true, nil, nil, []string{environments.CollectionName}, nil)))
+	assert.Contains(t, readOnly, environments.ListEnvironmentsDef.McpTool.Name)
+	assert.NotContains(t, readOnly, environments.CreateEnvironmentDef.McpTool.Name)
+	assert.NotContains(t, readOnly, populations.ListPopulationsDef.McpTool.Name)
+
+	included := toolNames(tools.ListEnabledTools(filter.NewFilter(false, []string{populations.ListPopulationsDef.McpTool.Name, environments.CreateEnvironmentDef.McpTool.Name}, nil, nil, []string{environments.CollectionName})))
+	assert.Equal(t, []string{populations.ListPopulationsDef.McpTool.Name}, included)
+}
+
 func TestAllToolsHaveSchemas(t *testing.T) {
 	for _, toolDef := range tools.ListTools() {
 		t.Run(toolDef.McpTool.Name, func(t *testing.T) {
@@ -62,3 +142,14 @@ func TestAllToolsHaveSchemas(t *testing.T) {
 		})
 	}
 }
+
+func TestGetAndListToolsSupportFieldSelection(t *testing.T) {
+	for _, toolDef := range tools.ListTools() {
+		if !strings.HasPrefix(toolDef.McpTool.Name, "get_") && !strings.HasPrefix(toolDef.McpTool.Name, "list_") {
+			continue
+		}
+		t.Run(toolDef.McpTool.Name, func(t *testing.T) {
+			assert.True(t, fieldselection.SupportsFieldSelection(toolDef), "tool %s should declare a fields input argument", toolDef.McpTool.Name)
+		})
+	}
+}
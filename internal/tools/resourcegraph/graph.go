@@ -0,0 +1,346 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package resourcegraph keeps track of the PingOne resources referenced and created by tool calls in a session,
+// and how they are linked, so that an agent can keep track of the resources of a multi-step setup.
+package resourcegraph
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxNodes is the number of resources the graph keeps before the least recently seen are forgotten.
+const DefaultMaxNodes = 500
+
+// Node is a resource referenced or returned by a tool call.
+type Node struct {
+	Id            string    `json:"id" jsonschema:"The UUID of the resource"`
+	Type          string    `json:"type" jsonschema:"The type of the resource, such as user, population or application"`
+	Name          string    `json:"name,omitempty" jsonschema:"The name of the resource, or the username of a user, if a tool returned it"`
+	EnvironmentId string    `json:"environmentId,omitempty" jsonschema:"The UUID of the environment the resource is in"`
+	Tools         []string  `json:"tools" jsonschema:"The tools whose arguments or output referenced the resource, in the order they first did"`
+	FirstSeenAt   time.Time `json:"firstSeenAt" jsonschema:"When a tool call first referenced the resource"`
+	LastSeenAt    time.Time `json:"lastSeenAt" jsonschema:"When a tool call last referenced the resource"`
+}
+
+// Edge is a link from one resource to another, such as from a user to its population.
+type Edge struct {
+	From     string `json:"from" jsonschema:"The UUID of the resource that links to the other"`
+	To       string `json:"to" jsonschema:"The UUID of the linked resource"`
+	Relation string `json:"relation" jsonschema:"How the resources are linked, named after the attribute holding the link, such as population"`
+	Tool     string `json:"tool" jsonschema:"The tool whose output first showed the link"`
+}
+
+// Graph holds the resources of a session and the links between them in memory. It is lost when the server
+// restarts.
+type Graph struct {
+	mutex    sync.Mutex
+	maxNodes int
+	nodes    map[string]*Node
+	// edges are ordered by when they were first seen
+	edges []Edge
+}
+
+// NewGraph creates a graph that keeps up to maxNodes resources.
+func NewGraph(maxNodes int) *Graph {
+	if maxNodes <= 0 {
+		maxNodes = DefaultMaxNodes
+	}
+	return &Graph{
+		maxNodes: maxNodes,
+		nodes:    map[string]*Node{},
+	}
+}
+
+// Observe adds the resources referenced by the arguments and output of a tool call to the graph, along with
+// the links between them found in the output. Arguments and output are values as decoded from JSON.
+// Observing with a nil graph does nothing.
+func (g *Graph) Observe(toolName string, arguments any, output any) {
+	if g == nil {
+		return
+	}
+	now := time.Now().UTC()
+	found := &extraction{}
+	environmentId := ""
+	if args, ok := arguments.(map[string]any); ok {
+		environmentId, _ = uuidField(args, "environmentId")
+	}
+	found.walk("", arguments, environmentId, false)
+	found.walk("", output, environmentId, true)
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	for _, observed := range found.nodes {
+		node, ok := g.nodes[observed.Id]
+		if !ok {
+			node = &Node{
+				Id:          observed.Id,
+				Type:        observed.Type,
+				Tools:       []string{},
+				FirstSeenAt: now,
+			}
+			g.nodes[observed.Id] = node
+		}
+		if observed.Name != "" {
+			node.Name = observed.Name
+		}
+		if node.EnvironmentId == "" {
+			node.EnvironmentId = observed.EnvironmentId
+		}
+		if !slices.Contains(node.Tools, toolName) {
+			node.Tools = append(node.Tools, toolName)
+		}
+		node.LastSeenAt = now
+	}
+	for _, edge := range found.edges {
+		if !slices.ContainsFunc(g.edges, func(e Edge) bool {
+			return e.From == edge.From && e.To == edge.To && e.Relation == edge.Relation
+		}) {
+			edge.Tool = toolName
+			g.edges = append(g.edges, edge)
+		}
+	}
+	g.evict()
+}
+
+// Nodes returns the resources in the graph and the links between them, oldest first. If environmentId is set,
+// only the resources in that environment are returned. If resourceId is set, only that resource and the
+// resources linked to it, directly or through other resources, are returned.
+func (g *Graph) Nodes(environmentId string, resourceId string) ([]Node, []Edge) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	included := map[string]bool{}
+	for id, node := range g.nodes {
+		if environmentId == "" || node.EnvironmentId == environmentId || id == environmentId {
+			included[id] = true
+		}
+	}
+	if resourceId != "" {
+		connected := map[string]bool{}
+		if included[resourceId] {
+			connected[resourceId] = true
+		}
+		for added := true; added; {
+			added = false
+			for _, edge := range g.edges {
+				if !included[edge.From] || !included[edge.To] || connected[edge.From] == connected[edge.To] {
+					continue
+				}
+				connected[edge.From] = true
+				connected[edge.To] = true
+				added = true
+			}
+		}
+		included = connected
+	}
+
+	nodes := []Node{}
+	for id := range included {
+		node := *g.nodes[id]
+		node.Tools = slices.Clone(node.Tools)
+		nodes = append(nodes, node)
+	}
+	slices.SortFunc(nodes, func(a, b Node) int {
+		if c := a.FirstSeenAt.Compare(b.FirstSeenAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Id, b.Id)
+	})
+	edges := []Edge{}
+	for _, edge := range g.edges {
+		if included[edge.From] && included[edge.To] {
+			edges = append(edges, edge)
+		}
+	}
+	return nodes, edges
+}
+
+// Clear removes all resources, such as when the server switches to another PingOne profile.
+func (g *Graph) Clear() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.nodes = map[string]*Node{}
+	g.edges = nil
+}
+
+// evict forgets the least recently seen resources and their links while there are more than maxNodes
+func (g *Graph) evict() {
+	for len(g.nodes) > g.maxNodes {
+		var oldest *Node
+		for _, node := range g.nodes {
+			if oldest == nil || node.LastSeenAt.Before(oldest.LastSeenAt) || (node.LastSeenAt.Equal(oldest.LastSeenAt) && node.FirstSeenAt.Before(oldest.FirstSeenAt)) {
+				oldest = node
+			}
+		}
+		delete(g.nodes, oldest.Id)
+		g.edges = slices.DeleteFunc(g.edges, func(e Edge) bool {
+			return e.From == oldest.Id || e.To == oldest.Id
+		})
+	}
+}
+
+// extraction collects the resources and links found in tool arguments and outputs
+type extraction struct {
+	nodes []Node
+	edges []Edge
+}
+
+// walk finds the resources in a value decoded from JSON. An object with a UUID "id" attribute is a resource,
+// whose type is named after the attribute holding it, and which links to the resources its other attributes
+// reference, such as "population": {"id": ...} or "populationId". The resources referenced by an object
+// without an id, such as tool arguments, are added without links. Links are only taken from tool outputs, as
+// arguments can hold changes that failed.
+func (e *extraction) walk(key string, value any, environmentId string, linked bool) {
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			e.walk(key, item, environmentId, linked)
+		}
+	case map[string]any:
+		if id, ok := uuidField(v, "environmentId"); ok {
+			environmentId = id
+			e.add(Node{Id: id, Type: "environment"})
+		}
+		if environment, ok := v["environment"].(map[string]any); ok {
+			if id, ok := uuidField(environment, "id"); ok {
+				environmentId = id
+				e.add(Node{Id: id, Type: "environment", Name: nameOf(environment, "environment")})
+			}
+		}
+
+		references := referencedIds(v)
+		id, hasId := uuidField(v, "id")
+		if hasId {
+			resourceType := singular(key)
+			node := Node{Id: id, Type: resourceType, Name: nameOf(v, resourceType)}
+			if resourceType != "environment" {
+				node.EnvironmentId = environmentId
+			}
+			e.add(node)
+			for _, relation := range slices.Sorted(maps.Keys(references)) {
+				referenced := Node{Id: references[relation], Type: relation, EnvironmentId: environmentId}
+				if referencedObject, ok := v[relation].(map[string]any); ok {
+					// Referenced objects may describe the resource further, such as with its name
+					referenced.Name = nameOf(referencedObject, relation)
+				}
+				e.add(referenced)
+				if linked {
+					e.link(id, references[relation], relation)
+				}
+			}
+		} else {
+			// Objects without an id, such as tool arguments or the wrapper of a tool output, hold resources
+			// rather than link them, so referenced objects are walked as resources below
+			for _, resourceType := range slices.Sorted(maps.Keys(references)) {
+				if _, ok := v[resourceType].(map[string]any); ok {
+					delete(references, resourceType)
+				}
+			}
+			for _, resourceType := range slices.Sorted(maps.Keys(references)) {
+				node := Node{Id: references[resourceType], Type: resourceType, EnvironmentId: environmentId}
+				if len(references) == 1 && resourceType == "user" {
+					// Such as the output of a user tool, naming the user by its username
+					node.Name = nameOf(v, resourceType)
+				}
+				e.add(node)
+			}
+		}
+
+		for nestedKey, nested := range v {
+			if nestedKey == "_links" || nestedKey == "environment" {
+				continue
+			}
+			if _, isReference := references[nestedKey]; hasId && isReference {
+				continue
+			}
+			e.walk(nestedKey, nested, environmentId, linked)
+		}
+	}
+}
+
+func (e *extraction) add(node Node) {
+	for i := range e.nodes {
+		if e.nodes[i].Id == node.Id {
+			if e.nodes[i].Name == "" {
+				e.nodes[i].Name = node.Name
+			}
+			if e.nodes[i].EnvironmentId == "" {
+				e.nodes[i].EnvironmentId = node.EnvironmentId
+			}
+			return
+		}
+	}
+	e.nodes = append(e.nodes, node)
+}
+
+func (e *extraction) link(from string, to string, relation string) {
+	if from == to {
+		return
+	}
+	e.edges = append(e.edges, Edge{From: from, To: to, Relation: relation})
+}
+
+// referencedIds returns the resources an object references by type, other than its environment, from
+// attributes such as "populationId" or "population": {"id": ...}
+func referencedIds(object map[string]any) map[string]string {
+	references := map[string]string{}
+	for key, value := range object {
+		if key == "id" || key == "environmentId" || key == "environment" || key == "_links" {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			if resourceType, ok := strings.CutSuffix(key, "Id"); ok && resourceType != "" && isUUID(v) {
+				references[resourceType] = v
+			}
+		case map[string]any:
+			if id, ok := uuidField(v, "id"); ok {
+				references[key] = id
+			}
+		}
+	}
+	return references
+}
+
+// nameOf returns the name of a resource, or the username of a user
+func nameOf(object map[string]any, resourceType string) string {
+	if resourceType == "user" {
+		if username, ok := object["username"].(string); ok {
+			return username
+		}
+	}
+	name, _ := object["name"].(string)
+	return name
+}
+
+// singular returns the resource type named by an attribute, such as user for users
+func singular(key string) string {
+	switch {
+	case key == "":
+		return "resource"
+	case strings.HasSuffix(key, "ies"):
+		return strings.TrimSuffix(key, "ies") + "y"
+	case strings.HasSuffix(key, "s") && !strings.HasSuffix(key, "ss"):
+		return strings.TrimSuffix(key, "s")
+	}
+	return key
+}
+
+func uuidField(object map[string]any, key string) (string, bool) {
+	value, ok := object[key].(string)
+	if !ok || !isUUID(value) {
+		return "", false
+	}
+	return value, true
+}
+
+func isUUID(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil
+}
@@ -0,0 +1,198 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resourcegraph_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resourcegraph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEnvironmentId      = "550e8400-e29b-41d4-a716-446655440000"
+	testOtherEnvironmentId = "550e8400-e29b-41d4-a716-446655440001"
+	testPopulationId       = "550e8400-e29b-41d4-a716-446655440100"
+	testUserId             = "550e8400-e29b-41d4-a716-446655440200"
+	testOtherUserId        = "550e8400-e29b-41d4-a716-446655440201"
+	testApplicationId      = "550e8400-e29b-41d4-a716-446655440300"
+	testPolicyId           = "550e8400-e29b-41d4-a716-446655440400"
+)
+
+func nodesById(nodes []resourcegraph.Node) map[string]resourcegraph.Node {
+	byId := map[string]resourcegraph.Node{}
+	for _, node := range nodes {
+		byId[node.Id] = node
+	}
+	return byId
+}
+
+func TestGraph_ObserveOutputResources(t *testing.T) {
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	graph.Observe("create_population",
+		map[string]any{"environmentId": testEnvironmentId, "population": map[string]any{"name": "Customers"}},
+		map[string]any{"population": map[string]any{
+			"id":          testPopulationId,
+			"name":        "Customers",
+			"environment": map[string]any{"id": testEnvironmentId},
+		}})
+	graph.Observe("find_user",
+		map[string]any{"environmentId": testEnvironmentId, "username": "alice"},
+		map[string]any{"users": []any{
+			map[string]any{"id": testUserId, "username": "alice", "populationId": testPopulationId},
+		}})
+
+	nodes, edges := graph.Nodes("", "")
+	byId := nodesById(nodes)
+	require.Len(t, byId, 3)
+	assert.Equal(t, "environment", byId[testEnvironmentId].Type)
+	assert.Empty(t, byId[testEnvironmentId].EnvironmentId)
+	assert.Equal(t, []string{"create_population", "find_user"}, byId[testEnvironmentId].Tools)
+
+	population := byId[testPopulationId]
+	assert.Equal(t, "population", population.Type)
+	assert.Equal(t, "Customers", population.Name)
+	assert.Equal(t, testEnvironmentId, population.EnvironmentId)
+	assert.Equal(t, []string{"create_population", "find_user"}, population.Tools)
+
+	user := byId[testUserId]
+	assert.Equal(t, "user", user.Type)
+	assert.Equal(t, "alice", user.Name)
+	assert.Equal(t, testEnvironmentId, user.EnvironmentId)
+
+	assert.Equal(t, []resourcegraph.Edge{
+		{From: testUserId, To: testPopulationId, Relation: "population", Tool: "find_user"},
+	}, edges)
+}
+
+func TestGraph_ObserveNestedReferences(t *testing.T) {
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	graph.Observe("get_application",
+		map[string]any{"environmentId": testEnvironmentId, "applicationId": testApplicationId},
+		map[string]any{"application": map[string]any{
+			"id":           testApplicationId,
+			"name":         "Portal",
+			"signOnPolicy": map[string]any{"id": testPolicyId, "name": "Single factor"},
+			"_links":       map[string]any{"self": map[string]any{"href": "https://api.pingone.com"}},
+		}})
+	graph.Observe("get_application",
+		map[string]any{"environmentId": testEnvironmentId, "applicationId": testApplicationId},
+		map[string]any{"application": map[string]any{
+			"id":           testApplicationId,
+			"name":         "Portal",
+			"signOnPolicy": map[string]any{"id": testPolicyId},
+		}})
+
+	nodes, edges := graph.Nodes("", "")
+	byId := nodesById(nodes)
+	assert.Equal(t, "application", byId[testApplicationId].Type)
+	assert.Equal(t, "signOnPolicy", byId[testPolicyId].Type)
+	assert.Equal(t, "Single factor", byId[testPolicyId].Name)
+	assert.Equal(t, testEnvironmentId, byId[testPolicyId].EnvironmentId)
+	assert.Equal(t, []resourcegraph.Edge{
+		{From: testApplicationId, To: testPolicyId, Relation: "signOnPolicy", Tool: "get_application"},
+	}, edges, "a link seen again should not be repeated")
+}
+
+func TestGraph_ObserveArgumentsWithoutLinks(t *testing.T) {
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	graph.Observe("bulk_create_users",
+		map[string]any{
+			"environmentId": testEnvironmentId,
+			"users": []any{
+				map[string]any{"username": "alice", "populationId": testPopulationId},
+			},
+		},
+		map[string]any{"results": []any{
+			map[string]any{"index": 0, "username": "alice", "success": true, "userId": testUserId},
+			map[string]any{"index": 1, "username": "bob", "success": false},
+		}})
+
+	nodes, edges := graph.Nodes("", "")
+	byId := nodesById(nodes)
+	require.Len(t, byId, 3)
+	assert.Equal(t, "population", byId[testPopulationId].Type)
+	assert.Empty(t, byId[testPopulationId].Name, "a population should not be named after the user referencing it")
+	assert.Equal(t, "user", byId[testUserId].Type)
+	assert.Equal(t, "alice", byId[testUserId].Name)
+	assert.Equal(t, testEnvironmentId, byId[testUserId].EnvironmentId)
+	assert.Empty(t, edges)
+}
+
+func TestGraph_ObserveIgnoresValuesThatAreNotUUIDs(t *testing.T) {
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	graph.Observe("query_audit_events",
+		map[string]any{"environmentId": "not-a-uuid", "actionTypes": []any{"USER.CREATED"}},
+		map[string]any{"events": []any{map[string]any{"id": "event-1", "clientId": "client-1"}}})
+
+	nodes, edges := graph.Nodes("", "")
+	assert.Empty(t, nodes)
+	assert.Empty(t, edges)
+}
+
+func TestGraph_NodesFilters(t *testing.T) {
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	graph.Observe("find_user",
+		map[string]any{"environmentId": testEnvironmentId},
+		map[string]any{"users": []any{
+			map[string]any{"id": testUserId, "username": "alice", "populationId": testPopulationId},
+			map[string]any{"id": testOtherUserId, "username": "bob"},
+		}})
+	graph.Observe("get_application",
+		map[string]any{"environmentId": testOtherEnvironmentId},
+		map[string]any{"application": map[string]any{"id": testApplicationId, "name": "Portal"}})
+
+	nodes, _ := graph.Nodes(testOtherEnvironmentId, "")
+	assert.ElementsMatch(t, []string{testOtherEnvironmentId, testApplicationId}, nodeIds(nodes))
+
+	nodes, edges := graph.Nodes("", testPopulationId)
+	assert.ElementsMatch(t, []string{testUserId, testPopulationId}, nodeIds(nodes))
+	assert.Len(t, edges, 1)
+
+	nodes, edges = graph.Nodes("", testOtherUserId)
+	assert.Equal(t, []string{testOtherUserId}, nodeIds(nodes))
+	assert.Empty(t, edges)
+
+	nodes, _ = graph.Nodes(testOtherEnvironmentId, testUserId)
+	assert.Empty(t, nodes)
+}
+
+func TestGraph_EvictsLeastRecentlySeen(t *testing.T) {
+	graph := resourcegraph.NewGraph(3)
+	graph.Observe("find_user", nil, map[string]any{"users": []any{
+		map[string]any{"id": testUserId, "username": "alice", "populationId": testPopulationId},
+	}})
+	graph.Observe("get_application", nil, map[string]any{"application": map[string]any{"id": testApplicationId}})
+	graph.Observe("get_user", nil, map[string]any{"user": map[string]any{"id": testUserId}})
+	graph.Observe("get_user", nil, map[string]any{"user": map[string]any{"id": testOtherUserId}})
+
+	nodes, edges := graph.Nodes("", "")
+	assert.ElementsMatch(t, []string{testUserId, testApplicationId, testOtherUserId}, nodeIds(nodes))
+	assert.Empty(t, edges, "links of forgotten resources should be removed")
+}
+
+func TestGraph_Clear(t *testing.T) {
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	graph.Observe("get_application", nil, map[string]any{"application": map[string]any{"id": testApplicationId}})
+	graph.Clear()
+
+	nodes, edges := graph.Nodes("", "")
+	assert.Empty(t, nodes)
+	assert.Empty(t, edges)
+}
+
+func TestGraph_NilGraphObservesNothing(t *testing.T) {
+	var graph *resourcegraph.Graph
+	assert.NotPanics(t, func() {
+		graph.Observe("get_application", nil, map[string]any{"application": map[string]any{"id": testApplicationId}})
+	})
+}
+
+func nodeIds(nodes []resourcegraph.Node) []string {
+	ids := []string{}
+	for _, node := range nodes {
+		ids = append(ids, node.Id)
+	}
+	return ids
+}
@@ -0,0 +1,72 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resourcegraph
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GraphMiddleware adds the resources referenced by the arguments and output of successful tool calls to the
+// graph of the session.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, inside any middleware that
+// reduces or reshapes tool outputs, so that it sees the full output of the tool.
+type GraphMiddleware struct {
+	graph *Graph
+}
+
+// NewGraphMiddleware creates middleware that adds resources to the graph. A nil graph records nothing.
+func NewGraphMiddleware(graph *Graph) *GraphMiddleware {
+	return &GraphMiddleware{
+		graph: graph,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *GraphMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.graph == nil {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok || callToolReq.Params.Name == ShowSessionResourceGraphDef.McpTool.Name {
+			return next(ctx, method, req)
+		}
+
+		result, err := next(ctx, method, req)
+		if err != nil {
+			return result, err
+		}
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callToolResult == nil || callToolResult.IsError {
+			return result, err
+		}
+
+		m.graph.Observe(callToolReq.Params.Name, jsonValue(callToolReq.Params.Arguments), jsonValue(callToolResult.StructuredContent))
+
+		return result, err
+	}
+}
+
+// jsonValue returns the value as decoded from JSON, so that typed tool outputs can be walked like arguments
+func jsonValue(value any) any {
+	if value == nil {
+		return nil
+	}
+	data, ok := value.(json.RawMessage)
+	if !ok {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			return nil
+		}
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil
+	}
+	return decoded
+}
@@ -0,0 +1,103 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resourcegraph_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resourcegraph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getUserInput struct {
+	EnvironmentId string `json:"environmentId"`
+	UserId        string `json:"userId"`
+}
+
+type testUser struct {
+	Id           string `json:"id"`
+	Username     string `json:"username"`
+	PopulationId string `json:"populationId"`
+}
+
+type getUserOutput struct {
+	User testUser `json:"user"`
+}
+
+// newGraphServer returns a server whose tool calls add their resources to a new graph
+func newGraphServer(t *testing.T) (*mcp.Server, *resourcegraph.Graph) {
+	t.Helper()
+
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(resourcegraph.NewGraphMiddleware(graph).Handler)
+
+	mcp.AddTool(server, &mcp.Tool{Name: "get_user", Description: "Get a user"}, func(ctx context.Context, req *mcp.CallToolRequest, input getUserInput) (*mcp.CallToolResult, *getUserOutput, error) {
+		if input.UserId != testUserId {
+			return nil, nil, errors.New("user not found")
+		}
+		return nil, &getUserOutput{User: testUser{Id: testUserId, Username: "alice", PopulationId: testPopulationId}}, nil
+	})
+	resourcegraph.RegisterShowSessionResourceGraphTool(server, graph)
+	return server, graph
+}
+
+func TestGraphMiddleware_ObservesToolCalls(t *testing.T) {
+	server, graph := newGraphServer(t)
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, "get_user", getUserInput{EnvironmentId: testEnvironmentId, UserId: testUserId})
+	require.NoError(t, err)
+	require.False(t, output.IsError)
+
+	nodes, edges := graph.Nodes("", "")
+	byId := nodesById(nodes)
+	assert.ElementsMatch(t, []string{testEnvironmentId, testUserId, testPopulationId}, nodeIds(nodes))
+	assert.Equal(t, "alice", byId[testUserId].Name)
+	assert.Equal(t, []string{"get_user"}, byId[testUserId].Tools)
+	assert.Equal(t, []resourcegraph.Edge{
+		{From: testUserId, To: testPopulationId, Relation: "population", Tool: "get_user"},
+	}, edges)
+}
+
+func TestGraphMiddleware_IgnoresFailedToolCalls(t *testing.T) {
+	server, graph := newGraphServer(t)
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, "get_user", getUserInput{EnvironmentId: testEnvironmentId, UserId: testOtherUserId})
+	require.NoError(t, err)
+	require.True(t, output.IsError)
+
+	nodes, _ := graph.Nodes("", "")
+	assert.Empty(t, nodes)
+}
+
+func TestGraphMiddleware_IgnoresShowSessionResourceGraph(t *testing.T) {
+	server, graph := newGraphServer(t)
+	graph.Observe("get_user", nil, map[string]any{"user": map[string]any{"id": testUserId}})
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, resourcegraph.ShowSessionResourceGraphDef.McpTool.Name, map[string]any{
+		"environmentId": testEnvironmentId,
+	})
+	require.NoError(t, err)
+	require.False(t, output.IsError)
+
+	nodes, _ := graph.Nodes("", "")
+	require.Len(t, nodes, 1)
+	assert.Equal(t, []string{"get_user"}, nodes[0].Tools)
+}
+
+func TestGraphMiddleware_NilGraph(t *testing.T) {
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(resourcegraph.NewGraphMiddleware(nil).Handler)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_user", Description: "Get a user"}, func(ctx context.Context, req *mcp.CallToolRequest, input getUserInput) (*mcp.CallToolResult, *getUserOutput, error) {
+		return nil, &getUserOutput{User: testUser{Id: testUserId}}, nil
+	})
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, "get_user", getUserInput{EnvironmentId: testEnvironmentId, UserId: testUserId})
+	require.NoError(t, err)
+	assert.False(t, output.IsError)
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resourcegraph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ShowSessionResourceGraphDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "show_session_resource_graph",
+		Title: "Show Session Resource Graph",
+		Description: `Show the PingOne resources that tool calls in this session have referenced, returned or created, and the links between them, such as from a user to its population. Use to keep track of the resources of a multi-step setup, such as the populations, users and applications created for it, without calling the tools again.
+
+Each resource lists the tools that referenced it. Links are taken from tool outputs, named after the attribute holding the link. The graph only holds what tool calls through this server have seen since it started, and does not call PingOne: it does not show resources that were never referenced, and may show resources that have since been changed or deleted.
+
+Filter by environment, or by a resource to show only it and the resources linked to it.`,
+		InputSchema:  schema.MustGenerateSchema[ShowSessionResourceGraphInput](),
+		OutputSchema: schema.MustGenerateSchema[ShowSessionResourceGraphOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ShowSessionResourceGraphInput struct {
+	EnvironmentId *uuid.UUID `json:"environmentId,omitempty" jsonschema:"OPTIONAL. Only show the resources in this environment UUID."`
+	ResourceId    *uuid.UUID `json:"resourceId,omitempty" jsonschema:"OPTIONAL. Only show this resource UUID and the resources linked to it, directly or through other resources."`
+}
+
+type ShowSessionResourceGraphOutput struct {
+	Nodes []Node `json:"nodes" jsonschema:"The resources, in the order tool calls first referenced them"`
+	Edges []Edge `json:"edges" jsonschema:"The links between the resources, in the order tool calls first showed them"`
+}
+
+// ShowSessionResourceGraphHandler returns the resources of the graph
+func ShowSessionResourceGraphHandler(graph *Graph) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ShowSessionResourceGraphInput,
+) (
+	*mcp.CallToolResult,
+	*ShowSessionResourceGraphOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ShowSessionResourceGraphInput) (*mcp.CallToolResult, *ShowSessionResourceGraphOutput, error) {
+		environmentId := ""
+		if input.EnvironmentId != nil {
+			environmentId = input.EnvironmentId.String()
+		}
+		resourceId := ""
+		if input.ResourceId != nil {
+			resourceId = input.ResourceId.String()
+		}
+
+		nodes, edges := graph.Nodes(environmentId, resourceId)
+		if resourceId != "" && len(nodes) == 0 {
+			err := fmt.Errorf("resource %s has not been referenced by a tool call in this session", resourceId)
+			if environmentId != "" {
+				err = fmt.Errorf("resource %s has not been referenced by a tool call in this session in environment %s", resourceId, environmentId)
+			}
+			toolErr := errs.NewToolError(ShowSessionResourceGraphDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		return nil, &ShowSessionResourceGraphOutput{
+			Nodes: nodes,
+			Edges: edges,
+		}, nil
+	}
+}
+
+// RegisterShowSessionResourceGraphTool adds the show_session_resource_graph tool to the MCP server.
+func RegisterShowSessionResourceGraphTool(server *mcp.Server, graph *Graph) {
+	mcp.AddTool(server, ShowSessionResourceGraphDef.McpTool, ShowSessionResourceGraphHandler(graph))
+}
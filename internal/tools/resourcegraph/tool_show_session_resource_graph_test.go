@@ -0,0 +1,105 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resourcegraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resourcegraph"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testGraph() *resourcegraph.Graph {
+	graph := resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes)
+	graph.Observe("find_user",
+		map[string]any{"environmentId": testEnvironmentId},
+		map[string]any{"users": []any{
+			map[string]any{"id": testUserId, "username": "alice", "populationId": testPopulationId},
+		}})
+	graph.Observe("get_application",
+		map[string]any{"environmentId": testOtherEnvironmentId},
+		map[string]any{"application": map[string]any{"id": testApplicationId, "name": "Portal"}})
+	return graph
+}
+
+func TestShowSessionResourceGraphHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     resourcegraph.ShowSessionResourceGraphInput
+		wantNodes []string
+		wantEdges int
+	}{
+		{
+			name:      "All resources",
+			input:     resourcegraph.ShowSessionResourceGraphInput{},
+			wantNodes: []string{testEnvironmentId, testUserId, testPopulationId, testOtherEnvironmentId, testApplicationId},
+			wantEdges: 1,
+		},
+		{
+			name:      "By environment",
+			input:     resourcegraph.ShowSessionResourceGraphInput{EnvironmentId: testutils.Pointer(uuid.MustParse(testOtherEnvironmentId))},
+			wantNodes: []string{testOtherEnvironmentId, testApplicationId},
+			wantEdges: 0,
+		},
+		{
+			name:      "By resource",
+			input:     resourcegraph.ShowSessionResourceGraphInput{ResourceId: testutils.Pointer(uuid.MustParse(testUserId))},
+			wantNodes: []string{testUserId, testPopulationId},
+			wantEdges: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := resourcegraph.ShowSessionResourceGraphHandler(testGraph())
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.ElementsMatch(t, tt.wantNodes, nodeIds(output.Nodes))
+			assert.Len(t, output.Edges, tt.wantEdges)
+		})
+	}
+}
+
+func TestShowSessionResourceGraphHandler_EmptyGraph(t *testing.T) {
+	handler := resourcegraph.ShowSessionResourceGraphHandler(resourcegraph.NewGraph(resourcegraph.DefaultMaxNodes))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resourcegraph.ShowSessionResourceGraphInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Empty(t, output.Nodes)
+	assert.Empty(t, output.Edges)
+}
+
+func TestShowSessionResourceGraphHandler_UnknownResource(t *testing.T) {
+	handler := resourcegraph.ShowSessionResourceGraphHandler(testGraph())
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resourcegraph.ShowSessionResourceGraphInput{
+		ResourceId: testutils.Pointer(uuid.MustParse(testOtherUserId)),
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "has not been referenced by a tool call in this session")
+}
+
+func TestShowSessionResourceGraphHandler_OverMcp(t *testing.T) {
+	server := mcptestutils.TestMcpServer(t)
+	resourcegraph.RegisterShowSessionResourceGraphTool(server, testGraph())
+
+	result, err := mcptestutils.CallToolOverMcp(t, server, resourcegraph.ShowSessionResourceGraphDef.McpTool.Name, map[string]any{
+		"resourceId": testPopulationId,
+	})
+	testutils.AssertMcpCallSuccess(t, err, result)
+
+	var output resourcegraph.ShowSessionResourceGraphOutput
+	textContent, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &output))
+	assert.ElementsMatch(t, []string{testUserId, testPopulationId}, nodeIds(output.Nodes))
+	require.Len(t, output.Edges, 1)
+	assert.Equal(t, "population", output.Edges[0].Relation)
+}
@@ -0,0 +1,22 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type ResourcesClient interface {
+	GetResources(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	CreateResource(ctx context.Context, environmentId uuid.UUID, createRequest management.Resource) (*management.Resource, *http.Response, error)
+	GetResourceScopes(ctx context.Context, environmentId uuid.UUID, resourceId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	CreateResourceScope(ctx context.Context, environmentId uuid.UUID, resourceId uuid.UUID, createRequest management.ResourceScope) (*management.ResourceScope, *http.Response, error)
+}
+
+type ResourcesClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (ResourcesClient, error)
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ ResourcesClient = &PingOneClientResourcesWrapper{}
+var _ ResourcesClientFactory = &PingOneClientResourcesWrapperFactory{}
+
+type PingOneClientResourcesWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientResourcesWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientResourcesWrapper(client *pingone.Client) *PingOneClientResourcesWrapper {
+	return &PingOneClientResourcesWrapper{client: client}
+}
+
+func NewPingOneClientResourcesWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientResourcesWrapperFactory {
+	return &PingOneClientResourcesWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientResourcesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (ResourcesClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientResourcesWrapper(client), nil
+}
+
+func (p *PingOneClientResourcesWrapper) GetResources(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.ResourcesApi.ReadAllResources(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve resources",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientResourcesWrapper) CreateResource(ctx context.Context, environmentId uuid.UUID, createRequest management.Resource) (*management.Resource, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.ResourcesApi.CreateResource(ctx, environmentId.String()).Resource(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create resource",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("name", createRequest.Name),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientResourcesWrapper) GetResourceScopes(ctx context.Context, environmentId uuid.UUID, resourceId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.ResourceScopesApi.ReadAllResourceScopes(ctx, environmentId.String(), resourceId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve resource scopes",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("resourceId", resourceId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientResourcesWrapper) CreateResourceScope(ctx context.Context, environmentId uuid.UUID, resourceId uuid.UUID, createRequest management.ResourceScope) (*management.ResourceScope, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.ResourceScopesApi.CreateResourceScope(ctx, environmentId.String(), resourceId.String()).ResourceScope(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create resource scope",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("resourceId", resourceId.String()),
+		slog.String("name", createRequest.Name),
+	)
+	return postRequest.Execute()
+}
@@ -0,0 +1,69 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "resources"
+
+var _ collections.LegacySdkCollection = &ResourcesCollection{}
+
+type ResourcesCollection struct{}
+
+func (c *ResourcesCollection) Name() string {
+	return CollectionName
+}
+
+func (c *ResourcesCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	resourcesClientFactory := NewPingOneClientResourcesWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListResourcesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListResourcesDef.McpTool.Name))
+		mcp.AddTool(server, ListResourcesDef.McpTool, ListResourcesHandler(resourcesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateResourceDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateResourceDef.McpTool.Name))
+		mcp.AddTool(server, CreateResourceDef.McpTool, CreateResourceHandler(resourcesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListResourceScopesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListResourceScopesDef.McpTool.Name))
+		mcp.AddTool(server, ListResourceScopesDef.McpTool, ListResourceScopesHandler(resourcesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CreateResourceScopeDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CreateResourceScopeDef.McpTool.Name))
+		mcp.AddTool(server, CreateResourceScopeDef.McpTool, CreateResourceScopeHandler(resourcesClientFactory))
+	}
+
+	return nil
+}
+
+func (c *ResourcesCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListResourcesDef,
+		CreateResourceDef,
+		ListResourceScopesDef,
+		CreateResourceScopeDef,
+	}
+}
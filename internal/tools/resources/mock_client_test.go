@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resources"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ resources.ResourcesClient = &mockPingOneClientResourcesWrapper{}
+var _ resources.ResourcesClientFactory = &mockPingOneClientResourcesWrapperFactory{}
+
+type mockPingOneClientResourcesWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientResourcesWrapperFactory struct {
+	mockClient resources.ResourcesClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientResourcesWrapperFactory(mockClient resources.ResourcesClient, err error) *mockPingOneClientResourcesWrapperFactory {
+	return &mockPingOneClientResourcesWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientResourcesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (resources.ResourcesClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientResourcesWrapper) GetResources(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientResourcesWrapper) CreateResource(ctx context.Context, environmentId uuid.UUID, createRequest management.Resource) (*management.Resource, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	response, ok := args.Get(0).(*management.Resource)
+	if !ok && args.Get(0) != nil {
+		panic("CreateResource mock setup error: expected *management.Resource or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("CreateResource mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientResourcesWrapper) GetResourceScopes(ctx context.Context, environmentId uuid.UUID, resourceId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, resourceId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientResourcesWrapper) CreateResourceScope(ctx context.Context, environmentId uuid.UUID, resourceId uuid.UUID, createRequest management.ResourceScope) (*management.ResourceScope, *http.Response, error) {
+	args := p.Called(ctx, environmentId, resourceId, createRequest)
+	response, ok := args.Get(0).(*management.ResourceScope)
+	if !ok && args.Get(0) != nil {
+		panic("CreateResourceScope mock setup error: expected *management.ResourceScope or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("CreateResourceScope mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
@@ -0,0 +1,60 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"time"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+const (
+	// minAccessTokenValiditySeconds and maxAccessTokenValiditySeconds bound the lifetime PingOne accepts for
+	// access tokens issued for a resource
+	minAccessTokenValiditySeconds = 300
+	maxAccessTokenValiditySeconds = 2592000
+)
+
+// Resource is a resource server as returned by the tools. The client secret of a resource, used to introspect
+// its access tokens, is not returned.
+type Resource struct {
+	Id                           string                                               `json:"id" jsonschema:"The UUID of the resource"`
+	Name                         string                                               `json:"name" jsonschema:"The name of the resource"`
+	Type                         *management.EnumResourceType                         `json:"type,omitempty" jsonschema:"The type of the resource: OPENID_CONNECT and PINGONE_API for the resources PingOne provides, or CUSTOM"`
+	Description                  *string                                              `json:"description,omitempty" jsonschema:"The description of the resource"`
+	Audience                     *string                                              `json:"audience,omitempty" jsonschema:"The audience of access tokens issued for the resource"`
+	AccessTokenValiditySeconds   *int32                                               `json:"accessTokenValiditySeconds,omitempty" jsonschema:"How long access tokens issued for the resource are valid, in seconds"`
+	IntrospectEndpointAuthMethod *management.EnumResourceIntrospectEndpointAuthMethod `json:"introspectEndpointAuthMethod,omitempty" jsonschema:"How the resource authenticates to the token introspection endpoint: NONE, CLIENT_SECRET_BASIC or CLIENT_SECRET_POST"`
+	CreatedAt                    *time.Time                                           `json:"createdAt,omitempty" jsonschema:"The creation timestamp of the resource"`
+}
+
+type Scope struct {
+	Id               string   `json:"id" jsonschema:"The UUID of the scope"`
+	Name             string   `json:"name" jsonschema:"The name of the scope, as requested by applications"`
+	Description      *string  `json:"description,omitempty" jsonschema:"The description of the scope"`
+	SchemaAttributes []string `json:"schemaAttributes,omitempty" jsonschema:"The user attributes the scope grants access to, for scopes of the PingOne API resource"`
+	MappedClaims     []string `json:"mappedClaims,omitempty" jsonschema:"The IDs of the resource attributes included as claims in tokens, for scopes of the OpenID Connect resource"`
+}
+
+func resourceOutput(resource management.Resource) Resource {
+	return Resource{
+		Id:                           resource.GetId(),
+		Name:                         resource.Name,
+		Type:                         resource.Type,
+		Description:                  resource.Description,
+		Audience:                     resource.Audience,
+		AccessTokenValiditySeconds:   resource.AccessTokenValiditySeconds,
+		IntrospectEndpointAuthMethod: resource.IntrospectEndpointAuthMethod,
+		CreatedAt:                    resource.CreatedAt,
+	}
+}
+
+func scopeOutput(scope management.ResourceScope) Scope {
+	return Scope{
+		Id:               scope.GetId(),
+		Name:             scope.Name,
+		Description:      scope.Description,
+		SchemaAttributes: scope.SchemaAttributes,
+		MappedClaims:     scope.MappedClaims,
+	}
+}
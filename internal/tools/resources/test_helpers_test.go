@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testResourceId    = uuid.MustParse("550e8400-e29b-41d4-a716-446655442000")
+	testOidcId        = uuid.MustParse("550e8400-e29b-41d4-a716-446655442001")
+	testScopeId       = uuid.MustParse("550e8400-e29b-41d4-a716-446655442100")
+)
+
+// customResource returns a new custom resource as returned by the API, with its client secret
+func customResource() *management.Resource {
+	return &management.Resource{
+		Id:                           testutils.Pointer(testResourceId.String()),
+		Name:                         "Orders API",
+		Type:                         management.ENUMRESOURCETYPE_CUSTOM.Ptr(),
+		Description:                  testutils.Pointer("Order management API"),
+		Audience:                     testutils.Pointer("https://api.example.com/orders"),
+		AccessTokenValiditySeconds:   testutils.Pointer(int32(3600)),
+		IntrospectEndpointAuthMethod: management.ENUMRESOURCEINTROSPECTENDPOINTAUTHMETHOD_CLIENT_SECRET_BASIC.Ptr(),
+		ClientSecret:                 testutils.Pointer("resource-secret"),
+		CreatedAt:                    testutils.Pointer(time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)),
+	}
+}
+
+// openidResource returns the OpenID Connect resource PingOne provides
+func openidResource() *management.Resource {
+	return &management.Resource{
+		Id:   testutils.Pointer(testOidcId.String()),
+		Name: "openid",
+		Type: management.ENUMRESOURCETYPE_OPENID_CONNECT.Ptr(),
+	}
+}
+
+// testScope returns a new scope of the custom resource
+func testScope() *management.ResourceScope {
+	return &management.ResourceScope{
+		Id:          testutils.Pointer(testScopeId.String()),
+		Name:        "orders:read",
+		Description: testutils.Pointer("Read orders"),
+		Resource:    &management.ObjectResource{Id: testutils.Pointer(testResourceId.String())},
+	}
+}
+
+func resourcesPages(resources ...*management.Resource) management.EntityArrayPagedIterator {
+	inner := make([]management.EntityArrayEmbeddedResourcesInner, 0, len(resources))
+	for _, resource := range resources {
+		inner = append(inner, management.ResourceAsEntityArrayEmbeddedResourcesInner(resource))
+	}
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Resources: inner}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func scopesPages(scopes ...management.ResourceScope) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Scopes: scopes}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func errorPages(err error) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{HTTPResponse: &http.Response{StatusCode: 403}, Error: err},
+	})
+}
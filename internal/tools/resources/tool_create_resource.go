@@ -0,0 +1,108 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateResourceDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "create_resource",
+		Title:        "Create PingOne Custom Resource",
+		Description:  "Create a custom resource that models your own API as a resource server, so that applications can be granted access to it. Add the permissions of the API with 'create_resource_scope' after creating the resource.",
+		InputSchema:  schema.MustGenerateSchema[CreateResourceInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateResourceOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateResourceInput struct {
+	EnvironmentId                uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Name                         string    `json:"name" jsonschema:"REQUIRED. The name of the resource, unique within the environment."`
+	Description                  *string   `json:"description,omitempty" jsonschema:"OPTIONAL. The description of the resource."`
+	Audience                     *string   `json:"audience,omitempty" jsonschema:"OPTIONAL. The audience of access tokens issued for the resource, such as the base URL of the API."`
+	AccessTokenValiditySeconds   *int32    `json:"accessTokenValiditySeconds,omitempty" jsonschema:"OPTIONAL. How long access tokens issued for the resource are valid, in seconds, from 300 to 2592000. Defaults to 3600."`
+	IntrospectEndpointAuthMethod *string   `json:"introspectEndpointAuthMethod,omitempty" jsonschema:"OPTIONAL. How the resource authenticates to the token introspection endpoint: NONE, CLIENT_SECRET_BASIC or CLIENT_SECRET_POST."`
+}
+
+type CreateResourceOutput struct {
+	Resource Resource `json:"resource" jsonschema:"The created resource"`
+}
+
+// CreateResourceHandler creates a PingOne custom resource using the provided client
+func CreateResourceHandler(resourcesClientFactory ResourcesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateResourceInput,
+) (
+	*mcp.CallToolResult,
+	*CreateResourceOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateResourceInput) (*mcp.CallToolResult, *CreateResourceOutput, error) {
+		createRequest := management.Resource{
+			Name:        input.Name,
+			Type:        management.ENUMRESOURCETYPE_CUSTOM.Ptr(),
+			Description: input.Description,
+			Audience:    input.Audience,
+		}
+		if input.AccessTokenValiditySeconds != nil {
+			if *input.AccessTokenValiditySeconds < minAccessTokenValiditySeconds || *input.AccessTokenValiditySeconds > maxAccessTokenValiditySeconds {
+				toolErr := errs.NewToolError(CreateResourceDef.McpTool.Name, fmt.Errorf("accessTokenValiditySeconds must be between %d and %d", minAccessTokenValiditySeconds, maxAccessTokenValiditySeconds))
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			createRequest.AccessTokenValiditySeconds = input.AccessTokenValiditySeconds
+		}
+		if input.IntrospectEndpointAuthMethod != nil {
+			parsed, err := management.NewEnumResourceIntrospectEndpointAuthMethodFromValue(*input.IntrospectEndpointAuthMethod)
+			if err != nil {
+				toolErr := errs.NewToolError(CreateResourceDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			createRequest.IntrospectEndpointAuthMethod = parsed
+		}
+
+		client, err := resourcesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateResourceDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating resource",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("name", input.Name))
+
+		resource, httpResponse, err := client.CreateResource(ctx, input.EnvironmentId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if resource == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no resource data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &CreateResourceOutput{
+			Resource: resourceOutput(*resource),
+		}, nil
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CreateResourceScopeDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "create_resource_scope",
+		Title:        "Create PingOne Resource Scope",
+		Description:  "Create a scope of a resource, such as a permission of a custom API like 'orders:read', that applications can then be granted. Scope names are unique within the resource; use 'list_resource_scopes' to see the existing scopes.",
+		InputSchema:  schema.MustGenerateSchema[CreateResourceScopeInput](),
+		OutputSchema: schema.MustGenerateSchema[CreateResourceScopeOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type CreateResourceScopeInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ResourceId    uuid.UUID `json:"resourceId" jsonschema:"REQUIRED. Resource UUID."`
+	Name          string    `json:"name" jsonschema:"REQUIRED. The name of the scope, as requested by applications, such as orders:read. Must not contain spaces."`
+	Description   *string   `json:"description,omitempty" jsonschema:"OPTIONAL. The description of the scope."`
+}
+
+type CreateResourceScopeOutput struct {
+	Scope Scope `json:"scope" jsonschema:"The created scope"`
+}
+
+// CreateResourceScopeHandler creates a scope of a PingOne resource using the provided client
+func CreateResourceScopeHandler(resourcesClientFactory ResourcesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CreateResourceScopeInput,
+) (
+	*mcp.CallToolResult,
+	*CreateResourceScopeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateResourceScopeInput) (*mcp.CallToolResult, *CreateResourceScopeOutput, error) {
+		client, err := resourcesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CreateResourceScopeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Creating resource scope",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("resourceId", input.ResourceId.String()),
+			slog.String("name", input.Name))
+
+		createRequest := management.ResourceScope{
+			Name:        input.Name,
+			Description: input.Description,
+		}
+		scope, httpResponse, err := client.CreateResourceScope(ctx, input.EnvironmentId, input.ResourceId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if scope == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no scope data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		return nil, &CreateResourceScopeOutput{
+			Scope: scopeOutput(*scope),
+		}, nil
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateResourceScopeHandler(t *testing.T) {
+	mockClient := &mockPingOneClientResourcesWrapper{}
+	expectedRequest := management.ResourceScope{
+		Name:        "orders:read",
+		Description: testutils.Pointer("Read orders"),
+	}
+	mockClient.On("CreateResourceScope", mock.Anything, testEnvironmentId, testResourceId, expectedRequest).Return(testScope(), &http.Response{StatusCode: 201}, nil)
+
+	handler := resources.CreateResourceScopeHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.CreateResourceScopeInput{
+		EnvironmentId: testEnvironmentId,
+		ResourceId:    testResourceId,
+		Name:          "orders:read",
+		Description:   testutils.Pointer("Read orders"),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testScopeId.String(), output.Scope.Id)
+	assert.Equal(t, "orders:read", output.Scope.Name)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateResourceScopeHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientResourcesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("CreateResourceScope", mock.Anything, testEnvironmentId, testResourceId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("scope name must be unique"))
+			},
+			wantErrContains: "scope name must be unique",
+		},
+		{
+			name: "No scope in response",
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("CreateResourceScope", mock.Anything, testEnvironmentId, testResourceId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no scope data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientResourcesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := resources.CreateResourceScopeHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.CreateResourceScopeInput{
+				EnvironmentId: testEnvironmentId,
+				ResourceId:    testResourceId,
+				Name:          "orders:read",
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateResourceScopeHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := resources.CreateResourceScopeHandler(NewMockPingOneClientResourcesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.CreateResourceScopeInput{
+		EnvironmentId: testEnvironmentId,
+		ResourceId:    testResourceId,
+		Name:          "orders:read",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,144 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateResourceHandler(t *testing.T) {
+	mockClient := &mockPingOneClientResourcesWrapper{}
+	expectedRequest := management.Resource{
+		Name:                         "Orders API",
+		Type:                         management.ENUMRESOURCETYPE_CUSTOM.Ptr(),
+		Description:                  testutils.Pointer("Order management API"),
+		Audience:                     testutils.Pointer("https://api.example.com/orders"),
+		AccessTokenValiditySeconds:   testutils.Pointer(int32(3600)),
+		IntrospectEndpointAuthMethod: management.ENUMRESOURCEINTROSPECTENDPOINTAUTHMETHOD_CLIENT_SECRET_BASIC.Ptr(),
+	}
+	mockClient.On("CreateResource", mock.Anything, testEnvironmentId, expectedRequest).Return(customResource(), &http.Response{StatusCode: 201}, nil)
+
+	handler := resources.CreateResourceHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.CreateResourceInput{
+		EnvironmentId:                testEnvironmentId,
+		Name:                         "Orders API",
+		Description:                  testutils.Pointer("Order management API"),
+		Audience:                     testutils.Pointer("https://api.example.com/orders"),
+		AccessTokenValiditySeconds:   testutils.Pointer(int32(3600)),
+		IntrospectEndpointAuthMethod: testutils.Pointer("CLIENT_SECRET_BASIC"),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testResourceId.String(), output.Resource.Id)
+	assert.Equal(t, management.ENUMRESOURCETYPE_CUSTOM.Ptr(), output.Resource.Type)
+
+	outputJson, err := json.Marshal(output)
+	require.NoError(t, err)
+	assert.NotContains(t, string(outputJson), "resource-secret")
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateResourceHandler_NameOnly(t *testing.T) {
+	mockClient := &mockPingOneClientResourcesWrapper{}
+	expectedRequest := management.Resource{
+		Name: "Orders API",
+		Type: management.ENUMRESOURCETYPE_CUSTOM.Ptr(),
+	}
+	mockClient.On("CreateResource", mock.Anything, testEnvironmentId, expectedRequest).Return(customResource(), &http.Response{StatusCode: 201}, nil)
+
+	handler := resources.CreateResourceHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.CreateResourceInput{
+		EnvironmentId: testEnvironmentId,
+		Name:          "Orders API",
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCreateResourceHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           resources.CreateResourceInput
+		setupMock       func(mockClient *mockPingOneClientResourcesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Access token validity too short",
+			input: resources.CreateResourceInput{
+				EnvironmentId:              testEnvironmentId,
+				Name:                       "Orders API",
+				AccessTokenValiditySeconds: testutils.Pointer(int32(60)),
+			},
+			wantErrContains: "accessTokenValiditySeconds must be between 300 and 2592000",
+		},
+		{
+			name: "Invalid introspection auth method",
+			input: resources.CreateResourceInput{
+				EnvironmentId:                testEnvironmentId,
+				Name:                         "Orders API",
+				IntrospectEndpointAuthMethod: testutils.Pointer("PRIVATE_KEY_JWT"),
+			},
+			wantErrContains: "PRIVATE_KEY_JWT",
+		},
+		{
+			name: "API error",
+			input: resources.CreateResourceInput{
+				EnvironmentId: testEnvironmentId,
+				Name:          "Orders API",
+			},
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("CreateResource", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("name must be unique"))
+			},
+			wantErrContains: "name must be unique",
+		},
+		{
+			name: "No resource in response",
+			input: resources.CreateResourceInput{
+				EnvironmentId: testEnvironmentId,
+				Name:          "Orders API",
+			},
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("CreateResource", mock.Anything, testEnvironmentId, mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no resource data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientResourcesWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := resources.CreateResourceHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreateResourceHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := resources.CreateResourceHandler(NewMockPingOneClientResourcesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.CreateResourceInput{
+		EnvironmentId: testEnvironmentId,
+		Name:          "Orders API",
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
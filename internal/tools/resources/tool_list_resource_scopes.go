@@ -0,0 +1,89 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListResourceScopesDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_resource_scopes",
+		Title:        "List PingOne Resource Scopes",
+		Description:  "Lists the scopes of a resource, which applications request to be granted access to the resource. Use 'list_resources' first if you need to find the resource ID.",
+		InputSchema:  schema.MustGenerateSchema[ListResourceScopesInput](),
+		OutputSchema: schema.MustGenerateSchema[ListResourceScopesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListResourceScopesInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	ResourceId    uuid.UUID `json:"resourceId" jsonschema:"REQUIRED. Resource UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'scopes.id' and 'scopes.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListResourceScopesOutput struct {
+	Scopes []Scope `json:"scopes" jsonschema:"The scopes of the resource"`
+}
+
+// ListResourceScopesHandler lists the scopes of a PingOne resource using the provided client
+func ListResourceScopesHandler(resourcesClientFactory ResourcesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListResourceScopesInput,
+) (
+	*mcp.CallToolResult,
+	*ListResourceScopesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListResourceScopesInput) (*mcp.CallToolResult, *ListResourceScopesOutput, error) {
+		client, err := resourcesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListResourceScopesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing resource scopes",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("resourceId", input.ResourceId.String()))
+
+		pagedIterator, err := client.GetResourceScopes(ctx, input.EnvironmentId, input.ResourceId)
+		if err != nil {
+			toolErr := errs.NewToolError(ListResourceScopesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &ListResourceScopesOutput{
+			Scopes: []Scope{},
+		}
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			logger.FromContext(ctx).Debug("Retrieved resource scopes page", slog.Int("count", len(embedded.Scopes)))
+			for _, scope := range embedded.Scopes {
+				result.Scopes = append(result.Scopes, scopeOutput(scope))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListResourceScopesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientResourcesWrapper{}
+	mockClient.On("GetResourceScopes", mock.Anything, testEnvironmentId, testResourceId).Return(scopesPages(*testScope()), nil)
+
+	handler := resources.ListResourceScopesHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourceScopesInput{
+		EnvironmentId: testEnvironmentId,
+		ResourceId:    testResourceId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.Scopes, 1)
+	assert.Equal(t, resources.Scope{
+		Id:          testScopeId.String(),
+		Name:        "orders:read",
+		Description: testutils.Pointer("Read orders"),
+	}, output.Scopes[0])
+	mockClient.AssertExpectations(t)
+}
+
+func TestListResourceScopesHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientResourcesWrapper{}
+	mockClient.On("GetResourceScopes", mock.Anything, testEnvironmentId, testResourceId).Return(scopesPages(), nil)
+
+	handler := resources.ListResourceScopesHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourceScopesInput{
+		EnvironmentId: testEnvironmentId,
+		ResourceId:    testResourceId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Scopes)
+	assert.Empty(t, output.Scopes)
+}
+
+func TestListResourceScopesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientResourcesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("GetResourceScopes", mock.Anything, testEnvironmentId, testResourceId).Return(nil, errors.New("invalid resource"))
+			},
+			wantErrContains: "invalid resource",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("GetResourceScopes", mock.Anything, testEnvironmentId, testResourceId).Return(errorPages(errors.New("resource not found")), nil)
+			},
+			wantErrContains: "resource not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientResourcesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := resources.ListResourceScopesHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourceScopesInput{
+				EnvironmentId: testEnvironmentId,
+				ResourceId:    testResourceId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListResourceScopesHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := resources.ListResourceScopesHandler(NewMockPingOneClientResourcesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourceScopesInput{
+		EnvironmentId: testEnvironmentId,
+		ResourceId:    testResourceId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,90 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListResourcesDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_resources",
+		Title:        "List PingOne Resources",
+		Description:  "Lists the resources of an environment: the resource servers that applications can be granted access to, including the OpenID Connect and PingOne API resources PingOne provides and custom resources for your own APIs. Use to discover resource IDs before listing or creating their scopes.",
+		InputSchema:  schema.MustGenerateSchema[ListResourcesInput](),
+		OutputSchema: schema.MustGenerateSchema[ListResourcesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListResourcesInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'resources.id' and 'resources.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ListResourcesOutput struct {
+	Resources []Resource `json:"resources" jsonschema:"The resources of the environment"`
+}
+
+// ListResourcesHandler lists the PingOne resources of an environment using the provided client
+func ListResourcesHandler(resourcesClientFactory ResourcesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListResourcesInput,
+) (
+	*mcp.CallToolResult,
+	*ListResourcesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListResourcesInput) (*mcp.CallToolResult, *ListResourcesOutput, error) {
+		client, err := resourcesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListResourcesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Listing resources", slog.String("environmentId", input.EnvironmentId.String()))
+
+		pagedIterator, err := client.GetResources(ctx, input.EnvironmentId)
+		if err != nil {
+			toolErr := errs.NewToolError(ListResourcesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &ListResourcesOutput{
+			Resources: []Resource{},
+		}
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			logger.FromContext(ctx).Debug("Retrieved resources page", slog.Int("count", len(embedded.Resources)))
+			for _, resource := range embedded.Resources {
+				// The resources endpoint returns resources only, application resources are listed per resource
+				if resource.Resource == nil {
+					continue
+				}
+				result.Resources = append(result.Resources, resourceOutput(*resource.Resource))
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListResourcesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientResourcesWrapper{}
+	mockClient.On("GetResources", mock.Anything, testEnvironmentId).Return(resourcesPages(openidResource(), customResource()), nil)
+
+	handler := resources.ListResourcesHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourcesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.Resources, 2)
+	assert.Equal(t, testOidcId.String(), output.Resources[0].Id)
+	assert.Equal(t, management.ENUMRESOURCETYPE_OPENID_CONNECT.Ptr(), output.Resources[0].Type)
+	assert.Equal(t, testResourceId.String(), output.Resources[1].Id)
+	assert.Equal(t, "Orders API", output.Resources[1].Name)
+	assert.Equal(t, testutils.Pointer("https://api.example.com/orders"), output.Resources[1].Audience)
+
+	// The client secret of the custom resource is not returned
+	outputJson, err := json.Marshal(output)
+	require.NoError(t, err)
+	assert.NotContains(t, string(outputJson), "resource-secret")
+	mockClient.AssertExpectations(t)
+}
+
+func TestListResourcesHandler_Empty(t *testing.T) {
+	mockClient := &mockPingOneClientResourcesWrapper{}
+	mockClient.On("GetResources", mock.Anything, testEnvironmentId).Return(resourcesPages(), nil)
+
+	handler := resources.ListResourcesHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourcesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.NotNil(t, output.Resources)
+	assert.Empty(t, output.Resources)
+}
+
+func TestListResourcesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientResourcesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Iterator error",
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("GetResources", mock.Anything, testEnvironmentId).Return(nil, errors.New("invalid environment"))
+			},
+			wantErrContains: "invalid environment",
+		},
+		{
+			name: "Page error",
+			setupMock: func(mockClient *mockPingOneClientResourcesWrapper) {
+				mockClient.On("GetResources", mock.Anything, testEnvironmentId).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientResourcesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := resources.ListResourcesHandler(NewMockPingOneClientResourcesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourcesInput{
+				EnvironmentId: testEnvironmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListResourcesHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := resources.ListResourcesHandler(NewMockPingOneClientResourcesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, resources.ListResourcesInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
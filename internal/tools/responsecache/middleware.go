@@ -0,0 +1,206 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package responsecache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	environmentIdArgument = "environmentId"
+	// fieldsArgument is excluded from cache keys, as fields are selected from the full result after it is cached
+	fieldsArgument = "fields"
+)
+
+// StatsRecorder records whether tool calls were answered from the cache.
+type StatsRecorder interface {
+	RecordCacheHit(toolName string)
+	RecordCacheMiss(toolName string)
+}
+
+// ResponseCacheMiddleware answers calls of read-only tools from a ResponseCache, keyed by the tool name and
+// its arguments, and invalidates the cache when write tools are called.
+//
+// A call of a write tool invalidates the cached results for the environment it is made for, as well as results
+// of calls made without an environment. Write tools called without an environment invalidate all results.
+// Only successful results are cached, and results that link to paged list result resources are not cached,
+// as the resources may be evicted before the cached result expires.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after any middleware that
+// authenticates or validates calls, so that cached results are only returned to calls that are allowed.
+// Read-only tools that set DisableResponseCache are never cached.
+type ResponseCacheMiddleware struct {
+	cache     *ResponseCache
+	cacheable map[string]bool
+	writes    map[string]bool
+	clears    map[string]bool
+	recorder  StatsRecorder
+}
+
+// NewResponseCacheMiddleware creates middleware that caches the results of the tools in the list. A nil cache
+// disables caching, and a nil recorder records nothing.
+func NewResponseCacheMiddleware(cache *ResponseCache, tools []types.ToolDefinition, recorder StatsRecorder) *ResponseCacheMiddleware {
+	m := &ResponseCacheMiddleware{
+		cache:     cache,
+		cacheable: make(map[string]bool),
+		writes:    make(map[string]bool),
+		clears:    make(map[string]bool),
+		recorder:  recorder,
+	}
+	for _, tool := range tools {
+		if tool.McpTool == nil {
+			continue
+		}
+		if !tool.IsReadOnly() {
+			m.writes[tool.McpTool.Name] = true
+		} else if !tool.DisableResponseCache {
+			m.cacheable[tool.McpTool.Name] = true
+		}
+	}
+	return m
+}
+
+// ClearOnTools clears the whole cache after calls of the named tools, such as tools that change the
+// logged in user, whose permissions may differ from the user the results were cached for.
+func (m *ResponseCacheMiddleware) ClearOnTools(toolNames ...string) {
+	for _, toolName := range toolNames {
+		m.clears[toolName] = true
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ResponseCacheMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.cache == nil {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		toolName := callToolReq.Params.Name
+		switch {
+		case m.clears[toolName]:
+			defer m.cache.Clear()
+			return next(ctx, method, req)
+		case m.writes[toolName]:
+			return m.invalidateAfter(ctx, next, method, callToolReq)
+		case m.cacheable[toolName]:
+			return m.cached(ctx, next, method, callToolReq)
+		default:
+			return next(ctx, method, req)
+		}
+	}
+}
+
+func (m *ResponseCacheMiddleware) invalidateAfter(ctx context.Context, next mcp.MethodHandler, method string, req *mcp.CallToolRequest) (mcp.Result, error) {
+	// Invalidate even if the call fails, as a failed write may still have changed some resources
+	defer func() {
+		environmentId := ""
+		if args, ok := parseArguments(req.Params.Arguments); ok {
+			environmentId = environmentIdFromArguments(args)
+		}
+		m.cache.InvalidateEnvironment(environmentId)
+		logger.FromContext(ctx).Debug("Invalidated cached tool results",
+			slog.String("tool", req.Params.Name),
+			slog.String("environmentId", environmentId))
+	}()
+	return next(ctx, method, req)
+}
+
+func (m *ResponseCacheMiddleware) cached(ctx context.Context, next mcp.MethodHandler, method string, req *mcp.CallToolRequest) (mcp.Result, error) {
+	toolName := req.Params.Name
+	args, ok := parseArguments(req.Params.Arguments)
+	if !ok {
+		// Arguments that are not a JSON object are left for the tool to reject
+		return next(ctx, method, req)
+	}
+	environmentId := environmentIdFromArguments(args)
+	delete(args, fieldsArgument)
+	keyJSON, err := json.Marshal(args)
+	if err != nil {
+		return next(ctx, method, req)
+	}
+	key := toolName + "\x00" + string(keyJSON)
+
+	cachedResult, err := m.cache.get(key)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to read cached tool result",
+			slog.String("tool", toolName),
+			slog.String("error", err.Error()))
+	}
+	if cachedResult != nil {
+		m.recordHit(toolName)
+		logger.FromContext(ctx).Debug("Returned cached tool result", slog.String("tool", toolName))
+		return cachedResult, nil
+	}
+	m.recordMiss(toolName)
+
+	generation := m.cache.currentGeneration()
+	result, err := next(ctx, method, req)
+	if err != nil {
+		return result, err
+	}
+	callToolResult, ok := result.(*mcp.CallToolResult)
+	if !ok || callToolResult == nil || callToolResult.IsError || hasResourceLink(callToolResult) {
+		return result, err
+	}
+
+	if err := m.cache.put(key, environmentId, callToolResult, generation); err != nil {
+		logger.FromContext(ctx).Error("Failed to cache tool result",
+			slog.String("tool", toolName),
+			slog.String("error", err.Error()))
+	}
+	return result, nil
+}
+
+func (m *ResponseCacheMiddleware) recordHit(toolName string) {
+	if m.recorder != nil {
+		m.recorder.RecordCacheHit(toolName)
+	}
+}
+
+func (m *ResponseCacheMiddleware) recordMiss(toolName string) {
+	if m.recorder != nil {
+		m.recorder.RecordCacheMiss(toolName)
+	}
+}
+
+// parseArguments returns the tool call arguments as a map, which marshals with sorted keys so that
+// equivalent arguments produce the same cache key.
+func parseArguments(argsJSON json.RawMessage) (map[string]any, bool) {
+	if len(argsJSON) == 0 {
+		return map[string]any{}, true
+	}
+	var args map[string]any
+	if err := json.Unmarshal(argsJSON, &args); err != nil {
+		return nil, false
+	}
+	if args == nil {
+		args = map[string]any{}
+	}
+	return args, true
+}
+
+func environmentIdFromArguments(args map[string]any) string {
+	environmentId, _ := args[environmentIdArgument].(string)
+	return strings.ToLower(environmentId)
+}
+
+func hasResourceLink(result *mcp.CallToolResult) bool {
+	for _, content := range result.Content {
+		if _, ok := content.(*mcp.ResourceLink); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,304 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package responsecache_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/responsecache"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEnvironmentId      = "3f0b8b7e-5d4c-4b1a-9d2e-1a2b3c4d5e6f"
+	otherTestEnvironmentId = "8c1d2e3f-4a5b-4c6d-8e7f-9a0b1c2d3e4f"
+)
+
+type testThingInput struct {
+	EnvironmentId string   `json:"environmentId"`
+	Id            string   `json:"id"`
+	Fields        []string `json:"fields,omitempty"`
+}
+
+type testThingOutput struct {
+	Id    string `json:"id"`
+	Calls int    `json:"calls"`
+}
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "get_thing", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}},
+	{McpTool: &mcp.Tool{Name: "list_things", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}},
+	{McpTool: &mcp.Tool{Name: "query_events", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, DisableResponseCache: true},
+	{McpTool: &mcp.Tool{Name: "update_thing"}},
+	{McpTool: &mcp.Tool{Name: "create_environment"}},
+}
+
+// countingTool answers every call with the number of calls it has received, so that cached results can be recognized
+type countingTool struct {
+	calls  int
+	result func(calls int) (mcp.Result, error)
+}
+
+func (c *countingTool) next(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+	c.calls++
+	if c.result != nil {
+		return c.result(c.calls)
+	}
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: "call"}},
+		StructuredContent: map[string]any{
+			"calls": c.calls,
+		},
+	}, nil
+}
+
+type testStatsRecorder struct {
+	hits   map[string]int
+	misses map[string]int
+}
+
+func newTestStatsRecorder() *testStatsRecorder {
+	return &testStatsRecorder{hits: map[string]int{}, misses: map[string]int{}}
+}
+
+func (r *testStatsRecorder) RecordCacheHit(toolName string)  { r.hits[toolName]++ }
+func (r *testStatsRecorder) RecordCacheMiss(toolName string) { r.misses[toolName]++ }
+
+func callTool(t *testing.T, handler mcp.MethodHandler, toolName string, args string) *mcp.CallToolResult {
+	t.Helper()
+	result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: toolName, Arguments: json.RawMessage(args)},
+	})
+	require.NoError(t, err)
+	callToolResult, ok := result.(*mcp.CallToolResult)
+	require.True(t, ok)
+	return callToolResult
+}
+
+func callCount(t *testing.T, result *mcp.CallToolResult) int {
+	t.Helper()
+	structuredJSON, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var output testThingOutput
+	require.NoError(t, json.Unmarshal(structuredJSON, &output))
+	return output.Calls
+}
+
+func newCachingHandler(tool *countingTool, recorder responsecache.StatsRecorder) mcp.MethodHandler {
+	cache := responsecache.NewResponseCache(time.Hour, responsecache.DefaultMaxCachedResponses)
+	return responsecache.NewResponseCacheMiddleware(cache, testToolDefs, recorder).Handler(tool.next)
+}
+
+func TestResponseCacheMiddleware_CachesReadOnlyTools(t *testing.T) {
+	tool := &countingTool{}
+	recorder := newTestStatsRecorder()
+	handler := newCachingHandler(tool, recorder)
+
+	first := callTool(t, handler, "get_thing", `{"environmentId":"`+testEnvironmentId+`","id":"thing-1"}`)
+	// Equivalent arguments in a different order, with fields selected, are the same call
+	second := callTool(t, handler, "get_thing", `{"id":"thing-1","environmentId":"`+testEnvironmentId+`","fields":["id"]}`)
+	other := callTool(t, handler, "get_thing", `{"environmentId":"`+testEnvironmentId+`","id":"thing-2"}`)
+
+	assert.Equal(t, 1, callCount(t, first))
+	assert.Equal(t, 1, callCount(t, second))
+	assert.Equal(t, 2, callCount(t, other))
+	assert.Equal(t, 2, tool.calls)
+	assert.Equal(t, 1, recorder.hits["get_thing"])
+	assert.Equal(t, 2, recorder.misses["get_thing"])
+}
+
+func TestResponseCacheMiddleware_HitsAreIndependentCopies(t *testing.T) {
+	tool := &countingTool{}
+	handler := newCachingHandler(tool, nil)
+
+	first := callTool(t, handler, "list_things", `{}`)
+	// Later middleware such as field selection modify results in place
+	first.Content[0].(*mcp.TextContent).Text = "modified"
+	first.StructuredContent = nil
+
+	second := callTool(t, handler, "list_things", `{}`)
+
+	assert.Equal(t, "call", second.Content[0].(*mcp.TextContent).Text)
+	assert.Equal(t, 1, callCount(t, second))
+	assert.Equal(t, 1, tool.calls)
+}
+
+func TestResponseCacheMiddleware_UncachedTools(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolName string
+	}{
+		{name: "read-only tool with cache disabled", toolName: "query_events"},
+		{name: "write tool", toolName: "update_thing"},
+		{name: "unknown tool", toolName: "whoami"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &countingTool{}
+			recorder := newTestStatsRecorder()
+			handler := newCachingHandler(tool, recorder)
+
+			callTool(t, handler, tt.toolName, `{"environmentId":"`+testEnvironmentId+`"}`)
+			callTool(t, handler, tt.toolName, `{"environmentId":"`+testEnvironmentId+`"}`)
+
+			assert.Equal(t, 2, tool.calls)
+			assert.Empty(t, recorder.hits)
+			assert.Empty(t, recorder.misses)
+		})
+	}
+}
+
+func TestResponseCacheMiddleware_UncachedResults(t *testing.T) {
+	tests := []struct {
+		name   string
+		result func(calls int) (mcp.Result, error)
+	}{
+		{
+			name: "error result",
+			result: func(calls int) (mcp.Result, error) {
+				return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "not found"}}}, nil
+			},
+		},
+		{
+			name: "paged result",
+			result: func(calls int) (mcp.Result, error) {
+				return &mcp.CallToolResult{Content: []mcp.Content{
+					&mcp.TextContent{Text: "{}"},
+					&mcp.ResourceLink{URI: "pingone://results/1?page=1", Name: "list_things"},
+				}}, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &countingTool{result: tt.result}
+			handler := newCachingHandler(tool, nil)
+
+			callTool(t, handler, "list_things", `{}`)
+			callTool(t, handler, "list_things", `{}`)
+
+			assert.Equal(t, 2, tool.calls)
+		})
+	}
+}
+
+func TestResponseCacheMiddleware_WriteToolsInvalidate(t *testing.T) {
+	tests := []struct {
+		name               string
+		writeTool          string
+		writeArgs          string
+		expectSameEnvCall  int
+		expectOtherEnvCall int
+		expectNoEnvCall    int
+	}{
+		{
+			name:               "write to an environment invalidates that environment and calls without an environment",
+			writeTool:          "update_thing",
+			writeArgs:          `{"environmentId":"` + testEnvironmentId + `"}`,
+			expectSameEnvCall:  5,
+			expectOtherEnvCall: 2,
+			expectNoEnvCall:    6,
+		},
+		{
+			name:               "environment IDs are matched case-insensitively",
+			writeTool:          "update_thing",
+			writeArgs:          `{"environmentId":"3F0B8B7E-5D4C-4B1A-9D2E-1A2B3C4D5E6F"}`,
+			expectSameEnvCall:  5,
+			expectOtherEnvCall: 2,
+			expectNoEnvCall:    6,
+		},
+		{
+			name:               "write without an environment invalidates everything",
+			writeTool:          "create_environment",
+			writeArgs:          `{"name":"New"}`,
+			expectSameEnvCall:  5,
+			expectOtherEnvCall: 6,
+			expectNoEnvCall:    7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := &countingTool{}
+			handler := newCachingHandler(tool, nil)
+			sameEnv := `{"environmentId":"` + testEnvironmentId + `","id":"thing-1"}`
+			otherEnv := `{"environmentId":"` + otherTestEnvironmentId + `","id":"thing-1"}`
+			noEnv := `{}`
+
+			callTool(t, handler, "get_thing", sameEnv)       // call 1
+			callTool(t, handler, "get_thing", otherEnv)      // call 2
+			callTool(t, handler, "list_things", noEnv)       // call 3
+			callTool(t, handler, tt.writeTool, tt.writeArgs) // call 4
+
+			// Invalidated results are fetched again in order, so their call counts follow the write
+			assert.Equal(t, tt.expectSameEnvCall, callCount(t, callTool(t, handler, "get_thing", sameEnv)))
+			assert.Equal(t, tt.expectOtherEnvCall, callCount(t, callTool(t, handler, "get_thing", otherEnv)))
+			assert.Equal(t, tt.expectNoEnvCall, callCount(t, callTool(t, handler, "list_things", noEnv)))
+		})
+	}
+}
+
+func TestResponseCacheMiddleware_ClearOnTools(t *testing.T) {
+	tool := &countingTool{}
+	cache := responsecache.NewResponseCache(time.Hour, responsecache.DefaultMaxCachedResponses)
+	middleware := responsecache.NewResponseCacheMiddleware(cache, testToolDefs, nil)
+	middleware.ClearOnTools("logout")
+	handler := middleware.Handler(tool.next)
+
+	callTool(t, handler, "list_things", `{}`)
+	require.Equal(t, 1, cache.Len())
+
+	callTool(t, handler, "logout", `{}`)
+
+	assert.Zero(t, cache.Len())
+	assert.Equal(t, 3, callCount(t, callTool(t, handler, "list_things", `{}`)))
+}
+
+func TestResponseCacheMiddleware_NilCache(t *testing.T) {
+	tool := &countingTool{}
+	handler := responsecache.NewResponseCacheMiddleware(nil, testToolDefs, nil).Handler(tool.next)
+
+	callTool(t, handler, "list_things", `{}`)
+	callTool(t, handler, "list_things", `{}`)
+
+	assert.Equal(t, 2, tool.calls)
+}
+
+func TestResponseCacheMiddleware_OverMcp(t *testing.T) {
+	toolDef := types.ToolDefinition{
+		McpTool: &mcp.Tool{
+			Name:         "get_thing",
+			InputSchema:  schema.MustGenerateSchema[testThingInput](),
+			OutputSchema: schema.MustGenerateSchema[testThingOutput](),
+			Annotations:  &mcp.ToolAnnotations{ReadOnlyHint: true},
+		},
+	}
+	cache := responsecache.NewResponseCache(time.Hour, responsecache.DefaultMaxCachedResponses)
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(responsecache.NewResponseCacheMiddleware(cache, []types.ToolDefinition{toolDef}, nil).Handler)
+	calls := 0
+	mcp.AddTool(server, toolDef.McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input testThingInput) (*mcp.CallToolResult, *testThingOutput, error) {
+		calls++
+		return nil, &testThingOutput{Id: input.Id, Calls: calls}, nil
+	})
+
+	input := testThingInput{EnvironmentId: testEnvironmentId, Id: "thing-1"}
+	first, err := mcptestutils.CallToolOverMcp(t, server, "get_thing", input)
+	require.NoError(t, err)
+	second, err := mcptestutils.CallToolOverMcp(t, server, "get_thing", input)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+	assert.False(t, second.IsError)
+	require.NotEmpty(t, second.Content)
+	assert.JSONEq(t, first.Content[0].(*mcp.TextContent).Text, second.Content[0].(*mcp.TextContent).Text)
+	assert.Equal(t, 1, callCount(t, second))
+}
@@ -0,0 +1,156 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package responsecache
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DefaultMaxCachedResponses is the default number of tool results retained before the oldest is evicted.
+const DefaultMaxCachedResponses = 200
+
+// ResponseCache holds the results of read-only tool calls for a limited time, so that repeated calls with the
+// same arguments within a conversation are answered without calling the PingOne API again.
+//
+// Results are held in memory. The number of cached results is bounded, and the oldest result is evicted when
+// the bound is reached.
+type ResponseCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+	entries    map[string]cacheEntry
+	order      []string
+	// generation is incremented by every invalidation, so that results fetched while a write tool ran are not cached
+	generation uint64
+}
+
+type cacheEntry struct {
+	environmentId string
+	result        []byte
+	expiresAt     time.Time
+}
+
+// NewResponseCache creates a cache that retains results for ttl and holds at most maxEntries results.
+func NewResponseCache(ttl time.Duration, maxEntries int) *ResponseCache {
+	return &ResponseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]cacheEntry),
+	}
+}
+
+// TTL returns how long results are retained.
+func (c *ResponseCache) TTL() time.Duration {
+	return c.ttl
+}
+
+// Len returns the number of cached results, including expired results not yet evicted.
+func (c *ResponseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Clear removes all cached results.
+func (c *ResponseCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.entries)
+	c.order = nil
+	c.generation++
+}
+
+// InvalidateEnvironment removes the cached results of calls made for the environment, and of calls made
+// without an environment, such as listing environments. An empty environment ID removes all results.
+func (c *ResponseCache) InvalidateEnvironment(environmentId string) {
+	if environmentId == "" {
+		c.Clear()
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	order := c.order[:0]
+	for _, key := range c.order {
+		entry := c.entries[key]
+		if entry.environmentId == "" || entry.environmentId == environmentId {
+			delete(c.entries, key)
+			continue
+		}
+		order = append(order, key)
+	}
+	c.order = order
+	c.generation++
+}
+
+// get returns a copy of the cached result for the key, or nil if there is no unexpired result.
+func (c *ResponseCache) get(key string) (*mcp.CallToolResult, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, nil
+	}
+
+	// Later middleware modify results in place, so each hit is given its own copy
+	var result mcp.CallToolResult
+	if err := json.Unmarshal(entry.result, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cached result: %w", err)
+	}
+	return &result, nil
+}
+
+// currentGeneration returns the generation to pass to put for a result fetched from now on.
+func (c *ResponseCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// put caches the result for the key, unless the cache was invalidated since the generation was read.
+func (c *ResponseCache) put(key string, environmentId string, result *mcp.CallToolResult, generation uint64) error {
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal result: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return nil
+	}
+
+	if _, ok := c.entries[key]; ok {
+		c.removeFromOrder(key)
+	}
+	for len(c.order) >= c.maxEntries && len(c.order) > 0 {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = cacheEntry{
+		environmentId: environmentId,
+		result:        resultJSON,
+		expiresAt:     c.now().Add(c.ttl),
+	}
+	c.order = append(c.order, key)
+	return nil
+}
+
+func (c *ResponseCache) removeFromOrder(key string) {
+	for i, k := range c.order {
+		if k == key {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			return
+		}
+	}
+}
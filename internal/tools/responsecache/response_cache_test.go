@@ -0,0 +1,69 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package responsecache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: text}}}
+}
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	require.NotNil(t, result)
+	return result.Content[0].(*mcp.TextContent).Text
+}
+
+func TestResponseCache_Expiry(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewResponseCache(time.Minute, DefaultMaxCachedResponses)
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.put("key", "", testResult("cached"), cache.currentGeneration()))
+
+	now = now.Add(59 * time.Second)
+	result, err := cache.get("key")
+	require.NoError(t, err)
+	assert.Equal(t, "cached", resultText(t, result))
+
+	now = now.Add(time.Second)
+	result, err = cache.get("key")
+	require.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestResponseCache_EvictsOldest(t *testing.T) {
+	cache := NewResponseCache(time.Hour, 2)
+
+	require.NoError(t, cache.put("first", "", testResult("first"), cache.currentGeneration()))
+	require.NoError(t, cache.put("second", "", testResult("second"), cache.currentGeneration()))
+	// Replacing a result does not evict another
+	require.NoError(t, cache.put("first", "", testResult("first again"), cache.currentGeneration()))
+	require.NoError(t, cache.put("third", "", testResult("third"), cache.currentGeneration()))
+
+	assert.Equal(t, 2, cache.Len())
+	second, err := cache.get("second")
+	require.NoError(t, err)
+	assert.Nil(t, second)
+	first, err := cache.get("first")
+	require.NoError(t, err)
+	assert.Equal(t, "first again", resultText(t, first))
+}
+
+func TestResponseCache_DoesNotCacheResultsFetchedDuringInvalidation(t *testing.T) {
+	cache := NewResponseCache(time.Hour, DefaultMaxCachedResponses)
+
+	generation := cache.currentGeneration()
+	// A write tool completes while the read is in progress
+	cache.InvalidateEnvironment("env-1")
+	require.NoError(t, cache.put("key", "env-2", testResult("stale"), generation))
+
+	assert.Zero(t, cache.Len())
+}
@@ -0,0 +1,34 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type RolesClient interface {
+	GetRoles(ctx context.Context) (management.EntityArrayPagedIterator, error)
+
+	GetUserRoleAssignments(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error)
+	GetUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error)
+	CreateUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error)
+	DeleteUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*http.Response, error)
+
+	GetGroupRoleAssignments(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error)
+	GetGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error)
+	CreateGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error)
+	DeleteGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*http.Response, error)
+
+	GetApplicationRoleAssignments(ctx context.Context, environmentId uuid.UUID, applicationId string) (management.EntityArrayPagedIterator, error)
+	GetApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error)
+	CreateApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error)
+	DeleteApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, roleAssignmentId string) (*http.Response, error)
+}
+
+type RolesClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (RolesClient, error)
+}
@@ -0,0 +1,244 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ RolesClient = &PingOneClientRolesWrapper{}
+var _ RolesClientFactory = &PingOneClientRolesWrapperFactory{}
+
+type PingOneClientRolesWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientRolesWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientRolesWrapper(client *pingone.Client) *PingOneClientRolesWrapper {
+	return &PingOneClientRolesWrapper{client: client}
+}
+
+func NewPingOneClientRolesWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientRolesWrapperFactory {
+	return &PingOneClientRolesWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientRolesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (RolesClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientRolesWrapper(client), nil
+}
+
+func (p *PingOneClientRolesWrapper) GetRoles(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.RolesApi.ReadAllRoles(ctx)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve roles")
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientRolesWrapper) GetUserRoleAssignments(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UserRoleAssignmentsApi.ReadUserRoleAssignments(ctx, environmentId.String(), userId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve user role assignments",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientRolesWrapper) GetUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UserRoleAssignmentsApi.ReadOneUserRoleAssignment(ctx, environmentId.String(), userId, roleAssignmentId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve user role assignment by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) CreateUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.UserRoleAssignmentsApi.CreateUserRoleAssignment(ctx, environmentId.String(), userId).RoleAssignment(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create user role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+		slog.String("roleId", createRequest.Role.Id),
+		slog.String("scopeType", string(createRequest.Scope.Type)),
+		slog.String("scopeId", createRequest.Scope.Id),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) DeleteUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.UserRoleAssignmentsApi.DeleteUserRoleAssignment(ctx, environmentId.String(), userId, roleAssignmentId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete user role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return deleteRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) GetGroupRoleAssignments(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupRoleAssignmentsApi.ReadGroupRoleAssignments(ctx, environmentId.String(), groupId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve group role assignments",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientRolesWrapper) GetGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupRoleAssignmentsApi.ReadOneGroupRoleAssignment(ctx, environmentId.String(), groupId, roleAssignmentId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve group role assignment by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) CreateGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.GroupRoleAssignmentsApi.CreateGroupRoleAssignment(ctx, environmentId.String(), groupId).RoleAssignment(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create group role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+		slog.String("roleId", createRequest.Role.Id),
+		slog.String("scopeType", string(createRequest.Scope.Type)),
+		slog.String("scopeId", createRequest.Scope.Id),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) DeleteGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.GroupRoleAssignmentsApi.DeleteGroupRoleAssignment(ctx, environmentId.String(), groupId, roleAssignmentId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete group role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("groupId", groupId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return deleteRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) GetApplicationRoleAssignments(ctx context.Context, environmentId uuid.UUID, applicationId string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.ApplicationRoleAssignmentsApi.ReadApplicationRoleAssignments(ctx, environmentId.String(), applicationId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve application role assignments",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("applicationId", applicationId),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientRolesWrapper) GetApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.ApplicationRoleAssignmentsApi.ReadOneApplicationRoleAssignment(ctx, environmentId.String(), applicationId, roleAssignmentId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve application role assignment by ID",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("applicationId", applicationId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) CreateApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.ApplicationRoleAssignmentsApi.CreateApplicationRoleAssignment(ctx, environmentId.String(), applicationId).RoleAssignment(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create application role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("applicationId", applicationId),
+		slog.String("roleId", createRequest.Role.Id),
+		slog.String("scopeType", string(createRequest.Scope.Type)),
+		slog.String("scopeId", createRequest.Scope.Id),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientRolesWrapper) DeleteApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, roleAssignmentId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.ApplicationRoleAssignmentsApi.DeleteApplicationRoleAssignment(ctx, environmentId.String(), applicationId, roleAssignmentId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete application role assignment",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("applicationId", applicationId),
+		slog.String("roleAssignmentId", roleAssignmentId),
+	)
+	return deleteRequest.Execute()
+}
@@ -0,0 +1,93 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "roles"
+
+var _ collections.LegacySdkCollection = &RolesCollection{}
+
+type RolesCollection struct{}
+
+func (c *RolesCollection) Name() string {
+	return CollectionName
+}
+
+func (c *RolesCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	rolesClientFactory := NewPingOneClientRolesWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&ListRolesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListRolesDef.McpTool.Name))
+		mcp.AddTool(server, ListRolesDef.McpTool, ListRolesHandler(rolesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListUserRoleAssignmentsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListUserRoleAssignmentsDef.McpTool.Name))
+		mcp.AddTool(server, ListUserRoleAssignmentsDef.McpTool, ListUserRoleAssignmentsHandler(rolesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListGroupRoleAssignmentsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListGroupRoleAssignmentsDef.McpTool.Name))
+		mcp.AddTool(server, ListGroupRoleAssignmentsDef.McpTool, ListGroupRoleAssignmentsHandler(rolesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ListApplicationRoleAssignmentsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ListApplicationRoleAssignmentsDef.McpTool.Name))
+		mcp.AddTool(server, ListApplicationRoleAssignmentsDef.McpTool, ListApplicationRoleAssignmentsHandler(rolesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&AssignRoleToUserDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", AssignRoleToUserDef.McpTool.Name))
+		mcp.AddTool(server, AssignRoleToUserDef.McpTool, AssignRoleToUserHandler(rolesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&AssignRoleToGroupDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", AssignRoleToGroupDef.McpTool.Name))
+		mcp.AddTool(server, AssignRoleToGroupDef.McpTool, AssignRoleToGroupHandler(rolesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&AssignRoleToApplicationDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", AssignRoleToApplicationDef.McpTool.Name))
+		mcp.AddTool(server, AssignRoleToApplicationDef.McpTool, AssignRoleToApplicationHandler(rolesClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&RemoveRoleAssignmentDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", RemoveRoleAssignmentDef.McpTool.Name))
+		mcp.AddTool(server, RemoveRoleAssignmentDef.McpTool, RemoveRoleAssignmentHandler(rolesClientFactory))
+	}
+
+	return nil
+}
+
+func (c *RolesCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		ListRolesDef,
+		ListUserRoleAssignmentsDef,
+		ListGroupRoleAssignmentsDef,
+		ListApplicationRoleAssignmentsDef,
+		AssignRoleToUserDef,
+		AssignRoleToGroupDef,
+		AssignRoleToApplicationDef,
+		RemoveRoleAssignmentDef,
+	}
+}
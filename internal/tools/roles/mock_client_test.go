@@ -0,0 +1,142 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ roles.RolesClient = &mockPingOneClientRolesWrapper{}
+var _ roles.RolesClientFactory = &mockPingOneClientRolesWrapperFactory{}
+
+type mockPingOneClientRolesWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientRolesWrapperFactory struct {
+	mockClient roles.RolesClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientRolesWrapperFactory(mockClient roles.RolesClient, err error) *mockPingOneClientRolesWrapperFactory {
+	return &mockPingOneClientRolesWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientRolesWrapperFactory) GetAuthenticatedClient(ctx context.Context) (roles.RolesClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientRolesWrapper) GetRoles(ctx context.Context) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientRolesWrapper) GetUserRoleAssignments(ctx context.Context, environmentId uuid.UUID, userId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, userId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientRolesWrapper) GetUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, roleAssignmentId)
+	return roleAssignmentResponse("GetUserRoleAssignment", args)
+}
+
+func (p *mockPingOneClientRolesWrapper) CreateUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, createRequest)
+	return roleAssignmentResponse("CreateUserRoleAssignment", args)
+}
+
+func (p *mockPingOneClientRolesWrapper) DeleteUserRoleAssignment(ctx context.Context, environmentId uuid.UUID, userId string, roleAssignmentId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, roleAssignmentId)
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("DeleteUserRoleAssignment mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func (p *mockPingOneClientRolesWrapper) GetGroupRoleAssignments(ctx context.Context, environmentId uuid.UUID, groupId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, groupId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientRolesWrapper) GetGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId, roleAssignmentId)
+	return roleAssignmentResponse("GetGroupRoleAssignment", args)
+}
+
+func (p *mockPingOneClientRolesWrapper) CreateGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId, createRequest)
+	return roleAssignmentResponse("CreateGroupRoleAssignment", args)
+}
+
+func (p *mockPingOneClientRolesWrapper) DeleteGroupRoleAssignment(ctx context.Context, environmentId uuid.UUID, groupId string, roleAssignmentId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, groupId, roleAssignmentId)
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("DeleteGroupRoleAssignment mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func (p *mockPingOneClientRolesWrapper) GetApplicationRoleAssignments(ctx context.Context, environmentId uuid.UUID, applicationId string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, applicationId)
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientRolesWrapper) GetApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error) {
+	args := p.Called(ctx, environmentId, applicationId, roleAssignmentId)
+	return roleAssignmentResponse("GetApplicationRoleAssignment", args)
+}
+
+func (p *mockPingOneClientRolesWrapper) CreateApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error) {
+	args := p.Called(ctx, environmentId, applicationId, createRequest)
+	return roleAssignmentResponse("CreateApplicationRoleAssignment", args)
+}
+
+func (p *mockPingOneClientRolesWrapper) DeleteApplicationRoleAssignment(ctx context.Context, environmentId uuid.UUID, applicationId string, roleAssignmentId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, applicationId, roleAssignmentId)
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("DeleteApplicationRoleAssignment mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func roleAssignmentResponse(method string, args mock.Arguments) (*management.RoleAssignment, *http.Response, error) {
+	response, ok := args.Get(0).(*management.RoleAssignment)
+	if !ok && args.Get(0) != nil {
+		panic(method + " mock setup error: expected *management.RoleAssignment or nil")
+	}
+	httpResponse, ok := args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic(method + " mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
@@ -0,0 +1,296 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+)
+
+// The types of actor that roles can be assigned to
+const (
+	SubjectTypeUser        = "USER"
+	SubjectTypeGroup       = "GROUP"
+	SubjectTypeApplication = "APPLICATION"
+)
+
+type RoleAssignmentSummary struct {
+	Id        string  `json:"id" jsonschema:"The UUID of the role assignment"`
+	RoleId    string  `json:"roleId" jsonschema:"The UUID of the assigned role"`
+	RoleName  *string `json:"roleName,omitempty" jsonschema:"The name of the assigned role, if it could be looked up"`
+	ScopeType string  `json:"scopeType" jsonschema:"The type of resource the role is granted over: ORGANIZATION, ENVIRONMENT, POPULATION or APPLICATION"`
+	ScopeId   string  `json:"scopeId" jsonschema:"The UUID of the organization, environment, population or application the role is granted over"`
+	GroupId   *string `json:"groupId,omitempty" jsonschema:"The UUID of the group associated with the role assignment, if any"`
+	ReadOnly  bool    `json:"readOnly" jsonschema:"True if the role assignment cannot be removed by the current actor"`
+}
+
+type ListRoleAssignmentsOutput struct {
+	RoleAssignments []RoleAssignmentSummary `json:"roleAssignments" jsonschema:"The roles assigned, with the resources they are granted over"`
+}
+
+type AssignRoleOutput struct {
+	RoleAssignment RoleAssignmentSummary `json:"roleAssignment" jsonschema:"The created role assignment"`
+}
+
+// roleAssignmentSubject binds the role assignment operations of the client to a type of actor
+type roleAssignmentSubject struct {
+	subjectType string
+	// name is the subject type as used in messages, such as user
+	name   string
+	list   func(ctx context.Context, environmentId uuid.UUID, subjectId string) (management.EntityArrayPagedIterator, error)
+	get    func(ctx context.Context, environmentId uuid.UUID, subjectId string, roleAssignmentId string) (*management.RoleAssignment, *http.Response, error)
+	create func(ctx context.Context, environmentId uuid.UUID, subjectId string, createRequest management.RoleAssignment) (*management.RoleAssignment, *http.Response, error)
+	delete func(ctx context.Context, environmentId uuid.UUID, subjectId string, roleAssignmentId string) (*http.Response, error)
+}
+
+func subjectOf(client RolesClient, subjectType string) (roleAssignmentSubject, error) {
+	switch strings.ToUpper(subjectType) {
+	case SubjectTypeUser:
+		return roleAssignmentSubject{SubjectTypeUser, "user", client.GetUserRoleAssignments, client.GetUserRoleAssignment, client.CreateUserRoleAssignment, client.DeleteUserRoleAssignment}, nil
+	case SubjectTypeGroup:
+		return roleAssignmentSubject{SubjectTypeGroup, "group", client.GetGroupRoleAssignments, client.GetGroupRoleAssignment, client.CreateGroupRoleAssignment, client.DeleteGroupRoleAssignment}, nil
+	case SubjectTypeApplication:
+		return roleAssignmentSubject{SubjectTypeApplication, "application", client.GetApplicationRoleAssignments, client.GetApplicationRoleAssignment, client.CreateApplicationRoleAssignment, client.DeleteApplicationRoleAssignment}, nil
+	}
+	return roleAssignmentSubject{}, fmt.Errorf("invalid subject type %q, must be one of %s, %s or %s", subjectType, SubjectTypeUser, SubjectTypeGroup, SubjectTypeApplication)
+}
+
+// listRoleAssignments returns the roles assigned to a user, group or application, named after the roles where
+// they can be looked up
+func listRoleAssignments(ctx context.Context, toolName string, rolesClientFactory RolesClientFactory, subjectType string, environmentId uuid.UUID, subjectId uuid.UUID) (*ListRoleAssignmentsOutput, error) {
+	client, err := rolesClientFactory.GetAuthenticatedClient(ctx)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	subject, err := subjectOf(client, subjectType)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+
+	logger.FromContext(ctx).Debug("Listing role assignments",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("subjectType", subject.subjectType),
+		slog.String("subjectId", subjectId.String()))
+
+	pagedIterator, err := subject.list(ctx, environmentId, subjectId.String())
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	result := &ListRoleAssignmentsOutput{
+		RoleAssignments: []RoleAssignmentSummary{},
+	}
+	err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, assignment := range embedded.RoleAssignments {
+			result.RoleAssignments = append(result.RoleAssignments, roleAssignmentSummary(assignment))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.RoleAssignments) == 0 {
+		return result, nil
+	}
+
+	// Role names are a convenience, so the assignments are returned without them if the roles cannot be read
+	roleNames, err := getRoleNames(ctx, client)
+	if err != nil {
+		logger.FromContext(ctx).Warn("Failed to look up role names, returning role assignments without them",
+			slog.String("error", err.Error()))
+		return result, nil
+	}
+	for i := range result.RoleAssignments {
+		if roleName, ok := roleNames[result.RoleAssignments[i].RoleId]; ok {
+			result.RoleAssignments[i].RoleName = &roleName
+		}
+	}
+	return result, nil
+}
+
+// assignRole assigns a role to a user, group or application, granted over the environment unless another
+// scope is given
+func assignRole(ctx context.Context, toolName string, rolesClientFactory RolesClientFactory, subjectType string, environmentId uuid.UUID, subjectId uuid.UUID, roleId uuid.UUID, scopeType *string, scopeId *uuid.UUID) (*AssignRoleOutput, error) {
+	scope, err := roleAssignmentScope(environmentId, scopeType, scopeId)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+
+	client, err := rolesClientFactory.GetAuthenticatedClient(ctx)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	subject, err := subjectOf(client, subjectType)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+
+	logger.FromContext(ctx).Debug("Assigning role",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("subjectType", subject.subjectType),
+		slog.String("subjectId", subjectId.String()),
+		slog.String("roleId", roleId.String()))
+
+	createRequest := management.RoleAssignment{
+		Role:  management.RoleAssignmentRole{Id: roleId.String()},
+		Scope: *scope,
+	}
+	assignment, httpResponse, err := subject.create(ctx, environmentId, subjectId.String(), createRequest)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+	if assignment == nil || assignment.Id == nil {
+		apiErr := errs.NewApiError(httpResponse, errors.New("no role assignment data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+
+	rollback.Record(ctx, rollback.Change{
+		Tool:          toolName,
+		EnvironmentId: environmentId.String(),
+		ResourceType:  "roleAssignment",
+		ResourceId:    *assignment.Id,
+		Description:   fmt.Sprintf("Remove role %s from %s %s", roleId, subject.name, subjectId),
+	}, undoAssignRole(rolesClientFactory, subject.subjectType, environmentId, subjectId.String(), *assignment.Id))
+
+	return &AssignRoleOutput{
+		RoleAssignment: roleAssignmentSummary(*assignment),
+	}, nil
+}
+
+// roleAssignmentScope returns the scope of a new role assignment, which defaults to the environment
+func roleAssignmentScope(environmentId uuid.UUID, scopeType *string, scopeId *uuid.UUID) (*management.RoleAssignmentScope, error) {
+	scope := &management.RoleAssignmentScope{
+		Type: management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT,
+	}
+	if scopeType != nil && *scopeType != "" {
+		enumScopeType, err := management.NewEnumRoleAssignmentScopeTypeFromValue(strings.ToUpper(*scopeType))
+		if err != nil {
+			return nil, fmt.Errorf("invalid scope type %q, must be one of ORGANIZATION, ENVIRONMENT, POPULATION or APPLICATION", *scopeType)
+		}
+		scope.Type = *enumScopeType
+	}
+	switch {
+	case scopeId != nil:
+		scope.Id = scopeId.String()
+	case scope.Type == management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT:
+		scope.Id = environmentId.String()
+	default:
+		return nil, fmt.Errorf("scopeId is required for scope type %s", scope.Type)
+	}
+	return scope, nil
+}
+
+// undoAssignRole returns the function that removes a role assignment made by a tool call. A role assignment
+// cannot be changed, so there is nothing to check before removing it.
+func undoAssignRole(rolesClientFactory RolesClientFactory, subjectType string, environmentId uuid.UUID, subjectId string, roleAssignmentId string) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := rolesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+		subject, err := subjectOf(client, subjectType)
+		if err != nil {
+			return err
+		}
+		httpResponse, err := subject.delete(ctx, environmentId, subjectId, roleAssignmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
+
+// AssignedRoleNames returns the names of the roles assigned to a user or application, whatever their scope. Roles
+// whose names cannot be looked up are returned by ID.
+func AssignedRoleNames(ctx context.Context, client RolesClient, subjectType string, environmentId uuid.UUID, subjectId string) ([]string, error) {
+	subject, err := subjectOf(client, subjectType)
+	if err != nil {
+		return nil, err
+	}
+	pagedIterator, err := subject.list(ctx, environmentId, subjectId)
+	if err != nil {
+		return nil, err
+	}
+	roleIds := []string{}
+	err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, assignment := range embedded.RoleAssignments {
+			roleIds = append(roleIds, assignment.Role.Id)
+		}
+		return true
+	})
+	if err != nil || len(roleIds) == 0 {
+		return roleIds, err
+	}
+
+	roleNames, err := getRoleNames(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(roleIds))
+	for i, roleId := range roleIds {
+		names[i] = roleId
+		if name, ok := roleNames[roleId]; ok && name != "" {
+			names[i] = name
+		}
+	}
+	return names, nil
+}
+
+// getRoleNames returns the names of the built-in and custom administrator roles by ID
+func getRoleNames(ctx context.Context, client RolesClient) (map[string]string, error) {
+	rolesIterator, err := client.GetRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roleNames := map[string]string{}
+	err = collections.ForEachPage(ctx, rolesIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, role := range embedded.Roles {
+			if summary, ok := roleSummary(role); ok {
+				roleNames[summary.Id] = summary.Name
+			}
+		}
+		return true
+	})
+	return roleNames, err
+}
+
+func roleAssignmentSummary(assignment management.RoleAssignment) RoleAssignmentSummary {
+	summary := RoleAssignmentSummary{
+		Id:        assignment.GetId(),
+		RoleId:    assignment.Role.Id,
+		ScopeType: string(assignment.Scope.Type),
+		ScopeId:   assignment.Scope.Id,
+		ReadOnly:  assignment.GetReadOnly(),
+	}
+	if assignment.Group != nil {
+		summary.GroupId = assignment.Group.Id
+	}
+	return summary
+}
@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignedRoleNames(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	customAssignment := environmentRoleAssignment("assignment-2", false)
+	customAssignment.Role.Id = testCustomRoleId.String()
+	unknownAssignment := environmentRoleAssignment("assignment-3", false)
+	unknownAssignment.Role.Id = "unknown-role"
+	mockClient.On("GetApplicationRoleAssignments", mock.Anything, testEnvironmentId, testApplicationId.String()).Return(
+		roleAssignmentsPages(environmentRoleAssignment("assignment-1", false), customAssignment, unknownAssignment), nil)
+	mockClient.On("GetRoles", mock.Anything).Return(rolesPages(testRole, testCustomRole), nil)
+
+	names, err := roles.AssignedRoleNames(context.Background(), mockClient, roles.SubjectTypeApplication, testEnvironmentId, testApplicationId.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Identity Data Admin", "Password Reset Operator", "unknown-role"}, names)
+}
+
+func TestAssignedRoleNames_NoRoles(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, testUserId.String()).Return(roleAssignmentsPages(), nil)
+
+	names, err := roles.AssignedRoleNames(context.Background(), mockClient, roles.SubjectTypeUser, testEnvironmentId, testUserId.String())
+
+	require.NoError(t, err)
+	assert.Empty(t, names)
+	mockClient.AssertNotCalled(t, "GetRoles", mock.Anything)
+}
+
+func TestAssignedRoleNames_Error(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, testUserId.String()).Return(errorPages(errors.New("forbidden")), nil)
+
+	_, err := roles.AssignedRoleNames(context.Background(), mockClient, roles.SubjectTypeUser, testEnvironmentId, testUserId.String())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "forbidden")
+}
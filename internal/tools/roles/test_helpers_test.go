@@ -0,0 +1,80 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+)
+
+var (
+	testEnvironmentId    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testPopulationId     = uuid.MustParse("550e8400-e29b-41d4-a716-446655440100")
+	testUserId           = uuid.MustParse("550e8400-e29b-41d4-a716-446655440200")
+	testGroupId          = uuid.MustParse("550e8400-e29b-41d4-a716-446655440300")
+	testApplicationId    = uuid.MustParse("550e8400-e29b-41d4-a716-446655440400")
+	testRoleId           = uuid.MustParse("550e8400-e29b-41d4-a716-446655440500")
+	testCustomRoleId     = uuid.MustParse("550e8400-e29b-41d4-a716-446655440501")
+	testRoleAssignmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440600")
+)
+
+var (
+	testRole = management.EntityArrayEmbeddedRolesInner{
+		Role: &management.Role{
+			Id:           testutils.Pointer(testRoleId.String()),
+			Name:         management.ENUMROLENAME_IDENTITY_DATA_ADMIN.Ptr(),
+			Description:  testutils.Pointer("Manages users, groups and populations"),
+			ApplicableTo: []management.EnumRoleAssignmentScopeType{management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT, management.ENUMROLEASSIGNMENTSCOPETYPE_POPULATION},
+			Permissions: []management.RolePermissionsInner{
+				{Id: testutils.Pointer("permissions:read:users")},
+				{Id: testutils.Pointer("permissions:update:users")},
+			},
+		},
+	}
+	testCustomRole = management.EntityArrayEmbeddedRolesInner{
+		CustomAdminRole: &management.CustomAdminRole{
+			Id:          testutils.Pointer(testCustomRoleId.String()),
+			Name:        "Password Reset Operator",
+			Permissions: []management.CustomAdminRolePermissionsInner{{Id: "permissions:update:userPasswords"}},
+		},
+	}
+)
+
+func environmentRoleAssignment(id string, readOnly bool) management.RoleAssignment {
+	return management.RoleAssignment{
+		Id:       testutils.Pointer(id),
+		ReadOnly: testutils.Pointer(readOnly),
+		Role:     management.RoleAssignmentRole{Id: testRoleId.String()},
+		Scope: management.RoleAssignmentScope{
+			Id:   testEnvironmentId.String(),
+			Type: management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT,
+		},
+	}
+}
+
+func rolesPages(roles ...management.EntityArrayEmbeddedRolesInner) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Roles: roles}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func roleAssignmentsPages(assignments ...management.RoleAssignment) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{RoleAssignments: assignments}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func errorPages(err error) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{HTTPResponse: &http.Response{StatusCode: 403}, Error: err},
+	})
+}
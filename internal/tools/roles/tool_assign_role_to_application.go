@@ -0,0 +1,54 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var AssignRoleToApplicationDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "assign_role_to_application",
+		Title: "Assign Role to PingOne Application",
+		Description: `Assign an administrator role to an application, granted over the environment by default, or over the organization, a population or an application with scopeType and scopeId. Use list_roles to find the role ID and the scope types it can be granted over, and use list_applications to find the application ID. Administrator roles are assigned to worker applications.
+
+The assignment can be removed with remove_role_assignment, or with undo_last_change.`,
+		InputSchema:  schema.MustGenerateSchema[AssignRoleToApplicationInput](),
+		OutputSchema: schema.MustGenerateSchema[AssignRoleOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type AssignRoleToApplicationInput struct {
+	EnvironmentId uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the application."`
+	ApplicationId uuid.UUID  `json:"applicationId" jsonschema:"REQUIRED. Application UUID."`
+	RoleId        uuid.UUID  `json:"roleId" jsonschema:"REQUIRED. UUID of the role to assign."`
+	ScopeType     *string    `json:"scopeType,omitempty" jsonschema:"OPTIONAL. The type of resource the role is granted over: ORGANIZATION, ENVIRONMENT, POPULATION or APPLICATION. Defaults to ENVIRONMENT."`
+	ScopeId       *uuid.UUID `json:"scopeId,omitempty" jsonschema:"OPTIONAL. UUID of the organization, environment, population or application the role is granted over. Defaults to environmentId when the scope type is ENVIRONMENT, and is required otherwise."`
+}
+
+// AssignRoleToApplicationHandler assigns a role to a PingOne application using the provided client
+func AssignRoleToApplicationHandler(rolesClientFactory RolesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input AssignRoleToApplicationInput,
+) (
+	*mcp.CallToolResult,
+	*AssignRoleOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AssignRoleToApplicationInput) (*mcp.CallToolResult, *AssignRoleOutput, error) {
+		result, err := assignRole(ctx, AssignRoleToApplicationDef.McpTool.Name, rolesClientFactory, SubjectTypeApplication, input.EnvironmentId, input.ApplicationId, input.RoleId, input.ScopeType, input.ScopeId)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
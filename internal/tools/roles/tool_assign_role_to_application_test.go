@@ -0,0 +1,48 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssignRoleToApplicationHandler(t *testing.T) {
+	created := environmentRoleAssignment(testRoleAssignmentId.String(), false)
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("CreateApplicationRoleAssignment", mock.Anything, testEnvironmentId, testApplicationId.String(), mock.Anything).Return(&created, &http.Response{StatusCode: 201}, nil)
+
+	handler := roles.AssignRoleToApplicationHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.AssignRoleToApplicationInput{
+		EnvironmentId: testEnvironmentId,
+		ApplicationId: testApplicationId,
+		RoleId:        testRoleId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, testRoleAssignmentId.String(), output.RoleAssignment.Id)
+	assert.Equal(t, "ENVIRONMENT", output.RoleAssignment.ScopeType)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAssignRoleToApplicationHandler_APIError(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("CreateApplicationRoleAssignment", mock.Anything, testEnvironmentId, testApplicationId.String(), mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("role cannot be assigned"))
+
+	handler := roles.AssignRoleToApplicationHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.AssignRoleToApplicationInput{
+		EnvironmentId: testEnvironmentId,
+		ApplicationId: testApplicationId,
+		RoleId:        testRoleId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "role cannot be assigned")
+}
@@ -0,0 +1,164 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignRoleToUserHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		scopeType *string
+		scopeId   *uuid.UUID
+		wantScope management.RoleAssignmentScope
+	}{
+		{
+			name:      "Defaults to the environment",
+			wantScope: management.RoleAssignmentScope{Id: testEnvironmentId.String(), Type: management.ENUMROLEASSIGNMENTSCOPETYPE_ENVIRONMENT},
+		},
+		{
+			name:      "Population scope",
+			scopeType: testutils.Pointer("population"),
+			scopeId:   &testPopulationId,
+			wantScope: management.RoleAssignmentScope{Id: testPopulationId.String(), Type: management.ENUMROLEASSIGNMENTSCOPETYPE_POPULATION},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			createRequest := management.RoleAssignment{
+				Role:  management.RoleAssignmentRole{Id: testRoleId.String()},
+				Scope: tt.wantScope,
+			}
+			created := createRequest
+			created.Id = testutils.Pointer(testRoleAssignmentId.String())
+
+			mockClient := &mockPingOneClientRolesWrapper{}
+			mockClient.On("CreateUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), createRequest).Return(&created, &http.Response{StatusCode: 201}, nil)
+
+			handler := roles.AssignRoleToUserHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.AssignRoleToUserInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+				RoleId:        testRoleId,
+				ScopeType:     tt.scopeType,
+				ScopeId:       tt.scopeId,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, testRoleAssignmentId.String(), output.RoleAssignment.Id)
+			assert.Equal(t, testRoleId.String(), output.RoleAssignment.RoleId)
+			assert.Equal(t, string(tt.wantScope.Type), output.RoleAssignment.ScopeType)
+			assert.Equal(t, tt.wantScope.Id, output.RoleAssignment.ScopeId)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssignRoleToUserHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		scopeType       *string
+		scopeId         *uuid.UUID
+		setupMock       func(mockClient *mockPingOneClientRolesWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Invalid scope type",
+			scopeType:       testutils.Pointer("TENANT"),
+			wantErrContains: "invalid scope type \"TENANT\"",
+		},
+		{
+			name:            "Scope ID required",
+			scopeType:       testutils.Pointer("POPULATION"),
+			wantErrContains: "scopeId is required for scope type POPULATION",
+		},
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("CreateUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), mock.Anything).Return(nil, &http.Response{StatusCode: 403}, errors.New("actor does not have the role"))
+			},
+			wantErrContains: "actor does not have the role",
+		},
+		{
+			name: "No role assignment data in response",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("CreateUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), mock.Anything).Return(nil, &http.Response{StatusCode: 201}, nil)
+			},
+			wantErrContains: "no role assignment data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientRolesWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := roles.AssignRoleToUserHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.AssignRoleToUserInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+				RoleId:        testRoleId,
+				ScopeType:     tt.scopeType,
+				ScopeId:       tt.scopeId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAssignRoleToUserHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := roles.AssignRoleToUserHandler(NewMockPingOneClientRolesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.AssignRoleToUserInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		RoleId:        testRoleId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestAssignRoleToUserHandler_RecordsUndo(t *testing.T) {
+	created := environmentRoleAssignment(testRoleAssignmentId.String(), false)
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("CreateUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), mock.Anything).Return(&created, &http.Response{StatusCode: 201}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := roles.AssignRoleToUserHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, roles.AssignRoleToUserInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		RoleId:        testRoleId,
+	})
+	require.NoError(t, err)
+
+	changes := journal.Changes(testEnvironmentId.String())
+	require.Len(t, changes, 1)
+	assert.Equal(t, "roleAssignment", changes[0].ResourceType)
+	assert.Equal(t, testRoleAssignmentId.String(), changes[0].ResourceId)
+
+	// Undoing the assignment removes it
+	mockClient.On("DeleteUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), testRoleAssignmentId.String()).Return(&http.Response{StatusCode: 204}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,53 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListApplicationRoleAssignmentsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_application_role_assignments",
+		Title:        "List PingOne Application Role Assignments",
+		Description:  "Lists the administrator roles assigned to an application, with the organization, environment, population or application each role is granted over. Use to review an application's admin access or to find the role assignment ID to remove with remove_role_assignment. Use list_applications to find the application ID. Administrator roles are assigned to worker applications.",
+		InputSchema:  schema.MustGenerateSchema[ListApplicationRoleAssignmentsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListRoleAssignmentsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListApplicationRoleAssignmentsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the application."`
+	ApplicationId uuid.UUID `json:"applicationId" jsonschema:"REQUIRED. Application UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'roleAssignments.roleName' and 'roleAssignments.scopeId'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+// ListApplicationRoleAssignmentsHandler lists the roles assigned to a PingOne application using the provided client
+func ListApplicationRoleAssignmentsHandler(rolesClientFactory RolesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListApplicationRoleAssignmentsInput,
+) (
+	*mcp.CallToolResult,
+	*ListRoleAssignmentsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListApplicationRoleAssignmentsInput) (*mcp.CallToolResult, *ListRoleAssignmentsOutput, error) {
+		result, err := listRoleAssignments(ctx, ListApplicationRoleAssignmentsDef.McpTool.Name, rolesClientFactory, SubjectTypeApplication, input.EnvironmentId, input.ApplicationId)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
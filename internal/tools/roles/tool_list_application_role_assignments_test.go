@@ -0,0 +1,48 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListApplicationRoleAssignmentsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetApplicationRoleAssignments", mock.Anything, testEnvironmentId, testApplicationId.String()).Return(
+		roleAssignmentsPages(environmentRoleAssignment("assignment-1", false)), nil)
+	mockClient.On("GetRoles", mock.Anything).Return(rolesPages(testRole), nil)
+
+	handler := roles.ListApplicationRoleAssignmentsHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListApplicationRoleAssignmentsInput{
+		EnvironmentId: testEnvironmentId,
+		ApplicationId: testApplicationId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.RoleAssignments, 1)
+	assert.Equal(t, "assignment-1", output.RoleAssignments[0].Id)
+	assert.Equal(t, testutils.Pointer("Identity Data Admin"), output.RoleAssignments[0].RoleName)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListApplicationRoleAssignmentsHandler_APIError(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetApplicationRoleAssignments", mock.Anything, testEnvironmentId, testApplicationId.String()).Return(errorPages(errors.New("application not found")), nil)
+
+	handler := roles.ListApplicationRoleAssignmentsHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListApplicationRoleAssignmentsInput{
+		EnvironmentId: testEnvironmentId,
+		ApplicationId: testApplicationId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "application not found")
+}
@@ -0,0 +1,53 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListGroupRoleAssignmentsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_group_role_assignments",
+		Title:        "List PingOne Group Role Assignments",
+		Description:  "Lists the administrator roles assigned to a group, with the organization, environment, population or application each role is granted over. Use to review a group's admin access or to find the role assignment ID to remove with remove_role_assignment.",
+		InputSchema:  schema.MustGenerateSchema[ListGroupRoleAssignmentsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListRoleAssignmentsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListGroupRoleAssignmentsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the group."`
+	GroupId       uuid.UUID `json:"groupId" jsonschema:"REQUIRED. Group UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'roleAssignments.roleName' and 'roleAssignments.scopeId'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+// ListGroupRoleAssignmentsHandler lists the roles assigned to a PingOne group using the provided client
+func ListGroupRoleAssignmentsHandler(rolesClientFactory RolesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListGroupRoleAssignmentsInput,
+) (
+	*mcp.CallToolResult,
+	*ListRoleAssignmentsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListGroupRoleAssignmentsInput) (*mcp.CallToolResult, *ListRoleAssignmentsOutput, error) {
+		result, err := listRoleAssignments(ctx, ListGroupRoleAssignmentsDef.McpTool.Name, rolesClientFactory, SubjectTypeGroup, input.EnvironmentId, input.GroupId)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
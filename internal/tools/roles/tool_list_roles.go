@@ -0,0 +1,135 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListRolesDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "list_roles",
+		Title:        "List PingOne Administrator Roles",
+		Description:  "Lists the built-in and custom administrator roles of the organization, with the types of resource each can be granted over and its permissions. Use to find the role ID to assign with assign_role_to_user, assign_role_to_group or assign_role_to_application, or to check what a role allows.",
+		InputSchema:  schema.MustGenerateSchema[ListRolesInput](),
+		OutputSchema: schema.MustGenerateSchema[ListRolesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListRolesInput struct {
+	Fields []string `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'roles.id' and 'roles.name'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type RoleSummary struct {
+	Id           string   `json:"id" jsonschema:"The UUID of the role"`
+	Name         string   `json:"name" jsonschema:"The name of the role, such as Environment Admin"`
+	Description  *string  `json:"description,omitempty" jsonschema:"The description of the role"`
+	Custom       bool     `json:"custom" jsonschema:"True for a custom administrator role, false for a built-in role"`
+	ApplicableTo []string `json:"applicableTo" jsonschema:"The scope types the role can be granted over: ORGANIZATION, ENVIRONMENT, POPULATION or APPLICATION"`
+	Permissions  []string `json:"permissions" jsonschema:"The IDs of the permissions the role grants, such as permissions:read:userRoleAssignments"`
+}
+
+type ListRolesOutput struct {
+	Roles []RoleSummary `json:"roles" jsonschema:"The administrator roles of the organization"`
+}
+
+// ListRolesHandler lists the PingOne administrator roles using the provided client
+func ListRolesHandler(rolesClientFactory RolesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListRolesInput,
+) (
+	*mcp.CallToolResult,
+	*ListRolesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListRolesInput) (*mcp.CallToolResult, *ListRolesOutput, error) {
+		client, err := rolesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListRolesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		pagedIterator, err := client.GetRoles(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ListRolesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		result := &ListRolesOutput{
+			Roles: []RoleSummary{},
+		}
+		err = collections.ForEachPage(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+			logger.FromContext(ctx).Debug("Retrieved roles page", slog.Int("count", len(embedded.Roles)))
+			for _, role := range embedded.Roles {
+				if summary, ok := roleSummary(role); ok {
+					result.Roles = append(result.Roles, summary)
+				}
+			}
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, result, nil
+	}
+}
+
+// roleSummary returns the summary of a built-in or custom role, or false if the item is neither
+func roleSummary(role management.EntityArrayEmbeddedRolesInner) (RoleSummary, bool) {
+	switch {
+	case role.Role != nil && role.Role.Id != nil:
+		summary := RoleSummary{
+			Id:           *role.Role.Id,
+			Description:  role.Role.Description,
+			ApplicableTo: []string{},
+			Permissions:  []string{},
+		}
+		if role.Role.Name != nil {
+			summary.Name = string(*role.Role.Name)
+		}
+		for _, scopeType := range role.Role.ApplicableTo {
+			summary.ApplicableTo = append(summary.ApplicableTo, string(scopeType))
+		}
+		for _, permission := range role.Role.Permissions {
+			if permission.Id != nil {
+				summary.Permissions = append(summary.Permissions, *permission.Id)
+			}
+		}
+		return summary, true
+	case role.CustomAdminRole != nil && role.CustomAdminRole.Id != nil:
+		summary := RoleSummary{
+			Id:           *role.CustomAdminRole.Id,
+			Name:         role.CustomAdminRole.Name,
+			Description:  role.CustomAdminRole.Description,
+			Custom:       true,
+			ApplicableTo: []string{},
+			Permissions:  []string{},
+		}
+		for _, scopeType := range role.CustomAdminRole.ApplicableTo {
+			summary.ApplicableTo = append(summary.ApplicableTo, string(scopeType))
+		}
+		for _, permission := range role.CustomAdminRole.Permissions {
+			summary.Permissions = append(summary.Permissions, permission.Id)
+		}
+		return summary, true
+	}
+	return RoleSummary{}, false
+}
@@ -0,0 +1,85 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestListRolesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetRoles", mock.Anything).Return(rolesPages(testRole, testCustomRole), nil)
+
+	handler := roles.ListRolesHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListRolesInput{})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []roles.RoleSummary{
+		{
+			Id:           testRoleId.String(),
+			Name:         "Identity Data Admin",
+			Description:  testutils.Pointer("Manages users, groups and populations"),
+			ApplicableTo: []string{"ENVIRONMENT", "POPULATION"},
+			Permissions:  []string{"permissions:read:users", "permissions:update:users"},
+		},
+		{
+			Id:           testCustomRoleId.String(),
+			Name:         "Password Reset Operator",
+			Custom:       true,
+			ApplicableTo: []string{},
+			Permissions:  []string{"permissions:update:userPasswords"},
+		},
+	}, output.Roles)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListRolesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientRolesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Client error",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("GetRoles", mock.Anything).Return(nil, errors.New("client error"))
+			},
+			wantErrContains: "client error",
+		},
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("GetRoles", mock.Anything).Return(errorPages(errors.New("forbidden")), nil)
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientRolesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := roles.ListRolesHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListRolesInput{})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListRolesHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := roles.ListRolesHandler(NewMockPingOneClientRolesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListRolesInput{})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,50 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ListUserRoleAssignmentsDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:         "list_user_role_assignments",
+		Title:        "List PingOne User Role Assignments",
+		Description:  "Lists the administrator roles assigned to a user, with the organization, environment, population or application each role is granted over. Use to review a user's admin access or to find the role assignment ID to remove with remove_role_assignment. Use find_user to look up the user ID from a username or email address.",
+		InputSchema:  schema.MustGenerateSchema[ListUserRoleAssignmentsInput](),
+		OutputSchema: schema.MustGenerateSchema[ListRoleAssignmentsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type ListUserRoleAssignmentsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the user."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. User UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'roleAssignments.roleName' and 'roleAssignments.scopeId'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+// ListUserRoleAssignmentsHandler lists the roles assigned to a PingOne user using the provided client
+func ListUserRoleAssignmentsHandler(rolesClientFactory RolesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ListUserRoleAssignmentsInput,
+) (
+	*mcp.CallToolResult,
+	*ListRoleAssignmentsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListUserRoleAssignmentsInput) (*mcp.CallToolResult, *ListRoleAssignmentsOutput, error) {
+		result, err := listRoleAssignments(ctx, ListUserRoleAssignmentsDef.McpTool.Name, rolesClientFactory, SubjectTypeUser, input.EnvironmentId, input.UserId)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
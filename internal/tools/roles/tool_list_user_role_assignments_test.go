@@ -0,0 +1,137 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUserRoleAssignmentsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	groupAssignment := environmentRoleAssignment("assignment-2", true)
+	groupAssignment.Role.Id = testCustomRoleId.String()
+	groupAssignment.Group = &management.RoleAssignmentGroup{Id: testutils.Pointer(testGroupId.String())}
+	mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, testUserId.String()).Return(
+		roleAssignmentsPages(environmentRoleAssignment("assignment-1", false), groupAssignment), nil)
+	mockClient.On("GetRoles", mock.Anything).Return(rolesPages(testRole, testCustomRole), nil)
+
+	handler := roles.ListUserRoleAssignmentsHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListUserRoleAssignmentsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, []roles.RoleAssignmentSummary{
+		{
+			Id:        "assignment-1",
+			RoleId:    testRoleId.String(),
+			RoleName:  testutils.Pointer("Identity Data Admin"),
+			ScopeType: "ENVIRONMENT",
+			ScopeId:   testEnvironmentId.String(),
+		},
+		{
+			Id:        "assignment-2",
+			RoleId:    testCustomRoleId.String(),
+			RoleName:  testutils.Pointer("Password Reset Operator"),
+			ScopeType: "ENVIRONMENT",
+			ScopeId:   testEnvironmentId.String(),
+			GroupId:   testutils.Pointer(testGroupId.String()),
+			ReadOnly:  true,
+		},
+	}, output.RoleAssignments)
+	mockClient.AssertExpectations(t)
+}
+
+func TestListUserRoleAssignmentsHandler_NoAssignments(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, testUserId.String()).Return(roleAssignmentsPages(), nil)
+
+	handler := roles.ListUserRoleAssignmentsHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListUserRoleAssignmentsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Empty(t, output.RoleAssignments)
+	// Roles are only looked up to name assignments
+	mockClient.AssertNotCalled(t, "GetRoles", mock.Anything)
+}
+
+func TestListUserRoleAssignmentsHandler_RoleNamesUnavailable(t *testing.T) {
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, testUserId.String()).Return(
+		roleAssignmentsPages(environmentRoleAssignment("assignment-1", false)), nil)
+	mockClient.On("GetRoles", mock.Anything).Return(errorPages(errors.New("forbidden")), nil)
+
+	handler := roles.ListUserRoleAssignmentsHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListUserRoleAssignmentsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.RoleAssignments, 1)
+	assert.Equal(t, testRoleId.String(), output.RoleAssignments[0].RoleId)
+	assert.Nil(t, output.RoleAssignments[0].RoleName)
+}
+
+func TestListUserRoleAssignmentsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientRolesWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Client error",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, errors.New("client error"))
+			},
+			wantErrContains: "client error",
+		},
+		{
+			name: "API error",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("GetUserRoleAssignments", mock.Anything, testEnvironmentId, testUserId.String()).Return(errorPages(errors.New("user not found")), nil)
+			},
+			wantErrContains: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientRolesWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := roles.ListUserRoleAssignmentsHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListUserRoleAssignmentsInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestListUserRoleAssignmentsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := roles.ListUserRoleAssignmentsHandler(NewMockPingOneClientRolesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.ListUserRoleAssignmentsInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
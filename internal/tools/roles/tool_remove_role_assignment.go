@@ -0,0 +1,147 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var RemoveRoleAssignmentDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "remove_role_assignment",
+		Title: "Remove PingOne Role Assignment",
+		Description: `Remove an administrator role assignment from a user, group or application, revoking the access the role granted over its scope. Use list_user_role_assignments, list_group_role_assignments or list_application_role_assignments to find the role assignment ID.
+
+Role assignments that the current actor cannot remove are reported as read-only by the list tools, and are not removed. The removed assignment can be restored with undo_last_change, which assigns the same role over the same scope again under a new role assignment ID.`,
+		InputSchema:  schema.MustGenerateSchema[RemoveRoleAssignmentInput](),
+		OutputSchema: schema.MustGenerateSchema[RemoveRoleAssignmentOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type RemoveRoleAssignmentInput struct {
+	EnvironmentId    uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID of the user, group or application."`
+	SubjectType      string    `json:"subjectType" jsonschema:"REQUIRED. The type of actor the role is assigned to: USER, GROUP or APPLICATION."`
+	SubjectId        uuid.UUID `json:"subjectId" jsonschema:"REQUIRED. UUID of the user, group or application the role is assigned to."`
+	RoleAssignmentId uuid.UUID `json:"roleAssignmentId" jsonschema:"REQUIRED. UUID of the role assignment to remove."`
+}
+
+type RemoveRoleAssignmentOutput struct {
+	SubjectType    string                `json:"subjectType" jsonschema:"The type of actor the role was assigned to"`
+	SubjectId      string                `json:"subjectId" jsonschema:"The UUID of the user, group or application the role was assigned to"`
+	RoleAssignment RoleAssignmentSummary `json:"roleAssignment" jsonschema:"The removed role assignment"`
+}
+
+// RemoveRoleAssignmentHandler removes a role assignment from a PingOne user, group or application using the provided client
+func RemoveRoleAssignmentHandler(rolesClientFactory RolesClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input RemoveRoleAssignmentInput,
+) (
+	*mcp.CallToolResult,
+	*RemoveRoleAssignmentOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input RemoveRoleAssignmentInput) (*mcp.CallToolResult, *RemoveRoleAssignmentOutput, error) {
+		client, err := rolesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(RemoveRoleAssignmentDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		subject, err := subjectOf(client, input.SubjectType)
+		if err != nil {
+			toolErr := errs.NewToolError(RemoveRoleAssignmentDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		subjectId := input.SubjectId.String()
+		roleAssignmentId := input.RoleAssignmentId.String()
+
+		// Read the assignment first, to restore the same role and scope on undo
+		assignment, httpResponse, err := subject.get(ctx, input.EnvironmentId, subjectId, roleAssignmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if assignment == nil {
+			apiErr := errs.NewApiError(httpResponse, errors.New("no role assignment data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if assignment.GetReadOnly() {
+			toolErr := errs.NewToolError(RemoveRoleAssignmentDef.McpTool.Name, fmt.Errorf("role assignment %s of %s %s is read-only and cannot be removed by the current actor", roleAssignmentId, subject.name, subjectId))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Removing role assignment",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("subjectType", subject.subjectType),
+			slog.String("subjectId", subjectId),
+			slog.String("roleAssignmentId", roleAssignmentId))
+
+		httpResponse, err = subject.delete(ctx, input.EnvironmentId, subjectId, roleAssignmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		rollback.Record(ctx, rollback.Change{
+			Tool:          RemoveRoleAssignmentDef.McpTool.Name,
+			EnvironmentId: input.EnvironmentId.String(),
+			ResourceType:  "roleAssignment",
+			ResourceId:    roleAssignmentId,
+			Description:   fmt.Sprintf("Assign role %s to %s %s again", assignment.Role.Id, subject.name, subjectId),
+		}, undoRemoveRoleAssignment(rolesClientFactory, subject.subjectType, input.EnvironmentId, subjectId, *assignment))
+
+		return nil, &RemoveRoleAssignmentOutput{
+			SubjectType:    subject.subjectType,
+			SubjectId:      subjectId,
+			RoleAssignment: roleAssignmentSummary(*assignment),
+		}, nil
+	}
+}
+
+// undoRemoveRoleAssignment returns the function that assigns the removed role over the same scope again.
+// Assigning a role does not change other assignments, so there is nothing to check before assigning it.
+func undoRemoveRoleAssignment(rolesClientFactory RolesClientFactory, subjectType string, environmentId uuid.UUID, subjectId string, removed management.RoleAssignment) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := rolesClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+		subject, err := subjectOf(client, subjectType)
+		if err != nil {
+			return err
+		}
+		createRequest := management.RoleAssignment{
+			Role:  removed.Role,
+			Scope: removed.Scope,
+		}
+		_, httpResponse, err := subject.create(ctx, environmentId, subjectId, createRequest)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package roles_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/roles"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveRoleAssignmentHandler(t *testing.T) {
+	tests := []struct {
+		subjectType string
+		subjectId   uuid.UUID
+		method      string
+	}{
+		{subjectType: "USER", subjectId: testUserId, method: "User"},
+		{subjectType: "group", subjectId: testGroupId, method: "Group"},
+		{subjectType: "APPLICATION", subjectId: testApplicationId, method: "Application"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subjectType, func(t *testing.T) {
+			assignment := environmentRoleAssignment(testRoleAssignmentId.String(), false)
+			mockClient := &mockPingOneClientRolesWrapper{}
+			mockClient.On("Get"+tt.method+"RoleAssignment", mock.Anything, testEnvironmentId, tt.subjectId.String(), testRoleAssignmentId.String()).Return(&assignment, &http.Response{StatusCode: 200}, nil)
+			mockClient.On("Delete"+tt.method+"RoleAssignment", mock.Anything, testEnvironmentId, tt.subjectId.String(), testRoleAssignmentId.String()).Return(&http.Response{StatusCode: 204}, nil)
+
+			handler := roles.RemoveRoleAssignmentHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.RemoveRoleAssignmentInput{
+				EnvironmentId:    testEnvironmentId,
+				SubjectType:      tt.subjectType,
+				SubjectId:        tt.subjectId,
+				RoleAssignmentId: testRoleAssignmentId,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, strings.ToUpper(tt.subjectType), output.SubjectType)
+			assert.Equal(t, tt.subjectId.String(), output.SubjectId)
+			assert.Equal(t, testRoleAssignmentId.String(), output.RoleAssignment.Id)
+			assert.Equal(t, testRoleId.String(), output.RoleAssignment.RoleId)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveRoleAssignmentHandler_Errors(t *testing.T) {
+	readOnlyAssignment := environmentRoleAssignment(testRoleAssignmentId.String(), true)
+	tests := []struct {
+		name            string
+		subjectType     string
+		setupMock       func(mockClient *mockPingOneClientRolesWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Invalid subject type",
+			subjectType:     "POPULATION",
+			wantErrContains: "invalid subject type \"POPULATION\"",
+		},
+		{
+			name:        "Get role assignment error",
+			subjectType: "USER",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("GetUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), testRoleAssignmentId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("role assignment not found"))
+			},
+			wantErrContains: "role assignment not found",
+		},
+		{
+			name:        "No role assignment data in response",
+			subjectType: "USER",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("GetUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), testRoleAssignmentId.String()).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no role assignment data in response",
+		},
+		{
+			name:        "Read-only role assignment",
+			subjectType: "USER",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				mockClient.On("GetUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), testRoleAssignmentId.String()).Return(&readOnlyAssignment, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "is read-only and cannot be removed by the current actor",
+		},
+		{
+			name:        "Delete error",
+			subjectType: "USER",
+			setupMock: func(mockClient *mockPingOneClientRolesWrapper) {
+				assignment := environmentRoleAssignment(testRoleAssignmentId.String(), false)
+				mockClient.On("GetUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), testRoleAssignmentId.String()).Return(&assignment, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("DeleteUserRoleAssignment", mock.Anything, testEnvironmentId, testUserId.String(), testRoleAssignmentId.String()).Return(&http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientRolesWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			handler := roles.RemoveRoleAssignmentHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.RemoveRoleAssignmentInput{
+				EnvironmentId:    testEnvironmentId,
+				SubjectType:      tt.subjectType,
+				SubjectId:        testUserId,
+				RoleAssignmentId: testRoleAssignmentId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRemoveRoleAssignmentHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := roles.RemoveRoleAssignmentHandler(NewMockPingOneClientRolesWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, roles.RemoveRoleAssignmentInput{
+		EnvironmentId:    testEnvironmentId,
+		SubjectType:      "USER",
+		SubjectId:        testUserId,
+		RoleAssignmentId: testRoleAssignmentId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestRemoveRoleAssignmentHandler_RecordsUndo(t *testing.T) {
+	assignment := environmentRoleAssignment(testRoleAssignmentId.String(), false)
+	assignment.Scope = management.RoleAssignmentScope{Id: testPopulationId.String(), Type: management.ENUMROLEASSIGNMENTSCOPETYPE_POPULATION}
+	mockClient := &mockPingOneClientRolesWrapper{}
+	mockClient.On("GetGroupRoleAssignment", mock.Anything, testEnvironmentId, testGroupId.String(), testRoleAssignmentId.String()).Return(&assignment, &http.Response{StatusCode: 200}, nil)
+	mockClient.On("DeleteGroupRoleAssignment", mock.Anything, testEnvironmentId, testGroupId.String(), testRoleAssignmentId.String()).Return(&http.Response{StatusCode: 204}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := roles.RemoveRoleAssignmentHandler(NewMockPingOneClientRolesWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, roles.RemoveRoleAssignmentInput{
+		EnvironmentId:    testEnvironmentId,
+		SubjectType:      "GROUP",
+		SubjectId:        testGroupId,
+		RoleAssignmentId: testRoleAssignmentId,
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// Undoing the removal assigns the same role over the same scope again
+	recreated := management.RoleAssignment{Role: assignment.Role, Scope: assignment.Scope}
+	mockClient.On("CreateGroupRoleAssignment", mock.Anything, testEnvironmentId, testGroupId.String(), recreated).Return(&assignment, &http.Response{StatusCode: 201}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
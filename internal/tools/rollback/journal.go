@@ -0,0 +1,223 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package rollback keeps a journal of recent changes made through the server, with the state each change
+// replaced, so that an agent can undo a change it made by accident.
+package rollback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+)
+
+// DefaultMaxChanges is the number of changes the journal keeps before the oldest are forgotten.
+const DefaultMaxChanges = 100
+
+// ErrChangedSince is returned by an UndoFunc when the resource has been changed again since the change was
+// made, so that restoring the previous state would also discard the later changes.
+var ErrChangedSince = errors.New("the resource has been changed since, and restoring its previous state would discard the later changes; undo with force to restore it anyway")
+
+// UndoFunc restores the state that a change replaced. Unless force is set, it returns ErrChangedSince if the
+// resource has been changed again since.
+type UndoFunc func(ctx context.Context, force bool) error
+
+// Change is an undoable change made by a tool call.
+type Change struct {
+	Id            string    `json:"id" jsonschema:"The ID of the change, to undo it with changeId"`
+	Tool          string    `json:"tool" jsonschema:"The tool that made the change"`
+	EnvironmentId string    `json:"environmentId" jsonschema:"The UUID of the environment that was changed"`
+	ResourceType  string    `json:"resourceType" jsonschema:"The type of the changed resource, such as population"`
+	ResourceId    string    `json:"resourceId" jsonschema:"The UUID of the changed resource"`
+	Description   string    `json:"description" jsonschema:"What undoing the change restores"`
+	ChangedAt     time.Time `json:"changedAt" jsonschema:"When the change was made"`
+	Principal     string    `json:"principal,omitempty" jsonschema:"The principal of the PingOne session that made the change"`
+}
+
+type journalEntry struct {
+	change  Change
+	undo    UndoFunc
+	undoing bool
+}
+
+// Journal holds the most recent undoable changes in memory. Changes are lost when the server restarts.
+type Journal struct {
+	mutex      sync.Mutex
+	maxChanges int
+	// entries are ordered oldest first
+	entries []*journalEntry
+}
+
+// NewJournal creates a journal that keeps up to maxChanges changes.
+func NewJournal(maxChanges int) *Journal {
+	if maxChanges <= 0 {
+		maxChanges = DefaultMaxChanges
+	}
+	return &Journal{
+		maxChanges: maxChanges,
+	}
+}
+
+// Record adds a change and the function that undoes it to the journal, and returns the change with the ID, time
+// and principal set by the journal. Recording to a nil journal does nothing and returns a change without an ID.
+func (j *Journal) Record(ctx context.Context, change Change, undo UndoFunc) Change {
+	if j == nil || undo == nil {
+		return Change{}
+	}
+	change.Id = uuid.NewString()
+	change.ChangedAt = time.Now().UTC()
+	change.Principal = audit.PrincipalFromContext(ctx)
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.entries = append(j.entries, &journalEntry{change: change, undo: undo})
+	if len(j.entries) > j.maxChanges {
+		j.entries = slices.Delete(j.entries, 0, len(j.entries)-j.maxChanges)
+	}
+	return change
+}
+
+// Changes returns the undoable changes to an environment, most recent first.
+func (j *Journal) Changes(environmentId string) []Change {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	changes := []Change{}
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		if j.entries[i].change.EnvironmentId == environmentId {
+			changes = append(changes, j.entries[i].change)
+		}
+	}
+	return changes
+}
+
+// Find returns the change to an environment with the given ID, or the most recent change to the environment
+// if changeId is empty.
+func (j *Journal) Find(environmentId string, changeId string) (Change, error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	entry, err := j.find(environmentId, changeId)
+	if err != nil {
+		return Change{}, err
+	}
+	return entry.change, nil
+}
+
+// Undo undoes the change to an environment with the given ID, or the most recent change to the environment
+// if changeId is empty, and removes it from the journal. A change that fails to be undone is kept.
+func (j *Journal) Undo(ctx context.Context, environmentId string, changeId string, force bool) (Change, error) {
+	j.mutex.Lock()
+	entry, err := j.find(environmentId, changeId)
+	if err == nil && entry.undoing {
+		err = fmt.Errorf("change %s is already being undone", entry.change.Id)
+	}
+	if err != nil {
+		j.mutex.Unlock()
+		return Change{}, err
+	}
+	entry.undoing = true
+	j.mutex.Unlock()
+
+	err = entry.undo(ctx, force)
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	entry.undoing = false
+	if err != nil {
+		return entry.change, err
+	}
+	j.entries = slices.DeleteFunc(j.entries, func(e *journalEntry) bool {
+		return e == entry
+	})
+	return entry.change, nil
+}
+
+// Clear removes all changes, such as when the server switches to another PingOne profile whose session
+// cannot undo them.
+func (j *Journal) Clear() {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.entries = nil
+}
+
+func (j *Journal) find(environmentId string, changeId string) (*journalEntry, error) {
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		entry := j.entries[i]
+		if entry.change.EnvironmentId != environmentId {
+			continue
+		}
+		if changeId == "" || entry.change.Id == changeId {
+			return entry, nil
+		}
+	}
+	if changeId != "" {
+		return nil, fmt.Errorf("no undoable change %s found in environment %s; it may have been undone already, or forgotten as older than the last %d changes", changeId, environmentId, j.maxChanges)
+	}
+	return nil, fmt.Errorf("no undoable changes found in environment %s", environmentId)
+}
+
+type journalContextKey struct{}
+
+// ContextWithJournal returns a context that tool handlers record their undoable changes to.
+func ContextWithJournal(ctx context.Context, journal *Journal) context.Context {
+	return context.WithValue(ctx, journalContextKey{}, journal)
+}
+
+// JournalFromContext returns the journal of the context, or nil if changes are not recorded.
+func JournalFromContext(ctx context.Context) *Journal {
+	if ctx == nil {
+		return nil
+	}
+	journal, _ := ctx.Value(journalContextKey{}).(*Journal)
+	return journal
+}
+
+// Record adds an undoable change to the journal of the context, if there is one, and to the change recorder of
+// the context, if there is one.
+func Record(ctx context.Context, change Change, undo UndoFunc) {
+	recorded := JournalFromContext(ctx).Record(ctx, change, undo)
+	if recorded.Id == "" {
+		return
+	}
+	if recorder, ok := ctx.Value(changeRecorderContextKey{}).(*ChangeRecorder); ok {
+		recorder.add(recorded)
+	}
+}
+
+// IsRecording reports whether the context has a journal, so that tool handlers only fetch the state a change
+// replaces when it can be recorded.
+func IsRecording(ctx context.Context) bool {
+	return JournalFromContext(ctx) != nil
+}
+
+type changeRecorderContextKey struct{}
+
+// ChangeRecorder collects the undoable changes recorded by a tool call, so that the caller can undo them, such
+// as when a later step of a plan fails.
+type ChangeRecorder struct {
+	mutex   sync.Mutex
+	changes []Change
+}
+
+// ContextWithChangeRecorder returns a context whose recorded changes are also collected by the returned recorder.
+func ContextWithChangeRecorder(ctx context.Context) (context.Context, *ChangeRecorder) {
+	recorder := &ChangeRecorder{}
+	return context.WithValue(ctx, changeRecorderContextKey{}, recorder), recorder
+}
+
+// Changes returns the changes collected by the recorder, oldest first.
+func (r *ChangeRecorder) Changes() []Change {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return slices.Clone(r.changes)
+}
+
+func (r *ChangeRecorder) add(change Change) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.changes = append(r.changes, change)
+}
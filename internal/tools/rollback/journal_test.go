@@ -0,0 +1,178 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package rollback_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordTestChange records a change of a resource that counts the times it was undone
+func recordTestChange(ctx context.Context, journal *rollback.Journal, environmentId string, resourceId string, undoErr error, undoCount *int) {
+	journal.Record(ctx, rollback.Change{
+		Tool:          "update_thing",
+		EnvironmentId: environmentId,
+		ResourceType:  "thing",
+		ResourceId:    resourceId,
+	}, func(ctx context.Context, force bool) error {
+		if undoErr != nil && !force {
+			return undoErr
+		}
+		*undoCount++
+		return nil
+	})
+}
+
+func resourceIds(changes []rollback.Change) []string {
+	ids := []string{}
+	for _, change := range changes {
+		ids = append(ids, change.ResourceId)
+	}
+	return ids
+}
+
+func TestJournal_Record(t *testing.T) {
+	journal := rollback.NewJournal(3)
+	ctx := audit.ContextWithPrincipal(context.Background(), "admin@example.com")
+	undoCount := 0
+	for _, resourceId := range []string{"thing-1", "thing-2", "thing-3", "thing-4"} {
+		recordTestChange(ctx, journal, "env-a", resourceId, nil, &undoCount)
+	}
+	recordTestChange(ctx, journal, "env-b", "thing-5", nil, &undoCount)
+
+	changes := journal.Changes("env-a")
+	assert.Equal(t, []string{"thing-4", "thing-3"}, resourceIds(changes), "oldest changes should be forgotten")
+	assert.NotEmpty(t, changes[0].Id)
+	assert.NotEqual(t, changes[0].Id, changes[1].Id)
+	assert.False(t, changes[0].ChangedAt.IsZero())
+	assert.Equal(t, "admin@example.com", changes[0].Principal)
+	assert.Equal(t, []string{"thing-5"}, resourceIds(journal.Changes("env-b")))
+	assert.Empty(t, journal.Changes("env-c"))
+}
+
+func TestJournal_Record_NilJournal(t *testing.T) {
+	var journal *rollback.Journal
+	undoCount := 0
+	assert.NotPanics(t, func() {
+		recordTestChange(context.Background(), journal, "env-a", "thing-1", nil, &undoCount)
+	})
+}
+
+func TestJournal_Undo(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	undoCount := 0
+	recordTestChange(context.Background(), journal, "env-a", "thing-1", nil, &undoCount)
+	recordTestChange(context.Background(), journal, "env-a", "thing-2", nil, &undoCount)
+
+	change, err := journal.Undo(context.Background(), "env-a", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "thing-2", change.ResourceId, "the most recent change should be undone")
+	assert.Equal(t, 1, undoCount)
+
+	change, err = journal.Undo(context.Background(), "env-a", "", false)
+	require.NoError(t, err)
+	assert.Equal(t, "thing-1", change.ResourceId)
+	assert.Equal(t, 2, undoCount)
+
+	_, err = journal.Undo(context.Background(), "env-a", "", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no undoable changes found in environment env-a")
+}
+
+func TestJournal_Undo_ByChangeId(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	undoCount := 0
+	recordTestChange(context.Background(), journal, "env-a", "thing-1", nil, &undoCount)
+	recordTestChange(context.Background(), journal, "env-a", "thing-2", nil, &undoCount)
+	recordTestChange(context.Background(), journal, "env-b", "thing-3", nil, &undoCount)
+	first := journal.Changes("env-a")[1]
+
+	_, err := journal.Undo(context.Background(), "env-b", first.Id, false)
+	require.Error(t, err, "a change should only be undone in its environment")
+	assert.Contains(t, err.Error(), "no undoable change "+first.Id+" found in environment env-b")
+
+	change, err := journal.Undo(context.Background(), "env-a", first.Id, false)
+	require.NoError(t, err)
+	assert.Equal(t, first, change)
+	assert.Equal(t, []string{"thing-2"}, resourceIds(journal.Changes("env-a")))
+}
+
+func TestJournal_Undo_FailedChangesAreKept(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	undoCount := 0
+	recordTestChange(context.Background(), journal, "env-a", "thing-1", rollback.ErrChangedSince, &undoCount)
+
+	_, err := journal.Undo(context.Background(), "env-a", "", false)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, rollback.ErrChangedSince))
+	assert.Equal(t, 0, undoCount)
+	assert.Len(t, journal.Changes("env-a"), 1)
+
+	_, err = journal.Undo(context.Background(), "env-a", "", true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, undoCount)
+	assert.Empty(t, journal.Changes("env-a"))
+}
+
+func TestJournal_Clear(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	undoCount := 0
+	recordTestChange(context.Background(), journal, "env-a", "thing-1", nil, &undoCount)
+
+	journal.Clear()
+
+	assert.Empty(t, journal.Changes("env-a"))
+}
+
+func TestChangeRecorder(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+	undo := func(ctx context.Context, force bool) error { return nil }
+
+	rollback.Record(ctx, rollback.Change{EnvironmentId: "env-a", ResourceId: "thing-1"}, undo)
+	recorderCtx, recorder := rollback.ContextWithChangeRecorder(ctx)
+	rollback.Record(recorderCtx, rollback.Change{EnvironmentId: "env-a", ResourceId: "thing-2"}, undo)
+	rollback.Record(recorderCtx, rollback.Change{EnvironmentId: "env-a", ResourceId: "thing-3"}, undo)
+
+	changes := recorder.Changes()
+	assert.Equal(t, []string{"thing-2", "thing-3"}, resourceIds(changes), "only changes recorded with the recorder should be collected")
+	assert.NotEmpty(t, changes[0].Id)
+	assert.Equal(t, []string{"thing-3", "thing-2", "thing-1"}, resourceIds(journal.Changes("env-a")))
+
+	_, recorder = rollback.ContextWithChangeRecorder(context.Background())
+	rollback.Record(recorderCtx, rollback.Change{EnvironmentId: "env-a", ResourceId: "thing-4"}, undo)
+	assert.Empty(t, recorder.Changes())
+
+	withoutJournalCtx, recorder := rollback.ContextWithChangeRecorder(context.Background())
+	rollback.Record(withoutJournalCtx, rollback.Change{EnvironmentId: "env-a", ResourceId: "thing-5"}, undo)
+	assert.Empty(t, recorder.Changes(), "changes that are not journaled cannot be undone and should not be collected")
+}
+
+func TestJournalMiddleware(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	var handlerJournal *rollback.Journal
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		handlerJournal = rollback.JournalFromContext(ctx)
+		return &mcp.CallToolResult{}, nil
+	}
+
+	_, err := rollback.NewJournalMiddleware(journal).Handler(next)(context.Background(), "tools/call", &mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Same(t, journal, handlerJournal)
+
+	_, err = rollback.NewJournalMiddleware(journal).Handler(next)(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, handlerJournal, "only tool calls should record changes")
+
+	_, err = rollback.NewJournalMiddleware(nil).Handler(next)(context.Background(), "tools/call", &mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Nil(t, handlerJournal)
+	assert.False(t, rollback.IsRecording(context.Background()))
+}
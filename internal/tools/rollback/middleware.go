@@ -0,0 +1,35 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package rollback
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// JournalMiddleware adds the journal to the context of tool calls, so that tool handlers can record the
+// state their changes replace.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type JournalMiddleware struct {
+	journal *Journal
+}
+
+// NewJournalMiddleware creates middleware that records undoable changes to the journal.
+// A nil journal records nothing.
+func NewJournalMiddleware(journal *Journal) *JournalMiddleware {
+	return &JournalMiddleware{
+		journal: journal,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *JournalMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.journal == nil {
+			return next(ctx, method, req)
+		}
+		return next(ContextWithJournal(ctx, m.journal), method, req)
+	}
+}
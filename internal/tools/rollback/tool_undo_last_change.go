@@ -0,0 +1,107 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package rollback
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UndoLastChangeDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "undo_last_change",
+		Title: "Undo Last Change",
+		Description: `Undo a recent change made through this MCP server by restoring the state it replaced. By default the most recent undoable change in the environment is undone; pass changeId to undo an earlier one. Each undone change is removed, so calling this tool repeatedly steps back through the changes of the environment. Run with dryRun first to see which change would be undone and the other undoable changes.
+
+Undoable changes: update_population and update_environment restore the previous configuration (an environment promoted to PRODUCTION stays PRODUCTION), update_environment_services, add_environment_service and remove_environment_service restore the previous services, reassign_environment_license moves the environment back to its previous license, set_user_enabled restores whether the user was enabled, assign_role_to_user, assign_role_to_group and assign_role_to_application are undone by removing the role assignment, remove_role_assignment assigns the same role over the same scope again, update_identity_provider_attribute_mapping restores the previous value of the mapping, delete_identity_provider_attribute_mapping creates the same mapping again, update_theme restores the previous template and configuration of the theme, activate_theme activates the previously active theme again, update_agreement restores the previous name, description and reconsent period of the agreement, set_agreement_enabled restores whether the agreement was enabled, and schedule_environment_deletion is undone by cancelling the deletion. Created resources, deletions that have already run and other changes cannot be undone.
+
+If the resource has been changed again since, the change is not undone, as that would also discard the later change; pass force to restore it anyway. Changes are kept in memory for the last 100 changes, and are lost when the server restarts or switches profile.`,
+		InputSchema:  schema.MustGenerateSchema[UndoLastChangeInput](),
+		OutputSchema: schema.MustGenerateSchema[UndoLastChangeOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type UndoLastChangeInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. The UUID of the environment whose change to undo."`
+	ChangeId      *string   `json:"changeId,omitempty" jsonschema:"OPTIONAL. The ID of the change to undo. Defaults to the most recent undoable change in the environment."`
+	Force         *bool     `json:"force,omitempty" jsonschema:"OPTIONAL. Restore the previous state even if the resource has been changed again since, discarding the later changes. Defaults to false."`
+	DryRun        *bool     `json:"dryRun,omitempty" jsonschema:"OPTIONAL. Return the change that would be undone without undoing it. Defaults to false."`
+}
+
+type UndoLastChangeOutput struct {
+	Change           Change   `json:"change" jsonschema:"The change that was undone, or would be undone in a dry run"`
+	Undone           bool     `json:"undone" jsonschema:"True if the change was undone, false in a dry run"`
+	RemainingChanges []Change `json:"remainingChanges" jsonschema:"The undoable changes still in the environment, most recent first"`
+}
+
+// UndoLastChangeHandler undoes the changes recorded in the journal
+func UndoLastChangeHandler(journal *Journal) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UndoLastChangeInput,
+) (
+	*mcp.CallToolResult,
+	*UndoLastChangeOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UndoLastChangeInput) (*mcp.CallToolResult, *UndoLastChangeOutput, error) {
+		environmentId := input.EnvironmentId.String()
+		changeId := ""
+		if input.ChangeId != nil {
+			changeId = *input.ChangeId
+		}
+
+		if input.DryRun != nil && *input.DryRun {
+			change, err := journal.Find(environmentId, changeId)
+			if err != nil {
+				toolErr := errs.NewToolError(UndoLastChangeDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			remaining := slices.DeleteFunc(journal.Changes(environmentId), func(c Change) bool {
+				return c.Id == change.Id
+			})
+			return nil, &UndoLastChangeOutput{
+				Change:           change,
+				RemainingChanges: remaining,
+			}, nil
+		}
+
+		force := input.Force != nil && *input.Force
+		change, err := journal.Undo(ctx, environmentId, changeId, force)
+		if err != nil {
+			toolErr := errs.NewToolError(UndoLastChangeDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Info("Change undone",
+			slog.String("changeId", change.Id),
+			slog.String("tool", change.Tool),
+			slog.String("environmentId", change.EnvironmentId),
+			slog.String("resourceId", change.ResourceId),
+			slog.Bool("force", force))
+
+		return nil, &UndoLastChangeOutput{
+			Change:           change,
+			Undone:           true,
+			RemainingChanges: journal.Changes(environmentId),
+		}, nil
+	}
+}
+
+// RegisterUndoLastChangeTool adds the undo_last_change tool to the MCP server.
+func RegisterUndoLastChangeTool(server *mcp.Server, journal *Journal) {
+	mcp.AddTool(server, UndoLastChangeDef.McpTool, UndoLastChangeHandler(journal))
+}
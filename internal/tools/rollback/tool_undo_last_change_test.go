@@ -0,0 +1,108 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package rollback_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+
+func TestUndoLastChangeHandler(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	undoCount := 0
+	recordTestChange(context.Background(), journal, testEnvironmentId.String(), "thing-1", nil, &undoCount)
+	recordTestChange(context.Background(), journal, testEnvironmentId.String(), "thing-2", nil, &undoCount)
+	handler := rollback.UndoLastChangeHandler(journal)
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, rollback.UndoLastChangeInput{
+		EnvironmentId: testEnvironmentId,
+		DryRun:        testutils.Pointer(true),
+	})
+	require.NoError(t, err)
+	assert.Nil(t, mcpResult)
+	require.NotNil(t, output)
+	assert.Equal(t, "thing-2", output.Change.ResourceId)
+	assert.False(t, output.Undone)
+	assert.Equal(t, []string{"thing-1"}, resourceIds(output.RemainingChanges))
+	assert.Equal(t, 0, undoCount, "a dry run should not undo the change")
+
+	_, output, err = handler(context.Background(), &mcp.CallToolRequest{}, rollback.UndoLastChangeInput{
+		EnvironmentId: testEnvironmentId,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.Equal(t, "thing-2", output.Change.ResourceId)
+	assert.True(t, output.Undone)
+	assert.Equal(t, []string{"thing-1"}, resourceIds(output.RemainingChanges))
+	assert.Equal(t, 1, undoCount)
+}
+
+func TestUndoLastChangeHandler_Errors(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	undoCount := 0
+	recordTestChange(context.Background(), journal, testEnvironmentId.String(), "thing-1", rollback.ErrChangedSince, &undoCount)
+	handler := rollback.UndoLastChangeHandler(journal)
+
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, rollback.UndoLastChangeInput{
+		EnvironmentId: testEnvironmentId,
+	})
+	require.Error(t, err)
+	assert.Nil(t, output)
+	assert.Contains(t, err.Error(), "undo with force")
+
+	_, output, err = handler(context.Background(), &mcp.CallToolRequest{}, rollback.UndoLastChangeInput{
+		EnvironmentId: testEnvironmentId,
+		ChangeId:      testutils.Pointer("unknown"),
+		DryRun:        testutils.Pointer(true),
+	})
+	require.Error(t, err)
+	assert.Nil(t, output)
+	assert.Contains(t, err.Error(), "no undoable change unknown found")
+
+	_, output, err = handler(context.Background(), &mcp.CallToolRequest{}, rollback.UndoLastChangeInput{
+		EnvironmentId: testEnvironmentId,
+		Force:         testutils.Pointer(true),
+	})
+	require.NoError(t, err)
+	assert.True(t, output.Undone)
+	assert.Equal(t, 1, undoCount)
+}
+
+func TestUndoLastChangeHandler_OverMcp(t *testing.T) {
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	undoCount := 0
+	recordTestChange(context.Background(), journal, testEnvironmentId.String(), "thing-1", nil, &undoCount)
+	server := mcptestutils.TestMcpServer(t)
+	rollback.RegisterUndoLastChangeTool(server, journal)
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, rollback.UndoLastChangeDef.McpTool.Name, map[string]any{
+		"environmentId": testEnvironmentId.String(),
+	})
+	testutils.AssertMcpCallSuccess(t, err, output)
+
+	result := &rollback.UndoLastChangeOutput{}
+	jsonBytes, err := json.Marshal(output.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsonBytes, result))
+
+	assert.True(t, result.Undone)
+	assert.Equal(t, "thing-1", result.Change.ResourceId)
+	assert.Empty(t, result.RemainingChanges)
+	assert.Equal(t, 1, undoCount)
+}
+
+func TestUndoLastChangeDef(t *testing.T) {
+	assert.False(t, rollback.UndoLastChangeDef.IsReadOnly())
+	assert.NotContains(t, rollback.UndoLastChangeDef.McpTool.Name, "pingone")
+}
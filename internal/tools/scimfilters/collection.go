@@ -0,0 +1,43 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scimfilters
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "scim_filters"
+
+var _ collections.LegacySdkCollection = &ScimFiltersCollection{}
+
+type ScimFiltersCollection struct{}
+
+func (c *ScimFiltersCollection) Name() string {
+	return CollectionName
+}
+
+// RegisterTools registers the SCIM filter tools. The tools do not call PingOne, so the client factory and token
+// store are not used.
+func (c *ScimFiltersCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if toolFilter.ShouldIncludeTool(&BuildScimFilterDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", BuildScimFilterDef.McpTool.Name))
+		mcp.AddTool(server, BuildScimFilterDef.McpTool, BuildScimFilterHandler())
+	}
+
+	return nil
+}
+
+func (c *ScimFiltersCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		BuildScimFilterDef,
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scimfilters
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/populations"
+)
+
+const (
+	ResourceTypeEnvironment = "ENVIRONMENT"
+	ResourceTypePopulation  = "POPULATION"
+	ResourceTypeAuditEvent  = "AUDIT_EVENT"
+
+	ConjunctionAnd = "and"
+	ConjunctionOr  = "or"
+)
+
+// operators are the SCIM filter operators. Operators other than 'pr' (present) compare the attribute with a value.
+var operators = []string{"eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le", "pr"}
+
+var attributePathRegexp = regexp.MustCompile(`^[A-Za-z][\w]*(\.[A-Za-z][\w]*)*$`)
+
+// resourceFilterRules are the filter rules of a resource type and the tool that accepts its filters
+type resourceFilterRules struct {
+	// toolName is the tool that accepts the filter
+	toolName string
+	rules    scimfilter.Rules
+	// attributeWarnings are warnings for attributes that are supported, but that the tool already filters by
+	attributeWarnings map[string]string
+}
+
+var resourceTypes = map[string]resourceFilterRules{
+	ResourceTypeEnvironment: {
+		toolName: environments.ListEnvironmentsDef.McpTool.Name,
+		rules:    scimfilter.Environments,
+	},
+	ResourceTypePopulation: {
+		toolName: populations.ListPopulationsDef.McpTool.Name,
+		rules:    scimfilter.Populations,
+	},
+	ResourceTypeAuditEvent: {
+		toolName: audit.QueryAuditEventsDef.McpTool.Name,
+		rules:    scimfilter.AuditActivities,
+		attributeWarnings: map[string]string{
+			"recordedAt": "the time range of query_audit_events is set with its startTime and endTime inputs; a recordedAt condition can only narrow it",
+		},
+	},
+}
+
+// resourceTypeNames returns the supported resource types, sorted
+func resourceTypeNames() []string {
+	names := []string{}
+	for name := range resourceTypes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// buildFilter returns the SCIM filter of the conditions, with warnings for the conditions PingOne does not support
+// for the resource type. Conditions that are not valid SCIM are errors.
+func buildFilter(resourceType string, conditions []FilterCondition) (string, []string, error) {
+	rules, ok := resourceTypes[resourceType]
+	if !ok {
+		return "", nil, fmt.Errorf("resourceType must be one of %s", strings.Join(resourceTypeNames(), ", "))
+	}
+	if len(conditions) == 0 {
+		return "", nil, errors.New("at least one condition is required")
+	}
+
+	var b strings.Builder
+	var validationErrs []error
+	warnings := []string{}
+	for i, condition := range conditions {
+		clause, err := conditionClause(condition)
+		if err != nil {
+			validationErrs = append(validationErrs, fmt.Errorf("condition %d: %w", i+1, err))
+			continue
+		}
+		if i > 0 {
+			conjunction := ConjunctionAnd
+			if condition.Conjunction != nil {
+				conjunction = strings.ToLower(strings.TrimSpace(*condition.Conjunction))
+			}
+			if conjunction != ConjunctionAnd && conjunction != ConjunctionOr {
+				validationErrs = append(validationErrs, fmt.Errorf("condition %d: conjunction must be 'and' or 'or'", i+1))
+				continue
+			}
+			if err := rules.rules.CheckLogicalOperator(conjunction); err != nil {
+				warnings = append(warnings, fmt.Sprintf("condition %d: %s", i+1, err))
+			}
+			fmt.Fprintf(&b, " %s ", conjunction)
+		}
+		b.WriteString(clause)
+		warnings = append(warnings, conditionWarnings(rules, i+1, condition)...)
+	}
+	if err := errors.Join(validationErrs...); err != nil {
+		return "", nil, err
+	}
+	return b.String(), warnings, nil
+}
+
+// conditionClause returns the SCIM comparison of the condition
+func conditionClause(condition FilterCondition) (string, error) {
+	attribute := strings.TrimSpace(condition.Attribute)
+	if !attributePathRegexp.MatchString(attribute) {
+		return "", fmt.Errorf("attribute %q must be a dot-separated attribute path, such as 'name' or 'license.id'", condition.Attribute)
+	}
+	operator := strings.ToLower(strings.TrimSpace(condition.Operator))
+	if !slices.Contains(operators, operator) {
+		return "", fmt.Errorf("operator %q must be one of %s", condition.Operator, strings.Join(operators, ", "))
+	}
+	if operator == "pr" {
+		if condition.Value != nil {
+			return "", errors.New("operator 'pr' does not take a value")
+		}
+		return fmt.Sprintf("%s pr", attribute), nil
+	}
+
+	var value string
+	switch v := condition.Value.(type) {
+	case string:
+		value = scimString(v)
+	case bool:
+		value = strconv.FormatBool(v)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return "", fmt.Errorf("operator '%s' requires a value", operator)
+	default:
+		return "", errors.New("value must be a string, number or boolean")
+	}
+	return fmt.Sprintf("%s %s %s", attribute, operator, value), nil
+}
+
+// conditionWarnings returns warnings for a condition that PingOne does not support for the resource type
+func conditionWarnings(rules resourceFilterRules, number int, condition FilterCondition) []string {
+	attribute := strings.TrimSpace(condition.Attribute)
+	warnings := []string{}
+	if err := rules.rules.CheckComparison(attribute, strings.TrimSpace(condition.Operator)); err != nil {
+		warnings = append(warnings, fmt.Sprintf("condition %d: %s", number, err))
+	}
+	if warning, ok := rules.attributeWarnings[attribute]; ok {
+		warnings = append(warnings, fmt.Sprintf("condition %d: %s", number, warning))
+	}
+	return warnings
+}
+
+// scimString quotes a value for use in a SCIM filter
+func scimString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scimfilters
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var BuildScimFilterDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "build_scim_filter",
+		Title: "Build SCIM Filter",
+		Description: `Build a SCIM filter for the 'filter' input of list_environments (ENVIRONMENT), list_populations (POPULATION) or query_audit_events (AUDIT_EVENT) from structured conditions, instead of writing the filter by hand. Values are quoted and escaped, and conditions that are not valid SCIM are rejected.
+Warnings list the attributes, operators and conjunctions that the tool for the resource type does not support, which the tool rejects. Conditions are joined in order; as in SCIM, 'and' binds more tightly than 'or'. Does not call PingOne.`,
+		InputSchema:  schema.MustGenerateSchema[BuildScimFilterInput](),
+		OutputSchema: schema.MustGenerateSchema[BuildScimFilterOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type BuildScimFilterInput struct {
+	ResourceType string            `json:"resourceType" jsonschema:"REQUIRED. The resource type the filter is for: ENVIRONMENT, POPULATION or AUDIT_EVENT."`
+	Conditions   []FilterCondition `json:"conditions" jsonschema:"REQUIRED. The conditions of the filter, in order."`
+}
+
+// FilterCondition is a single comparison of a SCIM filter
+type FilterCondition struct {
+	Attribute   string  `json:"attribute" jsonschema:"REQUIRED. The dot-separated attribute path, such as 'name' or 'license.id'."`
+	Operator    string  `json:"operator" jsonschema:"REQUIRED. The SCIM operator: eq (equals), ne (not equals), co (contains), sw (starts with), ew (ends with), gt, ge, lt, le (greater or less than), or pr (present, without a value)."`
+	Value       any     `json:"value,omitempty" jsonschema:"The string, number or boolean value to compare with. Required for all operators but pr."`
+	Conjunction *string `json:"conjunction,omitempty" jsonschema:"OPTIONAL. How the condition is joined to the previous condition: 'and' or 'or'. Defaults to 'and'. Ignored for the first condition."`
+}
+
+type BuildScimFilterOutput struct {
+	Filter   string   `json:"filter" jsonschema:"The SCIM filter"`
+	Tool     string   `json:"tool" jsonschema:"The tool that accepts the filter in its 'filter' input"`
+	Warnings []string `json:"warnings" jsonschema:"The conditions of the filter that the tool does not support, which the tool rejects. Empty if the filter is supported."`
+}
+
+// BuildScimFilterHandler builds a SCIM filter from structured conditions, without calling PingOne
+func BuildScimFilterHandler() func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input BuildScimFilterInput,
+) (
+	*mcp.CallToolResult,
+	*BuildScimFilterOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BuildScimFilterInput) (*mcp.CallToolResult, *BuildScimFilterOutput, error) {
+		filter, warnings, err := buildFilter(input.ResourceType, input.Conditions)
+		if err != nil {
+			toolErr := errs.NewToolError(BuildScimFilterDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Built SCIM filter",
+			slog.String("resourceType", input.ResourceType),
+			slog.String("filter", filter),
+			slog.Int("warnings", len(warnings)))
+
+		return nil, &BuildScimFilterOutput{
+			Filter:   filter,
+			Tool:     resourceTypes[input.ResourceType].toolName,
+			Warnings: warnings,
+		}, nil
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package scimfilters_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/scimfilters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildScimFilterHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        scimfilters.BuildScimFilterInput
+		wantFilter   string
+		wantTool     string
+		wantWarnings []string
+	}{
+		{
+			name: "supported environment filter",
+			input: scimfilters.BuildScimFilterInput{
+				ResourceType: scimfilters.ResourceTypeEnvironment,
+				Conditions: []scimfilters.FilterCondition{
+					{Attribute: "name", Operator: "sw", Value: `Dev "EU"`},
+					{Attribute: "license.id", Operator: "EQ", Value: "11111111-1111-4111-8111-111111111111", Conjunction: testutils.Pointer("AND")},
+				},
+			},
+			wantFilter:   `name sw "Dev \"EU\"" and license.id eq "11111111-1111-4111-8111-111111111111"`,
+			wantTool:     "list_environments",
+			wantWarnings: []string{},
+		},
+		{
+			name: "unsupported environment conjunction and operator",
+			input: scimfilters.BuildScimFilterInput{
+				ResourceType: scimfilters.ResourceTypeEnvironment,
+				Conditions: []scimfilters.FilterCondition{
+					{Attribute: "name", Operator: "co", Value: "Dev"},
+					{Attribute: "status", Operator: "eq", Value: "ACTIVE", Conjunction: testutils.Pointer("or")},
+				},
+			},
+			wantFilter: `name co "Dev" or status eq "ACTIVE"`,
+			wantTool:   "list_environments",
+			wantWarnings: []string{
+				"condition 1: 'name' only supports sw",
+				"condition 2: 'or' is not supported; supported operators to combine conditions are and",
+			},
+		},
+		{
+			name: "unsupported population attributes",
+			input: scimfilters.BuildScimFilterInput{
+				ResourceType: scimfilters.ResourceTypePopulation,
+				Conditions: []scimfilters.FilterCondition{
+					{Attribute: "default", Operator: "eq", Value: true},
+					{Attribute: "userCount", Operator: "gt", Value: float64(10)},
+					{Attribute: "description", Operator: "pr", Conjunction: testutils.Pointer("or")},
+				},
+			},
+			wantFilter: `default eq true and userCount gt 10 or description pr`,
+			wantTool:   "list_populations",
+			wantWarnings: []string{
+				"condition 1: filtering by 'default' is not supported; supported attributes are id, name",
+				"condition 2: filtering by 'userCount' is not supported; supported attributes are id, name",
+				"condition 3: filtering by 'description' is not supported; supported attributes are id, name",
+			},
+		},
+		{
+			name: "audit event time range",
+			input: scimfilters.BuildScimFilterInput{
+				ResourceType: scimfilters.ResourceTypeAuditEvent,
+				Conditions: []scimfilters.FilterCondition{
+					{Attribute: "result.status", Operator: "eq", Value: "FAILED"},
+					{Attribute: "recordedAt", Operator: "gt", Value: "2025-06-01T00:00:00Z"},
+				},
+			},
+			wantFilter: `result.status eq "FAILED" and recordedAt gt "2025-06-01T00:00:00Z"`,
+			wantTool:   "query_audit_events",
+			wantWarnings: []string{
+				"condition 2: the time range of query_audit_events is set with its startTime and endTime inputs; a recordedAt condition can only narrow it",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := scimfilters.BuildScimFilterHandler()
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, tt.wantFilter, output.Filter)
+			assert.Equal(t, tt.wantTool, output.Tool)
+			assert.Equal(t, tt.wantWarnings, output.Warnings)
+		})
+	}
+}
+
+func TestBuildScimFilterHandler_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           scimfilters.BuildScimFilterInput
+		wantErrContains []string
+	}{
+		{
+			name: "unknown resource type",
+			input: scimfilters.BuildScimFilterInput{
+				ResourceType: "USER",
+				Conditions:   []scimfilters.FilterCondition{{Attribute: "username", Operator: "eq", Value: "jdoe"}},
+			},
+			wantErrContains: []string{"resourceType must be one of AUDIT_EVENT, ENVIRONMENT, POPULATION"},
+		},
+		{
+			name:            "no conditions",
+			input:           scimfilters.BuildScimFilterInput{ResourceType: scimfilters.ResourceTypeEnvironment},
+			wantErrContains: []string{"at least one condition is required"},
+		},
+		{
+			name: "invalid conditions",
+			input: scimfilters.BuildScimFilterInput{
+				ResourceType: scimfilters.ResourceTypeEnvironment,
+				Conditions: []scimfilters.FilterCondition{
+					{Attribute: "name or 1", Operator: "eq", Value: "Dev"},
+					{Attribute: "name", Operator: "like", Value: "Dev"},
+					{Attribute: "name", Operator: "eq"},
+					{Attribute: "name", Operator: "pr", Value: "Dev"},
+					{Attribute: "name", Operator: "eq", Value: []any{"Dev"}},
+					{Attribute: "name", Operator: "eq", Value: "Dev", Conjunction: testutils.Pointer("xor")},
+				},
+			},
+			wantErrContains: []string{
+				`condition 1: attribute "name or 1" must be a dot-separated attribute path`,
+				`condition 2: operator "like" must be one of eq, ne, co, sw, ew, gt, ge, lt, le, pr`,
+				"condition 3: operator 'eq' requires a value",
+				"condition 4: operator 'pr' does not take a value",
+				"condition 5: value must be a string, number or boolean",
+				"condition 6: conjunction must be 'and' or 'or'",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := scimfilters.BuildScimFilterHandler()
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			for _, wantErr := range tt.wantErrContains {
+				testutils.AssertHandlerError(t, err, mcpResult, output, wantErr)
+			}
+		})
+	}
+}
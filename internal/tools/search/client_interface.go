@@ -0,0 +1,22 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package search
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// SearchClient lists the PingOne resources that are searched by name or ID
+type SearchClient interface {
+	GetApplications(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetGroups(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error)
+	GetUsers(ctx context.Context, environmentId uuid.UUID, filter string) (management.EntityArrayPagedIterator, error)
+}
+
+type SearchClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (SearchClient, error)
+}
@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "search"
+
+var _ collections.LegacySdkCollection = &SearchCollection{}
+
+type SearchCollection struct{}
+
+func (c *SearchCollection) Name() string {
+	return CollectionName
+}
+
+func (c *SearchCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	searchClientFactory := NewPingOneClientSearchWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&SearchResourcesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", SearchResourcesDef.McpTool.Name))
+		mcp.AddTool(server, SearchResourcesDef.McpTool, SearchResourcesHandler(searchClientFactory))
+	}
+
+	return nil
+}
+
+func (c *SearchCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		SearchResourcesDef,
+	}
+}
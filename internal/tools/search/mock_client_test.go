@@ -0,0 +1,64 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package search_test
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/search"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ search.SearchClient = &mockPingOneClientSearchWrapper{}
+var _ search.SearchClientFactory = &mockPingOneClientSearchWrapperFactory{}
+
+type mockPingOneClientSearchWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientSearchWrapperFactory struct {
+	mockClient search.SearchClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientSearchWrapperFactory(mockClient search.SearchClient, err error) *mockPingOneClientSearchWrapperFactory {
+	return &mockPingOneClientSearchWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientSearchWrapperFactory) GetAuthenticatedClient(ctx context.Context) (search.SearchClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientSearchWrapper) GetApplications(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return pagedIteratorResponse(args)
+}
+
+func (p *mockPingOneClientSearchWrapper) GetGroups(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return pagedIteratorResponse(args)
+}
+
+func (p *mockPingOneClientSearchWrapper) GetPopulations(ctx context.Context, environmentId uuid.UUID) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId)
+	return pagedIteratorResponse(args)
+}
+
+func (p *mockPingOneClientSearchWrapper) GetUsers(ctx context.Context, environmentId uuid.UUID, filter string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, filter)
+	return pagedIteratorResponse(args)
+}
+
+func pagedIteratorResponse(args mock.Arguments) (management.EntityArrayPagedIterator, error) {
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
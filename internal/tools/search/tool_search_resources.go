@@ -0,0 +1,389 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package search
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const (
+	defaultSearchLimit = 25
+	maxSearchLimit     = 100
+)
+
+// The types of resource that are searched
+const (
+	ResourceTypeApplication = "application"
+	ResourceTypeGroup       = "group"
+	ResourceTypePopulation  = "population"
+	ResourceTypeUser        = "user"
+)
+
+var resourceTypes = []string{ResourceTypeApplication, ResourceTypeGroup, ResourceTypePopulation, ResourceTypeUser}
+
+// The quality of a match, best first, by which the matches are ordered
+const (
+	matchExact = iota
+	matchPrefix
+	matchContains
+)
+
+var SearchResourcesDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "search_resources",
+		Title: "Search PingOne Resources",
+		Description: `Search the applications, groups, populations and users of an environment by name or ID with a single free-text query, returning typed matches. Use when you know the name of a resource but not its type or ID, rather than calling several list tools, then use the get or list tools of the matching type for its details.
+
+A UUID query matches the resource with that ID. Any other query matches applications, groups and populations whose name contains it, and users whose username or email address starts with it, ignoring case. Exact matches are returned first, then names starting with the query, then names containing it. Applications, groups and populations are read in full to match their names, so restrict 'types' in environments with many groups.`,
+		InputSchema:  schema.MustGenerateSchema[SearchResourcesInput](),
+		OutputSchema: schema.MustGenerateSchema[SearchResourcesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type SearchResourcesInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Query         string    `json:"query" jsonschema:"REQUIRED. The name, username, email address or UUID of the resource to find."`
+	Types         []string  `json:"types,omitempty" jsonschema:"OPTIONAL. The types of resource to search: application, group, population and user. Defaults to all of them."`
+	Limit         *int      `json:"limit,omitempty" jsonschema:"OPTIONAL. The maximum number of matches to return, from 1 to 100. Defaults to 25."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'matches.type' and 'matches.id'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type ResourceMatch struct {
+	Type        string  `json:"type" jsonschema:"The type of the resource: application, group, population or user"`
+	Id          string  `json:"id" jsonschema:"The UUID of the resource"`
+	Name        string  `json:"name" jsonschema:"The name of the resource, or the username of a user"`
+	Email       *string `json:"email,omitempty" jsonschema:"The email address of a user"`
+	Description *string `json:"description,omitempty" jsonschema:"The description of an application, group or population"`
+	MatchedOn   string  `json:"matchedOn" jsonschema:"The attribute that matched the query: id, name, username or email"`
+
+	quality int
+}
+
+type SearchResourcesOutput struct {
+	Query     string          `json:"query" jsonschema:"The query that was searched for"`
+	Matches   []ResourceMatch `json:"matches" jsonschema:"The matching resources, best matches first, empty if none matched"`
+	Truncated bool            `json:"truncated" jsonschema:"Whether more resources matched than the limit. Narrow the query or the types to see the others."`
+}
+
+// SearchResourcesHandler searches PingOne resources by name or ID using the provided client
+func SearchResourcesHandler(searchClientFactory SearchClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input SearchResourcesInput,
+) (
+	*mcp.CallToolResult,
+	*SearchResourcesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SearchResourcesInput) (*mcp.CallToolResult, *SearchResourcesOutput, error) {
+		query := strings.TrimSpace(input.Query)
+		searchTypes, limit, err := validateSearchInput(query, input)
+		if err != nil {
+			toolErr := errs.NewToolError(SearchResourcesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := searchClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(SearchResourcesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Searching resources",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Any("types", searchTypes))
+
+		var matches []ResourceMatch
+		for _, resourceType := range searchTypes {
+			var typeMatches []ResourceMatch
+			switch resourceType {
+			case ResourceTypeApplication:
+				typeMatches, err = searchApplications(ctx, client, input.EnvironmentId, query)
+			case ResourceTypeGroup:
+				typeMatches, err = searchGroups(ctx, client, input.EnvironmentId, query)
+			case ResourceTypePopulation:
+				typeMatches, err = searchPopulations(ctx, client, input.EnvironmentId, query)
+			case ResourceTypeUser:
+				typeMatches, err = searchUsers(ctx, client, input.EnvironmentId, query, limit+1)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			matches = append(matches, typeMatches...)
+		}
+
+		// Stable, so that matches of the same quality keep the order of the types
+		slices.SortStableFunc(matches, func(a, b ResourceMatch) int {
+			return cmp.Compare(a.quality, b.quality)
+		})
+
+		result := &SearchResourcesOutput{
+			Query:   query,
+			Matches: matches,
+		}
+		if len(result.Matches) > limit {
+			result.Matches = result.Matches[:limit]
+			result.Truncated = true
+		}
+		if result.Matches == nil {
+			result.Matches = []ResourceMatch{}
+		}
+
+		logger.FromContext(ctx).Debug("Searched resources", slog.Int("count", len(result.Matches)))
+
+		return nil, result, nil
+	}
+}
+
+// validateSearchInput returns the types of resource to search, in a fixed order, and the limit of the input
+func validateSearchInput(query string, input SearchResourcesInput) ([]string, int, error) {
+	if query == "" {
+		return nil, 0, errors.New("query must not be empty")
+	}
+
+	limit := defaultSearchLimit
+	if input.Limit != nil {
+		if *input.Limit < 1 || *input.Limit > maxSearchLimit {
+			return nil, 0, fmt.Errorf("limit must be between 1 and %d", maxSearchLimit)
+		}
+		limit = *input.Limit
+	}
+
+	if len(input.Types) == 0 {
+		return resourceTypes, limit, nil
+	}
+	var searchTypes []string
+	for _, resourceType := range resourceTypes {
+		if slices.Contains(input.Types, resourceType) {
+			searchTypes = append(searchTypes, resourceType)
+		}
+	}
+	for _, resourceType := range input.Types {
+		if !slices.Contains(resourceTypes, resourceType) {
+			return nil, 0, fmt.Errorf("unknown resource type %q, valid types are: %s", resourceType, strings.Join(resourceTypes, ", "))
+		}
+	}
+	return searchTypes, limit, nil
+}
+
+// matchName returns the match of the resource with the query by ID or name, or false if it does not match
+func matchName(resourceType string, id *string, name string, description *string, query string) (ResourceMatch, bool) {
+	if id == nil {
+		return ResourceMatch{}, false
+	}
+	match := ResourceMatch{
+		Type:        resourceType,
+		Id:          *id,
+		Name:        name,
+		Description: description,
+	}
+	if isUuid(query) {
+		match.MatchedOn = "id"
+		match.quality = matchExact
+		return match, strings.EqualFold(*id, query)
+	}
+	match.MatchedOn = "name"
+	lowerName, lowerQuery := strings.ToLower(name), strings.ToLower(query)
+	switch {
+	case lowerName == lowerQuery:
+		match.quality = matchExact
+	case strings.HasPrefix(lowerName, lowerQuery):
+		match.quality = matchPrefix
+	case strings.Contains(lowerName, lowerQuery):
+		match.quality = matchContains
+	default:
+		return ResourceMatch{}, false
+	}
+	return match, true
+}
+
+func searchApplications(ctx context.Context, client SearchClient, environmentId uuid.UUID, query string) ([]ResourceMatch, error) {
+	pagedIterator, err := client.GetApplications(ctx, environmentId)
+	if err != nil {
+		toolErr := errs.NewToolError(SearchResourcesDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	var matches []ResourceMatch
+	err = readPages(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, application := range embedded.Applications {
+			id, name, description := applicationName(application)
+			if match, ok := matchName(ResourceTypeApplication, id, name, description, query); ok {
+				matches = append(matches, match)
+			}
+		}
+		return true
+	})
+	return matches, err
+}
+
+func searchGroups(ctx context.Context, client SearchClient, environmentId uuid.UUID, query string) ([]ResourceMatch, error) {
+	pagedIterator, err := client.GetGroups(ctx, environmentId)
+	if err != nil {
+		toolErr := errs.NewToolError(SearchResourcesDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	var matches []ResourceMatch
+	err = readPages(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, group := range embedded.Groups {
+			if match, ok := matchName(ResourceTypeGroup, group.Id, group.Name, group.Description, query); ok {
+				matches = append(matches, match)
+			}
+		}
+		return true
+	})
+	return matches, err
+}
+
+func searchPopulations(ctx context.Context, client SearchClient, environmentId uuid.UUID, query string) ([]ResourceMatch, error) {
+	pagedIterator, err := client.GetPopulations(ctx, environmentId)
+	if err != nil {
+		toolErr := errs.NewToolError(SearchResourcesDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	var matches []ResourceMatch
+	err = readPages(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, population := range embedded.Populations {
+			if match, ok := matchName(ResourceTypePopulation, population.Id, population.Name, population.Description, query); ok {
+				matches = append(matches, match)
+			}
+		}
+		return true
+	})
+	return matches, err
+}
+
+// searchUsers returns up to maxUsers users matching the query, which PingOne filters, as users are too many to read
+// in full
+func searchUsers(ctx context.Context, client SearchClient, environmentId uuid.UUID, query string, maxUsers int) ([]ResourceMatch, error) {
+	pagedIterator, err := client.GetUsers(ctx, environmentId, userSearchFilter(query))
+	if err != nil {
+		toolErr := errs.NewToolError(SearchResourcesDef.McpTool.Name, err)
+		errs.Log(ctx, toolErr)
+		return nil, toolErr
+	}
+	var matches []ResourceMatch
+	err = readPages(ctx, pagedIterator, func(embedded *management.EntityArrayEmbedded) bool {
+		for _, user := range embedded.Users {
+			if user.Id == nil {
+				continue
+			}
+			matches = append(matches, userMatch(user, query))
+			if len(matches) == maxUsers {
+				return false
+			}
+		}
+		return true
+	})
+	return matches, err
+}
+
+// userSearchFilter returns the SCIM filter of the users matching the query by ID, or by the start of their username
+// or email address
+func userSearchFilter(query string) string {
+	if isUuid(query) {
+		return fmt.Sprintf("id eq %s", scimString(query))
+	}
+	return fmt.Sprintf("username sw %s or email sw %s", scimString(query), scimString(query))
+}
+
+func userMatch(user management.User, query string) ResourceMatch {
+	match := ResourceMatch{
+		Type:    ResourceTypeUser,
+		Id:      *user.Id,
+		Name:    user.Username,
+		Email:   &user.Email,
+		quality: matchPrefix,
+	}
+	if user.Email == "" {
+		match.Email = nil
+	}
+	switch {
+	case isUuid(query):
+		match.MatchedOn = "id"
+		match.quality = matchExact
+	case strings.HasPrefix(strings.ToLower(user.Username), strings.ToLower(query)):
+		match.MatchedOn = "username"
+		if strings.EqualFold(user.Username, query) {
+			match.quality = matchExact
+		}
+	default:
+		match.MatchedOn = "email"
+		if strings.EqualFold(user.Email, query) {
+			match.quality = matchExact
+		}
+	}
+	return match
+}
+
+// applicationName returns the ID, name and description of an application of any type. The PingOne admin console
+// application has no ID, so is never matched.
+func applicationName(application management.ReadOneApplication200Response) (*string, string, *string) {
+	switch {
+	case application.ApplicationExternalLink != nil:
+		return application.ApplicationExternalLink.Id, application.ApplicationExternalLink.Name, application.ApplicationExternalLink.Description
+	case application.ApplicationOIDC != nil:
+		return application.ApplicationOIDC.Id, application.ApplicationOIDC.Name, application.ApplicationOIDC.Description
+	case application.ApplicationPingOnePortal != nil:
+		return application.ApplicationPingOnePortal.Id, application.ApplicationPingOnePortal.Name, application.ApplicationPingOnePortal.Description
+	case application.ApplicationPingOneSelfService != nil:
+		return application.ApplicationPingOneSelfService.Id, application.ApplicationPingOneSelfService.Name, application.ApplicationPingOneSelfService.Description
+	case application.ApplicationSAML != nil:
+		return application.ApplicationSAML.Id, application.ApplicationSAML.Name, application.ApplicationSAML.Description
+	case application.ApplicationWSFED != nil:
+		return application.ApplicationWSFED.Id, application.ApplicationWSFED.Name, application.ApplicationWSFED.Description
+	}
+	return nil, "", nil
+}
+
+// readPages calls read with the resources of each page until it returns false or the pages run out
+func readPages(ctx context.Context, pagedIterator management.EntityArrayPagedIterator, read func(embedded *management.EntityArrayEmbedded) bool) error {
+	for next, err := range pagedIterator {
+		logger.LogHttpResponse(ctx, next.HTTPResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, err)
+			errs.Log(ctx, apiErr)
+			return apiErr
+		}
+		if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return apiErr
+		}
+		if !read(next.EntityArray.Embedded) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func isUuid(query string) bool {
+	_, err := uuid.Parse(query)
+	return err == nil
+}
+
+// scimString quotes a value for use in a SCIM filter
+func scimString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
@@ -0,0 +1,229 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package search_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/search"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEnvironmentId  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testApplicationId  = "550e8400-e29b-41d4-a716-446655440010"
+	testGroupId        = "550e8400-e29b-41d4-a716-446655440020"
+	testPopulationId   = "550e8400-e29b-41d4-a716-446655440030"
+	testOtherGroupId   = "550e8400-e29b-41d4-a716-446655440021"
+	testUserId         = "550e8400-e29b-41d4-a716-446655440040"
+	testUsernameFilter = `username sw "payroll" or email sw "payroll"`
+	testUserIdFilter   = `id eq "550e8400-e29b-41d4-a716-446655440040"`
+	testUserSearchErr  = errors.New("user search failed")
+)
+
+func pages(embedded management.EntityArrayEmbedded) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &embedded},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func applications() management.EntityArrayPagedIterator {
+	return pages(management.EntityArrayEmbedded{Applications: []management.ReadOneApplication200Response{
+		{ApplicationOIDC: &management.ApplicationOIDC{Id: testutils.Pointer(testApplicationId), Name: "Payroll Portal"}},
+		{ApplicationPingOneAdminConsole: &management.ApplicationPingOneAdminConsole{}},
+	}})
+}
+
+func groups() management.EntityArrayPagedIterator {
+	return pages(management.EntityArrayEmbedded{Groups: []management.Group{
+		{Id: testutils.Pointer(testGroupId), Name: "payroll", Description: testutils.Pointer("Payroll administrators")},
+		{Id: testutils.Pointer(testOtherGroupId), Name: "Sales"},
+	}})
+}
+
+func populations() management.EntityArrayPagedIterator {
+	return pages(management.EntityArrayEmbedded{Populations: []management.Population{
+		{Id: testutils.Pointer(testPopulationId), Name: "Contractors on Payroll"},
+	}})
+}
+
+func users() management.EntityArrayPagedIterator {
+	return pages(management.EntityArrayEmbedded{Users: []management.User{
+		{Id: testutils.Pointer(testUserId), Username: "payroll.bot", Email: "bot@example.com"},
+	}})
+}
+
+// matchNames returns the type and name of each match, in order
+func matchNames(matches []search.ResourceMatch) []string {
+	names := []string{}
+	for _, match := range matches {
+		names = append(names, match.Type+":"+match.Name)
+	}
+	return names
+}
+
+func TestSearchResourcesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientSearchWrapper{}
+	mockClient.On("GetApplications", mock.Anything, testEnvironmentId).Return(applications(), nil)
+	mockClient.On("GetGroups", mock.Anything, testEnvironmentId).Return(groups(), nil)
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId).Return(populations(), nil)
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testUsernameFilter).Return(users(), nil)
+
+	handler := search.SearchResourcesHandler(NewMockPingOneClientSearchWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, search.SearchResourcesInput{
+		EnvironmentId: testEnvironmentId,
+		Query:         " payroll ",
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, "payroll", output.Query)
+	assert.False(t, output.Truncated)
+	assert.Equal(t, []string{
+		"group:payroll",
+		"application:Payroll Portal",
+		"user:payroll.bot",
+		"population:Contractors on Payroll",
+	}, matchNames(output.Matches), "exact matches first, then prefixes, then names containing the query")
+	assert.Equal(t, testGroupId, output.Matches[0].Id)
+	assert.Equal(t, "name", output.Matches[0].MatchedOn)
+	assert.Equal(t, testutils.Pointer("Payroll administrators"), output.Matches[0].Description)
+	assert.Equal(t, "username", output.Matches[2].MatchedOn)
+	assert.Equal(t, testutils.Pointer("bot@example.com"), output.Matches[2].Email)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSearchResourcesHandler_Id(t *testing.T) {
+	mockClient := &mockPingOneClientSearchWrapper{}
+	mockClient.On("GetGroups", mock.Anything, testEnvironmentId).Return(groups(), nil)
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, `id eq "`+testOtherGroupId+`"`).Return(pages(management.EntityArrayEmbedded{}), nil)
+
+	handler := search.SearchResourcesHandler(NewMockPingOneClientSearchWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, search.SearchResourcesInput{
+		EnvironmentId: testEnvironmentId,
+		Query:         testOtherGroupId,
+		Types:         []string{"user", "group"},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.Len(t, output.Matches, 1)
+	assert.Equal(t, "group:Sales", matchNames(output.Matches)[0])
+	assert.Equal(t, "id", output.Matches[0].MatchedOn)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSearchResourcesHandler_UserId(t *testing.T) {
+	mockClient := &mockPingOneClientSearchWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testUserIdFilter).Return(users(), nil)
+
+	handler := search.SearchResourcesHandler(NewMockPingOneClientSearchWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, search.SearchResourcesInput{
+		EnvironmentId: testEnvironmentId,
+		Query:         testUserId,
+		Types:         []string{"user"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Matches, 1)
+	assert.Equal(t, testUserId, output.Matches[0].Id)
+	assert.Equal(t, "id", output.Matches[0].MatchedOn)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSearchResourcesHandler_NoMatches(t *testing.T) {
+	mockClient := &mockPingOneClientSearchWrapper{}
+	mockClient.On("GetPopulations", mock.Anything, testEnvironmentId).Return(populations(), nil)
+
+	handler := search.SearchResourcesHandler(NewMockPingOneClientSearchWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, search.SearchResourcesInput{
+		EnvironmentId: testEnvironmentId,
+		Query:         "Marketing",
+		Types:         []string{"population"},
+	})
+
+	require.NoError(t, err)
+	assert.NotNil(t, output.Matches)
+	assert.Empty(t, output.Matches)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSearchResourcesHandler_Truncated(t *testing.T) {
+	mockClient := &mockPingOneClientSearchWrapper{}
+	mockClient.On("GetApplications", mock.Anything, testEnvironmentId).Return(applications(), nil)
+	mockClient.On("GetGroups", mock.Anything, testEnvironmentId).Return(groups(), nil)
+
+	handler := search.SearchResourcesHandler(NewMockPingOneClientSearchWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, search.SearchResourcesInput{
+		EnvironmentId: testEnvironmentId,
+		Query:         "payroll",
+		Types:         []string{"group", "application"},
+		Limit:         testutils.Pointer(1),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.Truncated)
+	assert.Equal(t, []string{"group:payroll"}, matchNames(output.Matches))
+	mockClient.AssertExpectations(t)
+}
+
+func TestSearchResourcesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           search.SearchResourcesInput
+		setupMock       func(mockClient *mockPingOneClientSearchWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Empty query",
+			input:           search.SearchResourcesInput{EnvironmentId: testEnvironmentId, Query: "  "},
+			setupMock:       func(mockClient *mockPingOneClientSearchWrapper) {},
+			wantErrContains: "query must not be empty",
+		},
+		{
+			name:            "Unknown type",
+			input:           search.SearchResourcesInput{EnvironmentId: testEnvironmentId, Query: "payroll", Types: []string{"user", "role"}},
+			setupMock:       func(mockClient *mockPingOneClientSearchWrapper) {},
+			wantErrContains: `unknown resource type "role"`,
+		},
+		{
+			name:            "Limit out of range",
+			input:           search.SearchResourcesInput{EnvironmentId: testEnvironmentId, Query: "payroll", Limit: testutils.Pointer(101)},
+			setupMock:       func(mockClient *mockPingOneClientSearchWrapper) {},
+			wantErrContains: "limit must be between 1 and 100",
+		},
+		{
+			name:  "API error",
+			input: search.SearchResourcesInput{EnvironmentId: testEnvironmentId, Query: "payroll", Types: []string{"user"}},
+			setupMock: func(mockClient *mockPingOneClientSearchWrapper) {
+				mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testUsernameFilter).Return(testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+					{HTTPResponse: &http.Response{StatusCode: 500}, Error: testUserSearchErr},
+				}), nil)
+			},
+			wantErrContains: "user search failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientSearchWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := search.SearchResourcesHandler(NewMockPingOneClientSearchWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// StatisticsClient counts PingOne resources from the count of the first page of their lists, without reading the
+// resources themselves
+type StatisticsClient interface {
+	CountUsers(ctx context.Context, environmentId uuid.UUID, filter string) (int, *http.Response, error)
+	CountApplications(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error)
+	CountEnvironments(ctx context.Context, filter string) (int, *http.Response, error)
+	// ListEnvironmentIds returns the IDs of all the environments matching the filter, reading every page of the list
+	ListEnvironmentIds(ctx context.Context, filter string) ([]uuid.UUID, *http.Response, error)
+	CountPopulations(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error)
+	CountGroups(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error)
+	CountIdentityProviders(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error)
+}
+
+type StatisticsClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (StatisticsClient, error)
+}
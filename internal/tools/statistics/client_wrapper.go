@@ -0,0 +1,182 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+var _ StatisticsClient = &PingOneClientStatisticsWrapper{}
+var _ StatisticsClientFactory = &PingOneClientStatisticsWrapperFactory{}
+
+// countPageLimit is the size of the pages read by the lists that support paging, as only their count is used
+const countPageLimit = 1
+
+type PingOneClientStatisticsWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientStatisticsWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientStatisticsWrapper(client *pingone.Client) *PingOneClientStatisticsWrapper {
+	return &PingOneClientStatisticsWrapper{client: client}
+}
+
+func NewPingOneClientStatisticsWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientStatisticsWrapperFactory {
+	return &PingOneClientStatisticsWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientStatisticsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (StatisticsClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientStatisticsWrapper(client), nil
+}
+
+func (p *PingOneClientStatisticsWrapper) CountUsers(ctx context.Context, environmentId uuid.UUID, filter string) (int, *http.Response, error) {
+	if p.client == nil {
+		return 0, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UsersApi.ReadAllUsers(ctx, environmentId.String()).Limit(countPageLimit)
+	if filter != "" {
+		getRequest = getRequest.Filter(filter)
+	}
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to count users",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return pageCount(getRequest.ExecuteInitialPage())
+}
+
+func (p *PingOneClientStatisticsWrapper) CountApplications(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	if p.client == nil {
+		return 0, nil, errors.New("PingOne client is not initialized")
+	}
+	// Applications are not paged, so the first page has all of them
+	getRequest := p.client.ManagementAPIClient.ApplicationsApi.ReadAllApplications(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to count applications",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return pageCount(getRequest.ExecuteInitialPage())
+}
+
+func (p *PingOneClientStatisticsWrapper) CountEnvironments(ctx context.Context, filter string) (int, *http.Response, error) {
+	if p.client == nil {
+		return 0, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.EnvironmentsApi.ReadAllEnvironments(ctx).Limit(countPageLimit)
+	if filter != "" {
+		getRequest = getRequest.Filter(filter)
+	}
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to count environments")
+	return pageCount(getRequest.ExecuteInitialPage())
+}
+
+func (p *PingOneClientStatisticsWrapper) ListEnvironmentIds(ctx context.Context, filter string) ([]uuid.UUID, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.EnvironmentsApi.ReadAllEnvironments(ctx)
+	if filter != "" {
+		getRequest = getRequest.Filter(filter)
+	}
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to list environment IDs")
+
+	environmentIds := []uuid.UUID{}
+	var httpResponse *http.Response
+	for next, err := range getRequest.Execute() {
+		httpResponse = next.HTTPResponse
+		if err != nil {
+			return nil, httpResponse, err
+		}
+		if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+			return nil, httpResponse, errors.New("no data in response")
+		}
+		for _, environment := range next.EntityArray.Embedded.Environments {
+			environmentId, err := uuid.Parse(environment.GetId())
+			if err != nil {
+				return nil, httpResponse, fmt.Errorf("invalid environment ID %q: %w", environment.GetId(), err)
+			}
+			environmentIds = append(environmentIds, environmentId)
+		}
+	}
+	return environmentIds, httpResponse, nil
+}
+
+func (p *PingOneClientStatisticsWrapper) CountPopulations(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	if p.client == nil {
+		return 0, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.PopulationsApi.ReadAllPopulations(ctx, environmentId.String()).Limit(countPageLimit)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to count populations",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return pageCount(getRequest.ExecuteInitialPage())
+}
+
+func (p *PingOneClientStatisticsWrapper) CountGroups(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	if p.client == nil {
+		return 0, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.GroupsApi.ReadAllGroups(ctx, environmentId.String()).Limit(countPageLimit)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to count groups",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return pageCount(getRequest.ExecuteInitialPage())
+}
+
+func (p *PingOneClientStatisticsWrapper) CountIdentityProviders(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	if p.client == nil {
+		return 0, nil, errors.New("PingOne client is not initialized")
+	}
+	// Identity providers are not paged, so the first page has all of them
+	getRequest := p.client.ManagementAPIClient.IdentityProvidersApi.ReadAllIdentityProviders(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to count identity providers",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return pageCount(getRequest.ExecuteInitialPage())
+}
+
+// pageCount returns the count of the page, which PingOne sets to the number of resources in the whole list
+func pageCount(page *management.EntityArray, httpResponse *http.Response, err error) (int, *http.Response, error) {
+	if err != nil {
+		return 0, httpResponse, err
+	}
+	if page == nil || page.Count == nil {
+		return 0, httpResponse, errors.New("no count in response")
+	}
+	return int(*page.Count), httpResponse, nil
+}
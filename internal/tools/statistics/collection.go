@@ -0,0 +1,69 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "statistics"
+
+var _ collections.LegacySdkCollection = &StatisticsCollection{}
+
+type StatisticsCollection struct{}
+
+func (c *StatisticsCollection) Name() string {
+	return CollectionName
+}
+
+func (c *StatisticsCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	statisticsClientFactory := NewPingOneClientStatisticsWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&CountUsersDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CountUsersDef.McpTool.Name))
+		mcp.AddTool(server, CountUsersDef.McpTool, CountUsersHandler(statisticsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CountApplicationsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CountApplicationsDef.McpTool.Name))
+		mcp.AddTool(server, CountApplicationsDef.McpTool, CountApplicationsHandler(statisticsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CountEnvironmentsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CountEnvironmentsDef.McpTool.Name))
+		mcp.AddTool(server, CountEnvironmentsDef.McpTool, CountEnvironmentsHandler(statisticsClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetEnvironmentSummaryDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetEnvironmentSummaryDef.McpTool.Name))
+		mcp.AddTool(server, GetEnvironmentSummaryDef.McpTool, GetEnvironmentSummaryHandler(statisticsClientFactory))
+	}
+
+	return nil
+}
+
+func (c *StatisticsCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		CountUsersDef,
+		CountApplicationsDef,
+		CountEnvironmentsDef,
+		GetEnvironmentSummaryDef,
+	}
+}
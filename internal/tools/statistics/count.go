@@ -0,0 +1,23 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// readCount returns the count read by the client, handling its errors the same way for every tool
+func readCount(ctx context.Context, count func() (int, *http.Response, error)) (int, error) {
+	value, httpResponse, err := count()
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return 0, apiErr
+	}
+	return value, nil
+}
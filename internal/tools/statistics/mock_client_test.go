@@ -0,0 +1,78 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/statistics"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ statistics.StatisticsClient = &mockPingOneClientStatisticsWrapper{}
+var _ statistics.StatisticsClientFactory = &mockPingOneClientStatisticsWrapperFactory{}
+
+type mockPingOneClientStatisticsWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientStatisticsWrapperFactory struct {
+	mockClient statistics.StatisticsClient
+	err        error
+}
+
+// NewMockPingOneClientStatisticsWrapperFactory directly returns the provided mock client and error
+func NewMockPingOneClientStatisticsWrapperFactory(mockClient statistics.StatisticsClient, err error) *mockPingOneClientStatisticsWrapperFactory {
+	return &mockPingOneClientStatisticsWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientStatisticsWrapperFactory) GetAuthenticatedClient(ctx context.Context) (statistics.StatisticsClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientStatisticsWrapper) CountUsers(ctx context.Context, environmentId uuid.UUID, filter string) (int, *http.Response, error) {
+	args := p.Called(ctx, environmentId, filter)
+	return countResult(args)
+}
+
+func (p *mockPingOneClientStatisticsWrapper) CountApplications(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return countResult(args)
+}
+
+func (p *mockPingOneClientStatisticsWrapper) CountEnvironments(ctx context.Context, filter string) (int, *http.Response, error) {
+	args := p.Called(ctx, filter)
+	return countResult(args)
+}
+
+func (p *mockPingOneClientStatisticsWrapper) ListEnvironmentIds(ctx context.Context, filter string) ([]uuid.UUID, *http.Response, error) {
+	args := p.Called(ctx, filter)
+	environmentIds, _ := args.Get(0).([]uuid.UUID)
+	httpResponse, _ := args.Get(1).(*http.Response)
+	return environmentIds, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientStatisticsWrapper) CountPopulations(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return countResult(args)
+}
+
+func (p *mockPingOneClientStatisticsWrapper) CountGroups(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return countResult(args)
+}
+
+func (p *mockPingOneClientStatisticsWrapper) CountIdentityProviders(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	return countResult(args)
+}
+
+func countResult(args mock.Arguments) (int, *http.Response, error) {
+	httpResponse, _ := args.Get(1).(*http.Response)
+	return args.Int(0), httpResponse, args.Error(2)
+}
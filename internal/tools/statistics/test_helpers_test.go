@@ -0,0 +1,29 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testPopulationId  = uuid.MustParse("6d1f6c3a-2b7e-4c1f-9a51-0a3c1e2b4d5f")
+	okResponse        = &http.Response{StatusCode: http.StatusOK}
+)
+
+// structuredOutput unmarshals the structured content of a tool result into the output type
+func structuredOutput[T any](t *testing.T, result *mcp.CallToolResult) T {
+	t.Helper()
+	var output T
+	jsonBytes, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err, "Failed to marshal structured content")
+	require.NoError(t, json.Unmarshal(jsonBytes, &output), "Failed to unmarshal structured content")
+	return output
+}
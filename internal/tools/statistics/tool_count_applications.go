@@ -0,0 +1,71 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CountApplicationsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "count_applications",
+		Title:        "Count PingOne Applications",
+		Description:  "Count the applications of an environment, including the built-in PingOne applications, without listing them.",
+		InputSchema:  schema.MustGenerateSchema[CountApplicationsInput](),
+		OutputSchema: schema.MustGenerateSchema[CountOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type CountApplicationsInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+}
+
+type CountOutput struct {
+	Count int `json:"count" jsonschema:"The number of resources"`
+}
+
+// CountApplicationsHandler counts PingOne applications using the provided client
+func CountApplicationsHandler(statisticsClientFactory StatisticsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CountApplicationsInput,
+) (
+	*mcp.CallToolResult,
+	*CountOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CountApplicationsInput) (*mcp.CallToolResult, *CountOutput, error) {
+		client, err := statisticsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CountApplicationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Counting applications", slog.String("environmentId", input.EnvironmentId.String()))
+
+		count, err := readCount(ctx, func() (int, *http.Response, error) {
+			return client.CountApplications(ctx, input.EnvironmentId)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, &CountOutput{Count: count}, nil
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/statistics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCountApplicationsHandler_MockClient(t *testing.T) {
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	mockClient.On("CountApplications", mock.Anything, testEnvironmentId).Return(7, okResponse, nil)
+	handler := statistics.CountApplicationsHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountApplicationsInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 7, output.Count)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCountApplicationsHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientStatisticsWrapper{}
+			mockClient.On("CountApplications", mock.Anything, testEnvironmentId).Return(0, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+			handler := statistics.CountApplicationsHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountApplicationsInput{EnvironmentId: testEnvironmentId})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountApplicationsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := statistics.CountApplicationsHandler(NewMockPingOneClientStatisticsWrapperFactory(nil, errors.New("failed to get authenticated client")))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountApplicationsInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "failed to get authenticated client")
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CountEnvironmentsDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true, // Tool not applicable to a single environment
+	},
+	McpTool: &mcp.Tool{
+		Name:  "count_environments",
+		Title: "Count PingOne Environments",
+		Description: `Count the PingOne environments accessible to the authenticated user, optionally only those matching a SCIM filter, without listing them.
+
+Only filters that 'name' with 'sw' (starts with); 'id', 'organization.id', 'license.id', 'status' with 'eq' (equals); 'and' to combine are valid.
+
+Filter examples:
+- status eq "ACTIVE"
+- license.id eq "license-uuid"`,
+		InputSchema:  schema.MustGenerateSchema[CountEnvironmentsInput](),
+		OutputSchema: schema.MustGenerateSchema[CountOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type CountEnvironmentsInput struct {
+	Filter *string `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter. Only filters that 'name' with 'sw' (starts with); 'id', 'organization.id', 'license.id', 'status' with 'eq' (equals); 'and' to combine are valid."`
+}
+
+// CountEnvironmentsHandler counts PingOne environments using the provided client
+func CountEnvironmentsHandler(statisticsClientFactory StatisticsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CountEnvironmentsInput,
+) (
+	*mcp.CallToolResult,
+	*CountOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CountEnvironmentsInput) (*mcp.CallToolResult, *CountOutput, error) {
+		var filter string
+		if input.Filter != nil {
+			if err := scimfilter.Environments.Validate(*input.Filter); err != nil {
+				toolErr := errs.NewToolError(CountEnvironmentsDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			filter = *input.Filter
+		}
+
+		client, err := statisticsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CountEnvironmentsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Counting environments")
+
+		count, err := readCount(ctx, func() (int, *http.Response, error) {
+			allows := environments.EnvironmentFilterFromContext(ctx)
+			if allows == nil {
+				return client.CountEnvironments(ctx, filter)
+			}
+			// The count of the list would include environments the filter does not allow, so they are listed and
+			// counted here
+			environmentIds, httpResponse, err := client.ListEnvironmentIds(ctx, filter)
+			if err != nil {
+				return 0, httpResponse, err
+			}
+			count := 0
+			for _, environmentId := range environmentIds {
+				if allows(environmentId) {
+					count++
+				}
+			}
+			return count, httpResponse, nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return nil, &CountOutput{Count: count}, nil
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/statistics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCountEnvironmentsHandler_MockClient(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          statistics.CountEnvironmentsInput
+		expectedFilter string
+	}{
+		{
+			name:  "All environments",
+			input: statistics.CountEnvironmentsInput{},
+		},
+		{
+			name:           "Environments matching a filter",
+			input:          statistics.CountEnvironmentsInput{Filter: testutils.Pointer(`status eq "ACTIVE"`)},
+			expectedFilter: `status eq "ACTIVE"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientStatisticsWrapper{}
+			mockClient.On("CountEnvironments", mock.Anything, tt.expectedFilter).Return(3, okResponse, nil)
+			handler := statistics.CountEnvironmentsHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, 3, output.Count)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountEnvironmentsHandler_EnvironmentFilter(t *testing.T) {
+	allowedEnvironmentId := uuid.New()
+	otherEnvironmentId := uuid.New()
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	mockClient.On("ListEnvironmentIds", mock.Anything, `status eq "ACTIVE"`).Return([]uuid.UUID{allowedEnvironmentId, otherEnvironmentId}, okResponse, nil)
+	handler := statistics.CountEnvironmentsHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+	ctx := environments.ContextWithEnvironmentFilter(context.Background(), func(environmentId uuid.UUID) bool {
+		return environmentId == allowedEnvironmentId
+	})
+
+	mcpResult, output, err := handler(ctx, &mcp.CallToolRequest{}, statistics.CountEnvironmentsInput{
+		Filter: testutils.Pointer(`status eq "ACTIVE"`),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 1, output.Count)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "CountEnvironments", mock.Anything, mock.Anything)
+}
+
+func TestCountEnvironmentsHandler_UnsupportedFilter(t *testing.T) {
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	handler := statistics.CountEnvironmentsHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountEnvironmentsInput{
+		Filter: testutils.Pointer(`type eq "SANDBOX"`),
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "unsupported SCIM filter")
+	mockClient.AssertNotCalled(t, "CountEnvironments", mock.Anything, mock.Anything)
+}
+
+func TestCountEnvironmentsHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientStatisticsWrapper{}
+			mockClient.On("CountEnvironments", mock.Anything, "").Return(0, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+			handler := statistics.CountEnvironmentsHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountEnvironmentsInput{})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountEnvironmentsHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := statistics.CountEnvironmentsHandler(NewMockPingOneClientStatisticsWrapperFactory(nil, errors.New("failed to get authenticated client")))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountEnvironmentsInput{})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "failed to get authenticated client")
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/scimfilter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var CountUsersDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "count_users",
+		Title: "Count PingOne Users",
+		Description: `Count the users of an environment, optionally only those in a population or matching a SCIM filter, without listing them. Use to answer questions such as how many users are in a population or how many accounts are locked.
+
+The count is read from the list metadata PingOne returns, so a single API call is made however many users match.
+
+Filter examples:
+- account.status eq "LOCKED"
+- name.family eq "Smith"`,
+		InputSchema:  schema.MustGenerateSchema[CountUsersInput](),
+		OutputSchema: schema.MustGenerateSchema[CountUsersOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type CountUsersInput struct {
+	EnvironmentId uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	PopulationId  *uuid.UUID `json:"populationId,omitempty" jsonschema:"OPTIONAL. Population UUID. Only users in the population are counted."`
+	Filter        *string    `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter. Only users matching the filter are counted."`
+}
+
+type CountUsersOutput struct {
+	Count  int     `json:"count" jsonschema:"The number of users in the environment matching the population and filter"`
+	Filter *string `json:"filter,omitempty" jsonschema:"The SCIM filter the users were counted with, combining the population and filter of the input"`
+}
+
+// CountUsersHandler counts PingOne users using the provided client
+func CountUsersHandler(statisticsClientFactory StatisticsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CountUsersInput,
+) (
+	*mcp.CallToolResult,
+	*CountUsersOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CountUsersInput) (*mcp.CallToolResult, *CountUsersOutput, error) {
+		if input.Filter != nil {
+			if err := scimfilter.Users.Validate(*input.Filter); err != nil {
+				toolErr := errs.NewToolError(CountUsersDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+		filter := countUsersFilter(input)
+
+		client, err := statisticsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CountUsersDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Counting users",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("filter", filter))
+
+		count, err := readCount(ctx, func() (int, *http.Response, error) {
+			return client.CountUsers(ctx, input.EnvironmentId, filter)
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := &CountUsersOutput{
+			Count: count,
+		}
+		if filter != "" {
+			result.Filter = &filter
+		}
+		return nil, result, nil
+	}
+}
+
+// countUsersFilter returns the SCIM filter matching the population and filter of the input
+func countUsersFilter(input CountUsersInput) string {
+	var filter string
+	if input.Filter != nil {
+		filter = *input.Filter
+	}
+	if input.PopulationId == nil {
+		return filter
+	}
+	populationFilter := fmt.Sprintf(`population.id eq "%s"`, input.PopulationId.String())
+	if filter == "" {
+		return populationFilter
+	}
+	return fmt.Sprintf("(%s) and (%s)", populationFilter, filter)
+}
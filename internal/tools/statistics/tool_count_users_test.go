@@ -0,0 +1,118 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/statistics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountUsersHandler_MockClient(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          statistics.CountUsersInput
+		expectedFilter string
+	}{
+		{
+			name:  "All users",
+			input: statistics.CountUsersInput{EnvironmentId: testEnvironmentId},
+		},
+		{
+			name:           "Users of a population",
+			input:          statistics.CountUsersInput{EnvironmentId: testEnvironmentId, PopulationId: &testPopulationId},
+			expectedFilter: `population.id eq "6d1f6c3a-2b7e-4c1f-9a51-0a3c1e2b4d5f"`,
+		},
+		{
+			name:           "Users matching a filter",
+			input:          statistics.CountUsersInput{EnvironmentId: testEnvironmentId, Filter: testutils.Pointer(`account.status eq "LOCKED"`)},
+			expectedFilter: `account.status eq "LOCKED"`,
+		},
+		{
+			name:           "Users of a population matching a filter",
+			input:          statistics.CountUsersInput{EnvironmentId: testEnvironmentId, PopulationId: &testPopulationId, Filter: testutils.Pointer(`name.family eq "Smith" or name.family eq "Jones"`)},
+			expectedFilter: `(population.id eq "6d1f6c3a-2b7e-4c1f-9a51-0a3c1e2b4d5f") and (name.family eq "Smith" or name.family eq "Jones")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientStatisticsWrapper{}
+			mockClient.On("CountUsers", mock.Anything, testEnvironmentId, tt.expectedFilter).Return(12345, okResponse, nil)
+			handler := statistics.CountUsersHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, 12345, output.Count)
+			if tt.expectedFilter == "" {
+				assert.Nil(t, output.Filter)
+			} else {
+				require.NotNil(t, output.Filter)
+				assert.Equal(t, tt.expectedFilter, *output.Filter)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountUsersHandler_ViaMcp(t *testing.T) {
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	mockClient.On("CountUsers", mock.Anything, testEnvironmentId, `population.id eq "6d1f6c3a-2b7e-4c1f-9a51-0a3c1e2b4d5f"`).Return(42, okResponse, nil)
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, statistics.CountUsersDef.McpTool, statistics.CountUsersHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil)))
+
+	result, err := mcptestutils.CallToolOverMcp(t, server, statistics.CountUsersDef.McpTool.Name, statistics.CountUsersInput{
+		EnvironmentId: testEnvironmentId,
+		PopulationId:  &testPopulationId,
+	})
+
+	testutils.AssertMcpCallSuccess(t, err, result)
+	assert.Equal(t, 42, structuredOutput[statistics.CountUsersOutput](t, result).Count)
+	mockClient.AssertExpectations(t)
+}
+
+func TestCountUsersHandler_InvalidFilter(t *testing.T) {
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	handler := statistics.CountUsersHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountUsersInput{
+		EnvironmentId: testEnvironmentId,
+		Filter:        testutils.Pointer(`username eq`),
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "invalid SCIM filter")
+	mockClient.AssertNotCalled(t, "CountUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCountUsersHandler_APIErrors(t *testing.T) {
+	for _, tt := range testutils.CommonAPIErrorTestCases() {
+		t.Run(tt.Name, func(t *testing.T) {
+			mockClient := &mockPingOneClientStatisticsWrapper{}
+			mockClient.On("CountUsers", mock.Anything, testEnvironmentId, "").Return(0, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+			handler := statistics.CountUsersHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountUsersInput{EnvironmentId: testEnvironmentId})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.WantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCountUsersHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := statistics.CountUsersHandler(NewMockPingOneClientStatisticsWrapperFactory(nil, errors.New("failed to get authenticated client")))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.CountUsersInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "failed to get authenticated client")
+}
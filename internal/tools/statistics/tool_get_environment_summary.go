@@ -0,0 +1,96 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var GetEnvironmentSummaryDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		AllowProductionEnvironmentRead: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "get_environment_summary",
+		Title: "Get PingOne Environment Summary",
+		Description: `Summarize the size of an environment with the number of its users, populations, groups, applications and identity providers, without listing them.
+
+Use for an overview of an environment, or before listing resources to know how large the lists are. Each count is read from the list metadata PingOne returns, so one API call is made per resource type.`,
+		InputSchema:  schema.MustGenerateSchema[GetEnvironmentSummaryInput](),
+		OutputSchema: schema.MustGenerateSchema[GetEnvironmentSummaryOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetEnvironmentSummaryInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'users' and 'applications'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type GetEnvironmentSummaryOutput struct {
+	EnvironmentId     uuid.UUID `json:"environmentId" jsonschema:"The UUID of the environment"`
+	Users             int       `json:"users" jsonschema:"The number of users in the environment"`
+	Populations       int       `json:"populations" jsonschema:"The number of populations in the environment"`
+	Groups            int       `json:"groups" jsonschema:"The number of groups in the environment"`
+	Applications      int       `json:"applications" jsonschema:"The number of applications in the environment, including the built-in PingOne applications"`
+	IdentityProviders int       `json:"identityProviders" jsonschema:"The number of external identity providers in the environment"`
+}
+
+// GetEnvironmentSummaryHandler counts the resources of a PingOne environment using the provided client
+func GetEnvironmentSummaryHandler(statisticsClientFactory StatisticsClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetEnvironmentSummaryInput,
+) (
+	*mcp.CallToolResult,
+	*GetEnvironmentSummaryOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetEnvironmentSummaryInput) (*mcp.CallToolResult, *GetEnvironmentSummaryOutput, error) {
+		client, err := statisticsClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetEnvironmentSummaryDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Summarizing environment", slog.String("environmentId", input.EnvironmentId.String()))
+
+		result := &GetEnvironmentSummaryOutput{
+			EnvironmentId: input.EnvironmentId,
+		}
+		counts := []struct {
+			value *int
+			count func(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error)
+		}{
+			{&result.Users, func(ctx context.Context, environmentId uuid.UUID) (int, *http.Response, error) {
+				return client.CountUsers(ctx, environmentId, "")
+			}},
+			{&result.Populations, client.CountPopulations},
+			{&result.Groups, client.CountGroups},
+			{&result.Applications, client.CountApplications},
+			{&result.IdentityProviders, client.CountIdentityProviders},
+		}
+		for _, c := range counts {
+			*c.value, err = readCount(ctx, func() (int, *http.Response, error) {
+				return c.count(ctx, input.EnvironmentId)
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		return nil, result, nil
+	}
+}
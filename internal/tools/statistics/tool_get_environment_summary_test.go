@@ -0,0 +1,81 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package statistics_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/statistics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func mockEnvironmentCounts(m *mockPingOneClientStatisticsWrapper) {
+	m.On("CountUsers", mock.Anything, testEnvironmentId, "").Return(25000, okResponse, nil)
+	m.On("CountPopulations", mock.Anything, testEnvironmentId).Return(3, okResponse, nil)
+	m.On("CountGroups", mock.Anything, testEnvironmentId).Return(12, okResponse, nil)
+	m.On("CountApplications", mock.Anything, testEnvironmentId).Return(9, okResponse, nil)
+	m.On("CountIdentityProviders", mock.Anything, testEnvironmentId).Return(2, okResponse, nil)
+}
+
+func TestGetEnvironmentSummaryHandler_MockClient(t *testing.T) {
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	mockEnvironmentCounts(mockClient)
+	handler := statistics.GetEnvironmentSummaryHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.GetEnvironmentSummaryInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, statistics.GetEnvironmentSummaryOutput{
+		EnvironmentId:     testEnvironmentId,
+		Users:             25000,
+		Populations:       3,
+		Groups:            12,
+		Applications:      9,
+		IdentityProviders: 2,
+	}, *output)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetEnvironmentSummaryHandler_ViaMcp(t *testing.T) {
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	mockEnvironmentCounts(mockClient)
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, statistics.GetEnvironmentSummaryDef.McpTool, statistics.GetEnvironmentSummaryHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil)))
+
+	result, err := mcptestutils.CallToolOverMcp(t, server, statistics.GetEnvironmentSummaryDef.McpTool.Name, statistics.GetEnvironmentSummaryInput{
+		EnvironmentId: testEnvironmentId,
+	})
+
+	testutils.AssertMcpCallSuccess(t, err, result)
+	output := structuredOutput[statistics.GetEnvironmentSummaryOutput](t, result)
+	assert.Equal(t, 25000, output.Users)
+	assert.Equal(t, 2, output.IdentityProviders)
+}
+
+func TestGetEnvironmentSummaryHandler_APIError(t *testing.T) {
+	mockClient := &mockPingOneClientStatisticsWrapper{}
+	mockClient.On("CountUsers", mock.Anything, testEnvironmentId, "").Return(25000, okResponse, nil)
+	mockClient.On("CountPopulations", mock.Anything, testEnvironmentId).Return(0, &http.Response{StatusCode: http.StatusForbidden}, errors.New("forbidden"))
+	handler := statistics.GetEnvironmentSummaryHandler(NewMockPingOneClientStatisticsWrapperFactory(mockClient, nil))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.GetEnvironmentSummaryInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "forbidden")
+	mockClient.AssertNotCalled(t, "CountGroups", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetEnvironmentSummaryHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := statistics.GetEnvironmentSummaryHandler(NewMockPingOneClientStatisticsWrapperFactory(nil, errors.New("failed to get authenticated client")))
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, statistics.GetEnvironmentSummaryInput{EnvironmentId: testEnvironmentId})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "failed to get authenticated client")
+}
@@ -0,0 +1,91 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package texttemplates
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// TextTemplateMiddleware renders the text content of tool results with operator-provided templates.
+//
+// The text content of a tool result is the fallback for MCP clients that do not read structured content, and
+// holds the JSON output by default. A template replaces the first text content of the result with the
+// rendered text, while the structured content is unchanged. If a template fails to render, the JSON text is
+// kept, so that the tool call does not fail because of the presentation of its result.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware.
+type TextTemplateMiddleware struct {
+	templates TextTemplates
+}
+
+// NewTextTemplateMiddleware creates middleware from the templates returned by LoadTextTemplates.
+func NewTextTemplateMiddleware(templates TextTemplates) *TextTemplateMiddleware {
+	return &TextTemplateMiddleware{
+		templates: templates,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *TextTemplateMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || len(m.templates) == 0 {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		toolName := callToolReq.Params.Name
+		tmpl, ok := m.templates[toolName]
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		result, err := next(ctx, method, req)
+		if err != nil {
+			return result, err
+		}
+		callToolResult, ok := result.(*mcp.CallToolResult)
+		if !ok || callToolResult == nil || callToolResult.IsError || len(callToolResult.Content) == 0 {
+			return result, err
+		}
+		textContent, ok := callToolResult.Content[0].(*mcp.TextContent)
+		if !ok {
+			return result, err
+		}
+
+		output := json.RawMessage(textContent.Text)
+		if callToolResult.StructuredContent != nil {
+			if output, err = json.Marshal(callToolResult.StructuredContent); err != nil {
+				logger.FromContext(ctx).Warn("Failed to marshal structured content for text template",
+					slog.String("tool", toolName),
+					slog.String("error", err.Error()))
+				return result, nil
+			}
+		}
+		if !json.Valid(output) {
+			// Output is not JSON, so there is nothing to render
+			return result, nil
+		}
+
+		text, renderErr := Render(tmpl, output)
+		if renderErr != nil {
+			logger.FromContext(ctx).Warn("Failed to render text template, returning the JSON output",
+				slog.String("tool", toolName),
+				slog.String("error", renderErr.Error()))
+			return result, nil
+		}
+		textContent.Text = text
+
+		logger.FromContext(ctx).Debug("Rendered tool result with text template", slog.String("tool", toolName))
+
+		return callToolResult, nil
+	}
+}
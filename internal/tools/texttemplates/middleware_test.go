@@ -0,0 +1,110 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package texttemplates_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/texttemplates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testThing struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type testToolOutput struct {
+	Things []testThing `json:"things"`
+}
+
+func newThingsServer(t *testing.T, templates texttemplates.TextTemplates) *mcp.Server {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(texttemplates.NewTextTemplateMiddleware(templates).Handler)
+
+	mcp.AddTool(server, testToolDefs[0].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, *testToolOutput, error) {
+		return nil, &testToolOutput{Things: []testThing{{Id: "thing-1", Name: "Retail"}}}, nil
+	})
+	mcp.AddTool(server, testToolDefs[1].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{&mcp.TextContent{Text: `{"id": "thing-1", "name": "Retail"}`}},
+		}, nil, nil
+	})
+	return server
+}
+
+func mustParse(t *testing.T, toolName string, text string) texttemplates.TextTemplates {
+	t.Helper()
+	tmpl, err := texttemplates.ParseTextTemplate(toolName, text)
+	require.NoError(t, err)
+	return texttemplates.TextTemplates{toolName: tmpl}
+}
+
+func TestTextTemplateMiddleware_OverMcp(t *testing.T) {
+	tests := []struct {
+		name               string
+		templates          texttemplates.TextTemplates
+		toolName           string
+		expectedText       string
+		expectedStructured string
+	}{
+		{
+			name:               "Structured output is rendered as text",
+			templates:          mustParse(t, "list_things", `{{range .things}}Business unit {{.name}}{{end}}`),
+			toolName:           "list_things",
+			expectedText:       "Business unit Retail",
+			expectedStructured: `{"things": [{"id": "thing-1", "name": "Retail"}]}`,
+		},
+		{
+			name:         "Text output is rendered",
+			templates:    mustParse(t, "get_thing_text", `Business unit {{.name}}`),
+			toolName:     "get_thing_text",
+			expectedText: "Business unit Retail",
+		},
+		{
+			name:               "Tools without a template return JSON",
+			templates:          mustParse(t, "get_thing_text", `Business unit {{.name}}`),
+			toolName:           "list_things",
+			expectedText:       `{"things": [{"id": "thing-1", "name": "Retail"}]}`,
+			expectedStructured: `{"things": [{"id": "thing-1", "name": "Retail"}]}`,
+		},
+		{
+			name:               "Failed rendering returns JSON",
+			templates:          mustParse(t, "list_things", `Business unit {{.things.name}}`),
+			toolName:           "list_things",
+			expectedText:       `{"things": [{"id": "thing-1", "name": "Retail"}]}`,
+			expectedStructured: `{"things": [{"id": "thing-1", "name": "Retail"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newThingsServer(t, tt.templates)
+
+			result, err := mcptestutils.CallToolOverMcp(t, server, tt.toolName, map[string]any{})
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent, ok := result.Content[0].(*mcp.TextContent)
+			require.True(t, ok)
+			if json.Valid([]byte(tt.expectedText)) {
+				assert.JSONEq(t, tt.expectedText, textContent.Text)
+			} else {
+				assert.Equal(t, tt.expectedText, textContent.Text)
+			}
+
+			if tt.expectedStructured != "" {
+				structuredJSON, err := json.Marshal(result.StructuredContent)
+				require.NoError(t, err)
+				assert.JSONEq(t, tt.expectedStructured, string(structuredJSON))
+			}
+		})
+	}
+}
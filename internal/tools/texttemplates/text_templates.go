@@ -0,0 +1,117 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package texttemplates renders the text content of tool results with operator-provided Go templates, so that
+// deployments can present tool results in their own terminology and formats without code changes.
+package texttemplates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// FileExtension is the extension of template files in the templates directory. Each file is named after the
+// tool whose results it renders, such as list_populations.tmpl.
+const FileExtension = ".tmpl"
+
+// TextTemplates maps a tool name to the template that renders the text content of that tool's results.
+type TextTemplates map[string]*template.Template
+
+// LoadTextTemplates reads a template per tool from the template files of a directory. Other files are
+// ignored. An empty path returns no templates.
+func LoadTextTemplates(dir string, toolDefs []types.ToolDefinition) (TextTemplates, error) {
+	if dir == "" {
+		return TextTemplates{}, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read text templates directory: %w", err)
+	}
+
+	toolNames := make(map[string]bool, len(toolDefs))
+	for _, toolDef := range toolDefs {
+		toolNames[toolDef.McpTool.Name] = true
+	}
+
+	templates := TextTemplates{}
+	for _, entry := range entries {
+		toolName, ok := strings.CutSuffix(entry.Name(), FileExtension)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if !toolNames[toolName] {
+			return nil, fmt.Errorf("invalid text template %q for unknown tool %q", entry.Name(), toolName)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read text template %q: %w", entry.Name(), err)
+		}
+		tmpl, err := ParseTextTemplate(toolName, string(data))
+		if err != nil {
+			return nil, err
+		}
+		templates[toolName] = tmpl
+	}
+
+	return templates, nil
+}
+
+// ParseTextTemplate parses the template that renders the text content of a tool's results. The template is
+// executed with the tool output as decoded from JSON, so that attributes are referenced by their JSON names,
+// such as {{range .populations}}{{.name}}{{end}}.
+//
+// In addition to the built-in template functions, templates can use:
+//
+//	json    - the value as JSON, such as {{json .population}}
+//	join    - the items of a list joined with a separator, such as {{join .redirectUris ", "}}
+//	default - a fallback for a missing or empty value, such as {{default "-" .description}}
+func ParseTextTemplate(toolName string, text string) (*template.Template, error) {
+	tmpl, err := template.New(toolName).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid text template for tool %q: %w", toolName, err)
+	}
+	return tmpl, nil
+}
+
+// Render executes the template with the JSON output of a tool.
+func Render(tmpl *template.Template, output json.RawMessage) (string, error) {
+	var data any
+	if err := json.Unmarshal(output, &data); err != nil {
+		return "", fmt.Errorf("tool output is not valid JSON: %w", err)
+	}
+	var text bytes.Buffer
+	if err := tmpl.Execute(&text, data); err != nil {
+		return "", err
+	}
+	return text.String(), nil
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(value any) (string, error) {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	},
+	"join": func(items []any, separator string) string {
+		texts := make([]string, len(items))
+		for i, item := range items {
+			texts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(texts, separator)
+	},
+	"default": func(fallback any, value any) any {
+		if value == nil || value == "" {
+			return fallback
+		}
+		return value
+	},
+}
@@ -0,0 +1,152 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package texttemplates_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/texttemplates"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "list_things"}},
+	{McpTool: &mcp.Tool{Name: "get_thing_text"}},
+}
+
+// writeTemplates writes the files to a new templates directory
+func writeTemplates(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestLoadTextTemplates(t *testing.T) {
+	tests := []struct {
+		name            string
+		files           map[string]string
+		wantTools       []string
+		wantErrContains string
+	}{
+		{
+			name: "Valid templates",
+			files: map[string]string{
+				"list_things.tmpl":    `{{range .things}}{{.id}}{{end}}`,
+				"get_thing_text.tmpl": `Thing {{.id}}`,
+			},
+			wantTools: []string{"get_thing_text", "list_things"},
+		},
+		{
+			name:      "Other files are ignored",
+			files:     map[string]string{"README.md": "Templates for our deployment"},
+			wantTools: []string{},
+		},
+		{
+			name:            "Unknown tool",
+			files:           map[string]string{"delete_things.tmpl": `Deleted`},
+			wantErrContains: `unknown tool "delete_things"`,
+		},
+		{
+			name:            "Invalid template",
+			files:           map[string]string{"list_things.tmpl": `{{range .things}}`},
+			wantErrContains: `invalid text template for tool "list_things"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates, err := texttemplates.LoadTextTemplates(writeTemplates(t, tt.files), testToolDefs)
+
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			tools := []string{}
+			for toolName := range templates {
+				tools = append(tools, toolName)
+			}
+			slices.Sort(tools)
+			assert.Equal(t, tt.wantTools, tools)
+		})
+	}
+}
+
+func TestLoadTextTemplates_EmptyPath(t *testing.T) {
+	templates, err := texttemplates.LoadTextTemplates("", testToolDefs)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+}
+
+func TestLoadTextTemplates_MissingDirectory(t *testing.T) {
+	_, err := texttemplates.LoadTextTemplates(filepath.Join(t.TempDir(), "missing"), testToolDefs)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read text templates directory")
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		output   string
+		want     string
+	}{
+		{
+			name:     "Attributes by JSON name",
+			template: `{{range .populations}}Business unit {{.name}} has {{.userCount}} members{{"\n"}}{{end}}`,
+			output:   `{"populations": [{"name": "Retail", "userCount": 12}, {"name": "Wholesale", "userCount": 3}]}`,
+			want:     "Business unit Retail has 12 members\nBusiness unit Wholesale has 3 members\n",
+		},
+		{
+			name:     "join",
+			template: `Redirects: {{join .redirectUris ", "}}`,
+			output:   `{"redirectUris": ["https://a.example.com", "https://b.example.com"]}`,
+			want:     "Redirects: https://a.example.com, https://b.example.com",
+		},
+		{
+			name:     "default",
+			template: `{{default "-" .description}} / {{default "-" .name}}`,
+			output:   `{"name": "Retail"}`,
+			want:     "- / Retail",
+		},
+		{
+			name:     "json",
+			template: `Theme: {{json .theme}}`,
+			output:   `{"theme": {"id": "theme-1"}}`,
+			want:     `Theme: {"id":"theme-1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := texttemplates.ParseTextTemplate("list_things", tt.template)
+			require.NoError(t, err)
+
+			text, err := texttemplates.Render(tmpl, json.RawMessage(tt.output))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, text)
+		})
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	tmpl, err := texttemplates.ParseTextTemplate("list_things", `{{join .things ", "}}`)
+	require.NoError(t, err)
+
+	_, err = texttemplates.Render(tmpl, json.RawMessage(`not json`))
+	assert.ErrorContains(t, err, "tool output is not valid JSON")
+
+	_, err = texttemplates.Render(tmpl, json.RawMessage(`{"things": "not a list"}`))
+	assert.Error(t, err)
+}
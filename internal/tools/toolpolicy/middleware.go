@@ -0,0 +1,88 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolpolicy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+)
+
+// ToolDeniedError is returned when a tool that the tool policy denies is called.
+type ToolDeniedError struct {
+	ToolName string
+}
+
+func (e *ToolDeniedError) Error() string {
+	return fmt.Sprintf("tool '%s' is denied by the server's tool policy and cannot be called", e.ToolName)
+}
+
+// ToolPolicyMiddleware hides the tools that the tool policy denies from listed tools and rejects their calls.
+// Each rejected call is recorded in the audit log, when it is enabled. Tools whose action is confirm are
+// confirmed by the confirmation middleware, with the tools returned by Policy.ConfirmedTools.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after authentication, so that
+// rejected calls are recorded with the principal that made them.
+type ToolPolicyMiddleware struct {
+	policy *Policy
+	sink   auditlog.Sink
+}
+
+// NewToolPolicyMiddleware creates middleware that enforces the tool policy, recording rejected calls to the sink.
+// A nil policy allows every tool, and a nil sink records nothing.
+func NewToolPolicyMiddleware(policy *Policy, sink auditlog.Sink) *ToolPolicyMiddleware {
+	return &ToolPolicyMiddleware{
+		policy: policy,
+		sink:   sink,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ToolPolicyMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if m.policy == nil {
+			return next(ctx, method, req)
+		}
+
+		switch method {
+		case "tools/list":
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+			listResult, ok := result.(*mcp.ListToolsResult)
+			if !ok {
+				return result, err
+			}
+
+			allowed := *listResult
+			allowed.Tools = make([]*mcp.Tool, 0, len(listResult.Tools))
+			for _, tool := range listResult.Tools {
+				if m.policy.Action(tool.Name) != ActionDeny {
+					allowed.Tools = append(allowed.Tools, tool)
+				}
+			}
+			return &allowed, nil
+		case "tools/call":
+			callToolReq, ok := req.(*mcp.CallToolRequest)
+			if !ok {
+				// Should never happen for tools/call method, but the policy is mandatory, so we fail the call
+				return nil, fmt.Errorf("tool policy enforcement failed: %w", fmt.Errorf("invalid tool call request"))
+			}
+			toolName := callToolReq.Params.Name
+			if m.policy.Action(toolName) == ActionDeny {
+				deniedErr := &ToolDeniedError{ToolName: toolName}
+				logger.FromContext(ctx).Warn("Rejected call of tool denied by the tool policy",
+					slog.String("tool", toolName))
+				auditlog.RecordDenied(ctx, m.sink, callToolReq, deniedErr.Error())
+				return nil, fmt.Errorf("tool policy: %w", deniedErr)
+			}
+		}
+
+		return next(ctx, method, req)
+	}
+}
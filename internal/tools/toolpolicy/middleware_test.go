@@ -0,0 +1,116 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolpolicy_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/auditlog"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolpolicy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolicyServer(t *testing.T, policy *toolpolicy.Policy, sink auditlog.Sink) *mcp.ClientSession {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(toolpolicy.NewToolPolicyMiddleware(policy, sink).Handler)
+	for _, toolDef := range testToolDefs {
+		mcp.AddTool(server, &mcp.Tool{Name: toolDef.McpTool.Name}, func(ctx context.Context, req *mcp.CallToolRequest, input map[string]any) (*mcp.CallToolResult, any, error) {
+			return nil, map[string]any{"ok": true}, nil
+		})
+	}
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+	session, err := mcptestutils.TestMcpClient(t).Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func listedToolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+	result, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	names := []string{}
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestToolPolicyMiddleware_HidesDeniedTools(t *testing.T) {
+	policy, err := toolpolicy.ParsePolicy([]byte("categories:\n  destructive: deny\ntools:\n  create_thing: confirm\n"), testToolDefs)
+	require.NoError(t, err)
+
+	session := newPolicyServer(t, policy, nil)
+
+	assert.ElementsMatch(t, []string{"list_things", "create_thing"}, listedToolNames(t, session))
+}
+
+func TestToolPolicyMiddleware_RejectsDeniedCalls(t *testing.T) {
+	policy, err := toolpolicy.ParsePolicy([]byte("tools:\n  delete_thing: deny\n"), testToolDefs)
+	require.NoError(t, err)
+	log, err := auditlog.NewFileLog(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+
+	session := newPolicyServer(t, policy, log)
+
+	_, err = session.CallTool(t.Context(), &mcp.CallToolParams{Name: "delete_thing", Arguments: map[string]any{"environmentId": "env-1"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tool 'delete_thing' is denied by the server's tool policy")
+
+	output, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "create_thing", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.False(t, output.IsError)
+
+	entries, _, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "only the denied call is recorded")
+	assert.Equal(t, "delete_thing", entries[0].Tool)
+	assert.Equal(t, auditlog.StatusDenied, entries[0].Status)
+	assert.Equal(t, "env-1", entries[0].EnvironmentId)
+}
+
+func TestToolPolicyMiddleware_NilPolicy(t *testing.T) {
+	middleware := toolpolicy.NewToolPolicyMiddleware(nil, nil)
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "delete_thing"}}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	assert.NoError(t, err)
+	assert.True(t, nextCalled)
+}
+
+func TestToolPolicyMiddleware_ErrorType(t *testing.T) {
+	policy, err := toolpolicy.ParsePolicy([]byte("default: deny\n"), testToolDefs)
+	require.NoError(t, err)
+	middleware := toolpolicy.NewToolPolicyMiddleware(policy, nil)
+
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		t.Fatal("denied calls must not reach the next handler")
+		return nil, nil
+	}
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "list_things"}}
+
+	_, err = middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	var deniedErr *toolpolicy.ToolDeniedError
+	require.True(t, errors.As(err, &deniedErr))
+	assert.Equal(t, "list_things", deniedErr.ToolName)
+}
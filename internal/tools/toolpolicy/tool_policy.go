@@ -0,0 +1,173 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package toolpolicy applies an operator-provided policy that allows, denies or requires confirmation of tools,
+// by tool name or by category, so that a deployment can limit what agents may do beyond the server's own
+// read-only mode and confirmation of destructive tools.
+package toolpolicy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Action is what the policy does with calls of a tool.
+type Action string
+
+const (
+	// ActionAllow lets the tool be called as usual
+	ActionAllow Action = "allow"
+	// ActionDeny hides the tool from MCP clients and rejects its calls
+	ActionDeny Action = "deny"
+	// ActionConfirm asks the user to approve each call of the tool before it runs
+	ActionConfirm Action = "confirm"
+)
+
+// Categories of tools that the policy may set an action for.
+const (
+	// CategoryRead is tools annotated as read-only
+	CategoryRead = "read"
+	// CategoryWrite is tools that are not annotated as read-only, including destructive tools
+	CategoryWrite = "write"
+	// CategoryDestructive is write tools annotated as destructive
+	CategoryDestructive = "destructive"
+)
+
+var (
+	actions    = []Action{ActionAllow, ActionDeny, ActionConfirm}
+	categories = []string{CategoryRead, CategoryWrite, CategoryDestructive}
+)
+
+// Policy maps tool names and categories to actions. The action of a tool is that of its name, or else that of its
+// most specific category (destructive, then write, then read), or else the default action, which is allow.
+//
+// A nil policy allows every tool.
+type Policy struct {
+	Default    Action            `yaml:"default"`
+	Categories map[string]Action `yaml:"categories"`
+	Tools      map[string]Action `yaml:"tools"`
+
+	toolDefs map[string]types.ToolDefinition
+}
+
+// LoadPolicy reads a tool policy from a JSON or YAML file. An empty path returns a nil policy, which allows every
+// tool.
+func LoadPolicy(path string, toolDefs []types.ToolDefinition) (*Policy, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tool policy file: %w", err)
+	}
+
+	return ParsePolicy(data, toolDefs)
+}
+
+// ParsePolicy parses a tool policy from YAML, or from JSON, which is read as YAML, in the form:
+//
+//	default: allow
+//	categories:
+//	  destructive: confirm
+//	tools:
+//	  bulk_delete_users: deny
+//
+// Each tool must exist in toolDefs, so that a policy written for another version of the server is reported when
+// the server starts rather than silently ignored.
+func ParsePolicy(data []byte, toolDefs []types.ToolDefinition) (*Policy, error) {
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid tool policy: %w", err)
+	}
+
+	if policy.Default == "" {
+		policy.Default = ActionAllow
+	}
+	if !slices.Contains(actions, policy.Default) {
+		return nil, fmt.Errorf("invalid tool policy, unknown default action %q, must be one of %v", policy.Default, actions)
+	}
+
+	// Sorted, so that the same error is reported for a policy on every start
+	for _, category := range slices.Sorted(maps.Keys(policy.Categories)) {
+		if !slices.Contains(categories, category) {
+			return nil, fmt.Errorf("invalid tool policy, unknown category %q, must be one of %v", category, categories)
+		}
+		if action := policy.Categories[category]; !slices.Contains(actions, action) {
+			return nil, fmt.Errorf("invalid tool policy for category %q, unknown action %q, must be one of %v", category, action, actions)
+		}
+	}
+
+	policy.toolDefs = make(map[string]types.ToolDefinition, len(toolDefs))
+	for _, toolDef := range toolDefs {
+		policy.toolDefs[toolDef.McpTool.Name] = toolDef
+	}
+	for _, toolName := range slices.Sorted(maps.Keys(policy.Tools)) {
+		if _, ok := policy.toolDefs[toolName]; !ok {
+			return nil, fmt.Errorf("invalid tool policy for unknown tool %q", toolName)
+		}
+		if action := policy.Tools[toolName]; !slices.Contains(actions, action) {
+			return nil, fmt.Errorf("invalid tool policy for tool %q, unknown action %q, must be one of %v", toolName, action, actions)
+		}
+	}
+
+	return policy, nil
+}
+
+// Action returns the action of the policy for the tool. Tools that are not in the tool definitions the policy was
+// parsed with are treated as write tools, so that they are not allowed by a policy that only allows reads.
+func (p *Policy) Action(toolName string) Action {
+	if p == nil {
+		return ActionAllow
+	}
+	if action, ok := p.Tools[toolName]; ok {
+		return action
+	}
+
+	toolDef, ok := p.toolDefs[toolName]
+	if ok && toolDef.IsReadOnly() {
+		if action, ok := p.Categories[CategoryRead]; ok {
+			return action
+		}
+		return p.Default
+	}
+	if ok && isDestructive(toolDef) {
+		if action, ok := p.Categories[CategoryDestructive]; ok {
+			return action
+		}
+	}
+	if action, ok := p.Categories[CategoryWrite]; ok {
+		return action
+	}
+	return p.Default
+}
+
+// ConfirmedTools returns copies of the tools in toolDefs whose action is confirm, set to require confirmation.
+// Tools that already require confirmation keep their ConfirmationArgument, so that their previews still run
+// without asking the user.
+func (p *Policy) ConfirmedTools(toolDefs []types.ToolDefinition) []types.ToolDefinition {
+	var confirmed []types.ToolDefinition
+	for _, toolDef := range toolDefs {
+		if p.Action(toolDef.McpTool.Name) != ActionConfirm {
+			continue
+		}
+		toolDef.RequiresConfirmation = true
+		confirmed = append(confirmed, toolDef)
+	}
+	return confirmed
+}
+
+// isDestructive returns true if the tool is explicitly annotated as destructive
+func isDestructive(toolDef types.ToolDefinition) bool {
+	annotations := toolDef.McpTool.Annotations
+	return annotations != nil && annotations.DestructiveHint != nil && *annotations.DestructiveHint
+}
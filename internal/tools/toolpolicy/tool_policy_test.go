@@ -0,0 +1,211 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolpolicy_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolpolicy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var destructive = true
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "list_things", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}},
+	{McpTool: &mcp.Tool{Name: "create_thing"}},
+	{McpTool: &mcp.Tool{Name: "delete_thing", Annotations: &mcp.ToolAnnotations{DestructiveHint: &destructive}}},
+	{McpTool: &mcp.Tool{Name: "bulk_delete_things", Annotations: &mcp.ToolAnnotations{DestructiveHint: &destructive}}, RequiresConfirmation: true, ConfirmationArgument: "confirmationToken"},
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            string
+		wantDefault     toolpolicy.Action
+		wantErrContains string
+	}{
+		{
+			name:        "YAML",
+			data:        "default: deny\ncategories:\n  read: allow\ntools:\n  create_thing: confirm\n",
+			wantDefault: toolpolicy.ActionDeny,
+		},
+		{
+			name:        "JSON",
+			data:        `{"categories": {"destructive": "deny"}, "tools": {"list_things": "allow"}}`,
+			wantDefault: toolpolicy.ActionAllow,
+		},
+		{
+			name:        "Empty",
+			data:        ``,
+			wantDefault: toolpolicy.ActionAllow,
+		},
+		{
+			name:            "Invalid",
+			data:            `[`,
+			wantErrContains: "invalid tool policy",
+		},
+		{
+			name:            "Unknown attribute",
+			data:            `{"groups": {"write": "deny"}}`,
+			wantErrContains: "invalid tool policy",
+		},
+		{
+			name:            "Unknown default action",
+			data:            `{"default": "block"}`,
+			wantErrContains: `unknown default action "block"`,
+		},
+		{
+			name:            "Unknown category",
+			data:            `{"categories": {"admin": "deny"}}`,
+			wantErrContains: `unknown category "admin"`,
+		},
+		{
+			name:            "Unknown category action",
+			data:            `{"categories": {"write": "block"}}`,
+			wantErrContains: `invalid tool policy for category "write", unknown action "block"`,
+		},
+		{
+			name:            "Unknown tool",
+			data:            `{"tools": {"delete_things": "deny"}}`,
+			wantErrContains: `unknown tool "delete_things"`,
+		},
+		{
+			name:            "Unknown tool action",
+			data:            `{"tools": {"delete_thing": "block"}}`,
+			wantErrContains: `invalid tool policy for tool "delete_thing", unknown action "block"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := toolpolicy.ParsePolicy([]byte(tt.data), testToolDefs)
+
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDefault, policy.Default)
+		})
+	}
+}
+
+func TestPolicyAction(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want map[string]toolpolicy.Action
+	}{
+		{
+			name: "Default allow",
+			data: ``,
+			want: map[string]toolpolicy.Action{
+				"list_things":  toolpolicy.ActionAllow,
+				"create_thing": toolpolicy.ActionAllow,
+				"delete_thing": toolpolicy.ActionAllow,
+			},
+		},
+		{
+			name: "Read-only",
+			data: "default: deny\ncategories:\n  read: allow\n",
+			want: map[string]toolpolicy.Action{
+				"list_things":   toolpolicy.ActionAllow,
+				"create_thing":  toolpolicy.ActionDeny,
+				"delete_thing":  toolpolicy.ActionDeny,
+				"unknown_thing": toolpolicy.ActionDeny,
+			},
+		},
+		{
+			name: "Destructive is more specific than write",
+			data: "categories:\n  write: confirm\n  destructive: deny\n",
+			want: map[string]toolpolicy.Action{
+				"list_things":   toolpolicy.ActionAllow,
+				"create_thing":  toolpolicy.ActionConfirm,
+				"delete_thing":  toolpolicy.ActionDeny,
+				"unknown_thing": toolpolicy.ActionConfirm,
+			},
+		},
+		{
+			name: "Destructive tools fall back to write",
+			data: "categories:\n  write: deny\n",
+			want: map[string]toolpolicy.Action{
+				"list_things":  toolpolicy.ActionAllow,
+				"delete_thing": toolpolicy.ActionDeny,
+			},
+		},
+		{
+			name: "Tool is more specific than category",
+			data: "categories:\n  destructive: deny\n  read: deny\ntools:\n  delete_thing: confirm\n  list_things: allow\n",
+			want: map[string]toolpolicy.Action{
+				"list_things":        toolpolicy.ActionAllow,
+				"delete_thing":       toolpolicy.ActionConfirm,
+				"bulk_delete_things": toolpolicy.ActionDeny,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := toolpolicy.ParsePolicy([]byte(tt.data), testToolDefs)
+			require.NoError(t, err)
+
+			for toolName, want := range tt.want {
+				assert.Equal(t, want, policy.Action(toolName), toolName)
+			}
+		})
+	}
+}
+
+func TestPolicyAction_NilPolicy(t *testing.T) {
+	var policy *toolpolicy.Policy
+
+	assert.Equal(t, toolpolicy.ActionAllow, policy.Action("delete_thing"))
+	assert.Empty(t, policy.ConfirmedTools(testToolDefs))
+}
+
+func TestPolicyConfirmedTools(t *testing.T) {
+	policy, err := toolpolicy.ParsePolicy([]byte("categories:\n  destructive: confirm\ntools:\n  create_thing: confirm\n"), testToolDefs)
+	require.NoError(t, err)
+
+	confirmed := policy.ConfirmedTools(testToolDefs)
+
+	require.Len(t, confirmed, 3)
+	names := []string{}
+	for _, toolDef := range confirmed {
+		names = append(names, toolDef.McpTool.Name)
+		assert.True(t, toolDef.RequiresConfirmation, toolDef.McpTool.Name)
+	}
+	assert.Equal(t, []string{"create_thing", "delete_thing", "bulk_delete_things"}, names)
+	assert.Equal(t, "confirmationToken", confirmed[2].ConfirmationArgument, "tools keep their confirmation argument")
+	assert.False(t, testToolDefs[1].RequiresConfirmation, "the tool definitions are not changed")
+}
+
+func TestLoadPolicy(t *testing.T) {
+	t.Run("Empty path", func(t *testing.T) {
+		policy, err := toolpolicy.LoadPolicy("", testToolDefs)
+		require.NoError(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("tools:\n  delete_thing: deny\n"), 0600))
+
+		policy, err := toolpolicy.LoadPolicy(path, testToolDefs)
+		require.NoError(t, err)
+		assert.Equal(t, toolpolicy.ActionDeny, policy.Action("delete_thing"))
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := toolpolicy.LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"), testToolDefs)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read tool policy file")
+	})
+}
@@ -0,0 +1,178 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolsets
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// DynamicToolsets registers and removes the tools of toolsets while the server runs. The server sends a
+// tools/list_changed notification to connected clients whenever its tools change.
+//
+// The tool filter of the server still applies to the tools of enabled toolsets, so enabling a toolset in
+// read-only mode only adds its read-only tools.
+type DynamicToolsets struct {
+	server                 *mcp.Server
+	clientFactory          sdk.ClientFactory
+	legacySdkClientFactory legacy.ClientFactory
+	tokenStore             tokenstore.TokenStore
+	toolFilter             *filter.Filter
+	collectionState        *tools.CollectionState
+
+	mu      sync.Mutex
+	enabled []string
+}
+
+// ToolsetStatus describes a toolset and whether its tools are registered with the server
+type ToolsetStatus struct {
+	Name        string   `json:"name" jsonschema:"The name of the toolset"`
+	Description string   `json:"description" jsonschema:"What the tools of the toolset are for"`
+	Enabled     bool     `json:"enabled" jsonschema:"True if the tools of the toolset are available"`
+	Tools       []string `json:"tools" jsonschema:"The names of the tools of the toolset that are available when it is enabled, which excludes write tools in read-only mode"`
+}
+
+// NewDynamicToolsets creates the dynamic toolsets of the server, with the tools of the toolset collections
+// filtered by the tool filter. The collection state is shared by every registration of the tools, so it is kept
+// when a toolset is disabled and enabled again.
+func NewDynamicToolsets(server *mcp.Server, clientFactory sdk.ClientFactory, legacySdkClientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter, collectionState *tools.CollectionState) *DynamicToolsets {
+	return &DynamicToolsets{
+		server:                 server,
+		clientFactory:          clientFactory,
+		legacySdkClientFactory: legacySdkClientFactory,
+		tokenStore:             tokenStore,
+		toolFilter:             toolFilter,
+		collectionState:        collectionState,
+	}
+}
+
+// RegisterCollections registers the tools of the common collections, and of the named toolsets, which are enabled
+// from the start.
+func (d *DynamicToolsets) RegisterCollections(ctx context.Context, enabledToolsets []string) error {
+	if err := d.registerCollections(ctx, commonCollections); err != nil {
+		return err
+	}
+	for _, name := range enabledToolsets {
+		if err := d.Enable(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enable registers the tools of the named toolset. Enabling a toolset that is already enabled has no effect.
+func (d *DynamicToolsets) Enable(ctx context.Context, name string) error {
+	t, err := Get(name)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !slices.Contains(d.enabled, t.Name) {
+		if err := d.registerCollections(ctx, t.Collections); err != nil {
+			return fmt.Errorf("failed to enable toolset %q: %w", t.Name, err)
+		}
+		d.enabled = append(d.enabled, t.Name)
+		logger.FromContext(ctx).Info("Toolset enabled", slog.String("toolset", t.Name))
+	}
+	return nil
+}
+
+// Disable removes the tools of the named toolset. Disabling a toolset that is not enabled has no effect.
+func (d *DynamicToolsets) Disable(ctx context.Context, name string) error {
+	t, err := Get(name)
+	if err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i := slices.Index(d.enabled, t.Name); i >= 0 {
+		d.server.RemoveTools(d.toolNames(t.Collections)...)
+		d.enabled = slices.Delete(d.enabled, i, i+1)
+		logger.FromContext(ctx).Info("Toolset disabled", slog.String("toolset", t.Name))
+	}
+	return nil
+}
+
+// Toolsets returns the status of all toolsets
+func (d *DynamicToolsets) Toolsets() []ToolsetStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	statuses := make([]ToolsetStatus, len(toolsets))
+	for i, t := range toolsets {
+		statuses[i] = d.status(t)
+	}
+	return statuses
+}
+
+func (d *DynamicToolsets) status(t Toolset) ToolsetStatus {
+	return ToolsetStatus{
+		Name:        t.Name,
+		Description: t.Description,
+		Enabled:     slices.Contains(d.enabled, t.Name),
+		Tools:       d.toolNames(t.Collections),
+	}
+}
+
+func (d *DynamicToolsets) registerCollections(ctx context.Context, collectionNames []string) error {
+	collectionFilter := restrictFilter(d.toolFilter, collectionNames)
+	if collectionFilter == nil {
+		return nil
+	}
+	return tools.RegisterCollections(ctx, d.server, d.clientFactory, d.legacySdkClientFactory, d.tokenStore, collectionFilter, d.collectionState)
+}
+
+func (d *DynamicToolsets) toolNames(collectionNames []string) []string {
+	toolNames := []string{}
+	collectionFilter := restrictFilter(d.toolFilter, collectionNames)
+	if collectionFilter == nil {
+		return toolNames
+	}
+	for _, toolDef := range tools.ListEnabledTools(collectionFilter) {
+		toolNames = append(toolNames, toolDef.McpTool.Name)
+	}
+	return toolNames
+}
+
+// ListEnabledTools returns the definitions of the tools that are registered with the tool filter when only the
+// named toolsets are enabled.
+func ListEnabledTools(toolFilter *filter.Filter, names []string) ([]types.ToolDefinition, error) {
+	collectionNames, err := CollectionNames(names)
+	if err != nil {
+		return nil, err
+	}
+	collectionFilter := restrictFilter(toolFilter, collectionNames)
+	if collectionFilter == nil {
+		return nil, nil
+	}
+	return tools.ListEnabledTools(collectionFilter), nil
+}
+
+// restrictFilter returns the tool filter restricted to the named collections, or nil if the tool filter excludes
+// all of them
+func restrictFilter(toolFilter *filter.Filter, collectionNames []string) *filter.Filter {
+	var includedCollections []string
+	for _, collectionName := range collectionNames {
+		if toolFilter.ShouldIncludeCollection(collectionName) {
+			includedCollections = append(includedCollections, collectionName)
+		}
+	}
+	if len(includedCollections) == 0 {
+		return nil
+	}
+	return filter.NewFilter(toolFilter.ReadOnly, toolFilter.IncludedTools, toolFilter.ExcludedTools, includedCollections, toolFilter.ExcludedToolCollections)
+}
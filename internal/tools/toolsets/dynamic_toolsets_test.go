@@ -0,0 +1,206 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolsets_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolsets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dynamicToolsetsServer serves the dynamic toolsets with the initially enabled toolsets, and returns a connected
+// client session and a channel that receives the tool list changed notifications
+func dynamicToolsetsServer(t *testing.T, toolFilter *filter.Filter, enabledToolsets []string) (*mcp.ClientSession, chan struct{}) {
+	t.Helper()
+	return dynamicToolsetsServerWithState(t, toolFilter, enabledToolsets, tools.NewCollectionState(t.Context(), sdk.NewEmptyClientFactory(), testutils.NewInMemoryTokenStore()))
+}
+
+// dynamicToolsetsServerWithState is dynamicToolsetsServer with the given collection state
+func dynamicToolsetsServerWithState(t *testing.T, toolFilter *filter.Filter, enabledToolsets []string, collectionState *tools.CollectionState) (*mcp.ClientSession, chan struct{}) {
+	t.Helper()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "test-version"}, nil)
+	dynamicToolsets := toolsets.NewDynamicToolsets(server, sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), testutils.NewInMemoryTokenStore(), toolFilter, collectionState)
+	require.NoError(t, dynamicToolsets.RegisterCollections(t.Context(), enabledToolsets))
+	toolsets.RegisterToolsetTools(server, dynamicToolsets)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	listChanged := make(chan struct{}, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "test-version"}, &mcp.ClientOptions{
+		ToolListChangedHandler: func(ctx context.Context, req *mcp.ToolListChangedRequest) {
+			listChanged <- struct{}{}
+		},
+	})
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session, listChanged
+}
+
+func listToolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+	result, err := session.ListTools(t.Context(), &mcp.ListToolsParams{})
+	require.NoError(t, err)
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func waitForListChanged(t *testing.T, listChanged chan struct{}) {
+	t.Helper()
+	select {
+	case <-listChanged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for tool list changed notification")
+	}
+}
+
+func callToolset(t *testing.T, session *mcp.ClientSession, toolName, toolset string) toolsets.ToolsetsOutput {
+	t.Helper()
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: toolName, Arguments: map[string]any{"toolset": toolset}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, "tool call should succeed")
+	structuredJSON, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var output toolsets.ToolsetsOutput
+	require.NoError(t, json.Unmarshal(structuredJSON, &output))
+	return output
+}
+
+func toolsetStatus(t *testing.T, output toolsets.ToolsetsOutput, name string) toolsets.ToolsetStatus {
+	t.Helper()
+	for _, status := range output.Toolsets {
+		if status.Name == name {
+			return status
+		}
+	}
+	t.Fatalf("toolset %s not found", name)
+	return toolsets.ToolsetStatus{}
+}
+
+func TestDynamicToolsets_RegistersEnabledToolsets(t *testing.T) {
+	session, _ := dynamicToolsetsServer(t, filter.NewFilter(true, nil, nil, nil, nil), []string{"roles"})
+
+	toolNames := listToolNames(t, session)
+	assert.Contains(t, toolNames, "list_roles")
+	assert.Contains(t, toolNames, "build_scim_filter", "common collections should always be registered")
+	assert.Contains(t, toolNames, "enable_toolset")
+	assert.Contains(t, toolNames, "disable_toolset")
+	assert.NotContains(t, toolNames, "find_user")
+	assert.NotContains(t, toolNames, "assign_role_to_user", "write tools should not be registered in read-only mode")
+}
+
+func TestDynamicToolsets_EnableAndDisable(t *testing.T) {
+	session, listChanged := dynamicToolsetsServer(t, filter.NewFilter(false, nil, nil, nil, nil), nil)
+	assert.NotContains(t, listToolNames(t, session), "find_user")
+
+	output := callToolset(t, session, "enable_toolset", "users")
+	waitForListChanged(t, listChanged)
+	users := toolsetStatus(t, output, "users")
+	assert.True(t, users.Enabled)
+	assert.Contains(t, users.Tools, "find_user")
+	assert.Contains(t, users.Tools, "set_user_enabled")
+	assert.Contains(t, users.Tools, "list_populations")
+	assert.False(t, toolsetStatus(t, output, "roles").Enabled)
+
+	toolNames := listToolNames(t, session)
+	assert.Contains(t, toolNames, "find_user")
+	assert.Contains(t, toolNames, "set_user_enabled")
+	assert.Contains(t, toolNames, "list_populations")
+
+	output = callToolset(t, session, "disable_toolset", "users")
+	waitForListChanged(t, listChanged)
+	assert.False(t, toolsetStatus(t, output, "users").Enabled)
+
+	toolNames = listToolNames(t, session)
+	assert.NotContains(t, toolNames, "find_user")
+	assert.NotContains(t, toolNames, "list_populations")
+	assert.Contains(t, toolNames, "build_scim_filter", "common collections should not be removed with a toolset")
+}
+
+func TestDynamicToolsets_ReenabledToolsetKeepsState(t *testing.T) {
+	collectionState := tools.NewCollectionState(t.Context(), sdk.NewEmptyClientFactory(), testutils.NewInMemoryTokenStore())
+	session, listChanged := dynamicToolsetsServerWithState(t, filter.NewFilter(false, nil, nil, nil, nil), []string{"environments"}, collectionState)
+
+	environmentId := uuid.New()
+	_, err := collectionState.DeletionScheduler.Schedule(t.Context(), environmentId, "Test Environment", time.Hour, nil, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _, _ = collectionState.DeletionScheduler.Cancel(environmentId) })
+
+	callToolset(t, session, "disable_toolset", "environments")
+	waitForListChanged(t, listChanged)
+	callToolset(t, session, "enable_toolset", "environments")
+	waitForListChanged(t, listChanged)
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "cancel_environment_deletion", Arguments: map[string]any{"environmentId": environmentId.String()}})
+	require.NoError(t, err)
+	assert.False(t, result.IsError, "the deletion scheduled before the toolset was disabled should be cancellable")
+}
+
+func TestDynamicToolsets_EnableRespectsToolFilter(t *testing.T) {
+	session, listChanged := dynamicToolsetsServer(t, filter.NewFilter(true, nil, []string{"get_population"}, nil, nil), nil)
+
+	output := callToolset(t, session, "enable_toolset", "users")
+	waitForListChanged(t, listChanged)
+	users := toolsetStatus(t, output, "users")
+	assert.Contains(t, users.Tools, "find_user")
+	assert.NotContains(t, users.Tools, "set_user_enabled", "write tools should not be enabled in read-only mode")
+	assert.NotContains(t, users.Tools, "get_population", "excluded tools should not be enabled")
+
+	toolNames := listToolNames(t, session)
+	assert.Contains(t, toolNames, "find_user")
+	assert.NotContains(t, toolNames, "set_user_enabled")
+	assert.NotContains(t, toolNames, "get_population")
+}
+
+func TestDynamicToolsets_UnknownToolset(t *testing.T) {
+	session, _ := dynamicToolsetsServer(t, filter.NewFilter(true, nil, nil, nil, nil), nil)
+
+	for _, toolName := range []string{"enable_toolset", "disable_toolset"} {
+		result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: toolName, Arguments: map[string]any{"toolset": "unknown"}})
+		require.NoError(t, err)
+		assert.True(t, result.IsError, toolName)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, `unknown toolset "unknown"`, toolName)
+	}
+}
+
+func TestDynamicToolsets_RegisterCollections_UnknownToolset(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "test-version"}, nil)
+	collectionState := tools.NewCollectionState(t.Context(), sdk.NewEmptyClientFactory(), testutils.NewInMemoryTokenStore())
+	dynamicToolsets := toolsets.NewDynamicToolsets(server, sdk.NewEmptyClientFactory(), legacy.NewEmptyClientFactory(), testutils.NewInMemoryTokenStore(), filter.PassthroughFilter(), collectionState)
+
+	err := dynamicToolsets.RegisterCollections(t.Context(), []string{"unknown"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown toolset "unknown"`)
+}
+
+func TestListEnabledTools(t *testing.T) {
+	toolDefs, err := toolsets.ListEnabledTools(filter.NewFilter(true, nil, nil, nil, nil), []string{"roles"})
+	require.NoError(t, err)
+
+	var toolNames []string
+	for _, toolDef := range toolDefs {
+		toolNames = append(toolNames, toolDef.McpTool.Name)
+	}
+	assert.Contains(t, toolNames, "list_roles")
+	assert.Contains(t, toolNames, "build_scim_filter")
+	assert.NotContains(t, toolNames, "assign_role_to_user")
+	assert.NotContains(t, toolNames, "find_user")
+}
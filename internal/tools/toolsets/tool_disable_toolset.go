@@ -0,0 +1,49 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolsets
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var DisableToolsetDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:         "disable_toolset",
+		Title:        "Disable Toolset",
+		Description:  "Remove the tools of a toolset from this session, such as write tools that are no longer needed, so that they cannot be called by mistake. The toolset can be enabled again with 'enable_toolset'. Returns the status of every toolset; the tool list is refreshed once the toolset is disabled.",
+		InputSchema:  schema.MustGenerateSchema[ToolsetInput](),
+		OutputSchema: schema.MustGenerateSchema[ToolsetsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	},
+}
+
+// DisableToolsetHandler disables the requested toolset using the provided dynamic toolsets
+func DisableToolsetHandler(dynamicToolsets *DynamicToolsets) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ToolsetInput,
+) (
+	*mcp.CallToolResult,
+	*ToolsetsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ToolsetInput) (*mcp.CallToolResult, *ToolsetsOutput, error) {
+		if err := dynamicToolsets.Disable(ctx, input.Toolset); err != nil {
+			toolErr := errs.NewToolError(DisableToolsetDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		return nil, &ToolsetsOutput{Toolsets: dynamicToolsets.Toolsets()}, nil
+	}
+}
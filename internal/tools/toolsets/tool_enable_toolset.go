@@ -0,0 +1,83 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolsets
+
+import (
+	"context"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var EnableToolsetDef = types.ToolDefinition{
+	ValidationPolicy: &types.ToolValidationPolicy{
+		ProductionEnvironmentNotApplicable: true,
+	},
+	McpTool: &mcp.Tool{
+		Name:  "enable_toolset",
+		Title: "Enable Toolset",
+		Description: "Make the tools of a toolset available in this session, when the user needs tools that are not listed. Toolsets are: " + describeToolsets() + ". " +
+			"Write tools are only added if the server allows them, so enabling a toolset in read-only mode only adds its read-only tools. Returns the status of every toolset; the tool list is refreshed once the toolset is enabled.",
+		InputSchema:  schema.MustGenerateSchema[ToolsetInput](),
+		OutputSchema: schema.MustGenerateSchema[ToolsetsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint:   true,
+			IdempotentHint: true,
+		},
+	},
+}
+
+// ToolsetInput defines the input parameters for enabling or disabling a toolset
+type ToolsetInput struct {
+	Toolset string `json:"toolset" jsonschema:"REQUIRED. The name of the toolset."`
+}
+
+// ToolsetsOutput represents the status of the toolsets after a toolset is enabled or disabled
+type ToolsetsOutput struct {
+	Toolsets []ToolsetStatus `json:"toolsets" jsonschema:"The status of all toolsets"`
+}
+
+// EnableToolsetHandler enables the requested toolset using the provided dynamic toolsets
+func EnableToolsetHandler(dynamicToolsets *DynamicToolsets) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ToolsetInput,
+) (
+	*mcp.CallToolResult,
+	*ToolsetsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ToolsetInput) (*mcp.CallToolResult, *ToolsetsOutput, error) {
+		if err := dynamicToolsets.Enable(ctx, input.Toolset); err != nil {
+			toolErr := errs.NewToolError(EnableToolsetDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		return nil, &ToolsetsOutput{Toolsets: dynamicToolsets.Toolsets()}, nil
+	}
+}
+
+// RegisterToolsetTools adds the enable_toolset and disable_toolset tools to the MCP server.
+func RegisterToolsetTools(server *mcp.Server, dynamicToolsets *DynamicToolsets) {
+	mcp.AddTool(server, EnableToolsetDef.McpTool, EnableToolsetHandler(dynamicToolsets))
+	mcp.AddTool(server, DisableToolsetDef.McpTool, DisableToolsetHandler(dynamicToolsets))
+}
+
+// ListTools returns the definitions of the tools that enable and disable toolsets
+func ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		EnableToolsetDef,
+		DisableToolsetDef,
+	}
+}
+
+func describeToolsets() string {
+	descriptions := make([]string, len(toolsets))
+	for i, t := range toolsets {
+		descriptions[i] = "'" + t.Name + "' (" + t.Description + ")"
+	}
+	return strings.Join(descriptions, ", ")
+}
@@ -0,0 +1,106 @@
+// Copyright © 2025 Ping Identity Corporation
+
+// Package toolsets provides coarse groups of tool collections for the areas of PingOne administration, so that
+// operators can expose only the tools relevant to their work without listing individual collections.
+package toolsets
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// commonCollections are the tool collections enabled with every toolset, as they help to use the tools of any
+// toolset, by building the filters they take and finding the resources they act on
+var commonCollections = []string{
+	"scim_filters",
+	"search",
+}
+
+// Toolset is a group of tool collections for an area of PingOne administration.
+type Toolset struct {
+	Name        string
+	Description string
+	// Collections are the names of the tool collections in the toolset, in addition to the common collections.
+	Collections []string
+}
+
+var toolsets = []Toolset{
+	{
+		Name:        "environments",
+		Description: "Environments, their services, licenses and alert channels, identity and resource counts, and environment cloning, export and comparison",
+		Collections: []string{"environments", "environment_cloning", "environment_export", "licenses", "directory", "statistics", "alerting"},
+	},
+	{
+		Name:        "users",
+		Description: "Users, the groups and populations they belong to and the verifiable credentials issued to them",
+		Collections: []string{"users", "groups", "populations", "credentials"},
+	},
+	{
+		Name:        "applications",
+		Description: "Applications, the resources and scopes they are granted, external identity providers and PingOne Authorize policies",
+		Collections: []string{"applications", "resources", "identity_providers", "authorize"},
+	},
+	{
+		Name:        "roles",
+		Description: "Administrator roles and role assignments",
+		Collections: []string{"roles"},
+	},
+	{
+		Name:        "audit",
+		Description: "Audit events, configuration change history, MFA sign-on metrics and access reviews",
+		Collections: []string{"audit", "access_review"},
+	},
+	{
+		Name:        "experience",
+		Description: "Branding themes, agreements and localization of what users see",
+		Collections: []string{"branding_themes", "agreements", "localization"},
+	},
+	{
+		Name:        "davinci",
+		Description: "DaVinci flows and flow policies",
+		Collections: []string{"davinci"},
+	},
+}
+
+// List returns all toolsets.
+func List() []Toolset {
+	return slices.Clone(toolsets)
+}
+
+// Names returns the names of all toolsets.
+func Names() []string {
+	names := make([]string, len(toolsets))
+	for i, t := range toolsets {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Get returns the toolset with the given name.
+func Get(name string) (*Toolset, error) {
+	for _, t := range toolsets {
+		if t.Name == name {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown toolset %q, valid toolsets are: %s", name, strings.Join(Names(), ", "))
+}
+
+// CollectionNames returns the names of the tool collections enabled by the named toolsets, including the common
+// collections, without duplicates.
+func CollectionNames(names []string) ([]string, error) {
+	collectionNames := slices.Clone(commonCollections)
+	for _, name := range names {
+		t, err := Get(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, collectionName := range t.Collections {
+			if !slices.Contains(collectionNames, collectionName) {
+				collectionNames = append(collectionNames, collectionName)
+			}
+		}
+	}
+	return collectionNames, nil
+}
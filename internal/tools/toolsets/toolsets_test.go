@@ -0,0 +1,73 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package toolsets_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/toolsets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolsets_CollectionsExist(t *testing.T) {
+	collectionNames := tools.ListCollectionNames()
+	for _, toolset := range toolsets.List() {
+		t.Run(toolset.Name, func(t *testing.T) {
+			assert.NotEmpty(t, toolset.Description)
+			assert.NotEmpty(t, toolset.Collections)
+			for _, collectionName := range toolset.Collections {
+				assert.Contains(t, collectionNames, collectionName, "toolset collection should be a tool collection")
+			}
+		})
+	}
+}
+
+func TestToolsets_EveryCollectionInAToolset(t *testing.T) {
+	all, err := toolsets.CollectionNames(toolsets.Names())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, tools.ListCollectionNames(), all, "every tool collection should be in a toolset or common to all toolsets")
+}
+
+func TestCollectionNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		toolsets      []string
+		expected      []string
+		errorContains string
+	}{
+		{
+			name:     "No toolsets enable the common collections",
+			toolsets: []string{},
+			expected: []string{"scim_filters", "search"},
+		},
+		{
+			name:     "Single toolset",
+			toolsets: []string{"users"},
+			expected: []string{"scim_filters", "search", "users", "groups", "populations", "credentials"},
+		},
+		{
+			name:     "Multiple toolsets",
+			toolsets: []string{"environments", "roles", "roles"},
+			expected: []string{"scim_filters", "search", "environments", "environment_cloning", "environment_export", "licenses", "directory", "statistics", "alerting", "roles"},
+		},
+		{
+			name:          "Unknown toolset",
+			toolsets:      []string{"users", "groups"},
+			errorContains: `unknown toolset "groups", valid toolsets are: environments, users, applications, roles, audit, experience, davinci`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collectionNames, err := toolsets.CollectionNames(tt.toolsets)
+			if tt.errorContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, collectionNames)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltelemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	toolCallMethod = "tools/call"
+
+	// errorTypeToolError is the error type of calls that returned a result flagged as an error
+	errorTypeToolError = "tool_error"
+)
+
+// ToolTelemetryMiddleware records each tool call as an OpenTelemetry span, with the tool name and environment ID,
+// and records the duration of calls per tool and outcome as a metric. PingOne API requests made by the call are
+// recorded as child spans by the SDK clients.
+//
+// Spans and metrics are recorded with the global OpenTelemetry providers, so they are only exported once
+// telemetry is set up.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, before any middleware that
+// makes PingOne API requests or rejects calls, such as auth and environment validation, so that their
+// requests and rejections are recorded too.
+type ToolTelemetryMiddleware struct {
+	tracer            trace.Tracer
+	operationDuration metric.Float64Histogram
+}
+
+// NewToolTelemetryMiddleware creates middleware that records tool calls with the global OpenTelemetry providers.
+func NewToolTelemetryMiddleware() (*ToolTelemetryMiddleware, error) {
+	return NewToolTelemetryMiddlewareWithProviders(otel.GetTracerProvider(), otel.GetMeterProvider())
+}
+
+// NewToolTelemetryMiddlewareWithProviders creates middleware that records tool calls with the providers.
+func NewToolTelemetryMiddlewareWithProviders(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*ToolTelemetryMiddleware, error) {
+	operationDuration, err := meterProvider.Meter(telemetry.InstrumentationName).Float64Histogram("mcp.server.operation.duration",
+		metric.WithDescription("Duration of MCP tool calls."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tool call duration metric: %w", err)
+	}
+	return &ToolTelemetryMiddleware{
+		tracer:            tracerProvider.Tracer(telemetry.InstrumentationName),
+		operationDuration: operationDuration,
+	}, nil
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ToolTelemetryMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != toolCallMethod {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		toolName := callToolReq.Params.Name
+
+		attributes := []attribute.KeyValue{
+			attribute.String("mcp.method.name", toolCallMethod),
+			attribute.String("gen_ai.tool.name", toolName),
+		}
+
+		ctx, span := m.tracer.Start(ctx, toolCallMethod+" "+toolName,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attributes...))
+		defer span.End()
+		if environmentId := environmentIdFromArguments(callToolReq.Params.Arguments); environmentId != "" {
+			span.SetAttributes(attribute.String("pingone.environment.id", environmentId))
+		}
+
+		start := time.Now()
+		result, err := next(ctx, method, req)
+		duration := time.Since(start)
+
+		if err != nil {
+			attributes = append(attributes, attribute.String("error.type", fmt.Sprintf("%T", err)))
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult != nil && callToolResult.IsError {
+			attributes = append(attributes, attribute.String("error.type", errorTypeToolError))
+			span.SetStatus(codes.Error, "tool returned an error result")
+		}
+		span.SetAttributes(attributes...)
+		m.operationDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attributes...))
+
+		return result, err
+	}
+}
+
+// environmentIdFromArguments returns the environmentId argument of a call, or an empty string if it has none
+func environmentIdFromArguments(argsJSON json.RawMessage) string {
+	var args struct {
+		EnvironmentId string `json:"environmentId"`
+	}
+	if err := json.Unmarshal(argsJSON, &args); err != nil {
+		return ""
+	}
+	return args.EnvironmentId
+}
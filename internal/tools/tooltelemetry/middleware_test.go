@@ -0,0 +1,153 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltelemetry_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltelemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type testTelemetry struct {
+	spans   *tracetest.SpanRecorder
+	metrics *sdkmetric.ManualReader
+}
+
+func newTestMiddleware(t *testing.T) (*tooltelemetry.ToolTelemetryMiddleware, *testTelemetry) {
+	t.Helper()
+	telemetry := &testTelemetry{
+		spans:   tracetest.NewSpanRecorder(),
+		metrics: sdkmetric.NewManualReader(),
+	}
+	middleware, err := tooltelemetry.NewToolTelemetryMiddlewareWithProviders(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(telemetry.spans)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(telemetry.metrics)))
+	require.NoError(t, err)
+	return middleware, telemetry
+}
+
+// durationDataPoints returns the recorded tool call durations
+func (tt *testTelemetry) durationDataPoints(t *testing.T) []metricdata.HistogramDataPoint[float64] {
+	t.Helper()
+	var resourceMetrics metricdata.ResourceMetrics
+	require.NoError(t, tt.metrics.Collect(context.Background(), &resourceMetrics))
+	require.Len(t, resourceMetrics.ScopeMetrics, 1)
+	require.Len(t, resourceMetrics.ScopeMetrics[0].Metrics, 1)
+	metric := resourceMetrics.ScopeMetrics[0].Metrics[0]
+	assert.Equal(t, "mcp.server.operation.duration", metric.Name)
+	return metric.Data.(metricdata.Histogram[float64]).DataPoints
+}
+
+func callToolRequest(toolName string, args map[string]any) *mcp.CallToolRequest {
+	argsJSON, _ := json.Marshal(args)
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: toolName, Arguments: argsJSON},
+	}
+}
+
+func attributeValue(attributes []attribute.KeyValue, key string) (string, bool) {
+	for _, kv := range attributes {
+		if string(kv.Key) == key {
+			return kv.Value.Emit(), true
+		}
+	}
+	return "", false
+}
+
+func TestToolTelemetryMiddleware_RecordsSuccessfulCall(t *testing.T) {
+	middleware, telemetry := newTestMiddleware(t)
+	var spanContext trace.SpanContext
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		spanContext = trace.SpanContextFromContext(ctx)
+		return &mcp.CallToolResult{}, nil
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", callToolRequest("list_users", map[string]any{"environmentId": "env-1"}))
+
+	require.NoError(t, err)
+	spans := telemetry.spans.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "tools/call list_users", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Equal(t, span.SpanContext().SpanID(), spanContext.SpanID(), "the tool should be called with the span in its context, so that PingOne API requests are child spans")
+	toolName, _ := attributeValue(span.Attributes(), "gen_ai.tool.name")
+	assert.Equal(t, "list_users", toolName)
+	environmentId, _ := attributeValue(span.Attributes(), "pingone.environment.id")
+	assert.Equal(t, "env-1", environmentId)
+
+	dataPoints := telemetry.durationDataPoints(t)
+	require.Len(t, dataPoints, 1)
+	assert.Equal(t, uint64(1), dataPoints[0].Count)
+	toolName, _ = attributeValue(dataPoints[0].Attributes.ToSlice(), "gen_ai.tool.name")
+	assert.Equal(t, "list_users", toolName)
+	_, hasErrorType := attributeValue(dataPoints[0].Attributes.ToSlice(), "error.type")
+	assert.False(t, hasErrorType)
+	_, hasEnvironmentId := attributeValue(dataPoints[0].Attributes.ToSlice(), "pingone.environment.id")
+	assert.False(t, hasEnvironmentId, "metrics should not be recorded per environment")
+}
+
+func TestToolTelemetryMiddleware_RecordsToolErrors(t *testing.T) {
+	middleware, telemetry := newTestMiddleware(t)
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{IsError: true}, nil
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", callToolRequest("get_user", nil))
+
+	require.NoError(t, err)
+	spans := telemetry.spans.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	_, hasEnvironmentId := attributeValue(spans[0].Attributes(), "pingone.environment.id")
+	assert.False(t, hasEnvironmentId)
+	dataPoints := telemetry.durationDataPoints(t)
+	require.Len(t, dataPoints, 1)
+	errorType, _ := attributeValue(dataPoints[0].Attributes.ToSlice(), "error.type")
+	assert.Equal(t, "tool_error", errorType)
+}
+
+func TestToolTelemetryMiddleware_RecordsProtocolErrors(t *testing.T) {
+	middleware, telemetry := newTestMiddleware(t)
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return nil, errors.New("environment is not allowed")
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", callToolRequest("get_user", nil))
+
+	assert.EqualError(t, err, "environment is not allowed")
+	spans := telemetry.spans.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "environment is not allowed", spans[0].Status().Description)
+	dataPoints := telemetry.durationDataPoints(t)
+	require.Len(t, dataPoints, 1)
+	_, hasErrorType := attributeValue(dataPoints[0].Attributes.ToSlice(), "error.type")
+	assert.True(t, hasErrorType)
+}
+
+func TestToolTelemetryMiddleware_OtherMethods(t *testing.T) {
+	middleware, telemetry := newTestMiddleware(t)
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.ListToolsResult{}, nil
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+
+	require.NoError(t, err)
+	assert.Empty(t, telemetry.spans.Ended())
+}
@@ -0,0 +1,86 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltimeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// TimeoutError is returned when a tool call did not complete within the timeout of its tool.
+type TimeoutError struct {
+	ToolName string
+	Timeout  time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("tool '%s' did not complete within %s and was cancelled; narrow the call, such as with a filter, or call it with runInBackground if the tool supports it", e.ToolName, e.Timeout)
+}
+
+// ToolTimeoutMiddleware cancels the context of tool calls that run longer than the timeout of their tool, so that
+// runaway work such as paging through a very large organization is bounded. The PingOne API requests of the call
+// are made with its context, so they are cancelled with it.
+//
+// Calls that fail because they timed out are returned as a TimeoutError rather than the error of the tool, which
+// only reports that its context was cancelled. Calls cancelled by the client are returned unchanged.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after any middleware that waits
+// for the user, so that the time the user takes is not counted. Background jobs are not bounded by the timeout,
+// as they outlive the call that started them.
+type ToolTimeoutMiddleware struct {
+	timeouts ToolTimeouts
+}
+
+// NewToolTimeoutMiddleware creates middleware that applies the timeouts to tool calls.
+func NewToolTimeoutMiddleware(timeouts ToolTimeouts) *ToolTimeoutMiddleware {
+	return &ToolTimeoutMiddleware{
+		timeouts: timeouts,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ToolTimeoutMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+		toolName := callToolReq.Params.Name
+		timeout := m.timeouts.For(toolName)
+		if timeout <= 0 {
+			return next(ctx, method, req)
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		result, err := next(callCtx, method, req)
+
+		// Only the timeout of this middleware is reported, not an earlier deadline or cancellation of the caller
+		if ctx.Err() != nil || !errors.Is(callCtx.Err(), context.DeadlineExceeded) || !failed(result, err) {
+			return result, err
+		}
+		logger.FromContext(ctx).Warn("Tool call timed out",
+			slog.String("tool", toolName),
+			slog.Duration("timeout", timeout))
+		return nil, fmt.Errorf("tool call timed out: %w", &TimeoutError{ToolName: toolName, Timeout: timeout})
+	}
+}
+
+// failed returns whether the tool call failed, as calls that completed just as they timed out are still returned
+func failed(result mcp.Result, err error) bool {
+	if err != nil {
+		return true
+	}
+	callToolResult, ok := result.(*mcp.CallToolResult)
+	return ok && callToolResult != nil && callToolResult.IsError
+}
@@ -0,0 +1,118 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltimeout_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltimeout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callToolRequest(toolName string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: toolName},
+	}
+}
+
+// pagingHandler pages until its context is done, failing like a tool whose PingOne API request was cancelled
+func pagingHandler(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+	<-ctx.Done()
+	return &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: ctx.Err().Error()}}}, nil
+}
+
+func TestToolTimeoutMiddleware_CancelsCallsOverTimeout(t *testing.T) {
+	middleware := tooltimeout.NewToolTimeoutMiddleware(tooltimeout.ToolTimeouts{
+		Default: time.Hour,
+		PerTool: map[string]time.Duration{"list_things": 10 * time.Millisecond},
+	})
+	handler := middleware.Handler(pagingHandler)
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("list_things"))
+
+	assert.Nil(t, result)
+	var timeoutErr *tooltimeout.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, "list_things", timeoutErr.ToolName)
+	assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+	assert.ErrorContains(t, err, "tool 'list_things' did not complete within 10ms and was cancelled")
+}
+
+func TestToolTimeoutMiddleware_CallsWithinTimeout(t *testing.T) {
+	middleware := tooltimeout.NewToolTimeoutMiddleware(tooltimeout.ToolTimeouts{Default: time.Hour})
+	var deadline time.Time
+	handler := middleware.Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		deadline, _ = ctx.Deadline()
+		return &mcp.CallToolResult{}, nil
+	})
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("list_things"))
+
+	require.NoError(t, err)
+	assert.Equal(t, &mcp.CallToolResult{}, result)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Minute)
+}
+
+func TestToolTimeoutMiddleware_Disabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts tooltimeout.ToolTimeouts
+	}{
+		{
+			name:     "Default timeout disabled",
+			timeouts: tooltimeout.ToolTimeouts{},
+		},
+		{
+			name: "Tool timeout disabled",
+			timeouts: tooltimeout.ToolTimeouts{
+				Default: time.Millisecond,
+				PerTool: map[string]time.Duration{"list_things": 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hadDeadline bool
+			handler := tooltimeout.NewToolTimeoutMiddleware(tt.timeouts).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				_, hadDeadline = ctx.Deadline()
+				return &mcp.CallToolResult{}, nil
+			})
+
+			_, err := handler(context.Background(), "tools/call", callToolRequest("list_things"))
+
+			require.NoError(t, err)
+			assert.False(t, hadDeadline)
+		})
+	}
+}
+
+func TestToolTimeoutMiddleware_CancelledByClient(t *testing.T) {
+	handler := tooltimeout.NewToolTimeoutMiddleware(tooltimeout.ToolTimeouts{Default: time.Hour}).Handler(pagingHandler)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := handler(ctx, "tools/call", callToolRequest("list_things"))
+
+	require.NoError(t, err, "calls cancelled by the client should be returned unchanged")
+	callToolResult, ok := result.(*mcp.CallToolResult)
+	require.True(t, ok)
+	assert.True(t, callToolResult.IsError)
+}
+
+func TestToolTimeoutMiddleware_OtherMethods(t *testing.T) {
+	var hadDeadline bool
+	handler := tooltimeout.NewToolTimeoutMiddleware(tooltimeout.ToolTimeouts{Default: time.Hour}).Handler(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		_, hadDeadline = ctx.Deadline()
+		return &mcp.ListToolsResult{}, nil
+	})
+
+	_, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+
+	require.NoError(t, err)
+	assert.False(t, hadDeadline)
+}
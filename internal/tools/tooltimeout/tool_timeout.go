@@ -0,0 +1,71 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltimeout
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// DefaultToolTimeout is how long a tool call may run before it is cancelled, unless configured otherwise.
+// It bounds calls such as list tools paging through a very large organization.
+const DefaultToolTimeout = 5 * time.Minute
+
+// ToolTimeouts configures how long tool calls may run before they are cancelled.
+// A timeout of 0 lets calls run until they complete or the client cancels them.
+type ToolTimeouts struct {
+	// Default applies to tools without a timeout of their own
+	Default time.Duration
+	// PerTool overrides the default timeout for the named tools
+	PerTool map[string]time.Duration
+}
+
+// For returns the timeout of calls of the named tool.
+func (t ToolTimeouts) For(toolName string) time.Duration {
+	if timeout, ok := t.PerTool[toolName]; ok {
+		return timeout
+	}
+	return t.Default
+}
+
+// ParseToolTimeouts parses tool timeout overrides in the form "<tool name>=<duration>", such as
+// "query_audit_events=10m". Each tool must exist in toolDefs, so that misconfiguration is reported when
+// the server starts rather than silently ignored. A later override for the same tool replaces earlier ones.
+func ParseToolTimeouts(values []string, toolDefs []types.ToolDefinition) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	if len(values) == 0 {
+		return timeouts, nil
+	}
+
+	toolNames := make(map[string]bool)
+	for _, toolDef := range toolDefs {
+		toolNames[toolDef.McpTool.Name] = true
+	}
+
+	for _, value := range values {
+		toolName, durationValue, found := strings.Cut(value, "=")
+		toolName = strings.TrimSpace(toolName)
+		durationValue = strings.TrimSpace(durationValue)
+		if !found || toolName == "" || durationValue == "" {
+			return nil, fmt.Errorf("invalid tool timeout %q, expected format <tool name>=<duration>", value)
+		}
+
+		if !toolNames[toolName] {
+			return nil, fmt.Errorf("invalid tool timeout for unknown tool %q", toolName)
+		}
+		timeout, err := time.ParseDuration(durationValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool timeout for tool %q: %w", toolName, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("invalid tool timeout for tool %q, timeout must not be negative", toolName)
+		}
+
+		timeouts[toolName] = timeout
+	}
+
+	return timeouts, nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltimeout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltimeout"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testToolDefs = []types.ToolDefinition{
+	{McpTool: &mcp.Tool{Name: "list_things"}},
+	{McpTool: &mcp.Tool{Name: "query_events"}},
+}
+
+func TestToolTimeouts_For(t *testing.T) {
+	timeouts := tooltimeout.ToolTimeouts{
+		Default: time.Minute,
+		PerTool: map[string]time.Duration{
+			"query_events": 10 * time.Minute,
+			"list_things":  0,
+		},
+	}
+
+	assert.Equal(t, 10*time.Minute, timeouts.For("query_events"))
+	assert.Equal(t, time.Duration(0), timeouts.For("list_things"), "an override of 0 should disable the timeout")
+	assert.Equal(t, time.Minute, timeouts.For("other_tool"))
+}
+
+func TestParseToolTimeouts(t *testing.T) {
+	tests := []struct {
+		name            string
+		values          []string
+		expected        map[string]time.Duration
+		wantErrContains string
+	}{
+		{
+			name:     "No values",
+			values:   nil,
+			expected: map[string]time.Duration{},
+		},
+		{
+			name:   "Multiple tools",
+			values: []string{"list_things=30s", " query_events = 10m "},
+			expected: map[string]time.Duration{
+				"list_things":  30 * time.Second,
+				"query_events": 10 * time.Minute,
+			},
+		},
+		{
+			name:     "Later value replaces earlier value",
+			values:   []string{"list_things=30s", "list_things=0"},
+			expected: map[string]time.Duration{"list_things": 0},
+		},
+		{
+			name:            "Missing separator",
+			values:          []string{"list_things"},
+			wantErrContains: "expected format <tool name>=<duration>",
+		},
+		{
+			name:            "Missing duration",
+			values:          []string{"list_things="},
+			wantErrContains: "expected format <tool name>=<duration>",
+		},
+		{
+			name:            "Unknown tool",
+			values:          []string{"list_others=30s"},
+			wantErrContains: "unknown tool \"list_others\"",
+		},
+		{
+			name:            "Invalid duration",
+			values:          []string{"list_things=soon"},
+			wantErrContains: "invalid tool timeout for tool \"list_things\"",
+		},
+		{
+			name:            "Negative duration",
+			values:          []string{"list_things=-1m"},
+			wantErrContains: "timeout must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tooltimeout.ParseToolTimeouts(tt.values, testToolDefs)
+			if tt.wantErrContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErrContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltrace
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+)
+
+// MetaKey is the key of the execution trace in the _meta of tool results.
+const MetaKey = "pingidentity.com/toolTrace"
+
+// ToolTrace is the execution trace of a tool call.
+type ToolTrace struct {
+	Tool       string        `json:"tool"`
+	DurationMs int64         `json:"durationMs"`
+	SdkCalls   []sdk.SdkCall `json:"sdkCalls"`
+}
+
+// ToolTraceMiddleware traces the PingOne API requests made during each tool call, and attaches the trace to
+// the tool result's _meta, so that users can report precise performance problems and maintainers can profile
+// slow handlers. Traces are not part of the tool output, so MCP clients do not pass them to the model.
+//
+// Calls that fail with a protocol error have no result to attach the trace to, so their trace is only logged.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, before any middleware that
+// makes PingOne API requests, such as environment validation, so that those requests are traced too.
+type ToolTraceMiddleware struct {
+	enabled bool
+}
+
+// NewToolTraceMiddleware creates middleware that traces every tool call when enabled, and passes calls through
+// unchanged otherwise.
+func NewToolTraceMiddleware(enabled bool) *ToolTraceMiddleware {
+	return &ToolTraceMiddleware{
+		enabled: enabled,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *ToolTraceMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if !m.enabled || method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		callTrace := sdk.NewCallTrace()
+		start := time.Now()
+		result, err := next(sdk.ContextWithCallTrace(ctx, callTrace), method, req)
+		trace := ToolTrace{
+			Tool:       callToolReq.Params.Name,
+			DurationMs: time.Since(start).Milliseconds(),
+			SdkCalls:   callTrace.Calls(),
+		}
+
+		logger.FromContext(ctx).Info("Tool execution trace",
+			slog.String("tool", trace.Tool),
+			slog.Int64("durationMs", trace.DurationMs),
+			slog.Any("sdkCalls", trace.SdkCalls))
+
+		if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult != nil {
+			if callToolResult.Meta == nil {
+				callToolResult.Meta = mcp.Meta{}
+			}
+			callToolResult.Meta[MetaKey] = trace
+		}
+
+		return result, err
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package tooltrace_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/tooltrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callToolRequest(toolName string) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: toolName},
+	}
+}
+
+// tracedCallsHandler returns a result after checking that the context is traced
+func tracedCallsHandler(t *testing.T, traced bool) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if traced {
+			assert.NotNil(t, sdk.CallTraceFromContext(ctx))
+		} else {
+			assert.Nil(t, sdk.CallTraceFromContext(ctx))
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "ok"}}}, nil
+	}
+}
+
+func TestToolTraceMiddleware_AttachesTrace(t *testing.T) {
+	handler := tooltrace.NewToolTraceMiddleware(true).Handler(tracedCallsHandler(t, true))
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("list_users"))
+
+	require.NoError(t, err)
+	callToolResult := result.(*mcp.CallToolResult)
+	trace, ok := callToolResult.Meta[tooltrace.MetaKey].(tooltrace.ToolTrace)
+	require.True(t, ok)
+	assert.Equal(t, "list_users", trace.Tool)
+	assert.NotNil(t, trace.SdkCalls)
+	assert.Empty(t, trace.SdkCalls)
+}
+
+func TestToolTraceMiddleware_KeepsExistingMeta(t *testing.T) {
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return &mcp.CallToolResult{Meta: mcp.Meta{"other": "value"}}, nil
+	}
+	handler := tooltrace.NewToolTraceMiddleware(true).Handler(next)
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("list_users"))
+
+	require.NoError(t, err)
+	meta := result.(*mcp.CallToolResult).Meta
+	assert.Equal(t, "value", meta["other"])
+	assert.Contains(t, meta, tooltrace.MetaKey)
+}
+
+func TestToolTraceMiddleware_Errors(t *testing.T) {
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		return nil, errors.New("unauthorized")
+	}
+	handler := tooltrace.NewToolTraceMiddleware(true).Handler(next)
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("list_users"))
+
+	assert.EqualError(t, err, "unauthorized")
+	assert.Nil(t, result)
+}
+
+func TestToolTraceMiddleware_Disabled(t *testing.T) {
+	handler := tooltrace.NewToolTraceMiddleware(false).Handler(tracedCallsHandler(t, false))
+
+	result, err := handler(context.Background(), "tools/call", callToolRequest("list_users"))
+
+	require.NoError(t, err)
+	assert.Nil(t, result.(*mcp.CallToolResult).Meta)
+}
+
+func TestToolTraceMiddleware_OtherMethods(t *testing.T) {
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		assert.Nil(t, sdk.CallTraceFromContext(ctx))
+		return &mcp.ListToolsResult{}, nil
+	}
+	handler := tooltrace.NewToolTraceMiddleware(true).Handler(next)
+
+	_, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+
+	require.NoError(t, err)
+}
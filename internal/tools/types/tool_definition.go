@@ -11,6 +11,22 @@ type ToolDefinition struct {
 	McpTool *mcp.Tool
 	// ValidationPolicy allows modification of in-built validation rules and constraints for the tool's execution
 	ValidationPolicy *ToolValidationPolicy
+	// MaxConcurrentExecutions limits the number of calls of the tool that may run at the same time.
+	// Zero means the tool's calls are not limited.
+	MaxConcurrentExecutions int
+	// DisableResponseCache prevents results of a read-only tool being cached, for tools whose results
+	// change without a write tool being called, such as queries of audit events.
+	DisableResponseCache bool
+	// RequiresConfirmation asks the user to approve each call of the tool before it runs, for destructive
+	// tools that cannot be undone.
+	RequiresConfirmation bool
+	// ConfirmationArgument limits the confirmation of a tool that RequiresConfirmation to calls with the argument,
+	// for tools that are first called without it to preview what they would change.
+	ConfirmationArgument string
+	// RequiredRoles are the PingOne administrator roles, any of which the authenticated principal must be assigned
+	// for calls of the tool to succeed, for tools that only some roles can call. Empty means the tool does not
+	// declare the roles it requires.
+	RequiredRoles []string
 }
 
 // IsReadOnly returns true if the tool is read-only and does not modify its environment.
@@ -16,4 +16,11 @@ type ToolValidationPolicy struct {
 	// This is typically used for tools that don't have an environmentId parameter (e.g., list_environments) or operate at the organization level.
 	// When true, both AllowProductionEnvironmentWrite and AllowProductionEnvironmentRead are ignored.
 	ProductionEnvironmentNotApplicable bool
+	// ReadEnvironmentIdArguments names further arguments that hold the IDs of environments the tool reads from, such as
+	// the environment a comparison is made against. These environments are checked against the environment scope only,
+	// as environment validation applies to the environmentId argument, so they must not be written to.
+	ReadEnvironmentIdArguments []string
+	// CreatesEnvironment when set to true, indicates that the tool creates new environments. New environments are
+	// not within any environment scope, so the tool is rejected when an environment scope is set.
+	CreatesEnvironment bool
 }
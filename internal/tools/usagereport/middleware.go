@@ -0,0 +1,68 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package usagereport
+
+import (
+	"context"
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UsageReportMiddleware records the outcome of tool calls with a Recorder, so that operators can find
+// tool descriptions and schemas that models misuse.
+//
+// Calls rejected with invalid params, which the MCP SDK returns when arguments fail input schema
+// validation, are counted as schema validation failures, and calls that return an error result are
+// counted as tool errors.
+//
+// This middleware should be added to the MCP server via AddReceivingMiddleware, after any middleware
+// that rejects calls for reasons unrelated to the tool description, such as authentication.
+type UsageReportMiddleware struct {
+	recorder *Recorder
+}
+
+// NewUsageReportMiddleware creates middleware that records to the recorder. A nil recorder records nothing.
+func NewUsageReportMiddleware(recorder *Recorder) *UsageReportMiddleware {
+	return &UsageReportMiddleware{
+		recorder: recorder,
+	}
+}
+
+// Handler implements the middleware pattern by returning a MethodHandler that wraps the next handler.
+func (m *UsageReportMiddleware) Handler(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" || m.recorder == nil {
+			return next(ctx, method, req)
+		}
+
+		callToolReq, ok := req.(*mcp.CallToolRequest)
+		if !ok {
+			return next(ctx, method, req)
+		}
+
+		toolName := callToolReq.Params.Name
+		m.recorder.RecordCall(toolName)
+
+		result, err := next(ctx, method, req)
+		if err != nil {
+			if isInvalidParams(err) {
+				m.recorder.RecordSchemaValidationFailure(toolName)
+			} else {
+				m.recorder.RecordToolError(toolName)
+			}
+			return result, err
+		}
+		if callToolResult, ok := result.(*mcp.CallToolResult); ok && callToolResult != nil && callToolResult.IsError {
+			m.recorder.RecordToolError(toolName)
+		}
+		return result, err
+	}
+}
+
+// isInvalidParams returns true if the error is a JSON-RPC invalid params error.
+func isInvalidParams(err error) bool {
+	var wireErr *jsonrpc.Error
+	return errors.As(err, &wireErr) && wireErr.Code == jsonrpc.CodeInvalidParams
+}
@@ -0,0 +1,82 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package usagereport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/usagereport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newThingsServer(t *testing.T, recorder *usagereport.Recorder) *mcp.Server {
+	t.Helper()
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(usagereport.NewUsageReportMiddleware(recorder).Handler)
+
+	mcp.AddTool(server, testToolDefs[0].McpTool, func(ctx context.Context, req *mcp.CallToolRequest, input testToolInput) (*mcp.CallToolResult, any, error) {
+		if input.Name == "existing" {
+			return nil, nil, errors.New("thing already exists")
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "created"}}}, nil, nil
+	})
+	return server
+}
+
+func TestUsageReportMiddleware_OverMcp(t *testing.T) {
+	recorder := usagereport.NewRecorder("1.2.3", testToolDefs, time.Hour)
+	server := newThingsServer(t, recorder)
+
+	// Arguments that do not match the input schema
+	_, err := mcptestutils.CallToolOverMcp(t, server, "create_thing", map[string]any{"name": 1})
+	require.Error(t, err)
+
+	// Tool error
+	output, err := mcptestutils.CallToolOverMcp(t, server, "create_thing", testToolInput{Name: "existing"})
+	require.NoError(t, err)
+	assert.True(t, output.IsError)
+
+	// Success
+	output, err = mcptestutils.CallToolOverMcp(t, server, "create_thing", testToolInput{Name: "new"})
+	require.NoError(t, err)
+	assert.False(t, output.IsError)
+
+	report := recorder.Report()
+	require.Len(t, report.Tools, 1)
+	assert.Equal(t, usagereport.ToolUsage{
+		Tool:                     "create_thing",
+		DescriptionHash:          report.Tools[0].DescriptionHash,
+		Calls:                    3,
+		SchemaValidationFailures: 1,
+		ToolErrors:               1,
+		ImmediateRetries:         2,
+	}, report.Tools[0])
+}
+
+func TestUsageReportMiddleware_NilRecorder(t *testing.T) {
+	middleware := usagereport.NewUsageReportMiddleware(nil)
+
+	nextCalled := false
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		nextCalled = true
+		return nil, nil
+	}
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name: "create_thing",
+		},
+	}
+
+	_, err := middleware.Handler(next)(context.Background(), "tools/call", req)
+
+	assert.NoError(t, err)
+	assert.True(t, nextCalled)
+}
@@ -0,0 +1,182 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package usagereport
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// DefaultRetryWindow is the time after a failed tool call within which another call to the same tool
+// is counted as an immediate retry.
+const DefaultRetryWindow = 30 * time.Second
+
+// Report is an anonymized summary of how MCP clients used each tool. It contains only tool names and
+// counts, and never tool arguments, results, environment IDs or session details.
+type Report struct {
+	GeneratedAt   time.Time   `json:"generatedAt"`
+	ServerVersion string      `json:"serverVersion"`
+	Tools         []ToolUsage `json:"tools"`
+}
+
+// ToolUsage is the usage of a single tool.
+type ToolUsage struct {
+	Tool string `json:"tool"`
+	// DescriptionHash identifies the version of the tool description and input schema the counts were
+	// recorded against, so that reports from different server versions can be compared.
+	DescriptionHash string `json:"descriptionHash,omitempty"`
+	Calls           int    `json:"calls"`
+	// SchemaValidationFailures counts calls rejected because the arguments did not match the input schema.
+	SchemaValidationFailures int `json:"schemaValidationFailures"`
+	// ToolErrors counts calls that were accepted but returned an error result.
+	ToolErrors int `json:"toolErrors"`
+	// ImmediateRetries counts calls made within the retry window after a failed call of the same tool.
+	ImmediateRetries int `json:"immediateRetries"`
+	// CacheHits counts calls answered from the response cache, when it is enabled.
+	CacheHits int `json:"cacheHits,omitempty"`
+	// CacheMisses counts calls of cached tools that were not answered from the response cache.
+	CacheMisses int `json:"cacheMisses,omitempty"`
+}
+
+// Recorder counts tool call outcomes for the usage report. It is safe for concurrent use.
+type Recorder struct {
+	mu                sync.Mutex
+	serverVersion     string
+	retryWindow       time.Duration
+	descriptionHashes map[string]string
+	usage             map[string]*ToolUsage
+	lastFailure       map[string]time.Time
+	now               func() time.Time
+}
+
+// NewRecorder creates a recorder for the given tools. Calls within retryWindow of a failed call of the
+// same tool are counted as immediate retries.
+func NewRecorder(serverVersion string, toolDefs []types.ToolDefinition, retryWindow time.Duration) *Recorder {
+	descriptionHashes := make(map[string]string, len(toolDefs))
+	for _, toolDef := range toolDefs {
+		descriptionHashes[toolDef.McpTool.Name] = descriptionHash(toolDef)
+	}
+	return &Recorder{
+		serverVersion:     serverVersion,
+		retryWindow:       retryWindow,
+		descriptionHashes: descriptionHashes,
+		usage:             make(map[string]*ToolUsage),
+		lastFailure:       make(map[string]time.Time),
+		now:               time.Now,
+	}
+}
+
+// RecordCall records the start of a call to the tool, counting it as an immediate retry if the
+// previous call of the tool failed within the retry window.
+func (r *Recorder) RecordCall(toolName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	usage := r.toolUsage(toolName)
+	usage.Calls++
+	if failedAt, ok := r.lastFailure[toolName]; ok {
+		if r.now().Sub(failedAt) <= r.retryWindow {
+			usage.ImmediateRetries++
+		}
+		delete(r.lastFailure, toolName)
+	}
+}
+
+// RecordSchemaValidationFailure records that a call to the tool was rejected by input schema validation.
+func (r *Recorder) RecordSchemaValidationFailure(toolName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.toolUsage(toolName).SchemaValidationFailures++
+	r.lastFailure[toolName] = r.now()
+}
+
+// RecordToolError records that a call to the tool returned an error result.
+func (r *Recorder) RecordToolError(toolName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.toolUsage(toolName).ToolErrors++
+	r.lastFailure[toolName] = r.now()
+}
+
+// RecordCacheHit records that a call to the tool was answered from the response cache.
+func (r *Recorder) RecordCacheHit(toolName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.toolUsage(toolName).CacheHits++
+}
+
+// RecordCacheMiss records that a call to a cached tool was not answered from the response cache.
+func (r *Recorder) RecordCacheMiss(toolName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.toolUsage(toolName).CacheMisses++
+}
+
+// Report returns the usage recorded so far, sorted by tool name.
+func (r *Recorder) Report() Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tools := make([]ToolUsage, 0, len(r.usage))
+	for _, usage := range r.usage {
+		tools = append(tools, *usage)
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		return tools[i].Tool < tools[j].Tool
+	})
+
+	return Report{
+		GeneratedAt:   r.now().UTC(),
+		ServerVersion: r.serverVersion,
+		Tools:         tools,
+	}
+}
+
+// WriteReportFile writes the usage report as JSON to the file at path.
+func (r *Recorder) WriteReportFile(path string) error {
+	data, err := json.MarshalIndent(r.Report(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tool usage report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write tool usage report file: %w", err)
+	}
+	return nil
+}
+
+// toolUsage returns the usage of the tool, creating it if necessary. The caller must hold the lock.
+func (r *Recorder) toolUsage(toolName string) *ToolUsage {
+	usage, ok := r.usage[toolName]
+	if !ok {
+		usage = &ToolUsage{
+			Tool:            toolName,
+			DescriptionHash: r.descriptionHashes[toolName],
+		}
+		r.usage[toolName] = usage
+	}
+	return usage
+}
+
+// descriptionHash returns a short hash of the tool description and input schema.
+func descriptionHash(toolDef types.ToolDefinition) string {
+	hash := sha256.New()
+	hash.Write([]byte(toolDef.McpTool.Description))
+	if toolDef.McpTool.InputSchema != nil {
+		if schemaJSON, err := json.Marshal(toolDef.McpTool.InputSchema); err == nil {
+			hash.Write(schemaJSON)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:12]
+}
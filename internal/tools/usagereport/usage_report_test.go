@@ -0,0 +1,117 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package usagereport_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/usagereport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testToolInput struct {
+	Name string `json:"name" jsonschema:"REQUIRED. The name of the thing."`
+}
+
+var testToolDefs = []types.ToolDefinition{
+	{
+		McpTool: &mcp.Tool{
+			Name:        "create_thing",
+			Description: "Create a thing",
+			InputSchema: schema.MustGenerateSchema[testToolInput](),
+		},
+	},
+	{
+		McpTool: &mcp.Tool{
+			Name:        "get_thing",
+			Description: "Get a thing",
+			InputSchema: schema.MustGenerateSchema[testToolInput](),
+		},
+	},
+}
+
+func TestRecorder_Report(t *testing.T) {
+	recorder := usagereport.NewRecorder("1.2.3", testToolDefs, time.Hour)
+
+	recorder.RecordCall("get_thing")
+	recorder.RecordCall("create_thing")
+	recorder.RecordSchemaValidationFailure("create_thing")
+	recorder.RecordCall("create_thing")
+	recorder.RecordToolError("create_thing")
+	recorder.RecordCall("create_thing")
+
+	report := recorder.Report()
+
+	assert.Equal(t, "1.2.3", report.ServerVersion)
+	require.Len(t, report.Tools, 2)
+
+	createThing := report.Tools[0]
+	assert.Equal(t, "create_thing", createThing.Tool)
+	assert.Len(t, createThing.DescriptionHash, 12)
+	assert.Equal(t, 3, createThing.Calls)
+	assert.Equal(t, 1, createThing.SchemaValidationFailures)
+	assert.Equal(t, 1, createThing.ToolErrors)
+	assert.Equal(t, 2, createThing.ImmediateRetries)
+
+	getThing := report.Tools[1]
+	assert.Equal(t, "get_thing", getThing.Tool)
+	assert.NotEqual(t, createThing.DescriptionHash, getThing.DescriptionHash, "Tools with different descriptions should have different hashes")
+	assert.Equal(t, 1, getThing.Calls)
+	assert.Zero(t, getThing.ImmediateRetries)
+}
+
+func TestRecorder_RetryOutsideWindow(t *testing.T) {
+	recorder := usagereport.NewRecorder("1.2.3", testToolDefs, -time.Second)
+
+	recorder.RecordCall("create_thing")
+	recorder.RecordToolError("create_thing")
+	recorder.RecordCall("create_thing")
+
+	report := recorder.Report()
+
+	require.Len(t, report.Tools, 1)
+	assert.Equal(t, 2, report.Tools[0].Calls)
+	assert.Zero(t, report.Tools[0].ImmediateRetries)
+}
+
+func TestRecorder_CacheHitsAndMisses(t *testing.T) {
+	recorder := usagereport.NewRecorder("1.2.3", testToolDefs, time.Hour)
+
+	recorder.RecordCall("get_thing")
+	recorder.RecordCacheMiss("get_thing")
+	recorder.RecordCall("get_thing")
+	recorder.RecordCacheHit("get_thing")
+	recorder.RecordCall("get_thing")
+	recorder.RecordCacheHit("get_thing")
+
+	report := recorder.Report()
+
+	require.Len(t, report.Tools, 1)
+	assert.Equal(t, 3, report.Tools[0].Calls)
+	assert.Equal(t, 2, report.Tools[0].CacheHits)
+	assert.Equal(t, 1, report.Tools[0].CacheMisses)
+}
+
+func TestRecorder_WriteReportFile(t *testing.T) {
+	recorder := usagereport.NewRecorder("1.2.3", testToolDefs, time.Hour)
+	recorder.RecordCall("get_thing")
+	path := filepath.Join(t.TempDir(), "usage.json")
+
+	require.NoError(t, recorder.WriteReportFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var report usagereport.Report
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Len(t, report.Tools, 1)
+	assert.Equal(t, "get_thing", report.Tools[0].Tool)
+	assert.Equal(t, 1, report.Tools[0].Calls)
+}
@@ -0,0 +1,51 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+type UsersClient interface {
+	CreateUser(ctx context.Context, environmentId uuid.UUID, createRequest management.User) (*management.User, *http.Response, error)
+	GetUsers(ctx context.Context, environmentId uuid.UUID, filter string) (management.EntityArrayPagedIterator, error)
+	// FindUserByUsername returns the user with the given username, or nil if there is none
+	FindUserByUsername(ctx context.Context, environmentId uuid.UUID, username string) (*management.User, *http.Response, error)
+	UpdateUser(ctx context.Context, environmentId uuid.UUID, userId string, updateRequest management.User) (*management.User, *http.Response, error)
+	GetUser(ctx context.Context, environmentId uuid.UUID, userId string) (*management.User, *http.Response, error)
+	SetUserEnabled(ctx context.Context, environmentId uuid.UUID, userId string, enabled bool) (*management.UserEnabled, *http.Response, error)
+	// SetUserPopulation moves the user to the population
+	SetUserPopulation(ctx context.Context, environmentId uuid.UUID, userId string, populationId string) (*management.UserPopulation, *http.Response, error)
+	UnlockUserPassword(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error)
+	// SetUserPassword sets the user's password as an administrator, optionally requiring the user to change it at next sign-on
+	SetUserPassword(ctx context.Context, environmentId uuid.UUID, userId string, password string, forceChange bool) (*http.Response, error)
+	// ForceUserPasswordChange requires the user to change their current password at next sign-on
+	ForceUserPasswordChange(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error)
+	DeleteUser(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error)
+	// GetUserPassword returns the state of the user's password, without the password itself
+	GetUserPassword(ctx context.Context, environmentId uuid.UUID, userId string) (*UserPasswordState, *http.Response, error)
+	GetPopulation(ctx context.Context, environmentId uuid.UUID, populationId string) (*management.Population, *http.Response, error)
+	GetPasswordPolicy(ctx context.Context, environmentId uuid.UUID, passwordPolicyId string) (*management.PasswordPolicy, *http.Response, error)
+	// FindDefaultPasswordPolicy returns the default password policy of the environment, or nil if there is none
+	FindDefaultPasswordPolicy(ctx context.Context, environmentId uuid.UUID) (*management.PasswordPolicy, *http.Response, error)
+}
+
+// UserPasswordState is the state of a user's password as returned by the PingOne API, which the SDK does not model
+type UserPasswordState struct {
+	Status         *string                      `json:"status,omitempty"`
+	LastChangedAt  *time.Time                   `json:"lastChangedAt,omitempty"`
+	PasswordPolicy *UserPasswordPolicyReference `json:"passwordPolicy,omitempty"`
+}
+
+type UserPasswordPolicyReference struct {
+	Id string `json:"id"`
+}
+
+type UsersClientFactory interface {
+	GetAuthenticatedClient(ctx context.Context) (UsersClient, error)
+}
@@ -0,0 +1,309 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/patrickcping/pingone-go-sdk-v2/pingone"
+	"github.com/pingidentity/pingone-mcp-server/internal/audit"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+)
+
+const (
+	passwordSetContentType         = "application/vnd.pingidentity.password.set+json"
+	passwordUnlockContentType      = "application/vnd.pingidentity.password.unlock+json"
+	passwordForceChangeContentType = "application/vnd.pingidentity.password.forceChange+json"
+)
+
+var _ UsersClient = &PingOneClientUsersWrapper{}
+var _ UsersClientFactory = &PingOneClientUsersWrapperFactory{}
+
+type PingOneClientUsersWrapper struct {
+	client *pingone.Client
+}
+
+type PingOneClientUsersWrapperFactory struct {
+	clientFactory legacy.ClientFactory
+	tokenStore    tokenstore.TokenStore
+}
+
+func NewPingOneClientUsersWrapper(client *pingone.Client) *PingOneClientUsersWrapper {
+	return &PingOneClientUsersWrapper{client: client}
+}
+
+func NewPingOneClientUsersWrapperFactory(clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore) *PingOneClientUsersWrapperFactory {
+	return &PingOneClientUsersWrapperFactory{
+		clientFactory: clientFactory,
+		tokenStore:    tokenStore,
+	}
+}
+
+func (f *PingOneClientUsersWrapperFactory) GetAuthenticatedClient(ctx context.Context) (UsersClient, error) {
+	client, err := collections.InitializeAuthenticatedLegacyClient(ctx, f.clientFactory, f.tokenStore)
+	if err != nil {
+		return nil, err
+	}
+	return NewPingOneClientUsersWrapper(client), nil
+}
+
+func (p *PingOneClientUsersWrapper) CreateUser(ctx context.Context, environmentId uuid.UUID, createRequest management.User) (*management.User, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.UsersApi.CreateUser(ctx, environmentId.String()).User(createRequest)
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to create user",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) GetUsers(ctx context.Context, environmentId uuid.UUID, filter string) (management.EntityArrayPagedIterator, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UsersApi.ReadAllUsers(ctx, environmentId.String()).Filter(filter)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve users",
+		slog.String("environmentId", environmentId.String()),
+	)
+	return getRequest.Execute(), nil
+}
+
+func (p *PingOneClientUsersWrapper) FindUserByUsername(ctx context.Context, environmentId uuid.UUID, username string) (*management.User, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	// Usernames are unique within an environment, so the first page holds the only possible match
+	filter := fmt.Sprintf(`username eq %s`, scimString(username))
+	getRequest := p.client.ManagementAPIClient.UsersApi.ReadAllUsers(ctx, environmentId.String()).Filter(filter)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to find user by username",
+		slog.String("environmentId", environmentId.String()),
+	)
+	page, httpResponse, err := getRequest.ExecuteInitialPage()
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	if page == nil || page.Embedded == nil || len(page.Embedded.Users) == 0 {
+		return nil, httpResponse, nil
+	}
+	return &page.Embedded.Users[0], httpResponse, nil
+}
+
+func (p *PingOneClientUsersWrapper) UpdateUser(ctx context.Context, environmentId uuid.UUID, userId string, updateRequest management.User) (*management.User, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	patchRequest := p.client.ManagementAPIClient.UsersApi.UpdateUserPatch(ctx, environmentId.String(), userId).User(updateRequest)
+	patchRequest = patchRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	patchRequest = patchRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to update user",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return patchRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) GetUser(ctx context.Context, environmentId uuid.UUID, userId string) (*management.User, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UsersApi.ReadUser(ctx, environmentId.String(), userId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve user",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) SetUserEnabled(ctx context.Context, environmentId uuid.UUID, userId string, enabled bool) (*management.UserEnabled, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.EnableUsersApi.UpdateUserEnabled(ctx, environmentId.String(), userId).UserEnabled(management.UserEnabled{Enabled: &enabled})
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to set user enabled",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+		slog.Bool("enabled", enabled),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) SetUserPopulation(ctx context.Context, environmentId uuid.UUID, userId string, populationId string) (*management.UserPopulation, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.UserPopulationsApi.UpdateUserPopulation(ctx, environmentId.String(), userId).UserPopulation(management.UserPopulation{Id: populationId})
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to set user population",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+		slog.String("populationId", populationId),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) UnlockUserPassword(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.UserPasswordsApi.EnvironmentsEnvironmentIDUsersUserIDPasswordPost(ctx, environmentId.String(), userId).
+		ContentType(passwordUnlockContentType).
+		Body(map[string]interface{}{})
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to unlock user password",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) SetUserPassword(ctx context.Context, environmentId uuid.UUID, userId string, password string, forceChange bool) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	putRequest := p.client.ManagementAPIClient.UserPasswordsApi.EnvironmentsEnvironmentIDUsersUserIDPasswordPut(ctx, environmentId.String(), userId).
+		ContentType(passwordSetContentType).
+		Body(map[string]interface{}{
+			"value":       password,
+			"forceChange": forceChange,
+		})
+	putRequest = putRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	putRequest = putRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to set user password",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+		slog.Bool("forceChange", forceChange),
+	)
+	return putRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) ForceUserPasswordChange(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	postRequest := p.client.ManagementAPIClient.UserPasswordsApi.EnvironmentsEnvironmentIDUsersUserIDPasswordPost(ctx, environmentId.String(), userId).
+		ContentType(passwordForceChangeContentType).
+		Body(map[string]interface{}{})
+	postRequest = postRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	postRequest = postRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to force user password change",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return postRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) DeleteUser(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	if p.client == nil {
+		return nil, errors.New("PingOne client is not initialized")
+	}
+	deleteRequest := p.client.ManagementAPIClient.UsersApi.DeleteUser(ctx, environmentId.String(), userId)
+	deleteRequest = deleteRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	deleteRequest = deleteRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to delete user",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	return deleteRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) GetUserPassword(ctx context.Context, environmentId uuid.UUID, userId string) (*UserPasswordState, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.UserPasswordsApi.EnvironmentsEnvironmentIDUsersUserIDPasswordGet(ctx, environmentId.String(), userId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve user password state",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("userId", userId),
+	)
+	httpResponse, err := getRequest.Execute()
+	if err != nil {
+		return nil, httpResponse, err
+	}
+	// The SDK leaves the response body unparsed, but keeps it readable
+	var state UserPasswordState
+	if err := json.NewDecoder(httpResponse.Body).Decode(&state); err != nil {
+		return nil, httpResponse, fmt.Errorf("failed to decode user password state: %w", err)
+	}
+	return &state, httpResponse, nil
+}
+
+func (p *PingOneClientUsersWrapper) GetPopulation(ctx context.Context, environmentId uuid.UUID, populationId string) (*management.Population, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.PopulationsApi.ReadOnePopulation(ctx, environmentId.String(), populationId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve population",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("populationId", populationId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) GetPasswordPolicy(ctx context.Context, environmentId uuid.UUID, passwordPolicyId string) (*management.PasswordPolicy, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.PasswordPoliciesApi.ReadOnePasswordPolicy(ctx, environmentId.String(), passwordPolicyId)
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to retrieve password policy",
+		slog.String("environmentId", environmentId.String()),
+		slog.String("passwordPolicyId", passwordPolicyId),
+	)
+	return getRequest.Execute()
+}
+
+func (p *PingOneClientUsersWrapper) FindDefaultPasswordPolicy(ctx context.Context, environmentId uuid.UUID) (*management.PasswordPolicy, *http.Response, error) {
+	if p.client == nil {
+		return nil, nil, errors.New("PingOne client is not initialized")
+	}
+	getRequest := p.client.ManagementAPIClient.PasswordPoliciesApi.ReadAllPasswordPolicies(ctx, environmentId.String())
+	getRequest = getRequest.XPingExternalSessionID(audit.SessionIdFromContext(ctx))
+	getRequest = getRequest.XPingExternalTransactionID(audit.TransactionIdFromContext(ctx))
+	logger.FromContext(ctx).Debug("Calling PingOne API to find the default password policy",
+		slog.String("environmentId", environmentId.String()),
+	)
+	var httpResponse *http.Response
+	for page, err := range getRequest.Execute() {
+		httpResponse = page.HTTPResponse
+		if err != nil {
+			return nil, httpResponse, err
+		}
+		if page.EntityArray == nil || page.EntityArray.Embedded == nil {
+			continue
+		}
+		for _, policy := range page.EntityArray.Embedded.PasswordPolicies {
+			if policy.GetDefault() {
+				return &policy, httpResponse, nil
+			}
+		}
+	}
+	return nil, httpResponse, nil
+}
@@ -0,0 +1,111 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/tokenstore"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/collections"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/filter"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const CollectionName = "users"
+
+var _ collections.LegacySdkCollection = &UsersCollection{}
+
+type UsersCollection struct{}
+
+func (c *UsersCollection) Name() string {
+	return CollectionName
+}
+
+func (c *UsersCollection) RegisterTools(ctx context.Context, server *mcp.Server, clientFactory legacy.ClientFactory, tokenStore tokenstore.TokenStore, toolFilter *filter.Filter) error {
+	if clientFactory == nil {
+		return fmt.Errorf("PingOne API client factory is nil")
+	}
+	if tokenStore == nil {
+		return fmt.Errorf("token store is nil")
+	}
+
+	usersClientFactory := NewPingOneClientUsersWrapperFactory(clientFactory, tokenStore)
+
+	if toolFilter.ShouldIncludeTool(&FindUserDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", FindUserDef.McpTool.Name))
+		mcp.AddTool(server, FindUserDef.McpTool, FindUserHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&SetUserEnabledDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", SetUserEnabledDef.McpTool.Name))
+		mcp.AddTool(server, SetUserEnabledDef.McpTool, SetUserEnabledHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UpdateUserAttributesDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UpdateUserAttributesDef.McpTool.Name))
+		mcp.AddTool(server, UpdateUserAttributesDef.McpTool, UpdateUserAttributesHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&UnlockUserPasswordDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", UnlockUserPasswordDef.McpTool.Name))
+		mcp.AddTool(server, UnlockUserPasswordDef.McpTool, UnlockUserPasswordHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&GetUserPasswordStatusDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", GetUserPasswordStatusDef.McpTool.Name))
+		mcp.AddTool(server, GetUserPasswordStatusDef.McpTool, GetUserPasswordStatusHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&CheckPasswordAgainstPolicyDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", CheckPasswordAgainstPolicyDef.McpTool.Name))
+		mcp.AddTool(server, CheckPasswordAgainstPolicyDef.McpTool, CheckPasswordAgainstPolicyHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ResetUserPasswordDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ResetUserPasswordDef.McpTool.Name))
+		mcp.AddTool(server, ResetUserPasswordDef.McpTool, ResetUserPasswordHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&BulkCreateUsersDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", BulkCreateUsersDef.McpTool.Name))
+		mcp.AddTool(server, BulkCreateUsersDef.McpTool, BulkCreateUsersHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&ImportScimUsersDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", ImportScimUsersDef.McpTool.Name))
+		mcp.AddTool(server, ImportScimUsersDef.McpTool, ImportScimUsersHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&MoveUsersBetweenPopulationsDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", MoveUsersBetweenPopulationsDef.McpTool.Name))
+		mcp.AddTool(server, MoveUsersBetweenPopulationsDef.McpTool, MoveUsersBetweenPopulationsHandler(usersClientFactory))
+	}
+
+	if toolFilter.ShouldIncludeTool(&BulkDeleteUsersDef) {
+		logger.FromContext(ctx).Debug("Registering MCP tool", slog.String("collection", c.Name()), slog.String("tool", BulkDeleteUsersDef.McpTool.Name))
+		mcp.AddTool(server, BulkDeleteUsersDef.McpTool, BulkDeleteUsersHandler(usersClientFactory))
+	}
+
+	return nil
+}
+
+func (c *UsersCollection) ListTools() []types.ToolDefinition {
+	return []types.ToolDefinition{
+		FindUserDef,
+		SetUserEnabledDef,
+		UpdateUserAttributesDef,
+		UnlockUserPasswordDef,
+		GetUserPasswordStatusDef,
+		CheckPasswordAgainstPolicyDef,
+		ResetUserPasswordDef,
+		BulkCreateUsersDef,
+		ImportScimUsersDef,
+		MoveUsersBetweenPopulationsDef,
+		BulkDeleteUsersDef,
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ users.UsersClient = &mockPingOneClientUsersWrapper{}
+var _ users.UsersClientFactory = &mockPingOneClientUsersWrapperFactory{}
+
+type mockPingOneClientUsersWrapper struct {
+	mock.Mock
+}
+
+type mockPingOneClientUsersWrapperFactory struct {
+	mockClient users.UsersClient
+	err        error
+}
+
+// Directly returns the provided mock client and error
+func NewMockPingOneClientUsersWrapperFactory(mockClient users.UsersClient, err error) *mockPingOneClientUsersWrapperFactory {
+	return &mockPingOneClientUsersWrapperFactory{
+		mockClient: mockClient,
+		err:        err,
+	}
+}
+
+func (f *mockPingOneClientUsersWrapperFactory) GetAuthenticatedClient(ctx context.Context) (users.UsersClient, error) {
+	return f.mockClient, f.err
+}
+
+func (p *mockPingOneClientUsersWrapper) CreateUser(ctx context.Context, environmentId uuid.UUID, createRequest management.User) (*management.User, *http.Response, error) {
+	args := p.Called(ctx, environmentId, createRequest)
+	var response *management.User
+	response, ok := args.Get(0).(*management.User)
+	if !ok && args.Get(0) != nil {
+		panic("CreateUser mock setup error: expected *management.User or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("CreateUser mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) GetUsers(ctx context.Context, environmentId uuid.UUID, filter string) (management.EntityArrayPagedIterator, error) {
+	args := p.Called(ctx, environmentId, filter)
+	var response management.EntityArrayPagedIterator
+	response, ok := args.Get(0).(management.EntityArrayPagedIterator)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return response, args.Error(1)
+}
+
+func (p *mockPingOneClientUsersWrapper) FindUserByUsername(ctx context.Context, environmentId uuid.UUID, username string) (*management.User, *http.Response, error) {
+	args := p.Called(ctx, environmentId, username)
+	var response *management.User
+	response, ok := args.Get(0).(*management.User)
+	if !ok && args.Get(0) != nil {
+		panic("FindUserByUsername mock setup error: expected *management.User or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("FindUserByUsername mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) UpdateUser(ctx context.Context, environmentId uuid.UUID, userId string, updateRequest management.User) (*management.User, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, updateRequest)
+	var response *management.User
+	response, ok := args.Get(0).(*management.User)
+	if !ok && args.Get(0) != nil {
+		panic("UpdateUser mock setup error: expected *management.User or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("UpdateUser mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) GetUser(ctx context.Context, environmentId uuid.UUID, userId string) (*management.User, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId)
+	var response *management.User
+	response, ok := args.Get(0).(*management.User)
+	if !ok && args.Get(0) != nil {
+		panic("GetUser mock setup error: expected *management.User or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetUser mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) SetUserEnabled(ctx context.Context, environmentId uuid.UUID, userId string, enabled bool) (*management.UserEnabled, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, enabled)
+	var response *management.UserEnabled
+	response, ok := args.Get(0).(*management.UserEnabled)
+	if !ok && args.Get(0) != nil {
+		panic("SetUserEnabled mock setup error: expected *management.UserEnabled or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("SetUserEnabled mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) SetUserPopulation(ctx context.Context, environmentId uuid.UUID, userId string, populationId string) (*management.UserPopulation, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, populationId)
+	var response *management.UserPopulation
+	response, ok := args.Get(0).(*management.UserPopulation)
+	if !ok && args.Get(0) != nil {
+		panic("SetUserPopulation mock setup error: expected *management.UserPopulation or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("SetUserPopulation mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) UnlockUserPassword(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, userId)
+	var httpResponse *http.Response
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("UnlockUserPassword mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func (p *mockPingOneClientUsersWrapper) SetUserPassword(ctx context.Context, environmentId uuid.UUID, userId string, password string, forceChange bool) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, userId, password, forceChange)
+	var httpResponse *http.Response
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("SetUserPassword mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func (p *mockPingOneClientUsersWrapper) ForceUserPasswordChange(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, userId)
+	var httpResponse *http.Response
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("ForceUserPasswordChange mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func (p *mockPingOneClientUsersWrapper) DeleteUser(ctx context.Context, environmentId uuid.UUID, userId string) (*http.Response, error) {
+	args := p.Called(ctx, environmentId, userId)
+	var httpResponse *http.Response
+	httpResponse, ok := args.Get(0).(*http.Response)
+	if !ok && args.Get(0) != nil {
+		panic("DeleteUser mock setup error: expected *http.Response or nil")
+	}
+	return httpResponse, args.Error(1)
+}
+
+func (p *mockPingOneClientUsersWrapper) GetUserPassword(ctx context.Context, environmentId uuid.UUID, userId string) (*users.UserPasswordState, *http.Response, error) {
+	args := p.Called(ctx, environmentId, userId)
+	var response *users.UserPasswordState
+	response, ok := args.Get(0).(*users.UserPasswordState)
+	if !ok && args.Get(0) != nil {
+		panic("GetUserPassword mock setup error: expected *users.UserPasswordState or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetUserPassword mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) GetPopulation(ctx context.Context, environmentId uuid.UUID, populationId string) (*management.Population, *http.Response, error) {
+	args := p.Called(ctx, environmentId, populationId)
+	var response *management.Population
+	response, ok := args.Get(0).(*management.Population)
+	if !ok && args.Get(0) != nil {
+		panic("GetPopulation mock setup error: expected *management.Population or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetPopulation mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) GetPasswordPolicy(ctx context.Context, environmentId uuid.UUID, passwordPolicyId string) (*management.PasswordPolicy, *http.Response, error) {
+	args := p.Called(ctx, environmentId, passwordPolicyId)
+	var response *management.PasswordPolicy
+	response, ok := args.Get(0).(*management.PasswordPolicy)
+	if !ok && args.Get(0) != nil {
+		panic("GetPasswordPolicy mock setup error: expected *management.PasswordPolicy or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("GetPasswordPolicy mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
+
+func (p *mockPingOneClientUsersWrapper) FindDefaultPasswordPolicy(ctx context.Context, environmentId uuid.UUID) (*management.PasswordPolicy, *http.Response, error) {
+	args := p.Called(ctx, environmentId)
+	var response *management.PasswordPolicy
+	response, ok := args.Get(0).(*management.PasswordPolicy)
+	if !ok && args.Get(0) != nil {
+		panic("FindDefaultPasswordPolicy mock setup error: expected *management.PasswordPolicy or nil")
+	}
+	var httpResponse *http.Response
+	httpResponse, ok = args.Get(1).(*http.Response)
+	if !ok && args.Get(1) != nil {
+		panic("FindDefaultPasswordPolicy mock setup error: expected *http.Response or nil")
+	}
+	return response, httpResponse, args.Error(2)
+}
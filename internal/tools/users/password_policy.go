@@ -0,0 +1,83 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+)
+
+// Where the password policy that applies to a user or population comes from
+const (
+	PasswordPolicySourceUser               = "user"
+	PasswordPolicySourcePopulation         = "population"
+	PasswordPolicySourceEnvironmentDefault = "environmentDefault"
+)
+
+type PasswordPolicySummary struct {
+	Id     string `json:"id" jsonschema:"The UUID of the password policy"`
+	Name   string `json:"name" jsonschema:"The name of the password policy"`
+	Source string `json:"source" jsonschema:"Why the policy applies: 'user' if PingOne reported it for the user's password, 'population' if it is assigned to the population, or 'environmentDefault' if the population has no policy and the environment's default policy applies"`
+}
+
+// resolvedPasswordPolicy is a password policy with the reason it applies
+type resolvedPasswordPolicy struct {
+	policy *management.PasswordPolicy
+	source string
+}
+
+func (r resolvedPasswordPolicy) summary() PasswordPolicySummary {
+	return PasswordPolicySummary{
+		Id:     r.policy.GetId(),
+		Name:   r.policy.GetName(),
+		Source: r.source,
+	}
+}
+
+// resolvePasswordPolicy returns the password policy with the given ID if set, otherwise the policy of the population
+// if set, falling back to the default policy of the environment. Errors are returned as API errors, ready to log.
+func resolvePasswordPolicy(ctx context.Context, client UsersClient, environmentId uuid.UUID, passwordPolicyId, populationId string) (*resolvedPasswordPolicy, error) {
+	source := PasswordPolicySourceUser
+	if passwordPolicyId == "" && populationId != "" {
+		population, httpResponse, err := client.GetPopulation(ctx, environmentId, populationId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return nil, errs.NewApiError(httpResponse, err)
+		}
+		if population == nil {
+			return nil, errs.NewApiError(httpResponse, errors.New("no population data in response"))
+		}
+		if population.PasswordPolicy != nil && population.PasswordPolicy.Id != "" {
+			passwordPolicyId = population.PasswordPolicy.Id
+			source = PasswordPolicySourcePopulation
+		}
+	}
+
+	if passwordPolicyId == "" {
+		policy, httpResponse, err := client.FindDefaultPasswordPolicy(ctx, environmentId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return nil, errs.NewApiError(httpResponse, err)
+		}
+		if policy == nil {
+			return nil, errs.NewApiError(httpResponse, fmt.Errorf("environment %s has no default password policy", environmentId))
+		}
+		return &resolvedPasswordPolicy{policy: policy, source: PasswordPolicySourceEnvironmentDefault}, nil
+	}
+
+	policy, httpResponse, err := client.GetPasswordPolicy(ctx, environmentId, passwordPolicyId)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		return nil, errs.NewApiError(httpResponse, err)
+	}
+	if policy == nil {
+		return nil, errs.NewApiError(httpResponse, errors.New("no password policy data in response"))
+	}
+	return &resolvedPasswordPolicy{policy: policy, source: source}, nil
+}
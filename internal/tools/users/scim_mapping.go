@@ -0,0 +1,398 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+)
+
+// scimCoreUserSchema is the schema URN of the core SCIM User resource, whose attributes are at the top level
+const scimCoreUserSchema = "urn:ietf:params:scim:schemas:core:2.0:User"
+
+// DefaultScimUserMapping maps PingOne user attributes to the SCIM 2.0 (RFC 7643) User attributes they are
+// imported from by default.
+var DefaultScimUserMapping = map[string]string{
+	"username":              "userName",
+	"email":                 "emails.value",
+	"name.given":            "name.givenName",
+	"name.family":           "name.familyName",
+	"name.middle":           "name.middleName",
+	"name.formatted":        "name.formatted",
+	"name.honorificPrefix":  "name.honorificPrefix",
+	"name.honorificSuffix":  "name.honorificSuffix",
+	"nickname":              "nickName",
+	"title":                 "title",
+	"preferredLanguage":     "preferredLanguage",
+	"locale":                "locale",
+	"timezone":              "timezone",
+	"externalId":            "externalId",
+	"enabled":               "active",
+	"type":                  "userType",
+	"primaryPhone":          "phoneNumbers[type=work].value",
+	"mobilePhone":           "phoneNumbers[type=mobile].value",
+	"address.streetAddress": "addresses.streetAddress",
+	"address.locality":      "addresses.locality",
+	"address.region":        "addresses.region",
+	"address.postalCode":    "addresses.postalCode",
+	"address.countryCode":   "addresses.country",
+}
+
+// scimUserAttributeTargets sets each supported PingOne user attribute from a SCIM value
+var scimUserAttributeTargets = map[string]func(user *management.User, value any) error{
+	"username":              stringTarget(func(user *management.User, value string) { user.Username = value }),
+	"email":                 stringTarget(func(user *management.User, value string) { user.Email = value }),
+	"name.given":            stringTarget(func(user *management.User, value string) { userName(user).Given = &value }),
+	"name.family":           stringTarget(func(user *management.User, value string) { userName(user).Family = &value }),
+	"name.middle":           stringTarget(func(user *management.User, value string) { userName(user).Middle = &value }),
+	"name.formatted":        stringTarget(func(user *management.User, value string) { userName(user).Formatted = &value }),
+	"name.honorificPrefix":  stringTarget(func(user *management.User, value string) { userName(user).HonorificPrefix = &value }),
+	"name.honorificSuffix":  stringTarget(func(user *management.User, value string) { userName(user).HonorificSuffix = &value }),
+	"nickname":              stringTarget(func(user *management.User, value string) { user.Nickname = &value }),
+	"title":                 stringTarget(func(user *management.User, value string) { user.Title = &value }),
+	"preferredLanguage":     stringTarget(func(user *management.User, value string) { user.PreferredLanguage = &value }),
+	"locale":                stringTarget(func(user *management.User, value string) { user.Locale = &value }),
+	"timezone":              stringTarget(func(user *management.User, value string) { user.Timezone = &value }),
+	"externalId":            stringTarget(func(user *management.User, value string) { user.ExternalId = &value }),
+	"type":                  stringTarget(func(user *management.User, value string) { user.Type = &value }),
+	"primaryPhone":          stringTarget(func(user *management.User, value string) { user.PrimaryPhone = &value }),
+	"mobilePhone":           stringTarget(func(user *management.User, value string) { user.MobilePhone = &value }),
+	"address.streetAddress": stringTarget(func(user *management.User, value string) { userAddress(user).StreetAddress = &value }),
+	"address.locality":      stringTarget(func(user *management.User, value string) { userAddress(user).Locality = &value }),
+	"address.region":        stringTarget(func(user *management.User, value string) { userAddress(user).Region = &value }),
+	"address.postalCode":    stringTarget(func(user *management.User, value string) { userAddress(user).PostalCode = &value }),
+	"address.countryCode":   stringTarget(func(user *management.User, value string) { userAddress(user).CountryCode = &value }),
+	"enabled":               boolTarget(func(user *management.User, value bool) { user.Enabled = &value }),
+}
+
+func stringTarget(set func(user *management.User, value string)) func(user *management.User, value any) error {
+	return func(user *management.User, value any) error {
+		switch v := value.(type) {
+		case string:
+			set(user, strings.TrimSpace(v))
+		case json.Number:
+			set(user, v.String())
+		default:
+			return fmt.Errorf("expected a string value, got %s", scimValueKind(value))
+		}
+		return nil
+	}
+}
+
+func boolTarget(set func(user *management.User, value bool)) func(user *management.User, value any) error {
+	return func(user *management.User, value any) error {
+		switch v := value.(type) {
+		case bool:
+			set(user, v)
+		case string:
+			parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("expected a boolean value, got %q", v)
+			}
+			set(user, parsed)
+		default:
+			return fmt.Errorf("expected a boolean value, got %s", scimValueKind(value))
+		}
+		return nil
+	}
+}
+
+func userName(user *management.User) *management.UserName {
+	if user.Name == nil {
+		user.Name = &management.UserName{}
+	}
+	return user.Name
+}
+
+func userAddress(user *management.User) *management.UserAddress {
+	if user.Address == nil {
+		user.Address = &management.UserAddress{}
+	}
+	return user.Address
+}
+
+func scimValueKind(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// scimPathSegment is an attribute name, with an optional filter that selects a value of a multi-valued attribute
+type scimPathSegment struct {
+	name        string
+	filterAttr  string
+	filterValue string
+}
+
+// scimPath is a parsed SCIM attribute path, such as "emails[type=work].value" or
+// "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber"
+type scimPath struct {
+	source   string
+	schema   string
+	segments []scimPathSegment
+}
+
+func parseScimPath(source string) (scimPath, error) {
+	path := scimPath{source: source}
+	attributes := strings.TrimSpace(source)
+	if strings.HasPrefix(strings.ToLower(attributes), "urn:") {
+		i := strings.LastIndex(attributes, ":")
+		path.schema = attributes[:i]
+		attributes = attributes[i+1:]
+	}
+	if attributes == "" {
+		return scimPath{}, fmt.Errorf("invalid SCIM attribute path %q: no attribute name", source)
+	}
+
+	for _, segment := range strings.Split(attributes, ".") {
+		parsed := scimPathSegment{name: segment}
+		if open := strings.Index(segment, "["); open >= 0 {
+			if !strings.HasSuffix(segment, "]") {
+				return scimPath{}, fmt.Errorf("invalid SCIM attribute path %q: unterminated filter in %q", source, segment)
+			}
+			parsed.name = segment[:open]
+			filter := segment[open+1 : len(segment)-1]
+			// [primary] is shorthand for [primary=true]
+			parsed.filterAttr, parsed.filterValue, _ = strings.Cut(filter, "=")
+			if !strings.Contains(filter, "=") {
+				parsed.filterValue = "true"
+			}
+			if parsed.filterAttr == "" {
+				return scimPath{}, fmt.Errorf("invalid SCIM attribute path %q: empty filter in %q", source, segment)
+			}
+		}
+		if parsed.name == "" {
+			return scimPath{}, fmt.Errorf("invalid SCIM attribute path %q: empty attribute name", source)
+		}
+		path.segments = append(path.segments, parsed)
+	}
+	return path, nil
+}
+
+// resolve returns the value at the path in a SCIM resource, or false if the resource has no value at the path.
+// A multi-valued attribute without a filter resolves to its primary value, or its first value if none is primary.
+func (p scimPath) resolve(resource map[string]any) (any, bool) {
+	var current any = resource
+	if p.schema != "" && !strings.EqualFold(p.schema, scimCoreUserSchema) {
+		extension, ok := lookupScimAttribute(resource, p.schema)
+		if !ok {
+			return nil, false
+		}
+		current = extension
+	}
+
+	for _, segment := range p.segments {
+		object, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		value, ok := lookupScimAttribute(object, segment.name)
+		if !ok {
+			return nil, false
+		}
+		if values, ok := value.([]any); ok {
+			value, ok = selectScimValue(values, segment)
+			if !ok {
+				return nil, false
+			}
+		} else if segment.filterAttr != "" && !matchesScimFilter(value, segment) {
+			return nil, false
+		}
+		current = value
+	}
+
+	if current == nil {
+		return nil, false
+	}
+	if s, ok := current.(string); ok && strings.TrimSpace(s) == "" {
+		return nil, false
+	}
+	return current, true
+}
+
+func selectScimValue(values []any, segment scimPathSegment) (any, bool) {
+	if segment.filterAttr != "" {
+		for _, value := range values {
+			if matchesScimFilter(value, segment) {
+				return value, true
+			}
+		}
+		return nil, false
+	}
+	for _, value := range values {
+		if matchesScimFilter(value, scimPathSegment{filterAttr: "primary", filterValue: "true"}) {
+			return value, true
+		}
+	}
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+func matchesScimFilter(value any, segment scimPathSegment) bool {
+	object, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	attribute, ok := lookupScimAttribute(object, segment.filterAttr)
+	return ok && strings.EqualFold(fmt.Sprint(attribute), segment.filterValue)
+}
+
+// lookupScimAttribute returns the attribute of a SCIM object. SCIM attribute names are case-insensitive.
+func lookupScimAttribute(object map[string]any, name string) (any, bool) {
+	if value, ok := object[name]; ok {
+		return value, true
+	}
+	for key, value := range object {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+type scimAttributeMapping struct {
+	target string
+	path   scimPath
+}
+
+// buildScimUserMapping merges the mapping overrides into the default mapping. An override with an empty
+// source path removes the default mapping of its target.
+func buildScimUserMapping(overrides map[string]string) ([]scimAttributeMapping, error) {
+	sources := maps.Clone(DefaultScimUserMapping)
+	for target, source := range overrides {
+		if _, ok := scimUserAttributeTargets[target]; !ok {
+			return nil, fmt.Errorf("unsupported mapping target %q, supported targets are: %s", target, strings.Join(slices.Sorted(maps.Keys(scimUserAttributeTargets)), ", "))
+		}
+		if strings.TrimSpace(source) == "" {
+			delete(sources, target)
+			continue
+		}
+		sources[target] = source
+	}
+
+	mapping := make([]scimAttributeMapping, 0, len(sources))
+	for _, target := range slices.Sorted(maps.Keys(sources)) {
+		path, err := parseScimPath(sources[target])
+		if err != nil {
+			return nil, fmt.Errorf("mapping for %q: %w", target, err)
+		}
+		mapping = append(mapping, scimAttributeMapping{target: target, path: path})
+	}
+	return mapping, nil
+}
+
+// mapScimUser maps a SCIM User resource to a PingOne user, returning an error for each attribute that
+// could not be mapped
+func mapScimUser(resource map[string]any, mapping []scimAttributeMapping) (management.User, []string) {
+	var user management.User
+	var mappingErrs []string
+	for _, attribute := range mapping {
+		value, ok := attribute.path.resolve(resource)
+		if !ok {
+			continue
+		}
+		if err := scimUserAttributeTargets[attribute.target](&user, value); err != nil {
+			mappingErrs = append(mappingErrs, fmt.Sprintf("%s (from %s): %v", attribute.target, attribute.path.source, err))
+		}
+	}
+	if user.Username == "" {
+		mappingErrs = append(mappingErrs, "username is required but no value was mapped")
+	}
+	if user.Email == "" {
+		mappingErrs = append(mappingErrs, "email is required but no value was mapped")
+	}
+	return user, mappingErrs
+}
+
+// parseScimPayload returns the User resources of a SCIM ListResponse, a SCIM BulkRequest, a JSON array of
+// User resources, or a single User resource. Entries that are not User resources are reported per record,
+// while a payload that is not valid JSON fails the import.
+func parseScimPayload(payload string) ([]map[string]any, map[int]error, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(payload)))
+	// Keep numbers, such as employee numbers, exactly as they were exported
+	decoder.UseNumber()
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse SCIM payload as JSON: %w", err)
+	}
+
+	var entries []any
+	isBulkRequest := false
+	switch v := document.(type) {
+	case []any:
+		entries = v
+	case map[string]any:
+		if resources, ok := lookupScimAttribute(v, "Resources"); ok {
+			entries, ok = resources.([]any)
+			if !ok {
+				return nil, nil, errors.New("SCIM payload 'Resources' must be an array")
+			}
+		} else if operations, ok := lookupScimAttribute(v, "Operations"); ok {
+			entries, ok = operations.([]any)
+			if !ok {
+				return nil, nil, errors.New("SCIM payload 'Operations' must be an array")
+			}
+			isBulkRequest = true
+		} else {
+			entries = []any{v}
+		}
+	default:
+		return nil, nil, errors.New("SCIM payload must be a ListResponse, a BulkRequest, an array of User resources, or a User resource")
+	}
+
+	resources := make([]map[string]any, 0, len(entries))
+	recordErrs := make(map[int]error)
+	for i, entry := range entries {
+		if isBulkRequest {
+			entry = bulkOperationData(entry, func(err error) { recordErrs[i] = err })
+		}
+		resource, ok := entry.(map[string]any)
+		if !ok {
+			if recordErrs[i] == nil {
+				recordErrs[i] = fmt.Errorf("expected a SCIM User resource, got %s", scimValueKind(entry))
+			}
+			resource = map[string]any{}
+		}
+		resources = append(resources, resource)
+	}
+	return resources, recordErrs, nil
+}
+
+// bulkOperationData returns the User resource of a BulkRequest operation that creates or replaces a user
+func bulkOperationData(operation any, fail func(error)) any {
+	object, ok := operation.(map[string]any)
+	if !ok {
+		fail(fmt.Errorf("expected a SCIM bulk operation, got %s", scimValueKind(operation)))
+		return nil
+	}
+	method, _ := lookupScimAttribute(object, "method")
+	if methodName, _ := method.(string); !strings.EqualFold(methodName, "POST") && !strings.EqualFold(methodName, "PUT") {
+		fail(fmt.Errorf("unsupported SCIM bulk operation method %v, only POST and PUT operations can be imported", method))
+		return nil
+	}
+	data, ok := lookupScimAttribute(object, "data")
+	if !ok {
+		fail(errors.New("SCIM bulk operation has no data"))
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,111 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testEnvironmentId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	testPopulationId  = uuid.MustParse("550e8400-e29b-41d4-a716-446655440100")
+	testUserId        = uuid.MustParse("550e8400-e29b-41d4-a716-446655440200")
+)
+
+var (
+	testUserRecord1 = users.BulkCreateUserRecord{
+		Username:   "alice",
+		Email:      "alice@example.com",
+		GivenName:  testutils.Pointer("Alice"),
+		FamilyName: testutils.Pointer("Smith"),
+	}
+	testUserRecord2AllFields = users.BulkCreateUserRecord{
+		Username:     "bob",
+		Email:        "bob@example.com",
+		GivenName:    testutils.Pointer("Bob"),
+		FamilyName:   testutils.Pointer("Jones"),
+		PopulationId: &testPopulationId,
+		Enabled:      testutils.Pointer(false),
+	}
+	testUserRecord3OnlyRequiredFields = users.BulkCreateUserRecord{
+		Username: "carol",
+		Email:    "carol@example.com",
+	}
+)
+
+// createdUser returns the user the API would return after creating the given record
+func createdUser(id string, record users.BulkCreateUserRecord) *management.User {
+	return &management.User{
+		Id:       testutils.Pointer(id),
+		Username: record.Username,
+		Email:    record.Email,
+	}
+}
+
+// matchesUserRecord returns true if the create request was built from the given record
+func matchesUserRecord(req management.User, record users.BulkCreateUserRecord) bool {
+	if req.Username != record.Username || req.Email != record.Email {
+		return false
+	}
+	if (record.GivenName != nil || record.FamilyName != nil) != (req.Name != nil) {
+		return false
+	}
+	if req.Name != nil {
+		if !equalStringPointers(req.Name.Given, record.GivenName) || !equalStringPointers(req.Name.Family, record.FamilyName) {
+			return false
+		}
+	}
+	if (record.PopulationId != nil) != (req.Population != nil) {
+		return false
+	}
+	if req.Population != nil && req.Population.Id != record.PopulationId.String() {
+		return false
+	}
+	if (record.Enabled != nil) != (req.Enabled != nil) {
+		return false
+	}
+	return req.Enabled == nil || *req.Enabled == *record.Enabled
+}
+
+func equalStringPointers(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// assertBulkCreateUsersOutput verifies the per-record results and summary counts of a BulkCreateUsersOutput
+func assertBulkCreateUsersOutput(t *testing.T, expected []users.BulkCreateUserResult, output *users.BulkCreateUsersOutput) {
+	t.Helper()
+
+	require.NotNil(t, output, "Output should not be nil")
+	require.Len(t, output.Results, len(expected), "Result count should match record count")
+
+	successCount := 0
+	for i, expectedResult := range expected {
+		actual := output.Results[i]
+		assert.Equal(t, expectedResult.Index, actual.Index, "Result %d index should match", i)
+		assert.Equal(t, expectedResult.Username, actual.Username, "Result %d username should match", i)
+		assert.Equal(t, expectedResult.Success, actual.Success, "Result %d success should match", i)
+		if expectedResult.Success {
+			successCount++
+			require.NotNil(t, actual.UserId, "Result %d should have a user ID", i)
+			assert.Equal(t, *expectedResult.UserId, *actual.UserId, "Result %d user ID should match", i)
+			assert.Nil(t, actual.Error, "Result %d should not have an error", i)
+		} else {
+			assert.Nil(t, actual.UserId, "Result %d should not have a user ID", i)
+			require.NotNil(t, actual.Error, "Result %d should have an error", i)
+			assert.Contains(t, *actual.Error, *expectedResult.Error, "Result %d error should match", i)
+		}
+	}
+
+	assert.Equal(t, successCount, output.SuccessCount, "Success count should match")
+	assert.Equal(t, len(expected)-successCount, output.FailureCount, "Failure count should match")
+}
@@ -0,0 +1,296 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const maxBulkCreateUsers = 100
+
+var BulkCreateUsersDef = types.ToolDefinition{
+	// Each call makes up to 100 create requests, so parallel batches are limited to protect the tenant's rate limits
+	MaxConcurrentExecutions: 4,
+	McpTool: &mcp.Tool{
+		Name:  "bulk_create_users",
+		Title: "Bulk Create PingOne Users",
+		Description: `Create multiple users in an environment in a single call, up to 100 per call. Use to seed sandbox environments with test users.
+
+Provide users as a JSON array in 'users', as CSV text in 'csv', or both. CSV must include a header row; supported columns: username, email, givenName, familyName, populationId, enabled. 'username' and 'email' are required for every user. Users without a populationId are created in the default population.
+
+Each user is created independently; the output reports success or failure per record, so a failed record does not stop the remaining records from being created.`,
+		InputSchema:  schema.MustGenerateSchema[BulkCreateUsersInput](),
+		OutputSchema: schema.MustGenerateSchema[BulkCreateUsersOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type BulkCreateUserRecord struct {
+	Username     string     `json:"username" jsonschema:"REQUIRED. Unique username."`
+	Email        string     `json:"email" jsonschema:"REQUIRED. Email address."`
+	GivenName    *string    `json:"givenName,omitempty" jsonschema:"OPTIONAL. Given (first) name."`
+	FamilyName   *string    `json:"familyName,omitempty" jsonschema:"OPTIONAL. Family (last) name."`
+	PopulationId *uuid.UUID `json:"populationId,omitempty" jsonschema:"OPTIONAL. Population UUID. Defaults to the environment's default population."`
+	Enabled      *bool      `json:"enabled,omitempty" jsonschema:"OPTIONAL. Whether the user is enabled. Defaults to true."`
+}
+
+type BulkCreateUsersInput struct {
+	EnvironmentId uuid.UUID              `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Users         []BulkCreateUserRecord `json:"users,omitempty" jsonschema:"OPTIONAL. Users to create. Required if 'csv' is not provided."`
+	Csv           *string                `json:"csv,omitempty" jsonschema:"OPTIONAL. Users to create as CSV text with a header row. Required if 'users' is not provided."`
+}
+
+type BulkCreateUserResult struct {
+	Index    int     `json:"index" jsonschema:"Zero-based position of the record, with JSON users first followed by CSV rows"`
+	Username string  `json:"username" jsonschema:"The username of the record"`
+	Success  bool    `json:"success" jsonschema:"Whether the user was created"`
+	UserId   *string `json:"userId,omitempty" jsonschema:"The ID of the created user"`
+	Error    *string `json:"error,omitempty" jsonschema:"The reason the user could not be created"`
+}
+
+type BulkCreateUsersOutput struct {
+	Results      []BulkCreateUserResult `json:"results" jsonschema:"Per-record results in input order"`
+	SuccessCount int                    `json:"successCount" jsonschema:"The number of users created"`
+	FailureCount int                    `json:"failureCount" jsonschema:"The number of users that could not be created"`
+}
+
+// BulkCreateUsersHandler creates multiple PingOne users using the provided client, reporting per-record results
+func BulkCreateUsersHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input BulkCreateUsersInput,
+) (
+	*mcp.CallToolResult,
+	*BulkCreateUsersOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BulkCreateUsersInput) (*mcp.CallToolResult, *BulkCreateUsersOutput, error) {
+		records := append([]BulkCreateUserRecord{}, input.Users...)
+		var recordErrs map[int]error
+		if input.Csv != nil && strings.TrimSpace(*input.Csv) != "" {
+			csvRecords, csvRecordErrs, err := parseBulkCreateUsersCsv(*input.Csv)
+			if err != nil {
+				toolErr := errs.NewToolError(BulkCreateUsersDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			recordErrs = make(map[int]error, len(csvRecordErrs))
+			for i, recordErr := range csvRecordErrs {
+				recordErrs[len(records)+i] = recordErr
+			}
+			records = append(records, csvRecords...)
+		}
+
+		if len(records) == 0 {
+			toolErr := errs.NewToolError(BulkCreateUsersDef.McpTool.Name, errors.New("at least one user must be provided in 'users' or 'csv'"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(records) > maxBulkCreateUsers {
+			toolErr := errs.NewToolError(BulkCreateUsersDef.McpTool.Name, fmt.Errorf("a maximum of %d users can be created per call, got %d", maxBulkCreateUsers, len(records)))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(BulkCreateUsersDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Bulk creating users",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("count", len(records)),
+		)
+
+		result := &BulkCreateUsersOutput{
+			Results: make([]BulkCreateUserResult, 0, len(records)),
+		}
+
+		for i, record := range records {
+			// Stop creating users if the request has been cancelled
+			if ctx.Err() != nil {
+				toolErr := errs.NewToolError(BulkCreateUsersDef.McpTool.Name, ctx.Err())
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+
+			progress.Report(ctx, i, len(records), "Creating users")
+
+			recordResult := BulkCreateUserResult{
+				Index:    i,
+				Username: record.Username,
+			}
+
+			recordErr := recordErrs[i]
+			if recordErr == nil {
+				recordErr = validateBulkCreateUserRecord(record)
+			}
+			if recordErr == nil {
+				var userId *string
+				userId, recordErr = createBulkUser(ctx, client, input.EnvironmentId, record)
+				recordResult.UserId = userId
+			}
+
+			if recordErr != nil {
+				errMsg := recordErr.Error()
+				recordResult.Error = &errMsg
+				result.FailureCount++
+			} else {
+				recordResult.Success = true
+				result.SuccessCount++
+			}
+			result.Results = append(result.Results, recordResult)
+		}
+
+		logger.FromContext(ctx).Debug("Bulk user creation completed",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("successCount", result.SuccessCount),
+			slog.Int("failureCount", result.FailureCount),
+		)
+
+		return nil, result, nil
+	}
+}
+
+func createBulkUser(ctx context.Context, client UsersClient, environmentId uuid.UUID, record BulkCreateUserRecord) (*string, error) {
+	createRequest := management.User{
+		Username: record.Username,
+		Email:    record.Email,
+		Enabled:  record.Enabled,
+	}
+	if record.GivenName != nil || record.FamilyName != nil {
+		createRequest.Name = &management.UserName{
+			Given:  record.GivenName,
+			Family: record.FamilyName,
+		}
+	}
+	if record.PopulationId != nil {
+		createRequest.Population = &management.UserPopulation{
+			Id: record.PopulationId.String(),
+		}
+	}
+
+	userResponse, httpResponse, err := client.CreateUser(ctx, environmentId, createRequest)
+	logger.LogHttpResponse(ctx, httpResponse)
+
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+
+	if userResponse == nil {
+		apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+		errs.Log(ctx, apiErr)
+		return nil, apiErr
+	}
+
+	return userResponse.Id, nil
+}
+
+func validateBulkCreateUserRecord(record BulkCreateUserRecord) error {
+	if strings.TrimSpace(record.Username) == "" {
+		return errors.New("username is required")
+	}
+	if strings.TrimSpace(record.Email) == "" {
+		return errors.New("email is required")
+	}
+	return nil
+}
+
+// parseBulkCreateUsersCsv parses CSV text with a header row into user records.
+// Malformed values are reported per record rather than failing the whole import,
+// while a malformed header or CSV structure fails the import.
+func parseBulkCreateUsersCsv(csvText string) ([]BulkCreateUserRecord, map[int]error, error) {
+	reader := csv.NewReader(strings.NewReader(csvText))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		name := strings.TrimSpace(column)
+		switch strings.ToLower(name) {
+		case "username", "email", "givenname", "familyname", "populationid", "enabled":
+			columns[strings.ToLower(name)] = i
+		default:
+			return nil, nil, fmt.Errorf("unsupported CSV column %q, supported columns are: username, email, givenName, familyName, populationId, enabled", name)
+		}
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, nil, errors.New("CSV header must include a username column")
+	}
+
+	var records []BulkCreateUserRecord
+	recordErrs := make(map[int]error)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		record := BulkCreateUserRecord{
+			Username: value("username"),
+			Email:    value("email"),
+		}
+		if givenName := value("givenname"); givenName != "" {
+			record.GivenName = &givenName
+		}
+		if familyName := value("familyname"); familyName != "" {
+			record.FamilyName = &familyName
+		}
+		if populationId := value("populationid"); populationId != "" {
+			parsed, err := uuid.Parse(populationId)
+			if err != nil {
+				recordErrs[len(records)] = fmt.Errorf("invalid populationId %q: %w", populationId, err)
+			} else {
+				record.PopulationId = &parsed
+			}
+		}
+		if enabled := value("enabled"); enabled != "" {
+			parsed, err := strconv.ParseBool(enabled)
+			if err != nil {
+				recordErrs[len(records)] = fmt.Errorf("invalid enabled value %q, expected true or false", enabled)
+			} else {
+				record.Enabled = &parsed
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, recordErrs, nil
+}
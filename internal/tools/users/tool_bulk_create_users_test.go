@@ -0,0 +1,382 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/sdk/legacy"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkCreateUsersHandler_MockClient(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           users.BulkCreateUsersInput
+		setupMock       func(*mockPingOneClientUsersWrapper)
+		wantResults     []users.BulkCreateUserResult
+		wantErr         bool
+		wantErrContains string
+	}{
+		{
+			name: "Success - Create users from JSON records",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Users:         []users.BulkCreateUserRecord{testUserRecord1, testUserRecord2AllFields, testUserRecord3OnlyRequiredFields},
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				for i, record := range []users.BulkCreateUserRecord{testUserRecord1, testUserRecord2AllFields, testUserRecord3OnlyRequiredFields} {
+					m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+						return matchesUserRecord(req, record)
+					})).Return(createdUser(fmt.Sprintf("user-%d", i), record), &http.Response{StatusCode: 201}, nil).Once()
+				}
+			},
+			wantResults: []users.BulkCreateUserResult{
+				{Index: 0, Username: "alice", Success: true, UserId: testutils.Pointer("user-0")},
+				{Index: 1, Username: "bob", Success: true, UserId: testutils.Pointer("user-1")},
+				{Index: 2, Username: "carol", Success: true, UserId: testutils.Pointer("user-2")},
+			},
+		},
+		{
+			name: "Success - Create users from CSV",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Csv: testutils.Pointer("Username,Email,givenName,familyName,populationId,enabled\n" +
+					"alice,alice@example.com,Alice,Smith,,\n" +
+					"bob,bob@example.com,Bob,Jones," + testPopulationId.String() + ",false\n" +
+					"carol,carol@example.com,,,,\n"),
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				for i, record := range []users.BulkCreateUserRecord{testUserRecord1, testUserRecord2AllFields, testUserRecord3OnlyRequiredFields} {
+					m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+						return matchesUserRecord(req, record)
+					})).Return(createdUser(fmt.Sprintf("user-%d", i), record), &http.Response{StatusCode: 201}, nil).Once()
+				}
+			},
+			wantResults: []users.BulkCreateUserResult{
+				{Index: 0, Username: "alice", Success: true, UserId: testutils.Pointer("user-0")},
+				{Index: 1, Username: "bob", Success: true, UserId: testutils.Pointer("user-1")},
+				{Index: 2, Username: "carol", Success: true, UserId: testutils.Pointer("user-2")},
+			},
+		},
+		{
+			name: "Success - JSON records are created before CSV rows",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Users:         []users.BulkCreateUserRecord{testUserRecord1},
+				Csv:           testutils.Pointer("username,email\ncarol,carol@example.com\n"),
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return matchesUserRecord(req, testUserRecord1)
+				})).Return(createdUser("user-0", testUserRecord1), &http.Response{StatusCode: 201}, nil).Once()
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return matchesUserRecord(req, testUserRecord3OnlyRequiredFields)
+				})).Return(createdUser("user-1", testUserRecord3OnlyRequiredFields), &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.BulkCreateUserResult{
+				{Index: 0, Username: "alice", Success: true, UserId: testutils.Pointer("user-0")},
+				{Index: 1, Username: "carol", Success: true, UserId: testutils.Pointer("user-1")},
+			},
+		},
+		{
+			name: "Partial success - API error on one record does not stop the others",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Users:         []users.BulkCreateUserRecord{testUserRecord1, testUserRecord2AllFields, testUserRecord3OnlyRequiredFields},
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return matchesUserRecord(req, testUserRecord1)
+				})).Return(createdUser("user-0", testUserRecord1), &http.Response{StatusCode: 201}, nil).Once()
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return matchesUserRecord(req, testUserRecord2AllFields)
+				})).Return(nil, &http.Response{StatusCode: 400}, errors.New("username already exists")).Once()
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return matchesUserRecord(req, testUserRecord3OnlyRequiredFields)
+				})).Return(createdUser("user-2", testUserRecord3OnlyRequiredFields), &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.BulkCreateUserResult{
+				{Index: 0, Username: "alice", Success: true, UserId: testutils.Pointer("user-0")},
+				{Index: 1, Username: "bob", Success: false, Error: testutils.Pointer("username already exists")},
+				{Index: 2, Username: "carol", Success: true, UserId: testutils.Pointer("user-2")},
+			},
+		},
+		{
+			name: "Partial success - API returns nil response",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Users:         []users.BulkCreateUserRecord{testUserRecord1},
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(
+					nil, &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.BulkCreateUserResult{
+				{Index: 0, Username: "alice", Success: false, Error: testutils.Pointer("no user data in response")},
+			},
+		},
+		{
+			name: "Partial success - Invalid records are reported without calling the API",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Users: []users.BulkCreateUserRecord{
+					{Username: "no-email"},
+					testUserRecord1,
+				},
+				Csv: testutils.Pointer("username,email,populationId,enabled\n" +
+					"dave,dave@example.com,not-a-uuid,\n" +
+					"erin,erin@example.com,,maybe\n" +
+					",frank@example.com,,\n"),
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return matchesUserRecord(req, testUserRecord1)
+				})).Return(createdUser("user-1", testUserRecord1), &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.BulkCreateUserResult{
+				{Index: 0, Username: "no-email", Success: false, Error: testutils.Pointer("email is required")},
+				{Index: 1, Username: "alice", Success: true, UserId: testutils.Pointer("user-1")},
+				{Index: 2, Username: "dave", Success: false, Error: testutils.Pointer("invalid populationId")},
+				{Index: 3, Username: "erin", Success: false, Error: testutils.Pointer("invalid enabled value")},
+				{Index: 4, Username: "", Success: false, Error: testutils.Pointer("username is required")},
+			},
+		},
+		{
+			name: "Error - No users provided",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "at least one user must be provided",
+		},
+		{
+			name: "Error - CSV with header row only",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Csv:           testutils.Pointer("username,email\n"),
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "at least one user must be provided",
+		},
+		{
+			name: "Error - CSV with unsupported column",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Csv:           testutils.Pointer("username,email,password\nalice,alice@example.com,secret\n"),
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "unsupported CSV column \"password\"",
+		},
+		{
+			name: "Error - CSV without username column",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Csv:           testutils.Pointer("email\nalice@example.com\n"),
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "CSV header must include a username column",
+		},
+		{
+			name: "Error - Malformed CSV",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Csv:           testutils.Pointer("username,email\nalice,alice@example.com,extra\n"),
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "failed to read CSV",
+		},
+		{
+			name: "Error - Too many users",
+			input: users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Users:         make([]users.BulkCreateUserRecord, 101),
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "a maximum of 100 users can be created per call, got 101",
+		},
+	}
+
+	for _, tc := range tests {
+		// Test calling the handler directly
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tc.setupMock(mockClient)
+
+			req := &mcp.CallToolRequest{}
+			handler := users.BulkCreateUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+
+			mcpResult, structuredResponse, err := handler(context.Background(), req, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				if tc.wantErrContains != "" {
+					assert.Contains(t, err.Error(), tc.wantErrContains)
+				}
+				assert.Nil(t, mcpResult)
+				assert.Nil(t, structuredResponse)
+				mockClient.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Nil(t, mcpResult)
+			assertBulkCreateUsersOutput(t, tc.wantResults, structuredResponse)
+
+			mockClient.AssertExpectations(t)
+		})
+
+		// Test via call over MCP
+		t.Run(tc.name+" via MCP", func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tc.setupMock(mockClient)
+
+			handler := users.BulkCreateUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+
+			server := mcptestutils.TestMcpServer(t)
+			mcp.AddTool(server, users.BulkCreateUsersDef.McpTool, handler)
+
+			// Execute over MCP
+			output, err := mcptestutils.CallToolOverMcp(t, server, users.BulkCreateUsersDef.McpTool.Name, tc.input)
+			require.NoError(t, err, "Expect no error calling tool")
+			require.NotNil(t, output, "Expect non-nil output")
+
+			if tc.wantErr {
+				testutils.AssertMcpCallError(t, output, tc.wantErrContains)
+				mockClient.AssertExpectations(t)
+				return
+			}
+
+			// Assert success expectations
+			testutils.AssertMcpCallSuccess(t, err, output)
+
+			// marshal the structured content into the expected output type
+			outputUsers := &users.BulkCreateUsersOutput{}
+			jsonBytes, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err, "Failed to marshal structured content")
+			err = json.Unmarshal(jsonBytes, outputUsers)
+			require.NoError(t, err, "Failed to unmarshal structured content")
+
+			assertBulkCreateUsersOutput(t, tc.wantResults, outputUsers)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBulkCreateUsersHandler_ContextCancellation(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel the context immediately
+
+	req := &mcp.CallToolRequest{}
+	handler := users.BulkCreateUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	input := users.BulkCreateUsersInput{
+		EnvironmentId: testEnvironmentId,
+		Users:         []users.BulkCreateUserRecord{testUserRecord1, testUserRecord3OnlyRequiredFields},
+	}
+
+	mcpResult, structuredResponse, err := handler(ctx, req, input)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context canceled")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, structuredResponse)
+	mockClient.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkCreateUsersHandler_APIErrors(t *testing.T) {
+	tests := testutils.CommonAPIErrorTestCases()
+
+	for _, tt := range tests {
+		t.Run(tt.Name, func(t *testing.T) {
+			// Setup
+			mockClient := &mockPingOneClientUsersWrapper{}
+			mockClient.On("CreateUser", mock.Anything, mock.Anything, mock.Anything).Return(
+				nil, &http.Response{StatusCode: tt.StatusCode}, tt.ApiError)
+
+			handler := users.BulkCreateUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			input := users.BulkCreateUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Users:         []users.BulkCreateUserRecord{testUserRecord1, testUserRecord3OnlyRequiredFields},
+			}
+
+			// Execute
+			mcpResult, response, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			// Assert - API errors are reported per record rather than failing the call
+			require.NoError(t, err)
+			assert.Nil(t, mcpResult)
+			assertBulkCreateUsersOutput(t, []users.BulkCreateUserResult{
+				{Index: 0, Username: "alice", Success: false, Error: testutils.Pointer(tt.WantErrContains)},
+				{Index: 1, Username: "carol", Success: false, Error: testutils.Pointer(tt.WantErrContains)},
+			}, response)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBulkCreateUsersHandler_GetAuthenticatedClientError(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	clientFactoryErr := errors.New("failed to get authenticated client")
+	handler := users.BulkCreateUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, clientFactoryErr))
+	req := &mcp.CallToolRequest{}
+	input := users.BulkCreateUsersInput{
+		EnvironmentId: testEnvironmentId,
+		Users:         []users.BulkCreateUserRecord{testUserRecord1},
+	}
+
+	mcpResult, output, err := handler(context.Background(), req, input)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get authenticated client")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, output)
+}
+
+func TestBulkCreateUsersHandler_RealClient(t *testing.T) {
+	//TODO enable test when we have can run against a real P1 client
+	t.Skipf("Skipping TestBulkCreateUsersHandler_RealClient since it relies on real P1 client and creates actual resources")
+
+	var emptyToken string
+	client, err := legacy.NewDefaultClientFactory(testutils.TestServerVersion).NewClient(t.Context(), emptyToken)
+	require.NoError(t, err, "Failed to create PingOne client - check your credentials")
+
+	clientWrapper := users.NewPingOneClientUsersWrapper(client)
+	handler := users.BulkCreateUsersHandler(NewMockPingOneClientUsersWrapperFactory(clientWrapper, nil))
+
+	// Note: Replace with a valid environment ID from your PingOne organization
+	testEnvironmentId := uuid.MustParse("00000000-0000-0000-0000-000000000000")
+
+	req := &mcp.CallToolRequest{}
+	input := users.BulkCreateUsersInput{
+		EnvironmentId: testEnvironmentId,
+		Csv:           testutils.Pointer("username,email\nreal-client-test-user,real-client-test-user@example.com\n"),
+	}
+
+	mcpResult, response, err := handler(t.Context(), req, input)
+
+	require.NoError(t, err, "Handler should not return error with valid credentials")
+	assert.Nil(t, mcpResult, "MCP result should be nil for successful operations")
+	require.NotNil(t, response)
+	assert.Equal(t, 1, response.SuccessCount)
+}
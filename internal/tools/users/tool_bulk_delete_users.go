@@ -0,0 +1,239 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/bulkdelete"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var BulkDeleteUsersDef = types.ToolDefinition{
+	// Each call makes up to 100 delete requests, so parallel batches are limited to protect the tenant's rate limits
+	MaxConcurrentExecutions: 4,
+	// Only the call that deletes the users is confirmed, not the call that previews them
+	RequiresConfirmation: true,
+	ConfirmationArgument: bulkdelete.ConfirmationTokenArgument,
+	McpTool: &mcp.Tool{
+		Name:  "bulk_delete_users",
+		Title: "Bulk Delete PingOne Users",
+		Description: `Delete multiple users in an environment, matched by a SCIM filter or listed by ID, up to 100 per call. Use to clean up test users in sandbox environments.
+
+Deletion takes two calls. First call without 'confirmationToken' to preview the users that would be deleted; nothing is deleted, and the output includes a confirmation token for exactly those users. Review the users with the user, then call again with the same arguments and the token to delete them. The token is refused if the matching users have changed since it was returned.
+
+A filter matching more than 100 users deletes the first 100; 'moreMatching' is true when users remain, and the tool can be called again to delete them. Each user is deleted independently and the output reports the result per user. Deleted users cannot be restored.`,
+		InputSchema:  schema.MustGenerateSchema[BulkDeleteUsersInput](),
+		OutputSchema: schema.MustGenerateSchema[BulkDeleteUsersOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type BulkDeleteUsersInput struct {
+	EnvironmentId     uuid.UUID   `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Filter            *string     `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter matching the users to delete, such as 'population.id eq \"...\"' or 'username sw \"test-\"'. Required if 'userIds' is not provided."`
+	UserIds           []uuid.UUID `json:"userIds,omitempty" jsonschema:"OPTIONAL. UUIDs of the users to delete, up to 100. Required if 'filter' is not provided."`
+	ConfirmationToken *string     `json:"confirmationToken,omitempty" jsonschema:"OPTIONAL. The confirmation token returned by a previous call with the same arguments. Omit to preview the users that would be deleted."`
+}
+
+type BulkDeleteUserResult struct {
+	UserId   string  `json:"userId" jsonschema:"The ID of the user"`
+	Username string  `json:"username" jsonschema:"The username of the user"`
+	Deleted  bool    `json:"deleted" jsonschema:"Whether the user was deleted"`
+	Error    *string `json:"error,omitempty" jsonschema:"The reason the user could not be deleted"`
+}
+
+type BulkDeleteUsersOutput struct {
+	DryRun            bool                   `json:"dryRun" jsonschema:"Whether this was a preview, in which case no users were deleted"`
+	ConfirmationToken *string                `json:"confirmationToken,omitempty" jsonschema:"The token to pass back to delete the previewed users, set on previews"`
+	Users             []UserSummary          `json:"users,omitempty" jsonschema:"The users that would be deleted, set on previews"`
+	MoreMatching      bool                   `json:"moreMatching" jsonschema:"Whether the filter matches more users than are deleted per call"`
+	Results           []BulkDeleteUserResult `json:"results,omitempty" jsonschema:"Per-user results, set when users were deleted"`
+	DeletedCount      int                    `json:"deletedCount" jsonschema:"The number of users deleted"`
+	FailureCount      int                    `json:"failureCount" jsonschema:"The number of users that could not be deleted"`
+}
+
+// BulkDeleteUsersHandler previews, or deletes on confirmation, multiple PingOne users using the provided client,
+// reporting per-user results
+func BulkDeleteUsersHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input BulkDeleteUsersInput,
+) (
+	*mcp.CallToolResult,
+	*BulkDeleteUsersOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input BulkDeleteUsersInput) (*mcp.CallToolResult, *BulkDeleteUsersOutput, error) {
+		hasFilter := input.Filter != nil && strings.TrimSpace(*input.Filter) != ""
+		if hasFilter == (len(input.UserIds) > 0) {
+			toolErr := errs.NewToolError(BulkDeleteUsersDef.McpTool.Name, errors.New("exactly one of 'filter' or 'userIds' must be provided"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(input.UserIds) > bulkdelete.MaxResources {
+			toolErr := errs.NewToolError(BulkDeleteUsersDef.McpTool.Name, fmt.Errorf("a maximum of %d users can be deleted per call, got %d", bulkdelete.MaxResources, len(input.UserIds)))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(BulkDeleteUsersDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		var users []UserSummary
+		var moreMatching bool
+		if hasFilter {
+			users, moreMatching, err = usersMatchingFilter(ctx, BulkDeleteUsersDef.McpTool.Name, client, input.EnvironmentId, strings.TrimSpace(*input.Filter), bulkdelete.MaxResources)
+		} else {
+			users, err = usersWithIds(ctx, client, input.EnvironmentId, input.UserIds)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		userIds := make([]string, 0, len(users))
+		for _, user := range users {
+			if user.Id != nil {
+				userIds = append(userIds, *user.Id)
+			}
+		}
+		token := bulkdelete.ConfirmationToken(input.EnvironmentId, userIds)
+
+		if input.ConfirmationToken == nil || *input.ConfirmationToken == "" {
+			logger.FromContext(ctx).Debug("Previewing bulk user deletion",
+				slog.String("environmentId", input.EnvironmentId.String()),
+				slog.Int("count", len(users)),
+			)
+			return nil, &BulkDeleteUsersOutput{
+				DryRun:            true,
+				ConfirmationToken: &token,
+				Users:             users,
+				MoreMatching:      moreMatching,
+			}, nil
+		}
+
+		if *input.ConfirmationToken != token {
+			toolErr := errs.NewToolError(BulkDeleteUsersDef.McpTool.Name, bulkdelete.ErrConfirmationTokenMismatch)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Bulk deleting users",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("count", len(users)),
+		)
+
+		result := &BulkDeleteUsersOutput{
+			MoreMatching: moreMatching,
+			Results:      make([]BulkDeleteUserResult, 0, len(users)),
+		}
+		for i, user := range users {
+			// Stop deleting users if the request has been cancelled
+			if ctx.Err() != nil {
+				toolErr := errs.NewToolError(BulkDeleteUsersDef.McpTool.Name, ctx.Err())
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			progress.Report(ctx, i, len(users), "Deleting users")
+			if user.Id == nil {
+				continue
+			}
+
+			userResult := BulkDeleteUserResult{
+				UserId:   *user.Id,
+				Username: user.Username,
+			}
+
+			httpResponse, err := client.DeleteUser(ctx, input.EnvironmentId, *user.Id)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				errMsg := apiErr.Error()
+				userResult.Error = &errMsg
+				result.FailureCount++
+			} else {
+				userResult.Deleted = true
+				result.DeletedCount++
+			}
+			result.Results = append(result.Results, userResult)
+		}
+
+		logger.FromContext(ctx).Debug("Bulk user deletion completed",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("deletedCount", result.DeletedCount),
+			slog.Int("failureCount", result.FailureCount),
+		)
+
+		return nil, result, nil
+	}
+}
+
+// usersMatchingFilter returns up to maxUsers users matching the SCIM filter, and whether more users match it
+func usersMatchingFilter(ctx context.Context, toolName string, client UsersClient, environmentId uuid.UUID, filter string, maxUsers int) ([]UserSummary, bool, error) {
+	pagedIterator, err := client.GetUsers(ctx, environmentId, filter)
+	if err != nil {
+		toolErr := errs.NewToolError(toolName, err)
+		errs.Log(ctx, toolErr)
+		return nil, false, toolErr
+	}
+
+	users := []UserSummary{}
+	for next, err := range pagedIterator {
+		logger.LogHttpResponse(ctx, next.HTTPResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, false, apiErr
+		}
+		if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+			apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, false, apiErr
+		}
+		for _, user := range next.EntityArray.Embedded.Users {
+			if len(users) == maxUsers {
+				return users, true, nil
+			}
+			users = append(users, userSummary(user))
+		}
+	}
+	return users, false, nil
+}
+
+// usersWithIds returns the users with the given IDs, failing if any of them cannot be read
+func usersWithIds(ctx context.Context, client UsersClient, environmentId uuid.UUID, userIds []uuid.UUID) ([]UserSummary, error) {
+	users := make([]UserSummary, 0, len(userIds))
+	for _, userId := range userIds {
+		userResponse, httpResponse, err := client.GetUser(ctx, environmentId, userId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		if userResponse == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response for user %s", userId))
+			errs.Log(ctx, apiErr)
+			return nil, apiErr
+		}
+		users = append(users, userSummary(*userResponse))
+	}
+	return users, nil
+}
@@ -0,0 +1,239 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/bulkdelete"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testBulkDeleteFilter = `username sw "test-"`
+
+func bulkDeleteFilterInput() users.BulkDeleteUsersInput {
+	return users.BulkDeleteUsersInput{
+		EnvironmentId: testEnvironmentId,
+		Filter:        testutils.Pointer(testBulkDeleteFilter),
+	}
+}
+
+func mockTestUsers(mockClient *mockPingOneClientUsersWrapper) {
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testBulkDeleteFilter).Return(
+		testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+			usersPage(foundUser("user-1", "test-alice", "alice@example.com")),
+			usersPage(foundUser("user-2", "test-bob", "bob@example.com")),
+		}), nil)
+}
+
+func TestBulkDeleteUsersHandler_Preview(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockTestUsers(mockClient)
+
+	handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, bulkDeleteFilterInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.DryRun)
+	assert.False(t, output.MoreMatching)
+	require.NotNil(t, output.ConfirmationToken)
+	assert.Equal(t, bulkdelete.ConfirmationToken(testEnvironmentId, []string{"user-1", "user-2"}), *output.ConfirmationToken)
+	require.Len(t, output.Users, 2)
+	assert.Equal(t, "test-alice", output.Users[0].Username)
+	assert.Equal(t, "test-bob", output.Users[1].Username)
+	assert.Empty(t, output.Results)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteUsersHandler_Delete(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockTestUsers(mockClient)
+	mockClient.On("DeleteUser", mock.Anything, testEnvironmentId, "user-1").Return(&http.Response{StatusCode: 204}, nil)
+	mockClient.On("DeleteUser", mock.Anything, testEnvironmentId, "user-2").Return(&http.Response{StatusCode: 403}, errors.New("forbidden"))
+
+	input := bulkDeleteFilterInput()
+	input.ConfirmationToken = testutils.Pointer(bulkdelete.ConfirmationToken(testEnvironmentId, []string{"user-2", "user-1"}))
+
+	handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.DryRun)
+	assert.Nil(t, output.ConfirmationToken)
+	require.Len(t, output.Results, 2)
+	assert.Equal(t, users.BulkDeleteUserResult{UserId: "user-1", Username: "test-alice", Deleted: true}, output.Results[0])
+	assert.Equal(t, "user-2", output.Results[1].UserId)
+	assert.False(t, output.Results[1].Deleted)
+	require.NotNil(t, output.Results[1].Error)
+	assert.Contains(t, *output.Results[1].Error, "forbidden")
+	assert.Equal(t, 1, output.DeletedCount)
+	assert.Equal(t, 1, output.FailureCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBulkDeleteUsersHandler_ReportsProgress(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockTestUsers(mockClient)
+	mockClient.On("DeleteUser", mock.Anything, testEnvironmentId, mock.Anything).Return(&http.Response{StatusCode: 204}, nil)
+
+	var reported []progress.Progress
+	ctx := progress.ContextWithReporter(context.Background(), func(p progress.Progress) {
+		reported = append(reported, p)
+	})
+	input := bulkDeleteFilterInput()
+	input.ConfirmationToken = testutils.Pointer(bulkdelete.ConfirmationToken(testEnvironmentId, []string{"user-1", "user-2"}))
+
+	handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, input)
+
+	require.NoError(t, err)
+	assert.Equal(t, []progress.Progress{
+		{Completed: 0, Total: 2, Message: "Deleting users"},
+		{Completed: 1, Total: 2, Message: "Deleting users"},
+	}, reported)
+}
+
+func TestBulkDeleteUsersHandler_DeleteByIds(t *testing.T) {
+	otherUserId := uuid.MustParse("550e8400-e29b-41d4-a716-446655440201")
+	mockClient := &mockPingOneClientUsersWrapper{}
+	for i, userId := range []uuid.UUID{testUserId, otherUserId} {
+		user := foundUser(userId.String(), fmt.Sprintf("user-%d", i), "user@example.com")
+		mockClient.On("GetUser", mock.Anything, testEnvironmentId, userId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+		mockClient.On("DeleteUser", mock.Anything, testEnvironmentId, userId.String()).Return(&http.Response{StatusCode: 204}, nil)
+	}
+
+	input := users.BulkDeleteUsersInput{
+		EnvironmentId:     testEnvironmentId,
+		UserIds:           []uuid.UUID{testUserId, otherUserId},
+		ConfirmationToken: testutils.Pointer(bulkdelete.ConfirmationToken(testEnvironmentId, []string{testUserId.String(), otherUserId.String()})),
+	}
+
+	handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 2, output.DeletedCount)
+	assert.Equal(t, 0, output.FailureCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBulkDeleteUsersHandler_ConfirmationTokenMismatch(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockTestUsers(mockClient)
+
+	// The token was returned before a third user matched the filter
+	input := bulkDeleteFilterInput()
+	input.ConfirmationToken = testutils.Pointer(bulkdelete.ConfirmationToken(testEnvironmentId, []string{"user-1"}))
+
+	handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "confirmation token does not match")
+	assert.ErrorIs(t, err, bulkdelete.ErrConfirmationTokenMismatch)
+	mockClient.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBulkDeleteUsersHandler_MoreMatching(t *testing.T) {
+	var matching []management.User
+	for i := range bulkdelete.MaxResources + 5 {
+		matching = append(matching, foundUser(fmt.Sprintf("user-%d", i), fmt.Sprintf("test-%d", i), "user@example.com"))
+	}
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testBulkDeleteFilter).Return(
+		testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{usersPage(matching...)}), nil)
+
+	handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, bulkDeleteFilterInput())
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.MoreMatching)
+	assert.Len(t, output.Users, bulkdelete.MaxResources)
+}
+
+func TestBulkDeleteUsersHandler_Errors(t *testing.T) {
+	tooManyIds := make([]uuid.UUID, bulkdelete.MaxResources+1)
+	for i := range tooManyIds {
+		tooManyIds[i] = uuid.New()
+	}
+
+	tests := []struct {
+		name            string
+		input           users.BulkDeleteUsersInput
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "No filter or user IDs",
+			input:           users.BulkDeleteUsersInput{Filter: testutils.Pointer(" ")},
+			wantErrContains: "exactly one of 'filter' or 'userIds' must be provided",
+		},
+		{
+			name:            "Both filter and user IDs",
+			input:           users.BulkDeleteUsersInput{Filter: testutils.Pointer(testBulkDeleteFilter), UserIds: []uuid.UUID{testUserId}},
+			wantErrContains: "exactly one of 'filter' or 'userIds' must be provided",
+		},
+		{
+			name:            "Too many user IDs",
+			input:           users.BulkDeleteUsersInput{UserIds: tooManyIds},
+			wantErrContains: "a maximum of 100 users can be deleted per call, got 101",
+		},
+		{
+			name:  "List users error",
+			input: users.BulkDeleteUsersInput{Filter: testutils.Pointer(testBulkDeleteFilter)},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testBulkDeleteFilter).Return(
+					testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{{
+						HTTPResponse: &http.Response{StatusCode: 400},
+						Error:        errors.New("invalid filter"),
+					}}), nil)
+			},
+			wantErrContains: "invalid filter",
+		},
+		{
+			name:  "User not found",
+			input: users.BulkDeleteUsersInput{UserIds: []uuid.UUID{testUserId}},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("user not found"))
+			},
+			wantErrContains: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			input := tt.input
+			input.EnvironmentId = testEnvironmentId
+			handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+			mockClient.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestBulkDeleteUsersHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := users.BulkDeleteUsersHandler(NewMockPingOneClientUsersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, bulkDeleteFilterInput())
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
@@ -0,0 +1,288 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// Results of evaluating a password policy rule
+const (
+	PasswordRulePassed       = "passed"
+	PasswordRuleFailed       = "failed"
+	PasswordRuleNotEvaluated = "notEvaluated"
+)
+
+// specialCharacters are the characters PingOne counts towards the 'specialchar' minimum of a password policy
+const specialCharacters = "~!@#$%^&*()-_=+[]{}|;:,.<>/?"
+
+// minProfileValueLength is the length below which a profile value is too short to be matched in a password
+const minProfileValueLength = 3
+
+var CheckPasswordAgainstPolicyDef = types.ToolDefinition{
+	// Candidate passwords must not be retained in cache keys
+	DisableResponseCache: true,
+	McpTool: &mcp.Tool{
+		Name:  "check_password_against_policy",
+		Title: "Check Password Against PingOne Password Policy",
+		Description: `Check a candidate password against a password policy without setting it, and report each rule of the policy with whether the password passed or failed it and why. Use to explain exactly why a password was rejected, or to check a new password before reset_user_password.
+
+The policy is the one of the user's population when 'userId' is given, of the population when 'populationId' is given, and otherwise the environment's default policy. Length, character, repetition and uniqueness rules are evaluated, and so are profile data rules when 'userId' is given. PingOne only evaluates the commonly used password list, password history, similarity to the current password and complexity rules when a password is set, so these are reported as not evaluated.
+
+The password is checked by this server: it is never sent to PingOne and never returned.`,
+		InputSchema:  schema.MustGenerateSchema[CheckPasswordAgainstPolicyInput](),
+		OutputSchema: schema.MustGenerateSchema[CheckPasswordAgainstPolicyOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type CheckPasswordAgainstPolicyInput struct {
+	EnvironmentId uuid.UUID  `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Password      string     `json:"password" jsonschema:"REQUIRED. The candidate password to check."`
+	UserId        *uuid.UUID `json:"userId,omitempty" jsonschema:"OPTIONAL. The UUID of the user the password is for, to use the policy of their population and check that the password does not contain their profile data. Cannot be combined with 'populationId'."`
+	PopulationId  *uuid.UUID `json:"populationId,omitempty" jsonschema:"OPTIONAL. The UUID of the population whose policy to use. Cannot be combined with 'userId'."`
+}
+
+type PasswordRuleResult struct {
+	Rule        string `json:"rule" jsonschema:"The policy attribute of the rule, such as 'length.min' or 'minCharacters.uppercase'"`
+	Requirement string `json:"requirement" jsonschema:"What the rule requires"`
+	Result      string `json:"result" jsonschema:"'passed', 'failed', or 'notEvaluated' if the rule can only be evaluated by PingOne when the password is set"`
+	Reason      string `json:"reason,omitempty" jsonschema:"Why the password failed the rule, or why the rule was not evaluated"`
+}
+
+type CheckPasswordAgainstPolicyOutput struct {
+	Passed bool                  `json:"passed" jsonschema:"True if the password passed every evaluated rule. PingOne may still reject it for the rules that were not evaluated."`
+	Policy PasswordPolicySummary `json:"policy" jsonschema:"The password policy the password was checked against"`
+	Rules  []PasswordRuleResult  `json:"rules" jsonschema:"The result of each rule of the policy"`
+}
+
+// CheckPasswordAgainstPolicyHandler evaluates a candidate password against a PingOne password policy using the provided client
+func CheckPasswordAgainstPolicyHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input CheckPasswordAgainstPolicyInput,
+) (
+	*mcp.CallToolResult,
+	*CheckPasswordAgainstPolicyOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input CheckPasswordAgainstPolicyInput) (*mcp.CallToolResult, *CheckPasswordAgainstPolicyOutput, error) {
+		if input.Password == "" {
+			toolErr := errs.NewToolError(CheckPasswordAgainstPolicyDef.McpTool.Name, errors.New("password must not be empty"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if input.UserId != nil && input.PopulationId != nil {
+			toolErr := errs.NewToolError(CheckPasswordAgainstPolicyDef.McpTool.Name, errors.New("provide either 'userId' or 'populationId', not both"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(CheckPasswordAgainstPolicyDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Checking password against policy",
+			slog.String("environmentId", input.EnvironmentId.String()))
+
+		var user *management.User
+		populationId := ""
+		if input.PopulationId != nil {
+			populationId = input.PopulationId.String()
+		}
+		if input.UserId != nil {
+			var httpResponse *http.Response
+			user, httpResponse, err = client.GetUser(ctx, input.EnvironmentId, input.UserId.String())
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if user == nil {
+				apiErr := errs.NewApiError(httpResponse, errors.New("no user data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if user.Population != nil {
+				populationId = user.Population.Id
+			}
+		}
+
+		resolved, err := resolvePasswordPolicy(ctx, client, input.EnvironmentId, "", populationId)
+		if err != nil {
+			errs.Log(ctx, err)
+			return nil, nil, err
+		}
+
+		rules := evaluatePasswordPolicy(resolved.policy, input.Password, user)
+		passed := true
+		for _, rule := range rules {
+			if rule.Result == PasswordRuleFailed {
+				passed = false
+			}
+		}
+
+		return nil, &CheckPasswordAgainstPolicyOutput{
+			Passed: passed,
+			Policy: resolved.summary(),
+			Rules:  rules,
+		}, nil
+	}
+}
+
+// evaluatePasswordPolicy evaluates the password against each rule the policy sets. Profile data is only checked
+// when the user is known.
+func evaluatePasswordPolicy(policy *management.PasswordPolicy, password string, user *management.User) []PasswordRuleResult {
+	characters := []rune(password)
+	rules := []PasswordRuleResult{}
+
+	if policy.Length != nil && policy.Length.Min != nil {
+		rule := PasswordRuleResult{Rule: "length.min", Requirement: fmt.Sprintf("at least %d characters", *policy.Length.Min)}
+		rules = append(rules, checkRule(rule, len(characters) >= int(*policy.Length.Min), fmt.Sprintf("the password has %d characters", len(characters))))
+	}
+	if policy.Length != nil && policy.Length.Max != nil {
+		rule := PasswordRuleResult{Rule: "length.max", Requirement: fmt.Sprintf("at most %d characters", *policy.Length.Max)}
+		rules = append(rules, checkRule(rule, len(characters) <= int(*policy.Length.Max), fmt.Sprintf("the password has %d characters", len(characters))))
+	}
+
+	if policy.MinCharacters != nil {
+		characterSets := []struct {
+			rule        string
+			description string
+			minimum     *int32
+			contains    func(r rune) bool
+		}{
+			{"minCharacters.uppercase", "uppercase letters (A-Z)", policy.MinCharacters.ABCDEFGHIJKLMNOPQRSTUVWXYZ, func(r rune) bool { return r >= 'A' && r <= 'Z' }},
+			{"minCharacters.lowercase", "lowercase letters (a-z)", policy.MinCharacters.Abcdefghijklmnopqrstuvwxyz, func(r rune) bool { return r >= 'a' && r <= 'z' }},
+			{"minCharacters.digits", "digits (0-9)", policy.MinCharacters.Var0123456789, func(r rune) bool { return r >= '0' && r <= '9' }},
+			{"minCharacters.special", "special characters (" + specialCharacters + ")", policy.MinCharacters.SpecialChar, func(r rune) bool { return strings.ContainsRune(specialCharacters, r) }},
+		}
+		for _, set := range characterSets {
+			if set.minimum == nil || *set.minimum <= 0 {
+				continue
+			}
+			count := 0
+			for _, r := range characters {
+				if set.contains(r) {
+					count++
+				}
+			}
+			rule := PasswordRuleResult{Rule: set.rule, Requirement: fmt.Sprintf("at least %d %s", *set.minimum, set.description)}
+			rules = append(rules, checkRule(rule, count >= int(*set.minimum), fmt.Sprintf("the password has %d", count)))
+		}
+	}
+
+	if policy.MaxRepeatedCharacters != nil && *policy.MaxRepeatedCharacters > 0 {
+		longest, repeated := longestRepeat(characters)
+		rule := PasswordRuleResult{Rule: "maxRepeatedCharacters", Requirement: fmt.Sprintf("no character repeated more than %d times in a row", *policy.MaxRepeatedCharacters)}
+		rules = append(rules, checkRule(rule, longest <= int(*policy.MaxRepeatedCharacters), fmt.Sprintf("a character is repeated %d times in a row at position %d", longest, repeated+1)))
+	}
+
+	if policy.MinUniqueCharacters != nil && *policy.MinUniqueCharacters > 0 {
+		unique := map[rune]bool{}
+		for _, r := range characters {
+			unique[r] = true
+		}
+		rule := PasswordRuleResult{Rule: "minUniqueCharacters", Requirement: fmt.Sprintf("at least %d different characters", *policy.MinUniqueCharacters)}
+		rules = append(rules, checkRule(rule, len(unique) >= int(*policy.MinUniqueCharacters), fmt.Sprintf("the password has %d different characters", len(unique))))
+	}
+
+	if policy.ExcludesProfileData {
+		rule := PasswordRuleResult{Rule: "excludesProfileData", Requirement: "must not contain the user's username, email address or name"}
+		if user == nil {
+			rule.Result = PasswordRuleNotEvaluated
+			rule.Reason = "no user was given"
+			rules = append(rules, rule)
+		} else {
+			attribute := profileDataInPassword(password, user)
+			rules = append(rules, checkRule(rule, attribute == "", "the password contains the user's "+attribute))
+		}
+	}
+
+	if policy.ExcludesCommonlyUsed {
+		rules = append(rules, PasswordRuleResult{Rule: "excludesCommonlyUsed", Requirement: "must not be a commonly used password", Result: PasswordRuleNotEvaluated, Reason: "PingOne checks its list of commonly used passwords when the password is set"})
+	}
+	if policy.History != nil && policy.History.Count != nil && *policy.History.Count > 0 {
+		rules = append(rules, PasswordRuleResult{Rule: "history.count", Requirement: fmt.Sprintf("must not be one of the user's last %d passwords", *policy.History.Count), Result: PasswordRuleNotEvaluated, Reason: "previous passwords are only known to PingOne"})
+	}
+	if policy.NotSimilarToCurrent {
+		rules = append(rules, PasswordRuleResult{Rule: "notSimilarToCurrent", Requirement: "must not be similar to the current password", Result: PasswordRuleNotEvaluated, Reason: "the current password is only known to PingOne"})
+	}
+	if policy.MinComplexity != nil && *policy.MinComplexity > 0 {
+		rules = append(rules, PasswordRuleResult{Rule: "minComplexity", Requirement: fmt.Sprintf("a complexity of at least %d", *policy.MinComplexity), Result: PasswordRuleNotEvaluated, Reason: "PingOne calculates complexity when the password is set"})
+	}
+
+	return rules
+}
+
+// checkRule sets the result of the rule, with the reason if it failed
+func checkRule(rule PasswordRuleResult, passed bool, failureReason string) PasswordRuleResult {
+	if passed {
+		rule.Result = PasswordRulePassed
+	} else {
+		rule.Result = PasswordRuleFailed
+		rule.Reason = failureReason
+	}
+	return rule
+}
+
+// longestRepeat returns the length of the longest run of a repeated character and the index where it starts
+func longestRepeat(characters []rune) (int, int) {
+	longest, longestStart := 0, 0
+	for start := 0; start < len(characters); {
+		end := start
+		for end < len(characters) && characters[end] == characters[start] {
+			end++
+		}
+		if end-start > longest {
+			longest, longestStart = end-start, start
+		}
+		start = end
+	}
+	return longest, longestStart
+}
+
+type profileAttribute struct {
+	name  string
+	value string
+}
+
+// profileDataInPassword returns the name of the first profile attribute of the user that the password contains,
+// ignoring case, or an empty string if it contains none
+func profileDataInPassword(password string, user *management.User) string {
+	password = strings.ToLower(password)
+	emailName, _, _ := strings.Cut(user.Email, "@")
+	attributes := []profileAttribute{
+		{"username", user.Username},
+		{"email address", emailName},
+	}
+	if user.Name != nil {
+		attributes = append(attributes, profileAttribute{"given name", user.Name.GetGiven()}, profileAttribute{"family name", user.Name.GetFamily()})
+	}
+	for _, attribute := range attributes {
+		value := strings.ToLower(strings.TrimSpace(attribute.value))
+		if len([]rune(value)) >= minProfileValueLength && strings.Contains(password, value) {
+			return attribute.name
+		}
+	}
+	return ""
+}
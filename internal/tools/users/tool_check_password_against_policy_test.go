@@ -0,0 +1,238 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// strictPasswordPolicy returns a policy that sets every rule
+func strictPasswordPolicy() *management.PasswordPolicy {
+	return &management.PasswordPolicy{
+		Id:     testutils.Pointer(testPasswordPolicyId),
+		Name:   "Strict",
+		Length: &management.PasswordPolicyLength{Min: testutils.Pointer(int32(10)), Max: testutils.Pointer(int32(64))},
+		MinCharacters: &management.PasswordPolicyMinCharacters{
+			ABCDEFGHIJKLMNOPQRSTUVWXYZ: testutils.Pointer(int32(1)),
+			Abcdefghijklmnopqrstuvwxyz: testutils.Pointer(int32(1)),
+			Var0123456789:              testutils.Pointer(int32(2)),
+			SpecialChar:                testutils.Pointer(int32(1)),
+		},
+		MaxRepeatedCharacters: testutils.Pointer(int32(2)),
+		MinUniqueCharacters:   testutils.Pointer(int32(5)),
+		ExcludesProfileData:   true,
+		ExcludesCommonlyUsed:  true,
+		NotSimilarToCurrent:   true,
+		History:               &management.PasswordPolicyHistory{Count: testutils.Pointer(int32(6))},
+	}
+}
+
+// ruleResults returns the result of each rule by rule name
+func ruleResults(rules []users.PasswordRuleResult) map[string]string {
+	results := map[string]string{}
+	for _, rule := range rules {
+		results[rule.Rule] = rule.Result
+	}
+	return results
+}
+
+func TestCheckPasswordAgainstPolicyDef(t *testing.T) {
+	assert.Equal(t, "check_password_against_policy", users.CheckPasswordAgainstPolicyDef.McpTool.Name)
+	assert.True(t, users.CheckPasswordAgainstPolicyDef.IsReadOnly())
+	assert.True(t, users.CheckPasswordAgainstPolicyDef.DisableResponseCache, "candidate passwords should not be kept in cache keys")
+	assert.Nil(t, users.CheckPasswordAgainstPolicyDef.ValidationPolicy, "the production guardrail should apply")
+}
+
+func TestCheckPasswordAgainstPolicyHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		password    string
+		wantPassed  bool
+		wantResults map[string]string
+	}{
+		{
+			name:       "Password meeting the policy",
+			password:   "Tr0ub4dor&3x",
+			wantPassed: true,
+			wantResults: map[string]string{
+				"length.min":              users.PasswordRulePassed,
+				"length.max":              users.PasswordRulePassed,
+				"minCharacters.uppercase": users.PasswordRulePassed,
+				"minCharacters.lowercase": users.PasswordRulePassed,
+				"minCharacters.digits":    users.PasswordRulePassed,
+				"minCharacters.special":   users.PasswordRulePassed,
+				"maxRepeatedCharacters":   users.PasswordRulePassed,
+				"minUniqueCharacters":     users.PasswordRulePassed,
+				"excludesProfileData":     users.PasswordRulePassed,
+				"excludesCommonlyUsed":    users.PasswordRuleNotEvaluated,
+				"history.count":           users.PasswordRuleNotEvaluated,
+				"notSimilarToCurrent":     users.PasswordRuleNotEvaluated,
+			},
+		},
+		{
+			name:       "Password failing several rules",
+			password:   "aliceeee1",
+			wantPassed: false,
+			wantResults: map[string]string{
+				"length.min":              users.PasswordRuleFailed,
+				"length.max":              users.PasswordRulePassed,
+				"minCharacters.uppercase": users.PasswordRuleFailed,
+				"minCharacters.lowercase": users.PasswordRulePassed,
+				"minCharacters.digits":    users.PasswordRuleFailed,
+				"minCharacters.special":   users.PasswordRuleFailed,
+				"maxRepeatedCharacters":   users.PasswordRuleFailed,
+				"minUniqueCharacters":     users.PasswordRulePassed,
+				"excludesProfileData":     users.PasswordRuleFailed,
+				"excludesCommonlyUsed":    users.PasswordRuleNotEvaluated,
+				"history.count":           users.PasswordRuleNotEvaluated,
+				"notSimilarToCurrent":     users.PasswordRuleNotEvaluated,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := foundUser(testUserId.String(), "alice", "alice@example.com")
+			mockClient := &mockPingOneClientUsersWrapper{}
+			mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+			mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testPopulationId.String()).Return(&management.Population{
+				PasswordPolicy: &management.PopulationPasswordPolicy{Id: testPasswordPolicyId},
+			}, &http.Response{StatusCode: 200}, nil)
+			mockClient.On("GetPasswordPolicy", mock.Anything, testEnvironmentId, testPasswordPolicyId).Return(strictPasswordPolicy(), &http.Response{StatusCode: 200}, nil)
+
+			handler := users.CheckPasswordAgainstPolicyHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.CheckPasswordAgainstPolicyInput{
+				EnvironmentId: testEnvironmentId,
+				Password:      tt.password,
+				UserId:        &testUserId,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, tt.wantPassed, output.Passed)
+			assert.Equal(t, tt.wantResults, ruleResults(output.Rules))
+			assert.Equal(t, users.PasswordPolicySummary{Id: testPasswordPolicyId, Name: "Strict", Source: users.PasswordPolicySourcePopulation}, output.Policy)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCheckPasswordAgainstPolicyHandler_FailureReasons(t *testing.T) {
+	user := foundUser(testUserId.String(), "alice", "alice@example.com")
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testPopulationId.String()).Return(&management.Population{
+		PasswordPolicy: &management.PopulationPasswordPolicy{Id: testPasswordPolicyId},
+	}, &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetPasswordPolicy", mock.Anything, testEnvironmentId, testPasswordPolicyId).Return(strictPasswordPolicy(), &http.Response{StatusCode: 200}, nil)
+
+	handler := users.CheckPasswordAgainstPolicyHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.CheckPasswordAgainstPolicyInput{
+		EnvironmentId: testEnvironmentId,
+		Password:      "Smith!!!2025",
+		UserId:        &testUserId,
+	})
+	require.NoError(t, err)
+
+	reasons := map[string]string{}
+	for _, rule := range output.Rules {
+		reasons[rule.Rule] = rule.Reason
+	}
+	assert.Equal(t, "a character is repeated 3 times in a row at position 6", reasons["maxRepeatedCharacters"])
+	assert.Equal(t, "the password contains the user's family name", reasons["excludesProfileData"])
+	assert.Empty(t, reasons["length.min"], "passed rules have no reason")
+	for _, rule := range output.Rules {
+		assert.NotContains(t, rule.Reason, "Smith!!!2025", "the password must never be returned")
+	}
+}
+
+func TestCheckPasswordAgainstPolicyHandler_DefaultPolicy(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("FindDefaultPasswordPolicy", mock.Anything, testEnvironmentId).Return(strictPasswordPolicy(), &http.Response{StatusCode: 200}, nil)
+
+	handler := users.CheckPasswordAgainstPolicyHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.CheckPasswordAgainstPolicyInput{
+		EnvironmentId: testEnvironmentId,
+		Password:      "Tr0ub4dor&3x",
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.Passed)
+	assert.Equal(t, users.PasswordPolicySourceEnvironmentDefault, output.Policy.Source)
+	assert.Equal(t, users.PasswordRuleNotEvaluated, ruleResults(output.Rules)["excludesProfileData"], "profile data can only be checked for a user")
+	mockClient.AssertExpectations(t)
+}
+
+func TestCheckPasswordAgainstPolicyHandler_Errors(t *testing.T) {
+	populationId := uuid.MustParse("550e8400-e29b-41d4-a716-446655440101")
+	tests := []struct {
+		name            string
+		input           users.CheckPasswordAgainstPolicyInput
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Empty password",
+			input:           users.CheckPasswordAgainstPolicyInput{EnvironmentId: testEnvironmentId},
+			setupMock:       func(mockClient *mockPingOneClientUsersWrapper) {},
+			wantErrContains: "password must not be empty",
+		},
+		{
+			name:            "User and population",
+			input:           users.CheckPasswordAgainstPolicyInput{EnvironmentId: testEnvironmentId, Password: "secret", UserId: &testUserId, PopulationId: &populationId},
+			setupMock:       func(mockClient *mockPingOneClientUsersWrapper) {},
+			wantErrContains: "not both",
+		},
+		{
+			name:  "Get user error",
+			input: users.CheckPasswordAgainstPolicyInput{EnvironmentId: testEnvironmentId, Password: "secret", UserId: &testUserId},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("user not found"))
+			},
+			wantErrContains: "user not found",
+		},
+		{
+			name:  "Get population error",
+			input: users.CheckPasswordAgainstPolicyInput{EnvironmentId: testEnvironmentId, Password: "secret", PopulationId: &populationId},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, populationId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("population not found"))
+			},
+			wantErrContains: "population not found",
+		},
+		{
+			name:  "Get policy error",
+			input: users.CheckPasswordAgainstPolicyInput{EnvironmentId: testEnvironmentId, Password: "secret", PopulationId: &populationId},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, populationId.String()).Return(&management.Population{
+					PasswordPolicy: &management.PopulationPasswordPolicy{Id: testPasswordPolicyId},
+				}, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetPasswordPolicy", mock.Anything, testEnvironmentId, testPasswordPolicyId).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := users.CheckPasswordAgainstPolicyHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
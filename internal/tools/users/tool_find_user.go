@@ -0,0 +1,170 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var FindUserDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "find_user",
+		Title: "Find PingOne User",
+		Description: `Find users in an environment by username or email address, without writing a SCIM filter. Use to look up a user's ID, population and status before other operations.
+
+Matching is exact. Usernames are unique within an environment, so a username matches at most one user, while several users can share an email address. If both username and email are given, users matching either are returned. An empty result means no user matched.`,
+		InputSchema:  schema.MustGenerateSchema[FindUserInput](),
+		OutputSchema: schema.MustGenerateSchema[FindUserOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type FindUserInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Username      *string   `json:"username,omitempty" jsonschema:"OPTIONAL. The exact username to find. Required if 'email' is not provided."`
+	Email         *string   `json:"email,omitempty" jsonschema:"OPTIONAL. The exact email address to find. Required if 'username' is not provided."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'users.id' and 'users.username'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type UserSummary struct {
+	Id              *string    `json:"id" jsonschema:"The unique identifier of the user"`
+	Username        string     `json:"username" jsonschema:"The username of the user"`
+	Email           string     `json:"email" jsonschema:"The email address of the user"`
+	GivenName       *string    `json:"givenName,omitempty" jsonschema:"The given (first) name of the user"`
+	FamilyName      *string    `json:"familyName,omitempty" jsonschema:"The family (last) name of the user"`
+	Enabled         *bool      `json:"enabled,omitempty" jsonschema:"Whether the user is enabled"`
+	PopulationId    *string    `json:"populationId,omitempty" jsonschema:"The UUID of the population the user belongs to"`
+	AccountStatus   *string    `json:"accountStatus,omitempty" jsonschema:"The account status of the user, such as OK or LOCKED"`
+	LifecycleStatus *string    `json:"lifecycleStatus,omitempty" jsonschema:"The lifecycle status of the user: ACCOUNT_OK or VERIFICATION_REQUIRED"`
+	CreatedAt       *time.Time `json:"createdAt,omitempty" jsonschema:"When the user was created"`
+	LastSignOnAt    *time.Time `json:"lastSignOnAt,omitempty" jsonschema:"When the user last signed on"`
+}
+
+type FindUserOutput struct {
+	Users []UserSummary `json:"users" jsonschema:"The users matching the username or email, empty if none matched"`
+}
+
+// FindUserHandler finds PingOne users by username or email using the provided client
+func FindUserHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input FindUserInput,
+) (
+	*mcp.CallToolResult,
+	*FindUserOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input FindUserInput) (*mcp.CallToolResult, *FindUserOutput, error) {
+		filter, err := findUserFilter(input)
+		if err != nil {
+			toolErr := errs.NewToolError(FindUserDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(FindUserDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Finding users",
+			slog.String("environmentId", input.EnvironmentId.String()))
+
+		pagedIterator, err := client.GetUsers(ctx, input.EnvironmentId, filter)
+		if err != nil {
+			toolErr := errs.NewToolError(FindUserDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		result := FindUserOutput{
+			Users: []UserSummary{},
+		}
+		for next, err := range pagedIterator {
+			logger.LogHttpResponse(ctx, next.HTTPResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(next.HTTPResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			if next.EntityArray == nil || next.EntityArray.Embedded == nil {
+				apiErr := errs.NewApiError(next.HTTPResponse, errors.New("no data in response"))
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+			for _, user := range next.EntityArray.Embedded.Users {
+				result.Users = append(result.Users, userSummary(user))
+			}
+		}
+
+		logger.FromContext(ctx).Debug("Found users", slog.Int("count", len(result.Users)))
+
+		return nil, &result, nil
+	}
+}
+
+// findUserFilter returns the SCIM filter matching the username or email of the input
+func findUserFilter(input FindUserInput) (string, error) {
+	var clauses []string
+	if input.Username != nil && strings.TrimSpace(*input.Username) != "" {
+		clauses = append(clauses, fmt.Sprintf("username eq %s", scimString(strings.TrimSpace(*input.Username))))
+	}
+	if input.Email != nil && strings.TrimSpace(*input.Email) != "" {
+		clauses = append(clauses, fmt.Sprintf("email eq %s", scimString(strings.TrimSpace(*input.Email))))
+	}
+	if len(clauses) == 0 {
+		return "", errors.New("either 'username' or 'email' must be provided")
+	}
+	return strings.Join(clauses, " or "), nil
+}
+
+// scimString quotes a value for use in a SCIM filter
+func scimString(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+func userSummary(user management.User) UserSummary {
+	summary := UserSummary{
+		Id:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		Enabled:   user.Enabled,
+		CreatedAt: user.CreatedAt,
+	}
+	if user.Name != nil {
+		summary.GivenName = user.Name.Given
+		summary.FamilyName = user.Name.Family
+	}
+	if user.Population != nil {
+		summary.PopulationId = &user.Population.Id
+	}
+	if user.Account != nil {
+		status := string(user.Account.Status)
+		summary.AccountStatus = &status
+	}
+	if user.Lifecycle != nil && user.Lifecycle.Status != nil {
+		status := string(*user.Lifecycle.Status)
+		summary.LifecycleStatus = &status
+	}
+	if user.LastSignOn != nil {
+		summary.LastSignOnAt = user.LastSignOn.At
+	}
+	return summary
+}
@@ -0,0 +1,214 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func usersPage(users ...management.User) testutils.LegacySdkMockPage {
+	return testutils.LegacySdkMockPage{
+		EntityArray: &management.EntityArray{
+			Embedded: &management.EntityArrayEmbedded{
+				Users: users,
+			},
+		},
+		HTTPResponse: &http.Response{StatusCode: 200},
+	}
+}
+
+func foundUser(id string, username string, email string) management.User {
+	return management.User{
+		Id:         testutils.Pointer(id),
+		Username:   username,
+		Email:      email,
+		Enabled:    testutils.Pointer(true),
+		Name:       &management.UserName{Given: testutils.Pointer("Alice"), Family: testutils.Pointer("Smith")},
+		Population: &management.UserPopulation{Id: testPopulationId.String()},
+		Account:    &management.UserAccount{CanAuthenticate: true, Status: management.ENUMUSERSTATUS_OK},
+	}
+}
+
+func TestFindUserHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      users.FindUserInput
+		wantFilter string
+		pages      []testutils.LegacySdkMockPage
+		wantIds    []string
+	}{
+		{
+			name:       "By username",
+			input:      users.FindUserInput{Username: testutils.Pointer("alice")},
+			wantFilter: `username eq "alice"`,
+			pages:      []testutils.LegacySdkMockPage{usersPage(foundUser("user-1", "alice", "alice@example.com"))},
+			wantIds:    []string{"user-1"},
+		},
+		{
+			name:       "By email across pages",
+			input:      users.FindUserInput{Email: testutils.Pointer(" shared@example.com ")},
+			wantFilter: `email eq "shared@example.com"`,
+			pages: []testutils.LegacySdkMockPage{
+				usersPage(foundUser("user-1", "alice", "shared@example.com")),
+				usersPage(foundUser("user-2", "bob", "shared@example.com")),
+			},
+			wantIds: []string{"user-1", "user-2"},
+		},
+		{
+			name:       "By username or email",
+			input:      users.FindUserInput{Username: testutils.Pointer("alice"), Email: testutils.Pointer("alice@example.com")},
+			wantFilter: `username eq "alice" or email eq "alice@example.com"`,
+			pages:      []testutils.LegacySdkMockPage{usersPage(foundUser("user-1", "alice", "alice@example.com"))},
+			wantIds:    []string{"user-1"},
+		},
+		{
+			name:       "Quotes are escaped",
+			input:      users.FindUserInput{Username: testutils.Pointer(`al"ice\`)},
+			wantFilter: `username eq "al\"ice\\"`,
+			pages:      []testutils.LegacySdkMockPage{usersPage()},
+			wantIds:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			mockClient.On("GetUsers", mock.Anything, testEnvironmentId, tt.wantFilter).Return(testutils.MockLegacySdkPaginationIterator(tt.pages), nil)
+
+			input := tt.input
+			input.EnvironmentId = testEnvironmentId
+			handler := users.FindUserHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			ids := []string{}
+			for _, user := range output.Users {
+				ids = append(ids, *user.Id)
+			}
+			assert.Equal(t, tt.wantIds, ids)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFindUserHandler_UserSummary(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, mock.Anything).Return(
+		testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{usersPage(foundUser("user-1", "alice", "alice@example.com"))}), nil)
+
+	handler := users.FindUserHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.FindUserInput{
+		EnvironmentId: testEnvironmentId,
+		Username:      testutils.Pointer("alice"),
+	})
+
+	require.NoError(t, err)
+	require.Len(t, output.Users, 1)
+	user := output.Users[0]
+	assert.Equal(t, "alice", user.Username)
+	assert.Equal(t, "alice@example.com", user.Email)
+	assert.Equal(t, "Alice", *user.GivenName)
+	assert.Equal(t, "Smith", *user.FamilyName)
+	assert.True(t, *user.Enabled)
+	assert.Equal(t, testPopulationId.String(), *user.PopulationId)
+	assert.Equal(t, "OK", *user.AccountStatus)
+}
+
+func TestFindUserHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           users.FindUserInput
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "No username or email",
+			input:           users.FindUserInput{Username: testutils.Pointer(" ")},
+			wantErrContains: "either 'username' or 'email' must be provided",
+		},
+		{
+			name:  "API error",
+			input: users.FindUserInput{Email: testutils.Pointer("alice@example.com")},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUsers", mock.Anything, testEnvironmentId, mock.Anything).Return(
+					testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{{
+						HTTPResponse: &http.Response{StatusCode: 400},
+						Error:        errors.New("invalid filter"),
+					}}), nil)
+			},
+			wantErrContains: "invalid filter",
+		},
+		{
+			name:  "No data in response",
+			input: users.FindUserInput{Email: testutils.Pointer("alice@example.com")},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUsers", mock.Anything, testEnvironmentId, mock.Anything).Return(
+					testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{{
+						HTTPResponse: &http.Response{StatusCode: 200},
+					}}), nil)
+			},
+			wantErrContains: "no data in response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			input := tt.input
+			input.EnvironmentId = testEnvironmentId
+			handler := users.FindUserHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFindUserHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := users.FindUserHandler(NewMockPingOneClientUsersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.FindUserInput{
+		EnvironmentId: testEnvironmentId,
+		Username:      testutils.Pointer("alice"),
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestFindUserHandler_OverMcp(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, `email eq "alice@example.com"`).Return(
+		testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{usersPage(foundUser("user-1", "alice", "alice@example.com"))}), nil)
+	server := mcptestutils.TestMcpServer(t)
+	mcp.AddTool(server, users.FindUserDef.McpTool, users.FindUserHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil)))
+
+	output, err := mcptestutils.CallToolOverMcp(t, server, users.FindUserDef.McpTool.Name, map[string]any{
+		"environmentId": testEnvironmentId.String(),
+		"email":         "alice@example.com",
+	})
+	testutils.AssertMcpCallSuccess(t, err, output)
+
+	result := &users.FindUserOutput{}
+	jsonBytes, err := json.Marshal(output.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsonBytes, result))
+	require.Len(t, result.Users, 1)
+	assert.Equal(t, "user-1", *result.Users[0].Id)
+}
@@ -0,0 +1,191 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+// passwordExpiredStatus is the password status PingOne reports once a password is older than the policy's maximum age
+const passwordExpiredStatus = "PASSWORD_EXPIRED"
+
+var GetUserPasswordStatusDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "get_user_password_status",
+		Title: "Get PingOne User Password Status",
+		Description: `Get the state of a user's password: its status, when it was last changed, whether the account is locked out and until when, when the password expires, and the password policy that applies to the user with its lockout, expiry and history settings. Use to explain why a user cannot sign on with their password before unlocking or resetting it.
+
+The password itself is never returned. PingOne does not expose previous passwords, so only the policy's history settings are returned. Use find_user to look up the user ID from a username or email address.`,
+		InputSchema:  schema.MustGenerateSchema[GetUserPasswordStatusInput](),
+		OutputSchema: schema.MustGenerateSchema[GetUserPasswordStatusOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	},
+}
+
+type GetUserPasswordStatusInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. User UUID."`
+	Fields        []string  `json:"fields,omitempty" jsonschema:"OPTIONAL. Output attributes to return, as dot-separated JSON paths relative to the tool output, such as 'status' and 'lockout.unlockAt'. Use to reduce response size when only some attributes are needed. Defaults to all attributes."`
+}
+
+type PasswordLockoutStatus struct {
+	Locked             bool       `json:"locked" jsonschema:"True if the account is locked after too many failed sign-on attempts"`
+	AccountStatus      *string    `json:"accountStatus,omitempty" jsonschema:"The account status of the user, such as OK or LOCKED"`
+	LockedAt           *time.Time `json:"lockedAt,omitempty" jsonschema:"When the account was locked"`
+	UnlockAt           *time.Time `json:"unlockAt,omitempty" jsonschema:"When the account will be unlocked automatically"`
+	SecondsUntilUnlock *int32     `json:"secondsUntilUnlock,omitempty" jsonschema:"The number of seconds until the account is unlocked automatically"`
+	FailureCount       *int32     `json:"failureCount,omitempty" jsonschema:"The number of failed sign-on attempts the policy allows before locking the account. Not set if the policy does not lock accounts."`
+	DurationSeconds    *int32     `json:"durationSeconds,omitempty" jsonschema:"How long the policy locks an account for, in seconds"`
+}
+
+type PasswordExpiryStatus struct {
+	Expired          bool       `json:"expired" jsonschema:"True if the password has expired"`
+	MaxAgeDays       *int32     `json:"maxAgeDays,omitempty" jsonschema:"The number of days after which the policy expires a password. Not set if passwords do not expire."`
+	ExpiresAt        *time.Time `json:"expiresAt,omitempty" jsonschema:"When the password expires, from when it was last changed and the policy's maximum age"`
+	MinAgeDays       *int32     `json:"minAgeDays,omitempty" jsonschema:"The number of days the policy requires before a password can be changed again"`
+	EarliestChangeAt *time.Time `json:"earliestChangeAt,omitempty" jsonschema:"When the user can next change their password, from when it was last changed and the policy's minimum age"`
+}
+
+type PasswordHistoryPolicy struct {
+	Count         *int32 `json:"count,omitempty" jsonschema:"The number of previous passwords the user cannot reuse"`
+	RetentionDays *int32 `json:"retentionDays,omitempty" jsonschema:"The number of days previous passwords are kept"`
+}
+
+type GetUserPasswordStatusOutput struct {
+	UserId        string                `json:"userId" jsonschema:"The UUID of the user"`
+	Username      string                `json:"username,omitempty" jsonschema:"The username of the user"`
+	Status        *string               `json:"status,omitempty" jsonschema:"The status of the password as reported by PingOne, such as OK, MUST_CHANGE_PASSWORD, PASSWORD_EXPIRED or NO_PASSWORD"`
+	LastChangedAt *time.Time            `json:"lastChangedAt,omitempty" jsonschema:"When the password was last changed"`
+	Lockout       PasswordLockoutStatus `json:"lockout" jsonschema:"Whether the account is locked out, and the lockout settings of the policy"`
+	Expiry        PasswordExpiryStatus  `json:"expiry" jsonschema:"Whether and when the password expires, and the age settings of the policy"`
+	History       PasswordHistoryPolicy `json:"history" jsonschema:"The password history settings of the policy"`
+	Policy        PasswordPolicySummary `json:"policy" jsonschema:"The password policy that applies to the user"`
+}
+
+// GetUserPasswordStatusHandler gets the password state of a PingOne user and its policy using the provided client
+func GetUserPasswordStatusHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input GetUserPasswordStatusInput,
+) (
+	*mcp.CallToolResult,
+	*GetUserPasswordStatusOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetUserPasswordStatusInput) (*mcp.CallToolResult, *GetUserPasswordStatusOutput, error) {
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(GetUserPasswordStatusDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Getting user password status",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()))
+
+		user, httpResponse, err := client.GetUser(ctx, input.EnvironmentId, input.UserId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if user == nil {
+			apiErr := errs.NewApiError(httpResponse, errors.New("no user data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		state, httpResponse, err := client.GetUserPassword(ctx, input.EnvironmentId, input.UserId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if state == nil {
+			apiErr := errs.NewApiError(httpResponse, errors.New("no password data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		passwordPolicyId := ""
+		if state.PasswordPolicy != nil {
+			passwordPolicyId = state.PasswordPolicy.Id
+		}
+		populationId := ""
+		if user.Population != nil {
+			populationId = user.Population.Id
+		}
+		resolved, err := resolvePasswordPolicy(ctx, client, input.EnvironmentId, passwordPolicyId, populationId)
+		if err != nil {
+			errs.Log(ctx, err)
+			return nil, nil, err
+		}
+
+		return nil, userPasswordStatus(user, state, resolved, time.Now()), nil
+	}
+}
+
+// userPasswordStatus combines the account of the user, the state of their password and its policy
+func userPasswordStatus(user *management.User, state *UserPasswordState, resolved *resolvedPasswordPolicy, now time.Time) *GetUserPasswordStatusOutput {
+	policy := resolved.policy
+	output := &GetUserPasswordStatusOutput{
+		UserId:        user.GetId(),
+		Username:      user.Username,
+		Status:        state.Status,
+		LastChangedAt: state.LastChangedAt,
+		Expiry: PasswordExpiryStatus{
+			Expired:    state.Status != nil && *state.Status == passwordExpiredStatus,
+			MaxAgeDays: policy.MaxAgeDays,
+			MinAgeDays: policy.MinAgeDays,
+		},
+		Policy: resolved.summary(),
+	}
+
+	if user.Account != nil {
+		accountStatus := string(user.Account.Status)
+		output.Lockout.AccountStatus = &accountStatus
+		output.Lockout.Locked = user.Account.Status == management.ENUMUSERSTATUS_LOCKED
+		output.Lockout.LockedAt = user.Account.LockedAt
+		output.Lockout.UnlockAt = user.Account.UnlockAt
+		output.Lockout.SecondsUntilUnlock = user.Account.SecondsUntilUnlock
+	}
+	if policy.Lockout != nil {
+		output.Lockout.FailureCount = policy.Lockout.FailureCount
+		output.Lockout.DurationSeconds = policy.Lockout.DurationSeconds
+	}
+
+	if state.LastChangedAt != nil {
+		if policy.MaxAgeDays != nil && *policy.MaxAgeDays > 0 {
+			expiresAt := state.LastChangedAt.AddDate(0, 0, int(*policy.MaxAgeDays))
+			output.Expiry.ExpiresAt = &expiresAt
+			output.Expiry.Expired = output.Expiry.Expired || !now.Before(expiresAt)
+		}
+		if policy.MinAgeDays != nil && *policy.MinAgeDays > 0 {
+			earliestChangeAt := state.LastChangedAt.AddDate(0, 0, int(*policy.MinAgeDays))
+			output.Expiry.EarliestChangeAt = &earliestChangeAt
+		}
+	}
+
+	if policy.History != nil {
+		output.History.Count = policy.History.Count
+		output.History.RetentionDays = policy.History.RetentionDays
+	}
+
+	return output
+}
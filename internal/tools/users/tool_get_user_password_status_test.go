@@ -0,0 +1,196 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPasswordPolicyId        = "550e8400-e29b-41d4-a716-446655440300"
+	testDefaultPasswordPolicyId = "550e8400-e29b-41d4-a716-446655440301"
+)
+
+// testPasswordPolicy returns a policy that locks accounts, expires passwords and keeps a password history
+func testPasswordPolicy() *management.PasswordPolicy {
+	return &management.PasswordPolicy{
+		Id:          testutils.Pointer(testPasswordPolicyId),
+		Name:        "Standard",
+		Lockout:     &management.PasswordPolicyLockout{FailureCount: testutils.Pointer(int32(5)), DurationSeconds: testutils.Pointer(int32(900))},
+		MaxAgeDays:  testutils.Pointer(int32(182)),
+		MinAgeDays:  testutils.Pointer(int32(1)),
+		History:     &management.PasswordPolicyHistory{Count: testutils.Pointer(int32(6)), RetentionDays: testutils.Pointer(int32(365))},
+		Length:      &management.PasswordPolicyLength{Min: testutils.Pointer(int32(8)), Max: testutils.Pointer(int32(255))},
+		Description: testutils.Pointer("Standard password policy"),
+	}
+}
+
+func TestGetUserPasswordStatusHandler(t *testing.T) {
+	lockedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	unlockAt := lockedAt.Add(15 * time.Minute)
+	lastChangedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := foundUser(testUserId.String(), "alice", "alice@example.com")
+	user.Account = &management.UserAccount{Status: management.ENUMUSERSTATUS_LOCKED, LockedAt: &lockedAt, UnlockAt: &unlockAt}
+
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(&users.UserPasswordState{
+		Status:         testutils.Pointer("OK"),
+		LastChangedAt:  &lastChangedAt,
+		PasswordPolicy: &users.UserPasswordPolicyReference{Id: testPasswordPolicyId},
+	}, &http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetPasswordPolicy", mock.Anything, testEnvironmentId, testPasswordPolicyId).Return(testPasswordPolicy(), &http.Response{StatusCode: 200}, nil)
+
+	handler := users.GetUserPasswordStatusHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.GetUserPasswordStatusInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, "alice", output.Username)
+	assert.Equal(t, "OK", *output.Status)
+	assert.Equal(t, users.PasswordLockoutStatus{
+		Locked:          true,
+		AccountStatus:   testutils.Pointer("LOCKED"),
+		LockedAt:        &lockedAt,
+		UnlockAt:        &unlockAt,
+		FailureCount:    testutils.Pointer(int32(5)),
+		DurationSeconds: testutils.Pointer(int32(900)),
+	}, output.Lockout)
+	require.NotNil(t, output.Expiry.ExpiresAt)
+	assert.Equal(t, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), *output.Expiry.ExpiresAt)
+	assert.True(t, output.Expiry.Expired, "the password is older than the policy's maximum age")
+	assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), *output.Expiry.EarliestChangeAt)
+	assert.Equal(t, int32(6), *output.History.Count)
+	assert.Equal(t, users.PasswordPolicySummary{Id: testPasswordPolicyId, Name: "Standard", Source: users.PasswordPolicySourceUser}, output.Policy)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGetUserPasswordStatusHandler_PolicyFallback(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMock  func(mockClient *mockPingOneClientUsersWrapper)
+		wantPolicy users.PasswordPolicySummary
+	}{
+		{
+			name: "Population policy",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testPopulationId.String()).Return(&management.Population{
+					PasswordPolicy: &management.PopulationPasswordPolicy{Id: testPasswordPolicyId},
+				}, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetPasswordPolicy", mock.Anything, testEnvironmentId, testPasswordPolicyId).Return(testPasswordPolicy(), &http.Response{StatusCode: 200}, nil)
+			},
+			wantPolicy: users.PasswordPolicySummary{Id: testPasswordPolicyId, Name: "Standard", Source: users.PasswordPolicySourcePopulation},
+		},
+		{
+			name: "Environment default policy",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetPopulation", mock.Anything, testEnvironmentId, testPopulationId.String()).Return(&management.Population{}, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("FindDefaultPasswordPolicy", mock.Anything, testEnvironmentId).Return(&management.PasswordPolicy{
+					Id:   testutils.Pointer(testDefaultPasswordPolicyId),
+					Name: "Basic",
+				}, &http.Response{StatusCode: 200}, nil)
+			},
+			wantPolicy: users.PasswordPolicySummary{Id: testDefaultPasswordPolicyId, Name: "Basic", Source: users.PasswordPolicySourceEnvironmentDefault},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := foundUser(testUserId.String(), "alice", "alice@example.com")
+			mockClient := &mockPingOneClientUsersWrapper{}
+			mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+			mockClient.On("GetUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(&users.UserPasswordState{
+				Status: testutils.Pointer("NO_PASSWORD"),
+			}, &http.Response{StatusCode: 200}, nil)
+			tt.setupMock(mockClient)
+
+			handler := users.GetUserPasswordStatusHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.GetUserPasswordStatusInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, tt.wantPolicy, output.Policy)
+			assert.False(t, output.Lockout.Locked)
+			assert.False(t, output.Expiry.Expired)
+			assert.Nil(t, output.Expiry.ExpiresAt, "a password that was never set does not expire")
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetUserPasswordStatusHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get user error",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("user not found"))
+			},
+			wantErrContains: "user not found",
+		},
+		{
+			name: "Get password error",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				user := foundUser(testUserId.String(), "alice", "alice@example.com")
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+		{
+			name: "No password data in response",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				user := foundUser(testUserId.String(), "alice", "alice@example.com")
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no password data in response",
+		},
+		{
+			name: "No default policy",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				user := foundUser(testUserId.String(), "alice", "alice@example.com")
+				user.Population = nil
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&user, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("GetUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(&users.UserPasswordState{}, &http.Response{StatusCode: 200}, nil)
+				mockClient.On("FindDefaultPasswordPolicy", mock.Anything, testEnvironmentId).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "has no default password policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := users.GetUserPasswordStatusHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.GetUserPasswordStatusInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
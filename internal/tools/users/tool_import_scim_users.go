@@ -0,0 +1,283 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const maxImportScimUsers = 100
+
+const (
+	ImportScimUserActionCreated   = "created"
+	ImportScimUserActionUpdated   = "updated"
+	ImportScimUserActionValidated = "validated"
+	ImportScimUserActionFailed    = "failed"
+)
+
+var ImportScimUsersDef = types.ToolDefinition{
+	// Each call makes up to 200 requests, so parallel batches are limited to protect the tenant's rate limits
+	MaxConcurrentExecutions: 4,
+	McpTool: &mcp.Tool{
+		Name:  "import_scim_users",
+		Title: "Import SCIM Users into PingOne",
+		Description: `Import up to 100 users per call from a SCIM 2.0 payload exported from an HR system or identity provider, mapping SCIM attributes to PingOne user attributes. Use to migrate users into PingOne.
+
+Provide the export as JSON text in 'payload': a SCIM ListResponse ("Resources"), a SCIM BulkRequest ("Operations" with POST or PUT operations), a JSON array of User resources, or a single User resource.
+
+Attributes are mapped with a default mapping of SCIM core User attributes (userName to username, emails.value to email, name.givenName to name.given, active to enabled, phoneNumbers[type=work].value to primaryPhone, addresses.country to address.countryCode, and so on). Use 'mapping' to override it: keys are PingOne attributes, values are SCIM attribute paths, and an empty path removes a default mapping. Paths support sub-attributes (name.givenName), filters on multi-valued attributes (emails[type=work].value, addresses[primary].locality; without a filter the primary or first value is used), and schema extension URNs (urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber). Every user needs a mapped username and email.
+
+By default every user is created, in 'populationId' or the default population. Set 'updateExisting' to update users that already exist with the same username instead; the enabled state and population of existing users are not changed. Set 'dryRun' to check the mapping of every record without creating or updating users.
+
+Each record is imported independently; the output reports the action taken, mapping errors, or API error per record.`,
+		InputSchema:  schema.MustGenerateSchema[ImportScimUsersInput](),
+		OutputSchema: schema.MustGenerateSchema[ImportScimUsersOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+		},
+	},
+}
+
+type ImportScimUsersInput struct {
+	EnvironmentId   uuid.UUID         `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	Payload         string            `json:"payload" jsonschema:"REQUIRED. SCIM 2.0 JSON: a ListResponse, a BulkRequest, an array of User resources, or a single User resource."`
+	Mapping         map[string]string `json:"mapping,omitempty" jsonschema:"OPTIONAL. Overrides of the default mapping, from PingOne user attribute (such as 'email' or 'name.given') to SCIM attribute path. An empty path removes the default mapping of the attribute."`
+	PopulationId    *uuid.UUID        `json:"populationId,omitempty" jsonschema:"OPTIONAL. Population UUID for created users. Defaults to the environment's default population."`
+	UpdateExisting  bool              `json:"updateExisting,omitempty" jsonschema:"OPTIONAL. Update users that already exist with the same username instead of failing to create them. Defaults to false."`
+	DryRun          bool              `json:"dryRun,omitempty" jsonschema:"OPTIONAL. Only map the records and report mapping errors, without creating or updating users. Defaults to false."`
+	RunInBackground bool              `json:"runInBackground,omitempty" jsonschema:"OPTIONAL. Import the users in a background job and return its ID straight away, instead of waiting for the import to complete. Follow the job with get_job_status. Defaults to false."`
+}
+
+type ImportScimUserResult struct {
+	Index         int      `json:"index" jsonschema:"Zero-based position of the record in the payload"`
+	Username      string   `json:"username" jsonschema:"The mapped username of the record"`
+	Action        string   `json:"action" jsonschema:"The action taken: created, updated, validated (dry run), or failed"`
+	UserId        *string  `json:"userId,omitempty" jsonschema:"The ID of the created or updated user"`
+	MappingErrors []string `json:"mappingErrors,omitempty" jsonschema:"The attributes of the record that could not be mapped"`
+	Error         *string  `json:"error,omitempty" jsonschema:"The reason the record could not be imported"`
+}
+
+type ImportScimUsersOutput struct {
+	Job            *jobs.Job              `json:"job,omitempty" jsonschema:"The background job importing the users, if runInBackground was set. The results and counts are returned by get_job_status once the job has succeeded"`
+	Results        []ImportScimUserResult `json:"results" jsonschema:"Per-record results in payload order"`
+	CreatedCount   int                    `json:"createdCount" jsonschema:"The number of users created"`
+	UpdatedCount   int                    `json:"updatedCount" jsonschema:"The number of existing users updated"`
+	ValidatedCount int                    `json:"validatedCount" jsonschema:"The number of records mapped without errors in a dry run"`
+	FailedCount    int                    `json:"failedCount" jsonschema:"The number of records that could not be imported"`
+}
+
+// ImportScimUsersHandler imports the users of a SCIM payload into PingOne using the provided client, reporting
+// per-record results
+func ImportScimUsersHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ImportScimUsersInput,
+) (
+	*mcp.CallToolResult,
+	*ImportScimUsersOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ImportScimUsersInput) (*mcp.CallToolResult, *ImportScimUsersOutput, error) {
+		if strings.TrimSpace(input.Payload) == "" {
+			toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, errors.New("a SCIM payload must be provided in 'payload'"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		mapping, err := buildScimUserMapping(input.Mapping)
+		if err != nil {
+			toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		resources, recordErrs, err := parseScimPayload(input.Payload)
+		if err != nil {
+			toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(resources) == 0 {
+			toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, errors.New("the SCIM payload contains no users"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(resources) > maxImportScimUsers {
+			toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, fmt.Errorf("a maximum of %d users can be imported per call, got %d", maxImportScimUsers, len(resources)))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		var client UsersClient
+		if !input.DryRun {
+			client, err = usersClientFactory.GetAuthenticatedClient(ctx)
+			if err != nil {
+				toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+		}
+
+		logger.FromContext(ctx).Debug("Importing SCIM users",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.Int("count", len(resources)),
+			slog.Bool("dryRun", input.DryRun),
+		)
+
+		if input.RunInBackground {
+			job, err := jobs.Start(ctx, ImportScimUsersDef, input.EnvironmentId.String(), func(ctx context.Context) (any, error) {
+				return importScimUsers(ctx, client, input, mapping, resources, recordErrs)
+			})
+			if err != nil {
+				toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			return nil, &ImportScimUsersOutput{
+				Job:     &job,
+				Results: []ImportScimUserResult{},
+			}, nil
+		}
+
+		result, err := importScimUsers(ctx, client, input, mapping, resources, recordErrs)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
+
+// importScimUsers imports the mapped SCIM resources, reporting per-record results
+func importScimUsers(ctx context.Context, client UsersClient, input ImportScimUsersInput, mapping []scimAttributeMapping, resources []map[string]any, recordErrs map[int]error) (*ImportScimUsersOutput, error) {
+	result := &ImportScimUsersOutput{
+		Results: make([]ImportScimUserResult, 0, len(resources)),
+	}
+
+	for i, resource := range resources {
+		// Stop importing users if the request has been cancelled
+		if ctx.Err() != nil {
+			toolErr := errs.NewToolError(ImportScimUsersDef.McpTool.Name, ctx.Err())
+			errs.Log(ctx, toolErr)
+			return nil, toolErr
+		}
+
+		progress.Report(ctx, i, len(resources), "Importing users")
+
+		recordResult := ImportScimUserResult{
+			Index:  i,
+			Action: ImportScimUserActionFailed,
+		}
+
+		if recordErr := recordErrs[i]; recordErr != nil {
+			errMsg := recordErr.Error()
+			recordResult.Error = &errMsg
+		} else {
+			user, mappingErrs := mapScimUser(resource, mapping)
+			recordResult.Username = user.Username
+			recordResult.MappingErrors = mappingErrs
+
+			switch {
+			case len(mappingErrs) > 0:
+				// Records with mapping errors are not imported
+			case input.DryRun:
+				recordResult.Action = ImportScimUserActionValidated
+			default:
+				action, userId, err := importScimUser(ctx, client, input, user)
+				if err != nil {
+					errMsg := err.Error()
+					recordResult.Error = &errMsg
+				} else {
+					recordResult.Action = action
+					recordResult.UserId = userId
+				}
+			}
+		}
+
+		switch recordResult.Action {
+		case ImportScimUserActionCreated:
+			result.CreatedCount++
+		case ImportScimUserActionUpdated:
+			result.UpdatedCount++
+		case ImportScimUserActionValidated:
+			result.ValidatedCount++
+		default:
+			result.FailedCount++
+		}
+		result.Results = append(result.Results, recordResult)
+	}
+
+	logger.FromContext(ctx).Debug("SCIM user import completed",
+		slog.String("environmentId", input.EnvironmentId.String()),
+		slog.Int("createdCount", result.CreatedCount),
+		slog.Int("updatedCount", result.UpdatedCount),
+		slog.Int("validatedCount", result.ValidatedCount),
+		slog.Int("failedCount", result.FailedCount),
+	)
+
+	return result, nil
+}
+
+// importScimUser creates the user, or updates the existing user with the same username when requested
+func importScimUser(ctx context.Context, client UsersClient, input ImportScimUsersInput, user management.User) (string, *string, error) {
+	if input.UpdateExisting {
+		existingUser, httpResponse, err := client.FindUserByUsername(ctx, input.EnvironmentId, user.Username)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return "", nil, apiErr
+		}
+
+		if existingUser != nil && existingUser.Id != nil {
+			// The enabled state and population of existing users are changed through their own endpoints
+			user.Enabled = nil
+			userResponse, httpResponse, err := client.UpdateUser(ctx, input.EnvironmentId, *existingUser.Id, user)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return "", nil, apiErr
+			}
+			if userResponse == nil {
+				apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+				errs.Log(ctx, apiErr)
+				return "", nil, apiErr
+			}
+			return ImportScimUserActionUpdated, userResponse.Id, nil
+		}
+	}
+
+	if input.PopulationId != nil {
+		user.Population = &management.UserPopulation{
+			Id: input.PopulationId.String(),
+		}
+	}
+
+	userResponse, httpResponse, err := client.CreateUser(ctx, input.EnvironmentId, user)
+	logger.LogHttpResponse(ctx, httpResponse)
+	if err != nil {
+		apiErr := errs.NewApiError(httpResponse, err)
+		errs.Log(ctx, apiErr)
+		return "", nil, apiErr
+	}
+	if userResponse == nil {
+		apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+		errs.Log(ctx, apiErr)
+		return "", nil, apiErr
+	}
+	return ImportScimUserActionCreated, userResponse.Id, nil
+}
@@ -0,0 +1,456 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	mcptestutils "github.com/pingidentity/pingone-mcp-server/internal/testutils/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testScimUserAlice = `{
+	"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User", "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"],
+	"externalId": "hr-1001",
+	"userName": "alice",
+	"name": {"givenName": "Alice", "familyName": "Smith"},
+	"emails": [
+		{"value": "alice@personal.example.com", "type": "home"},
+		{"value": "alice@example.com", "type": "work", "primary": true}
+	],
+	"phoneNumbers": [
+		{"value": "+1 555 0100", "type": "work"},
+		{"value": "+1 555 0101", "type": "mobile"}
+	],
+	"addresses": [{"locality": "Denver", "region": "CO", "country": "US", "primary": true}],
+	"active": false,
+	"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User": {"employeeNumber": 1001, "department": "Sales"}
+}`
+
+const testScimUserBob = `{
+	"userName": "bob",
+	"emails": [{"value": "bob@example.com"}]
+}`
+
+func scimListResponse(resources ...string) string {
+	return fmt.Sprintf(`{"schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"], "totalResults": %d, "Resources": [%s]}`,
+		len(resources), strings.Join(resources, ","))
+}
+
+// matchesAlice returns true if the request holds the attributes mapped from testScimUserAlice with the default mapping
+func matchesAlice(req management.User) bool {
+	return req.Username == "alice" &&
+		req.Email == "alice@example.com" &&
+		req.Name != nil && equalStringPointers(req.Name.Given, testutils.Pointer("Alice")) && equalStringPointers(req.Name.Family, testutils.Pointer("Smith")) &&
+		equalStringPointers(req.ExternalId, testutils.Pointer("hr-1001")) &&
+		equalStringPointers(req.PrimaryPhone, testutils.Pointer("+1 555 0100")) &&
+		equalStringPointers(req.MobilePhone, testutils.Pointer("+1 555 0101")) &&
+		req.Address != nil && equalStringPointers(req.Address.Locality, testutils.Pointer("Denver")) && equalStringPointers(req.Address.CountryCode, testutils.Pointer("US"))
+}
+
+func TestImportScimUsersHandler_MockClient(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           users.ImportScimUsersInput
+		setupMock       func(*mockPingOneClientUsersWrapper)
+		wantResults     []users.ImportScimUserResult
+		wantErr         bool
+		wantErrContains string
+	}{
+		{
+			name: "Success - Create users from a ListResponse with the default mapping",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       scimListResponse(testScimUserAlice, testScimUserBob),
+				PopulationId:  &testPopulationId,
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return matchesAlice(req) && req.Enabled != nil && !*req.Enabled &&
+						req.Population != nil && req.Population.Id == testPopulationId.String()
+				})).Return(&management.User{Id: testutils.Pointer("user-0")}, &http.Response{StatusCode: 201}, nil).Once()
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return req.Username == "bob" && req.Email == "bob@example.com" && req.Name == nil && req.Enabled == nil
+				})).Return(&management.User{Id: testutils.Pointer("user-1")}, &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.ImportScimUserResult{
+				{Index: 0, Username: "alice", Action: users.ImportScimUserActionCreated, UserId: testutils.Pointer("user-0")},
+				{Index: 1, Username: "bob", Action: users.ImportScimUserActionCreated, UserId: testutils.Pointer("user-1")},
+			},
+		},
+		{
+			name: "Success - Update existing users and create new users",
+			input: users.ImportScimUsersInput{
+				EnvironmentId:  testEnvironmentId,
+				Payload:        "[" + testScimUserAlice + "," + testScimUserBob + "]",
+				PopulationId:   &testPopulationId,
+				UpdateExisting: true,
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("FindUserByUsername", mock.Anything, testEnvironmentId, "alice").
+					Return(&management.User{Id: testutils.Pointer("existing-alice")}, &http.Response{StatusCode: 200}, nil).Once()
+				m.On("UpdateUser", mock.Anything, testEnvironmentId, "existing-alice", mock.MatchedBy(func(req management.User) bool {
+					return matchesAlice(req) && req.Enabled == nil && req.Population == nil
+				})).Return(&management.User{Id: testutils.Pointer("existing-alice")}, &http.Response{StatusCode: 200}, nil).Once()
+				m.On("FindUserByUsername", mock.Anything, testEnvironmentId, "bob").
+					Return(nil, &http.Response{StatusCode: 200}, nil).Once()
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return req.Username == "bob" && req.Population != nil
+				})).Return(&management.User{Id: testutils.Pointer("user-1")}, &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.ImportScimUserResult{
+				{Index: 0, Username: "alice", Action: users.ImportScimUserActionUpdated, UserId: testutils.Pointer("existing-alice")},
+				{Index: 1, Username: "bob", Action: users.ImportScimUserActionCreated, UserId: testutils.Pointer("user-1")},
+			},
+		},
+		{
+			name: "Success - Mapping overrides use extension attributes and remove defaults",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       testScimUserAlice,
+				Mapping: map[string]string{
+					"externalId":   "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:employeeNumber",
+					"type":         "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department",
+					"email":        "emails[type=home].value",
+					"primaryPhone": "",
+				},
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return req.Username == "alice" &&
+						req.Email == "alice@personal.example.com" &&
+						equalStringPointers(req.ExternalId, testutils.Pointer("1001")) &&
+						equalStringPointers(req.Type, testutils.Pointer("Sales")) &&
+						req.PrimaryPhone == nil
+				})).Return(&management.User{Id: testutils.Pointer("user-0")}, &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.ImportScimUserResult{
+				{Index: 0, Username: "alice", Action: users.ImportScimUserActionCreated, UserId: testutils.Pointer("user-0")},
+			},
+		},
+		{
+			name: "Success - Dry run maps records without calling the API",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       scimListResponse(testScimUserAlice, `{"userName": "no-email"}`),
+				DryRun:        true,
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {},
+			wantResults: []users.ImportScimUserResult{
+				{Index: 0, Username: "alice", Action: users.ImportScimUserActionValidated},
+				{Index: 1, Username: "no-email", Action: users.ImportScimUserActionFailed, MappingErrors: []string{"email is required"}},
+			},
+		},
+		{
+			name: "Partial success - Mapping errors and unsupported operations are reported per record",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload: `{"schemas": ["urn:ietf:params:scim:api:messages:2.0:BulkRequest"], "Operations": [
+					{"method": "POST", "path": "/Users", "data": ` + testScimUserBob + `},
+					{"method": "DELETE", "path": "/Users/123"},
+					{"method": "POST", "path": "/Users", "data": {"userName": "carol", "emails": [{"value": "carol@example.com"}], "active": "sometimes", "title": {"code": "VP"}}}
+				]}`,
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return req.Username == "bob"
+				})).Return(&management.User{Id: testutils.Pointer("user-0")}, &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.ImportScimUserResult{
+				{Index: 0, Username: "bob", Action: users.ImportScimUserActionCreated, UserId: testutils.Pointer("user-0")},
+				{Index: 1, Action: users.ImportScimUserActionFailed, Error: testutils.Pointer("unsupported SCIM bulk operation method DELETE")},
+				{Index: 2, Username: "carol", Action: users.ImportScimUserActionFailed, MappingErrors: []string{
+					"enabled (from active): expected a boolean value",
+					"title (from title): expected a string value, got an object",
+				}},
+			},
+		},
+		{
+			name: "Partial success - API error on one record does not stop the others",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       scimListResponse(testScimUserAlice, testScimUserBob),
+			},
+			setupMock: func(m *mockPingOneClientUsersWrapper) {
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(matchesAlice)).
+					Return(nil, &http.Response{StatusCode: 400}, errors.New("username already exists")).Once()
+				m.On("CreateUser", mock.Anything, testEnvironmentId, mock.MatchedBy(func(req management.User) bool {
+					return req.Username == "bob"
+				})).Return(&management.User{Id: testutils.Pointer("user-1")}, &http.Response{StatusCode: 201}, nil).Once()
+			},
+			wantResults: []users.ImportScimUserResult{
+				{Index: 0, Username: "alice", Action: users.ImportScimUserActionFailed, Error: testutils.Pointer("username already exists")},
+				{Index: 1, Username: "bob", Action: users.ImportScimUserActionCreated, UserId: testutils.Pointer("user-1")},
+			},
+		},
+		{
+			name: "Error - Empty payload",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "a SCIM payload must be provided",
+		},
+		{
+			name: "Error - Payload is not JSON",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       "userName,email\nalice,alice@example.com",
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "failed to parse SCIM payload as JSON",
+		},
+		{
+			name: "Error - ListResponse without users",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       scimListResponse(),
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "the SCIM payload contains no users",
+		},
+		{
+			name: "Error - Unsupported mapping target",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       testScimUserBob,
+				Mapping:       map[string]string{"password": "password"},
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "unsupported mapping target \"password\"",
+		},
+		{
+			name: "Error - Invalid mapping path",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       testScimUserBob,
+				Mapping:       map[string]string{"email": "emails[type=work.value"},
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "invalid SCIM attribute path",
+		},
+		{
+			name: "Error - Too many users",
+			input: users.ImportScimUsersInput{
+				EnvironmentId: testEnvironmentId,
+				Payload:       "[" + strings.TrimSuffix(strings.Repeat(testScimUserBob+",", 101), ",") + "]",
+			},
+			setupMock:       func(m *mockPingOneClientUsersWrapper) {},
+			wantErr:         true,
+			wantErrContains: "a maximum of 100 users can be imported per call, got 101",
+		},
+	}
+
+	for _, tc := range tests {
+		// Test calling the handler directly
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tc.setupMock(mockClient)
+
+			req := &mcp.CallToolRequest{}
+			handler := users.ImportScimUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+
+			mcpResult, structuredResponse, err := handler(context.Background(), req, tc.input)
+
+			if tc.wantErr {
+				require.Error(t, err)
+				if tc.wantErrContains != "" {
+					assert.Contains(t, err.Error(), tc.wantErrContains)
+				}
+				assert.Nil(t, mcpResult)
+				assert.Nil(t, structuredResponse)
+				mockClient.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Nil(t, mcpResult)
+			assertImportScimUsersOutput(t, tc.wantResults, structuredResponse)
+
+			mockClient.AssertExpectations(t)
+		})
+
+		// Test via call over MCP
+		t.Run(tc.name+" via MCP", func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tc.setupMock(mockClient)
+
+			handler := users.ImportScimUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+
+			server := mcptestutils.TestMcpServer(t)
+			mcp.AddTool(server, users.ImportScimUsersDef.McpTool, handler)
+
+			// Execute over MCP
+			output, err := mcptestutils.CallToolOverMcp(t, server, users.ImportScimUsersDef.McpTool.Name, tc.input)
+			require.NoError(t, err, "Expect no error calling tool")
+			require.NotNil(t, output, "Expect non-nil output")
+
+			if tc.wantErr {
+				testutils.AssertMcpCallError(t, output, tc.wantErrContains)
+				mockClient.AssertExpectations(t)
+				return
+			}
+
+			// Assert success expectations
+			testutils.AssertMcpCallSuccess(t, err, output)
+
+			// marshal the structured content into the expected output type
+			outputUsers := &users.ImportScimUsersOutput{}
+			jsonBytes, err := json.Marshal(output.StructuredContent)
+			require.NoError(t, err, "Failed to marshal structured content")
+			err = json.Unmarshal(jsonBytes, outputUsers)
+			require.NoError(t, err, "Failed to unmarshal structured content")
+
+			assertImportScimUsersOutput(t, tc.wantResults, outputUsers)
+
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportScimUsersHandler_UpdateExistingLookupError(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("FindUserByUsername", mock.Anything, testEnvironmentId, "bob").
+		Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden")).Once()
+
+	handler := users.ImportScimUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	input := users.ImportScimUsersInput{
+		EnvironmentId:  testEnvironmentId,
+		Payload:        testScimUserBob,
+		UpdateExisting: true,
+	}
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	require.NoError(t, err)
+	assert.Nil(t, mcpResult)
+	assertImportScimUsersOutput(t, []users.ImportScimUserResult{
+		{Index: 0, Username: "bob", Action: users.ImportScimUserActionFailed, Error: testutils.Pointer("forbidden")},
+	}, output)
+	mockClient.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestImportScimUsersHandler_ContextCancellation(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel() // Cancel the context immediately
+
+	handler := users.ImportScimUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	input := users.ImportScimUsersInput{
+		EnvironmentId: testEnvironmentId,
+		Payload:       scimListResponse(testScimUserAlice, testScimUserBob),
+	}
+
+	mcpResult, structuredResponse, err := handler(ctx, &mcp.CallToolRequest{}, input)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "context canceled")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, structuredResponse)
+	mockClient.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportScimUsersHandler_RunInBackground(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("CreateUser", mock.Anything, testEnvironmentId, mock.Anything).
+		Return(&management.User{Id: testutils.Pointer("user-0")}, &http.Response{StatusCode: 201}, nil).Once()
+	manager := jobs.NewManager(0, 0)
+
+	handler := users.ImportScimUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	input := users.ImportScimUsersInput{
+		EnvironmentId:   testEnvironmentId,
+		Payload:         testScimUserBob,
+		RunInBackground: true,
+	}
+
+	mcpResult, output, err := handler(jobs.ContextWithManager(context.Background(), manager), &mcp.CallToolRequest{}, input)
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.Job)
+	assert.Equal(t, users.ImportScimUsersDef.McpTool.Name, output.Job.Tool)
+	assert.Empty(t, output.Results, "the results are returned by the job")
+
+	job, err := manager.Wait(t.Context(), output.Job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status)
+	assert.Equal(t, &progress.Progress{Completed: 0, Total: 1, Message: "Importing users"}, job.Progress)
+	assertImportScimUsersOutput(t, []users.ImportScimUserResult{
+		{Index: 0, Username: "bob", Action: users.ImportScimUserActionCreated, UserId: testutils.Pointer("user-0")},
+	}, job.Result.(*users.ImportScimUsersOutput))
+	mockClient.AssertExpectations(t)
+}
+
+func TestImportScimUsersHandler_GetAuthenticatedClientError(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	clientFactoryErr := errors.New("failed to get authenticated client")
+	handler := users.ImportScimUsersHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, clientFactoryErr))
+	input := users.ImportScimUsersInput{
+		EnvironmentId: testEnvironmentId,
+		Payload:       testScimUserBob,
+	}
+
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get authenticated client")
+	assert.Nil(t, mcpResult)
+	assert.Nil(t, output)
+}
+
+// assertImportScimUsersOutput verifies the per-record results and summary counts of an ImportScimUsersOutput
+func assertImportScimUsersOutput(t *testing.T, expected []users.ImportScimUserResult, output *users.ImportScimUsersOutput) {
+	t.Helper()
+
+	require.NotNil(t, output, "Output should not be nil")
+	require.Len(t, output.Results, len(expected), "Result count should match record count")
+
+	counts := map[string]int{}
+	for i, expectedResult := range expected {
+		actual := output.Results[i]
+		counts[expectedResult.Action]++
+		assert.Equal(t, expectedResult.Index, actual.Index, "Result %d index should match", i)
+		assert.Equal(t, expectedResult.Username, actual.Username, "Result %d username should match", i)
+		assert.Equal(t, expectedResult.Action, actual.Action, "Result %d action should match", i)
+		if expectedResult.UserId != nil {
+			require.NotNil(t, actual.UserId, "Result %d should have a user ID", i)
+			assert.Equal(t, *expectedResult.UserId, *actual.UserId, "Result %d user ID should match", i)
+		} else {
+			assert.Nil(t, actual.UserId, "Result %d should not have a user ID", i)
+		}
+		if expectedResult.Error != nil {
+			require.NotNil(t, actual.Error, "Result %d should have an error", i)
+			assert.Contains(t, *actual.Error, *expectedResult.Error, "Result %d error should match", i)
+		} else {
+			assert.Nil(t, actual.Error, "Result %d should not have an error", i)
+		}
+		require.Len(t, actual.MappingErrors, len(expectedResult.MappingErrors), "Result %d mapping error count should match", i)
+		for j, mappingErr := range expectedResult.MappingErrors {
+			assert.Contains(t, actual.MappingErrors[j], mappingErr, "Result %d mapping error %d should match", i, j)
+		}
+	}
+
+	assert.Equal(t, counts[users.ImportScimUserActionCreated], output.CreatedCount, "Created count should match")
+	assert.Equal(t, counts[users.ImportScimUserActionUpdated], output.UpdatedCount, "Updated count should match")
+	assert.Equal(t, counts[users.ImportScimUserActionValidated], output.ValidatedCount, "Validated count should match")
+	assert.Equal(t, counts[users.ImportScimUserActionFailed], output.FailedCount, "Failed count should match")
+}
@@ -0,0 +1,253 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/progress"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+const maxMoveUsers = 100
+
+var MoveUsersBetweenPopulationsDef = types.ToolDefinition{
+	// Each call makes up to 100 update requests, so parallel batches are limited to protect the tenant's rate limits
+	MaxConcurrentExecutions: 4,
+	McpTool: &mcp.Tool{
+		Name:  "move_users_between_populations",
+		Title: "Move PingOne Users Between Populations",
+		Description: `Move multiple users in an environment to another population, matched by a SCIM filter or listed by ID, up to 100 per call. Use to re-home users, such as moving everyone in one population to another with the filter 'population.id eq "<source population UUID>"'.
+
+A filter matching more than 100 users moves the first 100; 'moreMatching' is true when users remain, and the tool can be called again to move them. Users already in the target population are left unchanged. Each user is moved independently and the output reports the result per user, with the population they were moved from.
+
+Set 'runInBackground' to move the users in a background job followed with get_job_status. The moved users can be moved back to their previous populations with undo_last_change.`,
+		InputSchema:  schema.MustGenerateSchema[MoveUsersBetweenPopulationsInput](),
+		OutputSchema: schema.MustGenerateSchema[MoveUsersBetweenPopulationsOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type MoveUsersBetweenPopulationsInput struct {
+	EnvironmentId      uuid.UUID   `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	TargetPopulationId uuid.UUID   `json:"targetPopulationId" jsonschema:"REQUIRED. UUID of the population to move the users to."`
+	Filter             *string     `json:"filter,omitempty" jsonschema:"OPTIONAL. SCIM filter matching the users to move, such as 'population.id eq \"...\"'. Required if 'userIds' is not provided."`
+	UserIds            []uuid.UUID `json:"userIds,omitempty" jsonschema:"OPTIONAL. UUIDs of the users to move, up to 100. Required if 'filter' is not provided."`
+	RunInBackground    bool        `json:"runInBackground,omitempty" jsonschema:"OPTIONAL. Move the users in a background job and return its ID straight away, instead of waiting for the move to complete. Follow the job with get_job_status. Defaults to false."`
+}
+
+type MoveUserResult struct {
+	UserId               string  `json:"userId" jsonschema:"The ID of the user"`
+	Username             string  `json:"username" jsonschema:"The username of the user"`
+	PreviousPopulationId *string `json:"previousPopulationId,omitempty" jsonschema:"The UUID of the population the user was in before"`
+	Moved                bool    `json:"moved" jsonschema:"Whether the user was moved. False if the user was already in the target population or could not be moved"`
+	Error                *string `json:"error,omitempty" jsonschema:"The reason the user could not be moved"`
+}
+
+type MoveUsersBetweenPopulationsOutput struct {
+	Job            *jobs.Job        `json:"job,omitempty" jsonschema:"The background job moving the users, if runInBackground was set. The results and counts are returned by get_job_status once the job has succeeded"`
+	Results        []MoveUserResult `json:"results" jsonschema:"Per-user results"`
+	MoreMatching   bool             `json:"moreMatching" jsonschema:"Whether the filter matches more users than are moved per call"`
+	MovedCount     int              `json:"movedCount" jsonschema:"The number of users moved"`
+	UnchangedCount int              `json:"unchangedCount" jsonschema:"The number of users already in the target population"`
+	FailureCount   int              `json:"failureCount" jsonschema:"The number of users that could not be moved"`
+}
+
+// MoveUsersBetweenPopulationsHandler moves multiple PingOne users to a population using the provided client,
+// reporting per-user results
+func MoveUsersBetweenPopulationsHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input MoveUsersBetweenPopulationsInput,
+) (
+	*mcp.CallToolResult,
+	*MoveUsersBetweenPopulationsOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input MoveUsersBetweenPopulationsInput) (*mcp.CallToolResult, *MoveUsersBetweenPopulationsOutput, error) {
+		hasFilter := input.Filter != nil && strings.TrimSpace(*input.Filter) != ""
+		if hasFilter == (len(input.UserIds) > 0) {
+			toolErr := errs.NewToolError(MoveUsersBetweenPopulationsDef.McpTool.Name, errors.New("exactly one of 'filter' or 'userIds' must be provided"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if len(input.UserIds) > maxMoveUsers {
+			toolErr := errs.NewToolError(MoveUsersBetweenPopulationsDef.McpTool.Name, fmt.Errorf("a maximum of %d users can be moved per call, got %d", maxMoveUsers, len(input.UserIds)))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(MoveUsersBetweenPopulationsDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Moving users between populations",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("targetPopulationId", input.TargetPopulationId.String()),
+		)
+
+		if input.RunInBackground {
+			job, err := jobs.Start(ctx, MoveUsersBetweenPopulationsDef, input.EnvironmentId.String(), func(ctx context.Context) (any, error) {
+				return moveUsersBetweenPopulations(ctx, client, usersClientFactory, input, hasFilter)
+			})
+			if err != nil {
+				toolErr := errs.NewToolError(MoveUsersBetweenPopulationsDef.McpTool.Name, err)
+				errs.Log(ctx, toolErr)
+				return nil, nil, toolErr
+			}
+			return nil, &MoveUsersBetweenPopulationsOutput{
+				Job:     &job,
+				Results: []MoveUserResult{},
+			}, nil
+		}
+
+		result, err := moveUsersBetweenPopulations(ctx, client, usersClientFactory, input, hasFilter)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, result, nil
+	}
+}
+
+// moveUsersBetweenPopulations moves the users of the filter or IDs to the target population, reporting per-user
+// results, and records the moves so that they can be undone together
+func moveUsersBetweenPopulations(ctx context.Context, client UsersClient, usersClientFactory UsersClientFactory, input MoveUsersBetweenPopulationsInput, hasFilter bool) (*MoveUsersBetweenPopulationsOutput, error) {
+	var users []UserSummary
+	var moreMatching bool
+	var err error
+	if hasFilter {
+		users, moreMatching, err = usersMatchingFilter(ctx, MoveUsersBetweenPopulationsDef.McpTool.Name, client, input.EnvironmentId, strings.TrimSpace(*input.Filter), maxMoveUsers)
+	} else {
+		users, err = usersWithIds(ctx, client, input.EnvironmentId, input.UserIds)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	targetPopulationId := input.TargetPopulationId.String()
+	result := &MoveUsersBetweenPopulationsOutput{
+		Results:      make([]MoveUserResult, 0, len(users)),
+		MoreMatching: moreMatching,
+	}
+	// previousPopulations are the populations the moved users were in, by user ID
+	previousPopulations := map[string]string{}
+	for i, user := range users {
+		// Stop moving users if the request has been cancelled, keeping the moves made so far undoable
+		if ctx.Err() != nil {
+			recordMoveUsers(ctx, usersClientFactory, input, previousPopulations)
+			toolErr := errs.NewToolError(MoveUsersBetweenPopulationsDef.McpTool.Name, ctx.Err())
+			errs.Log(ctx, toolErr)
+			return nil, toolErr
+		}
+		progress.Report(ctx, i, len(users), "Moving users")
+		if user.Id == nil {
+			continue
+		}
+
+		userResult := MoveUserResult{
+			UserId:               *user.Id,
+			Username:             user.Username,
+			PreviousPopulationId: user.PopulationId,
+		}
+		if user.PopulationId != nil && *user.PopulationId == targetPopulationId {
+			result.UnchangedCount++
+			result.Results = append(result.Results, userResult)
+			continue
+		}
+
+		_, httpResponse, err := client.SetUserPopulation(ctx, input.EnvironmentId, *user.Id, targetPopulationId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			errMsg := apiErr.Error()
+			userResult.Error = &errMsg
+			result.FailureCount++
+		} else {
+			userResult.Moved = true
+			result.MovedCount++
+			if user.PopulationId != nil {
+				previousPopulations[*user.Id] = *user.PopulationId
+			}
+		}
+		result.Results = append(result.Results, userResult)
+	}
+	recordMoveUsers(ctx, usersClientFactory, input, previousPopulations)
+
+	logger.FromContext(ctx).Debug("Moving users between populations completed",
+		slog.String("environmentId", input.EnvironmentId.String()),
+		slog.Int("movedCount", result.MovedCount),
+		slog.Int("unchangedCount", result.UnchangedCount),
+		slog.Int("failureCount", result.FailureCount),
+	)
+
+	return result, nil
+}
+
+// recordMoveUsers records the moves of the users, if any were moved, so that they can be undone together
+func recordMoveUsers(ctx context.Context, usersClientFactory UsersClientFactory, input MoveUsersBetweenPopulationsInput, previousPopulations map[string]string) {
+	if len(previousPopulations) == 0 {
+		return
+	}
+	rollback.Record(ctx, rollback.Change{
+		Tool:          MoveUsersBetweenPopulationsDef.McpTool.Name,
+		EnvironmentId: input.EnvironmentId.String(),
+		ResourceType:  "userPopulation",
+		ResourceId:    input.TargetPopulationId.String(),
+		Description:   fmt.Sprintf("Move %d users from population %s back to their previous populations", len(previousPopulations), input.TargetPopulationId),
+	}, undoMoveUsers(usersClientFactory, input.EnvironmentId, input.TargetPopulationId.String(), previousPopulations))
+}
+
+// undoMoveUsers returns the function that moves the users back to their previous populations, unless any of
+// them has been moved out of the target population since
+func undoMoveUsers(usersClientFactory UsersClientFactory, environmentId uuid.UUID, targetPopulationId string, previousPopulations map[string]string) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			for userId := range previousPopulations {
+				current, httpResponse, err := client.GetUser(ctx, environmentId, userId)
+				logger.LogHttpResponse(ctx, httpResponse)
+				if err != nil {
+					return errs.NewApiError(httpResponse, err)
+				}
+				if current == nil {
+					return errs.NewApiError(httpResponse, fmt.Errorf("no user data in response for user %s", userId))
+				}
+				if current.Population == nil || current.Population.Id != targetPopulationId {
+					return rollback.ErrChangedSince
+				}
+			}
+		}
+
+		var moveErrs []error
+		for userId, populationId := range previousPopulations {
+			_, httpResponse, err := client.SetUserPopulation(ctx, environmentId, userId, populationId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				moveErrs = append(moveErrs, fmt.Errorf("user %s: %w", userId, errs.NewApiError(httpResponse, err)))
+			}
+		}
+		return errors.Join(moveErrs...)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/jobs"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testTargetPopulationId = uuid.MustParse("550e8400-e29b-41d4-a716-446655440110")
+	testBobUserId          = uuid.MustParse("550e8400-e29b-41d4-a716-446655440201")
+	testCarolUserId        = uuid.MustParse("550e8400-e29b-41d4-a716-446655440202")
+)
+
+const testMoveFilter = `population.id eq "550e8400-e29b-41d4-a716-446655440100"`
+
+// usersToMove returns alice and bob in the test population, and carol already in the target population
+func usersToMove() []management.User {
+	alice := foundUser(testUserId.String(), "alice", "alice@example.com")
+	bob := foundUser(testBobUserId.String(), "bob", "bob@example.com")
+	carol := foundUser(testCarolUserId.String(), "carol", "carol@example.com")
+	carol.Population = &management.UserPopulation{Id: testTargetPopulationId.String()}
+	return []management.User{alice, bob, carol}
+}
+
+func usersPages(users ...management.User) management.EntityArrayPagedIterator {
+	return testutils.MockLegacySdkPaginationIterator([]testutils.LegacySdkMockPage{
+		{
+			EntityArray:  &management.EntityArray{Embedded: &management.EntityArrayEmbedded{Users: users}},
+			HTTPResponse: &http.Response{StatusCode: 200},
+		},
+	})
+}
+
+func TestMoveUsersBetweenPopulationsHandler(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testMoveFilter).Return(usersPages(usersToMove()...), nil)
+	mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, testUserId.String(), testTargetPopulationId.String()).Return(&management.UserPopulation{Id: testTargetPopulationId.String()}, &http.Response{StatusCode: 200}, nil)
+	mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, testBobUserId.String(), testTargetPopulationId.String()).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+
+	handler := users.MoveUsersBetweenPopulationsHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.MoveUsersBetweenPopulationsInput{
+		EnvironmentId:      testEnvironmentId,
+		TargetPopulationId: testTargetPopulationId,
+		Filter:             testutils.Pointer(testMoveFilter),
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 1, output.MovedCount)
+	assert.Equal(t, 1, output.UnchangedCount)
+	assert.Equal(t, 1, output.FailureCount)
+	assert.False(t, output.MoreMatching)
+	require.Len(t, output.Results, 3)
+	assert.Equal(t, users.MoveUserResult{
+		UserId:               testUserId.String(),
+		Username:             "alice",
+		PreviousPopulationId: testutils.Pointer(testPopulationId.String()),
+		Moved:                true,
+	}, output.Results[0])
+	assert.False(t, output.Results[1].Moved)
+	require.NotNil(t, output.Results[1].Error)
+	assert.Contains(t, *output.Results[1].Error, "forbidden")
+	assert.False(t, output.Results[2].Moved)
+	assert.Nil(t, output.Results[2].Error, "users already in the target population are unchanged")
+	mockClient.AssertExpectations(t)
+}
+
+func TestMoveUsersBetweenPopulationsHandler_UserIds(t *testing.T) {
+	alice := foundUser(testUserId.String(), "alice", "alice@example.com")
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&alice, &http.Response{StatusCode: 200}, nil)
+	mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, testUserId.String(), testTargetPopulationId.String()).Return(&management.UserPopulation{Id: testTargetPopulationId.String()}, &http.Response{StatusCode: 200}, nil)
+
+	handler := users.MoveUsersBetweenPopulationsHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.MoveUsersBetweenPopulationsInput{
+		EnvironmentId:      testEnvironmentId,
+		TargetPopulationId: testTargetPopulationId,
+		UserIds:            []uuid.UUID{testUserId},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.Equal(t, 1, output.MovedCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMoveUsersBetweenPopulationsHandler_MoreMatching(t *testing.T) {
+	matching := make([]management.User, 101)
+	for i := range matching {
+		matching[i] = foundUser(uuid.NewString(), "user", "user@example.com")
+	}
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testMoveFilter).Return(usersPages(matching...), nil)
+	mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, mock.Anything, testTargetPopulationId.String()).Return(&management.UserPopulation{Id: testTargetPopulationId.String()}, &http.Response{StatusCode: 200}, nil)
+
+	handler := users.MoveUsersBetweenPopulationsHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.MoveUsersBetweenPopulationsInput{
+		EnvironmentId:      testEnvironmentId,
+		TargetPopulationId: testTargetPopulationId,
+		Filter:             testutils.Pointer(testMoveFilter),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, output.MoreMatching)
+	assert.Equal(t, 100, output.MovedCount)
+	mockClient.AssertNumberOfCalls(t, "SetUserPopulation", 100)
+}
+
+func TestMoveUsersBetweenPopulationsHandler_RunInBackground(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testMoveFilter).Return(usersPages(usersToMove()[0]), nil)
+	mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, testUserId.String(), testTargetPopulationId.String()).Return(&management.UserPopulation{Id: testTargetPopulationId.String()}, &http.Response{StatusCode: 200}, nil)
+	manager := jobs.NewManager(0, 0)
+
+	handler := users.MoveUsersBetweenPopulationsHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(jobs.ContextWithManager(context.Background(), manager), &mcp.CallToolRequest{}, users.MoveUsersBetweenPopulationsInput{
+		EnvironmentId:      testEnvironmentId,
+		TargetPopulationId: testTargetPopulationId,
+		Filter:             testutils.Pointer(testMoveFilter),
+		RunInBackground:    true,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	require.NotNil(t, output.Job)
+	assert.Equal(t, users.MoveUsersBetweenPopulationsDef.McpTool.Name, output.Job.Tool)
+	assert.Empty(t, output.Results, "the results are returned by the job")
+
+	job, err := manager.Wait(t.Context(), output.Job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status)
+	assert.Equal(t, 1, job.Result.(*users.MoveUsersBetweenPopulationsOutput).MovedCount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestMoveUsersBetweenPopulationsHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           users.MoveUsersBetweenPopulationsInput
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Neither filter nor IDs",
+			input:           users.MoveUsersBetweenPopulationsInput{EnvironmentId: testEnvironmentId, TargetPopulationId: testTargetPopulationId},
+			setupMock:       func(mockClient *mockPingOneClientUsersWrapper) {},
+			wantErrContains: "exactly one of 'filter' or 'userIds'",
+		},
+		{
+			name:            "Filter and IDs",
+			input:           users.MoveUsersBetweenPopulationsInput{EnvironmentId: testEnvironmentId, TargetPopulationId: testTargetPopulationId, Filter: testutils.Pointer(testMoveFilter), UserIds: []uuid.UUID{testUserId}},
+			setupMock:       func(mockClient *mockPingOneClientUsersWrapper) {},
+			wantErrContains: "exactly one of 'filter' or 'userIds'",
+		},
+		{
+			name:            "Too many IDs",
+			input:           users.MoveUsersBetweenPopulationsInput{EnvironmentId: testEnvironmentId, TargetPopulationId: testTargetPopulationId, UserIds: make([]uuid.UUID, 101)},
+			setupMock:       func(mockClient *mockPingOneClientUsersWrapper) {},
+			wantErrContains: "a maximum of 100 users",
+		},
+		{
+			name:  "Get user error",
+			input: users.MoveUsersBetweenPopulationsInput{EnvironmentId: testEnvironmentId, TargetPopulationId: testTargetPopulationId, UserIds: []uuid.UUID{testUserId}},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("user not found"))
+			},
+			wantErrContains: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := users.MoveUsersBetweenPopulationsHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMoveUsersBetweenPopulationsHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testMoveFilter).Return(usersPages(usersToMove()...), nil)
+	mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, mock.Anything, testTargetPopulationId.String()).Return(&management.UserPopulation{Id: testTargetPopulationId.String()}, &http.Response{StatusCode: 200}, nil).Twice()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := users.MoveUsersBetweenPopulationsHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, users.MoveUsersBetweenPopulationsInput{
+		EnvironmentId:      testEnvironmentId,
+		TargetPopulationId: testTargetPopulationId,
+		Filter:             testutils.Pointer(testMoveFilter),
+	})
+	require.NoError(t, err)
+
+	changes := journal.Changes(testEnvironmentId.String())
+	require.Len(t, changes, 1)
+	assert.Equal(t, "userPopulation", changes[0].ResourceType)
+	assert.Contains(t, changes[0].Description, "Move 2 users")
+
+	// Undoing the move puts alice and bob back, but not carol, who was already in the target population
+	for _, userId := range []uuid.UUID{testUserId, testBobUserId} {
+		moved := foundUser(userId.String(), "user", "user@example.com")
+		moved.Population = &management.UserPopulation{Id: testTargetPopulationId.String()}
+		mockClient.On("GetUser", mock.Anything, testEnvironmentId, userId.String()).Return(&moved, &http.Response{StatusCode: 200}, nil).Once()
+		mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, userId.String(), testPopulationId.String()).Return(&management.UserPopulation{Id: testPopulationId.String()}, &http.Response{StatusCode: 200}, nil).Once()
+	}
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "SetUserPopulation", mock.Anything, testEnvironmentId, testCarolUserId.String(), mock.Anything)
+}
+
+func TestMoveUsersBetweenPopulationsHandler_UndoChangedSince(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUsers", mock.Anything, testEnvironmentId, testMoveFilter).Return(usersPages(usersToMove()[0]), nil)
+	mockClient.On("SetUserPopulation", mock.Anything, testEnvironmentId, testUserId.String(), testTargetPopulationId.String()).Return(&management.UserPopulation{Id: testTargetPopulationId.String()}, &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := users.MoveUsersBetweenPopulationsHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, users.MoveUsersBetweenPopulationsInput{
+		EnvironmentId:      testEnvironmentId,
+		TargetPopulationId: testTargetPopulationId,
+		Filter:             testutils.Pointer(testMoveFilter),
+	})
+	require.NoError(t, err)
+
+	// alice has been moved to yet another population since
+	alice := foundUser(testUserId.String(), "alice", "alice@example.com")
+	alice.Population = &management.UserPopulation{Id: uuid.NewString()}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(&alice, &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	assert.ErrorIs(t, err, rollback.ErrChangedSince)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var ResetUserPasswordDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "reset_user_password",
+		Title: "Reset PingOne User Password",
+		Description: `Reset a user's password as an administrator. Either set a new password, or leave 'password' out to keep the current password but require the user to change it at their next sign-on.
+
+A new password must meet the password policy of the user's population. By default the user must change a new password at their next sign-on, so that it can be used as a temporary password; set 'forceChange' to false to set a permanent password. The password is never returned, and is redacted from the mutation audit log.
+
+Use find_user to look up the user ID from a username or email address. The previous password cannot be restored.`,
+		InputSchema:  schema.MustGenerateSchema[ResetUserPasswordInput](),
+		OutputSchema: schema.MustGenerateSchema[ResetUserPasswordOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := true; return &b }(),
+		},
+	},
+}
+
+type ResetUserPasswordInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. User UUID."`
+	Password      *string   `json:"password,omitempty" jsonschema:"OPTIONAL. The new password. Omit to keep the current password and require the user to change it at next sign-on."`
+	ForceChange   *bool     `json:"forceChange,omitempty" jsonschema:"OPTIONAL. Require the user to change the password at next sign-on. Defaults to true. Must be true when 'password' is omitted."`
+}
+
+type ResetUserPasswordOutput struct {
+	UserId      string `json:"userId" jsonschema:"The UUID of the user"`
+	PasswordSet bool   `json:"passwordSet" jsonschema:"True if a new password was set, false if only a password change was required"`
+	ForceChange bool   `json:"forceChange" jsonschema:"True if the user must change their password at next sign-on"`
+}
+
+// ResetUserPasswordHandler resets the password of a PingOne user using the provided client
+func ResetUserPasswordHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input ResetUserPasswordInput,
+) (
+	*mcp.CallToolResult,
+	*ResetUserPasswordOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ResetUserPasswordInput) (*mcp.CallToolResult, *ResetUserPasswordOutput, error) {
+		forceChange := input.ForceChange == nil || *input.ForceChange
+		setPassword := input.Password != nil
+		if setPassword && *input.Password == "" {
+			toolErr := errs.NewToolError(ResetUserPasswordDef.McpTool.Name, errors.New("password must not be empty; omit it to only require a password change"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+		if !setPassword && !forceChange {
+			toolErr := errs.NewToolError(ResetUserPasswordDef.McpTool.Name, errors.New("either provide a new password or leave forceChange as true to require a password change"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(ResetUserPasswordDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Resetting user password",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()),
+			slog.Bool("passwordSet", setPassword),
+			slog.Bool("forceChange", forceChange))
+
+		if setPassword {
+			httpResponse, err := client.SetUserPassword(ctx, input.EnvironmentId, input.UserId.String(), *input.Password, forceChange)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+		} else {
+			httpResponse, err := client.ForceUserPasswordChange(ctx, input.EnvironmentId, input.UserId.String())
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				apiErr := errs.NewApiError(httpResponse, err)
+				errs.Log(ctx, apiErr)
+				return nil, nil, apiErr
+			}
+		}
+
+		return nil, &ResetUserPasswordOutput{
+			UserId:      input.UserId.String(),
+			PasswordSet: setPassword,
+			ForceChange: forceChange,
+		}, nil
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetUserPasswordHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           users.ResetUserPasswordInput
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantPasswordSet bool
+		wantForceChange bool
+	}{
+		{
+			name:  "Temporary password by default",
+			input: users.ResetUserPasswordInput{Password: testutils.Pointer("Temp-Passw0rd!")},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("SetUserPassword", mock.Anything, testEnvironmentId, testUserId.String(), "Temp-Passw0rd!", true).Return(&http.Response{StatusCode: 200}, nil)
+			},
+			wantPasswordSet: true,
+			wantForceChange: true,
+		},
+		{
+			name:  "Permanent password",
+			input: users.ResetUserPasswordInput{Password: testutils.Pointer("Perm-Passw0rd!"), ForceChange: testutils.Pointer(false)},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("SetUserPassword", mock.Anything, testEnvironmentId, testUserId.String(), "Perm-Passw0rd!", false).Return(&http.Response{StatusCode: 200}, nil)
+			},
+			wantPasswordSet: true,
+			wantForceChange: false,
+		},
+		{
+			name:  "Force change only",
+			input: users.ResetUserPasswordInput{},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("ForceUserPasswordChange", mock.Anything, testEnvironmentId, testUserId.String()).Return(&http.Response{StatusCode: 200}, nil)
+			},
+			wantPasswordSet: false,
+			wantForceChange: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tt.setupMock(mockClient)
+
+			input := tt.input
+			input.EnvironmentId = testEnvironmentId
+			input.UserId = testUserId
+			handler := users.ResetUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, tt.wantPasswordSet, output.PasswordSet)
+			assert.Equal(t, tt.wantForceChange, output.ForceChange)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResetUserPasswordHandler_DoesNotReturnPassword(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("SetUserPassword", mock.Anything, testEnvironmentId, testUserId.String(), "Temp-Passw0rd!", true).Return(&http.Response{StatusCode: 200}, nil)
+
+	handler := users.ResetUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.ResetUserPasswordInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		Password:      testutils.Pointer("Temp-Passw0rd!"),
+	})
+	require.NoError(t, err)
+
+	outputJson, err := json.Marshal(output)
+	require.NoError(t, err)
+	assert.NotContains(t, string(outputJson), "Temp-Passw0rd!")
+}
+
+func TestResetUserPasswordHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           users.ResetUserPasswordInput
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "Empty password",
+			input:           users.ResetUserPasswordInput{Password: testutils.Pointer("")},
+			wantErrContains: "password must not be empty",
+		},
+		{
+			name:            "No password and no force change",
+			input:           users.ResetUserPasswordInput{ForceChange: testutils.Pointer(false)},
+			wantErrContains: "either provide a new password or leave forceChange as true",
+		},
+		{
+			name:  "Password policy violation",
+			input: users.ResetUserPasswordInput{Password: testutils.Pointer("short")},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("SetUserPassword", mock.Anything, testEnvironmentId, testUserId.String(), "short", true).Return(&http.Response{StatusCode: 400}, errors.New("password does not meet the password policy"))
+			},
+			wantErrContains: "password does not meet the password policy",
+		},
+		{
+			name:  "Force change error",
+			input: users.ResetUserPasswordInput{},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("ForceUserPasswordChange", mock.Anything, testEnvironmentId, testUserId.String()).Return(&http.Response{StatusCode: 404}, errors.New("user not found"))
+			},
+			wantErrContains: "user not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			if tt.setupMock != nil {
+				tt.setupMock(mockClient)
+			}
+
+			input := tt.input
+			input.EnvironmentId = testEnvironmentId
+			input.UserId = testUserId
+			handler := users.ResetUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResetUserPasswordHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := users.ResetUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.ResetUserPasswordInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
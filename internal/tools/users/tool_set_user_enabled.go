@@ -0,0 +1,156 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var SetUserEnabledDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "set_user_enabled",
+		Title: "Enable or Disable PingOne User",
+		Description: `Enable or disable a user. A disabled user cannot sign on until enabled again; their attributes, population and devices are kept.
+
+Use find_user to look up the user ID from a username or email address. The output reports whether the user was enabled before the call; if the user is already in the requested state, nothing is changed.`,
+		InputSchema:  schema.MustGenerateSchema[SetUserEnabledInput](),
+		OutputSchema: schema.MustGenerateSchema[SetUserEnabledOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type SetUserEnabledInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. User UUID."`
+	Enabled       bool      `json:"enabled" jsonschema:"REQUIRED. True to enable the user, false to disable them."`
+}
+
+type SetUserEnabledOutput struct {
+	UserId          string `json:"userId" jsonschema:"The UUID of the user"`
+	Username        string `json:"username" jsonschema:"The username of the user"`
+	PreviousEnabled bool   `json:"previousEnabled" jsonschema:"Whether the user was enabled before the call"`
+	Enabled         bool   `json:"enabled" jsonschema:"Whether the user is enabled after the call"`
+	Changed         bool   `json:"changed" jsonschema:"True if the user's enabled state was changed, false if it was already as requested"`
+}
+
+// SetUserEnabledHandler enables or disables a PingOne user using the provided client
+func SetUserEnabledHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input SetUserEnabledInput,
+) (
+	*mcp.CallToolResult,
+	*SetUserEnabledOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SetUserEnabledInput) (*mcp.CallToolResult, *SetUserEnabledOutput, error) {
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(SetUserEnabledDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		user, httpResponse, err := client.GetUser(ctx, input.EnvironmentId, input.UserId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if user == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		result := &SetUserEnabledOutput{
+			UserId:          input.UserId.String(),
+			Username:        user.Username,
+			PreviousEnabled: user.GetEnabled(),
+			Enabled:         user.GetEnabled(),
+		}
+		if result.PreviousEnabled == input.Enabled {
+			return nil, result, nil
+		}
+
+		logger.FromContext(ctx).Debug("Setting user enabled",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()),
+			slog.Bool("enabled", input.Enabled))
+
+		userEnabled, httpResponse, err := client.SetUserEnabled(ctx, input.EnvironmentId, input.UserId.String(), input.Enabled)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		result.Enabled = input.Enabled
+		if userEnabled != nil && userEnabled.Enabled != nil {
+			result.Enabled = *userEnabled.Enabled
+		}
+		result.Changed = result.Enabled != result.PreviousEnabled
+
+		if result.Changed {
+			rollback.Record(ctx, rollback.Change{
+				Tool:          SetUserEnabledDef.McpTool.Name,
+				EnvironmentId: input.EnvironmentId.String(),
+				ResourceType:  "user",
+				ResourceId:    input.UserId.String(),
+				Description:   fmt.Sprintf("%s user %q", enabledVerb(result.PreviousEnabled), user.Username),
+			}, undoSetUserEnabled(usersClientFactory, input.EnvironmentId, input.UserId.String(), result.PreviousEnabled))
+		}
+
+		return nil, result, nil
+	}
+}
+
+func enabledVerb(enabled bool) string {
+	if enabled {
+		return "Enable"
+	}
+	return "Disable"
+}
+
+// undoSetUserEnabled returns the function that restores the previous enabled state of a user, unless it has
+// been changed again since
+func undoSetUserEnabled(usersClientFactory UsersClientFactory, environmentId uuid.UUID, userId string, previousEnabled bool) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !force {
+			current, httpResponse, err := client.GetUser(ctx, environmentId, userId)
+			logger.LogHttpResponse(ctx, httpResponse)
+			if err != nil {
+				return errs.NewApiError(httpResponse, err)
+			}
+			if current == nil || current.GetEnabled() == previousEnabled {
+				return rollback.ErrChangedSince
+			}
+		}
+
+		_, httpResponse, err := client.SetUserEnabled(ctx, environmentId, userId, previousEnabled)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func userWithEnabled(enabled bool) *management.User {
+	user := foundUser(testUserId.String(), "alice", "alice@example.com")
+	user.Enabled = testutils.Pointer(enabled)
+	return &user
+}
+
+func TestSetUserEnabledHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     bool
+		enabled     bool
+		wantChanged bool
+	}{
+		{name: "Disable enabled user", current: true, enabled: false, wantChanged: true},
+		{name: "Enable disabled user", current: false, enabled: true, wantChanged: true},
+		{name: "Already enabled", current: true, enabled: true, wantChanged: false},
+		{name: "Already disabled", current: false, enabled: false, wantChanged: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithEnabled(tt.current), &http.Response{StatusCode: 200}, nil)
+			if tt.wantChanged {
+				mockClient.On("SetUserEnabled", mock.Anything, testEnvironmentId, testUserId.String(), tt.enabled).Return(
+					&management.UserEnabled{Enabled: testutils.Pointer(tt.enabled)}, &http.Response{StatusCode: 200}, nil)
+			}
+
+			handler := users.SetUserEnabledHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.SetUserEnabledInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+				Enabled:       tt.enabled,
+			})
+
+			testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+			assert.Equal(t, "alice", output.Username)
+			assert.Equal(t, tt.current, output.PreviousEnabled)
+			assert.Equal(t, tt.enabled, output.Enabled)
+			assert.Equal(t, tt.wantChanged, output.Changed)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetUserEnabledHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name: "Get user error",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("user not found"))
+			},
+			wantErrContains: "user not found",
+		},
+		{
+			name: "No user data in response",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no user data in response",
+		},
+		{
+			name: "Update error",
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithEnabled(true), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("SetUserEnabled", mock.Anything, testEnvironmentId, testUserId.String(), false).Return(nil, &http.Response{StatusCode: 403}, errors.New("forbidden"))
+			},
+			wantErrContains: "forbidden",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := users.SetUserEnabledHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.SetUserEnabledInput{
+				EnvironmentId: testEnvironmentId,
+				UserId:        testUserId,
+				Enabled:       false,
+			})
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSetUserEnabledHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := users.SetUserEnabledHandler(NewMockPingOneClientUsersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.SetUserEnabledInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
+
+func TestSetUserEnabledHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithEnabled(true), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("SetUserEnabled", mock.Anything, testEnvironmentId, testUserId.String(), false).Return(
+		&management.UserEnabled{Enabled: testutils.Pointer(false)}, &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := users.SetUserEnabledHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, users.SetUserEnabledInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		Enabled:       false,
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// The user has been enabled again since, so the undo is refused without force
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithEnabled(true), &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithEnabled(false), &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("SetUserEnabled", mock.Anything, testEnvironmentId, testUserId.String(), true).Return(
+		&management.UserEnabled{Enabled: testutils.Pointer(true)}, &http.Response{StatusCode: 200}, nil).Once()
+
+	change, err := journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, users.SetUserEnabledDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSetUserEnabledHandler_UnchangedDoesNotRecordUndo(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithEnabled(false), &http.Response{StatusCode: 200}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := users.SetUserEnabledHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, users.SetUserEnabledInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		Enabled:       false,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, journal.Changes(testEnvironmentId.String()))
+}
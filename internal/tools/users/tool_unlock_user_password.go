@@ -0,0 +1,95 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UnlockUserPasswordDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "unlock_user_password",
+		Title: "Unlock PingOne User Password",
+		Description: `Unlock a user whose password is locked out after too many failed sign-on attempts, so that they can sign on with their password again without waiting for the lockout to expire. The password itself is not changed.
+
+Use find_user to look up the user ID from a username or email address. The output reports the user's account status after unlocking.`,
+		InputSchema:  schema.MustGenerateSchema[UnlockUserPasswordInput](),
+		OutputSchema: schema.MustGenerateSchema[UnlockUserPasswordOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type UnlockUserPasswordInput struct {
+	EnvironmentId uuid.UUID `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserId        uuid.UUID `json:"userId" jsonschema:"REQUIRED. User UUID."`
+}
+
+type UnlockUserPasswordOutput struct {
+	UserId        string  `json:"userId" jsonschema:"The UUID of the user"`
+	Username      string  `json:"username,omitempty" jsonschema:"The username of the user"`
+	AccountStatus *string `json:"accountStatus,omitempty" jsonschema:"The account status of the user after unlocking, such as OK"`
+	Unlocked      bool    `json:"unlocked" jsonschema:"True if the password was unlocked"`
+}
+
+// UnlockUserPasswordHandler unlocks the password of a PingOne user using the provided client
+func UnlockUserPasswordHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UnlockUserPasswordInput,
+) (
+	*mcp.CallToolResult,
+	*UnlockUserPasswordOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UnlockUserPasswordInput) (*mcp.CallToolResult, *UnlockUserPasswordOutput, error) {
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UnlockUserPasswordDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		logger.FromContext(ctx).Debug("Unlocking user password",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()))
+
+		httpResponse, err := client.UnlockUserPassword(ctx, input.EnvironmentId, input.UserId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		result := &UnlockUserPasswordOutput{
+			UserId:   input.UserId.String(),
+			Unlocked: true,
+		}
+
+		// The user is only read to report their status, so the unlock is still reported if it cannot be read
+		user, httpResponse, err := client.GetUser(ctx, input.EnvironmentId, input.UserId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			logger.FromContext(ctx).Warn("Unable to retrieve the user after unlocking their password",
+				slog.String("userId", input.UserId.String()),
+				slog.Any("error", err))
+		} else if user != nil {
+			summary := userSummary(*user)
+			result.Username = summary.Username
+			result.AccountStatus = summary.AccountStatus
+		}
+
+		return nil, result, nil
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUnlockUserPasswordHandler(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("UnlockUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(&http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithEnabled(true), &http.Response{StatusCode: 200}, nil)
+
+	handler := users.UnlockUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.UnlockUserPasswordInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.Unlocked)
+	assert.Equal(t, "alice", output.Username)
+	assert.Equal(t, "OK", *output.AccountStatus)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUnlockUserPasswordHandler_UserReadErrorStillReportsUnlock(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("UnlockUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(&http.Response{StatusCode: 200}, nil)
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 500}, errors.New("server error"))
+
+	handler := users.UnlockUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.UnlockUserPasswordInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.Unlocked)
+	assert.Nil(t, output.AccountStatus)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUnlockUserPasswordHandler_ApiError(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("UnlockUserPassword", mock.Anything, testEnvironmentId, testUserId.String()).Return(&http.Response{StatusCode: 400}, errors.New("password is not locked"))
+
+	handler := users.UnlockUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.UnlockUserPasswordInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "password is not locked")
+	mockClient.AssertExpectations(t)
+}
+
+func TestUnlockUserPasswordHandler_GetAuthenticatedClientError(t *testing.T) {
+	handler := users.UnlockUserPasswordHandler(NewMockPingOneClientUsersWrapperFactory(nil, errors.New("authentication failed")))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.UnlockUserPasswordInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+	})
+
+	testutils.AssertHandlerError(t, err, mcpResult, output, "authentication failed")
+}
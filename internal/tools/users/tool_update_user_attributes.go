@@ -0,0 +1,370 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/errs"
+	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/schema"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
+)
+
+var UpdateUserAttributesDef = types.ToolDefinition{
+	McpTool: &mcp.Tool{
+		Name:  "update_user_attributes",
+		Title: "Update PingOne User Attributes",
+		Description: `Update only the given attributes of a user, leaving every other attribute as it is (HTTP PATCH). There is no need to fetch the user first: attributes that are not provided are never cleared, including the other parts of the user's name and address.
+
+Use find_user to look up the user ID from a username or email address. The output lists each attribute whose value was changed, with its previous and new values; attributes that already had the requested value are not changed. Use set_user_enabled to enable or disable the user, and reset_user_password to change their password.`,
+		InputSchema:  schema.MustGenerateSchema[UpdateUserAttributesInput](),
+		OutputSchema: schema.MustGenerateSchema[UpdateUserAttributesOutput](),
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: func() *bool { b := false; return &b }(),
+			IdempotentHint:  true,
+		},
+	},
+}
+
+type UpdateUserAttributesInput struct {
+	EnvironmentId     uuid.UUID              `json:"environmentId" jsonschema:"REQUIRED. Environment UUID."`
+	UserId            uuid.UUID              `json:"userId" jsonschema:"REQUIRED. User UUID."`
+	Username          *string                `json:"username,omitempty" jsonschema:"OPTIONAL. New username, unique within the environment."`
+	Email             *string                `json:"email,omitempty" jsonschema:"OPTIONAL. New email address."`
+	Name              *UserNameAttributes    `json:"name,omitempty" jsonschema:"OPTIONAL. Parts of the user's name to change. Parts that are not provided are kept."`
+	Nickname          *string                `json:"nickname,omitempty" jsonschema:"OPTIONAL. New nickname."`
+	Title             *string                `json:"title,omitempty" jsonschema:"OPTIONAL. New title, such as 'Vice President'."`
+	PreferredLanguage *string                `json:"preferredLanguage,omitempty" jsonschema:"OPTIONAL. New preferred languages, in the format of the HTTP Accept-Language header, such as 'en-US, en;q=0.7'."`
+	Locale            *string                `json:"locale,omitempty" jsonschema:"OPTIONAL. New default location, as an RFC 5646 language tag such as 'en-US'."`
+	Timezone          *string                `json:"timezone,omitempty" jsonschema:"OPTIONAL. New time zone from the IANA time zone database, such as 'America/Los_Angeles'."`
+	ExternalId        *string                `json:"externalId,omitempty" jsonschema:"OPTIONAL. New identifier of the user in another system of record."`
+	Type              *string                `json:"type,omitempty" jsonschema:"OPTIONAL. New organization-specific user type, such as 'Employee' or 'Contractor'."`
+	PrimaryPhone      *string                `json:"primaryPhone,omitempty" jsonschema:"OPTIONAL. New primary phone number."`
+	MobilePhone       *string                `json:"mobilePhone,omitempty" jsonschema:"OPTIONAL. New mobile phone number."`
+	Address           *UserAddressAttributes `json:"address,omitempty" jsonschema:"OPTIONAL. Parts of the user's address to change. Parts that are not provided are kept."`
+}
+
+type UserNameAttributes struct {
+	Given           *string `json:"given,omitempty" jsonschema:"OPTIONAL. New given (first) name."`
+	Family          *string `json:"family,omitempty" jsonschema:"OPTIONAL. New family (last) name."`
+	Middle          *string `json:"middle,omitempty" jsonschema:"OPTIONAL. New middle name."`
+	Formatted       *string `json:"formatted,omitempty" jsonschema:"OPTIONAL. New full name, formatted for display."`
+	HonorificPrefix *string `json:"honorificPrefix,omitempty" jsonschema:"OPTIONAL. New honorific prefix, such as 'Dr.'."`
+	HonorificSuffix *string `json:"honorificSuffix,omitempty" jsonschema:"OPTIONAL. New honorific suffix, such as 'Jr.'."`
+}
+
+type UserAddressAttributes struct {
+	StreetAddress *string `json:"streetAddress,omitempty" jsonschema:"OPTIONAL. New street address."`
+	Locality      *string `json:"locality,omitempty" jsonschema:"OPTIONAL. New city or locality."`
+	Region        *string `json:"region,omitempty" jsonschema:"OPTIONAL. New state or region."`
+	PostalCode    *string `json:"postalCode,omitempty" jsonschema:"OPTIONAL. New postal code."`
+	CountryCode   *string `json:"countryCode,omitempty" jsonschema:"OPTIONAL. New ISO 3166-1 country code, such as 'US'."`
+}
+
+type UpdateUserAttributesOutput struct {
+	UserId   string            `json:"userId" jsonschema:"The UUID of the user"`
+	Username string            `json:"username" jsonschema:"The username of the user after the call"`
+	Changed  bool              `json:"changed" jsonschema:"True if any attribute was changed, false if all already had the requested values"`
+	Changes  []AttributeChange `json:"changes" jsonschema:"The attributes whose values were changed"`
+	User     management.User   `json:"user" jsonschema:"The user after the call"`
+}
+
+// AttributeChange is the change of the value of a user attribute
+type AttributeChange struct {
+	Attribute     string  `json:"attribute" jsonschema:"The changed attribute, as a dot-separated path such as 'name.family'"`
+	PreviousValue *string `json:"previousValue,omitempty" jsonschema:"The value of the attribute before the call. Not set if the attribute had no value."`
+	NewValue      string  `json:"newValue" jsonschema:"The value of the attribute after the call"`
+}
+
+// userAttribute is a user attribute that can be updated on its own
+type userAttribute struct {
+	path      string
+	requested func(input UpdateUserAttributesInput) *string
+	get       func(user *management.User) *string
+	set       func(user *management.User, value string)
+}
+
+// updatableUserAttributes are the attributes the tool updates, in the order their changes are reported
+var updatableUserAttributes = []userAttribute{
+	{
+		path:      "username",
+		requested: func(input UpdateUserAttributesInput) *string { return input.Username },
+		get:       func(user *management.User) *string { return &user.Username },
+		set:       func(user *management.User, value string) { user.Username = value },
+	},
+	{
+		path:      "email",
+		requested: func(input UpdateUserAttributesInput) *string { return input.Email },
+		get:       func(user *management.User) *string { return &user.Email },
+		set:       func(user *management.User, value string) { user.Email = value },
+	},
+	nameAttribute("name.given", func(name *UserNameAttributes) *string { return name.Given }, func(name *management.UserName) **string { return &name.Given }),
+	nameAttribute("name.family", func(name *UserNameAttributes) *string { return name.Family }, func(name *management.UserName) **string { return &name.Family }),
+	nameAttribute("name.middle", func(name *UserNameAttributes) *string { return name.Middle }, func(name *management.UserName) **string { return &name.Middle }),
+	nameAttribute("name.formatted", func(name *UserNameAttributes) *string { return name.Formatted }, func(name *management.UserName) **string { return &name.Formatted }),
+	nameAttribute("name.honorificPrefix", func(name *UserNameAttributes) *string { return name.HonorificPrefix }, func(name *management.UserName) **string { return &name.HonorificPrefix }),
+	nameAttribute("name.honorificSuffix", func(name *UserNameAttributes) *string { return name.HonorificSuffix }, func(name *management.UserName) **string { return &name.HonorificSuffix }),
+	optionalAttribute("nickname", func(input UpdateUserAttributesInput) *string { return input.Nickname }, func(user *management.User) **string { return &user.Nickname }),
+	optionalAttribute("title", func(input UpdateUserAttributesInput) *string { return input.Title }, func(user *management.User) **string { return &user.Title }),
+	optionalAttribute("preferredLanguage", func(input UpdateUserAttributesInput) *string { return input.PreferredLanguage }, func(user *management.User) **string { return &user.PreferredLanguage }),
+	optionalAttribute("locale", func(input UpdateUserAttributesInput) *string { return input.Locale }, func(user *management.User) **string { return &user.Locale }),
+	optionalAttribute("timezone", func(input UpdateUserAttributesInput) *string { return input.Timezone }, func(user *management.User) **string { return &user.Timezone }),
+	optionalAttribute("externalId", func(input UpdateUserAttributesInput) *string { return input.ExternalId }, func(user *management.User) **string { return &user.ExternalId }),
+	optionalAttribute("type", func(input UpdateUserAttributesInput) *string { return input.Type }, func(user *management.User) **string { return &user.Type }),
+	optionalAttribute("primaryPhone", func(input UpdateUserAttributesInput) *string { return input.PrimaryPhone }, func(user *management.User) **string { return &user.PrimaryPhone }),
+	optionalAttribute("mobilePhone", func(input UpdateUserAttributesInput) *string { return input.MobilePhone }, func(user *management.User) **string { return &user.MobilePhone }),
+	addressAttribute("address.streetAddress", func(address *UserAddressAttributes) *string { return address.StreetAddress }, func(address *management.UserAddress) **string { return &address.StreetAddress }),
+	addressAttribute("address.locality", func(address *UserAddressAttributes) *string { return address.Locality }, func(address *management.UserAddress) **string { return &address.Locality }),
+	addressAttribute("address.region", func(address *UserAddressAttributes) *string { return address.Region }, func(address *management.UserAddress) **string { return &address.Region }),
+	addressAttribute("address.postalCode", func(address *UserAddressAttributes) *string { return address.PostalCode }, func(address *management.UserAddress) **string { return &address.PostalCode }),
+	addressAttribute("address.countryCode", func(address *UserAddressAttributes) *string { return address.CountryCode }, func(address *management.UserAddress) **string { return &address.CountryCode }),
+}
+
+func optionalAttribute(path string, requested func(input UpdateUserAttributesInput) *string, field func(user *management.User) **string) userAttribute {
+	return userAttribute{
+		path:      path,
+		requested: requested,
+		get:       func(user *management.User) *string { return *field(user) },
+		set:       func(user *management.User, value string) { *field(user) = &value },
+	}
+}
+
+func nameAttribute(path string, requested func(name *UserNameAttributes) *string, field func(name *management.UserName) **string) userAttribute {
+	return userAttribute{
+		path: path,
+		requested: func(input UpdateUserAttributesInput) *string {
+			if input.Name == nil {
+				return nil
+			}
+			return requested(input.Name)
+		},
+		get: func(user *management.User) *string {
+			if user.Name == nil {
+				return nil
+			}
+			return *field(user.Name)
+		},
+		set: func(user *management.User, value string) { *field(userName(user)) = &value },
+	}
+}
+
+func addressAttribute(path string, requested func(address *UserAddressAttributes) *string, field func(address *management.UserAddress) **string) userAttribute {
+	return userAttribute{
+		path: path,
+		requested: func(input UpdateUserAttributesInput) *string {
+			if input.Address == nil {
+				return nil
+			}
+			return requested(input.Address)
+		},
+		get: func(user *management.User) *string {
+			if user.Address == nil {
+				return nil
+			}
+			return *field(user.Address)
+		},
+		set: func(user *management.User, value string) { *field(userAddress(user)) = &value },
+	}
+}
+
+// UpdateUserAttributesHandler updates the given attributes of a PingOne user using the provided client
+func UpdateUserAttributesHandler(usersClientFactory UsersClientFactory) func(
+	ctx context.Context,
+	req *mcp.CallToolRequest,
+	input UpdateUserAttributesInput,
+) (
+	*mcp.CallToolResult,
+	*UpdateUserAttributesOutput,
+	error,
+) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateUserAttributesInput) (*mcp.CallToolResult, *UpdateUserAttributesOutput, error) {
+		requested := map[string]string{}
+		for _, attribute := range updatableUserAttributes {
+			if value := attribute.requested(input); value != nil {
+				requested[attribute.path] = strings.TrimSpace(*value)
+			}
+		}
+		if len(requested) == 0 {
+			toolErr := errs.NewToolError(UpdateUserAttributesDef.McpTool.Name, errors.New("at least one attribute to update must be provided"))
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			toolErr := errs.NewToolError(UpdateUserAttributesDef.McpTool.Name, err)
+			errs.Log(ctx, toolErr)
+			return nil, nil, toolErr
+		}
+
+		user, httpResponse, err := client.GetUser(ctx, input.EnvironmentId, input.UserId.String())
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if user == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		// Only attributes whose values change are sent, so that unchanged attributes are never touched
+		changes := []AttributeChange{}
+		for _, attribute := range updatableUserAttributes {
+			value, ok := requested[attribute.path]
+			if !ok {
+				continue
+			}
+			previous := attribute.get(user)
+			if previous != nil && *previous == value {
+				continue
+			}
+			change := AttributeChange{
+				Attribute: attribute.path,
+				NewValue:  value,
+			}
+			if previous != nil {
+				previousValue := *previous
+				change.PreviousValue = &previousValue
+			}
+			changes = append(changes, change)
+		}
+
+		user.Links = nil
+		result := &UpdateUserAttributesOutput{
+			UserId:   input.UserId.String(),
+			Username: user.Username,
+			Changes:  changes,
+			User:     *user,
+		}
+		if len(changes) == 0 {
+			return nil, result, nil
+		}
+
+		logger.FromContext(ctx).Debug("Updating user attributes",
+			slog.String("environmentId", input.EnvironmentId.String()),
+			slog.String("userId", input.UserId.String()),
+			slog.Int("attributes", len(changes)))
+
+		newValues := map[string]string{}
+		for _, change := range changes {
+			newValues[change.Attribute] = change.NewValue
+		}
+		updatedUser, httpResponse, err := client.UpdateUser(ctx, input.EnvironmentId, input.UserId.String(), userAttributesPatch(user, newValues))
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			apiErr := errs.NewApiError(httpResponse, err)
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+		if updatedUser == nil {
+			apiErr := errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+			errs.Log(ctx, apiErr)
+			return nil, nil, apiErr
+		}
+
+		updatedUser.Links = nil
+		result.Username = updatedUser.Username
+		result.Changed = true
+		result.User = *updatedUser
+
+		recordUpdateUserAttributes(ctx, usersClientFactory, input, changes, updatedUser)
+
+		return nil, result, nil
+	}
+}
+
+// userAttributesPatch returns the PATCH request that sets the attributes to their new values. The username and
+// email are always sent, as the API requires them, and the name and address are sent whole when any of their parts
+// change, so that the parts that are not changed are kept.
+func userAttributesPatch(current *management.User, values map[string]string) management.User {
+	patch := management.User{
+		Username: current.Username,
+		Email:    current.Email,
+	}
+	for _, attribute := range updatableUserAttributes {
+		value, ok := values[attribute.path]
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(attribute.path, "name.") && patch.Name == nil && current.Name != nil {
+			name := *current.Name
+			patch.Name = &name
+		}
+		if strings.HasPrefix(attribute.path, "address.") && patch.Address == nil && current.Address != nil {
+			address := *current.Address
+			patch.Address = &address
+		}
+		attribute.set(&patch, value)
+	}
+	return patch
+}
+
+// recordUpdateUserAttributes records how to restore the previous values of the changed attributes. Attributes
+// that had no value cannot be cleared again with a PATCH of the SDK, so changes that set them cannot be undone.
+func recordUpdateUserAttributes(ctx context.Context, usersClientFactory UsersClientFactory, input UpdateUserAttributesInput, changes []AttributeChange, updatedUser *management.User) {
+	previousValues := map[string]string{}
+	for _, change := range changes {
+		if change.PreviousValue == nil {
+			logger.FromContext(ctx).Warn("The user attribute had no value before the update, the update cannot be undone",
+				slog.String("userId", input.UserId.String()),
+				slog.String("attribute", change.Attribute))
+			return
+		}
+		previousValues[change.Attribute] = *change.PreviousValue
+	}
+
+	attributes := make([]string, 0, len(changes))
+	for _, change := range changes {
+		attributes = append(attributes, change.Attribute)
+	}
+	rollback.Record(ctx, rollback.Change{
+		Tool:          UpdateUserAttributesDef.McpTool.Name,
+		EnvironmentId: input.EnvironmentId.String(),
+		ResourceType:  "user",
+		ResourceId:    input.UserId.String(),
+		Description:   fmt.Sprintf("Restore the previous %s of user %q", strings.Join(attributes, ", "), updatedUser.Username),
+	}, undoUpdateUserAttributes(usersClientFactory, input.EnvironmentId, input.UserId.String(), previousValues, updatedUser.GetUpdatedAt()))
+}
+
+// undoUpdateUserAttributes returns the function that restores the previous values of user attributes, unless the
+// user has been updated again since
+func undoUpdateUserAttributes(usersClientFactory UsersClientFactory, environmentId uuid.UUID, userId string, previousValues map[string]string, updatedAt time.Time) rollback.UndoFunc {
+	return func(ctx context.Context, force bool) error {
+		client, err := usersClientFactory.GetAuthenticatedClient(ctx)
+		if err != nil {
+			return err
+		}
+
+		current, httpResponse, err := client.GetUser(ctx, environmentId, userId)
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		if current == nil {
+			return errs.NewApiError(httpResponse, fmt.Errorf("no user data in response"))
+		}
+		if !force && !current.GetUpdatedAt().Equal(updatedAt) {
+			return rollback.ErrChangedSince
+		}
+
+		_, httpResponse, err = client.UpdateUser(ctx, environmentId, userId, userAttributesPatch(current, previousValues))
+		logger.LogHttpResponse(ctx, httpResponse)
+		if err != nil {
+			return errs.NewApiError(httpResponse, err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package users_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/patrickcping/pingone-go-sdk-v2/management"
+	"github.com/pingidentity/pingone-mcp-server/internal/testutils"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/rollback"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testUpdatedAt = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// userWithAttributes returns a user with a full name, a title and an address
+func userWithAttributes() *management.User {
+	user := foundUser(testUserId.String(), "alice", "alice@example.com")
+	user.Title = testutils.Pointer("Engineer")
+	user.Address = &management.UserAddress{Locality: testutils.Pointer("Denver"), CountryCode: testutils.Pointer("US")}
+	user.UpdatedAt = testutils.Pointer(testUpdatedAt)
+	return &user
+}
+
+func TestUpdateUserAttributesHandler(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithAttributes(), &http.Response{StatusCode: 200}, nil)
+	expectedPatch := management.User{
+		Username: "alice",
+		Email:    "alice@example.com",
+		// The other parts of the name and address are sent unchanged, so that they are kept
+		Name:     &management.UserName{Given: testutils.Pointer("Alice"), Family: testutils.Pointer("Jones")},
+		Address:  &management.UserAddress{Locality: testutils.Pointer("Boulder"), CountryCode: testutils.Pointer("US")},
+		Nickname: testutils.Pointer("Ali"),
+	}
+	updatedUser := userWithAttributes()
+	updatedUser.Name.Family = testutils.Pointer("Jones")
+	updatedUser.Address.Locality = testutils.Pointer("Boulder")
+	updatedUser.Nickname = testutils.Pointer("Ali")
+	mockClient.On("UpdateUser", mock.Anything, testEnvironmentId, testUserId.String(), expectedPatch).Return(updatedUser, &http.Response{StatusCode: 200}, nil)
+
+	handler := users.UpdateUserAttributesHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, users.UpdateUserAttributesInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		Name:          &users.UserNameAttributes{Family: testutils.Pointer("Jones")},
+		Title:         testutils.Pointer("Engineer"),
+		Nickname:      testutils.Pointer("Ali"),
+		Address:       &users.UserAddressAttributes{Locality: testutils.Pointer("Boulder")},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.True(t, output.Changed)
+	assert.Equal(t, "alice", output.Username)
+	assert.Equal(t, []users.AttributeChange{
+		{Attribute: "name.family", PreviousValue: testutils.Pointer("Smith"), NewValue: "Jones"},
+		{Attribute: "nickname", NewValue: "Ali"},
+		{Attribute: "address.locality", PreviousValue: testutils.Pointer("Denver"), NewValue: "Boulder"},
+	}, output.Changes, "the title already had the requested value")
+	assert.Equal(t, "Jones", output.User.Name.GetFamily())
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateUserAttributesHandler_Unchanged(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithAttributes(), &http.Response{StatusCode: 200}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := users.UpdateUserAttributesHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	mcpResult, output, err := handler(ctx, &mcp.CallToolRequest{}, users.UpdateUserAttributesInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		Email:         testutils.Pointer("alice@example.com"),
+		Name:          &users.UserNameAttributes{Given: testutils.Pointer("Alice")},
+	})
+
+	testutils.AssertStructuredHandlerSuccess(t, err, mcpResult, output)
+	assert.False(t, output.Changed)
+	assert.NotNil(t, output.Changes)
+	assert.Empty(t, output.Changes)
+	assert.Empty(t, journal.Changes(testEnvironmentId.String()))
+	mockClient.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateUserAttributesHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           users.UpdateUserAttributesInput
+		setupMock       func(mockClient *mockPingOneClientUsersWrapper)
+		wantErrContains string
+	}{
+		{
+			name:            "No attributes",
+			input:           users.UpdateUserAttributesInput{EnvironmentId: testEnvironmentId, UserId: testUserId, Name: &users.UserNameAttributes{}},
+			setupMock:       func(mockClient *mockPingOneClientUsersWrapper) {},
+			wantErrContains: "at least one attribute to update must be provided",
+		},
+		{
+			name:  "Get user error",
+			input: users.UpdateUserAttributesInput{EnvironmentId: testEnvironmentId, UserId: testUserId, Title: testutils.Pointer("Manager")},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 404}, errors.New("user not found"))
+			},
+			wantErrContains: "user not found",
+		},
+		{
+			name:  "No user data in response",
+			input: users.UpdateUserAttributesInput{EnvironmentId: testEnvironmentId, UserId: testUserId, Title: testutils.Pointer("Manager")},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(nil, &http.Response{StatusCode: 200}, nil)
+			},
+			wantErrContains: "no user data in response",
+		},
+		{
+			name:  "Update error",
+			input: users.UpdateUserAttributesInput{EnvironmentId: testEnvironmentId, UserId: testUserId, Title: testutils.Pointer("Manager")},
+			setupMock: func(mockClient *mockPingOneClientUsersWrapper) {
+				mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithAttributes(), &http.Response{StatusCode: 200}, nil)
+				mockClient.On("UpdateUser", mock.Anything, testEnvironmentId, testUserId.String(), mock.Anything).Return(nil, &http.Response{StatusCode: 400}, errors.New("invalid title"))
+			},
+			wantErrContains: "invalid title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockPingOneClientUsersWrapper{}
+			tt.setupMock(mockClient)
+
+			handler := users.UpdateUserAttributesHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+			mcpResult, output, err := handler(context.Background(), &mcp.CallToolRequest{}, tt.input)
+
+			testutils.AssertHandlerError(t, err, mcpResult, output, tt.wantErrContains)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateUserAttributesHandler_RecordsUndo(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithAttributes(), &http.Response{StatusCode: 200}, nil).Once()
+	updatedUser := userWithAttributes()
+	updatedUser.Title = testutils.Pointer("Manager")
+	updatedUser.UpdatedAt = testutils.Pointer(testUpdatedAt.Add(time.Minute))
+	mockClient.On("UpdateUser", mock.Anything, testEnvironmentId, testUserId.String(), mock.Anything).Return(updatedUser, &http.Response{StatusCode: 200}, nil).Once()
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := users.UpdateUserAttributesHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, _, err := handler(ctx, &mcp.CallToolRequest{}, users.UpdateUserAttributesInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		Title:         testutils.Pointer("Manager"),
+	})
+	require.NoError(t, err)
+	require.Len(t, journal.Changes(testEnvironmentId.String()), 1)
+
+	// The user has been updated again since, so the undo is refused without force
+	updatedAgain := userWithAttributes()
+	updatedAgain.UpdatedAt = testutils.Pointer(testUpdatedAt.Add(time.Hour))
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(updatedAgain, &http.Response{StatusCode: 200}, nil).Once()
+	_, err = journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.ErrorIs(t, err, rollback.ErrChangedSince)
+
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(updatedUser, &http.Response{StatusCode: 200}, nil).Once()
+	mockClient.On("UpdateUser", mock.Anything, testEnvironmentId, testUserId.String(), mock.MatchedBy(func(patch management.User) bool {
+		return patch.GetTitle() == "Engineer" && patch.Name == nil && patch.Nickname == nil
+	})).Return(userWithAttributes(), &http.Response{StatusCode: 200}, nil).Once()
+
+	change, err := journal.Undo(context.Background(), testEnvironmentId.String(), "", false)
+	require.NoError(t, err)
+	assert.Equal(t, users.UpdateUserAttributesDef.McpTool.Name, change.Tool)
+	mockClient.AssertExpectations(t)
+}
+
+func TestUpdateUserAttributesHandler_SettingUnsetAttributeDoesNotRecordUndo(t *testing.T) {
+	mockClient := &mockPingOneClientUsersWrapper{}
+	mockClient.On("GetUser", mock.Anything, testEnvironmentId, testUserId.String()).Return(userWithAttributes(), &http.Response{StatusCode: 200}, nil)
+	mockClient.On("UpdateUser", mock.Anything, testEnvironmentId, testUserId.String(), mock.Anything).Return(userWithAttributes(), &http.Response{StatusCode: 200}, nil)
+	journal := rollback.NewJournal(rollback.DefaultMaxChanges)
+	ctx := rollback.ContextWithJournal(context.Background(), journal)
+
+	handler := users.UpdateUserAttributesHandler(NewMockPingOneClientUsersWrapperFactory(mockClient, nil))
+	_, output, err := handler(ctx, &mcp.CallToolRequest{}, users.UpdateUserAttributesInput{
+		EnvironmentId: testEnvironmentId,
+		UserId:        testUserId,
+		MobilePhone:   testutils.Pointer("+1 555 0100"),
+	})
+	require.NoError(t, err)
+	assert.True(t, output.Changed)
+	assert.Empty(t, journal.Changes(testEnvironmentId.String()), "the mobile phone had no value to restore")
+}
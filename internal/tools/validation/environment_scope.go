@@ -0,0 +1,31 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package validation
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// EnvironmentScope restricts the environments that tools can act on, so that the server can be
+// handed to a team that should only access specific environments. The zero value applies no restrictions.
+type EnvironmentScope struct {
+	// AllowedEnvironmentIds, if not empty, lists the only environments that tools can act on
+	AllowedEnvironmentIds []uuid.UUID
+	// DeniedEnvironmentIds lists environments that tools cannot act on, taking priority over AllowedEnvironmentIds
+	DeniedEnvironmentIds []uuid.UUID
+}
+
+// IsEmpty returns true if the scope does not restrict any environments.
+func (s EnvironmentScope) IsEmpty() bool {
+	return len(s.AllowedEnvironmentIds) == 0 && len(s.DeniedEnvironmentIds) == 0
+}
+
+// Allows returns true if tools can act on the environment.
+func (s EnvironmentScope) Allows(environmentId uuid.UUID) bool {
+	if slices.Contains(s.DeniedEnvironmentIds, environmentId) {
+		return false
+	}
+	return len(s.AllowedEnvironmentIds) == 0 || slices.Contains(s.AllowedEnvironmentIds, environmentId)
+}
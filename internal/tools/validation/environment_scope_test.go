@@ -0,0 +1,28 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvironmentScope(t *testing.T) {
+	allowedEnvId := uuid.New()
+	deniedEnvId := uuid.New()
+
+	assert.True(t, validation.EnvironmentScope{}.IsEmpty())
+	assert.True(t, validation.EnvironmentScope{}.Allows(allowedEnvId))
+
+	allowlist := validation.EnvironmentScope{AllowedEnvironmentIds: []uuid.UUID{allowedEnvId}}
+	assert.False(t, allowlist.IsEmpty())
+	assert.True(t, allowlist.Allows(allowedEnvId))
+	assert.False(t, allowlist.Allows(deniedEnvId))
+
+	denylist := validation.EnvironmentScope{DeniedEnvironmentIds: []uuid.UUID{deniedEnvId}}
+	assert.True(t, denylist.Allows(allowedEnvId))
+	assert.False(t, denylist.Allows(deniedEnvId))
+}
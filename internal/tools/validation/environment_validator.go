@@ -5,7 +5,9 @@ package validation
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pingidentity/pingone-go-client/pingone"
@@ -30,14 +32,66 @@ type EnvironmentValidator interface {
 	ValidateEnvironment(ctx context.Context, environmentId uuid.UUID, operationType OperationType) error
 }
 
+const (
+	// DefaultEnvironmentCacheTTL is the time a cached environment is used before it is looked up again.
+	DefaultEnvironmentCacheTTL = time.Hour
+	// DefaultEnvironmentCacheMaxEntries is the maximum number of environments held in the cache.
+	DefaultEnvironmentCacheMaxEntries = 1000
+)
+
+// EnvironmentCacheOptions bounds the environment cache of the CachingEnvironmentValidator.
+// A TTL or MaxEntries of zero disables caching.
+type EnvironmentCacheOptions struct {
+	// TTL is the time a cached environment is used before it is looked up again
+	TTL time.Duration
+	// MaxEntries is the maximum number of cached environments. When full, the entry closest to expiry is evicted
+	MaxEntries int
+}
+
+// DefaultEnvironmentCacheOptions returns the cache options used when none are configured.
+func DefaultEnvironmentCacheOptions() EnvironmentCacheOptions {
+	return EnvironmentCacheOptions{
+		TTL:        DefaultEnvironmentCacheTTL,
+		MaxEntries: DefaultEnvironmentCacheMaxEntries,
+	}
+}
+
+// Validate returns an error if the options are negative.
+func (o EnvironmentCacheOptions) Validate() error {
+	if o.TTL < 0 {
+		return fmt.Errorf("environment cache TTL must not be negative")
+	}
+	if o.MaxEntries < 0 {
+		return fmt.Errorf("environment cache max entries must not be negative")
+	}
+	return nil
+}
+
+func (o EnvironmentCacheOptions) enabled() bool {
+	return o.TTL > 0 && o.MaxEntries > 0
+}
+
+type cachedEnvironment struct {
+	env       *pingone.EnvironmentResponse
+	expiresAt time.Time
+}
+
 // CachingEnvironmentValidator validates environments with caching to reduce API calls.
 // Only PRODUCTION environments are cached after successful validation, as PRODUCTION
 // environments cannot be downgraded to SANDBOX (ensuring cache consistency).
 // SANDBOX environments are not cached since they can be upgraded to PRODUCTION.
+// Cached environments expire after the cache TTL, so that deleted or changed environments are
+// looked up again, and the cache is bounded to a maximum number of entries.
 // For write operations, it enforces that the environment type is not PRODUCTION.
+// The production access policy allows operators to override the PRODUCTION restrictions.
 type CachingEnvironmentValidator struct {
-	clientFactory environments.EnvironmentsClientFactory
-	cache         sync.Map // uuid.UUID -> *pingone.EnvironmentResponse
+	clientFactory          environments.EnvironmentsClientFactory
+	productionAccessPolicy ProductionAccessPolicy
+	cacheOptions           EnvironmentCacheOptions
+	now                    func() time.Time
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedEnvironment
 }
 
 // NewCachingEnvironmentValidator creates a new caching environment validator.
@@ -45,28 +99,57 @@ type CachingEnvironmentValidator struct {
 // and caches successful validations to improve performance.
 // The initializeAuthContext function is called to establish authentication before
 // making API calls, ensuring the context has a valid auth session.
-func NewCachingEnvironmentValidator(clientFactory environments.EnvironmentsClientFactory) *CachingEnvironmentValidator {
+// The productionAccessPolicy lists operator overrides of the PRODUCTION restrictions, and
+// may be the zero value to apply no overrides.
+// The cacheOptions bound the lifetime and number of cached environments.
+func NewCachingEnvironmentValidator(clientFactory environments.EnvironmentsClientFactory, productionAccessPolicy ProductionAccessPolicy, cacheOptions EnvironmentCacheOptions) *CachingEnvironmentValidator {
 	return &CachingEnvironmentValidator{
-		clientFactory: clientFactory,
-		cache:         sync.Map{},
+		clientFactory:          clientFactory,
+		productionAccessPolicy: productionAccessPolicy,
+		cacheOptions:           cacheOptions,
+		now:                    time.Now,
+		cache:                  make(map[uuid.UUID]cachedEnvironment),
 	}
 }
 
+// StartCacheExpiry removes expired environments from the cache in the background until the context is done,
+// so that environments that are no longer used do not hold memory in long-running servers.
+func (v *CachingEnvironmentValidator) StartCacheExpiry(ctx context.Context) {
+	if !v.cacheOptions.enabled() {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(v.cacheOptions.TTL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if removed := v.RemoveExpired(); removed > 0 {
+					logger.FromContext(ctx).Debug("Removed expired environments from validation cache", slog.Int("count", removed))
+				}
+			}
+		}
+	}()
+}
+
 // ValidateEnvironment checks if the given environment exists and is accessible.
 // It first checks the cache, and if not found, makes an API call to verify the environment.
 // Only PRODUCTION environments are cached after successful validation, as they cannot be
 // downgraded to SANDBOX (ensuring cache consistency).
 // By default, both READ and WRITE operations on PRODUCTION environments are restricted
 // to prevent unintended access or changes. Tools can opt-in to PRODUCTION access via
-// their validation policy (AllowProductionEnvironmentRead or AllowProductionEnvironmentWrite).
+// their validation policy (AllowProductionEnvironmentRead or AllowProductionEnvironmentWrite),
+// and operators can opt in via the validator's production access policy.
 // Returns an error if:
 //   - The environment does not exist or is not accessible
 //   - The operation type is not allowed on the PRODUCTION environment
 func (v *CachingEnvironmentValidator) ValidateEnvironment(ctx context.Context, environmentId uuid.UUID, operationType OperationType) error {
 	// Check cache first
-	if cachedEnv, ok := v.cache.Load(environmentId); ok {
-		env := cachedEnv.(*pingone.EnvironmentResponse)
-		return v.validateEnvironmentType(env, operationType)
+	if env := v.loadFromCache(environmentId); env != nil {
+		return v.validateEnvironmentType(ctx, env, operationType)
 	}
 
 	// Get authenticated client
@@ -95,24 +178,33 @@ func (v *CachingEnvironmentValidator) ValidateEnvironment(ctx context.Context, e
 	// PRODUCTION environments cannot be downgraded to SANDBOX, so caching is safe
 	// SANDBOX environments can be upgraded to PRODUCTION, so we should not cache them
 	if httpResponse != nil && httpResponse.StatusCode >= 200 && httpResponse.StatusCode < 300 && envResponse != nil && envResponse.Type == pingone.ENVIRONMENTTYPEVALUE_PRODUCTION {
-		v.cache.Store(environmentId, envResponse)
+		v.storeInCache(environmentId, envResponse)
 	}
 
 	// Validate environment type for write operations
-	return v.validateEnvironmentType(envResponse, operationType)
+	return v.validateEnvironmentType(ctx, envResponse, operationType)
 }
 
 // validateEnvironmentType checks if the operation type is allowed for the given environment.
 // By default, both READ and WRITE operations on PRODUCTION environments are restricted to prevent
 // unintended access or breaking changes. This safeguard ensures PRODUCTION environments are protected
-// unless tools explicitly opt-in via their validation policy.
-func (v *CachingEnvironmentValidator) validateEnvironmentType(env *pingone.EnvironmentResponse, operationType OperationType) error {
+// unless tools explicitly opt-in via their validation policy, or the production access policy allows the operation.
+// Operations allowed by the production access policy are logged, so that the override can be audited.
+func (v *CachingEnvironmentValidator) validateEnvironmentType(ctx context.Context, env *pingone.EnvironmentResponse, operationType OperationType) error {
 	if env == nil {
 		return fmt.Errorf("environment response is nil")
 	}
 
 	// Restrict both READ and WRITE operations on PRODUCTION environments by default
 	if env.Type == pingone.ENVIRONMENTTYPEVALUE_PRODUCTION {
+		if allowed, reason := v.productionAccessPolicy.Allows(env.Id, operationType); allowed {
+			logger.FromContext(ctx).Warn("PRODUCTION environment restriction overridden by production access policy",
+				slog.String("environmentId", env.Id.String()),
+				slog.String("environmentName", env.Name),
+				slog.String("operationType", string(operationType)),
+				slog.String("reason", reason))
+			return nil
+		}
 		if operationType == OperationTypeWrite {
 			return fmt.Errorf("to safeguard against unintended or breaking changes, this write operation is not allowed against PRODUCTION environments (environment ID: %s, name: %s)", env.Id, env.Name)
 		}
@@ -124,17 +216,79 @@ func (v *CachingEnvironmentValidator) validateEnvironmentType(env *pingone.Envir
 	return nil
 }
 
+// loadFromCache returns the cached environment, or nil if it is not cached or has expired.
+func (v *CachingEnvironmentValidator) loadFromCache(environmentId uuid.UUID) *pingone.EnvironmentResponse {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	cached, ok := v.cache[environmentId]
+	if !ok {
+		return nil
+	}
+	if !v.now().Before(cached.expiresAt) {
+		delete(v.cache, environmentId)
+		return nil
+	}
+	return cached.env
+}
+
+// storeInCache caches the environment until the cache TTL elapses. If the cache is full,
+// the environment closest to expiry is evicted to make room.
+func (v *CachingEnvironmentValidator) storeInCache(environmentId uuid.UUID, env *pingone.EnvironmentResponse) {
+	if !v.cacheOptions.enabled() {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if _, ok := v.cache[environmentId]; !ok && len(v.cache) >= v.cacheOptions.MaxEntries {
+		var evictId uuid.UUID
+		var evictAt time.Time
+		for id, cached := range v.cache {
+			if evictAt.IsZero() || cached.expiresAt.Before(evictAt) {
+				evictId, evictAt = id, cached.expiresAt
+			}
+		}
+		delete(v.cache, evictId)
+	}
+
+	v.cache[environmentId] = cachedEnvironment{
+		env:       env,
+		expiresAt: v.now().Add(v.cacheOptions.TTL),
+	}
+}
+
+// RemoveExpired removes all expired environments from the cache, and returns the number removed.
+func (v *CachingEnvironmentValidator) RemoveExpired() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	removed := 0
+	for id, cached := range v.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(v.cache, id)
+			removed++
+		}
+	}
+	return removed
+}
+
 // ClearCache removes all cached environment validations.
 // This can be useful in testing or when you want to force revalidation.
 func (v *CachingEnvironmentValidator) ClearCache() {
-	v.cache.Range(func(key, value interface{}) bool {
-		v.cache.Delete(key)
-		return true
-	})
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	clear(v.cache)
 }
 
 // RemoveFromCache removes a specific environment from the cache.
 // This can be useful when an environment is deleted or becomes inaccessible.
 func (v *CachingEnvironmentValidator) RemoveFromCache(environmentId uuid.UUID) {
-	v.cache.Delete(environmentId)
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	delete(v.cache, environmentId)
 }
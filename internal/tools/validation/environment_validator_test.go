@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pingidentity/pingone-go-client/pingone"
@@ -46,7 +47,7 @@ func TestCachingEnvironmentValidator_ValidateEnvironment_Success(t *testing.T) {
 	// SANDBOX environments are not cached, so expect multiple API calls
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Times(3)
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// First call should hit the API (read operation)
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
@@ -75,7 +76,7 @@ func TestCachingEnvironmentValidator_ValidateEnvironment_NotFound(t *testing.T)
 
 	mockClient.On("GetEnvironment", ctx, envId).Return(nil, resp, apiErr)
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
 	assert.Error(t, err)
@@ -89,7 +90,7 @@ func TestCachingEnvironmentValidator_ValidateEnvironment_ClientFactoryError(t *t
 
 	mockFactory := &mockEnvironmentsClientFactory{client: nil}
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
 	assert.Error(t, err)
@@ -108,7 +109,7 @@ func TestCachingEnvironmentValidator_ValidateEnvironment_NilEnvironmentResponse(
 	// API returns success but nil environment (should not happen in practice but code handles it)
 	mockClient.On("GetEnvironment", ctx, envId).Return(nil, resp, nil)
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
 	assert.Error(t, err)
@@ -134,7 +135,7 @@ func TestCachingEnvironmentValidator_ClearCache(t *testing.T) {
 	// Expect two API calls since we'll clear cache
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Twice()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// Validate to populate cache (READ will be blocked on PRODUCTION)
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
@@ -169,7 +170,7 @@ func TestCachingEnvironmentValidator_RemoveFromCache(t *testing.T) {
 	// Expect two API calls since we'll remove from cache
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Twice()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// Validate to populate cache (READ will be blocked on PRODUCTION)
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
@@ -202,7 +203,7 @@ func TestCachingEnvironmentValidator_ProductionEnvironment_ReadBlocked(t *testin
 
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Once()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// Read operations should NOT be allowed on PRODUCTION environments by default
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
@@ -228,7 +229,7 @@ func TestCachingEnvironmentValidator_ProductionEnvironment_WriteBlocked(t *testi
 
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Once()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// Write operations should NOT be allowed on PRODUCTION environments
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeWrite)
@@ -254,7 +255,7 @@ func TestCachingEnvironmentValidator_SandboxEnvironment_WriteAllowed(t *testing.
 
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Once()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// Write operations should be allowed on SANDBOX environments
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeWrite)
@@ -278,7 +279,7 @@ func TestCachingEnvironmentValidator_SandboxEnvironment_ReadAllowed(t *testing.T
 
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Once()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// Read operations should be allowed on SANDBOX environments
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
@@ -303,7 +304,7 @@ func TestCachingEnvironmentValidator_SandboxEnvironment_NotCached(t *testing.T)
 	// SANDBOX environments should NOT be cached, expect API call each time
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Twice()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// First read operation
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
@@ -334,7 +335,7 @@ func TestCachingEnvironmentValidator_ProductionEnvironment_IsCached(t *testing.T
 	// PRODUCTION environments should be cached, expect only one API call
 	mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Once()
 
-	validator := NewCachingEnvironmentValidator(mockFactory)
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{}, DefaultEnvironmentCacheOptions())
 
 	// First read operation - populates cache (and gets blocked)
 	err := validator.ValidateEnvironment(ctx, envId, OperationTypeRead)
@@ -358,3 +359,169 @@ func TestCachingEnvironmentValidator_ProductionEnvironment_IsCached(t *testing.T
 	// Verify only one API call was made (all subsequent calls used cache)
 	mockClient.AssertExpectations(t)
 }
+
+func TestCachingEnvironmentValidator_ProductionEnvironment_AccessPolicy(t *testing.T) {
+	envId := uuid.New()
+	otherEnvId := uuid.New()
+
+	tests := []struct {
+		name          string
+		policy        ProductionAccessPolicy
+		operationType OperationType
+		wantErr       bool
+	}{
+		{name: "read allowed by policy", policy: ProductionAccessPolicy{AllowRead: true}, operationType: OperationTypeRead},
+		{name: "write blocked by read policy", policy: ProductionAccessPolicy{AllowRead: true}, operationType: OperationTypeWrite, wantErr: true},
+		{name: "write allowed by policy", policy: ProductionAccessPolicy{AllowWrite: true}, operationType: OperationTypeWrite},
+		{name: "read blocked by write policy", policy: ProductionAccessPolicy{AllowWrite: true}, operationType: OperationTypeRead, wantErr: true},
+		{name: "read allowed by environment allowlist", policy: ProductionAccessPolicy{AllowedEnvironmentIds: []uuid.UUID{envId}}, operationType: OperationTypeRead},
+		{name: "write allowed by environment allowlist", policy: ProductionAccessPolicy{AllowedEnvironmentIds: []uuid.UUID{envId}}, operationType: OperationTypeWrite},
+		{name: "write blocked for environment not in allowlist", policy: ProductionAccessPolicy{AllowedEnvironmentIds: []uuid.UUID{otherEnvId}}, operationType: OperationTypeWrite, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			mockClient := new(testutils.MockEnvironmentsClient)
+			mockFactory := &mockEnvironmentsClientFactory{client: mockClient}
+
+			env := &pingone.EnvironmentResponse{
+				Id:   envId,
+				Name: "Production Environment",
+				Type: pingone.ENVIRONMENTTYPEVALUE_PRODUCTION,
+			}
+			resp := &http.Response{StatusCode: 200}
+
+			mockClient.On("GetEnvironment", ctx, envId).Return(env, resp, nil).Once()
+
+			validator := NewCachingEnvironmentValidator(mockFactory, tt.policy, DefaultEnvironmentCacheOptions())
+
+			err := validator.ValidateEnvironment(ctx, envId, tt.operationType)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func newProductionEnvironment(envId uuid.UUID) *pingone.EnvironmentResponse {
+	return &pingone.EnvironmentResponse{
+		Id:   envId,
+		Name: "Production Environment",
+		Type: pingone.ENVIRONMENTTYPEVALUE_PRODUCTION,
+	}
+}
+
+func TestCachingEnvironmentValidator_CacheExpiresAfterTTL(t *testing.T) {
+	ctx := context.Background()
+	envId := uuid.New()
+
+	mockClient := new(testutils.MockEnvironmentsClient)
+	mockFactory := &mockEnvironmentsClientFactory{client: mockClient}
+	resp := &http.Response{StatusCode: 200}
+
+	// Expect a second API call once the cached environment has expired
+	mockClient.On("GetEnvironment", ctx, envId).Return(newProductionEnvironment(envId), resp, nil).Twice()
+
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{AllowRead: true}, EnvironmentCacheOptions{TTL: time.Minute, MaxEntries: 10})
+	now := time.Now()
+	validator.now = func() time.Time { return now }
+
+	assert.NoError(t, validator.ValidateEnvironment(ctx, envId, OperationTypeRead))
+
+	// Within the TTL, the cached environment is used
+	now = now.Add(30 * time.Second)
+	assert.NoError(t, validator.ValidateEnvironment(ctx, envId, OperationTypeRead))
+
+	// After the TTL, the environment is looked up again
+	now = now.Add(time.Minute)
+	assert.NoError(t, validator.ValidateEnvironment(ctx, envId, OperationTypeRead))
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCachingEnvironmentValidator_CacheMaxEntries(t *testing.T) {
+	ctx := context.Background()
+	firstEnvId := uuid.New()
+	secondEnvId := uuid.New()
+
+	mockClient := new(testutils.MockEnvironmentsClient)
+	mockFactory := &mockEnvironmentsClientFactory{client: mockClient}
+	resp := &http.Response{StatusCode: 200}
+
+	// The first environment is evicted when the second is cached, so is looked up twice
+	mockClient.On("GetEnvironment", ctx, firstEnvId).Return(newProductionEnvironment(firstEnvId), resp, nil).Twice()
+	mockClient.On("GetEnvironment", ctx, secondEnvId).Return(newProductionEnvironment(secondEnvId), resp, nil).Once()
+
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{AllowRead: true}, EnvironmentCacheOptions{TTL: time.Hour, MaxEntries: 1})
+	now := time.Now()
+	validator.now = func() time.Time { return now }
+
+	assert.NoError(t, validator.ValidateEnvironment(ctx, firstEnvId, OperationTypeRead))
+	now = now.Add(time.Second)
+	assert.NoError(t, validator.ValidateEnvironment(ctx, secondEnvId, OperationTypeRead))
+	assert.Len(t, validator.cache, 1)
+
+	assert.NoError(t, validator.ValidateEnvironment(ctx, firstEnvId, OperationTypeRead))
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCachingEnvironmentValidator_CacheDisabled(t *testing.T) {
+	ctx := context.Background()
+	envId := uuid.New()
+
+	mockClient := new(testutils.MockEnvironmentsClient)
+	mockFactory := &mockEnvironmentsClientFactory{client: mockClient}
+	resp := &http.Response{StatusCode: 200}
+
+	mockClient.On("GetEnvironment", ctx, envId).Return(newProductionEnvironment(envId), resp, nil).Twice()
+
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{AllowRead: true}, EnvironmentCacheOptions{})
+
+	assert.NoError(t, validator.ValidateEnvironment(ctx, envId, OperationTypeRead))
+	assert.NoError(t, validator.ValidateEnvironment(ctx, envId, OperationTypeRead))
+	assert.Empty(t, validator.cache)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestCachingEnvironmentValidator_RemoveExpired(t *testing.T) {
+	ctx := context.Background()
+	firstEnvId := uuid.New()
+	secondEnvId := uuid.New()
+
+	mockClient := new(testutils.MockEnvironmentsClient)
+	mockFactory := &mockEnvironmentsClientFactory{client: mockClient}
+	resp := &http.Response{StatusCode: 200}
+
+	mockClient.On("GetEnvironment", ctx, firstEnvId).Return(newProductionEnvironment(firstEnvId), resp, nil).Once()
+	mockClient.On("GetEnvironment", ctx, secondEnvId).Return(newProductionEnvironment(secondEnvId), resp, nil).Once()
+
+	validator := NewCachingEnvironmentValidator(mockFactory, ProductionAccessPolicy{AllowRead: true}, EnvironmentCacheOptions{TTL: time.Minute, MaxEntries: 10})
+	now := time.Now()
+	validator.now = func() time.Time { return now }
+
+	assert.NoError(t, validator.ValidateEnvironment(ctx, firstEnvId, OperationTypeRead))
+	now = now.Add(45 * time.Second)
+	assert.NoError(t, validator.ValidateEnvironment(ctx, secondEnvId, OperationTypeRead))
+
+	// Only the first environment has expired
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 1, validator.RemoveExpired())
+	assert.Len(t, validator.cache, 1)
+	assert.Contains(t, validator.cache, secondEnvId)
+
+	mockClient.AssertExpectations(t)
+}
+
+func TestEnvironmentCacheOptions_Validate(t *testing.T) {
+	assert.NoError(t, DefaultEnvironmentCacheOptions().Validate())
+	assert.NoError(t, EnvironmentCacheOptions{}.Validate())
+	assert.Error(t, EnvironmentCacheOptions{TTL: -time.Second, MaxEntries: 1}.Validate())
+	assert.Error(t, EnvironmentCacheOptions{TTL: time.Second, MaxEntries: -1}.Validate())
+}
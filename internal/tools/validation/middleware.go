@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/pingidentity/pingone-mcp-server/internal/logger"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 )
 
@@ -50,23 +51,42 @@ func (r *DefaultToolRegistry) GetTool(name string) *types.ToolDefinition {
 // 1. Environment exists and is accessible
 // 2. For write operations, environment is not PRODUCTION type
 //
+// The production guardrail determines whether tool validation policies are honoured
+// (strict) or whether all read-only tools are allowed on PRODUCTION environments (read-only).
+//
+// The environment scope is checked before any other validation, rejecting calls that target
+// environments outside the scope without calling the PingOne API, regardless of tool validation policies.
+// When a scope is set, tools that create environments are rejected, and tools that list or count the
+// environments of the organization only include the environments within the scope.
+//
+// Tools that read from each environment of the organization (e.g., get_license_utilization) validate every
+// environment before reading from it, as if they had been called for it, and skip the environments that fail.
+//
 // This middleware should be added to the MCP server via AddReceivingMiddleware.
 // Tools without an environmentId parameter are not validated (e.g., list_environments).
 type EnvironmentValidationMiddleware struct {
-	validator    EnvironmentValidator
-	toolRegistry ToolRegistry
+	validator           EnvironmentValidator
+	toolRegistry        ToolRegistry
+	productionGuardrail ProductionGuardrail
+	environmentScope    EnvironmentScope
 }
 
 // NewEnvironmentValidationMiddleware creates middleware with validator and tool registry.
 // The validator is used to check environment access and type.
 // The toolRegistry is used to determine if a tool is read-only or performs write operations.
+// The productionGuardrail selects how PRODUCTION environments are protected.
+// The environmentScope restricts the environments tools can act on, and may be the zero value to apply no restrictions.
 func NewEnvironmentValidationMiddleware(
 	validator EnvironmentValidator,
 	toolRegistry ToolRegistry,
+	productionGuardrail ProductionGuardrail,
+	environmentScope EnvironmentScope,
 ) *EnvironmentValidationMiddleware {
 	return &EnvironmentValidationMiddleware{
-		validator:    validator,
-		toolRegistry: toolRegistry,
+		validator:           validator,
+		toolRegistry:        toolRegistry,
+		productionGuardrail: productionGuardrail,
+		environmentScope:    environmentScope,
 	}
 }
 
@@ -86,68 +106,179 @@ func (m *EnvironmentValidationMiddleware) Handler(next mcp.MethodHandler) mcp.Me
 			return nil, fmt.Errorf("environment validation failed: %w", fmt.Errorf("invalid tool call request"))
 		}
 
-		toolName := callToolReq.Params.Name
+		if err := m.ValidateToolCall(ctx, callToolReq.Params.Name, callToolReq.Params.Arguments); err != nil {
+			return nil, err
+		}
+
+		// Tools that list or count the environments of the organization only include those within the scope
+		if !m.environmentScope.IsEmpty() {
+			ctx = environments.ContextWithEnvironmentFilter(ctx, m.environmentScope.Allows)
+		}
+		// Tools that read from each environment of the organization validate the environments they read
+		ctx = environments.ContextWithEnvironmentReadValidator(ctx, m.environmentReadValidator(callToolReq.Params.Name))
 
-		// Lookup tool definition
-		toolDef := m.toolRegistry.GetTool(toolName)
+		// Validation passed, continue to tool handler
+		return next(ctx, method, req)
+	}
+}
 
-		// Determine operation type from tool definition
-		operationType := determineOperationType(toolDef)
+// ValidateToolCall validates the environment a call of the tool with the given arguments acts on, as the
+// middleware does before calling the tool. This lets callers validate tool calls before making them, such as
+// all the steps of a plan before running the first.
+func (m *EnvironmentValidationMiddleware) ValidateToolCall(ctx context.Context, toolName string, arguments json.RawMessage) error {
+	// Lookup tool definition
+	toolDef := m.toolRegistry.GetTool(toolName)
 
-		// Check if validation should be skipped based on tool policy and operation type
-		if shouldSkipEnvironmentValidation(toolDef, operationType) {
-			// Skip environment validation for this tool as per its validation policy
-			logger.FromContext(ctx).Debug("Skipping environment validation for tool",
-				slog.String("tool", toolName),
-				slog.String("operationType", string(operationType)),
-				slog.String("reason", "validation policy allows operation"))
-			return next(ctx, method, req)
+	// Check the environment scope before any validation policy can skip validation
+	if toolDef != nil && !m.environmentScope.IsEmpty() {
+		if err := m.validateEnvironmentScope(ctx, toolDef, arguments); err != nil {
+			return fmt.Errorf("environment validation failed: %w", err)
+		}
+	}
+
+	// Determine operation type from tool definition
+	operationType := determineOperationType(toolDef)
+
+	// Check if validation should be skipped based on tool policy and operation type
+	if shouldSkipEnvironmentValidation(toolDef, operationType, m.productionGuardrail) {
+		// Skip environment validation for this tool as per its validation policy
+		logger.FromContext(ctx).Debug("Skipping environment validation for tool",
+			slog.String("tool", toolName),
+			slog.String("operationType", string(operationType)),
+			slog.String("reason", "validation policy allows operation"))
+		return nil
+	}
+
+	// Extract environmentId from parameters
+	environmentId, hasEnvId, err := extractEnvironmentId(arguments)
+	if err != nil {
+		// Failed to parse arguments, validation is mandatory, so we fail the call
+		logger.FromContext(ctx).Error("Failed to parse tool arguments",
+			slog.String("tool", toolName),
+			slog.String("error", err.Error()))
+		return fmt.Errorf("environment validation failed: %w", err)
+	}
+	if !hasEnvId {
+		// Tool doesn't use environmentId, validation is mandatory, so we fail the call
+		logger.FromContext(ctx).Error("Tool requires environment validation, but no environmentId was found to validate",
+			slog.String("tool", toolName))
+		return fmt.Errorf("environment validation failed: %w", err)
+	}
+	if environmentId == nil {
+		// Tool does use environmentId, but for whatever reason wasn't returned, validation is mandatory, so we fail the call
+		logger.FromContext(ctx).Error("Tool requires environment validation, environmentId is expected, but no environmentId was found",
+			slog.String("tool", toolName))
+		return fmt.Errorf("environment validation failed: %w", err)
+	}
+
+	logger.FromContext(ctx).Debug("Validating environment for tool",
+		slog.String("tool", toolName),
+		slog.String("environmentId", environmentId.String()),
+		slog.String("operationType", string(operationType)))
+
+	// Validate environment
+	if err := m.validator.ValidateEnvironment(ctx, *environmentId, operationType); err != nil {
+		logger.FromContext(ctx).Error("Environment validation failed",
+			slog.String("tool", toolName),
+			slog.String("environmentId", environmentId.String()),
+			slog.String("operationType", string(operationType)),
+			slog.String("error", err.Error()))
+		return fmt.Errorf("environment validation failed: %w", err)
+	}
+
+	logger.FromContext(ctx).Debug("Environment validation passed",
+		slog.String("tool", toolName),
+		slog.String("environmentId", environmentId.String()))
+	return nil
+}
+
+// environmentReadValidator returns the validator of the environments that a call of the tool reads from, other than
+// the environment of its arguments. The environment must be within the environment scope, and is validated as a read
+// of the tool, so PRODUCTION environments are rejected unless the production guardrail, the tool's validation policy
+// or the production access policy allows them to be read.
+func (m *EnvironmentValidationMiddleware) environmentReadValidator(toolName string) func(ctx context.Context, environmentId uuid.UUID) error {
+	return func(ctx context.Context, environmentId uuid.UUID) error {
+		if !m.environmentScope.Allows(environmentId) {
+			return fmt.Errorf("this server is not permitted to act on environment %s", environmentId)
 		}
+		toolDef := m.toolRegistry.GetTool(toolName)
+		if m.productionGuardrail == ProductionGuardrailReadOnly || (toolDef != nil && toolDef.ValidationPolicy != nil && toolDef.ValidationPolicy.AllowProductionEnvironmentRead) {
+			return nil
+		}
+		return m.validator.ValidateEnvironment(ctx, environmentId, OperationTypeRead)
+	}
+}
 
-		// Extract environmentId from parameters
-		environmentId, hasEnvId, err := extractEnvironmentId(callToolReq.Params.Arguments)
+// validateEnvironmentScope checks that the environment targeted by the tool call, and any further environments the
+// tool reads from, are within the environment scope, and that the tool does not create environments, as new
+// environments are not within the scope.
+// Tools that list or count the environments of the organization (e.g., list_environments) are not rejected, as the
+// handler filters their results to the scope.
+func (m *EnvironmentValidationMiddleware) validateEnvironmentScope(ctx context.Context, toolDef *types.ToolDefinition, argsJSON json.RawMessage) error {
+	toolName := toolDef.McpTool.Name
+	if toolDef.ValidationPolicy != nil && toolDef.ValidationPolicy.CreatesEnvironment {
+		logger.FromContext(ctx).Error("Tool creates environments, which are outside the environment scope",
+			slog.String("tool", toolName))
+		return fmt.Errorf("this server is restricted to an environment scope, so it is not permitted to create environments")
+	}
+	environmentId, _, err := extractEnvironmentId(argsJSON)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to parse tool arguments",
+			slog.String("tool", toolName),
+			slog.String("error", err.Error()))
+		return err
+	}
+	environmentIds := []uuid.UUID{}
+	if environmentId != nil {
+		environmentIds = append(environmentIds, *environmentId)
+	}
+	if toolDef.ValidationPolicy != nil && len(toolDef.ValidationPolicy.ReadEnvironmentIdArguments) > 0 {
+		readEnvironmentIds, err := extractEnvironmentIdArguments(argsJSON, toolDef.ValidationPolicy.ReadEnvironmentIdArguments)
 		if err != nil {
-			// Failed to parse arguments, validation is mandatory, so we fail the call
 			logger.FromContext(ctx).Error("Failed to parse tool arguments",
 				slog.String("tool", toolName),
 				slog.String("error", err.Error()))
-			return nil, fmt.Errorf("environment validation failed: %w", err)
+			return err
 		}
-		if !hasEnvId {
-			// Tool doesn't use environmentId, validation is mandatory, so we fail the call
-			logger.FromContext(ctx).Error("Tool requires environment validation, but no environmentId was found to validate",
-				slog.String("tool", toolName))
-			return nil, fmt.Errorf("environment validation failed: %w", err)
-		}
-		if environmentId == nil {
-			// Tool does use environmentId, but for whatever reason wasn't returned, validation is mandatory, so we fail the call
-			logger.FromContext(ctx).Error("Tool requires environment validation, environmentId is expected, but no environmentId was found",
-				slog.String("tool", toolName))
-			return nil, fmt.Errorf("environment validation failed: %w", err)
-		}
-
-		logger.FromContext(ctx).Debug("Validating environment for tool",
-			slog.String("tool", toolName),
-			slog.String("environmentId", environmentId.String()),
-			slog.String("operationType", string(operationType)))
+		environmentIds = append(environmentIds, readEnvironmentIds...)
+	}
 
-		// Validate environment
-		if err := m.validator.ValidateEnvironment(ctx, *environmentId, operationType); err != nil {
-			logger.FromContext(ctx).Error("Environment validation failed",
+	for _, environmentId := range environmentIds {
+		if !m.environmentScope.Allows(environmentId) {
+			logger.FromContext(ctx).Error("Environment is outside the environment scope",
 				slog.String("tool", toolName),
-				slog.String("environmentId", environmentId.String()),
-				slog.String("operationType", string(operationType)),
-				slog.String("error", err.Error()))
-			return nil, fmt.Errorf("environment validation failed: %w", err)
+				slog.String("environmentId", environmentId.String()))
+			return fmt.Errorf("this server is not permitted to act on environment %s", environmentId)
 		}
+	}
+	return nil
+}
 
-		logger.FromContext(ctx).Debug("Environment validation passed",
-			slog.String("tool", toolName),
-			slog.String("environmentId", environmentId.String()))
+// extractEnvironmentIdArguments extracts the environment IDs of the named arguments from tool call arguments.
+// Arguments that are not set are ignored.
+func extractEnvironmentIdArguments(argsJSON json.RawMessage, names []string) ([]uuid.UUID, error) {
+	var args map[string]any
+	if err := json.Unmarshal(argsJSON, &args); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal arguments: %w", err)
+	}
 
-		// Validation passed, continue to tool handler
-		return next(ctx, method, req)
+	environmentIds := []uuid.UUID{}
+	for _, name := range names {
+		envIdRaw, ok := args[name]
+		if !ok || envIdRaw == nil {
+			continue
+		}
+		envIdStr, ok := envIdRaw.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s format: not a string", name)
+		}
+		parsed, err := uuid.Parse(envIdStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s format: %w", name, err)
+		}
+		environmentIds = append(environmentIds, parsed)
 	}
+	return environmentIds, nil
 }
 
 // extractEnvironmentId extracts the environmentId from tool call arguments.
@@ -196,10 +327,11 @@ func determineOperationType(toolDef *types.ToolDefinition) OperationType {
 // The logic follows this priority:
 // 1. If toolDef is nil, skip validation (unknown tool)
 // 2. If ProductionEnvironmentNotApplicable is true, skip validation (tool doesn't use environmentId)
-// 3. If operation is WRITE and AllowProductionEnvironmentWrite is true, skip validation
-// 4. If operation is READ and AllowProductionEnvironmentRead is true, skip validation
-// 5. Otherwise, perform validation (default restrictive behavior)
-func shouldSkipEnvironmentValidation(toolDef *types.ToolDefinition, operationType OperationType) bool {
+// 3. If the guardrail is read-only, skip validation for READ operations and always validate WRITE operations
+// 4. If operation is WRITE and AllowProductionEnvironmentWrite is true, skip validation
+// 5. If operation is READ and AllowProductionEnvironmentRead is true, skip validation
+// 6. Otherwise, perform validation (default restrictive behavior)
+func shouldSkipEnvironmentValidation(toolDef *types.ToolDefinition, operationType OperationType, productionGuardrail ProductionGuardrail) bool {
 	if toolDef == nil {
 		return true
 	}
@@ -209,7 +341,14 @@ func shouldSkipEnvironmentValidation(toolDef *types.ToolDefinition, operationTyp
 		if toolDef.ValidationPolicy.ProductionEnvironmentNotApplicable {
 			return true
 		}
+	}
+
+	if productionGuardrail == ProductionGuardrailReadOnly {
+		// Read-only tools are always allowed, writes are always validated regardless of tool policy
+		return operationType == OperationTypeRead
+	}
 
+	if toolDef.ValidationPolicy != nil {
 		// Check operation-specific permissions
 		if operationType == OperationTypeWrite && toolDef.ValidationPolicy.AllowProductionEnvironmentWrite {
 			return true
@@ -75,7 +75,7 @@ func TestEnvironmentValidationMiddleware_ReadOperation_OverMcp(t *testing.T) {
 	mockReg.On("GetTool", "list_test_resources").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, validation.OperationTypeRead).Return(nil)
 
-	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg, validation.ProductionGuardrailStrict, validation.EnvironmentScope{})
 
 	// Create a simple handler that returns success
 	successHandler := func(ctx context.Context, req *mcp.CallToolRequest, input testToolInput) (*mcp.CallToolResult, *testToolOutput, error) {
@@ -124,7 +124,7 @@ func TestEnvironmentValidationMiddleware_WriteOperation_OverMcp(t *testing.T) {
 	mockReg.On("GetTool", "create_test_resource").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, validation.OperationTypeWrite).Return(nil)
 
-	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg, validation.ProductionGuardrailStrict, validation.EnvironmentScope{})
 
 	// Create a simple handler that returns success
 	successHandler := func(ctx context.Context, req *mcp.CallToolRequest, input testToolInput) (*mcp.CallToolResult, *testToolOutput, error) {
@@ -177,7 +177,7 @@ func TestEnvironmentValidationMiddleware_ValidationFailure_OverMcp(t *testing.T)
 	mockReg.On("GetTool", "create_test_resource").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, validation.OperationTypeWrite).Return(validationErr)
 
-	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg, validation.ProductionGuardrailStrict, validation.EnvironmentScope{})
 
 	// Create a handler that should not be called
 	handlerCalled := false
@@ -241,7 +241,7 @@ func TestEnvironmentValidationMiddleware_SkipValidation_ProductionNotApplicable_
 	mockReg.On("GetTool", "list_all_resources").Return(toolDef)
 	// Validator should not be called since ProductionEnvironmentNotApplicable is true
 
-	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg, validation.ProductionGuardrailStrict, validation.EnvironmentScope{})
 
 	// Create a simple handler that returns success
 	handlerCalled := false
@@ -292,7 +292,7 @@ func TestEnvironmentValidationMiddleware_InvalidEnvironmentId_OverMcp(t *testing
 	mockReg.On("GetTool", "list_test_resources").Return(toolDef)
 	// Validator should not be called for invalid UUID
 
-	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg, validation.ProductionGuardrailStrict, validation.EnvironmentScope{})
 
 	// Create a handler that should not be called
 	handlerCalled := false
@@ -351,7 +351,7 @@ func TestEnvironmentValidationMiddleware_WithStructuredContent_OverMcp(t *testin
 	mockReg.On("GetTool", "list_test_resources").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, validation.OperationTypeRead).Return(nil)
 
-	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg, validation.ProductionGuardrailStrict, validation.EnvironmentScope{})
 
 	// Create a handler that returns structured content
 	successHandler := func(ctx context.Context, req *mcp.CallToolRequest, input testToolInput) (*mcp.CallToolResult, *resourceListOutput, error) {
@@ -399,3 +399,69 @@ func TestEnvironmentValidationMiddleware_WithStructuredContent_OverMcp(t *testin
 	mockVal.AssertExpectations(t)
 	mockReg.AssertExpectations(t)
 }
+
+// TestEnvironmentValidationMiddleware_ReadOnlyGuardrail_OverMcp tests that the read-only production guardrail
+// allows read-only tools without a production read policy, while write tools are still validated.
+func TestEnvironmentValidationMiddleware_ReadOnlyGuardrail_OverMcp(t *testing.T) {
+	envId := uuid.New()
+	mockVal := new(mockValidatorMiddleware)
+	mockReg := new(mockToolRegistry)
+
+	readToolDef := &types.ToolDefinition{
+		McpTool: &mcp.Tool{
+			Name:         "list_test_resources",
+			Description:  "List test resources in an environment",
+			InputSchema:  schema.MustGenerateSchema[testToolInput](),
+			OutputSchema: schema.MustGenerateSchema[testToolOutput](),
+			Annotations: &mcp.ToolAnnotations{
+				ReadOnlyHint: true,
+			},
+		},
+	}
+	writeToolDef := &types.ToolDefinition{
+		ValidationPolicy: &types.ToolValidationPolicy{
+			AllowProductionEnvironmentWrite: true,
+		},
+		McpTool: &mcp.Tool{
+			Name:         "create_test_resource",
+			Description:  "Create a test resource in an environment",
+			InputSchema:  schema.MustGenerateSchema[testToolInput](),
+			OutputSchema: schema.MustGenerateSchema[testToolOutput](),
+		},
+	}
+
+	mockReg.On("GetTool", "list_test_resources").Return(readToolDef)
+	mockReg.On("GetTool", "create_test_resource").Return(writeToolDef)
+	mockVal.On("ValidateEnvironment", mock.Anything, envId, validation.OperationTypeWrite).Return(errors.New("write operation is not allowed against PRODUCTION environments"))
+
+	middleware := validation.NewEnvironmentValidationMiddleware(mockVal, mockReg, validation.ProductionGuardrailReadOnly, validation.EnvironmentScope{})
+
+	successHandler := func(ctx context.Context, req *mcp.CallToolRequest, input testToolInput) (*mcp.CallToolResult, *testToolOutput, error) {
+		return nil, &testToolOutput{
+			Message: "Operation succeeded",
+		}, nil
+	}
+
+	server := mcptestutils.TestMcpServer(t)
+	server.AddReceivingMiddleware(middleware.Handler)
+	mcp.AddTool(server, readToolDef.McpTool, successHandler)
+	mcp.AddTool(server, writeToolDef.McpTool, successHandler)
+
+	input := testToolInput{
+		EnvironmentId: envId,
+	}
+
+	// Read tool is allowed without environment validation
+	output, err := mcptestutils.CallToolOverMcp(t, server, "list_test_resources", input)
+	require.NoError(t, err)
+	require.NotNil(t, output)
+	assert.False(t, output.IsError)
+
+	// Write tool is validated even though its policy allows production writes
+	_, err = mcptestutils.CallToolOverMcp(t, server, "create_test_resource", input)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not allowed against PRODUCTION environments")
+
+	mockVal.AssertNumberOfCalls(t, "ValidateEnvironment", 1)
+	mockReg.AssertExpectations(t)
+}
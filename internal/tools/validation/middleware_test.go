@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/environments"
 	"github.com/pingidentity/pingone-mcp-server/internal/tools/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -373,7 +374,68 @@ func TestShouldSkipEnvironmentValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := shouldSkipEnvironmentValidation(tt.toolDef, tt.operationType)
+			result := shouldSkipEnvironmentValidation(tt.toolDef, tt.operationType, ProductionGuardrailStrict)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestShouldSkipEnvironmentValidation_ReadOnlyGuardrail(t *testing.T) {
+	tests := []struct {
+		name          string
+		toolDef       *types.ToolDefinition
+		operationType OperationType
+		expected      bool
+	}{
+		{
+			name: "No permissions - READ operation",
+			toolDef: &types.ToolDefinition{
+				McpTool: &mcp.Tool{Name: "restricted_tool"},
+			},
+			operationType: OperationTypeRead,
+			expected:      true, // All read-only tools are allowed
+		},
+		{
+			name: "No permissions - WRITE operation",
+			toolDef: &types.ToolDefinition{
+				McpTool: &mcp.Tool{Name: "restricted_tool"},
+			},
+			operationType: OperationTypeWrite,
+			expected:      false,
+		},
+		{
+			name: "AllowProductionEnvironmentWrite true - WRITE operation",
+			toolDef: &types.ToolDefinition{
+				ValidationPolicy: &types.ToolValidationPolicy{
+					AllowProductionEnvironmentWrite: true,
+				},
+				McpTool: &mcp.Tool{Name: "trusted_write_tool"},
+			},
+			operationType: OperationTypeWrite,
+			expected:      false, // Writes are always validated regardless of tool policy
+		},
+		{
+			name: "ProductionEnvironmentNotApplicable - WRITE operation",
+			toolDef: &types.ToolDefinition{
+				ValidationPolicy: &types.ToolValidationPolicy{
+					ProductionEnvironmentNotApplicable: true,
+				},
+				McpTool: &mcp.Tool{Name: "not_applicable_tool"},
+			},
+			operationType: OperationTypeWrite,
+			expected:      true, // ProductionEnvironmentNotApplicable takes precedence
+		},
+		{
+			name:          "Nil tool definition",
+			toolDef:       nil,
+			operationType: OperationTypeRead,
+			expected:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := shouldSkipEnvironmentValidation(tt.toolDef, tt.operationType, ProductionGuardrailReadOnly)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -442,7 +504,7 @@ func TestEnvironmentValidationMiddleware_NonToolCall(t *testing.T) {
 	mockVal := new(mockValidatorMiddleware)
 	mockReg := new(mockToolRegistry)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -466,7 +528,7 @@ func TestEnvironmentValidationMiddleware_InvalidRequestType(t *testing.T) {
 	mockVal := new(mockValidatorMiddleware)
 	mockReg := new(mockToolRegistry)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -504,7 +566,7 @@ func TestEnvironmentValidationMiddleware_ToolWithoutEnvironmentId(t *testing.T)
 	}
 	mockReg.On("GetTool", "list_environments").Return(toolDef)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -546,7 +608,7 @@ func TestEnvironmentValidationMiddleware_ReadOperation_Success(t *testing.T) {
 	mockReg.On("GetTool", "list_populations").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, OperationTypeRead).Return(nil)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -583,7 +645,7 @@ func TestEnvironmentValidationMiddleware_WriteOperation_Success(t *testing.T) {
 	mockReg.On("GetTool", "create_population").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, OperationTypeWrite).Return(nil)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -623,7 +685,7 @@ func TestEnvironmentValidationMiddleware_ValidationError(t *testing.T) {
 	mockReg.On("GetTool", "create_population").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, OperationTypeWrite).Return(validationErr)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -648,6 +710,35 @@ func TestEnvironmentValidationMiddleware_ValidationError(t *testing.T) {
 	mockReg.AssertExpectations(t)
 }
 
+func TestEnvironmentValidationMiddleware_ValidateToolCall(t *testing.T) {
+	envId := uuid.New()
+	mockVal := new(mockValidatorMiddleware)
+	mockReg := new(mockToolRegistry)
+
+	toolDef := &types.ToolDefinition{
+		McpTool: &mcp.Tool{
+			Name:        "create_population",
+			Annotations: &mcp.ToolAnnotations{},
+		},
+	}
+
+	mockReg.On("GetTool", "create_population").Return(toolDef)
+	mockVal.On("ValidateEnvironment", mock.Anything, envId, OperationTypeWrite).Return(errors.New("environment not found"))
+
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
+
+	argsJSON, err := json.Marshal(map[string]any{"environmentId": envId.String()})
+	require.NoError(t, err)
+
+	err = middleware.ValidateToolCall(context.Background(), "create_population", argsJSON)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "environment validation failed")
+	assert.Contains(t, err.Error(), "environment not found")
+	mockVal.AssertExpectations(t)
+	mockReg.AssertExpectations(t)
+}
+
 func TestEnvironmentValidationMiddleware_ProductionProtection(t *testing.T) {
 	envId := uuid.New()
 	mockVal := new(mockValidatorMiddleware)
@@ -665,7 +756,7 @@ func TestEnvironmentValidationMiddleware_ProductionProtection(t *testing.T) {
 	mockReg.On("GetTool", "delete_population").Return(toolDef)
 	mockVal.On("ValidateEnvironment", mock.Anything, envId, OperationTypeWrite).Return(productionErr)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -699,7 +790,7 @@ func TestEnvironmentValidationMiddleware_UnknownTool(t *testing.T) {
 	// Tool not found in registry - when nil, validation is skipped
 	mockReg.On("GetTool", "unknown_tool").Return((*types.ToolDefinition)(nil))
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -737,7 +828,7 @@ func TestEnvironmentValidationMiddleware_InvalidJSON(t *testing.T) {
 	}
 	mockReg.On("GetTool", "test_tool").Return(toolDef)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	nextCalled := false
 	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
@@ -769,9 +860,249 @@ func TestNewEnvironmentValidationMiddleware(t *testing.T) {
 	mockVal := new(mockValidatorMiddleware)
 	mockReg := new(mockToolRegistry)
 
-	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg)
+	middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{})
 
 	assert.NotNil(t, middleware)
 	assert.Equal(t, mockVal, middleware.validator)
 	assert.Equal(t, mockReg, middleware.toolRegistry)
+	assert.Equal(t, ProductionGuardrailStrict, middleware.productionGuardrail)
+}
+
+func TestEnvironmentValidationMiddleware_EnvironmentScope(t *testing.T) {
+	allowedEnvId := uuid.New()
+	deniedEnvId := uuid.New()
+	otherEnvId := uuid.New()
+
+	scope := EnvironmentScope{
+		AllowedEnvironmentIds: []uuid.UUID{allowedEnvId, deniedEnvId},
+		DeniedEnvironmentIds:  []uuid.UUID{deniedEnvId},
+	}
+
+	// Tool policy allows PRODUCTION reads, so environment validation would otherwise be skipped
+	toolDef := &types.ToolDefinition{
+		ValidationPolicy: &types.ToolValidationPolicy{
+			AllowProductionEnvironmentRead: true,
+			ReadEnvironmentIdArguments:     []string{"compareToEnvironmentId"},
+		},
+		McpTool: &mcp.Tool{
+			Name: "list_populations",
+			Annotations: &mcp.ToolAnnotations{
+				ReadOnlyHint: true,
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		expectAllowed bool
+	}{
+		{name: "allowed environment", args: map[string]any{"environmentId": allowedEnvId.String()}, expectAllowed: true},
+		{name: "denied environment", args: map[string]any{"environmentId": deniedEnvId.String()}},
+		{name: "environment not in allowlist", args: map[string]any{"environmentId": otherEnvId.String()}},
+		{name: "tool without environment", args: map[string]any{}, expectAllowed: true},
+		{name: "allowed read environment", args: map[string]any{"environmentId": allowedEnvId.String(), "compareToEnvironmentId": allowedEnvId.String()}, expectAllowed: true},
+		{name: "denied read environment", args: map[string]any{"environmentId": allowedEnvId.String(), "compareToEnvironmentId": deniedEnvId.String()}},
+		{name: "read environment not in allowlist", args: map[string]any{"environmentId": allowedEnvId.String(), "compareToEnvironmentId": otherEnvId.String()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVal := new(mockValidatorMiddleware)
+			mockReg := new(mockToolRegistry)
+			mockReg.On("GetTool", "list_populations").Return(toolDef)
+
+			middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, scope)
+
+			nextCalled := false
+			next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				nextCalled = true
+				return nil, nil
+			}
+
+			handler := middleware.Handler(next)
+			_, err := handler(context.Background(), "tools/call", createCallToolRequest("list_populations", tt.args))
+
+			if tt.expectAllowed {
+				assert.NoError(t, err)
+				assert.True(t, nextCalled)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "this server is not permitted to act on environment")
+				assert.False(t, nextCalled)
+			}
+			mockVal.AssertNotCalled(t, "ValidateEnvironment", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestEnvironmentValidationMiddleware_EnvironmentScope_CreatesEnvironment(t *testing.T) {
+	toolDef := &types.ToolDefinition{
+		ValidationPolicy: &types.ToolValidationPolicy{
+			ProductionEnvironmentNotApplicable: true,
+			CreatesEnvironment:                 true,
+		},
+		McpTool: &mcp.Tool{Name: "create_environment"},
+	}
+
+	tests := []struct {
+		name          string
+		scope         EnvironmentScope
+		expectAllowed bool
+	}{
+		{name: "no scope", scope: EnvironmentScope{}, expectAllowed: true},
+		{name: "allowed environments", scope: EnvironmentScope{AllowedEnvironmentIds: []uuid.UUID{uuid.New()}}},
+		{name: "denied environments", scope: EnvironmentScope{DeniedEnvironmentIds: []uuid.UUID{uuid.New()}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVal := new(mockValidatorMiddleware)
+			mockReg := new(mockToolRegistry)
+			mockReg.On("GetTool", "create_environment").Return(toolDef)
+
+			middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, tt.scope)
+
+			nextCalled := false
+			next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+				nextCalled = true
+				return nil, nil
+			}
+
+			handler := middleware.Handler(next)
+			_, err := handler(context.Background(), "tools/call", createCallToolRequest("create_environment", map[string]any{"name": "Test"}))
+
+			if tt.expectAllowed {
+				assert.NoError(t, err)
+				assert.True(t, nextCalled)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "not permitted to create environments")
+				assert.False(t, nextCalled)
+			}
+			mockVal.AssertNotCalled(t, "ValidateEnvironment", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestEnvironmentValidationMiddleware_EnvironmentScope_EnvironmentFilter(t *testing.T) {
+	allowedEnvId := uuid.New()
+	deniedEnvId := uuid.New()
+	otherEnvId := uuid.New()
+
+	toolDef := &types.ToolDefinition{
+		ValidationPolicy: &types.ToolValidationPolicy{
+			ProductionEnvironmentNotApplicable: true,
+		},
+		McpTool: &mcp.Tool{
+			Name: "list_environments",
+			Annotations: &mcp.ToolAnnotations{
+				ReadOnlyHint: true,
+			},
+		},
+	}
+
+	t.Run("scope filters environments", func(t *testing.T) {
+		mockReg := new(mockToolRegistry)
+		mockReg.On("GetTool", "list_environments").Return(toolDef)
+		middleware := NewEnvironmentValidationMiddleware(new(mockValidatorMiddleware), mockReg, ProductionGuardrailStrict, EnvironmentScope{
+			AllowedEnvironmentIds: []uuid.UUID{allowedEnvId, deniedEnvId},
+			DeniedEnvironmentIds:  []uuid.UUID{deniedEnvId},
+		})
+
+		var allows func(uuid.UUID) bool
+		next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			allows = environments.EnvironmentFilterFromContext(ctx)
+			return nil, nil
+		}
+
+		_, err := middleware.Handler(next)(context.Background(), "tools/call", createCallToolRequest("list_environments", map[string]any{}))
+
+		require.NoError(t, err)
+		require.NotNil(t, allows)
+		assert.True(t, allows(allowedEnvId))
+		assert.False(t, allows(deniedEnvId))
+		assert.False(t, allows(otherEnvId))
+	})
+
+	t.Run("no scope does not filter environments", func(t *testing.T) {
+		mockReg := new(mockToolRegistry)
+		mockReg.On("GetTool", "list_environments").Return(toolDef)
+		middleware := NewEnvironmentValidationMiddleware(new(mockValidatorMiddleware), mockReg, ProductionGuardrailStrict, EnvironmentScope{})
+
+		filtered := true
+		next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			filtered = environments.EnvironmentFilterFromContext(ctx) != nil
+			return nil, nil
+		}
+
+		_, err := middleware.Handler(next)(context.Background(), "tools/call", createCallToolRequest("list_environments", map[string]any{}))
+
+		require.NoError(t, err)
+		assert.False(t, filtered)
+	})
+}
+
+func TestEnvironmentValidationMiddleware_EnvironmentReadValidator(t *testing.T) {
+	sandboxEnvId := uuid.New()
+	productionEnvId := uuid.New()
+	outOfScopeEnvId := uuid.New()
+
+	toolDef := &types.ToolDefinition{
+		ValidationPolicy: &types.ToolValidationPolicy{
+			ProductionEnvironmentNotApplicable: true,
+		},
+		McpTool: &mcp.Tool{
+			Name: "get_license_utilization",
+			Annotations: &mcp.ToolAnnotations{
+				ReadOnlyHint: true,
+			},
+		},
+	}
+
+	readValidator := func(t *testing.T, middleware *EnvironmentValidationMiddleware) func(context.Context, uuid.UUID) error {
+		t.Helper()
+		var validate func(context.Context, uuid.UUID) error
+		next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			validate = environments.EnvironmentReadValidatorFromContext(ctx)
+			return nil, nil
+		}
+		_, err := middleware.Handler(next)(context.Background(), "tools/call", createCallToolRequest("get_license_utilization", map[string]any{}))
+		require.NoError(t, err)
+		require.NotNil(t, validate)
+		return validate
+	}
+
+	t.Run("strict guardrail validates each environment read", func(t *testing.T) {
+		mockVal := new(mockValidatorMiddleware)
+		mockVal.On("ValidateEnvironment", mock.Anything, sandboxEnvId, OperationTypeRead).Return(nil)
+		mockVal.On("ValidateEnvironment", mock.Anything, productionEnvId, OperationTypeRead).Return(errors.New("this read operation is not allowed against PRODUCTION environments"))
+		mockReg := new(mockToolRegistry)
+		mockReg.On("GetTool", "get_license_utilization").Return(toolDef)
+		middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailStrict, EnvironmentScope{
+			DeniedEnvironmentIds: []uuid.UUID{outOfScopeEnvId},
+		})
+
+		validate := readValidator(t, middleware)
+
+		assert.NoError(t, validate(context.Background(), sandboxEnvId))
+		assert.ErrorContains(t, validate(context.Background(), productionEnvId), "not allowed against PRODUCTION environments")
+		assert.ErrorContains(t, validate(context.Background(), outOfScopeEnvId), "not permitted to act on environment")
+		mockVal.AssertNotCalled(t, "ValidateEnvironment", mock.Anything, outOfScopeEnvId, mock.Anything)
+	})
+
+	t.Run("read-only guardrail allows every environment read within the scope", func(t *testing.T) {
+		mockVal := new(mockValidatorMiddleware)
+		mockReg := new(mockToolRegistry)
+		mockReg.On("GetTool", "get_license_utilization").Return(toolDef)
+		middleware := NewEnvironmentValidationMiddleware(mockVal, mockReg, ProductionGuardrailReadOnly, EnvironmentScope{
+			DeniedEnvironmentIds: []uuid.UUID{outOfScopeEnvId},
+		})
+
+		validate := readValidator(t, middleware)
+
+		assert.NoError(t, validate(context.Background(), productionEnvId))
+		assert.Error(t, validate(context.Background(), outOfScopeEnvId))
+		mockVal.AssertNotCalled(t, "ValidateEnvironment", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
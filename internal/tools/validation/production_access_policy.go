@@ -0,0 +1,64 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ProductionAccessPolicy holds operator overrides of the restrictions the environment validator applies
+// to PRODUCTION environments, so that deployments with the appropriate approvals can opt in to
+// PRODUCTION access. The zero value applies no overrides.
+type ProductionAccessPolicy struct {
+	// AllowRead allows read operations against all PRODUCTION environments
+	AllowRead bool
+	// AllowWrite allows write operations against all PRODUCTION environments
+	AllowWrite bool
+	// AllowedEnvironmentIds lists PRODUCTION environments that allow both read and write operations
+	AllowedEnvironmentIds []uuid.UUID
+}
+
+// ParseEnvironmentIds parses environment IDs for the ProductionAccessPolicy and EnvironmentScope lists.
+// Duplicate IDs are ignored.
+func ParseEnvironmentIds(values []string) ([]uuid.UUID, error) {
+	environmentIds := []uuid.UUID{}
+	for _, value := range values {
+		environmentId, err := uuid.Parse(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid environment ID %q: %w", value, err)
+		}
+		if !slices.Contains(environmentIds, environmentId) {
+			environmentIds = append(environmentIds, environmentId)
+		}
+	}
+	return environmentIds, nil
+}
+
+// IsEmpty returns true if the policy does not override any PRODUCTION environment restrictions.
+func (p ProductionAccessPolicy) IsEmpty() bool {
+	return !p.AllowRead && !p.AllowWrite && len(p.AllowedEnvironmentIds) == 0
+}
+
+// AllowsWrite returns true if the policy allows write operations against any PRODUCTION environment.
+func (p ProductionAccessPolicy) AllowsWrite() bool {
+	return p.AllowWrite || len(p.AllowedEnvironmentIds) > 0
+}
+
+// Allows returns whether the policy allows the operation against the PRODUCTION environment,
+// and if so, the reason the restriction is overridden.
+func (p ProductionAccessPolicy) Allows(environmentId uuid.UUID, operationType OperationType) (bool, string) {
+	if slices.Contains(p.AllowedEnvironmentIds, environmentId) {
+		return true, "environment is in the allowed production environments list"
+	}
+	if operationType == OperationTypeRead && p.AllowRead {
+		return true, "production reads are allowed"
+	}
+	if operationType == OperationTypeWrite && p.AllowWrite {
+		return true, "production writes are allowed"
+	}
+	return false, ""
+}
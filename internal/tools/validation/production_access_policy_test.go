@@ -0,0 +1,43 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnvironmentIds(t *testing.T) {
+	envId := uuid.New()
+
+	environmentIds, err := validation.ParseEnvironmentIds([]string{envId.String(), " " + envId.String() + " "})
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{envId}, environmentIds)
+
+	_, err = validation.ParseEnvironmentIds([]string{"not-a-uuid"})
+	assert.Error(t, err)
+}
+
+func TestProductionAccessPolicy(t *testing.T) {
+	envId := uuid.New()
+
+	assert.True(t, validation.ProductionAccessPolicy{}.IsEmpty())
+	assert.False(t, validation.ProductionAccessPolicy{}.AllowsWrite())
+	assert.False(t, validation.ProductionAccessPolicy{AllowRead: true}.AllowsWrite())
+	assert.True(t, validation.ProductionAccessPolicy{AllowWrite: true}.AllowsWrite())
+	assert.True(t, validation.ProductionAccessPolicy{AllowedEnvironmentIds: []uuid.UUID{envId}}.AllowsWrite())
+
+	allowed, _ := validation.ProductionAccessPolicy{}.Allows(envId, validation.OperationTypeRead)
+	assert.False(t, allowed)
+
+	allowed, reason := validation.ProductionAccessPolicy{AllowedEnvironmentIds: []uuid.UUID{envId}}.Allows(envId, validation.OperationTypeWrite)
+	assert.True(t, allowed)
+	assert.NotEmpty(t, reason)
+
+	allowed, _ = validation.ProductionAccessPolicy{AllowedEnvironmentIds: []uuid.UUID{uuid.New()}}.Allows(envId, validation.OperationTypeRead)
+	assert.False(t, allowed)
+}
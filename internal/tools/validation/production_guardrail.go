@@ -0,0 +1,40 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package validation
+
+import "fmt"
+
+// ProductionGuardrail controls how tool calls against PRODUCTION environments are restricted.
+type ProductionGuardrail int
+
+const (
+	_ ProductionGuardrail = iota
+	// ProductionGuardrailStrict blocks reads and writes against PRODUCTION environments unless
+	// the tool explicitly opts in via its validation policy. This is the default.
+	ProductionGuardrailStrict
+	// ProductionGuardrailReadOnly allows all read-only tools against PRODUCTION environments,
+	// while write tools are always blocked regardless of their validation policy.
+	ProductionGuardrailReadOnly
+)
+
+func (g ProductionGuardrail) String() string {
+	switch g {
+	case ProductionGuardrailStrict:
+		return "strict"
+	case ProductionGuardrailReadOnly:
+		return "read-only"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseProductionGuardrail(s string) (ProductionGuardrail, error) {
+	switch s {
+	case "strict":
+		return ProductionGuardrailStrict, nil
+	case "read-only":
+		return ProductionGuardrailReadOnly, nil
+	default:
+		return 0, fmt.Errorf("unable to parse production guardrail from string: %s", s)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright © 2025 Ping Identity Corporation
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/pingidentity/pingone-mcp-server/internal/tools/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProductionGuardrail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected validation.ProductionGuardrail
+		wantErr  bool
+	}{
+		{input: "strict", expected: validation.ProductionGuardrailStrict},
+		{input: "read-only", expected: validation.ProductionGuardrailReadOnly},
+		{input: "off", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			guardrail, err := validation.ParseProductionGuardrail(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, guardrail)
+			assert.Equal(t, tt.input, guardrail.String())
+		})
+	}
+}